@@ -16,6 +16,8 @@ limitations under the License.
 package factory
 
 import (
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/hyperledger/fabric/bccsp"
@@ -91,3 +93,118 @@ func initBCCSP(f BCCSPFactory, config *FactoryOpts) error {
 	bccspMap[f.Name()] = csp
 	return nil
 }
+
+// electDefaultBCCSP selects the default BCCSP among the providers that were
+// initialized, and applies the key residency policy of config.
+// initErrors holds the reason each of the other configured providers failed.
+//
+// When config.Providers is empty, the provider named by config.ProviderName
+// is used and any initialization failure is reported. Otherwise the first
+// available provider of the list is used, and failures of the listed
+// providers are logged rather than reported.
+func electDefaultBCCSP(config *FactoryOpts, initErrors map[string]error) error {
+	providers := config.Providers
+	fallback := len(providers) > 0
+	if !fallback {
+		providers = []string{config.ProviderName}
+	}
+
+	var elected string
+	for _, name := range providers {
+		if _, ok := bccspMap[name]; ok {
+			elected = name
+			break
+		}
+		if fallback {
+			logger.Warningf("BCCSP provider %s is not available, trying the next one: %s", name, unavailableReason(name, initErrors))
+		}
+	}
+
+	var failures []string
+	for _, name := range sortedNames(initErrors) {
+		if fallback && elected != "" && containsName(providers, name) {
+			continue
+		}
+		failures = append(failures, errors.WithMessage(initErrors[name], "Failed initializing "+name+".BCCSP").Error())
+	}
+
+	if elected == "" {
+		if fallback {
+			failures = append(failures, "none of the BCCSP providers ["+strings.Join(providers, ", ")+"] is available")
+		} else {
+			failures = append(failures, "Could not find default `"+config.ProviderName+"` BCCSP")
+		}
+		return errors.New(strings.Join(failures, "\n"))
+	}
+
+	csp, err := applyKeyResidency(elected, config.KeyResidency, initErrors)
+	if err != nil {
+		failures = append(failures, err.Error())
+	} else {
+		defaultBCCSP = csp
+	}
+
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// applyKeyResidency returns the default BCCSP that serves the key types listed
+// in residency with the provider assigned to them, and every other operation
+// with the elected provider. Unlike the provider list, a residency policy
+// never falls back: an assigned provider that is not available is an error.
+func applyKeyResidency(elected string, residency map[string]string, initErrors map[string]error) (bccsp.BCCSP, error) {
+	if len(residency) == 0 {
+		logger.Infof("BCCSP provider %s serves all operations", elected)
+		return bccspMap[elected], nil
+	}
+
+	var keyTypes []string
+	for keyType := range residency {
+		keyTypes = append(keyTypes, keyType)
+	}
+	sort.Strings(keyTypes)
+
+	byKeyType := map[string]bccsp.BCCSP{}
+	for _, keyType := range keyTypes {
+		name := residency[keyType]
+		if !containsName(residentKeyTypes, strings.ToUpper(keyType)) {
+			return nil, errors.Errorf("invalid key residency policy: unknown key type %s, expected one of [%s]", keyType, strings.Join(residentKeyTypes, ", "))
+		}
+		csp, ok := bccspMap[name]
+		if !ok {
+			return nil, errors.Errorf("key residency policy requires %s keys to be served by BCCSP provider %s, but it is not available: %s", keyType, name, unavailableReason(name, initErrors))
+		}
+		logger.Infof("BCCSP provider %s serves %s keys", name, strings.ToUpper(keyType))
+		byKeyType[strings.ToUpper(keyType)] = csp
+	}
+	logger.Infof("BCCSP provider %s serves hashing and all other keys", elected)
+
+	return &routingBCCSP{defaultCSP: bccspMap[elected], byKeyType: byKeyType}, nil
+}
+
+func unavailableReason(name string, initErrors map[string]error) string {
+	if err, ok := initErrors[name]; ok {
+		return err.Error()
+	}
+	return "it is not configured or not supported by this build"
+}
+
+func sortedNames(initErrors map[string]error) []string {
+	var names []string
+	for name := range initErrors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -27,6 +27,12 @@ type FactoryOpts struct {
 	ProviderName string      `mapstructure:"default" json:"default" yaml:"Default"`
 	SwOpts       *SwOpts     `mapstructure:"SW,omitempty" json:"SW,omitempty" yaml:"SwOpts"`
 	PluginOpts   *PluginOpts `mapstructure:"PLUGIN,omitempty" json:"PLUGIN,omitempty" yaml:"PluginOpts"`
+	// Providers, when set, lists the providers to try in order; the first one
+	// available becomes the default one and ProviderName is ignored.
+	Providers []string `mapstructure:"providers,omitempty" json:"providers,omitempty" yaml:"Providers"`
+	// KeyResidency assigns key types (ECDSA, RSA, AES, HMAC) to the provider
+	// that must serve them.
+	KeyResidency map[string]string `mapstructure:"keyresidency,omitempty" json:"keyresidency,omitempty" yaml:"KeyResidency"`
 }
 
 // InitFactories must be called before using factory interfaces
@@ -50,30 +56,25 @@ func InitFactories(config *FactoryOpts) error {
 
 		// Initialize factories map
 		bccspMap = make(map[string]bccsp.BCCSP)
+		initErrors := map[string]error{}
 
 		// Software-Based BCCSP
 		if config.SwOpts != nil {
 			f := &SWFactory{}
-			err := initBCCSP(f, config)
-			if err != nil {
-				factoriesInitError = errors.Wrapf(err, "Failed initializing BCCSP.")
+			if err := initBCCSP(f, config); err != nil {
+				initErrors[f.Name()] = err
 			}
 		}
 
 		// BCCSP Plugin
 		if config.PluginOpts != nil {
 			f := &PluginFactory{}
-			err := initBCCSP(f, config)
-			if err != nil {
-				factoriesInitError = errors.Wrapf(err, "Failed initializing PKCS11.BCCSP %s", factoriesInitError)
+			if err := initBCCSP(f, config); err != nil {
+				initErrors[f.Name()] = err
 			}
 		}
 
-		var ok bool
-		defaultBCCSP, ok = bccspMap[config.ProviderName]
-		if !ok {
-			factoriesInitError = errors.Errorf("%s\nCould not find default `%s` BCCSP", factoriesInitError, config.ProviderName)
-		}
+		factoriesInitError = electDefaultBCCSP(config, initErrors)
 	})
 
 	return factoriesInitError
@@ -29,6 +29,12 @@ type FactoryOpts struct {
 	SwOpts       *SwOpts            `mapstructure:"SW,omitempty" json:"SW,omitempty" yaml:"SwOpts"`
 	PluginOpts   *PluginOpts        `mapstructure:"PLUGIN,omitempty" json:"PLUGIN,omitempty" yaml:"PluginOpts"`
 	Pkcs11Opts   *pkcs11.PKCS11Opts `mapstructure:"PKCS11,omitempty" json:"PKCS11,omitempty" yaml:"PKCS11"`
+	// Providers, when set, lists the providers to try in order; the first one
+	// available becomes the default one and ProviderName is ignored.
+	Providers []string `mapstructure:"providers,omitempty" json:"providers,omitempty" yaml:"Providers"`
+	// KeyResidency assigns key types (ECDSA, RSA, AES, HMAC) to the provider
+	// that must serve them.
+	KeyResidency map[string]string `mapstructure:"keyresidency,omitempty" json:"keyresidency,omitempty" yaml:"KeyResidency"`
 }
 
 // InitFactories must be called before using factory interfaces
@@ -59,39 +65,33 @@ func setFactories(config *FactoryOpts) error {
 
 	// Initialize factories map
 	bccspMap = make(map[string]bccsp.BCCSP)
+	initErrors := map[string]error{}
 
 	// Software-Based BCCSP
 	if config.SwOpts != nil {
 		f := &SWFactory{}
-		err := initBCCSP(f, config)
-		if err != nil {
-			factoriesInitError = errors.Wrap(err, "Failed initializing SW.BCCSP")
+		if err := initBCCSP(f, config); err != nil {
+			initErrors[f.Name()] = err
 		}
 	}
 
 	// PKCS11-Based BCCSP
 	if config.Pkcs11Opts != nil {
 		f := &PKCS11Factory{}
-		err := initBCCSP(f, config)
-		if err != nil {
-			factoriesInitError = errors.Wrapf(err, "Failed initializing PKCS11.BCCSP %s", factoriesInitError)
+		if err := initBCCSP(f, config); err != nil {
+			initErrors[f.Name()] = err
 		}
 	}
 
 	// BCCSP Plugin
 	if config.PluginOpts != nil {
 		f := &PluginFactory{}
-		err := initBCCSP(f, config)
-		if err != nil {
-			factoriesInitError = errors.Wrapf(err, "Failed initializing PKCS11.BCCSP %s", factoriesInitError)
+		if err := initBCCSP(f, config); err != nil {
+			initErrors[f.Name()] = err
 		}
 	}
 
-	var ok bool
-	defaultBCCSP, ok = bccspMap[config.ProviderName]
-	if !ok {
-		factoriesInitError = errors.Errorf("%s\nCould not find default `%s` BCCSP", factoriesInitError, config.ProviderName)
-	}
+	factoriesInitError = electDefaultBCCSP(config, initErrors)
 
 	return factoriesInitError
 }
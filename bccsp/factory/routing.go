@@ -0,0 +1,147 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"hash"
+	"strings"
+
+	"github.com/hyperledger/fabric/bccsp"
+)
+
+// residentKeyTypes are the key types that a key residency policy may assign
+// to a provider.
+var residentKeyTypes = []string{"ECDSA", "RSA", "AES", "HMAC"}
+
+// routingBCCSP dispatches the operations on a key to the provider its key
+// type resides on, and the remaining operations to a default provider.
+type routingBCCSP struct {
+	defaultCSP bccsp.BCCSP
+	byKeyType  map[string]bccsp.BCCSP
+}
+
+// routedKey is a key along with the provider that owns it.
+type routedKey struct {
+	bccsp.Key
+	csp bccsp.BCCSP
+}
+
+// PublicKey returns the public part of the key, owned by the same provider.
+func (k *routedKey) PublicKey() (bccsp.Key, error) {
+	pk, err := k.Key.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return &routedKey{Key: pk, csp: k.csp}, nil
+}
+
+// providerFor returns the provider that serves keys created with algorithm.
+func (r *routingBCCSP) providerFor(algorithm string) bccsp.BCCSP {
+	algorithm = strings.ToUpper(algorithm)
+	for keyType, csp := range r.byKeyType {
+		if strings.HasPrefix(algorithm, keyType) {
+			return csp
+		}
+	}
+	return r.defaultCSP
+}
+
+// unwrap returns the key as known to its provider, and that provider.
+// Keys that were not produced by r are handed to the default provider.
+func (r *routingBCCSP) unwrap(k bccsp.Key) (bccsp.Key, bccsp.BCCSP) {
+	if rk, ok := k.(*routedKey); ok {
+		return rk.Key, rk.csp
+	}
+	return k, r.defaultCSP
+}
+
+func wrap(k bccsp.Key, csp bccsp.BCCSP, err error) (bccsp.Key, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &routedKey{Key: k, csp: csp}, nil
+}
+
+func (r *routingBCCSP) KeyGen(opts bccsp.KeyGenOpts) (bccsp.Key, error) {
+	if opts == nil {
+		return r.defaultCSP.KeyGen(opts)
+	}
+	csp := r.providerFor(opts.Algorithm())
+	k, err := csp.KeyGen(opts)
+	return wrap(k, csp, err)
+}
+
+func (r *routingBCCSP) KeyDeriv(k bccsp.Key, opts bccsp.KeyDerivOpts) (bccsp.Key, error) {
+	key, csp := r.unwrap(k)
+	dk, err := csp.KeyDeriv(key, opts)
+	return wrap(dk, csp, err)
+}
+
+func (r *routingBCCSP) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (bccsp.Key, error) {
+	if opts == nil {
+		return r.defaultCSP.KeyImport(raw, opts)
+	}
+	csp := r.providerFor(opts.Algorithm())
+	k, err := csp.KeyImport(raw, opts)
+	return wrap(k, csp, err)
+}
+
+// GetKey looks the key up in the providers assigned to a key type first,
+// and then in the default provider.
+func (r *routingBCCSP) GetKey(ski []byte) (bccsp.Key, error) {
+	var lastErr error
+	for _, csp := range r.providers() {
+		k, err := csp.GetKey(ski)
+		if err == nil {
+			return &routedKey{Key: k, csp: csp}, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// providers returns the distinct providers of r, the default one last.
+func (r *routingBCCSP) providers() []bccsp.BCCSP {
+	var csps []bccsp.BCCSP
+	seen := map[bccsp.BCCSP]bool{r.defaultCSP: true}
+	for _, keyType := range residentKeyTypes {
+		csp, ok := r.byKeyType[keyType]
+		if ok && !seen[csp] {
+			seen[csp] = true
+			csps = append(csps, csp)
+		}
+	}
+	return append(csps, r.defaultCSP)
+}
+
+func (r *routingBCCSP) Hash(msg []byte, opts bccsp.HashOpts) ([]byte, error) {
+	return r.defaultCSP.Hash(msg, opts)
+}
+
+func (r *routingBCCSP) GetHash(opts bccsp.HashOpts) (hash.Hash, error) {
+	return r.defaultCSP.GetHash(opts)
+}
+
+func (r *routingBCCSP) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	key, csp := r.unwrap(k)
+	return csp.Sign(key, digest, opts)
+}
+
+func (r *routingBCCSP) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
+	key, csp := r.unwrap(k)
+	return csp.Verify(key, signature, digest, opts)
+}
+
+func (r *routingBCCSP) Encrypt(k bccsp.Key, plaintext []byte, opts bccsp.EncrypterOpts) ([]byte, error) {
+	key, csp := r.unwrap(k)
+	return csp.Encrypt(key, plaintext, opts)
+}
+
+func (r *routingBCCSP) Decrypt(k bccsp.Key, ciphertext []byte, opts bccsp.DecrypterOpts) ([]byte, error) {
+	key, csp := r.unwrap(k)
+	return csp.Decrypt(key, ciphertext, opts)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElectDefaultBCCSP(t *testing.T) {
+	defer func(csps map[string]bccsp.BCCSP, csp bccsp.BCCSP) {
+		bccspMap, defaultBCCSP = csps, csp
+	}(bccspMap, defaultBCCSP)
+
+	swCSP, err := (&SWFactory{}).Get(GetDefaultOpts())
+	require.NoError(t, err)
+	bccspMap = map[string]bccsp.BCCSP{"SW": swCSP}
+	initErrors := map[string]error{"PKCS11": errors.New("no library")}
+
+	err = electDefaultBCCSP(&FactoryOpts{ProviderName: "PKCS11"}, initErrors)
+	assert.EqualError(t, err, "Failed initializing PKCS11.BCCSP: no library\nCould not find default `PKCS11` BCCSP")
+
+	err = electDefaultBCCSP(&FactoryOpts{ProviderName: "SW"}, initErrors)
+	assert.EqualError(t, err, "Failed initializing PKCS11.BCCSP: no library")
+
+	defaultBCCSP = nil
+	err = electDefaultBCCSP(&FactoryOpts{Providers: []string{"PKCS11", "SW"}}, initErrors)
+	assert.NoError(t, err)
+	assert.Equal(t, swCSP, defaultBCCSP)
+
+	err = electDefaultBCCSP(&FactoryOpts{Providers: []string{"PKCS11", "PLUGIN"}}, initErrors)
+	assert.EqualError(t, err, "Failed initializing PKCS11.BCCSP: no library\nnone of the BCCSP providers [PKCS11, PLUGIN] is available")
+
+	err = electDefaultBCCSP(&FactoryOpts{
+		Providers:    []string{"PKCS11", "SW"},
+		KeyResidency: map[string]string{"ECDSA": "PKCS11"},
+	}, initErrors)
+	assert.EqualError(t, err, "key residency policy requires ECDSA keys to be served by BCCSP provider PKCS11, but it is not available: no library")
+
+	err = electDefaultBCCSP(&FactoryOpts{
+		ProviderName: "SW",
+		KeyResidency: map[string]string{"DSA": "SW"},
+	}, nil)
+	assert.EqualError(t, err, "invalid key residency policy: unknown key type DSA, expected one of [ECDSA, RSA, AES, HMAC]")
+
+	err = electDefaultBCCSP(&FactoryOpts{
+		ProviderName: "SW",
+		KeyResidency: map[string]string{"ecdsa": "SW"},
+	}, nil)
+	assert.NoError(t, err)
+	assert.IsType(t, &routingBCCSP{}, defaultBCCSP)
+}
+
+func TestRoutingBCCSP(t *testing.T) {
+	ksDir, err := ioutil.TempDir("", "routing")
+	require.NoError(t, err)
+	defer os.RemoveAll(ksDir)
+
+	ks, err := sw.NewFileBasedKeyStore(nil, ksDir, false)
+	require.NoError(t, err)
+	ecdsaCSP, err := sw.NewWithParams(256, "SHA2", ks)
+	require.NoError(t, err)
+	defaultCSP, err := sw.NewWithParams(256, "SHA2", sw.NewDummyKeyStore())
+	require.NoError(t, err)
+
+	r := &routingBCCSP{
+		defaultCSP: defaultCSP,
+		byKeyType:  map[string]bccsp.BCCSP{"ECDSA": ecdsaCSP},
+	}
+
+	k, err := r.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	require.NoError(t, err)
+	assert.Equal(t, ecdsaCSP, k.(*routedKey).csp)
+
+	digest := sha256.Sum256([]byte("hello"))
+	signature, err := r.Sign(k, digest[:], nil)
+	require.NoError(t, err)
+	pk, err := k.PublicKey()
+	require.NoError(t, err)
+	assert.Equal(t, ecdsaCSP, pk.(*routedKey).csp)
+	valid, err := r.Verify(pk, signature, digest[:], nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	found, err := r.GetKey(k.SKI())
+	require.NoError(t, err)
+	assert.Equal(t, k.SKI(), found.SKI())
+	assert.Equal(t, ecdsaCSP, found.(*routedKey).csp)
+
+	_, err = r.GetKey([]byte("missing"))
+	assert.Error(t, err)
+
+	aesKey, err := r.KeyGen(&bccsp.AES256KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	assert.Equal(t, defaultCSP, aesKey.(*routedKey).csp)
+	ciphertext, err := r.Encrypt(aesKey, []byte("secret"), &bccsp.AESCBCPKCS7ModeOpts{})
+	require.NoError(t, err)
+	plaintext, err := r.Decrypt(aesKey, ciphertext, &bccsp.AESCBCPKCS7ModeOpts{})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("secret"), plaintext)
+
+	// keys that were not created through the router go to the default provider
+	plainKey, err := defaultCSP.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	_, err = r.Sign(plainKey, digest[:], nil)
+	assert.NoError(t, err)
+
+	hash, err := r.Hash([]byte("hello"), &bccsp.SHA256Opts{})
+	assert.NoError(t, err)
+	assert.Equal(t, digest[:], hash)
+}
@@ -57,6 +57,9 @@ func (f *SWFactory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
 		ks = sw.NewDummyKeyStore()
 	}
 
+	if swOpts.Deterministic {
+		return sw.NewDeterministicWithParams(swOpts.SecLevel, swOpts.HashFamily, ks)
+	}
 	return sw.NewWithParams(swOpts.SecLevel, swOpts.HashFamily, ks)
 }
 
@@ -70,6 +73,10 @@ type SwOpts struct {
 	Ephemeral     bool               `mapstructure:"tempkeys,omitempty" json:"tempkeys,omitempty"`
 	FileKeystore  *FileKeystoreOpts  `mapstructure:"filekeystore,omitempty" json:"filekeystore,omitempty" yaml:"FileKeyStore"`
 	DummyKeystore *DummyKeystoreOpts `mapstructure:"dummykeystore,omitempty" json:"dummykeystore,omitempty"`
+
+	// Deterministic makes ECDSA signatures deterministic (RFC 6979). Since it
+	// changes the signature bytes, it is disabled unless explicitly enabled.
+	Deterministic bool `mapstructure:"deterministic,omitempty" json:"deterministic,omitempty" yaml:"Deterministic"`
 }
 
 // Pluggable Keystores, could add JKS, P12, etc..
@@ -19,6 +19,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/hyperledger/fabric/bccsp"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -67,4 +68,15 @@ func TestSWFactoryGet(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, csp)
 
+	opts.SwOpts.Deterministic = true
+	csp, err = f.Get(opts)
+	assert.NoError(t, err)
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	digest := make([]byte, 32)
+	sigma1, err := csp.Sign(k, digest, nil)
+	assert.NoError(t, err)
+	sigma2, err := csp.Sign(k, digest, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, sigma1, sigma2)
 }
@@ -38,6 +38,20 @@ func signECDSA(k *ecdsa.PrivateKey, digest []byte, opts bccsp.SignerOpts) ([]byt
 	return utils.MarshalECDSASignature(r, s)
 }
 
+func signECDSADeterministic(k *ecdsa.PrivateKey, digest []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	r, s, err := signDeterministic(k, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	s, _, err = utils.ToLowS(&k.PublicKey, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.MarshalECDSASignature(r, s)
+}
+
 func verifyECDSA(k *ecdsa.PublicKey, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
 	r, s, err := utils.UnmarshalECDSASignature(signature)
 	if err != nil {
@@ -56,9 +70,15 @@ func verifyECDSA(k *ecdsa.PublicKey, signature, digest []byte, opts bccsp.Signer
 	return ecdsa.Verify(k, digest, r, s), nil
 }
 
-type ecdsaSigner struct{}
+type ecdsaSigner struct {
+	// deterministic selects RFC 6979 nonces instead of random ones
+	deterministic bool
+}
 
 func (s *ecdsaSigner) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	if s.deterministic {
+		return signECDSADeterministic(k.(*ecdsaPrivateKey).privKey, digest, opts)
+	}
 	return signECDSA(k.(*ecdsaPrivateKey).privKey, digest, opts)
 }
 
@@ -36,6 +36,17 @@ func NewDefaultSecurityLevelWithKeystore(keyStore bccsp.KeyStore) (bccsp.BCCSP,
 // NewWithParams returns a new instance of the software-based BCCSP
 // set at the passed security level, hash family and KeyStore.
 func NewWithParams(securityLevel int, hashFamily string, keyStore bccsp.KeyStore) (bccsp.BCCSP, error) {
+	return newWithParams(securityLevel, hashFamily, keyStore, false)
+}
+
+// NewDeterministicWithParams returns a new instance of the software-based BCCSP
+// set at the passed security level, hash family and KeyStore, whose ECDSA
+// signatures are deterministic as specified by RFC 6979.
+func NewDeterministicWithParams(securityLevel int, hashFamily string, keyStore bccsp.KeyStore) (bccsp.BCCSP, error) {
+	return newWithParams(securityLevel, hashFamily, keyStore, true)
+}
+
+func newWithParams(securityLevel int, hashFamily string, keyStore bccsp.KeyStore, deterministic bool) (bccsp.BCCSP, error) {
 	// Init config
 	conf := &config{}
 	err := conf.setSecurityLevel(securityLevel, hashFamily)
@@ -58,7 +69,7 @@ func NewWithParams(securityLevel int, hashFamily string, keyStore bccsp.KeyStore
 	swbccsp.AddWrapper(reflect.TypeOf(&aesPrivateKey{}), &aescbcpkcs7Decryptor{})
 
 	// Set the signers
-	swbccsp.AddWrapper(reflect.TypeOf(&ecdsaPrivateKey{}), &ecdsaSigner{})
+	swbccsp.AddWrapper(reflect.TypeOf(&ecdsaPrivateKey{}), &ecdsaSigner{deterministic: deterministic})
 	swbccsp.AddWrapper(reflect.TypeOf(&rsaPrivateKey{}), &rsaSigner{})
 
 	// Set the verifiers
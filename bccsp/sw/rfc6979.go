@@ -0,0 +1,131 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// nonceGenerator produces the sequence of candidate nonces defined in
+// section 3.2 of RFC 6979 for a private key and a digest.
+type nonceGenerator struct {
+	q    *big.Int
+	qlen int
+	hash func() hash.Hash
+	k, v []byte
+}
+
+func newNonceGenerator(priv *ecdsa.PrivateKey, digest []byte) *nonceGenerator {
+	q := priv.Params().N
+	g := &nonceGenerator{q: q, qlen: q.BitLen(), hash: nonceHash(q.BitLen())}
+
+	size := g.hash().Size()
+	g.v = bytes.Repeat([]byte{0x01}, size)
+	g.k = make([]byte, size)
+
+	x := g.int2octets(priv.D)
+	h := g.bits2octets(digest)
+	g.k = g.mac(g.k, g.v, []byte{0x00}, x, h)
+	g.v = g.mac(g.k, g.v)
+	g.k = g.mac(g.k, g.v, []byte{0x01}, x, h)
+	g.v = g.mac(g.k, g.v)
+	return g
+}
+
+// nonceHash returns the HMAC hash function matching the curve order size.
+func nonceHash(qlen int) func() hash.Hash {
+	if qlen > 256 {
+		return sha512.New384
+	}
+	return sha256.New
+}
+
+func (g *nonceGenerator) mac(key []byte, data ...[]byte) []byte {
+	m := hmac.New(g.hash, key)
+	for _, d := range data {
+		m.Write(d)
+	}
+	return m.Sum(nil)
+}
+
+// next returns the next candidate nonce in [1, q-1].
+func (g *nonceGenerator) next() *big.Int {
+	for {
+		var t []byte
+		for len(t)*8 < g.qlen {
+			g.v = g.mac(g.k, g.v)
+			t = append(t, g.v...)
+		}
+		k := g.bits2int(t)
+		g.k = g.mac(g.k, g.v, []byte{0x00})
+		g.v = g.mac(g.k, g.v)
+		if k.Sign() > 0 && k.Cmp(g.q) < 0 {
+			return k
+		}
+	}
+}
+
+func (g *nonceGenerator) bits2int(b []byte) *big.Int {
+	x := new(big.Int).SetBytes(b)
+	if excess := len(b)*8 - g.qlen; excess > 0 {
+		x.Rsh(x, uint(excess))
+	}
+	return x
+}
+
+func (g *nonceGenerator) int2octets(x *big.Int) []byte {
+	out := make([]byte, (g.qlen+7)/8)
+	b := x.Bytes()
+	copy(out[len(out)-len(b):], b)
+	return out
+}
+
+func (g *nonceGenerator) bits2octets(b []byte) []byte {
+	z := g.bits2int(b)
+	if z.Cmp(g.q) >= 0 {
+		z.Sub(z, g.q)
+	}
+	return g.int2octets(z)
+}
+
+// signDeterministic computes an ECDSA signature of digest whose nonce is
+// derived from the private key and the digest, as specified by RFC 6979.
+func signDeterministic(priv *ecdsa.PrivateKey, digest []byte) (r, s *big.Int, err error) {
+	n := priv.Params().N
+	if n.Sign() == 0 {
+		return nil, nil, errors.New("zero parameter")
+	}
+
+	g := newNonceGenerator(priv, digest)
+	e := g.bits2int(digest)
+	for {
+		k := g.next()
+
+		r, _ = priv.Curve.ScalarBaseMult(g.int2octets(k))
+		r.Mod(r, n)
+		if r.Sign() == 0 {
+			continue
+		}
+
+		kInv := new(big.Int).ModInverse(k, n)
+		s = new(big.Int).Mul(priv.D, r)
+		s.Add(s, e)
+		s.Mul(s, kInv)
+		s.Mod(s, n)
+		if s.Sign() != 0 {
+			return r, s, nil
+		}
+	}
+}
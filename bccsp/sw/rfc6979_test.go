@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hexInt(t *testing.T, s string) *big.Int {
+	i, ok := new(big.Int).SetString(s, 16)
+	require.True(t, ok)
+	return i
+}
+
+func TestSignDeterministic(t *testing.T) {
+	// test vector from RFC 6979, appendix A.2.5 (P-256, SHA-256, "sample")
+	priv := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: elliptic.P256()},
+		D:         hexInt(t, "C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721"),
+	}
+	priv.PublicKey.X, priv.PublicKey.Y = priv.Curve.ScalarBaseMult(priv.D.Bytes())
+	digest := sha256.Sum256([]byte("sample"))
+
+	k := newNonceGenerator(priv, digest[:]).next()
+	assert.Equal(t, hexInt(t, "A6E3C57DD01ABE90086538398355DD4C3B17AA873382B0F24D6129493D8AAD60"), k)
+
+	r, s, err := signDeterministic(priv, digest[:])
+	require.NoError(t, err)
+	assert.Equal(t, hexInt(t, "EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716"), r)
+	assert.Equal(t, hexInt(t, "F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8"), s)
+	assert.True(t, ecdsa.Verify(&priv.PublicKey, digest[:], r, s))
+
+	sigma, err := signECDSADeterministic(priv, digest[:], nil)
+	require.NoError(t, err)
+	valid, err := verifyECDSA(&priv.PublicKey, sigma, digest[:], nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestDeterministicCSP(t *testing.T) {
+	csp, err := NewDeterministicWithParams(256, "SHA2", NewDummyKeyStore())
+	require.NoError(t, err)
+
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+
+	digest := sha256.Sum256([]byte("hello world"))
+	sigma1, err := csp.Sign(k, digest[:], nil)
+	require.NoError(t, err)
+	sigma2, err := csp.Sign(k, digest[:], nil)
+	require.NoError(t, err)
+	assert.Equal(t, sigma1, sigma2)
+
+	valid, err := csp.Verify(k, sigma1, digest[:], nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	k384, err := csp.KeyGen(&bccsp.ECDSAP384KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	sigma, err := csp.Sign(k384, digest[:], nil)
+	require.NoError(t, err)
+	valid, err = csp.Verify(k384, sigma, digest[:], nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
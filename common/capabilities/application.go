@@ -27,6 +27,30 @@ const (
 
 	// ApplicationResourcesTreeExperimental is the capabilties string for private data using the experimental feature of collections/sideDB.
 	ApplicationResourcesTreeExperimental = "V1_1_RESOURCETREE_EXPERIMENTAL"
+
+	// ApplicationMaintenanceMode is the capabilities string for putting application channels in maintenance through their config.
+	ApplicationMaintenanceMode = "V1_3_MAINTENANCE_MODE"
+
+	// ApplicationTxValidityWindow is the capabilities string for enforcing the validity windows of the transactions at commit.
+	ApplicationTxValidityWindow = "V1_3_TX_VALIDITY_WINDOW"
+
+	// ApplicationChaincodeRetirement is the capabilities string for retiring chaincodes through lscc.
+	ApplicationChaincodeRetirement = "V1_3_CHAINCODE_RETIREMENT"
+
+	// ApplicationChannelConfigPolicyReference is the capabilities string for endorsement policies referencing channel config policies.
+	ApplicationChannelConfigPolicyReference = "V1_3_CHANNEL_CONFIG_POLICY_REFERENCE"
+
+	// ApplicationCollectionEndorsementPolicy is the capabilities string for endorsement policies of the writes to the collections.
+	ApplicationCollectionEndorsementPolicy = "V1_3_COLLECTION_ENDORSEMENT_POLICY"
+
+	// ApplicationRangeQueryValidation is the capabilities string for checking the range queries of the transactions at commit.
+	ApplicationRangeQueryValidation = "V1_3_RANGE_QUERY_VALIDATION"
+
+	// ApplicationDefaultAnchorPeers is the capabilities string for defaulting the anchor peers of the orgs to their peer endpoints.
+	ApplicationDefaultAnchorPeers = "V1_4_DEFAULT_ANCHOR_PEERS"
+
+	// ApplicationCouchDBBinaryValues is the capabilities string for storing the binary values in the documents of the CouchDB state database.
+	ApplicationCouchDBBinaryValues = "V1_4_COUCHDB_BINARY_VALUES"
 )
 
 // ApplicationProvider provides capabilities information for application level config.
@@ -36,6 +60,14 @@ type ApplicationProvider struct {
 	v12                    bool
 	v13                    bool
 	v11PvtDataExperimental bool
+	maintenanceMode        bool
+	txValidityWindow       bool
+	chaincodeRetirement    bool
+	channelConfigPolicyRef bool
+	collectionEndorsement  bool
+	rangeQueryValidation   bool
+	defaultAnchorPeers     bool
+	couchDBBinaryValues    bool
 }
 
 // NewApplicationProvider creates a application capabilities provider.
@@ -46,6 +78,14 @@ func NewApplicationProvider(capabilities map[string]*cb.Capability) *Application
 	_, ap.v12 = capabilities[ApplicationV1_2]
 	_, ap.v13 = capabilities[ApplicationV1_3]
 	_, ap.v11PvtDataExperimental = capabilities[ApplicationPvtDataExperimental]
+	_, ap.maintenanceMode = capabilities[ApplicationMaintenanceMode]
+	_, ap.txValidityWindow = capabilities[ApplicationTxValidityWindow]
+	_, ap.chaincodeRetirement = capabilities[ApplicationChaincodeRetirement]
+	_, ap.channelConfigPolicyRef = capabilities[ApplicationChannelConfigPolicyReference]
+	_, ap.collectionEndorsement = capabilities[ApplicationCollectionEndorsementPolicy]
+	_, ap.rangeQueryValidation = capabilities[ApplicationRangeQueryValidation]
+	_, ap.defaultAnchorPeers = capabilities[ApplicationDefaultAnchorPeers]
+	_, ap.couchDBBinaryValues = capabilities[ApplicationCouchDBBinaryValues]
 	return ap
 }
 
@@ -109,6 +149,54 @@ func (ap *ApplicationProvider) KeyLevelEndorsement() bool {
 	return ap.v13
 }
 
+// MaintenanceMode returns true if the channel state may be specified in the
+// channel application config, so that the channel can be put in maintenance
+func (ap *ApplicationProvider) MaintenanceMode() bool {
+	return ap.maintenanceMode
+}
+
+// TxValidityWindow returns true if the validity windows of the transactions
+// are enforced at commit, against the time of the blocks holding them
+func (ap *ApplicationProvider) TxValidityWindow() bool {
+	return ap.txValidityWindow
+}
+
+// ChaincodeRetirement returns true if the chaincodes may be retired through
+// lscc, after which their invocations are rejected
+func (ap *ApplicationProvider) ChaincodeRetirement() bool {
+	return ap.chaincodeRetirement
+}
+
+// ChannelConfigPolicyReference returns true if the endorsement policy of a chaincode
+// may reference a policy of the channel config instead of being a signature policy
+func (ap *ApplicationProvider) ChannelConfigPolicyReference() bool {
+	return ap.channelConfigPolicyRef
+}
+
+// CollectionEndorsementPolicy returns true if the collections may define the endorsement
+// policy of their writes, enforced by the v1.3 validation in place of the chaincode one
+func (ap *ApplicationProvider) CollectionEndorsementPolicy() bool {
+	return ap.v13 && ap.collectionEndorsement
+}
+
+// RangeQueryValidation returns true if the range queries of the transactions are
+// checked at commit to carry well formed results within the bound of the channel
+func (ap *ApplicationProvider) RangeQueryValidation() bool {
+	return ap.rangeQueryValidation
+}
+
+// DefaultAnchorPeers returns true if the peers use the peer endpoints of the orgs
+// which define no anchor peers as their anchor peers for gossip
+func (ap *ApplicationProvider) DefaultAnchorPeers() bool {
+	return ap.defaultAnchorPeers
+}
+
+// CouchDBBinaryValues returns true if the peers store the binary values in the
+// documents of the CouchDB state database rather than in attachments
+func (ap *ApplicationProvider) CouchDBBinaryValues() bool {
+	return ap.couchDBBinaryValues
+}
+
 // HasCapability returns true if the capability is supported by this binary.
 func (ap *ApplicationProvider) HasCapability(capability string) bool {
 	switch capability {
@@ -123,6 +211,22 @@ func (ap *ApplicationProvider) HasCapability(capability string) bool {
 		return true
 	case ApplicationResourcesTreeExperimental:
 		return true
+	case ApplicationMaintenanceMode:
+		return true
+	case ApplicationTxValidityWindow:
+		return true
+	case ApplicationChaincodeRetirement:
+		return true
+	case ApplicationChannelConfigPolicyReference:
+		return true
+	case ApplicationCollectionEndorsementPolicy:
+		return true
+	case ApplicationRangeQueryValidation:
+		return true
+	case ApplicationDefaultAnchorPeers:
+		return true
+	case ApplicationCouchDBBinaryValues:
+		return true
 	default:
 		return false
 	}
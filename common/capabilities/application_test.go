@@ -63,6 +63,122 @@ func TestApplicationPvtDataExperimental(t *testing.T) {
 	assert.True(t, ap.PrivateChannelData())
 }
 
+func TestApplicationMaintenanceMode(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.False(t, ap.MaintenanceMode())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3:            {},
+		ApplicationMaintenanceMode: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.MaintenanceMode())
+}
+
+func TestApplicationTxValidityWindow(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.False(t, ap.TxValidityWindow())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3:             {},
+		ApplicationTxValidityWindow: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.TxValidityWindow())
+}
+
+func TestApplicationChaincodeRetirement(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.False(t, ap.ChaincodeRetirement())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3:                {},
+		ApplicationChaincodeRetirement: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.ChaincodeRetirement())
+}
+
+func TestApplicationChannelConfigPolicyReference(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.False(t, ap.ChannelConfigPolicyReference())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3:                         {},
+		ApplicationChannelConfigPolicyReference: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.ChannelConfigPolicyReference())
+}
+
+func TestApplicationCollectionEndorsementPolicy(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.False(t, ap.CollectionEndorsementPolicy())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_2:                        {},
+		ApplicationCollectionEndorsementPolicy: {},
+	})
+	assert.False(t, ap.CollectionEndorsementPolicy())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3:                        {},
+		ApplicationCollectionEndorsementPolicy: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.CollectionEndorsementPolicy())
+}
+
+func TestApplicationRangeQueryValidation(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.False(t, ap.RangeQueryValidation())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3:                 {},
+		ApplicationRangeQueryValidation: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.RangeQueryValidation())
+}
+
+func TestApplicationDefaultAnchorPeers(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.False(t, ap.DefaultAnchorPeers())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationDefaultAnchorPeers: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.DefaultAnchorPeers())
+}
+
+func TestApplicationCouchDBBinaryValues(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.False(t, ap.CouchDBBinaryValues())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationCouchDBBinaryValues: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.CouchDBBinaryValues())
+}
+
 func TestHasCapability(t *testing.T) {
 	ap := NewApplicationProvider(map[string]*cb.Capability{})
 	assert.True(t, ap.HasCapability(ApplicationV1_1))
@@ -70,5 +186,13 @@ func TestHasCapability(t *testing.T) {
 	assert.True(t, ap.HasCapability(ApplicationV1_3))
 	assert.True(t, ap.HasCapability(ApplicationPvtDataExperimental))
 	assert.True(t, ap.HasCapability(ApplicationResourcesTreeExperimental))
+	assert.True(t, ap.HasCapability(ApplicationMaintenanceMode))
+	assert.True(t, ap.HasCapability(ApplicationTxValidityWindow))
+	assert.True(t, ap.HasCapability(ApplicationChaincodeRetirement))
+	assert.True(t, ap.HasCapability(ApplicationChannelConfigPolicyReference))
+	assert.True(t, ap.HasCapability(ApplicationCollectionEndorsementPolicy))
+	assert.True(t, ap.HasCapability(ApplicationRangeQueryValidation))
+	assert.True(t, ap.HasCapability(ApplicationDefaultAnchorPeers))
+	assert.True(t, ap.HasCapability(ApplicationCouchDBBinaryValues))
 	assert.False(t, ap.HasCapability("default"))
 }
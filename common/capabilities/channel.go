@@ -19,6 +19,15 @@ const (
 
 	// ChannelV1_3 is the capabilties string for standard new non-backwards compatible fabric v1.3 channel capabilities.
 	ChannelV1_3 = "V1_3"
+
+	// ChannelV1_4 is the capabilties string for standard new non-backwards compatible fabric v1.4 channel capabilities.
+	ChannelV1_4 = "V1_4"
+
+	// ChannelExpiredCertTolerance is the capabilities string for validating the certificates of the channel MSPs independently from the current time.
+	ChannelExpiredCertTolerance = "V1_4_EXPIRED_CERT_TOLERANCE"
+
+	// ChannelDefinedSubPolicies is the capabilities string for implicit meta policies only counting the sub-groups which define their sub-policy.
+	ChannelDefinedSubPolicies = "V1_4_DEFINED_SUB_POLICIES"
 )
 
 // ChannelProvider provides capabilities information for channel level config.
@@ -26,6 +35,10 @@ type ChannelProvider struct {
 	*registry
 	v11 bool
 	v13 bool
+	v14 bool
+
+	expiredCertTolerance bool
+	definedSubPolicies   bool
 }
 
 // NewChannelProvider creates a channel capabilities provider.
@@ -34,6 +47,9 @@ func NewChannelProvider(capabilities map[string]*cb.Capability) *ChannelProvider
 	cp.registry = newRegistry(cp, capabilities)
 	_, cp.v11 = capabilities[ChannelV1_1]
 	_, cp.v13 = capabilities[ChannelV1_3]
+	_, cp.v14 = capabilities[ChannelV1_4]
+	_, cp.expiredCertTolerance = capabilities[ChannelExpiredCertTolerance]
+	_, cp.definedSubPolicies = capabilities[ChannelDefinedSubPolicies]
 	return cp
 }
 
@@ -46,6 +62,12 @@ func (cp *ChannelProvider) Type() string {
 func (cp *ChannelProvider) HasCapability(capability string) bool {
 	switch capability {
 	// Add new capability names here
+	case ChannelDefinedSubPolicies:
+		return true
+	case ChannelExpiredCertTolerance:
+		return true
+	case ChannelV1_4:
+		return true
 	case ChannelV1_3:
 		return true
 	case ChannelV1_1:
@@ -55,9 +77,33 @@ func (cp *ChannelProvider) HasCapability(capability string) bool {
 	}
 }
 
+// ConfigurableHashing returns true if the channel may use a hashing algorithm
+// other than SHA256 for block hashes and transaction ids.
+func (cp *ChannelProvider) ConfigurableHashing() bool {
+	return cp.v14
+}
+
+// ExpiredCertTolerance returns true if the TLS CA certificates of the MSPs of
+// the channel are validated at a time they were valid rather than at the
+// current time, so that the config blocks defining them can still be
+// processed once they expired.
+func (cp *ChannelProvider) ExpiredCertTolerance() bool {
+	return cp.expiredCertTolerance
+}
+
+// DefinedSubPolicies returns true if the implicit meta policies of the channel
+// only count the sub-groups which define their sub-policy, rather than counting
+// the others as rejecting it, so that they can roll up policies such as an
+// Auditors policy which only some organizations define.
+func (cp *ChannelProvider) DefinedSubPolicies() bool {
+	return cp.definedSubPolicies
+}
+
 // MSPVersion returns the level of MSP support required by this channel.
 func (cp *ChannelProvider) MSPVersion() msp.MSPVersion {
 	switch {
+	case cp.v14:
+		return msp.MSPv1_4
 	case cp.v13:
 		return msp.MSPv1_3
 	case cp.v11:
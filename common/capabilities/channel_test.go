@@ -36,4 +36,44 @@ func TestChannelV13(t *testing.T) {
 	})
 	assert.NoError(t, op.Supported())
 	assert.True(t, op.MSPVersion() == msp.MSPv1_3)
+	assert.False(t, op.ConfigurableHashing())
+}
+
+func TestChannelV14(t *testing.T) {
+	op := NewChannelProvider(map[string]*cb.Capability{
+		ChannelV1_3: {},
+		ChannelV1_4: {},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.MSPVersion() == msp.MSPv1_4)
+	assert.True(t, op.ConfigurableHashing())
+}
+
+func TestChannelExpiredCertTolerance(t *testing.T) {
+	op := NewChannelProvider(map[string]*cb.Capability{
+		ChannelV1_3: {},
+	})
+	assert.False(t, op.ExpiredCertTolerance())
+
+	op = NewChannelProvider(map[string]*cb.Capability{
+		ChannelV1_3:                 {},
+		ChannelExpiredCertTolerance: {},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.ExpiredCertTolerance())
+	assert.True(t, op.MSPVersion() == msp.MSPv1_3)
+}
+
+func TestChannelDefinedSubPolicies(t *testing.T) {
+	op := NewChannelProvider(map[string]*cb.Capability{
+		ChannelV1_4: {},
+	})
+	assert.False(t, op.DefinedSubPolicies())
+
+	op = NewChannelProvider(map[string]*cb.Capability{
+		ChannelV1_4:               {},
+		ChannelDefinedSubPolicies: {},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.DefinedSubPolicies())
 }
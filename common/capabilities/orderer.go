@@ -15,12 +15,21 @@ const (
 
 	// OrdererV1_1 is the capabilties string for standard new non-backwards compatible fabric v1.1 orderer capabilities.
 	OrdererV1_1 = "V1_1"
+
+	// OrdererTxPriority is the capabilities string for ordering the transactions of a batch according to their priorities.
+	OrdererTxPriority = "V1_4_TX_PRIORITY"
+
+	// OrdererConsensusTypeMigration is the capabilities string for changing the consensus type of a channel in maintenance.
+	OrdererConsensusTypeMigration = "V1_4_CONSENSUS_TYPE_MIGRATION"
 )
 
 // OrdererProvider provides capabilities information for orderer level config.
 type OrdererProvider struct {
 	*registry
 	v11BugFixes bool
+
+	txPriority             bool
+	consensusTypeMigration bool
 }
 
 // NewOrdererProvider creates an orderer capabilities provider.
@@ -28,6 +37,8 @@ func NewOrdererProvider(capabilities map[string]*cb.Capability) *OrdererProvider
 	cp := &OrdererProvider{}
 	cp.registry = newRegistry(cp, capabilities)
 	_, cp.v11BugFixes = capabilities[OrdererV1_1]
+	_, cp.txPriority = capabilities[OrdererTxPriority]
+	_, cp.consensusTypeMigration = capabilities[OrdererConsensusTypeMigration]
 	return cp
 }
 
@@ -40,6 +51,10 @@ func (cp *OrdererProvider) Type() string {
 func (cp *OrdererProvider) HasCapability(capability string) bool {
 	switch capability {
 	// Add new capability names here
+	case OrdererTxPriority:
+		return true
+	case OrdererConsensusTypeMigration:
+		return true
 	case OrdererV1_1:
 		return true
 	default:
@@ -64,3 +79,15 @@ func (cp *OrdererProvider) Resubmission() bool {
 func (cp *OrdererProvider) ExpirationCheck() bool {
 	return cp.v11BugFixes
 }
+
+// TxPriority specifies whether the orderer orders the transactions of a batch
+// according to their priorities, within the reordering limit of the batch size
+func (cp *OrdererProvider) TxPriority() bool {
+	return cp.txPriority
+}
+
+// ConsensusTypeMigration specifies whether the channel may be put in maintenance
+// through the state of its consensus type, so that the consensus type may change
+func (cp *OrdererProvider) ConsensusTypeMigration() bool {
+	return cp.consensusTypeMigration
+}
@@ -31,3 +31,31 @@ func TestOrdererV11(t *testing.T) {
 	assert.True(t, op.Resubmission())
 	assert.True(t, op.ExpirationCheck())
 }
+
+func TestOrdererTxPriority(t *testing.T) {
+	op := NewOrdererProvider(map[string]*cb.Capability{
+		OrdererV1_1: {},
+	})
+	assert.False(t, op.TxPriority())
+
+	op = NewOrdererProvider(map[string]*cb.Capability{
+		OrdererV1_1:       {},
+		OrdererTxPriority: {},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.TxPriority())
+}
+
+func TestOrdererConsensusTypeMigration(t *testing.T) {
+	op := NewOrdererProvider(map[string]*cb.Capability{
+		OrdererV1_1: {},
+	})
+	assert.False(t, op.ConsensusTypeMigration())
+
+	op = NewOrdererProvider(map[string]*cb.Capability{
+		OrdererV1_1:                   {},
+		OrdererConsensusTypeMigration: {},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.ConsensusTypeMigration())
+}
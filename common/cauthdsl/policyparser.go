@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/Knetic/govaluate"
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -50,9 +51,20 @@ var (
 		fmt.Sprintf("^([[:alnum:].-]+)([.])(%s|%s|%s|%s)$",
 			RoleAdmin, RoleMember, RoleClient, RolePeer),
 	)
+	regexAttr = regexp.MustCompile(
+		fmt.Sprintf("^([[:alnum:].-]+)[.]%s[.]([[:alnum:]_.-]+)=([^']+)$", AttributePrincipal),
+	)
 	regexErr = regexp.MustCompile("^No parameter '([^']+)' found[.]$")
 )
 
+// AttributePrincipal is the keyword introducing attribute based principals
+const AttributePrincipal = "attr"
+
+// isPrincipal returns true if the string is a role or attribute principal
+func isPrincipal(s string) bool {
+	return regex.MatchString(s) || regexAttr.MatchString(s)
+}
+
 // a stub function - it returns the same string as it's passed.
 // This will be evaluated by second/third passes to convert to a proto policy
 func outof(args ...interface{}) (interface{}, error) {
@@ -77,7 +89,7 @@ func outof(args ...interface{}) (interface{}, error) {
 		toret += ", "
 		switch t := arg.(type) {
 		case string:
-			if regex.MatchString(t) {
+			if isPrincipal(t) {
 				toret += "'" + t + "'"
 			} else {
 				toret += t
@@ -105,7 +117,7 @@ func firstPass(args ...interface{}) (interface{}, error) {
 		toret += ", "
 		switch t := arg.(type) {
 		case string:
-			if regex.MatchString(t) {
+			if isPrincipal(t) {
 				toret += "'" + t + "'"
 			} else {
 				toret += t
@@ -163,6 +175,17 @@ func secondPass(args ...interface{}) (interface{}, error) {
 		   <MSP_ID> . <ROLE>, where MSP_ID is the MSP identifier
 		   and ROLE is either a member, an admin, a client, a peer or an orderer*/
 		case string:
+			/* attribute principals are formed as
+			   <MSP_ID> . attr . <NAME> = <VALUE> */
+			if attr := regexAttr.FindStringSubmatch(t); attr != nil {
+				p := &msp.MSPPrincipal{
+					PrincipalClassification: msp.MSPPrincipal_ATTRIBUTE,
+					Principal:               utils.MarshalOrPanic(&msp.MSPAttribute{MspIdentifier: attr[1], Name: attr[2], Value: attr[3]})}
+				ctx.principals = append(ctx.principals, p)
+				policies = append(policies, SignedBy(int32(ctx.IDNum)))
+				ctx.IDNum++
+				continue
+			}
 			/* split the string */
 			subm := regex.FindAllStringSubmatch(t, -1)
 			if subm == nil || len(subm) != 1 || len(subm[0]) != 4 {
@@ -241,6 +264,13 @@ func newContext() *context {
 //	- ORG is a string (representing the MSP identifier)
 //	- ROLE takes the value of any of the RoleXXX constants representing
 //    the required role
+//
+// or, to require an identity holding a certified attribute, as:
+//
+// ORG.attr.NAME=VALUE
+//
+// where NAME and VALUE are the name and value of the attribute. Attribute
+// principals require the V1_4 channel capability.
 func FromString(policy string) (*common.SignaturePolicyEnvelope, error) {
 	// first we translate the and/or business into outof gates
 	intermediate, err := govaluate.NewEvaluableExpressionWithFunctions(
@@ -339,3 +369,88 @@ func FromString(policy string) (*common.SignaturePolicyEnvelope, error) {
 
 	return p, nil
 }
+
+// ToString returns the string representation of a policy in the language
+// parsed by FromString. Gates requiring all of their sub-policies are
+// rendered as AND, gates requiring one of them as OR, and the others as
+// OutOf. Only role and attribute principals can be represented.
+func ToString(policy *common.SignaturePolicyEnvelope) (string, error) {
+	if policy == nil || policy.Rule == nil {
+		return "", fmt.Errorf("empty policy")
+	}
+	principals := make([]string, len(policy.Identities))
+	for i, principal := range policy.Identities {
+		s, err := principalToString(principal)
+		if err != nil {
+			return "", err
+		}
+		principals[i] = s
+	}
+	return ruleToString(policy.Rule, principals)
+}
+
+func ruleToString(rule *common.SignaturePolicy, principals []string) (string, error) {
+	switch t := rule.Type.(type) {
+	case *common.SignaturePolicy_SignedBy:
+		if t.SignedBy < 0 || int(t.SignedBy) >= len(principals) {
+			return "", fmt.Errorf("identity index %d out of range", t.SignedBy)
+		}
+		return principals[t.SignedBy], nil
+	case *common.SignaturePolicy_NOutOf_:
+		rules := t.NOutOf.Rules
+		if len(rules) == 0 {
+			return "", fmt.Errorf("gate without sub-policies")
+		}
+		subPolicies := make([]string, len(rules))
+		for i, r := range rules {
+			s, err := ruleToString(r, principals)
+			if err != nil {
+				return "", err
+			}
+			subPolicies[i] = s
+		}
+		args := strings.Join(subPolicies, ", ")
+		switch int(t.NOutOf.N) {
+		case len(rules):
+			return fmt.Sprintf("%s(%s)", strings.ToUpper(GateAnd), args), nil
+		case 1:
+			return fmt.Sprintf("%s(%s)", strings.ToUpper(GateOr), args), nil
+		default:
+			return fmt.Sprintf("%s(%d, %s)", GateOutOf, t.NOutOf.N, args), nil
+		}
+	default:
+		return "", fmt.Errorf("unknown policy type %T", rule.Type)
+	}
+}
+
+func principalToString(principal *msp.MSPPrincipal) (string, error) {
+	switch principal.PrincipalClassification {
+	case msp.MSPPrincipal_ROLE:
+		role := &msp.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err != nil {
+			return "", fmt.Errorf("malformed role principal: %s", err)
+		}
+		var r string
+		switch role.Role {
+		case msp.MSPRole_MEMBER:
+			r = RoleMember
+		case msp.MSPRole_ADMIN:
+			r = RoleAdmin
+		case msp.MSPRole_CLIENT:
+			r = RoleClient
+		case msp.MSPRole_PEER:
+			r = RolePeer
+		default:
+			return "", fmt.Errorf("unknown role %s", role.Role)
+		}
+		return fmt.Sprintf("'%s.%s'", role.MspIdentifier, r), nil
+	case msp.MSPPrincipal_ATTRIBUTE:
+		attr := &msp.MSPAttribute{}
+		if err := proto.Unmarshal(principal.Principal, attr); err != nil {
+			return "", fmt.Errorf("malformed attribute principal: %s", err)
+		}
+		return fmt.Sprintf("'%s.%s.%s=%s'", attr.MspIdentifier, AttributePrincipal, attr.Name, attr.Value), nil
+	default:
+		return "", fmt.Errorf("principal classification %s cannot be represented", principal.PrincipalClassification)
+	}
+}
@@ -305,3 +305,73 @@ func TestBadStringBeforeFAB11404_ThisCanDeleteAfterFAB11404HasMerged(t *testing.
 	assert.Nil(t, p3)
 	assert.EqualError(t, err3, `invalid policy string ''\'1\'''`)
 }
+
+func TestAttributePrincipal(t *testing.T) {
+	p1, err := FromString("OR('A.attr.role=auditor', 'B.member')")
+	assert.NoError(t, err)
+
+	principals := make([]*msp.MSPPrincipal, 0)
+
+	principals = append(principals, &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ATTRIBUTE,
+		Principal:               utils.MarshalOrPanic(&msp.MSPAttribute{MspIdentifier: "A", Name: "role", Value: "auditor"})})
+
+	principals = append(principals, &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal:               utils.MarshalOrPanic(&msp.MSPRole{Role: msp.MSPRole_MEMBER, MspIdentifier: "B"})})
+
+	p2 := &common.SignaturePolicyEnvelope{
+		Version:    0,
+		Rule:       NOutOf(1, []*common.SignaturePolicy{SignedBy(0), SignedBy(1)}),
+		Identities: principals,
+	}
+
+	assert.Equal(t, p1, p2)
+
+	_, err = FromString("OR('A.attr.role', 'B.member')")
+	assert.Error(t, err)
+}
+
+func TestToString(t *testing.T) {
+	policies := []string{
+		"AND('A.member', 'B.admin')",
+		"OR('A.client', 'B.peer')",
+		"OutOf(2, 'A.member', 'B.member', 'C.member')",
+		"OR(AND('A.member', 'B.member'), 'C.attr.role=auditor')",
+		"AND('A.member')",
+		"OR('A-1.org.member', AND('B.admin', 'C.admin'))",
+	}
+	for _, policy := range policies {
+		env, err := FromString(policy)
+		assert.NoError(t, err)
+		s, err := ToString(env)
+		assert.NoError(t, err)
+		assert.Equal(t, policy, s)
+	}
+
+	// gates are normalized to AND and OR where possible
+	env, err := FromString("OutOf(1, 'A.member', 'B.member')")
+	assert.NoError(t, err)
+	s, err := ToString(env)
+	assert.NoError(t, err)
+	assert.Equal(t, "OR('A.member', 'B.member')", s)
+}
+
+func TestToStringErrors(t *testing.T) {
+	_, err := ToString(nil)
+	assert.EqualError(t, err, "empty policy")
+
+	_, err = ToString(&common.SignaturePolicyEnvelope{Rule: SignedBy(1), Identities: []*msp.MSPPrincipal{{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal:               utils.MarshalOrPanic(&msp.MSPRole{Role: msp.MSPRole_MEMBER, MspIdentifier: "A"}),
+	}}})
+	assert.EqualError(t, err, "identity index 1 out of range")
+
+	_, err = ToString(&common.SignaturePolicyEnvelope{Rule: SignedBy(0), Identities: []*msp.MSPPrincipal{{
+		PrincipalClassification: msp.MSPPrincipal_IDENTITY,
+	}}})
+	assert.EqualError(t, err, "principal classification IDENTITY cannot be represented")
+
+	_, err = ToString(&common.SignaturePolicyEnvelope{Rule: NOutOf(1, nil)})
+	assert.EqualError(t, err, "gate without sub-policies")
+}
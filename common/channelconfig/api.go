@@ -32,6 +32,9 @@ type ApplicationOrg interface {
 
 	// AnchorPeers returns the list of gossip anchor peers
 	AnchorPeers() []*pb.AnchorPeer
+
+	// PeerEndpoints returns the host:port external endpoints of the peers of the org
+	PeerEndpoints() []string
 }
 
 // Application stores the common shared application config
@@ -44,6 +47,18 @@ type Application interface {
 
 	// Capabilities defines the capabilities for the application portion of a channel
 	Capabilities() ApplicationCapabilities
+
+	// ChannelState returns the state of the channel, a channel in maintenance
+	// only accepts config transactions
+	ChannelState() pb.ChannelState_State
+
+	// MaxClockSkew returns the tolerance applied to the validity windows of
+	// the transactions when they are enforced
+	MaxClockSkew() time.Duration
+
+	// MaxRangeQueryResults returns the max number of results a peer goes
+	// through to validate a range query at commit, zero for no limit
+	MaxRangeQueryResults() uint32
 }
 
 // Channel gives read only access to the channel configuration
@@ -86,6 +101,10 @@ type Orderer interface {
 	// ConsensusMetadata returns the metadata associated with the consensus type.
 	ConsensusMetadata() []byte
 
+	// ConsensusState returns the state of the consensus type, a channel in
+	// maintenance only accepts config transactions
+	ConsensusState() ab.ConsensusType_State
+
 	// BatchSize returns the maximum number of messages to include in a block
 	BatchSize() *ab.BatchSize
 
@@ -115,6 +134,18 @@ type ChannelCapabilities interface {
 	// MSPVersion specifies the version of the MSP this channel must understand, including the MSP types
 	// and MSP principal types.
 	MSPVersion() msp.MSPVersion
+
+	// ConfigurableHashing specifies whether the channel may use a hashing algorithm other
+	// than SHA256 for block hashes and transaction ids.
+	ConfigurableHashing() bool
+
+	// ExpiredCertTolerance specifies whether the TLS CA certificates of the MSPs of the
+	// channel are validated at a time they were valid rather than at the current time.
+	ExpiredCertTolerance() bool
+
+	// DefinedSubPolicies specifies whether the implicit meta policies of the channel only
+	// count the sub-groups which define their sub-policy.
+	DefinedSubPolicies() bool
 }
 
 // ApplicationCapabilities defines the capabilities for the application portion of a channel
@@ -160,6 +191,38 @@ type ApplicationCapabilities interface {
 	// KeyLevelEndorsement returns true if this channel supports endorsement
 	// policies expressible at a ledger key granularity, as described in FAB-8812
 	KeyLevelEndorsement() bool
+
+	// MaintenanceMode returns true if the channel state may be specified in the
+	// channel application config, so that the channel can be put in maintenance
+	MaintenanceMode() bool
+
+	// TxValidityWindow returns true if the validity windows of the transactions
+	// are enforced at commit, against the time of the blocks holding them
+	TxValidityWindow() bool
+
+	// ChaincodeRetirement returns true if the chaincodes may be retired through
+	// lscc, after which their invocations are rejected
+	ChaincodeRetirement() bool
+
+	// ChannelConfigPolicyReference returns true if the endorsement policy of a chaincode
+	// may reference a policy of the channel config instead of being a signature policy
+	ChannelConfigPolicyReference() bool
+
+	// CollectionEndorsementPolicy returns true if the collections may define the endorsement
+	// policy of their writes, enforced by the v1.3 validation in place of the chaincode one
+	CollectionEndorsementPolicy() bool
+
+	// RangeQueryValidation returns true if the range queries of the transactions are
+	// checked at commit to carry well formed results within the bound of the channel
+	RangeQueryValidation() bool
+
+	// DefaultAnchorPeers returns true if the peers use the peer endpoints of the orgs
+	// which define no anchor peers as their anchor peers for gossip
+	DefaultAnchorPeers() bool
+
+	// CouchDBBinaryValues returns true if the peers store the binary values in the
+	// documents of the CouchDB state database rather than in attachments
+	CouchDBBinaryValues() bool
 }
 
 // OrdererCapabilities defines the capabilities for the orderer portion of a channel
@@ -178,6 +241,14 @@ type OrdererCapabilities interface {
 	// ExpirationCheck specifies whether the orderer checks for identity expiration checks
 	// when validating messages
 	ExpirationCheck() bool
+
+	// TxPriority specifies whether the orderer orders the transactions of a batch
+	// according to their priorities
+	TxPriority() bool
+
+	// ConsensusTypeMigration specifies whether the channel may be put in maintenance
+	// through the state of its consensus type, so that the consensus type may change
+	ConsensusTypeMigration() bool
 }
 
 // PolicyMapper is an interface for
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package channelconfig
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/common/capabilities"
 	cb "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -20,18 +22,31 @@ const (
 
 	// ACLsKey is the name of the ACLs config
 	ACLsKey = "ACLs"
+
+	// ChannelStateKey is the name of the channel state config
+	ChannelStateKey = "ChannelState"
+
+	// TxValidityWindowKey is the name of the transaction validity window config
+	TxValidityWindowKey = "TxValidityWindow"
+
+	// RangeQueryValidationKey is the name of the range query validation config
+	RangeQueryValidationKey = "RangeQueryValidation"
 )
 
 // ApplicationProtos is used as the source of the ApplicationConfig
 type ApplicationProtos struct {
-	ACLs         *pb.ACLs
-	Capabilities *cb.Capabilities
+	ACLs                 *pb.ACLs
+	Capabilities         *cb.Capabilities
+	ChannelState         *pb.ChannelState
+	TxValidityWindow     *pb.TxValidityWindow
+	RangeQueryValidation *pb.RangeQueryValidation
 }
 
 // ApplicationConfig implements the Application interface
 type ApplicationConfig struct {
 	applicationOrgs map[string]ApplicationOrg
 	protos          *ApplicationProtos
+	maxClockSkew    time.Duration
 }
 
 // NewApplicationConfig creates config from an Application config group
@@ -51,6 +66,27 @@ func NewApplicationConfig(appGroup *cb.ConfigGroup, mspConfig *MSPConfigHandler)
 		}
 	}
 
+	if !ac.Capabilities().MaintenanceMode() {
+		if _, ok := appGroup.Values[ChannelStateKey]; ok {
+			return nil, errors.New("ChannelState may not be specified without the required capability")
+		}
+	}
+
+	if !ac.Capabilities().TxValidityWindow() {
+		if _, ok := appGroup.Values[TxValidityWindowKey]; ok {
+			return nil, errors.New("TxValidityWindow may not be specified without the required capability")
+		}
+	}
+	if err := ac.validateMaxClockSkew(); err != nil {
+		return nil, err
+	}
+
+	if !ac.Capabilities().RangeQueryValidation() {
+		if _, ok := appGroup.Values[RangeQueryValidationKey]; ok {
+			return nil, errors.New("RangeQueryValidation may not be specified without the required capability")
+		}
+	}
+
 	var err error
 	for orgName, orgGroup := range appGroup.Groups {
 		ac.applicationOrgs[orgName], err = NewApplicationOrgConfig(orgName, orgGroup, mspConfig)
@@ -72,6 +108,40 @@ func (ac *ApplicationConfig) Capabilities() ApplicationCapabilities {
 	return capabilities.NewApplicationProvider(ac.protos.Capabilities.Capabilities)
 }
 
+// ChannelState returns the state of the channel, a channel in maintenance
+// only accepts config transactions
+func (ac *ApplicationConfig) ChannelState() pb.ChannelState_State {
+	return ac.protos.ChannelState.State
+}
+
+// MaxClockSkew returns the tolerance applied to the validity windows of the
+// transactions when they are enforced
+func (ac *ApplicationConfig) MaxClockSkew() time.Duration {
+	return ac.maxClockSkew
+}
+
+func (ac *ApplicationConfig) validateMaxClockSkew() error {
+	skew := ac.protos.TxValidityWindow.MaxClockSkew
+	if skew == "" {
+		return nil
+	}
+	var err error
+	ac.maxClockSkew, err = time.ParseDuration(skew)
+	if err != nil {
+		return errors.Errorf("invalid max clock skew of the transaction validity windows: %s", err)
+	}
+	if ac.maxClockSkew < 0 {
+		return errors.Errorf("negative max clock skew of the transaction validity windows: %s", ac.maxClockSkew)
+	}
+	return nil
+}
+
+// MaxRangeQueryResults returns the max number of results a peer goes through
+// to validate a range query at commit, zero for no limit
+func (ac *ApplicationConfig) MaxRangeQueryResults() uint32 {
+	return ac.protos.RangeQueryValidation.MaxResults
+}
+
 // APIPolicyMapper returns a PolicyMapper that maps API names to policies
 func (ac *ApplicationConfig) APIPolicyMapper() PolicyMapper {
 	pm := newAPIsProvider(ac.protos.ACLs.Acls)
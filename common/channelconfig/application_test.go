@@ -8,9 +8,11 @@ package channelconfig
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/capabilities"
 	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 
 	"github.com/golang/protobuf/proto"
@@ -53,3 +55,143 @@ func TestACL(t *testing.T) {
 		g.Expect(err).To(MatchError("ACLs may not be specified without the required capability"))
 	})
 }
+
+func TestChannelState(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cgt := &cb.ConfigGroup{
+		Values: map[string]*cb.ConfigValue{
+			ChannelStateKey: {
+				Value: utils.MarshalOrPanic(
+					ChannelStateValue(pb.ChannelState_MAINTENANCE).Value(),
+				),
+			},
+			CapabilitiesKey: {
+				Value: utils.MarshalOrPanic(
+					CapabilitiesValue(map[string]bool{
+						capabilities.ApplicationMaintenanceMode: true,
+					}).Value(),
+				),
+			},
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		cg := proto.Clone(cgt).(*cb.ConfigGroup)
+		ac, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ac.ChannelState()).To(Equal(pb.ChannelState_MAINTENANCE))
+	})
+
+	t.Run("DefaultState", func(t *testing.T) {
+		cg := proto.Clone(cgt).(*cb.ConfigGroup)
+		delete(cg.Values, ChannelStateKey)
+		ac, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ac.ChannelState()).To(Equal(pb.ChannelState_NORMAL))
+	})
+
+	t.Run("MissingCapability", func(t *testing.T) {
+		cg := proto.Clone(cgt).(*cb.ConfigGroup)
+		delete(cg.Values, CapabilitiesKey)
+		_, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).To(MatchError("ChannelState may not be specified without the required capability"))
+	})
+}
+
+func TestTxValidityWindow(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cgt := &cb.ConfigGroup{
+		Values: map[string]*cb.ConfigValue{
+			TxValidityWindowKey: {
+				Value: utils.MarshalOrPanic(
+					TxValidityWindowValue(30 * time.Second).Value(),
+				),
+			},
+			CapabilitiesKey: {
+				Value: utils.MarshalOrPanic(
+					CapabilitiesValue(map[string]bool{
+						capabilities.ApplicationTxValidityWindow: true,
+					}).Value(),
+				),
+			},
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		cg := proto.Clone(cgt).(*cb.ConfigGroup)
+		ac, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ac.MaxClockSkew()).To(Equal(30 * time.Second))
+	})
+
+	t.Run("DefaultSkew", func(t *testing.T) {
+		cg := proto.Clone(cgt).(*cb.ConfigGroup)
+		delete(cg.Values, TxValidityWindowKey)
+		ac, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ac.MaxClockSkew()).To(BeZero())
+	})
+
+	t.Run("InvalidSkew", func(t *testing.T) {
+		cg := proto.Clone(cgt).(*cb.ConfigGroup)
+		cg.Values[TxValidityWindowKey].Value = utils.MarshalOrPanic(&pb.TxValidityWindow{MaxClockSkew: "forever"})
+		_, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).To(MatchError(ContainSubstring("invalid max clock skew of the transaction validity windows")))
+	})
+
+	t.Run("NegativeSkew", func(t *testing.T) {
+		cg := proto.Clone(cgt).(*cb.ConfigGroup)
+		cg.Values[TxValidityWindowKey].Value = utils.MarshalOrPanic(&pb.TxValidityWindow{MaxClockSkew: "-1s"})
+		_, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).To(MatchError("negative max clock skew of the transaction validity windows: -1s"))
+	})
+
+	t.Run("MissingCapability", func(t *testing.T) {
+		cg := proto.Clone(cgt).(*cb.ConfigGroup)
+		delete(cg.Values, CapabilitiesKey)
+		_, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).To(MatchError("TxValidityWindow may not be specified without the required capability"))
+	})
+}
+
+func TestRangeQueryValidation(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cgt := &cb.ConfigGroup{
+		Values: map[string]*cb.ConfigValue{
+			RangeQueryValidationKey: {
+				Value: utils.MarshalOrPanic(
+					RangeQueryValidationValue(1000).Value(),
+				),
+			},
+			CapabilitiesKey: {
+				Value: utils.MarshalOrPanic(
+					CapabilitiesValue(map[string]bool{
+						capabilities.ApplicationRangeQueryValidation: true,
+					}).Value(),
+				),
+			},
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		cg := proto.Clone(cgt).(*cb.ConfigGroup)
+		ac, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ac.MaxRangeQueryResults()).To(Equal(uint32(1000)))
+	})
+
+	t.Run("NoLimit", func(t *testing.T) {
+		cg := proto.Clone(cgt).(*cb.ConfigGroup)
+		delete(cg.Values, RangeQueryValidationKey)
+		ac, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(ac.MaxRangeQueryResults()).To(BeZero())
+	})
+
+	t.Run("MissingCapability", func(t *testing.T) {
+		cg := proto.Clone(cgt).(*cb.ConfigGroup)
+		delete(cg.Values, CapabilitiesKey)
+		_, err := NewApplicationConfig(cg, nil)
+		g.Expect(err).To(MatchError("RangeQueryValidation may not be specified without the required capability"))
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
 
 	"github.com/pkg/errors"
@@ -31,7 +32,6 @@ const RootGroupKey = "Channel"
 // entirety, with new backing memory.
 type Bundle struct {
 	policyManager   policies.Manager
-	mspManager      msp.MSPManager
 	channelConfig   *ChannelConfig
 	configtxManager configtx.Validator
 }
@@ -80,14 +80,27 @@ func (b *Bundle) ConfigtxValidator() configtx.Validator {
 // ValidateNew checks if a new bundle's contained configuration is valid to be derived from the current bundle.
 // This allows checks of the nature "Make sure that the consensus type did not change." which is otherwise
 func (b *Bundle) ValidateNew(nb Resources) error {
+	// Blocks and transaction ids already on the chain are bound to the hashing algorithm
+	if ncc, ok := nb.ChannelConfig().(*ChannelConfig); ok {
+		name, newName := b.channelConfig.hashingAlgorithmName(), ncc.hashingAlgorithmName()
+		if name != newName {
+			return errors.Errorf("Attempted to change hashing algorithm from %s to %s", name, newName)
+		}
+	}
+
 	if oc, ok := b.OrdererConfig(); ok {
 		noc, ok := nb.OrdererConfig()
 		if !ok {
 			return errors.New("Current config has orderer section, but new config does not")
 		}
 
+		// The consensus type may only change while the channel is in maintenance, which requires
+		// the consensus type migration capability, and the channel must stay in maintenance until
+		// the orderers are running the new consensus type
 		if oc.ConsensusType() != noc.ConsensusType() {
-			return errors.Errorf("Attempted to change consensus type from %s to %s", oc.ConsensusType(), noc.ConsensusType())
+			if oc.ConsensusState() != ab.ConsensusType_STATE_MAINTENANCE || noc.ConsensusState() != ab.ConsensusType_STATE_MAINTENANCE {
+				return errors.Errorf("Attempted to change consensus type from %s to %s outside of maintenance", oc.ConsensusType(), noc.ConsensusType())
+			}
 		}
 
 		for orgName, org := range oc.Organizations() {
@@ -197,7 +210,11 @@ func NewBundle(channelID string, config *cb.Config) (*Bundle, error) {
 		}
 	}
 
-	policyManager, err := policies.NewManagerImpl(RootGroupKey, policyProviderMap, config.ChannelGroup)
+	newPolicyManager := policies.NewManagerImpl
+	if channelConfig.Capabilities().DefinedSubPolicies() {
+		newPolicyManager = policies.NewDefinedSubPoliciesManagerImpl
+	}
+	policyManager, err := newPolicyManager(RootGroupKey, policyProviderMap, config.ChannelGroup)
 	if err != nil {
 		return nil, errors.Wrap(err, "initializing policymanager failed")
 	}
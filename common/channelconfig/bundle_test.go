@@ -9,6 +9,7 @@ package channelconfig
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/capabilities"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 
@@ -16,6 +17,37 @@ import (
 )
 
 func TestValidateNew(t *testing.T) {
+	t.Run("ChangedHashingAlgorithm", func(t *testing.T) {
+		sha256 := &cb.HashingAlgorithm{Name: "SHA256"}
+		sha3 := &cb.HashingAlgorithm{Name: "SHA3_256"}
+		v14 := &cb.Capabilities{Capabilities: map[string]*cb.Capability{capabilities.ChannelV1_4: {}}}
+
+		cb := &Bundle{
+			channelConfig: &ChannelConfig{
+				protos: &ChannelProtos{HashingAlgorithm: sha256},
+			},
+		}
+
+		nb := &Bundle{
+			channelConfig: &ChannelConfig{
+				protos: &ChannelProtos{HashingAlgorithm: sha3, Capabilities: v14},
+			},
+		}
+
+		err := cb.ValidateNew(nb)
+		assert.EqualError(t, err, "Attempted to change hashing algorithm from SHA256 to SHA3_256")
+
+		// without the V1_4 capability, the channel is hashed with SHA256 either way
+		nb.channelConfig.protos.Capabilities = nil
+		assert.NoError(t, cb.ValidateNew(nb))
+
+		// so that a channel may not enable SHA3_256 by adding the capability
+		cb.channelConfig.protos.HashingAlgorithm = sha3
+		nb.channelConfig.protos.Capabilities = v14
+		err = cb.ValidateNew(nb)
+		assert.EqualError(t, err, "Attempted to change hashing algorithm from SHA256 to SHA3_256")
+	})
+
 	t.Run("DisappearingOrdererConfig", func(t *testing.T) {
 		cb := &Bundle{
 			channelConfig: &ChannelConfig{
@@ -92,6 +124,13 @@ func TestValidateNew(t *testing.T) {
 		err := cb.ValidateNew(nb)
 		assert.Error(t, err)
 		assert.Regexp(t, "Attempted to change consensus type from", err.Error())
+
+		cb.channelConfig.ordererConfig.protos.ConsensusType.State = ab.ConsensusType_STATE_MAINTENANCE
+		err = cb.ValidateNew(nb)
+		assert.EqualError(t, err, "Attempted to change consensus type from type1 to type2 outside of maintenance")
+
+		nb.channelConfig.ordererConfig.protos.ConsensusType.State = ab.ConsensusType_STATE_MAINTENANCE
+		assert.NoError(t, cb.ValidateNew(nb))
 	})
 
 	t.Run("OrdererOrgMSPIDChange", func(t *testing.T) {
@@ -63,7 +63,7 @@ func (bs *BundleSource) PolicyManager() policies.Manager {
 
 // MSPManager returns the MSP manager constructed for this config
 func (bs *BundleSource) MSPManager() msp.MSPManager {
-	return bs.StableBundle().mspManager
+	return bs.StableBundle().MSPManager()
 }
 
 // ChannelConfig returns the config.Channel for the chain
@@ -92,7 +92,7 @@ func NewChannelConfig(channelGroup *cb.ConfigGroup) (*ChannelConfig, error) {
 	}
 
 	capabilities := cc.Capabilities()
-	mspConfigHandler := NewMSPConfigHandler(capabilities.MSPVersion())
+	mspConfigHandler := NewMSPConfigHandler(capabilities.MSPVersion(), capabilities.ExpiredCertTolerance())
 
 	var err error
 	for groupName, group := range channelGroup.Groups {
@@ -143,6 +143,19 @@ func (cc *ChannelConfig) HashingAlgorithm() func(input []byte) []byte {
 	return cc.hashingAlgorithm
 }
 
+// hashingAlgorithmName returns the name of the algorithm the blocks and
+// transaction ids of the channel are hashed with, which is SHA256 unless the
+// channel has the V1_4 capability.
+func (cc *ChannelConfig) hashingAlgorithmName() string {
+	if cc.protos == nil || cc.protos.HashingAlgorithm == nil {
+		return ""
+	}
+	if cc.protos.Capabilities == nil || !cc.Capabilities().ConfigurableHashing() {
+		return bccsp.SHA256
+	}
+	return cc.protos.HashingAlgorithm.Name
+}
+
 // BlockDataHashingStructure returns the width to use when forming the block data hashing structure
 func (cc *ChannelConfig) BlockDataHashingStructureWidth() uint32 {
 	return cc.protos.BlockDataHashingStructure.Width
@@ -183,6 +196,12 @@ func (cc *ChannelConfig) validateHashingAlgorithm() error {
 	case bccsp.SHA256:
 		cc.hashingAlgorithm = util.ComputeSHA256
 	case bccsp.SHA3_256:
+		// Earlier releases hashed the blocks and transaction ids with SHA256
+		// regardless of the hashing algorithm of the channel
+		if !cc.Capabilities().ConfigurableHashing() {
+			cc.hashingAlgorithm = util.ComputeSHA256
+			break
+		}
 		cc.hashingAlgorithm = util.ComputeSHA3256
 	default:
 		return fmt.Errorf("Unknown hashing algorithm type: %s", cc.protos.HashingAlgorithm.Name)
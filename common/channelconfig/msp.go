@@ -23,14 +23,20 @@ type pendingMSPConfig struct {
 
 // MSPConfigHandler
 type MSPConfigHandler struct {
-	version msp.MSPVersion
-	idMap   map[string]*pendingMSPConfig
+	version              msp.MSPVersion
+	expiredCertTolerance bool
+	idMap                map[string]*pendingMSPConfig
 }
 
-func NewMSPConfigHandler(mspVersion msp.MSPVersion) *MSPConfigHandler {
+// NewMSPConfigHandler creates a handler for the MSPs of a channel of the given
+// MSP version. If expiredCertTolerance is set, the TLS CA certs of the
+// X.509 MSPs are validated at a time they were valid, rather than at the
+// current time.
+func NewMSPConfigHandler(mspVersion msp.MSPVersion, expiredCertTolerance bool) *MSPConfigHandler {
 	return &MSPConfigHandler{
-		version: mspVersion,
-		idMap:   make(map[string]*pendingMSPConfig),
+		version:              mspVersion,
+		expiredCertTolerance: expiredCertTolerance,
+		idMap:                make(map[string]*pendingMSPConfig),
 	}
 }
 
@@ -42,7 +48,10 @@ func (bh *MSPConfigHandler) ProposeMSP(mspConfig *mspprotos.MSPConfig) (msp.MSP,
 	switch mspConfig.Type {
 	case int32(msp.FABRIC):
 		// create the bccsp msp instance
-		mspInst, err := msp.New(&msp.BCCSPNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: bh.version}})
+		mspInst, err := msp.New(&msp.BCCSPNewOpts{
+			NewBaseOpts:          msp.NewBaseOpts{Version: bh.version},
+			ExpiredCertTolerance: bh.expiredCertTolerance,
+		})
 		if err != nil {
 			return nil, errors.WithMessage(err, "creating the MSP manager failed")
 		}
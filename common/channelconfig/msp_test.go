@@ -26,7 +26,7 @@ func TestMSPConfigManager(t *testing.T) {
 	mspVers := []msp.MSPVersion{msp.MSPv1_0, msp.MSPv1_1}
 
 	for _, ver := range mspVers {
-		mspCH := NewMSPConfigHandler(ver)
+		mspCH := NewMSPConfigHandler(ver, false)
 
 		_, err = mspCH.ProposeMSP(conf)
 		assert.NoError(t, err)
@@ -49,7 +49,7 @@ func TestMSPConfigManager(t *testing.T) {
 }
 
 func TestMSPConfigFailure(t *testing.T) {
-	mspCH := NewMSPConfigHandler(msp.MSPv1_0)
+	mspCH := NewMSPConfigHandler(msp.MSPv1_0, false)
 
 	// begin/propose/commit
 	t.Run("Bad proto", func(t *testing.T) {
@@ -94,6 +94,12 @@ func (oc *OrdererConfig) ConsensusMetadata() []byte {
 	return oc.protos.ConsensusType.Metadata
 }
 
+// ConsensusState returns the state of the consensus type, a channel in
+// maintenance only accepts config transactions
+func (oc *OrdererConfig) ConsensusState() ab.ConsensusType_State {
+	return oc.protos.ConsensusType.State
+}
+
 // BatchSize returns the maximum number of messages to include in a block
 func (oc *OrdererConfig) BatchSize() *ab.BatchSize {
 	return oc.protos.BatchSize
@@ -128,6 +134,7 @@ func (oc *OrdererConfig) Capabilities() OrdererCapabilities {
 
 func (oc *OrdererConfig) Validate() error {
 	for _, validator := range []func() error{
+		oc.validateConsensusState,
 		oc.validateBatchSize,
 		oc.validateBatchTimeout,
 		oc.validateKafkaBrokers,
@@ -140,6 +147,13 @@ func (oc *OrdererConfig) Validate() error {
 	return nil
 }
 
+func (oc *OrdererConfig) validateConsensusState() error {
+	if oc.protos.ConsensusType.State != ab.ConsensusType_STATE_NORMAL && !capabilities.NewOrdererProvider(oc.protos.Capabilities.GetCapabilities()).ConsensusTypeMigration() {
+		return fmt.Errorf("Attempted to set the consensus type state to %s without the %s orderer capability", oc.protos.ConsensusType.State, capabilities.OrdererConsensusTypeMigration)
+	}
+	return nil
+}
+
 func (oc *OrdererConfig) validateBatchSize() error {
 	if oc.protos.BatchSize.MaxMessageCount == 0 {
 		return fmt.Errorf("Attempted to set the batch size max message count to an invalid value: 0")
@@ -153,6 +167,9 @@ func (oc *OrdererConfig) validateBatchSize() error {
 	if oc.protos.BatchSize.PreferredMaxBytes > oc.protos.BatchSize.AbsoluteMaxBytes {
 		return fmt.Errorf("Attempted to set the batch size preferred max bytes (%v) greater than the absolute max bytes (%v).", oc.protos.BatchSize.PreferredMaxBytes, oc.protos.BatchSize.AbsoluteMaxBytes)
 	}
+	if oc.protos.BatchSize.PriorityReorderingLimit != 0 && !capabilities.NewOrdererProvider(oc.protos.Capabilities.GetCapabilities()).TxPriority() {
+		return fmt.Errorf("Attempted to set the batch size priority reordering limit without the %s orderer capability", capabilities.OrdererTxPriority)
+	}
 	return nil
 }
 
@@ -9,6 +9,8 @@ package channelconfig
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/capabilities"
+	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 
 	"github.com/stretchr/testify/assert"
@@ -30,6 +32,25 @@ func TestBatchSize(t *testing.T) {
 
 	oc = &OrdererConfig{protos: &OrdererProtos{BatchSize: &ab.BatchSize{MaxMessageCount: validMaxMessageCount, AbsoluteMaxBytes: validAbsoluteMaxBytes, PreferredMaxBytes: validAbsoluteMaxBytes + 1}}}
 	assert.Error(t, oc.validateBatchSize(), "PreferredMaxBytes larger to AbsoluteMaxBytes")
+
+	oc = &OrdererConfig{protos: &OrdererProtos{BatchSize: &ab.BatchSize{MaxMessageCount: validMaxMessageCount, AbsoluteMaxBytes: validAbsoluteMaxBytes, PreferredMaxBytes: validPreferredMaxBytes, PriorityReorderingLimit: 5}}}
+	assert.Error(t, oc.validateBatchSize(), "PriorityReorderingLimit set without the capability")
+
+	oc.protos.Capabilities = &cb.Capabilities{Capabilities: map[string]*cb.Capability{capabilities.OrdererTxPriority: {}}}
+	assert.NoError(t, oc.validateBatchSize(), "PriorityReorderingLimit set with the capability")
+}
+
+func TestConsensusState(t *testing.T) {
+	oc := &OrdererConfig{protos: &OrdererProtos{ConsensusType: &ab.ConsensusType{Type: "kafka"}}}
+	assert.NoError(t, oc.validateConsensusState())
+	assert.Equal(t, ab.ConsensusType_STATE_NORMAL, oc.ConsensusState())
+
+	oc.protos.ConsensusType.State = ab.ConsensusType_STATE_MAINTENANCE
+	assert.EqualError(t, oc.validateConsensusState(), "Attempted to set the consensus type state to STATE_MAINTENANCE without the V1_4_CONSENSUS_TYPE_MIGRATION orderer capability")
+
+	oc.protos.Capabilities = &cb.Capabilities{Capabilities: map[string]*cb.Capability{capabilities.OrdererConsensusTypeMigration: {}}}
+	assert.NoError(t, oc.validateConsensusState())
+	assert.Equal(t, ab.ConsensusType_STATE_MAINTENANCE, oc.ConsensusState())
 }
 
 func TestBatchTimeout(t *testing.T) {
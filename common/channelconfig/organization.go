@@ -8,6 +8,8 @@ package channelconfig
 
 import (
 	"fmt"
+	"net"
+	"strconv"
 
 	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -19,11 +21,15 @@ import (
 const (
 	// MSPKey is the key for the MSP definition in orderer groups
 	MSPKey = "MSP"
+
+	// PeerEndpointsKey is the key for the external endpoints of the peers of an organization
+	PeerEndpointsKey = "PeerEndpoints"
 )
 
 // OrganizationProtos are used to deserialize the organization config
 type OrganizationProtos struct {
-	MSP *mspprotos.MSPConfig
+	MSP           *mspprotos.MSPConfig
+	PeerEndpoints *cb.PeerEndpoints
 }
 
 // OrganizationConfig stores the configuration for an organization
@@ -69,11 +75,32 @@ func (oc *OrganizationConfig) MSPID() string {
 	return oc.mspID
 }
 
+// PeerEndpoints returns the host:port external endpoints of the peers of this org
+func (oc *OrganizationConfig) PeerEndpoints() []string {
+	return oc.protos.PeerEndpoints.Addresses
+}
+
 // Validate returns whether the configuration is valid
 func (oc *OrganizationConfig) Validate() error {
+	if err := oc.validatePeerEndpoints(); err != nil {
+		return err
+	}
 	return oc.validateMSP()
 }
 
+func (oc *OrganizationConfig) validatePeerEndpoints() error {
+	for _, address := range oc.protos.PeerEndpoints.Addresses {
+		_, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return errors.Wrapf(err, "invalid peer endpoint %s for org %s", address, oc.name)
+		}
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return errors.Errorf("invalid port %s of peer endpoint %s for org %s", port, address, oc.name)
+		}
+	}
+	return nil
+}
+
 func (oc *OrganizationConfig) validateMSP() error {
 	var err error
 
@@ -8,8 +8,28 @@ package channelconfig
 
 import (
 	"testing"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestOrganization(t *testing.T) {
 	_ = Org(&OrganizationConfig{})
 }
+
+func TestOrganizationPeerEndpoints(t *testing.T) {
+	oc := &OrganizationConfig{
+		name: "org1",
+		protos: &OrganizationProtos{
+			PeerEndpoints: &cb.PeerEndpoints{Addresses: []string{"peer0.org1:7051", "[::1]:8051"}},
+		},
+	}
+	assert.NoError(t, oc.validatePeerEndpoints())
+	assert.Equal(t, []string{"peer0.org1:7051", "[::1]:8051"}, oc.PeerEndpoints())
+
+	oc.protos.PeerEndpoints.Addresses = []string{"peer0.org1"}
+	assert.EqualError(t, oc.validatePeerEndpoints(), "invalid peer endpoint peer0.org1 for org org1: address peer0.org1: missing port in address")
+
+	oc.protos.PeerEndpoints.Addresses = []string{"peer0.org1:70510"}
+	assert.EqualError(t, oc.validatePeerEndpoints(), "invalid port 70510 of peer endpoint peer0.org1:70510 for org org1")
+}
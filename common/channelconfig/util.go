@@ -8,6 +8,7 @@ package channelconfig
 
 import (
 	"math"
+	"time"
 
 	"github.com/hyperledger/fabric/bccsp"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -69,13 +70,20 @@ func ConsortiumValue(name string) *StandardConfigValue {
 	}
 }
 
-// HashingAlgorithm returns the only currently valid hashing algorithm.
+// HashingAlgorithm returns the default hashing algorithm, SHA256.
 // It is a value for the /Channel group.
 func HashingAlgorithmValue() *StandardConfigValue {
+	return HashingAlgorithmValueWithName(defaultHashingAlgorithm)
+}
+
+// HashingAlgorithmValueWithName returns the named hashing algorithm.  Any
+// algorithm other than SHA256 only takes effect with the V1_4 channel capability.
+// It is a value for the /Channel group.
+func HashingAlgorithmValueWithName(name string) *StandardConfigValue {
 	return &StandardConfigValue{
 		key: HashingAlgorithmKey,
 		value: &cb.HashingAlgorithm{
-			Name: defaultHashingAlgorithm,
+			Name: name,
 		},
 	}
 }
@@ -116,13 +124,14 @@ func ConsensusTypeValue(consensusType string, consensusMetadata []byte) *Standar
 
 // BatchSizeValue returns the config definition for the orderer batch size.
 // It is a value for the /Channel/Orderer group.
-func BatchSizeValue(maxMessages, absoluteMaxBytes, preferredMaxBytes uint32) *StandardConfigValue {
+func BatchSizeValue(maxMessages, absoluteMaxBytes, preferredMaxBytes, priorityReorderingLimit uint32) *StandardConfigValue {
 	return &StandardConfigValue{
 		key: BatchSizeKey,
 		value: &ab.BatchSize{
-			MaxMessageCount:   maxMessages,
-			AbsoluteMaxBytes:  absoluteMaxBytes,
-			PreferredMaxBytes: preferredMaxBytes,
+			MaxMessageCount:         maxMessages,
+			AbsoluteMaxBytes:        absoluteMaxBytes,
+			PreferredMaxBytes:       preferredMaxBytes,
+			PriorityReorderingLimit: priorityReorderingLimit,
 		},
 	}
 }
@@ -198,6 +207,15 @@ func AnchorPeersValue(anchorPeers []*pb.AnchorPeer) *StandardConfigValue {
 	}
 }
 
+// PeerEndpointsValue returns the config definition for the external endpoints of an org's peers.
+// It is a value for the /Channel/Consortiums/*/* and the /Channel/Application/*.
+func PeerEndpointsValue(addresses []string) *StandardConfigValue {
+	return &StandardConfigValue{
+		key:   PeerEndpointsKey,
+		value: &cb.PeerEndpoints{Addresses: addresses},
+	}
+}
+
 // ChannelCreationPolicyValue returns the config definition for a consortium's channel creation policy
 // It is a value for the /Channel/Consortiums/*/*.
 func ChannelCreationPolicyValue(policy *cb.Policy) *StandardConfigValue {
@@ -207,6 +225,35 @@ func ChannelCreationPolicyValue(policy *cb.Policy) *StandardConfigValue {
 	}
 }
 
+// ChannelStateValue returns the config definition for the state of an application channel.
+// It is a value for the /Channel/Application/.
+func ChannelStateValue(state pb.ChannelState_State) *StandardConfigValue {
+	return &StandardConfigValue{
+		key:   ChannelStateKey,
+		value: &pb.ChannelState{State: state},
+	}
+}
+
+// TxValidityWindowValue returns the config definition for the enforcement of the validity windows
+// of the transactions of an application channel.
+// It is a value for the /Channel/Application/.
+func TxValidityWindowValue(maxClockSkew time.Duration) *StandardConfigValue {
+	return &StandardConfigValue{
+		key:   TxValidityWindowKey,
+		value: &pb.TxValidityWindow{MaxClockSkew: maxClockSkew.String()},
+	}
+}
+
+// RangeQueryValidationValue returns the config definition for the validation of the range queries
+// of the transactions of an application channel.
+// It is a value for the /Channel/Application/.
+func RangeQueryValidationValue(maxResults uint32) *StandardConfigValue {
+	return &StandardConfigValue{
+		key:   RangeQueryValidationKey,
+		value: &pb.RangeQueryValidation{MaxResults: maxResults},
+	}
+}
+
 // ACLsValues returns the config definition for an applications resources based ACL definitions.
 // It is a value for the /Channel/Application/.
 func ACLValues(acls map[string]string) *StandardConfigValue {
@@ -31,10 +31,11 @@ func basicTest(t *testing.T, sv *StandardConfigValue) {
 func TestUtilsBasic(t *testing.T) {
 	basicTest(t, ConsortiumValue("foo"))
 	basicTest(t, HashingAlgorithmValue())
+	basicTest(t, HashingAlgorithmValueWithName("SHA3_256"))
 	basicTest(t, BlockDataHashingStructureValue())
 	basicTest(t, OrdererAddressesValue([]string{"foo:1", "bar:2"}))
 	basicTest(t, ConsensusTypeValue("foo", []byte("bar")))
-	basicTest(t, BatchSizeValue(1, 2, 3))
+	basicTest(t, BatchSizeValue(1, 2, 3, 4))
 	basicTest(t, BatchTimeoutValue("1s"))
 	basicTest(t, ChannelRestrictionsValue(7))
 	basicTest(t, KafkaBrokersValue([]string{"foo:1", "bar:2"}))
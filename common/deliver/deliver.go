@@ -10,6 +10,7 @@ import (
 	"context"
 	"io"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -89,6 +90,10 @@ type Handler struct {
 	ChainManager     ChainManager
 	TimeWindow       time.Duration
 	BindingInspector Inspector
+
+	initOnce  sync.Once
+	drainOnce sync.Once
+	draining  chan struct{}
 }
 
 //go:generate counterfeiter -o mock/receiver.go -fake-name Receiver . Receiver
@@ -107,12 +112,30 @@ type ResponseSender interface {
 	SendBlockResponse(block *cb.Block) error
 }
 
+// StartResolver resolves the position the blocks are delivered from, given
+// the start position of the seek request being served.
+type StartResolver interface {
+	ResolveStart(channelID string, start *ab.SeekPosition) (*ab.SeekPosition, error)
+}
+
+// The StartResolverFunc is an adapter that allows the use of an ordinary
+// function as a StartResolver.
+type StartResolverFunc func(channelID string, start *ab.SeekPosition) (*ab.SeekPosition, error)
+
+// ResolveStart calls srf(channelID, start)
+func (srf StartResolverFunc) ResolveStart(channelID string, start *ab.SeekPosition) (*ab.SeekPosition, error) {
+	return srf(channelID, start)
+}
+
 // Server is a polymorphic structure to support generalization of this handler
 // to be able to deliver different type of responses.
 type Server struct {
 	Receiver
 	PolicyChecker
 	ResponseSender
+	// StartResolver is optional; when set, the blocks are delivered from
+	// the position it resolves instead of the start of the seek info.
+	StartResolver StartResolver
 }
 
 // ExtractChannelHeaderCertHash extracts the TLS cert hash from a channel header.
@@ -133,6 +156,22 @@ func NewHandler(cm ChainManager, timeWindow time.Duration, mutualTLS bool) *Hand
 	}
 }
 
+// Drain ends the deliver requests being served, and the ones received from
+// now on, with a SERVICE_UNAVAILABLE status so that clients retry against
+// another node. It is used to shut down gracefully.
+func (h *Handler) Drain() {
+	h.drainOnce.Do(func() {
+		close(h.drainSignal())
+	})
+}
+
+func (h *Handler) drainSignal() chan struct{} {
+	h.initOnce.Do(func() {
+		h.draining = make(chan struct{})
+	})
+	return h.draining
+}
+
 // Handle receives incoming deliver requests.
 func (h *Handler) Handle(ctx context.Context, srv *Server) error {
 	addr := util.ExtractRemoteAddress(ctx)
@@ -154,6 +193,13 @@ func (h *Handler) Handle(ctx context.Context, srv *Server) error {
 			return err
 		}
 
+		select {
+		case <-h.drainSignal():
+			logger.Debugf("Closing deliver stream of %s because the server is shutting down", addr)
+			return nil
+		default:
+		}
+
 		logger.Debugf("Waiting for new SeekInfo from %s", addr)
 	}
 }
@@ -177,7 +223,7 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 		return srv.SendStatusResponse(cb.Status_BAD_REQUEST)
 	}
 
-	err = h.validateChannelHeader(ctx, chdr)
+	err = h.ValidateChannelHeader(ctx, chdr)
 	if err != nil {
 		logger.Warningf("Rejecting deliver for %s due to envelope validation error: %s", addr, err)
 		return srv.SendStatusResponse(cb.Status_BAD_REQUEST)
@@ -192,10 +238,14 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 	}
 
 	erroredChan := chain.Errored()
+	drainChan := h.drainSignal()
 	select {
 	case <-erroredChan:
 		logger.Warningf("[channel: %s] Rejecting deliver request for %s because of consenter error", chdr.ChannelId, addr)
 		return srv.SendStatusResponse(cb.Status_SERVICE_UNAVAILABLE)
+	case <-drainChan:
+		logger.Infof("[channel: %s] Rejecting deliver request for %s because the server is shutting down", chdr.ChannelId, addr)
+		return srv.SendStatusResponse(cb.Status_SERVICE_UNAVAILABLE)
 	default:
 
 	}
@@ -224,7 +274,16 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 
 	logger.Debugf("[channel: %s] Received seekInfo (%p) %v from %s", chdr.ChannelId, seekInfo, seekInfo, addr)
 
-	cursor, number := chain.Reader().Iterator(seekInfo.Start)
+	start := seekInfo.Start
+	if srv.StartResolver != nil {
+		start, err = srv.StartResolver.ResolveStart(chdr.ChannelId, seekInfo.Start)
+		if err != nil {
+			logger.Errorf("[channel: %s] Failed to resolve the start position of the deliver request from %s: %s", chdr.ChannelId, addr, err)
+			return srv.SendStatusResponse(cb.Status_INTERNAL_SERVER_ERROR)
+		}
+	}
+
+	cursor, number := chain.Reader().Iterator(start)
 	defer cursor.Close()
 	var stopNum uint64
 	switch stop := seekInfo.Stop.Type.(type) {
@@ -263,6 +322,9 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 		case <-erroredChan:
 			logger.Warningf("Aborting deliver for request because of background error")
 			return srv.SendStatusResponse(cb.Status_SERVICE_UNAVAILABLE)
+		case <-drainChan:
+			logger.Infof("[channel: %s] Aborting deliver for %s because the server is shutting down", chdr.ChannelId, addr)
+			return srv.SendStatusResponse(cb.Status_SERVICE_UNAVAILABLE)
 		case <-iterCh:
 			// Iterator has set the block and status vars
 		}
@@ -302,7 +364,10 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 	return nil
 }
 
-func (h *Handler) validateChannelHeader(ctx context.Context, chdr *cb.ChannelHeader) error {
+// ValidateChannelHeader checks that the timestamp of the channel header is
+// within the time window of the handler, and that the header is bound to
+// the context.
+func (h *Handler) ValidateChannelHeader(ctx context.Context, chdr *cb.ChannelHeader) error {
 	if chdr.GetTimestamp() == nil {
 		err := errors.New("channel header in envelope must contain timestamp")
 		return err
@@ -244,6 +244,50 @@ var _ = Describe("Deliver", func() {
 			Expect(proto.Equal(startPosition, seekInfo.Start)).To(BeTrue())
 		})
 
+		Context("when a start resolver is set", func() {
+			var resolvedStart *ab.SeekPosition
+
+			BeforeEach(func() {
+				resolvedStart = &ab.SeekPosition{
+					Type: &ab.SeekPosition_Specified{
+						Specified: &ab.SeekSpecified{Number: 99},
+					},
+				}
+				server.StartResolver = deliver.StartResolverFunc(func(channelID string, start *ab.SeekPosition) (*ab.SeekPosition, error) {
+					Expect(channelID).To(Equal("chain-id"))
+					Expect(proto.Equal(start, seekInfo.Start)).To(BeTrue())
+					return resolvedStart, nil
+				})
+			})
+
+			It("gets a block iterator from the resolved position", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBlockReader.IteratorCallCount()).To(Equal(1))
+				startPosition := fakeBlockReader.IteratorArgsForCall(0)
+				Expect(proto.Equal(startPosition, resolvedStart)).To(BeTrue())
+			})
+
+			Context("when resolving the start fails", func() {
+				BeforeEach(func() {
+					server.StartResolver = deliver.StartResolverFunc(func(string, *ab.SeekPosition) (*ab.SeekPosition, error) {
+						return nil, errors.New("cursor-store-failure")
+					})
+				})
+
+				It("sends status internal server error", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBlockReader.IteratorCallCount()).To(Equal(0))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+					resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+					Expect(resp).To(Equal(cb.Status_INTERNAL_SERVER_ERROR))
+				})
+			})
+		})
+
 		Context("when multiple blocks are requested", func() {
 			BeforeEach(func() {
 				fakeBlockIterator.NextStub = func() (*cb.Block, cb.Status) {
@@ -528,6 +572,54 @@ var _ = Describe("Deliver", func() {
 			})
 		})
 
+		Context("when the handler is drained before reading from the chain", func() {
+			BeforeEach(func() {
+				handler.Drain()
+			})
+
+			It("sends status service unavailable and closes the stream", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeChain.ReaderCallCount()).To(Equal(0))
+				Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+				resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+				Expect(resp).To(Equal(cb.Status_SERVICE_UNAVAILABLE))
+				Expect(fakeReceiver.RecvCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the handler is drained while waiting for a block", func() {
+			var done chan struct{}
+
+			BeforeEach(func() {
+				done = make(chan struct{})
+				fakeChain.ReaderStub = func() blockledger.Reader {
+					handler.Drain()
+					return fakeBlockReader
+				}
+				fakeBlockIterator.NextStub = func() (*cb.Block, cb.Status) {
+					<-done
+					return nil, cb.Status_BAD_REQUEST
+				}
+			})
+
+			AfterEach(func() {
+				close(done)
+			})
+
+			It("sends status service unavailable and closes the stream", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+				Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+				resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+				Expect(resp).To(Equal(cb.Status_SERVICE_UNAVAILABLE))
+				Expect(fakeReceiver.RecvCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("when the access evaluation fails", func() {
 			BeforeEach(func() {
 				fakePolicyChecker.CheckPolicyReturns(errors.New("no-access-for-you"))
@@ -0,0 +1,165 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metadata"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("common/diagnostics")
+
+// responsePrefix is prepended to the ping response payload before it is
+// signed, so that the signature of a node over a ping response cannot be
+// passed off as its signature over any other message.
+const responsePrefix = "hyperledger-fabric-diagnostics-ping-response:"
+
+// Deserializers returns the identity deserializers of the MSPs whose members
+// may ping the node: its local MSP and the MSPs of its channels.
+type Deserializers func() []msp.IdentityDeserializer
+
+// Server implements the Diagnostics service of peers and orderers
+type Server struct {
+	signer        crypto.LocalSigner
+	deserializers Deserializers
+	timeWindow    time.Duration
+}
+
+// NewServer creates a Diagnostics service which signs its responses with the
+// given signer, which only answers requests signed by a valid identity of one
+// of the given MSPs, and which rejects requests whose timestamp is off by more
+// than the time window from the local time.
+func NewServer(signer crypto.LocalSigner, deserializers Deserializers, timeWindow time.Duration) *Server {
+	return &Server{
+		signer:        signer,
+		deserializers: deserializers,
+		timeWindow:    timeWindow,
+	}
+}
+
+// Ping validates that the request is signed by a valid identity of the local
+// MSP or of the MSP of a channel of the node, and returns a response signed by
+// the node. Members of organizations which do not share a channel with the
+// node yet can ping it once it knows their MSP through its system channel.
+func (s *Server) Ping(ctx context.Context, env *cb.Envelope) (*cb.PingResponse, error) {
+	addr := util.ExtractRemoteAddress(ctx)
+	req, err := s.validate(env)
+	if err != nil {
+		logger.Warningf("Ping from %s rejected: %s", addr, err)
+		return nil, err
+	}
+	logger.Debugf("Ping from %s", addr)
+
+	sh, err := s.signer.NewSignatureHeader()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed creating signature header")
+	}
+	payload, err := proto.Marshal(&cb.PingResponsePayload{
+		Nonce:     req.Nonce,
+		Identity:  sh.Creator,
+		Timestamp: ptypes.TimestampNow(),
+		Version:   metadata.Version,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling ping response")
+	}
+	sig, err := s.signer.Sign(signedResponse(payload))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed signing ping response")
+	}
+	return &cb.PingResponse{Payload: payload, Signature: sig}, nil
+}
+
+func (s *Server) validate(env *cb.Envelope) (*cb.PingRequest, error) {
+	if env == nil {
+		return nil, errors.New("nil envelope")
+	}
+	req := &cb.PingRequest{}
+	chdr, err := utils.UnmarshalEnvelopeOfType(env, cb.HeaderType_MESSAGE, req)
+	if err != nil {
+		return nil, errors.WithMessage(err, "bad request")
+	}
+	if chdr.Timestamp == nil {
+		return nil, errors.New("empty timestamp")
+	}
+	reqTs := time.Unix(chdr.Timestamp.Seconds, int64(chdr.Timestamp.Nanos))
+	now := time.Now()
+	if reqTs.Add(s.timeWindow).Before(now) || reqTs.Add(-s.timeWindow).After(now) {
+		return nil, errors.Errorf("request timestamp %s is more than %s apart from the current time", reqTs, s.timeWindow)
+	}
+
+	sd, err := env.AsSignedData()
+	if err != nil {
+		return nil, errors.WithMessage(err, "bad request")
+	}
+	id, err := deserialize(s.deserializers(), sd[0].Identity)
+	if err != nil {
+		return nil, errors.WithMessage(err, "creator is not a member of the local MSP or of any channel MSP")
+	}
+	if err := id.Verify(sd[0].Data, sd[0].Signature); err != nil {
+		return nil, errors.Wrapf(err, "invalid signature of %s", id.GetMSPIdentifier())
+	}
+	return req, nil
+}
+
+// VerifyResponse verifies that the ping response is signed by a valid
+// identity of one of the given MSPs, and returns its payload and the MSP ID
+// of the responding node.
+func VerifyResponse(deserializers []msp.IdentityDeserializer, resp *cb.PingResponse) (*cb.PingResponsePayload, string, error) {
+	payload := &cb.PingResponsePayload{}
+	if err := proto.Unmarshal(resp.Payload, payload); err != nil {
+		return nil, "", errors.Wrap(err, "failed unmarshaling ping response")
+	}
+	id, err := deserialize(deserializers, payload.Identity)
+	if err != nil {
+		return payload, "", err
+	}
+	if err := id.Verify(signedResponse(resp.Payload), resp.Signature); err != nil {
+		return payload, "", errors.Wrapf(err, "invalid signature of %s", id.GetMSPIdentifier())
+	}
+	return payload, id.GetMSPIdentifier(), nil
+}
+
+// deserialize returns the identity from the first MSP which deserializes it,
+// once validated by that MSP.
+func deserialize(deserializers []msp.IdentityDeserializer, serializedID []byte) (msp.Identity, error) {
+	var deserializeErr, validateErr error
+	for _, d := range deserializers {
+		id, err := d.DeserializeIdentity(serializedID)
+		if err != nil {
+			deserializeErr = err
+			continue
+		}
+		if err := id.Validate(); err != nil {
+			validateErr = errors.Wrapf(err, "identity of %s is not valid", id.GetMSPIdentifier())
+			continue
+		}
+		return id, nil
+	}
+	if validateErr != nil {
+		return nil, validateErr
+	}
+	if deserializeErr != nil {
+		return nil, errors.WithMessage(deserializeErr, "failed deserializing identity")
+	}
+	return nil, errors.New("no MSP to validate the identity against")
+}
+
+func signedResponse(payload []byte) []byte {
+	return append([]byte(responsePrefix), payload...)
+}
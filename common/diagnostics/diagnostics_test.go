@@ -0,0 +1,161 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/metadata"
+	"github.com/hyperledger/fabric/msp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mspproto "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	if err := msptesttools.LoadDevMsp(); err != nil {
+		os.Exit(-1)
+	}
+
+	os.Exit(m.Run())
+}
+
+func localDeserializers() []msp.IdentityDeserializer {
+	return []msp.IdentityDeserializer{mspmgmt.GetLocalMSP()}
+}
+
+func TestPing(t *testing.T) {
+	signer := localmsp.NewSigner()
+	s := NewServer(signer, localDeserializers, time.Minute)
+
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_MESSAGE, "", signer, &cb.PingRequest{Nonce: []byte("nonce")}, 0, 0)
+	assert.NoError(t, err)
+	resp, err := s.Ping(context.Background(), env)
+	assert.NoError(t, err)
+
+	payload, mspID, err := VerifyResponse(localDeserializers(), resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "SampleOrg", mspID)
+	assert.Equal(t, []byte("nonce"), payload.Nonce)
+	assert.Equal(t, metadata.Version, payload.Version)
+	assert.NotNil(t, payload.Timestamp)
+
+	// The response is not signed over its bare payload, so that the node
+	// cannot be used to sign arbitrary messages
+	id, err := mspmgmt.GetLocalMSP().DeserializeIdentity(payload.Identity)
+	assert.NoError(t, err)
+	assert.Error(t, id.Verify(resp.Payload, resp.Signature))
+
+	payload.Version = "0.0.1"
+	tamperedPayload, err := proto.Marshal(payload)
+	assert.NoError(t, err)
+	tampered := &cb.PingResponse{Payload: tamperedPayload, Signature: resp.Signature}
+	_, _, err = VerifyResponse(localDeserializers(), tampered)
+	assert.Contains(t, err.Error(), "invalid signature of SampleOrg")
+
+	_, _, err = VerifyResponse(nil, resp)
+	assert.EqualError(t, err, "no MSP to validate the identity against")
+
+	_, _, err = VerifyResponse(localDeserializers(), &cb.PingResponse{Payload: []byte("garbage")})
+	assert.Contains(t, err.Error(), "failed unmarshaling ping response")
+}
+
+func TestPingBadRequests(t *testing.T) {
+	signer := localmsp.NewSigner()
+	s := NewServer(signer, localDeserializers, time.Minute)
+
+	_, err := s.Ping(context.Background(), nil)
+	assert.EqualError(t, err, "nil envelope")
+
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_PEER_ADMIN_OPERATION, "", signer, &cb.PingRequest{}, 0, 0)
+	assert.NoError(t, err)
+	_, err = s.Ping(context.Background(), env)
+	assert.Contains(t, err.Error(), "invalid type PEER_ADMIN_OPERATION, expected MESSAGE")
+
+	env, err = utils.CreateSignedEnvelope(cb.HeaderType_MESSAGE, "", signer, &cb.PingRequest{}, 0, 0)
+	assert.NoError(t, err)
+	env.Signature = []byte("forged")
+	_, err = s.Ping(context.Background(), env)
+	assert.Contains(t, err.Error(), "invalid signature of SampleOrg")
+
+	// A request from a node whose clock is off by more than the time window
+	env, err = utils.CreateSignedEnvelope(cb.HeaderType_MESSAGE, "", signer, &cb.PingRequest{}, 0, 0)
+	assert.NoError(t, err)
+	s = NewServer(signer, localDeserializers, 0)
+	time.Sleep(time.Millisecond)
+	_, err = s.Ping(context.Background(), env)
+	assert.Contains(t, err.Error(), "is more than 0s apart from the current time")
+}
+
+func TestPingUnknownCreator(t *testing.T) {
+	signer := localmsp.NewSigner()
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_MESSAGE, "", signer, &cb.PingRequest{}, 0, 0)
+	assert.NoError(t, err)
+
+	// A creator which none of the MSPs of the node knows
+	s := NewServer(signer, func() []msp.IdentityDeserializer {
+		return []msp.IdentityDeserializer{&mockDeserializer{err: errors.New("MSP Org1MSP is unknown")}}
+	}, time.Minute)
+	_, err = s.Ping(context.Background(), env)
+	assert.EqualError(t, err, "creator is not a member of the local MSP or of any channel MSP: failed deserializing identity: MSP Org1MSP is unknown")
+
+	// A creator whose certificate is not valid for its MSP, for instance
+	// because it was revoked
+	s = NewServer(signer, func() []msp.IdentityDeserializer {
+		return []msp.IdentityDeserializer{
+			&mockDeserializer{validateErr: errors.New("certificate revoked")},
+			&mockDeserializer{err: errors.New("MSP SampleOrg is unknown")},
+		}
+	}, time.Minute)
+	_, err = s.Ping(context.Background(), env)
+	assert.EqualError(t, err, "creator is not a member of the local MSP or of any channel MSP: identity of SampleOrg is not valid: certificate revoked")
+
+	// The channel MSPs are tried when the local MSP does not know the creator
+	s = NewServer(signer, func() []msp.IdentityDeserializer {
+		return []msp.IdentityDeserializer{&mockDeserializer{err: errors.New("MSP SampleOrg is unknown")}, mspmgmt.GetLocalMSP()}
+	}, time.Minute)
+	_, err = s.Ping(context.Background(), env)
+	assert.NoError(t, err)
+}
+
+type mockDeserializer struct {
+	err         error
+	validateErr error
+}
+
+func (d *mockDeserializer) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	id, err := mspmgmt.GetLocalMSP().DeserializeIdentity(serializedIdentity)
+	if err != nil {
+		return nil, err
+	}
+	return &invalidIdentity{Identity: id, err: d.validateErr}, nil
+}
+
+func (d *mockDeserializer) IsWellFormed(identity *mspproto.SerializedIdentity) error {
+	return nil
+}
+
+type invalidIdentity struct {
+	msp.Identity
+	err error
+}
+
+func (id *invalidIdentity) Validate() error {
+	return id.err
+}
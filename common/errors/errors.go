@@ -6,6 +6,8 @@ SPDX-License-Identifier: Apache-2.0
 
 package errors
 
+import "fmt"
+
 // TxValidationError marks that the error is related to
 // validation of a transaction
 type TxValidationError interface {
@@ -54,3 +56,16 @@ func (e VSCCExecutionFailureError) Error() string {
 func (e *VSCCExecutionFailureError) IsValid() bool {
 	return e.Err == nil
 }
+
+// UnsupportedCapabilitiesError error to indicate that the
+// config of a channel requires capabilities which are not
+// supported, so that the channel cannot be processed any further
+type UnsupportedCapabilitiesError struct {
+	ChainID string
+	Err     error
+}
+
+// Error returns reasons which lead to the failure
+func (e UnsupportedCapabilitiesError) Error() string {
+	return fmt.Sprintf("channel [%s] requires unsupported capabilities: %s", e.ChainID, e.Err)
+}
@@ -0,0 +1,144 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package errors
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// codesByReason maps the reasons of the errors to the codes of the gRPC statuses
+// carrying them
+var codesByReason = map[common.ErrorReason]codes.Code{
+	common.ErrorReason_MALFORMED_REQUEST:  codes.InvalidArgument,
+	common.ErrorReason_POLICY_FAILURE:     codes.PermissionDenied,
+	common.ErrorReason_BAD_CHANNEL:        codes.NotFound,
+	common.ErrorReason_CHAINCODE_ERROR:    codes.Aborted,
+	common.ErrorReason_LEDGER_UNAVAILABLE: codes.Unavailable,
+}
+
+// DetailedError is an error carrying an ErrorDetail. When it is returned by
+// a gRPC service, the detail is attached to the status of the call.
+type DetailedError struct {
+	Detail *common.ErrorDetail
+	Err    error
+}
+
+// Error returns the message of the underlying error
+func (e *DetailedError) Error() string {
+	return e.Err.Error()
+}
+
+// GRPCStatus returns the status of the gRPC call which failed with the error
+func (e *DetailedError) GRPCStatus() *status.Status {
+	return toStatus(e.Err.Error(), e.Detail)
+}
+
+// WithDetail attaches a detail to the error. An error which already carries
+// a detail is returned unchanged, the innermost detail being the most
+// accurate.
+func WithDetail(err error, detail *common.ErrorDetail) error {
+	if err == nil || DetailOf(err) != nil {
+		return err
+	}
+	return &DetailedError{Detail: detail, Err: err}
+}
+
+// WithReason attaches the reason of the error and the channel it relates to
+func WithReason(err error, reason common.ErrorReason, channelID string) error {
+	return WithDetail(err, &common.ErrorDetail{Reason: reason, ChannelId: channelID})
+}
+
+// DetailOf returns the detail of the error, which may be a DetailedError,
+// the status of a failed gRPC call, or wrap either of them. It returns nil
+// when the error has no detail.
+func DetailOf(err error) *common.ErrorDetail {
+	for err != nil {
+		if de, ok := err.(*DetailedError); ok {
+			return de.Detail
+		}
+		if se, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+			for _, detail := range se.GRPCStatus().Details() {
+				if detail, ok := detail.(*common.ErrorDetail); ok {
+					return detail
+				}
+			}
+			return nil
+		}
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			return nil
+		}
+		err = cause.Cause()
+	}
+	return nil
+}
+
+// ReasonOf returns the reason of the error, or UNKNOWN_REASON when the error
+// has no detail
+func ReasonOf(err error) common.ErrorReason {
+	if detail := DetailOf(err); detail != nil {
+		return detail.Reason
+	}
+	return common.ErrorReason_UNKNOWN_REASON
+}
+
+// ReasonOfStatus returns the reason matching the status of the responses of
+// the Broadcast and Deliver services
+func ReasonOfStatus(s common.Status) common.ErrorReason {
+	switch s {
+	case common.Status_BAD_REQUEST, common.Status_REQUEST_ENTITY_TOO_LARGE:
+		return common.ErrorReason_MALFORMED_REQUEST
+	case common.Status_FORBIDDEN:
+		return common.ErrorReason_POLICY_FAILURE
+	case common.Status_NOT_FOUND:
+		return common.ErrorReason_BAD_CHANNEL
+	default:
+		return common.ErrorReason_UNKNOWN_REASON
+	}
+}
+
+// ToGRPCError returns the error to be returned by a gRPC service, whose
+// status carries the detail of the error if it has one. The message of the
+// status is the message of the error, including the context added by the
+// errors wrapping the detailed one.
+func ToGRPCError(err error) error {
+	detail := DetailOf(err)
+	if detail == nil {
+		return err
+	}
+	return toStatus(err.Error(), detail).Err()
+}
+
+func toStatus(message string, detail *common.ErrorDetail) *status.Status {
+	code, exists := codesByReason[detail.Reason]
+	if !exists {
+		code = codes.Unknown
+	}
+	s := status.New(code, message)
+	if withDetail, err := s.WithDetails(detail); err == nil {
+		return withDetail
+	}
+	return s
+}
+
+// UnaryServerInterceptor converts the errors returned by unary gRPC services
+// into statuses carrying their detail
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	return resp, ToGRPCError(err)
+}
+
+// StreamServerInterceptor converts the errors returned by streaming gRPC
+// services into statuses carrying their detail
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return ToGRPCError(handler(srv, ss))
+}
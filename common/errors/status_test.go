@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package errors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDetailOf(t *testing.T) {
+	assert.Nil(t, DetailOf(nil))
+	assert.Nil(t, DetailOf(errors.New("no detail")))
+	assert.Nil(t, WithReason(nil, common.ErrorReason_POLICY_FAILURE, "mychannel"))
+
+	err := WithReason(errors.New("access denied"), common.ErrorReason_POLICY_FAILURE, "mychannel")
+	assert.EqualError(t, err, "access denied")
+	expected := &common.ErrorDetail{Reason: common.ErrorReason_POLICY_FAILURE, ChannelId: "mychannel"}
+	assert.Equal(t, expected, DetailOf(err))
+
+	// the detail is found through the errors wrapping it
+	wrapped := errors.WithMessage(err, "failed to endorse")
+	assert.Equal(t, expected, DetailOf(wrapped))
+	assert.Equal(t, common.ErrorReason_POLICY_FAILURE, ReasonOf(wrapped))
+	assert.Equal(t, common.ErrorReason_UNKNOWN_REASON, ReasonOf(errors.New("no detail")))
+
+	// the innermost detail is kept
+	assert.Equal(t, expected, DetailOf(WithReason(wrapped, common.ErrorReason_LEDGER_UNAVAILABLE, "")))
+
+	// the detail is carried by the status of the gRPC call
+	grpcErr := ToGRPCError(wrapped)
+	assert.Equal(t, codes.PermissionDenied, status.Code(grpcErr))
+	assert.Equal(t, "failed to endorse: access denied", status.Convert(grpcErr).Message())
+	assert.True(t, proto.Equal(expected, DetailOf(errors.Wrap(grpcErr, "remote peer"))))
+
+	plain := errors.New("no detail")
+	assert.Equal(t, plain, ToGRPCError(plain))
+	assert.Nil(t, ToGRPCError(nil))
+}
+
+func TestReasonOfStatus(t *testing.T) {
+	assert.Equal(t, common.ErrorReason_MALFORMED_REQUEST, ReasonOfStatus(common.Status_BAD_REQUEST))
+	assert.Equal(t, common.ErrorReason_POLICY_FAILURE, ReasonOfStatus(common.Status_FORBIDDEN))
+	assert.Equal(t, common.ErrorReason_BAD_CHANNEL, ReasonOfStatus(common.Status_NOT_FOUND))
+	assert.Equal(t, common.ErrorReason_UNKNOWN_REASON, ReasonOfStatus(common.Status_SERVICE_UNAVAILABLE))
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.WithMessage(WithReason(errors.New("not joined"), common.ErrorReason_BAD_CHANNEL, "mychannel"), "failed")
+	}
+	_, err := UnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, common.ErrorReason_BAD_CHANNEL, ReasonOf(err))
+}
@@ -54,7 +54,11 @@ func (f *factory) Block(channelID string) (*cb.Block, error) {
 
 	block := cb.NewBlock(0, nil)
 	block.Data = &cb.BlockData{Data: [][]byte{utils.MarshalOrPanic(envelope)}}
-	block.Header.DataHash = block.Data.Hash()
+	hash, err := utils.GetHashingAlgorithmFromBlock(block)
+	if err != nil {
+		return nil, err
+	}
+	block.Header.DataHash = block.Data.HashWith(hash)
 	block.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&cb.Metadata{
 		Value: utils.MarshalOrPanic(&cb.LastConfig{Index: 0}),
 	})
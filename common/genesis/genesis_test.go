@@ -19,6 +19,7 @@ package genesis
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
@@ -38,3 +39,24 @@ func TestForTransactionID(t *testing.T) {
 	configEnvPayloadChannelHeader, _ := utils.UnmarshalChannelHeader(configEnvPayload.GetHeader().ChannelHeader)
 	assert.NotEmpty(t, configEnvPayloadChannelHeader.TxId, "tx_id of configuration transaction should not be empty")
 }
+
+func TestDataHashUsesHashingAlgorithm(t *testing.T) {
+	group := cb.NewConfigGroup()
+	group.Values["HashingAlgorithm"] = &cb.ConfigValue{
+		Value: utils.MarshalOrPanic(&cb.HashingAlgorithm{Name: "SHA3_256"}),
+	}
+	block, err := NewFactoryImpl(group).Block("testchainid")
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeSHA256(block.Data.Bytes()), block.Header.DataHash, "SHA3_256 requires the V1_4 capability to take effect")
+
+	group.Values["Capabilities"] = &cb.ConfigValue{
+		Value: utils.MarshalOrPanic(&cb.Capabilities{Capabilities: map[string]*cb.Capability{"V1_4": {}}}),
+	}
+	block, err = NewFactoryImpl(group).Block("testchainid")
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeSHA3256(block.Data.Bytes()), block.Header.DataHash)
+
+	group.Values["HashingAlgorithm"].Value = utils.MarshalOrPanic(&cb.HashingAlgorithm{Name: "MD5"})
+	_, err = NewFactoryImpl(group).Block("testchainid")
+	assert.EqualError(t, err, "Unknown hashing algorithm type: MD5")
+}
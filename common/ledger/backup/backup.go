@@ -0,0 +1,266 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package backup writes and verifies the backups of the block files of
+// ledgers, which the peer and the orderer extend with the other data of
+// their ledgers.
+package backup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/protos/common"
+	putil "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// The layout of a backup directory. The manifest is written last, so that an
+// incomplete backup is not mistaken for a complete one.
+const (
+	ManifestFile = "manifest.json"
+	BlocksDir    = "blocks"
+)
+
+// Manifest describes the backup of a ledger
+type Manifest struct {
+	LedgerID         string    `json:"ledgerID"`
+	Height           uint64    `json:"height"`
+	CurrentBlockHash []byte    `json:"currentBlockHash"`
+	CreatedAt        time.Time `json:"createdAt"`
+	Files            []File    `json:"files"`
+}
+
+// File is a file of a backup, described by its path relative to the backup
+// directory, its size and its SHA256 hash
+type File struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// BackupBlockfiles verifies the chain of the blocks held by the block files
+// found in the ledger directory, and copies the files to the blocks directory
+// of the backup. It returns the manifest of the backup, to which the caller
+// adds its own files before writing it. The block files must not be written
+// to while they are backed up.
+func BackupBlockfiles(ledgerDir, outputDir string) (*Manifest, error) {
+	verifier := &chainVerifier{}
+	segments, err := fsblkstorage.ScanBlockfiles(ledgerDir, verifier.verify)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error scanning the block files")
+	}
+	manifest := &Manifest{
+		LedgerID:         verifier.ledgerID,
+		Height:           verifier.height,
+		CurrentBlockHash: verifier.currentBlockHash,
+		CreatedAt:        time.Now().UTC(),
+	}
+
+	if err := os.Mkdir(filepath.Join(outputDir, BlocksDir), 0755); err != nil {
+		return nil, errors.Wrap(err, "error creating the blocks directory")
+	}
+	for _, segment := range segments {
+		path := filepath.Join(BlocksDir, segment.Name)
+		digest := sha256.New()
+		if err := copyFile(filepath.Join(ledgerDir, segment.Name), filepath.Join(outputDir, path), segment.Size, digest); err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, File{Path: path, Size: segment.Size, SHA256: hex.EncodeToString(digest.Sum(nil))})
+	}
+	return manifest, nil
+}
+
+// MarshalManifest returns the bytes of the manifest as written to the backup
+// directory
+func MarshalManifest(manifest *Manifest) ([]byte, error) {
+	return json.MarshalIndent(manifest, "", "  ")
+}
+
+// WriteManifest writes the manifest to the backup directory, completing the
+// backup
+func WriteManifest(dir string, manifest *Manifest) error {
+	manifestBytes, err := MarshalManifest(manifest)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(ioutil.WriteFile(filepath.Join(dir, ManifestFile), manifestBytes, 0644), "error writing the backup manifest")
+}
+
+// Verify checks the files of the backup found in the given directory against
+// its manifest, and the chain of the blocks it holds. It returns the manifest
+// along with the genesis block of the ledger.
+func Verify(dir string) (*Manifest, *common.Block, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := VerifyFiles(dir, manifest); err != nil {
+		return nil, nil, err
+	}
+
+	verifier := &chainVerifier{}
+	if _, err := fsblkstorage.ScanBlockfiles(filepath.Join(dir, BlocksDir), verifier.verify); err != nil {
+		return nil, nil, errors.WithMessage(err, "error scanning the block files of the backup")
+	}
+	if verifier.height != manifest.Height || !bytes.Equal(verifier.currentBlockHash, manifest.CurrentBlockHash) {
+		return nil, nil, errors.Errorf("the blocks of the backup end at height [%d], the manifest expects height [%d]", verifier.height, manifest.Height)
+	}
+	if verifier.ledgerID != manifest.LedgerID {
+		return nil, nil, errors.Errorf("the blocks of the backup belong to ledger [%s], the manifest expects ledger [%s]", verifier.ledgerID, manifest.LedgerID)
+	}
+	return manifest, verifier.genesisBlock, nil
+}
+
+// ReadManifest reads the manifest of the backup found in the given directory
+func ReadManifest(dir string) (*Manifest, error) {
+	manifestBytes, err := ioutil.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading the backup manifest")
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(manifestBytes, manifest); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling the backup manifest")
+	}
+	return manifest, nil
+}
+
+// VerifyFiles checks the files of the backup found in the given directory
+// against its manifest
+func VerifyFiles(dir string, manifest *Manifest) error {
+	for _, file := range manifest.Files {
+		size, sum, err := hashFile(filepath.Join(dir, file.Path))
+		if err != nil {
+			return err
+		}
+		if size != file.Size || sum != file.SHA256 {
+			return errors.Errorf("file [%s] of the backup does not match the manifest", file.Path)
+		}
+	}
+	return nil
+}
+
+// DescribeFile returns the description of the file of the backup found at
+// the given path, relative to the backup directory, for its manifest
+func DescribeFile(dir, path string) (File, error) {
+	size, sum, err := hashFile(filepath.Join(dir, path))
+	if err != nil {
+		return File{}, err
+	}
+	return File{Path: path, Size: size, SHA256: sum}, nil
+}
+
+// RestoreBlockfiles copies the block files of a verified backup to the
+// ledger directory, which must not exist or be empty
+func RestoreBlockfiles(dir, ledgerDir string) error {
+	if err := CreateEmptyDir(ledgerDir); err != nil {
+		return err
+	}
+	blockfiles, err := ioutil.ReadDir(filepath.Join(dir, BlocksDir))
+	if err != nil {
+		return errors.Wrap(err, "error reading the blocks directory of the backup")
+	}
+	for _, blockfile := range blockfiles {
+		src := filepath.Join(dir, BlocksDir, blockfile.Name())
+		if err := copyFile(src, filepath.Join(ledgerDir, blockfile.Name()), blockfile.Size(), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateEmptyDir creates the given directory, if it does not exist, and
+// fails if it is not empty
+func CreateEmptyDir(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "error creating directory [%s]", dir)
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errors.Wrapf(err, "error reading directory [%s]", dir)
+	}
+	if len(files) != 0 {
+		return errors.Errorf("directory [%s] is not empty", dir)
+	}
+	return nil
+}
+
+// chainVerifier checks that the blocks it is handed follow each other and
+// are chained by their hashes
+type chainVerifier struct {
+	ledgerID         string
+	genesisBlock     *common.Block
+	height           uint64
+	currentBlockHash []byte
+	hashingAlgorithm func([]byte) []byte
+}
+
+func (v *chainVerifier) verify(block *common.Block) error {
+	if block.Header.Number != v.height {
+		return errors.Errorf("expected block [%d] but found block [%d]", v.height, block.Header.Number)
+	}
+	if block.Header.Number == 0 {
+		var err error
+		v.genesisBlock = block
+		if v.ledgerID, err = putil.GetChainIDFromBlock(block); err != nil {
+			return err
+		}
+		if v.hashingAlgorithm, err = putil.GetHashingAlgorithmFromBlock(block); err != nil {
+			return err
+		}
+	} else if !bytes.Equal(block.Header.PreviousHash, v.currentBlockHash) {
+		return errors.Errorf("the previous hash of block [%d] does not match the hash of block [%d]", block.Header.Number, v.height-1)
+	}
+	v.currentBlockHash = block.Header.HashWith(v.hashingAlgorithm)
+	v.height++
+	return nil
+}
+
+// copyFile copies the first size bytes of a file, feeding them to the given
+// digest if any, and syncs the copy
+func copyFile(src, dst string, size int64, digest hash.Hash) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return errors.Wrapf(err, "error opening file [%s]", src)
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "error creating file [%s]", dst)
+	}
+	defer out.Close()
+	var w io.Writer = out
+	if digest != nil {
+		w = io.MultiWriter(out, digest)
+	}
+	if _, err := io.CopyN(w, in, size); err != nil {
+		return errors.Wrapf(err, "error copying file [%s] to [%s]", src, dst)
+	}
+	return errors.Wrapf(out.Sync(), "error syncing file [%s]", dst)
+}
+
+func hashFile(path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", errors.Wrapf(err, "error opening file [%s]", path)
+	}
+	defer f.Close()
+	digest := sha256.New()
+	size, err := io.Copy(digest, f)
+	if err != nil {
+		return 0, "", errors.Wrapf(err, "error reading file [%s]", path)
+	}
+	return size, hex.EncodeToString(digest.Sum(nil)), nil
+}
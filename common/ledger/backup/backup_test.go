@@ -0,0 +1,49 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package backup
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainVerifier(t *testing.T) {
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	blocks := bg.NextTestBlocks(2)
+
+	v := &chainVerifier{}
+	assert.NoError(t, v.verify(gb))
+	assert.NoError(t, v.verify(blocks[0]))
+	assert.NoError(t, v.verify(blocks[1]))
+	assert.Equal(t, "testLedger", v.ledgerID)
+	assert.Equal(t, gb, v.genesisBlock)
+	assert.Equal(t, uint64(3), v.height)
+	assert.Equal(t, blocks[1].Header.Hash(), v.currentBlockHash)
+
+	v = &chainVerifier{}
+	assert.EqualError(t, v.verify(blocks[0]), "expected block [0] but found block [1]")
+
+	v = &chainVerifier{}
+	assert.NoError(t, v.verify(gb))
+	blocks[0].Header.PreviousHash = []byte("tampered")
+	assert.EqualError(t, v.verify(blocks[0]), "the previous hash of block [1] does not match the hash of block [0]")
+}
+
+func TestCreateEmptyDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, CreateEmptyDir(filepath.Join(dir, "a", "b")))
+	assert.NoError(t, CreateEmptyDir(filepath.Join(dir, "a", "b")))
+	assert.EqualError(t, CreateEmptyDir(filepath.Join(dir, "a")), "directory ["+filepath.Join(dir, "a")+"] is not empty")
+}
@@ -62,5 +62,7 @@ type BlockStore interface {
 	RetrieveTxByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error)
 	RetrieveBlockByTxID(txID string) (*common.Block, error)
 	RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	// Sync syncs to disk the blocks added so far, including those whose sync was deferred
+	Sync() error
 	Shutdown()
 }
@@ -0,0 +1,306 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/pkg/errors"
+)
+
+// restoredFileTTL is how long an archived block file is kept locally after
+// its last read, once it is archived or fetched back from the archive
+const restoredFileTTL = 10 * time.Minute
+
+var archivedFilesKey = []byte("filesArchived")
+
+// BlockfileArchive is the storage which the block files of the ledgers are
+// moved to once all their blocks are far enough below the height of their
+// ledgers, such as a secondary path or an object store like S3 or GCS.
+type BlockfileArchive interface {
+	// Put stores the local block file under the given name, replacing the
+	// file stored under it, if any
+	Put(name string, localPath string) error
+	// Get copies the block file stored under the given name to the local path
+	Get(name string, localPath string) error
+}
+
+// NewDirBlockfileArchive returns a BlockfileArchive storing the block files
+// under the given directory, such as the mount point of a cheaper disk.
+func NewDirBlockfileArchive(dir string) BlockfileArchive {
+	return &dirBlockfileArchive{dir: dir}
+}
+
+type dirBlockfileArchive struct {
+	dir string
+}
+
+func (a *dirBlockfileArchive) Put(name string, localPath string) error {
+	return copyBlockfile(localPath, filepath.Join(a.dir, name))
+}
+
+func (a *dirBlockfileArchive) Get(name string, localPath string) error {
+	return copyBlockfile(filepath.Join(a.dir, name), localPath)
+}
+
+// copyBlockfile copies a block file through a temporary file, so that the
+// destination is either missing or complete after a crash
+func copyBlockfile(src, dst string) error {
+	if _, err := util.CreateDirIfMissing(filepath.Dir(dst)); err != nil {
+		return errors.Wrapf(err, "error creating the directory of [%s]", dst)
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return errors.Wrapf(err, "error opening [%s]", src)
+	}
+	defer in.Close()
+	tmp := dst + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0660)
+	if err != nil {
+		return errors.Wrapf(err, "error creating [%s]", tmp)
+	}
+	_, err = io.Copy(out, in)
+	if err == nil {
+		err = out.Sync()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return errors.Wrapf(err, "error copying [%s] to [%s]", src, dst)
+	}
+	return errors.Wrapf(os.Rename(tmp, dst), "error renaming [%s] to [%s]", tmp, dst)
+}
+
+// blockfileArchiver moves the block files of a ledger to the archive, in
+// order, once all their blocks are at least retainedBlocks below the height
+// of the ledger, and fetches them back when their blocks are read. The block
+// index stays local, as it points to the same files. Since the blocks of an
+// archived file are usually read in bursts, such as by a deliver client
+// catching up, its local copy is only removed once it is not read for a while.
+type blockfileArchiver struct {
+	mgr            *blockfileMgr
+	ledgerID       string
+	archive        BlockfileArchive
+	retainedBlocks uint64
+
+	lock sync.Mutex
+	// archivedFiles is the number of the leading block files of the ledger
+	// which are archived
+	archivedFiles int
+	// restored holds the archived block files which are found locally, along
+	// with the time of their last read
+	restored map[int]time.Time
+	// firstBlockNums caches the numbers of the first blocks of the block
+	// files, which decide when the previous files are archived
+	firstBlockNums map[int]uint64
+
+	trigger chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+func newBlockfileArchiver(mgr *blockfileMgr, ledgerID string, archive BlockfileArchive, retainedBlocks uint64) (*blockfileArchiver, error) {
+	a := &blockfileArchiver{
+		mgr:            mgr,
+		ledgerID:       ledgerID,
+		archive:        archive,
+		retainedBlocks: retainedBlocks,
+		restored:       map[int]time.Time{},
+		firstBlockNums: map[int]uint64{},
+		trigger:        make(chan struct{}, 1),
+		done:           make(chan struct{}),
+		stopped:        make(chan struct{}),
+	}
+	b, err := mgr.db.Get(archivedFilesKey)
+	if err != nil {
+		return nil, err
+	}
+	if b != nil {
+		archivedFiles, _ := util.DecodeOrderPreservingVarUint64(b)
+		a.archivedFiles = int(archivedFiles)
+	}
+	// the local copies left by a previous run are removed once they expire
+	now := time.Now()
+	for fileNum := 0; fileNum < a.archivedFiles; fileNum++ {
+		exists, _, err := util.FileExists(deriveBlockfilePath(mgr.rootDir, fileNum))
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			a.restored[fileNum] = now
+		}
+	}
+	return a, nil
+}
+
+func (a *blockfileArchiver) start() {
+	go a.run()
+}
+
+func (a *blockfileArchiver) stop() {
+	close(a.done)
+	<-a.stopped
+}
+
+// notify triggers a pass of the archiver, unless one is already pending
+func (a *blockfileArchiver) notify() {
+	select {
+	case a.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (a *blockfileArchiver) run() {
+	defer close(a.stopped)
+	for {
+		select {
+		case <-a.done:
+			return
+		case <-a.trigger:
+			if err := a.archiveBlockfiles(); err != nil {
+				logger.Errorf("Error archiving the block files of ledger [%s]: %s", a.ledgerID, err)
+			}
+			a.removeExpiredFiles(time.Now())
+		}
+	}
+}
+
+// archiveBlockfiles archives the block files whose blocks are all at least
+// retainedBlocks below the height of the ledger
+func (a *blockfileArchiver) archiveBlockfiles() error {
+	for {
+		a.lock.Lock()
+		fileNum := a.archivedFiles
+		a.lock.Unlock()
+
+		a.mgr.cpInfoCond.L.Lock()
+		cpInfo := a.mgr.cpInfo
+		a.mgr.cpInfoCond.L.Unlock()
+		if fileNum >= cpInfo.latestFileChunkSuffixNum {
+			// the last file is never archived
+			return nil
+		}
+		nextBlockNum, ok, err := a.nextBlockNumber(fileNum, cpInfo)
+		if err != nil || !ok || nextBlockNum+a.retainedBlocks > cpInfo.lastBlockNumber+1 {
+			return err
+		}
+		if err := a.archiveBlockfile(fileNum); err != nil {
+			return err
+		}
+	}
+}
+
+func (a *blockfileArchiver) archiveBlockfile(fileNum int) error {
+	path := deriveBlockfilePath(a.mgr.rootDir, fileNum)
+	if err := a.archive.Put(a.archivedName(fileNum), path); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error archiving block file [%s]", path))
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if err := a.mgr.db.Put(archivedFilesKey, util.EncodeOrderPreservingVarUint64(uint64(fileNum+1)), true); err != nil {
+		return err
+	}
+	a.archivedFiles = fileNum + 1
+	a.restored[fileNum] = time.Now()
+	delete(a.firstBlockNums, fileNum)
+	logger.Infof("Archived block file [%s] of ledger [%s]", filepath.Base(path), a.ledgerID)
+	return nil
+}
+
+// nextBlockNumber returns the number of the first block following the blocks
+// of a block file, which is held by one of the next files, and whether it is
+// written yet. A file is left empty when a block larger than the maximum size
+// of the files is added, in which case the block starts the next file.
+func (a *blockfileArchiver) nextBlockNumber(fileNum int, cpInfo *checkpointInfo) (uint64, bool, error) {
+	for next := fileNum + 1; next <= cpInfo.latestFileChunkSuffixNum; next++ {
+		if next == cpInfo.latestFileChunkSuffixNum && cpInfo.latestFileChunksize == 0 {
+			return 0, false, nil
+		}
+		if blockNum, ok := a.firstBlockNums[next]; ok {
+			return blockNum, true, nil
+		}
+		blockNum, ok, err := firstBlockNumber(a.mgr.rootDir, next)
+		if err != nil {
+			return 0, false, err
+		}
+		if ok {
+			a.firstBlockNums[next] = blockNum
+			return blockNum, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// removeExpiredFiles removes the local copies of the archived block files
+// which were not read for restoredFileTTL
+func (a *blockfileArchiver) removeExpiredFiles(now time.Time) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	for fileNum, lastRead := range a.restored {
+		if now.Sub(lastRead) < restoredFileTTL {
+			continue
+		}
+		path := deriveBlockfilePath(a.mgr.rootDir, fileNum)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			logger.Errorf("Error removing archived block file [%s]: %s", path, err)
+			continue
+		}
+		delete(a.restored, fileNum)
+	}
+}
+
+// restore makes sure that the block file is found locally before it is read,
+// fetching it back from the archive if it was archived and removed since
+func (a *blockfileArchiver) restore(fileNum int) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if fileNum >= a.archivedFiles {
+		return nil
+	}
+	if _, ok := a.restored[fileNum]; !ok {
+		path := deriveBlockfilePath(a.mgr.rootDir, fileNum)
+		logger.Infof("Fetching archived block file [%s] of ledger [%s]", filepath.Base(path), a.ledgerID)
+		if err := a.archive.Get(a.archivedName(fileNum), path); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error fetching archived block file [%s]", path))
+		}
+	}
+	a.restored[fileNum] = time.Now()
+	return nil
+}
+
+// archivedName returns the name of a block file of the ledger in the archive
+func (a *blockfileArchiver) archivedName(fileNum int) string {
+	return a.ledgerID + "/" + filepath.Base(deriveBlockfilePath("", fileNum))
+}
+
+// firstBlockNumber returns the number of the first block of a block file,
+// and whether the file holds any block
+func firstBlockNumber(rootDir string, fileNum int) (uint64, bool, error) {
+	stream, err := newBlockfileStream(rootDir, fileNum, 0)
+	if err != nil {
+		return 0, false, err
+	}
+	defer stream.close()
+	blockBytes, err := stream.nextBlockBytes()
+	if err != nil || blockBytes == nil {
+		return 0, false, err
+	}
+	info, err := extractSerializedBlockInfo(blockBytes)
+	if err != nil {
+		return 0, false, err
+	}
+	return info.blockHeader.Number, true, nil
+}
@@ -0,0 +1,184 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	putil "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func archivedFiles(mgr *blockfileMgr) int {
+	mgr.archiver.lock.Lock()
+	defer mgr.archiver.lock.Unlock()
+	return mgr.archiver.archivedFiles
+}
+
+func localBlockfileExists(t *testing.T, mgr *blockfileMgr, fileNum int) bool {
+	exists, _, err := util.FileExists(deriveBlockfilePath(mgr.rootDir, fileNum))
+	assert.NoError(t, err)
+	return exists
+}
+
+func TestBlockfileArchiving(t *testing.T) {
+	archiveDir, err := ioutil.TempDir("", "fsblkstorage-archive-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(archiveDir)
+
+	ledgerid := "testLedger"
+	bg, gb := testutil.NewBlockGenerator(t, ledgerid, false)
+	blocks := append([]*common.Block{gb}, bg.NextTestBlocks(19)...)
+	genesisBytes, _, err := serializeBlock(gb)
+	assert.NoError(t, err)
+	blockBytes, _, err := serializeBlock(blocks[1])
+	assert.NoError(t, err)
+	// each block file holds two blocks, but for the genesis block which holds its own
+	maxBlockfileSize := 2*len(blockBytes) + 16
+	if len(genesisBytes)+16 > maxBlockfileSize {
+		maxBlockfileSize = len(genesisBytes) + 16
+	}
+	conf := NewConf(testPath(), maxBlockfileSize).WithArchive(NewDirBlockfileArchive(archiveDir), 4)
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+
+	w := newTestBlockfileWrapper(env, ledgerid)
+	w.addBlocks(blocks)
+	mgr := w.blockfileMgr
+	assert.True(t, mgr.cpInfo.latestFileChunkSuffixNum >= 3)
+
+	// the files whose blocks are all at least 4 blocks below the height are archived
+	expected := 0
+	for fileNum := 1; fileNum <= mgr.cpInfo.latestFileChunkSuffixNum; fileNum++ {
+		firstBlock, ok, err := firstBlockNumber(mgr.rootDir, fileNum)
+		assert.NoError(t, err)
+		if ok && firstBlock+4 > uint64(len(blocks)) {
+			break
+		}
+		expected = fileNum
+	}
+	assert.True(t, expected > 0)
+	deadline := time.Now().Add(10 * time.Second)
+	for archivedFiles(mgr) < expected && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	archived := archivedFiles(mgr)
+	assert.Equal(t, expected, archived)
+	for fileNum := 0; fileNum < archived; fileNum++ {
+		_, err := os.Stat(filepath.Join(archiveDir, ledgerid, filepath.Base(deriveBlockfilePath("", fileNum))))
+		assert.NoError(t, err, "block file [%d] should have been archived", fileNum)
+	}
+
+	// the local copies are removed once they are not read for a while
+	assert.True(t, localBlockfileExists(t, mgr, 0))
+	mgr.archiver.removeExpiredFiles(time.Now().Add(restoredFileTTL))
+	for fileNum := 0; fileNum < archived; fileNum++ {
+		assert.False(t, localBlockfileExists(t, mgr, fileNum), "the local copy of block file [%d] should have been removed", fileNum)
+	}
+
+	// the archived blocks are fetched back when read
+	w.testGetBlockByNumber(blocks, 0)
+	w.testGetBlockByHash(blocks)
+	assert.True(t, localBlockfileExists(t, mgr, 0))
+	mgr.archiver.removeExpiredFiles(time.Now().Add(restoredFileTTL))
+	itr, err := mgr.retrieveBlocks(0)
+	assert.NoError(t, err)
+	for _, block := range blocks {
+		next, err := itr.Next()
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(block, next.(*common.Block)))
+	}
+	itr.Close()
+	mgr.archiver.removeExpiredFiles(time.Now().Add(restoredFileTTL))
+	w.close()
+
+	// the archived files are known when the block store is reopened
+	env.provider.Close()
+	env = newTestEnv(t, conf)
+	w = newTestBlockfileWrapper(env, ledgerid)
+	defer w.close()
+	assert.Equal(t, archived, archivedFiles(w.blockfileMgr))
+	w.testGetBlockByNumber(blocks, 0)
+	tx, err := w.blockfileMgr.retrieveTransactionByBlockNumTranNum(1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[1].Data.Data[0], putil.MarshalOrPanic(tx))
+}
+
+func TestBlockfileArchivingIndexRebuilt(t *testing.T) {
+	archiveDir, err := ioutil.TempDir("", "fsblkstorage-archive-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(archiveDir)
+
+	ledgerid := "testLedger"
+	bg, gb := testutil.NewBlockGenerator(t, ledgerid, false)
+	blocks := append([]*common.Block{gb}, bg.NextTestBlocks(19)...)
+	genesisBytes, _, err := serializeBlock(gb)
+	assert.NoError(t, err)
+	conf := NewConf(testPath(), len(genesisBytes)+16).WithArchive(NewDirBlockfileArchive(archiveDir), 4)
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+
+	w := newTestBlockfileWrapper(env, ledgerid)
+	w.addBlocks(blocks)
+	deadline := time.Now().Add(10 * time.Second)
+	for archivedFiles(w.blockfileMgr) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	w.close()
+	archived := archivedFiles(w.blockfileMgr)
+	assert.True(t, archived > 1)
+	w.blockfileMgr.archiver.removeExpiredFiles(time.Now().Add(restoredFileTTL))
+	assert.False(t, localBlockfileExists(t, w.blockfileMgr, 1))
+
+	// simulate an index which refers to blocks missing from the block files,
+	// which is rebuilt from the archived block files as well
+	indexStore := env.provider.indexStoreProvider.GetDBHandle(ledgerid)
+	assert.NoError(t, indexStore.Put(indexCheckpointKey, encodeBlockNum(uint64(len(blocks)+5)), true))
+	env.provider.Close()
+	env = newTestEnv(t, conf)
+	w = newTestBlockfileWrapper(env, ledgerid)
+	w.testGetBlockByHash(blocks)
+	w.blockfileMgr.archiver.removeExpiredFiles(time.Now().Add(restoredFileTTL))
+	w.close()
+
+	// the archived files are still known after the index was rebuilt
+	env.provider.Close()
+	env = newTestEnv(t, conf)
+	w = newTestBlockfileWrapper(env, ledgerid)
+	defer w.close()
+	assert.Equal(t, archived, archivedFiles(w.blockfileMgr))
+	assert.False(t, localBlockfileExists(t, w.blockfileMgr, 1))
+	w.testGetBlockByNumber(blocks, 0)
+	assert.True(t, localBlockfileExists(t, w.blockfileMgr, 1))
+}
+
+func TestBlockfileArchiveErrors(t *testing.T) {
+	archiveDir, err := ioutil.TempDir("", "fsblkstorage-archive-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(archiveDir)
+
+	archive := NewDirBlockfileArchive(archiveDir)
+	err = archive.Get("testLedger/blockfile_000000", filepath.Join(archiveDir, "restored"))
+	assert.Contains(t, err.Error(), "error opening")
+
+	src := filepath.Join(archiveDir, "src")
+	assert.NoError(t, ioutil.WriteFile(src, []byte("blocks"), 0600))
+	assert.NoError(t, archive.Put("testLedger/blockfile_000000", src))
+	dst := filepath.Join(archiveDir, "dst")
+	assert.NoError(t, archive.Get("testLedger/blockfile_000000", dst))
+	content, err := ioutil.ReadFile(dst)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("blocks"), content)
+}
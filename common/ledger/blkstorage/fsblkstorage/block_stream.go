@@ -38,6 +38,8 @@ type blockStream struct {
 	currentFileNum    int
 	endFileNum        int
 	currentFileStream *blockfileStream
+	// restore, if set, is called before each next file is opened
+	restore func(fileNum int) error
 }
 
 // blockPlacementInfo captures the information related
@@ -150,7 +152,7 @@ func newBlockStream(rootDir string, startFileNum int, startOffset int64, endFile
 	if err != nil {
 		return nil, err
 	}
-	return &blockStream{rootDir, startFileNum, endFileNum, startFileStream}, nil
+	return &blockStream{rootDir: rootDir, currentFileNum: startFileNum, endFileNum: endFileNum, currentFileStream: startFileStream}, nil
 }
 
 func (s *blockStream) moveToNextBlockfileStream() error {
@@ -159,6 +161,11 @@ func (s *blockStream) moveToNextBlockfileStream() error {
 		return err
 	}
 	s.currentFileNum++
+	if s.restore != nil {
+		if err = s.restore(s.currentFileNum); err != nil {
+			return err
+		}
+	}
 	if s.currentFileStream, err = newBlockfileStream(s.rootDir, s.currentFileNum, 0); err != nil {
 		return err
 	}
@@ -18,7 +18,6 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
-	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
 	putil "github.com/hyperledger/fabric/protos/utils"
@@ -38,12 +37,16 @@ var (
 type blockfileMgr struct {
 	rootDir           string
 	conf              *Conf
-	db                *leveldbhelper.DBHandle
+	db                indexStore
 	index             index
 	cpInfo            *checkpointInfo
 	cpInfoCond        *sync.Cond
 	currentFileWriter *blockfileWriter
 	bcInfo            atomic.Value
+	// hashingAlgorithm is the block hashing algorithm specified by the genesis block
+	hashingAlgorithm func([]byte) []byte
+	// archiver moves the old block files to the archive, if any
+	archiver *blockfileArchiver
 }
 
 /*
@@ -78,16 +81,19 @@ At start up a new manager:
 		-- If cpinfo was loaded from db, compares to FS
 		-- If cpinfo and file system are not in sync, syncs cpInfo from FS
   *) Starts a new file writer
-		-- truncates file per cpinfo to remove any excess past last block
+		-- truncates file per cpinfo to remove any excess past last block,
+		   i.e. a block partially written or corrupted by a crash
   *) Determines the index information used to find tx and blocks in
   the file blkstorage
 		-- Instantiates a new blockIdxInfo
 		-- Loads the index from the db if exists
 		-- syncIndex comparing the last block indexed to what is in the FS
 		-- If index and file system are not in sync, syncs index from the FS
+		-- If the index cannot be synced, rebuilds it from the FS
   *)  Updates blockchain info used by the APIs
+  *)  Logs a report of the repairs made, if any
 */
-func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig, indexStore *leveldbhelper.DBHandle) *blockfileMgr {
+func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig, indexStore indexStore) *blockfileMgr {
 	logger.Debugf("newBlockfileMgr() initializing file-based block storage for ledger: %s ", id)
 	//Determine the root directory for the blockfile storage, if it does not exist create it
 	rootDir := conf.getLedgerBlockDir(id)
@@ -97,6 +103,7 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	}
 	// Instantiate the manager, i.e. blockFileMgr structure
 	mgr := &blockfileMgr{rootDir: rootDir, conf: conf, db: indexStore}
+	report := &recoveryReport{ledgerID: id}
 
 	// cp = checkpointInfo, retrieve from the database the file suffix or number of where blocks were stored.
 	// It also retrieves the current size of that file and the last block number that was written to that file.
@@ -105,15 +112,20 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	if err != nil {
 		panic(fmt.Sprintf("Could not get block file info for current block file from db: %s", err))
 	}
+	if cpInfo != nil && checkpointAheadOfBlockFiles(rootDir, cpInfo) {
+		logger.Warningf("Checkpoint info of ledger [%s] refers to blocks missing from the block files, rebuilding it", id)
+		cpInfo = nil
+	}
 	if cpInfo == nil {
 		logger.Info(`Getting block information from block storage`)
 		if cpInfo, err = constructCheckpointInfoFromBlockFiles(rootDir); err != nil {
 			panic(fmt.Sprintf("Could not build checkpoint info from block files: %s", err))
 		}
 		logger.Debugf("Info constructed by scanning the blocks dir = %s", spew.Sdump(cpInfo))
+		report.checkpointRebuilt = !cpInfo.isChainEmpty
 	} else {
 		logger.Debug(`Synching block information from block storage (if needed)`)
-		syncCPInfoFromFS(rootDir, cpInfo)
+		report.blocksRecovered = syncCPInfoFromFS(rootDir, cpInfo)
 	}
 	err = mgr.saveCurrentInfo(cpInfo, true)
 	if err != nil {
@@ -122,11 +134,20 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 
 	//Open a writer to the file identified by the number and truncate it to only contain the latest block
 	// that was completely saved (file system, index, cpinfo, etc)
-	currentFileWriter, err := newBlockfileWriter(deriveBlockfilePath(rootDir, cpInfo.latestFileChunkSuffixNum))
+	currentFilePath := deriveBlockfilePath(rootDir, cpInfo.latestFileChunkSuffixNum)
+	currentFileWriter, err := newBlockfileWriter(currentFilePath)
 	if err != nil {
 		panic(fmt.Sprintf("Could not open writer to current file: %s", err))
 	}
 	//Truncate the file to remove excess past last block
+	_, currentFileSize, err := util.FileExists(currentFilePath)
+	if err != nil {
+		panic(fmt.Sprintf("Could not get the size of the current file: %s", err))
+	}
+	if excess := currentFileSize - int64(cpInfo.latestFileChunksize); excess > 0 {
+		report.truncatedFile = currentFilePath
+		report.truncatedBytes = excess
+	}
 	err = currentFileWriter.truncateFile(cpInfo.latestFileChunksize)
 	if err != nil {
 		panic(fmt.Sprintf("Could not truncate current file to known size in db: %s", err))
@@ -144,6 +165,18 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	// or announcing the occurrence of an event.
 	mgr.cpInfoCond = sync.NewCond(&sync.Mutex{})
 
+	if conf.archive != nil {
+		if mgr.archiver, err = newBlockfileArchiver(mgr, id, conf.archive, conf.retainedBlocks); err != nil {
+			panic(fmt.Sprintf("Could not load the archived block files: %s", err))
+		}
+	}
+
+	if !cpInfo.isChainEmpty {
+		if err := mgr.loadHashingAlgorithm(); err != nil {
+			panic(fmt.Sprintf("Could not determine the hashing algorithm of the chain: %s", err))
+		}
+	}
+
 	// init BlockchainInfo for external API's
 	bcInfo := &common.BlockchainInfo{
 		Height:            0,
@@ -152,12 +185,14 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 
 	if !cpInfo.isChainEmpty {
 		//If start up is a restart of an existing storage, sync the index from block storage and update BlockchainInfo for external API's
-		mgr.syncIndex()
+		if err := mgr.recoverIndex(report); err != nil {
+			panic(fmt.Sprintf("Could not sync the index with the block files: %s", err))
+		}
 		lastBlockHeader, err := mgr.retrieveBlockHeaderByNumber(cpInfo.lastBlockNumber)
 		if err != nil {
 			panic(fmt.Sprintf("Could not retrieve header of the last block form file: %s", err))
 		}
-		lastBlockHash := lastBlockHeader.Hash()
+		lastBlockHash := mgr.blockHash(lastBlockHeader)
 		previousBlockHash := lastBlockHeader.PreviousHash
 		bcInfo = &common.BlockchainInfo{
 			Height:            cpInfo.lastBlockNumber + 1,
@@ -165,6 +200,11 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 			PreviousBlockHash: previousBlockHash}
 	}
 	mgr.bcInfo.Store(bcInfo)
+	report.log()
+	if mgr.archiver != nil {
+		mgr.archiver.start()
+		mgr.archiver.notify()
+	}
 	return mgr
 }
 
@@ -172,7 +212,8 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 // the file of where the last block was written.  Also retrieves contains the
 // last block number that was written.  At init
 //checkpointInfo:latestFileChunkSuffixNum=[0], latestFileChunksize=[0], lastBlockNumber=[0]
-func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
+//It returns the number of blocks found in the file past the checkpoint.
+func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) int {
 	logger.Debugf("Starting checkpoint=%s", cpInfo)
 	//Checks if the file suffix of where the last block was written exists
 	filePath := deriveBlockfilePath(rootDir, cpInfo.latestFileChunkSuffixNum)
@@ -186,26 +227,33 @@ func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
 	//status of file [/tmp/tests/ledger/blkstorage/fsblkstorage/blocks/blockfile_000000]: exists=[false], size=[0]
 	if !exists || int(size) == cpInfo.latestFileChunksize {
 		// check point info is in sync with the file on disk
-		return
+		return 0
 	}
 	//Scan the file system to verify that the checkpoint info stored in db is correct
-	_, endOffsetLastBlock, numBlocks, err := scanForLastCompleteBlock(
+	lastBlockBytes, endOffsetLastBlock, numBlocks, err := scanForLastCompleteBlock(
 		rootDir, cpInfo.latestFileChunkSuffixNum, int64(cpInfo.latestFileChunksize))
 	if err != nil {
 		panic(fmt.Sprintf("Could not open current file for detecting last block in the file: %s", err))
 	}
-	cpInfo.latestFileChunksize = int(endOffsetLastBlock)
 	if numBlocks == 0 {
-		return
+		cpInfo.latestFileChunksize = int(endOffsetLastBlock)
+		return 0
 	}
-	//Updates the checkpoint info for the actual last block number stored and it's end location
+	lastBlockNumber := cpInfo.lastBlockNumber + uint64(numBlocks)
 	if cpInfo.isChainEmpty {
-		cpInfo.lastBlockNumber = uint64(numBlocks - 1)
-	} else {
-		cpInfo.lastBlockNumber += uint64(numBlocks)
+		lastBlockNumber = uint64(numBlocks - 1)
 	}
+	//The blocks found past the checkpoint are garbage left by a crash if they do not follow the last block
+	if info, err := extractSerializedBlockInfo(lastBlockBytes); err != nil || info.blockHeader.Number != lastBlockNumber {
+		logger.Warningf("Blocks found in file [%s] past the checkpoint do not follow the last block, ignoring them", filePath)
+		return 0
+	}
+	//Updates the checkpoint info for the actual last block number stored and it's end location
+	cpInfo.latestFileChunksize = int(endOffsetLastBlock)
+	cpInfo.lastBlockNumber = lastBlockNumber
 	cpInfo.isChainEmpty = false
 	logger.Debugf("Checkpoint after updates by scanning the last file segment:%s", cpInfo)
+	return numBlocks
 }
 
 func deriveBlockfilePath(rootDir string, suffixNum int) string {
@@ -213,9 +261,43 @@ func deriveBlockfilePath(rootDir string, suffixNum int) string {
 }
 
 func (mgr *blockfileMgr) close() {
+	if mgr.archiver != nil {
+		mgr.archiver.stop()
+	}
 	mgr.currentFileWriter.close()
 }
 
+// newBlockStream opens a block stream over the block files of the ledger,
+// fetching them back from the archive as they are reached
+func (mgr *blockfileMgr) newBlockStream(startFileNum int, startOffset int64, endFileNum int) (*blockStream, error) {
+	if err := mgr.restoreBlockfile(startFileNum); err != nil {
+		return nil, err
+	}
+	stream, err := newBlockStream(mgr.rootDir, startFileNum, startOffset, endFileNum)
+	if err != nil {
+		return nil, err
+	}
+	stream.restore = mgr.restoreBlockfile
+	return stream, nil
+}
+
+// restoreBlockfile fetches the block file back from the archive, if it was
+// archived, before it is read
+func (mgr *blockfileMgr) restoreBlockfile(fileNum int) error {
+	if mgr.archiver == nil {
+		return nil
+	}
+	return mgr.archiver.restore(fileNum)
+}
+
+// sync syncs to disk the current block file and the index
+func (mgr *blockfileMgr) sync() error {
+	if err := mgr.currentFileWriter.sync(); err != nil {
+		return err
+	}
+	return mgr.db.Sync()
+}
+
 func (mgr *blockfileMgr) moveToNextFile() {
 	cpInfo := &checkpointInfo{
 		latestFileChunkSuffixNum: mgr.cpInfo.latestFileChunkSuffixNum + 1,
@@ -228,6 +310,12 @@ func (mgr *blockfileMgr) moveToNextFile() {
 	if err != nil {
 		panic(fmt.Sprintf("Could not open writer to next file: %s", err))
 	}
+	if mgr.conf.deferSync {
+		// the block store syncs only the current file
+		if err := mgr.currentFileWriter.sync(); err != nil {
+			panic(fmt.Sprintf("Could not sync current file: %s", err))
+		}
+	}
 	mgr.currentFileWriter.close()
 	err = mgr.saveCurrentInfo(cpInfo, true)
 	if err != nil {
@@ -237,6 +325,41 @@ func (mgr *blockfileMgr) moveToNextFile() {
 	mgr.updateCheckpoint(cpInfo)
 }
 
+// loadHashingAlgorithm reads the genesis block of the chain to determine the
+// hashing algorithm of its blocks.
+func (mgr *blockfileMgr) loadHashingAlgorithm() error {
+	if err := mgr.restoreBlockfile(0); err != nil {
+		return err
+	}
+	stream, err := newBlockfileStream(mgr.rootDir, 0, 0)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+	blockBytes, err := stream.nextBlockBytes()
+	if err != nil {
+		return err
+	}
+	if blockBytes == nil {
+		return errors.New("genesis block not found")
+	}
+	block, err := deserializeBlock(blockBytes)
+	if err != nil {
+		return err
+	}
+	mgr.hashingAlgorithm, err = putil.GetHashingAlgorithmFromBlock(block)
+	return err
+}
+
+// blockHash returns the hash of a block header computed with the hashing
+// algorithm of the chain.
+func (mgr *blockfileMgr) blockHash(header *common.BlockHeader) []byte {
+	if mgr.hashingAlgorithm == nil {
+		return header.Hash()
+	}
+	return header.HashWith(mgr.hashingAlgorithm)
+}
+
 func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	bcInfo := mgr.getBlockchainInfo()
 	if block.Header.Number != bcInfo.Height {
@@ -260,7 +383,14 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	if err != nil {
 		return errors.WithMessage(err, "error serializing block")
 	}
-	blockHash := block.Header.Hash()
+	if block.Header.Number == 0 {
+		hashingAlgorithm, err := putil.GetHashingAlgorithmFromBlock(block)
+		if err != nil {
+			return errors.WithMessage(err, "error determining the hashing algorithm of the chain")
+		}
+		mgr.hashingAlgorithm = hashingAlgorithm
+	}
+	blockHash := mgr.blockHash(block.Header)
 	//Get the location / offset where each transaction starts in the block and where the block ends
 	txOffsets := info.txOffsets
 	currentOffset := mgr.cpInfo.latestFileChunksize
@@ -279,7 +409,7 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	err = mgr.currentFileWriter.append(blockBytesEncodedLen, false)
 	if err == nil {
 		//append the actual block bytes to the file
-		err = mgr.currentFileWriter.append(blockBytes, true)
+		err = mgr.appendBlockBytes(blockBytes, block.Header.Number)
 	}
 	if err != nil {
 		truncateErr := mgr.currentFileWriter.truncateFile(mgr.cpInfo.latestFileChunksize)
@@ -296,6 +426,7 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 		latestFileChunksize:      currentCPInfo.latestFileChunksize + totalBytesToAppend,
 		isChainEmpty:             false,
 		lastBlockNumber:          block.Header.Number}
+	injectFault(FaultBeforeCheckpoint, block.Header.Number)
 	//save the checkpoint information in the database
 	if err = mgr.saveCurrentInfo(newCPInfo, false); err != nil {
 		truncateErr := mgr.currentFileWriter.truncateFile(currentCPInfo.latestFileChunksize)
@@ -312,6 +443,7 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	for _, txOffset := range txOffsets {
 		txOffset.loc.offset += len(blockBytesEncodedLen)
 	}
+	injectFault(FaultBeforeIndex, block.Header.Number)
 	//save the index in the database
 	if err = mgr.index.indexBlock(&blockIdxInfo{
 		blockNum: block.Header.Number, blockHash: blockHash,
@@ -322,6 +454,9 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	//update the checkpoint info (for storage) and the blockchain info (for APIs) in the manager
 	mgr.updateCheckpoint(newCPInfo)
 	mgr.updateBlockchainInfo(blockHash, block)
+	if mgr.archiver != nil {
+		mgr.archiver.notify()
+	}
 	return nil
 }
 
@@ -352,6 +487,10 @@ func (mgr *blockfileMgr) syncIndex() error {
 			return nil
 		}
 		logger.Debugf("Last block indexed [%d], Last block present in block files [%d]", lastBlockIndexed, mgr.cpInfo.lastBlockNumber)
+		if lastBlockIndexed > mgr.cpInfo.lastBlockNumber {
+			return errors.Errorf("last block indexed [%d] is beyond the last block present in block files [%d]",
+				lastBlockIndexed, mgr.cpInfo.lastBlockNumber)
+		}
 		var flp *fileLocPointer
 		if flp, err = mgr.index.getBlockLocByBlockNum(lastBlockIndexed); err != nil {
 			return err
@@ -368,7 +507,7 @@ func (mgr *blockfileMgr) syncIndex() error {
 
 	//open a blockstream to the file location that was stored in the index
 	var stream *blockStream
-	if stream, err = newBlockStream(mgr.rootDir, startFileNum, int64(startOffset), endFileNum); err != nil {
+	if stream, err = mgr.newBlockStream(startFileNum, int64(startOffset), endFileNum); err != nil {
 		return err
 	}
 	var blockBytes []byte
@@ -382,6 +521,14 @@ func (mgr *blockfileMgr) syncIndex() error {
 			return errors.Errorf("block bytes for block num = [%d] should not be nil here. The indexes for the block are already present",
 				lastBlockIndexed)
 		}
+		info, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return err
+		}
+		if info.blockHeader.Number != lastBlockIndexed {
+			return errors.Errorf("index refers to block num = [%d] at the location of block num = [%d] in block files",
+				lastBlockIndexed, info.blockHeader.Number)
+		}
 	}
 
 	//Should be at the last block already, but go ahead and loop looking for next blockBytes.
@@ -408,7 +555,7 @@ func (mgr *blockfileMgr) syncIndex() error {
 		}
 
 		//Update the blockIndexInfo with what was actually stored in file system
-		blockIdxInfo.blockHash = info.blockHeader.Hash()
+		blockIdxInfo.blockHash = mgr.blockHash(info.blockHeader)
 		blockIdxInfo.blockNum = info.blockHeader.Number
 		blockIdxInfo.flp = &fileLocPointer{fileSuffixNum: blockPlacementInfo.fileNum,
 			locPointer: locPointer{offset: int(blockPlacementInfo.blockStartOffset)}}
@@ -552,6 +699,9 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 }
 
 func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
+	if err := mgr.restoreBlockfile(lp.fileSuffixNum); err != nil {
+		return nil, err
+	}
 	stream, err := newBlockfileStream(mgr.rootDir, lp.fileSuffixNum, int64(lp.offset))
 	if err != nil {
 		return nil, err
@@ -565,6 +715,9 @@ func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
 }
 
 func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
+	if err := mgr.restoreBlockfile(lp.fileSuffixNum); err != nil {
+		return nil, err
+	}
 	filePath := deriveBlockfilePath(mgr.rootDir, lp.fileSuffixNum)
 	reader, err := newBlockfileReader(filePath)
 	if err != nil {
@@ -617,13 +770,24 @@ func scanForLastCompleteBlock(rootDir string, fileNum int, startingOffset int64)
 	defer blockStream.close()
 	var errRead error
 	var blockBytes []byte
+	var lastBlockNum uint64
+	endOffset := startingOffset
 	for {
 		blockBytes, errRead = blockStream.nextBlockBytes()
 		if blockBytes == nil || errRead != nil {
 			break
 		}
+		// a block which cannot be decoded, or which does not follow the previous block, is
+		// garbage left by a crash during block appending (e.g. the file was extended but not written)
+		info, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil || (numBlocks > 0 && info.blockHeader.Number != lastBlockNum+1) {
+			logger.Warningf("Corrupted block found at offset [%d] of block file [%d], ignoring the rest of the file", endOffset, fileNum)
+			break
+		}
+		lastBlockNum = info.blockHeader.Number
 		lastBlockBytes = blockBytes
 		numBlocks++
+		endOffset = blockStream.currentOffset
 	}
 	if errRead == ErrUnexpectedEndOfBlockfile {
 		logger.Debugf(`Error:%s
@@ -631,8 +795,8 @@ func scanForLastCompleteBlock(rootDir string, fileNum int, startingOffset int64)
 		Resetting error to nil and returning current offset as a last complete block's end offset`, errRead)
 		errRead = nil
 	}
-	logger.Debugf("scanForLastCompleteBlock(): last complete block ends at offset=[%d]", blockStream.currentOffset)
-	return lastBlockBytes, blockStream.currentOffset, numBlocks, errRead
+	logger.Debugf("scanForLastCompleteBlock(): last complete block ends at offset=[%d]", endOffset)
+	return lastBlockBytes, endOffset, numBlocks, errRead
 }
 
 // checkpointInfo
@@ -29,7 +29,7 @@ func (w *blockfileWriter) truncateFile(targetSize int) error {
 		return err
 	}
 	if fileStat.Size() > int64(targetSize) {
-		w.file.Truncate(int64(targetSize))
+		return w.file.Truncate(int64(targetSize))
 	}
 	return nil
 }
@@ -45,6 +45,10 @@ func (w *blockfileWriter) append(b []byte, sync bool) error {
 	return nil
 }
 
+func (w *blockfileWriter) sync() error {
+	return errors.Wrapf(w.file.Sync(), "error syncing block file %s", w.filePath)
+}
+
 func (w *blockfileWriter) open() error {
 	file, err := os.OpenFile(w.filePath, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0660)
 	if err != nil {
@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// BlockfileSegment is the part of a block file holding complete blocks
+type BlockfileSegment struct {
+	// Name is the name of the block file
+	Name string
+	// Size is the number of bytes at the start of the file holding complete blocks
+	Size int64
+}
+
+// ScanBlockfiles reads, in order, the blocks held by the block files of a
+// ledger found in the given directory and hands each of them to the given
+// function. It returns the block files along with the number of bytes of each
+// holding complete blocks, so that a partially written block left at the end
+// of the last file by a crash can be left out of a copy of the files.
+// The block files must not be written to while they are scanned.
+func ScanBlockfiles(ledgerDir string, handle func(block *common.Block) error) ([]BlockfileSegment, error) {
+	lastFileNum, err := retrieveLastFileSuffix(ledgerDir)
+	if err != nil {
+		return nil, err
+	}
+	var segments []BlockfileSegment
+	for fileNum := 0; fileNum <= lastFileNum; fileNum++ {
+		size, err := scanBlockfile(ledgerDir, fileNum, fileNum == lastFileNum, handle)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, BlockfileSegment{
+			Name: filepath.Base(deriveBlockfilePath(ledgerDir, fileNum)),
+			Size: size,
+		})
+	}
+	return segments, nil
+}
+
+func scanBlockfile(ledgerDir string, fileNum int, last bool, handle func(block *common.Block) error) (int64, error) {
+	stream, err := newBlockfileStream(ledgerDir, fileNum, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer stream.close()
+	for {
+		blockBytes, err := stream.nextBlockBytes()
+		if err == ErrUnexpectedEndOfBlockfile && last {
+			logger.Warningf("Ignoring a partially written block at offset [%d] of the last block file of [%s]", stream.currentOffset, ledgerDir)
+			return stream.currentOffset, nil
+		}
+		if err != nil {
+			return 0, errors.WithMessage(err, "error reading block file")
+		}
+		if blockBytes == nil {
+			return stream.currentOffset, nil
+		}
+		block, err := deserializeBlock(blockBytes)
+		if err != nil {
+			return 0, errors.WithMessage(err, "error deserializing block")
+		}
+		if err := handle(block); err != nil {
+			return 0, err
+		}
+	}
+}
@@ -20,8 +20,10 @@ import (
 	"os"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/hyperledger/fabric/protos/common"
@@ -83,3 +85,55 @@ func TestBlockFileScanSmallTxLastTxIncomplete(t *testing.T) {
 	expectedLastBlockBytes, _, err := serializeBlock(blocks[len(blocks)-2])
 	assert.Equal(t, expectedLastBlockBytes, lastBlockBytes)
 }
+
+func TestScanBlockfiles(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	bg, gb := testutil.NewBlockGenerator(t, ledgerid, false)
+	blocks := append([]*common.Block{gb}, bg.NextTestBlocks(3)...)
+	blkfileMgrWrapper.addBlocks(blocks[:2])
+	blkfileMgrWrapper.blockfileMgr.moveToNextFile()
+	blkfileMgrWrapper.addBlocks(blocks[2:])
+	blkfileMgrWrapper.close()
+
+	ledgerDir := env.provider.conf.getLedgerBlockDir(ledgerid)
+	_, firstFileSize, err := util.FileExists(deriveBlockfilePath(ledgerDir, 0))
+	assert.NoError(t, err)
+	lastFilePath := deriveBlockfilePath(ledgerDir, 1)
+	_, lastFileSize, err := util.FileExists(lastFilePath)
+	assert.NoError(t, err)
+
+	var scanned []*common.Block
+	collect := func(block *common.Block) error {
+		scanned = append(scanned, block)
+		return nil
+	}
+	segments, err := ScanBlockfiles(ledgerDir, collect)
+	assert.NoError(t, err)
+	assert.Equal(t, []BlockfileSegment{{"blockfile_000000", firstFileSize}, {"blockfile_000001", lastFileSize}}, segments)
+	assert.Len(t, scanned, len(blocks))
+	for i, block := range scanned {
+		assert.True(t, proto.Equal(blocks[i], block))
+	}
+
+	// a partially written block at the end of the last file is left out
+	assert.NoError(t, os.Truncate(lastFilePath, lastFileSize-1))
+	lastBlockBytes, _, err := serializeBlock(blocks[3])
+	assert.NoError(t, err)
+	lastBlockSize := int64(len(proto.EncodeVarint(uint64(len(lastBlockBytes))))) + int64(len(lastBlockBytes))
+	scanned = nil
+	segments, err = ScanBlockfiles(ledgerDir, collect)
+	assert.NoError(t, err)
+	assert.Equal(t, []BlockfileSegment{{"blockfile_000000", firstFileSize}, {"blockfile_000001", lastFileSize - lastBlockSize}}, segments)
+	assert.Len(t, scanned, len(blocks)-1)
+
+	// but not from another file
+	assert.NoError(t, os.Truncate(deriveBlockfilePath(ledgerDir, 0), firstFileSize-1))
+	_, err = ScanBlockfiles(ledgerDir, collect)
+	assert.Error(t, err)
+
+	_, err = ScanBlockfiles(ledgerDir, func(*common.Block) error { return errors.New("handler error") })
+	assert.EqualError(t, err, "handler error")
+}
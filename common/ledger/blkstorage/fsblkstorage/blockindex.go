@@ -27,7 +27,10 @@ const (
 	blockNumTranNumIdxKeyPrefix    = 'a'
 	blockTxIDIdxKeyPrefix          = 'b'
 	txValidationResultIdxKeyPrefix = 'v'
-	indexCheckpointKeyStr          = "indexCheckpointKey"
+	// the validation codes imported from a snapshot are kept apart from those
+	// of the block files, as the index cannot be rebuilt with them
+	importedTxValidationResultIdxKeyPrefix = 's'
+	indexCheckpointKeyStr                  = "indexCheckpointKey"
 )
 
 var indexCheckpointKey = []byte(indexCheckpointKeyStr)
@@ -42,6 +45,8 @@ type index interface {
 	getTXLocByBlockNumTranNum(blockNum uint64, tranNum uint64) (*fileLocPointer, error)
 	getBlockLocByTxID(txID string) (*fileLocPointer, error)
 	getTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	exportTxValidationCodes(handle func(txID string, code peer.TxValidationCode) error) error
+	importTxValidationCodes(next func() (string, peer.TxValidationCode, error)) error
 }
 
 type blockIdxInfo struct {
@@ -54,10 +59,10 @@ type blockIdxInfo struct {
 
 type blockIndex struct {
 	indexItemsMap map[blkstorage.IndexableAttr]bool
-	db            *leveldbhelper.DBHandle
+	db            indexStore
 }
 
-func newBlockIndex(indexConfig *blkstorage.IndexConfig, db *leveldbhelper.DBHandle) (*blockIndex, error) {
+func newBlockIndex(indexConfig *blkstorage.IndexConfig, db indexStore) (*blockIndex, error) {
 	indexItems := indexConfig.AttrsToIndex
 	logger.Debugf("newBlockIndex() - indexItems:[%s]", indexItems)
 	indexItemsMap := make(map[blkstorage.IndexableAttr]bool)
@@ -192,6 +197,18 @@ func (index *blockIndex) markDuplicateTxids(blockIdxInfo *blockIdxInfo) error {
 		if err != blkstorage.ErrNotFoundInIndex {
 			return err
 		}
+		// the transactions imported from a snapshot are only indexed by their
+		// validation codes
+		if index.indexItemsMap[blkstorage.IndexableAttrTxValidationCode] {
+			_, err := index.getTxValidationCodeByTxID(txid)
+			if err == nil {
+				txIdxInfo.isDuplicate = true
+				continue
+			}
+			if err != blkstorage.ErrNotFoundInIndex {
+				return err
+			}
+		}
 		uniqueTxids[txid] = true
 	}
 	return nil
@@ -283,6 +300,9 @@ func (index *blockIndex) getTxValidationCodeByTxID(txID string) (peer.TxValidati
 	}
 
 	raw, err := index.db.Get(constructTxValidationCodeIDKey(txID))
+	if err == nil && raw == nil {
+		raw, err = index.db.Get(constructImportedTxValidationCodeIDKey(txID))
+	}
 
 	if err != nil {
 		return peer.TxValidationCode(-1), err
@@ -318,6 +338,10 @@ func constructTxValidationCodeIDKey(txID string) []byte {
 	return append([]byte{txValidationResultIdxKeyPrefix}, []byte(txID)...)
 }
 
+func constructImportedTxValidationCodeIDKey(txID string) []byte {
+	return append([]byte{importedTxValidationResultIdxKeyPrefix}, []byte(txID)...)
+}
+
 func constructBlockNumTranNumKey(blockNum uint64, txNum uint64) []byte {
 	blkNumBytes := util.EncodeOrderPreservingVarUint64(blockNum)
 	tranNumBytes := util.EncodeOrderPreservingVarUint64(txNum)
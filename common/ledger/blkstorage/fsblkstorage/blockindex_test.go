@@ -59,6 +59,14 @@ func (i *noopIndex) getTxValidationCodeByTxID(txID string) (peer.TxValidationCod
 	return peer.TxValidationCode(-1), nil
 }
 
+func (i *noopIndex) exportTxValidationCodes(handle func(txID string, code peer.TxValidationCode) error) error {
+	return nil
+}
+
+func (i *noopIndex) importTxValidationCodes(next func() (string, peer.TxValidationCode, error)) error {
+	return nil
+}
+
 func TestBlockIndexSync(t *testing.T) {
 	testBlockIndexSync(t, 10, 5, false)
 	testBlockIndexSync(t, 10, 5, true)
@@ -20,6 +20,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/pkg/errors"
 )
 
 // blocksItr - an iterator for iterating over a sequence of blocks
@@ -54,7 +55,7 @@ func (itr *blocksItr) initStream() error {
 	if lp, err = itr.mgr.index.getBlockLocByBlockNum(itr.blockNumToRetrieve); err != nil {
 		return err
 	}
-	if itr.stream, err = newBlockStream(itr.mgr.rootDir, lp.fileSuffixNum, int64(lp.offset), -1); err != nil {
+	if itr.stream, err = itr.mgr.newBlockStream(lp.fileSuffixNum, int64(lp.offset), -1); err != nil {
 		return err
 	}
 	return nil
@@ -86,8 +87,17 @@ func (itr *blocksItr) Next() (ledger.QueryResult, error) {
 	if err != nil {
 		return nil, err
 	}
+	block, err := deserializeBlock(nextBlockBytes)
+	if err != nil {
+		return nil, err
+	}
+	// the block files of a ledger bootstrapped from a snapshot do not hold
+	// the blocks between its last config block and its last block
+	if block.Header.Number != itr.blockNumToRetrieve {
+		return nil, errors.Errorf("block [%d] is not held by the block files, which hold block [%d] next", itr.blockNumToRetrieve, block.Header.Number)
+	}
 	itr.blockNumToRetrieve++
-	return deserializeBlock(nextBlockBytes)
+	return block, nil
 }
 
 // Close releases any resources held by the iterator
@@ -16,13 +16,23 @@ limitations under the License.
 
 package fsblkstorage
 
-import "path/filepath"
+import (
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/ledger/util/rocksdbhelper"
+)
 
 const (
 	// ChainsDir is the name of the directory containing the channel ledgers.
 	ChainsDir = "chains"
 	// IndexDir is the name of the directory containing all block indexes across ledgers.
-	IndexDir                = "index"
+	IndexDir = "index"
+	// BadgerIndexDir is the name of the directory containing all block indexes across ledgers,
+	// when the indexes are maintained in badger.
+	BadgerIndexDir = "badgerindex"
+	// RocksDBIndexDir is the name of the directory containing all block indexes across ledgers,
+	// when the indexes are maintained in rocksdb.
+	RocksDBIndexDir         = "rocksdbindex"
 	defaultMaxBlockfileSize = 64 * 1024 * 1024 // bytes
 )
 
@@ -30,6 +40,12 @@ const (
 type Conf struct {
 	blockStorageDir  string
 	maxBlockfileSize int
+	deferSync        bool
+	badgerIndex      bool
+	rocksDBIndex     bool
+	rocksDBTuning    *rocksdbhelper.CompactionConf
+	archive          BlockfileArchive
+	retainedBlocks   uint64
 }
 
 // NewConf constructs new `Conf`.
@@ -38,13 +54,55 @@ func NewConf(blockStorageDir string, maxBlockfileSize int) *Conf {
 	if maxBlockfileSize <= 0 {
 		maxBlockfileSize = defaultMaxBlockfileSize
 	}
-	return &Conf{blockStorageDir, maxBlockfileSize}
+	return &Conf{blockStorageDir: blockStorageDir, maxBlockfileSize: maxBlockfileSize}
+}
+
+// NewConfWithDeferredSync constructs new `Conf` with which the blocks and
+// their indexes are not synced to disk as they are added, but when the block
+// store is synced. It is meant for block stores whose blocks are journaled elsewhere.
+func NewConfWithDeferredSync(blockStorageDir string, maxBlockfileSize int) *Conf {
+	conf := NewConf(blockStorageDir, maxBlockfileSize)
+	conf.deferSync = true
+	return conf
+}
+
+// WithBadgerIndex sets the block indexes to be maintained in badger, instead of leveldb.
+// As the writes to badger are always synced, it overrides the deferred sync of the indexes.
+func (conf *Conf) WithBadgerIndex() *Conf {
+	conf.badgerIndex = true
+	return conf
+}
+
+// WithRocksDBIndex sets the block indexes to be maintained in rocksdb, with
+// the given compaction tuning, instead of leveldb. The block store provider
+// panics if the binary was not built with the build tag `rocksdb`.
+func (conf *Conf) WithRocksDBIndex(compaction *rocksdbhelper.CompactionConf) *Conf {
+	conf.rocksDBIndex = true
+	conf.rocksDBTuning = compaction
+	return conf
+}
+
+// WithArchive sets the block files whose blocks are all at least retainedBlocks
+// below the height of their ledger to be moved to the archive, while their
+// indexes are kept locally. The archived blocks are fetched back when read.
+func (conf *Conf) WithArchive(archive BlockfileArchive, retainedBlocks uint64) *Conf {
+	conf.archive = archive
+	conf.retainedBlocks = retainedBlocks
+	return conf
 }
 
 func (conf *Conf) getIndexDir() string {
 	return filepath.Join(conf.blockStorageDir, IndexDir)
 }
 
+func (conf *Conf) getBadgerIndexDir() string {
+	return filepath.Join(conf.blockStorageDir, BadgerIndexDir)
+}
+
+func (conf *Conf) getRocksDBIndexDir() string {
+	return filepath.Join(conf.blockStorageDir, RocksDBIndexDir)
+}
+
 func (conf *Conf) getChainsDir() string {
 	return filepath.Join(conf.blockStorageDir, ChainsDir)
 }
@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// FaultPoint identifies a step of appending a block to the block store at
+// which a crash can be simulated, to exercise the recovery of the block store
+// when it is opened again
+type FaultPoint string
+
+const (
+	// FaultPartialBlock is reached once half of the bytes of the block are
+	// written to the block file
+	FaultPartialBlock FaultPoint = "partialblock"
+	// FaultBeforeCheckpoint is reached once the block is written to the block
+	// file, before the checkpoint info is saved
+	FaultBeforeCheckpoint FaultPoint = "beforecheckpoint"
+	// FaultBeforeIndex is reached once the checkpoint info is saved, before the
+	// block is indexed
+	FaultBeforeIndex FaultPoint = "beforeindex"
+)
+
+// FaultHook is invoked at each fault point with the number of the block being
+// appended. It simulates a crash by not returning, e.g. by exiting the process.
+type FaultHook func(point FaultPoint, blockNum uint64)
+
+const (
+	// FaultInjectionEnvVar is the environment variable which, set to
+	// <fault point>:<block number>, makes the process exit with FaultExitCode
+	// when the block store reaches the fault point while appending the block.
+	// It lets integration suites crash peers and orderers at will.
+	FaultInjectionEnvVar = "FABRIC_BLOCKSTORE_FAULT"
+	// FaultExitCode is the exit code of a process crashed by FaultInjectionEnvVar
+	FaultExitCode = 86
+)
+
+var faultHook FaultHook
+
+func init() {
+	value := os.Getenv(FaultInjectionEnvVar)
+	if value == "" {
+		return
+	}
+	point, blockNum, err := parseFault(value)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid value for %s: %s", FaultInjectionEnvVar, err))
+	}
+	faultHook = exitAtFault(point, blockNum)
+}
+
+// SetFaultHook sets the hook invoked at each fault point and returns the
+// previous one. It must be called before block stores are opened.
+func SetFaultHook(hook FaultHook) FaultHook {
+	previous := faultHook
+	faultHook = hook
+	return previous
+}
+
+func injectFault(point FaultPoint, blockNum uint64) {
+	if faultHook != nil {
+		faultHook(point, blockNum)
+	}
+}
+
+// appendBlockBytes appends the bytes of the block to the current block file.
+// When a fault hook is set, the bytes are appended in two halves, with the hook
+// invoked in between, so that a crash leaves a partially written block behind.
+func (mgr *blockfileMgr) appendBlockBytes(blockBytes []byte, blockNum uint64) error {
+	sync := !mgr.conf.deferSync
+	if faultHook == nil {
+		return mgr.currentFileWriter.append(blockBytes, sync)
+	}
+	half := len(blockBytes) / 2
+	if err := mgr.currentFileWriter.append(blockBytes[:half], sync); err != nil {
+		return err
+	}
+	injectFault(FaultPartialBlock, blockNum)
+	return mgr.currentFileWriter.append(blockBytes[half:], sync)
+}
+
+func parseFault(value string) (FaultPoint, uint64, error) {
+	fields := strings.Split(value, ":")
+	if len(fields) != 2 {
+		return "", 0, errors.Errorf("expected <fault point>:<block number>, got [%s]", value)
+	}
+	point := FaultPoint(fields[0])
+	switch point {
+	case FaultPartialBlock, FaultBeforeCheckpoint, FaultBeforeIndex:
+	default:
+		return "", 0, errors.Errorf("unknown fault point [%s]", fields[0])
+	}
+	blockNum, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return "", 0, errors.Wrapf(err, "invalid block number [%s]", fields[1])
+	}
+	return point, blockNum, nil
+}
+
+func exitAtFault(point FaultPoint, blockNum uint64) FaultHook {
+	return func(p FaultPoint, n uint64) {
+		if p == point && n == blockNum {
+			logger.Warningf("Injecting fault [%s] while appending block [%d], exiting", p, n)
+			os.Exit(FaultExitCode)
+		}
+	}
+}
@@ -17,12 +17,14 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"fmt"
+
 	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
-	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
 )
 
 // fsBlockStore - filesystem based implementation for `BlockStore`
@@ -34,7 +36,7 @@ type fsBlockStore struct {
 
 // NewFsBlockStore constructs a `FsBlockStore`
 func newFsBlockStore(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
-	dbHandle *leveldbhelper.DBHandle) *fsBlockStore {
+	dbHandle indexStore) *fsBlockStore {
 	return &fsBlockStore{id, conf, newBlockfileMgr(id, conf, indexConfig, dbHandle)}
 }
 
@@ -60,30 +62,50 @@ func (store *fsBlockStore) RetrieveBlocks(startNum uint64) (ledger.ResultsIterat
 
 // RetrieveBlockByHash returns the block for given block-hash
 func (store *fsBlockStore) RetrieveBlockByHash(blockHash []byte) (*common.Block, error) {
-	return store.fileMgr.retrieveBlockByHash(blockHash)
+	block, err := store.fileMgr.retrieveBlockByHash(blockHash)
+	return block, notIndexedErr(err, blkstorage.IndexableAttrBlockHash)
 }
 
 // RetrieveBlockByNumber returns the block at a given blockchain height
 func (store *fsBlockStore) RetrieveBlockByNumber(blockNum uint64) (*common.Block, error) {
-	return store.fileMgr.retrieveBlockByNumber(blockNum)
+	block, err := store.fileMgr.retrieveBlockByNumber(blockNum)
+	return block, notIndexedErr(err, blkstorage.IndexableAttrBlockNum)
 }
 
 // RetrieveTxByID returns a transaction for given transaction id
 func (store *fsBlockStore) RetrieveTxByID(txID string) (*common.Envelope, error) {
-	return store.fileMgr.retrieveTransactionByID(txID)
+	txEnvelope, err := store.fileMgr.retrieveTransactionByID(txID)
+	return txEnvelope, notIndexedErr(err, blkstorage.IndexableAttrTxID)
 }
 
 // RetrieveTxByID returns a transaction for given transaction id
 func (store *fsBlockStore) RetrieveTxByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error) {
-	return store.fileMgr.retrieveTransactionByBlockNumTranNum(blockNum, tranNum)
+	txEnvelope, err := store.fileMgr.retrieveTransactionByBlockNumTranNum(blockNum, tranNum)
+	return txEnvelope, notIndexedErr(err, blkstorage.IndexableAttrBlockNumTranNum)
 }
 
 func (store *fsBlockStore) RetrieveBlockByTxID(txID string) (*common.Block, error) {
-	return store.fileMgr.retrieveBlockByTxID(txID)
+	block, err := store.fileMgr.retrieveBlockByTxID(txID)
+	return block, notIndexedErr(err, blkstorage.IndexableAttrBlockTxID)
 }
 
 func (store *fsBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error) {
-	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
+	code, err := store.fileMgr.retrieveTxValidationCodeByTxID(txID)
+	return code, notIndexedErr(err, blkstorage.IndexableAttrTxValidationCode)
+}
+
+// notIndexedErr tells which index is missing when a retrieval requires an
+// index which is not maintained by the block store
+func notIndexedErr(err error, attr blkstorage.IndexableAttr) error {
+	if err == blkstorage.ErrAttrNotIndexed {
+		return errors.WithMessage(err, fmt.Sprintf("the %s index is disabled on this peer", attr))
+	}
+	return err
+}
+
+// Sync syncs to disk the blocks added so far, including those whose sync was deferred
+func (store *fsBlockStore) Sync() error {
+	return store.fileMgr.sync()
 }
 
 // Shutdown shuts down the block store
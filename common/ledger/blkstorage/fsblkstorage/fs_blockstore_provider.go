@@ -19,20 +19,18 @@ package fsblkstorage
 import (
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
-	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 )
 
 // FsBlockstoreProvider provides handle to block storage - this is not thread-safe
 type FsBlockstoreProvider struct {
-	conf            *Conf
-	indexConfig     *blkstorage.IndexConfig
-	leveldbProvider *leveldbhelper.Provider
+	conf               *Conf
+	indexConfig        *blkstorage.IndexConfig
+	indexStoreProvider indexStoreProvider
 }
 
 // NewProvider constructs a filesystem based block store provider
 func NewProvider(conf *Conf, indexConfig *blkstorage.IndexConfig) blkstorage.BlockStoreProvider {
-	p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir()})
-	return &FsBlockstoreProvider{conf, indexConfig, p}
+	return &FsBlockstoreProvider{conf, indexConfig, newIndexStoreProvider(conf)}
 }
 
 // CreateBlockStore simply calls OpenBlockStore
@@ -44,7 +42,7 @@ func (p *FsBlockstoreProvider) CreateBlockStore(ledgerid string) (blkstorage.Blo
 // If a blockstore is not existing, this method creates one
 // This method should be invoked only once for a particular ledgerid
 func (p *FsBlockstoreProvider) OpenBlockStore(ledgerid string) (blkstorage.BlockStore, error) {
-	indexStoreHandle := p.leveldbProvider.GetDBHandle(ledgerid)
+	indexStoreHandle := p.indexStoreProvider.GetDBHandle(ledgerid)
 	return newFsBlockStore(ledgerid, p.conf, p.indexConfig, indexStoreHandle), nil
 }
 
@@ -61,5 +59,5 @@ func (p *FsBlockstoreProvider) List() ([]string, error) {
 
 // Close closes the FsBlockstoreProvider
 func (p *FsBlockstoreProvider) Close() {
-	p.leveldbProvider.Close()
+	p.indexStoreProvider.Close()
 }
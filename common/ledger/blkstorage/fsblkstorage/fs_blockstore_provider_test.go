@@ -31,7 +31,15 @@ import (
 )
 
 func TestMultipleBlockStores(t *testing.T) {
-	env := newTestEnv(t, NewConf(testPath(), 0))
+	testMultipleBlockStores(t, NewConf(testPath(), 0))
+}
+
+func TestMultipleBlockStoresBadgerIndex(t *testing.T) {
+	testMultipleBlockStores(t, NewConf(testPath(), 0).WithBadgerIndex())
+}
+
+func testMultipleBlockStores(t *testing.T, conf *Conf) {
+	env := newTestEnv(t, conf)
 	defer env.Cleanup()
 
 	provider := env.provider
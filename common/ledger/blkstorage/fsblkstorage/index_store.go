@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"github.com/hyperledger/fabric/common/ledger/util/badgerhelper"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+)
+
+// indexStore is the key-value store of a ledger in which the block indexes
+// and the checkpoint info of the block files are maintained
+type indexStore interface {
+	Get(key []byte) ([]byte, error)
+	Put(key []byte, value []byte, sync bool) error
+	Delete(key []byte, sync bool) error
+	WriteBatch(batch *leveldbhelper.UpdateBatch, sync bool) error
+	GetIterator(startKey []byte, endKey []byte) indexStoreIterator
+	Sync() error
+}
+
+type indexStoreIterator interface {
+	Next() bool
+	Key() []byte
+	Value() []byte
+	Error() error
+	Release()
+}
+
+// indexStoreProvider provides the index stores of the ledgers, which share a single underlying db
+type indexStoreProvider interface {
+	GetDBHandle(ledgerid string) indexStore
+	Close()
+}
+
+func newIndexStoreProvider(conf *Conf) indexStoreProvider {
+	if conf.rocksDBIndex {
+		return newRocksDBIndexStoreProvider(conf)
+	}
+	if conf.badgerIndex {
+		return &badgerIndexStoreProvider{badgerhelper.NewProvider(&badgerhelper.Conf{DBPath: conf.getBadgerIndexDir()})}
+	}
+	return &levelIndexStoreProvider{leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir(), DeferSync: conf.deferSync})}
+}
+
+type levelIndexStoreProvider struct {
+	*leveldbhelper.Provider
+}
+
+func (p *levelIndexStoreProvider) GetDBHandle(ledgerid string) indexStore {
+	return &levelIndexStore{p.Provider.GetDBHandle(ledgerid)}
+}
+
+type levelIndexStore struct {
+	*leveldbhelper.DBHandle
+}
+
+func (s *levelIndexStore) GetIterator(startKey []byte, endKey []byte) indexStoreIterator {
+	return s.DBHandle.GetIterator(startKey, endKey)
+}
+
+type badgerIndexStoreProvider struct {
+	*badgerhelper.Provider
+}
+
+func (p *badgerIndexStoreProvider) GetDBHandle(ledgerid string) indexStore {
+	return &badgerIndexStore{p.Provider.GetDBHandle(ledgerid)}
+}
+
+type badgerIndexStore struct {
+	*badgerhelper.DBHandle
+}
+
+func (s *badgerIndexStore) GetIterator(startKey []byte, endKey []byte) indexStoreIterator {
+	return s.DBHandle.GetIterator(startKey, endKey)
+}
@@ -0,0 +1,13 @@
+// +build !rocksdb
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+func newRocksDBIndexStoreProvider(conf *Conf) indexStoreProvider {
+	panic("Block indexes cannot be maintained in rocksdb, as this binary was built without the rocksdb build tag")
+}
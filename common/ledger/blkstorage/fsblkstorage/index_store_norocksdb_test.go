@@ -0,0 +1,19 @@
+// +build !rocksdb
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRocksDBIndexNotBuilt(t *testing.T) {
+	assert.Panics(t, func() { NewProvider(NewConf(testPath(), 0).WithRocksDBIndex(nil), nil) })
+}
@@ -0,0 +1,43 @@
+// +build rocksdb
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/ledger/util/rocksdbhelper"
+)
+
+func newRocksDBIndexStoreProvider(conf *Conf) indexStoreProvider {
+	return &rocksDBIndexStoreProvider{rocksdbhelper.NewProvider(&rocksdbhelper.Conf{
+		DBPath:     conf.getRocksDBIndexDir(),
+		DeferSync:  conf.deferSync,
+		Compaction: conf.rocksDBTuning,
+	})}
+}
+
+type rocksDBIndexStoreProvider struct {
+	*rocksdbhelper.Provider
+}
+
+func (p *rocksDBIndexStoreProvider) GetDBHandle(ledgerid string) indexStore {
+	dbHandle, err := p.Provider.GetDBHandle(ledgerid)
+	if err != nil {
+		panic(fmt.Sprintf("Error opening the index store of ledger [%s]: %s", ledgerid, err))
+	}
+	return &rocksDBIndexStore{dbHandle}
+}
+
+type rocksDBIndexStore struct {
+	*rocksdbhelper.DBHandle
+}
+
+func (s *rocksDBIndexStore) GetIterator(startKey []byte, endKey []byte) indexStoreIterator {
+	return s.DBHandle.GetIterator(startKey, endKey)
+}
@@ -0,0 +1,19 @@
+// +build rocksdb
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/util/rocksdbhelper"
+)
+
+func TestMultipleBlockStoresRocksDBIndex(t *testing.T) {
+	testMultipleBlockStores(t, NewConf(testPath(), 0).WithRocksDBIndex(&rocksdbhelper.CompactionConf{Style: "level"}))
+}
@@ -0,0 +1,145 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+)
+
+// recoveryReport records the repairs made to the block store of a ledger when
+// it is opened after a crash
+type recoveryReport struct {
+	ledgerID string
+	// checkpointRebuilt is set when the checkpoint info was missing from the
+	// index db and was rebuilt by scanning the block files
+	checkpointRebuilt bool
+	// blocksRecovered is the number of blocks found in the block files past
+	// the checkpoint info
+	blocksRecovered int
+	// truncatedFile and truncatedBytes describe the partially written or
+	// corrupted block removed from the end of the current block file
+	truncatedFile  string
+	truncatedBytes int64
+	// indexRebuilt is set when the index could not be synced with the block
+	// files and was rebuilt from them
+	indexRebuilt bool
+	// blocksIndexed is the number of blocks which were missing from the index,
+	// or the number of blocks indexed when the index is rebuilt
+	blocksIndexed uint64
+}
+
+// repairs describes the repairs made
+func (r *recoveryReport) repairs() []string {
+	var repairs []string
+	if r.checkpointRebuilt {
+		repairs = append(repairs, "rebuilt the checkpoint info from the block files")
+	}
+	if r.blocksRecovered > 0 {
+		repairs = append(repairs, fmt.Sprintf("recovered [%d] blocks missing from the checkpoint info", r.blocksRecovered))
+	}
+	if r.truncatedBytes > 0 {
+		repairs = append(repairs, fmt.Sprintf("truncated [%d] bytes of a partially written block from file [%s]", r.truncatedBytes, r.truncatedFile))
+	}
+	switch {
+	case r.indexRebuilt:
+		repairs = append(repairs, fmt.Sprintf("rebuilt the index of [%d] blocks from the block files", r.blocksIndexed))
+	case r.blocksIndexed > 0:
+		repairs = append(repairs, fmt.Sprintf("indexed [%d] blocks missing from the index", r.blocksIndexed))
+	}
+	return repairs
+}
+
+func (r *recoveryReport) log() {
+	repairs := r.repairs()
+	if len(repairs) == 0 {
+		logger.Debugf("Block store of ledger [%s] did not need repairs", r.ledgerID)
+		return
+	}
+	logger.Warningf("Block store of ledger [%s] was not closed cleanly and was repaired: %s", r.ledgerID, strings.Join(repairs, "; "))
+}
+
+// checkpointAheadOfBlockFiles returns true if the checkpoint info refers to
+// bytes missing from the current block file. This happens after a crash when
+// the syncs of the block store are deferred, as the checkpoint info may reach
+// the disk before the block file does.
+func checkpointAheadOfBlockFiles(rootDir string, cpInfo *checkpointInfo) bool {
+	filePath := deriveBlockfilePath(rootDir, cpInfo.latestFileChunkSuffixNum)
+	_, size, err := util.FileExists(filePath)
+	if err != nil {
+		panic(fmt.Sprintf("Error in checking whether file [%s] exists: %s", filePath, err))
+	}
+	return size < int64(cpInfo.latestFileChunksize)
+}
+
+// recoverIndex syncs the index with the block files. If the index cannot be
+// synced, e.g. because it refers to blocks which were truncated from the block
+// files, it is dropped and rebuilt from the block files.
+func (mgr *blockfileMgr) recoverIndex(report *recoveryReport) error {
+	lastBlockIndexed, indexEmpty, err := mgr.lastBlockIndexed()
+	if err != nil {
+		return err
+	}
+	if err := mgr.syncIndex(); err != nil {
+		logger.Warningf("Could not sync the index of ledger [%s] with the block files, rebuilding it: %s", report.ledgerID, err)
+		if err := mgr.resetIndex(); err != nil {
+			return err
+		}
+		report.indexRebuilt = true
+		indexEmpty = true
+		if err := mgr.syncIndex(); err != nil {
+			return err
+		}
+	}
+
+	newLastBlockIndexed, newIndexEmpty, err := mgr.lastBlockIndexed()
+	switch {
+	case err != nil:
+		return err
+	case newIndexEmpty:
+		// nothing is indexed when indexing is disabled
+	case indexEmpty:
+		report.blocksIndexed = newLastBlockIndexed + 1
+	default:
+		report.blocksIndexed = newLastBlockIndexed - lastBlockIndexed
+	}
+	return nil
+}
+
+func (mgr *blockfileMgr) lastBlockIndexed() (uint64, bool, error) {
+	lastBlockIndexed, err := mgr.index.getLastBlockIndexed()
+	if err == errIndexEmpty {
+		return 0, true, nil
+	}
+	return lastBlockIndexed, false, err
+}
+
+// resetIndex deletes all the entries of the index which can be rebuilt from
+// the block files. The checkpoint info, the number of archived block files and
+// the validation codes imported from a snapshot are kept, as the block files
+// do not hold them.
+func (mgr *blockfileMgr) resetIndex() error {
+	itr := mgr.db.GetIterator(nil, nil)
+	defer itr.Release()
+	batch := leveldbhelper.NewUpdateBatch()
+	for itr.Next() {
+		key := itr.Key()
+		if bytes.Equal(key, blkMgrInfoKey) || bytes.Equal(key, archivedFilesKey) ||
+			key[0] == importedTxValidationResultIdxKeyPrefix {
+			continue
+		}
+		batch.Delete(append([]byte(nil), key...))
+	}
+	if err := itr.Error(); err != nil {
+		return err
+	}
+	return mgr.db.WriteBatch(batch, true)
+}
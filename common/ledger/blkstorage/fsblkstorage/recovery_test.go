@@ -0,0 +1,250 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+type simulatedCrash struct{}
+
+func TestRecoveryAtFaultPoints(t *testing.T) {
+	testRecoveryAtFaultPoint(t, FaultPartialBlock, 5)
+	testRecoveryAtFaultPoint(t, FaultBeforeCheckpoint, 6)
+	testRecoveryAtFaultPoint(t, FaultBeforeIndex, 6)
+}
+
+func testRecoveryAtFaultPoint(t *testing.T, point FaultPoint, expectedHeight uint64) {
+	t.Run(string(point), func(t *testing.T) {
+		env := newTestEnv(t, NewConf(testPath(), 0))
+		defer env.Cleanup()
+		ledgerid := "testLedger"
+		blocks := testutil.ConstructTestBlocks(t, 10)
+
+		previous := SetFaultHook(func(p FaultPoint, blockNum uint64) {
+			if p == point && blockNum == 5 {
+				panic(simulatedCrash{})
+			}
+		})
+		defer SetFaultHook(previous)
+
+		blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+		blkfileMgrWrapper.addBlocks(blocks[:5])
+		assert.PanicsWithValue(t, simulatedCrash{}, func() { blkfileMgrWrapper.blockfileMgr.addBlock(blocks[5]) })
+		blkfileMgrWrapper.close()
+
+		// simulate a start after the crash
+		SetFaultHook(nil)
+		blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+		defer blkfileMgrWrapper.close()
+		assert.Equal(t, expectedHeight, blkfileMgrWrapper.blockfileMgr.getBlockchainInfo().Height)
+		blkfileMgrWrapper.testGetBlockByNumber(blocks[:expectedHeight], 0)
+
+		blkfileMgrWrapper.addBlocks(blocks[expectedHeight:])
+		testBlockfileMgrBlockIterator(t, blkfileMgrWrapper.blockfileMgr, 0, len(blocks)-1, blocks)
+	})
+}
+
+func TestRecoveryCorruptedBlock(t *testing.T) {
+	testRecoveryCorruptedBlock(t, false)
+	testRecoveryCorruptedBlock(t, true)
+}
+
+func testRecoveryCorruptedBlock(t *testing.T, clearCheckpoint bool) {
+	t.Run(fmt.Sprintf("clearCheckpoint=%t", clearCheckpoint), func(t *testing.T) {
+		env := newTestEnv(t, NewConf(testPath(), 0))
+		defer env.Cleanup()
+		ledgerid := "testLedger"
+		blocks := testutil.ConstructTestBlocks(t, 10)
+
+		blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+		blkfileMgrWrapper.addBlocks(blocks[:3])
+		cpInfo := blkfileMgrWrapper.blockfileMgr.cpInfo
+		blkfileMgrWrapper.addBlocks(blocks[3:5])
+		filePath := deriveBlockfilePath(env.provider.conf.getLedgerBlockDir(ledgerid), 0)
+		_, fileSize, err := util.FileExists(filePath)
+		assert.NoError(t, err)
+
+		// simulate a crash which extended the file without writing the block
+		corruptedBlockBytes := append(proto.EncodeVarint(100), make([]byte, 100)...)
+		assert.NoError(t, blkfileMgrWrapper.blockfileMgr.currentFileWriter.append(corruptedBlockBytes, true))
+		blkfileMgrWrapper.close()
+
+		indexStore := env.provider.indexStoreProvider.GetDBHandle(ledgerid)
+		if clearCheckpoint {
+			// the checkpoint info is rebuilt by scanning the block files
+			assert.NoError(t, indexStore.Delete(blkMgrInfoKey, true))
+		} else {
+			// the blocks past the checkpoint info are recovered by scanning the block file
+			b, err := cpInfo.marshal()
+			assert.NoError(t, err)
+			assert.NoError(t, indexStore.Put(blkMgrInfoKey, b, true))
+		}
+
+		blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+		defer blkfileMgrWrapper.close()
+		assert.Equal(t, uint64(5), blkfileMgrWrapper.blockfileMgr.getBlockchainInfo().Height)
+		_, newFileSize, err := util.FileExists(filePath)
+		assert.NoError(t, err)
+		assert.Equal(t, fileSize, newFileSize)
+
+		blkfileMgrWrapper.addBlocks(blocks[5:])
+		testBlockfileMgrBlockIterator(t, blkfileMgrWrapper.blockfileMgr, 0, len(blocks)-1, blocks)
+	})
+}
+
+func TestRecoveryIndexRebuilt(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	blocks := testutil.ConstructTestBlocks(t, 10)
+
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.addBlocks(blocks[:5])
+	blkfileMgrWrapper.close()
+
+	// simulate an index which refers to blocks missing from the block files
+	indexStore := env.provider.indexStoreProvider.GetDBHandle(ledgerid)
+	assert.NoError(t, indexStore.Put(indexCheckpointKey, encodeBlockNum(7), true))
+	assert.NoError(t, indexStore.Put(constructTxIDKey("missingTx"), []byte("missingTxLoc"), true))
+
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	defer blkfileMgrWrapper.close()
+	lastBlockIndexed, err := blkfileMgrWrapper.blockfileMgr.index.getLastBlockIndexed()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), lastBlockIndexed)
+	missingTxLoc, err := indexStore.Get(constructTxIDKey("missingTx"))
+	assert.NoError(t, err)
+	assert.Nil(t, missingTxLoc)
+	blkfileMgrWrapper.testGetBlockByHash(blocks[:5])
+
+	blkfileMgrWrapper.addBlocks(blocks[5:])
+	testBlockfileMgrBlockIterator(t, blkfileMgrWrapper.blockfileMgr, 0, len(blocks)-1, blocks)
+}
+
+func TestRecoveryIndexRebuiltFromSnapshot(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	bg, gb := testutil.NewBlockGenerator(t, ledgerid, false)
+	blocks := append([]*common.Block{gb}, bg.NextTestBlocks(4)...)
+
+	// the ledger is bootstrapped from a snapshot of the first and last blocks,
+	// and the validation codes of the transactions of the other blocks
+	assert.NoError(t, os.MkdirAll(env.provider.conf.getLedgerBlockDir(ledgerid), 0755))
+	_, err := WriteBlockfiles(env.provider.conf.getLedgerBlockDir(ledgerid), []*common.Block{gb, blocks[4]})
+	assert.NoError(t, err)
+	replayed, err := extractTxID(blocks[2].Data.Data[0])
+	assert.NoError(t, err)
+	imported := []string{replayed}
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	err = blkfileMgrWrapper.blockfileMgr.index.importTxValidationCodes(func() (string, peer.TxValidationCode, error) {
+		if len(imported) == 0 {
+			return "", 0, io.EOF
+		}
+		txID := imported[0]
+		imported = imported[1:]
+		return txID, peer.TxValidationCode_VALID, nil
+	})
+	assert.NoError(t, err)
+	blkfileMgrWrapper.close()
+
+	// simulate an index which refers to blocks missing from the block files
+	indexStore := env.provider.indexStoreProvider.GetDBHandle(ledgerid)
+	assert.NoError(t, indexStore.Put(indexCheckpointKey, encodeBlockNum(7), true))
+
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	defer blkfileMgrWrapper.close()
+	lastBlockIndexed, err := blkfileMgrWrapper.blockfileMgr.index.getLastBlockIndexed()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), lastBlockIndexed)
+	code, err := blkfileMgrWrapper.blockfileMgr.index.getTxValidationCodeByTxID(replayed)
+	assert.NoError(t, err)
+	assert.Equal(t, peer.TxValidationCode_VALID, code)
+
+	// and a replay of an imported transaction is still told apart
+	next := bg.NextBlockWithTxid([][]byte{[]byte("value")}, []string{replayed})
+	next.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = []byte{byte(peer.TxValidationCode_DUPLICATE_TXID)}
+	blkfileMgrWrapper.addBlocks([]*common.Block{next})
+	code, err = blkfileMgrWrapper.blockfileMgr.index.getTxValidationCodeByTxID(replayed)
+	assert.NoError(t, err)
+	assert.Equal(t, peer.TxValidationCode_VALID, code)
+}
+
+func TestRecoveryDeferredSync(t *testing.T) {
+	env := newTestEnv(t, NewConfWithDeferredSync(testPath(), 0))
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	blocks := testutil.ConstructTestBlocks(t, 10)
+
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	blkfileMgrWrapper.addBlocks(blocks[:5])
+	assert.NoError(t, blkfileMgrWrapper.blockfileMgr.sync())
+	filePath := deriveBlockfilePath(env.provider.conf.getLedgerBlockDir(ledgerid), 0)
+	_, syncedSize, err := util.FileExists(filePath)
+	assert.NoError(t, err)
+	blkfileMgrWrapper.addBlocks(blocks[5:8])
+	blkfileMgrWrapper.close()
+
+	// simulate a crash which lost the unsynced blocks from the block file but
+	// not the checkpoint info and the index
+	assert.NoError(t, os.Truncate(filePath, syncedSize))
+
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	defer blkfileMgrWrapper.close()
+	assert.Equal(t, uint64(5), blkfileMgrWrapper.blockfileMgr.getBlockchainInfo().Height)
+	lastBlockIndexed, err := blkfileMgrWrapper.blockfileMgr.index.getLastBlockIndexed()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), lastBlockIndexed)
+
+	blkfileMgrWrapper.addBlocks(blocks[5:])
+	testBlockfileMgrBlockIterator(t, blkfileMgrWrapper.blockfileMgr, 0, len(blocks)-1, blocks)
+	blkfileMgrWrapper.testGetBlockByHash(blocks)
+}
+
+func TestRecoveryReport(t *testing.T) {
+	report := &recoveryReport{ledgerID: "testLedger"}
+	assert.Empty(t, report.repairs())
+
+	report.blocksRecovered = 2
+	report.truncatedFile = "blockfile_000000"
+	report.truncatedBytes = 10
+	report.blocksIndexed = 7
+	assert.Equal(t, []string{
+		"recovered [2] blocks missing from the checkpoint info",
+		"truncated [10] bytes of a partially written block from file [blockfile_000000]",
+		"indexed [7] blocks missing from the index",
+	}, report.repairs())
+
+	report.indexRebuilt = true
+	assert.Equal(t, "rebuilt the index of [7] blocks from the block files", report.repairs()[2])
+}
+
+func TestParseFault(t *testing.T) {
+	point, blockNum, err := parseFault("beforeindex:12")
+	assert.NoError(t, err)
+	assert.Equal(t, FaultBeforeIndex, point)
+	assert.Equal(t, uint64(12), blockNum)
+
+	_, _, err = parseFault("beforeindex")
+	assert.EqualError(t, err, "expected <fault point>:<block number>, got [beforeindex]")
+	_, _, err = parseFault("afterindex:12")
+	assert.EqualError(t, err, "unknown fault point [afterindex]")
+	_, _, err = parseFault("beforeindex:twelve")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid block number [twelve]")
+}
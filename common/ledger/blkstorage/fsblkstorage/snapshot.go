@@ -0,0 +1,137 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// maxImportBatchSize bounds the number of index entries written at once when
+// the validation codes of transactions are imported
+const maxImportBatchSize = 1000
+
+// WriteBlockfiles writes each of the given blocks, in order, to a block file
+// of its own in the ledger directory, and returns the names of the files. It
+// lets the block store of a ledger bootstrapped from a snapshot start with the
+// blocks its peer needs rather than with the whole chain: the store indexes
+// the blocks by their numbers, and starts at the height following the last
+// one. The blocks do not share a file, as the store takes a block which does
+// not follow the previous block of its file for garbage left by a crash.
+// The first block must be a config block, from which the store determines
+// the hashing algorithm of the chain.
+func WriteBlockfiles(ledgerDir string, blocks []*common.Block) ([]string, error) {
+	var names []string
+	for i, block := range blocks {
+		path := deriveBlockfilePath(ledgerDir, i)
+		if err := writeBlockfile(path, block); err != nil {
+			return nil, err
+		}
+		names = append(names, filepath.Base(path))
+	}
+	return names, nil
+}
+
+func writeBlockfile(path string, block *common.Block) error {
+	writer, err := newBlockfileWriter(path)
+	if err != nil {
+		return errors.Wrapf(err, "error opening block file [%s]", path)
+	}
+	defer writer.close()
+	blockBytes, _, err := serializeBlock(block)
+	if err != nil {
+		return errors.WithMessage(err, "error serializing block")
+	}
+	if err := writer.append(proto.EncodeVarint(uint64(len(blockBytes))), false); err != nil {
+		return errors.Wrapf(err, "error writing block file [%s]", path)
+	}
+	if err := writer.append(blockBytes, true); err != nil {
+		return errors.Wrapf(err, "error writing block file [%s]", path)
+	}
+	return nil
+}
+
+// ExportTxValidationCodes hands the id and the validation code of each
+// transaction indexed by the store to the given function
+func (store *fsBlockStore) ExportTxValidationCodes(handle func(txID string, code peer.TxValidationCode) error) error {
+	err := store.fileMgr.index.exportTxValidationCodes(handle)
+	return notIndexedErr(err, blkstorage.IndexableAttrTxValidationCode)
+}
+
+// ImportTxValidationCodes indexes the validation codes of transactions which
+// the block files of the store do not hold, such as the transactions
+// committed before the snapshot the ledger was bootstrapped from, until the
+// given function returns io.EOF. The transactions can then be told apart from
+// new ones by their ids, although their envelopes cannot be retrieved. The
+// codes survive a rebuild of the index, since the block files cannot restore
+// them.
+func (store *fsBlockStore) ImportTxValidationCodes(next func() (string, peer.TxValidationCode, error)) error {
+	err := store.fileMgr.index.importTxValidationCodes(next)
+	return notIndexedErr(err, blkstorage.IndexableAttrTxValidationCode)
+}
+
+func (index *blockIndex) exportTxValidationCodes(handle func(txID string, code peer.TxValidationCode) error) error {
+	if !index.indexItemsMap[blkstorage.IndexableAttrTxValidationCode] {
+		return blkstorage.ErrAttrNotIndexed
+	}
+	// the codes imported from the snapshot the ledger was bootstrapped from
+	// are exported as well
+	for _, prefix := range []byte{txValidationResultIdxKeyPrefix, importedTxValidationResultIdxKeyPrefix} {
+		if err := index.exportTxValidationCodesWithPrefix(prefix, handle); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (index *blockIndex) exportTxValidationCodesWithPrefix(prefix byte, handle func(txID string, code peer.TxValidationCode) error) error {
+	itr := index.db.GetIterator([]byte{prefix}, []byte{prefix + 1})
+	defer itr.Release()
+	for itr.Next() {
+		if len(itr.Value()) != 1 {
+			return errors.New("invalid value in indexItems")
+		}
+		if err := handle(string(itr.Key()[1:]), peer.TxValidationCode(int32(itr.Value()[0]))); err != nil {
+			return err
+		}
+	}
+	return itr.Error()
+}
+
+func (index *blockIndex) importTxValidationCodes(next func() (string, peer.TxValidationCode, error)) error {
+	if !index.indexItemsMap[blkstorage.IndexableAttrTxValidationCode] {
+		return blkstorage.ErrAttrNotIndexed
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	for {
+		txID, code, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		batch.Put(constructImportedTxValidationCodeIDKey(txID), []byte{byte(code)})
+		if len(batch.KVs) == maxImportBatchSize {
+			if err := index.db.WriteBatch(batch, false); err != nil {
+				return err
+			}
+			batch = leveldbhelper.NewUpdateBatch()
+		}
+	}
+	if err := index.db.WriteBatch(batch, false); err != nil {
+		return err
+	}
+	return index.db.Sync()
+}
@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockStoreFromSnapshot(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	ledgerid := "testLedger"
+	bg, gb := testutil.NewBlockGenerator(t, ledgerid, false)
+	blocks := append([]*common.Block{gb}, bg.NextTestBlocks(4)...)
+	source, err := env.provider.OpenBlockStore(ledgerid)
+	assert.NoError(t, err)
+	for _, block := range blocks {
+		assert.NoError(t, source.AddBlock(block))
+	}
+
+	codes := map[string]peer.TxValidationCode{}
+	err = source.(*fsBlockStore).ExportTxValidationCodes(func(txID string, code peer.TxValidationCode) error {
+		codes[txID] = code
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, codes, 1+4*10)
+
+	// the block store of the snapshot holds the genesis block and the last one
+	snapshotid := "snapshotLedger"
+	snapshotDir := env.provider.conf.getLedgerBlockDir(snapshotid)
+	assert.NoError(t, os.MkdirAll(snapshotDir, 0755))
+	names, err := WriteBlockfiles(snapshotDir, []*common.Block{gb, blocks[4]})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"blockfile_000000", "blockfile_000001"}, names)
+
+	store, err := env.provider.OpenBlockStore(snapshotid)
+	assert.NoError(t, err)
+	bcInfo, err := store.GetBlockchainInfo()
+	assert.NoError(t, err)
+	sourceInfo, err := source.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(sourceInfo, bcInfo))
+
+	for _, num := range []uint64{0, 4} {
+		block, err := store.RetrieveBlockByNumber(num)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(blocks[num], block))
+	}
+	_, err = store.RetrieveBlockByNumber(2)
+	assert.Error(t, err)
+
+	itr, err := store.RetrieveBlocks(0)
+	assert.NoError(t, err)
+	_, err = itr.Next()
+	assert.NoError(t, err)
+	_, err = itr.Next()
+	assert.EqualError(t, err, "block [1] is not held by the block files, which hold block [4] next")
+	itr.Close()
+
+	// the validation codes of the transactions left out can be imported
+	var imported []string
+	for txID := range codes {
+		imported = append(imported, txID)
+	}
+	err = store.(*fsBlockStore).ImportTxValidationCodes(func() (string, peer.TxValidationCode, error) {
+		if len(imported) == 0 {
+			return "", 0, io.EOF
+		}
+		txID := imported[0]
+		imported = imported[1:]
+		return txID, codes[txID], nil
+	})
+	assert.NoError(t, err)
+	for txID, code := range codes {
+		retrieved, err := store.RetrieveTxValidationCodeByTxID(txID)
+		assert.NoError(t, err)
+		assert.Equal(t, code, retrieved)
+	}
+
+	// and the chain goes on from the last block, without overwriting the
+	// validation codes of the transactions replayed
+	replayed, err := extractTxID(blocks[2].Data.Data[0])
+	assert.NoError(t, err)
+	next := bg.NextBlockWithTxid([][]byte{[]byte("value")}, []string{replayed})
+	next.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = []byte{byte(peer.TxValidationCode_DUPLICATE_TXID)}
+	assert.NoError(t, store.AddBlock(next))
+	block, err := store.RetrieveBlockByNumber(5)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(next, block))
+	code, err := store.RetrieveTxValidationCodeByTxID(replayed)
+	assert.NoError(t, err)
+	assert.Equal(t, codes[replayed], code)
+}
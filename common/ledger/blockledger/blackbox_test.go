@@ -184,17 +184,15 @@ func testMultichain(lf ledgerTestFactory, t *testing.T) {
 	chain1 := "chain1"
 	chain2 := "chain2"
 
-	c1p1 := []byte("c1 payload1")
 	c1p2 := []byte("c1 payload2")
 
-	c2p1 := []byte("c2 payload1")
-
 	c1, err := f.GetOrCreate(chain1)
 	if err != nil {
 		t.Fatalf("Error creating chain1: %s", err)
 	}
 
-	c1.Append(blockledger.CreateNextBlock(c1, []*cb.Envelope{{Payload: c1p1}}))
+	// the genesis blocks carry no transaction, so that the chains are hashed with SHA256
+	c1.Append(blockledger.CreateNextBlock(c1, nil))
 	c1b1 := blockledger.CreateNextBlock(c1, []*cb.Envelope{{Payload: c1p2}})
 	c1.Append(c1b1)
 
@@ -206,7 +204,7 @@ func testMultichain(lf ledgerTestFactory, t *testing.T) {
 	if err != nil {
 		t.Fatalf("Error creating chain2: %s", err)
 	}
-	c2b0 := c2.Append(blockledger.CreateNextBlock(c2, []*cb.Envelope{{Payload: c2p1}}))
+	c2b0 := c2.Append(blockledger.CreateNextBlock(c2, nil))
 
 	if c2.Height() != 1 {
 		t.Fatalf("Block height for c2 should be 1")
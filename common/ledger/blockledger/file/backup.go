@@ -0,0 +1,98 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fileledger
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/ledger/backup"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// BackupLedger writes a backup of the block files of the ledger of the given
+// chain, found in the given directory, to the output directory, which must
+// not exist or be empty. The ledgers must not be open. The block index is not
+// backed up, it is rebuilt from the blocks when the restored ledger is opened.
+func BackupLedger(directory, chainID, outputDir string) (*backup.Manifest, error) {
+	if err := checkIndexNotOpen(directory); err != nil {
+		return nil, err
+	}
+	ledgerDir := filepath.Join(directory, fsblkstorage.ChainsDir, chainID)
+	exists, _, err := util.FileExists(ledgerDir)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.Errorf("ledger of chain [%s] does not exist", chainID)
+	}
+	if err := backup.CreateEmptyDir(outputDir); err != nil {
+		return nil, err
+	}
+
+	manifest, err := backup.BackupBlockfiles(ledgerDir, outputDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := backup.WriteManifest(outputDir, manifest); err != nil {
+		return nil, err
+	}
+	logger.Infof("Backed up ledger [%s] at height [%d] to [%s]", chainID, manifest.Height, outputDir)
+	return manifest, nil
+}
+
+// RestoreLedger restores the ledger backed up in the given backup directory
+// to the given directory, after verifying the backup. The ledger must not
+// exist and the ledgers must not be open.
+func RestoreLedger(directory, backupDir string) (*backup.Manifest, error) {
+	manifest, _, err := backup.Verify(backupDir)
+	if err != nil {
+		return nil, err
+	}
+	chainID := manifest.LedgerID
+	if err := checkIndexNotOpen(directory); err != nil {
+		return nil, err
+	}
+	// a stale checkpoint in the index would hide the restored blocks
+	p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: filepath.Join(directory, fsblkstorage.IndexDir)})
+	itr := p.GetDBHandle(chainID).GetIterator(nil, nil)
+	indexed := itr.Next()
+	itr.Release()
+	p.Close()
+	if indexed {
+		return nil, errors.Errorf("the block index holds entries of chain [%s]", chainID)
+	}
+
+	ledgerDir := filepath.Join(directory, fsblkstorage.ChainsDir, chainID)
+	if exists, _, err := util.FileExists(ledgerDir); err != nil || exists {
+		return nil, errors.Errorf("ledger of chain [%s] already exists", chainID)
+	}
+	if err := backup.RestoreBlockfiles(backupDir, ledgerDir); err != nil {
+		// leave no partially restored ledger behind, so that the restore can be retried
+		if removeErr := os.RemoveAll(ledgerDir); removeErr != nil {
+			logger.Errorf("Error removing the block files of ledger [%s]: %s", chainID, removeErr)
+		}
+		return nil, err
+	}
+	logger.Infof("Restored ledger [%s] at height [%d] from [%s]", chainID, manifest.Height, backupDir)
+	return manifest, nil
+}
+
+// checkIndexNotOpen fails if the block index of the ledgers found in the
+// given directory is held by a running process
+func checkIndexNotOpen(directory string) error {
+	path := filepath.Join(directory, fsblkstorage.IndexDir)
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return errors.Wrapf(err, "cannot open the block index at [%s], make sure the orderer is stopped", path)
+	}
+	return db.Close()
+}
@@ -0,0 +1,67 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fileledger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fileledger-backup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	ledgerDir := filepath.Join(dir, "ledger")
+	backupDir := filepath.Join(dir, "backup")
+
+	bg, gb := testutil.NewBlockGenerator(t, "testchain", false)
+	flf := New(ledgerDir)
+	fl, err := flf.GetOrCreate("testchain")
+	assert.NoError(t, err)
+	assert.NoError(t, fl.Append(gb))
+	for _, block := range bg.NextTestBlocks(3) {
+		assert.NoError(t, fl.Append(block))
+	}
+
+	// the ledgers are open
+	_, err = BackupLedger(ledgerDir, "testchain", backupDir)
+	assert.Contains(t, err.Error(), "make sure the orderer is stopped")
+	flf.Close()
+
+	_, err = BackupLedger(ledgerDir, "otherchain", backupDir)
+	assert.EqualError(t, err, "ledger of chain [otherchain] does not exist")
+	manifest, err := BackupLedger(ledgerDir, "testchain", backupDir)
+	assert.NoError(t, err)
+	assert.Equal(t, "testchain", manifest.LedgerID)
+	assert.Equal(t, uint64(4), manifest.Height)
+
+	_, err = RestoreLedger(ledgerDir, backupDir)
+	assert.EqualError(t, err, "the block index holds entries of chain [testchain]")
+
+	restoredDir := filepath.Join(dir, "restored")
+	manifest, err = RestoreLedger(restoredDir, backupDir)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), manifest.Height)
+	_, err = RestoreLedger(restoredDir, backupDir)
+	assert.Error(t, err)
+
+	flf = New(restoredDir)
+	defer flf.Close()
+	assert.Equal(t, []string{"testchain"}, flf.ChainIDs())
+	fl, err = flf.GetOrCreate("testchain")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), fl.Height())
+	block := blockledger.GetBlock(fl, 3)
+	assert.NotNil(t, block)
+	assert.Equal(t, uint64(3), block.Header.Number)
+}
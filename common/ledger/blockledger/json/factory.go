@@ -15,6 +15,7 @@ import (
 
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
 
@@ -95,7 +96,15 @@ func (jl *jsonLedger) initializeBlockHeight() {
 	if block == nil {
 		logger.Panicf("Error reading block %d", jl.height-1)
 	}
-	jl.lastHash = block.Header.Hash()
+	genesis, found := jl.readBlock(0)
+	if !found || genesis == nil {
+		logger.Panicf("Error reading genesis block")
+	}
+	jl.hashingAlgorithm, err = utils.GetHashingAlgorithmFromBlock(genesis)
+	if err != nil {
+		logger.Panicf("Could not determine the hashing algorithm of the chain: %s", err)
+	}
+	jl.lastHash = jl.blockHash(block.Header)
 }
 
 // ChainIDs returns the chain IDs the factory is aware of
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
 
@@ -48,6 +49,8 @@ type jsonLedger struct {
 	height    uint64
 	lastHash  []byte
 	marshaler *jsonpb.Marshaler
+	// hashingAlgorithm is the block hashing algorithm specified by the genesis block
+	hashingAlgorithm func([]byte) []byte
 
 	mutex  sync.Mutex
 	signal chan struct{}
@@ -126,6 +129,15 @@ func (jl *jsonLedger) Height() uint64 {
 	return jl.height
 }
 
+// blockHash returns the hash of a block header computed with the hashing
+// algorithm of the chain.
+func (jl *jsonLedger) blockHash(header *cb.BlockHeader) []byte {
+	if jl.hashingAlgorithm == nil {
+		return header.Hash()
+	}
+	return header.HashWith(jl.hashingAlgorithm)
+}
+
 // Append appends a new block to the ledger
 func (jl *jsonLedger) Append(block *cb.Block) error {
 	if block.Header.Number != jl.height {
@@ -136,8 +148,16 @@ func (jl *jsonLedger) Append(block *cb.Block) error {
 		return errors.Errorf("block should have had previous hash of %x but was %x", jl.lastHash, block.Header.PreviousHash)
 	}
 
+	if block.Header.Number == 0 {
+		hashingAlgorithm, err := utils.GetHashingAlgorithmFromBlock(block)
+		if err != nil {
+			return err
+		}
+		jl.hashingAlgorithm = hashingAlgorithm
+	}
+
 	jl.writeBlock(block)
-	jl.lastHash = block.Header.Hash()
+	jl.lastHash = jl.blockHash(block.Header)
 	jl.height++
 
 	// Manage the signal channel under lock to avoid race with read in Next
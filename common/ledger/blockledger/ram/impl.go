@@ -14,6 +14,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
 
@@ -50,6 +51,8 @@ type ramLedger struct {
 	size    int
 	oldest  *simpleList
 	newest  *simpleList
+	// hashingAlgorithm is the block hashing algorithm specified by the genesis block
+	hashingAlgorithm func([]byte) []byte
 }
 
 // Next blocks until there is a new block available, or returns an error if the
@@ -142,6 +145,15 @@ func (rl *ramLedger) Height() uint64 {
 	return rl.newest.block.Header.Number + 1
 }
 
+// blockHash returns the hash of a block header computed with the hashing
+// algorithm of the chain.
+func (rl *ramLedger) blockHash(header *cb.BlockHeader) []byte {
+	if rl.hashingAlgorithm == nil {
+		return header.Hash()
+	}
+	return header.HashWith(rl.hashingAlgorithm)
+}
+
 // Append appends a new block to the ledger
 func (rl *ramLedger) Append(block *cb.Block) error {
 	rl.lock.Lock()
@@ -153,10 +165,19 @@ func (rl *ramLedger) Append(block *cb.Block) error {
 	}
 
 	if rl.newest.block.Header.Number+1 != 0 { // Skip this check for genesis block insertion
-		if !bytes.Equal(block.Header.PreviousHash, rl.newest.block.Header.Hash()) {
+		previousHash := rl.blockHash(rl.newest.block.Header)
+		if !bytes.Equal(block.Header.PreviousHash, previousHash) {
 			return errors.Errorf("block should have had previous hash of %x but was %x",
-				rl.newest.block.Header.Hash(), block.Header.PreviousHash)
+				previousHash, block.Header.PreviousHash)
+		}
+	}
+
+	if block.Header.Number == 0 {
+		hashingAlgorithm, err := utils.GetHashingAlgorithmFromBlock(block)
+		if err != nil {
+			return err
 		}
+		rl.hashingAlgorithm = hashingAlgorithm
 	}
 
 	rl.appendBlock(block)
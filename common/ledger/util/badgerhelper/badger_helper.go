@@ -0,0 +1,278 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package badgerhelper
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dgraph-io/badger"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("badgerhelper")
+
+type dbState int32
+
+const (
+	closed dbState = iota
+	opened
+)
+
+// Conf configuration for `DB`
+type Conf struct {
+	DBPath string
+}
+
+// DB - a wrapper on an actual badger store.
+// Badger syncs every write to its value log, hence the writes to a `DB` are
+// always synced to disk and, unlike leveldb, there is no sync to defer.
+type DB struct {
+	conf    *Conf
+	db      *badger.DB
+	dbState dbState
+	mux     sync.Mutex
+}
+
+// CreateDB constructs a `DB`
+func CreateDB(conf *Conf) *DB {
+	return &DB{
+		conf:    conf,
+		dbState: closed,
+	}
+}
+
+// Open opens the underlying db
+func (dbInst *DB) Open() {
+	dbInst.mux.Lock()
+	defer dbInst.mux.Unlock()
+	if dbInst.dbState == opened {
+		return
+	}
+	dbPath := dbInst.conf.DBPath
+	if _, err := util.CreateDirIfMissing(dbPath); err != nil {
+		panic(fmt.Sprintf("Error creating dir if missing: %s", err))
+	}
+	dbOpts := badger.DefaultOptions
+	dbOpts.Dir = dbPath
+	dbOpts.ValueDir = dbPath
+	dbOpts.SyncWrites = true
+	// discard the value log entries left partially written by a crash
+	dbOpts.Truncate = true
+	var err error
+	if dbInst.db, err = badger.Open(dbOpts); err != nil {
+		panic(fmt.Sprintf("Error opening badger db: %s", err))
+	}
+	dbInst.dbState = opened
+}
+
+// Close closes the underlying db
+func (dbInst *DB) Close() {
+	dbInst.mux.Lock()
+	defer dbInst.mux.Unlock()
+	if dbInst.dbState == closed {
+		return
+	}
+	if err := dbInst.db.Close(); err != nil {
+		logger.Errorf("Error closing badger db: %s", err)
+	}
+	dbInst.dbState = closed
+}
+
+// Get returns the value for the given key
+func (dbInst *DB) Get(key []byte) ([]byte, error) {
+	var value []byte
+	err := dbInst.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		value, err = item.ValueCopy(nil)
+		return err
+	})
+	if err == badger.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		logger.Errorf("Error retrieving badger key [%#v]: %s", key, err)
+		return nil, errors.Wrapf(err, "error retrieving badger key [%#v]", key)
+	}
+	return value, nil
+}
+
+// Put saves the key/value
+func (dbInst *DB) Put(key []byte, value []byte) error {
+	err := dbInst.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(key, value)
+	})
+	if err != nil {
+		logger.Errorf("Error writing badger key [%#v]", key)
+		return errors.Wrapf(err, "error writing badger key [%#v]", key)
+	}
+	return nil
+}
+
+// Delete deletes the given key
+func (dbInst *DB) Delete(key []byte) error {
+	err := dbInst.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete(key)
+	})
+	if err != nil {
+		logger.Errorf("Error deleting badger key [%#v]", key)
+		return errors.Wrapf(err, "error deleting badger key [%#v]", key)
+	}
+	return nil
+}
+
+// WriteBatch writes the given key-values, where a nil value deletes the key.
+// The batch is written in a single badger transaction, unless it does not fit
+// in one, in which case it is split into several transactions in the order of
+// the keys. The stores built on a `DB` recover such a partially written batch
+// through their savepoints, as they do for the blocks lost by a crash.
+func (dbInst *DB) WriteBatch(kvs map[string][]byte) error {
+	keys := make([]string, 0, len(kvs))
+	for k := range kvs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	txn := dbInst.db.NewTransaction(true)
+	defer func() { txn.Discard() }()
+	for i := 0; i < len(keys); {
+		err := writeToTxn(txn, []byte(keys[i]), kvs[keys[i]])
+		if err == badger.ErrTxnTooBig {
+			logger.Debugf("Splitting a batch of [%d] keys at key [%d] across badger transactions", len(keys), i)
+			if err := txn.Commit(nil); err != nil {
+				return errors.Wrap(err, "error writing batch to badger db")
+			}
+			txn = dbInst.db.NewTransaction(true)
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, "error writing batch to badger db")
+		}
+		i++
+	}
+	if err := txn.Commit(nil); err != nil {
+		return errors.Wrap(err, "error writing batch to badger db")
+	}
+	return nil
+}
+
+func writeToTxn(txn *badger.Txn, key, value []byte) error {
+	if value == nil {
+		return txn.Delete(key)
+	}
+	return txn.Set(key, value)
+}
+
+// GetIterator returns an iterator over key-value store. The iterator should be released after the use.
+// The resultset contains all the keys that are present in the db between the startKey (inclusive) and the endKey (exclusive).
+// A nil startKey represents the first available key and a nil endKey represent a logical key after the last available key
+func (dbInst *DB) GetIterator(startKey []byte, endKey []byte) *Iterator {
+	txn := dbInst.db.NewTransaction(false)
+	return &Iterator{
+		txn:      txn,
+		itr:      txn.NewIterator(badger.DefaultIteratorOptions),
+		startKey: startKey,
+		endKey:   endKey,
+	}
+}
+
+// GetReverseIterator returns an iterator over the same keys as the iterator returned by `GetIterator`,
+// in the descending order. The iterator should be released after the use.
+func (dbInst *DB) GetReverseIterator(startKey []byte, endKey []byte) *Iterator {
+	txn := dbInst.db.NewTransaction(false)
+	opts := badger.DefaultIteratorOptions
+	opts.Reverse = true
+	return &Iterator{
+		txn:      txn,
+		itr:      txn.NewIterator(opts),
+		startKey: startKey,
+		endKey:   endKey,
+		reverse:  true,
+	}
+}
+
+// Iterator iterates over the keys of a badger db in the manner of a leveldb
+// iterator, i.e., it is positioned at the first key by the first call to `Next`
+type Iterator struct {
+	txn      *badger.Txn
+	itr      *badger.Iterator
+	startKey []byte
+	endKey   []byte
+	reverse  bool
+	started  bool
+	err      error
+}
+
+// Next moves the iterator to the next key and returns false when the iterator is exhausted
+func (itr *Iterator) Next() bool {
+	if !itr.started {
+		if itr.reverse {
+			// a reverse seek positions the iterator at the last key not greater than the endKey,
+			// which is excluded from the results
+			itr.itr.Seek(itr.endKey)
+			if itr.itr.Valid() && bytes.Equal(itr.itr.Item().Key(), itr.endKey) {
+				itr.itr.Next()
+			}
+		} else {
+			itr.itr.Seek(itr.startKey)
+		}
+		itr.started = true
+	} else if itr.itr.Valid() {
+		itr.itr.Next()
+	}
+	return itr.Valid()
+}
+
+// Valid returns true if the iterator is positioned at a key
+func (itr *Iterator) Valid() bool {
+	if !itr.started || !itr.itr.Valid() {
+		return false
+	}
+	if itr.reverse {
+		return itr.startKey == nil || bytes.Compare(itr.itr.Item().Key(), itr.startKey) >= 0
+	}
+	return itr.endKey == nil || bytes.Compare(itr.itr.Item().Key(), itr.endKey) < 0
+}
+
+// Key returns the key the iterator is positioned at
+func (itr *Iterator) Key() []byte {
+	if !itr.Valid() {
+		return nil
+	}
+	return itr.itr.Item().KeyCopy(nil)
+}
+
+// Value returns the value of the key the iterator is positioned at
+func (itr *Iterator) Value() []byte {
+	if !itr.Valid() {
+		return nil
+	}
+	value, err := itr.itr.Item().ValueCopy(nil)
+	if err != nil {
+		itr.err = errors.Wrap(err, "error reading value from badger db")
+		return nil
+	}
+	return value
+}
+
+// Error returns the error, if any, encountered while reading the values
+func (itr *Iterator) Error() error {
+	return itr.err
+}
+
+// Release releases the iterator
+func (itr *Iterator) Release() {
+	itr.itr.Close()
+	itr.txn.Discard()
+}
@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package badgerhelper
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBReopen(t *testing.T) {
+	env := newTestDBEnv(t, testDBPath)
+	defer env.cleanup()
+	db := env.db
+	db.Open()
+	// second time open should not have any side effect
+	db.Open()
+	assert.NoError(t, db.Put([]byte("key1"), []byte("value1")))
+	assert.NoError(t, db.Put([]byte("key2"), []byte("value2")))
+	assert.NoError(t, db.Delete([]byte("key2")))
+	db.Close()
+	// second time close should not have any side effect
+	db.Close()
+
+	db.Open()
+	val, err := db.Get([]byte("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), val)
+	val, err = db.Get([]byte("key2"))
+	assert.NoError(t, err)
+	assert.Nil(t, val)
+}
+
+func TestWriteBatchLargerThanTxn(t *testing.T) {
+	env := newTestDBEnv(t, testDBPath)
+	defer env.cleanup()
+	db := env.db
+	db.Open()
+
+	numKeys := int(db.db.MaxBatchCount()) + 100
+	kvs := make(map[string][]byte)
+	for i := 0; i < numKeys; i++ {
+		kvs[fmt.Sprintf("key_%08d", i)] = []byte(fmt.Sprintf("value_%08d", i))
+	}
+	assert.NoError(t, db.WriteBatch(kvs))
+
+	itr := db.GetIterator(nil, nil)
+	defer itr.Release()
+	count := 0
+	for itr.Next() {
+		assert.Equal(t, fmt.Sprintf("key_%08d", count), string(itr.Key()))
+		assert.Equal(t, fmt.Sprintf("value_%08d", count), string(itr.Value()))
+		count++
+	}
+	assert.NoError(t, itr.Error())
+	assert.Equal(t, numKeys, count)
+}
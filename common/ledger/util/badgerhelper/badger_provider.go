@@ -0,0 +1,135 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package badgerhelper
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+)
+
+var dbNameKeySep = []byte{0x00}
+var lastKeyIndicator = byte(0x01)
+
+// Provider enables to use a single badger db as multiple logical dbs
+type Provider struct {
+	db        *DB
+	dbHandles map[string]*DBHandle
+	mux       sync.Mutex
+}
+
+// NewProvider constructs a Provider
+func NewProvider(conf *Conf) *Provider {
+	db := CreateDB(conf)
+	db.Open()
+	return &Provider{db, make(map[string]*DBHandle), sync.Mutex{}}
+}
+
+// GetDBHandle returns a handle to a named db
+func (p *Provider) GetDBHandle(dbName string) *DBHandle {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	dbHandle := p.dbHandles[dbName]
+	if dbHandle == nil {
+		dbHandle = &DBHandle{dbName, p.db}
+		p.dbHandles[dbName] = dbHandle
+	}
+	return dbHandle
+}
+
+// Close closes the underlying badger db
+func (p *Provider) Close() {
+	p.db.Close()
+}
+
+// DBHandle is an handle to a named db. Its methods mirror those of
+// `leveldbhelper.DBHandle`, so that the stores can be built on either.
+type DBHandle struct {
+	dbName string
+	db     *DB
+}
+
+// Get returns the value for the given key
+func (h *DBHandle) Get(key []byte) ([]byte, error) {
+	return h.db.Get(constructBadgerKey(h.dbName, key))
+}
+
+// Put saves the key/value. The write is always synced to disk.
+func (h *DBHandle) Put(key []byte, value []byte, sync bool) error {
+	return h.db.Put(constructBadgerKey(h.dbName, key), value)
+}
+
+// Delete deletes the given key. The write is always synced to disk.
+func (h *DBHandle) Delete(key []byte, sync bool) error {
+	return h.db.Delete(constructBadgerKey(h.dbName, key))
+}
+
+// Sync is a noop, as the writes to a badger db are always synced to disk
+func (h *DBHandle) Sync() error {
+	return nil
+}
+
+// WriteBatch writes a batch. The write is always synced to disk.
+func (h *DBHandle) WriteBatch(batch *leveldbhelper.UpdateBatch, sync bool) error {
+	if len(batch.KVs) == 0 {
+		return nil
+	}
+	kvs := make(map[string][]byte, len(batch.KVs))
+	for k, v := range batch.KVs {
+		kvs[string(constructBadgerKey(h.dbName, []byte(k)))] = v
+	}
+	return h.db.WriteBatch(kvs)
+}
+
+// GetIterator gets an handle to iterator. The iterator should be released after the use.
+// The resultset contains all the keys that are present in the db between the startKey (inclusive) and the endKey (exclusive).
+// A nil startKey represents the first available key and a nil endKey represent a logical key after the last available key
+func (h *DBHandle) GetIterator(startKey []byte, endKey []byte) *HandleIterator {
+	sKey := constructBadgerKey(h.dbName, startKey)
+	eKey := constructBadgerKey(h.dbName, endKey)
+	if endKey == nil {
+		// replace the last byte 'dbNameKeySep' by 'lastKeyIndicator'
+		eKey[len(eKey)-1] = lastKeyIndicator
+	}
+	logger.Debugf("Getting iterator for range [%#v] - [%#v]", sKey, eKey)
+	return &HandleIterator{h.db.GetIterator(sKey, eKey)}
+}
+
+// GetReverseIterator gets an handle to an iterator over the same keys as `GetIterator`, in the descending order.
+// The iterator should be released after the use.
+func (h *DBHandle) GetReverseIterator(startKey []byte, endKey []byte) *HandleIterator {
+	sKey := constructBadgerKey(h.dbName, startKey)
+	eKey := constructBadgerKey(h.dbName, endKey)
+	if endKey == nil {
+		eKey[len(eKey)-1] = lastKeyIndicator
+	}
+	logger.Debugf("Getting reverse iterator for range [%#v] - [%#v]", sKey, eKey)
+	return &HandleIterator{h.db.GetReverseIterator(sKey, eKey)}
+}
+
+// HandleIterator extends the badger db iterator to return the keys of the named db
+type HandleIterator struct {
+	*Iterator
+}
+
+// Key wraps actual iterator method
+func (itr *HandleIterator) Key() []byte {
+	key := itr.Iterator.Key()
+	if key == nil {
+		return nil
+	}
+	return retrieveAppKey(key)
+}
+
+func constructBadgerKey(dbName string, key []byte) []byte {
+	return append(append([]byte(dbName), dbNameKeySep...), key...)
+}
+
+func retrieveAppKey(badgerKey []byte) []byte {
+	return bytes.SplitN(badgerKey, dbNameKeySep, 2)[1]
+}
@@ -28,9 +28,16 @@ const (
 	opened
 )
 
+// syncKey is deleted, with a synced write, to sync the db to disk. It is never written.
+var syncKey = []byte{0xff, 's', 'y', 'n', 'c'}
+
 // Conf configuration for `DB`
 type Conf struct {
 	DBPath string
+	// DeferSync defers the sync to disk of the writes, including those
+	// requested to be synced, until `Sync` is invoked. It is meant for the
+	// dbs whose writes are journaled elsewhere.
+	DeferSync bool
 }
 
 // DB - a wrapper on an actual store
@@ -110,10 +117,7 @@ func (dbInst *DB) Get(key []byte) ([]byte, error) {
 
 // Put saves the key/value
 func (dbInst *DB) Put(key []byte, value []byte, sync bool) error {
-	wo := dbInst.writeOptsNoSync
-	if sync {
-		wo = dbInst.writeOptsSync
-	}
+	wo := dbInst.writeOpts(sync)
 	err := dbInst.db.Put(key, value, wo)
 	if err != nil {
 		logger.Errorf("Error writing leveldb key [%#v]", key)
@@ -124,10 +128,7 @@ func (dbInst *DB) Put(key []byte, value []byte, sync bool) error {
 
 // Delete deletes the given key
 func (dbInst *DB) Delete(key []byte, sync bool) error {
-	wo := dbInst.writeOptsNoSync
-	if sync {
-		wo = dbInst.writeOptsSync
-	}
+	wo := dbInst.writeOpts(sync)
 	err := dbInst.db.Delete(key, wo)
 	if err != nil {
 		logger.Errorf("Error deleting leveldb key [%#v]", key)
@@ -136,6 +137,14 @@ func (dbInst *DB) Delete(key []byte, sync bool) error {
 	return nil
 }
 
+// Sync syncs to disk the writes made so far, including those whose sync was deferred
+func (dbInst *DB) Sync() error {
+	if err := dbInst.db.Delete(syncKey, dbInst.writeOptsSync); err != nil {
+		return errors.Wrap(err, "error syncing leveldb")
+	}
+	return nil
+}
+
 // GetIterator returns an iterator over key-value store. The iterator should be released after the use.
 // The resultset contains all the keys that are present in the db between the startKey (inclusive) and the endKey (exclusive).
 // A nil startKey represents the first available key and a nil endKey represent a logical key after the last available key
@@ -145,12 +154,18 @@ func (dbInst *DB) GetIterator(startKey []byte, endKey []byte) iterator.Iterator
 
 // WriteBatch writes a batch
 func (dbInst *DB) WriteBatch(batch *leveldb.Batch, sync bool) error {
-	wo := dbInst.writeOptsNoSync
-	if sync {
-		wo = dbInst.writeOptsSync
-	}
+	wo := dbInst.writeOpts(sync)
 	if err := dbInst.db.Write(batch, wo); err != nil {
 		return errors.Wrap(err, "error writing batch to leveldb")
 	}
 	return nil
 }
+
+// writeOpts returns the options of a write, which is synced to disk if
+// requested, unless the sync is deferred
+func (dbInst *DB) writeOpts(sync bool) *opt.WriteOptions {
+	if sync && !dbInst.conf.DeferSync {
+		return dbInst.writeOptsSync
+	}
+	return dbInst.writeOptsNoSync
+}
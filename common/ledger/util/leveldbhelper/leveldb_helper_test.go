@@ -109,10 +109,34 @@ func TestLevelDBHelper(t *testing.T) {
 	assert.Equal(t, []string{"key1", "key2"}, keys)
 }
 
+func TestLevelDBHelperDeferSync(t *testing.T) {
+	assert.NoError(t, os.RemoveAll(testDBPath))
+	defer os.RemoveAll(testDBPath)
+	db := CreateDB(&Conf{DBPath: testDBPath, DeferSync: true})
+	db.Open()
+	defer db.Close()
+
+	assert.Equal(t, db.writeOptsNoSync, db.writeOpts(true))
+	assert.NoError(t, db.Put([]byte("key1"), []byte("value1"), true))
+	assert.NoError(t, db.Sync())
+
+	val, err := db.Get([]byte("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "value1", string(val))
+
+	keys := []string{}
+	itr := db.GetIterator(nil, nil)
+	for itr.Next() {
+		keys = append(keys, string(itr.Key()))
+	}
+	itr.Release()
+	assert.Equal(t, []string{"key1"}, keys)
+}
+
 func TestCreateDBInEmptyDir(t *testing.T) {
 	assert.NoError(t, os.RemoveAll(testDBPath), "")
 	assert.NoError(t, os.MkdirAll(testDBPath, 0775), "")
-	db := CreateDB(&Conf{testDBPath})
+	db := CreateDB(&Conf{DBPath: testDBPath})
 	defer db.Close()
 	defer func() {
 		if r := recover(); r != nil {
@@ -128,7 +152,7 @@ func TestCreateDBInNonEmptyDir(t *testing.T) {
 	file, err := os.Create(filepath.Join(testDBPath, "dummyfile.txt"))
 	assert.NoError(t, err, "")
 	file.Close()
-	db := CreateDB(&Conf{testDBPath})
+	db := CreateDB(&Conf{DBPath: testDBPath})
 	defer db.Close()
 	defer func() {
 		if r := recover(); r == nil {
@@ -79,6 +79,12 @@ func (h *DBHandle) Delete(key []byte, sync bool) error {
 	return h.db.Delete(constructLevelKey(h.dbName, key), sync)
 }
 
+// Sync syncs to disk the writes made so far to the underlying leveldb,
+// including those whose sync was deferred
+func (h *DBHandle) Sync() error {
+	return h.db.Sync()
+}
+
 // WriteBatch writes a batch in an atomic way
 func (h *DBHandle) WriteBatch(batch *UpdateBatch, sync bool) error {
 	if len(batch.KVs) == 0 {
@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package rocksdbhelper maintains multiple logical dbs in a single rocksdb,
+// each in its own column family. The rocksdb implementation requires cgo and
+// the rocksdb library, and is only built with the build tag `rocksdb`.
+package rocksdbhelper
+
+// Conf configuration for `DB`
+type Conf struct {
+	DBPath string
+	// DeferSync defers the sync to disk of the writes, including those
+	// requested to be synced, until `Sync` is invoked. It is meant for the
+	// dbs whose writes are journaled elsewhere.
+	DeferSync bool
+	// Compaction tunes the compaction of the db. A nil Compaction, as well
+	// as the zero value of any of its fields, keeps the rocksdb default.
+	Compaction *CompactionConf
+}
+
+// CompactionConf tunes the memtables and the compaction of a rocksdb
+type CompactionConf struct {
+	// Style is one of "level", "universal" or "fifo"
+	Style string
+	// WriteBufferSize is the size, in bytes, of a memtable
+	WriteBufferSize int
+	// MaxBackgroundCompactions is the maximum number of concurrent compactions
+	MaxBackgroundCompactions int
+	// Level0FileNumCompactionTrigger is the number of level-0 files which triggers a compaction to level-1
+	Level0FileNumCompactionTrigger int
+	// TargetFileSizeBase is the target size, in bytes, of the level-1 files
+	TargetFileSizeBase uint64
+	// MaxBytesForLevelBase is the maximum size, in bytes, of level-1
+	MaxBytesForLevelBase uint64
+	// DynamicLevelBytes sizes the levels dynamically, based on the size of the last level
+	DynamicLevelBytes bool
+}
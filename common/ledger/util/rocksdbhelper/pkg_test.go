@@ -0,0 +1,38 @@
+// +build rocksdb
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rocksdbhelper
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDBPath = "/tmp/fabric/ledgertests/util/rocksdbhelper"
+
+type testDBProviderEnv struct {
+	t        *testing.T
+	path     string
+	provider *Provider
+}
+
+func newTestProviderEnv(t *testing.T, path string) *testDBProviderEnv {
+	testProviderEnv := &testDBProviderEnv{t: t, path: path}
+	testProviderEnv.cleanup()
+	testProviderEnv.provider = NewProvider(&Conf{DBPath: path})
+	return testProviderEnv
+}
+
+func (providerEnv *testDBProviderEnv) cleanup() {
+	if providerEnv.provider != nil {
+		providerEnv.provider.Close()
+	}
+	assert.NoError(providerEnv.t, os.RemoveAll(providerEnv.path))
+}
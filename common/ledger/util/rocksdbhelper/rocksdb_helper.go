@@ -0,0 +1,331 @@
+// +build rocksdb
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rocksdbhelper
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/pkg/errors"
+	"github.com/tecbot/gorocksdb"
+)
+
+var logger = flogging.MustGetLogger("rocksdbhelper")
+
+type dbState int32
+
+const (
+	closed dbState = iota
+	opened
+)
+
+const defaultColumnFamily = "default"
+
+// syncKey is deleted, with a synced write, to sync the db to disk. It is never written.
+var syncKey = []byte{0xff, 's', 'y', 'n', 'c'}
+
+// DB - a wrapper on an actual rocksdb store
+type DB struct {
+	conf      *Conf
+	db        *gorocksdb.DB
+	dbOpts    *gorocksdb.Options
+	cfHandles map[string]*gorocksdb.ColumnFamilyHandle
+	dbState   dbState
+	mux       sync.Mutex
+
+	readOpts        *gorocksdb.ReadOptions
+	writeOptsNoSync *gorocksdb.WriteOptions
+	writeOptsSync   *gorocksdb.WriteOptions
+}
+
+// CreateDB constructs a `DB`
+func CreateDB(conf *Conf) *DB {
+	readOpts := gorocksdb.NewDefaultReadOptions()
+	writeOptsNoSync := gorocksdb.NewDefaultWriteOptions()
+	writeOptsSync := gorocksdb.NewDefaultWriteOptions()
+	writeOptsSync.SetSync(true)
+
+	return &DB{
+		conf:            conf,
+		dbState:         closed,
+		readOpts:        readOpts,
+		writeOptsNoSync: writeOptsNoSync,
+		writeOptsSync:   writeOptsSync}
+}
+
+// Open opens the underlying db, along with all of its column families
+func (dbInst *DB) Open() {
+	dbInst.mux.Lock()
+	defer dbInst.mux.Unlock()
+	if dbInst.dbState == opened {
+		return
+	}
+	dbPath := dbInst.conf.DBPath
+	dirEmpty, err := util.CreateDirIfMissing(dbPath)
+	if err != nil {
+		panic(fmt.Sprintf("Error creating dir if missing: %s", err))
+	}
+	dbOpts, err := newOptions(dbInst.conf.Compaction)
+	if err != nil {
+		panic(fmt.Sprintf("Error configuring rocksdb: %s", err))
+	}
+	dbOpts.SetCreateIfMissing(dirEmpty)
+	cfNames := []string{defaultColumnFamily}
+	if !dirEmpty {
+		if cfNames, err = gorocksdb.ListColumnFamilies(dbOpts, dbPath); err != nil {
+			panic(fmt.Sprintf("Error listing column families of rocksdb: %s", err))
+		}
+	}
+	cfOpts := make([]*gorocksdb.Options, len(cfNames))
+	for i := range cfNames {
+		cfOpts[i] = dbOpts
+	}
+	db, cfHandles, err := gorocksdb.OpenDbColumnFamilies(dbOpts, dbPath, cfNames, cfOpts)
+	if err != nil {
+		panic(fmt.Sprintf("Error opening rocksdb: %s", err))
+	}
+	dbInst.db = db
+	dbInst.dbOpts = dbOpts
+	dbInst.cfHandles = make(map[string]*gorocksdb.ColumnFamilyHandle)
+	for i, cfName := range cfNames {
+		dbInst.cfHandles[cfName] = cfHandles[i]
+	}
+	dbInst.dbState = opened
+}
+
+// newOptions returns the options of the db, tuned by the given compaction configuration
+func newOptions(compaction *CompactionConf) (*gorocksdb.Options, error) {
+	opts := gorocksdb.NewDefaultOptions()
+	if compaction == nil {
+		return opts, nil
+	}
+	switch compaction.Style {
+	case "", "level":
+		opts.SetCompactionStyle(gorocksdb.LevelCompactionStyle)
+	case "universal":
+		opts.SetCompactionStyle(gorocksdb.UniversalCompactionStyle)
+	case "fifo":
+		opts.SetCompactionStyle(gorocksdb.FIFOCompactionStyle)
+	default:
+		opts.Destroy()
+		return nil, errors.Errorf("unknown compaction style [%s]", compaction.Style)
+	}
+	if compaction.WriteBufferSize > 0 {
+		opts.SetWriteBufferSize(compaction.WriteBufferSize)
+	}
+	if compaction.MaxBackgroundCompactions > 0 {
+		opts.SetMaxBackgroundCompactions(compaction.MaxBackgroundCompactions)
+	}
+	if compaction.Level0FileNumCompactionTrigger > 0 {
+		opts.SetLevel0FileNumCompactionTrigger(compaction.Level0FileNumCompactionTrigger)
+	}
+	if compaction.TargetFileSizeBase > 0 {
+		opts.SetTargetFileSizeBase(compaction.TargetFileSizeBase)
+	}
+	if compaction.MaxBytesForLevelBase > 0 {
+		opts.SetMaxBytesForLevelBase(compaction.MaxBytesForLevelBase)
+	}
+	opts.SetLevelCompactionDynamicLevelBytes(compaction.DynamicLevelBytes)
+	return opts, nil
+}
+
+// Close closes the underlying db
+func (dbInst *DB) Close() {
+	dbInst.mux.Lock()
+	defer dbInst.mux.Unlock()
+	if dbInst.dbState == closed {
+		return
+	}
+	for _, cfHandle := range dbInst.cfHandles {
+		cfHandle.Destroy()
+	}
+	dbInst.db.Close()
+	dbInst.dbOpts.Destroy()
+	dbInst.dbState = closed
+}
+
+// columnFamily returns the handle to the named column family, creating the
+// column family if it does not exist
+func (dbInst *DB) columnFamily(cfName string) (*gorocksdb.ColumnFamilyHandle, error) {
+	dbInst.mux.Lock()
+	defer dbInst.mux.Unlock()
+	if cfHandle, ok := dbInst.cfHandles[cfName]; ok {
+		return cfHandle, nil
+	}
+	cfHandle, err := dbInst.db.CreateColumnFamily(dbInst.dbOpts, cfName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating rocksdb column family [%s]", cfName)
+	}
+	dbInst.cfHandles[cfName] = cfHandle
+	return cfHandle, nil
+}
+
+// Get returns the value for the given key of the given column family
+func (dbInst *DB) Get(cf *gorocksdb.ColumnFamilyHandle, key []byte) ([]byte, error) {
+	slice, err := dbInst.db.GetCF(dbInst.readOpts, cf, key)
+	if err != nil {
+		logger.Errorf("Error retrieving rocksdb key [%#v]: %s", key, err)
+		return nil, errors.Wrapf(err, "error retrieving rocksdb key [%#v]", key)
+	}
+	defer slice.Free()
+	data := slice.Data()
+	if data == nil {
+		return nil, nil
+	}
+	return append([]byte{}, data...), nil
+}
+
+// Put saves the key/value in the given column family
+func (dbInst *DB) Put(cf *gorocksdb.ColumnFamilyHandle, key []byte, value []byte, sync bool) error {
+	if err := dbInst.db.PutCF(dbInst.writeOpts(sync), cf, key, value); err != nil {
+		logger.Errorf("Error writing rocksdb key [%#v]", key)
+		return errors.Wrapf(err, "error writing rocksdb key [%#v]", key)
+	}
+	return nil
+}
+
+// Delete deletes the given key from the given column family
+func (dbInst *DB) Delete(cf *gorocksdb.ColumnFamilyHandle, key []byte, sync bool) error {
+	if err := dbInst.db.DeleteCF(dbInst.writeOpts(sync), cf, key); err != nil {
+		logger.Errorf("Error deleting rocksdb key [%#v]", key)
+		return errors.Wrapf(err, "error deleting rocksdb key [%#v]", key)
+	}
+	return nil
+}
+
+// Sync syncs to disk the writes made so far, including those whose sync was deferred
+func (dbInst *DB) Sync() error {
+	if err := dbInst.db.Delete(dbInst.writeOptsSync, syncKey); err != nil {
+		return errors.Wrap(err, "error syncing rocksdb")
+	}
+	return nil
+}
+
+// WriteBatch writes a batch
+func (dbInst *DB) WriteBatch(batch *gorocksdb.WriteBatch, sync bool) error {
+	if err := dbInst.db.Write(dbInst.writeOpts(sync), batch); err != nil {
+		return errors.Wrap(err, "error writing batch to rocksdb")
+	}
+	return nil
+}
+
+// GetIterator returns an iterator over the given column family. The iterator should be released after the use.
+// The resultset contains all the keys that are present in the column family between the startKey (inclusive) and the endKey (exclusive).
+// A nil startKey represents the first available key and a nil endKey represent a logical key after the last available key
+func (dbInst *DB) GetIterator(cf *gorocksdb.ColumnFamilyHandle, startKey []byte, endKey []byte) *Iterator {
+	return &Iterator{
+		itr:      dbInst.db.NewIteratorCF(dbInst.readOpts, cf),
+		startKey: startKey,
+		endKey:   endKey,
+	}
+}
+
+// GetReverseIterator returns an iterator over the given column family, which iterates over the same keys as the
+// iterator returned by `GetIterator`, in the descending order. The iterator should be released after the use.
+func (dbInst *DB) GetReverseIterator(cf *gorocksdb.ColumnFamilyHandle, startKey []byte, endKey []byte) *Iterator {
+	itr := dbInst.GetIterator(cf, startKey, endKey)
+	itr.reverse = true
+	return itr
+}
+
+// writeOpts returns the options of a write, which is synced to disk if
+// requested, unless the sync is deferred
+func (dbInst *DB) writeOpts(sync bool) *gorocksdb.WriteOptions {
+	if sync && !dbInst.conf.DeferSync {
+		return dbInst.writeOptsSync
+	}
+	return dbInst.writeOptsNoSync
+}
+
+// Iterator iterates over the keys of a rocksdb column family in the manner of
+// a leveldb iterator, i.e., it is positioned at the first key by the first call to `Next`
+type Iterator struct {
+	itr      *gorocksdb.Iterator
+	startKey []byte
+	endKey   []byte
+	reverse  bool
+	started  bool
+}
+
+// Next moves the iterator to the next key and returns false when the iterator is exhausted
+func (itr *Iterator) Next() bool {
+	if itr.reverse {
+		return itr.prev()
+	}
+	if !itr.started {
+		if itr.startKey == nil {
+			itr.itr.SeekToFirst()
+		} else {
+			itr.itr.Seek(itr.startKey)
+		}
+		itr.started = true
+	} else if itr.itr.Valid() {
+		itr.itr.Next()
+	}
+	return itr.Valid()
+}
+
+// prev moves a reverse iterator to the previous key, the first call positioning it
+// at the last key before the endKey
+func (itr *Iterator) prev() bool {
+	if !itr.started {
+		if itr.endKey == nil {
+			itr.itr.SeekToLast()
+		} else {
+			itr.itr.SeekForPrev(itr.endKey)
+			if itr.itr.Valid() && bytes.Equal(itr.itr.Key().Data(), itr.endKey) {
+				itr.itr.Prev()
+			}
+		}
+		itr.started = true
+	} else if itr.itr.Valid() {
+		itr.itr.Prev()
+	}
+	return itr.Valid()
+}
+
+// Valid returns true if the iterator is positioned at a key
+func (itr *Iterator) Valid() bool {
+	if !itr.started || !itr.itr.Valid() {
+		return false
+	}
+	if itr.reverse {
+		return itr.startKey == nil || bytes.Compare(itr.itr.Key().Data(), itr.startKey) >= 0
+	}
+	return itr.endKey == nil || bytes.Compare(itr.itr.Key().Data(), itr.endKey) < 0
+}
+
+// Key returns the key the iterator is positioned at
+func (itr *Iterator) Key() []byte {
+	if !itr.Valid() {
+		return nil
+	}
+	return append([]byte{}, itr.itr.Key().Data()...)
+}
+
+// Value returns the value of the key the iterator is positioned at
+func (itr *Iterator) Value() []byte {
+	if !itr.Valid() {
+		return nil
+	}
+	return append([]byte{}, itr.itr.Value().Data()...)
+}
+
+// Error returns the error, if any, encountered by the iterator
+func (itr *Iterator) Error() error {
+	return itr.itr.Err()
+}
+
+// Release releases the iterator
+func (itr *Iterator) Release() {
+	itr.itr.Close()
+}
@@ -0,0 +1,117 @@
+// +build rocksdb
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rocksdbhelper
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/tecbot/gorocksdb"
+)
+
+// cfNamePrefix is prepended to the names of the dbs to name their column
+// families, so that a db cannot clash with the default column family
+const cfNamePrefix = "db."
+
+// Provider enables to use a single rocksdb as multiple logical dbs, each
+// maintained in a column family of its own
+type Provider struct {
+	db        *DB
+	dbHandles map[string]*DBHandle
+	mux       sync.Mutex
+}
+
+// NewProvider constructs a Provider
+func NewProvider(conf *Conf) *Provider {
+	db := CreateDB(conf)
+	db.Open()
+	return &Provider{db, make(map[string]*DBHandle), sync.Mutex{}}
+}
+
+// GetDBHandle returns a handle to a named db, creating its column family if it does not exist
+func (p *Provider) GetDBHandle(dbName string) (*DBHandle, error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	dbHandle := p.dbHandles[dbName]
+	if dbHandle == nil {
+		cf, err := p.db.columnFamily(cfNamePrefix + dbName)
+		if err != nil {
+			return nil, err
+		}
+		dbHandle = &DBHandle{dbName, p.db, cf}
+		p.dbHandles[dbName] = dbHandle
+	}
+	return dbHandle, nil
+}
+
+// Close closes the underlying rocksdb
+func (p *Provider) Close() {
+	p.db.Close()
+}
+
+// DBHandle is an handle to a named db. Its methods mirror those of
+// `leveldbhelper.DBHandle`, so that the stores can be built on either.
+type DBHandle struct {
+	dbName string
+	db     *DB
+	cf     *gorocksdb.ColumnFamilyHandle
+}
+
+// Get returns the value for the given key
+func (h *DBHandle) Get(key []byte) ([]byte, error) {
+	return h.db.Get(h.cf, key)
+}
+
+// Put saves the key/value
+func (h *DBHandle) Put(key []byte, value []byte, sync bool) error {
+	return h.db.Put(h.cf, key, value, sync)
+}
+
+// Delete deletes the given key
+func (h *DBHandle) Delete(key []byte, sync bool) error {
+	return h.db.Delete(h.cf, key, sync)
+}
+
+// Sync syncs to disk the writes made so far to the underlying rocksdb,
+// including those whose sync was deferred
+func (h *DBHandle) Sync() error {
+	return h.db.Sync()
+}
+
+// WriteBatch writes a batch in an atomic way
+func (h *DBHandle) WriteBatch(batch *leveldbhelper.UpdateBatch, sync bool) error {
+	if len(batch.KVs) == 0 {
+		return nil
+	}
+	rocksBatch := gorocksdb.NewWriteBatch()
+	defer rocksBatch.Destroy()
+	for k, v := range batch.KVs {
+		if v == nil {
+			rocksBatch.DeleteCF(h.cf, []byte(k))
+		} else {
+			rocksBatch.PutCF(h.cf, []byte(k), v)
+		}
+	}
+	return h.db.WriteBatch(rocksBatch, sync)
+}
+
+// GetIterator gets an handle to iterator. The iterator should be released after the use.
+// The resultset contains all the keys that are present in the db between the startKey (inclusive) and the endKey (exclusive).
+// A nil startKey represents the first available key and a nil endKey represent a logical key after the last available key
+func (h *DBHandle) GetIterator(startKey []byte, endKey []byte) *Iterator {
+	logger.Debugf("Getting iterator for range [%#v] - [%#v] of db [%s]", startKey, endKey, h.dbName)
+	return h.db.GetIterator(h.cf, startKey, endKey)
+}
+
+// GetReverseIterator gets an handle to an iterator over the same keys as `GetIterator`, in the descending order.
+// The iterator should be released after the use.
+func (h *DBHandle) GetReverseIterator(startKey []byte, endKey []byte) *Iterator {
+	logger.Debugf("Getting reverse iterator for range [%#v] - [%#v] of db [%s]", startKey, endKey, h.dbName)
+	return h.db.GetReverseIterator(h.cf, startKey, endKey)
+}
@@ -0,0 +1,186 @@
+// +build rocksdb
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rocksdbhelper
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBBasicWriteAndReads(t *testing.T) {
+	env := newTestProviderEnv(t, testDBPath)
+	defer env.cleanup()
+	p := env.provider
+
+	dbNames := []string{"db1", "db2", "", "default"}
+	for _, dbName := range dbNames {
+		db, err := p.GetDBHandle(dbName)
+		assert.NoError(t, err)
+		assert.NoError(t, db.Put([]byte("key1"), []byte("value1_"+dbName), false))
+		assert.NoError(t, db.Put([]byte("key2"), []byte("value2_"+dbName), false))
+	}
+
+	for _, dbName := range dbNames {
+		db, err := p.GetDBHandle(dbName)
+		assert.NoError(t, err)
+		val, err := db.Get([]byte("key1"))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("value1_"+dbName), val)
+		assert.NoError(t, db.Delete([]byte("key1"), false))
+		val, err = db.Get([]byte("key1"))
+		assert.NoError(t, err)
+		assert.Nil(t, val)
+		assert.NoError(t, db.Sync())
+	}
+}
+
+func TestColumnFamiliesAfterReopen(t *testing.T) {
+	env := newTestProviderEnv(t, testDBPath)
+	defer env.cleanup()
+
+	for _, dbName := range []string{"db1", "db2"} {
+		db, err := env.provider.GetDBHandle(dbName)
+		assert.NoError(t, err)
+		assert.NoError(t, db.Put([]byte("key1"), []byte("value1_"+dbName), true))
+	}
+	env.provider.Close()
+
+	env.provider = NewProvider(&Conf{
+		DBPath:     testDBPath,
+		Compaction: &CompactionConf{Style: "universal", WriteBufferSize: 4 << 20, MaxBackgroundCompactions: 2},
+	})
+	for _, dbName := range []string{"db1", "db2"} {
+		db, err := env.provider.GetDBHandle(dbName)
+		assert.NoError(t, err)
+		val, err := db.Get([]byte("key1"))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("value1_"+dbName), val)
+	}
+}
+
+func TestUnknownCompactionStyle(t *testing.T) {
+	_, err := newOptions(&CompactionConf{Style: "tiered"})
+	assert.EqualError(t, err, "unknown compaction style [tiered]")
+}
+
+func TestIterator(t *testing.T) {
+	env := newTestProviderEnv(t, testDBPath)
+	defer env.cleanup()
+	p := env.provider
+
+	db1, _ := p.GetDBHandle("db1")
+	db2, _ := p.GetDBHandle("db2")
+	for i := 0; i < 20; i++ {
+		db1.Put([]byte(createTestKey(i)), []byte(createTestValue("db1", i)), false)
+		db2.Put([]byte(createTestKey(i)), []byte(createTestValue("db2", i)), false)
+	}
+
+	itr1 := db2.GetIterator([]byte(createTestKey(2)), []byte(createTestKey(4)))
+	checkItrResults(t, itr1, createTestKeys(2, 3), createTestValues("db2", 2, 3))
+
+	itr2 := db2.GetIterator([]byte(createTestKey(2)), nil)
+	checkItrResults(t, itr2, createTestKeys(2, 19), createTestValues("db2", 2, 19))
+
+	itr3 := db2.GetIterator(nil, nil)
+	checkItrResults(t, itr3, createTestKeys(0, 19), createTestValues("db2", 0, 19))
+
+	itr4 := db2.GetReverseIterator([]byte(createTestKey(2)), []byte(createTestKey(4)))
+	checkItrResults(t, itr4, reverseStrings(createTestKeys(2, 3)), reverseStrings(createTestValues("db2", 2, 3)))
+
+	itr5 := db2.GetReverseIterator([]byte(createTestKey(2)), nil)
+	checkItrResults(t, itr5, reverseStrings(createTestKeys(2, 19)), reverseStrings(createTestValues("db2", 2, 19)))
+
+	itr6 := db2.GetReverseIterator(nil, nil)
+	checkItrResults(t, itr6, reverseStrings(createTestKeys(0, 19)), reverseStrings(createTestValues("db2", 0, 19)))
+}
+
+func TestBatchedUpdates(t *testing.T) {
+	env := newTestProviderEnv(t, testDBPath)
+	defer env.cleanup()
+	p := env.provider
+
+	db1, _ := p.GetDBHandle("db1")
+	db2, _ := p.GetDBHandle("db2")
+
+	dbs := []*DBHandle{db1, db2}
+	for _, db := range dbs {
+		batch := leveldbhelper.NewUpdateBatch()
+		batch.Put([]byte("key1"), []byte("value1"))
+		batch.Put([]byte("key2"), []byte("value2"))
+		batch.Put([]byte("key3"), []byte("value3"))
+		assert.NoError(t, db.WriteBatch(batch, true))
+	}
+
+	for _, db := range dbs {
+		batch := leveldbhelper.NewUpdateBatch()
+		batch.Delete([]byte("key2"))
+		assert.NoError(t, db.WriteBatch(batch, true))
+	}
+
+	for _, db := range dbs {
+		val1, _ := db.Get([]byte("key1"))
+		assert.Equal(t, "value1", string(val1))
+
+		val2, err2 := db.Get([]byte("key2"))
+		assert.NoError(t, err2, "")
+		assert.Nil(t, val2)
+
+		val3, _ := db.Get([]byte("key3"))
+		assert.Equal(t, "value3", string(val3))
+	}
+}
+
+func checkItrResults(t *testing.T, itr *Iterator, expectedKeys []string, expectedValues []string) {
+	defer itr.Release()
+	var actualKeys []string
+	var actualValues []string
+	for itr.Next(); itr.Valid(); itr.Next() {
+		actualKeys = append(actualKeys, string(itr.Key()))
+		actualValues = append(actualValues, string(itr.Value()))
+	}
+	assert.Equal(t, expectedKeys, actualKeys)
+	assert.Equal(t, expectedValues, actualValues)
+	assert.Equal(t, false, itr.Next())
+	assert.NoError(t, itr.Error())
+}
+
+func createTestKey(i int) string {
+	return fmt.Sprintf("key_%06d", i)
+}
+
+func createTestValue(dbname string, i int) string {
+	return fmt.Sprintf("value_%s_%06d", dbname, i)
+}
+
+func createTestKeys(start int, end int) []string {
+	var keys []string
+	for i := start; i <= end; i++ {
+		keys = append(keys, createTestKey(i))
+	}
+	return keys
+}
+
+func createTestValues(dbname string, start int, end int) []string {
+	var values []string
+	for i := start; i <= end; i++ {
+		values = append(values, createTestValue(dbname, i))
+	}
+	return values
+}
+
+func reverseStrings(s []string) []string {
+	reversed := make([]string, len(s))
+	for i, v := range s {
+		reversed[len(s)-1-i] = v
+	}
+	return reversed
+}
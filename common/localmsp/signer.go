@@ -25,6 +25,7 @@ import (
 )
 
 type mspSigner struct {
+	channelID string
 }
 
 // NewSigner returns a new instance of the msp-based LocalSigner.
@@ -34,9 +35,16 @@ func NewSigner() crypto.LocalSigner {
 	return &mspSigner{}
 }
 
+// NewChannelSigner returns a new instance of the msp-based LocalSigner
+// that signs with the identity of the local MSP used on the given channel.
+// Look at mspmgmt.GetLocalMSPForChannel for further information.
+func NewChannelSigner(channelID string) crypto.LocalSigner {
+	return &mspSigner{channelID: channelID}
+}
+
 // NewSignatureHeader creates a SignatureHeader with the correct signing identity and a valid nonce
 func (s *mspSigner) NewSignatureHeader() (*cb.SignatureHeader, error) {
-	signer, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+	signer, err := mspmgmt.GetLocalSigningIdentityForChannel(s.channelID)
 	if err != nil {
 		return nil, fmt.Errorf("Failed getting MSP-based signer [%s]", err)
 	}
@@ -60,7 +68,7 @@ func (s *mspSigner) NewSignatureHeader() (*cb.SignatureHeader, error) {
 
 // Sign a message which should embed a signature header created by NewSignatureHeader
 func (s *mspSigner) Sign(message []byte) ([]byte, error) {
-	signer, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+	signer, err := mspmgmt.GetLocalSigningIdentityForChannel(s.channelID)
 	if err != nil {
 		return nil, fmt.Errorf("Failed getting MSP-based signer [%s]", err)
 	}
@@ -62,3 +62,22 @@ func TestMspSigner_Sign(t *testing.T) {
 	err = mspIdentity.Verify(msg, sigma)
 	assert.NoError(t, err, "Failed verifiing signature")
 }
+
+func TestMspSigner_ChannelSigner(t *testing.T) {
+	signer := NewChannelSigner("mychannel")
+	assert.NotNil(t, signer)
+
+	// without additional local MSPs, the local MSP signs on every channel
+	sh, err := signer.NewSignatureHeader()
+	assert.NoError(t, err)
+	mspIdentity, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	creator, err := mspIdentity.Serialize()
+	assert.NoError(t, err)
+	assert.Equal(t, creator, sh.Creator)
+
+	msg := []byte("Hello World")
+	sigma, err := signer.Sign(msg)
+	assert.NoError(t, err)
+	assert.NoError(t, mspIdentity.Verify(msg, sigma))
+}
@@ -82,6 +82,17 @@ func Init(opts Opts) (err error) {
 	return
 }
 
+//SubScope returns a child of the root scope with the given name prefix. It returns
+//a scope that discards all metrics if the root scope has not been initialized.
+func SubScope(name string) Scope {
+	rootScopeMutex.Lock()
+	defer rootScopeMutex.Unlock()
+	if RootScope == nil {
+		return newNoOpScope()
+	}
+	return RootScope.SubScope(name)
+}
+
 //Start starts metrics server
 func Start() error {
 	rootScopeMutex.Lock()
@@ -145,9 +156,17 @@ func (g *noOpGauge) Update(v float64) {
 
 }
 
+type noOpHistogram struct {
+}
+
+func (h *noOpHistogram) RecordValue(v float64) {
+
+}
+
 type noOpScope struct {
-	counter *noOpCounter
-	gauge   *noOpGauge
+	counter   *noOpCounter
+	gauge     *noOpGauge
+	histogram *noOpHistogram
 }
 
 func (s *noOpScope) Counter(name string) Counter {
@@ -158,6 +177,10 @@ func (s *noOpScope) Gauge(name string) Gauge {
 	return s.gauge
 }
 
+func (s *noOpScope) Histogram(name string, buckets []float64) Histogram {
+	return s.histogram
+}
+
 func (s *noOpScope) Tagged(tags map[string]string) Scope {
 	return s
 }
@@ -176,8 +199,9 @@ func (s *noOpScope) Start() error {
 
 func newNoOpScope() Scope {
 	return &noOpScope{
-		counter: &noOpCounter{},
-		gauge:   &noOpGauge{},
+		counter:   &noOpCounter{},
+		gauge:     &noOpGauge{},
+		histogram: &noOpHistogram{},
 	}
 }
 
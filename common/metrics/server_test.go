@@ -172,6 +172,30 @@ func TestStartAndClose(t *testing.T) {
 	gt.Eventually(isRunning).Should(BeTrue())
 }
 
+func TestRootSubScope(t *testing.T) {
+	assert.Nil(t, RootScope)
+	assert.IsType(t, &noOpScope{}, SubScope("test"))
+
+	opts := Opts{
+		Enabled:  true,
+		Reporter: statsdReporterType,
+		Interval: 1 * time.Second,
+		StatsdReporterOpts: StatsdReporterOpts{
+			Address:       "127.0.0.1:0",
+			FlushInterval: 2 * time.Second,
+			FlushBytes:    512,
+		}}
+	s, err := create(opts)
+	assert.NoError(t, err)
+	defer s.Close()
+
+	RootScope = s
+	defer func() { RootScope = nil }()
+	sub := SubScope("test")
+	assert.IsType(t, &scope{}, sub)
+	assert.NotEqual(t, s, sub)
+}
+
 func TestNoOpScopeMetrics(t *testing.T) {
 	t.Parallel()
 	opts := Opts{
@@ -53,11 +53,13 @@ func (m *testFloatValue) ReportGauge(value float64) {
 type testStatsReporter struct {
 	cg sync.WaitGroup
 	gg sync.WaitGroup
+	hg sync.WaitGroup
 
 	scope Scope
 
-	counters map[string]*testIntValue
-	gauges   map[string]*testFloatValue
+	counters   map[string]*testIntValue
+	gauges     map[string]*testFloatValue
+	histograms map[string]map[float64]int64
 
 	flushes int32
 }
@@ -65,8 +67,9 @@ type testStatsReporter struct {
 // newTestStatsReporter returns a new TestStatsReporter
 func newTestStatsReporter() *testStatsReporter {
 	return &testStatsReporter{
-		counters: make(map[string]*testIntValue),
-		gauges:   make(map[string]*testFloatValue)}
+		counters:   make(map[string]*testIntValue),
+		gauges:     make(map[string]*testFloatValue),
+		histograms: make(map[string]map[float64]int64)}
 }
 
 func (r *testStatsReporter) WaitAll() {
@@ -140,7 +143,11 @@ func (r *testStatsReporter) ReportHistogramValueSamples(
 	bucketUpperBound float64,
 	samples int64,
 ) {
-
+	if r.histograms[name] == nil {
+		r.histograms[name] = make(map[float64]int64)
+	}
+	r.histograms[name][bucketUpperBound] = samples
+	r.hg.Done()
 }
 
 func (r *testStatsReporter) ReportHistogramDurationSamples(
@@ -245,6 +252,27 @@ func TestMultiGaugeReport(t *testing.T) {
 	assert.Equal(t, float64(3.33), r.gauges[namespace+".foo"].val)
 }
 
+func TestHistogram(t *testing.T) {
+	t.Parallel()
+	r := newTestStatsReporter()
+	opts := tally.ScopeOptions{
+		Prefix:    namespace,
+		Separator: tally.DefaultSeparator,
+		Reporter:  r}
+
+	s := newRootScope(opts, 1*time.Second)
+	go s.Start()
+	defer s.Close()
+	r.hg.Add(2)
+	buckets := []float64{10, 100}
+	s.Histogram("foo", buckets).RecordValue(5)
+	s.Histogram("foo", buckets).RecordValue(7)
+	s.Histogram("foo", buckets).RecordValue(50)
+	r.hg.Wait()
+
+	assert.Equal(t, map[float64]int64{10: 2, 100: 1}, r.histograms[namespace+".foo"])
+}
+
 func TestSubScope(t *testing.T) {
 	t.Parallel()
 	r := newTestStatsReporter()
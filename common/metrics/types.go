@@ -20,6 +20,12 @@ type Gauge interface {
 	Update(value float64)
 }
 
+// Histogram is the interface for emitting Histogram metrics.
+type Histogram interface {
+	// RecordValue records the value in the bucket it falls into.
+	RecordValue(value float64)
+}
+
 // Scope is a namespace wrapper around a stats Reporter, ensuring that
 // all emitted values have a given prefix or set of tags.
 type Scope interface {
@@ -30,6 +36,10 @@ type Scope interface {
 	// Gauge returns the Gauge object corresponding to the name.
 	Gauge(name string) Gauge
 
+	// Histogram returns the Histogram object corresponding to the name,
+	// whose buckets are delimited by the given upper bounds.
+	Histogram(name string, buckets []float64) Histogram
+
 	// Tagged returns a new child Scope with the given tags and current tags.
 	Tagged(tags map[string]string) Scope
 
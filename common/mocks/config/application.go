@@ -7,12 +7,18 @@ SPDX-License-Identifier: Apache-2.0
 package config
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/common/channelconfig"
+	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
 type MockApplication struct {
-	CapabilitiesRv channelconfig.ApplicationCapabilities
-	Acls           map[string]string
+	CapabilitiesRv         channelconfig.ApplicationCapabilities
+	Acls                   map[string]string
+	ChannelStateRv         pb.ChannelState_State
+	MaxClockSkewRv         time.Duration
+	MaxRangeQueryResultsRv uint32
 }
 
 func (m *MockApplication) Organizations() map[string]channelconfig.ApplicationOrg {
@@ -35,6 +41,18 @@ func (m *MockApplication) APIPolicyMapper() channelconfig.PolicyMapper {
 	return m
 }
 
+func (m *MockApplication) ChannelState() pb.ChannelState_State {
+	return m.ChannelStateRv
+}
+
+func (m *MockApplication) MaxClockSkew() time.Duration {
+	return m.MaxClockSkewRv
+}
+
+func (m *MockApplication) MaxRangeQueryResults() uint32 {
+	return m.MaxRangeQueryResultsRv
+}
+
 type MockApplicationCapabilities struct {
 	SupportedRv                  error
 	ForbidDuplicateTXIdInBlockRv bool
@@ -46,6 +64,14 @@ type MockApplicationCapabilities struct {
 	MetadataLifecycleRv          bool
 	KeyLevelEndorsementRv        bool
 	V1_3ValidationRv             bool
+	MaintenanceModeRv            bool
+	TxValidityWindowRv           bool
+	ChaincodeRetirementRv        bool
+	ChannelConfigPolicyRefRv     bool
+	CollectionEndorsementRv      bool
+	RangeQueryValidationRv       bool
+	DefaultAnchorPeersRv         bool
+	CouchDBBinaryValuesRv        bool
 }
 
 func (mac *MockApplicationCapabilities) Supported() error {
@@ -87,3 +113,35 @@ func (mac *MockApplicationCapabilities) KeyLevelEndorsement() bool {
 func (mac *MockApplicationCapabilities) V1_3Validation() bool {
 	return mac.V1_3ValidationRv
 }
+
+func (mac *MockApplicationCapabilities) MaintenanceMode() bool {
+	return mac.MaintenanceModeRv
+}
+
+func (mac *MockApplicationCapabilities) TxValidityWindow() bool {
+	return mac.TxValidityWindowRv
+}
+
+func (mac *MockApplicationCapabilities) ChaincodeRetirement() bool {
+	return mac.ChaincodeRetirementRv
+}
+
+func (mac *MockApplicationCapabilities) ChannelConfigPolicyReference() bool {
+	return mac.ChannelConfigPolicyRefRv
+}
+
+func (mac *MockApplicationCapabilities) CollectionEndorsementPolicy() bool {
+	return mac.CollectionEndorsementRv
+}
+
+func (mac *MockApplicationCapabilities) RangeQueryValidation() bool {
+	return mac.RangeQueryValidationRv
+}
+
+func (mac *MockApplicationCapabilities) DefaultAnchorPeers() bool {
+	return mac.DefaultAnchorPeersRv
+}
+
+func (mac *MockApplicationCapabilities) CouchDBBinaryValues() bool {
+	return mac.CouchDBBinaryValuesRv
+}
@@ -68,6 +68,15 @@ type ChannelCapabilities struct {
 
 	// MSPVersionVal is returned by MSPVersion()
 	MSPVersionVal msp.MSPVersion
+
+	// ConfigurableHashingVal is returned by ConfigurableHashing()
+	ConfigurableHashingVal bool
+
+	// ExpiredCertToleranceVal is returned by ExpiredCertTolerance()
+	ExpiredCertToleranceVal bool
+
+	// DefinedSubPoliciesVal is returned by DefinedSubPolicies()
+	DefinedSubPoliciesVal bool
 }
 
 // Supported returns SupportedErr
@@ -79,3 +88,18 @@ func (cc *ChannelCapabilities) Supported() error {
 func (cc *ChannelCapabilities) MSPVersion() msp.MSPVersion {
 	return cc.MSPVersionVal
 }
+
+// ConfigurableHashing returns ConfigurableHashingVal
+func (cc *ChannelCapabilities) ConfigurableHashing() bool {
+	return cc.ConfigurableHashingVal
+}
+
+// ExpiredCertTolerance returns ExpiredCertToleranceVal
+func (cc *ChannelCapabilities) ExpiredCertTolerance() bool {
+	return cc.ExpiredCertToleranceVal
+}
+
+// DefinedSubPolicies returns DefinedSubPoliciesVal
+func (cc *ChannelCapabilities) DefinedSubPolicies() bool {
+	return cc.DefinedSubPoliciesVal
+}
@@ -19,6 +19,8 @@ type Orderer struct {
 	ConsensusTypeVal string
 	// ConsensusMetadataVal is returned as the result of ConsensusMetadata()
 	ConsensusMetadataVal []byte
+	// ConsensusStateVal is returned as the result of ConsensusState()
+	ConsensusStateVal ab.ConsensusType_State
 	// BatchSizeVal is returned as the result of BatchSize()
 	BatchSizeVal *ab.BatchSize
 	// BatchTimeoutVal is returned as the result of BatchTimeout()
@@ -43,6 +45,11 @@ func (o *Orderer) ConsensusMetadata() []byte {
 	return o.ConsensusMetadataVal
 }
 
+// ConsensusState returns the ConsensusStateVal
+func (o *Orderer) ConsensusState() ab.ConsensusType_State {
+	return o.ConsensusStateVal
+}
+
 // BatchSize returns the BatchSizeVal
 func (o *Orderer) BatchSize() *ab.BatchSize {
 	return o.BatchSizeVal
@@ -86,6 +93,12 @@ type OrdererCapabilities struct {
 
 	// ExpirationVal is returned by ExpirationCheck()
 	ExpirationVal bool
+
+	// TxPriorityVal is returned by TxPriority()
+	TxPriorityVal bool
+
+	// ConsensusTypeMigrationVal is returned by ConsensusTypeMigration()
+	ConsensusTypeMigrationVal bool
 }
 
 // Supported returns SupportedErr
@@ -108,3 +121,13 @@ func (oc *OrdererCapabilities) Resubmission() bool {
 func (oc *OrdererCapabilities) ExpirationCheck() bool {
 	return oc.ExpirationVal
 }
+
+// TxPriority returns TxPriorityVal
+func (oc *OrdererCapabilities) TxPriority() bool {
+	return oc.TxPriorityVal
+}
+
+// ConsensusTypeMigration returns ConsensusTypeMigrationVal
+func (oc *OrdererCapabilities) ConsensusTypeMigration() bool {
+	return oc.ConsensusTypeMigrationVal
+}
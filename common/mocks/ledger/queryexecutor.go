@@ -55,6 +55,10 @@ func (m *MockQueryExecutor) GetStateRangeScanIterator(namespace string, startKey
 	return nil, nil
 }
 
+func (m *MockQueryExecutor) GetStateRangeScanIteratorReverse(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	return nil, nil
+}
+
 func (m *MockQueryExecutor) GetStateRangeScanIteratorWithMetadata(namespace string, startKey, endKey string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
 	return nil, nil
 }
@@ -0,0 +1,154 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+// MockScope is a metrics.Scope which records the values of the metrics
+// created from it, by name and by the tags of the scope they were created
+// from. The scopes derived from a MockScope share its values, which the
+// metrics update safely from concurrent goroutines.
+type MockScope struct {
+	prefix string
+	tags   map[string]string
+	values *values
+}
+
+type values struct {
+	mutex      sync.Mutex
+	counters   map[string]int64
+	gauges     map[string]float64
+	histograms map[string][]float64
+}
+
+// NewMockScope returns an untagged MockScope which has recorded no value
+func NewMockScope() *MockScope {
+	return &MockScope{
+		values: &values{
+			counters:   map[string]int64{},
+			gauges:     map[string]float64{},
+			histograms: map[string][]float64{},
+		},
+	}
+}
+
+// Counter returns the counter of the name
+func (s *MockScope) Counter(name string) metrics.Counter {
+	return &mockCounter{values: s.values, key: key(s.prefix+name, s.tags)}
+}
+
+// Gauge returns the gauge of the name
+func (s *MockScope) Gauge(name string) metrics.Gauge {
+	return &mockGauge{values: s.values, key: key(s.prefix+name, s.tags)}
+}
+
+// Histogram returns the histogram of the name, which records every value
+// rather than bucketing them
+func (s *MockScope) Histogram(name string, buckets []float64) metrics.Histogram {
+	return &mockHistogram{values: s.values, key: key(s.prefix+name, s.tags)}
+}
+
+// Tagged returns a child scope with the tags merged into the tags of the scope
+func (s *MockScope) Tagged(tags map[string]string) metrics.Scope {
+	merged := map[string]string{}
+	for k, v := range s.tags {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return &MockScope{prefix: s.prefix, tags: merged, values: s.values}
+}
+
+// SubScope returns a child scope whose metric names are prefixed with the name
+func (s *MockScope) SubScope(name string) metrics.Scope {
+	return &MockScope{prefix: s.prefix + name + ".", tags: s.tags, values: s.values}
+}
+
+// Close does nothing
+func (s *MockScope) Close() error {
+	return nil
+}
+
+// Start does nothing
+func (s *MockScope) Start() error {
+	return nil
+}
+
+// CounterValue returns the value of the counter of the name created from a
+// scope with exactly the tags
+func (s *MockScope) CounterValue(name string, tags map[string]string) int64 {
+	s.values.mutex.Lock()
+	defer s.values.mutex.Unlock()
+	return s.values.counters[key(name, tags)]
+}
+
+// GaugeValue returns the value of the gauge of the name created from a scope
+// with exactly the tags, and whether it was ever updated
+func (s *MockScope) GaugeValue(name string, tags map[string]string) (float64, bool) {
+	s.values.mutex.Lock()
+	defer s.values.mutex.Unlock()
+	value, ok := s.values.gauges[key(name, tags)]
+	return value, ok
+}
+
+// HistogramValues returns the values recorded by the histogram of the name
+// created from a scope with exactly the tags, in the order they were recorded
+func (s *MockScope) HistogramValues(name string, tags map[string]string) []float64 {
+	s.values.mutex.Lock()
+	defer s.values.mutex.Unlock()
+	return append([]float64(nil), s.values.histograms[key(name, tags)]...)
+}
+
+// key returns the name of the metric followed by the tags, ordered by tag
+func key(name string, tags map[string]string) string {
+	var pairs []string
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return name + "+" + strings.Join(pairs, ",")
+}
+
+type mockCounter struct {
+	values *values
+	key    string
+}
+
+func (c *mockCounter) Inc(delta int64) {
+	c.values.mutex.Lock()
+	defer c.values.mutex.Unlock()
+	c.values.counters[c.key] += delta
+}
+
+type mockGauge struct {
+	values *values
+	key    string
+}
+
+func (g *mockGauge) Update(value float64) {
+	g.values.mutex.Lock()
+	defer g.values.mutex.Unlock()
+	g.values.gauges[g.key] = value
+}
+
+type mockHistogram struct {
+	values *values
+	key    string
+}
+
+func (h *mockHistogram) RecordValue(value float64) {
+	h.values.mutex.Lock()
+	defer h.values.mutex.Unlock()
+	h.values.histograms[h.key] = append(h.values.histograms[h.key], value)
+}
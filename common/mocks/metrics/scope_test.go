@@ -0,0 +1,41 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockScope(t *testing.T) {
+	var scope metrics.Scope = NewMockScope()
+	mock := scope.(*MockScope)
+
+	scope.Counter("requests").Inc(2)
+	tagged := scope.Tagged(map[string]string{"channel": "mychannel"})
+	tagged.Tagged(map[string]string{"org": "Org1MSP"}).Counter("requests").Inc(1)
+	tagged.Tagged(map[string]string{"org": "Org1MSP"}).Counter("requests").Inc(3)
+	assert.Equal(t, int64(2), mock.CounterValue("requests", nil))
+	assert.Equal(t, int64(4), mock.CounterValue("requests", map[string]string{"channel": "mychannel", "org": "Org1MSP"}))
+	assert.Equal(t, int64(0), mock.CounterValue("requests", map[string]string{"channel": "mychannel"}))
+
+	_, ok := mock.GaugeValue("height", map[string]string{"channel": "mychannel"})
+	assert.False(t, ok)
+	tagged.Gauge("height").Update(7)
+	height, ok := mock.GaugeValue("height", map[string]string{"channel": "mychannel"})
+	assert.True(t, ok)
+	assert.Equal(t, float64(7), height)
+
+	scope.SubScope("grpc").Histogram("size", nil).RecordValue(1)
+	scope.SubScope("grpc").Histogram("size", nil).RecordValue(2)
+	assert.Equal(t, []float64{1, 2}, mock.HistogramValues("grpc.size", nil))
+
+	assert.NoError(t, scope.Start())
+	assert.NoError(t, scope.Close())
+}
@@ -25,19 +25,29 @@ type implicitMetaPolicy struct {
 	subPolicyName string
 }
 
-// NewPolicy creates a new policy based on the policy bytes
-func newImplicitMetaPolicy(data []byte, managers map[string]*ManagerImpl) (*implicitMetaPolicy, error) {
+// NewPolicy creates a new policy based on the policy bytes. If definedOnly is
+// set, the managers which do not define the sub-policy are left out instead
+// of counting as rejecting it, and at least one of the managers must define it.
+func newImplicitMetaPolicy(data []byte, managers map[string]*ManagerImpl, definedOnly bool) (*implicitMetaPolicy, error) {
 	definition := &cb.ImplicitMetaPolicy{}
 	if err := proto.Unmarshal(data, definition); err != nil {
 		return nil, fmt.Errorf("Error unmarshaling to ImplicitMetaPolicy: %s", err)
 	}
 
-	subPolicies := make([]Policy, len(managers))
+	subPolicies := make([]Policy, 0, len(managers))
+	subManagers := make(map[string]*ManagerImpl, len(managers))
 
-	i := 0
-	for _, manager := range managers {
-		subPolicies[i], _ = manager.GetPolicy(definition.SubPolicy)
-		i++
+	for name, manager := range managers {
+		subPolicy, ok := manager.GetPolicy(definition.SubPolicy)
+		if !ok && definedOnly {
+			continue
+		}
+		subPolicies = append(subPolicies, subPolicy)
+		subManagers[name] = manager
+	}
+
+	if definedOnly && len(managers) > 0 && len(subPolicies) == 0 {
+		return nil, fmt.Errorf("no sub-group defines the sub-policy %s", definition.SubPolicy)
 	}
 
 	var threshold int
@@ -59,7 +69,7 @@ func newImplicitMetaPolicy(data []byte, managers map[string]*ManagerImpl) (*impl
 	return &implicitMetaPolicy{
 		subPolicies:   subPolicies,
 		threshold:     threshold,
-		managers:      managers,
+		managers:      subManagers,
 		subPolicyName: definition.SubPolicy,
 	}, nil
 }
@@ -35,7 +35,7 @@ func (rp acceptPolicy) Evaluate(signedData []*cb.SignedData) error {
 }
 
 func TestImplicitMarshalError(t *testing.T) {
-	_, err := newImplicitMetaPolicy([]byte("GARBAGE"), nil)
+	_, err := newImplicitMetaPolicy([]byte("GARBAGE"), nil, false)
 	assert.Error(t, err, "Should have errored unmarshaling garbage")
 }
 
@@ -61,7 +61,7 @@ func runPolicyTest(rule cb.ImplicitMetaPolicy_Rule, managerCount int, passingCou
 	imp, err := newImplicitMetaPolicy(utils.MarshalOrPanic(&cb.ImplicitMetaPolicy{
 		Rule:      rule,
 		SubPolicy: TestPolicyName,
-	}), makeManagers(managerCount, passingCount))
+	}), makeManagers(managerCount, passingCount), false)
 	if err != nil {
 		panic(err)
 	}
@@ -93,3 +93,22 @@ func TestImplicitMetaMajority(t *testing.T) {
 	assert.Error(t, runPolicyTest(cb.ImplicitMetaPolicy_MAJORITY, 10, 0))
 	assert.NoError(t, runPolicyTest(cb.ImplicitMetaPolicy_MAJORITY, 0, 0))
 }
+
+func TestImplicitMetaDefinedSubPolicies(t *testing.T) {
+	definition := utils.MarshalOrPanic(&cb.ImplicitMetaPolicy{
+		Rule:      cb.ImplicitMetaPolicy_ALL,
+		SubPolicy: TestPolicyName,
+	})
+
+	imp, err := newImplicitMetaPolicy(definition, makeManagers(10, 3), true)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, imp.threshold)
+	assert.NoError(t, imp.Evaluate(nil))
+
+	_, err = newImplicitMetaPolicy(definition, makeManagers(10, 0), true)
+	assert.EqualError(t, err, "no sub-group defines the sub-policy TestPolicyName")
+
+	imp, err = newImplicitMetaPolicy(definition, makeManagers(0, 0), true)
+	assert.NoError(t, err)
+	assert.NoError(t, imp.Evaluate(nil))
+}
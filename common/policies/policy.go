@@ -157,6 +157,18 @@ type ManagerImpl struct {
 
 // NewManagerImpl creates a new ManagerImpl with the given CryptoHelper
 func NewManagerImpl(path string, providers map[int32]Provider, root *cb.ConfigGroup) (*ManagerImpl, error) {
+	return newManagerImpl(path, providers, root, false)
+}
+
+// NewDefinedSubPoliciesManagerImpl creates a new ManagerImpl like NewManagerImpl,
+// except that its implicit meta policies only count the sub-groups which define
+// their sub-policy, so that they can roll up policies which only some of the
+// sub-groups define, such as an Auditors policy of a few organizations.
+func NewDefinedSubPoliciesManagerImpl(path string, providers map[int32]Provider, root *cb.ConfigGroup) (*ManagerImpl, error) {
+	return newManagerImpl(path, providers, root, true)
+}
+
+func newManagerImpl(path string, providers map[int32]Provider, root *cb.ConfigGroup, definedSubPolicies bool) (*ManagerImpl, error) {
 	var err error
 	_, ok := providers[int32(cb.Policy_IMPLICIT_META)]
 	if ok {
@@ -166,7 +178,7 @@ func NewManagerImpl(path string, providers map[int32]Provider, root *cb.ConfigGr
 	managers := make(map[string]*ManagerImpl)
 
 	for groupName, group := range root.Groups {
-		managers[groupName], err = NewManagerImpl(path+PathSeparator+groupName, providers, group)
+		managers[groupName], err = newManagerImpl(path+PathSeparator+groupName, providers, group, definedSubPolicies)
 		if err != nil {
 			return nil, err
 		}
@@ -182,7 +194,7 @@ func NewManagerImpl(path string, providers map[int32]Provider, root *cb.ConfigGr
 		var cPolicy Policy
 
 		if policy.Type == int32(cb.Policy_IMPLICIT_META) {
-			imp, err := newImplicitMetaPolicy(policy.Value, managers)
+			imp, err := newImplicitMetaPolicy(policy.Value, managers, definedSubPolicies)
 			if err != nil {
 				return nil, errors.Wrapf(err, "implicit policy %s at path %s did not compile", policyName, path)
 			}
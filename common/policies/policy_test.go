@@ -18,6 +18,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -183,6 +184,81 @@ func TestNestedManager(t *testing.T) {
 	}
 }
 
+type acceptProvider struct{}
+
+func (ap acceptProvider) NewPolicy(data []byte) (Policy, proto.Message, error) {
+	return acceptPolicy{}, nil, nil
+}
+
+func auditorsConfig(subPolicy string) *cb.ConfigGroup {
+	implicitMetaAll := &cb.Policy{
+		Type: int32(cb.Policy_IMPLICIT_META),
+		Value: utils.MarshalOrPanic(&cb.ImplicitMetaPolicy{
+			Rule:      cb.ImplicitMetaPolicy_ALL,
+			SubPolicy: subPolicy,
+		}),
+	}
+	return &cb.ConfigGroup{
+		Policies: map[string]*cb.ConfigPolicy{
+			"Auditors": {Policy: implicitMetaAll},
+		},
+		Groups: map[string]*cb.ConfigGroup{
+			"Application": {
+				Policies: map[string]*cb.ConfigPolicy{
+					"Auditors": {Policy: implicitMetaAll},
+				},
+				Groups: map[string]*cb.ConfigGroup{
+					"Org1": {
+						Policies: map[string]*cb.ConfigPolicy{
+							"Auditors": {Policy: &cb.Policy{Type: mockType}},
+						},
+					},
+					"Org2": {
+						Policies: map[string]*cb.ConfigPolicy{
+							"Auditors": {Policy: &cb.Policy{Type: mockType}},
+						},
+					},
+					"Org3": {
+						Policies: map[string]*cb.ConfigPolicy{
+							"Readers": {Policy: &cb.Policy{Type: mockType}},
+						},
+					},
+				},
+			},
+			"Orderer": {
+				Policies: map[string]*cb.ConfigPolicy{
+					"Readers": {Policy: &cb.Policy{Type: mockType}},
+				},
+			},
+		},
+	}
+}
+
+func TestDefinedSubPoliciesManager(t *testing.T) {
+	providers := map[int32]Provider{mockType: acceptProvider{}}
+
+	m, err := NewManagerImpl("Channel", providers, auditorsConfig("Auditors"))
+	assert.NoError(t, err)
+	for _, name := range []string{"/Channel/Auditors", "/Channel/Application/Auditors"} {
+		policy, ok := m.GetPolicy(name)
+		assert.True(t, ok)
+		assert.Error(t, policy.Evaluate(nil), "%s should count the groups not defining Auditors", name)
+	}
+
+	m, err = NewDefinedSubPoliciesManagerImpl("Channel", providers, auditorsConfig("Auditors"))
+	assert.NoError(t, err)
+	for _, name := range []string{"/Channel/Auditors", "/Channel/Application/Auditors"} {
+		policy, ok := m.GetPolicy(name)
+		assert.True(t, ok)
+		assert.NoError(t, policy.Evaluate(nil), "%s should only count the groups defining Auditors", name)
+	}
+
+	_, err = NewManagerImpl("Channel", providers, auditorsConfig("Auditor"))
+	assert.NoError(t, err)
+	_, err = NewDefinedSubPoliciesManagerImpl("Channel", providers, auditorsConfig("Auditor"))
+	assert.EqualError(t, err, "implicit policy Auditors at path Channel/Application did not compile: no sub-group defines the sub-policy Auditor")
+}
+
 func TestPrincipalUniqueSet(t *testing.T) {
 	var principalSet PrincipalSet
 	addPrincipal := func(i int) {
@@ -148,7 +148,11 @@ func NewChannelGroup(conf *genesisconfig.Profile) (*cb.ConfigGroup, error) {
 		}
 	}
 
-	addValue(channelGroup, channelconfig.HashingAlgorithmValue(), channelconfig.AdminsPolicyKey)
+	if conf.HashingAlgorithm != "" {
+		addValue(channelGroup, channelconfig.HashingAlgorithmValueWithName(conf.HashingAlgorithm), channelconfig.AdminsPolicyKey)
+	} else {
+		addValue(channelGroup, channelconfig.HashingAlgorithmValue(), channelconfig.AdminsPolicyKey)
+	}
 	addValue(channelGroup, channelconfig.BlockDataHashingStructureValue(), channelconfig.AdminsPolicyKey)
 	addValue(channelGroup, channelconfig.OrdererAddressesValue(conf.Orderer.Addresses), ordererAdminsPolicyName)
 
@@ -205,6 +209,7 @@ func NewOrdererGroup(conf *genesisconfig.Orderer) (*cb.ConfigGroup, error) {
 		conf.BatchSize.MaxMessageCount,
 		conf.BatchSize.AbsoluteMaxBytes,
 		conf.BatchSize.PreferredMaxBytes,
+		conf.BatchSize.PriorityReorderingLimit,
 	), channelconfig.AdminsPolicyKey)
 	addValue(ordererGroup, channelconfig.BatchTimeoutValue(conf.BatchTimeout.String()), channelconfig.AdminsPolicyKey)
 	addValue(ordererGroup, channelconfig.ChannelRestrictionsValue(conf.MaxChannels), channelconfig.AdminsPolicyKey)
@@ -225,7 +230,10 @@ func NewOrdererGroup(conf *genesisconfig.Orderer) (*cb.ConfigGroup, error) {
 			return nil, errors.Errorf("cannot marshal metadata for orderer type %s: %s", etcdraft.TypeKey, err)
 		}
 	default:
-		return nil, errors.Errorf("unknown orderer type: %s", conf.OrdererType)
+		if conf.Plugin == nil {
+			return nil, errors.Errorf("unknown orderer type: %s", conf.OrdererType)
+		}
+		consensusMetadata = []byte(conf.Plugin.Metadata)
 	}
 
 	addValue(ordererGroup, channelconfig.ConsensusTypeValue(conf.OrdererType, consensusMetadata), channelconfig.AdminsPolicyKey)
@@ -326,6 +334,9 @@ func NewApplicationOrgGroup(conf *genesisconfig.Organization) (*cb.ConfigGroup,
 		})
 	}
 	addValue(applicationOrgGroup, channelconfig.AnchorPeersValue(anchorProtos), channelconfig.AdminsPolicyKey)
+	if len(conf.PeerEndpoints) > 0 {
+		addValue(applicationOrgGroup, channelconfig.PeerEndpointsValue(conf.PeerEndpoints), channelconfig.AdminsPolicyKey)
+	}
 
 	applicationOrgGroup.ModPolicy = channelconfig.AdminsPolicyKey
 	return applicationOrgGroup, nil
@@ -364,6 +375,9 @@ func NewConsortiumGroup(conf *genesisconfig.Consortium) (*cb.ConfigGroup, error)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create consortium org")
 		}
+		if len(org.PeerEndpoints) > 0 {
+			addValue(consortiumGroup.Groups[org.Name], channelconfig.PeerEndpointsValue(org.PeerEndpoints), channelconfig.AdminsPolicyKey)
+		}
 	}
 
 	addValue(consortiumGroup, channelconfig.ChannelCreationPolicyValue(policies.ImplicitMetaAnyPolicy(channelconfig.AdminsPolicyKey).Value()), ordererAdminsPolicyName)
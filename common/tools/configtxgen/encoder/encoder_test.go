@@ -80,6 +80,17 @@ func TestGoodChannelCreateConfigUpdate(t *testing.T) {
 	assert.NotNil(t, configUpdate)
 }
 
+func TestChannelCreateWithPolicyTemplate(t *testing.T) {
+	createConfig := configtxgentest.Load(genesisconfig.SampleSingleMSPChannelKOfNProfile)
+
+	configUpdate, err := NewChannelCreateConfigUpdate("channel.id", createConfig)
+	assert.NoError(t, err)
+	policy := configUpdate.WriteSet.Groups["Application"].Policies[genesisconfig.LifecycleEndorsementPolicyName]
+	if assert.NotNil(t, policy) {
+		assert.Equal(t, int32(cb.Policy_SIGNATURE), policy.Policy.Type)
+	}
+}
+
 func TestGoodChannelCreateNoAnchorPeers(t *testing.T) {
 	createConfig := configtxgentest.Load(genesisconfig.SampleSingleMSPChannelProfile)
 	createConfig.Application.Organizations[0].AnchorPeers = nil
@@ -170,6 +181,15 @@ func TestNewApplicationGroup(t *testing.T) {
 		assert.NotNil(t, group)
 	})
 
+	t.Run("Application with peer endpoints", func(t *testing.T) {
+		config := configtxgentest.Load(genesisconfig.SampleSingleMSPChannelProfile)
+		config.Application.Organizations[0].PeerEndpoints = []string{"peer0.org1:7051"}
+		group, err := NewApplicationGroup(config.Application)
+		assert.NoError(t, err)
+		orgGroup := group.Groups[config.Application.Organizations[0].Name]
+		assert.Equal(t, utils.MarshalOrPanic(&cb.PeerEndpoints{Addresses: []string{"peer0.org1:7051"}}), orgGroup.Values[channelconfig.PeerEndpointsKey].Value)
+	})
+
 	t.Run("Application unknown MSP", func(t *testing.T) {
 		config := configtxgentest.Load(genesisconfig.SampleSingleMSPChannelProfile)
 		config.Application.Organizations[0] = &genesisconfig.Organization{Name: "FakeOrg", ID: "FakeOrg"}
@@ -179,6 +199,23 @@ func TestNewApplicationGroup(t *testing.T) {
 	})
 }
 
+func TestNewConsortiumGroupPeerEndpoints(t *testing.T) {
+	config := configtxgentest.Load(genesisconfig.SampleDevModeSoloProfile)
+	consortium := config.Consortiums["SampleConsortium"]
+
+	group, err := NewConsortiumGroup(consortium)
+	assert.NoError(t, err)
+	orgGroup := group.Groups[consortium.Organizations[0].Name]
+	assert.NotContains(t, orgGroup.Values, channelconfig.PeerEndpointsKey)
+
+	consortium.Organizations[0].PeerEndpoints = []string{"peer0.org1:7051"}
+	group, err = NewConsortiumGroup(consortium)
+	assert.NoError(t, err)
+	orgGroup = group.Groups[consortium.Organizations[0].Name]
+	assert.Equal(t, utils.MarshalOrPanic(&cb.PeerEndpoints{Addresses: []string{"peer0.org1:7051"}}), orgGroup.Values[channelconfig.PeerEndpointsKey].Value)
+	assert.Equal(t, channelconfig.AdminsPolicyKey, orgGroup.Values[channelconfig.PeerEndpointsKey].ModPolicy)
+}
+
 func TestNewChannelGroup(t *testing.T) {
 	t.Run("Nil orderer", func(t *testing.T) {
 		config := configtxgentest.Load(genesisconfig.SampleDevModeSoloProfile)
@@ -196,6 +233,21 @@ func TestNewChannelGroup(t *testing.T) {
 		assert.NotNil(t, group)
 	})
 
+	t.Run("Hashing algorithm", func(t *testing.T) {
+		config := configtxgentest.Load(genesisconfig.SampleDevModeSoloProfile)
+		group, err := NewChannelGroup(config)
+		assert.NoError(t, err)
+		hashingAlgorithm := &cb.HashingAlgorithm{}
+		assert.NoError(t, proto.Unmarshal(group.Values[channelconfig.HashingAlgorithmKey].Value, hashingAlgorithm))
+		assert.Equal(t, "SHA256", hashingAlgorithm.Name)
+
+		config.HashingAlgorithm = "SHA3_256"
+		group, err = NewChannelGroup(config)
+		assert.NoError(t, err)
+		assert.NoError(t, proto.Unmarshal(group.Values[channelconfig.HashingAlgorithmKey].Value, hashingAlgorithm))
+		assert.Equal(t, "SHA3_256", hashingAlgorithm.Name)
+	})
+
 	t.Run("Channel missing policies", func(t *testing.T) {
 		config := configtxgentest.Load(genesisconfig.SampleDevModeSoloProfile)
 		config.Policies = nil
@@ -238,6 +290,18 @@ func TestNewOrdererGroup(t *testing.T) {
 		assert.Nil(t, group)
 	})
 
+	t.Run("Plugin orderer type", func(t *testing.T) {
+		config := configtxgentest.Load(genesisconfig.SampleDevModeSoloProfile)
+		config.Orderer.OrdererType = "TestOrderer"
+		config.Orderer.Plugin = &genesisconfig.ConsensusPlugin{Metadata: "metadata"}
+		group, err := NewOrdererGroup(config.Orderer)
+		require.NoError(t, err)
+		consensusType := &ab.ConsensusType{}
+		require.NoError(t, proto.Unmarshal(group.Values[channelconfig.ConsensusTypeKey].Value, consensusType))
+		assert.Equal(t, "TestOrderer", consensusType.Type)
+		assert.Equal(t, []byte("metadata"), consensusType.Metadata)
+	})
+
 	t.Run("Orderer missing policies", func(t *testing.T) {
 		config := configtxgentest.Load(genesisconfig.SampleDevModeSoloProfile)
 		config.Orderer.Policies = nil
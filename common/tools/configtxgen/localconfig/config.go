@@ -68,6 +68,10 @@ const (
 	// SampleSingleMSPChannelProfile references the sample profile which
 	// includes only the sample MSP and is used to create a channel
 	SampleSingleMSPChannelProfile = "SampleSingleMSPChannel"
+	// SampleSingleMSPChannelKOfNProfile references the sample profile which
+	// includes only the sample MSP, requires the peers of K of the orgs to
+	// endorse lifecycle operations and is used to create a channel
+	SampleSingleMSPChannelKOfNProfile = "SampleSingleMSPChannelKOfN"
 
 	// SampleConsortiumName is the sample consortium from the
 	// sample configtx.yaml
@@ -97,12 +101,14 @@ type TopLevel struct {
 // Profile encodes orderer/application configuration combinations for the
 // configtxgen tool.
 type Profile struct {
-	Consortium   string                 `yaml:"Consortium"`
-	Application  *Application           `yaml:"Application"`
-	Orderer      *Orderer               `yaml:"Orderer"`
-	Consortiums  map[string]*Consortium `yaml:"Consortiums"`
-	Capabilities map[string]bool        `yaml:"Capabilities"`
-	Policies     map[string]*Policy     `yaml:"Policies"`
+	Consortium       string                 `yaml:"Consortium"`
+	Application      *Application           `yaml:"Application"`
+	Orderer          *Orderer               `yaml:"Orderer"`
+	Consortiums      map[string]*Consortium `yaml:"Consortiums"`
+	Capabilities     map[string]bool        `yaml:"Capabilities"`
+	Policies         map[string]*Policy     `yaml:"Policies"`
+	PolicyTemplate   *PolicyTemplate        `yaml:"PolicyTemplate"`
+	HashingAlgorithm string                 `yaml:"HashingAlgorithm"`
 }
 
 // Policy encodes a channel config policy
@@ -120,11 +126,12 @@ type Consortium struct {
 // Application encodes the application-level configuration needed in config
 // transactions.
 type Application struct {
-	Organizations []*Organization    `yaml:"Organizations"`
-	Capabilities  map[string]bool    `yaml:"Capabilities"`
-	Resources     *Resources         `yaml:"Resources"`
-	Policies      map[string]*Policy `yaml:"Policies"`
-	ACLs          map[string]string  `yaml:"ACLs"`
+	Organizations  []*Organization    `yaml:"Organizations"`
+	Capabilities   map[string]bool    `yaml:"Capabilities"`
+	Resources      *Resources         `yaml:"Resources"`
+	Policies       map[string]*Policy `yaml:"Policies"`
+	PolicyTemplate *PolicyTemplate    `yaml:"PolicyTemplate"`
+	ACLs           map[string]string  `yaml:"ACLs"`
 }
 
 // Resources encodes the application-level resources configuration needed to
@@ -147,6 +154,10 @@ type Organization struct {
 	// for both orderers and applications.
 	AnchorPeers []*AnchorPeer `yaml:"AnchorPeers"`
 
+	// PeerEndpoints are the host:port external endpoints of the peers of
+	// the org, encoded both for consortiums and applications.
+	PeerEndpoints []string `yaml:"PeerEndpoints"`
+
 	// AdminPrincipal is deprecated and may be removed in a future release
 	// it was used for modifying the default policy generation, but policies
 	// may now be specified explicitly so it is redundant and unnecessary
@@ -162,23 +173,33 @@ type AnchorPeer struct {
 // Orderer contains configuration which is used for the
 // bootstrapping of an orderer by the provisional bootstrapper.
 type Orderer struct {
-	OrdererType   string             `yaml:"OrdererType"`
-	Addresses     []string           `yaml:"Addresses"`
-	BatchTimeout  time.Duration      `yaml:"BatchTimeout"`
-	BatchSize     BatchSize          `yaml:"BatchSize"`
-	Kafka         Kafka              `yaml:"Kafka"`
-	EtcdRaft      *etcdraft.Metadata `yaml:"EtcdRaft"`
-	Organizations []*Organization    `yaml:"Organizations"`
-	MaxChannels   uint64             `yaml:"MaxChannels"`
-	Capabilities  map[string]bool    `yaml:"Capabilities"`
-	Policies      map[string]*Policy `yaml:"Policies"`
+	OrdererType    string             `yaml:"OrdererType"`
+	Addresses      []string           `yaml:"Addresses"`
+	BatchTimeout   time.Duration      `yaml:"BatchTimeout"`
+	BatchSize      BatchSize          `yaml:"BatchSize"`
+	Kafka          Kafka              `yaml:"Kafka"`
+	EtcdRaft       *etcdraft.Metadata `yaml:"EtcdRaft"`
+	Plugin         *ConsensusPlugin   `yaml:"Plugin"`
+	Organizations  []*Organization    `yaml:"Organizations"`
+	MaxChannels    uint64             `yaml:"MaxChannels"`
+	Capabilities   map[string]bool    `yaml:"Capabilities"`
+	Policies       map[string]*Policy `yaml:"Policies"`
+	PolicyTemplate *PolicyTemplate    `yaml:"PolicyTemplate"`
+}
+
+// ConsensusPlugin marks the OrdererType as being provided by an orderer
+// consensus plugin rather than being builtin.
+type ConsensusPlugin struct {
+	// Metadata is passed to the plugin as the consensus metadata of the channel.
+	Metadata string `yaml:"Metadata"`
 }
 
 // BatchSize contains configuration affecting the size of batches.
 type BatchSize struct {
-	MaxMessageCount   uint32 `yaml:"MaxMessageCount"`
-	AbsoluteMaxBytes  uint32 `yaml:"AbsoluteMaxBytes"`
-	PreferredMaxBytes uint32 `yaml:"PreferredMaxBytes"`
+	MaxMessageCount         uint32 `yaml:"MaxMessageCount"`
+	AbsoluteMaxBytes        uint32 `yaml:"AbsoluteMaxBytes"`
+	PreferredMaxBytes       uint32 `yaml:"PreferredMaxBytes"`
+	PriorityReorderingLimit uint32 `yaml:"PriorityReorderingLimit"`
 }
 
 // Kafka contains configuration for the Kafka-based orderer.
@@ -303,6 +324,8 @@ func (t *TopLevel) completeInitialization(configDir string) {
 }
 
 func (p *Profile) completeInitialization(configDir string) {
+	applyPolicyTemplate("Channel", p.PolicyTemplate, nil, &p.Policies)
+
 	if p.Application != nil {
 		for _, org := range p.Application.Organizations {
 			org.completeInitialization(configDir)
@@ -310,6 +333,7 @@ func (p *Profile) completeInitialization(configDir string) {
 		if p.Application.Resources != nil {
 			p.Application.Resources.completeInitialization()
 		}
+		applyPolicyTemplate("Application", p.Application.PolicyTemplate, p.Application.Organizations, &p.Application.Policies)
 	}
 
 	if p.Consortiums != nil {
@@ -379,6 +403,11 @@ loop:
 		}
 	}
 
+	if ord.PolicyTemplate != nil && ord.PolicyTemplate.Name == KOfNPolicyTemplate {
+		logger.Panicf("The %s policy template applies to the Application section only", KOfNPolicyTemplate)
+	}
+	applyPolicyTemplate("Orderer", ord.PolicyTemplate, ord.Organizations, &ord.Policies)
+
 	// Additional, consensus type-dependent initialization goes here
 	switch ord.OrdererType {
 	case "kafka":
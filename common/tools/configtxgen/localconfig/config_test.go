@@ -22,6 +22,7 @@ func TestLoadProfile(t *testing.T) {
 		SampleDevModeKafkaProfile,
 		SampleDevModeSoloProfile,
 		SampleSingleMSPChannelProfile,
+		SampleSingleMSPChannelKOfNProfile,
 		SampleSingleMSPKafkaProfile,
 		SampleSingleMSPSoloProfile,
 	}
@@ -41,6 +42,7 @@ func TestLoadProfileWithPath(t *testing.T) {
 		SampleDevModeKafkaProfile,
 		SampleDevModeSoloProfile,
 		SampleSingleMSPChannelProfile,
+		SampleSingleMSPChannelKOfNProfile,
 		SampleSingleMSPKafkaProfile,
 		SampleSingleMSPSoloProfile,
 	}
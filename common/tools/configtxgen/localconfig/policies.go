@@ -0,0 +1,166 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localconfig
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/pkg/errors"
+)
+
+const (
+	implicitMetaPolicyType = "ImplicitMeta"
+	signaturePolicyType    = "Signature"
+
+	// LifecycleEndorsementPolicyName is the name of the policy the
+	// organizations endorse chaincode lifecycle operations with, referenced
+	// as /Channel/Application/LifecycleEndorsement
+	LifecycleEndorsementPolicyName = "LifecycleEndorsement"
+
+	// MajorityPolicyTemplate requires any reader or writer of the
+	// organizations for reads and writes, and a majority of the admins of
+	// the organizations for config changes
+	MajorityPolicyTemplate = "Majority"
+	// UnanimousPolicyTemplate is the majority template where config changes
+	// require the admins of every organization, each holding a veto
+	UnanimousPolicyTemplate = "Unanimous"
+	// VetoPolicyTemplate is the majority template where config changes also
+	// require the admins of each of the veto organizations
+	VetoPolicyTemplate = "Veto"
+	// KOfNPolicyTemplate is the majority template with a lifecycle
+	// endorsement policy requiring the peers of K of the organizations
+	KOfNPolicyTemplate = "KOfN"
+)
+
+// PolicyTemplate selects a preset set of policies for a section of a
+// profile, for the common governance models of a consortium. The policies
+// of the section override those of the template.
+type PolicyTemplate struct {
+	// Name is the name of the template
+	Name string `yaml:"Name"`
+	// K is the number of organizations whose peers must endorse lifecycle
+	// operations with the KOfN template
+	K int `yaml:"K"`
+	// Veto lists the IDs of the organizations whose admins must sign config
+	// changes with the Veto template
+	Veto []string `yaml:"Veto"`
+}
+
+// Policies returns the policies of the template for a section holding the
+// organizations with the given MSP IDs
+func (t *PolicyTemplate) Policies(mspIDs []string) (map[string]*Policy, error) {
+	switch t.Name {
+	case MajorityPolicyTemplate:
+		return MajorityPolicies(), nil
+	case UnanimousPolicyTemplate:
+		policies := MajorityPolicies()
+		policies[channelconfig.AdminsPolicyKey] = &Policy{Type: implicitMetaPolicyType, Rule: "ALL " + channelconfig.AdminsPolicyKey}
+		return policies, nil
+	case VetoPolicyTemplate:
+		admins, err := VetoAdminsPolicy(t.Veto, mspIDs)
+		if err != nil {
+			return nil, err
+		}
+		policies := MajorityPolicies()
+		policies[channelconfig.AdminsPolicyKey] = admins
+		return policies, nil
+	case KOfNPolicyTemplate:
+		endorsement, err := KOfNEndorsementPolicy(t.K, mspIDs)
+		if err != nil {
+			return nil, err
+		}
+		policies := MajorityPolicies()
+		policies[LifecycleEndorsementPolicyName] = endorsement
+		return policies, nil
+	default:
+		return nil, errors.Errorf("unknown policy template: %s", t.Name)
+	}
+}
+
+// MajorityPolicies returns the Readers, Writers and Admins policies
+// requiring any reader, any writer and a majority of the admins of the
+// organizations respectively
+func MajorityPolicies() map[string]*Policy {
+	return map[string]*Policy{
+		channelconfig.ReadersPolicyKey: {Type: implicitMetaPolicyType, Rule: "ANY " + channelconfig.ReadersPolicyKey},
+		channelconfig.WritersPolicyKey: {Type: implicitMetaPolicyType, Rule: "ANY " + channelconfig.WritersPolicyKey},
+		channelconfig.AdminsPolicyKey:  {Type: implicitMetaPolicyType, Rule: "MAJORITY " + channelconfig.AdminsPolicyKey},
+	}
+}
+
+// KOfNEndorsementPolicy returns the signature policy requiring a peer of k of
+// the organizations with the given MSP IDs
+func KOfNEndorsementPolicy(k int, mspIDs []string) (*Policy, error) {
+	if len(mspIDs) == 0 {
+		return nil, errors.New("no organizations to endorse with")
+	}
+	if k < 1 || k > len(mspIDs) {
+		return nil, errors.Errorf("K must be between 1 and the number of organizations (%d), got %d", len(mspIDs), k)
+	}
+	return &Policy{Type: signaturePolicyType, Rule: outOf(k, mspIDs, "peer")}, nil
+}
+
+// VetoAdminsPolicy returns the signature policy requiring an admin of each of
+// the veto organizations along with admins of a majority of the organizations
+// with the given MSP IDs
+func VetoAdminsPolicy(vetoMSPIDs, mspIDs []string) (*Policy, error) {
+	if len(vetoMSPIDs) == 0 {
+		return nil, errors.New("no veto organizations")
+	}
+	principals := make([]string, 0, len(vetoMSPIDs)+1)
+	for _, vetoMSPID := range vetoMSPIDs {
+		if !contains(mspIDs, vetoMSPID) {
+			return nil, errors.Errorf("veto organization %s is not one of the organizations", vetoMSPID)
+		}
+		principals = append(principals, fmt.Sprintf("'%s.admin'", vetoMSPID))
+	}
+	principals = append(principals, outOf(len(mspIDs)/2+1, mspIDs, "admin"))
+	return &Policy{Type: signaturePolicyType, Rule: fmt.Sprintf("AND(%s)", strings.Join(principals, ", "))}, nil
+}
+
+func outOf(k int, mspIDs []string, role string) string {
+	principals := make([]string, len(mspIDs))
+	for i, mspID := range mspIDs {
+		principals[i] = fmt.Sprintf("'%s.%s'", mspID, role)
+	}
+	return fmt.Sprintf("OutOf(%d, %s)", k, strings.Join(principals, ", "))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// applyPolicyTemplate adds the policies of the template of a section to its
+// policies, keeping the policies defined in the section
+func applyPolicyTemplate(section string, template *PolicyTemplate, orgs []*Organization, policies *map[string]*Policy) {
+	if template == nil {
+		return
+	}
+	mspIDs := make([]string, len(orgs))
+	for i, org := range orgs {
+		mspIDs[i] = org.ID
+	}
+	templatePolicies, err := template.Policies(mspIDs)
+	if err != nil {
+		logger.Panicf("Invalid policy template for %s: %s", section, err)
+	}
+	if *policies == nil {
+		*policies = map[string]*Policy{}
+	}
+	for name, policy := range templatePolicies {
+		if _, ok := (*policies)[name]; !ok {
+			(*policies)[name] = policy
+		}
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localconfig
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyTemplates(t *testing.T) {
+	orgs := []string{"Org1MSP", "Org2MSP", "Org3MSP"}
+	readers := &Policy{Type: "ImplicitMeta", Rule: "ANY Readers"}
+	writers := &Policy{Type: "ImplicitMeta", Rule: "ANY Writers"}
+
+	tests := []struct {
+		template *PolicyTemplate
+		expected map[string]*Policy
+	}{
+		{
+			template: &PolicyTemplate{Name: MajorityPolicyTemplate},
+			expected: map[string]*Policy{
+				"Readers": readers,
+				"Writers": writers,
+				"Admins":  {Type: "ImplicitMeta", Rule: "MAJORITY Admins"},
+			},
+		},
+		{
+			template: &PolicyTemplate{Name: UnanimousPolicyTemplate},
+			expected: map[string]*Policy{
+				"Readers": readers,
+				"Writers": writers,
+				"Admins":  {Type: "ImplicitMeta", Rule: "ALL Admins"},
+			},
+		},
+		{
+			template: &PolicyTemplate{Name: VetoPolicyTemplate, Veto: []string{"Org1MSP"}},
+			expected: map[string]*Policy{
+				"Readers": readers,
+				"Writers": writers,
+				"Admins":  {Type: "Signature", Rule: "AND('Org1MSP.admin', OutOf(2, 'Org1MSP.admin', 'Org2MSP.admin', 'Org3MSP.admin'))"},
+			},
+		},
+		{
+			template: &PolicyTemplate{Name: KOfNPolicyTemplate, K: 2},
+			expected: map[string]*Policy{
+				"Readers":              readers,
+				"Writers":              writers,
+				"Admins":               {Type: "ImplicitMeta", Rule: "MAJORITY Admins"},
+				"LifecycleEndorsement": {Type: "Signature", Rule: "OutOf(2, 'Org1MSP.peer', 'Org2MSP.peer', 'Org3MSP.peer')"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.template.Name, func(t *testing.T) {
+			policies, err := test.template.Policies(orgs)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, policies)
+		})
+	}
+}
+
+func TestPolicyTemplateErrors(t *testing.T) {
+	orgs := []string{"Org1MSP", "Org2MSP"}
+	tests := []struct {
+		template    *PolicyTemplate
+		mspIDs      []string
+		expectedErr string
+	}{
+		{&PolicyTemplate{Name: "Anarchy"}, orgs, "unknown policy template: Anarchy"},
+		{&PolicyTemplate{Name: VetoPolicyTemplate}, orgs, "no veto organizations"},
+		{&PolicyTemplate{Name: VetoPolicyTemplate, Veto: []string{"Org3MSP"}}, orgs, "veto organization Org3MSP is not one of the organizations"},
+		{&PolicyTemplate{Name: KOfNPolicyTemplate, K: 1}, nil, "no organizations to endorse with"},
+		{&PolicyTemplate{Name: KOfNPolicyTemplate}, orgs, "K must be between 1 and the number of organizations (2), got 0"},
+		{&PolicyTemplate{Name: KOfNPolicyTemplate, K: 3}, orgs, "K must be between 1 and the number of organizations (2), got 3"},
+	}
+	for _, test := range tests {
+		_, err := test.template.Policies(test.mspIDs)
+		assert.EqualError(t, err, test.expectedErr)
+	}
+}
+
+func TestApplyPolicyTemplate(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+
+	// the policies of the section override those of the template
+	profile := Load(SampleSingleMSPChannelKOfNProfile)
+	require.NotNil(t, profile.Application)
+	assert.Equal(t, &Policy{Type: "Signature", Rule: "OutOf(1, 'SampleOrg.peer')"}, profile.Application.Policies[LifecycleEndorsementPolicyName])
+	assert.Equal(t, &Policy{Type: "ImplicitMeta", Rule: "MAJORITY Admins"}, profile.Application.Policies["Admins"])
+
+	devConfigDir, err := configtest.GetDevConfigDir()
+	require.NoError(t, err)
+	profile = &Profile{
+		PolicyTemplate: &PolicyTemplate{Name: UnanimousPolicyTemplate},
+		Orderer: &Orderer{
+			OrdererType:    "solo",
+			Organizations:  []*Organization{{Name: "Org1", ID: "Org1MSP"}, {Name: "Org2", ID: "Org2MSP"}},
+			PolicyTemplate: &PolicyTemplate{Name: VetoPolicyTemplate, Veto: []string{"Org2MSP"}},
+			Policies:       map[string]*Policy{"Readers": {Type: "Signature", Rule: "OR('Org1MSP.member')"}},
+		},
+	}
+	profile.completeInitialization(devConfigDir)
+	assert.Equal(t, &Policy{Type: "ImplicitMeta", Rule: "ALL Admins"}, profile.Policies["Admins"])
+	assert.Equal(t, &Policy{Type: "Signature", Rule: "OR('Org1MSP.member')"}, profile.Orderer.Policies["Readers"])
+	assert.Equal(t, &Policy{Type: "Signature", Rule: "AND('Org2MSP.admin', OutOf(2, 'Org1MSP.admin', 'Org2MSP.admin'))"}, profile.Orderer.Policies["Admins"])
+
+	profile = &Profile{
+		Orderer: &Orderer{
+			OrdererType:    "solo",
+			PolicyTemplate: &PolicyTemplate{Name: KOfNPolicyTemplate, K: 1},
+		},
+	}
+	assert.Panics(t, func() { profile.completeInitialization(devConfigDir) })
+	profile = &Profile{PolicyTemplate: &PolicyTemplate{Name: VetoPolicyTemplate, Veto: []string{"Org1MSP"}}}
+	assert.Panics(t, func() { profile.completeInitialization(devConfigDir) })
+}
@@ -0,0 +1,194 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package delta
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Added is the kind of the changes of the elements only in the updated config
+	Added = "added"
+	// Removed is the kind of the changes of the elements only in the original config
+	Removed = "removed"
+	// Modified is the kind of the changes of the elements in both configs which differ
+	Modified = "modified"
+)
+
+// Delta holds the differences between two channel configs, decoded to JSON
+// as by protolator. Organizations are the groups of the Application and
+// Orderer groups, and of the consortiums; the content of the groups which
+// were added or removed is not repeated in the values and policies.
+type Delta struct {
+	OriginalSequence uint64    `json:"original_sequence"`
+	UpdatedSequence  uint64    `json:"updated_sequence"`
+	Groups           []*Change `json:"groups"`
+	Values           []*Change `json:"values"`
+	Policies         []*Change `json:"policies"`
+}
+
+// Change is a difference of an element of the configs. For groups which were
+// modified, the original and updated elements are their mod policies.
+type Change struct {
+	Path     string      `json:"path"`
+	Kind     string      `json:"kind"`
+	Original interface{} `json:"original,omitempty"`
+	Updated  interface{} `json:"updated,omitempty"`
+}
+
+// ConfigFromBlock returns the config held by a config block, such as the
+// blocks retrieved by peer channel fetch config.
+func ConfigFromBlock(block *cb.Block) (*cb.Config, error) {
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error extracting the envelope of the block")
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshaling the payload of the envelope")
+	}
+	if payload.Header == nil {
+		return nil, errors.New("the payload of the envelope has no header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshaling the channel header")
+	}
+	if chdr.Type != int32(cb.HeaderType_CONFIG) {
+		return nil, errors.Errorf("block %d is not a config block", block.Header.Number)
+	}
+	configEnv, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshaling the config envelope")
+	}
+	if configEnv.Config == nil {
+		return nil, errors.New("the config envelope holds no config")
+	}
+	return configEnv.Config, nil
+}
+
+// Compute returns the differences between the original and the updated configs.
+func Compute(original, updated *cb.Config) (*Delta, error) {
+	originalGroup, err := channelGroup(original)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error decoding the original config")
+	}
+	updatedGroup, err := channelGroup(updated)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error decoding the updated config")
+	}
+
+	delta := &Delta{
+		OriginalSequence: original.Sequence,
+		UpdatedSequence:  updated.Sequence,
+		Groups:           []*Change{},
+		Values:           []*Change{},
+		Policies:         []*Change{},
+	}
+	delta.compareGroups("", originalGroup, updatedGroup)
+
+	for _, changes := range [][]*Change{delta.Groups, delta.Values, delta.Policies} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	}
+	return delta, nil
+}
+
+// channelGroup decodes the channel group of the config to JSON, so that the
+// values and the policies of the groups are compared and shown as decoded by
+// protolator.
+func channelGroup(config *cb.Config) (map[string]interface{}, error) {
+	buf := &bytes.Buffer{}
+	if err := protolator.DeepMarshalJSON(buf, config); err != nil {
+		return nil, err
+	}
+	tree := map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &tree); err != nil {
+		return nil, err
+	}
+	group, _ := tree["channel_group"].(map[string]interface{})
+	return group, nil
+}
+
+// members returns the groups, values or policies of a decoded group.
+func members(group map[string]interface{}, field string) map[string]interface{} {
+	m, _ := group[field].(map[string]interface{})
+	return m
+}
+
+func (d *Delta) compareGroups(path string, original, updated map[string]interface{}) {
+	if original["mod_policy"] != updated["mod_policy"] {
+		d.Groups = append(d.Groups, &Change{
+			Path:     path,
+			Kind:     Modified,
+			Original: original["mod_policy"],
+			Updated:  updated["mod_policy"],
+		})
+	}
+
+	d.Values = append(d.Values, compareElements(path+".values.", members(original, "values"), members(updated, "values"))...)
+	d.Policies = append(d.Policies, compareElements(path+".policies.", members(original, "policies"), members(updated, "policies"))...)
+
+	originalGroups, updatedGroups := members(original, "groups"), members(updated, "groups")
+	for name, originalGroup := range originalGroups {
+		subPath := path + ".groups." + name
+		updatedGroup, ok := updatedGroups[name]
+		if !ok {
+			d.Groups = append(d.Groups, &Change{Path: subPath, Kind: Removed, Original: originalGroup})
+			continue
+		}
+		originalSubGroup, _ := originalGroup.(map[string]interface{})
+		updatedSubGroup, _ := updatedGroup.(map[string]interface{})
+		d.compareGroups(subPath, originalSubGroup, updatedSubGroup)
+	}
+	for name, updatedGroup := range updatedGroups {
+		if _, ok := originalGroups[name]; !ok {
+			d.Groups = append(d.Groups, &Change{Path: path + ".groups." + name, Kind: Added, Updated: updatedGroup})
+		}
+	}
+}
+
+// compareElements compares the values or the policies of a group. Their
+// versions are left out of the comparison, as they only change along with
+// the rest of the elements.
+func compareElements(pathPrefix string, original, updated map[string]interface{}) []*Change {
+	var changes []*Change
+	for name, originalElement := range original {
+		updatedElement, ok := updated[name]
+		switch {
+		case !ok:
+			changes = append(changes, &Change{Path: pathPrefix + name, Kind: Removed, Original: originalElement})
+		case !reflect.DeepEqual(withoutVersion(originalElement), withoutVersion(updatedElement)):
+			changes = append(changes, &Change{Path: pathPrefix + name, Kind: Modified, Original: originalElement, Updated: updatedElement})
+		}
+	}
+	for name, updatedElement := range updated {
+		if _, ok := original[name]; !ok {
+			changes = append(changes, &Change{Path: pathPrefix + name, Kind: Added, Updated: updatedElement})
+		}
+	}
+	return changes
+}
+
+func withoutVersion(element interface{}) map[string]interface{} {
+	fields, _ := element.(map[string]interface{})
+	result := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		if key != "version" {
+			result[key] = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,98 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package delta
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/configtxgentest"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	factory.InitFactories(nil)
+}
+
+func sampleConfig(t *testing.T) *cb.Config {
+	block := encoder.New(configtxgentest.Load(genesisconfig.SampleSingleMSPSoloProfile)).GenesisBlockForChannel("testchannel")
+	config, err := ConfigFromBlock(block)
+	assert.NoError(t, err)
+	return config
+}
+
+func TestComputeSameConfig(t *testing.T) {
+	d, err := Compute(sampleConfig(t), sampleConfig(t))
+	assert.NoError(t, err)
+	assert.Equal(t, &Delta{Groups: []*Change{}, Values: []*Change{}, Policies: []*Change{}}, d)
+}
+
+func TestCompute(t *testing.T) {
+	original := sampleConfig(t)
+	updated := proto.Clone(original).(*cb.Config)
+	updated.Sequence = 1
+
+	orderer := updated.ChannelGroup.Groups["Orderer"]
+	orderer.Values["BatchSize"].Value = utils.MarshalOrPanic(&ab.BatchSize{MaxMessageCount: 50})
+	orderer.Values["BatchSize"].Version++
+	delete(orderer.Policies, "BlockValidation")
+	orderer.Groups["OtherOrg"] = orderer.Groups["SampleOrg"]
+	delete(orderer.Groups, "SampleOrg")
+	updated.ChannelGroup.ModPolicy = "Writers"
+
+	d, err := Compute(original, updated)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), d.OriginalSequence)
+	assert.Equal(t, uint64(1), d.UpdatedSequence)
+
+	assert.Len(t, d.Groups, 3)
+	assert.Equal(t, &Change{Path: "", Kind: Modified, Original: "Admins", Updated: "Writers"}, d.Groups[0])
+	assert.Equal(t, ".groups.Orderer.groups.OtherOrg", d.Groups[1].Path)
+	assert.Equal(t, Added, d.Groups[1].Kind)
+	assert.Nil(t, d.Groups[1].Original)
+	assert.NotNil(t, d.Groups[1].Updated)
+	assert.Equal(t, ".groups.Orderer.groups.SampleOrg", d.Groups[2].Path)
+	assert.Equal(t, Removed, d.Groups[2].Kind)
+	assert.NotNil(t, d.Groups[2].Original)
+	assert.Nil(t, d.Groups[2].Updated)
+
+	assert.Len(t, d.Values, 1)
+	assert.Equal(t, ".groups.Orderer.values.BatchSize", d.Values[0].Path)
+	assert.Equal(t, Modified, d.Values[0].Kind)
+	assert.Equal(t, float64(50), d.Values[0].Updated.(map[string]interface{})["value"].(map[string]interface{})["max_message_count"])
+
+	assert.Len(t, d.Policies, 1)
+	assert.Equal(t, &Change{
+		Path:     ".groups.Orderer.policies.BlockValidation",
+		Kind:     Removed,
+		Original: d.Policies[0].Original,
+	}, d.Policies[0])
+}
+
+func TestConfigFromBlock(t *testing.T) {
+	_, err := ConfigFromBlock(&cb.Block{Header: &cb.BlockHeader{}})
+	assert.EqualError(t, err, "error extracting the envelope of the block: block data is nil")
+
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "testchannel", nil, &cb.ConfigEnvelope{}, 0, 0)
+	assert.NoError(t, err)
+	block := cb.NewBlock(3, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(env)}
+	_, err = ConfigFromBlock(block)
+	assert.EqualError(t, err, "block 3 is not a config block")
+
+	env, err = utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, "testchannel", nil, &cb.ConfigEnvelope{}, 0, 0)
+	assert.NoError(t, err)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(env)}
+	_, err = ConfigFromBlock(block)
+	assert.EqualError(t, err, "the config envelope holds no config")
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -24,6 +25,7 @@ import (
 	"reflect"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/delta"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/metadata"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/rest"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
@@ -66,6 +68,11 @@ var (
 	computeUpdateChannelID = computeUpdate.Flag("channel_id", "The name of the channel for this update.").Required().String()
 	computeUpdateDest      = computeUpdate.Flag("output", "A file to write the JSON document to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 
+	computeDelta         = app.Command("compute_delta", "Takes two marshaled common.Block config blocks and lists the differences between their configs as JSON.")
+	computeDeltaOriginal = computeDelta.Flag("original", "The original config block.").Required().File()
+	computeDeltaUpdated  = computeDelta.Flag("updated", "The updated config block.").Required().File()
+	computeDeltaDest     = computeDelta.Flag("output", "A file to write the JSON document to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
 	version = app.Command("version", "Show version information")
 )
 
@@ -100,6 +107,14 @@ func main() {
 		if err != nil {
 			app.Fatalf("Error computing update: %s", err)
 		}
+	case computeDelta.FullCommand():
+		defer (*computeDeltaOriginal).Close()
+		defer (*computeDeltaUpdated).Close()
+		defer (*computeDeltaDest).Close()
+		err := computeDlt(*computeDeltaOriginal, *computeDeltaUpdated, *computeDeltaDest)
+		if err != nil {
+			app.Fatalf("Error computing delta: %s", err)
+		}
 	// "version" command
 	case version.FullCommand():
 		printVersion()
@@ -210,3 +225,47 @@ func computeUpdt(original, updated, output *os.File, channelID string) error {
 
 	return nil
 }
+
+func readConfigBlock(input *os.File) (*cb.Config, error) {
+	in, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading block")
+	}
+
+	block := &cb.Block{}
+	err = proto.Unmarshal(in, block)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling block")
+	}
+
+	return delta.ConfigFromBlock(block)
+}
+
+func computeDlt(original, updated, output *os.File) error {
+	origConf, err := readConfigBlock(original)
+	if err != nil {
+		return errors.WithMessage(err, "error reading original config block")
+	}
+
+	updtConf, err := readConfigBlock(updated)
+	if err != nil {
+		return errors.WithMessage(err, "error reading updated config block")
+	}
+
+	d, err := delta.Compute(origConf, updtConf)
+	if err != nil {
+		return errors.WithMessage(err, "error computing config delta")
+	}
+
+	outBytes, err := json.MarshalIndent(d, "", "\t")
+	if err != nil {
+		return errors.Wrapf(err, "error marshaling computed config delta")
+	}
+
+	_, err = output.Write(append(outBytes, '\n'))
+	if err != nil {
+		return errors.Wrapf(err, "error writing config delta to output")
+	}
+
+	return nil
+}
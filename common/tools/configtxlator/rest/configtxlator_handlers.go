@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"github.com/hyperledger/fabric/common/tools/configtxlator/delta"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/sanitycheck"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -54,6 +55,21 @@ func fieldConfigProto(fieldName string, r *http.Request) (*cb.Config, error) {
 	return config, nil
 }
 
+func fieldBlockConfig(fieldName string, r *http.Request) (*cb.Config, error) {
+	fieldBytes, err := fieldBytes(fieldName, r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading field bytes: %s", err)
+	}
+
+	block := &cb.Block{}
+	err = proto.Unmarshal(fieldBytes, block)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling field bytes: %s", err)
+	}
+
+	return delta.ConfigFromBlock(block)
+}
+
 func ComputeUpdateFromConfigs(w http.ResponseWriter, r *http.Request) {
 	originalConfig, err := fieldConfigProto("original", r)
 	if err != nil {
@@ -90,6 +106,40 @@ func ComputeUpdateFromConfigs(w http.ResponseWriter, r *http.Request) {
 	w.Write(encoded)
 }
 
+func ComputeDeltaFromBlocks(w http.ResponseWriter, r *http.Request) {
+	originalConfig, err := fieldBlockConfig("original", r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'original': %s\n", err)
+		return
+	}
+
+	updatedConfig, err := fieldBlockConfig("updated", r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'updated': %s\n", err)
+		return
+	}
+
+	configDelta, err := delta.Compute(originalConfig, updatedConfig)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error computing delta: %s\n", err)
+		return
+	}
+
+	resBytes, err := json.Marshal(configDelta)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "Error marshaling delta to JSON: %s\n", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resBytes)
+}
+
 func SanityCheckConfig(w http.ResponseWriter, r *http.Request) {
 	buf, err := ioutil.ReadAll(r.Body)
 	if err != nil {
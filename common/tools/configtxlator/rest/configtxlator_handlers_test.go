@@ -71,6 +71,92 @@ func TestProtolatorComputeConfigUpdate(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
 }
 
+func configBlock(t *testing.T, config *cb.Config) []byte {
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, "foo", nil, &cb.ConfigEnvelope{Config: config}, 0, 0)
+	assert.NoError(t, err)
+	block := cb.NewBlock(0, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(env)}
+	return utils.MarshalOrPanic(block)
+}
+
+func TestComputeDeltaFromBlocks(t *testing.T) {
+	originalBlock := configBlock(t, &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			ModPolicy: "foo",
+		},
+	})
+
+	updatedBlock := configBlock(t, &cb.Config{
+		Sequence: 1,
+		ChannelGroup: &cb.ConfigGroup{
+			ModPolicy: "bar",
+		},
+	})
+
+	buffer := &bytes.Buffer{}
+	mpw := multipart.NewWriter(buffer)
+
+	ffw, err := mpw.CreateFormFile("original", "foo")
+	assert.NoError(t, err)
+	_, err = bytes.NewReader(originalBlock).WriteTo(ffw)
+	assert.NoError(t, err)
+
+	ffw, err = mpw.CreateFormFile("updated", "bar")
+	assert.NoError(t, err)
+	_, err = bytes.NewReader(updatedBlock).WriteTo(ffw)
+	assert.NoError(t, err)
+
+	err = mpw.Close()
+	assert.NoError(t, err)
+
+	req, err := http.NewRequest("POST", "/configtxlator/compute/delta-from-blocks", buffer)
+	assert.NoError(t, err)
+
+	req.Header.Set("Content-Type", mpw.FormDataContentType())
+	rec := httptest.NewRecorder()
+	r := NewRouter()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{
+		"original_sequence": 0,
+		"updated_sequence": 1,
+		"groups": [{"path": "", "kind": "modified", "original": "foo", "updated": "bar"}],
+		"values": [],
+		"policies": []
+	}`, rec.Body.String())
+}
+
+func TestComputeDeltaNotFromBlocks(t *testing.T) {
+	config := utils.MarshalOrPanic(&cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			ModPolicy: "foo",
+		},
+	})
+
+	buffer := &bytes.Buffer{}
+	mpw := multipart.NewWriter(buffer)
+
+	ffw, err := mpw.CreateFormFile("original", "foo")
+	assert.NoError(t, err)
+	_, err = bytes.NewReader(config).WriteTo(ffw)
+	assert.NoError(t, err)
+
+	err = mpw.Close()
+	assert.NoError(t, err)
+
+	req, err := http.NewRequest("POST", "/configtxlator/compute/delta-from-blocks", buffer)
+	assert.NoError(t, err)
+
+	req.Header.Set("Content-Type", mpw.FormDataContentType())
+	rec := httptest.NewRecorder()
+	r := NewRouter()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Error with field 'original'")
+}
+
 func TestProtolatorMissingOriginal(t *testing.T) {
 	updatedConfig := utils.MarshalOrPanic(&cb.Config{
 		ChannelGroup: &cb.ConfigGroup{
@@ -111,6 +111,14 @@ func checkPolicyPrincipals(group *cb.ConfigGroup, basePath string, mspMap map[st
 					continue
 				}
 				mspID = ou.MspIdentifier
+			case mspprotos.MSPPrincipal_ATTRIBUTE:
+				attr := &mspprotos.MSPAttribute{}
+				err = proto.Unmarshal(identity.Principal, attr)
+				if err != nil {
+					appendError(fmt.Sprintf("value of identities array at index %d is of type ATTRIBUTE, but could not be unmarshaled to msp.MSPAttribute: %s", i, err))
+					continue
+				}
+				mspID = attr.MspIdentifier
 			default:
 				continue
 			}
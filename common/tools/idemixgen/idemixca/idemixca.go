@@ -96,3 +96,33 @@ func GenerateSignerConfig(roleMask int, ouString string, enrollmentId string, re
 
 	return proto.Marshal(signer)
 }
+
+// GenerateCRI creates the credential revocation information for an epoch.
+// Only the signers whose revocation handle is among the unrevoked handles
+// can prove that they are not revoked in that epoch.
+func GenerateCRI(unrevokedHandles []int, epoch int, revKey *ecdsa.PrivateKey) ([]byte, error) {
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, errors.WithMessage(err, "Error getting PRNG")
+	}
+	handles := make([]*FP256BN.BIG, len(unrevokedHandles))
+	for i, rh := range unrevokedHandles {
+		handles[i] = FP256BN.NewBIGint(rh)
+	}
+	cri, err := idemix.CreateCRI(revKey, handles, epoch, idemix.ALG_PLAIN_SIGNATURE, rng)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(cri)
+}
+
+// UpdateSignerCRI replaces the credential revocation information of a signer config.
+func UpdateSignerCRI(signerConfig []byte, cri []byte) ([]byte, error) {
+	signer := &m.IdemixMSPSignerConfig{}
+	err := proto.Unmarshal(signerConfig, signer)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal signer config")
+	}
+	signer.CredentialRevocationInformation = cri
+	return proto.Marshal(signer)
+}
@@ -59,6 +59,29 @@ func TestIdemixCa(t *testing.T) {
 	assert.NoError(t, writeSignerToFile(conf))
 	assert.NoError(t, setupMSP())
 
+	// A CRI in which the signer is not revoked can be used by the signer
+	cri, err := GenerateCRI([]int{1, 1234}, 1, revocationkey)
+	assert.NoError(t, err)
+	conf, err = UpdateSignerCRI(conf, cri)
+	assert.NoError(t, err)
+	cleanupSigner()
+	assert.NoError(t, writeSignerToFile(conf))
+	assert.NoError(t, setupMSP())
+
+	// Once the signer is revoked, it cannot prove that it is not revoked
+	cri, err = GenerateCRI([]int{1}, 2, revocationkey)
+	assert.NoError(t, err)
+	conf, err = UpdateSignerCRI(conf, cri)
+	assert.NoError(t, err)
+	cleanupSigner()
+	assert.NoError(t, writeSignerToFile(conf))
+	err = setupMSP()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the revocation handle is not valid in epoch 2")
+
+	_, err = UpdateSignerCRI([]byte("garbage"), cri)
+	assert.Error(t, err)
+
 	// Without the verifier dir present, setup should give an error
 	cleanupVerifier()
 	assert.Error(t, setupMSP())
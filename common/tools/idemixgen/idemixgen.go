@@ -35,6 +35,7 @@ const (
 	IdemixDirIssuer             = "ca"
 	IdemixConfigIssuerSecretKey = "IssuerSecretKey"
 	IdemixConfigRevocationKey   = "RevocationKey"
+	IdemixConfigCRI             = "CRI"
 )
 
 // command line flags
@@ -49,6 +50,11 @@ var (
 	genCredIsAdmin          = genSignerConfig.Flag("admin", "Make the default signer admin").Short('a').Bool()
 	genCredEnrollmentId     = genSignerConfig.Flag("enrollmentId", "The enrollment id of the default signer").Short('e').String()
 	genCredRevocationHandle = genSignerConfig.Flag("revocationHandle", "The handle used to revoke this signer").Short('r').Int()
+	genCredCAInput          = genSignerConfig.Flag("ca-input", "The directory holding the CA key material, if not the output directory, so that several signers of the same CA are placed in directories of their own").String()
+
+	genCRI          = app.Command("cri", "Generate the credential revocation information for an epoch")
+	genCRIEpoch     = genCRI.Flag("epoch", "The epoch in which the credential revocation information is valid").Required().Int()
+	genCRIUnrevoked = genCRI.Flag("unrevoked", "A revocation handle that is not revoked in the epoch, can be repeated").Ints()
 
 	version = app.Command("version", "Show version information")
 )
@@ -95,16 +101,46 @@ func main() {
 		} else {
 			roleMask = msp.GetRoleMaskFromIdemixRole(msp.MEMBER)
 		}
-		config, err := idemixca.GenerateSignerConfig(roleMask, *genCredOU, *genCredEnrollmentId, *genCredRevocationHandle, readIssuerKey(), readRevocationKey())
+		caDir := *outputDir
+		if *genCredCAInput != "" {
+			caDir = *genCredCAInput
+		}
+		config, err := idemixca.GenerateSignerConfig(roleMask, *genCredOU, *genCredEnrollmentId, *genCredRevocationHandle, readIssuerKey(caDir), readRevocationKey(caDir))
 		handleError(err)
 
 		path := filepath.Join(*outputDir, msp.IdemixConfigDirUser)
 		checkDirectoryNotExists(path, fmt.Sprintf("This MSP config already contains a directory \"%s\"", path))
 
+		// The output directory of a signer of another directory gets the
+		// public keys of the CA, so that it holds a complete MSP config
+		if caDir != *outputDir {
+			path = filepath.Join(*outputDir, msp.IdemixConfigDirMsp)
+			checkDirectoryNotExists(path, fmt.Sprintf("This MSP config already contains a directory \"%s\"", path))
+			handleError(os.MkdirAll(path, 0770))
+			for _, file := range []string{msp.IdemixConfigFileIssuerPublicKey, msp.IdemixConfigFileRevocationPublicKey} {
+				contents, err := ioutil.ReadFile(filepath.Join(caDir, msp.IdemixConfigDirMsp, file))
+				handleError(err)
+				writeFile(filepath.Join(path, file), contents)
+			}
+		}
+
 		// Write config to file
-		handleError(os.Mkdir(filepath.Join(*outputDir, msp.IdemixConfigDirUser), 0770))
+		handleError(os.MkdirAll(filepath.Join(*outputDir, msp.IdemixConfigDirUser), 0770))
 		writeFile(filepath.Join(*outputDir, msp.IdemixConfigDirUser, msp.IdemixConfigFileSigner), config)
 
+	case genCRI.FullCommand():
+		cri, err := idemixca.GenerateCRI(*genCRIUnrevoked, *genCRIEpoch, readRevocationKey(*outputDir))
+		handleError(err)
+		writeFile(filepath.Join(*outputDir, IdemixDirIssuer, IdemixConfigCRI), cri)
+
+		// Update the default signer, if any, to use the new CRI
+		path := filepath.Join(*outputDir, msp.IdemixConfigDirUser, msp.IdemixConfigFileSigner)
+		if signerBytes, err := ioutil.ReadFile(path); err == nil {
+			config, err := idemixca.UpdateSignerCRI(signerBytes, cri)
+			handleError(err)
+			writeFile(path, config)
+		}
+
 	case version.FullCommand():
 		printVersion()
 	}
@@ -119,14 +155,14 @@ func writeFile(path string, contents []byte) {
 	handleError(ioutil.WriteFile(path, contents, 0640))
 }
 
-// readIssuerKey reads the issuer key from the current directory
-func readIssuerKey() *idemix.IssuerKey {
-	path := filepath.Join(*outputDir, IdemixDirIssuer, IdemixConfigIssuerSecretKey)
+// readIssuerKey reads the issuer key from the CA directory
+func readIssuerKey(caDir string) *idemix.IssuerKey {
+	path := filepath.Join(caDir, IdemixDirIssuer, IdemixConfigIssuerSecretKey)
 	isk, err := ioutil.ReadFile(path)
 	if err != nil {
 		handleError(errors.Wrapf(err, "failed to open issuer secret key file: %s", path))
 	}
-	path = filepath.Join(*outputDir, IdemixDirIssuer, msp.IdemixConfigFileIssuerPublicKey)
+	path = filepath.Join(caDir, IdemixDirIssuer, msp.IdemixConfigFileIssuerPublicKey)
 	ipkBytes, err := ioutil.ReadFile(path)
 	if err != nil {
 		handleError(errors.Wrapf(err, "failed to open issuer public key file: %s", path))
@@ -138,8 +174,8 @@ func readIssuerKey() *idemix.IssuerKey {
 	return key
 }
 
-func readRevocationKey() *ecdsa.PrivateKey {
-	path := filepath.Join(*outputDir, IdemixDirIssuer, IdemixConfigRevocationKey)
+func readRevocationKey(caDir string) *ecdsa.PrivateKey {
+	path := filepath.Join(caDir, IdemixDirIssuer, IdemixConfigRevocationKey)
 	keyBytes, err := ioutil.ReadFile(path)
 	if err != nil {
 		handleError(errors.Wrapf(err, "failed to open revocation secret key file: %s", path))
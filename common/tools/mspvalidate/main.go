@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+// mspvalidate is a command line tool that checks an MSP the way the peers
+// and the orderers set it up, and reports why they would reject it, and which
+// identities they accept if they do not
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/tools/mspvalidate/metadata"
+	"github.com/hyperledger/fabric/common/tools/mspvalidate/validator"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	"github.com/hyperledger/fabric/msp"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// command line flags
+var (
+	app = kingpin.New("mspvalidate", "Utility for checking the MSPs of Hyperledger Fabric before the peers and the orderers load them")
+
+	local        = app.Command("local", "Check a local MSP directory, as the peers and the orderers load it")
+	localDir     = local.Flag("mspdir", "The MSP directory").Required().String()
+	localID      = local.Flag("mspid", "The identifier of the MSP").Required().String()
+	localType    = local.Flag("msptype", "The type of the MSP, bccsp or idemix").Default(msp.ProviderTypeToString(msp.FABRIC)).String()
+	localVersion = local.Flag("version", "The version the MSP is set up with, 1.0 for the local MSPs of the peers and the orderers").Default("1.0").String()
+
+	channel        = app.Command("channel", "Check the MSP of an organization of a channel, given as JSON as in the MSP value of its group in the channel config decoded by configtxlator")
+	channelConfig  = channel.Flag("config", "The file holding the JSON of the MSP config").Required().String()
+	channelVersion = channel.Flag("version", "The version the MSP is set up with, as selected by the channel capabilities: 1.0, 1.1, 1.3 or 1.4").Default("1.4").String()
+
+	version = app.Command("version", "Show version information")
+)
+
+func main() {
+	app.HelpFlag.Short('h')
+
+	var report *validator.Report
+	switch kingpin.MustParse(app.Parse(os.Args[1:])) {
+
+	case local.FullCommand():
+		mspVersion, err := validator.ParseVersion(*localVersion)
+		handleError(err)
+		conf, err := msp.GetLocalMspConfigWithType(*localDir, nil, *localID, *localType)
+		handleError(errors.WithMessage(err, "failed loading the MSP directory"))
+		opts := validator.Options{Version: mspVersion}
+		if *localType == msp.ProviderTypeToString(msp.FABRIC) {
+			opts.KeystoreDir = filepath.Join(*localDir, "keystore")
+		}
+		report = validator.Validate(conf, opts)
+
+	case channel.FullCommand():
+		mspVersion, err := validator.ParseVersion(*channelVersion)
+		handleError(err)
+		f, err := os.Open(*channelConfig)
+		handleError(err)
+		conf := &mspprotos.MSPConfig{}
+		err = protolator.DeepUnmarshalJSON(f, conf)
+		f.Close()
+		handleError(errors.WithMessage(err, "failed decoding the MSP config"))
+		report = validator.Validate(conf, validator.Options{Version: mspVersion})
+
+	case version.FullCommand():
+		printVersion()
+		return
+	}
+
+	report.Print(os.Stdout)
+	if !report.Accepted() {
+		os.Exit(1)
+	}
+}
+
+func printVersion() {
+	fmt.Println(metadata.GetVersionInfo())
+}
+
+// handleError prints an error and exits with a non-zero code if the error is not nil
+func handleError(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,666 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validator
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/msp"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+)
+
+// expiryWarning is how long before a certificate expires it is reported
+const expiryWarning = 30 * 24 * time.Hour
+
+// Result is the outcome of a check
+type Result int
+
+const (
+	// Pass means the check found nothing wrong
+	Pass Result = iota
+	// Warn means the MSP is accepted, but is likely not to work as intended
+	Warn
+	// Fail means the peers and orderers reject the MSP, or the identities
+	// checked
+	Fail
+)
+
+func (r Result) String() string {
+	switch r {
+	case Pass:
+		return "PASS"
+	case Warn:
+		return "WARN"
+	default:
+		return "FAIL"
+	}
+}
+
+// Finding is the outcome of a check of an MSP
+type Finding struct {
+	// Check is the name of the check, as in chain or nodeous
+	Check   string
+	Result  Result
+	Message string
+}
+
+// Report holds the findings of the validation of an MSP, and describes the
+// identities it accepts
+type Report struct {
+	MSPID    string
+	Version  msp.MSPVersion
+	Findings []Finding
+	// Accepts describes the identities the MSP accepts, and their roles
+	Accepts []string
+}
+
+// Accepted returns true if no check failed, meaning that the peers and the
+// orderers accept the MSP
+func (r *Report) Accepted() bool {
+	for _, f := range r.Findings {
+		if f.Result == Fail {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Report) add(check string, result Result, format string, args ...interface{}) {
+	r.Findings = append(r.Findings, Finding{Check: check, Result: result, Message: fmt.Sprintf(format, args...)})
+}
+
+// Print writes the findings, the identities accepted and the outcome
+func (r *Report) Print(w io.Writer) {
+	fmt.Fprintf(w, "MSP %s (version %s)\n", r.MSPID, VersionString(r.Version))
+	for _, f := range r.Findings {
+		fmt.Fprintf(w, "[%s] %s: %s\n", f.Result, f.Check, f.Message)
+	}
+	if len(r.Accepts) != 0 {
+		fmt.Fprintln(w, "Accepted identities:")
+		for _, a := range r.Accepts {
+			fmt.Fprintf(w, "  %s\n", a)
+		}
+	}
+	if r.Accepted() {
+		fmt.Fprintln(w, "Result: the MSP is accepted")
+	} else {
+		fmt.Fprintln(w, "Result: the MSP is rejected")
+	}
+}
+
+// Options configures the validation of an MSP
+type Options struct {
+	// Version is the version the MSP is set up with. The peers and the
+	// orderers set up their local MSPs with version 1.0, and the MSPs of a
+	// channel with the version its capabilities select.
+	Version msp.MSPVersion
+	// KeystoreDir is the keystore of a local MSP, which holds the private
+	// key of its signing identity
+	KeystoreDir string
+	// Now is the time the certificates are validated at, the current time
+	// if zero
+	Now time.Time
+}
+
+// Validate checks the given MSP config as the peers and the orderers would
+// set it up, and reports why they would reject it, if they do
+func Validate(conf *mspprotos.MSPConfig, opts Options) *Report {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	v := &validation{opts: opts, report: &Report{Version: opts.Version}}
+
+	if conf.Type == int32(msp.IDEMIX) {
+		v.report.MSPID = "(idemix)"
+		ic := &mspprotos.IdemixMSPConfig{}
+		if err := proto.Unmarshal(conf.Config, ic); err == nil {
+			v.report.MSPID = ic.Name
+		}
+		v.setup(conf)
+		v.report.add("checks", Pass, "the other checks only apply to X.509 MSPs")
+		return v.report
+	}
+	if conf.Type != int32(msp.FABRIC) {
+		v.report.add("setup", Fail, "unknown MSP type %d", conf.Type)
+		return v.report
+	}
+
+	fc := &mspprotos.FabricMSPConfig{}
+	if err := proto.Unmarshal(conf.Config, fc); err != nil {
+		v.report.add("setup", Fail, "the config is not a FabricMSPConfig: %s", err)
+		return v.report
+	}
+	v.config = fc
+	v.report.MSPID = fc.Name
+
+	v.checkChains()
+	v.checkOUs()
+	v.checkNodeOUs()
+	v.checkKeys()
+	v.checkTLS()
+	if inst := v.setup(conf); inst != nil {
+		v.checkAdmins(inst)
+	}
+	v.describe()
+	return v.report
+}
+
+// validation holds the state of the validation of a FabricMSPConfig
+type validation struct {
+	opts   Options
+	report *Report
+	config *mspprotos.FabricMSPConfig
+
+	roots         []*x509.Certificate
+	intermediates []*x509.Certificate
+	signer        *x509.Certificate
+	admins        []*x509.Certificate
+	tlsCAs        []*x509.Certificate
+}
+
+// setup sets the MSP up the way the peers and the orderers do, and returns
+// it if it succeeds
+func (v *validation) setup(conf *mspprotos.MSPConfig) (inst msp.MSP) {
+	defer func() {
+		if r := recover(); r != nil {
+			v.report.add("setup", Fail, "setting up the MSP panics: %v", r)
+			inst = nil
+		}
+	}()
+
+	var newOpts msp.NewOpts
+	if conf.Type == int32(msp.IDEMIX) {
+		newOpts = &msp.IdemixNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: v.opts.Version}}
+	} else {
+		ks := sw.NewDummyKeyStore()
+		if v.opts.KeystoreDir != "" {
+			fileKS, err := sw.NewFileBasedKeyStore(nil, v.opts.KeystoreDir, true)
+			if err != nil {
+				v.report.add("setup", Fail, "cannot open the keystore: %s", err)
+				return nil
+			}
+			ks = fileKS
+		}
+		csp, err := sw.NewDefaultSecurityLevelWithKeystore(ks)
+		if err != nil {
+			v.report.add("setup", Fail, "cannot initialize the crypto provider: %s", err)
+			return nil
+		}
+		newOpts = &msp.BCCSPNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: v.opts.Version}, BCCSP: csp}
+	}
+
+	inst, err := msp.New(newOpts)
+	if err != nil {
+		v.report.add("setup", Fail, "cannot create the MSP: %s", err)
+		return nil
+	}
+	if err := inst.Setup(conf); err != nil {
+		v.report.add("setup", Fail, "the MSP cannot be set up: %s", err)
+		return nil
+	}
+	v.report.add("setup", Pass, "the MSP can be set up")
+	return inst
+}
+
+// parse parses the PEM encoded certificates, reporting those which cannot
+// be parsed
+func (v *validation) parse(check, kind string, raw [][]byte) []*x509.Certificate {
+	var certs []*x509.Certificate
+	for i, pemBytes := range raw {
+		cert, err := parseCertificate(pemBytes)
+		if err != nil {
+			v.report.add(check, Fail, "%s #%d cannot be parsed: %s", kind, i+1, err)
+			continue
+		}
+		certs = append(certs, cert)
+	}
+	return certs
+}
+
+func parseCertificate(pemBytes []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// name describes a certificate by its common name, or its subject if it
+// has none
+func name(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	return cert.Subject.String()
+}
+
+func names(certs []*x509.Certificate) string {
+	var n []string
+	for _, cert := range certs {
+		n = append(n, name(cert))
+	}
+	return strings.Join(n, ", ")
+}
+
+// checkCA reports CA certificates which are not CAs, or are expired
+func (v *validation) checkCA(check, kind string, cert *x509.Certificate) {
+	if !cert.IsCA || !cert.BasicConstraintsValid {
+		v.report.add(check, Fail, "%s %s is not a CA certificate", kind, name(cert))
+		return
+	}
+	v.checkExpiry(check, kind, cert)
+}
+
+// checkExpiry reports expired certificates, and those about to expire
+func (v *validation) checkExpiry(check, kind string, cert *x509.Certificate) {
+	switch {
+	case v.opts.Now.After(cert.NotAfter):
+		v.report.add(check, Fail, "%s %s expired on %s", kind, name(cert), cert.NotAfter.Format(time.RFC3339))
+	case v.opts.Now.Before(cert.NotBefore):
+		v.report.add(check, Fail, "%s %s is not valid before %s", kind, name(cert), cert.NotBefore.Format(time.RFC3339))
+	case cert.NotAfter.Sub(v.opts.Now) < expiryWarning:
+		v.report.add(check, Warn, "%s %s expires on %s", kind, name(cert), cert.NotAfter.Format(time.RFC3339))
+	}
+}
+
+// chain builds the chain of the certificate up to one of the roots, the way
+// the MSP does: at the time the certificate was issued
+func chain(cert *x509.Certificate, roots, intermediates []*x509.Certificate) ([]*x509.Certificate, error) {
+	opts := x509.VerifyOptions{
+		Roots:         x509.NewCertPool(),
+		Intermediates: x509.NewCertPool(),
+		CurrentTime:   cert.NotBefore.Add(time.Second),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	for _, root := range roots {
+		opts.Roots.AddCert(root)
+	}
+	for _, intermediate := range intermediates {
+		opts.Intermediates.AddCert(intermediate)
+	}
+	chains, err := cert.Verify(opts)
+	if err != nil {
+		return nil, err
+	}
+	return chains[0], nil
+}
+
+// checkChain reports certificates which do not chain to a root
+func (v *validation) checkChain(check, kind string, cert *x509.Certificate, roots, intermediates []*x509.Certificate) []*x509.Certificate {
+	c, err := chain(cert, roots, intermediates)
+	if err != nil {
+		v.report.add(check, Fail, "%s %s does not chain to a root CA: %s", kind, name(cert), err)
+		return nil
+	}
+	v.report.add(check, Pass, "%s %s chains as %s", kind, name(cert), describeChain(c))
+	return c
+}
+
+func describeChain(c []*x509.Certificate) string {
+	var n []string
+	for _, cert := range c {
+		n = append(n, name(cert))
+	}
+	return strings.Join(n, " -> ")
+}
+
+// checkChains checks the CA certificates, and the chains of the
+// intermediate CAs, of the admins and of the signing identity
+func (v *validation) checkChains() {
+	if len(v.config.RootCerts) == 0 {
+		v.report.add("chain", Fail, "no root CA certificate is configured")
+	}
+	v.roots = v.parse("chain", "root CA certificate", v.config.RootCerts)
+	for _, root := range v.roots {
+		v.checkCA("chain", "root CA", root)
+		if err := root.CheckSignatureFrom(root); err != nil {
+			v.report.add("chain", Fail, "root CA %s is not self-signed", name(root))
+		}
+	}
+	v.intermediates = v.parse("chain", "intermediate CA certificate", v.config.IntermediateCerts)
+	for _, intermediate := range v.intermediates {
+		v.checkCA("chain", "intermediate CA", intermediate)
+		v.checkChain("chain", "intermediate CA", intermediate, v.roots, v.intermediates)
+	}
+
+	if v.config.SigningIdentity != nil {
+		if certs := v.parse("chain", "signing certificate", [][]byte{v.config.SigningIdentity.PublicSigner}); len(certs) == 1 {
+			v.signer = certs[0]
+			v.checkChain("chain", "signing certificate", v.signer, v.roots, v.intermediates)
+			v.checkExpiry("chain", "signing certificate", v.signer)
+		}
+	}
+	if len(v.config.Admins) == 0 {
+		v.report.add("admins", Warn, "no admin certificate is configured, none of the identities of the MSP is an admin")
+	}
+	v.admins = v.parse("chain", "admin certificate", v.config.Admins)
+	for _, admin := range v.admins {
+		v.checkChain("chain", "admin certificate", admin, v.roots, v.intermediates)
+		v.checkExpiry("chain", "admin certificate", admin)
+	}
+}
+
+// isCA returns true if the PEM encoded certificate is one of the root or
+// intermediate CAs
+func (v *validation) isCA(pemBytes []byte) (*x509.Certificate, bool) {
+	cert, err := parseCertificate(pemBytes)
+	if err != nil {
+		return nil, false
+	}
+	for _, ca := range append(v.roots, v.intermediates...) {
+		if ca.Equal(cert) {
+			return cert, true
+		}
+	}
+	return cert, false
+}
+
+// checkOUIdentifier reports OU identifiers with no OU, or which are certified
+// by a certificate that is not a CA of the MSP
+func (v *validation) checkOUIdentifier(check, kind string, oui *mspprotos.FabricOUIdentifier) bool {
+	if oui.OrganizationalUnitIdentifier == "" {
+		v.report.add(check, Fail, "the %s has no organizational unit", kind)
+		return false
+	}
+	if len(oui.Certificate) == 0 {
+		return true
+	}
+	if cert, ok := v.isCA(oui.Certificate); !ok {
+		if cert == nil {
+			v.report.add(check, Fail, "the certificate of the %s %s cannot be parsed", kind, oui.OrganizationalUnitIdentifier)
+		} else {
+			v.report.add(check, Fail, "the certificate %s of the %s %s is not a root or intermediate CA of the MSP", name(cert), kind, oui.OrganizationalUnitIdentifier)
+		}
+		return false
+	}
+	return true
+}
+
+// checkOUs checks that the signing identity and the admins carry one of the
+// organizational units the MSP is restricted to, if any
+func (v *validation) checkOUs() {
+	if len(v.config.OrganizationalUnitIdentifiers) == 0 {
+		return
+	}
+	ous := map[string]bool{}
+	for _, oui := range v.config.OrganizationalUnitIdentifiers {
+		if v.checkOUIdentifier("ous", "OU identifier", oui) {
+			ous[oui.OrganizationalUnitIdentifier] = true
+		}
+	}
+
+	check := func(kind string, cert *x509.Certificate) {
+		for _, ou := range cert.Subject.OrganizationalUnit {
+			if ous[ou] {
+				v.report.add("ous", Pass, "%s %s carries organizational unit %s", kind, name(cert), ou)
+				return
+			}
+		}
+		v.report.add("ous", Fail, "%s %s carries none of the organizational units the MSP is restricted to, but %v", kind, name(cert), cert.Subject.OrganizationalUnit)
+	}
+	if v.signer != nil {
+		check("signing certificate", v.signer)
+	}
+	for _, admin := range v.admins {
+		check("admin certificate", admin)
+	}
+}
+
+// nodeOU returns the node OU the certificate is classified as, client or
+// peer, or an error if it carries none or both of them
+func (v *validation) nodeOU(cert *x509.Certificate) (string, error) {
+	nodeOUs := v.config.FabricNodeOus
+	var classes []string
+	for _, ou := range cert.Subject.OrganizationalUnit {
+		switch ou {
+		case nodeOUs.ClientOuIdentifier.OrganizationalUnitIdentifier:
+			classes = append(classes, "client")
+		case nodeOUs.PeerOuIdentifier.OrganizationalUnitIdentifier:
+			classes = append(classes, "peer")
+		}
+	}
+	switch len(classes) {
+	case 0:
+		return "", fmt.Errorf("carries neither the client OU %s nor the peer OU %s", nodeOUs.ClientOuIdentifier.OrganizationalUnitIdentifier, nodeOUs.PeerOuIdentifier.OrganizationalUnitIdentifier)
+	case 1:
+		return classes[0], nil
+	default:
+		return "", fmt.Errorf("carries both the client and the peer OUs")
+	}
+}
+
+// checkNodeOUs checks the NodeOUs config, and classifies the signing
+// identity and the admins as clients or peers
+func (v *validation) checkNodeOUs() {
+	nodeOUs := v.config.FabricNodeOus
+	if nodeOUs == nil || !nodeOUs.Enable {
+		v.report.add("nodeous", Pass, "NodeOUs are disabled, identities are not classified as clients or peers")
+		return
+	}
+	if v.opts.Version < msp.MSPv1_1 {
+		v.report.add("nodeous", Warn, "NodeOUs are enabled, but ignored by MSPs of version 1.0, which the peers and the orderers set their local MSPs up with")
+	}
+	valid := true
+	if nodeOUs.ClientOuIdentifier == nil {
+		v.report.add("nodeous", Fail, "NodeOUs are enabled, but the client OU identifier is missing")
+		valid = false
+	} else if !v.checkOUIdentifier("nodeous", "client OU identifier", nodeOUs.ClientOuIdentifier) {
+		valid = false
+	}
+	if nodeOUs.PeerOuIdentifier == nil {
+		v.report.add("nodeous", Fail, "NodeOUs are enabled, but the peer OU identifier is missing")
+		valid = false
+	} else if !v.checkOUIdentifier("nodeous", "peer OU identifier", nodeOUs.PeerOuIdentifier) {
+		valid = false
+	}
+	if !valid || v.opts.Version < msp.MSPv1_1 {
+		return
+	}
+
+	classify := func(kind string, cert *x509.Certificate) {
+		class, err := v.nodeOU(cert)
+		if err != nil {
+			v.report.add("nodeous", Fail, "%s %s is rejected, it %s", kind, name(cert), err)
+			return
+		}
+		v.report.add("nodeous", Pass, "%s %s is a %s", kind, name(cert), class)
+	}
+	if v.signer != nil {
+		classify("signing certificate", v.signer)
+	}
+	for _, admin := range v.admins {
+		classify("admin certificate", admin)
+	}
+}
+
+// checkKeys checks that the keystore of a local MSP holds the private key of
+// its signing certificate
+func (v *validation) checkKeys() {
+	if v.opts.KeystoreDir == "" {
+		return
+	}
+	if v.config.SigningIdentity == nil {
+		v.report.add("keys", Fail, "the local MSP has no signing certificate")
+		return
+	}
+	signer := v.signer
+	if signer == nil {
+		return
+	}
+	public, err := x509.MarshalPKIXPublicKey(signer.PublicKey)
+	if err != nil {
+		v.report.add("keys", Fail, "the public key of signing certificate %s is not supported: %s", name(signer), err)
+		return
+	}
+
+	files, err := ioutil.ReadDir(v.opts.KeystoreDir)
+	if err != nil {
+		v.report.add("keys", Fail, "cannot read the keystore: %s", err)
+		return
+	}
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		raw, err := ioutil.ReadFile(filepath.Join(v.opts.KeystoreDir, f.Name()))
+		if err != nil {
+			continue
+		}
+		key, err := utils.PEMtoPrivateKey(raw, nil)
+		if err != nil {
+			continue
+		}
+		signerKey, ok := key.(crypto.Signer)
+		if !ok {
+			continue
+		}
+		keyPublic, err := x509.MarshalPKIXPublicKey(signerKey.Public())
+		if err == nil && bytes.Equal(keyPublic, public) {
+			v.report.add("keys", Pass, "private key %s matches signing certificate %s", f.Name(), name(signer))
+			return
+		}
+	}
+	v.report.add("keys", Fail, "no private key in %s matches the public key of signing certificate %s", v.opts.KeystoreDir, name(signer))
+}
+
+// checkTLS checks the TLS CA certificates, which the peers and the orderers
+// authenticate the TLS connections of the members of the MSP with
+func (v *validation) checkTLS() {
+	if len(v.config.TlsRootCerts) == 0 {
+		v.report.add("tls", Warn, "no TLS root CA certificate is configured, the TLS connections of the members of the MSP cannot be authenticated")
+		return
+	}
+	roots := v.parse("tls", "TLS root CA certificate", v.config.TlsRootCerts)
+	for _, root := range roots {
+		v.checkCA("tls", "TLS root CA", root)
+	}
+	intermediates := v.parse("tls", "TLS intermediate CA certificate", v.config.TlsIntermediateCerts)
+	for _, intermediate := range intermediates {
+		v.checkCA("tls", "TLS intermediate CA", intermediate)
+		v.checkChain("tls", "TLS intermediate CA", intermediate, roots, intermediates)
+	}
+	v.tlsCAs = append(roots, intermediates...)
+	v.report.add("tls", Pass, "TLS certificates issued by %s are trusted", names(v.tlsCAs))
+}
+
+// checkAdmins checks that the admins are valid identities of the MSP set up,
+// and whether its signing identity is an admin
+func (v *validation) checkAdmins(inst msp.MSP) {
+	adminPrincipal := &mspprotos.MSPPrincipal{
+		PrincipalClassification: mspprotos.MSPPrincipal_ROLE,
+		Principal:               mustMarshal(&mspprotos.MSPRole{MspIdentifier: v.config.Name, Role: mspprotos.MSPRole_ADMIN}),
+	}
+	for i, admin := range v.config.Admins {
+		id, err := inst.DeserializeIdentity(mustMarshal(&mspprotos.SerializedIdentity{Mspid: v.config.Name, IdBytes: admin}))
+		if err != nil {
+			v.report.add("admins", Fail, "admin certificate #%d cannot be deserialized: %s", i+1, err)
+			continue
+		}
+		if err := inst.Validate(id); err != nil {
+			v.report.add("admins", Fail, "admin certificate #%d is not a valid identity of the MSP: %s", i+1, err)
+			continue
+		}
+		v.report.add("admins", Pass, "admin certificate #%d is a valid identity of the MSP", i+1)
+	}
+
+	if v.config.SigningIdentity == nil {
+		return
+	}
+	signer, err := inst.DeserializeIdentity(mustMarshal(&mspprotos.SerializedIdentity{Mspid: v.config.Name, IdBytes: v.config.SigningIdentity.PublicSigner}))
+	if err != nil {
+		return
+	}
+	if err := inst.SatisfiesPrincipal(signer, adminPrincipal); err != nil {
+		v.report.add("admins", Pass, "the signing identity is not an admin")
+	} else {
+		v.report.add("admins", Pass, "the signing identity is an admin")
+	}
+}
+
+// describe describes the identities the MSP accepts
+func (v *validation) describe() {
+	r := v.report
+	if len(v.roots) == 0 {
+		return
+	}
+	members := fmt.Sprintf("members: certificates issued by %s", names(v.roots))
+	if len(v.intermediates) != 0 {
+		members += fmt.Sprintf(", or through %s", names(v.intermediates))
+	}
+	if ouis := v.config.OrganizationalUnitIdentifiers; len(ouis) != 0 {
+		var ous []string
+		for _, oui := range ouis {
+			ous = append(ous, oui.OrganizationalUnitIdentifier)
+		}
+		members += fmt.Sprintf(", carrying one of the organizational units %s", strings.Join(ous, ", "))
+	}
+	r.Accepts = append(r.Accepts, members)
+
+	if nodeOUs := v.config.FabricNodeOus; nodeOUs != nil && nodeOUs.Enable && v.opts.Version >= msp.MSPv1_1 &&
+		nodeOUs.ClientOuIdentifier != nil && nodeOUs.PeerOuIdentifier != nil {
+		r.Accepts = append(r.Accepts,
+			fmt.Sprintf("clients: members carrying organizational unit %s", nodeOUs.ClientOuIdentifier.OrganizationalUnitIdentifier),
+			fmt.Sprintf("peers: members carrying organizational unit %s", nodeOUs.PeerOuIdentifier.OrganizationalUnitIdentifier),
+			"the members carrying neither or both of them are rejected")
+	}
+
+	if len(v.admins) != 0 {
+		r.Accepts = append(r.Accepts, fmt.Sprintf("admins: %s", names(v.admins)))
+	}
+	if len(v.config.RevocationList) != 0 {
+		r.Accepts = append(r.Accepts, fmt.Sprintf("revoked: the certificates listed in %d CRLs", len(v.config.RevocationList)))
+	}
+	if len(v.tlsCAs) != 0 {
+		r.Accepts = append(r.Accepts, fmt.Sprintf("TLS: certificates issued by %s", names(v.tlsCAs)))
+	}
+}
+
+func mustMarshal(msg proto.Message) []byte {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// VersionString returns the version of an MSP, as in 1.3
+func VersionString(version msp.MSPVersion) string {
+	switch version {
+	case msp.MSPv1_0:
+		return "1.0"
+	case msp.MSPv1_1:
+		return "1.1"
+	case msp.MSPv1_3:
+		return "1.3"
+	case msp.MSPv1_4:
+		return "1.4"
+	default:
+		return fmt.Sprintf("unknown (%d)", version)
+	}
+}
+
+// ParseVersion returns the MSP version of the given string, as in 1.3
+func ParseVersion(version string) (msp.MSPVersion, error) {
+	for _, v := range []msp.MSPVersion{msp.MSPv1_0, msp.MSPv1_1, msp.MSPv1_3, msp.MSPv1_4} {
+		if VersionString(v) == version {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown MSP version %s, expected 1.0, 1.1, 1.3 or 1.4", version)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validator
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/msp"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+// findings returns the messages of the findings of the given check and result
+func findings(r *Report, check string, result Result) []string {
+	var messages []string
+	for _, f := range r.Findings {
+		if f.Check == check && f.Result == result {
+			messages = append(messages, f.Message)
+		}
+	}
+	return messages
+}
+
+func TestValidateLocalMSP(t *testing.T) {
+	mspDir, err := configtest.GetDevMspDir()
+	assert.NoError(t, err)
+	conf, err := msp.GetLocalMspConfig(mspDir, nil, "SampleOrg")
+	assert.NoError(t, err)
+
+	report := Validate(conf, Options{Version: msp.MSPv1_0, KeystoreDir: filepath.Join(mspDir, "keystore")})
+	assert.True(t, report.Accepted())
+	assert.Equal(t, "SampleOrg", report.MSPID)
+	assert.Equal(t, []string{"the MSP can be set up"}, findings(report, "setup", Pass))
+	assert.Equal(t, []string{"private key key.pem matches signing certificate peer0.org1.example.com"}, findings(report, "keys", Pass))
+	assert.Contains(t, findings(report, "admins", Pass), "the signing identity is an admin")
+	assert.Contains(t, report.Accepts, "admins: peer0.org1.example.com")
+
+	buf := &bytes.Buffer{}
+	report.Print(buf)
+	assert.Contains(t, buf.String(), "MSP SampleOrg (version 1.0)\n")
+	assert.Contains(t, buf.String(), "[PASS] setup: the MSP can be set up\n")
+	assert.Contains(t, buf.String(), "Result: the MSP is accepted\n")
+}
+
+func TestValidateMissingKey(t *testing.T) {
+	mspDir, err := configtest.GetDevMspDir()
+	assert.NoError(t, err)
+	conf, err := msp.GetLocalMspConfig(mspDir, nil, "SampleOrg")
+	assert.NoError(t, err)
+	keystore, err := ioutil.TempDir("", "keystore")
+	assert.NoError(t, err)
+	defer os.RemoveAll(keystore)
+
+	report := Validate(conf, Options{Version: msp.MSPv1_0, KeystoreDir: keystore})
+	assert.False(t, report.Accepted())
+	assert.Equal(t, []string{"no private key in " + keystore + " matches the public key of signing certificate peer0.org1.example.com"}, findings(report, "keys", Fail))
+	assert.Len(t, findings(report, "setup", Fail), 1)
+}
+
+func TestValidateNodeOUs(t *testing.T) {
+	conf, err := msp.GetLocalMspConfig("../../../../msp/testdata/nodeous1", nil, "SampleOrg")
+	assert.NoError(t, err)
+
+	// the local MSPs of version 1.0 ignore NodeOUs
+	report := Validate(conf, Options{Version: msp.MSPv1_0, KeystoreDir: "../../../../msp/testdata/nodeous1/keystore"})
+	assert.True(t, report.Accepted())
+	assert.Len(t, findings(report, "nodeous", Warn), 1)
+
+	report = Validate(conf, Options{Version: msp.MSPv1_3, KeystoreDir: "../../../../msp/testdata/nodeous1/keystore"})
+	assert.False(t, report.Accepted())
+	assert.Equal(t, []string{
+		"signing certificate peer0.org1.example.com is rejected, it carries neither the client OU OU_client nor the peer OU OU_peer",
+		"admin certificate peer0.org1.example.com is rejected, it carries neither the client OU OU_client nor the peer OU OU_peer",
+	}, findings(report, "nodeous", Fail))
+
+	conf, err = msp.GetLocalMspConfig("../../../../msp/testdata/nodeous3", nil, "SampleOrg")
+	assert.NoError(t, err)
+	report = Validate(conf, Options{Version: msp.MSPv1_3, KeystoreDir: "../../../../msp/testdata/nodeous3/keystore"})
+	assert.True(t, report.Accepted())
+	assert.Equal(t, []string{"signing certificate peer0 is a peer", "admin certificate peer0 is a client"}, findings(report, "nodeous", Pass))
+	assert.Contains(t, findings(report, "admins", Pass), "the signing identity is not an admin")
+}
+
+func TestValidateExpired(t *testing.T) {
+	conf, err := msp.GetLocalMspConfig("../../../../msp/testdata/expired", nil, "SampleOrg")
+	assert.NoError(t, err)
+
+	report := Validate(conf, Options{Version: msp.MSPv1_0})
+	assert.False(t, report.Accepted())
+	assert.Contains(t, findings(report, "chain", Fail), "signing certificate peer0.org1.example.com expired on 1989-12-15T08:00:00Z")
+}
+
+func TestValidateChannelMSP(t *testing.T) {
+	mspDir, err := configtest.GetDevMspDir()
+	assert.NoError(t, err)
+	conf, err := msp.GetVerifyingMspConfig(mspDir, "SampleOrg", "bccsp")
+	assert.NoError(t, err)
+
+	report := Validate(conf, Options{Version: msp.MSPv1_4})
+	assert.True(t, report.Accepted())
+	assert.Empty(t, findings(report, "keys", Pass))
+	assert.Equal(t, []string{"admin certificate #1 is a valid identity of the MSP"}, findings(report, "admins", Pass))
+
+	// an admin not issued by the CAs of the MSP
+	fc := &mspprotos.FabricMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(conf.Config, fc))
+	fc.Admins = append(fc.Admins, fc.TlsRootCerts[0])
+	fc.TlsRootCerts = nil
+	fc.TlsIntermediateCerts = nil
+	conf.Config, err = proto.Marshal(fc)
+	assert.NoError(t, err)
+
+	report = Validate(conf, Options{Version: msp.MSPv1_4})
+	assert.False(t, report.Accepted())
+	assert.Len(t, findings(report, "chain", Fail), 1)
+	assert.Contains(t, findings(report, "chain", Fail)[0], "admin certificate Org2 does not chain to a root CA")
+	assert.Len(t, findings(report, "tls", Warn), 1)
+}
+
+func TestParseVersion(t *testing.T) {
+	version, err := ParseVersion("1.3")
+	assert.NoError(t, err)
+	assert.Equal(t, msp.MSPVersion(msp.MSPv1_3), version)
+	assert.Equal(t, "1.3", VersionString(version))
+
+	_, err = ParseVersion("1.2")
+	assert.EqualError(t, err, "unknown MSP version 1.2, expected 1.0, 1.1, 1.3 or 1.4")
+}
@@ -16,6 +16,8 @@ import (
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
 	"github.com/hyperledger/fabric/common/tools/protolator"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/msp"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -231,3 +233,121 @@ func TestChannelCreationPolicy(t *testing.T) {
 
 	bidirectionalMarshal(t, cu)
 }
+
+func sampleProposalAndResponse(t *testing.T) (*pb.Proposal, *pb.ProposalResponse) {
+	creator := utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "SampleOrg", IdBytes: []byte("cert")})
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: "mycc"},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte("invoke")}},
+		},
+	}
+	prop, _, err := utils.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "foo", cis, creator)
+	assert.NoError(t, err)
+
+	results := utils.MarshalOrPanic(&rwset.TxReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsRwset: []*rwset.NsReadWriteSet{{
+			Namespace: "mycc",
+			Rwset: utils.MarshalOrPanic(&kvrwset.KVRWSet{
+				Writes: []*kvrwset.KVWrite{{Key: "key", Value: []byte("value")}},
+			}),
+		}},
+	})
+	prp := utils.MarshalOrPanic(&pb.ProposalResponsePayload{
+		ProposalHash: []byte("hash"),
+		Extension: utils.MarshalOrPanic(&pb.ChaincodeAction{
+			Results:     results,
+			ChaincodeId: &pb.ChaincodeID{Name: "mycc"},
+		}),
+	})
+	resp := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Payload:     prp,
+		Endorsement: &pb.Endorsement{Endorser: creator, Signature: []byte("signature")},
+	}
+	return prop, resp
+}
+
+func TestSignedProposal(t *testing.T) {
+	prop, _ := sampleProposalAndResponse(t)
+	sp := &pb.SignedProposal{
+		ProposalBytes: utils.MarshalOrPanic(prop),
+		Signature:     []byte("signature"),
+	}
+	bidirectionalMarshal(t, sp)
+
+	var buffer bytes.Buffer
+	assert.NoError(t, protolator.DeepMarshalJSON(&buffer, sp))
+	// the header, its extension and the payload of the proposal are decoded
+	assert.Contains(t, buffer.String(), `"mspid": "SampleOrg"`)
+	assert.Contains(t, buffer.String(), `"chaincode_id": {`)
+	assert.Contains(t, buffer.String(), `"input": {`)
+}
+
+func TestProposalResponse(t *testing.T) {
+	_, resp := sampleProposalAndResponse(t)
+	bidirectionalMarshal(t, resp)
+
+	var buffer bytes.Buffer
+	assert.NoError(t, protolator.DeepMarshalJSON(&buffer, resp))
+	assert.Contains(t, buffer.String(), `"mspid": "SampleOrg"`)
+	assert.Contains(t, buffer.String(), `"key": "key"`)
+}
+
+func TestEndorserTransaction(t *testing.T) {
+	prop, resp := sampleProposalAndResponse(t)
+	hdr, err := utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+
+	tx := &pb.Transaction{
+		Actions: []*pb.TransactionAction{{
+			Header: hdr.SignatureHeader,
+			Payload: utils.MarshalOrPanic(&pb.ChaincodeActionPayload{
+				ChaincodeProposalPayload: prop.Payload,
+				Action: &pb.ChaincodeEndorsedAction{
+					ProposalResponsePayload: resp.Payload,
+					Endorsements:            []*pb.Endorsement{resp.Endorsement},
+				},
+			}),
+		}},
+	}
+	env := &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: hdr,
+			Data:   utils.MarshalOrPanic(tx),
+		}),
+		Signature: []byte("signature"),
+	}
+	bidirectionalMarshal(t, &cb.Block{
+		Header:   &cb.BlockHeader{Number: 1},
+		Data:     &cb.BlockData{Data: [][]byte{utils.MarshalOrPanic(env)}},
+		Metadata: &cb.BlockMetadata{Metadata: [][]byte{{}, {}, {}, {}}},
+	})
+	bidirectionalMarshal(t, &pb.SignedTransaction{TransactionBytes: utils.MarshalOrPanic(tx)})
+}
+
+func TestTxPvtReadWriteSet(t *testing.T) {
+	bidirectionalMarshal(t, &rwset.TxPvtReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsPvtRwset: []*rwset.NsPvtReadWriteSet{{
+			Namespace: "mycc",
+			CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{{
+				CollectionName: "collection",
+				Rwset: utils.MarshalOrPanic(&kvrwset.KVRWSet{
+					Writes: []*kvrwset.KVWrite{{Key: "key", Value: []byte("value")}},
+				}),
+			}},
+		}},
+	})
+}
+
+func TestSignedChaincodeDeploymentSpec(t *testing.T) {
+	bidirectionalMarshal(t, &pb.SignedChaincodeDeploymentSpec{
+		ChaincodeDeploymentSpec: utils.MarshalOrPanic(&pb.ChaincodeDeploymentSpec{
+			ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "mycc", Version: "1.0"}},
+			CodePackage:   []byte("code"),
+		}),
+		InstantiationPolicy: utils.MarshalOrPanic(&cb.SignaturePolicyEnvelope{Version: 1}),
+	})
+}
@@ -0,0 +1,233 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	otlpTracesPath = "/v1/traces"
+	scopeName      = "github.com/hyperledger/fabric/common/tracing"
+
+	spanKindInternal = 1
+	statusCodeError  = 2
+)
+
+// exporter buffers the ended spans and posts them in batches to the
+// OTLP/HTTP receiver of a collector, encoded in the OTLP JSON format
+type exporter struct {
+	url           string
+	serviceName   string
+	flushInterval time.Duration
+	maxBatchSize  int
+	client        *http.Client
+
+	spans   chan *Span
+	stopped chan struct{}
+	done    sync.WaitGroup
+}
+
+func newExporter(opts Opts) (*exporter, error) {
+	endpoint := opts.Endpoint
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		endpoint = "http://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid tracing endpoint [%s]", opts.Endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = otlpTracesPath
+	}
+	return &exporter{
+		url:           u.String(),
+		serviceName:   opts.ServiceName,
+		flushInterval: opts.FlushInterval,
+		maxBatchSize:  opts.MaxBatchSize,
+		client:        &http.Client{Timeout: opts.Timeout},
+		spans:         make(chan *Span, 4*opts.MaxBatchSize),
+		stopped:       make(chan struct{}),
+	}, nil
+}
+
+func (e *exporter) start() {
+	e.done.Add(1)
+	go e.run()
+}
+
+// stop exports the spans still buffered and waits for the exporter to exit
+func (e *exporter) stop() {
+	close(e.stopped)
+	e.done.Wait()
+}
+
+// export hands over an ended span. The span is dropped rather than blocking
+// the transaction flow when the buffer is full.
+func (e *exporter) export(span *Span) {
+	select {
+	case e.spans <- span:
+	default:
+		logger.Debugf("Dropping span [%s] as the export buffer is full", span.name)
+	}
+}
+
+func (e *exporter) run() {
+	defer e.done.Done()
+	ticker := time.NewTicker(e.flushInterval)
+	defer ticker.Stop()
+
+	var batch []*Span
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.post(batch); err != nil {
+			logger.Warningf("Failed to export %d spans: %s", len(batch), err)
+		}
+		batch = nil
+	}
+
+	for {
+		select {
+		case span := <-e.spans:
+			batch = append(batch, span)
+			if len(batch) >= e.maxBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.stopped:
+			for {
+				select {
+				case span := <-e.spans:
+					batch = append(batch, span)
+					if len(batch) >= e.maxBatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (e *exporter) post(batch []*Span) error {
+	body, err := json.Marshal(e.encode(batch))
+	if err != nil {
+		return errors.Wrap(err, "failed to encode spans")
+	}
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "failed to post spans to [%s]", e.url)
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("collector at [%s] responded with status [%s]", e.url, resp.Status)
+	}
+	return nil
+}
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func (e *exporter) encode(batch []*Span) *otlpRequest {
+	spans := make([]otlpSpan, 0, len(batch))
+	for _, s := range batch {
+		s.mutex.Lock()
+		span := otlpSpan{
+			TraceID:           hex.EncodeToString(s.context.TraceID[:]),
+			SpanID:            hex.EncodeToString(s.context.SpanID[:]),
+			Name:              s.name,
+			Kind:              spanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		}
+		if s.parentID != (SpanID{}) {
+			span.ParentSpanID = hex.EncodeToString(s.parentID[:])
+		}
+		for _, a := range s.attributes {
+			span.Attributes = append(span.Attributes, otlpKeyValue{a.key, otlpAnyValue{a.value}})
+		}
+		if s.errMsg != "" {
+			span.Status = otlpStatus{Code: statusCodeError, Message: s.errMsg}
+		}
+		s.mutex.Unlock()
+		spans = append(spans, span)
+	}
+	return &otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: []otlpKeyValue{{"service.name", otlpAnyValue{e.serviceName}}},
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: scopeName},
+				Spans: spans,
+			}},
+		}},
+	}
+}
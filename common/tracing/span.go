@@ -0,0 +1,187 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// traceParentVersion is the version of the W3C trace context format
+// the trace contexts are encoded with
+const traceParentVersion = "00"
+
+// TraceID identifies a trace
+type TraceID [16]byte
+
+// SpanID identifies a span within a trace
+type SpanID [8]byte
+
+// SpanContext is the part of a span that is propagated to its children,
+// in process or across the network
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+}
+
+// IsValid returns whether the context identifies a trace. The span id of a
+// valid context may be zero, in which case the spans started from it are
+// roots of the trace.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != TraceID{}
+}
+
+// TraceParent encodes the context as a W3C traceparent string
+func (sc SpanContext) TraceParent() string {
+	return fmt.Sprintf("%s-%s-%s-01", traceParentVersion, hex.EncodeToString(sc.TraceID[:]), hex.EncodeToString(sc.SpanID[:]))
+}
+
+// ParseTraceParent decodes a W3C traceparent string
+func ParseTraceParent(traceParent string) (SpanContext, error) {
+	sc := SpanContext{}
+	parts := strings.Split(traceParent, "-")
+	if len(parts) != 4 || parts[0] != traceParentVersion {
+		return sc, errors.Errorf("malformed traceparent [%s]", traceParent)
+	}
+	traceID, err := hex.DecodeString(parts[1])
+	if err != nil || len(traceID) != len(sc.TraceID) {
+		return sc, errors.Errorf("malformed trace id in traceparent [%s]", traceParent)
+	}
+	spanID, err := hex.DecodeString(parts[2])
+	if err != nil || len(spanID) != len(sc.SpanID) {
+		return sc, errors.Errorf("malformed span id in traceparent [%s]", traceParent)
+	}
+	copy(sc.TraceID[:], traceID)
+	copy(sc.SpanID[:], spanID)
+	if !sc.IsValid() || sc.SpanID == (SpanID{}) {
+		return SpanContext{}, errors.Errorf("invalid traceparent [%s]", traceParent)
+	}
+	return sc, nil
+}
+
+type attribute struct {
+	key   string
+	value string
+}
+
+// Span records the duration of an operation. The spans are nil when tracing
+// is not enabled, and all their methods can be called on a nil span.
+type Span struct {
+	name       string
+	context    SpanContext
+	parentID   SpanID
+	start      time.Time
+	end        time.Time
+	attributes []attribute
+	errMsg     string
+	ended      bool
+	mutex      sync.Mutex
+	exporter   *exporter
+}
+
+// StartSpan starts a span, as a child of the given context if it is valid or as
+// the root of a new trace otherwise
+func StartSpan(name string, parent SpanContext) *Span {
+	return StartSpanAt(name, parent, time.Now())
+}
+
+// StartSpanAt starts a span at the given time, for operations whose parent is
+// only known once they are under way
+func StartSpanAt(name string, parent SpanContext, start time.Time) *Span {
+	e := activeExporter()
+	if e == nil {
+		return nil
+	}
+	span := &Span{
+		name:     name,
+		parentID: parent.SpanID,
+		start:    start,
+		exporter: e,
+	}
+	if parent.IsValid() {
+		span.context.TraceID = parent.TraceID
+	} else {
+		rand.Read(span.context.TraceID[:])
+	}
+	rand.Read(span.context.SpanID[:])
+	return span
+}
+
+// Context returns the context to propagate to the children of the span
+func (s *Span) Context() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.context
+}
+
+// SetAttribute annotates the span with a key/value
+func (s *Span) SetAttribute(key, value string) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.attributes = append(s.attributes, attribute{key, value})
+}
+
+// SetError marks the operation recorded by the span as failed
+func (s *Span) SetError(msg string) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.errMsg = msg
+}
+
+// End ends the span and hands it over for export. Ending a span more than
+// once has no effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	if s.ended {
+		s.mutex.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mutex.Unlock()
+	s.exporter.export(s)
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpan returns a copy of the context carrying the span
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	if span == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, spanContextKey{}, span)
+}
+
+// SpanFromContext returns the span carried by the context, if any
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanContextKey{}).(*Span)
+	return span
+}
+
+// StartSpanFromContext starts a span as a child of the span carried by the
+// context, and returns it along with a copy of the context carrying it
+func StartSpanFromContext(ctx context.Context, name string) (*Span, context.Context) {
+	span := StartSpan(name, SpanFromContext(ctx).Context())
+	return span, ContextWithSpan(ctx, span)
+}
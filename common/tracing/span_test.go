@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceParent(t *testing.T) {
+	traceParent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, err := ParseTraceParent(traceParent)
+	assert.NoError(t, err)
+	assert.True(t, sc.IsValid())
+	assert.Equal(t, byte(0x4b), sc.TraceID[0])
+	assert.Equal(t, byte(0xb7), sc.SpanID[7])
+	assert.Equal(t, traceParent, sc.TraceParent())
+}
+
+func TestParseTraceParentErrors(t *testing.T) {
+	tests := []struct {
+		traceParent string
+		err         string
+	}{
+		{"", "malformed traceparent []"},
+		{"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "malformed traceparent [01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01]"},
+		{"00-4bf92f3577b34da6-00f067aa0ba902b7-01", "malformed trace id in traceparent [00-4bf92f3577b34da6-00f067aa0ba902b7-01]"},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902bz-01", "malformed span id in traceparent [00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902bz-01]"},
+		{"00-00000000000000000000000000000000-00f067aa0ba902b7-01", "invalid traceparent [00-00000000000000000000000000000000-00f067aa0ba902b7-01]"},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", "invalid traceparent [00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01]"},
+	}
+	for _, test := range tests {
+		_, err := ParseTraceParent(test.traceParent)
+		assert.EqualError(t, err, test.err)
+	}
+}
+
+func TestSpanFromContext(t *testing.T) {
+	collector := newTestCollector(t)
+	defer collector.server.Close()
+	startTestExporter(t, testOpts(collector.server.URL))
+	defer Shutdown()
+
+	ctx := context.Background()
+	assert.Nil(t, SpanFromContext(ctx))
+
+	parent, ctx := StartSpanFromContext(ctx, "parent")
+	assert.Equal(t, parent, SpanFromContext(ctx))
+	assert.Equal(t, SpanID{}, parent.parentID)
+
+	child, childCtx := StartSpanFromContext(ctx, "child")
+	assert.Equal(t, child, SpanFromContext(childCtx))
+	assert.Equal(t, parent.Context().TraceID, child.Context().TraceID)
+	assert.Equal(t, parent.Context().SpanID, child.parentID)
+	assert.NotEqual(t, parent.Context().SpanID, child.Context().SpanID)
+
+	// a nil span is not stored in the context
+	assert.Equal(t, ctx, ContextWithSpan(ctx, nil))
+}
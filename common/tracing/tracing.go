@@ -0,0 +1,145 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package tracing records spans for the stages a transaction goes through,
+// from the arrival of its proposal at the endorsers to its commit on the
+// peers, and exports them to an OpenTelemetry collector over OTLP/HTTP.
+//
+// The trace context of a transaction travels in the channel header of its
+// proposal, which is carried over unchanged into the transaction envelope,
+// so that the orderers and the committing peers record their spans in the
+// same trace as the endorsers.
+package tracing
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultServiceName   = "peer"
+	defaultEndpoint      = "localhost:4318"
+	defaultFlushInterval = 5 * time.Second
+	defaultMaxBatchSize  = 512
+	defaultTimeout       = 10 * time.Second
+)
+
+var logger = flogging.MustGetLogger("tracing")
+
+var (
+	globalExporter *exporter
+	once           sync.Once
+	exporterMutex  = &sync.Mutex{}
+	running        bool
+)
+
+// Opts contains the configuration of the tracing
+type Opts struct {
+	// Enabled enables the recording of the spans
+	Enabled bool
+	// ServiceName is the name the spans are reported under
+	ServiceName string
+	// Endpoint is the address of the OTLP/HTTP receiver of the collector,
+	// the spans are posted to the `/v1/traces` path of it
+	Endpoint string
+	// FlushInterval is the maximum time spans are buffered before export
+	FlushInterval time.Duration
+	// MaxBatchSize is the maximum number of spans exported in one request
+	MaxBatchSize int
+	// Timeout bounds each export request
+	Timeout time.Duration
+}
+
+// NewOpts creates the tracing options of the peer based on the config file
+func NewOpts() Opts {
+	opts := Opts{}
+	opts.Enabled = viper.GetBool("tracing.enabled")
+	if serviceName := viper.GetString("tracing.serviceName"); serviceName != "" {
+		opts.ServiceName = serviceName
+	} else {
+		opts.ServiceName = defaultServiceName
+	}
+	if endpoint := viper.GetString("tracing.endpoint"); endpoint != "" {
+		opts.Endpoint = endpoint
+	} else {
+		opts.Endpoint = defaultEndpoint
+	}
+	if flushInterval := viper.GetDuration("tracing.flushInterval"); flushInterval > 0 {
+		opts.FlushInterval = flushInterval
+	} else {
+		opts.FlushInterval = defaultFlushInterval
+	}
+	if maxBatchSize := viper.GetInt("tracing.maxBatchSize"); maxBatchSize > 0 {
+		opts.MaxBatchSize = maxBatchSize
+	} else {
+		opts.MaxBatchSize = defaultMaxBatchSize
+	}
+	if timeout := viper.GetDuration("tracing.timeout"); timeout > 0 {
+		opts.Timeout = timeout
+	} else {
+		opts.Timeout = defaultTimeout
+	}
+	return opts
+}
+
+// Init initializes the global exporter the spans are handed to once they end.
+// Spans are not recorded until Start is called, nor when tracing is disabled.
+func Init(opts Opts) (err error) {
+	once.Do(func() {
+		if !opts.Enabled {
+			return
+		}
+		var e *exporter
+		if e, err = newExporter(opts); err != nil {
+			return
+		}
+		exporterMutex.Lock()
+		globalExporter = e
+		exporterMutex.Unlock()
+	})
+	return
+}
+
+// Start starts exporting the spans
+func Start() {
+	exporterMutex.Lock()
+	defer exporterMutex.Unlock()
+	if running || globalExporter == nil {
+		return
+	}
+	running = true
+	globalExporter.start()
+}
+
+// Shutdown exports the spans still buffered and stops the exporter
+func Shutdown() {
+	exporterMutex.Lock()
+	defer exporterMutex.Unlock()
+	if !running {
+		return
+	}
+	globalExporter.stop()
+	globalExporter = nil
+	running = false
+}
+
+// Enabled returns whether spans are being recorded. Callers can check it to
+// skip work that is only needed to annotate the spans.
+func Enabled() bool {
+	return activeExporter() != nil
+}
+
+func activeExporter() *exporter {
+	exporterMutex.Lock()
+	defer exporterMutex.Unlock()
+	if !running {
+		return nil
+	}
+	return globalExporter
+}
@@ -0,0 +1,185 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// testCollector is an OTLP/HTTP receiver recording the requests it gets
+type testCollector struct {
+	server   *httptest.Server
+	mutex    sync.Mutex
+	paths    []string
+	requests []*otlpRequest
+}
+
+func newTestCollector(t *testing.T) *testCollector {
+	c := &testCollector{}
+	c.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		req := &otlpRequest{}
+		assert.NoError(t, json.Unmarshal(body, req))
+		c.mutex.Lock()
+		c.paths = append(c.paths, r.URL.Path)
+		c.requests = append(c.requests, req)
+		c.mutex.Unlock()
+	}))
+	return c
+}
+
+func (c *testCollector) spans() []otlpSpan {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var spans []otlpSpan
+	for _, req := range c.requests {
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				spans = append(spans, ss.Spans...)
+			}
+		}
+	}
+	return spans
+}
+
+// startTestExporter starts the global exporter, bypassing Init which only
+// takes effect once per process
+func startTestExporter(t *testing.T, opts Opts) {
+	e, err := newExporter(opts)
+	assert.NoError(t, err)
+	exporterMutex.Lock()
+	globalExporter = e
+	exporterMutex.Unlock()
+	Start()
+}
+
+func testOpts(endpoint string) Opts {
+	return Opts{
+		Enabled:       true,
+		ServiceName:   "testpeer",
+		Endpoint:      endpoint,
+		FlushInterval: time.Hour,
+		MaxBatchSize:  10,
+		Timeout:       time.Second,
+	}
+}
+
+func TestNewOpts(t *testing.T) {
+	defer viper.Reset()
+	assert.NoError(t, configtest.AddDevConfigPath(nil))
+	viper.SetConfigName("core")
+	assert.NoError(t, viper.ReadInConfig())
+
+	opts := NewOpts()
+	assert.Equal(t, Opts{
+		Enabled:       false,
+		ServiceName:   "peer",
+		Endpoint:      "localhost:4318",
+		FlushInterval: 5 * time.Second,
+		MaxBatchSize:  512,
+		Timeout:       10 * time.Second,
+	}, opts)
+}
+
+func TestNewOptsDefaults(t *testing.T) {
+	defer viper.Reset()
+	viper.Reset()
+	viper.Set("tracing.enabled", true)
+	opts := NewOpts()
+	assert.True(t, opts.Enabled)
+	assert.Equal(t, defaultServiceName, opts.ServiceName)
+	assert.Equal(t, defaultEndpoint, opts.Endpoint)
+	assert.Equal(t, defaultFlushInterval, opts.FlushInterval)
+	assert.Equal(t, defaultMaxBatchSize, opts.MaxBatchSize)
+	assert.Equal(t, defaultTimeout, opts.Timeout)
+}
+
+func TestDisabled(t *testing.T) {
+	assert.False(t, Enabled())
+	span := StartSpan("test", SpanContext{})
+	assert.Nil(t, span)
+	// the methods of a nil span are noops
+	span.SetAttribute("key", "value")
+	span.SetError("failure")
+	span.End()
+	assert.Equal(t, SpanContext{}, span.Context())
+	Shutdown()
+}
+
+func TestExport(t *testing.T) {
+	collector := newTestCollector(t)
+	defer collector.server.Close()
+	startTestExporter(t, testOpts(collector.server.URL))
+	assert.True(t, Enabled())
+
+	parent := StartSpan("parent", SpanContext{})
+	parent.SetAttribute("key", "value")
+	child := StartSpan("child", parent.Context())
+	child.SetError("failure")
+	child.End()
+	child.End()
+	parent.End()
+	Shutdown()
+	assert.False(t, Enabled())
+
+	assert.Equal(t, []string{otlpTracesPath}, collector.paths)
+	assert.Equal(t, "service.name", collector.requests[0].ResourceSpans[0].Resource.Attributes[0].Key)
+	assert.Equal(t, "testpeer", collector.requests[0].ResourceSpans[0].Resource.Attributes[0].Value.StringValue)
+
+	spans := collector.spans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, "parent", spans[1].Name)
+	assert.Equal(t, spans[1].TraceID, spans[0].TraceID)
+	assert.Equal(t, spans[1].SpanID, spans[0].ParentSpanID)
+	assert.Empty(t, spans[1].ParentSpanID)
+	assert.Equal(t, statusCodeError, spans[0].Status.Code)
+	assert.Equal(t, "failure", spans[0].Status.Message)
+	assert.Equal(t, []otlpKeyValue{{"key", otlpAnyValue{"value"}}}, spans[1].Attributes)
+	assert.NotEqual(t, "0", spans[1].StartTimeUnixNano)
+}
+
+func TestExportBatches(t *testing.T) {
+	collector := newTestCollector(t)
+	defer collector.server.Close()
+	startTestExporter(t, testOpts(collector.server.URL+"/custom/path"))
+
+	for i := 0; i < 25; i++ {
+		StartSpan("span", SpanContext{}).End()
+	}
+	Shutdown()
+
+	assert.Len(t, collector.spans(), 25)
+	assert.Len(t, collector.requests, 3)
+	assert.Equal(t, "/custom/path", collector.paths[0])
+}
+
+func TestExportFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	e, err := newExporter(testOpts(server.URL))
+	assert.NoError(t, err)
+	err = e.post([]*Span{{name: "span"}})
+	assert.Contains(t, err.Error(), "responded with status [503 Service Unavailable]")
+
+	e, err = newExporter(testOpts("http://[::1"))
+	assert.Nil(t, e)
+	assert.Contains(t, err.Error(), "invalid tracing endpoint [http://[::1]")
+}
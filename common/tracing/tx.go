@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"crypto/sha256"
+	"strconv"
+	"time"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// TxSpanContext returns the context the spans of a transaction are started
+// from. This is the trace context set by the client in the channel header
+// when there is one. Otherwise, the trace id is derived from the transaction
+// id, so that the spans recorded for the transaction by the different nodes
+// still end up in the same trace.
+func TxSpanContext(chdr *cb.ChannelHeader) SpanContext {
+	if chdr == nil {
+		return SpanContext{}
+	}
+	if chdr.TraceContext != "" {
+		sc, err := ParseTraceParent(chdr.TraceContext)
+		if err == nil {
+			return sc
+		}
+		logger.Debugf("Ignoring the trace context of transaction [%s]: %s", chdr.TxId, err)
+	}
+	sc := SpanContext{}
+	if chdr.TxId != "" {
+		hash := sha256.Sum256([]byte(chdr.TxId))
+		copy(sc.TraceID[:], hash[:])
+	}
+	return sc
+}
+
+// StartTxSpans starts, at the given time, a span for each transaction of
+// the block. The spans are returned in the order of the transactions, with
+// a nil span for the transactions whose header cannot be read. No span is
+// started when tracing is not enabled.
+func StartTxSpans(name string, block *cb.Block, start time.Time) []*Span {
+	if !Enabled() || block == nil || block.Data == nil {
+		return nil
+	}
+	blockNum := ""
+	if block.Header != nil {
+		blockNum = strconv.FormatUint(block.Header.Number, 10)
+	}
+	spans := make([]*Span, len(block.Data.Data))
+	for i, d := range block.Data.Data {
+		chdr, err := txChannelHeader(d)
+		if err != nil {
+			logger.Debugf("Not tracing transaction %d of block [%s]: %s", i, blockNum, err)
+			continue
+		}
+		span := StartSpanAt(name, TxSpanContext(chdr), start)
+		span.SetAttribute("channel", chdr.ChannelId)
+		span.SetAttribute("txid", chdr.TxId)
+		span.SetAttribute("block", blockNum)
+		spans[i] = span
+	}
+	return spans
+}
+
+// EndSpans ends all the spans
+func EndSpans(spans []*Span) {
+	for _, span := range spans {
+		span.End()
+	}
+}
+
+func txChannelHeader(envBytes []byte) (*cb.ChannelHeader, error) {
+	env, err := utils.GetEnvelopeFromBlock(envBytes)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ChannelHeader(env)
+}
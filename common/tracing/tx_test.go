@@ -0,0 +1,69 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxSpanContext(t *testing.T) {
+	assert.Equal(t, SpanContext{}, TxSpanContext(nil))
+	assert.Equal(t, SpanContext{}, TxSpanContext(&cb.ChannelHeader{}))
+
+	traceParent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc := TxSpanContext(&cb.ChannelHeader{TxId: "tx1", TraceContext: traceParent})
+	assert.Equal(t, traceParent, sc.TraceParent())
+
+	// without a valid trace context, the trace id is derived from the txid
+	hash := sha256.Sum256([]byte("tx1"))
+	for _, traceContext := range []string{"", "malformed"} {
+		sc = TxSpanContext(&cb.ChannelHeader{TxId: "tx1", TraceContext: traceContext})
+		assert.True(t, sc.IsValid())
+		assert.Equal(t, hash[:16], sc.TraceID[:])
+		assert.Equal(t, SpanID{}, sc.SpanID)
+	}
+}
+
+func TestStartTxSpans(t *testing.T) {
+	block := cb.NewBlock(5, nil)
+	for _, txid := range []string{"tx1", "tx2"} {
+		env := &cb.Envelope{
+			Payload: utils.MarshalOrPanic(&cb.Payload{
+				Header: &cb.Header{
+					ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{ChannelId: "ch1", TxId: txid}),
+				},
+			}),
+		}
+		block.Data.Data = append(block.Data.Data, utils.MarshalOrPanic(env))
+	}
+	block.Data.Data = append(block.Data.Data, []byte("garbage"))
+
+	assert.Nil(t, StartTxSpans("validate", block, time.Now()))
+
+	collector := newTestCollector(t)
+	defer collector.server.Close()
+	startTestExporter(t, testOpts(collector.server.URL))
+
+	start := time.Now().Add(-time.Second)
+	spans := StartTxSpans("validate", block, start)
+	assert.Len(t, spans, 3)
+	assert.Nil(t, spans[2])
+	for i, txid := range []string{"tx1", "tx2"} {
+		assert.Equal(t, TxSpanContext(&cb.ChannelHeader{TxId: txid}).TraceID, spans[i].Context().TraceID)
+		assert.Equal(t, start, spans[i].start)
+		assert.Equal(t, []attribute{{"channel", "ch1"}, {"txid", txid}, {"block", "5"}}, spans[i].attributes)
+	}
+	EndSpans(spans)
+	Shutdown()
+	assert.Len(t, collector.spans(), 2)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package txbuilder constructs the messages a client exchanges with the
+// peers and the ordering service: signed proposals, the transactions
+// assembled from their responses, config updates and blocks. It is meant to
+// be used by Go applications instead of the lower level helpers of
+// protos/utils, whose signatures follow the needs of the peer.
+package txbuilder
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
+	"github.com/hyperledger/fabric/common/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// Signer signs messages on behalf of an identity. It is implemented by the
+// signing identities of the MSP.
+type Signer interface {
+	// Sign returns the signature over the digest of the message
+	Sign(message []byte) ([]byte, error)
+	// Serialize returns the serialized identity of the signer
+	Serialize() ([]byte, error)
+}
+
+// NewProposal creates a proposal to invoke the given chaincode on a channel,
+// created by the signer. It returns the proposal and the id of the
+// transaction it proposes.
+func NewProposal(channelID string, spec *pb.ChaincodeSpec, signer Signer, transientMap map[string][]byte) (*pb.Proposal, string, error) {
+	if spec == nil {
+		return nil, "", errors.New("nil chaincode spec")
+	}
+	creator, err := signer.Serialize()
+	if err != nil {
+		return nil, "", errors.WithMessage(err, "failed to serialize the signer")
+	}
+	cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: spec}
+	return utils.CreateChaincodeProposalWithTransient(cb.HeaderType_ENDORSER_TRANSACTION, channelID, cis, creator, transientMap)
+}
+
+// SignProposal signs a proposal, which can then be sent to the endorsers
+func SignProposal(proposal *pb.Proposal, signer Signer) (*pb.SignedProposal, error) {
+	return utils.GetSignedProposal(proposal, signer)
+}
+
+// NewTransaction assembles the endorsements of a proposal into a transaction
+// signed by the creator of the proposal, which can be broadcast to the
+// ordering service. The responses must all be successful and carry the same
+// payload.
+func NewTransaction(proposal *pb.Proposal, signer Signer, responses ...*pb.ProposalResponse) (*cb.Envelope, error) {
+	for i, response := range responses {
+		if response == nil || response.Response == nil {
+			return nil, errors.Errorf("proposal response %d has no response", i)
+		}
+		if response.Endorsement == nil {
+			return nil, errors.Errorf("proposal response %d is not endorsed: %s", i, response.Response.Message)
+		}
+	}
+	return utils.CreateSignedTx(proposal, signer, responses...)
+}
+
+// NewConfigUpdate computes the config update of a channel transitioning
+// from the original to the updated config
+func NewConfigUpdate(channelID string, original, updated *cb.Config) (*cb.ConfigUpdate, error) {
+	configUpdate, err := update.Compute(original, updated)
+	if err != nil {
+		return nil, err
+	}
+	configUpdate.ChannelId = channelID
+	return configUpdate, nil
+}
+
+// SignConfigUpdate returns the signature of the signer over a config update,
+// to be collected by the submitter of the update
+func SignConfigUpdate(configUpdate *cb.ConfigUpdate, signer Signer) (*cb.ConfigSignature, error) {
+	configUpdateBytes, err := proto.Marshal(configUpdate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the config update")
+	}
+	return signConfigUpdateBytes(configUpdateBytes, signer)
+}
+
+func signConfigUpdateBytes(configUpdateBytes []byte, signer Signer) (*cb.ConfigSignature, error) {
+	sigHeader, err := crypto.NewSignatureHeaderCreator(signer).NewSignatureHeader()
+	if err != nil {
+		return nil, err
+	}
+	configSig := &cb.ConfigSignature{
+		SignatureHeader: utils.MarshalOrPanic(sigHeader),
+	}
+	configSig.Signature, err = signer.Sign(util.ConcatenateBytes(configSig.SignatureHeader, configUpdateBytes))
+	if err != nil {
+		return nil, err
+	}
+	return configSig, nil
+}
+
+// NewConfigUpdateEnvelope wraps a config update, the signatures collected
+// for it and the signature of the submitter in an envelope which can be
+// broadcast to the ordering service
+func NewConfigUpdateEnvelope(configUpdate *cb.ConfigUpdate, signer Signer, signatures ...*cb.ConfigSignature) (*cb.Envelope, error) {
+	if configUpdate.ChannelId == "" {
+		return nil, errors.New("config update has no channel ID")
+	}
+	configUpdateBytes, err := proto.Marshal(configUpdate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the config update")
+	}
+	configSig, err := signConfigUpdateBytes(configUpdateBytes, signer)
+	if err != nil {
+		return nil, err
+	}
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{
+		ConfigUpdate: configUpdateBytes,
+		Signatures:   append(signatures, configSig),
+	}
+	return utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, configUpdate.ChannelId, crypto.NewSignatureHeaderCreator(signer), configUpdateEnv, 0, 0)
+}
+
+// NewBlock creates a block with the given envelopes following the block
+// with the given hash, without metadata
+func NewBlock(number uint64, previousHash []byte, envelopes ...*cb.Envelope) (*cb.Block, error) {
+	block := cb.NewBlock(number, previousHash)
+	for _, env := range envelopes {
+		envBytes, err := proto.Marshal(env)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal envelope")
+		}
+		block.Data.Data = append(block.Data.Data, envBytes)
+	}
+	block.Header.DataHash = block.Data.Hash()
+	return block, nil
+}
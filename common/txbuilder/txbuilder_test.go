@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txbuilder
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/configtx"
+	mockmsp "github.com/hyperledger/fabric/common/mocks/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransaction(t *testing.T) {
+	signer, err := mockmsp.NewNoopMsp().GetDefaultSigningIdentity()
+	require.NoError(t, err)
+
+	spec := &pb.ChaincodeSpec{
+		ChaincodeId: &pb.ChaincodeID{Name: "mycc"},
+		Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte("invoke")}},
+	}
+	_, _, err = NewProposal("mychannel", nil, signer, nil)
+	assert.EqualError(t, err, "nil chaincode spec")
+
+	prop, txID, err := NewProposal("mychannel", spec, signer, map[string][]byte{"key": []byte("value")})
+	require.NoError(t, err)
+	hdr, err := utils.GetHeader(prop.Header)
+	require.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	require.NoError(t, err)
+	assert.Equal(t, "mychannel", chdr.ChannelId)
+	assert.Equal(t, txID, chdr.TxId)
+
+	signedProp, err := SignProposal(prop, signer)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("signature"), signedProp.Signature)
+
+	resp, err := utils.CreateProposalResponse(prop.Header, prop.Payload, &pb.Response{Status: 200}, []byte("results"), nil, spec.ChaincodeId, nil, signer)
+	require.NoError(t, err)
+
+	_, err = NewTransaction(prop, signer, &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: "failed"}})
+	assert.EqualError(t, err, "proposal response 0 is not endorsed: failed")
+
+	env, err := NewTransaction(prop, signer, resp, resp)
+	require.NoError(t, err)
+	tx, err := utils.GetTransaction(utils.UnmarshalPayloadOrPanic(env.Payload).Data)
+	require.NoError(t, err)
+	cap, err := utils.GetChaincodeActionPayload(tx.Actions[0].Payload)
+	require.NoError(t, err)
+	assert.Len(t, cap.Action.Endorsements, 2)
+	// the transient map is not part of the transaction
+	cpp, err := utils.GetChaincodeProposalPayload(cap.ChaincodeProposalPayload)
+	require.NoError(t, err)
+	assert.Nil(t, cpp.TransientMap)
+}
+
+func TestConfigUpdate(t *testing.T) {
+	signer, err := mockmsp.NewNoopMsp().GetDefaultSigningIdentity()
+	require.NoError(t, err)
+
+	original := &cb.Config{ChannelGroup: &cb.ConfigGroup{
+		Values: map[string]*cb.ConfigValue{"foo": {Value: []byte("foo")}},
+	}}
+	updated := &cb.Config{ChannelGroup: &cb.ConfigGroup{
+		Values: map[string]*cb.ConfigValue{"foo": {Value: []byte("bar")}},
+	}}
+
+	_, err = NewConfigUpdate("mychannel", original, original)
+	assert.Error(t, err)
+
+	configUpdate, err := NewConfigUpdate("mychannel", original, updated)
+	require.NoError(t, err)
+	assert.Equal(t, "mychannel", configUpdate.ChannelId)
+	assert.Equal(t, []byte("bar"), configUpdate.WriteSet.Values["foo"].Value)
+
+	configSig, err := SignConfigUpdate(configUpdate, signer)
+	require.NoError(t, err)
+
+	env, err := NewConfigUpdateEnvelope(configUpdate, signer, configSig)
+	require.NoError(t, err)
+	payload := utils.UnmarshalPayloadOrPanic(env.Payload)
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	require.NoError(t, err)
+	assert.Equal(t, int32(cb.HeaderType_CONFIG_UPDATE), chdr.Type)
+	assert.Equal(t, "mychannel", chdr.ChannelId)
+	configUpdateEnv, err := configtx.UnmarshalConfigUpdateEnvelope(payload.Data)
+	require.NoError(t, err)
+	// the signature of the submitter is appended to the collected ones
+	assert.Len(t, configUpdateEnv.Signatures, 2)
+
+	configUpdate.ChannelId = ""
+	_, err = NewConfigUpdateEnvelope(configUpdate, signer)
+	assert.EqualError(t, err, "config update has no channel ID")
+}
+
+func TestNewBlock(t *testing.T) {
+	env := &cb.Envelope{Payload: []byte("payload")}
+	block, err := NewBlock(1, []byte("previous"), env, env)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), block.Header.Number)
+	assert.Equal(t, []byte("previous"), block.Header.PreviousHash)
+	assert.Len(t, block.Data.Data, 2)
+	assert.Equal(t, block.Data.Hash(), block.Header.DataHash)
+	assert.Len(t, block.Metadata.Metadata, len(cb.BlockMetadataIndex_name))
+}
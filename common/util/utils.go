@@ -59,6 +59,19 @@ func ComputeSHA3256(data []byte) (hash []byte) {
 	return
 }
 
+// HashFunction returns the function computing the named channel hashing
+// algorithm, SHA256 or SHA3_256.
+func HashFunction(algorithm string) (func([]byte) []byte, error) {
+	switch algorithm {
+	case bccsp.SHA256:
+		return ComputeSHA256, nil
+	case bccsp.SHA3_256:
+		return ComputeSHA3256, nil
+	default:
+		return nil, fmt.Errorf("Unknown hashing algorithm type: %s", algorithm)
+	}
+}
+
 // GenerateBytesUUID returns a UUID based on RFC 4122 returning the generated bytes
 func GenerateBytesUUID() []byte {
 	uuid := make([]byte, 16)
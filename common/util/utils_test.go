@@ -45,6 +45,19 @@ func TestComputeSHA3256(t *testing.T) {
 	}
 }
 
+func TestHashFunction(t *testing.T) {
+	hash, err := HashFunction("SHA256")
+	assert.NoError(t, err)
+	assert.Equal(t, ComputeSHA256([]byte("foobar")), hash([]byte("foobar")))
+
+	hash, err = HashFunction("SHA3_256")
+	assert.NoError(t, err)
+	assert.Equal(t, ComputeSHA3256([]byte("foobar")), hash([]byte("foobar")))
+
+	_, err = HashFunction("MD5")
+	assert.EqualError(t, err, "Unknown hashing algorithm type: MD5")
+}
+
 func TestUUIDGeneration(t *testing.T) {
 	uuid := GenerateUUID()
 	if len(uuid) != 36 {
@@ -61,6 +61,102 @@ func TestEnvSlice(t *testing.T) {
 	}
 }
 
+type testStructSlice struct {
+	Inner struct {
+		Servers []struct {
+			Host string
+			Port int
+		}
+	}
+}
+
+func TestEnvJSONSlice(t *testing.T) {
+	envVar := "VIPERUTIL_INNER_SERVERS"
+	envVal := `[{"Host": "a", "Port": 1}, {"Host": "b", "Port": 2}]`
+	os.Setenv(envVar, envVal)
+	defer os.Unsetenv(envVar)
+	config := viper.New()
+	config.SetEnvPrefix(Prefix)
+	config.AutomaticEnv()
+	replacer := strings.NewReplacer(".", "_")
+	config.SetEnvKeyReplacer(replacer)
+	config.SetConfigType("yaml")
+
+	data := "---\nInner:\n    Servers:\n        - Host: c\n          Port: 3"
+
+	err := config.ReadConfig(bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatalf("Error reading %s plugin config: %s", Prefix, err)
+	}
+
+	var uconf testStructSlice
+	err = EnhancedExactUnmarshal(config, &uconf)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal with: %s", err)
+	}
+
+	if len(uconf.Inner.Servers) != 2 {
+		t.Fatalf("Expected 2 servers, got %v", uconf.Inner.Servers)
+	}
+	if uconf.Inner.Servers[0].Host != "a" || uconf.Inner.Servers[1].Port != 2 {
+		t.Fatalf("Did not get back the right servers, got %v", uconf.Inner.Servers)
+	}
+}
+
+func TestEnvJSONStringSlice(t *testing.T) {
+	envVar := "VIPERUTIL_INNER_SLICE"
+	envVal := `["a", "b c"]`
+	os.Setenv(envVar, envVal)
+	defer os.Unsetenv(envVar)
+	config := viper.New()
+	config.SetEnvPrefix(Prefix)
+	config.AutomaticEnv()
+	config.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	config.SetConfigType("yaml")
+
+	data := "---\nInner:\n    Slice: [d,e,f]"
+	if err := config.ReadConfig(bytes.NewReader([]byte(data))); err != nil {
+		t.Fatalf("Error reading %s plugin config: %s", Prefix, err)
+	}
+
+	var uconf testSlice
+	if err := EnhancedExactUnmarshal(config, &uconf); err != nil {
+		t.Fatalf("Failed to unmarshal with: %s", err)
+	}
+
+	expected := []string{"a", "b c"}
+	if !reflect.DeepEqual(uconf.Inner.Slice, expected) {
+		t.Fatalf("Did not get back the right slice, expected: %v got %v", expected, uconf.Inner.Slice)
+	}
+}
+
+func TestGetStringSlice(t *testing.T) {
+	defer viper.Reset()
+
+	testCases := []struct {
+		name     string
+		value    interface{}
+		expected []string
+	}{
+		{"native", []interface{}{"a", "b"}, []string{"a", "b"}},
+		{"json", `["a", "b"]`, []string{"a", "b"}},
+		{"brackets", "[a, b]", []string{"a", "b"}},
+		{"spaces", "a  b", []string{"a", "b"}},
+		{"single", "a", []string{"a"}},
+		{"json numbers", "[1, 2]", []string{"1", "2"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Set("viperutil.slice", tc.value)
+			result := GetStringSlice("viperutil.slice")
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
 func TestKafkaVersionDecode(t *testing.T) {
 
 	type testKafkaVersion struct {
@@ -84,9 +84,57 @@ func unmarshalJSON(val interface{}) (map[string]string, bool) {
 	return mp, true
 }
 
+// sliceFromString parses a list supplied as a single string, which is how
+// environment variable overrides reach viper. JSON arrays are decoded as is, so
+// that lists of structures can be overridden too; otherwise strings of the format
+// "[thing1, thing2, thing3]" are split on commas with surrounding whitespace removed.
+func sliceFromString(raw string) (interface{}, bool) {
+	l := len(raw)
+	if l < 2 || raw[0] != '[' || raw[l-1] != ']' {
+		return nil, false
+	}
+
+	var jsonSlice []interface{}
+	if err := json.Unmarshal([]byte(raw), &jsonSlice); err == nil {
+		return jsonSlice, true
+	}
+
+	slice := strings.Split(raw[1:l-1], ",")
+	for i, v := range slice {
+		slice[i] = strings.TrimSpace(v)
+	}
+	return slice, true
+}
+
+// GetStringSlice returns the value associated with key in the global viper
+// instance as a string slice. Unlike viper.GetStringSlice, a string value
+// (typically an environment variable override) may use either JSON array
+// notation or the "[thing1, thing2]" notation accepted by EnhancedExactUnmarshal;
+// any other string is split on whitespace.
+func GetStringSlice(key string) []string {
+	raw, ok := viper.Get(key).(string)
+	if !ok {
+		return viper.GetStringSlice(key)
+	}
+
+	parsed, ok := sliceFromString(strings.TrimSpace(raw))
+	if !ok {
+		return strings.Fields(raw)
+	}
+	switch slice := parsed.(type) {
+	case []string:
+		return slice
+	default:
+		var result []string
+		for _, v := range slice.([]interface{}) {
+			result = append(result, fmt.Sprint(v))
+		}
+		return result
+	}
+}
+
 // customDecodeHook adds the additional functions of parsing durations from strings
-// as well as parsing strings of the format "[thing1, thing2, thing3]" into string slices
-// Note that whitespace around slice elements is removed
+// as well as parsing list strings (see sliceFromString) into slices
 func customDecodeHook() mapstructure.DecodeHookFunc {
 	durationHook := mapstructure.StringToTimeDurationHookFunc()
 	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
@@ -101,13 +149,7 @@ func customDecodeHook() mapstructure.DecodeHookFunc {
 			return data, nil
 		}
 
-		raw := data.(string)
-		l := len(raw)
-		if l > 1 && raw[0] == '[' && raw[l-1] == ']' {
-			slice := strings.Split(raw[1:l-1], ",")
-			for i, v := range slice {
-				slice[i] = strings.TrimSpace(v)
-			}
+		if slice, ok := sliceFromString(data.(string)); ok {
 			return slice, nil
 		}
 
@@ -287,6 +329,15 @@ func kafkaVersionDecodeHook() mapstructure.DecodeHookFunc {
 // EnhancedExactUnmarshal is intended to unmarshal a config file into a structure
 // producing error when extraneous variables are introduced and supporting
 // the time.Duration type
+//
+// Values are resolved with the following precedence, highest first:
+//	1. environment variables (when AutomaticEnv is enabled on v)
+//	2. the config file
+//	3. defaults registered on v
+// A list-typed setting is always replaced as a whole; there is no merging of
+// list elements across sources. To override a list from the environment, use
+// a JSON array (e.g. '["kafka0:9092","kafka1:9092"]', or '[{"Host":"a"}]' for
+// lists of structures) or the "[kafka0:9092, kafka1:9092]" notation.
 func EnhancedExactUnmarshal(v *viper.Viper, output interface{}) error {
 	// AllKeys doesn't actually return all keys, it only returns the base ones
 	baseKeys := v.AllSettings()
@@ -0,0 +1,229 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package viperutil
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+// ReloadSection identifies a subtree of the configuration that can be
+// changed without restarting the process.
+type ReloadSection struct {
+	// Key is the fully qualified key of the subtree, e.g. "peer.keepalive.client".
+	Key string
+
+	// Apply, when set, is invoked with the reloaded configuration before the
+	// changed values are committed to the live configuration. Sections whose
+	// values are read on demand need no Apply function.
+	Apply func(v *viper.Viper) error
+}
+
+// ReloadStatus is the outcome of a configuration reload.
+type ReloadStatus struct {
+	Time time.Time
+	// Applied lists the keys whose new values are in effect.
+	Applied []string
+	// RequiresRestart lists the keys that changed but are only read at startup.
+	RequiresRestart []string
+	// Err is set if the configuration could not be reloaded, or if a section
+	// failed to apply, in which case its keys are reported as requiring a restart.
+	Err error
+}
+
+// LiveConfig is the configuration in use by a process, typically a viper
+// instance or the global viper functions.
+type LiveConfig interface {
+	Get(key string) interface{}
+	Set(key string, value interface{})
+}
+
+// ReloadableConfig is a LiveConfig which keeps the reloaded values apart from
+// the global viper, since viper cannot be written while other goroutines read
+// it. Values which were not reloaded are read from the global viper, which is
+// only written before the process starts serving.
+type ReloadableConfig struct {
+	mutex  sync.RWMutex
+	values map[string]interface{}
+}
+
+// Reloadable holds the reloaded values of the process. Keys which can be
+// reloaded must be read through it rather than through the global viper.
+var Reloadable = &ReloadableConfig{}
+
+// Get returns the reloaded value of the key, or its value in the global viper.
+func (c *ReloadableConfig) Get(key string) interface{} {
+	c.mutex.RLock()
+	value, ok := c.values[strings.ToLower(key)]
+	c.mutex.RUnlock()
+	if ok {
+		return value
+	}
+	return viper.Get(key)
+}
+
+// Set records the reloaded value of the key.
+func (c *ReloadableConfig) Set(key string, value interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.values == nil {
+		c.values = map[string]interface{}{}
+	}
+	c.values[strings.ToLower(key)] = value
+}
+
+// IsSet reports whether the key was reloaded or is set in the global viper.
+func (c *ReloadableConfig) IsSet(key string) bool {
+	c.mutex.RLock()
+	_, ok := c.values[strings.ToLower(key)]
+	c.mutex.RUnlock()
+	return ok || viper.IsSet(key)
+}
+
+// GetBool returns the value of the key as a bool.
+func (c *ReloadableConfig) GetBool(key string) bool {
+	return cast.ToBool(c.Get(key))
+}
+
+// GetInt returns the value of the key as an int.
+func (c *ReloadableConfig) GetInt(key string) int {
+	return cast.ToInt(c.Get(key))
+}
+
+// GetDuration returns the value of the key as a duration.
+func (c *ReloadableConfig) GetDuration(key string) time.Duration {
+	return cast.ToDuration(c.Get(key))
+}
+
+// UnmarshalKey decodes the value of the key into rawVal, as viper does.
+func (c *ReloadableConfig) UnmarshalKey(key string, rawVal interface{}) error {
+	return mapstructure.Decode(c.Get(key), rawVal)
+}
+
+// Reset drops the reloaded values.
+func (c *ReloadableConfig) Reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.values = nil
+}
+
+// Reloader re-reads a configuration and applies the changed values of its
+// reloadable sections to the live configuration.
+type Reloader struct {
+	Live LiveConfig
+	// Load returns a freshly read copy of the configuration.
+	Load     func() (*viper.Viper, error)
+	Sections []ReloadSection
+
+	mutex  sync.Mutex
+	status ReloadStatus
+}
+
+// Reload reads the configuration, applies the reloadable changes, and
+// records the result as the latest status.
+func (r *Reloader) Reload() ReloadStatus {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	status := ReloadStatus{Time: time.Now()}
+	defer func() { r.status = status }()
+
+	fresh, err := r.Load()
+	if err != nil {
+		status.Err = errors.WithMessage(err, "failed reloading configuration")
+		return status
+	}
+
+	changed := map[*ReloadSection][]string{}
+	for _, key := range leafKeys(fresh) {
+		if reflect.DeepEqual(r.Live.Get(key), fresh.Get(key)) {
+			continue
+		}
+		section := r.sectionFor(key)
+		if section == nil {
+			status.RequiresRestart = append(status.RequiresRestart, key)
+			continue
+		}
+		changed[section] = append(changed[section], key)
+	}
+
+	for i := range r.Sections {
+		section := &r.Sections[i]
+		keys := changed[section]
+		if len(keys) == 0 {
+			continue
+		}
+		if section.Apply != nil {
+			if err := section.Apply(fresh); err != nil {
+				status.Err = errors.WithMessage(err, "failed applying "+section.Key)
+				status.RequiresRestart = append(status.RequiresRestart, keys...)
+				continue
+			}
+		}
+		for _, key := range keys {
+			r.Live.Set(key, fresh.Get(key))
+		}
+		status.Applied = append(status.Applied, keys...)
+	}
+
+	sort.Strings(status.Applied)
+	sort.Strings(status.RequiresRestart)
+	return status
+}
+
+// Status returns the outcome of the latest reload.
+func (r *Reloader) Status() ReloadStatus {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.status
+}
+
+// leafKeys returns the fully qualified keys of all scalar and list values in v.
+// Nested keys retain their case, since viper only looks them up case sensitively.
+func leafKeys(v *viper.Viper) []string {
+	var keys []string
+	var walk func(prefix string, value interface{})
+	walk = func(prefix string, value interface{}) {
+		switch m := value.(type) {
+		case map[string]interface{}:
+			for k, child := range m {
+				walk(prefix+k+".", child)
+			}
+		case map[interface{}]interface{}:
+			for k, child := range m {
+				walk(prefix+fmt.Sprint(k)+".", child)
+			}
+		default:
+			keys = append(keys, strings.TrimSuffix(prefix, "."))
+		}
+	}
+	for key, value := range v.AllSettings() {
+		walk(key+".", value)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (r *Reloader) sectionFor(key string) *ReloadSection {
+	key = strings.ToLower(key)
+	for i := range r.Sections {
+		sectionKey := strings.ToLower(r.Sections[i].Key)
+		if key == sectionKey || strings.HasPrefix(key, sectionKey+".") {
+			return &r.Sections[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package viperutil
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func yamlViper(t *testing.T, data string) *viper.Viper {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	require.NoError(t, v.ReadConfig(bytes.NewReader([]byte(data))))
+	return v
+}
+
+const reloadBaseConfig = `
+logging:
+    level: info
+keepalive:
+    interval: 10s
+gossip:
+    fanout: 3
+`
+
+func TestReload(t *testing.T) {
+	live := yamlViper(t, reloadBaseConfig)
+	var applied *viper.Viper
+	r := &Reloader{
+		Live: live,
+		Load: func() (*viper.Viper, error) {
+			return yamlViper(t, "logging:\n    level: debug\nkeepalive:\n    interval: 20s\ngossip:\n    fanout: 5\n"), nil
+		},
+		Sections: []ReloadSection{
+			{Key: "logging", Apply: func(v *viper.Viper) error { applied = v; return nil }},
+			{Key: "keepAlive"},
+		},
+	}
+
+	assert.Equal(t, ReloadStatus{}, r.Status())
+	status := r.Reload()
+	assert.NoError(t, status.Err)
+	assert.False(t, status.Time.IsZero())
+	assert.Equal(t, []string{"keepalive.interval", "logging.level"}, status.Applied)
+	assert.Equal(t, []string{"gossip.fanout"}, status.RequiresRestart)
+	assert.Equal(t, status, r.Status())
+
+	require.NotNil(t, applied)
+	assert.Equal(t, "debug", applied.GetString("logging.level"))
+	assert.Equal(t, "debug", live.GetString("logging.level"))
+	assert.Equal(t, "20s", live.GetString("keepalive.interval"))
+	assert.Equal(t, 3, live.GetInt("gossip.fanout"), "values requiring a restart must not be committed")
+}
+
+func TestReloadNoChanges(t *testing.T) {
+	r := &Reloader{
+		Live: yamlViper(t, reloadBaseConfig),
+		Load: func() (*viper.Viper, error) {
+			return yamlViper(t, reloadBaseConfig), nil
+		},
+		Sections: []ReloadSection{
+			{Key: "logging", Apply: func(*viper.Viper) error { panic("should not be called") }},
+		},
+	}
+	status := r.Reload()
+	assert.NoError(t, status.Err)
+	assert.Empty(t, status.Applied)
+	assert.Empty(t, status.RequiresRestart)
+}
+
+func TestReloadLoadFailure(t *testing.T) {
+	r := &Reloader{
+		Live: yamlViper(t, reloadBaseConfig),
+		Load: func() (*viper.Viper, error) {
+			return nil, errors.New("no such file")
+		},
+	}
+	status := r.Reload()
+	assert.EqualError(t, status.Err, "failed reloading configuration: no such file")
+}
+
+func TestReloadApplyFailure(t *testing.T) {
+	live := yamlViper(t, reloadBaseConfig)
+	r := &Reloader{
+		Live: live,
+		Load: func() (*viper.Viper, error) {
+			return yamlViper(t, "logging:\n    level: bogus\nkeepalive:\n    interval: 20s\n"), nil
+		},
+		Sections: []ReloadSection{
+			{Key: "logging", Apply: func(*viper.Viper) error { return errors.New("invalid spec") }},
+			{Key: "keepalive"},
+		},
+	}
+	status := r.Reload()
+	assert.EqualError(t, status.Err, "failed applying logging: invalid spec")
+	assert.Equal(t, []string{"keepalive.interval"}, status.Applied)
+	assert.Equal(t, []string{"logging.level"}, status.RequiresRestart)
+	assert.Equal(t, "info", live.GetString("logging.level"))
+}
+
+func TestReloadableConfig(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("peer.gossip.connTimeout", "2s")
+	viper.Set("ledger.state.chaincodeQueryLimits", []interface{}{map[interface{}]interface{}{"chaincode": "mycc", "totalQueryLimit": 10}})
+
+	c := &ReloadableConfig{}
+	assert.Equal(t, 2*time.Second, c.GetDuration("peer.gossip.connTimeout"))
+	assert.False(t, c.IsSet("peer.gossip.nonBlockingCommitMode"))
+
+	c.Set("peer.gossip.connTimeout", "5s")
+	c.Set("peer.gossip.nonBlockingCommitMode", true)
+	c.Set("ledger.state.totalQueryLimit", "50")
+	assert.Equal(t, 5*time.Second, c.GetDuration("peer.gossip.conntimeout"))
+	assert.True(t, c.IsSet("peer.gossip.nonBlockingCommitMode"))
+	assert.True(t, c.GetBool("peer.gossip.nonBlockingCommitMode"))
+	assert.Equal(t, 50, c.GetInt("ledger.state.totalQueryLimit"))
+	assert.Equal(t, "2s", viper.GetString("peer.gossip.connTimeout"), "the global viper must not be written")
+
+	var limits []struct {
+		Chaincode       string
+		TotalQueryLimit int `mapstructure:"totalQueryLimit"`
+	}
+	require.NoError(t, c.UnmarshalKey("ledger.state.chaincodeQueryLimits", &limits))
+	require.Len(t, limits, 1)
+	assert.Equal(t, 10, limits[0].TotalQueryLimit)
+
+	c.Reset()
+	assert.Equal(t, 2*time.Second, c.GetDuration("peer.gossip.connTimeout"))
+}
+
+func TestReloadableConfigConcurrentReload(t *testing.T) {
+	c := &ReloadableConfig{}
+	r := &Reloader{
+		Live: c,
+		Load: func() (*viper.Viper, error) {
+			return yamlViper(t, "keepalive:\n    interval: 20s\n"), nil
+		},
+		Sections: []ReloadSection{{Key: "keepalive"}},
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			r.Reload()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			c.GetDuration("keepalive.interval")
+		}
+	}()
+	wg.Wait()
+	assert.Equal(t, 20*time.Second, c.GetDuration("keepalive.interval"))
+}
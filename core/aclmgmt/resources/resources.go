@@ -23,11 +23,12 @@ const (
 	Lscc_GetCollectionsConfig      = "lscc/GetCollectionsConfig"
 
 	//Qscc resources
-	Qscc_GetChainInfo       = "qscc/GetChainInfo"
-	Qscc_GetBlockByNumber   = "qscc/GetBlockByNumber"
-	Qscc_GetBlockByHash     = "qscc/GetBlockByHash"
-	Qscc_GetTransactionByID = "qscc/GetTransactionByID"
-	Qscc_GetBlockByTxID     = "qscc/GetBlockByTxID"
+	Qscc_GetChainInfo        = "qscc/GetChainInfo"
+	Qscc_GetBlockByNumber    = "qscc/GetBlockByNumber"
+	Qscc_GetBlockByHash      = "qscc/GetBlockByHash"
+	Qscc_GetTransactionByID  = "qscc/GetTransactionByID"
+	Qscc_GetBlockByTxID      = "qscc/GetBlockByTxID"
+	Qscc_GetStateAttestation = "qscc/GetStateAttestation"
 
 	//Cscc resources
 	Cscc_JoinChain                = "cscc/JoinChain"
@@ -43,4 +44,5 @@ const (
 	//Events
 	Event_Block         = "event/Block"
 	Event_FilteredBlock = "event/FilteredBlock"
+	Event_BlockData     = "event/BlockData"
 )
@@ -32,13 +32,47 @@ type AccessControlEvaluator interface {
 	Evaluate(signatureSet []*common.SignedData) error
 }
 
+// ReloadStatusProvider supplies the outcome of the latest configuration reload
+type ReloadStatusProvider interface {
+	// ReloadStatus returns the outcome of the latest configuration reload
+	ReloadStatus() *pb.ConfigReloadStatus
+}
+
+// MembershipProvider supplies the gossip membership view of the peer
+type MembershipProvider interface {
+	// Membership returns the membership view of the given channel, or of
+	// the whole network if channel is empty
+	Membership(channel string) (*pb.GossipMembership, error)
+}
+
+// ChannelStatusProvider supplies the channels the peer stopped processing
+type ChannelStatusProvider interface {
+	// StalledChannels returns the channels the peer stopped processing,
+	// along with the reason why
+	StalledChannels() []*pb.StalledChannel
+}
+
+// ChannelStatusProviderFunc is a function implementing ChannelStatusProvider
+type ChannelStatusProviderFunc func() []*pb.StalledChannel
+
+// StalledChannels returns the channels the peer stopped processing
+func (f ChannelStatusProviderFunc) StalledChannels() []*pb.StalledChannel {
+	return f()
+}
+
 // NewAdminServer creates and returns a Admin service instance.
-func NewAdminServer(ace AccessControlEvaluator) *ServerAdmin {
+// The ReloadStatusProvider may be nil if configuration reload is not supported,
+// the MembershipProvider may be nil if the peer does not run gossip, and the
+// ChannelStatusProvider may be nil if the peer does not process channels.
+func NewAdminServer(ace AccessControlEvaluator, rsp ReloadStatusProvider, mp MembershipProvider, csp ChannelStatusProvider) *ServerAdmin {
 	s := &ServerAdmin{
 		v: &validator{
 			ace: ace,
 		},
 		levelsAtStartup: flogging.GetModuleLevels(),
+		reloadStatus:    rsp,
+		membership:      mp,
+		channelStatus:   csp,
 	}
 	return s
 }
@@ -48,6 +82,9 @@ type ServerAdmin struct {
 	v requestValidator
 
 	levelsAtStartup map[string]zapcore.Level
+	reloadStatus    ReloadStatusProvider
+	membership      MembershipProvider
+	channelStatus   ChannelStatusProvider
 }
 
 func (s *ServerAdmin) GetStatus(ctx context.Context, env *common.Envelope) (*pb.ServerStatus, error) {
@@ -55,6 +92,9 @@ func (s *ServerAdmin) GetStatus(ctx context.Context, env *common.Envelope) (*pb.
 		return nil, err
 	}
 	status := &pb.ServerStatus{Status: pb.ServerStatus_STARTED}
+	if s.channelStatus != nil {
+		status.StalledChannels = s.channelStatus.StalledChannels()
+	}
 	logger.Debugf("returning status: %s", status)
 	return status, nil
 }
@@ -103,3 +143,24 @@ func (s *ServerAdmin) RevertLogLevels(ctx context.Context, env *common.Envelope)
 	flogging.RestoreLevels(s.levelsAtStartup)
 	return &empty.Empty{}, nil
 }
+
+func (s *ServerAdmin) GetConfigReloadStatus(ctx context.Context, env *common.Envelope) (*pb.ConfigReloadStatus, error) {
+	if _, err := s.v.validate(ctx, env); err != nil {
+		return nil, err
+	}
+	if s.reloadStatus == nil {
+		return nil, errors.New("configuration reload is not supported")
+	}
+	return s.reloadStatus.ReloadStatus(), nil
+}
+
+func (s *ServerAdmin) GetGossipMembership(ctx context.Context, env *common.Envelope) (*pb.GossipMembership, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	if s.membership == nil {
+		return nil, errors.New("gossip membership is not available")
+	}
+	return s.membership.Membership(op.GetMembershipReq().GetChannel())
+}
@@ -8,6 +8,7 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/hyperledger/fabric/common/flogging"
@@ -35,17 +36,28 @@ func (v *mockValidator) validate(ctx context.Context, env *common.Envelope) (*pb
 }
 
 func TestGetStatus(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil, nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	mv.On("validate").Return(nil, nil).Once()
 	response, err := adminServer.GetStatus(context.Background(), nil)
 	assert.NotNil(t, response, "Response should have been set")
 	assert.Nil(t, err, "Error should have been nil")
+	assert.Empty(t, response.StalledChannels)
+
+	stalled := []*pb.StalledChannel{{ChannelId: "mychannel", Reason: "missing capability"}}
+	adminServer.channelStatus = ChannelStatusProviderFunc(func() []*pb.StalledChannel {
+		return stalled
+	})
+	mv.On("validate").Return(nil, nil).Once()
+	response, err = adminServer.GetStatus(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.ServerStatus_STARTED, response.Status)
+	assert.Equal(t, stalled, response.StalledChannels)
 }
 
 func TestStartServer(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil, nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	mv.On("validate").Return(nil, nil).Once()
@@ -55,7 +67,7 @@ func TestStartServer(t *testing.T) {
 }
 
 func TestForbidden(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil, nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	mv.On("validate").Return(nil, accessDenied).Times(5)
@@ -81,7 +93,7 @@ func TestForbidden(t *testing.T) {
 }
 
 func TestLoggingCalls(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil, nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	flogging.MustGetLogger("test")
@@ -130,3 +142,77 @@ func TestLoggingCalls(t *testing.T) {
 	assert.Equal(t, flogging.DefaultLevel(), logResponse.LogLevel, "logger level should have been the default")
 	assert.Nil(t, err, "Error should have been nil")
 }
+
+type mockReloadStatus struct {
+	status *pb.ConfigReloadStatus
+}
+
+func (m *mockReloadStatus) ReloadStatus() *pb.ConfigReloadStatus {
+	return m.status
+}
+
+func TestGetConfigReloadStatus(t *testing.T) {
+	adminServer := NewAdminServer(nil, nil, nil, nil)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+
+	mv.On("validate").Return(nil, nil).Once()
+	_, err := adminServer.GetConfigReloadStatus(context.Background(), nil)
+	assert.EqualError(t, err, "configuration reload is not supported")
+
+	status := &pb.ConfigReloadStatus{Applied: []string{"logging.level"}}
+	adminServer.reloadStatus = &mockReloadStatus{status: status}
+	mv.On("validate").Return(nil, nil).Once()
+	response, err := adminServer.GetConfigReloadStatus(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, status, response)
+
+	mv.On("validate").Return(nil, errors.New("forbidden")).Once()
+	_, err = adminServer.GetConfigReloadStatus(context.Background(), nil)
+	assert.EqualError(t, err, "forbidden")
+}
+
+type mockMembership struct {
+	channels []string
+}
+
+func (m *mockMembership) Membership(channel string) (*pb.GossipMembership, error) {
+	m.channels = append(m.channels, channel)
+	if channel == "unknown" {
+		return nil, errors.New("peer is not part of channel unknown")
+	}
+	return &pb.GossipMembership{Channel: channel}, nil
+}
+
+func TestGetGossipMembership(t *testing.T) {
+	adminServer := NewAdminServer(nil, nil, nil, nil)
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+
+	mv.On("validate").Return(nil, nil).Once()
+	_, err := adminServer.GetGossipMembership(context.Background(), nil)
+	assert.EqualError(t, err, "gossip membership is not available")
+
+	mp := &mockMembership{}
+	adminServer.membership = mp
+	mv.On("validate").Return(nil, nil).Once()
+	response, err := adminServer.GetGossipMembership(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.GossipMembership{}, response)
+
+	op := &pb.AdminOperation{Content: &pb.AdminOperation_MembershipReq{MembershipReq: &pb.GossipMembershipRequest{Channel: "mychannel"}}}
+	mv.On("validate").Return(op, nil).Once()
+	response, err = adminServer.GetGossipMembership(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "mychannel", response.Channel)
+
+	op = &pb.AdminOperation{Content: &pb.AdminOperation_MembershipReq{MembershipReq: &pb.GossipMembershipRequest{Channel: "unknown"}}}
+	mv.On("validate").Return(op, nil).Once()
+	_, err = adminServer.GetGossipMembership(context.Background(), nil)
+	assert.EqualError(t, err, "peer is not part of channel unknown")
+	assert.Equal(t, []string{"", "mychannel", "unknown"}, mp.channels)
+
+	mv.On("validate").Return(nil, errors.New("forbidden")).Once()
+	_, err = adminServer.GetGossipMembership(context.Background(), nil)
+	assert.EqualError(t, err, "forbidden")
+}
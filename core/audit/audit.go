@@ -0,0 +1,227 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package audit
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/util"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("audit")
+
+// reportInterval is the number of blocks after which the report of a channel
+// is written while the auditor catches up with its ledger
+const reportInterval = 1000
+
+// Ledger is the part of the ledger of a channel read by the auditor
+type Ledger interface {
+	// GetBlockchainInfo returns basic info about the blockchain
+	GetBlockchainInfo() (*common.BlockchainInfo, error)
+	// GetBlocksIterator returns a blocking iterator over the blocks starting
+	// from the given block
+	GetBlocksIterator(startBlockNumber uint64) (commonledger.ResultsIterator, error)
+}
+
+// InvalidTransaction is a transaction invalidated by the peer
+type InvalidTransaction struct {
+	BlockNumber    uint64 `json:"block_number"`
+	TxID           string `json:"tx_id"`
+	ValidationCode string `json:"validation_code"`
+}
+
+// Report is the conformance report of a channel, written as JSON to the
+// report directory of the auditor
+type Report struct {
+	ChannelID        string `json:"channel_id"`
+	Height           uint64 `json:"height"`
+	CurrentBlockHash string `json:"current_block_hash"`
+	Transactions     uint64 `json:"transactions"`
+	// ValidationCodes counts the transactions by validation code
+	ValidationCodes map[string]uint64 `json:"validation_codes"`
+	// InvalidTransactions holds the first invalid transactions
+	InvalidTransactions []InvalidTransaction `json:"invalid_transactions"`
+	// Violations describes the blocks breaking the hash chain
+	Violations []string `json:"violations"`
+	Conformant bool     `json:"conformant"`
+}
+
+// Auditor reports on the blocks of the ledgers of the peer. The blocks are
+// validated and committed by the peer as usual, which replays all the
+// transactions of a channel when it joins it, recomputes the state and checks
+// the endorsements against the policies in force when the transactions were
+// ordered. The auditor checks the hash chain of the blocks and tallies the
+// results of their validation.
+type Auditor struct {
+	// ReportDir is the directory the reports are written to
+	ReportDir string
+	// MaxInvalidTransactions is the number of invalid transactions listed by
+	// the reports
+	MaxInvalidTransactions int
+}
+
+// Audit reports on the blocks of the ledger of the given channel, as they are
+// committed, until done is closed
+func (a *Auditor) Audit(channelID string, l Ledger, done <-chan struct{}) error {
+	itr, err := l.GetBlocksIterator(0)
+	if err != nil {
+		return errors.WithMessage(err, "failed to iterate over the blocks of channel "+channelID)
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-done:
+		case <-stop:
+		}
+		itr.Close()
+	}()
+
+	ca := &channelAudit{
+		maxInvalidTransactions: a.MaxInvalidTransactions,
+		report: &Report{
+			ChannelID:       channelID,
+			ValidationCodes: map[string]uint64{},
+			Conformant:      true,
+		},
+	}
+	report := ca.report
+	for {
+		result, err := itr.Next()
+		if err != nil || result == nil {
+			select {
+			case <-done:
+				return nil
+			default:
+			}
+			if err == nil {
+				err = errors.New("the iterator was closed")
+			}
+			return errors.WithMessage(err, "failed to read the blocks of channel "+channelID)
+		}
+		ca.check(result.(*common.Block))
+
+		info, err := l.GetBlockchainInfo()
+		if err != nil {
+			return errors.WithMessage(err, "failed to get the height of channel "+channelID)
+		}
+		if report.Height >= info.Height || report.Height%reportInterval == 0 {
+			if err := a.write(report); err != nil {
+				logger.Warningf("Failed writing the report of channel %s: %s", channelID, err)
+			}
+		}
+	}
+}
+
+// channelAudit holds the report of a channel along with what is needed to
+// check its next block
+type channelAudit struct {
+	maxInvalidTransactions int
+	report                 *Report
+	currentBlockHash       []byte
+	hashingAlgorithm       func([]byte) []byte
+}
+
+// check adds the given block, which should follow the last block checked, to
+// the report
+func (ca *channelAudit) check(block *common.Block) {
+	report := ca.report
+	number := block.Header.Number
+	if number != report.Height {
+		ca.violation("block %d was read instead of block %d", number, report.Height)
+	}
+	if number == 0 {
+		hashingAlgorithm, err := utils.GetHashingAlgorithmFromBlock(block)
+		if err != nil {
+			ca.violation("the hashing algorithm of the channel cannot be read from block 0: %s", err)
+			hashingAlgorithm = util.ComputeSHA256
+		}
+		ca.hashingAlgorithm = hashingAlgorithm
+	} else if !bytes.Equal(block.Header.PreviousHash, ca.currentBlockHash) {
+		ca.violation("block %d does not hold the hash of block %d", number, number-1)
+	}
+	if !bytes.Equal(block.Header.DataHash, block.Data.HashWith(ca.hashingAlgorithm)) {
+		ca.violation("block %d does not hold the hash of its data", number)
+	}
+	ca.currentBlockHash = block.Header.HashWith(ca.hashingAlgorithm)
+	report.Height = number + 1
+	report.CurrentBlockHash = hex.EncodeToString(ca.currentBlockHash)
+
+	var flags ledgerutil.TxValidationFlags
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		flags = ledgerutil.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+	for i, envBytes := range block.Data.Data {
+		code := peer.TxValidationCode_NOT_VALIDATED
+		if i < len(flags) {
+			code = flags.Flag(i)
+		}
+		report.Transactions++
+		report.ValidationCodes[code.String()]++
+		if code == peer.TxValidationCode_VALID || len(report.InvalidTransactions) >= ca.maxInvalidTransactions {
+			continue
+		}
+		report.InvalidTransactions = append(report.InvalidTransactions, InvalidTransaction{
+			BlockNumber:    number,
+			TxID:           txID(envBytes),
+			ValidationCode: code.String(),
+		})
+	}
+}
+
+func (ca *channelAudit) violation(format string, args ...interface{}) {
+	ca.report.Violations = append(ca.report.Violations, fmt.Sprintf(format, args...))
+	ca.report.Conformant = false
+}
+
+// txID returns the id of the transaction of the given envelope, if any
+func txID(envBytes []byte) string {
+	env, err := utils.GetEnvelopeFromBlock(envBytes)
+	if err != nil {
+		return ""
+	}
+	payload, err := utils.GetPayload(env)
+	if err != nil || payload.Header == nil {
+		return ""
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return ""
+	}
+	return chdr.TxId
+}
+
+// write writes the report to the report directory, replacing the previous
+// report of the channel
+func (a *Auditor) write(report *Report) error {
+	if err := os.MkdirAll(a.ReportDir, 0755); err != nil {
+		return err
+	}
+	reportBytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(a.ReportDir, report.ChannelID+".json")
+	if err := ioutil.WriteFile(path+".tmp", reportBytes, 0644); err != nil {
+		return err
+	}
+	logger.Debugf("Channel %s audited up to block %d, conformant: %t", report.ChannelID, report.Height-1, report.Conformant)
+	return os.Rename(path+".tmp", path)
+}
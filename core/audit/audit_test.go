@@ -0,0 +1,125 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package audit
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type blocksIterator struct {
+	blocks  []*common.Block
+	drained chan struct{}
+	closed  chan struct{}
+}
+
+func (itr *blocksIterator) Next() (commonledger.QueryResult, error) {
+	if len(itr.blocks) == 0 {
+		close(itr.drained)
+		<-itr.closed
+		return nil, nil
+	}
+	block := itr.blocks[0]
+	itr.blocks = itr.blocks[1:]
+	return block, nil
+}
+
+func (itr *blocksIterator) Close() {
+	close(itr.closed)
+}
+
+type testLedger struct {
+	blocks []*common.Block
+	itr    *blocksIterator
+}
+
+func (l *testLedger) GetBlockchainInfo() (*common.BlockchainInfo, error) {
+	return &common.BlockchainInfo{Height: uint64(len(l.blocks))}, nil
+}
+
+func (l *testLedger) GetBlocksIterator(startBlockNumber uint64) (commonledger.ResultsIterator, error) {
+	l.itr.blocks = l.blocks[startBlockNumber:]
+	return l.itr, nil
+}
+
+func audit(t *testing.T, blocks []*common.Block) *Report {
+	reportDir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(reportDir)
+
+	auditor := &Auditor{ReportDir: reportDir, MaxInvalidTransactions: 1}
+	l := &testLedger{
+		blocks: blocks,
+		itr:    &blocksIterator{drained: make(chan struct{}), closed: make(chan struct{})},
+	}
+	done := make(chan struct{})
+	audited := make(chan error)
+	go func() {
+		audited <- auditor.Audit("mychannel", l, done)
+	}()
+	// the report is written once the auditor catches up with the ledger
+	<-l.itr.drained
+	close(done)
+	assert.NoError(t, <-audited)
+
+	reportBytes, err := ioutil.ReadFile(filepath.Join(reportDir, "mychannel.json"))
+	require.NoError(t, err)
+	report := &Report{}
+	require.NoError(t, json.Unmarshal(reportBytes, report))
+	return report
+}
+
+func TestAudit(t *testing.T) {
+	bg, gb := testutil.NewBlockGenerator(t, "mychannel", false)
+	block1 := bg.NextBlockWithTxid([][]byte{[]byte("rwset1"), []byte("rwset2"), []byte("rwset3")}, []string{"tx1", "tx2", "tx3"})
+	flags := ledgerutil.NewTxValidationFlagsSetValue(3, peer.TxValidationCode_VALID)
+	flags.SetFlag(1, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	flags.SetFlag(2, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+	block1.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = flags
+	block2 := bg.NextBlockWithTxid([][]byte{[]byte("rwset4")}, []string{"tx4"})
+	block2.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = ledgerutil.NewTxValidationFlagsSetValue(1, peer.TxValidationCode_VALID)
+
+	report := audit(t, []*common.Block{gb, block1, block2})
+	assert.Equal(t, &Report{
+		ChannelID:        "mychannel",
+		Height:           3,
+		CurrentBlockHash: hex.EncodeToString(block2.Header.Hash()),
+		Transactions:     5,
+		ValidationCodes: map[string]uint64{
+			"VALID":                      3,
+			"MVCC_READ_CONFLICT":         1,
+			"ENDORSEMENT_POLICY_FAILURE": 1,
+		},
+		InvalidTransactions: []InvalidTransaction{
+			{BlockNumber: 1, TxID: "tx2", ValidationCode: "MVCC_READ_CONFLICT"},
+		},
+		Conformant: true,
+	}, report)
+
+	// the blocks breaking the hash chain are reported
+	block2.Header.PreviousHash = []byte("tampered")
+	block2.Data.Data[0] = []byte("tampered")
+	report = audit(t, []*common.Block{gb, block1, block2})
+	assert.False(t, report.Conformant)
+	assert.Equal(t, []string{
+		"block 2 does not hold the hash of block 1",
+		"block 2 does not hold the hash of its data",
+	}, report.Violations)
+	assert.Equal(t, uint64(5), report.Transactions)
+}
@@ -0,0 +1,196 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package certexpiry
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/viperutil"
+	"github.com/spf13/viper"
+)
+
+const (
+	certDaysToExpiry    = "cert_days_to_expiry"
+	minCertDaysToExpiry = "min_cert_days_to_expiry"
+
+	defaultCheckInterval = time.Hour
+)
+
+var defaultWarningDays = []int{30, 7, 1}
+
+var logger = flogging.MustGetLogger("certexpiry")
+
+// Opts contains the configuration of the monitoring of the certificates
+type Opts struct {
+	// CheckInterval is the interval between two checks of the certificates
+	CheckInterval time.Duration
+	// WarningDays are the numbers of days before the expiration of a
+	// certificate at which a warning is logged, in decreasing order
+	WarningDays []int
+}
+
+// NewOpts creates the certificate monitoring options of the peer based on the
+// config file
+func NewOpts() Opts {
+	opts := Opts{}
+	if checkInterval := viper.GetDuration("peer.certExpiry.checkInterval"); checkInterval > 0 {
+		opts.CheckInterval = checkInterval
+	} else {
+		opts.CheckInterval = defaultCheckInterval
+	}
+	for _, d := range viperutil.GetStringSlice("peer.certExpiry.warningDays") {
+		days, err := strconv.Atoi(d)
+		if err != nil || days <= 0 {
+			logger.Warningf("Ignoring invalid value [%s] of peer.certExpiry.warningDays, it must be a positive number of days", d)
+			continue
+		}
+		opts.WarningDays = append(opts.WarningDays, days)
+	}
+	if len(opts.WarningDays) == 0 {
+		opts.WarningDays = defaultWarningDays
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(opts.WarningDays)))
+	return opts
+}
+
+// Cert describes a monitored certificate
+type Cert struct {
+	// Kind is the role of the certificate, such as "signing" or "tls_ca"
+	Kind string
+	// Source is where the certificate comes from, either "local" for the
+	// certificates of the peer or the ID of the channel whose config holds it
+	Source string
+	// MSPID is the ID of the MSP the certificate belongs to, if any
+	MSPID string
+	// Subject is the common name of the subject of the certificate
+	Subject string
+	// Serial is the serial number of the certificate, in hexadecimal
+	Serial string
+	// NotAfter is the time at which the certificate expires
+	NotAfter time.Time
+}
+
+func (c *Cert) String() string {
+	s := fmt.Sprintf("%s certificate [%s] (serial %s)", c.Kind, c.Subject, c.Serial)
+	if c.MSPID != "" {
+		s += fmt.Sprintf(" of MSP %s", c.MSPID)
+	}
+	if c.Source != LocalSource {
+		s += fmt.Sprintf(" in the config of channel %s", c.Source)
+	}
+	return s
+}
+
+// key identifies the certificate in the warnings already logged
+func (c *Cert) key() string {
+	return c.Kind + "/" + c.Source + "/" + c.MSPID + "/" + c.Serial
+}
+
+// Source supplies certificates to monitor
+type Source func() ([]*Cert, error)
+
+// Monitor periodically reports, through metrics, the number of days left
+// before the expiration of the certificates supplied by its sources, and logs
+// a warning each time a certificate gets within one of the warning thresholds
+// of its expiration
+type Monitor struct {
+	opts    Opts
+	sources []Source
+	scope   metrics.Scope
+	now     func() time.Time
+	// warned holds the smallest threshold, in days, a warning was logged at
+	// for each certificate; 0 once the expiration of the certificate is logged
+	warned map[string]int
+}
+
+// NewMonitor creates a Monitor of the certificates supplied by the given
+// sources, reporting its metrics to the given scope
+func NewMonitor(opts Opts, scope metrics.Scope, sources ...Source) *Monitor {
+	return &Monitor{
+		opts:    opts,
+		sources: sources,
+		scope:   scope,
+		now:     time.Now,
+		warned:  make(map[string]int),
+	}
+}
+
+// Run checks the certificates right away and then at each check interval,
+// until the stop channel is closed
+func (m *Monitor) Run(stop <-chan struct{}) {
+	m.Check()
+	ticker := time.NewTicker(m.opts.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.Check()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Check reports the number of days left before the expiration of each of the
+// certificates, and logs the certificates that crossed a warning threshold or
+// expired since the previous check
+func (m *Monitor) Check() {
+	now := m.now()
+	minDays := math.Inf(1)
+	for _, source := range m.sources {
+		certs, err := source()
+		if err != nil {
+			logger.Warningf("Failed reading certificates to monitor: %s", err)
+		}
+		for _, cert := range certs {
+			days := cert.NotAfter.Sub(now).Hours() / 24
+			minDays = math.Min(minDays, days)
+			m.scope.Tagged(map[string]string{
+				"kind":    cert.Kind,
+				"source":  cert.Source,
+				"msp_id":  cert.MSPID,
+				"subject": cert.Subject,
+				"serial":  cert.Serial,
+			}).Gauge(certDaysToExpiry).Update(days)
+			m.warn(cert, days)
+		}
+	}
+	if !math.IsInf(minDays, 1) {
+		m.scope.Gauge(minCertDaysToExpiry).Update(minDays)
+	}
+}
+
+func (m *Monitor) warn(cert *Cert, days float64) {
+	warned, ok := m.warned[cert.key()]
+	if days <= 0 {
+		if !ok || warned > 0 {
+			logger.Errorf("The %s expired at %s", cert, cert.NotAfter)
+			m.warned[cert.key()] = 0
+		}
+		return
+	}
+
+	// the thresholds are in decreasing order, the last one crossed is the
+	// most urgent
+	threshold := 0
+	for _, t := range m.opts.WarningDays {
+		if days <= float64(t) {
+			threshold = t
+		}
+	}
+	if threshold == 0 || (ok && warned <= threshold) {
+		return
+	}
+	logger.Warningf("The %s expires in %d days, at %s", cert, int(days), cert.NotAfter)
+	m.warned[cert.key()] = threshold
+}
@@ -0,0 +1,129 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package certexpiry
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging/floggingtest"
+	mockmetrics "github.com/hyperledger/fabric/common/mocks/metrics"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// certTags returns the tags of the gauge of the certificate
+func certTags(cert *Cert) map[string]string {
+	return map[string]string{
+		"kind":    cert.Kind,
+		"source":  cert.Source,
+		"msp_id":  cert.MSPID,
+		"subject": cert.Subject,
+		"serial":  cert.Serial,
+	}
+}
+
+// gauge returns the value of the gauge, or NaN if it was never updated
+func gauge(scope *mockmetrics.MockScope, name string, tags map[string]string) float64 {
+	value, ok := scope.GaugeValue(name, tags)
+	if !ok {
+		return math.NaN()
+	}
+	return value
+}
+
+func TestNewOpts(t *testing.T) {
+	defer viper.Reset()
+
+	opts := NewOpts()
+	assert.Equal(t, time.Hour, opts.CheckInterval)
+	assert.Equal(t, []int{30, 7, 1}, opts.WarningDays)
+
+	viper.Set("peer.certExpiry.checkInterval", "10m")
+	viper.Set("peer.certExpiry.warningDays", []string{"3", "60", "bogus", "-1"})
+	opts = NewOpts()
+	assert.Equal(t, 10*time.Minute, opts.CheckInterval)
+	assert.Equal(t, []int{60, 3}, opts.WarningDays)
+}
+
+func TestMonitorCheck(t *testing.T) {
+	oldLogger := logger
+	defer func() { logger = oldLogger }()
+	l, recorder := floggingtest.NewTestLogger(t)
+	logger = l
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	certs := []*Cert{
+		{Kind: SigningCert, Source: LocalSource, MSPID: "Org1MSP", Subject: "peer0", Serial: "1", NotAfter: now.Add(40 * day)},
+		{Kind: CACert, Source: "mychannel", MSPID: "Org2MSP", Subject: "ca", Serial: "2", NotAfter: now.Add(10 * day)},
+	}
+	failing := func() ([]*Cert, error) { return nil, errors.New("no such file") }
+	scope := mockmetrics.NewMockScope()
+	m := NewMonitor(Opts{CheckInterval: time.Hour, WarningDays: []int{30, 7, 1}}, scope, func() ([]*Cert, error) {
+		return certs, nil
+	}, failing)
+	m.now = func() time.Time { return now }
+
+	m.Check()
+	assert.Equal(t, float64(40), gauge(scope, certDaysToExpiry, certTags(certs[0])))
+	assert.Equal(t, float64(10), gauge(scope, certDaysToExpiry, certTags(certs[1])))
+	assert.Equal(t, float64(10), gauge(scope, minCertDaysToExpiry, nil))
+	assert.Len(t, recorder.MessagesContaining("Failed reading certificates to monitor: no such file"), 1)
+	assert.Equal(t, []string{
+		"The ca certificate [ca] (serial 2) of MSP Org2MSP in the config of channel mychannel expires in 10 days, at 2020-01-11 00:00:00 +0000 UTC",
+	}, recorder.MessagesContaining("expires in"))
+
+	// the warning at a threshold is logged once
+	recorder.Reset()
+	now = now.Add(day)
+	m.Check()
+	assert.Equal(t, float64(9), gauge(scope, certDaysToExpiry, certTags(certs[1])))
+	assert.Empty(t, recorder.MessagesContaining("expires in"))
+
+	// crossing the next threshold logs another warning
+	now = now.Add(3 * day)
+	m.Check()
+	assert.Len(t, recorder.MessagesContaining("ca certificate [ca] (serial 2) of MSP Org2MSP in the config of channel mychannel expires in 6 days"), 1)
+	assert.Empty(t, recorder.MessagesContaining("signing certificate"))
+
+	// the expiration is logged once, as an error
+	recorder.Reset()
+	now = now.Add(10 * day)
+	m.Check()
+	m.Check()
+	assert.Equal(t, float64(-4), gauge(scope, certDaysToExpiry, certTags(certs[1])))
+	assert.Equal(t, float64(-4), gauge(scope, minCertDaysToExpiry, nil))
+	assert.Equal(t, []string{
+		"The ca certificate [ca] (serial 2) of MSP Org2MSP in the config of channel mychannel expired at 2020-01-11 00:00:00 +0000 UTC",
+	}, recorder.MessagesContaining("expired at"))
+	assert.Len(t, recorder.MessagesContaining("signing certificate [peer0] (serial 1) of MSP Org1MSP expires in 26 days"), 1)
+}
+
+func TestMonitorRun(t *testing.T) {
+	checked := make(chan struct{}, 10)
+	m := NewMonitor(Opts{CheckInterval: time.Millisecond}, mockmetrics.NewMockScope(), func() ([]*Cert, error) {
+		select {
+		case checked <- struct{}{}:
+		default:
+		}
+		return nil, nil
+	})
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		m.Run(stop)
+		close(done)
+	}()
+	<-checked
+	<-checked
+	close(stop)
+	<-done
+}
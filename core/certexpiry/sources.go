@@ -0,0 +1,201 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package certexpiry
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// LocalSource is the source of the certificates of the peer itself
+const LocalSource = "local"
+
+// The kinds of the monitored certificates
+const (
+	SigningCert           = "signing"
+	AdminCert             = "admin"
+	CACert                = "ca"
+	IntermediateCACert    = "intermediate_ca"
+	TLSCACert             = "tls_ca"
+	TLSIntermediateCACert = "tls_intermediate_ca"
+	TLSServerCert         = "tls_server"
+	TLSClientCert         = "tls_client"
+)
+
+// mspDirs are the folders of a local MSP holding certificates, along with
+// the kind of their certificates
+var mspDirs = []struct {
+	name string
+	kind string
+}{
+	{"signcerts", SigningCert},
+	{"admincerts", AdminCert},
+	{"cacerts", CACert},
+	{"intermediatecerts", IntermediateCACert},
+	{"tlscacerts", TLSCACert},
+	{"tlsintermediatecerts", TLSIntermediateCACert},
+}
+
+// ParseCerts parses the certificates of the given kind held by the given PEM
+// encoded blocks
+func ParseCerts(kind, source, mspID string, pemBytes ...[]byte) ([]*Cert, error) {
+	var certs []*Cert
+	for _, b := range pemBytes {
+		for {
+			var block *pem.Block
+			block, b = pem.Decode(b)
+			if block == nil {
+				break
+			}
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed parsing %s certificate", kind)
+			}
+			certs = append(certs, &Cert{
+				Kind:     kind,
+				Source:   source,
+				MSPID:    mspID,
+				Subject:  cert.Subject.CommonName,
+				Serial:   fmt.Sprintf("%x", cert.SerialNumber),
+				NotAfter: cert.NotAfter,
+			})
+		}
+	}
+	return certs, nil
+}
+
+// FileSource supplies the certificates of the given kind held by the PEM file
+// at the given path. No certificates are supplied if the path is empty.
+func FileSource(kind, path string) Source {
+	return func() ([]*Cert, error) {
+		if path == "" {
+			return nil, nil
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed reading %s certificate", kind)
+		}
+		return ParseCerts(kind, LocalSource, "", b)
+	}
+}
+
+// MSPDirSource supplies the signing, admin, and CA certificates of the local
+// MSP found in the given directory
+func MSPDirSource(mspID, dir string) Source {
+	return func() ([]*Cert, error) {
+		var certs []*Cert
+		for _, d := range mspDirs {
+			kind := d.kind
+			files, err := ioutil.ReadDir(filepath.Join(dir, d.name))
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return certs, errors.Wrapf(err, "failed reading the %s certificates of local MSP %s", kind, mspID)
+			}
+			for _, f := range files {
+				if f.IsDir() {
+					continue
+				}
+				b, err := ioutil.ReadFile(filepath.Join(dir, d.name, f.Name()))
+				if err != nil {
+					return certs, errors.Wrapf(err, "failed reading the %s certificates of local MSP %s", kind, mspID)
+				}
+				c, err := ParseCerts(kind, LocalSource, mspID, b)
+				if err != nil {
+					return certs, errors.WithMessage(err, fmt.Sprintf("invalid certificate [%s] in local MSP %s", f.Name(), mspID))
+				}
+				certs = append(certs, c...)
+			}
+		}
+		return certs, nil
+	}
+}
+
+// ChannelConfigCerts returns the admin and CA certificates of the MSPs defined
+// in the given config of a channel
+func ChannelConfigCerts(channelID string, config *cb.Config) ([]*Cert, error) {
+	var certs []*Cert
+	seen := make(map[string]bool)
+	var walk func(group *cb.ConfigGroup) error
+	walk = func(group *cb.ConfigGroup) error {
+		if value, ok := group.Values[channelconfig.MSPKey]; ok {
+			c, err := mspConfigCerts(channelID, value.Value)
+			if err != nil {
+				return err
+			}
+			for _, cert := range c {
+				// the same MSP may be defined in several groups
+				if !seen[cert.key()] {
+					seen[cert.key()] = true
+					certs = append(certs, cert)
+				}
+			}
+		}
+		for _, g := range group.Groups {
+			if err := walk(g); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if config.ChannelGroup == nil {
+		return nil, nil
+	}
+	if err := walk(config.ChannelGroup); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("invalid MSP in the config of channel %s", channelID))
+	}
+	return certs, nil
+}
+
+func mspConfigCerts(channelID string, value []byte) ([]*Cert, error) {
+	mspConfig := &mspprotos.MSPConfig{}
+	if err := proto.Unmarshal(value, mspConfig); err != nil {
+		return nil, err
+	}
+	if mspConfig.Type != int32(msp.FABRIC) {
+		// only the MSPs based on X.509 certificates have certificates to monitor
+		return nil, nil
+	}
+	fabricConfig := &mspprotos.FabricMSPConfig{}
+	if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+		return nil, err
+	}
+
+	var certs []*Cert
+	for _, set := range []struct {
+		kind     string
+		pemBytes [][]byte
+	}{
+		{AdminCert, fabricConfig.Admins},
+		{CACert, fabricConfig.RootCerts},
+		{IntermediateCACert, fabricConfig.IntermediateCerts},
+		{TLSCACert, fabricConfig.TlsRootCerts},
+		{TLSIntermediateCACert, fabricConfig.TlsIntermediateCerts},
+	} {
+		c, err := ParseCerts(set.kind, channelID, fabricConfig.Name, set.pemBytes...)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("MSP %s", fabricConfig.Name))
+		}
+		certs = append(certs, c...)
+	}
+	return certs, nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package certexpiry
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var notAfter = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newCertPEM(t *testing.T, cn string, serial int64) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestParseCerts(t *testing.T) {
+	bundle := append(newCertPEM(t, "ca", 10), newCertPEM(t, "intermediate", 11)...)
+	bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte{1}})...)
+	certs, err := ParseCerts(CACert, LocalSource, "Org1MSP", bundle)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Cert{
+		{Kind: CACert, Source: LocalSource, MSPID: "Org1MSP", Subject: "ca", Serial: "a", NotAfter: notAfter},
+		{Kind: CACert, Source: LocalSource, MSPID: "Org1MSP", Subject: "intermediate", Serial: "b", NotAfter: notAfter},
+	}, certs)
+
+	_, err = ParseCerts(CACert, LocalSource, "Org1MSP", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{1}}))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed parsing ca certificate")
+}
+
+func TestFileSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "certexpiry")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "server.crt")
+	require.NoError(t, ioutil.WriteFile(path, newCertPEM(t, "peer0", 1), 0644))
+
+	certs, err := FileSource(TLSServerCert, path)()
+	assert.NoError(t, err)
+	assert.Equal(t, []*Cert{{Kind: TLSServerCert, Source: LocalSource, Subject: "peer0", Serial: "1", NotAfter: notAfter}}, certs)
+
+	certs, err = FileSource(TLSClientCert, "")()
+	assert.NoError(t, err)
+	assert.Empty(t, certs)
+
+	_, err = FileSource(TLSClientCert, filepath.Join(dir, "missing.crt"))()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed reading tls_client certificate")
+}
+
+func TestMSPDirSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "certexpiry")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for _, f := range []struct {
+		subdir string
+		cn     string
+		serial int64
+	}{
+		{"signcerts", "peer0", 1},
+		{"cacerts", "ca", 2},
+		{"tlscacerts", "tlsca", 3},
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, f.subdir), 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, f.subdir, "cert.pem"), newCertPEM(t, f.cn, f.serial), 0644))
+	}
+
+	certs, err := MSPDirSource("Org1MSP", dir)()
+	assert.NoError(t, err)
+	assert.Equal(t, []*Cert{
+		{Kind: SigningCert, Source: LocalSource, MSPID: "Org1MSP", Subject: "peer0", Serial: "1", NotAfter: notAfter},
+		{Kind: CACert, Source: LocalSource, MSPID: "Org1MSP", Subject: "ca", Serial: "2", NotAfter: notAfter},
+		{Kind: TLSCACert, Source: LocalSource, MSPID: "Org1MSP", Subject: "tlsca", Serial: "3", NotAfter: notAfter},
+	}, certs)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "cacerts", "bad.pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{1}}), 0644))
+	_, err = MSPDirSource("Org1MSP", dir)()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid certificate [bad.pem] in local MSP Org1MSP")
+}
+
+func TestChannelConfigCerts(t *testing.T) {
+	mspValue := func(name string, fabricConfig *mspprotos.FabricMSPConfig) *cb.ConfigValue {
+		fabricConfig.Name = name
+		mspConfig := &mspprotos.MSPConfig{Config: mspMarshal(t, fabricConfig)}
+		return &cb.ConfigValue{Value: mspMarshal(t, mspConfig)}
+	}
+	org1 := mspValue("Org1MSP", &mspprotos.FabricMSPConfig{
+		RootCerts: [][]byte{newCertPEM(t, "ca.org1", 1)},
+		Admins:    [][]byte{newCertPEM(t, "admin.org1", 2)},
+	})
+	org2 := mspValue("Org2MSP", &mspprotos.FabricMSPConfig{
+		TlsRootCerts: [][]byte{newCertPEM(t, "tlsca.org2", 3)},
+	})
+	idemix := &cb.ConfigValue{Value: mspMarshal(t, &mspprotos.MSPConfig{Type: 1, Config: []byte("idemix")})}
+	org := func(value *cb.ConfigValue) *cb.ConfigGroup {
+		return &cb.ConfigGroup{Values: map[string]*cb.ConfigValue{channelconfig.MSPKey: value}}
+	}
+	config := &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				channelconfig.ApplicationGroupKey: {
+					Groups: map[string]*cb.ConfigGroup{"Org1": org(org1), "Org2": org(org2), "Org3": org(idemix)},
+				},
+				channelconfig.OrdererGroupKey: {
+					// the orderer org is also defined by Org1
+					Groups: map[string]*cb.ConfigGroup{"Org1": org(org1)},
+				},
+			},
+		},
+	}
+
+	certs, err := ChannelConfigCerts("mychannel", config)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []*Cert{
+		{Kind: AdminCert, Source: "mychannel", MSPID: "Org1MSP", Subject: "admin.org1", Serial: "2", NotAfter: notAfter},
+		{Kind: CACert, Source: "mychannel", MSPID: "Org1MSP", Subject: "ca.org1", Serial: "1", NotAfter: notAfter},
+		{Kind: TLSCACert, Source: "mychannel", MSPID: "Org2MSP", Subject: "tlsca.org2", Serial: "3", NotAfter: notAfter},
+	}, certs)
+
+	certs, err = ChannelConfigCerts("mychannel", &cb.Config{})
+	assert.NoError(t, err)
+	assert.Empty(t, certs)
+
+	config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Groups["Org1"].Values[channelconfig.MSPKey] = &cb.ConfigValue{Value: []byte("garbage")}
+	_, err = ChannelConfigCerts("mychannel", config)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid MSP in the config of channel mychannel")
+}
+
+func mspMarshal(t *testing.T, msg proto.Message) []byte {
+	b, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	return b
+}
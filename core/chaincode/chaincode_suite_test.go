@@ -109,6 +109,11 @@ type peerLedger interface {
 	ledger.PeerLedger
 }
 
+//go:generate counterfeiter -o mock/channel_lister.go --fake-name ChannelLister . channelLister
+type channelLister interface {
+	chaincode.ChannelLister
+}
+
 // NOTE: These are getting generated into the "fake" package to avoid import cycles. We need to revisit this.
 
 //go:generate counterfeiter -o fake/launch_registry.go --fake-name LaunchRegistry . launchRegistry
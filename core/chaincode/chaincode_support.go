@@ -53,7 +53,11 @@ type ChaincodeSupport struct {
 	Launcher         Launcher
 	SystemCCProvider sysccprovider.SystemChaincodeProvider
 	Lifecycle        Lifecycle
-	appConfig        ApplicationConfigRetriever
+	FailureDiagnoser *FailureDiagnoser
+	// ContainerCollector is set when the garbage collection of the chaincode
+	// containers is enabled, and is to be run by the peer
+	ContainerCollector *ContainerCollector
+	appConfig          ApplicationConfigRetriever
 }
 
 // NewChaincodeSupport creates a new ChaincodeSupport instance.
@@ -87,24 +91,55 @@ func NewChaincodeSupport(
 		certGenerator = nil
 	}
 
-	cs.Runtime = &ContainerRuntime{
-		CertGenerator:    certGenerator,
-		Processor:        processor,
-		CACert:           caCert,
-		PeerAddress:      peerAddress,
-		PlatformRegistry: platformRegistry,
-		CommonEnv: []string{
-			"CORE_CHAINCODE_LOGGING_LEVEL=" + config.LogLevel,
-			"CORE_CHAINCODE_LOGGING_SHIM=" + config.ShimLogLevel,
-			"CORE_CHAINCODE_LOGGING_FORMAT=" + config.LogFormat,
+	commonEnv := []string{
+		"CORE_CHAINCODE_LOGGING_LEVEL=" + config.LogLevel,
+		"CORE_CHAINCODE_LOGGING_SHIM=" + config.ShimLogLevel,
+		"CORE_CHAINCODE_LOGGING_FORMAT=" + config.LogFormat,
+	}
+	if config.Compression {
+		commonEnv = append(commonEnv, "CORE_CHAINCODE_COMPRESSION_ENABLED=true")
+	}
+
+	// the peer connects to the chaincodes run as external services, and
+	// launches the others in containers
+	cs.Runtime = &ExternalRuntime{
+		Runtime: &ContainerRuntime{
+			CertGenerator:    certGenerator,
+			Processor:        processor,
+			CACert:           caCert,
+			PeerAddress:      peerAddress,
+			PlatformRegistry: platformRegistry,
+			CommonEnv:        commonEnv,
 		},
+		StreamHandler:     cs,
+		TLSEnabled:        config.TLSEnabled,
+		ConnectionTimeout: config.StartupTimeout,
+	}
+
+	if config.Diagnostics {
+		cs.FailureDiagnoser = &FailureDiagnoser{
+			Processor:   processor,
+			OutputLines: config.DiagnosticsOutputLines,
+		}
+	}
+
+	if config.GarbageCollection && !userRunsCC {
+		cs.ContainerCollector = &ContainerCollector{
+			Processor:     processor,
+			ContainerType: pb.ChaincodeDeploymentSpec_DOCKER.String(),
+			Channels:      peer.Default,
+			LedgerGetter:  peer.Default,
+			Interval:      config.GCInterval,
+			Retention:     config.GCRetention,
+		}
 	}
 
 	cs.Launcher = &RuntimeLauncher{
-		Runtime:         cs.Runtime,
-		Registry:        cs.HandlerRegistry,
-		PackageProvider: packageProvider,
-		StartupTimeout:  config.StartupTimeout,
+		Runtime:          cs.Runtime,
+		Registry:         cs.HandlerRegistry,
+		PackageProvider:  packageProvider,
+		StartupTimeout:   config.StartupTimeout,
+		FailureDiagnoser: cs.FailureDiagnoser,
 	}
 
 	return cs
@@ -222,13 +257,20 @@ func (cs *ChaincodeSupport) ExecuteLegacyInit(txParams *ccprovider.TransactionPa
 	}
 
 	resp, err := cs.execute(pb.ChaincodeMessage_INIT, txParams, cccid, spec.GetChaincodeSpec().Input, h)
-	return processChaincodeExecutionResult(txParams.TxID, cccid.Name, resp, err)
+	res, event, err := processChaincodeExecutionResult(txParams.TxID, cccid.Name, resp, err)
+	return res, event, cs.FailureDiagnoser.Diagnose(ccci, err)
 }
 
 // Execute invokes chaincode and returns the original response.
 func (cs *ChaincodeSupport) Execute(txParams *ccprovider.TransactionParams, cccid *ccprovider.CCContext, input *pb.ChaincodeInput) (*pb.Response, *pb.ChaincodeEvent, error) {
 	resp, err := cs.Invoke(txParams, cccid, input)
-	return processChaincodeExecutionResult(txParams.TxID, cccid.Name, resp, err)
+	res, event, err := processChaincodeExecutionResult(txParams.TxID, cccid.Name, resp, err)
+	if err != nil && cs.FailureDiagnoser != nil {
+		if ccci, lerr := cs.Lifecycle.ChaincodeContainerInfo(txParams.ChannelID, cccid.Name); lerr == nil {
+			err = cs.FailureDiagnoser.Diagnose(ccci, err)
+		}
+	}
+	return res, event, err
 }
 
 func processChaincodeExecutionResult(txid, ccName string, resp *pb.ChaincodeMessage, err error) (*pb.Response, *pb.ChaincodeEvent, error) {
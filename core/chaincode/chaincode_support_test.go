@@ -1418,7 +1418,7 @@ func TestCCFramework(t *testing.T) {
 	getHistory(t, chainID, ccname, ccSide, chaincodeSupport)
 
 	//just use the previous certGenerator for generating TLS key/pair
-	cr := chaincodeSupport.Runtime.(*ContainerRuntime)
+	cr := chaincodeSupport.Runtime.(*ExternalRuntime).Runtime.(*ContainerRuntime)
 	getLaunchConfigs(t, cr)
 
 	ccSide.Quit()
@@ -17,8 +17,11 @@ import (
 )
 
 const (
-	defaultExecutionTimeout = 30 * time.Second
-	minimumStartupTimeout   = 5 * time.Second
+	defaultExecutionTimeout       = 30 * time.Second
+	minimumStartupTimeout         = 5 * time.Second
+	defaultDiagnosticsOutputLines = 100
+	defaultGCInterval             = time.Hour
+	defaultGCRetention            = 24 * time.Hour
 )
 
 type Config struct {
@@ -29,6 +32,20 @@ type Config struct {
 	LogFormat      string
 	LogLevel       string
 	ShimLogLevel   string
+	Compression    bool
+
+	// Diagnostics enables returning the diagnostics of the containers of
+	// the chaincodes whose execution fails, with their last DiagnosticsOutputLines
+	// lines of output
+	Diagnostics            bool
+	DiagnosticsOutputLines int
+
+	// GarbageCollection enables removing, every GCInterval, the exited
+	// containers of the chaincodes and the images of the chaincodes which
+	// are not instantiated anymore, once they are older than GCRetention
+	GarbageCollection bool
+	GCInterval        time.Duration
+	GCRetention       time.Duration
 }
 
 func GlobalConfig() *Config {
@@ -58,6 +75,24 @@ func (c *Config) load() {
 	c.LogFormat = viper.GetString("chaincode.logging.format")
 	c.LogLevel = getLogLevelFromViper("chaincode.logging.level")
 	c.ShimLogLevel = getLogLevelFromViper("chaincode.logging.shim")
+
+	c.Compression = viper.GetBool("chaincode.compression.enabled")
+
+	c.Diagnostics = viper.GetBool("chaincode.diagnostics.enabled")
+	c.DiagnosticsOutputLines = viper.GetInt("chaincode.diagnostics.outputLines")
+	if c.DiagnosticsOutputLines <= 0 {
+		c.DiagnosticsOutputLines = defaultDiagnosticsOutputLines
+	}
+
+	c.GarbageCollection = viper.GetBool("chaincode.garbageCollection.enabled")
+	c.GCInterval = viper.GetDuration("chaincode.garbageCollection.interval")
+	if c.GCInterval <= 0 {
+		c.GCInterval = defaultGCInterval
+	}
+	c.GCRetention = viper.GetDuration("chaincode.garbageCollection.retention")
+	if c.GCRetention < 0 || !viper.IsSet("chaincode.garbageCollection.retention") {
+		c.GCRetention = defaultGCRetention
+	}
 }
 
 func toSeconds(s string, def int) time.Duration {
@@ -36,6 +36,7 @@ var _ = Describe("Config", func() {
 			viper.Set("chaincode.logging.format", "test-chaincode-logging-format")
 			viper.Set("chaincode.logging.level", "WARNING")
 			viper.Set("chaincode.logging.shim", "WARNING")
+			viper.Set("chaincode.compression.enabled", "true")
 
 			config := chaincode.GlobalConfig()
 			Expect(config.TLSEnabled).To(BeTrue())
@@ -45,6 +46,7 @@ var _ = Describe("Config", func() {
 			Expect(config.LogFormat).To(Equal("test-chaincode-logging-format"))
 			Expect(config.LogLevel).To(Equal("WARNING"))
 			Expect(config.ShimLogLevel).To(Equal("WARNING"))
+			Expect(config.Compression).To(BeTrue())
 		})
 
 		Context("when an invalid keepalive is configured", func() {
@@ -120,6 +122,8 @@ func capture() (restore func()) {
 		"chaincode.logging.format": viper.GetString("chaincode.logging.format"),
 		"chaincode.logging.level":  viper.GetString("chaincode.logging.level"),
 		"chaincode.logging.shim":   viper.GetString("chaincode.logging.shim"),
+
+		"chaincode.compression.enabled": viper.GetString("chaincode.compression.enabled"),
 	}
 
 	return func() {
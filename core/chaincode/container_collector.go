@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// ChannelLister lists the channels joined by the peer
+type ChannelLister interface {
+	GetChannelsInfo() []*pb.ChannelInfo
+}
+
+// ContainerCollector periodically removes the exited containers of the
+// chaincodes, and the images of the chaincodes which are not instantiated on
+// any channel joined by the peer, once they are older than Retention.
+type ContainerCollector struct {
+	Processor     Processor
+	ContainerType string
+	Channels      ChannelLister
+	LedgerGetter  LedgerGetter
+	Interval      time.Duration
+	Retention     time.Duration
+}
+
+// Run collects the garbage every Interval until done is closed
+func (c *ContainerCollector) Run(done <-chan struct{}) {
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.Collect(); err != nil {
+				chaincodeLogger.Warningf("Failed collecting the garbage of the chaincode containers: %s", err)
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// Collect removes the exited containers and the images left behind
+func (c *ContainerCollector) Collect() error {
+	referenced, err := c.instantiatedChaincodes()
+	if err != nil {
+		return err
+	}
+	return c.Processor.Process(c.ContainerType, container.CollectGarbageReq{
+		Referenced: referenced,
+		Retention:  c.Retention,
+	})
+}
+
+// instantiatedChaincodes returns the chaincodes defined by lscc on the
+// channels joined by the peer
+func (c *ContainerCollector) instantiatedChaincodes() ([]ccintf.CCID, error) {
+	var ccids []ccintf.CCID
+	for _, channel := range c.Channels.GetChannelsInfo() {
+		l := c.LedgerGetter.GetLedger(channel.ChannelId)
+		if l == nil {
+			continue
+		}
+		channelCCIDs, err := instantiatedChaincodes(l)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to list the chaincodes of channel "+channel.ChannelId)
+		}
+		ccids = append(ccids, channelCCIDs...)
+	}
+	return ccids, nil
+}
+
+func instantiatedChaincodes(l ledger.PeerLedger) ([]ccintf.CCID, error) {
+	qe, err := l.NewQueryExecutor()
+	if err != nil {
+		return nil, err
+	}
+	defer qe.Done()
+	itr, err := qe.GetStateRangeScanIterator("lscc", "", "")
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	var ccids []ccintf.CCID
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			return ccids, nil
+		}
+		kv := result.(*queryresult.KV)
+		// collection configs are stored along with the chaincode definitions
+		if privdata.IsCollectionConfigKey(kv.Key) {
+			continue
+		}
+		cd := &ccprovider.ChaincodeData{}
+		if err := proto.Unmarshal(kv.Value, cd); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal the definition of chaincode %s", kv.Key)
+		}
+		ccids = append(ccids, ccintf.CCID{Name: cd.Name, Version: cd.Version})
+	}
+}
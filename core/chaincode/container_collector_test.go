@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/mock"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerCollector(t *testing.T) {
+	cdBytes, err := proto.Marshal(&ccprovider.ChaincodeData{Name: "mycc", Version: "2.0"})
+	assert.NoError(t, err)
+
+	fakeIterator := &mock.QueryResultsIterator{}
+	fakeIterator.NextReturnsOnCall(0, &queryresult.KV{Key: "mycc", Value: cdBytes}, nil)
+	fakeIterator.NextReturnsOnCall(1, &queryresult.KV{Key: "mycc~collection", Value: []byte("not a definition")}, nil)
+	fakeQueryExecutor := &mock.TxSimulator{}
+	fakeQueryExecutor.GetStateRangeScanIteratorReturns(fakeIterator, nil)
+	fakeLedger := &mock.PeerLedger{}
+	fakeLedger.NewQueryExecutorReturns(fakeQueryExecutor, nil)
+	fakeLedgerGetter := &mock.LedgerGetter{}
+	fakeLedgerGetter.GetLedgerStub = func(cid string) ledger.PeerLedger {
+		if cid == "mychannel" {
+			return fakeLedger
+		}
+		return nil
+	}
+	fakeChannels := &mock.ChannelLister{}
+	fakeChannels.GetChannelsInfoReturns([]*pb.ChannelInfo{{ChannelId: "mychannel"}, {ChannelId: "leftchannel"}})
+	fakeProcessor := &mock.Processor{}
+
+	collector := &chaincode.ContainerCollector{
+		Processor:     fakeProcessor,
+		ContainerType: "DOCKER",
+		Channels:      fakeChannels,
+		LedgerGetter:  fakeLedgerGetter,
+		Interval:      time.Hour,
+		Retention:     24 * time.Hour,
+	}
+	assert.NoError(t, collector.Collect())
+
+	assert.Equal(t, 1, fakeProcessor.ProcessCallCount())
+	vmtype, req := fakeProcessor.ProcessArgsForCall(0)
+	assert.Equal(t, "DOCKER", vmtype)
+	assert.Equal(t, container.CollectGarbageReq{
+		Referenced: []ccintf.CCID{{Name: "mycc", Version: "2.0"}},
+		Retention:  24 * time.Hour,
+	}, req)
+	namespace, _, _ := fakeQueryExecutor.GetStateRangeScanIteratorArgsForCall(0)
+	assert.Equal(t, "lscc", namespace)
+	assert.Equal(t, 1, fakeQueryExecutor.DoneCallCount())
+	assert.Equal(t, 1, fakeIterator.CloseCallCount())
+
+	// nothing is removed when the chaincodes in use cannot be listed
+	fakeQueryExecutor.GetStateRangeScanIteratorReturns(nil, errors.New("ledger closed"))
+	assert.EqualError(t, collector.Collect(), "failed to list the chaincodes of channel mychannel: ledger closed")
+	assert.Equal(t, 1, fakeProcessor.ProcessCallCount())
+}
@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"strings"
+
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// ExecutionError is returned when the execution of a chaincode fails, along
+// with the diagnostics of its container
+type ExecutionError struct {
+	Err         error
+	Diagnostics *pb.ChaincodeDiagnostics
+}
+
+// Error returns the message of the underlying error
+func (e *ExecutionError) Error() string {
+	return e.Err.Error()
+}
+
+// ChaincodeDiagnostics returns the diagnostics of the container of the
+// chaincode
+func (e *ExecutionError) ChaincodeDiagnostics() *pb.ChaincodeDiagnostics {
+	return e.Diagnostics
+}
+
+// FailureDiagnoser collects the diagnostics of the containers of the
+// chaincodes whose execution fails. A nil FailureDiagnoser collects nothing.
+type FailureDiagnoser struct {
+	Processor   Processor
+	OutputLines int
+}
+
+// Diagnose returns the error of the execution of the chaincode along with the
+// diagnostics of its container. The error is returned unchanged when the
+// container cannot be diagnosed.
+func (f *FailureDiagnoser) Diagnose(ccci *ccprovider.ChaincodeContainerInfo, err error) error {
+	if f == nil || err == nil {
+		return err
+	}
+	if diagnosed(err) {
+		return err
+	}
+	// the chaincodes run as external services have no container
+	if ccci.Endpoint != "" {
+		return err
+	}
+
+	diagnostics := &container.Diagnostics{}
+	dcr := container.DiagnoseContainerReq{
+		CCID: ccintf.CCID{
+			Name:    ccci.Name,
+			Version: ccci.Version,
+		},
+		OutputLines: f.OutputLines,
+		Diagnostics: diagnostics,
+	}
+	if derr := f.Processor.Process(ccci.ContainerType, dcr); derr != nil {
+		chaincodeLogger.Debugf("failed to diagnose chaincode %s:%s: %s", ccci.Name, ccci.Version, derr)
+		return err
+	}
+
+	return &ExecutionError{
+		Err: err,
+		Diagnostics: &pb.ChaincodeDiagnostics{
+			Output:   diagnostics.Output,
+			Panic:    panicOf(diagnostics.Output),
+			Exited:   diagnostics.Exited,
+			ExitCode: int32(diagnostics.ExitCode),
+		},
+	}
+}
+
+// diagnosed tells whether the error, or one it wraps, already carries the
+// diagnostics of the container
+func diagnosed(err error) bool {
+	for err != nil {
+		if _, ok := err.(*ExecutionError); ok {
+			return true
+		}
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			return false
+		}
+		err = cause.Cause()
+	}
+	return false
+}
+
+// panicOf returns the panic message and the stack printed by the Go runtime
+// when a chaincode crashed, if any
+func panicOf(output []string) string {
+	for i := len(output) - 1; i >= 0; i-- {
+		if strings.HasPrefix(output[i], "panic: ") {
+			return strings.Join(output[i:], "\n")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/mock"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailureDiagnoser(t *testing.T) {
+	fakeProcessor := &mock.Processor{}
+	fakeProcessor.ProcessStub = func(vmtype string, req container.VMCReq) error {
+		dcr := req.(container.DiagnoseContainerReq)
+		*dcr.Diagnostics = container.Diagnostics{
+			Output:   []string{"starting", "panic: boom", "", "goroutine 1 [running]:", "main.main()"},
+			Exited:   true,
+			ExitCode: 2,
+		}
+		return nil
+	}
+	diagnoser := &chaincode.FailureDiagnoser{Processor: fakeProcessor, OutputLines: 10}
+	ccci := &ccprovider.ChaincodeContainerInfo{Name: "mycc", Version: "1.0", ContainerType: "DOCKER"}
+
+	execErr := errors.New("timeout expired while executing transaction")
+	err := diagnoser.Diagnose(ccci, execErr)
+	assert.EqualError(t, err, "timeout expired while executing transaction")
+	assert.Equal(t, &pb.ChaincodeDiagnostics{
+		Output:   []string{"starting", "panic: boom", "", "goroutine 1 [running]:", "main.main()"},
+		Panic:    "panic: boom\n\ngoroutine 1 [running]:\nmain.main()",
+		Exited:   true,
+		ExitCode: 2,
+	}, err.(*chaincode.ExecutionError).ChaincodeDiagnostics())
+
+	assert.Equal(t, 1, fakeProcessor.ProcessCallCount())
+	vmtype, req := fakeProcessor.ProcessArgsForCall(0)
+	assert.Equal(t, "DOCKER", vmtype)
+	assert.Equal(t, ccintf.CCID{Name: "mycc", Version: "1.0"}, req.GetCCID())
+	assert.Equal(t, 10, req.(container.DiagnoseContainerReq).OutputLines)
+
+	// an error already diagnosed is not diagnosed again
+	wrapped := errors.WithMessage(err, "could not launch chaincode")
+	assert.Equal(t, wrapped, diagnoser.Diagnose(ccci, wrapped))
+	assert.Equal(t, 1, fakeProcessor.ProcessCallCount())
+
+	// the error is unchanged when the container cannot be diagnosed
+	fakeProcessor.ProcessStub = nil
+	fakeProcessor.ProcessReturns(errors.New("no such container"))
+	assert.Equal(t, execErr, diagnoser.Diagnose(ccci, execErr))
+
+	// a nil diagnoser does not diagnose
+	var disabled *chaincode.FailureDiagnoser
+	assert.Equal(t, execErr, disabled.Diagnose(ccci, execErr))
+	assert.Nil(t, diagnoser.Diagnose(ccci, nil))
+}
@@ -0,0 +1,143 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// StreamHandler handles the streams over which the chaincodes register.
+type StreamHandler interface {
+	HandleChaincodeStream(stream ccintf.ChaincodeStream) error
+}
+
+// ExternalRuntime connects to the chaincodes run as external services, whose
+// deployment specs hold the endpoints of their servers, rather than launching
+// them. The chaincodes register over the streams the peer opens, as they do
+// over the streams they open themselves. The runtime of the other chaincodes
+// is managed by the wrapped Runtime.
+type ExternalRuntime struct {
+	Runtime
+	StreamHandler     StreamHandler
+	TLSEnabled        bool
+	ConnectionTimeout time.Duration
+
+	mutex       sync.Mutex
+	connections map[string]*grpc.ClientConn
+}
+
+// Start connects to the server of a chaincode run as an external service, and
+// starts the other chaincodes in the wrapped Runtime.
+func (e *ExternalRuntime) Start(ccci *ccprovider.ChaincodeContainerInfo, codePackage []byte) error {
+	if ccci.Endpoint == "" {
+		return e.Runtime.Start(ccci, codePackage)
+	}
+
+	cname := ccci.Name + ":" + ccci.Version
+	conn, err := e.dial(ccci.Endpoint, ccci.EndpointRootCert)
+	if err != nil {
+		return errors.Wrapf(err, "error connecting to chaincode %s at %s", cname, ccci.Endpoint)
+	}
+	stream, err := pb.NewChaincodeClient(conn).Connect(context.Background())
+	if err != nil {
+		conn.Close()
+		return errors.Wrapf(err, "error opening stream to chaincode %s at %s", cname, ccci.Endpoint)
+	}
+
+	e.mutex.Lock()
+	if e.connections == nil {
+		e.connections = map[string]*grpc.ClientConn{}
+	}
+	if previous, ok := e.connections[cname]; ok {
+		previous.Close()
+	}
+	e.connections[cname] = conn
+	e.mutex.Unlock()
+
+	go func() {
+		defer e.disconnect(cname, conn)
+		if err := e.StreamHandler.HandleChaincodeStream(stream); err != nil {
+			chaincodeLogger.Warningf("stream to chaincode %s at %s ended: %s", cname, ccci.Endpoint, err)
+		}
+	}()
+	return nil
+}
+
+// Stop closes the connection to a chaincode run as an external service, which
+// is left running, and stops the other chaincodes in the wrapped Runtime.
+func (e *ExternalRuntime) Stop(ccci *ccprovider.ChaincodeContainerInfo) error {
+	if ccci.Endpoint == "" {
+		return e.Runtime.Stop(ccci)
+	}
+
+	e.mutex.Lock()
+	conn, ok := e.connections[ccci.Name+":"+ccci.Version]
+	e.mutex.Unlock()
+	if ok {
+		e.disconnect(ccci.Name+":"+ccci.Version, conn)
+	}
+	return nil
+}
+
+// disconnect closes the connection to the chaincode, unless it was replaced
+// by a new one already
+func (e *ExternalRuntime) disconnect(cname string, conn *grpc.ClientConn) {
+	e.mutex.Lock()
+	if e.connections[cname] == conn {
+		delete(e.connections, cname)
+	}
+	e.mutex.Unlock()
+	conn.Close()
+}
+
+// dial connects to the server of a chaincode the way the peer connects to
+// the other peers, presenting its client certificate and trusting their
+// root certificates when TLS is enabled. When the root certificates of the
+// server were declared at install time, the peer connects over TLS and
+// trusts only them.
+func (e *ExternalRuntime) dial(endpoint string, rootCert []byte) (*grpc.ClientConn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), e.ConnectionTimeout)
+	defer cancel()
+
+	opts := []grpc.DialOption{
+		grpc.WithBlock(),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(comm.MaxRecvMsgSize),
+			grpc.MaxCallSendMsgSize(comm.MaxSendMsgSize),
+		),
+	}
+	opts = append(opts, comm.ClientKeepaliveOptions(comm.DefaultKeepaliveOptions)...)
+	switch {
+	case len(rootCert) != 0:
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(rootCert) {
+			return nil, errors.New("the endpoint root certificate holds no valid certificate")
+		}
+		tlsConfig := &tls.Config{RootCAs: certPool}
+		if e.TLSEnabled {
+			tlsConfig.Certificates = []tls.Certificate{comm.GetCredentialSupport().GetClientCertificate()}
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	case e.TLSEnabled:
+		opts = append(opts, grpc.WithTransportCredentials(comm.GetCredentialSupport().GetPeerCredentials()))
+	default:
+		opts = append(opts, grpc.WithInsecure())
+	}
+	return grpc.DialContext(ctx, endpoint, opts...)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"net"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/mock"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type streamHandlerFunc func(ccintf.ChaincodeStream) error
+
+func (f streamHandlerFunc) HandleChaincodeStream(stream ccintf.ChaincodeStream) error {
+	return f(stream)
+}
+
+type noopChaincode struct{}
+
+func (noopChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response   { return shim.Success(nil) }
+func (noopChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response { return shim.Success(nil) }
+
+var _ = Describe("ExternalRuntime", func() {
+	var (
+		fakeRuntime     *mock.Runtime
+		registered      chan *pb.ChaincodeMessage
+		streamEnded     chan error
+		externalRuntime *chaincode.ExternalRuntime
+		ccci            *ccprovider.ChaincodeContainerInfo
+	)
+
+	BeforeEach(func() {
+		fakeRuntime = &mock.Runtime{}
+		registered = make(chan *pb.ChaincodeMessage, 1)
+		streamEnded = make(chan error, 1)
+		externalRuntime = &chaincode.ExternalRuntime{
+			Runtime: fakeRuntime,
+			StreamHandler: streamHandlerFunc(func(stream ccintf.ChaincodeStream) error {
+				msg, err := stream.Recv()
+				if err != nil {
+					return err
+				}
+				registered <- msg
+				_, err = stream.Recv()
+				streamEnded <- err
+				return err
+			}),
+			ConnectionTimeout: time.Second,
+		}
+		ccci = &ccprovider.ChaincodeContainerInfo{
+			Name:    "chaincode-name",
+			Version: "chaincode-version",
+		}
+	})
+
+	It("starts and stops the chaincodes without endpoint in the wrapped runtime", func() {
+		err := externalRuntime.Start(ccci, []byte("code-package"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeRuntime.StartCallCount()).To(Equal(1))
+		startCCCI, codePackage := fakeRuntime.StartArgsForCall(0)
+		Expect(startCCCI).To(Equal(ccci))
+		Expect(codePackage).To(Equal([]byte("code-package")))
+
+		err = externalRuntime.Stop(ccci)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeRuntime.StopCallCount()).To(Equal(1))
+	})
+
+	Context("when the chaincode is run as an external service", func() {
+		var ccServer *shim.ChaincodeServer
+
+		BeforeEach(func() {
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			ccci.Endpoint = lis.Addr().String()
+			lis.Close()
+
+			ccServer = &shim.ChaincodeServer{
+				CCID:         "chaincode-name:chaincode-version",
+				Address:      ccci.Endpoint,
+				CC:           noopChaincode{},
+				ServerConfig: comm.ServerConfig{SecOpts: &comm.SecureOptions{}},
+			}
+			go ccServer.Start()
+		})
+
+		AfterEach(func() {
+			ccServer.Stop()
+		})
+
+		It("connects to the chaincode, which registers over the stream", func() {
+			Eventually(func() error { return externalRuntime.Start(ccci, nil) }).Should(Succeed())
+			Expect(fakeRuntime.StartCallCount()).To(Equal(0))
+
+			var msg *pb.ChaincodeMessage
+			Eventually(registered).Should(Receive(&msg))
+			Expect(msg.Type).To(Equal(pb.ChaincodeMessage_REGISTER))
+			chaincodeID := &pb.ChaincodeID{}
+			Expect(proto.Unmarshal(msg.Payload, chaincodeID)).To(Succeed())
+			Expect(chaincodeID.Name).To(Equal("chaincode-name:chaincode-version"))
+
+			err := externalRuntime.Stop(ccci)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeRuntime.StopCallCount()).To(Equal(0))
+			Eventually(streamEnded).Should(Receive(HaveOccurred()))
+		})
+	})
+
+	Context("when the root certificate of the chaincode server was declared", func() {
+		var ccServer *shim.ChaincodeServer
+
+		BeforeEach(func() {
+			ca, err := tlsgen.NewCA()
+			Expect(err).NotTo(HaveOccurred())
+			serverKeyPair, err := ca.NewServerCertKeyPair("127.0.0.1")
+			Expect(err).NotTo(HaveOccurred())
+
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			ccci.Endpoint = lis.Addr().String()
+			ccci.EndpointRootCert = ca.CertBytes()
+			lis.Close()
+
+			ccServer = &shim.ChaincodeServer{
+				CCID:    "chaincode-name:chaincode-version",
+				Address: ccci.Endpoint,
+				CC:      noopChaincode{},
+				ServerConfig: comm.ServerConfig{SecOpts: &comm.SecureOptions{
+					UseTLS:      true,
+					Certificate: serverKeyPair.Cert,
+					Key:         serverKeyPair.Key,
+				}},
+			}
+			go ccServer.Start()
+		})
+
+		AfterEach(func() {
+			ccServer.Stop()
+		})
+
+		It("connects to the chaincode over TLS", func() {
+			Eventually(func() error { return externalRuntime.Start(ccci, nil) }).Should(Succeed())
+
+			var msg *pb.ChaincodeMessage
+			Eventually(registered).Should(Receive(&msg))
+			Expect(msg.Type).To(Equal(pb.ChaincodeMessage_REGISTER))
+		})
+
+		Context("when the root certificate is not valid", func() {
+			BeforeEach(func() {
+				ccci.EndpointRootCert = []byte("not-a-certificate")
+			})
+
+			It("returns an error", func() {
+				err := externalRuntime.Start(ccci, nil)
+				Expect(err).To(MatchError(ContainSubstring("the endpoint root certificate holds no valid certificate")))
+			})
+		})
+	})
+
+	Context("when the chaincode server cannot be reached", func() {
+		BeforeEach(func() {
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			ccci.Endpoint = lis.Addr().String()
+			lis.Close()
+			externalRuntime.ConnectionTimeout = 100 * time.Millisecond
+		})
+
+		It("returns an error", func() {
+			err := externalRuntime.Start(ccci, nil)
+			Expect(err).To(MatchError(ContainSubstring("error connecting to chaincode chaincode-name:chaincode-version at " + ccci.Endpoint)))
+		})
+	})
+})
@@ -639,7 +639,7 @@ func (h *Handler) HandleGetStateByRange(msg *pb.ChaincodeMessage, txContext *Tra
 		return nil, err
 	}
 
-	totalReturnLimit := calculateTotalReturnLimit(metadata)
+	totalReturnLimit := calculateTotalReturnLimit(h.ChaincodeName(), metadata)
 
 	iterID := h.UUIDGenerator.New()
 	chaincodeName := h.ChaincodeName()
@@ -649,7 +649,12 @@ func (h *Handler) HandleGetStateByRange(msg *pb.ChaincodeMessage, txContext *Tra
 
 	isPaginated := false
 
-	if isCollectionSet(getStateByRange.Collection) {
+	if getStateByRange.Reverse {
+		if isCollectionSet(getStateByRange.Collection) || isMetadataSetForPagination(metadata) {
+			return nil, errors.New("reverse range queries are not supported on private data or with pagination")
+		}
+		rangeIter, err = txContext.TXSimulator.GetStateRangeScanIteratorReverse(chaincodeName, getStateByRange.StartKey, getStateByRange.EndKey)
+	} else if isCollectionSet(getStateByRange.Collection) {
 		rangeIter, err = txContext.TXSimulator.GetPrivateDataRangeScanIterator(chaincodeName, getStateByRange.Collection,
 			getStateByRange.StartKey, getStateByRange.EndKey)
 	} else if isMetadataSetForPagination(metadata) {
@@ -705,7 +710,7 @@ func (h *Handler) HandleQueryStateNext(msg *pb.ChaincodeMessage, txContext *Tran
 		return nil, errors.New("query iterator not found")
 	}
 
-	totalReturnLimit := calculateTotalReturnLimit(nil)
+	totalReturnLimit := calculateTotalReturnLimit(h.ChaincodeName(), nil)
 
 	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, queryIter, queryStateNext.Id, false, totalReturnLimit)
 	if err != nil {
@@ -760,7 +765,7 @@ func (h *Handler) HandleGetQueryResult(msg *pb.ChaincodeMessage, txContext *Tran
 		return nil, err
 	}
 
-	totalReturnLimit := calculateTotalReturnLimit(metadata)
+	totalReturnLimit := calculateTotalReturnLimit(h.ChaincodeName(), metadata)
 	isPaginated := false
 
 	var executeIter commonledger.ResultsIterator
@@ -818,7 +823,7 @@ func (h *Handler) HandleGetHistoryForKey(msg *pb.ChaincodeMessage, txContext *Tr
 		return nil, errors.WithStack(err)
 	}
 
-	totalReturnLimit := calculateTotalReturnLimit(nil)
+	totalReturnLimit := calculateTotalReturnLimit(h.ChaincodeName(), nil)
 
 	txContext.InitializeQueryContext(iterID, historyIter)
 	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, false, totalReturnLimit)
@@ -881,8 +886,10 @@ func createPaginationInfoFromMetadata(metadata *pb.QueryMetadata, totalReturnLim
 	return paginationInfoMap, nil
 }
 
-func calculateTotalReturnLimit(metadata *pb.QueryMetadata) int32 {
-	totalReturnLimit := int32(ledgerconfig.GetTotalQueryLimit())
+// calculateTotalReturnLimit returns the limit on the number of records returned
+// to the given chaincode by a query, which is bounded by the page size if any
+func calculateTotalReturnLimit(chaincodeName string, metadata *pb.QueryMetadata) int32 {
+	totalReturnLimit := int32(ledgerconfig.GetTotalQueryLimitForChaincode(chaincodeName))
 	if metadata != nil {
 		pageSize := int32(metadata.PageSize)
 		if pageSize > 0 && pageSize < totalReturnLimit {
@@ -1143,6 +1143,56 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("when reverse is set", func() {
+			BeforeEach(func() {
+				request.Reverse = true
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+
+				fakeTxSimulator.GetStateRangeScanIteratorReverseReturns(fakeIterator, nil)
+			})
+
+			It("calls GetStateRangeScanIteratorReverse on the transaction simulator", func() {
+				resp, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(expectedResponse))
+
+				Expect(fakeTxSimulator.GetStateRangeScanIteratorCallCount()).To(Equal(0))
+				Expect(fakeTxSimulator.GetStateRangeScanIteratorReverseCallCount()).To(Equal(1))
+				ccname, startKey, endKey := fakeTxSimulator.GetStateRangeScanIteratorReverseArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(startKey).To(Equal("get-state-start-key"))
+				Expect(endKey).To(Equal("get-state-end-key"))
+			})
+
+			Context("and GetStateRangeScanIteratorReverse fails", func() {
+				BeforeEach(func() {
+					fakeTxSimulator.GetStateRangeScanIteratorReverseReturns(nil, errors.New("parsnip"))
+				})
+
+				It("returns the error from GetStateRangeScanIteratorReverse", func() {
+					_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+					Expect(err).To(MatchError("parsnip"))
+				})
+			})
+
+			Context("and collection is set", func() {
+				BeforeEach(func() {
+					request.Collection = "collection-name"
+					payload, err := proto.Marshal(request)
+					Expect(err).NotTo(HaveOccurred())
+					incomingMessage.Payload = payload
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+					Expect(err).To(MatchError("reverse range queries are not supported on private data or with pagination"))
+					Expect(fakeTxSimulator.GetStateRangeScanIteratorReverseCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		Context("when unmarshalling the request fails", func() {
 			BeforeEach(func() {
 				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
@@ -0,0 +1,333 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ledgerstub runs chaincodes in the unit tests against an in-process
+// ledger, without a peer nor containers. Unlike shim.MockStub, the state is
+// held by the ledger of a peer, so that the chaincodes are exercised against
+// the actual semantics of the state database, of its range queries and of
+// its pagination, and their transactions are validated as on a peer, which
+// invalidates the transactions whose reads conflict with the writes of the
+// transactions committed before them.
+package ledgerstub
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/mocks/msp"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	lutils "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/core/scc/lscc"
+	mspi "github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// ChaincodeVersion is the version the chaincodes are instantiated with
+const ChaincodeVersion = "1.0"
+
+// Transaction is the result of the execution of a chaincode for a proposal
+type Transaction struct {
+	// TxID is the ID of the transaction
+	TxID string
+	// Response is the response of the chaincode
+	Response pb.Response
+	// Event is the event set by the chaincode, if any
+	Event *pb.ChaincodeEvent
+	// ValidationCode is the validation code of the transaction once committed,
+	// NOT_VALIDATED until then
+	ValidationCode pb.TxValidationCode
+
+	envelope *common.Envelope
+	pvtData  *rwset.TxPvtReadWriteSet
+}
+
+// Ledger is the ledger of a channel, against which chaincodes are executed
+// as by the endorsers of the channel, and whose transactions are validated
+// and committed as by its peers. The ledger is stored in a directory of its
+// own, as set in the peer.fileSystemPath config of viper. A Ledger may not
+// be used concurrently, nor along with other ledgers in the same process.
+type Ledger struct {
+	// ChannelID is the ID of the channel of the ledger
+	ChannelID string
+	// Creator is the serialized identity of the client submitting the
+	// proposals, as returned by the GetCreator function of the stub
+	Creator []byte
+
+	provider   ledger.PeerLedgerProvider
+	lgr        ledger.PeerLedger
+	chaincodes map[string]shim.Chaincode
+}
+
+// New creates the ledger of the given channel in the given directory, which
+// must not hold a ledger of the channel already
+func New(dir, channelID string) (*Ledger, error) {
+	viper.Set("peer.fileSystemPath", dir)
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+
+	provider, err := kvledger.NewProvider()
+	if err != nil {
+		return nil, err
+	}
+	provider.Initialize(&ledger.Initializer{
+		DeployedChaincodeInfoProvider: &lscc.DeployedCCInfoProvider{},
+	})
+	genesisBlock, err := genesisBlock(channelID)
+	if err != nil {
+		provider.Close()
+		return nil, err
+	}
+	lgr, err := provider.Create(genesisBlock)
+	if err != nil {
+		provider.Close()
+		return nil, err
+	}
+	return &Ledger{
+		ChannelID:  channelID,
+		provider:   provider,
+		lgr:        lgr,
+		chaincodes: make(map[string]shim.Chaincode),
+	}, nil
+}
+
+// genesisBlock creates a genesis block holding an empty config, as the
+// config of the channel is not processed by the ledger
+func genesisBlock(channelID string) (*common.Block, error) {
+	env, err := putils.CreateSignedEnvelope(common.HeaderType_CONFIG, channelID, nil, &common.ConfigEnvelope{Config: &common.Config{}}, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	block := common.NewBlock(0, nil)
+	block.Data.Data = [][]byte{putils.MarshalOrPanic(env)}
+	block.Header.DataHash = block.Data.Hash()
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = lutils.NewTxValidationFlagsSetValue(1, pb.TxValidationCode_VALID)
+	return block, nil
+}
+
+// Close closes the ledger, which remains in its directory
+func (l *Ledger) Close() {
+	l.lgr.Close()
+	l.provider.Close()
+}
+
+// Instantiate records the chaincode, along with the configs of its
+// collections if any, in the lscc namespace and executes its Init function
+// with the given arguments, in a single transaction committed in a block of
+// its own. The chaincode can then be executed under the given name.
+func (l *Ledger) Instantiate(name string, cc shim.Chaincode, collections *common.CollectionConfigPackage, args ...[]byte) (*Transaction, error) {
+	l.chaincodes[name] = cc
+	tx, err := l.execute(name, true, nil, args, func(txsim ledger.TxSimulator) error {
+		ccData, err := proto.Marshal(&ccprovider.ChaincodeData{Name: name, Version: ChaincodeVersion, Escc: "escc", Vscc: "vscc"})
+		if err != nil {
+			return err
+		}
+		if err := txsim.SetState("lscc", name, ccData); err != nil {
+			return err
+		}
+		if collections == nil {
+			return nil
+		}
+		collBytes, err := proto.Marshal(collections)
+		if err != nil {
+			return err
+		}
+		return txsim.SetState("lscc", privdata.BuildCollectionKVSKey(name), collBytes)
+	})
+	if err == nil && tx.Response.Status < shim.ERRORTHRESHOLD {
+		err = l.Commit(tx)
+	}
+	if err != nil || tx.ValidationCode != pb.TxValidationCode_VALID {
+		delete(l.chaincodes, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// Execute executes the Invoke function of the chaincode with the given
+// arguments against the current state of the ledger, as an endorser does. The
+// resulting transaction is not committed.
+func (l *Ledger) Execute(name string, args ...[]byte) (*Transaction, error) {
+	return l.ExecuteWithTransient(name, nil, args...)
+}
+
+// ExecuteWithTransient executes the Invoke function of the chaincode as
+// Execute does, with the given transient data in the proposal
+func (l *Ledger) ExecuteWithTransient(name string, transient map[string][]byte, args ...[]byte) (*Transaction, error) {
+	if _, ok := l.chaincodes[name]; !ok {
+		return nil, errors.Errorf("chaincode [%s] is not instantiated", name)
+	}
+	return l.execute(name, false, transient, args, nil)
+}
+
+// Invoke executes the Invoke function of the chaincode with the given
+// arguments, and commits the resulting transaction in a block of its own if
+// the chaincode succeeds
+func (l *Ledger) Invoke(name string, args ...[]byte) (*Transaction, error) {
+	tx, err := l.Execute(name, args...)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Response.Status >= shim.ERRORTHRESHOLD {
+		return tx, nil
+	}
+	return tx, l.Commit(tx)
+}
+
+func (l *Ledger) execute(name string, init bool, transient map[string][]byte, args [][]byte, lifecycle func(txsim ledger.TxSimulator) error) (*Transaction, error) {
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_GOLANG,
+			ChaincodeId: &pb.ChaincodeID{Name: name},
+			Input:       &pb.ChaincodeInput{Args: args},
+		},
+	}
+	prop, txID, err := putils.CreateChaincodeProposalWithTransient(common.HeaderType_ENDORSER_TRANSACTION, l.ChannelID, cis, l.Creator, transient)
+	if err != nil {
+		return nil, err
+	}
+	txsim, err := l.lgr.NewTxSimulator(txID)
+	if err != nil {
+		return nil, err
+	}
+	defer txsim.Done()
+
+	if lifecycle != nil {
+		if err := lifecycle(txsim); err != nil {
+			return nil, err
+		}
+	}
+	stub, err := newStub(l, name, txsim, prop, args)
+	if err != nil {
+		return nil, err
+	}
+	cc := l.chaincodes[name]
+	var res pb.Response
+	if init {
+		res = cc.Init(stub)
+	} else {
+		res = cc.Invoke(stub)
+	}
+	tx := &Transaction{TxID: txID, Response: res, Event: stub.event, ValidationCode: pb.TxValidationCode_NOT_VALIDATED}
+	if res.Status >= shim.ERRORTHRESHOLD {
+		return tx, nil
+	}
+
+	simRes, err := txsim.GetTxSimulationResults()
+	if err != nil {
+		return nil, err
+	}
+	pubSimRes, err := simRes.GetPubSimulationBytes()
+	if err != nil {
+		return nil, err
+	}
+	var eventBytes []byte
+	if tx.Event != nil {
+		if eventBytes, err = proto.Marshal(tx.Event); err != nil {
+			return nil, err
+		}
+	}
+	signer := &creatorSigner{SigningIdentity: noopSigner(), creator: l.Creator}
+	presp, err := putils.CreateProposalResponse(prop.Header, prop.Payload, &res, pubSimRes, eventBytes, cis.ChaincodeSpec.ChaincodeId, nil, signer)
+	if err != nil {
+		return nil, err
+	}
+	if tx.envelope, err = putils.CreateSignedTx(prop, signer, presp); err != nil {
+		return nil, err
+	}
+	tx.pvtData = simRes.PvtSimulationResults
+	return tx, nil
+}
+
+// Commit commits the given transactions in a block, in the given order, and
+// sets their validation codes. As on a peer, the transactions whose reads
+// conflict with the writes of the transactions before them are invalidated.
+func (l *Ledger) Commit(txs ...*Transaction) error {
+	info, err := l.lgr.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	block := common.NewBlock(info.Height, info.CurrentBlockHash)
+	pvtData := make(map[uint64]*ledger.TxPvtData)
+	for i, tx := range txs {
+		if tx.envelope == nil {
+			return errors.Errorf("transaction [%s] cannot be committed, the chaincode returned status %d: %s", tx.TxID, tx.Response.Status, tx.Response.Message)
+		}
+		if tx.ValidationCode != pb.TxValidationCode_NOT_VALIDATED {
+			return errors.Errorf("transaction [%s] is already committed", tx.TxID)
+		}
+		block.Data.Data = append(block.Data.Data, putils.MarshalOrPanic(tx.envelope))
+		if tx.pvtData != nil {
+			pvtData[uint64(i)] = &ledger.TxPvtData{SeqInBlock: uint64(i), WriteSet: tx.pvtData}
+		}
+	}
+	block.Header.DataHash = block.Data.Hash()
+	flags := lutils.NewTxValidationFlagsSetValue(len(txs), pb.TxValidationCode_VALID)
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = flags
+
+	if err := l.lgr.CommitWithPvtData(&ledger.BlockAndPvtData{Block: block, BlockPvtData: pvtData}); err != nil {
+		return err
+	}
+	// the ledger sets the validation codes of the transactions in the block
+	flags = lutils.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for i, tx := range txs {
+		tx.ValidationCode = flags.Flag(i)
+	}
+	return nil
+}
+
+// GetState returns the value of the key in the state of the chaincode
+func (l *Ledger) GetState(name, key string) ([]byte, error) {
+	qe, err := l.lgr.NewQueryExecutor()
+	if err != nil {
+		return nil, err
+	}
+	defer qe.Done()
+	return qe.GetState(name, key)
+}
+
+// GetPrivateData returns the value of the key in the given collection of the
+// chaincode
+func (l *Ledger) GetPrivateData(name, collection, key string) ([]byte, error) {
+	qe, err := l.lgr.NewQueryExecutor()
+	if err != nil {
+		return nil, err
+	}
+	defer qe.Done()
+	return qe.GetPrivateData(name, collection, key)
+}
+
+// Height returns the number of blocks of the ledger
+func (l *Ledger) Height() (uint64, error) {
+	info, err := l.lgr.GetBlockchainInfo()
+	if err != nil {
+		return 0, err
+	}
+	return info.Height, nil
+}
+
+func noopSigner() mspi.SigningIdentity {
+	signer, _ := msp.NewNoopMsp().GetDefaultSigningIdentity()
+	return signer
+}
+
+// creatorSigner stands for the client and the endorsers, the transactions are
+// not signed as their signatures are not checked by the ledger
+type creatorSigner struct {
+	mspi.SigningIdentity
+	creator []byte
+}
+
+func (s *creatorSigner) Serialize() ([]byte, error) {
+	return s.creator, nil
+}
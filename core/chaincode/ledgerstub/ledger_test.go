@@ -0,0 +1,524 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledgerstub
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testChaincode exposes the functions of the stub used by the tests
+type testChaincode struct{}
+
+func (t *testChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	args := stub.GetStringArgs()
+	for i := 0; i+1 < len(args); i += 2 {
+		if err := stub.PutState(args[i], []byte(args[i+1])); err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+	return shim.Success(nil)
+}
+
+func (t *testChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	fn, args := stub.GetFunctionAndParameters()
+	switch fn {
+	case "put":
+		if err := stub.PutState(args[0], []byte(args[1])); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "get":
+		value, err := stub.GetState(args[0])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(value)
+	case "del":
+		if err := stub.DelState(args[0]); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "increment":
+		value, err := stub.GetState(args[0])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		n, _ := strconv.Atoi(string(value))
+		if err := stub.PutState(args[0], []byte(strconv.Itoa(n+1))); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "count":
+		// writes the number of keys in the range to the given key
+		itr, err := stub.GetStateByRange(args[0], args[1])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		keys, err := readKeys(itr)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		if err := stub.PutState(args[2], []byte(strconv.Itoa(len(keys)))); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "range":
+		itr, err := stub.GetStateByRange(args[0], args[1])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		keys, err := readKeys(itr)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success([]byte(strings.Join(keys, ",")))
+	case "reverse":
+		itr, err := stub.GetStateByRangeReverse(args[0], args[1])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		keys, err := readKeys(itr)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success([]byte(strings.Join(keys, ",")))
+	case "page":
+		pageSize, _ := strconv.Atoi(args[2])
+		itr, metadata, err := stub.GetStateByRangeWithPagination(args[0], args[1], int32(pageSize), args[3])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		keys, err := readKeys(itr)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success([]byte(fmt.Sprintf("%s|%d|%s", strings.Join(keys, ","), metadata.FetchedRecordsCount, metadata.Bookmark)))
+	case "putcomposite":
+		key, err := stub.CreateCompositeKey(args[0], args[1:len(args)-1])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		if err := stub.PutState(key, []byte(args[len(args)-1])); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "partial", "partialreverse":
+		getStateByPartialCompositeKey := stub.GetStateByPartialCompositeKey
+		if fn == "partialreverse" {
+			getStateByPartialCompositeKey = stub.GetStateByPartialCompositeKeyReverse
+		}
+		itr, err := getStateByPartialCompositeKey(args[0], args[1:])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		var values []string
+		for itr.HasNext() {
+			kv, err := itr.Next()
+			if err != nil {
+				return shim.Error(err.Error())
+			}
+			values = append(values, string(kv.Value))
+		}
+		itr.Close()
+		return shim.Success([]byte(strings.Join(values, ",")))
+	case "query":
+		if _, err := stub.GetQueryResult(args[0]); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "putpvt":
+		if err := stub.PutPrivateData(args[0], args[1], []byte(args[2])); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "getpvt":
+		value, err := stub.GetPrivateData(args[0], args[1])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(value)
+	case "history":
+		itr, err := stub.GetHistoryForKey(args[0])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		var values []string
+		for itr.HasNext() {
+			km, err := itr.Next()
+			if err != nil {
+				return shim.Error(err.Error())
+			}
+			if km.IsDelete {
+				values = append(values, "<deleted>")
+			} else {
+				values = append(values, string(km.Value))
+			}
+		}
+		itr.Close()
+		return shim.Success([]byte(strings.Join(values, ",")))
+	case "event":
+		if err := stub.SetEvent(args[0], []byte(args[1])); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "call":
+		return stub.InvokeChaincode(args[0], toChaincodeArgs(args[2:]...), args[1])
+	case "creator":
+		creator, err := stub.GetCreator()
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(creator)
+	case "transient":
+		transient, err := stub.GetTransient()
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(transient[args[0]])
+	case "setvp":
+		if err := stub.SetStateValidationParameter(args[0], []byte(args[1])); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "getvp":
+		ep, err := stub.GetStateValidationParameter(args[0])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(ep)
+	case "fail":
+		return shim.Error("failed")
+	}
+	return shim.Error("unknown function " + fn)
+}
+
+func readKeys(itr shim.StateQueryIteratorInterface) ([]string, error) {
+	defer itr.Close()
+	var keys []string
+	for itr.HasNext() {
+		kv, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, kv.Key)
+	}
+	if itr.Truncated() {
+		keys = append(keys, "...")
+	}
+	return keys, nil
+}
+
+func toChaincodeArgs(args ...string) [][]byte {
+	bargs := make([][]byte, len(args))
+	for i, arg := range args {
+		bargs[i] = []byte(arg)
+	}
+	return bargs
+}
+
+func newTestLedger(t *testing.T) (*Ledger, func()) {
+	dir, err := ioutil.TempDir("", "ledgerstub")
+	require.NoError(t, err)
+	l, err := New(dir, "testchannel")
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("failed creating the ledger: %s", err)
+	}
+	return l, func() {
+		l.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func invoke(t *testing.T, l *Ledger, name string, args ...string) *Transaction {
+	tx, err := l.Invoke(name, toChaincodeArgs(args...)...)
+	require.NoError(t, err)
+	return tx
+}
+
+func TestInstantiateAndInvoke(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	tx, err := l.Instantiate("mycc", &testChaincode{}, nil, toChaincodeArgs("a", "100")...)
+	require.NoError(t, err)
+	assert.Equal(t, pb.TxValidationCode_VALID, tx.ValidationCode)
+	value, err := l.GetState("mycc", "a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("100"), value)
+
+	tx = invoke(t, l, "mycc", "put", "b", "200")
+	assert.Equal(t, int32(shim.OK), tx.Response.Status)
+	assert.Equal(t, pb.TxValidationCode_VALID, tx.ValidationCode)
+	tx = invoke(t, l, "mycc", "get", "b")
+	assert.Equal(t, []byte("200"), tx.Response.Payload)
+	height, err := l.Height()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), height)
+
+	// the failed transactions are not committed
+	tx = invoke(t, l, "mycc", "fail")
+	assert.Equal(t, int32(shim.ERROR), tx.Response.Status)
+	assert.Equal(t, pb.TxValidationCode_NOT_VALIDATED, tx.ValidationCode)
+	err = l.Commit(tx)
+	assert.EqualError(t, err, fmt.Sprintf("transaction [%s] cannot be committed, the chaincode returned status 500: failed", tx.TxID))
+	height, err = l.Height()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), height)
+
+	tx = invoke(t, l, "mycc", "put", "", "value")
+	assert.Equal(t, "key must not be an empty string", tx.Response.Message)
+
+	_, err = l.Execute("yourcc", []byte("get"), []byte("a"))
+	assert.EqualError(t, err, "chaincode [yourcc] is not instantiated")
+}
+
+func TestInstantiateFailure(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	tx, err := l.Instantiate("mycc", &testChaincode{}, nil, toChaincodeArgs("", "100")...)
+	require.NoError(t, err)
+	assert.Equal(t, int32(shim.ERROR), tx.Response.Status)
+	_, err = l.Execute("mycc", []byte("get"), []byte("a"))
+	assert.EqualError(t, err, "chaincode [mycc] is not instantiated")
+}
+
+func TestMVCCConflict(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	_, err := l.Instantiate("mycc", &testChaincode{}, nil, toChaincodeArgs("counter", "0")...)
+	require.NoError(t, err)
+
+	// both transactions read the counter before either is committed
+	tx1, err := l.Execute("mycc", toChaincodeArgs("increment", "counter")...)
+	require.NoError(t, err)
+	tx2, err := l.Execute("mycc", toChaincodeArgs("increment", "counter")...)
+	require.NoError(t, err)
+	assert.NoError(t, l.Commit(tx1, tx2))
+	assert.Equal(t, pb.TxValidationCode_VALID, tx1.ValidationCode)
+	assert.Equal(t, pb.TxValidationCode_MVCC_READ_CONFLICT, tx2.ValidationCode)
+
+	value, err := l.GetState("mycc", "counter")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+
+	err = l.Commit(tx1)
+	assert.EqualError(t, err, fmt.Sprintf("transaction [%s] is already committed", tx1.TxID))
+}
+
+func TestPhantomRead(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	_, err := l.Instantiate("mycc", &testChaincode{}, nil, toChaincodeArgs("key1", "1", "key3", "3")...)
+	require.NoError(t, err)
+
+	count, err := l.Execute("mycc", toChaincodeArgs("count", "key1", "key9", "count")...)
+	require.NoError(t, err)
+	put, err := l.Execute("mycc", toChaincodeArgs("put", "key2", "2")...)
+	require.NoError(t, err)
+	assert.NoError(t, l.Commit(put, count))
+	assert.Equal(t, pb.TxValidationCode_VALID, put.ValidationCode)
+	assert.Equal(t, pb.TxValidationCode_PHANTOM_READ_CONFLICT, count.ValidationCode)
+}
+
+func TestRangeQueries(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	_, err := l.Instantiate("mycc", &testChaincode{}, nil, toChaincodeArgs("a", "1", "b", "2", "c", "3", "d", "4")...)
+	require.NoError(t, err)
+	invoke(t, l, "mycc", "putcomposite", "color~name", "blue", "sky", "x")
+	invoke(t, l, "mycc", "putcomposite", "color~name", "blue", "sea", "y")
+	invoke(t, l, "mycc", "putcomposite", "color~name", "red", "rose", "z")
+
+	// the end key is excluded, and the composite keys are not in the range
+	// of the simple keys
+	tx := invoke(t, l, "mycc", "range", "b", "d")
+	assert.Equal(t, "b,c", string(tx.Response.Payload))
+	tx = invoke(t, l, "mycc", "range", "", "")
+	assert.Equal(t, "a,b,c,d", string(tx.Response.Payload))
+	tx = invoke(t, l, "mycc", "range", "\x00a", "")
+	assert.Equal(t, "first character of the key [\x00a] contains a null character which is not allowed", tx.Response.Message)
+
+	tx = invoke(t, l, "mycc", "partial", "color~name", "blue")
+	assert.Equal(t, "y,x", string(tx.Response.Payload))
+
+	tx = invoke(t, l, "mycc", "reverse", "b", "d")
+	assert.Equal(t, "c,b", string(tx.Response.Payload))
+	tx = invoke(t, l, "mycc", "reverse", "", "")
+	assert.Equal(t, "d,c,b,a", string(tx.Response.Payload))
+	tx = invoke(t, l, "mycc", "partialreverse", "color~name", "blue")
+	assert.Equal(t, "x,y", string(tx.Response.Payload))
+
+	// rich queries are not supported by LevelDB
+	tx = invoke(t, l, "mycc", "query", `{"selector":{}}`)
+	assert.Equal(t, int32(shim.ERROR), tx.Response.Status)
+}
+
+func TestPagination(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	_, err := l.Instantiate("mycc", &testChaincode{}, nil, toChaincodeArgs("a", "1", "b", "2", "c", "3", "d", "4", "e", "5")...)
+	require.NoError(t, err)
+
+	tx := invoke(t, l, "mycc", "page", "", "", "2", "")
+	assert.Equal(t, "a,b|2|c", string(tx.Response.Payload))
+	tx = invoke(t, l, "mycc", "page", "", "", "2", "c")
+	assert.Equal(t, "c,d|2|e", string(tx.Response.Payload))
+	tx = invoke(t, l, "mycc", "page", "", "", "2", "e")
+	assert.Equal(t, "e|1|", string(tx.Response.Payload))
+	tx = invoke(t, l, "mycc", "page", "b", "d", "0", "")
+	assert.Equal(t, "b,c|2|", string(tx.Response.Payload))
+}
+
+func TestQueryLimit(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+	viper.Set("ledger.state.chaincodeQueryLimits", []map[string]interface{}{{"chaincode": "mycc", "totalQueryLimit": 2}})
+	defer viper.Set("ledger.state.chaincodeQueryLimits", nil)
+
+	_, err := l.Instantiate("mycc", &testChaincode{}, nil, toChaincodeArgs("a", "1", "b", "2", "c", "3")...)
+	require.NoError(t, err)
+
+	tx := invoke(t, l, "mycc", "range", "", "")
+	assert.Equal(t, "a,b,...", string(tx.Response.Payload))
+	tx = invoke(t, l, "mycc", "range", "b", "")
+	assert.Equal(t, "b,c", string(tx.Response.Payload))
+	tx = invoke(t, l, "mycc", "page", "", "", "5", "")
+	assert.Equal(t, "a,b|2|c", string(tx.Response.Payload))
+}
+
+func TestPrivateData(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	collections := &common.CollectionConfigPackage{
+		Config: []*common.CollectionConfig{
+			{
+				Payload: &common.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: &common.StaticCollectionConfig{Name: "secrets", MaximumPeerCount: 1},
+				},
+			},
+		},
+	}
+	_, err := l.Instantiate("mycc", &testChaincode{}, collections)
+	require.NoError(t, err)
+
+	tx := invoke(t, l, "mycc", "putpvt", "secrets", "key", "secret")
+	assert.Equal(t, pb.TxValidationCode_VALID, tx.ValidationCode)
+	value, err := l.GetPrivateData("mycc", "secrets", "key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("secret"), value)
+	tx = invoke(t, l, "mycc", "getpvt", "secrets", "key")
+	assert.Equal(t, []byte("secret"), tx.Response.Payload)
+
+	// the private data is not in the public state
+	value, err = l.GetState("mycc", "key")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	tx = invoke(t, l, "mycc", "putpvt", "others", "key", "secret")
+	assert.Equal(t, "collection [others] not defined in the collection config for chaincode [mycc]", tx.Response.Message)
+	tx = invoke(t, l, "mycc", "putpvt", "", "key", "secret")
+	assert.Equal(t, "collection must not be an empty string", tx.Response.Message)
+}
+
+func TestHistory(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	_, err := l.Instantiate("mycc", &testChaincode{}, nil, toChaincodeArgs("a", "1")...)
+	require.NoError(t, err)
+	invoke(t, l, "mycc", "put", "a", "2")
+	invoke(t, l, "mycc", "del", "a")
+
+	tx := invoke(t, l, "mycc", "history", "a")
+	assert.Equal(t, "1,2,<deleted>", string(tx.Response.Payload))
+}
+
+func TestEvent(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	_, err := l.Instantiate("mycc", &testChaincode{}, nil)
+	require.NoError(t, err)
+
+	tx := invoke(t, l, "mycc", "event", "transfer", "payload")
+	assert.True(t, proto.Equal(&pb.ChaincodeEvent{ChaincodeId: "mycc", TxId: tx.TxID, EventName: "transfer", Payload: []byte("payload")}, tx.Event))
+	tx = invoke(t, l, "mycc", "event", "", "payload")
+	assert.Equal(t, "event name can not be nil string", tx.Response.Message)
+}
+
+func TestInvokeChaincode(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+
+	_, err := l.Instantiate("mycc", &testChaincode{}, nil)
+	require.NoError(t, err)
+	_, err = l.Instantiate("yourcc", &testChaincode{}, nil, toChaincodeArgs("a", "1")...)
+	require.NoError(t, err)
+
+	// the called chaincode writes to its own namespace, in the same transaction
+	tx := invoke(t, l, "mycc", "call", "yourcc", "", "put", "b", "2")
+	assert.Equal(t, pb.TxValidationCode_VALID, tx.ValidationCode)
+	value, err := l.GetState("yourcc", "b")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("2"), value)
+	value, err = l.GetState("mycc", "b")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	tx = invoke(t, l, "mycc", "call", "yourcc", "testchannel", "get", "a")
+	assert.Equal(t, []byte("1"), tx.Response.Payload)
+	tx = invoke(t, l, "mycc", "call", "yourcc", "otherchannel", "get", "a")
+	assert.Equal(t, "invoking the chaincodes of other channels is not supported", tx.Response.Message)
+	tx = invoke(t, l, "mycc", "call", "theircc", "", "get", "a")
+	assert.Equal(t, "chaincode [theircc] is not instantiated", tx.Response.Message)
+}
+
+func TestProposal(t *testing.T) {
+	l, cleanup := newTestLedger(t)
+	defer cleanup()
+	l.Creator = []byte("creator")
+
+	_, err := l.Instantiate("mycc", &testChaincode{}, nil)
+	require.NoError(t, err)
+
+	tx := invoke(t, l, "mycc", "creator")
+	assert.Equal(t, []byte("creator"), tx.Response.Payload)
+	tx, err = l.ExecuteWithTransient("mycc", map[string][]byte{"key": []byte("transient")}, toChaincodeArgs("transient", "key")...)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("transient"), tx.Response.Payload)
+
+	// as on a peer, the writes of a transaction are only read once committed
+	invoke(t, l, "mycc", "put", "a", "1")
+	tx, err = l.Execute("mycc", toChaincodeArgs("setvp", "a", "policy")...)
+	require.NoError(t, err)
+	tx, err = l.Execute("mycc", toChaincodeArgs("getvp", "a")...)
+	require.NoError(t, err)
+	assert.Nil(t, tx.Response.Payload)
+	invoke(t, l, "mycc", "setvp", "a", "policy")
+	tx = invoke(t, l, "mycc", "getvp", "a")
+	assert.Equal(t, []byte("policy"), tx.Response.Payload)
+}
@@ -0,0 +1,560 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledgerstub
+
+import (
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// compositeKeyNamespace prefixes the composite keys, which are hence not
+	// returned by the range queries of the simple keys
+	compositeKeyNamespace = "\x00"
+	emptyKeySubstitute    = "\x01"
+)
+
+var validationParameterMetakey = pb.MetaDataKeys_VALIDATION_PARAMETER.String()
+
+// stub implements the ChaincodeStubInterface against a transaction simulator
+// of the ledger, as the handler of the peer does for the chaincode stubs
+type stub struct {
+	ledger    *Ledger
+	namespace string
+	txsim     ledger.TxSimulator
+	txID      string
+	args      [][]byte
+
+	signedProp *pb.SignedProposal
+	chdr       *common.ChannelHeader
+	shdr       *common.SignatureHeader
+	transient  map[string][]byte
+	binding    []byte
+
+	event *pb.ChaincodeEvent
+}
+
+func newStub(l *Ledger, namespace string, txsim ledger.TxSimulator, prop *pb.Proposal, args [][]byte) (*stub, error) {
+	hdr, err := putils.GetHeader(prop.Header)
+	if err != nil {
+		return nil, err
+	}
+	chdr, err := putils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	shdr, err := putils.GetSignatureHeader(hdr.SignatureHeader)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := putils.GetChaincodeProposalPayload(prop.Payload)
+	if err != nil {
+		return nil, err
+	}
+	binding, err := putils.ComputeProposalBinding(prop)
+	if err != nil {
+		return nil, err
+	}
+	return &stub{
+		ledger:     l,
+		namespace:  namespace,
+		txsim:      txsim,
+		txID:       chdr.TxId,
+		args:       args,
+		signedProp: &pb.SignedProposal{ProposalBytes: putils.MarshalOrPanic(prop)},
+		chdr:       chdr,
+		shdr:       shdr,
+		transient:  payload.TransientMap,
+		binding:    binding,
+	}, nil
+}
+
+func (s *stub) GetArgs() [][]byte {
+	return s.args
+}
+
+func (s *stub) GetStringArgs() []string {
+	strargs := make([]string, 0, len(s.args))
+	for _, barg := range s.args {
+		strargs = append(strargs, string(barg))
+	}
+	return strargs
+}
+
+func (s *stub) GetFunctionAndParameters() (string, []string) {
+	allargs := s.GetStringArgs()
+	if len(allargs) == 0 {
+		return "", []string{}
+	}
+	return allargs[0], allargs[1:]
+}
+
+func (s *stub) GetArgsSlice() ([]byte, error) {
+	res := []byte{}
+	for _, barg := range s.args {
+		res = append(res, barg...)
+	}
+	return res, nil
+}
+
+func (s *stub) GetTxID() string {
+	return s.txID
+}
+
+func (s *stub) GetChannelID() string {
+	return s.chdr.ChannelId
+}
+
+// InvokeChaincode executes the Invoke function of a chaincode of the ledger
+// within the transaction, against the namespace of the called chaincode.
+// The chaincodes of other channels cannot be invoked.
+func (s *stub) InvokeChaincode(chaincodeName string, args [][]byte, channel string) pb.Response {
+	if channel != "" && channel != s.chdr.ChannelId {
+		return shim.Error("invoking the chaincodes of other channels is not supported")
+	}
+	cc, ok := s.ledger.chaincodes[chaincodeName]
+	if !ok {
+		return shim.Error(errors.Errorf("chaincode [%s] is not instantiated", chaincodeName).Error())
+	}
+	called := *s
+	called.namespace = chaincodeName
+	called.args = args
+	called.event = nil
+	return cc.Invoke(&called)
+}
+
+func (s *stub) GetState(key string) ([]byte, error) {
+	return s.txsim.GetState(s.namespace, key)
+}
+
+func (s *stub) PutState(key string, value []byte) error {
+	if key == "" {
+		return errors.New("key must not be an empty string")
+	}
+	return s.txsim.SetState(s.namespace, key, value)
+}
+
+func (s *stub) DelState(key string) error {
+	return s.txsim.DeleteState(s.namespace, key)
+}
+
+func (s *stub) SetStateValidationParameter(key string, ep []byte) error {
+	return s.SetPrivateDataValidationParameter("", key, ep)
+}
+
+func (s *stub) GetStateValidationParameter(key string) ([]byte, error) {
+	return s.GetPrivateDataValidationParameter("", key)
+}
+
+func (s *stub) GetStateByRange(startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
+	if startKey == "" {
+		startKey = emptyKeySubstitute
+	}
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, err
+	}
+	itr, err := s.txsim.GetStateRangeScanIterator(s.namespace, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	return &stateIterator{s.newResultsIterator(itr)}, nil
+}
+
+func (s *stub) GetStateByRangeReverse(startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
+	if startKey == "" {
+		startKey = emptyKeySubstitute
+	}
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, err
+	}
+	itr, err := s.txsim.GetStateRangeScanIteratorReverse(s.namespace, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	return &stateIterator{s.newResultsIterator(itr)}, nil
+}
+
+func (s *stub) GetStateByRangeWithPagination(startKey, endKey string, pageSize int32,
+	bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	if startKey == "" {
+		startKey = emptyKeySubstitute
+	}
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, nil, err
+	}
+	return s.getStateByRangeWithPagination(startKey, endKey, pageSize, bookmark)
+}
+
+func (s *stub) GetStateByPartialCompositeKey(objectType string, keys []string) (shim.StateQueryIteratorInterface, error) {
+	startKey, endKey, err := s.partialCompositeKeyRange(objectType, keys)
+	if err != nil {
+		return nil, err
+	}
+	itr, err := s.txsim.GetStateRangeScanIterator(s.namespace, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	return &stateIterator{s.newResultsIterator(itr)}, nil
+}
+
+func (s *stub) GetStateByPartialCompositeKeyReverse(objectType string, keys []string) (shim.StateQueryIteratorInterface, error) {
+	startKey, endKey, err := s.partialCompositeKeyRange(objectType, keys)
+	if err != nil {
+		return nil, err
+	}
+	itr, err := s.txsim.GetStateRangeScanIteratorReverse(s.namespace, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	return &stateIterator{s.newResultsIterator(itr)}, nil
+}
+
+func (s *stub) GetStateByPartialCompositeKeyWithPagination(objectType string, keys []string,
+	pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	startKey, endKey, err := s.partialCompositeKeyRange(objectType, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.getStateByRangeWithPagination(startKey, endKey, pageSize, bookmark)
+}
+
+// getStateByRangeWithPagination reads a page of the range as the peer does,
+// starting at the bookmark if any
+func (s *stub) getStateByRangeWithPagination(startKey, endKey string, pageSize int32,
+	bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	if bookmark != "" {
+		startKey = bookmark
+	}
+	itr, err := s.txsim.GetStateRangeScanIteratorWithMetadata(s.namespace, startKey, endKey, map[string]interface{}{
+		"limit": s.pageLimit(pageSize),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return readPage(itr)
+}
+
+func (s *stub) CreateCompositeKey(objectType string, attributes []string) (string, error) {
+	return (&shim.ChaincodeStub{}).CreateCompositeKey(objectType, attributes)
+}
+
+func (s *stub) SplitCompositeKey(compositeKey string) (string, []string, error) {
+	return (&shim.ChaincodeStub{}).SplitCompositeKey(compositeKey)
+}
+
+func (s *stub) partialCompositeKeyRange(objectType string, attributes []string) (string, string, error) {
+	partialCompositeKey, err := s.CreateCompositeKey(objectType, attributes)
+	if err != nil {
+		return "", "", err
+	}
+	return partialCompositeKey, partialCompositeKey + string(utf8.MaxRune), nil
+}
+
+// GetQueryResult executes the rich query against the state database of the
+// ledger, which fails as rich queries are not supported by LevelDB
+func (s *stub) GetQueryResult(query string) (shim.StateQueryIteratorInterface, error) {
+	itr, err := s.txsim.ExecuteQuery(s.namespace, query)
+	if err != nil {
+		return nil, err
+	}
+	return &stateIterator{s.newResultsIterator(itr)}, nil
+}
+
+func (s *stub) GetQueryResultWithPagination(query string, pageSize int32,
+	bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	itr, err := s.txsim.ExecuteQueryWithMetadata(s.namespace, query, map[string]interface{}{
+		"bookmark": bookmark,
+		"limit":    s.pageLimit(pageSize),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return readPage(itr)
+}
+
+func (s *stub) GetHistoryForKey(key string) (shim.HistoryQueryIteratorInterface, error) {
+	hqe, err := s.ledger.lgr.NewHistoryQueryExecutor()
+	if err != nil {
+		return nil, err
+	}
+	itr, err := hqe.GetHistoryForKey(s.namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	return &historyIterator{s.newResultsIterator(itr)}, nil
+}
+
+func (s *stub) GetPrivateData(collection, key string) ([]byte, error) {
+	if collection == "" {
+		return nil, errors.New("collection must not be an empty string")
+	}
+	return s.txsim.GetPrivateData(s.namespace, collection, key)
+}
+
+func (s *stub) PutPrivateData(collection string, key string, value []byte) error {
+	if collection == "" {
+		return errors.New("collection must not be an empty string")
+	}
+	if key == "" {
+		return errors.New("key must not be an empty string")
+	}
+	return s.txsim.SetPrivateData(s.namespace, collection, key, value)
+}
+
+func (s *stub) DelPrivateData(collection, key string) error {
+	if collection == "" {
+		return errors.New("collection must not be an empty string")
+	}
+	return s.txsim.DeletePrivateData(s.namespace, collection, key)
+}
+
+func (s *stub) SetPrivateDataValidationParameter(collection, key string, ep []byte) error {
+	metadata, err := s.getMetadata(collection, key)
+	if err != nil {
+		return err
+	}
+	if metadata == nil {
+		metadata = make(map[string][]byte)
+	}
+	metadata[validationParameterMetakey] = ep
+	if collection == "" {
+		return s.txsim.SetStateMetadata(s.namespace, key, metadata)
+	}
+	return s.txsim.SetPrivateDataMetadata(s.namespace, collection, key, metadata)
+}
+
+func (s *stub) GetPrivateDataValidationParameter(collection, key string) ([]byte, error) {
+	metadata, err := s.getMetadata(collection, key)
+	if err != nil {
+		return nil, err
+	}
+	return metadata[validationParameterMetakey], nil
+}
+
+func (s *stub) getMetadata(collection, key string) (map[string][]byte, error) {
+	if collection == "" {
+		return s.txsim.GetStateMetadata(s.namespace, key)
+	}
+	return s.txsim.GetPrivateDataMetadata(s.namespace, collection, key)
+}
+
+func (s *stub) GetPrivateDataByRange(collection, startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
+	if collection == "" {
+		return nil, errors.New("collection must not be an empty string")
+	}
+	if startKey == "" {
+		startKey = emptyKeySubstitute
+	}
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, err
+	}
+	itr, err := s.txsim.GetPrivateDataRangeScanIterator(s.namespace, collection, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	return &stateIterator{s.newResultsIterator(itr)}, nil
+}
+
+func (s *stub) GetPrivateDataByPartialCompositeKey(collection, objectType string, keys []string) (shim.StateQueryIteratorInterface, error) {
+	if collection == "" {
+		return nil, errors.New("collection must not be an empty string")
+	}
+	startKey, endKey, err := s.partialCompositeKeyRange(objectType, keys)
+	if err != nil {
+		return nil, err
+	}
+	itr, err := s.txsim.GetPrivateDataRangeScanIterator(s.namespace, collection, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	return &stateIterator{s.newResultsIterator(itr)}, nil
+}
+
+// GetPrivateDataQueryResult executes the rich query against the private data
+// of the ledger, which fails as rich queries are not supported by LevelDB
+func (s *stub) GetPrivateDataQueryResult(collection, query string) (shim.StateQueryIteratorInterface, error) {
+	if collection == "" {
+		return nil, errors.New("collection must not be an empty string")
+	}
+	itr, err := s.txsim.ExecuteQueryOnPrivateData(s.namespace, collection, query)
+	if err != nil {
+		return nil, err
+	}
+	return &stateIterator{s.newResultsIterator(itr)}, nil
+}
+
+func (s *stub) GetCreator() ([]byte, error) {
+	return s.shdr.Creator, nil
+}
+
+func (s *stub) GetTransient() (map[string][]byte, error) {
+	return s.transient, nil
+}
+
+func (s *stub) GetBinding() ([]byte, error) {
+	return s.binding, nil
+}
+
+func (s *stub) GetDecorations() map[string][]byte {
+	return nil
+}
+
+func (s *stub) GetSignedProposal() (*pb.SignedProposal, error) {
+	return s.signedProp, nil
+}
+
+func (s *stub) GetTxTimestamp() (*timestamp.Timestamp, error) {
+	return s.chdr.Timestamp, nil
+}
+
+func (s *stub) GetTxValidityWindow() (*common.ValidityWindow, error) {
+	return s.chdr.ValidityWindow, nil
+}
+
+func (s *stub) SetEvent(name string, payload []byte) error {
+	if name == "" {
+		return errors.New("event name can not be nil string")
+	}
+	s.event = &pb.ChaincodeEvent{ChaincodeId: s.namespace, TxId: s.txID, EventName: name, Payload: payload}
+	return nil
+}
+
+func validateSimpleKeys(simpleKeys ...string) error {
+	for _, key := range simpleKeys {
+		if len(key) > 0 && key[0] == compositeKeyNamespace[0] {
+			return errors.Errorf(`first character of the key [%s] contains a null character which is not allowed`, key)
+		}
+	}
+	return nil
+}
+
+// pageLimit returns the number of results of a page, bounded by the total
+// query limit of the chaincode
+func (s *stub) pageLimit(pageSize int32) int32 {
+	limit := int32(ledgerconfig.GetTotalQueryLimitForChaincode(s.namespace))
+	if pageSize > 0 && pageSize < limit {
+		return pageSize
+	}
+	return limit
+}
+
+// readPage reads the results of a page and returns an iterator over them,
+// along with the bookmark of the next page
+func readPage(itr ledger.QueryResultsIterator) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	var results []commonledger.QueryResult
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			itr.Close()
+			return nil, nil, err
+		}
+		if result == nil {
+			break
+		}
+		results = append(results, result)
+	}
+	metadata := &pb.QueryResponseMetadata{
+		FetchedRecordsCount: int32(len(results)),
+		Bookmark:            itr.GetBookmarkAndClose(),
+	}
+	return &stateIterator{resultsIterator{results: results, read: true}}, metadata, nil
+}
+
+// newResultsIterator returns an iterator over the results of a ledger
+// iterator, capped by the total query limit of the chaincode
+func (s *stub) newResultsIterator(itr commonledger.ResultsIterator) resultsIterator {
+	return resultsIterator{itr: itr, limit: ledgerconfig.GetTotalQueryLimitForChaincode(s.namespace)}
+}
+
+// resultsIterator iterates over the results of a ledger iterator, or over
+// results already read if read is set
+type resultsIterator struct {
+	itr       commonledger.ResultsIterator
+	read      bool
+	results   []commonledger.QueryResult
+	closed    bool
+	limit     int
+	returned  int
+	truncated bool
+}
+
+func (r *resultsIterator) HasNext() bool {
+	if r.closed {
+		return false
+	}
+	if len(r.results) == 0 && !r.read {
+		result, err := r.itr.Next()
+		if err != nil || result == nil {
+			// the ledger iterators only fail when the database does
+			r.read = true
+			return false
+		}
+		if r.limit > 0 && r.returned >= r.limit {
+			r.read = true
+			r.truncated = true
+			return false
+		}
+		r.results = append(r.results, result)
+	}
+	return len(r.results) > 0
+}
+
+func (r *resultsIterator) next() (commonledger.QueryResult, error) {
+	if !r.HasNext() {
+		return nil, errors.New("no more results")
+	}
+	result := r.results[0]
+	r.results = r.results[1:]
+	r.returned++
+	return result, nil
+}
+
+func (r *resultsIterator) Truncated() bool {
+	return r.truncated
+}
+
+func (r *resultsIterator) Close() error {
+	if !r.closed && r.itr != nil {
+		r.itr.Close()
+	}
+	r.closed = true
+	return nil
+}
+
+type stateIterator struct {
+	resultsIterator
+}
+
+func (s *stateIterator) Next() (*queryresult.KV, error) {
+	result, err := s.next()
+	if err != nil {
+		return nil, err
+	}
+	return result.(*queryresult.KV), nil
+}
+
+type historyIterator struct {
+	resultsIterator
+}
+
+func (h *historyIterator) Next() (*queryresult.KeyModification, error) {
+	result, err := h.next()
+	if err != nil {
+		return nil, err
+	}
+	return result.(*queryresult.KeyModification), nil
+}
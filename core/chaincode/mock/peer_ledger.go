@@ -245,6 +245,22 @@ type PeerLedger struct {
 		result1 ledger.MissingPvtDataTracker
 		result2 error
 	}
+	GetStateAndBlockchainInfoStub        func(namespace string, key string) ([]byte, *common.BlockchainInfo, error)
+	getStateAndBlockchainInfoMutex       sync.RWMutex
+	getStateAndBlockchainInfoArgsForCall []struct {
+		namespace string
+		key       string
+	}
+	getStateAndBlockchainInfoReturns struct {
+		result1 []byte
+		result2 *common.BlockchainInfo
+		result3 error
+	}
+	getStateAndBlockchainInfoReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 *common.BlockchainInfo
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1189,6 +1205,61 @@ func (fake *PeerLedger) GetMissingPvtDataTrackerReturnsOnCall(i int, result1 led
 	}{result1, result2}
 }
 
+func (fake *PeerLedger) GetStateAndBlockchainInfo(namespace string, key string) ([]byte, *common.BlockchainInfo, error) {
+	fake.getStateAndBlockchainInfoMutex.Lock()
+	ret, specificReturn := fake.getStateAndBlockchainInfoReturnsOnCall[len(fake.getStateAndBlockchainInfoArgsForCall)]
+	fake.getStateAndBlockchainInfoArgsForCall = append(fake.getStateAndBlockchainInfoArgsForCall, struct {
+		namespace string
+		key       string
+	}{namespace, key})
+	fake.recordInvocation("GetStateAndBlockchainInfo", []interface{}{namespace, key})
+	fake.getStateAndBlockchainInfoMutex.Unlock()
+	if fake.GetStateAndBlockchainInfoStub != nil {
+		return fake.GetStateAndBlockchainInfoStub(namespace, key)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getStateAndBlockchainInfoReturns.result1, fake.getStateAndBlockchainInfoReturns.result2, fake.getStateAndBlockchainInfoReturns.result3
+}
+
+func (fake *PeerLedger) GetStateAndBlockchainInfoCallCount() int {
+	fake.getStateAndBlockchainInfoMutex.RLock()
+	defer fake.getStateAndBlockchainInfoMutex.RUnlock()
+	return len(fake.getStateAndBlockchainInfoArgsForCall)
+}
+
+func (fake *PeerLedger) GetStateAndBlockchainInfoArgsForCall(i int) (string, string) {
+	fake.getStateAndBlockchainInfoMutex.RLock()
+	defer fake.getStateAndBlockchainInfoMutex.RUnlock()
+	return fake.getStateAndBlockchainInfoArgsForCall[i].namespace, fake.getStateAndBlockchainInfoArgsForCall[i].key
+}
+
+func (fake *PeerLedger) GetStateAndBlockchainInfoReturns(result1 []byte, result2 *common.BlockchainInfo, result3 error) {
+	fake.GetStateAndBlockchainInfoStub = nil
+	fake.getStateAndBlockchainInfoReturns = struct {
+		result1 []byte
+		result2 *common.BlockchainInfo
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *PeerLedger) GetStateAndBlockchainInfoReturnsOnCall(i int, result1 []byte, result2 *common.BlockchainInfo, result3 error) {
+	fake.GetStateAndBlockchainInfoStub = nil
+	if fake.getStateAndBlockchainInfoReturnsOnCall == nil {
+		fake.getStateAndBlockchainInfoReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 *common.BlockchainInfo
+			result3 error
+		})
+	}
+	fake.getStateAndBlockchainInfoReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 *common.BlockchainInfo
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1232,6 +1303,8 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.commitPvtDataMutex.RUnlock()
 	fake.getMissingPvtDataTrackerMutex.RLock()
 	defer fake.getMissingPvtDataTrackerMutex.RUnlock()
+	fake.getStateAndBlockchainInfoMutex.RLock()
+	defer fake.getStateAndBlockchainInfoMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
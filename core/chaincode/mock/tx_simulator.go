@@ -38,6 +38,21 @@ type TxSimulator struct {
 		result1 commonledger.ResultsIterator
 		result2 error
 	}
+	GetStateRangeScanIteratorReverseStub        func(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error)
+	getStateRangeScanIteratorReverseMutex       sync.RWMutex
+	getStateRangeScanIteratorReverseArgsForCall []struct {
+		namespace string
+		startKey  string
+		endKey    string
+	}
+	getStateRangeScanIteratorReverseReturns struct {
+		result1 commonledger.ResultsIterator
+		result2 error
+	}
+	getStateRangeScanIteratorReverseReturnsOnCall map[int]struct {
+		result1 commonledger.ResultsIterator
+		result2 error
+	}
 	GetStateMetadataStub        func(namespace, key string) (map[string][]byte, error)
 	getStateMetadataMutex       sync.RWMutex
 	getStateMetadataArgsForCall []struct {
@@ -465,6 +480,59 @@ func (fake *TxSimulator) GetStateRangeScanIteratorReturnsOnCall(i int, result1 c
 	}{result1, result2}
 }
 
+func (fake *TxSimulator) GetStateRangeScanIteratorReverse(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	fake.getStateRangeScanIteratorReverseMutex.Lock()
+	ret, specificReturn := fake.getStateRangeScanIteratorReverseReturnsOnCall[len(fake.getStateRangeScanIteratorReverseArgsForCall)]
+	fake.getStateRangeScanIteratorReverseArgsForCall = append(fake.getStateRangeScanIteratorReverseArgsForCall, struct {
+		namespace string
+		startKey  string
+		endKey    string
+	}{namespace, startKey, endKey})
+	fake.recordInvocation("GetStateRangeScanIteratorReverse", []interface{}{namespace, startKey, endKey})
+	fake.getStateRangeScanIteratorReverseMutex.Unlock()
+	if fake.GetStateRangeScanIteratorReverseStub != nil {
+		return fake.GetStateRangeScanIteratorReverseStub(namespace, startKey, endKey)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getStateRangeScanIteratorReverseReturns.result1, fake.getStateRangeScanIteratorReverseReturns.result2
+}
+
+func (fake *TxSimulator) GetStateRangeScanIteratorReverseCallCount() int {
+	fake.getStateRangeScanIteratorReverseMutex.RLock()
+	defer fake.getStateRangeScanIteratorReverseMutex.RUnlock()
+	return len(fake.getStateRangeScanIteratorReverseArgsForCall)
+}
+
+func (fake *TxSimulator) GetStateRangeScanIteratorReverseArgsForCall(i int) (string, string, string) {
+	fake.getStateRangeScanIteratorReverseMutex.RLock()
+	defer fake.getStateRangeScanIteratorReverseMutex.RUnlock()
+	return fake.getStateRangeScanIteratorReverseArgsForCall[i].namespace, fake.getStateRangeScanIteratorReverseArgsForCall[i].startKey, fake.getStateRangeScanIteratorReverseArgsForCall[i].endKey
+}
+
+func (fake *TxSimulator) GetStateRangeScanIteratorReverseReturns(result1 commonledger.ResultsIterator, result2 error) {
+	fake.GetStateRangeScanIteratorReverseStub = nil
+	fake.getStateRangeScanIteratorReverseReturns = struct {
+		result1 commonledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TxSimulator) GetStateRangeScanIteratorReverseReturnsOnCall(i int, result1 commonledger.ResultsIterator, result2 error) {
+	fake.GetStateRangeScanIteratorReverseStub = nil
+	if fake.getStateRangeScanIteratorReverseReturnsOnCall == nil {
+		fake.getStateRangeScanIteratorReverseReturnsOnCall = make(map[int]struct {
+			result1 commonledger.ResultsIterator
+			result2 error
+		})
+	}
+	fake.getStateRangeScanIteratorReverseReturnsOnCall[i] = struct {
+		result1 commonledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *TxSimulator) GetStateMetadata(namespace string, key string) (map[string][]byte, error) {
 	fake.getStateMetadataMutex.Lock()
 	ret, specificReturn := fake.getStateMetadataReturnsOnCall[len(fake.getStateMetadataArgsForCall)]
@@ -1685,6 +1753,8 @@ func (fake *TxSimulator) Invocations() map[string][][]interface{} {
 	defer fake.getStateMutex.RUnlock()
 	fake.getStateRangeScanIteratorMutex.RLock()
 	defer fake.getStateRangeScanIteratorMutex.RUnlock()
+	fake.getStateRangeScanIteratorReverseMutex.RLock()
+	defer fake.getStateRangeScanIteratorReverseMutex.RUnlock()
 	fake.getStateMetadataMutex.RLock()
 	defer fake.getStateMetadataMutex.RUnlock()
 	fake.getStateMultipleKeysMutex.RLock()
@@ -37,6 +37,11 @@ func (carPlatform *Platform) ValidatePath(path string) error {
 	return nil
 }
 
+// NormalizePath returns the path of CAR chaincodes unchanged
+func (carPlatform *Platform) NormalizePath(path string) (string, error) {
+	return path, nil
+}
+
 func (carPlatform *Platform) ValidateCodePackage(codePackage []byte) error {
 	// CAR platform will validate the code package within chaintool
 	return nil
@@ -60,7 +65,7 @@ func (carPlatform *Platform) GenerateDockerfile() (string, error) {
 	return dockerFileContents, nil
 }
 
-func (carPlatform *Platform) GenerateDockerBuild(path string, code []byte, tw *tar.Writer) error {
+func (carPlatform *Platform) GenerateDockerBuild(path string, code []byte, builderImage string, tw *tar.Writer) error {
 
 	// Bundle the .car file into a tar stream so it may be transferred to the builder container
 	codepackage, output := io.Pipe()
@@ -75,6 +80,7 @@ func (carPlatform *Platform) GenerateDockerBuild(path string, code []byte, tw *t
 
 	binpackage := bytes.NewBuffer(nil)
 	err := util.DockerBuild(util.DockerBuildOptions{
+		Image:        builderImage,
 		Cmd:          "java -jar /usr/local/bin/chaintool buildcar /chaincode/input/codepackage.car -o /chaincode/output/chaincode",
 		InputStream:  codepackage,
 		OutputStream: binpackage,
@@ -51,7 +51,8 @@ func getGoEnv() (Env, error) {
 	for _, entry := range envout {
 		tokens := strings.SplitN(entry, "=", 2)
 		if len(tokens) > 1 {
-			goenv[tokens[0]] = strings.Trim(tokens[1], "\"")
+			// newer toolchains single-quote the values on unix
+			goenv[tokens[0]] = strings.Trim(tokens[1], "\"'")
 		}
 	}
 
@@ -37,4 +37,7 @@ func Test_getGoEnv(t *testing.T) {
 
 	_, ok = goenv["GOROOT"]
 	assert.Equal(t, ok, true)
+
+	assert.NotContains(t, goenv["GOROOT"], "'", "Expected the quotes of the values to be removed")
+	assert.NotContains(t, goenv["GOROOT"], "\"", "Expected the quotes of the values to be removed")
 }
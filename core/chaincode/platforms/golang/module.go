@@ -0,0 +1,185 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package golang
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ModuleDescriptor describes the Go module holding a chaincode
+type ModuleDescriptor struct {
+	// Dir is the root directory of the module, holding its go.mod
+	Dir string
+	// Path is the module path declared in the go.mod of the module
+	Path string
+	// PkgDir is the directory of the chaincode package within the module
+	PkgDir string
+}
+
+// ImportPath returns the import path of the chaincode package
+func (m *ModuleDescriptor) ImportPath() string {
+	rel, err := filepath.Rel(m.Dir, m.PkgDir)
+	if err != nil || rel == "." {
+		return m.Path
+	}
+	return path.Join(m.Path, filepath.ToSlash(rel))
+}
+
+// findModule returns the description of the Go module holding the chaincode
+// package in the local directory ccPath, or nil if ccPath is not a directory
+// of a module or the module mode is turned off with GO111MODULE=off
+func findModule(env Env, ccPath string) (*ModuleDescriptor, error) {
+	if env["GO111MODULE"] == "off" {
+		return nil, nil
+	}
+	info, err := os.Stat(ccPath)
+	if err != nil || !info.IsDir() {
+		return nil, nil
+	}
+	pkgDir, err := filepath.Abs(ccPath)
+	if err != nil {
+		return nil, fmt.Errorf("error obtaining absolute path for %s: %s", ccPath, err)
+	}
+
+	for dir := pkgDir; ; dir = filepath.Dir(dir) {
+		gomod := filepath.Join(dir, "go.mod")
+		contents, err := ioutil.ReadFile(gomod)
+		if err == nil {
+			modPath := modulePath(contents)
+			if modPath == "" {
+				return nil, fmt.Errorf("%s does not declare a module path", gomod)
+			}
+			return &ModuleDescriptor{Dir: dir, Path: modPath, PkgDir: pkgDir}, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("error reading %s: %s", gomod, err)
+		}
+		if filepath.Dir(dir) == dir {
+			return nil, nil
+		}
+	}
+}
+
+// modulePath returns the module path declared by the module directive of a go.mod
+func modulePath(gomod []byte) string {
+	for _, line := range strings.Split(string(gomod), "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != "module" {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(fields[1]); err == nil {
+			return unquoted
+		}
+		return fields[1]
+	}
+	return ""
+}
+
+// findModuleSource collects the files of the module to package under src:
+// its go.mod and go.sum, its vendored dependencies and the source of its
+// packages, but not those of the modules nested in it
+func findModuleSource(m *ModuleDescriptor) (Sources, error) {
+	files := make(Sources, 0)
+	walkFn := func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(m.Dir, path)
+		if err != nil {
+			return fmt.Errorf("error obtaining relative path for %s: %s", path, err)
+		}
+
+		if info.IsDir() {
+			if path == m.Dir {
+				return nil
+			}
+			// the go tool ignores these directories too
+			name := info.Name()
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" {
+				logger.Debugf("skipping dir: %s", path)
+				return filepath.SkipDir
+			}
+			if isVendored(rel) {
+				return nil
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				logger.Debugf("skipping nested module: %s", path)
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !isModuleFile(rel) {
+			return nil
+		}
+
+		name := filepath.Join("src", rel)
+		files = append(files, SourceDescriptor{Name: name, Path: path, IsMetadata: isMetadataDir(path, m.PkgDir), Info: info})
+		return nil
+	}
+
+	if err := filepath.Walk(m.Dir, walkFn); err != nil {
+		return nil, fmt.Errorf("Error walking directory: %s", err)
+	}
+
+	return files, nil
+}
+
+func isVendored(rel string) bool {
+	return rel == "vendor" || strings.HasPrefix(rel, "vendor"+string(filepath.Separator))
+}
+
+// isModuleFile returns whether the file at the given path relative to the
+// root of a module is packaged
+func isModuleFile(rel string) bool {
+	switch filepath.ToSlash(rel) {
+	case "go.mod", "go.sum", "vendor/modules.txt":
+		return true
+	}
+	_, ok := includeFileTypes[filepath.Ext(rel)]
+	return ok
+}
+
+// moduleLayout returns whether the code package holds a Go module, that is,
+// a go.mod at the root of src, and whether the module vendors its dependencies
+func moduleLayout(files map[string]bool) (module, vendored bool) {
+	return files["src/go.mod"], files["src/vendor/modules.txt"]
+}
+
+// moduleBuildEnv returns the environment of the builds of Go modules. GOPROXY
+// and GOFLAGS are taken from chaincode.golang if set, and the variables
+// configuring the download of modules are otherwise passed through from the
+// environment of the peer
+func moduleBuildEnv() []string {
+	env := []string{"GO111MODULE=on"}
+	configured := map[string]string{
+		"GOPROXY": viper.GetString("chaincode.golang.goproxy"),
+		"GOFLAGS": viper.GetString("chaincode.golang.goflags"),
+	}
+	for _, key := range []string{"GOPROXY", "GOFLAGS", "GOPRIVATE", "GONOPROXY", "GONOSUMDB", "GOSUMDB"} {
+		value, set := configured[key], configured[key] != ""
+		if !set {
+			value, set = os.LookupEnv(key)
+		}
+		if set {
+			env = append(env, key+"="+value)
+		}
+	}
+	return env
+}
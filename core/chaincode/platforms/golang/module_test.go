@@ -0,0 +1,156 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package golang
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindModule(t *testing.T) {
+	moduleDir, err := filepath.Abs("testdata/module")
+	require.NoError(t, err)
+
+	module, err := findModule(Env{}, "testdata/module/chaincode")
+	require.NoError(t, err)
+	require.NotNil(t, module)
+	assert.Equal(t, moduleDir, module.Dir)
+	assert.Equal(t, "example.com/mycc", module.Path)
+	assert.Equal(t, filepath.Join(moduleDir, "chaincode"), module.PkgDir)
+	assert.Equal(t, "example.com/mycc/chaincode", module.ImportPath())
+
+	module, err = findModule(Env{}, "testdata/module")
+	require.NoError(t, err)
+	require.NotNil(t, module)
+	assert.Equal(t, "example.com/mycc", module.ImportPath())
+
+	module, err = findModule(Env{}, "testdata/module/nested")
+	require.NoError(t, err)
+	require.NotNil(t, module)
+	assert.Equal(t, "example.com/nested", module.ImportPath())
+
+	module, err = findModule(Env{"GO111MODULE": "off"}, "testdata/module/chaincode")
+	assert.NoError(t, err)
+	assert.Nil(t, module)
+
+	module, err = findModule(Env{}, "github.com/hyperledger/fabric/examples/chaincode/go/map")
+	assert.NoError(t, err)
+	assert.Nil(t, module)
+
+	module, err = findModule(Env{}, "testdata/module/go.mod")
+	assert.NoError(t, err)
+	assert.Nil(t, module)
+}
+
+func TestModulePath(t *testing.T) {
+	tests := []struct {
+		gomod string
+		path  string
+	}{
+		{gomod: "module example.com/mycc\n", path: "example.com/mycc"},
+		{gomod: "// the chaincode\nmodule example.com/mycc // v2 soon\n\nrequire example.com/dep v1.0.0\n", path: "example.com/mycc"},
+		{gomod: "module \"example.com/mycc\"\n", path: "example.com/mycc"},
+		{gomod: "go 1.11\n", path: ""},
+		{gomod: "// module example.com/mycc\n", path: ""},
+	}
+
+	for _, tst := range tests {
+		assert.Equal(t, tst.path, modulePath([]byte(tst.gomod)), "go.mod: %q", tst.gomod)
+	}
+}
+
+func TestModuleDeploymentPayload(t *testing.T) {
+	if value, set := os.LookupEnv("GO111MODULE"); set {
+		defer os.Setenv("GO111MODULE", value)
+	}
+	os.Unsetenv("GO111MODULE")
+
+	platform := &Platform{}
+
+	path, err := platform.NormalizePath("testdata/module/chaincode")
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com/mycc/chaincode", path)
+
+	path, err = platform.NormalizePath("github.com/hyperledger/fabric/examples/chaincode/go/map")
+	assert.NoError(t, err)
+	assert.Equal(t, "github.com/hyperledger/fabric/examples/chaincode/go/map", path)
+
+	assert.NoError(t, platform.ValidatePath("testdata/module/chaincode"))
+
+	payload, err := platform.GetDeploymentPayload("testdata/module/chaincode")
+	require.NoError(t, err)
+	assert.NoError(t, platform.ValidateCodePackage(payload))
+
+	var names []string
+	gr, err := gzip.NewReader(bytes.NewReader(payload))
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, header.Name)
+	}
+	assert.Equal(t, []string{
+		"META-INF/statedb/couchdb/indexes/indexOwner.json",
+		"src/chaincode/main.go",
+		"src/go.mod",
+		"src/lib/lib.go",
+	}, names)
+
+	files, err := codePackageFiles(payload)
+	require.NoError(t, err)
+	module, vendored := moduleLayout(files)
+	assert.True(t, module)
+	assert.False(t, vendored)
+}
+
+func TestModuleLayout(t *testing.T) {
+	module, vendored := moduleLayout(map[string]bool{"src/github.com/acme/cc/main.go": true})
+	assert.False(t, module)
+	assert.False(t, vendored)
+
+	module, vendored = moduleLayout(map[string]bool{"src/go.mod": true, "src/vendor/modules.txt": true})
+	assert.True(t, module)
+	assert.True(t, vendored)
+}
+
+func TestModuleBuildEnv(t *testing.T) {
+	defer viper.Set("chaincode.golang.goproxy", viper.GetString("chaincode.golang.goproxy"))
+	defer viper.Set("chaincode.golang.goflags", viper.GetString("chaincode.golang.goflags"))
+	for _, key := range []string{"GOPROXY", "GOFLAGS", "GOPRIVATE", "GONOPROXY", "GONOSUMDB", "GOSUMDB"} {
+		if value, set := os.LookupEnv(key); set {
+			defer os.Setenv(key, value)
+		} else {
+			defer os.Unsetenv(key)
+		}
+		os.Unsetenv(key)
+	}
+
+	viper.Set("chaincode.golang.goproxy", "")
+	viper.Set("chaincode.golang.goflags", "")
+	assert.Equal(t, []string{"GO111MODULE=on"}, moduleBuildEnv())
+
+	os.Setenv("GOPROXY", "https://proxy.example.com")
+	os.Setenv("GOPRIVATE", "example.com/private")
+	assert.Equal(t, []string{"GO111MODULE=on", "GOPROXY=https://proxy.example.com", "GOPRIVATE=example.com/private"}, moduleBuildEnv())
+
+	viper.Set("chaincode.golang.goproxy", "off")
+	viper.Set("chaincode.golang.goflags", "-trimpath")
+	assert.Equal(t, []string{"GO111MODULE=on", "GOPROXY=off", "GOFLAGS=-trimpath", "GOPRIVATE=example.com/private"}, moduleBuildEnv())
+}
@@ -46,7 +46,9 @@ func getCodeFromFS(path string) (codegopath string, err error) {
 
 type CodeDescriptor struct {
 	Gopath, Pkg string
-	Cleanup     func()
+	// Module describes the Go module holding the code, if any
+	Module  *ModuleDescriptor
+	Cleanup func()
 }
 
 // collectChaincodeFiles collects chaincode files. If path is a HTTP(s) url it
@@ -59,9 +61,22 @@ func getCode(path string) (*CodeDescriptor, error) {
 		return nil, errors.New("Cannot collect files from empty chaincode path")
 	}
 
+	// code in a local directory of a Go module is packaged as the module
+	env, err := getGoEnv()
+	if err != nil {
+		return nil, err
+	}
+	module, err := findModule(env, path)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting code %s", err)
+	}
+	if module != nil {
+		return &CodeDescriptor{Pkg: module.ImportPath(), Module: module, Cleanup: nil}, nil
+	}
+
 	// code root will point to the directory where the code exists
 	var gopath string
-	gopath, err := getCodeFromFS(path)
+	gopath, err = getCodeFromFS(path)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting code %s", err)
 	}
@@ -11,6 +11,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/url"
 	"os"
@@ -105,6 +106,18 @@ func (goPlatform *Platform) ValidatePath(rawPath string) error {
 	//which we do later anyway. But we *can* - and *should* - test for existence of local paths.
 	//Treat empty scheme as a local filesystem path
 	if path.Scheme == "" {
+		env, err := getGoEnv()
+		if err != nil {
+			return err
+		}
+		module, err := findModule(env, rawPath)
+		if err != nil {
+			return fmt.Errorf("error validating chaincode path: %s", err)
+		}
+		if module != nil {
+			return nil
+		}
+
 		gopath, err := getGopath()
 		if err != nil {
 			return err
@@ -121,6 +134,23 @@ func (goPlatform *Platform) ValidatePath(rawPath string) error {
 	return nil
 }
 
+// NormalizePath returns the import path of the chaincode package in the local
+// directory of a Go module, and returns any other path unchanged
+func (goPlatform *Platform) NormalizePath(rawPath string) (string, error) {
+	env, err := getGoEnv()
+	if err != nil {
+		return "", err
+	}
+	module, err := findModule(env, rawPath)
+	if err != nil {
+		return "", err
+	}
+	if module == nil {
+		return rawPath, nil
+	}
+	return module.ImportPath(), nil
+}
+
 func (goPlatform *Platform) ValidateCodePackage(code []byte) error {
 
 	if len(code) == 0 {
@@ -261,6 +291,99 @@ func (goPlatform *Platform) GetDeploymentPayload(path string) ([]byte, error) {
 		defer code.Cleanup()
 	}
 
+	// --------------------------------------------------------------------------------------
+	// Collect the source of the module holding the code, or of the code and the
+	// dependencies it has in our GOPATH
+	// --------------------------------------------------------------------------------------
+	var files Sources
+	pkgDir := filepath.Join("src", code.Pkg)
+	if code.Module != nil {
+		files, err = findModuleSource(code.Module)
+		if err != nil {
+			return nil, err
+		}
+		rel, err := filepath.Rel(code.Module.Dir, code.Module.PkgDir)
+		if err != nil {
+			return nil, err
+		}
+		pkgDir = filepath.Join("src", rel)
+	} else {
+		files, err = findGopathSource(code)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// --------------------------------------------------------------------------------------
+	// Sort on the filename so the tarball at least looks sane in terms of package grouping
+	// --------------------------------------------------------------------------------------
+	sort.Sort(files)
+
+	// --------------------------------------------------------------------------------------
+	// Write out our tar package
+	// --------------------------------------------------------------------------------------
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+
+	for _, file := range files {
+
+		// file.Path represents os localpath
+		// file.Name represents tar packagepath
+
+		// If the file is metadata rather than golang code, remove the leading go code path, for example:
+		// original file.Name:  src/github.com/hyperledger/fabric/examples/chaincode/go/marbles02/META-INF/statedb/couchdb/indexes/indexOwner.json
+		// updated file.Name:   META-INF/statedb/couchdb/indexes/indexOwner.json
+		if file.IsMetadata {
+
+			file.Name, err = filepath.Rel(pkgDir, file.Name)
+			if err != nil {
+				return nil, fmt.Errorf("This error was caused by bad packaging of the metadata.  The file [%s] is marked as MetaFile, however not located under META-INF   Error:[%s]", file.Name, err)
+			}
+
+			// Split the tar location (file.Name) into a tar package directory and filename
+			_, filename := filepath.Split(file.Name)
+
+			// Hidden files are not supported as metadata, therefore ignore them.
+			// User often doesn't know that hidden files are there, and may not be able to delete them, therefore warn user rather than error out.
+			if strings.HasPrefix(filename, ".") {
+				logger.Warningf("Ignoring hidden file in metadata directory: %s", file.Name)
+				continue
+			}
+
+			fileBytes, err := ioutil.ReadFile(file.Path)
+			if err != nil {
+				return nil, err
+			}
+
+			// Validate metadata file for inclusion in tar
+			// Validation is based on the passed filename with path
+			err = ccmetadata.ValidateMetadataFile(file.Name, fileBytes)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err = cutil.WriteFileToPackage(file.Path, file.Name, tw)
+		if err != nil {
+			return nil, fmt.Errorf("Error writing %s to tar: %s", file.Name, err)
+		}
+	}
+
+	err = tw.Close()
+	if err == nil {
+		err = gw.Close()
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create tar for chaincode")
+	}
+
+	return payload.Bytes(), nil
+}
+
+// findGopathSource collects the source of the code and of the dependencies it
+// has in our GOPATH, vendoring the latter under the code
+func findGopathSource(code *CodeDescriptor) (Sources, error) {
 	// --------------------------------------------------------------------------------------
 	// Update our environment for the purposes of executing go-list directives
 	// --------------------------------------------------------------------------------------
@@ -272,6 +395,7 @@ func (goPlatform *Platform) GetDeploymentPayload(path string) ([]byte, error) {
 	goroots := splitEnvPaths(env["GOROOT"])
 	gopaths[code.Gopath] = true
 	env["GOPATH"] = flattenEnvPaths(gopaths)
+	env["GO111MODULE"] = "off"
 
 	// --------------------------------------------------------------------------------------
 	// Retrieve the list of first-order imports referenced by the chaincode
@@ -410,71 +534,7 @@ func (goPlatform *Platform) GetDeploymentPayload(path string) ([]byte, error) {
 	// --------------------------------------------------------------------------------------
 	vendorDependencies(code.Pkg, files)
 
-	// --------------------------------------------------------------------------------------
-	// Sort on the filename so the tarball at least looks sane in terms of package grouping
-	// --------------------------------------------------------------------------------------
-	sort.Sort(files)
-
-	// --------------------------------------------------------------------------------------
-	// Write out our tar package
-	// --------------------------------------------------------------------------------------
-	payload := bytes.NewBuffer(nil)
-	gw := gzip.NewWriter(payload)
-	tw := tar.NewWriter(gw)
-
-	for _, file := range files {
-
-		// file.Path represents os localpath
-		// file.Name represents tar packagepath
-
-		// If the file is metadata rather than golang code, remove the leading go code path, for example:
-		// original file.Name:  src/github.com/hyperledger/fabric/examples/chaincode/go/marbles02/META-INF/statedb/couchdb/indexes/indexOwner.json
-		// updated file.Name:   META-INF/statedb/couchdb/indexes/indexOwner.json
-		if file.IsMetadata {
-
-			file.Name, err = filepath.Rel(filepath.Join("src", code.Pkg), file.Name)
-			if err != nil {
-				return nil, fmt.Errorf("This error was caused by bad packaging of the metadata.  The file [%s] is marked as MetaFile, however not located under META-INF   Error:[%s]", file.Name, err)
-			}
-
-			// Split the tar location (file.Name) into a tar package directory and filename
-			_, filename := filepath.Split(file.Name)
-
-			// Hidden files are not supported as metadata, therefore ignore them.
-			// User often doesn't know that hidden files are there, and may not be able to delete them, therefore warn user rather than error out.
-			if strings.HasPrefix(filename, ".") {
-				logger.Warningf("Ignoring hidden file in metadata directory: %s", file.Name)
-				continue
-			}
-
-			fileBytes, err := ioutil.ReadFile(file.Path)
-			if err != nil {
-				return nil, err
-			}
-
-			// Validate metadata file for inclusion in tar
-			// Validation is based on the passed filename with path
-			err = ccmetadata.ValidateMetadataFile(file.Name, fileBytes)
-			if err != nil {
-				return nil, err
-			}
-		}
-
-		err = cutil.WriteFileToPackage(file.Path, file.Name, tw)
-		if err != nil {
-			return nil, fmt.Errorf("Error writing %s to tar: %s", file.Name, err)
-		}
-	}
-
-	err = tw.Close()
-	if err == nil {
-		err = gw.Close()
-	}
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to create tar for chaincode")
-	}
-
-	return payload.Bytes(), nil
+	return files, nil
 }
 
 func (goPlatform *Platform) GenerateDockerfile() (string, error) {
@@ -499,7 +559,7 @@ func getLDFlagsOpts() string {
 	return staticLDFlagsOpts
 }
 
-func (goPlatform *Platform) GenerateDockerBuild(path string, code []byte, tw *tar.Writer) error {
+func (goPlatform *Platform) GenerateDockerBuild(path string, code []byte, builderImage string, tw *tar.Writer) error {
 	pkgname, err := decodeUrl(path)
 	if err != nil {
 		return fmt.Errorf("could not decode url: %s", err)
@@ -515,13 +575,32 @@ func (goPlatform *Platform) GenerateDockerBuild(path string, code []byte, tw *ta
 	}
 	logger.Infof("building chaincode with tags: %s", gotags)
 
+	files, err := codePackageFiles(code)
+	if err != nil {
+		return err
+	}
+
+	opts := util.DockerBuildOptions{
+		Image: builderImage,
+		Cmd:   fmt.Sprintf("GOPATH=/chaincode/input:$GOPATH go build -tags \"%s\" %s -o /chaincode/output/chaincode %s", gotags, ldflagsOpt, pkgname),
+	}
+	if module, vendored := moduleLayout(files); module {
+		// modules are built in module mode from the root of the module, with
+		// the dependencies it vendors or else those downloaded by the go tool
+		mod := "readonly"
+		if vendored {
+			mod = "vendor"
+		}
+		logger.Infof("building chaincode as a module with -mod=%s", mod)
+		opts.Cmd = fmt.Sprintf("cd /chaincode/input/src && go build -mod=%s -tags \"%s\" %s -o /chaincode/output/chaincode %s", mod, gotags, ldflagsOpt, pkgname)
+		opts.Env = moduleBuildEnv()
+	}
+
 	codepackage := bytes.NewReader(code)
 	binpackage := bytes.NewBuffer(nil)
-	err = util.DockerBuild(util.DockerBuildOptions{
-		Cmd:          fmt.Sprintf("GOPATH=/chaincode/input:$GOPATH go build -tags \"%s\" %s -o /chaincode/output/chaincode %s", gotags, ldflagsOpt, pkgname),
-		InputStream:  codepackage,
-		OutputStream: binpackage,
-	})
+	opts.InputStream = codepackage
+	opts.OutputStream = binpackage
+	err = util.DockerBuild(opts)
 	if err != nil {
 		return err
 	}
@@ -529,6 +608,30 @@ func (goPlatform *Platform) GenerateDockerBuild(path string, code []byte, tw *ta
 	return cutil.WriteBytesToPackage("binpackage.tar", binpackage.Bytes(), tw)
 }
 
+// codePackageFiles returns the names of the files in the code package
+func codePackageFiles(code []byte) (map[string]bool, error) {
+	files := make(map[string]bool)
+	if len(code) == 0 {
+		return files, nil
+	}
+
+	gr, err := gzip.NewReader(bytes.NewReader(code))
+	if err != nil {
+		return nil, fmt.Errorf("failure opening codepackage gzip stream: %s", err)
+	}
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failure reading codepackage: %s", err)
+		}
+		files[strings.TrimPrefix(header.Name, "/")] = true
+	}
+}
+
 //GetMetadataProvider fetches metadata provider given deployment spec
 func (goPlatform *Platform) GetMetadataProvider(code []byte) platforms.MetadataProvider {
 	return &ccmetadata.TargzMetadataProvider{Code: code}
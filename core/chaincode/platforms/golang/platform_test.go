@@ -354,7 +354,7 @@ func TestGenerateDockerBuild(t *testing.T) {
 		if _, err = platform.GenerateDockerfile(); err != nil {
 			t.Errorf("could not generate docker file for a valid spec: %s, %s", cds.ChaincodeSpec.ChaincodeId.Path, err)
 		}
-		err = platform.GenerateDockerBuild(cds.Path(), cds.Bytes(), tw)
+		err = platform.GenerateDockerBuild(cds.Path(), cds.Bytes(), "", tw)
 		if err = testerr(err, tst.SuccessExpected); err != nil {
 			t.Errorf("Error validating chaincode spec: %s, %s", cds.ChaincodeSpec.ChaincodeId.Path, err)
 		}
@@ -0,0 +1,3 @@
+module example.com/mycc
+
+go 1.11
@@ -176,7 +176,7 @@ func TestGenerateDockerBuild(t *testing.T) {
 	gw := gzip.NewWriter(payload)
 	tw := tar.NewWriter(gw)
 
-	err := platform.GenerateDockerBuild(cds.Path(), cds.Bytes(), tw)
+	err := platform.GenerateDockerBuild(cds.Path(), cds.Bytes(), "", tw)
 	assert.NoError(t, err)
 }
 
@@ -46,6 +46,12 @@ func (javaPlatform *Platform) ValidatePath(rawPath string) error {
 	return nil
 }
 
+// NormalizePath returns the path unchanged, as the path of java chaincodes
+// is not used to build them
+func (javaPlatform *Platform) NormalizePath(path string) (string, error) {
+	return path, nil
+}
+
 func (javaPlatform *Platform) ValidateCodePackage(code []byte) error {
 	if len(code) == 0 {
 		// Nothing to validate if no CodePackage was included
@@ -143,11 +149,16 @@ func (javaPlatform *Platform) GenerateDockerfile() (string, error) {
 	return dockerFileContents, nil
 }
 
-func (javaPlatform *Platform) GenerateDockerBuild(path string, code []byte, tw *tar.Writer) error {
+func (javaPlatform *Platform) GenerateDockerBuild(path string, code []byte, builderImage string, tw *tar.Writer) error {
+	// java chaincodes are built with their runtime image by default
+	if builderImage == "" {
+		builderImage = cutil.GetDockerfileFromConfig("chaincode.java.runtime")
+	}
+
 	codepackage := bytes.NewReader(code)
 	binpackage := bytes.NewBuffer(nil)
 	buildOptions := util.DockerBuildOptions{
-		Image:        cutil.GetDockerfileFromConfig("chaincode.java.runtime"),
+		Image:        builderImage,
 		Cmd:          "./build.sh",
 		InputStream:  codepackage,
 		OutputStream: binpackage,
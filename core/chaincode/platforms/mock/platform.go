@@ -29,6 +29,19 @@ type Platform struct {
 	validatePathReturnsOnCall map[int]struct {
 		result1 error
 	}
+	NormalizePathStub        func(path string) (string, error)
+	normalizePathMutex       sync.RWMutex
+	normalizePathArgsForCall []struct {
+		path string
+	}
+	normalizePathReturns struct {
+		result1 string
+		result2 error
+	}
+	normalizePathReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	ValidateCodePackageStub        func(code []byte) error
 	validateCodePackageMutex       sync.RWMutex
 	validateCodePackageArgsForCall []struct {
@@ -64,12 +77,13 @@ type Platform struct {
 		result1 string
 		result2 error
 	}
-	GenerateDockerBuildStub        func(path string, code []byte, tw *tar.Writer) error
+	GenerateDockerBuildStub        func(path string, code []byte, builderImage string, tw *tar.Writer) error
 	generateDockerBuildMutex       sync.RWMutex
 	generateDockerBuildArgsForCall []struct {
-		path string
-		code []byte
-		tw   *tar.Writer
+		path         string
+		code         []byte
+		builderImage string
+		tw           *tar.Writer
 	}
 	generateDockerBuildReturns struct {
 		result1 error
@@ -180,6 +194,57 @@ func (fake *Platform) ValidatePathReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Platform) NormalizePath(path string) (string, error) {
+	fake.normalizePathMutex.Lock()
+	ret, specificReturn := fake.normalizePathReturnsOnCall[len(fake.normalizePathArgsForCall)]
+	fake.normalizePathArgsForCall = append(fake.normalizePathArgsForCall, struct {
+		path string
+	}{path})
+	fake.recordInvocation("NormalizePath", []interface{}{path})
+	fake.normalizePathMutex.Unlock()
+	if fake.NormalizePathStub != nil {
+		return fake.NormalizePathStub(path)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.normalizePathReturns.result1, fake.normalizePathReturns.result2
+}
+
+func (fake *Platform) NormalizePathCallCount() int {
+	fake.normalizePathMutex.RLock()
+	defer fake.normalizePathMutex.RUnlock()
+	return len(fake.normalizePathArgsForCall)
+}
+
+func (fake *Platform) NormalizePathArgsForCall(i int) string {
+	fake.normalizePathMutex.RLock()
+	defer fake.normalizePathMutex.RUnlock()
+	return fake.normalizePathArgsForCall[i].path
+}
+
+func (fake *Platform) NormalizePathReturns(result1 string, result2 error) {
+	fake.NormalizePathStub = nil
+	fake.normalizePathReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Platform) NormalizePathReturnsOnCall(i int, result1 string, result2 error) {
+	fake.NormalizePathStub = nil
+	if fake.normalizePathReturnsOnCall == nil {
+		fake.normalizePathReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.normalizePathReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Platform) ValidateCodePackage(code []byte) error {
 	var codeCopy []byte
 	if code != nil {
@@ -327,7 +392,7 @@ func (fake *Platform) GenerateDockerfileReturnsOnCall(i int, result1 string, res
 	}{result1, result2}
 }
 
-func (fake *Platform) GenerateDockerBuild(path string, code []byte, tw *tar.Writer) error {
+func (fake *Platform) GenerateDockerBuild(path string, code []byte, builderImage string, tw *tar.Writer) error {
 	var codeCopy []byte
 	if code != nil {
 		codeCopy = make([]byte, len(code))
@@ -336,14 +401,15 @@ func (fake *Platform) GenerateDockerBuild(path string, code []byte, tw *tar.Writ
 	fake.generateDockerBuildMutex.Lock()
 	ret, specificReturn := fake.generateDockerBuildReturnsOnCall[len(fake.generateDockerBuildArgsForCall)]
 	fake.generateDockerBuildArgsForCall = append(fake.generateDockerBuildArgsForCall, struct {
-		path string
-		code []byte
-		tw   *tar.Writer
-	}{path, codeCopy, tw})
-	fake.recordInvocation("GenerateDockerBuild", []interface{}{path, codeCopy, tw})
+		path         string
+		code         []byte
+		builderImage string
+		tw           *tar.Writer
+	}{path, codeCopy, builderImage, tw})
+	fake.recordInvocation("GenerateDockerBuild", []interface{}{path, codeCopy, builderImage, tw})
 	fake.generateDockerBuildMutex.Unlock()
 	if fake.GenerateDockerBuildStub != nil {
-		return fake.GenerateDockerBuildStub(path, code, tw)
+		return fake.GenerateDockerBuildStub(path, code, builderImage, tw)
 	}
 	if specificReturn {
 		return ret.result1
@@ -357,10 +423,10 @@ func (fake *Platform) GenerateDockerBuildCallCount() int {
 	return len(fake.generateDockerBuildArgsForCall)
 }
 
-func (fake *Platform) GenerateDockerBuildArgsForCall(i int) (string, []byte, *tar.Writer) {
+func (fake *Platform) GenerateDockerBuildArgsForCall(i int) (string, []byte, string, *tar.Writer) {
 	fake.generateDockerBuildMutex.RLock()
 	defer fake.generateDockerBuildMutex.RUnlock()
-	return fake.generateDockerBuildArgsForCall[i].path, fake.generateDockerBuildArgsForCall[i].code, fake.generateDockerBuildArgsForCall[i].tw
+	return fake.generateDockerBuildArgsForCall[i].path, fake.generateDockerBuildArgsForCall[i].code, fake.generateDockerBuildArgsForCall[i].builderImage, fake.generateDockerBuildArgsForCall[i].tw
 }
 
 func (fake *Platform) GenerateDockerBuildReturns(result1 error) {
@@ -442,6 +508,8 @@ func (fake *Platform) Invocations() map[string][][]interface{} {
 	defer fake.nameMutex.RUnlock()
 	fake.validatePathMutex.RLock()
 	defer fake.validatePathMutex.RUnlock()
+	fake.normalizePathMutex.RLock()
+	defer fake.normalizePathMutex.RUnlock()
 	fake.validateCodePackageMutex.RLock()
 	defer fake.validateCodePackageMutex.RUnlock()
 	fake.getDeploymentPayloadMutex.RLock()
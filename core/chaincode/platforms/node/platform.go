@@ -12,6 +12,8 @@ import (
 	"compress/gzip"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -22,8 +24,10 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/ccmetadata"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/util"
+	"github.com/hyperledger/fabric/core/config"
 	cutil "github.com/hyperledger/fabric/core/container/util"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/viper"
 )
 
 var logger = flogging.MustGetLogger("node-platform")
@@ -74,6 +78,12 @@ func (nodePlatform *Platform) ValidatePath(rawPath string) error {
 	return nil
 }
 
+// NormalizePath returns the path unchanged, as the path of node chaincodes
+// is not used to build them
+func (nodePlatform *Platform) NormalizePath(path string) (string, error) {
+	return path, nil
+}
+
 func (nodePlatform *Platform) ValidateCodePackage(code []byte) error {
 
 	if len(code) == 0 {
@@ -186,12 +196,101 @@ func (nodePlatform *Platform) GenerateDockerfile() (string, error) {
 	return dockerFileContents, nil
 }
 
-func (nodePlatform *Platform) GenerateDockerBuild(path string, code []byte, tw *tar.Writer) error {
+// offlineModules is the name of the node_modules tarball in the build input
+const offlineModules = "node_modules.tar.gz"
+
+// npmInstallCmd returns the command installing the dependencies of node
+// chaincodes: from the configured registry, or offline from the modules of
+// the tarball extracted beforehand, with any configured npm install flags
+func npmInstallCmd(offline bool) (string, error) {
+	cmd := []string{"npm install --production"}
+	if offline {
+		cmd = append(cmd, "--offline")
+	}
+	if registry := viper.GetString("chaincode.node.registry"); registry != "" {
+		u, err := url.Parse(registry)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", fmt.Errorf("invalid npm registry: %s", registry)
+		}
+		cmd = append(cmd, "--registry="+u.String())
+	}
+	if flags := strings.TrimSpace(viper.GetString("chaincode.node.npmFlags")); flags != "" {
+		cmd = append(cmd, flags)
+	}
+	return strings.Join(cmd, " "), nil
+}
+
+// addOfflineModules returns the code package with the node_modules tarball
+// read from path added to it
+func addOfflineModules(code []byte, path string) ([]byte, error) {
+	modules, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading node_modules tarball: %s", err)
+	}
+
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+
+	gr, err := gzip.NewReader(bytes.NewReader(code))
+	if err != nil {
+		return nil, fmt.Errorf("failure opening codepackage gzip stream: %s", err)
+	}
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failure reading codepackage: %s", err)
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, fmt.Errorf("Error writing Chaincode package contents: %s", err)
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return nil, fmt.Errorf("Error writing Chaincode package contents: %s", err)
+		}
+	}
+
+	if err := cutil.WriteBytesToPackage(offlineModules, modules, tw); err != nil {
+		return nil, fmt.Errorf("Error writing Chaincode package contents: %s", err)
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("Error writing Chaincode package contents: %s", err)
+	}
+	if err := gw.Close(); err != nil {
+		return nil, fmt.Errorf("Error writing Chaincode package contents: %s", err)
+	}
+
+	return payload.Bytes(), nil
+}
+
+func (nodePlatform *Platform) GenerateDockerBuild(path string, code []byte, builderImage string, tw *tar.Writer) error {
+	cmd := "cp -R /chaincode/input/src/. /chaincode/output && cd /chaincode/output && "
+
+	modulesPath := config.GetPath("chaincode.node.offlineModules")
+	if modulesPath != "" {
+		logger.Infof("building chaincode offline with node_modules from %s", modulesPath)
+		var err error
+		code, err = addOfflineModules(code, modulesPath)
+		if err != nil {
+			return err
+		}
+		cmd += fmt.Sprintf("tar -xzf /chaincode/input/%s && ", offlineModules)
+	}
+
+	installCmd, err := npmInstallCmd(modulesPath != "")
+	if err != nil {
+		return err
+	}
+	cmd += installCmd
 
 	codepackage := bytes.NewReader(code)
 	binpackage := bytes.NewBuffer(nil)
-	err := util.DockerBuild(util.DockerBuildOptions{
-		Cmd:          fmt.Sprint("cp -R /chaincode/input/src/. /chaincode/output && cd /chaincode/output && npm install --production"),
+	err = util.DockerBuild(util.DockerBuildOptions{
+		Image:        builderImage,
+		Cmd:          cmd,
 		InputStream:  codepackage,
 		OutputStream: binpackage,
 	})
@@ -11,6 +11,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -21,6 +22,8 @@ import (
 	"github.com/hyperledger/fabric/core/config/configtest"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var _ = platforms.Platform(&Platform{})
@@ -201,12 +204,72 @@ func TestGenerateDockerBuild(t *testing.T) {
 	gw := gzip.NewWriter(payload)
 	tw := tar.NewWriter(gw)
 
-	err = platform.GenerateDockerBuild(cds.Path(), cds.Bytes(), tw)
+	err = platform.GenerateDockerBuild(cds.Path(), cds.Bytes(), "", tw)
 	if err != nil {
 		t.Fatal(err)
 	}
 }
 
+func TestNpmInstallCmd(t *testing.T) {
+	defer viper.Set("chaincode.node.registry", viper.GetString("chaincode.node.registry"))
+	defer viper.Set("chaincode.node.npmFlags", viper.GetString("chaincode.node.npmFlags"))
+
+	viper.Set("chaincode.node.registry", "")
+	viper.Set("chaincode.node.npmFlags", "")
+	cmd, err := npmInstallCmd(false)
+	assert.NoError(t, err)
+	assert.Equal(t, "npm install --production", cmd)
+
+	viper.Set("chaincode.node.registry", "https://npm.example.com/repo")
+	viper.Set("chaincode.node.npmFlags", " --no-audit --no-optional ")
+	cmd, err = npmInstallCmd(true)
+	assert.NoError(t, err)
+	assert.Equal(t, "npm install --production --offline --registry=https://npm.example.com/repo --no-audit --no-optional", cmd)
+
+	viper.Set("chaincode.node.registry", "npm.example.com; rm -rf /")
+	_, err = npmInstallCmd(false)
+	assert.EqualError(t, err, "invalid npm registry: npm.example.com; rm -rf /")
+}
+
+func TestAddOfflineModules(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nodejs-offline-modules")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	modules := filepath.Join(dir, "modules.tar.gz")
+	err = ioutil.WriteFile(modules, []byte("node_modules"), 0644)
+	require.NoError(t, err)
+
+	cp, err := makeCodePackage([]*packageFile{{"src/package.json", 0100644}})
+	require.NoError(t, err)
+
+	cp, err = addOfflineModules(cp, modules)
+	require.NoError(t, err)
+
+	gr, err := gzip.NewReader(bytes.NewReader(cp))
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	contents := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		b, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		contents[header.Name] = string(b)
+	}
+	assert.Equal(t, map[string]string{
+		"src/package.json":    "fake file's content",
+		"node_modules.tar.gz": "node_modules",
+	}, contents)
+
+	_, err = addOfflineModules(cp, filepath.Join(dir, "missing.tar.gz"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed reading node_modules tarball")
+}
+
 func makeCodePackage(pfiles []*packageFile) ([]byte, error) {
 	contents := []byte("fake file's content")
 
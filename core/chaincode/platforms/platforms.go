@@ -33,10 +33,13 @@ type MetadataProvider interface {
 type Platform interface {
 	Name() string
 	ValidatePath(path string) error
+	NormalizePath(path string) (string, error)
 	ValidateCodePackage(code []byte) error
 	GetDeploymentPayload(path string) ([]byte, error)
 	GenerateDockerfile() (string, error)
-	GenerateDockerBuild(path string, code []byte, tw *tar.Writer) error
+	// GenerateDockerBuild builds the chaincode with the given builder image,
+	// or with the default builder of the platform if empty
+	GenerateDockerBuild(path string, code []byte, builderImage string, tw *tar.Writer) error
 	GetMetadataProvider(code []byte) MetadataProvider
 }
 
@@ -79,6 +82,14 @@ func (r *Registry) ValidateSpec(ccType, path string) error {
 	return platform.ValidatePath(path)
 }
 
+func (r *Registry) NormalizePath(ccType, path string) (string, error) {
+	platform, ok := r.Platforms[ccType]
+	if !ok {
+		return "", fmt.Errorf("Unknown chaincodeType: %s", ccType)
+	}
+	return platform.NormalizePath(path)
+}
+
 func (r *Registry) ValidateDeploymentSpec(ccType string, codePackage []byte) error {
 	platform, ok := r.Platforms[ccType]
 	if !ok {
@@ -119,6 +130,16 @@ func (r *Registry) GenerateDockerfile(ccType, name, version string) (string, err
 	if err != nil {
 		return "", fmt.Errorf("Failed to generate platform-specific Dockerfile: %s", err)
 	}
+	// The runtime image configured for the chaincode replaces the platform base
+	images := cutil.GetChaincodeImages(name)
+	if images.Runtime != "" {
+		lines := strings.Split(base, "\n")
+		if !strings.HasPrefix(lines[0], "FROM ") {
+			return "", fmt.Errorf("Failed to set the runtime image of the platform-specific Dockerfile: %s", lines[0])
+		}
+		lines[0] = "FROM " + images.Runtime
+		base = strings.Join(lines, "\n")
+	}
 	buf = append(buf, base)
 
 	// ----------------------------------------------------------------------------------------------------
@@ -129,6 +150,11 @@ func (r *Registry) GenerateDockerfile(ccType, name, version string) (string, err
 	buf = append(buf, fmt.Sprintf(`      %s.chaincode.type="%s" \`, metadata.BaseDockerLabel, ccType))
 	buf = append(buf, fmt.Sprintf(`      %s.version="%s" \`, metadata.BaseDockerLabel, metadata.Version))
 	buf = append(buf, fmt.Sprintf(`      %s.base.version="%s"`, metadata.BaseDockerLabel, metadata.BaseVersion))
+	// record the configured images so that images built with others are not used
+	if images.Pinned() {
+		buf = append(buf, fmt.Sprintf(`LABEL %s="%s" \`, cutil.BuilderImageLabel, images.Builder))
+		buf = append(buf, fmt.Sprintf(`      %s="%s"`, cutil.RuntimeImageLabel, images.Runtime))
+	}
 	// ----------------------------------------------------------------------------------------------------
 	// Then augment it with any general options
 	// ----------------------------------------------------------------------------------------------------
@@ -144,7 +170,7 @@ func (r *Registry) GenerateDockerfile(ccType, name, version string) (string, err
 	return contents, nil
 }
 
-func (r *Registry) StreamDockerBuild(ccType, path string, codePackage []byte, inputFiles map[string][]byte, tw *tar.Writer) error {
+func (r *Registry) StreamDockerBuild(ccType, path, builderImage string, codePackage []byte, inputFiles map[string][]byte, tw *tar.Writer) error {
 	var err error
 
 	// ----------------------------------------------------------------------------------------------------
@@ -168,7 +194,7 @@ func (r *Registry) StreamDockerBuild(ccType, path string, codePackage []byte, in
 	// ----------------------------------------------------------------------------------------------------
 	// Now give the platform an opportunity to contribute its own context to the build
 	// ----------------------------------------------------------------------------------------------------
-	err = platform.GenerateDockerBuild(path, codePackage, tw)
+	err = platform.GenerateDockerBuild(path, codePackage, builderImage, tw)
 	if err != nil {
 		return fmt.Errorf("Failed to generate platform-specific docker build: %s", err)
 	}
@@ -198,7 +224,7 @@ func (r *Registry) GenerateDockerBuild(ccType, path, name, version string, codeP
 	go func() {
 		gw := gzip.NewWriter(output)
 		tw := tar.NewWriter(gw)
-		err := r.StreamDockerBuild(ccType, path, codePackage, inputFiles, tw)
+		err := r.StreamDockerBuild(ccType, path, cutil.GetChaincodeImages(name).Builder, codePackage, inputFiles, tw)
 		if err != nil {
 			logger.Error(err)
 		}
@@ -20,6 +20,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
 )
 
 var _ = Describe("Platforms", func() {
@@ -55,6 +56,24 @@ var _ = Describe("Platforms", func() {
 			})
 		})
 
+		Describe("NormalizePath", func() {
+			It("returns the result of the underlying platform", func() {
+				fakePlatform.NormalizePathReturns("normalized-path", errors.New("fake-error"))
+				path, err := registry.NormalizePath("fakeType", "cc-path")
+				Expect(err).To(MatchError(errors.New("fake-error")))
+				Expect(path).To(Equal("normalized-path"))
+				Expect(fakePlatform.NormalizePathCallCount()).To(Equal(1))
+				Expect(fakePlatform.NormalizePathArgsForCall(0)).To(Equal("cc-path"))
+			})
+
+			Context("when the platform is unknown", func() {
+				It("returns an error", func() {
+					_, err := registry.NormalizePath("badType", "")
+					Expect(err).To(MatchError("Unknown chaincodeType: badType"))
+				})
+			})
+		})
+
 		Describe("ValidateDeploymentSpec", func() {
 			It("returns the result of the underlying platform", func() {
 				fakePlatform.ValidateCodePackageReturns(errors.New("fake-error"))
@@ -125,6 +144,54 @@ ENV CORE_CHAINCODE_BUILDLEVEL=%s`, metadata.Version, metadata.BaseVersion, metad
 			Expect(df).To(Equal(expectedDockerfile))
 		})
 
+		Context("when images are configured for the chaincode", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.images.cc-name.builder", "builder@sha256:0123")
+				viper.Set("chaincode.images.cc-name.runtime", "runtime@sha256:4567")
+			})
+
+			AfterEach(func() {
+				viper.Set("chaincode.images", nil)
+			})
+
+			It("replaces the base image and labels the images", func() {
+				fakePlatform.GenerateDockerfileReturns("FROM base\nADD binpackage.tar /usr/local/bin", nil)
+				df, err := registry.GenerateDockerfile("fakeType", "cc-name", "cc-version")
+				Expect(err).NotTo(HaveOccurred())
+				expectedDockerfile := fmt.Sprintf(`FROM runtime@sha256:4567
+ADD binpackage.tar /usr/local/bin
+LABEL org.hyperledger.fabric.chaincode.id.name="cc-name" \
+      org.hyperledger.fabric.chaincode.id.version="cc-version" \
+      org.hyperledger.fabric.chaincode.type="fakeType" \
+      org.hyperledger.fabric.version="%s" \
+      org.hyperledger.fabric.base.version="%s"
+LABEL org.hyperledger.fabric.chaincode.builder="builder@sha256:0123" \
+      org.hyperledger.fabric.chaincode.runtime="runtime@sha256:4567"
+ENV CORE_CHAINCODE_BUILDLEVEL=%s`, metadata.Version, metadata.BaseVersion, metadata.Version)
+				Expect(df).To(Equal(expectedDockerfile))
+			})
+
+			It("builds the chaincode with the builder image", func() {
+				registry.PackageWriter = &mock.PackageWriter{}
+				fakePlatform.GenerateDockerfileReturns("FROM base", nil)
+				reader, err := registry.GenerateDockerBuild("fakeType", "", "cc-name", "cc-version", nil)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = ioutil.ReadAll(reader)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakePlatform.GenerateDockerBuildCallCount()).To(Equal(1))
+				_, _, builderImage, _ := fakePlatform.GenerateDockerBuildArgsForCall(0)
+				Expect(builderImage).To(Equal("builder@sha256:0123"))
+			})
+
+			Context("when the platform Dockerfile does not start with FROM", func() {
+				It("returns an error", func() {
+					fakePlatform.GenerateDockerfileReturns("docker-header", nil)
+					_, err := registry.GenerateDockerfile("fakeType", "cc-name", "cc-version")
+					Expect(err).To(MatchError("Failed to set the runtime image of the platform-specific Dockerfile: docker-header"))
+				})
+			})
+		})
+
 		Context("when the underlying platform returns an error", func() {
 			It("returns the error", func() {
 				fakePlatform.GenerateDockerfileReturns("docker-header", errors.New("fake-error"))
@@ -165,7 +232,7 @@ ENV CORE_CHAINCODE_BUILDLEVEL=%s`, metadata.Version, metadata.BaseVersion, metad
 				fileMap := map[string][]byte{
 					"foo": []byte("foo-bytes"),
 				}
-				err := registry.StreamDockerBuild("fakeType", "", nil, fileMap, tw)
+				err := registry.StreamDockerBuild("fakeType", "", "", nil, fileMap, tw)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(pw.WriteCallCount()).To(Equal(1))
 				name, data, writer := pw.WriteArgsForCall(0)
@@ -177,7 +244,7 @@ ENV CORE_CHAINCODE_BUILDLEVEL=%s`, metadata.Version, metadata.BaseVersion, metad
 
 			Context("when the platform is unknown", func() {
 				It("returns an error", func() {
-					err := registry.StreamDockerBuild("badType", "", nil, nil, tw)
+					err := registry.StreamDockerBuild("badType", "", "", nil, nil, tw)
 					Expect(err).To(MatchError("could not find platform of type: badType"))
 				})
 			})
@@ -189,7 +256,7 @@ ENV CORE_CHAINCODE_BUILDLEVEL=%s`, metadata.Version, metadata.BaseVersion, metad
 					}
 
 					pw.WriteReturns(errors.New("fake-error"))
-					err := registry.StreamDockerBuild("fakeType", "", nil, fileMap, tw)
+					err := registry.StreamDockerBuild("fakeType", "", "", nil, fileMap, tw)
 					Expect(err).To(MatchError("Failed to inject \"foo\": fake-error"))
 					Expect(pw.WriteCallCount()).To(Equal(1))
 				})
@@ -198,7 +265,7 @@ ENV CORE_CHAINCODE_BUILDLEVEL=%s`, metadata.Version, metadata.BaseVersion, metad
 			Context("when the underlying platform fails", func() {
 				It("returns an error", func() {
 					fakePlatform.GenerateDockerBuildReturns(errors.New("fake-error"))
-					err := registry.StreamDockerBuild("fakeType", "", nil, nil, tw)
+					err := registry.StreamDockerBuild("fakeType", "", "", nil, nil, tw)
 					Expect(err).To(MatchError("Failed to generate platform-specific docker build: fake-error"))
 				})
 			})
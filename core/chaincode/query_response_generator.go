@@ -25,8 +25,22 @@ func (q *QueryResponseGenerator) BuildQueryResponse(txContext *TransactionContex
 
 	for {
 		// if the total count has been reached, return the result and prevent the Next() being called
+		// beyond the one needed to tell the chaincode whether its results were truncated
 		if *totalReturnCount >= totalReturnLimit {
-			return createQueryResponse(txContext, iterID, isPaginated, pendingQueryResults, *totalReturnCount)
+			truncated := false
+			if !isPaginated {
+				queryResult, err := iter.Next()
+				if err != nil {
+					chaincodeLogger.Errorf("Failed to get query result from iterator")
+					txContext.CleanupQueryContext(iterID)
+					return nil, err
+				}
+				truncated = queryResult != nil
+			}
+			if truncated {
+				chaincodeLogger.Warningf("Query results truncated to the query limit of %d records", totalReturnLimit)
+			}
+			return createQueryResponse(txContext, iterID, isPaginated, pendingQueryResults, *totalReturnCount, truncated)
 		}
 
 		queryResult, err := iter.Next()
@@ -38,7 +52,7 @@ func (q *QueryResponseGenerator) BuildQueryResponse(txContext *TransactionContex
 
 		case queryResult == nil:
 
-			return createQueryResponse(txContext, iterID, isPaginated, pendingQueryResults, *totalReturnCount)
+			return createQueryResponse(txContext, iterID, isPaginated, pendingQueryResults, *totalReturnCount, false)
 
 		case !isPaginated && pendingQueryResults.Size() == q.MaxResultLimit:
 			// if explicit pagination is not used
@@ -63,7 +77,7 @@ func (q *QueryResponseGenerator) BuildQueryResponse(txContext *TransactionContex
 	}
 }
 
-func createQueryResponse(txContext *TransactionContext, iterID string, isPaginated bool, pendingQueryResults *PendingQueryResult, totalReturnCount int32) (*pb.QueryResponse, error) {
+func createQueryResponse(txContext *TransactionContext, iterID string, isPaginated bool, pendingQueryResults *PendingQueryResult, totalReturnCount int32, truncated bool) (*pb.QueryResponse, error) {
 
 	batch := pendingQueryResults.Cut()
 
@@ -80,7 +94,7 @@ func createQueryResponse(txContext *TransactionContext, iterID string, isPaginat
 
 	// if explicit pagination is not used, then the end of the resultset has been reached, return the batch
 	txContext.CleanupQueryContext(iterID)
-	return &pb.QueryResponse{Results: batch, HasMore: false, Id: iterID}, nil
+	return &pb.QueryResponse{Results: batch, HasMore: false, Id: iterID, Truncated: truncated}, nil
 
 }
 
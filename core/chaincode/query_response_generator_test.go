@@ -100,6 +100,8 @@ func TestBuildQueryResponse(t *testing.T) {
 					// remainder retrieved, no more expected
 					assert.Len(t, queryResponse.GetResults(), tc.expectedResultCount-totalResultCount)
 					assert.False(t, queryResponse.GetHasMore())
+					// results beyond the total query limit are reported as truncated
+					assert.Equal(t, !tc.isPaginated && tc.recordCount > tc.totalQueryLimit, queryResponse.GetTruncated())
 
 				}
 				totalResultCount += len(queryResponse.GetResults())
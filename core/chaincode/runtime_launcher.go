@@ -27,10 +27,11 @@ type PackageProvider interface {
 
 // RuntimeLauncher is responsible for launching chaincode runtimes.
 type RuntimeLauncher struct {
-	Runtime         Runtime
-	Registry        LaunchRegistry
-	PackageProvider PackageProvider
-	StartupTimeout  time.Duration
+	Runtime          Runtime
+	Registry         LaunchRegistry
+	PackageProvider  PackageProvider
+	StartupTimeout   time.Duration
+	FailureDiagnoser *FailureDiagnoser
 }
 
 func (r *RuntimeLauncher) Launch(ccci *ccprovider.ChaincodeContainerInfo) error {
@@ -67,6 +68,8 @@ func (r *RuntimeLauncher) Launch(ccci *ccprovider.ChaincodeContainerInfo) error
 	}
 
 	if err != nil && !started {
+		// the container is diagnosed before it is removed
+		err = r.FailureDiagnoser.Diagnose(ccci, err)
 		chaincodeLogger.Debugf("stopping due to error while launching: %+v", err)
 		defer r.Registry.Deregister(cname)
 		if err := r.Runtime.Stop(ccci); err != nil {
@@ -25,6 +25,7 @@ import (
 	"github.com/hyperledger/fabric/bccsp/factory"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -119,7 +120,11 @@ func userChaincodeStreamGetter(name string) (PeerChaincodeStream, error) {
 	chaincodeSupportClient := pb.NewChaincodeSupportClient(clientConn)
 
 	// Establish stream with validating peer
-	stream, err := chaincodeSupportClient.Register(context.Background())
+	var callOpts []grpc.CallOption
+	if viper.GetBool("chaincode.compression.enabled") {
+		callOpts = append(callOpts, comm.CompressionCallOption())
+	}
+	stream, err := chaincodeSupportClient.Register(context.Background(), callOpts...)
 	if err != nil {
 		return nil, errors.WithMessage(err, fmt.Sprintf("error chatting with leader at address=%s", getPeerAddress()))
 	}
@@ -552,7 +557,7 @@ func (stub *ChaincodeStub) GetPrivateDataByRange(collection, startKey, endKey st
 		return nil, err
 	}
 	// ignore QueryResponseMetadata as it is not applicable for a range query without pagination
-	iterator, _, err := stub.handleGetStateByRange(collection, startKey, endKey, nil)
+	iterator, _, err := stub.handleGetStateByRange(collection, startKey, endKey, nil, false)
 
 	return iterator, err
 }
@@ -579,7 +584,7 @@ func (stub *ChaincodeStub) GetPrivateDataByPartialCompositeKey(collection, objec
 		return nil, err
 	}
 	// ignore QueryResponseMetadata as it is not applicable for a partial composite key query without pagination
-	iterator, _, err := stub.handleGetStateByRange(collection, startKey, endKey, nil)
+	iterator, _, err := stub.handleGetStateByRange(collection, startKey, endKey, nil, false)
 
 	return iterator, err
 }
@@ -649,9 +654,9 @@ func createQueryResponseMetadata(metadataBytes []byte) (*pb.QueryResponseMetadat
 }
 
 func (stub *ChaincodeStub) handleGetStateByRange(collection, startKey, endKey string,
-	metadata []byte) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	metadata []byte, reverse bool) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
 
-	response, err := stub.handler.handleGetStateByRange(collection, startKey, endKey, metadata, stub.ChannelId, stub.TxID)
+	response, err := stub.handler.handleGetStateByRange(collection, startKey, endKey, metadata, reverse, stub.ChannelId, stub.TxID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -693,7 +698,23 @@ func (stub *ChaincodeStub) GetStateByRange(startKey, endKey string) (StateQueryI
 	collection := ""
 
 	// ignore QueryResponseMetadata as it is not applicable for a range query without pagination
-	iterator, _, err := stub.handleGetStateByRange(collection, startKey, endKey, nil)
+	iterator, _, err := stub.handleGetStateByRange(collection, startKey, endKey, nil, false)
+
+	return iterator, err
+}
+
+// GetStateByRangeReverse documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetStateByRangeReverse(startKey, endKey string) (StateQueryIteratorInterface, error) {
+	if startKey == "" {
+		startKey = emptyKeySubstitute
+	}
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, err
+	}
+	collection := ""
+
+	// ignore QueryResponseMetadata as it is not applicable for a range query without pagination
+	iterator, _, err := stub.handleGetStateByRange(collection, startKey, endKey, nil, true)
 
 	return iterator, err
 }
@@ -782,7 +803,20 @@ func (stub *ChaincodeStub) GetStateByPartialCompositeKey(objectType string, attr
 		return nil, err
 	}
 	// ignore QueryResponseMetadata as it is not applicable for a partial composite key query without pagination
-	iterator, _, err := stub.handleGetStateByRange(collection, startKey, endKey, nil)
+	iterator, _, err := stub.handleGetStateByRange(collection, startKey, endKey, nil, false)
+
+	return iterator, err
+}
+
+// GetStateByPartialCompositeKeyReverse documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetStateByPartialCompositeKeyReverse(objectType string, attributes []string) (StateQueryIteratorInterface, error) {
+	collection := ""
+	startKey, endKey, err := stub.createRangeKeysForPartialCompositeKey(objectType, attributes)
+	if err != nil {
+		return nil, err
+	}
+	// ignore QueryResponseMetadata as it is not applicable for a partial composite key query without pagination
+	iterator, _, err := stub.handleGetStateByRange(collection, startKey, endKey, nil, true)
 
 	return iterator, err
 }
@@ -814,7 +848,7 @@ func (stub *ChaincodeStub) GetStateByRangeWithPagination(startKey, endKey string
 		return nil, nil, err
 	}
 
-	return stub.handleGetStateByRange(collection, startKey, endKey, metadata)
+	return stub.handleGetStateByRange(collection, startKey, endKey, metadata, false)
 }
 
 func (stub *ChaincodeStub) GetStateByPartialCompositeKeyWithPagination(objectType string, keys []string,
@@ -831,7 +865,7 @@ func (stub *ChaincodeStub) GetStateByPartialCompositeKeyWithPagination(objectTyp
 	if err != nil {
 		return nil, nil, err
 	}
-	return stub.handleGetStateByRange(collection, startKey, endKey, metadata)
+	return stub.handleGetStateByRange(collection, startKey, endKey, metadata, false)
 }
 
 func (stub *ChaincodeStub) GetQueryResultWithPagination(query string, pageSize int32,
@@ -936,6 +970,11 @@ func (iter *CommonIterator) nextResult(rType resultType) (commonledger.QueryResu
 	return nil, errors.New("invalid iterator state")
 }
 
+// Truncated documentation can be found in interfaces.go
+func (iter *CommonIterator) Truncated() bool {
+	return !iter.response.HasMore && iter.response.Truncated
+}
+
 // Close documentation can be found in interfaces.go
 func (iter *CommonIterator) Close() error {
 	_, err := iter.handler.handleQueryStateClose(iter.response.Id, iter.channelId, iter.txid)
@@ -1001,16 +1040,33 @@ func (stub *ChaincodeStub) GetArgsSlice() ([]byte, error) {
 
 // GetTxTimestamp documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
-	hdr, err := utils.GetHeader(stub.proposal.Header)
+	chdr, err := stub.channelHeader()
 	if err != nil {
 		return nil, err
 	}
-	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	if chdr.GetTimestamp() == nil {
+		return nil, errors.New("the transaction has no timestamp")
+	}
+
+	return chdr.GetTimestamp(), nil
+}
+
+// GetTxValidityWindow documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetTxValidityWindow() (*common.ValidityWindow, error) {
+	chdr, err := stub.channelHeader()
 	if err != nil {
 		return nil, err
 	}
 
-	return chdr.GetTimestamp(), nil
+	return chdr.GetValidityWindow(), nil
+}
+
+func (stub *ChaincodeStub) channelHeader() (*common.ChannelHeader, error) {
+	hdr, err := utils.GetHeader(stub.proposal.Header)
+	if err != nil {
+		return nil, err
+	}
+	return utils.UnmarshalChannelHeader(hdr.ChannelHeader)
 }
 
 // ------------- ChaincodeEvent API ----------------------
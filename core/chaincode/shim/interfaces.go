@@ -9,6 +9,7 @@ package shim
 import (
 	"github.com/golang/protobuf/ptypes/timestamp"
 
+	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
@@ -122,6 +123,18 @@ type ChaincodeStubInterface interface {
 	// has not changed since transaction endorsement (phantom reads detected).
 	GetStateByRange(startKey, endKey string) (StateQueryIteratorInterface, error)
 
+	// GetStateByRangeReverse returns a range iterator over the same set of keys
+	// as GetStateByRange, which returns the keys in the reverse lexical order,
+	// i.e., starting from the last key before endKey. This allows, for instance,
+	// to iterate over the most recent entries first when the keys embed a
+	// sequence number or a timestamp of fixed width. As for GetStateByRange,
+	// the results are capped by the totalQueryLimit (defined in core.yaml).
+	// Call Close() on the returned StateQueryIteratorInterface object when done.
+	// The query is re-executed during validation phase to ensure result set
+	// has not changed since transaction endorsement (phantom reads detected).
+	// Reverse range queries are not supported by CouchDB.
+	GetStateByRangeReverse(startKey, endKey string) (StateQueryIteratorInterface, error)
+
 	// GetStateByRangeWithPagination returns a range iterator over a set of keys in the
 	// ledger. The iterator can be used to fetch keys between the startKey (inclusive)
 	// and endKey (exclusive).
@@ -155,6 +168,19 @@ type ChaincodeStubInterface interface {
 	// has not changed since transaction endorsement (phantom reads detected).
 	GetStateByPartialCompositeKey(objectType string, keys []string) (StateQueryIteratorInterface, error)
 
+	// GetStateByPartialCompositeKeyReverse queries the state in the ledger based
+	// on a given partial composite key, as GetStateByPartialCompositeKey, and
+	// returns an iterator over the matching composite keys in the reverse order.
+	// Composite keys collate by their attributes in order, each attribute
+	// comparing by its utf8 bytes, so that the chaincode defines the collation
+	// of its keys by the order and the encoding of their attributes, e.g.,
+	// numbers should be encoded with a fixed width to collate numerically.
+	// Call Close() on the returned StateQueryIteratorInterface object when done.
+	// The query is re-executed during validation phase to ensure result set
+	// has not changed since transaction endorsement (phantom reads detected).
+	// Reverse range queries are not supported by CouchDB.
+	GetStateByPartialCompositeKeyReverse(objectType string, keys []string) (StateQueryIteratorInterface, error)
+
 	// GetStateByPartialCompositeKeyWithPagination queries the state in the ledger based on
 	// a given partial composite key. This function returns an iterator
 	// which can be used to iterate over the composite keys whose
@@ -338,8 +364,21 @@ type ChaincodeStubInterface interface {
 	// GetTxTimestamp returns the timestamp when the transaction was created. This
 	// is taken from the transaction ChannelHeader, therefore it will indicate the
 	// client's timestamp and will have the same value across all endorsers.
+	// Chaincodes needing the current time must use it rather than the clock of
+	// the peer, which differs across the endorsers and hence makes them produce
+	// different results for the same proposal.
 	GetTxTimestamp() (*timestamp.Timestamp, error)
 
+	// GetTxValidityWindow returns the validity window of the transaction, or nil
+	// if the client did not set any. This is taken from the transaction
+	// ChannelHeader, therefore it will have the same value across all endorsers.
+	// If the channel enforces the validity windows of the transactions, the
+	// transaction is only valid if the block holding it is created within its
+	// window, extended by the max clock skew of the channel. The window hence
+	// bounds the time at which the transaction is committed, which is not known
+	// when it is endorsed.
+	GetTxValidityWindow() (*common.ValidityWindow, error)
+
 	// SetEvent allows the chaincode to set an event on the response to the
 	// proposal to be included as part of a transaction. The event will be
 	// available within the transaction in the committed block regardless of the
@@ -357,12 +396,19 @@ type CommonIteratorInterface interface {
 	// Close closes the iterator. This should be called when done
 	// reading from the iterator to free up resources.
 	Close() error
+
+	// Truncated returns true if the results were capped by the totalQueryLimit
+	// (defined in core.yaml, and which may be overridden for the chaincode in
+	// chaincodeQueryLimits), i.e., more results matched the query than the
+	// iterator returned. It is only meaningful once HasNext returns false, and is never
+	// set for the paginated queries, whose bookmark resumes the query instead.
+	Truncated() bool
 }
 
 // StateQueryIteratorInterface allows a chaincode to iterate over a set of
 // key/value pairs returned by range and execute query.
 type StateQueryIteratorInterface interface {
-	// Inherit HasNext() and Close()
+	// Inherit HasNext(), Close() and Truncated()
 	CommonIteratorInterface
 
 	// Next returns the next key and value in the range and execute query iterator.
@@ -372,7 +418,7 @@ type StateQueryIteratorInterface interface {
 // HistoryQueryIteratorInterface allows a chaincode to iterate over a set of
 // key/value pairs returned by a history query.
 type HistoryQueryIteratorInterface interface {
-	// Inherit HasNext() and Close()
+	// Inherit HasNext(), Close() and Truncated()
 	CommonIteratorInterface
 
 	// Next returns the next key and value in the history query iterator.
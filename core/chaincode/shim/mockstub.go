@@ -11,10 +11,12 @@ package shim
 import (
 	"container/list"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/op/go-logging"
@@ -52,6 +54,10 @@ type MockStub struct {
 
 	TxTimestamp *timestamp.Timestamp
 
+	// TxValidityWindow is the validity window of the transaction being
+	// invoked, if any
+	TxValidityWindow *common.ValidityWindow
+
 	// mocked signedProposal
 	signedProposal *pb.SignedProposal
 
@@ -60,6 +66,12 @@ type MockStub struct {
 
 	PvtState map[string]map[string][]byte
 
+	// stores the results of rich queries, map index is the query
+	QueryResults map[string][]*queryresult.KV
+
+	// stores the results of rich queries of private data, first map index is the collection, second map index is the query
+	PvtQueryResults map[string]map[string][]*queryresult.KV
+
 	// stores per-key endorsement policy, first map index is the collection, second map index is the key
 	EndorsementPolicies map[string]map[string][]byte
 
@@ -155,7 +167,27 @@ func (stub *MockStub) MockInvokeWithSignedProposal(uuid string, args [][]byte, s
 	return res
 }
 
+// MockQueryResult sets the results of the given rich query of the state
+func (stub *MockStub) MockQueryResult(query string, results []*queryresult.KV) {
+	stub.QueryResults[query] = results
+}
+
+// MockPrivateDataQueryResult sets the results of the given rich query of the
+// private data of the given collection
+func (stub *MockStub) MockPrivateDataQueryResult(collection, query string, results []*queryresult.KV) {
+	m, in := stub.PvtQueryResults[collection]
+	if !in {
+		m = make(map[string][]*queryresult.KV)
+		stub.PvtQueryResults[collection] = m
+	}
+
+	m[query] = results
+}
+
 func (stub *MockStub) GetPrivateData(collection string, key string) ([]byte, error) {
+	if collection == "" {
+		return nil, errors.New("collection must not be an empty string")
+	}
 	m, in := stub.PvtState[collection]
 
 	if !in {
@@ -166,6 +198,12 @@ func (stub *MockStub) GetPrivateData(collection string, key string) ([]byte, err
 }
 
 func (stub *MockStub) PutPrivateData(collection string, key string, value []byte) error {
+	if collection == "" {
+		return errors.New("collection must not be an empty string")
+	}
+	if key == "" {
+		return errors.New("key must not be an empty string")
+	}
 	m, in := stub.PvtState[collection]
 	if !in {
 		stub.PvtState[collection] = make(map[string][]byte)
@@ -178,22 +216,52 @@ func (stub *MockStub) PutPrivateData(collection string, key string, value []byte
 }
 
 func (stub *MockStub) DelPrivateData(collection string, key string) error {
-	return errors.New("Not Implemented")
+	if collection == "" {
+		return errors.New("collection must not be an empty string")
+	}
+	delete(stub.PvtState[collection], key)
+
+	return nil
 }
 
+// GetPrivateDataByRange returns an iterator over the private data of the
+// collection whose keys are within the range, in lexical order
 func (stub *MockStub) GetPrivateDataByRange(collection, startKey, endKey string) (StateQueryIteratorInterface, error) {
-	return nil, errors.New("Not Implemented")
+	if collection == "" {
+		return nil, errors.New("collection must not be an empty string")
+	}
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, err
+	}
+	return &mockResultsIterator{results: stub.pvtStateByRange(collection, startKey, endKey)}, nil
 }
 
+// GetPrivateDataByPartialCompositeKey returns an iterator over the private
+// data of the collection whose composite keys start with the given partial
+// composite key
 func (stub *MockStub) GetPrivateDataByPartialCompositeKey(collection, objectType string, attributes []string) (StateQueryIteratorInterface, error) {
-	return nil, errors.New("Not Implemented")
+	if collection == "" {
+		return nil, errors.New("collection must not be an empty string")
+	}
+	partialCompositeKey, err := stub.CreateCompositeKey(objectType, attributes)
+	if err != nil {
+		return nil, err
+	}
+	return &mockResultsIterator{results: stub.pvtStateByRange(collection, partialCompositeKey, partialCompositeKey+string(maxUnicodeRuneValue))}, nil
 }
 
+// GetPrivateDataQueryResult returns an iterator over the results set for the
+// query of the private data of the collection with MockPrivateDataQueryResult,
+// as the mock engine does not have a query engine
 func (stub *MockStub) GetPrivateDataQueryResult(collection, query string) (StateQueryIteratorInterface, error) {
-	// Not implemented since the mock engine does not have a query engine.
-	// However, a very simple query engine that supports string matching
-	// could be implemented to test that the framework supports queries
-	return nil, errors.New("Not Implemented")
+	if collection == "" {
+		return nil, errors.New("collection must not be an empty string")
+	}
+	results, in := stub.PvtQueryResults[collection][query]
+	if !in {
+		return nil, errors.Errorf("no results for query [%s] of collection [%s] - call stub.MockPrivateDataQueryResult()?", query, collection)
+	}
+	return &mockResultsIterator{results: results}, nil
 }
 
 // GetState retrieves the value for a given key from the ledger
@@ -268,16 +336,27 @@ func (stub *MockStub) GetStateByRange(startKey, endKey string) (StateQueryIterat
 	return NewMockStateRangeQueryIterator(stub, startKey, endKey), nil
 }
 
+// GetStateByRangeReverse returns an iterator over the keys of the range, as
+// GetStateByRange, in the reverse lexical order
+func (stub *MockStub) GetStateByRangeReverse(startKey, endKey string) (StateQueryIteratorInterface, error) {
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, err
+	}
+	return &mockResultsIterator{results: reverseResults(stub.stateByRange(startKey, endKey))}, nil
+}
+
 // GetQueryResult function can be invoked by a chaincode to perform a
 // rich query against state database.  Only supported by state database implementations
 // that support rich query.  The query string is in the syntax of the underlying
 // state database. An iterator is returned which can be used to iterate (next) over
-// the query result set
+// the query result set. As the mock engine does not have a query engine, the
+// results are the ones set for the query with MockQueryResult.
 func (stub *MockStub) GetQueryResult(query string) (StateQueryIteratorInterface, error) {
-	// Not implemented since the mock engine does not have a query engine.
-	// However, a very simple query engine that supports string matching
-	// could be implemented to test that the framework supports queries
-	return nil, errors.New("not implemented")
+	results, in := stub.QueryResults[query]
+	if !in {
+		return nil, errors.Errorf("no results for query [%s] - call stub.MockQueryResult()?", query)
+	}
+	return &mockResultsIterator{results: results}, nil
 }
 
 // GetHistoryForKey function can be invoked by a chaincode to return a history of
@@ -300,6 +379,17 @@ func (stub *MockStub) GetStateByPartialCompositeKey(objectType string, attribute
 	return NewMockStateRangeQueryIterator(stub, partialCompositeKey, partialCompositeKey+string(maxUnicodeRuneValue)), nil
 }
 
+// GetStateByPartialCompositeKeyReverse returns an iterator over the keys
+// starting with the given partial composite key, in the reverse lexical order
+func (stub *MockStub) GetStateByPartialCompositeKeyReverse(objectType string, attributes []string) (StateQueryIteratorInterface, error) {
+	partialCompositeKey, err := stub.CreateCompositeKey(objectType, attributes)
+	if err != nil {
+		return nil, err
+	}
+	results := stub.stateByRange(partialCompositeKey, partialCompositeKey+string(maxUnicodeRuneValue))
+	return &mockResultsIterator{results: reverseResults(results)}, nil
+}
+
 // CreateCompositeKey combines the list of attributes
 //to form a composite key.
 func (stub *MockStub) CreateCompositeKey(objectType string, attributes []string) (string, error) {
@@ -312,19 +402,120 @@ func (stub *MockStub) SplitCompositeKey(compositeKey string) (string, []string,
 	return splitCompositeKey(compositeKey)
 }
 
+// GetStateByRangeWithPagination returns an iterator over at most pageSize
+// keys of the range, starting at the bookmark if any. As with the ledger, the
+// returned bookmark is the key following the page, empty after the last page,
+// and a pageSize of 0 means no limit.
 func (stub *MockStub) GetStateByRangeWithPagination(startKey, endKey string, pageSize int32,
 	bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
-	return nil, nil, nil
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, nil, err
+	}
+	if bookmark != "" {
+		startKey = bookmark
+	}
+	iter, metadata := paginate(stub.stateByRange(startKey, endKey), pageSize)
+	return iter, metadata, nil
 }
 
+// GetStateByPartialCompositeKeyWithPagination returns an iterator over at most
+// pageSize keys starting with the given partial composite key, starting at the
+// bookmark if any
 func (stub *MockStub) GetStateByPartialCompositeKeyWithPagination(objectType string, keys []string,
 	pageSize int32, bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
-	return nil, nil, nil
+	partialCompositeKey, err := stub.CreateCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	startKey := partialCompositeKey
+	if bookmark != "" {
+		startKey = bookmark
+	}
+	iter, metadata := paginate(stub.stateByRange(startKey, partialCompositeKey+string(maxUnicodeRuneValue)), pageSize)
+	return iter, metadata, nil
 }
 
+// GetQueryResultWithPagination returns an iterator over at most pageSize of
+// the results set for the query with MockQueryResult, starting at the result
+// whose key is the bookmark if any
 func (stub *MockStub) GetQueryResultWithPagination(query string, pageSize int32,
 	bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
-	return nil, nil, nil
+	results, in := stub.QueryResults[query]
+	if !in {
+		return nil, nil, errors.Errorf("no results for query [%s] - call stub.MockQueryResult()?", query)
+	}
+	if bookmark != "" {
+		i := 0
+		for i < len(results) && results[i].Key != bookmark {
+			i++
+		}
+		if i == len(results) {
+			return nil, nil, errors.Errorf("bookmark [%s] is not the key of any result of query [%s]", bookmark, query)
+		}
+		results = results[i:]
+	}
+	iter, metadata := paginate(results, pageSize)
+	return iter, metadata, nil
+}
+
+// stateByRange returns the keys and values of the state within the range, in
+// lexical order
+func (stub *MockStub) stateByRange(startKey, endKey string) []*queryresult.KV {
+	var results []*queryresult.KV
+	for elem := stub.Keys.Front(); elem != nil; elem = elem.Next() {
+		key := elem.Value.(string)
+		if inRange(key, startKey, endKey) {
+			results = append(results, &queryresult.KV{Key: key, Value: stub.State[key]})
+		}
+	}
+	return results
+}
+
+// pvtStateByRange returns the keys and values of the private data of the
+// collection within the range, in lexical order
+func (stub *MockStub) pvtStateByRange(collection, startKey, endKey string) []*queryresult.KV {
+	var keys []string
+	for key := range stub.PvtState[collection] {
+		if inRange(key, startKey, endKey) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	results := make([]*queryresult.KV, 0, len(keys))
+	for _, key := range keys {
+		results = append(results, &queryresult.KV{Key: key, Value: stub.PvtState[collection][key]})
+	}
+	return results
+}
+
+// reverseResults reverses the order of the results in place
+func reverseResults(results []*queryresult.KV) []*queryresult.KV {
+	for i, j := 0, len(results)-1; i < j; i, j = i+1, j-1 {
+		results[i], results[j] = results[j], results[i]
+	}
+	return results
+}
+
+// inRange returns whether the key is within the range. As with
+// MockStateRangeQueryIterator, the end key is part of the range. An empty
+// end key does not bound the range.
+func inRange(key, startKey, endKey string) bool {
+	return key >= startKey && (endKey == "" || key <= endKey)
+}
+
+// paginate returns an iterator over the first pageSize results, or over all
+// of them if pageSize is 0, along with the key of the next result as bookmark
+func paginate(results []*queryresult.KV, pageSize int32) (StateQueryIteratorInterface, *pb.QueryResponseMetadata) {
+	bookmark := ""
+	if pageSize > 0 && int(pageSize) < len(results) {
+		bookmark = results[pageSize].Key
+		results = results[:pageSize]
+	}
+	return &mockResultsIterator{results: results}, &pb.QueryResponseMetadata{
+		FetchedRecordsCount: int32(len(results)),
+		Bookmark:            bookmark,
+	}
 }
 
 // InvokeChaincode calls a peered chaincode.
@@ -385,6 +576,10 @@ func (stub *MockStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
 	return stub.TxTimestamp, nil
 }
 
+func (stub *MockStub) GetTxValidityWindow() (*common.ValidityWindow, error) {
+	return stub.TxValidityWindow, nil
+}
+
 func (stub *MockStub) SetEvent(name string, payload []byte) error {
 	stub.ChaincodeEventsChannel <- &pb.ChaincodeEvent{EventName: name, Payload: payload}
 	return nil
@@ -427,6 +622,8 @@ func NewMockStub(name string, cc Chaincode) *MockStub {
 	s.cc = cc
 	s.State = make(map[string][]byte)
 	s.PvtState = make(map[string]map[string][]byte)
+	s.QueryResults = make(map[string][]*queryresult.KV)
+	s.PvtQueryResults = make(map[string]map[string][]*queryresult.KV)
 	s.EndorsementPolicies = make(map[string]map[string][]byte)
 	s.Invokables = make(map[string]*MockStub)
 	s.Keys = list.New()
@@ -533,6 +730,11 @@ func (iter *MockStateRangeQueryIterator) Close() error {
 	return nil
 }
 
+// Truncated returns false as the results of the mock iterator are never capped
+func (iter *MockStateRangeQueryIterator) Truncated() bool {
+	return false
+}
+
 func (iter *MockStateRangeQueryIterator) Print() {
 	mockLogger.Debug("MockStateRangeQueryIterator {")
 	mockLogger.Debug("Closed?", iter.Closed)
@@ -558,6 +760,45 @@ func NewMockStateRangeQueryIterator(stub *MockStub, startKey string, endKey stri
 	return iter
 }
 
+// mockResultsIterator iterates over the results of a query computed upfront
+type mockResultsIterator struct {
+	closed  bool
+	results []*queryresult.KV
+	current int
+}
+
+// HasNext returns true if the iterator contains additional keys and values.
+func (iter *mockResultsIterator) HasNext() bool {
+	return !iter.closed && iter.current < len(iter.results)
+}
+
+// Next returns the next key and value in the iterator.
+func (iter *mockResultsIterator) Next() (*queryresult.KV, error) {
+	if iter.closed {
+		return nil, errors.New("mockResultsIterator.Next() called after Close()")
+	}
+	if !iter.HasNext() {
+		return nil, errors.New("mockResultsIterator.Next() called when it does not HaveNext()")
+	}
+	result := iter.results[iter.current]
+	iter.current++
+	return result, nil
+}
+
+// Close closes the iterator.
+func (iter *mockResultsIterator) Close() error {
+	if iter.closed {
+		return errors.New("mockResultsIterator.Close() called after Close()")
+	}
+	iter.closed = true
+	return nil
+}
+
+// Truncated returns false as the results of the mock iterator are never capped
+func (iter *mockResultsIterator) Truncated() bool {
+	return false
+}
+
 func getBytes(function string, args []string) [][]byte {
 	bytes := make([][]byte, 0, len(args)+1)
 	bytes = append(bytes, []byte(function))
@@ -20,10 +20,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMockStateRangeQueryIterator(t *testing.T) {
@@ -223,6 +230,246 @@ func TestGetStateByPartialCompositeKeyCollision(t *testing.T) {
 	}
 }
 
+// readAll returns the keys and values of the iterator, and closes it
+func readAll(t *testing.T, iter StateQueryIteratorInterface) map[string]string {
+	kvs := map[string]string{}
+	var keys []string
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		assert.NoError(t, err)
+		kvs[kv.Key] = string(kv.Value)
+		keys = append(keys, kv.Key)
+	}
+	assert.True(t, sort.StringsAreSorted(keys), "keys are not in lexical order: %v", keys)
+	assert.NoError(t, iter.Close())
+	return kvs
+}
+
+func TestMockPrivateData(t *testing.T) {
+	stub := NewMockStub("PrivateData", nil)
+	stub.MockTransactionStart("init")
+	defer stub.MockTransactionEnd("init")
+
+	for _, kv := range []struct{ collection, key, value string }{
+		{"coll1", "a", "A1"},
+		{"coll1", "b", "B1"},
+		{"coll1", "c", "C1"},
+		{"coll2", "a", "A2"},
+	} {
+		assert.NoError(t, stub.PutPrivateData(kv.collection, kv.key, []byte(kv.value)))
+	}
+	value, err := stub.GetPrivateData("coll1", "a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("A1"), value)
+	value, err = stub.GetPrivateData("coll3", "a")
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	iter, err := stub.GetPrivateDataByRange("coll1", "b", "")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"b": "B1", "c": "C1"}, readAll(t, iter))
+	iter, err = stub.GetPrivateDataByRange("coll1", "", "b")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "A1", "b": "B1"}, readAll(t, iter))
+
+	assert.NoError(t, stub.DelPrivateData("coll1", "b"))
+	assert.NoError(t, stub.DelPrivateData("coll3", "b"))
+	iter, err = stub.GetPrivateDataByRange("coll1", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "A1", "c": "C1"}, readAll(t, iter))
+
+	_, err = stub.GetPrivateData("", "a")
+	assert.EqualError(t, err, "collection must not be an empty string")
+	assert.EqualError(t, stub.PutPrivateData("", "a", nil), "collection must not be an empty string")
+	assert.EqualError(t, stub.PutPrivateData("coll1", "", nil), "key must not be an empty string")
+	assert.EqualError(t, stub.DelPrivateData("", "a"), "collection must not be an empty string")
+	_, err = stub.GetPrivateDataByRange("", "a", "b")
+	assert.EqualError(t, err, "collection must not be an empty string")
+	_, err = stub.GetPrivateDataByRange("coll1", compositeKeyNamespace+"a", "b")
+	assert.Error(t, err)
+}
+
+func TestMockPrivateDataByPartialCompositeKey(t *testing.T) {
+	stub := NewMockStub("PrivateDataByPartialCompositeKey", nil)
+	stub.MockTransactionStart("init")
+	defer stub.MockTransactionEnd("init")
+
+	key := func(attributes ...string) string {
+		k, err := stub.CreateCompositeKey("marble", attributes)
+		assert.NoError(t, err)
+		return k
+	}
+	assert.NoError(t, stub.PutPrivateData("coll1", key("red", "1"), []byte("red 1")))
+	assert.NoError(t, stub.PutPrivateData("coll1", key("blue", "1"), []byte("blue 1")))
+	assert.NoError(t, stub.PutPrivateData("coll2", key("red", "2"), []byte("red 2")))
+	// the state and the other collections are not queried
+	assert.NoError(t, stub.PutState(key("red", "3"), []byte("red 3")))
+
+	iter, err := stub.GetPrivateDataByPartialCompositeKey("coll1", "marble", []string{"red"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{key("red", "1"): "red 1"}, readAll(t, iter))
+	iter, err = stub.GetPrivateDataByPartialCompositeKey("coll2", "marble", []string{"red"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{key("red", "2"): "red 2"}, readAll(t, iter))
+	iter, err = stub.GetPrivateDataByPartialCompositeKey("coll1", "marble", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{key("red", "1"): "red 1", key("blue", "1"): "blue 1"}, readAll(t, iter))
+
+	_, err = stub.GetPrivateDataByPartialCompositeKey("", "marble", nil)
+	assert.EqualError(t, err, "collection must not be an empty string")
+	_, err = stub.GetPrivateDataByPartialCompositeKey("coll1", "marble", []string{"\x00"})
+	assert.Error(t, err)
+}
+
+func TestMockStateWithPagination(t *testing.T) {
+	stub := NewMockStub("StateWithPagination", nil)
+	stub.MockTransactionStart("init")
+	defer stub.MockTransactionEnd("init")
+	for _, key := range []string{"e", "a", "d", "b", "c"} {
+		assert.NoError(t, stub.PutState(key, []byte(strings.ToUpper(key))))
+	}
+
+	iter, metadata, err := stub.GetStateByRangeWithPagination("a", "d", 2, "")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "A", "b": "B"}, readAll(t, iter))
+	assert.Equal(t, &pb.QueryResponseMetadata{FetchedRecordsCount: 2, Bookmark: "c"}, metadata)
+
+	iter, metadata, err = stub.GetStateByRangeWithPagination("a", "d", 2, metadata.Bookmark)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"c": "C", "d": "D"}, readAll(t, iter))
+	assert.Equal(t, &pb.QueryResponseMetadata{FetchedRecordsCount: 2, Bookmark: ""}, metadata)
+
+	iter, metadata, err = stub.GetStateByRangeWithPagination("", "", 0, "")
+	assert.NoError(t, err)
+	assert.Len(t, readAll(t, iter), 5)
+	assert.Equal(t, &pb.QueryResponseMetadata{FetchedRecordsCount: 5, Bookmark: ""}, metadata)
+
+	_, _, err = stub.GetStateByRangeWithPagination(compositeKeyNamespace+"a", "", 2, "")
+	assert.Error(t, err)
+
+	for _, attributes := range [][]string{{"red", "1"}, {"red", "2"}, {"red", "3"}, {"blue", "1"}} {
+		key, err := stub.CreateCompositeKey("marble", attributes)
+		assert.NoError(t, err)
+		assert.NoError(t, stub.PutState(key, []byte(strings.Join(attributes, " "))))
+	}
+	var pages []map[string]string
+	bookmark := ""
+	for {
+		iter, metadata, err := stub.GetStateByPartialCompositeKeyWithPagination("marble", []string{"red"}, 2, bookmark)
+		assert.NoError(t, err)
+		page := map[string]string{}
+		for _, value := range readAll(t, iter) {
+			page[value] = value
+		}
+		pages = append(pages, page)
+		if bookmark = metadata.Bookmark; bookmark == "" {
+			break
+		}
+	}
+	assert.Equal(t, []map[string]string{{"red 1": "red 1", "red 2": "red 2"}, {"red 3": "red 3"}}, pages)
+}
+
+func TestMockStateReverse(t *testing.T) {
+	stub := NewMockStub("StateReverse", nil)
+	stub.MockTransactionStart("init")
+	defer stub.MockTransactionEnd("init")
+	for _, key := range []string{"e", "a", "d", "b", "c"} {
+		assert.NoError(t, stub.PutState(key, []byte(strings.ToUpper(key))))
+	}
+	keys := func(iter StateQueryIteratorInterface) []string {
+		var keys []string
+		for iter.HasNext() {
+			kv, err := iter.Next()
+			assert.NoError(t, err)
+			keys = append(keys, kv.Key)
+		}
+		return keys
+	}
+
+	iter, err := stub.GetStateByRangeReverse("b", "d")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"d", "c", "b"}, keys(iter))
+
+	iter, err = stub.GetStateByRangeReverse("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"e", "d", "c", "b", "a"}, keys(iter))
+
+	_, err = stub.GetStateByRangeReverse(compositeKeyNamespace+"a", "")
+	assert.Error(t, err)
+
+	// the sequence numbers are encoded with a fixed width to collate numerically
+	for _, attributes := range [][]string{{"red", "08"}, {"red", "10"}, {"red", "09"}, {"blue", "11"}} {
+		key, err := stub.CreateCompositeKey("marble", attributes)
+		assert.NoError(t, err)
+		assert.NoError(t, stub.PutState(key, []byte(strings.Join(attributes, " "))))
+	}
+	iter, err = stub.GetStateByPartialCompositeKeyReverse("marble", []string{"red"})
+	assert.NoError(t, err)
+	var sequences []string
+	for _, key := range keys(iter) {
+		_, attributes, err := stub.SplitCompositeKey(key)
+		assert.NoError(t, err)
+		sequences = append(sequences, attributes[1])
+	}
+	assert.Equal(t, []string{"10", "09", "08"}, sequences)
+}
+
+func TestMockQueryResult(t *testing.T) {
+	stub := NewMockStub("QueryResult", nil)
+	query := `{"selector":{"color":"red"}}`
+	results := []*queryresult.KV{
+		{Key: "marble3", Value: []byte("3")},
+		{Key: "marble1", Value: []byte("1")},
+		{Key: "marble2", Value: []byte("2")},
+	}
+
+	_, err := stub.GetQueryResult(query)
+	assert.EqualError(t, err, `no results for query [{"selector":{"color":"red"}}] - call stub.MockQueryResult()?`)
+	_, _, err = stub.GetQueryResultWithPagination(query, 2, "")
+	assert.Error(t, err)
+
+	stub.MockQueryResult(query, results)
+	iter, err := stub.GetQueryResult(query)
+	assert.NoError(t, err)
+	var keys []string
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		assert.NoError(t, err)
+		keys = append(keys, kv.Key)
+	}
+	assert.Equal(t, []string{"marble3", "marble1", "marble2"}, keys)
+	_, err = iter.Next()
+	assert.Error(t, err)
+	assert.NoError(t, iter.Close())
+	assert.Error(t, iter.Close())
+	_, err = iter.Next()
+	assert.Error(t, err)
+
+	iter, metadata, err := stub.GetQueryResultWithPagination(query, 2, "")
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.QueryResponseMetadata{FetchedRecordsCount: 2, Bookmark: "marble2"}, metadata)
+	iter, metadata, err = stub.GetQueryResultWithPagination(query, 2, metadata.Bookmark)
+	assert.NoError(t, err)
+	kv, err := iter.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, "marble2", kv.Key)
+	assert.False(t, iter.HasNext())
+	assert.Equal(t, &pb.QueryResponseMetadata{FetchedRecordsCount: 1, Bookmark: ""}, metadata)
+	_, _, err = stub.GetQueryResultWithPagination(query, 2, "marble4")
+	assert.EqualError(t, err, `bookmark [marble4] is not the key of any result of query [{"selector":{"color":"red"}}]`)
+
+	_, err = stub.GetPrivateDataQueryResult("coll1", query)
+	assert.EqualError(t, err, `no results for query [{"selector":{"color":"red"}}] of collection [coll1] - call stub.MockPrivateDataQueryResult()?`)
+	stub.MockPrivateDataQueryResult("coll1", query, results[:1])
+	iter, err = stub.GetPrivateDataQueryResult("coll1", query)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"marble3": "3"}, readAll(t, iter))
+	_, err = stub.GetPrivateDataQueryResult("coll2", query)
+	assert.Error(t, err)
+	_, err = stub.GetPrivateDataQueryResult("", query)
+	assert.EqualError(t, err, "collection must not be an empty string")
+}
+
 func TestGetTxTimestamp(t *testing.T) {
 	stub := NewMockStub("GetTxTimestamp", nil)
 	stub.MockTransactionStart("init")
@@ -235,6 +482,20 @@ func TestGetTxTimestamp(t *testing.T) {
 	stub.MockTransactionEnd("init")
 }
 
+func TestGetTxValidityWindow(t *testing.T) {
+	stub := NewMockStub("GetTxValidityWindow", nil)
+	window, err := stub.GetTxValidityWindow()
+	if window != nil || err != nil {
+		t.FailNow()
+	}
+
+	stub.TxValidityWindow = &common.ValidityWindow{NotAfter: &timestamp.Timestamp{Seconds: 60}}
+	window, err = stub.GetTxValidityWindow()
+	if window != stub.TxValidityWindow || err != nil {
+		t.FailNow()
+	}
+}
+
 //TestMockMock clearly cheating for coverage... but not. Mock should
 //be tucked away under common/mocks package which is not
 //included for coverage. Moving mockstub to another package
@@ -254,6 +515,7 @@ func TestMockMock(t *testing.T) {
 	stub.MockInvokeWithSignedProposal("id", nil, nil)
 	stub.DelState("dummy")
 	stub.GetStateByRange("start", "end")
+	stub.GetStateByRangeReverse("start", "end")
 	stub.GetQueryResult("q")
 	stub2 := NewMockStub("othercc", &shimTestCC{})
 	stub.MockPeerChaincode("othercc/mychan", stub2)
@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/core/comm"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// ChaincodeServer serves a chaincode run as an external service. Rather than
+// launching the chaincode, the peers connect to the server at the endpoint
+// of its deployment spec, which lets the chaincode run where the peers
+// cannot open connections back to.
+type ChaincodeServer struct {
+	// CCID is the name and the version of the chaincode, as in "mycc:1.0",
+	// which the chaincode registers with
+	CCID string
+	// Address is the address the server listens on
+	Address string
+	// CC is the chaincode served
+	CC Chaincode
+	// ServerConfig configures the gRPC server, such as with the TLS
+	// certificate the peers verify the chaincode with
+	ServerConfig comm.ServerConfig
+
+	server *comm.GRPCServer
+}
+
+// Start serves the chaincode until the server is stopped
+func (cs *ChaincodeServer) Start() error {
+	if cs.CCID == "" {
+		return errors.New("error chaincode id not provided")
+	}
+	if cs.CC == nil {
+		return errors.New("error chaincode not provided")
+	}
+
+	err := factory.InitFactories(factory.GetDefaultOpts())
+	if err != nil {
+		return errors.WithMessage(err, "internal error, BCCSP could not be initialized with default options")
+	}
+
+	cs.server, err = comm.NewGRPCServer(cs.Address, cs.ServerConfig)
+	if err != nil {
+		return errors.WithMessage(err, "error creating chaincode server")
+	}
+	pb.RegisterChaincodeServer(cs.server.Server(), &chaincodeConnector{ccid: cs.CCID, cc: cs.CC})
+	return cs.server.Start()
+}
+
+// Stop stops the server, ending the streams of the peers
+func (cs *ChaincodeServer) Stop() {
+	if cs.server != nil {
+		cs.server.Stop()
+	}
+}
+
+// chaincodeConnector registers the chaincode over the streams the peers open
+type chaincodeConnector struct {
+	ccid string
+	cc   Chaincode
+}
+
+// Connect chats with the peer over the stream it opened until it ends
+func (c *chaincodeConnector) Connect(stream pb.Chaincode_ConnectServer) error {
+	return chatWithPeer(c.ccid, &serverStream{stream}, c.cc)
+}
+
+// serverStream adapts the server side of a stream to PeerChaincodeStream.
+// The stream is closed when Connect returns.
+type serverStream struct {
+	pb.Chaincode_ConnectServer
+}
+
+func (s *serverStream) CloseSend() error {
+	return nil
+}
@@ -23,9 +23,12 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/flogging"
 	mockpeer "github.com/hyperledger/fabric/common/mocks/peer"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protos/common"
 	lproto "github.com/hyperledger/fabric/protos/ledger/queryresult"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -490,6 +493,39 @@ func TestNilEventName(t *testing.T) {
 
 }
 
+func TestTxTimestampAndValidityWindow(t *testing.T) {
+	prop, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, "testchannel", &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "mycc"}}}, []byte("creator"))
+	assert.NoError(t, err)
+	stub := &ChaincodeStub{proposal: prop}
+
+	ts, err := stub.GetTxTimestamp()
+	assert.NoError(t, err)
+	assert.NotNil(t, ts)
+	window, err := stub.GetTxValidityWindow()
+	assert.NoError(t, err)
+	assert.Nil(t, window)
+
+	notAfter := &timestamp.Timestamp{Seconds: ts.Seconds + 60}
+	err = utils.SetProposalValidityWindow(prop, &common.ValidityWindow{NotAfter: notAfter})
+	assert.NoError(t, err)
+	window, err = stub.GetTxValidityWindow()
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(&common.ValidityWindow{NotAfter: notAfter}, window))
+	newTs, err := stub.GetTxTimestamp()
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(ts, newTs))
+
+	hdr, err := utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	assert.NoError(t, err)
+	chdr.Timestamp = nil
+	hdr.ChannelHeader = utils.MarshalOrPanic(chdr)
+	prop.Header = utils.MarshalOrPanic(hdr)
+	_, err = stub.GetTxTimestamp()
+	assert.EqualError(t, err, "the transaction has no timestamp")
+}
+
 type testCase struct {
 	name         string
 	ccLogLevel   string
@@ -1197,3 +1233,13 @@ func TestSend(t *testing.T) {
 	err := stream.Send(msg)
 	assert.NotNil(t, err, "should have errored on panic")
 }
+
+func TestCommonIteratorTruncated(t *testing.T) {
+	// the flag is only reported on the last response of the query
+	iter := &CommonIterator{response: &pb.QueryResponse{HasMore: true, Truncated: true}}
+	assert.False(t, iter.Truncated())
+	iter.response = &pb.QueryResponse{HasMore: false, Truncated: true}
+	assert.True(t, iter.Truncated())
+	iter.response = &pb.QueryResponse{HasMore: false}
+	assert.False(t, iter.Truncated())
+}
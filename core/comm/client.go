@@ -58,6 +58,10 @@ func NewGRPCClient(config ClientConfig) (*GRPCClient, error) {
 	// set keepalive and blocking
 	client.dialOpts = append(client.dialOpts, grpc.WithKeepaliveParams(kap),
 		grpc.WithBlock())
+	if config.Compression {
+		client.dialOpts = append(client.dialOpts,
+			grpc.WithDefaultCallOptions(CompressionCallOption()))
+	}
 	client.timeout = config.Timeout
 	// set send/recv message size to package defaults
 	client.maxRecvMsgSize = MaxRecvMsgSize
@@ -73,7 +77,10 @@ func (client *GRPCClient) parseSecureOptions(opts *SecureOptions) error {
 	}
 	client.tlsConfig = &tls.Config{
 		VerifyPeerCertificate: opts.VerifyCertificate,
-		MinVersion:            tls.VersionTLS12} // TLS 1.2 only
+		// the connections of the client resume the TLS sessions of the
+		// previous ones rather than performing full handshakes
+		ClientSessionCache: tls.NewLRUClientSessionCache(0),
+		MinVersion:         tls.VersionTLS12} // TLS 1.2 only
 	if len(opts.ServerRootCAs) > 0 {
 		client.tlsConfig.RootCAs = x509.NewCertPool()
 		for _, certBytes := range opts.ServerRootCAs {
@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// CompressorName is the name the gzip compression of the calls is
+// negotiated under
+const CompressorName = "gzip"
+
+var enableCompressionOnce sync.Once
+
+// EnableCompression registers the gzip compressor with gRPC. Once it is
+// registered, the gRPC servers of the process decompress the calls
+// compressed by their clients, and compress their responses to them in
+// kind; the clients of the process can request compression with
+// CompressionCallOption. Calls are never compressed unless the client
+// asks for it, so compression is negotiated per call.
+func EnableCompression() {
+	enableCompressionOnce.Do(func() {
+		encoding.RegisterCompressor(newGzipCompressor())
+	})
+}
+
+// CompressionEnabled returns whether the gzip compressor is registered
+func CompressionEnabled() bool {
+	return encoding.GetCompressor(CompressorName) != nil
+}
+
+// CompressionCallOption returns the call option making a client compress
+// its calls, and hence get the responses to them compressed. It enables
+// compression in the process if needed, as the client must be able to
+// decompress the responses.
+func CompressionCallOption() grpc.CallOption {
+	EnableCompression()
+	return grpc.UseCompressor(CompressorName)
+}
+
+// gzipCompressor implements encoding.Compressor, reusing the gzip writers
+// across messages as they are costly to allocate
+type gzipCompressor struct {
+	writers sync.Pool
+}
+
+func newGzipCompressor() *gzipCompressor {
+	c := &gzipCompressor{}
+	c.writers.New = func() interface{} {
+		return &gzipWriter{Writer: gzip.NewWriter(ioutil.Discard), pool: &c.writers}
+	}
+	return c
+}
+
+type gzipWriter struct {
+	*gzip.Writer
+	pool *sync.Pool
+}
+
+// Close flushes the compressed message and hands the writer back to the pool
+func (w *gzipWriter) Close() error {
+	defer w.pool.Put(w)
+	return w.Writer.Close()
+}
+
+func (c *gzipCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	gw := c.writers.Get().(*gzipWriter)
+	gw.Reset(w)
+	return gw, nil
+}
+
+func (c *gzipCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	return gzip.NewReader(r)
+}
+
+func (c *gzipCompressor) Name() string {
+	return CompressorName
+}
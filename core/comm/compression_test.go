@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/comm"
+	testpb "github.com/hyperledger/fabric/core/comm/testdata/grpc"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/stats"
+)
+
+// payloadRecorder is a stats handler recording the sizes of the payloads
+// received by a client
+type payloadRecorder struct {
+	mutex    sync.Mutex
+	payloads []*stats.InPayload
+}
+
+func (r *payloadRecorder) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (r *payloadRecorder) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if p, ok := s.(*stats.InPayload); ok {
+		r.mutex.Lock()
+		r.payloads = append(r.payloads, p)
+		r.mutex.Unlock()
+	}
+}
+
+func (r *payloadRecorder) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (r *payloadRecorder) HandleConn(context.Context, stats.ConnStats) {}
+
+func TestCompressor(t *testing.T) {
+	t.Parallel()
+	comm.EnableCompression()
+	assert.True(t, comm.CompressionEnabled())
+	compressor := encoding.GetCompressor(comm.CompressorName)
+	assert.NotNil(t, compressor)
+
+	msg := bytes.Repeat([]byte("compress me"), 1000)
+	// the writers are pooled, so compress twice to reuse one
+	for i := 0; i < 2; i++ {
+		buf := &bytes.Buffer{}
+		w, err := compressor.Compress(buf)
+		assert.NoError(t, err)
+		_, err = w.Write(msg)
+		assert.NoError(t, err)
+		assert.NoError(t, w.Close())
+		assert.True(t, buf.Len() < len(msg))
+
+		r, err := compressor.Decompress(buf)
+		assert.NoError(t, err)
+		decompressed, err := ioutil.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, msg, decompressed)
+	}
+}
+
+func TestCompression(t *testing.T) {
+	t.Parallel()
+	lis, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	srv, err := comm.NewGRPCServerFromListener(lis, comm.ServerConfig{Compression: true})
+	assert.NoError(t, err)
+	testpb.RegisterEchoServiceServer(srv.Server(), &echoServer{})
+	go srv.Start()
+	defer srv.Stop()
+	assert.True(t, comm.CompressionEnabled())
+
+	client, err := comm.NewGRPCClient(comm.ClientConfig{
+		Compression: true,
+		Timeout:     testTimeout,
+	})
+	assert.NoError(t, err)
+	conn, err := client.NewConnection(lis.Addr().String(), "")
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	msg := &testpb.Echo{Payload: bytes.Repeat([]byte("compress me"), 1000)}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	resp, err := testpb.NewEchoServiceClient(conn).EchoCall(ctx, msg)
+	assert.NoError(t, err)
+	assert.Equal(t, msg.Payload, resp.Payload)
+
+	// the server responds compressed to compressed calls only
+	for _, compressed := range []bool{true, false} {
+		recorder := &payloadRecorder{}
+		opts := []grpc.DialOption{grpc.WithInsecure(), grpc.WithStatsHandler(recorder)}
+		if compressed {
+			opts = append(opts, grpc.WithDefaultCallOptions(comm.CompressionCallOption()))
+		}
+		cc, err := grpc.Dial(lis.Addr().String(), opts...)
+		assert.NoError(t, err)
+		resp, err := testpb.NewEchoServiceClient(cc).EchoCall(ctx, msg)
+		cc.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, msg.Payload, resp.Payload)
+
+		recorder.mutex.Lock()
+		assert.Len(t, recorder.payloads, 1)
+		p := recorder.payloads[0]
+		assert.Equal(t, compressed, p.WireLength < p.Length, "compressed: %t, wire length: %d, length: %d", compressed, p.WireLength, p.Length)
+		recorder.mutex.Unlock()
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
 )
@@ -59,6 +60,25 @@ type ServerConfig struct {
 	UnaryInterceptors []grpc.UnaryServerInterceptor
 	// Logger specifies the logger the server will use
 	Logger *flogging.FabricLogger
+	// Compression lets the server negotiate the gzip compression of the
+	// calls with its clients. As the compressor is registered with gRPC
+	// for the whole process, it applies to all the servers of the process
+	// once enabled for one of them.
+	Compression bool
+	// MaxConcurrentStreams limits the number of concurrent streams of each
+	// connection with the server. There is no limit if it is 0.
+	MaxConcurrentStreams uint32
+	// MetricsScope, if set, is the scope the server reports its open
+	// connections, its streams and its unary calls to
+	MetricsScope metrics.Scope
+}
+
+// ForServer returns a copy of the config for the server of the given name,
+// whose logs and metrics are tagged with the name
+func (sc ServerConfig) ForServer(name string) ServerConfig {
+	sc.Logger = flogging.MustGetLogger("core/comm").With("server", name)
+	sc.MetricsScope = metrics.SubScope("grpc").Tagged(map[string]string{"server": name})
+	return sc
 }
 
 // ClientConfig defines the parameters for configuring a GRPCClient instance
@@ -70,6 +90,9 @@ type ClientConfig struct {
 	// Timeout specifies how long the client will block when attempting to
 	// establish a connection
 	Timeout time.Duration
+	// Compression makes the client compress its calls with gzip, and get
+	// the responses to them compressed
+	Compression bool
 }
 
 // SecureOptions defines the security parameters (e.g. TLS) for a
@@ -30,6 +30,10 @@ var commLogger = flogging.MustGetLogger("comm")
 var credSupport *CredentialSupport
 var once sync.Once
 
+// clientSessionCache lets the connections to the peers and the orderers
+// resume the TLS sessions established by the previous ones
+var clientSessionCache = tls.NewLRUClientSessionCache(0)
+
 // CASupport type manages certificate authorities scoped by channel
 type CASupport struct {
 	sync.RWMutex
@@ -127,7 +131,8 @@ func (cs *CredentialSupport) GetDeliverServiceCredentials(channelID string) (cre
 
 	var creds credentials.TransportCredentials
 	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{cs.clientCert},
+		Certificates:       []tls.Certificate{cs.clientCert},
+		ClientSessionCache: clientSessionCache,
 	}
 	certPool := x509.NewCertPool()
 
@@ -160,7 +165,8 @@ func (cs *CredentialSupport) GetDeliverServiceCredentials(channelID string) (cre
 func (cs *CredentialSupport) GetPeerCredentials() credentials.TransportCredentials {
 	var creds credentials.TransportCredentials
 	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{cs.clientCert},
+		Certificates:       []tls.Certificate{cs.clientCert},
+		ClientSessionCache: clientSessionCache,
 	}
 	certPool := x509.NewCertPool()
 	// loop through the server root CAs
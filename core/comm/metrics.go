@@ -0,0 +1,128 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+// Names of the metrics of the gRPC servers, tagged by server
+const (
+	// openConnectionsGauge is the number of connections open with the server
+	openConnectionsGauge = "open_connections"
+	// openStreamsGauge is the number of streams open with the server,
+	// additionally tagged by service
+	openStreamsGauge = "open_streams"
+	// streamsCounter counts the streams opened with the server, additionally
+	// tagged by service
+	streamsCounter = "streams"
+	// unaryCallsCounter counts the unary calls completed by the server,
+	// additionally tagged by service and status code
+	unaryCallsCounter = "unary_calls"
+)
+
+// serverMetrics reports the connections, streams and calls of a gRPC server
+type serverMetrics struct {
+	scope metrics.Scope
+
+	lock            sync.Mutex
+	openConnections int
+	openStreams     map[string]int
+}
+
+func newServerMetrics(scope metrics.Scope) *serverMetrics {
+	return &serverMetrics{
+		scope:       scope,
+		openStreams: make(map[string]int),
+	}
+}
+
+// serviceName returns the name of the service of the given full method name,
+// /package.Service/Method
+func serviceName(fullMethod string) string {
+	service := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(service, "/"); i >= 0 {
+		service = service[:i]
+	}
+	return service
+}
+
+func (m *serverMetrics) serviceScope(service string) metrics.Scope {
+	return m.scope.Tagged(map[string]string{"service": service})
+}
+
+// streamOpened counts a stream of the given service, and returns the number
+// of the streams of the service open
+func (m *serverMetrics) streamOpened(service string) int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.openStreams[service]++
+	return m.openStreams[service]
+}
+
+func (m *serverMetrics) streamClosed(service string) int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.openStreams[service]--
+	return m.openStreams[service]
+}
+
+// StreamServerInterceptor reports the streams opened and open by service
+func (m *serverMetrics) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	service := serviceName(info.FullMethod)
+	scope := m.serviceScope(service)
+	scope.Counter(streamsCounter).Inc(1)
+	scope.Gauge(openStreamsGauge).Update(float64(m.streamOpened(service)))
+	defer func() {
+		scope.Gauge(openStreamsGauge).Update(float64(m.streamClosed(service)))
+	}()
+	return handler(srv, ss)
+}
+
+// UnaryServerInterceptor reports the unary calls by service and status code
+func (m *serverMetrics) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	m.serviceScope(serviceName(info.FullMethod)).
+		Tagged(map[string]string{"code": status.Code(err).String()}).
+		Counter(unaryCallsCounter).Inc(1)
+	return resp, err
+}
+
+// TagRPC implements stats.Handler
+func (m *serverMetrics) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+// HandleRPC implements stats.Handler
+func (m *serverMetrics) HandleRPC(context.Context, stats.RPCStats) {}
+
+// TagConn implements stats.Handler
+func (m *serverMetrics) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn implements stats.Handler, reporting the open connections
+func (m *serverMetrics) HandleConn(_ context.Context, s stats.ConnStats) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	switch s.(type) {
+	case *stats.ConnBegin:
+		m.openConnections++
+	case *stats.ConnEnd:
+		m.openConnections--
+	default:
+		return
+	}
+	m.scope.Gauge(openConnectionsGauge).Update(float64(m.openConnections))
+}
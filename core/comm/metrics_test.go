@@ -0,0 +1,86 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockmetrics "github.com/hyperledger/fabric/common/mocks/metrics"
+	"github.com/hyperledger/fabric/core/comm"
+	testpb "github.com/hyperledger/fabric/core/comm/testdata/grpc"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// waitForGauge waits for the gauge to reach the value, as the connections are
+// reported asynchronously
+func waitForGauge(scope *mockmetrics.MockScope, name string, tags map[string]string, value float64) float64 {
+	current, _ := scope.GaugeValue(name, tags)
+	for i := 0; i < 100 && current != value; i++ {
+		time.Sleep(10 * time.Millisecond)
+		current, _ = scope.GaugeValue(name, tags)
+	}
+	return current
+}
+
+func TestServerMetrics(t *testing.T) {
+	t.Parallel()
+	scope := mockmetrics.NewMockScope()
+	serverTags := map[string]string{"server": "TestServer"}
+	serviceTags := map[string]string{"server": "TestServer", "service": "EmptyService"}
+	srv, err := comm.NewGRPCServer("localhost:0", comm.ServerConfig{
+		SecOpts:              &comm.SecureOptions{UseTLS: false},
+		MaxConcurrentStreams: 1,
+		MetricsScope:         scope.Tagged(serverTags),
+	})
+	assert.NoError(t, err)
+	testpb.RegisterEmptyServiceServer(srv.Server(), &emptyServiceServer{})
+	go srv.Start()
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(srv.Address(), grpc.WithInsecure())
+	assert.NoError(t, err)
+	client := testpb.NewEmptyServiceClient(conn)
+
+	// the unary calls are counted by status code
+	_, err = client.EmptyCall(context.Background(), &testpb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), scope.CounterValue("unary_calls", map[string]string{"server": "TestServer", "service": "EmptyService", "code": "OK"}))
+	assert.Equal(t, float64(1), waitForGauge(scope, "open_connections", serverTags, 1))
+
+	// the streams are counted while open
+	stream, err := client.EmptyStream(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Send(&testpb.Empty{}))
+	_, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), scope.CounterValue("streams", serviceTags))
+	openStreams, _ := scope.GaugeValue("open_streams", serviceTags)
+	assert.Equal(t, float64(1), openStreams)
+
+	// the streams over the limit of the connection wait for the others
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	blocked, err := client.EmptyStream(ctx)
+	if err == nil {
+		_, err = blocked.Recv()
+	}
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Equal(t, int64(1), scope.CounterValue("streams", serviceTags))
+
+	assert.NoError(t, stream.CloseSend())
+	_, err = stream.Recv()
+	assert.Error(t, err)
+	assert.Equal(t, float64(0), waitForGauge(scope, "open_streams", serviceTags, 0))
+
+	conn.Close()
+	assert.Equal(t, float64(0), waitForGauge(scope, "open_connections", serverTags, 0))
+}
@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// ConnectionPool shares a connection per endpoint between its clients,
+// instead of having each of them establish its own. The connections are
+// established on first use, and re-established once they are shut down.
+type ConnectionPool struct {
+	connect ConnectionFactory
+
+	lock  sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+// NewConnectionPool creates a ConnectionPool establishing its connections
+// with the given factory
+func NewConnectionPool(factory ConnectionFactory) *ConnectionPool {
+	return &ConnectionPool{
+		connect: factory,
+		conns:   make(map[string]*grpc.ClientConn),
+	}
+}
+
+// Get returns the connection to the given endpoint. The connection is shared,
+// and must not be closed by the caller.
+func (p *ConnectionPool) Get(endpoint string) (*grpc.ClientConn, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if conn, exists := p.conns[endpoint]; exists {
+		if conn.GetState() != connectivity.Shutdown {
+			return conn, nil
+		}
+		delete(p.conns, endpoint)
+	}
+	conn, err := p.connect(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	p.conns[endpoint] = conn
+	return conn, nil
+}
+
+// Evict closes the connection to the given endpoint, if any, so that the
+// next client gets a new one. It is meant for the connections whose
+// endpoint is known to have changed, such as the ones failing the TLS
+// handshake after a certificate rotation.
+func (p *ConnectionPool) Evict(endpoint string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if conn, exists := p.conns[endpoint]; exists {
+		conn.Close()
+		delete(p.conns, endpoint)
+	}
+}
+
+// Close closes all the connections of the pool
+func (p *ConnectionPool) Close() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for endpoint, conn := range p.conns {
+		conn.Close()
+		delete(p.conns, endpoint)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestConnectionPool(t *testing.T) {
+	t.Parallel()
+	connected := map[string]int{}
+	factory := func(endpoint string) (*grpc.ClientConn, error) {
+		if endpoint == "unreachable" {
+			return nil, errors.New("connection refused")
+		}
+		connected[endpoint]++
+		return grpc.Dial(endpoint, grpc.WithInsecure())
+	}
+	pool := NewConnectionPool(factory)
+
+	// the connections are shared
+	conn1, err := pool.Get("localhost:7050")
+	assert.NoError(t, err)
+	conn2, err := pool.Get("localhost:7050")
+	assert.NoError(t, err)
+	assert.True(t, conn1 == conn2)
+	other, err := pool.Get("localhost:8050")
+	assert.NoError(t, err)
+	assert.False(t, conn1 == other)
+	assert.Equal(t, map[string]int{"localhost:7050": 1, "localhost:8050": 1}, connected)
+
+	_, err = pool.Get("unreachable")
+	assert.EqualError(t, err, "connection refused")
+
+	// the connections shut down are re-established
+	conn1.Close()
+	conn2, err = pool.Get("localhost:7050")
+	assert.NoError(t, err)
+	assert.False(t, conn1 == conn2)
+	assert.Equal(t, 2, connected["localhost:7050"])
+
+	// the connections evicted are closed and re-established
+	pool.Evict("localhost:7050")
+	pool.Evict("unknown")
+	conn1, err = pool.Get("localhost:7050")
+	assert.NoError(t, err)
+	assert.False(t, conn1 == conn2)
+	assert.Equal(t, 3, connected["localhost:7050"])
+
+	pool.Close()
+	conn2, err = pool.Get("localhost:8050")
+	assert.NoError(t, err)
+	assert.False(t, conn2 == other)
+	pool.Close()
+}
@@ -14,6 +14,7 @@ import (
 	"net"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
@@ -132,6 +133,23 @@ func NewGRPCServerFromListener(listener net.Listener, serverConfig ServerConfig)
 	serverOpts = append(
 		serverOpts,
 		grpc.ConnectionTimeout(serverConfig.ConnectionTimeout))
+	if serverConfig.MaxConcurrentStreams > 0 {
+		serverOpts = append(
+			serverOpts,
+			grpc.MaxConcurrentStreams(serverConfig.MaxConcurrentStreams))
+	}
+	// report the metrics of the server, ahead of the other interceptors so
+	// that the calls are counted with their final status
+	if serverConfig.MetricsScope != nil {
+		m := newServerMetrics(serverConfig.MetricsScope)
+		serverOpts = append(serverOpts, grpc.StatsHandler(m))
+		serverConfig.StreamInterceptors = append(
+			[]grpc.StreamServerInterceptor{m.StreamServerInterceptor},
+			serverConfig.StreamInterceptors...)
+		serverConfig.UnaryInterceptors = append(
+			[]grpc.UnaryServerInterceptor{m.UnaryServerInterceptor},
+			serverConfig.UnaryInterceptors...)
+	}
 	// set the interceptors
 	if len(serverConfig.StreamInterceptors) > 0 {
 		serverOpts = append(
@@ -148,6 +166,10 @@ func NewGRPCServerFromListener(listener net.Listener, serverConfig ServerConfig)
 					serverConfig.UnaryInterceptors...)))
 	}
 
+	if serverConfig.Compression {
+		EnableCompression()
+	}
+
 	grpcServer.server = grpc.NewServer(serverOpts...)
 
 	return grpcServer, nil
@@ -202,6 +224,27 @@ func (gServer *GRPCServer) Stop() {
 	gServer.server.Stop()
 }
 
+// GracefulStop stops the underlying grpc.Server from accepting new connections
+// and RPCs, and waits up to the given timeout for the pending RPCs to finish
+// before closing the remaining connections. It returns false if the pending
+// RPCs did not finish in time.
+func (gServer *GRPCServer) GracefulStop(timeout time.Duration) bool {
+	stopped := make(chan struct{})
+	go func() {
+		gServer.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return true
+	case <-time.After(timeout):
+		gServer.server.Stop()
+		<-stopped
+		return false
+	}
+}
+
 // AppendClientRootCAs appends PEM-encoded X509 certificate authorities to
 // the list of authorities used to verify client certificates
 func (gServer *GRPCServer) AppendClientRootCAs(clientRoots [][]byte) error {
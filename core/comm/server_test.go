@@ -1800,3 +1800,42 @@ func TestServerInterceptors(t *testing.T) {
 	assert.Equal(t, grpc.ErrorDesc(err), msg, "Expected error from second ssi")
 	assert.Equal(t, uint32(2), atomic.LoadUint32(&ssiCount), "Expected both ssi handlers to be invoked")
 }
+
+func TestGracefulStop(t *testing.T) {
+	t.Parallel()
+
+	newServer := func() *comm.GRPCServer {
+		srv, err := comm.NewGRPCServer("localhost:0", comm.ServerConfig{})
+		assert.NoError(t, err)
+		testpb.RegisterEmptyServiceServer(srv.Server(), &emptyServiceServer{})
+		go srv.Start()
+		return srv
+	}
+
+	t.Run("NoPendingRPCs", func(t *testing.T) {
+		srv := newServer()
+		_, err := invokeEmptyCall(srv.Address(), []grpc.DialOption{grpc.WithInsecure()})
+		assert.NoError(t, err)
+		assert.True(t, srv.GracefulStop(time.Second))
+	})
+
+	t.Run("PendingStream", func(t *testing.T) {
+		srv := newServer()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		conn, err := grpc.DialContext(ctx, srv.Address(), grpc.WithInsecure(), grpc.WithBlock())
+		assert.NoError(t, err)
+		defer conn.Close()
+		stream, err := testpb.NewEmptyServiceClient(conn).EmptyStream(context.Background())
+		assert.NoError(t, err)
+		assert.NoError(t, stream.Send(&testpb.Empty{}))
+		_, err = stream.Recv()
+		assert.NoError(t, err)
+
+		// the stream is never closed by the client, so the server is stopped
+		// once the timeout expires
+		assert.False(t, srv.GracefulStop(100*time.Millisecond))
+		_, err = stream.Recv()
+		assert.Error(t, err)
+	})
+}
@@ -7,8 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package committer
 
 import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -16,6 +22,25 @@ import (
 
 var logger = flogging.MustGetLogger("committer")
 
+// transactionsCounter is the name of the counter of the committed
+// transactions, tagged by channel, chaincode and validation code
+const transactionsCounter = "transactions"
+
+// Names of the metrics of the ledger growth, tagged by channel
+const (
+	blockSizeHistogram         = "block_size_bytes"
+	blockTransactionsHistogram = "block_transactions"
+	committedBytesCounter      = "committed_bytes"
+	committedBytesRateGauge    = "committed_bytes_per_second"
+)
+
+var (
+	// blockSizeBuckets range from 1KiB to 256MiB
+	blockSizeBuckets = []float64{1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20, 4 << 20, 16 << 20, 64 << 20, 256 << 20}
+	// blockTransactionsBuckets cover blocks of up to a few thousand transactions
+	blockTransactionsBuckets = []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000}
+)
+
 //--------!!!IMPORTANT!!-!!IMPORTANT!!-!!IMPORTANT!!---------
 // This is used merely to complete the loop for the "skeleton"
 // path so we can reason about and  modify committer component
@@ -48,7 +73,11 @@ type PeerLedgerSupport interface {
 // chain information
 type LedgerCommitter struct {
 	PeerLedgerSupport
-	eventer ConfigBlockEventer
+	eventer      ConfigBlockEventer
+	metricsScope metrics.Scope
+	// lastCommit is the time at which the previous block was committed,
+	// used to compute the rate at which the ledger grows
+	lastCommit time.Time
 }
 
 // ConfigBlockEventer callback function proto type to define action
@@ -65,7 +94,11 @@ func NewLedgerCommitter(ledger PeerLedgerSupport) *LedgerCommitter {
 // same as way as NewLedgerCommitter, while also provides an option to specify callback to
 // be called upon new configuration block arrival and commit event
 func NewLedgerCommitterReactive(ledger PeerLedgerSupport, eventer ConfigBlockEventer) *LedgerCommitter {
-	return &LedgerCommitter{PeerLedgerSupport: ledger, eventer: eventer}
+	return &LedgerCommitter{
+		PeerLedgerSupport: ledger,
+		eventer:           eventer,
+		metricsScope:      metrics.SubScope("committer"),
+	}
 }
 
 // preCommit takes care to validate the block and update based on its
@@ -83,6 +116,8 @@ func (lc *LedgerCommitter) preCommit(block *common.Block) error {
 
 // CommitWithPvtData commits blocks atomically with private data
 func (lc *LedgerCommitter) CommitWithPvtData(blockAndPvtData *ledger.BlockAndPvtData) error {
+	startCommit := time.Now()
+
 	// Do validation and whatever needed before
 	// committing new block
 	if err := lc.preCommit(blockAndPvtData.Block); err != nil {
@@ -94,9 +129,98 @@ func (lc *LedgerCommitter) CommitWithPvtData(blockAndPvtData *ledger.BlockAndPvt
 		return err
 	}
 
+	tracing.EndSpans(tracing.StartTxSpans("committer.Commit", blockAndPvtData.Block, startCommit))
+	lc.reportMetrics(blockAndPvtData.Block, time.Now())
+
 	return nil
 }
 
+// reportMetrics reports the metrics of the block committed at the given time
+func (lc *LedgerCommitter) reportMetrics(block *common.Block, committed time.Time) {
+	channelID, err := utils.GetChainIDFromBlock(block)
+	if err != nil {
+		logger.Debugf("Not reporting the metrics of block [%d]: %s", block.Header.Number, err)
+		return
+	}
+	lc.reportGrowth(channelID, block, committed)
+	lc.reportValidationCodes(channelID, block)
+}
+
+// reportGrowth reports the size and the number of transactions of the
+// committed block, along with the rate at which the ledger grows
+func (lc *LedgerCommitter) reportGrowth(channelID string, block *common.Block, committed time.Time) {
+	scope := lc.metricsScope.Tagged(map[string]string{"channel": channelID})
+	size := proto.Size(block)
+	scope.Histogram(blockSizeHistogram, blockSizeBuckets).RecordValue(float64(size))
+	scope.Histogram(blockTransactionsHistogram, blockTransactionsBuckets).RecordValue(float64(len(block.GetData().GetData())))
+	scope.Counter(committedBytesCounter).Inc(int64(size))
+
+	if !lc.lastCommit.IsZero() {
+		if elapsed := committed.Sub(lc.lastCommit).Seconds(); elapsed > 0 {
+			scope.Gauge(committedBytesRateGauge).Update(float64(size) / elapsed)
+		}
+	}
+	lc.lastCommit = committed
+}
+
+// txMetricKey identifies the counter a committed transaction is reported to
+type txMetricKey struct {
+	chaincode      string
+	validationCode string
+}
+
+// reportValidationCodes counts the transactions of the committed block by
+// chaincode and final validation code, which includes the codes set by the
+// ledger upon commit, such as MVCC_READ_CONFLICT
+func (lc *LedgerCommitter) reportValidationCodes(channelID string, block *common.Block) {
+	if block.Data == nil || block.Metadata == nil || len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		return
+	}
+	txsFilter := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	if len(txsFilter) < len(block.Data.Data) {
+		logger.Debugf("Not reporting the validation codes of block [%d]: transactions filter too short", block.Header.Number)
+		return
+	}
+
+	counts := make(map[txMetricKey]int64)
+	for i, envBytes := range block.Data.Data {
+		key := txMetricKey{
+			chaincode:      txChaincodeName(envBytes),
+			validationCode: txsFilter.Flag(i).String(),
+		}
+		counts[key]++
+	}
+	for key, count := range counts {
+		lc.metricsScope.Tagged(map[string]string{
+			"channel":         channelID,
+			"chaincode":       key.chaincode,
+			"validation_code": key.validationCode,
+		}).Counter(transactionsCounter).Inc(count)
+	}
+}
+
+// txChaincodeName returns the name of the chaincode invoked by the given
+// transaction, or an empty string if it is not an endorser transaction
+func txChaincodeName(envBytes []byte) string {
+	env, err := utils.GetEnvelopeFromBlock(envBytes)
+	if err != nil {
+		return ""
+	}
+	payload, err := utils.GetPayload(env)
+	if err != nil || payload.Header == nil {
+		return ""
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil || common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+		return ""
+	}
+	ext, err := utils.GetChaincodeHeaderExtension(payload.Header)
+	if err != nil {
+		return ""
+	}
+	return ext.GetChaincodeId().GetName()
+}
+
 // GetPvtDataAndBlockByNum retrieves private data and block for given sequence number
 func (lc *LedgerCommitter) GetPvtDataAndBlockByNum(seqNum uint64) (*ledger.BlockAndPvtData, error) {
 	return lc.PeerLedgerSupport.GetPvtDataAndBlockByNum(seqNum, nil)
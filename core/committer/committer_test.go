@@ -10,10 +10,13 @@ import (
 	"errors"
 	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	mockmetrics "github.com/hyperledger/fabric/common/mocks/metrics"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/configtxgentest"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
@@ -121,6 +124,10 @@ func (m *mockLedger) GetMissingPvtDataTracker() (ledger2.MissingPvtDataTracker,
 	panic("implement me")
 }
 
+func (m *mockLedger) GetStateAndBlockchainInfo(namespace string, key string) ([]byte, *common.BlockchainInfo, error) {
+	panic("implement me")
+}
+
 func (m *mockLedger) PurgePrivateData(maxBlockNumToRetain uint64) error {
 	args := m.Called(maxBlockNumToRetain)
 	return args.Error(0)
@@ -230,3 +237,67 @@ func TestNewLedgerCommitterReactiveFailedConfigUpdate(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, int32(0), atomic.LoadInt32(&configArrived))
 }
+
+func TestValidationCodeMetrics(t *testing.T) {
+	t.Parallel()
+	chainID := "TestLedger"
+	_, ledger := createLedger(chainID)
+	ledger.On("CommitWithPvtData", mock.Anything).Return(nil)
+	committer := NewLedgerCommitter(ledger)
+	scope := mockmetrics.NewMockScope()
+	committer.metricsScope = scope
+
+	block := testutil.ConstructTestBlock(t, 1, 3, 10)
+	txsFilter := cut.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	txsFilter.SetFlag(1, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	err := committer.CommitWithPvtData(&ledger2.BlockAndPvtData{Block: block})
+	assert.NoError(t, err)
+
+	profile := configtxgentest.Load(localconfig.SampleSingleMSPSoloProfile)
+	configBlock := encoder.New(profile).GenesisBlockForChannel(chainID)
+	configBlock.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = cut.NewTxValidationFlagsSetValue(len(configBlock.Data.Data), peer.TxValidationCode_VALID)
+	err = committer.CommitWithPvtData(&ledger2.BlockAndPvtData{Block: configBlock})
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(2), scope.CounterValue("transactions", map[string]string{"channel": "testchainid", "chaincode": "foo", "validation_code": "VALID"}))
+	assert.Equal(t, int64(1), scope.CounterValue("transactions", map[string]string{"channel": "testchainid", "chaincode": "foo", "validation_code": "MVCC_READ_CONFLICT"}))
+	assert.Equal(t, int64(1), scope.CounterValue("transactions", map[string]string{"channel": "TestLedger", "chaincode": "", "validation_code": "VALID"}))
+
+	// blocks without a complete transactions filter are not reported
+	block = testutil.ConstructTestBlock(t, 2, 3, 10)
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = nil
+	err = committer.CommitWithPvtData(&ledger2.BlockAndPvtData{Block: block})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), scope.CounterValue("transactions", map[string]string{"channel": "testchainid", "chaincode": "foo", "validation_code": "VALID"}))
+	assert.Equal(t, int64(1), scope.CounterValue("transactions", map[string]string{"channel": "testchainid", "chaincode": "foo", "validation_code": "MVCC_READ_CONFLICT"}))
+}
+
+func TestLedgerGrowthMetrics(t *testing.T) {
+	t.Parallel()
+	_, ledger := createLedger("TestLedger")
+	committer := NewLedgerCommitter(ledger)
+	scope := mockmetrics.NewMockScope()
+	committer.metricsScope = scope
+
+	start := time.Now()
+	block1 := testutil.ConstructTestBlock(t, 1, 3, 10)
+	committer.reportMetrics(block1, start)
+	block2 := testutil.ConstructTestBlock(t, 2, 5, 10)
+	committer.reportMetrics(block2, start.Add(2*time.Second))
+
+	size1, size2 := float64(proto.Size(block1)), float64(proto.Size(block2))
+	assert.Equal(t, []float64{size1, size2}, scope.HistogramValues("block_size_bytes", map[string]string{"channel": "testchainid"}))
+	assert.Equal(t, []float64{3, 5}, scope.HistogramValues("block_transactions", map[string]string{"channel": "testchainid"}))
+	assert.Equal(t, int64(size1+size2), scope.CounterValue("committed_bytes", map[string]string{"channel": "testchainid"}))
+	rate, _ := scope.GaugeValue("committed_bytes_per_second", map[string]string{"channel": "testchainid"})
+	assert.Equal(t, size2/2, rate)
+
+	// the rate is not reported for the first block committed by the peer
+	committer = NewLedgerCommitter(ledger)
+	scope = mockmetrics.NewMockScope()
+	committer.metricsScope = scope
+	committer.reportMetrics(block1, start)
+	assert.Len(t, scope.HistogramValues("block_size_bytes", map[string]string{"channel": "testchainid"}), 1)
+	_, ok := scope.GaugeValue("committed_bytes_per_second", map[string]string{"channel": "testchainid"})
+	assert.False(t, ok)
+}
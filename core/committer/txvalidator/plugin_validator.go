@@ -12,6 +12,8 @@ import (
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	ledger2 "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
 	. "github.com/hyperledger/fabric/core/handlers/validation/api/capabilities"
 	. "github.com/hyperledger/fabric/core/handlers/validation/api/identities"
@@ -72,16 +74,18 @@ type PluginValidator struct {
 	PluginMapper
 	QueryExecutorCreator
 	msp.IdentityDeserializer
-	capabilities Capabilities
+	policyManager policies.Manager
+	capabilities  Capabilities
 }
 
 //go:generate mockery -dir ../../handlers/validation/api/capabilities/ -name Capabilities -case underscore -output mocks/
 //go:generate mockery -dir ../../../msp/ -name IdentityDeserializer -case underscore -output mocks/
 
 // NewPluginValidator creates a new PluginValidator
-func NewPluginValidator(pm PluginMapper, qec QueryExecutorCreator, deserializer msp.IdentityDeserializer, capabilities Capabilities) *PluginValidator {
+func NewPluginValidator(pm PluginMapper, qec QueryExecutorCreator, deserializer msp.IdentityDeserializer, policyManager policies.Manager, capabilities Capabilities) *PluginValidator {
 	return &PluginValidator{
 		capabilities:         capabilities,
+		policyManager:        policyManager,
 		pluginChannelMapping: make(map[PluginName]*pluginsByChannel),
 		PluginMapper:         pm,
 		QueryExecutorCreator: qec,
@@ -166,7 +170,11 @@ func (pbc *pluginsByChannel) createPluginIfAbsent(channel string) (validation.Pl
 }
 
 func (pbc *pluginsByChannel) initPlugin(plugin validation.Plugin, channel string) (validation.Plugin, error) {
-	pe := &PolicyEvaluator{IdentityDeserializer: pbc.pv.IdentityDeserializer}
+	pe := &PolicyEvaluator{
+		IdentityDeserializer: pbc.pv.IdentityDeserializer,
+		PolicyManager:        pbc.pv.policyManager,
+		Capabilities:         pbc.pv.capabilities,
+	}
 	sf := &StateFetcherImpl{QueryExecutorCreator: pbc.pv}
 	if err := plugin.Init(pe, sf, pbc.pv.capabilities); err != nil {
 		return nil, errors.Wrap(err, "failed initializing plugin")
@@ -176,10 +184,23 @@ func (pbc *pluginsByChannel) initPlugin(plugin validation.Plugin, channel string
 
 type PolicyEvaluator struct {
 	msp.IdentityDeserializer
+	// PolicyManager resolves the channel config policies referenced by the
+	// endorsement policies, on the channels with the capability to do so
+	PolicyManager policies.Manager
+	Capabilities  Capabilities
 }
 
 // Evaluate takes a set of SignedData and evaluates whether this set of signatures satisfies the policy
 func (id *PolicyEvaluator) Evaluate(policyBytes []byte, signatureSet []*common.SignedData) error {
+	if id.Capabilities != nil && id.Capabilities.ChannelConfigPolicyReference() {
+		if policyName, isReference := ccprovider.ChannelConfigPolicyReference(policyBytes); isReference {
+			policy, exists := id.PolicyManager.GetPolicy(policyName)
+			if !exists {
+				return errors.Errorf("channel config policy %s does not exist", policyName)
+			}
+			return policy.Evaluate(signatureSet)
+		}
+	}
 	pp := cauthdsl.NewPolicyProvider(id.IdentityDeserializer)
 	policy, _, err := pp.NewPolicy(policyBytes)
 	if err != nil {
@@ -14,14 +14,18 @@ import (
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/mocks/ledger"
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/committer/txvalidator/mocks"
 	"github.com/hyperledger/fabric/core/committer/txvalidator/testdata"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
 	. "github.com/hyperledger/fabric/core/handlers/validation/api/capabilities"
 	"github.com/hyperledger/fabric/msp"
 	. "github.com/hyperledger/fabric/msp/mocks"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -32,7 +36,7 @@ func TestValidateWithPlugin(t *testing.T) {
 	qec := &mocks.QueryExecutorCreator{}
 	deserializer := &mocks.IdentityDeserializer{}
 	capabilites := &mocks.Capabilities{}
-	v := txvalidator.NewPluginValidator(pm, qec, deserializer, capabilites)
+	v := txvalidator.NewPluginValidator(pm, qec, deserializer, &mockpolicies.Manager{}, capabilites)
 	ctx := &txvalidator.Context{
 		Namespace: "mycc",
 		VSCCName:  "vscc",
@@ -89,6 +93,7 @@ func TestSamplePlugin(t *testing.T) {
 	deserializer.On("DeserializeIdentity", []byte{7, 8, 9}).Return(identity, nil)
 	capabilites := &mocks.Capabilities{}
 	capabilites.On("PrivateChannelData").Return(true)
+	capabilites.On("ChannelConfigPolicyReference").Return(false)
 	factory := &mocks.PluginFactory{}
 	factory.On("New").Return(&testdata.SampleValidationPlugin{})
 	pm["vscc"] = factory
@@ -101,7 +106,7 @@ func TestSamplePlugin(t *testing.T) {
 
 	txnData, _ := proto.Marshal(&transaction)
 
-	v := txvalidator.NewPluginValidator(pm, qec, deserializer, capabilites)
+	v := txvalidator.NewPluginValidator(pm, qec, deserializer, &mockpolicies.Manager{}, capabilites)
 	acceptAllPolicyBytes, _ := proto.Marshal(cauthdsl.AcceptAllPolicy)
 	ctx := &txvalidator.Context{
 		Namespace: "mycc",
@@ -118,6 +123,36 @@ func TestSamplePlugin(t *testing.T) {
 	assert.NoError(t, v.ValidateWithPlugin(ctx))
 }
 
+func TestPolicyEvaluatorChannelConfigPolicyReference(t *testing.T) {
+	capabilities := &mocks.Capabilities{}
+	pe := &txvalidator.PolicyEvaluator{
+		IdentityDeserializer: &mocks.IdentityDeserializer{},
+		PolicyManager: &mockpolicies.Manager{
+			PolicyMap: map[string]policies.Policy{
+				"/Channel/Application/Endorsement": &mockpolicies.Policy{},
+				"/Channel/Application/Admins":      &mockpolicies.Policy{Err: errors.New("signature set did not satisfy policy")},
+			},
+		},
+		Capabilities: capabilities,
+	}
+	endorsement, err := ccprovider.MarshalChannelConfigPolicyReference("/Channel/Application/Endorsement")
+	assert.NoError(t, err)
+	admins, err := ccprovider.MarshalChannelConfigPolicyReference("/Channel/Application/Admins")
+	assert.NoError(t, err)
+	missing, err := ccprovider.MarshalChannelConfigPolicyReference("/Channel/Application/Missing")
+	assert.NoError(t, err)
+
+	// Without the capability, references are parsed as signature policies
+	capabilities.On("ChannelConfigPolicyReference").Return(false).Once()
+	assert.Error(t, pe.Evaluate(endorsement, nil))
+
+	capabilities.On("ChannelConfigPolicyReference").Return(true)
+	assert.NoError(t, pe.Evaluate(endorsement, nil))
+	assert.EqualError(t, pe.Evaluate(admins, nil), "signature set did not satisfy policy")
+	assert.EqualError(t, pe.Evaluate(missing, nil), "channel config policy /Channel/Application/Missing does not exist")
+	assert.NoError(t, pe.Evaluate(utils.MarshalOrPanic(cauthdsl.AcceptAllPolicy), nil))
+}
+
 func TestCapabilitiesInterface(t *testing.T) {
 	// Make sure that the application capabilities are all implemented by the validation capabilities
 	// Obtain all methods of the ApplicationCapabilities and ensure
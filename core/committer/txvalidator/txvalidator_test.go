@@ -7,15 +7,20 @@ SPDX-License-Identifier: Apache-2.0
 package txvalidator
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/mocks/config"
 	util2 "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	ledger2 "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
@@ -25,6 +30,7 @@ import (
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/spf13/viper"
@@ -406,3 +412,223 @@ func TestInvalidTXsForUpgradeCC(t *testing.T) {
 
 	assert.EqualValues(t, expectTxsFltr, txsfltr)
 }
+
+func TestCheckValidityWindow(t *testing.T) {
+	blockTime := time.Date(2018, time.October, 1, 12, 0, 0, 0, time.UTC)
+	block := &common.Block{Header: &common.BlockHeader{Number: 5}, Metadata: &common.BlockMetadata{Metadata: make([][]byte, 4)}}
+	assert.NoError(t, utils.SetBlockTime(block, blockTime))
+
+	toProto := func(t time.Time) *timestamp.Timestamp {
+		ts, _ := ptypes.TimestampProto(t)
+		return ts
+	}
+
+	tests := []struct {
+		name         string
+		capability   bool
+		maxClockSkew time.Duration
+		window       *common.ValidityWindow
+		noBlockTime  bool
+		expectedErr  string
+	}{
+		{
+			name:       "NoWindow",
+			capability: true,
+		},
+		{
+			name:       "CapabilityDisabled",
+			capability: false,
+			window:     &common.ValidityWindow{NotAfter: toProto(blockTime.Add(-time.Hour))},
+		},
+		{
+			name:       "WithinWindow",
+			capability: true,
+			window:     &common.ValidityWindow{NotBefore: toProto(blockTime.Add(-time.Minute)), NotAfter: toProto(blockTime.Add(time.Minute))},
+		},
+		{
+			name:       "OpenEnded",
+			capability: true,
+			window:     &common.ValidityWindow{NotBefore: toProto(blockTime)},
+		},
+		{
+			name:        "BeforeWindow",
+			capability:  true,
+			window:      &common.ValidityWindow{NotBefore: toProto(blockTime.Add(time.Minute))},
+			expectedErr: "block [5] was created at 2018-10-01 12:00:00 +0000 UTC, before the validity window starting at 2018-10-01 12:01:00 +0000 UTC",
+		},
+		{
+			name:        "AfterWindow",
+			capability:  true,
+			window:      &common.ValidityWindow{NotAfter: toProto(blockTime.Add(-time.Minute))},
+			expectedErr: "block [5] was created at 2018-10-01 12:00:00 +0000 UTC, after the validity window ending at 2018-10-01 11:59:00 +0000 UTC",
+		},
+		{
+			name:         "BeforeWindowWithinSkew",
+			capability:   true,
+			maxClockSkew: 2 * time.Minute,
+			window:       &common.ValidityWindow{NotBefore: toProto(blockTime.Add(time.Minute))},
+		},
+		{
+			name:         "AfterWindowWithinSkew",
+			capability:   true,
+			maxClockSkew: 2 * time.Minute,
+			window:       &common.ValidityWindow{NotAfter: toProto(blockTime.Add(-time.Minute))},
+		},
+		{
+			name:         "AfterWindowBeyondSkew",
+			capability:   true,
+			maxClockSkew: 30 * time.Second,
+			window:       &common.ValidityWindow{NotAfter: toProto(blockTime.Add(-time.Minute))},
+			expectedErr:  "after the validity window ending at",
+		},
+		{
+			name:        "NoBlockTime",
+			capability:  true,
+			window:      &common.ValidityWindow{NotBefore: toProto(blockTime)},
+			noBlockTime: true,
+			expectedErr: "block [5] does not record the time it was created at",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			vcs := struct {
+				*mocktxvalidator.Support
+				*semaphore.Weighted
+			}{&mocktxvalidator.Support{
+				ACVal:           &config.MockApplicationCapabilities{TxValidityWindowRv: tt.capability},
+				MaxClockSkewVal: tt.maxClockSkew,
+			}, semaphore.NewWeighted(10)}
+			tValidator := &TxValidator{Support: vcs}
+			b := block
+			if tt.noBlockTime {
+				b = &common.Block{Header: block.Header, Metadata: &common.BlockMetadata{Metadata: make([][]byte, 4)}}
+			}
+
+			err := tValidator.checkValidityWindow(&common.ChannelHeader{ValidityWindow: tt.window}, b)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestCheckRangeQueries(t *testing.T) {
+	rawReads := func(n int) *kvrwset.RangeQueryInfo {
+		rqi := &kvrwset.RangeQueryInfo{StartKey: "a", EndKey: "z"}
+		var kvReads []*kvrwset.KVRead
+		for i := 0; i < n; i++ {
+			kvReads = append(kvReads, &kvrwset.KVRead{Key: fmt.Sprintf("k%d", i)})
+		}
+		rqi.SetRawReads(kvReads)
+		return rqi
+	}
+	summary := func(maxDegree, maxLevel uint32, hashes int) *kvrwset.RangeQueryInfo {
+		rqi := &kvrwset.RangeQueryInfo{StartKey: "a", EndKey: "z"}
+		summary := &kvrwset.QueryReadsMerkleSummary{MaxDegree: maxDegree, MaxLevel: maxLevel}
+		for i := 0; i < hashes; i++ {
+			summary.MaxLevelHashes = append(summary.MaxLevelHashes, []byte{byte(i)})
+		}
+		rqi.SetMerkelSummary(summary)
+		return rqi
+	}
+
+	tests := []struct {
+		name        string
+		capability  bool
+		maxResults  uint32
+		rqi         *kvrwset.RangeQueryInfo
+		expectedErr string
+	}{
+		{
+			name:       "CapabilityDisabled",
+			capability: false,
+			maxResults: 1,
+			rqi:        summary(1, 0, 0),
+		},
+		{
+			name:       "RawReadsWithinBound",
+			capability: true,
+			maxResults: 3,
+			rqi:        rawReads(3),
+		},
+		{
+			name:        "RawReadsBeyondBound",
+			capability:  true,
+			maxResults:  3,
+			rqi:         rawReads(4),
+			expectedErr: "range query [a, z] of namespace ns covers up to 4 results, more than the 3 allowed",
+		},
+		{
+			name:       "SummaryWithinBound",
+			capability: true,
+			maxResults: 18,
+			rqi:        summary(2, 2, 2),
+		},
+		{
+			name:        "SummaryBeyondBound",
+			capability:  true,
+			maxResults:  17,
+			rqi:         summary(2, 2, 2),
+			expectedErr: "range query [a, z] of namespace ns covers up to 18 results, more than the 17 allowed",
+		},
+		{
+			name:       "NoBound",
+			capability: true,
+			rqi:        summary(50, 100, 50),
+		},
+		{
+			name:        "LowMaxDegree",
+			capability:  true,
+			rqi:         summary(1, 1, 1),
+			expectedErr: "invalid range query [a, z] of namespace ns: max degree [1] of the merkle summary is lower than 2",
+		},
+		{
+			name:        "NoMaxLevel",
+			capability:  true,
+			rqi:         summary(2, 0, 1),
+			expectedErr: "invalid range query [a, z] of namespace ns: max level of the merkle summary is 0",
+		},
+		{
+			name:        "NoHashes",
+			capability:  true,
+			rqi:         summary(2, 1, 0),
+			expectedErr: "invalid range query [a, z] of namespace ns: merkle summary has 0 hashes at its max level, instead of 1 to 2",
+		},
+		{
+			name:        "TooManyHashes",
+			capability:  true,
+			rqi:         summary(2, 1, 3),
+			expectedErr: "invalid range query [a, z] of namespace ns: merkle summary has 3 hashes at its max level, instead of 1 to 2",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			vcs := struct {
+				*mocktxvalidator.Support
+				*semaphore.Weighted
+			}{&mocktxvalidator.Support{
+				ACVal:                   &config.MockApplicationCapabilities{RangeQueryValidationRv: tt.capability},
+				MaxRangeQueryResultsVal: tt.maxResults,
+			}, semaphore.NewWeighted(10)}
+			v := &VsccValidatorImpl{support: vcs}
+
+			txRWSet := &rwsetutil.TxRwSet{NsRwSets: []*rwsetutil.NsRwSet{{
+				NameSpace: "ns",
+				KvRwSet:   &kvrwset.KVRWSet{RangeQueriesInfo: []*kvrwset.RangeQueryInfo{tt.rqi}},
+			}}}
+			err := v.checkRangeQueries(txRWSet)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
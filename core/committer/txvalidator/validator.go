@@ -12,10 +12,13 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/configtx"
 	commonerrors "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
@@ -44,6 +47,9 @@ type Support interface {
 	// MSPManager returns the MSP manager for this channel
 	MSPManager() msp.MSPManager
 
+	// PolicyManager returns the policy manager for this channel
+	PolicyManager() policies.Manager
+
 	// Apply attempts to apply a configtx to become the new config
 	Apply(configtx *common.ConfigEnvelope) error
 
@@ -53,6 +59,12 @@ type Support interface {
 
 	// Capabilities defines the capabilities for the application portion of this channel
 	Capabilities() channelconfig.ApplicationCapabilities
+
+	// MaxClockSkew returns the tolerance applied to the validity windows of the transactions
+	MaxClockSkew() time.Duration
+
+	// MaxRangeQueryResults returns the max number of results a peer goes through to validate a range query
+	MaxRangeQueryResults() uint32
 }
 
 //Validator interface which defines API to validate block transactions
@@ -98,7 +110,7 @@ type blockValidationResult struct {
 // NewTxValidator creates new transactions validator
 func NewTxValidator(chainID string, support Support, sccp sysccprovider.SystemChaincodeProvider, pm PluginMapper) *TxValidator {
 	// Encapsulates interface implementation
-	pluginValidator := NewPluginValidator(pm, support.Ledger(), &dynamicDeserializer{support: support}, &dynamicCapabilities{support: support})
+	pluginValidator := NewPluginValidator(pm, support.Ledger(), &dynamicDeserializer{support: support}, &dynamicPolicyManager{support: support}, &dynamicCapabilities{support: support})
 	return &TxValidator{
 		ChainID: chainID,
 		Support: support,
@@ -228,12 +240,57 @@ func (v *TxValidator) Validate(block *common.Block) error {
 
 	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txsfltr
 
+	spans := tracing.StartTxSpans("committer.Validate", block, startValidation)
+	for tIdx, span := range spans {
+		span.SetAttribute("validation_code", txsfltr.Flag(tIdx).String())
+	}
+	tracing.EndSpans(spans)
+
 	elapsedValidation := time.Since(startValidation) / time.Millisecond // duration in ms
 	logger.Infof("[%s] Validated block [%d] in %dms", v.ChainID, block.Header.Number, elapsedValidation)
 
 	return nil
 }
 
+// checkValidityWindow checks, if the channel enforces the validity windows of
+// the transactions, that the block holding the transaction was created within
+// its validity window, extended by the max clock skew of the channel
+func (v *TxValidator) checkValidityWindow(chdr *common.ChannelHeader, block *common.Block) error {
+	window := chdr.ValidityWindow
+	if window == nil || !v.Support.Capabilities().TxValidityWindow() {
+		return nil
+	}
+
+	blockTime, ok, err := utils.GetBlockTime(block)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.Errorf("block [%d] does not record the time it was created at", block.Header.Number)
+	}
+	skew := v.Support.MaxClockSkew()
+
+	if window.NotBefore != nil {
+		notBefore, err := ptypes.Timestamp(window.NotBefore)
+		if err != nil {
+			return errors.Wrap(err, "invalid start of the validity window")
+		}
+		if blockTime.Add(skew).Before(notBefore) {
+			return errors.Errorf("block [%d] was created at %s, before the validity window starting at %s", block.Header.Number, blockTime, notBefore)
+		}
+	}
+	if window.NotAfter != nil {
+		notAfter, err := ptypes.Timestamp(window.NotAfter)
+		if err != nil {
+			return errors.Wrap(err, "invalid end of the validity window")
+		}
+		if blockTime.Add(-skew).After(notAfter) {
+			return errors.Errorf("block [%d] was created at %s, after the validity window ending at %s", block.Header.Number, blockTime, notAfter)
+		}
+	}
+	return nil
+}
+
 // allValidated returns error if some of the validation flags have not been set
 // during validation
 func (v *TxValidator) allValidated(txsfltr ledgerUtil.TxValidationFlags, block *common.Block) error {
@@ -330,6 +387,15 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 		}
 
 		if common.HeaderType(chdr.Type) == common.HeaderType_ENDORSER_TRANSACTION {
+			if err := v.checkValidityWindow(chdr, block); err != nil {
+				logger.Errorf("Transaction %s is outside of its validity window: %s", chdr.TxId, err)
+				results <- &blockValidationResult{
+					tIdx:           tIdx,
+					validationCode: peer.TxValidationCode_OUTSIDE_VALIDITY_WINDOW,
+				}
+				return
+			}
+
 			// Check duplicate transactions
 			txID = chdr.TxId
 			// GetTransactionByID will return:
@@ -590,6 +656,18 @@ func (ds *dynamicDeserializer) IsWellFormed(identity *mspprotos.SerializedIdenti
 	return ds.support.MSPManager().IsWellFormed(identity)
 }
 
+type dynamicPolicyManager struct {
+	support Support
+}
+
+func (pm *dynamicPolicyManager) GetPolicy(id string) (policies.Policy, bool) {
+	return pm.support.PolicyManager().GetPolicy(id)
+}
+
+func (pm *dynamicPolicyManager) Manager(path []string) (policies.Manager, bool) {
+	return pm.support.PolicyManager().Manager(path)
+}
+
 type dynamicCapabilities struct {
 	support Support
 }
@@ -598,10 +676,30 @@ func (ds *dynamicCapabilities) ACLs() bool {
 	return ds.support.Capabilities().ACLs()
 }
 
+func (ds *dynamicCapabilities) ChaincodeRetirement() bool {
+	return ds.support.Capabilities().ChaincodeRetirement()
+}
+
+func (ds *dynamicCapabilities) ChannelConfigPolicyReference() bool {
+	return ds.support.Capabilities().ChannelConfigPolicyReference()
+}
+
+func (ds *dynamicCapabilities) CollectionEndorsementPolicy() bool {
+	return ds.support.Capabilities().CollectionEndorsementPolicy()
+}
+
 func (ds *dynamicCapabilities) CollectionUpgrade() bool {
 	return ds.support.Capabilities().CollectionUpgrade()
 }
 
+func (ds *dynamicCapabilities) CouchDBBinaryValues() bool {
+	return ds.support.Capabilities().CouchDBBinaryValues()
+}
+
+func (ds *dynamicCapabilities) DefaultAnchorPeers() bool {
+	return ds.support.Capabilities().DefaultAnchorPeers()
+}
+
 func (ds *dynamicCapabilities) ForbidDuplicateTXIdInBlock() bool {
 	return ds.support.Capabilities().ForbidDuplicateTXIdInBlock()
 }
@@ -610,6 +708,10 @@ func (ds *dynamicCapabilities) KeyLevelEndorsement() bool {
 	return ds.support.Capabilities().KeyLevelEndorsement()
 }
 
+func (ds *dynamicCapabilities) MaintenanceMode() bool {
+	return ds.support.Capabilities().MaintenanceMode()
+}
+
 func (ds *dynamicCapabilities) MetadataLifecycle() bool {
 	return ds.support.Capabilities().MetadataLifecycle()
 }
@@ -618,10 +720,18 @@ func (ds *dynamicCapabilities) PrivateChannelData() bool {
 	return ds.support.Capabilities().PrivateChannelData()
 }
 
+func (ds *dynamicCapabilities) RangeQueryValidation() bool {
+	return ds.support.Capabilities().RangeQueryValidation()
+}
+
 func (ds *dynamicCapabilities) Supported() error {
 	return ds.support.Capabilities().Supported()
 }
 
+func (ds *dynamicCapabilities) TxValidityWindow() bool {
+	return ds.support.Capabilities().TxValidityWindow()
+}
+
 func (ds *dynamicCapabilities) V1_1Validation() bool {
 	return ds.support.Capabilities().V1_1Validation()
 }
@@ -203,6 +203,11 @@ func putCCInfoWithVSCCAndVer(theLedger ledger.PeerLedger, ccname, vscc, ver stri
 		Policy:  policy,
 	}
 
+	putCCData(theLedger, cd, t)
+}
+
+func putCCData(theLedger ledger.PeerLedger, cd *ccp.ChaincodeData, t *testing.T) {
+	ccname := cd.Name
 	cdbytes := utils.MarshalOrPanic(cd)
 
 	txid := util.GenerateUUID()
@@ -1203,6 +1208,46 @@ func testInvokeNOKExpiredCC(t *testing.T, l ledger.PeerLedger, v txvalidator.Val
 	assertInvalid(b, t, peer.TxValidationCode_EXPIRED_CHAINCODE)
 }
 
+func TestInvokeNOKRetiredCC(t *testing.T) {
+	t.Run("1.2Capability", func(t *testing.T) {
+		l, v := setupLedgerAndValidatorWithV12Capabilities(t)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+
+		testInvokeNOKRetiredCC(t, l, v)
+	})
+
+	t.Run("1.3Capability", func(t *testing.T) {
+		l, v := setupLedgerAndValidatorWithV13Capabilities(t)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+
+		testInvokeNOKRetiredCC(t, l, v)
+	})
+}
+
+func testInvokeNOKRetiredCC(t *testing.T, l ledger.PeerLedger, v txvalidator.Validator) {
+	ccID := "mycc"
+
+	putCCData(l, &ccp.ChaincodeData{
+		Name:    ccID,
+		Version: ccVersion,
+		Vscc:    "vscc",
+		Policy:  signedByAnyMember([]string{"SampleOrg"}),
+		Retired: true,
+	}, t)
+
+	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
+	b := &common.Block{
+		Data:   &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}},
+		Header: &common.BlockHeader{},
+	}
+
+	err := v.Validate(b)
+	assert.NoError(t, err)
+	assertInvalid(b, t, peer.TxValidationCode_CHAINCODE_RETIRED)
+}
+
 func TestInvokeNOKBogusActions(t *testing.T) {
 	t.Run("1.2Capability", func(t *testing.T) {
 		l, v := setupLedgerAndValidatorWithV12Capabilities(t)
@@ -1501,6 +1546,12 @@ func (m *mockLedger) GetMissingPvtDataTracker() (ledger.MissingPvtDataTracker, e
 	return args.Get(0).(ledger.MissingPvtDataTracker), nil
 }
 
+// GetStateAndBlockchainInfo returns the value of a key and the info about the blockchain
+func (m *mockLedger) GetStateAndBlockchainInfo(namespace string, key string) ([]byte, *common.BlockchainInfo, error) {
+	args := m.Called(namespace, key)
+	return args.Get(0).([]byte), args.Get(1).(*common.BlockchainInfo), args.Error(2)
+}
+
 // mockQueryExecutor mock of the query executor,
 // needed to simulate inability to access state db, e.g.
 // the case where due to db failure it's not possible to
@@ -1527,6 +1578,11 @@ func (exec *mockQueryExecutor) GetStateRangeScanIterator(namespace string, start
 	return args.Get(0).(ledger2.ResultsIterator), args.Error(1)
 }
 
+func (exec *mockQueryExecutor) GetStateRangeScanIteratorReverse(namespace string, startKey string, endKey string) (ledger2.ResultsIterator, error) {
+	args := exec.Called(namespace, startKey, endKey)
+	return args.Get(0).(ledger2.ResultsIterator), args.Error(1)
+}
+
 func (exec *mockQueryExecutor) GetStateRangeScanIteratorWithMetadata(namespace, startKey, endKey string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
 	args := exec.Called(namespace, startKey, endKey, metadata)
 	return args.Get(0).(ledger.QueryResultsIterator), args.Error(1)
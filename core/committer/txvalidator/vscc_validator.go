@@ -11,6 +11,7 @@ package txvalidator
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
@@ -21,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -77,6 +79,9 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 	if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
 		return errors.WithMessage(err, "txRWSet.FromProtoBytes failed"), peer.TxValidationCode_BAD_RWSET
 	}
+	if err = v.checkRangeQueries(txRWSet); err != nil {
+		return err, peer.TxValidationCode_BAD_RWSET
+	}
 
 	// Verify the header extension and response payload contain the ChaincodeId
 	if hdrExt.ChaincodeId == nil {
@@ -180,6 +185,10 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 		for _, ns := range wrNamespace {
 			// Get latest chaincode version, vscc and validate policy
 			txcc, vscc, policy, err := v.GetInfoForValidate(chdr, ns)
+			if _, retired := err.(ccprovider.ChaincodeRetiredErr); retired {
+				logger.Errorf("GetInfoForValidate for txId = %s returned error: %+v", chdr.TxId, err)
+				return err, peer.TxValidationCode_CHAINCODE_RETIRED
+			}
 			if err != nil {
 				logger.Errorf("GetInfoForValidate for txId = %s returned error: %+v", chdr.TxId, err)
 				return err, peer.TxValidationCode_INVALID_OTHER_REASON
@@ -302,6 +311,10 @@ func (v *VsccValidatorImpl) getCDataForCC(chid, ccid string) (ccprovider.Chainco
 		return nil, errors.Wrap(err, "unmarshalling ChaincodeQueryResponse failed")
 	}
 
+	if cd.Retired {
+		return nil, ccprovider.ChaincodeRetiredErr(ccid)
+	}
+
 	if cd.Vscc == "" {
 		return nil, errors.Errorf("lscc's state for [%s] is invalid, vscc field must be set", ccid)
 	}
@@ -356,6 +369,66 @@ func (v *VsccValidatorImpl) GetInfoForValidate(chdr *common.ChannelHeader, ccID
 	return cc, vscc, policy, nil
 }
 
+// checkRangeQueries checks, if the channel validates the range queries of the
+// transactions, that their results are recorded in a well formed summary, and
+// that the number of results a peer goes through to check them for phantom
+// reads is within the bound of the channel
+func (v *VsccValidatorImpl) checkRangeQueries(txRWSet *rwsetutil.TxRwSet) error {
+	if !v.support.Capabilities().RangeQueryValidation() {
+		return nil
+	}
+
+	maxResults := v.support.MaxRangeQueryResults()
+	for _, ns := range txRWSet.NsRwSets {
+		if ns.KvRwSet == nil {
+			continue
+		}
+		for _, rqi := range ns.KvRwSet.RangeQueriesInfo {
+			results, err := rangeQueryResultsBound(rqi)
+			if err != nil {
+				return errors.WithMessage(err, fmt.Sprintf("invalid range query [%s, %s] of namespace %s", rqi.StartKey, rqi.EndKey, ns.NameSpace))
+			}
+			if maxResults != 0 && results > uint64(maxResults) {
+				return errors.Errorf("range query [%s, %s] of namespace %s covers up to %d results, more than the %d allowed",
+					rqi.StartKey, rqi.EndKey, ns.NameSpace, results, maxResults)
+			}
+		}
+	}
+	return nil
+}
+
+// rangeQueryResultsBound returns the max number of results the given range
+// query covers, which are either recorded as is in the read set, or summarized
+// by the hashes of the merkle tree built over them
+func rangeQueryResultsBound(rqi *kvrwset.RangeQueryInfo) (uint64, error) {
+	summary := rqi.GetReadsMerkleHashes()
+	if summary == nil {
+		return uint64(len(rqi.GetRawReads().GetKvReads())), nil
+	}
+
+	if summary.MaxDegree < 2 {
+		return 0, errors.Errorf("max degree [%d] of the merkle summary is lower than 2", summary.MaxDegree)
+	}
+	if summary.MaxLevel < 1 {
+		return 0, errors.New("max level of the merkle summary is 0")
+	}
+	hashes := len(summary.MaxLevelHashes)
+	if hashes == 0 || uint64(hashes) > uint64(summary.MaxDegree) {
+		return 0, errors.Errorf("merkle summary has %d hashes at its max level, instead of 1 to %d", hashes, summary.MaxDegree)
+	}
+
+	// Each hash of the leaf level covers up to max degree + 1 results, and each
+	// hash of an upper level up to max degree + 1 hashes of the level below
+	bound := uint64(hashes)
+	for level := uint32(0); level < summary.MaxLevel; level++ {
+		bound *= uint64(summary.MaxDegree) + 1
+		if bound > math.MaxUint32 {
+			break
+		}
+	}
+	return bound, nil
+}
+
 // txWritesToNamespace returns true if the supplied NsRwSet
 // performs a ledger write
 func (v *VsccValidatorImpl) txWritesToNamespace(ns *rwsetutil.NsRwSet) bool {
@@ -50,6 +50,11 @@ func ExtractStatedbArtifactsForChaincode(ccname, ccversion string, pr *platforms
 // This function is called during chaincode instantiate/upgrade (from above), and from install, so that statedb artifacts can be created.
 func ExtractStatedbArtifactsFromCCPackage(ccpackage CCPackage, pr *platforms.Registry) (statedbArtifactsTar []byte, err error) {
 	cds := ccpackage.GetDepSpec()
+	if cds.Endpoint != "" && len(cds.CodePackage) == 0 {
+		// a chaincode run as an external service may be installed without
+		// its code, and then has no statedb artifacts
+		return nil, nil
+	}
 	metaprov, err := pr.GetMetadataProvider(cds.CCType(), cds.Bytes())
 	if err != nil {
 		ccproviderLogger.Infof("invalid deployment spec: %s", err)
@@ -17,6 +17,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/ledger"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
@@ -435,6 +436,53 @@ type ChaincodeData struct {
 
 	// InstantiationPolicy for the chaincode
 	InstantiationPolicy []byte `protobuf:"bytes,8,opt,name=instantiation_policy,proto3"`
+
+	// Retired is set when the chaincode instance is retired, after which
+	// its invocations are rejected
+	Retired bool `protobuf:"varint,9,opt,name=retired,proto3"`
+
+	// ArchiveState is set when the state of a retired chaincode instance
+	// is archived by the peers
+	ArchiveState bool `protobuf:"varint,10,opt,name=archive_state,proto3"`
+}
+
+// ChaincodeRetiredErr is returned for the invocations of a retired chaincode
+type ChaincodeRetiredErr string
+
+func (e ChaincodeRetiredErr) Error() string {
+	return fmt.Sprintf("chaincode %s has been retired", string(e))
+}
+
+// channelConfigPolicyPrefix prefixes the absolute paths of the channel config policies
+const channelConfigPolicyPrefix = policies.PathSeparator + policies.ChannelPrefix + policies.PathSeparator
+
+// MarshalChannelConfigPolicyReference returns the endorsement policy referencing
+// the given channel config policy, such as /Channel/Application/Endorsement
+func MarshalChannelConfigPolicyReference(policyName string) ([]byte, error) {
+	if !strings.HasPrefix(policyName, channelConfigPolicyPrefix) || len(policyName) == len(channelConfigPolicyPrefix) {
+		return nil, errors.Errorf("invalid channel config policy reference %s, it must be an absolute path starting with %s", policyName, channelConfigPolicyPrefix)
+	}
+	return proto.Marshal(&pb.ApplicationPolicy{
+		Type: &pb.ApplicationPolicy_ChannelConfigPolicyReference{
+			ChannelConfigPolicyReference: policyName,
+		},
+	})
+}
+
+// ChannelConfigPolicyReference returns the channel config policy referenced by
+// the given endorsement policy, or false if it is a signature policy. The
+// SignaturePolicyEnvelope of a signature policy never reads as a reference, as
+// its rule cannot start with a path separator.
+func ChannelConfigPolicyReference(policy []byte) (string, bool) {
+	ap := &pb.ApplicationPolicy{}
+	if err := proto.Unmarshal(policy, ap); err != nil {
+		return "", false
+	}
+	policyName := ap.GetChannelConfigPolicyReference()
+	if !strings.HasPrefix(policyName, channelConfigPolicyPrefix) {
+		return "", false
+	}
+	return policyName, true
 }
 
 // CCName returns the name of this chaincode (the name it was put in the ChaincodeRegistry with).
@@ -487,6 +535,15 @@ type ChaincodeContainerInfo struct {
 
 	// ContainerType is not a great name, but 'DOCKER' and 'SYSTEM' are the valid types
 	ContainerType string
+
+	// Endpoint is the address of the server of a chaincode run as an external
+	// service, which the peer connects to instead of launching a container
+	Endpoint string
+
+	// EndpointRootCert holds the PEM encoded certificates of the CAs issuing
+	// the TLS certificate of the server of a chaincode run as an external
+	// service
+	EndpointRootCert []byte
 }
 
 // TransactionParams are parameters which are tied to a particular transaction
@@ -519,10 +576,12 @@ type ChaincodeProvider interface {
 
 func DeploymentSpecToChaincodeContainerInfo(cds *pb.ChaincodeDeploymentSpec) *ChaincodeContainerInfo {
 	return &ChaincodeContainerInfo{
-		Name:          cds.Name(),
-		Version:       cds.Version(),
-		Path:          cds.Path(),
-		Type:          cds.CCType(),
-		ContainerType: cds.ExecEnv.String(),
+		Name:             cds.Name(),
+		Version:          cds.Version(),
+		Path:             cds.Path(),
+		Type:             cds.CCType(),
+		ContainerType:    cds.ExecEnv.String(),
+		Endpoint:         cds.Endpoint,
+		EndpointRootCert: cds.EndpointRootCert,
 	}
 }
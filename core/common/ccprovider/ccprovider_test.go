@@ -16,9 +16,14 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
@@ -143,3 +148,51 @@ func setupDirectoryStructure(t *testing.T) string {
 
 	return tmp
 }
+
+func TestChannelConfigPolicyReference(t *testing.T) {
+	policy, err := ccprovider.MarshalChannelConfigPolicyReference("/Channel/Application/Endorsement")
+	assert.NoError(t, err)
+	policyName, isReference := ccprovider.ChannelConfigPolicyReference(policy)
+	assert.True(t, isReference)
+	assert.Equal(t, "/Channel/Application/Endorsement", policyName)
+
+	for _, invalid := range []string{"", "Endorsement", "/Channel/", "/Application/Endorsement"} {
+		_, err = ccprovider.MarshalChannelConfigPolicyReference(invalid)
+		assert.Error(t, err, "policy name %q", invalid)
+	}
+
+	for _, spe := range []*common.SignaturePolicyEnvelope{
+		cauthdsl.SignedByMspMember("SampleOrg"),
+		cauthdsl.SignedByAnyMember([]string{"Org1", "Org2"}),
+		{},
+	} {
+		_, isReference = ccprovider.ChannelConfigPolicyReference(utils.MarshalOrPanic(spe))
+		assert.False(t, isReference)
+	}
+	_, isReference = ccprovider.ChannelConfigPolicyReference([]byte("garbage"))
+	assert.False(t, isReference)
+}
+
+func TestExtractStatedbArtifactsWithoutCode(t *testing.T) {
+	pr := platforms.NewRegistry(&golang.Platform{})
+	cds := &peer.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			Type:        peer.ChaincodeSpec_GOLANG,
+			ChaincodeId: &peer.ChaincodeID{Name: "cc", Version: "1.0"},
+		},
+	}
+	ccpack := &ccprovider.CDSPackage{}
+	_, err := ccpack.InitFromBuffer(utils.MarshalOrPanic(cds))
+	assert.NoError(t, err)
+	_, err = ccprovider.ExtractStatedbArtifactsFromCCPackage(ccpack, pr)
+	assert.EqualError(t, err, "nil code package")
+
+	// a chaincode run as an external service may be installed without its code
+	cds.Endpoint = "cc.example.com:9999"
+	ccpack = &ccprovider.CDSPackage{}
+	_, err = ccpack.InitFromBuffer(utils.MarshalOrPanic(cds))
+	assert.NoError(t, err)
+	artifacts, err := ccprovider.ExtractStatedbArtifactsFromCCPackage(ccpack, pr)
+	assert.NoError(t, err)
+	assert.Nil(t, artifacts)
+}
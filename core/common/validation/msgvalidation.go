@@ -21,6 +21,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/channelconfig"
+	commonerrors "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/common/flogging"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/protos/common"
@@ -32,6 +33,29 @@ import (
 
 var putilsLogger = flogging.MustGetLogger("protoutils")
 
+// HashingAlgorithmGetter returns the hashing algorithm of the given channel,
+// or nil if the channel is not known.
+type HashingAlgorithmGetter func(channelID string) func([]byte) []byte
+
+var hashingAlgorithmGetter HashingAlgorithmGetter
+
+// SetHashingAlgorithmGetter sets the function used to look up the hashing
+// algorithm transaction ids are checked with. Until it is set, or for channels
+// it does not know, transaction ids are checked with SHA256.
+func SetHashingAlgorithmGetter(getter HashingAlgorithmGetter) {
+	hashingAlgorithmGetter = getter
+}
+
+// checkTxID checks that txid was computed from nonce and creator with the
+// hashing algorithm of the channel.
+func checkTxID(channelID, txid string, nonce, creator []byte) error {
+	var hash func([]byte) []byte
+	if hashingAlgorithmGetter != nil {
+		hash = hashingAlgorithmGetter(channelID)
+	}
+	return utils.CheckTxIDWith(txid, nonce, creator, hash)
+}
+
 // validateChaincodeProposalMessage checks the validity of a Proposal message of type CHAINCODE
 func validateChaincodeProposalMessage(prop *pb.Proposal, hdr *common.Header) (*pb.ChaincodeHeaderExtension, error) {
 	if prop == nil || hdr == nil {
@@ -111,13 +135,15 @@ func ValidateProposalMessage(signedProp *pb.SignedProposal) (*pb.Proposal, *comm
 			err = errors.Wrap(err, "could not deserialize a SerializedIdentity")
 			putilsLogger.Warningf("channel [%s]: %s", chdr.ChannelId, err)
 		}
-		return nil, nil, nil, errors.Errorf("access denied: channel [%s] creator org [%s]", chdr.ChannelId, sId.Mspid)
+		err = errors.Errorf("access denied: channel [%s] creator org [%s]", chdr.ChannelId, sId.Mspid)
+		return nil, nil, nil, commonerrors.WithReason(err, common.ErrorReason_POLICY_FAILURE, chdr.ChannelId)
 	}
 
 	// Verify that the transaction ID has been computed properly.
 	// This check is needed to ensure that the lookup into the ledger
 	// for the same TxID catches duplicates.
-	err = utils.CheckProposalTxID(
+	err = checkTxID(
+		chdr.ChannelId,
 		chdr.TxId,
 		shdr.Nonce,
 		shdr.Creator)
@@ -410,13 +436,14 @@ func ValidateTransaction(e *common.Envelope, c channelconfig.ApplicationCapabili
 		// Verify that the transaction ID has been computed properly.
 		// This check is needed to ensure that the lookup into the ledger
 		// for the same TxID catches duplicates.
-		err = utils.CheckProposalTxID(
+		err = checkTxID(
+			chdr.ChannelId,
 			chdr.TxId,
 			shdr.Nonce,
 			shdr.Creator)
 
 		if err != nil {
-			putilsLogger.Errorf("CheckProposalTxID returns err %s", err)
+			putilsLogger.Errorf("checkTxID returns err %s", err)
 			return nil, pb.TxValidationCode_BAD_PROPOSAL_TXID
 		}
 
@@ -117,3 +117,25 @@ func TestValidateProposalMessage(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), fmt.Sprintf("access denied: channel [%s] creator org [%s]", util.GetTestChainID(), signerMSPId))
 }
+
+func TestCheckTxIDWithChannelHashingAlgorithm(t *testing.T) {
+	defer SetHashingAlgorithmGetter(nil)
+
+	nonce, creator := []byte("nonce"), []byte("creator")
+	sha256TxID, err := utils.ComputeProposalTxID(nonce, creator)
+	assert.NoError(t, err)
+	sha3TxID := utils.ComputeTxIDWith(nonce, creator, util.ComputeSHA3256)
+
+	assert.NoError(t, checkTxID("mychannel", sha256TxID, nonce, creator))
+	assert.Error(t, checkTxID("mychannel", sha3TxID, nonce, creator))
+
+	SetHashingAlgorithmGetter(func(channelID string) func([]byte) []byte {
+		if channelID == "mychannel" {
+			return util.ComputeSHA3256
+		}
+		return nil
+	})
+	assert.NoError(t, checkTxID("mychannel", sha3TxID, nonce, creator))
+	assert.Error(t, checkTxID("mychannel", sha256TxID, nonce, creator))
+	assert.NoError(t, checkTxID("otherchannel", sha256TxID, nonce, creator))
+}
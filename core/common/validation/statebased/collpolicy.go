@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebased
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	capabilities "github.com/hyperledger/fabric/core/handlers/validation/api/capabilities"
+	"github.com/hyperledger/fabric/core/handlers/validation/api/state"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// CollectionEndorsementPolicyRetriever is used by the key-level validator
+// in order to retrieve the endorsement policies of the writes to collections.
+type CollectionEndorsementPolicyRetriever interface {
+	// CollectionEndorsementPolicy returns the endorsement policy of the writes
+	// to collection coll of chaincode cc, or nil if they are endorsed according
+	// to the endorsement policy of the chaincode.
+	CollectionEndorsementPolicy(cc, coll string) ([]byte, error)
+}
+
+// CollectionEndorsementPolicyRetrieverImpl retrieves the endorsement policies
+// from the collection configurations stored in the lscc namespace, on the
+// channels with the capability to define them.
+type CollectionEndorsementPolicyRetrieverImpl struct {
+	StateFetcher validation.StateFetcher
+	Capabilities capabilities.Capabilities
+}
+
+// CollectionEndorsementPolicy implements the method of the same
+// name of the CollectionEndorsementPolicyRetriever interface
+func (r *CollectionEndorsementPolicyRetrieverImpl) CollectionEndorsementPolicy(cc, coll string) ([]byte, error) {
+	if !r.Capabilities.CollectionEndorsementPolicy() {
+		return nil, nil
+	}
+
+	s, err := r.StateFetcher.FetchState()
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not retrieve ledger")
+	}
+	defer s.Done()
+
+	ccp, err := privdata.RetrieveCollectionConfigPackageFromState(common.CollectionCriteria{Namespace: cc}, &stateGetter{State: s})
+	if err != nil {
+		if _, ok := err.(privdata.NoSuchCollectionError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for _, config := range ccp.Config {
+		staticConfig := config.GetStaticCollectionConfig()
+		if staticConfig.GetName() != coll {
+			continue
+		}
+		policy := staticConfig.GetEndorsementPolicy().GetSignaturePolicy()
+		if policy == nil {
+			return nil, nil
+		}
+		return proto.Marshal(policy)
+	}
+	return nil, nil
+}
+
+// stateGetter retrieves single keys from the state
+type stateGetter struct {
+	validation.State
+}
+
+func (s *stateGetter) GetState(namespace string, key string) ([]byte, error) {
+	values, err := s.GetStateMultipleKeys(namespace, []string{key})
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values[0], nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebased
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectionEndorsementPolicyRetriever(t *testing.T) {
+	t.Parallel()
+
+	collEP := cauthdsl.SignedByMspPeer("Org1MSP")
+	memberOrgsPolicy := &common.CollectionPolicyConfig{
+		Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+			SignaturePolicy: cauthdsl.SignedByAnyMember([]string{"Org1MSP", "Org2MSP"}),
+		},
+	}
+	ccp := &common.CollectionConfigPackage{
+		Config: []*common.CollectionConfig{
+			{
+				Payload: &common.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: &common.StaticCollectionConfig{
+						Name:             "coll1",
+						MemberOrgsPolicy: memberOrgsPolicy,
+						EndorsementPolicy: &common.CollectionPolicyConfig{
+							Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+								SignaturePolicy: collEP,
+							},
+						},
+					},
+				},
+			},
+			{
+				Payload: &common.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: &common.StaticCollectionConfig{
+						Name:             "coll2",
+						MemberOrgsPolicy: memberOrgsPolicy,
+					},
+				},
+			},
+		},
+	}
+
+	ms := &mockState{GetStateMultipleKeysRv: [][]byte{utils.MarshalOrPanic(ccp)}}
+	sf := &mockStateFetcher{FetchStateRv: ms, returnedStates: []*mockState{}}
+	capabilities := &config.MockApplicationCapabilities{CollectionEndorsementRv: true}
+	r := &CollectionEndorsementPolicyRetrieverImpl{
+		StateFetcher: sf,
+		Capabilities: capabilities,
+	}
+
+	policy, err := r.CollectionEndorsementPolicy("cc", "coll1")
+	assert.NoError(t, err)
+	assert.Equal(t, utils.MarshalOrPanic(collEP), policy)
+	assert.True(t, sf.DoneCalled())
+
+	policy, err = r.CollectionEndorsementPolicy("cc", "coll2")
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+
+	policy, err = r.CollectionEndorsementPolicy("cc", "coll3")
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+
+	// the collection endorsement policies are ignored without the capability
+	capabilities.CollectionEndorsementRv = false
+	policy, err = r.CollectionEndorsementPolicy("cc", "coll1")
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+	capabilities.CollectionEndorsementRv = true
+
+	// the chaincode has no collections
+	ms.GetStateMultipleKeysRv = [][]byte{nil}
+	policy, err = r.CollectionEndorsementPolicy("cc", "coll1")
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+
+	ms.GetStateMultipleKeysErr = fmt.Errorf("ledger error")
+	_, err = r.CollectionEndorsementPolicy("cc", "coll1")
+	assert.Error(t, err)
+
+	r.StateFetcher = &mockStateFetcher{FetchStateErr: fmt.Errorf("ledger error")}
+	_, err = r.CollectionEndorsementPolicy("cc", "coll1")
+	assert.EqualError(t, err, "could not retrieve ledger: ledger error")
+}
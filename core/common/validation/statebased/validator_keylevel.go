@@ -26,7 +26,9 @@ import (
 type policyChecker struct {
 	someEPChecked bool
 	ccEPChecked   bool
+	collEPChecked map[string]bool
 	vpmgr         KeyLevelValidationParameterManager
+	collEPs       CollectionEndorsementPolicyRetriever
 	policySupport validation.PolicyEvaluator
 	ccEP          []byte
 	signatureSet  []*common.SignedData
@@ -56,6 +58,38 @@ func (p *policyChecker) checkCCEPIfNoEPChecked(cc string, blockNum, txNum uint64
 	return p.checkCCEPIfCondition(cc, blockNum, txNum, p.someEPChecked)
 }
 
+func (p *policyChecker) checkCollEPOrCCEP(cc, coll string, blockNum, txNum uint64) commonerrors.TxValidationError {
+	if p.collEPChecked[coll] {
+		return nil
+	}
+
+	collEP, err := p.collEPs.CollectionEndorsementPolicy(cc, coll)
+	if err != nil {
+		return &commonerrors.VSCCExecutionFailureError{
+			Err: errors.WithMessage(err, fmt.Sprintf("could not retrieve the endorsement policy of collection %s of chaincode %s", coll, cc)),
+		}
+	}
+
+	// if the collection has no endorsement policy, the regular cc endorsement policy needs to hold
+	if len(collEP) == 0 {
+		if err := p.checkCCEPIfNotChecked(cc, blockNum, txNum); err != nil {
+			return err
+		}
+		p.collEPChecked[coll] = true
+		return nil
+	}
+
+	// validate against the collection endorsement policy
+	err = p.policySupport.Evaluate(collEP, p.signatureSet)
+	if err != nil {
+		return policyErr(errors.Wrapf(err, "validation of endorsement policy for collection %s of chaincode %s in tx %d:%d failed", coll, cc, blockNum, txNum))
+	}
+
+	p.collEPChecked[coll] = true
+	p.someEPChecked = true
+	return nil
+}
+
 func (p *policyChecker) checkSBAndCCEP(cc, coll, key string, blockNum, txNum uint64) commonerrors.TxValidationError {
 	// see if there is a key-level validation parameter for this key
 	vp, err := p.vpmgr.GetValidationParameterForKey(cc, coll, key, blockNum, txNum)
@@ -90,8 +124,12 @@ func (p *policyChecker) checkSBAndCCEP(cc, coll, key string, blockNum, txNum uin
 		}
 	}
 
-	// if no key-level validation parameter has been specified, the regular cc endorsement policy needs to hold
+	// if no key-level validation parameter has been specified, the endorsement policy
+	// of the collection, or else the regular cc endorsement policy needs to hold
 	if len(vp) == 0 {
+		if coll != "" {
+			return p.checkCollEPOrCCEP(cc, coll, blockNum, txNum)
+		}
 		return p.checkCCEPIfNotChecked(cc, blockNum, txNum)
 	}
 
@@ -118,13 +156,15 @@ type blockDependency struct {
 // KeyLevelValidator implements per-key level ep validation
 type KeyLevelValidator struct {
 	vpmgr         KeyLevelValidationParameterManager
+	collEPs       CollectionEndorsementPolicyRetriever
 	policySupport validation.PolicyEvaluator
 	blockDep      blockDependency
 }
 
-func NewKeyLevelValidator(policySupport validation.PolicyEvaluator, vpmgr KeyLevelValidationParameterManager) *KeyLevelValidator {
+func NewKeyLevelValidator(policySupport validation.PolicyEvaluator, vpmgr KeyLevelValidationParameterManager, collEPs CollectionEndorsementPolicyRetriever) *KeyLevelValidator {
 	return &KeyLevelValidator{
 		vpmgr:         vpmgr,
+		collEPs:       collEPs,
 		policySupport: policySupport,
 		blockDep:      blockDependency{},
 	}
@@ -218,6 +258,8 @@ func (klv *KeyLevelValidator) Validate(cc string, blockNum, txNum uint64, rwsetB
 		policySupport: klv.policySupport,
 		signatureSet:  signatureSet,
 		vpmgr:         klv.vpmgr,
+		collEPs:       klv.collEPs,
+		collEPChecked: make(map[string]bool),
 	}
 
 	// unpack the rwset
@@ -253,7 +295,8 @@ func (klv *KeyLevelValidator) Validate(cc string, blockNum, txNum uint64, rwsetB
 		}
 		// writes in collections
 		// we validate writes against key-level validation parameters
-		// if any are present or the chaincode-wide endorsement policy
+		// if any are present, the collection endorsement policy if
+		// any is defined or the chaincode-wide endorsement policy
 		for _, collRWSet := range nsRWSet.CollHashedRwSets {
 			coll := collRWSet.CollectionName
 			for _, hashedWrite := range collRWSet.HashedRwSet.HashedWrites {
@@ -266,7 +309,8 @@ func (klv *KeyLevelValidator) Validate(cc string, blockNum, txNum uint64, rwsetB
 		}
 		// metadata writes in collections
 		// we validate writes against key-level validation parameters
-		// if any are present or the chaincode-wide endorsement policy
+		// if any are present, the collection endorsement policy if
+		// any is defined or the chaincode-wide endorsement policy
 		for _, collRWSet := range nsRWSet.CollHashedRwSets {
 			coll := collRWSet.CollectionName
 			for _, hashedMdWrite := range collRWSet.HashedRwSet.MetadataWrites {
@@ -33,6 +33,15 @@ func (m *mockPolicyEvaluator) Evaluate(policyBytes []byte, signatureSet []*commo
 	return m.EvaluateRV
 }
 
+type mockCollEPs struct {
+	EPs map[string][]byte
+	Err error
+}
+
+func (m *mockCollEPs) CollectionEndorsementPolicy(cc, coll string) ([]byte, error) {
+	return m.EPs[coll], m.Err
+}
+
 func buildBlockWithTxs(txs ...[]byte) *common.Block {
 	return &common.Block{
 		Header: &common.BlockHeader{
@@ -92,7 +101,7 @@ func TestKeylevelValidation(t *testing.T) {
 	ms := &mockStateFetcher{FetchStateRv: mr}
 	pm := &KeyLevelValidationParameterManagerImpl{StateFetcher: ms}
 	pe := &mockPolicyEvaluator{}
-	validator := NewKeyLevelValidator(pe, pm)
+	validator := NewKeyLevelValidator(pe, pm, &mockCollEPs{})
 
 	rwsb := rwsetBytes(t, "cc")
 	prp := []byte("barf")
@@ -133,7 +142,7 @@ func TestKeylevelValidationPvtData(t *testing.T) {
 	ms := &mockStateFetcher{FetchStateRv: mr}
 	pm := &KeyLevelValidationParameterManagerImpl{StateFetcher: ms}
 	pe := &mockPolicyEvaluator{}
-	validator := NewKeyLevelValidator(pe, pm)
+	validator := NewKeyLevelValidator(pe, pm, &mockCollEPs{})
 
 	rwsbu := rwsetutil.NewRWSetBuilder()
 	rwsbu.AddToPvtAndHashedWriteSet("cc", "coll", "key", []byte("value"))
@@ -171,7 +180,7 @@ func TestKeylevelValidationMetaUpdate(t *testing.T) {
 	ms := &mockStateFetcher{FetchStateRv: mr}
 	pm := &KeyLevelValidationParameterManagerImpl{StateFetcher: ms}
 	pe := &mockPolicyEvaluator{}
-	validator := NewKeyLevelValidator(pe, pm)
+	validator := NewKeyLevelValidator(pe, pm, &mockCollEPs{})
 
 	rwsbu := rwsetutil.NewRWSetBuilder()
 	rwsbu.AddToMetadataWriteSet("cc", "key", map[string][]byte{})
@@ -209,7 +218,7 @@ func TestKeylevelValidationPvtMetaUpdate(t *testing.T) {
 	ms := &mockStateFetcher{FetchStateRv: mr}
 	pm := &KeyLevelValidationParameterManagerImpl{StateFetcher: ms}
 	pe := &mockPolicyEvaluator{}
-	validator := NewKeyLevelValidator(pe, pm)
+	validator := NewKeyLevelValidator(pe, pm, &mockCollEPs{})
 
 	rwsbu := rwsetutil.NewRWSetBuilder()
 	rwsbu.AddToHashedMetadataWriteSet("cc", "coll", "key", map[string][]byte{})
@@ -246,7 +255,7 @@ func TestKeylevelValidationPolicyRetrievalFailure(t *testing.T) {
 	mr := &mockState{GetStateMetadataErr: fmt.Errorf("metadata retrieval failure")}
 	ms := &mockStateFetcher{FetchStateRv: mr}
 	pm := &KeyLevelValidationParameterManagerImpl{StateFetcher: ms}
-	validator := NewKeyLevelValidator(&mockPolicyEvaluator{}, pm)
+	validator := NewKeyLevelValidator(&mockPolicyEvaluator{}, pm, &mockCollEPs{})
 
 	rwsb := rwsetBytes(t, "cc")
 	prp := []byte("barf")
@@ -277,7 +286,7 @@ func TestKeylevelValidationLedgerFailures(t *testing.T) {
 		mr := &mockState{GetStateMetadataErr: &ledger.CollConfigNotDefinedError{Ns: "mycc"}}
 		ms := &mockStateFetcher{FetchStateRv: mr}
 		pm := &KeyLevelValidationParameterManagerImpl{StateFetcher: ms}
-		validator := NewKeyLevelValidator(&mockPolicyEvaluator{}, pm)
+		validator := NewKeyLevelValidator(&mockPolicyEvaluator{}, pm, &mockCollEPs{})
 
 		err := validator.Validate("cc", 1, 0, rwsb, prp, []byte("CCEP"), []*pb.Endorsement{})
 		assert.NoError(t, err)
@@ -287,7 +296,7 @@ func TestKeylevelValidationLedgerFailures(t *testing.T) {
 		mr := &mockState{GetStateMetadataErr: &ledger.InvalidCollNameError{Ns: "mycc", Coll: "mycoll"}}
 		ms := &mockStateFetcher{FetchStateRv: mr}
 		pm := &KeyLevelValidationParameterManagerImpl{StateFetcher: ms}
-		validator := NewKeyLevelValidator(&mockPolicyEvaluator{}, pm)
+		validator := NewKeyLevelValidator(&mockPolicyEvaluator{}, pm, &mockCollEPs{})
 
 		err := validator.Validate("cc", 1, 0, rwsb, prp, []byte("CCEP"), []*pb.Endorsement{})
 		assert.NoError(t, err)
@@ -297,7 +306,7 @@ func TestKeylevelValidationLedgerFailures(t *testing.T) {
 		mr := &mockState{GetStateMetadataErr: fmt.Errorf("some I/O error")}
 		ms := &mockStateFetcher{FetchStateRv: mr}
 		pm := &KeyLevelValidationParameterManagerImpl{StateFetcher: ms}
-		validator := NewKeyLevelValidator(&mockPolicyEvaluator{}, pm)
+		validator := NewKeyLevelValidator(&mockPolicyEvaluator{}, pm, &mockCollEPs{})
 
 		err := validator.Validate("cc", 1, 0, rwsb, prp, []byte("CCEP"), []*pb.Endorsement{})
 		assert.Error(t, err)
@@ -316,7 +325,7 @@ func TestCCEPValidation(t *testing.T) {
 	ms := &mockStateFetcher{FetchStateRv: mr}
 	pm := &KeyLevelValidationParameterManagerImpl{StateFetcher: ms}
 	pe := &mockPolicyEvaluator{}
-	validator := NewKeyLevelValidator(pe, pm)
+	validator := NewKeyLevelValidator(pe, pm, &mockCollEPs{})
 
 	rwsbu := rwsetutil.NewRWSetBuilder()
 	rwsbu.AddToWriteSet("cc", "key", []byte("value"))
@@ -356,7 +365,7 @@ func TestCCEPValidationReads(t *testing.T) {
 	ms := &mockStateFetcher{FetchStateRv: mr}
 	pm := &KeyLevelValidationParameterManagerImpl{StateFetcher: ms}
 	pe := &mockPolicyEvaluator{}
-	validator := NewKeyLevelValidator(pe, pm)
+	validator := NewKeyLevelValidator(pe, pm, &mockCollEPs{})
 
 	rwsbu := rwsetutil.NewRWSetBuilder()
 	rwsbu.AddToReadSet("cc", "readkey", &version.Height{})
@@ -396,7 +405,7 @@ func TestOnlySBEPChecked(t *testing.T) {
 	ms := &mockStateFetcher{FetchStateRv: mr}
 	pm := &KeyLevelValidationParameterManagerImpl{StateFetcher: ms}
 	pe := &mockPolicyEvaluator{}
-	validator := NewKeyLevelValidator(pe, pm)
+	validator := NewKeyLevelValidator(pe, pm, &mockCollEPs{})
 
 	rwsb := rwsetBytes(t, "cc")
 	prp := []byte("barf")
@@ -438,7 +447,7 @@ func TestCCEPValidationPvtReads(t *testing.T) {
 	ms := &mockStateFetcher{FetchStateRv: mr}
 	pm := &KeyLevelValidationParameterManagerImpl{StateFetcher: ms}
 	pe := &mockPolicyEvaluator{}
-	validator := NewKeyLevelValidator(pe, pm)
+	validator := NewKeyLevelValidator(pe, pm, &mockCollEPs{})
 
 	rwsbu := rwsetutil.NewRWSetBuilder()
 	rwsbu.AddToHashedReadSet("cc", "coll", "readpvtkey", &version.Height{})
@@ -477,7 +486,7 @@ func TestKeylevelValidationFailure(t *testing.T) {
 	mr := &mockState{GetStateMetadataRv: map[string][]byte{vpMetadataKey: []byte("EP")}, GetPrivateDataMetadataByHashRv: map[string][]byte{vpMetadataKey: []byte("EP")}}
 	ms := &mockStateFetcher{FetchStateRv: mr}
 	pm := &KeyLevelValidationParameterManagerImpl{StateFetcher: ms}
-	validator := NewKeyLevelValidator(&mockPolicyEvaluator{}, pm)
+	validator := NewKeyLevelValidator(&mockPolicyEvaluator{}, pm, &mockCollEPs{})
 
 	rwsb := rwsetBytes(t, "cc")
 	prp := []byte("barf")
@@ -493,3 +502,70 @@ func TestKeylevelValidationFailure(t *testing.T) {
 	assert.Error(t, err)
 	assert.IsType(t, &errors.VSCCEndorsementPolicyError{}, err)
 }
+
+func TestCollectionEPValidation(t *testing.T) {
+	t.Parallel()
+
+	// Scenario: we validate a transaction that writes
+	// to pvt keys without key-level validation params,
+	// in a collection with an endorsement policy and in
+	// a collection without one. We expect to check the
+	// collection endorsement policy in place of the
+	// cc-endorsement policy for the former.
+
+	mr := &mockState{GetStateMetadataRv: map[string][]byte{}, GetPrivateDataMetadataByHashRv: map[string][]byte{}}
+	ms := &mockStateFetcher{FetchStateRv: mr}
+	pm := &KeyLevelValidationParameterManagerImpl{StateFetcher: ms}
+	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{"CCEP": fmt.Errorf("cc policy evaluation error")}}
+	collEPs := &mockCollEPs{EPs: map[string][]byte{"coll": []byte("COLLEP")}}
+	validator := NewKeyLevelValidator(pe, pm, collEPs)
+
+	rwsbu := rwsetutil.NewRWSetBuilder()
+	rwsbu.AddToPvtAndHashedWriteSet("cc", "coll", "key1", []byte("value"))
+	rwsbu.AddToPvtAndHashedWriteSet("cc", "coll", "key2", []byte("value"))
+	rws := rwsbu.GetTxReadWriteSet()
+	rwsb, err := rws.ToProtoBytes()
+	assert.NoError(t, err)
+	prp := []byte("barf")
+	block := buildBlockWithTxs(buildTXWithRwset(rwsetUpdatingMetadataFor("cc", "key")), buildTXWithRwset(rwsetUpdatingMetadataFor("cc", "key")))
+
+	validator.PreValidate(1, block)
+
+	go func() {
+		validator.PostValidate("cc", 1, 0, fmt.Errorf(""))
+	}()
+
+	// the collection endorsement policy suffices
+	err = validator.Validate("cc", 1, 1, rwsb, prp, []byte("CCEP"), []*pb.Endorsement{})
+	assert.NoError(t, err)
+
+	pe.EvaluateResByPolicy = map[string]error{"COLLEP": fmt.Errorf("coll policy evaluation error")}
+	err = validator.Validate("cc", 1, 1, rwsb, prp, []byte("CCEP"), []*pb.Endorsement{})
+	assert.Error(t, err)
+	assert.IsType(t, &errors.VSCCEndorsementPolicyError{}, err)
+	assert.Contains(t, err.Error(), "validation of endorsement policy for collection coll of chaincode cc in tx 1:1 failed")
+
+	// a write to a collection without endorsement policy requires the cc-endorsement policy
+	rwsbu = rwsetutil.NewRWSetBuilder()
+	rwsbu.AddToPvtAndHashedWriteSet("cc", "coll", "key1", []byte("value"))
+	rwsbu.AddToPvtAndHashedWriteSet("cc", "coll2", "key1", []byte("value"))
+	rws = rwsbu.GetTxReadWriteSet()
+	rwsb, err = rws.ToProtoBytes()
+	assert.NoError(t, err)
+
+	pe.EvaluateResByPolicy = map[string]error{"CCEP": fmt.Errorf("cc policy evaluation error")}
+	err = validator.Validate("cc", 1, 1, rwsb, prp, []byte("CCEP"), []*pb.Endorsement{})
+	assert.Error(t, err)
+	assert.IsType(t, &errors.VSCCEndorsementPolicyError{}, err)
+	assert.Contains(t, err.Error(), "validation of endorsement policy for chaincode cc in tx 1:1 failed")
+
+	pe.EvaluateResByPolicy = nil
+	err = validator.Validate("cc", 1, 1, rwsb, prp, []byte("CCEP"), []*pb.Endorsement{})
+	assert.NoError(t, err)
+
+	// failing to retrieve the collection endorsement policy is an execution failure
+	collEPs.Err = fmt.Errorf("ledger error")
+	err = validator.Validate("cc", 1, 1, rwsb, prp, []byte("CCEP"), []*pb.Endorsement{})
+	assert.Error(t, err)
+	assert.IsType(t, &errors.VSCCExecutionFailureError{}, err)
+}
@@ -26,6 +26,8 @@ import (
 )
 
 type mockState struct {
+	GetStateMultipleKeysRv          [][]byte
+	GetStateMultipleKeysErr         error
 	GetStateMetadataRv              map[string][]byte
 	GetStateMetadataErr             error
 	GetPrivateDataMetadataByHashRv  map[string][]byte
@@ -34,7 +36,7 @@ type mockState struct {
 }
 
 func (ms *mockState) GetStateMultipleKeys(namespace string, keys []string) ([][]byte, error) {
-	return nil, nil
+	return ms.GetStateMultipleKeysRv, ms.GetStateMultipleKeysErr
 }
 
 func (ms *mockState) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (validation.ResultsIterator, error) {
@@ -73,6 +75,8 @@ func (ms *mockStateFetcher) FetchState() (validation.State, error) {
 	var rv *mockState
 	if ms.FetchStateRv != nil {
 		rv = &mockState{
+			GetStateMultipleKeysRv:          ms.FetchStateRv.GetStateMultipleKeysRv,
+			GetStateMultipleKeysErr:         ms.FetchStateRv.GetStateMultipleKeysErr,
 			GetPrivateDataMetadataByHashErr: ms.FetchStateRv.GetPrivateDataMetadataByHashErr,
 			GetStateMetadataErr:             ms.FetchStateRv.GetStateMetadataErr,
 			GetPrivateDataMetadataByHashRv:  ms.FetchStateRv.GetPrivateDataMetadataByHashRv,
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
@@ -170,6 +171,71 @@ func (si StopContainerReq) GetCCID() ccintf.CCID {
 	return si.CCID
 }
 
+// Diagnostics describe the state of the container of a chaincode
+type Diagnostics struct {
+	// Output holds the last lines written by the container
+	Output []string
+	// Exited tells whether the container exited, with the given exit code
+	Exited   bool
+	ExitCode int
+}
+
+// Diagnoser is implemented by the VMs which can describe their containers
+type Diagnoser interface {
+	Diagnose(ccid ccintf.CCID, outputLines int) (*Diagnostics, error)
+}
+
+//DiagnoseContainerReq - properties for describing a container. The
+//diagnostics of the container are stored in Diagnostics.
+type DiagnoseContainerReq struct {
+	ccintf.CCID
+	OutputLines int
+	Diagnostics *Diagnostics
+}
+
+func (di DiagnoseContainerReq) Do(v VM) error {
+	diagnoser, ok := v.(Diagnoser)
+	if !ok {
+		return fmt.Errorf("containers of type %T cannot be diagnosed", v)
+	}
+	diagnostics, err := diagnoser.Diagnose(di.CCID, di.OutputLines)
+	if err != nil {
+		return err
+	}
+	*di.Diagnostics = *diagnostics
+	return nil
+}
+
+func (di DiagnoseContainerReq) GetCCID() ccintf.CCID {
+	return di.CCID
+}
+
+// GarbageCollector is implemented by the VMs which can remove the containers
+// and the images of the chaincodes left behind
+type GarbageCollector interface {
+	CollectGarbage(referenced []ccintf.CCID, retention time.Duration) error
+}
+
+//CollectGarbageReq - properties for removing the exited containers, and the
+//images of the chaincodes which are not referenced, once they are older than
+//Retention. As it is not about a single container, its CCID is empty.
+type CollectGarbageReq struct {
+	Referenced []ccintf.CCID
+	Retention  time.Duration
+}
+
+func (gc CollectGarbageReq) Do(v VM) error {
+	collector, ok := v.(GarbageCollector)
+	if !ok {
+		return fmt.Errorf("containers of type %T cannot be garbage collected", v)
+	}
+	return collector.CollectGarbage(gc.Referenced, gc.Retention)
+}
+
+func (gc CollectGarbageReq) GetCCID() ccintf.CCID {
+	return ccintf.CCID{}
+}
+
 func (vmc *VMController) Process(vmtype string, req VMCReq) error {
 	v := vmc.newVM(vmtype)
 	ccid := req.GetCCID()
@@ -13,6 +13,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
@@ -65,6 +66,9 @@ type dockerClient interface {
 	// BuildImage builds an image from a tarball's url or a Dockerfile in the input
 	// stream, returns an error in case of failure
 	BuildImage(opts docker.BuildImageOptions) error
+	// InspectImage returns an image by its name or ID, returns an error in case
+	// of failure
+	InspectImage(name string) (*docker.Image, error)
 	// RemoveImageExtended removes a docker image by its name or ID, returns an
 	// error in case of failure
 	RemoveImageExtended(id string, opts docker.RemoveImageOptions) error
@@ -76,6 +80,31 @@ type dockerClient interface {
 	KillContainer(opts docker.KillContainerOptions) error
 	// RemoveContainer removes a docker container, returns an error in case of failure
 	RemoveContainer(opts docker.RemoveContainerOptions) error
+	// InspectContainer returns information about a container, returns an
+	// error in case of failure
+	InspectContainer(id string) (*docker.Container, error)
+	// Logs writes the output of a container to the streams of the options,
+	// returns an error in case of failure
+	Logs(opts docker.LogsOptions) error
+	// ListContainers returns the containers matching the options, returns an
+	// error in case of failure
+	ListContainers(opts docker.ListContainersOptions) ([]docker.APIContainers, error)
+	// ListImages returns the images matching the options, returns an error in
+	// case of failure
+	ListImages(opts docker.ListImagesOptions) ([]docker.APIImages, error)
+	// NetworkInfo returns a network by its name or ID, returns an error in
+	// case of failure
+	NetworkInfo(id string) (*docker.Network, error)
+	// CreateNetwork creates a network, returns an error in case of failure
+	CreateNetwork(opts docker.CreateNetworkOptions) (*docker.Network, error)
+	// ConnectNetwork connects a container to a network, returns an error in
+	// case of failure
+	ConnectNetwork(id string, opts docker.NetworkConnectionOptions) error
+	// DisconnectNetwork disconnects a container from a network, returns an
+	// error in case of failure
+	DisconnectNetwork(id string, opts docker.NetworkConnectionOptions) error
+	// RemoveNetwork removes a network, returns an error in case of failure
+	RemoveNetwork(id string) error
 }
 
 // Controller implements container.VMProvider
@@ -172,10 +201,16 @@ func getDockerHostConfig() *docker.HostConfig {
 }
 
 func (vm *DockerVM) createContainer(client dockerClient,
-	imageID string, containerID string, args []string,
+	imageID string, containerID string, networkMode string, args []string,
 	env []string, attachStdout bool) error {
 	config := docker.Config{Cmd: args, Image: imageID, Env: env, AttachStdout: attachStdout, AttachStderr: attachStdout}
-	copts := docker.CreateContainerOptions{Name: containerID, Config: &config, HostConfig: getDockerHostConfig()}
+	hostConfig := getDockerHostConfig()
+	if networkMode != "" {
+		isolated := *hostConfig
+		isolated.NetworkMode = networkMode
+		hostConfig = &isolated
+	}
+	copts := docker.CreateContainerOptions{Name: containerID, Config: &config, HostConfig: hostConfig}
 	dockerLogger.Debugf("Create container: %s", containerID)
 	_, err := client.CreateContainer(copts)
 	if err != nil {
@@ -232,8 +267,20 @@ func (vm *DockerVM) Start(ccid ccintf.CCID,
 	dockerLogger.Debugf("Cleanup container %s", containerName)
 	vm.stopInternal(client, containerName, 0, false, false)
 
+	if err = vm.checkImages(client, ccid, imageName); err != nil {
+		return err
+	}
+
+	var networkMode string
+	if viper.GetBool("vm.docker.isolation.enabled") {
+		networkMode, err = vm.isolate(client, containerName)
+		if err != nil {
+			return err
+		}
+	}
+
 	dockerLogger.Debugf("Start container %s", containerName)
-	err = vm.createContainer(client, imageName, containerName, args, env, attachStdout)
+	err = vm.createContainer(client, imageName, containerName, networkMode, args, env, attachStdout)
 	if err != nil {
 		//if image not found try to create image and retry
 		if err == docker.ErrNoSuchImage {
@@ -252,7 +299,7 @@ func (vm *DockerVM) Start(ccid ccintf.CCID,
 				}
 
 				dockerLogger.Debug("start-recreated image successfully")
-				if err1 = vm.createContainer(client, imageName, containerName, args, env, attachStdout); err1 != nil {
+				if err1 = vm.createContainer(client, imageName, containerName, networkMode, args, env, attachStdout); err1 != nil {
 					dockerLogger.Errorf("start-could not recreate container post recreate image: %s", err1)
 					return err1
 				}
@@ -378,6 +425,40 @@ func (vm *DockerVM) Start(ccid ccintf.CCID,
 	return nil
 }
 
+// checkImages removes the image of the chaincode if it was not built with the
+// builder and runtime images configured for the chaincode, so that it is
+// rebuilt with them
+func (vm *DockerVM) checkImages(client dockerClient, ccid ccintf.CCID, imageName string) error {
+	images := cutil.GetChaincodeImages(ccid.Name)
+	if !images.Pinned() {
+		return nil
+	}
+
+	image, err := client.InspectImage(imageName)
+	if err == docker.ErrNoSuchImage {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed inspecting image %s: %s", imageName, err)
+	}
+
+	var labels map[string]string
+	if image.Config != nil {
+		labels = image.Config.Labels
+	}
+	if labels[cutil.BuilderImageLabel] == images.Builder && labels[cutil.RuntimeImageLabel] == images.Runtime {
+		return nil
+	}
+
+	dockerLogger.Warningf("Image %s was built with builder image [%s] and runtime image [%s] instead of [%s] and [%s], removing it",
+		imageName, labels[cutil.BuilderImageLabel], labels[cutil.RuntimeImageLabel], images.Builder, images.Runtime)
+	err = client.RemoveImageExtended(imageName, docker.RemoveImageOptions{Force: true})
+	if err != nil {
+		return fmt.Errorf("failed removing image %s: %s", imageName, err)
+	}
+	return nil
+}
+
 //Stop stops a running chaincode
 func (vm *DockerVM) Stop(ccid ccintf.CCID, timeout uint, dontkill bool, dontremove bool) error {
 	id := vm.GetVMName(ccid)
@@ -390,10 +471,73 @@ func (vm *DockerVM) Stop(ccid ccintf.CCID, timeout uint, dontkill bool, dontremo
 	id = strings.Replace(id, ":", "_", -1)
 
 	err = vm.stopInternal(client, id, timeout, dontkill, dontremove)
+	if !dontremove && viper.GetBool("vm.docker.isolation.enabled") {
+		vm.removeNetwork(client, id)
+	}
 
 	return err
 }
 
+// isolate creates the network dedicated to the container of a chaincode, if
+// it does not exist yet, and connects the allowed hosts to it. The network is
+// internal unless vm.docker.isolation.external is set, so that the chaincode
+// can only reach the allowed hosts, which should include the peer.
+func (vm *DockerVM) isolate(client dockerClient, containerName string) (string, error) {
+	name := networkName(containerName)
+	network, err := client.NetworkInfo(name)
+	if _, ok := err.(*docker.NoSuchNetwork); ok {
+		dockerLogger.Debugf("Create network %s", name)
+		network, err = client.CreateNetwork(docker.CreateNetworkOptions{
+			Name:           name,
+			Driver:         "bridge",
+			CheckDuplicate: true,
+			Internal:       !viper.GetBool("vm.docker.isolation.external"),
+		})
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed creating network %s: %s", name, err)
+	}
+
+	connected := map[string]bool{}
+	for id, endpoint := range network.Containers {
+		connected[id] = true
+		connected[endpoint.Name] = true
+	}
+	for _, host := range viper.GetStringSlice("vm.docker.isolation.allowedHosts") {
+		if connected[host] {
+			continue
+		}
+		err := client.ConnectNetwork(name, docker.NetworkConnectionOptions{Container: host})
+		if err != nil {
+			return "", fmt.Errorf("failed connecting %s to network %s: %s", host, name, err)
+		}
+	}
+
+	return name, nil
+}
+
+// removeNetwork disconnects the allowed hosts from the network dedicated to
+// the container of a chaincode and removes it
+func (vm *DockerVM) removeNetwork(client dockerClient, containerName string) {
+	name := networkName(containerName)
+	for _, host := range viper.GetStringSlice("vm.docker.isolation.allowedHosts") {
+		err := client.DisconnectNetwork(name, docker.NetworkConnectionOptions{Container: host, Force: true})
+		if err != nil {
+			dockerLogger.Debugf("Disconnect %s from network %s (%s)", host, name, err)
+		}
+	}
+	if err := client.RemoveNetwork(name); err != nil {
+		dockerLogger.Debugf("Remove network %s (%s)", name, err)
+	} else {
+		dockerLogger.Debugf("Removed network %s", name)
+	}
+}
+
+// networkName returns the name of the network dedicated to a container
+func networkName(containerName string) string {
+	return containerName + "-net"
+}
+
 func (vm *DockerVM) stopInternal(client dockerClient,
 	id string, timeout uint, dontkill bool, dontremove bool) error {
 	err := client.StopContainer(id, timeout)
@@ -421,6 +565,126 @@ func (vm *DockerVM) stopInternal(client dockerClient,
 	return err
 }
 
+// Diagnose returns the last lines written by the container of a chaincode,
+// and its exit code if it exited
+func (vm *DockerVM) Diagnose(ccid ccintf.CCID, outputLines int) (*container.Diagnostics, error) {
+	client, err := vm.getClientFnc()
+	if err != nil {
+		dockerLogger.Debugf("diagnose - cannot create client %s", err)
+		return nil, err
+	}
+	id := strings.Replace(vm.GetVMName(ccid), ":", "_", -1)
+
+	c, err := client.InspectContainer(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed inspecting container %s: %s", id, err)
+	}
+	output := &bytes.Buffer{}
+	err = client.Logs(docker.LogsOptions{
+		Container:    id,
+		OutputStream: output,
+		ErrorStream:  output,
+		Stdout:       true,
+		Stderr:       true,
+		Tail:         strconv.Itoa(outputLines),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the output of container %s: %s", id, err)
+	}
+
+	diagnostics := &container.Diagnostics{}
+	if output.Len() > 0 {
+		diagnostics.Output = strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	}
+	if c.State.Running {
+		return diagnostics, nil
+	}
+	diagnostics.Exited = true
+	diagnostics.ExitCode = c.State.ExitCode
+	return diagnostics, nil
+}
+
+// CollectGarbage removes the exited containers of the chaincodes of the peer,
+// and the images of the chaincodes which are not referenced, once they exited
+// or were built more than retention ago. Images still used by a container are
+// kept.
+func (vm *DockerVM) CollectGarbage(referenced []ccintf.CCID, retention time.Duration) error {
+	// the containers and the images of the peer are recognized by the prefix
+	// of their names, without which those of other peers could be removed
+	if vm.NetworkID == "" && vm.PeerID == "" {
+		return fmt.Errorf("cannot collect garbage without a network or a peer id")
+	}
+	client, err := vm.getClientFnc()
+	if err != nil {
+		dockerLogger.Debugf("collect garbage - cannot create client %s", err)
+		return err
+	}
+	containerPrefix := vm.GetVMName(ccintf.CCID{})
+	imagePrefix := strings.ToLower(containerPrefix)
+	referencedImages := map[string]bool{}
+	for _, ccid := range referenced {
+		imageName, err := vm.GetVMNameForDocker(ccid)
+		if err != nil {
+			return err
+		}
+		referencedImages[imageName] = true
+	}
+	deadline := time.Now().Add(-retention)
+
+	containers, err := client.ListContainers(docker.ListContainersOptions{
+		All:     true,
+		Filters: map[string][]string{"status": {"exited", "dead"}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed listing containers: %s", err)
+	}
+	for _, c := range containers {
+		if len(c.Names) == 0 || !strings.HasPrefix(strings.TrimPrefix(c.Names[0], "/"), containerPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(c.Names[0], "/")
+		details, err := client.InspectContainer(c.ID)
+		if err != nil {
+			dockerLogger.Warningf("Failed inspecting container %s: %s", name, err)
+			continue
+		}
+		if details.State.Running || details.State.FinishedAt.After(deadline) {
+			continue
+		}
+		if err := client.RemoveContainer(docker.RemoveContainerOptions{ID: c.ID}); err != nil {
+			dockerLogger.Warningf("Failed removing exited container %s: %s", name, err)
+			continue
+		}
+		dockerLogger.Infof("Removed exited container %s", name)
+		if viper.GetBool("vm.docker.isolation.enabled") {
+			vm.removeNetwork(client, name)
+		}
+	}
+
+	images, err := client.ListImages(docker.ListImagesOptions{})
+	if err != nil {
+		return fmt.Errorf("failed listing images: %s", err)
+	}
+	for _, image := range images {
+		if time.Unix(image.Created, 0).After(deadline) {
+			continue
+		}
+		for _, tag := range image.RepoTags {
+			imageName := strings.SplitN(tag, ":", 2)[0]
+			if !strings.HasPrefix(imageName, imagePrefix) || referencedImages[imageName] {
+				continue
+			}
+			// images used by containers, even exited ones, are not removed
+			if err := client.RemoveImageExtended(tag, docker.RemoveImageOptions{}); err != nil {
+				dockerLogger.Warningf("Failed removing unreferenced image %s: %s", tag, err)
+				continue
+			}
+			dockerLogger.Infof("Removed unreferenced image %s", tag)
+		}
+	}
+	return nil
+}
+
 // GetVMName generates the VM name from peer information. It accepts a format
 // function parameter to allow different formatting based on the desired use of
 // the name.
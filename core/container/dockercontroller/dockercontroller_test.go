@@ -25,7 +25,9 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
+	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/ccintf"
+	cutil "github.com/hyperledger/fabric/core/container/util"
 	coreutil "github.com/hyperledger/fabric/core/testutil"
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
@@ -168,6 +170,55 @@ func Test_Start(t *testing.T) {
 	testerr(t, err, true)
 }
 
+func TestCheckImages(t *testing.T) {
+	dvm := DockerVM{PeerID: "peer0", NetworkID: "dev"}
+	dvm.getClientFnc = getMockClient
+	client, _ := getMockClient()
+	defer func() {
+		inspectedImage = nil
+		removedImages = nil
+		viper.Set("chaincode.images", nil)
+	}()
+
+	ccid := ccintf.CCID{Name: "pinned", Version: "1.0"}
+	imageName, err := dvm.GetVMNameForDocker(ccid)
+	assert.NoError(t, err)
+
+	// images of chaincodes without configured images are not inspected
+	inspectedImage = &docker.Image{Config: &docker.Config{}}
+	assert.NoError(t, dvm.checkImages(client, ccid, imageName))
+	assert.Empty(t, removedImages)
+
+	viper.Set("chaincode.images.pinned.builder", "builder@sha256:0123")
+	viper.Set("chaincode.images.pinned.runtime", "runtime@sha256:4567")
+
+	// an image built with other images is removed
+	assert.NoError(t, dvm.checkImages(client, ccid, imageName))
+	assert.Equal(t, []string{imageName}, removedImages)
+	removedImages = nil
+
+	// an image built with the configured images is kept
+	inspectedImage = &docker.Image{Config: &docker.Config{Labels: map[string]string{
+		cutil.BuilderImageLabel: "builder@sha256:0123",
+		cutil.RuntimeImageLabel: "runtime@sha256:4567",
+	}}}
+	assert.NoError(t, dvm.checkImages(client, ccid, imageName))
+	assert.Empty(t, removedImages)
+
+	// a missing image is built anyway
+	inspectedImage = nil
+	assert.NoError(t, dvm.checkImages(client, ccid, imageName))
+	assert.Empty(t, removedImages)
+
+	// failing to remove the image fails the launch
+	inspectedImage = &docker.Image{}
+	removeImgErr = true
+	err = dvm.Start(ccid, nil, nil, nil, nil)
+	removeImgErr = false
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed removing image "+imageName)
+}
+
 func Test_Stop(t *testing.T) {
 	dvm := DockerVM{}
 	ccid := ccintf.CCID{Name: "simple"}
@@ -184,6 +235,127 @@ func Test_Stop(t *testing.T) {
 	testerr(t, err, true)
 }
 
+func TestDiagnose(t *testing.T) {
+	dvm := DockerVM{PeerID: "peer0", NetworkID: "dev"}
+	dvm.getClientFnc = getMockClient
+	ccid := ccintf.CCID{Name: "mycc", Version: "1.0"}
+	defer func() {
+		inspectedContainer = nil
+		containerOutput = ""
+	}()
+
+	_, err := dvm.Diagnose(ccid, 10)
+	assert.EqualError(t, err, "failed inspecting container dev-peer0-mycc-1.0: No such container: dev-peer0-mycc-1.0")
+
+	inspectedContainer = &docker.Container{State: docker.State{Running: true}}
+	diagnostics, err := dvm.Diagnose(ccid, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, &container.Diagnostics{}, diagnostics)
+
+	inspectedContainer = &docker.Container{State: docker.State{ExitCode: 2}}
+	containerOutput = "starting\npanic: boom\n"
+	diagnostics, err = dvm.Diagnose(ccid, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, &container.Diagnostics{
+		Output:   []string{"starting", "panic: boom"},
+		Exited:   true,
+		ExitCode: 2,
+	}, diagnostics)
+}
+
+func TestIsolation(t *testing.T) {
+	dvm := DockerVM{PeerID: "peer0", NetworkID: "dev"}
+	dvm.getClientFnc = getMockClient
+	ccid := ccintf.CCID{Name: "mycc", Version: "1.0"}
+	viper.Set("vm.docker.isolation.enabled", true)
+	viper.Set("vm.docker.isolation.allowedHosts", []string{"peer0.org1.example.com"})
+	defer func() {
+		viper.Set("vm.docker.isolation", nil)
+		networks = nil
+	}()
+
+	// the container is attached to an internal network shared with the peer
+	err := dvm.Start(ccid, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "dev-peer0-mycc-1.0-net", createdContainer.HostConfig.NetworkMode)
+	assert.Equal(t, "host", getDockerHostConfig().NetworkMode)
+	network := networks["dev-peer0-mycc-1.0-net"]
+	assert.NotNil(t, network)
+	assert.True(t, network.Internal)
+	assert.Contains(t, network.Containers, "peer0.org1.example.com")
+
+	// the network is reused when the container is restarted
+	err = dvm.Start(ccid, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, networks, 1)
+
+	// the network is removed along with the container
+	err = dvm.Stop(ccid, 0, false, false)
+	assert.NoError(t, err)
+	assert.Empty(t, networks)
+
+	// the network may give access to external hosts
+	viper.Set("vm.docker.isolation.external", true)
+	err = dvm.Start(ccid, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.False(t, networks["dev-peer0-mycc-1.0-net"].Internal)
+
+	// the chaincode cannot be started when the peer cannot be connected to it
+	networks = nil
+	connectErr = true
+	err = dvm.Start(ccid, nil, nil, nil, nil)
+	assert.EqualError(t, err, "failed connecting peer0.org1.example.com to network dev-peer0-mycc-1.0-net: Error connecting container to network")
+	connectErr = false
+}
+
+func TestCollectGarbage(t *testing.T) {
+	dvm := DockerVM{PeerID: "peer0", NetworkID: "dev"}
+	dvm.getClientFnc = getMockClient
+	removedContainers = nil
+	removedImages = nil
+	defer func() {
+		listedContainers = nil
+		listedImages = nil
+		inspectedContainers = nil
+		removedContainers = nil
+		removedImages = nil
+	}()
+
+	old := time.Now().Add(-2 * time.Hour)
+	recent := time.Now().Add(-time.Minute)
+	listedContainers = []docker.APIContainers{
+		{ID: "1", Names: []string{"/dev-peer0-mycc-1.0"}},
+		{ID: "2", Names: []string{"/dev-peer0-mycc-2.0"}},
+		{ID: "3", Names: []string{"/dev-peer1-mycc-1.0"}},
+	}
+	inspectedContainers = map[string]*docker.Container{
+		"1": {State: docker.State{FinishedAt: old}},
+		"2": {State: docker.State{FinishedAt: recent}},
+		"3": {State: docker.State{FinishedAt: old}},
+	}
+	imageName := func(name, version string) string {
+		name, err := dvm.GetVMNameForDocker(ccintf.CCID{Name: name, Version: version})
+		assert.NoError(t, err)
+		return name
+	}
+	listedImages = []docker.APIImages{
+		{ID: "a", RepoTags: []string{imageName("mycc", "1.0") + ":latest"}, Created: old.Unix()},
+		{ID: "b", RepoTags: []string{imageName("mycc", "2.0") + ":latest"}, Created: old.Unix()},
+		{ID: "c", RepoTags: []string{imageName("mycc", "3.0") + ":latest"}, Created: recent.Unix()},
+		{ID: "d", RepoTags: []string{"hyperledger/fabric-ccenv:latest"}, Created: old.Unix()},
+	}
+
+	err := dvm.CollectGarbage([]ccintf.CCID{{Name: "mycc", Version: "2.0"}}, time.Hour)
+	assert.NoError(t, err)
+	// only the containers of the peer which exited before the retention are removed
+	assert.Equal(t, []string{"1"}, removedContainers)
+	// only the images of the peer which are neither referenced nor recent are removed
+	assert.Equal(t, []string{imageName("mycc", "1.0") + ":latest"}, removedImages)
+
+	err = (&DockerVM{}).CollectGarbage(nil, time.Hour)
+	assert.EqualError(t, err, "cannot collect garbage without a network or a peer id")
+}
+
 type testCase struct {
 	name           string
 	vm             *DockerVM
@@ -313,7 +485,31 @@ type mockClient struct {
 }
 
 var getClientErr, createErr, uploadErr, noSuchImgErr, buildErr, removeImgErr,
-	startErr, stopErr, killErr, removeErr bool
+	startErr, stopErr, killErr, removeErr, connectErr bool
+
+// inspectedImage is returned by InspectImage, which fails with
+// docker.ErrNoSuchImage if nil, and removedImages records the removed images
+var inspectedImage *docker.Image
+var removedImages []string
+
+// inspectedContainer is returned by InspectContainer, which fails if nil, and
+// containerOutput is written by Logs
+var inspectedContainer *docker.Container
+var containerOutput string
+
+// listedContainers and listedImages are returned by ListContainers and
+// ListImages, inspectedContainers by InspectContainer for their ids, and
+// removedContainers records the removed containers
+var listedContainers []docker.APIContainers
+var listedImages []docker.APIImages
+var inspectedContainers map[string]*docker.Container
+var removedContainers []string
+
+// createdContainer records the options of the last created container, and
+// networks holds the networks created, which ConnectNetwork and
+// DisconnectNetwork connect and disconnect the containers to and from
+var createdContainer docker.CreateContainerOptions
+var networks map[string]*docker.Network
 
 func (c *mockClient) CreateContainer(options docker.CreateContainerOptions) (*docker.Container, error) {
 	if createErr {
@@ -323,6 +519,7 @@ func (c *mockClient) CreateContainer(options docker.CreateContainerOptions) (*do
 		c.noSuchImgErrReturned = true
 		return nil, docker.ErrNoSuchImage
 	}
+	createdContainer = options
 	return &docker.Container{}, nil
 }
 
@@ -354,10 +551,18 @@ func (c *mockClient) BuildImage(opts docker.BuildImageOptions) error {
 	return nil
 }
 
+func (c *mockClient) InspectImage(name string) (*docker.Image, error) {
+	if inspectedImage == nil {
+		return nil, docker.ErrNoSuchImage
+	}
+	return inspectedImage, nil
+}
+
 func (c *mockClient) RemoveImageExtended(id string, opts docker.RemoveImageOptions) error {
 	if removeImgErr {
 		return errors.New("Error removing extended image")
 	}
+	removedImages = append(removedImages, id)
 	return nil
 }
 
@@ -379,5 +584,77 @@ func (c *mockClient) RemoveContainer(opts docker.RemoveContainerOptions) error {
 	if removeErr {
 		return errors.New("Error removing container")
 	}
+	removedContainers = append(removedContainers, opts.ID)
+	return nil
+}
+
+func (c *mockClient) InspectContainer(id string) (*docker.Container, error) {
+	if container, ok := inspectedContainers[id]; ok {
+		return container, nil
+	}
+	if inspectedContainer == nil {
+		return nil, &docker.NoSuchContainer{ID: id}
+	}
+	return inspectedContainer, nil
+}
+
+func (c *mockClient) Logs(opts docker.LogsOptions) error {
+	_, err := io.WriteString(opts.OutputStream, containerOutput)
+	return err
+}
+
+func (c *mockClient) ListContainers(opts docker.ListContainersOptions) ([]docker.APIContainers, error) {
+	return listedContainers, nil
+}
+
+func (c *mockClient) ListImages(opts docker.ListImagesOptions) ([]docker.APIImages, error) {
+	return listedImages, nil
+}
+
+func (c *mockClient) NetworkInfo(id string) (*docker.Network, error) {
+	if network, ok := networks[id]; ok {
+		return network, nil
+	}
+	return nil, &docker.NoSuchNetwork{ID: id}
+}
+
+func (c *mockClient) CreateNetwork(opts docker.CreateNetworkOptions) (*docker.Network, error) {
+	if networks == nil {
+		networks = map[string]*docker.Network{}
+	}
+	networks[opts.Name] = &docker.Network{Name: opts.Name, Internal: opts.Internal, Containers: map[string]docker.Endpoint{}}
+	return networks[opts.Name], nil
+}
+
+func (c *mockClient) ConnectNetwork(id string, opts docker.NetworkConnectionOptions) error {
+	network, ok := networks[id]
+	if !ok {
+		return &docker.NoSuchNetworkOrContainer{NetworkID: id, ContainerID: opts.Container}
+	}
+	if connectErr {
+		return errors.New("Error connecting container to network")
+	}
+	network.Containers[opts.Container] = docker.Endpoint{Name: opts.Container}
+	return nil
+}
+
+func (c *mockClient) DisconnectNetwork(id string, opts docker.NetworkConnectionOptions) error {
+	network, ok := networks[id]
+	if !ok {
+		return &docker.NoSuchNetworkOrContainer{NetworkID: id, ContainerID: opts.Container}
+	}
+	delete(network.Containers, opts.Container)
+	return nil
+}
+
+func (c *mockClient) RemoveNetwork(id string) error {
+	network, ok := networks[id]
+	if !ok {
+		return &docker.NoSuchNetwork{ID: id}
+	}
+	if len(network.Containers) != 0 {
+		return errors.New("network has active endpoints")
+	}
+	delete(networks, id)
 	return nil
 }
@@ -45,3 +45,31 @@ func ParseDockerfileTemplate(template string) string {
 func GetDockerfileFromConfig(path string) string {
 	return ParseDockerfileTemplate(viper.GetString(path))
 }
+
+// Labels of chaincode images recording the images they were built with
+var (
+	BuilderImageLabel = metadata.BaseDockerLabel + ".chaincode.builder"
+	RuntimeImageLabel = metadata.BaseDockerLabel + ".chaincode.runtime"
+)
+
+// ChaincodeImages are the images a chaincode is built with and runs on. An
+// empty image is the default one of the platform of the chaincode.
+type ChaincodeImages struct {
+	Builder string
+	Runtime string
+}
+
+// Pinned returns whether any image of the chaincode is configured
+func (i ChaincodeImages) Pinned() bool {
+	return i.Builder != "" || i.Runtime != ""
+}
+
+// GetChaincodeImages returns the images configured for the chaincode with
+// the given name under chaincode.images
+func GetChaincodeImages(name string) ChaincodeImages {
+	key := "chaincode.images." + name
+	return ChaincodeImages{
+		Builder: GetDockerfileFromConfig(key + ".builder"),
+		Runtime: GetDockerfileFromConfig(key + ".runtime"),
+	}
+}
@@ -36,3 +36,18 @@ func TestUtil_GetDockertClient(t *testing.T) {
 	_, err := NewDockerClient()
 	assert.NoError(t, err, "Error getting docker client")
 }
+
+func TestUtil_GetChaincodeImages(t *testing.T) {
+	viper.Set("chaincode.images.mycc.builder", "$(DOCKER_NS)/fabric-ccenv@sha256:0123")
+	viper.Set("chaincode.images.mycc.runtime", "$(BASE_DOCKER_NS)/fabric-baseos@sha256:4567")
+	defer viper.Set("chaincode.images", nil)
+
+	images := GetChaincodeImages("mycc")
+	assert.True(t, images.Pinned())
+	assert.Equal(t, metadata.DockerNamespace+"/fabric-ccenv@sha256:0123", images.Builder)
+	assert.Equal(t, metadata.BaseDockerNamespace+"/fabric-baseos@sha256:4567", images.Runtime)
+
+	images = GetChaincodeImages("othercc")
+	assert.False(t, images.Pinned())
+	assert.Equal(t, ChaincodeImages{}, images)
+}
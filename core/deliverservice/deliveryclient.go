@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/viperutil"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -240,15 +241,19 @@ func DefaultConnectionFactory(channelID string) func(endpoint string) (*grpc.Cli
 			grpc.MaxCallSendMsgSize(comm.MaxSendMsgSize)))
 		// set the keepalive options
 		kaOpts := comm.DefaultKeepaliveOptions
-		if viper.IsSet("peer.keepalive.deliveryClient.interval") {
-			kaOpts.ClientInterval = viper.GetDuration(
+		if viperutil.Reloadable.IsSet("peer.keepalive.deliveryClient.interval") {
+			kaOpts.ClientInterval = viperutil.Reloadable.GetDuration(
 				"peer.keepalive.deliveryClient.interval")
 		}
-		if viper.IsSet("peer.keepalive.deliveryClient.timeout") {
-			kaOpts.ClientTimeout = viper.GetDuration(
+		if viperutil.Reloadable.IsSet("peer.keepalive.deliveryClient.timeout") {
+			kaOpts.ClientTimeout = viperutil.Reloadable.GetDuration(
 				"peer.keepalive.deliveryClient.timeout")
 		}
 		dialOpts = append(dialOpts, comm.ClientKeepaliveOptions(kaOpts)...)
+		// compress the blocks delivered by the ordering service
+		if viper.GetBool("peer.deliveryclient.compression") {
+			dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(comm.CompressionCallOption()))
+		}
 
 		if viper.GetBool("peer.tls.enabled") {
 			creds, err := comm.GetCredentialSupport().GetDeliverServiceCredentials(channelID)
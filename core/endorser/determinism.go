@@ -0,0 +1,272 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// DeterminismCheck makes the endorser execute each proposal of an
+// application chaincode a second time, and log a warning if the results of
+// the two executions differ. A chaincode producing different results for the
+// same proposal, for instance as it relies on the clock or on randomness,
+// gets mismatching endorsements from the peers of different organizations.
+// The check doubles the cost of the endorsements, and does not affect them.
+type DeterminismCheck struct {
+	// Chaincodes are the names of the chaincodes checked, all the
+	// application chaincodes are if empty
+	Chaincodes []string
+}
+
+// covers returns whether the proposals of the given chaincode are checked
+func (dc *DeterminismCheck) covers(ccName string) bool {
+	if dc == nil {
+		return false
+	}
+	if len(dc.Chaincodes) == 0 {
+		return true
+	}
+	for _, name := range dc.Chaincodes {
+		if name == ccName {
+			return true
+		}
+	}
+	return false
+}
+
+// executionResult holds what an execution of a chaincode contributes to
+// an endorsement
+type executionResult struct {
+	response *pb.Response
+	simRes   []byte
+	event    *pb.ChaincodeEvent
+}
+
+// determinismCheckHeight returns the height of the ledger before the
+// simulation of a proposal of the given chaincode, and whether the
+// proposal is to be checked
+func (e *Endorser) determinismCheckHeight(chainID string, ccName string) (uint64, bool) {
+	if chainID == "" || e.s.IsSysCC(ccName) || !e.DeterminismCheck.covers(ccName) {
+		return 0, false
+	}
+	height, err := e.s.GetLedgerHeight(chainID)
+	if err != nil {
+		endorserLogger.Warningf("[%s] Skipping the determinism check of chaincode %s, failed to obtain the ledger height: %s", chainID, ccName, err)
+		return 0, false
+	}
+	return height, true
+}
+
+// checkDeterminism executes the proposal a second time, and logs a warning
+// describing the differences between the results of the two executions, if
+// any. The results are only compared if no block was committed in between,
+// as the chaincode may then legitimately read different states.
+func (e *Endorser) checkDeterminism(txParams *ccprovider.TransactionParams, cid *pb.ChaincodeID, version string, heightBefore uint64, first *executionResult) {
+	logger := endorserLogger.With("channel", txParams.ChannelID, "txID", txParams.TxID, "chaincode", cid.Name, "version", version)
+
+	second, err := e.reexecute(txParams, cid, version)
+	if err != nil {
+		logger.Warningf("Skipping the determinism check, the second execution of the proposal failed: %s", err)
+		return
+	}
+	heightAfter, err := e.s.GetLedgerHeight(txParams.ChannelID)
+	if err != nil || heightAfter != heightBefore {
+		logger.Debugf("Skipping the determinism check, the ledger changed between the two executions of the proposal")
+		return
+	}
+
+	mismatches, err := resultMismatches(first, second)
+	if err != nil {
+		logger.Warningf("Skipping the determinism check, the results of the proposal cannot be compared: %s", err)
+		return
+	}
+	if len(mismatches) == 0 {
+		logger.Debugf("The two executions of the proposal produced the same results")
+		return
+	}
+	logger.Warnw("Chaincode is not deterministic, two executions of the same proposal produced different results", "mismatches", mismatches)
+}
+
+// reexecute executes the proposal with a new simulator, which is released
+// without the private data being distributed
+func (e *Endorser) reexecute(txParams *ccprovider.TransactionParams, cid *pb.ChaincodeID, version string) (*executionResult, error) {
+	cis, err := putils.GetChaincodeInvocationSpec(txParams.Proposal)
+	if err != nil {
+		return nil, err
+	}
+	txsim, err := e.s.GetTxSimulator(txParams.ChannelID, txParams.TxID)
+	if err != nil {
+		return nil, err
+	}
+	defer txsim.Done()
+
+	params := *txParams
+	params.TXSimulator = txsim
+	params.ProposalDecorations = nil
+	res, event, err := e.s.Execute(&params, params.ChannelID, cid.Name, version, params.TxID, params.SignedProp, params.Proposal, cis.ChaincodeSpec.Input)
+	if err != nil {
+		return nil, err
+	}
+	simResult, err := txsim.GetTxSimulationResults()
+	if err != nil {
+		return nil, err
+	}
+	txsim.Done()
+	simRes, err := simResult.GetPubSimulationBytes()
+	if err != nil {
+		return nil, err
+	}
+	return &executionResult{response: res, simRes: simRes, event: event}, nil
+}
+
+// resultMismatches describes the differences between the results of two
+// executions of a proposal. The private data is compared through its hashes,
+// held by the public read-write set.
+func resultMismatches(first, second *executionResult) ([]string, error) {
+	var mismatches []string
+	if !proto.Equal(first.response, second.response) {
+		mismatches = append(mismatches, fmt.Sprintf("response (status %d, %d payload bytes) differs from response (status %d, %d payload bytes)",
+			first.response.GetStatus(), len(first.response.GetPayload()), second.response.GetStatus(), len(second.response.GetPayload())))
+	}
+	if !proto.Equal(first.event, second.event) {
+		mismatches = append(mismatches, "chaincode events differ")
+	}
+	if bytes.Equal(first.simRes, second.simRes) {
+		return mismatches, nil
+	}
+
+	firstRwSet, secondRwSet := &rwsetutil.TxRwSet{}, &rwsetutil.TxRwSet{}
+	if err := firstRwSet.FromProtoBytes(first.simRes); err != nil {
+		return nil, errors.WithMessage(err, "invalid read-write set")
+	}
+	if err := secondRwSet.FromProtoBytes(second.simRes); err != nil {
+		return nil, errors.WithMessage(err, "invalid read-write set")
+	}
+	rwSetMismatches := nsRwSetMismatches(firstRwSet, secondRwSet)
+	if len(rwSetMismatches) == 0 {
+		// the read-write sets only differ in their encoding
+		rwSetMismatches = []string{"read-write sets differ"}
+	}
+	return append(mismatches, rwSetMismatches...), nil
+}
+
+func nsRwSetMismatches(first, second *rwsetutil.TxRwSet) []string {
+	firstNsRwSets, firstNames := nsRwSetsByName(first)
+	secondNsRwSets, secondNames := nsRwSetsByName(second)
+	var mismatches []string
+	for _, ns := range sortedUnion(firstNames, secondNames) {
+		firstNs, secondNs := firstNsRwSets[ns], secondNsRwSets[ns]
+		if firstNs == nil || secondNs == nil {
+			mismatches = append(mismatches, fmt.Sprintf("namespace [%s] is only accessed by one of the executions", ns))
+			continue
+		}
+		firstKv, secondKv := firstNs.KvRwSet, secondNs.KvRwSet
+		if keys := differingKeys(readsByKey(firstKv), readsByKey(secondKv)); len(keys) > 0 {
+			mismatches = append(mismatches, fmt.Sprintf("namespace [%s]: reads of keys %v differ", ns, keys))
+		}
+		if keys := differingKeys(writesByKey(firstKv), writesByKey(secondKv)); len(keys) > 0 {
+			mismatches = append(mismatches, fmt.Sprintf("namespace [%s]: writes of keys %v differ", ns, keys))
+		}
+		if !proto.Equal(&kvrwset.KVRWSet{RangeQueriesInfo: firstKv.GetRangeQueriesInfo()}, &kvrwset.KVRWSet{RangeQueriesInfo: secondKv.GetRangeQueriesInfo()}) {
+			mismatches = append(mismatches, fmt.Sprintf("namespace [%s]: range queries differ", ns))
+		}
+		if !proto.Equal(&kvrwset.KVRWSet{MetadataWrites: firstKv.GetMetadataWrites()}, &kvrwset.KVRWSet{MetadataWrites: secondKv.GetMetadataWrites()}) {
+			mismatches = append(mismatches, fmt.Sprintf("namespace [%s]: metadata writes differ", ns))
+		}
+
+		firstColls, firstCollNames := collHashedRwSetsByName(firstNs)
+		secondColls, secondCollNames := collHashedRwSetsByName(secondNs)
+		for _, coll := range sortedUnion(firstCollNames, secondCollNames) {
+			firstColl, secondColl := firstColls[coll], secondColls[coll]
+			if firstColl == nil || secondColl == nil ||
+				!bytes.Equal(firstColl.PvtRwSetHash, secondColl.PvtRwSetHash) ||
+				!proto.Equal(firstColl.HashedRwSet, secondColl.HashedRwSet) {
+				mismatches = append(mismatches, fmt.Sprintf("namespace [%s]: private data of collection [%s] differs", ns, coll))
+			}
+		}
+	}
+	return mismatches
+}
+
+func nsRwSetsByName(txRwSet *rwsetutil.TxRwSet) (map[string]*rwsetutil.NsRwSet, []string) {
+	m := make(map[string]*rwsetutil.NsRwSet)
+	var names []string
+	for _, nsRwSet := range txRwSet.NsRwSets {
+		m[nsRwSet.NameSpace] = nsRwSet
+		names = append(names, nsRwSet.NameSpace)
+	}
+	return m, names
+}
+
+func collHashedRwSetsByName(nsRwSet *rwsetutil.NsRwSet) (map[string]*rwsetutil.CollHashedRwSet, []string) {
+	m := make(map[string]*rwsetutil.CollHashedRwSet)
+	var names []string
+	for _, collHashedRwSet := range nsRwSet.CollHashedRwSets {
+		m[collHashedRwSet.CollectionName] = collHashedRwSet
+		names = append(names, collHashedRwSet.CollectionName)
+	}
+	return m, names
+}
+
+func readsByKey(kvRwSet *kvrwset.KVRWSet) map[string]proto.Message {
+	m := make(map[string]proto.Message)
+	for _, read := range kvRwSet.GetReads() {
+		m[read.Key] = read
+	}
+	return m
+}
+
+func writesByKey(kvRwSet *kvrwset.KVRWSet) map[string]proto.Message {
+	m := make(map[string]proto.Message)
+	for _, write := range kvRwSet.GetWrites() {
+		m[write.Key] = write
+	}
+	return m
+}
+
+// differingKeys returns, in order, the keys of the entries which are either
+// not in both maps or not equal in both
+func differingKeys(first, second map[string]proto.Message) []string {
+	var keys []string
+	for key, firstMsg := range first {
+		if secondMsg, ok := second[key]; !ok || !proto.Equal(firstMsg, secondMsg) {
+			keys = append(keys, key)
+		}
+	}
+	for key := range second {
+		if _, ok := first[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedUnion returns, in order and without duplicates, the names found in
+// either of the given lists
+func sortedUnion(first, second []string) []string {
+	seen := make(map[string]struct{})
+	var names []string
+	for _, name := range append(append([]string(nil), first...), second...) {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,142 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeterminismCheckCovers(t *testing.T) {
+	var dc *DeterminismCheck
+	assert.False(t, dc.covers("mycc"))
+
+	dc = &DeterminismCheck{}
+	assert.True(t, dc.covers("mycc"))
+
+	dc = &DeterminismCheck{Chaincodes: []string{"mycc", "yourcc"}}
+	assert.True(t, dc.covers("mycc"))
+	assert.True(t, dc.covers("yourcc"))
+	assert.False(t, dc.covers("theircc"))
+}
+
+func TestResultMismatches(t *testing.T) {
+	simRes := func(build func(b *rwsetutil.RWSetBuilder)) []byte {
+		b := rwsetutil.NewRWSetBuilder()
+		build(b)
+		res, err := b.GetTxSimulationResults()
+		assert.NoError(t, err)
+		bytes, err := res.GetPubSimulationBytes()
+		assert.NoError(t, err)
+		return bytes
+	}
+	baseline := func(b *rwsetutil.RWSetBuilder) {
+		b.AddToReadSet("mycc", "a", version.NewHeight(1, 1))
+		b.AddToWriteSet("mycc", "b", []byte("value"))
+		b.AddToPvtAndHashedWriteSet("mycc", "coll", "c", []byte("secret"))
+	}
+	result := func(build func(b *rwsetutil.RWSetBuilder)) *executionResult {
+		return &executionResult{
+			response: &pb.Response{Status: 200, Payload: []byte("payload")},
+			simRes:   simRes(build),
+			event:    &pb.ChaincodeEvent{ChaincodeId: "mycc", EventName: "event"},
+		}
+	}
+
+	tests := []struct {
+		name               string
+		second             *executionResult
+		expectedMismatches []string
+	}{
+		{
+			name:   "SameResults",
+			second: result(baseline),
+		},
+		{
+			name: "DifferentResponse",
+			second: &executionResult{
+				response: &pb.Response{Status: 200, Payload: []byte("other payload")},
+				simRes:   simRes(baseline),
+				event:    &pb.ChaincodeEvent{ChaincodeId: "mycc", EventName: "event"},
+			},
+			expectedMismatches: []string{"response (status 200, 7 payload bytes) differs from response (status 200, 13 payload bytes)"},
+		},
+		{
+			name: "DifferentEvent",
+			second: &executionResult{
+				response: &pb.Response{Status: 200, Payload: []byte("payload")},
+				simRes:   simRes(baseline),
+				event:    &pb.ChaincodeEvent{ChaincodeId: "mycc", EventName: "event", Payload: []byte("now")},
+			},
+			expectedMismatches: []string{"chaincode events differ"},
+		},
+		{
+			name: "DifferentReadsAndWrites",
+			second: result(func(b *rwsetutil.RWSetBuilder) {
+				b.AddToReadSet("mycc", "a", version.NewHeight(1, 2))
+				b.AddToReadSet("mycc", "d", version.NewHeight(1, 1))
+				b.AddToWriteSet("mycc", "b", []byte("other value"))
+				b.AddToPvtAndHashedWriteSet("mycc", "coll", "c", []byte("secret"))
+			}),
+			expectedMismatches: []string{
+				"namespace [mycc]: reads of keys [a d] differ",
+				"namespace [mycc]: writes of keys [b] differ",
+			},
+		},
+		{
+			name: "DifferentRangeQueriesAndMetadata",
+			second: result(func(b *rwsetutil.RWSetBuilder) {
+				baseline(b)
+				b.AddToRangeQuerySet("mycc", &kvrwset.RangeQueryInfo{StartKey: "a", EndKey: "z", ItrExhausted: true})
+				b.AddToMetadataWriteSet("mycc", "b", map[string][]byte{"entry": []byte("metadata")})
+			}),
+			expectedMismatches: []string{
+				"namespace [mycc]: range queries differ",
+				"namespace [mycc]: metadata writes differ",
+			},
+		},
+		{
+			name: "DifferentPrivateData",
+			second: result(func(b *rwsetutil.RWSetBuilder) {
+				b.AddToReadSet("mycc", "a", version.NewHeight(1, 1))
+				b.AddToWriteSet("mycc", "b", []byte("value"))
+				b.AddToPvtAndHashedWriteSet("mycc", "coll", "c", []byte("other secret"))
+			}),
+			expectedMismatches: []string{"namespace [mycc]: private data of collection [coll] differs"},
+		},
+		{
+			name: "DifferentNamespaces",
+			second: result(func(b *rwsetutil.RWSetBuilder) {
+				baseline(b)
+				b.AddToWriteSet("yourcc", "b", []byte("value"))
+			}),
+			expectedMismatches: []string{"namespace [yourcc] is only accessed by one of the executions"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			mismatches, err := resultMismatches(result(baseline), tt.second)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedMismatches, mismatches)
+		})
+	}
+
+	t.Run("InvalidReadWriteSet", func(t *testing.T) {
+		second := result(baseline)
+		second.simRes = []byte("garbage")
+		_, err := resultMismatches(result(baseline), second)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid read-write set")
+	})
+}
@@ -14,7 +14,9 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
+	commonerrors "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
@@ -103,15 +105,23 @@ type Endorser struct {
 	s                     Support
 	PlatformRegistry      *platforms.Registry
 	PvtRWSetAssembler
+	// DeterminismCheck, if set, makes the endorser check that the chaincodes
+	// are deterministic
+	DeterminismCheck *DeterminismCheck
+	// AuditMode, if set, makes the endorser reject the proposals of the
+	// application chaincodes, as the peer only verifies the blocks of its
+	// channels. The system chaincodes remain available to administer the peer.
+	AuditMode bool
 }
 
 // validateResult provides the result of endorseProposal verification
 type validateResult struct {
-	prop    *pb.Proposal
-	hdrExt  *pb.ChaincodeHeaderExtension
-	chainID string
-	txid    string
-	resp    *pb.ProposalResponse
+	prop        *pb.Proposal
+	hdrExt      *pb.ChaincodeHeaderExtension
+	chainID     string
+	txid        string
+	spanContext tracing.SpanContext
+	resp        *pb.ProposalResponse
 }
 
 // NewEndorserServer creates and returns a new Endorser server instance.
@@ -216,6 +226,9 @@ func (e *Endorser) SimulateProposal(txParams *ccprovider.TransactionParams, cid
 
 	if !e.s.IsSysCC(cid.Name) {
 		cdLedger, err = e.s.GetChaincodeDefinition(cid.Name, txParams.TXSimulator)
+		if _, retired := errors.Cause(err).(ccprovider.ChaincodeRetiredErr); retired {
+			return nil, nil, nil, nil, err
+		}
 		if err != nil {
 			return nil, nil, nil, nil, errors.WithMessage(err, fmt.Sprintf("make sure the chaincode %s has been successfully instantiated and try again", cid.Name))
 		}
@@ -342,19 +355,19 @@ func (e *Endorser) preProcess(signedProp *pb.SignedProposal) (*validateResult, e
 
 	if err != nil {
 		vr.resp = &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}
-		return vr, err
+		return vr, commonerrors.WithReason(err, common.ErrorReason_MALFORMED_REQUEST, "")
 	}
 
 	chdr, err := putils.UnmarshalChannelHeader(hdr.ChannelHeader)
 	if err != nil {
 		vr.resp = &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}
-		return vr, err
+		return vr, commonerrors.WithReason(err, common.ErrorReason_MALFORMED_REQUEST, "")
 	}
 
 	shdr, err := putils.GetSignatureHeader(hdr.SignatureHeader)
 	if err != nil {
 		vr.resp = &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}
-		return vr, err
+		return vr, commonerrors.WithReason(err, common.ErrorReason_MALFORMED_REQUEST, "")
 	}
 
 	// block invocations to security-sensitive system chaincodes
@@ -362,7 +375,7 @@ func (e *Endorser) preProcess(signedProp *pb.SignedProposal) (*validateResult, e
 		endorserLogger.Errorf("Error: an attempt was made by %#v to invoke system chaincode %s", shdr.Creator, hdrExt.ChaincodeId.Name)
 		err = errors.Errorf("chaincode %s cannot be invoked through a proposal", hdrExt.ChaincodeId.Name)
 		vr.resp = &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}
-		return vr, err
+		return vr, commonerrors.WithReason(err, common.ErrorReason_MALFORMED_REQUEST, "")
 	}
 
 	chainID := chdr.ChannelId
@@ -375,7 +388,7 @@ func (e *Endorser) preProcess(signedProp *pb.SignedProposal) (*validateResult, e
 		if _, err = e.s.GetTransactionByID(chainID, txid); err == nil {
 			err = errors.Errorf("duplicate transaction found [%s]. Creator [%x]", txid, shdr.Creator)
 			vr.resp = &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}
-			return vr, err
+			return vr, commonerrors.WithReason(err, common.ErrorReason_MALFORMED_REQUEST, chainID)
 		}
 
 		// check ACL only for application chaincodes; ACLs
@@ -384,7 +397,15 @@ func (e *Endorser) preProcess(signedProp *pb.SignedProposal) (*validateResult, e
 			// check that the proposal complies with the Channel's writers
 			if err = e.s.CheckACL(signedProp, chdr, shdr, hdrExt); err != nil {
 				vr.resp = &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}
-				return vr, err
+				return vr, commonerrors.WithReason(err, common.ErrorReason_POLICY_FAILURE, chainID)
+			}
+
+			// the transactions of application chaincodes would be rejected by
+			// the orderers while the channel is in maintenance
+			if ac, ok := e.s.GetApplicationConfig(chainID); ok && ac.ChannelState() == pb.ChannelState_MAINTENANCE {
+				err = errors.Errorf("channel [%s] is in maintenance mode, only config transactions are accepted", chainID)
+				vr.resp = &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}
+				return vr, commonerrors.WithReason(err, common.ErrorReason_BAD_CHANNEL, chainID)
 			}
 		}
 	} else {
@@ -395,11 +416,13 @@ func (e *Endorser) preProcess(signedProp *pb.SignedProposal) (*validateResult, e
 	}
 
 	vr.prop, vr.hdrExt, vr.chainID, vr.txid = prop, hdrExt, chainID, txid
+	vr.spanContext = tracing.TxSpanContext(chdr)
 	return vr, nil
 }
 
 // ProcessProposal process the Proposal
 func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+	startTime := time.Now()
 	addr := util.ExtractRemoteAddress(ctx)
 	endorserLogger.Debug("Entering: request from", addr)
 	defer endorserLogger.Debug("Exit: request from", addr)
@@ -413,6 +436,20 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 
 	prop, hdrExt, chainID, txid := vr.prop, vr.hdrExt, vr.chainID, vr.txid
 
+	if e.AuditMode && !e.s.IsSysCC(hdrExt.ChaincodeId.Name) {
+		err := errors.Errorf("the peer is in audit mode and does not endorse proposals for chaincode %s", hdrExt.ChaincodeId.Name)
+		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+	}
+
+	// the trace context of the transaction is only known once the proposal
+	// is validated, hence the span is started retroactively
+	span := tracing.StartSpanAt("endorser.ProcessProposal", vr.spanContext, startTime)
+	span.SetAttribute("channel", chainID)
+	span.SetAttribute("txid", txid)
+	span.SetAttribute("chaincode", hdrExt.ChaincodeId.Name)
+	defer span.End()
+	ctx = tracing.ContextWithSpan(ctx, span)
+
 	// obtaining once the tx simulator for this proposal. This will be nil
 	// for chainless proposals
 	// Also obtain a history query executor for history queries, since tx simulator does not cover history
@@ -420,7 +457,7 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	var historyQueryExecutor ledger.HistoryQueryExecutor
 	if acquireTxSimulator(chainID, vr.hdrExt.ChaincodeId) {
 		if txsim, err = e.s.GetTxSimulator(chainID, txid); err != nil {
-			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
+			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, commonerrors.WithReason(err, common.ErrorReason_LEDGER_UNAVAILABLE, chainID)
 		}
 
 		// txsim acquires a shared lock on the stateDB. As this would impact the block commits (i.e., commit
@@ -433,7 +470,7 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 		defer txsim.Done()
 
 		if historyQueryExecutor, err = e.s.GetHistoryQueryExecutor(chainID); err != nil {
-			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
+			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, commonerrors.WithReason(err, common.ErrorReason_LEDGER_UNAVAILABLE, chainID)
 		}
 	}
 
@@ -452,10 +489,18 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	//       we're trying to emulate a submitting peer. On the other hand, we need
 	//       to validate the supplied action before endorsing it
 
+	heightBeforeSimulation, checkDeterminism := e.determinismCheckHeight(chainID, hdrExt.ChaincodeId.Name)
+
 	// 1 -- simulate
+	simulateSpan := tracing.StartSpan("endorser.SimulateProposal", span.Context())
 	cd, res, simulationResult, ccevent, err := e.SimulateProposal(txParams, hdrExt.ChaincodeId)
+	simulateSpan.End()
 	if err != nil {
-		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
+		span.SetError(err.Error())
+		return &pb.ProposalResponse{
+			Response:             &pb.Response{Status: 500, Message: err.Error()},
+			ChaincodeDiagnostics: chaincodeDiagnostics(err),
+		}, nil
 	}
 	if res != nil {
 		if res.Status >= shim.ERROR {
@@ -471,11 +516,16 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 			if err != nil {
 				return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
 			}
+			pResp.SimulationHints = simulationHints(simulationResult, ccevent)
 
 			return pResp, nil
 		}
 	}
 
+	if checkDeterminism {
+		e.checkDeterminism(txParams, hdrExt.ChaincodeId, cd.CCVersion(), heightBeforeSimulation, &executionResult{response: res, simRes: simulationResult, event: ccevent})
+	}
+
 	// 2 -- endorse and get a marshalled ProposalResponse message
 	var pResp *pb.ProposalResponse
 
@@ -485,8 +535,11 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 		pResp = &pb.ProposalResponse{Response: res}
 	} else {
 		//Note: To endorseProposal(), we pass the released txsim. Hence, an error would occur if we try to use this txsim
-		pResp, err = e.endorseProposal(ctx, chainID, txid, signedProp, prop, res, simulationResult, ccevent, hdrExt.PayloadVisibility, hdrExt.ChaincodeId, txsim, cd)
+		endorseSpan, endorseCtx := tracing.StartSpanFromContext(ctx, "endorser.EndorseProposal")
+		pResp, err = e.endorseProposal(endorseCtx, chainID, txid, signedProp, prop, res, simulationResult, ccevent, hdrExt.PayloadVisibility, hdrExt.ChaincodeId, txsim, cd)
+		endorseSpan.End()
 		if err != nil {
+			span.SetError(err.Error())
 			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
 		}
 		if pResp.Response.Status >= shim.ERRORTHRESHOLD {
@@ -499,10 +552,40 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	// contains the "return value" from the
 	// chaincode invocation
 	pResp.Response = res
+	if chainID != "" {
+		pResp.SimulationHints = simulationHints(simulationResult, ccevent)
+	}
 
 	return pResp, nil
 }
 
+// simulationHints describes the results of the simulation of a proposal to
+// the client, which may then discard the transaction before submitting it
+func simulationHints(simRes []byte, event *pb.ChaincodeEvent) *pb.SimulationHints {
+	return &pb.SimulationHints{
+		Event:            event,
+		ReadWriteSetSize: uint64(len(simRes)),
+	}
+}
+
+// chaincodeDiagnostics returns the diagnostics of the container of the
+// chaincode carried by the error of its execution, if the peer collects them
+func chaincodeDiagnostics(err error) *pb.ChaincodeDiagnostics {
+	for err != nil {
+		if d, ok := err.(interface {
+			ChaincodeDiagnostics() *pb.ChaincodeDiagnostics
+		}); ok {
+			return d.ChaincodeDiagnostics()
+		}
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			return nil
+		}
+		err = cause.Cause()
+	}
+	return nil
+}
+
 // determine whether or not a transaction simulator should be
 // obtained for a proposal.
 func acquireTxSimulator(chainID string, ccid *pb.ChaincodeID) bool {
@@ -15,10 +15,12 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/golang/protobuf/proto"
+	commonerrors "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/common/flogging"
 	mc "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/common/mocks/resourcesconfig"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
@@ -26,6 +28,7 @@ import (
 	"github.com/hyperledger/fabric/core/endorser/mocks"
 	"github.com/hyperledger/fabric/core/handlers/endorsement/builtin"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	mockccprovider "github.com/hyperledger/fabric/core/mocks/ccprovider"
 	em "github.com/hyperledger/fabric/core/mocks/endorser"
 	"github.com/hyperledger/fabric/msp"
@@ -136,6 +139,7 @@ func TestEndorserCCInvocationFailed(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, 1000, pResp.Response.Status)
 	assert.Regexp(t, "Chaincode Error", pResp.Response.Message)
+	assert.NotNil(t, pResp.SimulationHints)
 }
 
 func TestEndorserNoCCDef(t *testing.T) {
@@ -160,6 +164,28 @@ func TestEndorserNoCCDef(t *testing.T) {
 	assert.Regexp(t, "make sure the chaincode", pResp.Response.Message)
 }
 
+func TestEndorserRetiredCC(t *testing.T) {
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, &em.MockSupport{
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		GetTransactionByIDErr:      errors.New(""),
+		ChaincodeDefinitionError:   ccprovider.ChaincodeRetiredErr("ccid"),
+		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
+		GetTxSimulatorRv: &mockccprovider.MockTxSim{
+			GetTxSimulationResultsRv: &ledger.TxSimulationResults{
+				PubSimulationResults: &rwset.TxReadWriteSet{},
+			},
+		},
+	}, platforms.NewRegistry(&golang.Platform{}))
+
+	signedProp := getSignedProp("ccid", "0", t)
+
+	pResp, err := es.ProcessProposal(context.Background(), signedProp)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 500, pResp.Response.Status)
+	assert.Equal(t, "chaincode ccid has been retired", pResp.Response.Message)
+}
+
 func TestEndorserBadInstPolicy(t *testing.T) {
 	es := endorser.NewEndorserServer(pvtEmptyDistributor, &em.MockSupport{
 		GetApplicationConfigBoolRv:    true,
@@ -206,6 +232,35 @@ func TestEndorserSysCC(t *testing.T) {
 	assert.EqualValues(t, 200, pResp.Response.Status)
 }
 
+func TestEndorserAuditMode(t *testing.T) {
+	m := &mock.Mock{}
+	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+	m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+	m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(newMockTxSim(), nil)
+	support := &em.MockSupport{
+		Mock:                       m,
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		GetTransactionByIDErr:      errors.New(""),
+		ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Escc: "ESCC"},
+		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
+	}
+	attachPluginEndorser(support)
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, support, platforms.NewRegistry(&golang.Platform{}))
+	es.AuditMode = true
+
+	// the proposals of the application chaincodes are rejected
+	pResp, err := es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.EqualError(t, err, "the peer is in audit mode and does not endorse proposals for chaincode ccid")
+	assert.EqualValues(t, 500, pResp.Response.Status)
+
+	// the system chaincodes remain available
+	support.IsSysCCRv = true
+	pResp, err = es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
+}
+
 func TestEndorserCCInvocationError(t *testing.T) {
 	es := endorser.NewEndorserServer(pvtEmptyDistributor, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
@@ -225,6 +280,34 @@ func TestEndorserCCInvocationError(t *testing.T) {
 	pResp, err := es.ProcessProposal(context.Background(), signedProp)
 	assert.NoError(t, err)
 	assert.EqualValues(t, 500, pResp.Response.Status)
+	assert.Nil(t, pResp.ChaincodeDiagnostics)
+}
+
+func TestEndorserCCInvocationErrorDiagnostics(t *testing.T) {
+	diagnostics := &pb.ChaincodeDiagnostics{Output: []string{"panic: boom"}, Panic: "panic: boom", Exited: true, ExitCode: 2}
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, &em.MockSupport{
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		GetTransactionByIDErr:      errors.New(""),
+		ExecuteError: errors.WithMessage(&chaincode.ExecutionError{
+			Err:         errors.New("timeout expired while executing transaction"),
+			Diagnostics: diagnostics,
+		}, "failed to execute transaction"),
+		ChaincodeDefinitionRv: &ccprovider.ChaincodeData{Escc: "ESCC"},
+		GetTxSimulatorRv: &mockccprovider.MockTxSim{
+			GetTxSimulationResultsRv: &ledger.TxSimulationResults{
+				PubSimulationResults: &rwset.TxReadWriteSet{},
+			},
+		},
+	}, platforms.NewRegistry(&golang.Platform{}))
+
+	signedProp := getSignedProp("ccid", "0", t)
+
+	pResp, err := es.ProcessProposal(context.Background(), signedProp)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 500, pResp.Response.Status)
+	assert.Contains(t, pResp.Response.Message, "timeout expired while executing transaction")
+	assert.Equal(t, diagnostics, pResp.ChaincodeDiagnostics)
 }
 
 func TestEndorserLSCCBadType(t *testing.T) {
@@ -276,6 +359,7 @@ func TestEndorserDupTXId(t *testing.T) {
 	assert.Error(t, err)
 	assert.EqualValues(t, 500, pResp.Response.Status)
 	assert.Regexp(t, "duplicate transaction found", pResp.Response.Message)
+	assert.Equal(t, common.ErrorReason_MALFORMED_REQUEST, commonerrors.ReasonOf(err))
 }
 
 func TestEndorserBadACL(t *testing.T) {
@@ -298,6 +382,41 @@ func TestEndorserBadACL(t *testing.T) {
 	pResp, err := es.ProcessProposal(context.Background(), signedProp)
 	assert.Error(t, err)
 	assert.EqualValues(t, 500, pResp.Response.Status)
+	assert.Equal(t, common.ErrorReason_POLICY_FAILURE, commonerrors.ReasonOf(err))
+}
+
+func TestEndorserChannelInMaintenance(t *testing.T) {
+	m := &mock.Mock{}
+	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+	m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+	m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(newMockTxSim(), nil)
+	support := &em.MockSupport{
+		Mock:                       m,
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv: &mc.MockApplication{
+			CapabilitiesRv: &mc.MockApplicationCapabilities{},
+			ChannelStateRv: pb.ChannelState_MAINTENANCE,
+		},
+		GetTransactionByIDErr: errors.New(""),
+		ChaincodeDefinitionRv: &ccprovider.ChaincodeData{Escc: "ESCC"},
+		ExecuteResp:           &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
+	}
+	attachPluginEndorser(support)
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, support, platforms.NewRegistry(&golang.Platform{}))
+
+	signedProp := getSignedProp("ccid", "0", t)
+
+	pResp, err := es.ProcessProposal(context.Background(), signedProp)
+	assert.Error(t, err)
+	assert.EqualValues(t, 500, pResp.Response.Status)
+	assert.Equal(t, "channel [testchainid] is in maintenance mode, only config transactions are accepted", pResp.Response.Message)
+	assert.Equal(t, &common.ErrorDetail{Reason: common.ErrorReason_BAD_CHANNEL, ChannelId: "testchainid"}, commonerrors.DetailOf(err))
+
+	// system chaincodes remain available
+	support.IsSysCCRv = true
+	pResp, err = es.ProcessProposal(context.Background(), signedProp)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
 }
 
 func TestEndorserGoodPathEmptyChannel(t *testing.T) {
@@ -386,10 +505,13 @@ func TestEndorserLSCCDeploySysCC(t *testing.T) {
 }
 
 func TestEndorserGoodPathWEvents(t *testing.T) {
+	pubSimRes := &rwset.TxReadWriteSet{NsRwset: []*rwset.NsReadWriteSet{{Namespace: "ccid", Rwset: []byte("rwset")}}}
 	m := &mock.Mock{}
 	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
 	m.On("Serialize").Return([]byte{1, 1, 1}, nil)
-	m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(newMockTxSim(), nil)
+	m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(&mockccprovider.MockTxSim{
+		GetTxSimulationResultsRv: &ledger.TxSimulationResults{PubSimulationResults: pubSimRes},
+	}, nil)
 	support := &em.MockSupport{
 		Mock: m,
 		GetApplicationConfigBoolRv: true,
@@ -397,7 +519,7 @@ func TestEndorserGoodPathWEvents(t *testing.T) {
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Escc: "ESCC"},
 		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
-		ExecuteEvent:               &pb.ChaincodeEvent{},
+		ExecuteEvent:               &pb.ChaincodeEvent{ChaincodeId: "ccid", EventName: "event"},
 	}
 	attachPluginEndorser(support)
 	es := endorser.NewEndorserServer(pvtEmptyDistributor, support, platforms.NewRegistry(&golang.Platform{}))
@@ -407,6 +529,8 @@ func TestEndorserGoodPathWEvents(t *testing.T) {
 	pResp, err := es.ProcessProposal(context.Background(), signedProp)
 	assert.NoError(t, err)
 	assert.EqualValues(t, 200, pResp.Response.Status)
+	assert.Equal(t, "event", pResp.SimulationHints.Event.EventName)
+	assert.EqualValues(t, len(utils.MarshalOrPanic(pubSimRes)), pResp.SimulationHints.ReadWriteSetSize)
 }
 
 func TestEndorserBadChannel(t *testing.T) {
@@ -429,6 +553,7 @@ func TestEndorserBadChannel(t *testing.T) {
 	assert.Error(t, err)
 	assert.EqualValues(t, 500, pResp.Response.Status)
 	assert.Equal(t, "access denied: channel [barfchain] creator org [SampleOrg]", pResp.Response.Message)
+	assert.Equal(t, common.ErrorReason_POLICY_FAILURE, commonerrors.ReasonOf(err))
 }
 
 func TestEndorserGoodPath(t *testing.T) {
@@ -482,6 +607,80 @@ func TestEndorserChaincodeCallLogging(t *testing.T) {
 	gt.Eventually(buf).Should(gbytes.Say(`INFO.*\[testchainid\]\[[[:xdigit:]]{8}\] Exit chaincode: name:"chaincode-name" version:"chaincode-version"  (.*ms)`))
 }
 
+func TestEndorserDeterminismCheck(t *testing.T) {
+	txSimWithWrite := func(value string) *mockccprovider.MockTxSim {
+		b := rwsetutil.NewRWSetBuilder()
+		b.AddToWriteSet("ccid", "key", []byte(value))
+		simRes, err := b.GetTxSimulationResults()
+		assert.NoError(t, err)
+		return &mockccprovider.MockTxSim{GetTxSimulationResultsRv: simRes}
+	}
+
+	tests := []struct {
+		name            string
+		secondTxSim     *mockccprovider.MockTxSim
+		heightAfter     uint64
+		expectedWarning bool
+	}{
+		{
+			name:        "Deterministic",
+			secondTxSim: txSimWithWrite("value"),
+			heightAfter: 5,
+		},
+		{
+			name:            "NotDeterministic",
+			secondTxSim:     txSimWithWrite("other value"),
+			heightAfter:     5,
+			expectedWarning: true,
+		},
+		{
+			name:        "LedgerChanged",
+			secondTxSim: txSimWithWrite("other value"),
+			heightAfter: 6,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			gt := NewGomegaWithT(t)
+			m := &mock.Mock{}
+			m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+			m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+			m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(txSimWithWrite("value"), nil).Once()
+			m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(tt.secondTxSim, nil).Once()
+			m.On("GetLedgerHeight", "testchainid").Return(uint64(5), nil).Once()
+			m.On("GetLedgerHeight", "testchainid").Return(tt.heightAfter, nil).Once()
+			support := &em.MockSupport{
+				Mock: m,
+				GetApplicationConfigBoolRv: true,
+				GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+				GetTransactionByIDErr:      errors.New(""),
+				ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Escc: "ESCC"},
+				ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
+			}
+			attachPluginEndorser(support)
+			es := endorser.NewEndorserServer(pvtEmptyDistributor, support, platforms.NewRegistry(&golang.Platform{}))
+			es.DeterminismCheck = &endorser.DeterminismCheck{Chaincodes: []string{"ccid"}}
+
+			buf := gbytes.NewBuffer()
+			flogging.Global.SetWriter(buf)
+			defer flogging.Global.SetWriter(os.Stderr)
+
+			pResp, err := es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+			assert.NoError(t, err)
+			assert.EqualValues(t, 200, pResp.Response.Status)
+			m.AssertNumberOfCalls(t, "GetTxSimulator", 2)
+
+			if tt.expectedWarning {
+				gt.Expect(buf).To(gbytes.Say(`WARN.*Chaincode is not deterministic, two executions of the same proposal produced different results.*"chaincode": "ccid".*"mismatches": \["namespace \[ccid\]: writes of keys \[key\] differ"\]`))
+			} else {
+				gt.Expect(buf).NotTo(gbytes.Say(`Chaincode is not deterministic`))
+			}
+		})
+	}
+}
+
 func TestEndorserLSCC(t *testing.T) {
 	m := &mock.Mock{}
 	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
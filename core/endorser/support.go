@@ -11,6 +11,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
+	commonerrors "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/chaincode"
@@ -23,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/core/scc"
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
 
@@ -36,6 +38,11 @@ type SupportImpl struct {
 	ChaincodeSupport *chaincode.ChaincodeSupport
 	SysCCProvider    *scc.Provider
 	ACLProvider      aclmgmt.ACLProvider
+
+	// ChannelSigningIdentity, when set, returns the identity the peer
+	// endorses with on the given channel, for peers hosting several
+	// local MSPs. Otherwise, the SignerSupport is used on every channel.
+	ChannelSigningIdentity func(channel string) (SigningIdentity, error)
 }
 
 func (s *SupportImpl) NewQueryCreator(channel string) (QueryCreator, error) {
@@ -46,8 +53,23 @@ func (s *SupportImpl) NewQueryCreator(channel string) (QueryCreator, error) {
 	return lgr, nil
 }
 
-func (s *SupportImpl) SigningIdentityForRequest(*pb.SignedProposal) (SigningIdentity, error) {
-	return s.SignerSupport, nil
+func (s *SupportImpl) SigningIdentityForRequest(signedProp *pb.SignedProposal) (SigningIdentity, error) {
+	if s.ChannelSigningIdentity == nil {
+		return s.SignerSupport, nil
+	}
+	prop, err := utils.GetProposal(signedProp.ProposalBytes)
+	if err != nil {
+		return nil, err
+	}
+	hdr, err := utils.GetHeader(prop.Header)
+	if err != nil {
+		return nil, err
+	}
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	return s.ChannelSigningIdentity(chdr.ChannelId)
 }
 
 // IsSysCCAndNotInvokableExternal returns true if the supplied chaincode is
@@ -62,7 +84,7 @@ func (s *SupportImpl) IsSysCCAndNotInvokableExternal(name string) bool {
 func (s *SupportImpl) GetTxSimulator(ledgername string, txid string) (ledger.TxSimulator, error) {
 	lgr := s.Peer.GetLedger(ledgername)
 	if lgr == nil {
-		return nil, errors.Errorf("Channel does not exist: %s", ledgername)
+		return nil, commonerrors.WithReason(errors.Errorf("Channel does not exist: %s", ledgername), common.ErrorReason_BAD_CHANNEL, ledgername)
 	}
 	return lgr.NewTxSimulator(txid)
 }
@@ -72,7 +94,7 @@ func (s *SupportImpl) GetTxSimulator(ledgername string, txid string) (ledger.TxS
 func (s *SupportImpl) GetHistoryQueryExecutor(ledgername string) (ledger.HistoryQueryExecutor, error) {
 	lgr := s.Peer.GetLedger(ledgername)
 	if lgr == nil {
-		return nil, errors.Errorf("Channel does not exist: %s", ledgername)
+		return nil, commonerrors.WithReason(errors.Errorf("Channel does not exist: %s", ledgername), common.ErrorReason_BAD_CHANNEL, ledgername)
 	}
 	return lgr.NewHistoryQueryExecutor()
 }
@@ -155,6 +177,9 @@ func (s *SupportImpl) GetChaincodeDefinition(chaincodeName string, txsim ledger.
 // CheckACL checks the ACL for the resource for the Channel using the
 // SignedProposal from which an id can be extracted for testing against a policy
 func (s *SupportImpl) CheckACL(signedProp *pb.SignedProposal, chdr *common.ChannelHeader, shdr *common.SignatureHeader, hdrext *pb.ChaincodeHeaderExtension) error {
+	if s.Peer.GetLedger(chdr.ChannelId) == nil {
+		return commonerrors.WithReason(errors.Errorf("channel %s not found", chdr.ChannelId), common.ErrorReason_BAD_CHANNEL, chdr.ChannelId)
+	}
 	return s.ACLProvider.CheckACL(resources.Peer_Propose, chdr.ChannelId, signedProp)
 }
 
@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/hyperledger/fabric/core/handlers/endorsement/api/identities"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type channelSigner struct {
+	channel string
+}
+
+func (s *channelSigner) Serialize() ([]byte, error) {
+	return []byte(s.channel), nil
+}
+
+func (s *channelSigner) Sign(msg []byte) ([]byte, error) {
+	return msg, nil
+}
+
+func signedProposalForChannel(channel string) *pb.SignedProposal {
+	hdr := &common.Header{
+		ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{ChannelId: channel}),
+	}
+	prop := &pb.Proposal{Header: utils.MarshalOrPanic(hdr)}
+	return &pb.SignedProposal{ProposalBytes: utils.MarshalOrPanic(prop)}
+}
+
+func TestSigningIdentityForRequest(t *testing.T) {
+	support := &SupportImpl{}
+	signer, err := support.SigningIdentityForRequest(signedProposalForChannel("mychannel"))
+	assert.NoError(t, err)
+	assert.Nil(t, signer)
+
+	support.ChannelSigningIdentity = func(channel string) (SigningIdentity, error) {
+		if channel == "" {
+			return nil, errors.New("no channel")
+		}
+		return &channelSigner{channel: channel}, nil
+	}
+	signer, err = support.SigningIdentityForRequest(signedProposalForChannel("mychannel"))
+	assert.NoError(t, err)
+	assert.Equal(t, &channelSigner{channel: "mychannel"}, signer)
+
+	_, err = support.SigningIdentityForRequest(signedProposalForChannel(""))
+	assert.EqualError(t, err, "no channel")
+
+	_, err = support.SigningIdentityForRequest(&pb.SignedProposal{ProposalBytes: []byte("garbage")})
+	assert.Error(t, err)
+}
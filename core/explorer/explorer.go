@@ -0,0 +1,176 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package explorer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+var logger = flogging.MustGetLogger("explorer")
+
+// Ledger is the part of the ledger of a channel read by the explorer
+type Ledger interface {
+	// GetBlockchainInfo returns basic info about the blockchain
+	GetBlockchainInfo() (*common.BlockchainInfo, error)
+	// GetBlockByNumber returns the block at the given height
+	GetBlockByNumber(blockNumber uint64) (*common.Block, error)
+	// GetTransactionByID returns the transaction with the given id
+	GetTransactionByID(txID string) (*peer.ProcessedTransaction, error)
+}
+
+// Support provides the channels joined by the peer and their ledgers
+type Support interface {
+	// Channels returns the ids of the channels joined by the peer
+	Channels() []string
+	// Ledger returns the ledger of the given channel, or nil if the peer
+	// has not joined it
+	Ledger(channelID string) Ledger
+}
+
+// ChannelInfo is the summary of a channel returned by the /channels endpoint
+type ChannelInfo struct {
+	ChannelID        string `json:"channel_id"`
+	Height           uint64 `json:"height"`
+	CurrentBlockHash string `json:"current_block_hash"`
+}
+
+// NewHandler returns the handler of the read-only endpoints of the explorer,
+// which decode the blocks and transactions of the ledgers to JSON
+func NewHandler(support Support) http.Handler {
+	e := &explorer{support: support}
+	router := mux.NewRouter().StrictSlash(true)
+	router.
+		HandleFunc("/channels", e.listChannels).
+		Methods("GET")
+	router.
+		HandleFunc("/channels/{channel}/blocks/{number}", e.getBlock).
+		Methods("GET")
+	router.
+		HandleFunc("/channels/{channel}/transactions/{txid}", e.getTransaction).
+		Methods("GET")
+	return router
+}
+
+type explorer struct {
+	support Support
+}
+
+func (e *explorer) listChannels(w http.ResponseWriter, r *http.Request) {
+	channels := []ChannelInfo{}
+	for _, channelID := range e.support.Channels() {
+		l := e.support.Ledger(channelID)
+		if l == nil {
+			continue
+		}
+		info, err := l.GetBlockchainInfo()
+		if err != nil {
+			logger.Warningf("Failed to get the blockchain info of channel %s: %s", channelID, err)
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		channels = append(channels, ChannelInfo{
+			ChannelID:        channelID,
+			Height:           info.Height,
+			CurrentBlockHash: hex.EncodeToString(info.CurrentBlockHash),
+		})
+	}
+
+	buffer, err := json.Marshal(channels)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, bytes.NewBuffer(buffer))
+}
+
+func (e *explorer) getBlock(w http.ResponseWriter, r *http.Request) {
+	l, ok := e.ledger(w, r)
+	if !ok {
+		return
+	}
+	number, err := strconv.ParseUint(mux.Vars(r)["number"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid block number %s", mux.Vars(r)["number"]))
+		return
+	}
+	info, err := l.GetBlockchainInfo()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if number >= info.Height {
+		writeError(w, http.StatusNotFound, fmt.Errorf("block %d not found, the height of the channel is %d", number, info.Height))
+		return
+	}
+	block, err := l.GetBlockByNumber(number)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeProto(w, block)
+}
+
+func (e *explorer) getTransaction(w http.ResponseWriter, r *http.Request) {
+	l, ok := e.ledger(w, r)
+	if !ok {
+		return
+	}
+	txID := mux.Vars(r)["txid"]
+	tx, err := l.GetTransactionByID(txID)
+	if err != nil {
+		// the ledger does not tell a missing transaction from a failure to
+		// read it, which is logged
+		logger.Debugf("Failed to get transaction %s: %s", txID, err)
+		writeError(w, http.StatusNotFound, fmt.Errorf("transaction %s not found", txID))
+		return
+	}
+	writeProto(w, tx)
+}
+
+// ledger returns the ledger of the channel of the request, or writes an
+// error response if the peer has not joined it
+func (e *explorer) ledger(w http.ResponseWriter, r *http.Request) (Ledger, bool) {
+	channelID := mux.Vars(r)["channel"]
+	l := e.support.Ledger(channelID)
+	if l == nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("channel %s not found", channelID))
+		return nil, false
+	}
+	return l, true
+}
+
+func writeProto(w http.ResponseWriter, msg proto.Message) {
+	var buffer bytes.Buffer
+	if err := protolator.DeepMarshalJSON(&buffer, msg); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, &buffer)
+}
+
+func writeJSON(w http.ResponseWriter, buffer *bytes.Buffer) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	buffer.WriteTo(w)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+	fmt.Fprintln(w, err)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package explorer
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testLedger struct {
+	blocks []*common.Block
+	txs    map[string]*peer.ProcessedTransaction
+}
+
+func (l *testLedger) GetBlockchainInfo() (*common.BlockchainInfo, error) {
+	return &common.BlockchainInfo{Height: uint64(len(l.blocks)), CurrentBlockHash: []byte{0xab}}, nil
+}
+
+func (l *testLedger) GetBlockByNumber(blockNumber uint64) (*common.Block, error) {
+	return l.blocks[blockNumber], nil
+}
+
+func (l *testLedger) GetTransactionByID(txID string) (*peer.ProcessedTransaction, error) {
+	tx, exists := l.txs[txID]
+	if !exists {
+		return nil, errors.New("entry not found in index")
+	}
+	return tx, nil
+}
+
+type testSupport map[string]*testLedger
+
+func (s testSupport) Channels() []string {
+	var channels []string
+	for channelID := range s {
+		channels = append(channels, channelID)
+	}
+	return channels
+}
+
+func (s testSupport) Ledger(channelID string) Ledger {
+	l, exists := s[channelID]
+	if !exists {
+		return nil
+	}
+	return l
+}
+
+func newTestSupport() testSupport {
+	env := &common.Envelope{
+		Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{
+				ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+					Type:      int32(common.HeaderType_MESSAGE),
+					ChannelId: "mychannel",
+					TxId:      "tx1",
+				}),
+			},
+		}),
+	}
+	block := &common.Block{
+		Header: &common.BlockHeader{Number: 0},
+		Data:   &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(env)}},
+	}
+	return testSupport{
+		"mychannel": &testLedger{
+			blocks: []*common.Block{block},
+			txs:    map[string]*peer.ProcessedTransaction{"tx1": {TransactionEnvelope: env}},
+		},
+	}
+}
+
+func get(t *testing.T, handler http.Handler, path string) (int, string) {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", path, nil))
+	body, err := ioutil.ReadAll(recorder.Body)
+	require.NoError(t, err)
+	return recorder.Code, string(body)
+}
+
+func TestListChannels(t *testing.T) {
+	handler := NewHandler(newTestSupport())
+
+	code, body := get(t, handler, "/channels")
+	assert.Equal(t, http.StatusOK, code)
+	var channels []ChannelInfo
+	require.NoError(t, json.Unmarshal([]byte(body), &channels))
+	assert.Equal(t, []ChannelInfo{{ChannelID: "mychannel", Height: 1, CurrentBlockHash: "ab"}}, channels)
+
+	code, body = get(t, NewHandler(testSupport{}), "/channels")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "[]", body)
+}
+
+func TestGetBlock(t *testing.T) {
+	handler := NewHandler(newTestSupport())
+
+	code, body := get(t, handler, "/channels/mychannel/blocks/0")
+	assert.Equal(t, http.StatusOK, code)
+	// the envelopes of the block are decoded
+	assert.Contains(t, body, `"tx_id": "tx1"`)
+
+	code, body = get(t, handler, "/channels/mychannel/blocks/1")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Contains(t, body, "block 1 not found, the height of the channel is 1")
+
+	code, body = get(t, handler, "/channels/mychannel/blocks/latest")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, body, "invalid block number latest")
+
+	code, body = get(t, handler, "/channels/otherchannel/blocks/0")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Contains(t, body, "channel otherchannel not found")
+}
+
+func TestGetTransaction(t *testing.T) {
+	handler := NewHandler(newTestSupport())
+
+	code, body := get(t, handler, "/channels/mychannel/transactions/tx1")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, body, `"tx_id": "tx1"`)
+
+	code, body = get(t, handler, "/channels/mychannel/transactions/tx2")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Contains(t, body, "transaction tx2 not found")
+}
+
+func TestReadOnly(t *testing.T) {
+	handler := NewHandler(newTestSupport())
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/channels", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package explorer
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TLSConfig holds the TLS settings of the explorer server
+type TLSConfig struct {
+	Enabled            bool
+	CertFile           string
+	KeyFile            string
+	ClientAuthRequired bool
+	ClientRootCAs      []string
+}
+
+// Config returns the TLS configuration of the server, or nil if TLS is not
+// enabled
+func (t TLSConfig) Config() (*tls.Config, error) {
+	if !t.Enabled {
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the TLS key pair")
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if t.ClientAuthRequired {
+		clientCAs := x509.NewCertPool()
+		for _, file := range t.ClientRootCAs {
+			pem, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read client root CA %s", file)
+			}
+			if !clientCAs.AppendCertsFromPEM(pem) {
+				return nil, errors.Errorf("failed to parse client root CA %s", file)
+			}
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+// Server serves the explorer endpoints over HTTP(S)
+type Server struct {
+	listener net.Listener
+	server   *http.Server
+}
+
+// NewServer creates a server listening on the given address, which serves
+// the explorer endpoints over TLS if it is enabled
+func NewServer(listenAddress string, tlsConfig TLSConfig, support Support) (*Server, error) {
+	config, err := tlsConfig.Config()
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to listen on %s", listenAddress)
+	}
+	if config != nil {
+		listener = tls.NewListener(listener, config)
+	}
+	return &Server{
+		listener: listener,
+		server: &http.Server{
+			Handler:      NewHandler(support),
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 2 * time.Minute,
+		},
+	}, nil
+}
+
+// Addr returns the address the server listens on
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Start serves the requests until the server is stopped
+func (s *Server) Start() error {
+	err := s.server.Serve(s.listener)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// Stop closes the server
+func (s *Server) Stop() error {
+	return s.server.Close()
+}
@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package explorer
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "explorer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	serverKeyPair, err := ca.NewServerCertKeyPair("127.0.0.1")
+	require.NoError(t, err)
+	clientKeyPair, err := ca.NewClientCertKeyPair()
+	require.NoError(t, err)
+	writeFile := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, data, 0600))
+		return path
+	}
+	tlsConfig := TLSConfig{
+		Enabled:            true,
+		CertFile:           writeFile("server.crt", serverKeyPair.Cert),
+		KeyFile:            writeFile("server.key", serverKeyPair.Key),
+		ClientAuthRequired: true,
+		ClientRootCAs:      []string{writeFile("ca.crt", ca.CertBytes())},
+	}
+
+	server, err := NewServer("127.0.0.1:0", tlsConfig, newTestSupport())
+	require.NoError(t, err)
+	go server.Start()
+	defer server.Stop()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AppendCertsFromPEM(ca.CertBytes())
+	clientCert, err := tls.X509KeyPair(clientKeyPair.Cert, clientKeyPair.Key)
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		RootCAs:      rootCAs,
+		Certificates: []tls.Certificate{clientCert},
+	}}}
+	resp, err := client.Get("https://" + server.Addr() + "/channels")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// clients without a certificate are rejected
+	client = &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCAs}}}
+	_, err = client.Get("https://" + server.Addr() + "/channels")
+	assert.Error(t, err)
+}
+
+func TestTLSConfig(t *testing.T) {
+	config, err := TLSConfig{}.Config()
+	assert.NoError(t, err)
+	assert.Nil(t, config)
+
+	_, err = TLSConfig{Enabled: true, CertFile: "missing.crt", KeyFile: "missing.key"}.Config()
+	assert.Contains(t, err.Error(), "failed to load the TLS key pair")
+}
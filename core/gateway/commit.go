@@ -0,0 +1,200 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"sync"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// commitStatus is the outcome of the commit of a transaction
+type commitStatus struct {
+	code        pb.TxValidationCode
+	blockNumber uint64
+}
+
+// commitNotifier notifies the subscribers of transactions of their commit.
+// The blocks of each channel are read by a single iterator, started with the
+// first subscription to a transaction of the channel, whose blocks are
+// dispatched to the subscribers.
+type commitNotifier struct {
+	lock     sync.Mutex
+	channels map[string]*channelNotifier
+}
+
+func newCommitNotifier() *commitNotifier {
+	return &commitNotifier{channels: make(map[string]*channelNotifier)}
+}
+
+// waitForCommit returns the outcome of the commit of a transaction, waiting
+// for the block containing it to be committed if the transaction is not in
+// the ledger yet
+func (n *commitNotifier) waitForCommit(ctx context.Context, channelID string, l Ledger, txID string) (*commitStatus, error) {
+	// the subscription is registered before the ledger is looked up, so that
+	// the transaction is notified if it is committed in between
+	notification, unsubscribe, err := n.subscribe(channelID, l, txID)
+	if err != nil {
+		return nil, err
+	}
+	defer unsubscribe()
+
+	if tx, err := l.GetTransactionByID(txID); err == nil {
+		block, err := l.GetBlockByTxID(txID)
+		if err != nil {
+			return nil, err
+		}
+		return &commitStatus{code: pb.TxValidationCode(tx.ValidationCode), blockNumber: block.Header.Number}, nil
+	}
+
+	select {
+	case status, ok := <-notification:
+		if !ok {
+			return nil, errors.Errorf("stopped following the commits of channel %s", channelID)
+		}
+		return status, nil
+	case <-ctx.Done():
+		return nil, errors.Wrapf(ctx.Err(), "stopped waiting for transaction %s", txID)
+	}
+}
+
+func (n *commitNotifier) subscribe(channelID string, l Ledger, txID string) (<-chan *commitStatus, func(), error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	cn, exists := n.channels[channelID]
+	if !exists {
+		info, err := l.GetBlockchainInfo()
+		if err != nil {
+			return nil, nil, err
+		}
+		itr, err := l.GetBlocksIterator(info.Height)
+		if err != nil {
+			return nil, nil, err
+		}
+		cn = &channelNotifier{subscribers: make(map[string]map[chan *commitStatus]struct{})}
+		n.channels[channelID] = cn
+		go func() {
+			cn.run(itr)
+			n.lock.Lock()
+			delete(n.channels, channelID)
+			n.lock.Unlock()
+			cn.close()
+		}()
+	}
+	notification, unsubscribe := cn.subscribe(txID)
+	return notification, unsubscribe, nil
+}
+
+// channelNotifier dispatches the blocks of a channel to the subscribers of
+// their transactions
+type channelNotifier struct {
+	lock        sync.Mutex
+	closed      bool
+	subscribers map[string]map[chan *commitStatus]struct{}
+}
+
+func (cn *channelNotifier) subscribe(txID string) (<-chan *commitStatus, func()) {
+	cn.lock.Lock()
+	defer cn.lock.Unlock()
+	notification := make(chan *commitStatus, 1)
+	if cn.closed {
+		close(notification)
+		return notification, func() {}
+	}
+	if cn.subscribers[txID] == nil {
+		cn.subscribers[txID] = make(map[chan *commitStatus]struct{})
+	}
+	cn.subscribers[txID][notification] = struct{}{}
+	return notification, func() {
+		cn.lock.Lock()
+		defer cn.lock.Unlock()
+		delete(cn.subscribers[txID], notification)
+		if len(cn.subscribers[txID]) == 0 {
+			delete(cn.subscribers, txID)
+		}
+	}
+}
+
+// run dispatches the blocks read by the iterator until it fails
+func (cn *channelNotifier) run(itr commonledger.ResultsIterator) {
+	defer itr.Close()
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			logger.Warningf("Failed to read the next block: %s", err)
+			return
+		}
+		if result == nil {
+			return
+		}
+		cn.notify(result.(*cb.Block))
+	}
+}
+
+func (cn *channelNotifier) notify(block *cb.Block) {
+	cn.lock.Lock()
+	defer cn.lock.Unlock()
+	if len(cn.subscribers) == 0 || block.Data == nil {
+		return
+	}
+	flags := util.TxValidationFlags(block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for i, envBytes := range block.Data.Data {
+		txID, err := txIDOf(envBytes)
+		if err != nil {
+			continue
+		}
+		subscribers, exists := cn.subscribers[txID]
+		if !exists {
+			continue
+		}
+		status := &commitStatus{code: flags.Flag(i), blockNumber: block.Header.Number}
+		for notification := range subscribers {
+			notification <- status
+		}
+		// only the first commit of a transaction is notified, the following
+		// ones being invalidated as duplicates
+		delete(cn.subscribers, txID)
+	}
+}
+
+// close closes the notifications of the remaining subscribers
+func (cn *channelNotifier) close() {
+	cn.lock.Lock()
+	defer cn.lock.Unlock()
+	cn.closed = true
+	for _, subscribers := range cn.subscribers {
+		for notification := range subscribers {
+			close(notification)
+		}
+	}
+	cn.subscribers = nil
+}
+
+func txIDOf(envBytes []byte) (string, error) {
+	env, err := utils.GetEnvelopeFromBlock(envBytes)
+	if err != nil {
+		return "", err
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return "", err
+	}
+	if payload.Header == nil {
+		return "", errors.New("missing header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return "", err
+	}
+	return chdr.TxId, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waitForSubscribers waits until the transaction has the given number of
+// subscribers
+func waitForSubscribers(t *testing.T, n *commitNotifier, channelID, txID string, count int) {
+	subscribed := func() bool {
+		n.lock.Lock()
+		cn, exists := n.channels[channelID]
+		n.lock.Unlock()
+		if !exists {
+			return false
+		}
+		cn.lock.Lock()
+		defer cn.lock.Unlock()
+		return len(cn.subscribers[txID]) == count
+	}
+	for deadline := time.Now().Add(5 * time.Second); !subscribed(); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("transaction %s of channel %s does not have %d subscribers", txID, channelID, count)
+		}
+	}
+}
+
+func TestCommitNotifier(t *testing.T) {
+	l := &testLedger{height: 5, blocks: make(chan *cb.Block, 2)}
+	n := newCommitNotifier()
+
+	type result struct {
+		status *commitStatus
+		err    error
+	}
+	results := make(chan result, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			status, err := n.waitForCommit(context.Background(), "mychannel", l, "tx1")
+			results <- result{status: status, err: err}
+		}()
+	}
+	waitForSubscribers(t, n, "mychannel", "tx1", 2)
+
+	// all the subscribers of the transaction are notified
+	l.blocks <- blockWithTx(5, "tx1", pb.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+	for i := 0; i < 2; i++ {
+		r := <-results
+		require.NoError(t, r.err)
+		assert.Equal(t, &commitStatus{code: pb.TxValidationCode_ENDORSEMENT_POLICY_FAILURE, blockNumber: 5}, r.status)
+	}
+
+	// a cancelled subscriber is unsubscribed
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		_, err := n.waitForCommit(ctx, "mychannel", l, "tx2")
+		results <- result{err: err}
+	}()
+	waitForSubscribers(t, n, "mychannel", "tx2", 1)
+	cancel()
+	assert.EqualError(t, (<-results).err, "stopped waiting for transaction tx2: context canceled")
+	n.lock.Lock()
+	cn := n.channels["mychannel"]
+	n.lock.Unlock()
+	cn.lock.Lock()
+	assert.Empty(t, cn.subscribers)
+	cn.lock.Unlock()
+
+	// the subscribers are released when the blocks can no longer be read
+	go func() {
+		_, err := n.waitForCommit(context.Background(), "mychannel", l, "tx3")
+		results <- result{err: err}
+	}()
+	waitForSubscribers(t, n, "mychannel", "tx3", 1)
+	close(l.blocks)
+	assert.EqualError(t, (<-results).err, "stopped following the commits of channel mychannel")
+}
@@ -0,0 +1,394 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	commonerrors "github.com/hyperledger/fabric/common/errors"
+	"github.com/hyperledger/fabric/common/flogging"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	gcommon "github.com/hyperledger/fabric/gossip/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	gp "github.com/hyperledger/fabric/protos/gateway"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("gateway")
+
+// Endorser processes the proposals sent to a peer
+type Endorser interface {
+	// ProcessProposal endorses a proposal
+	ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error)
+}
+
+// Discovery computes the peers which can satisfy the endorsement policy of
+// a chaincode
+type Discovery interface {
+	// PeersForEndorsement returns the endorsement descriptor of the chaincodes
+	// of the given interest
+	PeersForEndorsement(chainID gcommon.ChainID, interest *discprotos.ChaincodeInterest) (*discprotos.EndorsementDescriptor, error)
+}
+
+// Ledger is the part of the ledger of a channel read to find the commit
+// status of transactions
+type Ledger interface {
+	// GetBlockchainInfo returns basic info about the blockchain
+	GetBlockchainInfo() (*cb.BlockchainInfo, error)
+	// GetTransactionByID returns the transaction with the given id
+	GetTransactionByID(txID string) (*pb.ProcessedTransaction, error)
+	// GetBlockByTxID returns the block containing the transaction with the
+	// given id
+	GetBlockByTxID(txID string) (*cb.Block, error)
+	// GetBlocksIterator returns an iterator over the blocks starting at the
+	// given number, which blocks until the next block is committed
+	GetBlocksIterator(startBlockNumber uint64) (commonledger.ResultsIterator, error)
+}
+
+// Support provides the connections to the peers and the ordering service,
+// and the ledgers of the peer
+type Support interface {
+	// Endorser returns the endorser of the peer at the given endpoint
+	Endorser(endpoint string) (Endorser, error)
+	// Broadcast sends an envelope to the ordering service of the channel and
+	// returns its response
+	Broadcast(ctx context.Context, channelID string, env *cb.Envelope) (*ab.BroadcastResponse, error)
+	// Ledger returns the ledger of the given channel, or nil if the peer has
+	// not joined it
+	Ledger(channelID string) Ledger
+}
+
+// ChannelVerifier verifies that signed data satisfies the Readers policy of
+// a channel
+type ChannelVerifier interface {
+	// VerifyByChannel checks that the signature is valid, and that the
+	// identity satisfies the policy of the channel
+	VerifyByChannel(channel string, sd *cb.SignedData) error
+}
+
+// Config holds the settings of the gateway
+type Config struct {
+	// PeerSelection is the strategy ordering the peers tried for the
+	// endorsements of a group: random, localOrg, latency or height
+	PeerSelection string
+	// LocalMSPID is the MSP ID of the organization of the peer, preferred by
+	// the localOrg strategy
+	LocalMSPID string
+}
+
+// Server implements the gateway service
+type Server struct {
+	discovery Discovery
+	support   Support
+	verifier  ChannelVerifier
+	config    Config
+	latencies *latencies
+	notifier  *commitNotifier
+}
+
+// NewServer creates a gateway server
+func NewServer(discovery Discovery, support Support, verifier ChannelVerifier, config Config) (*Server, error) {
+	if config.PeerSelection == "" {
+		config.PeerSelection = SelectRandom
+	}
+	if _, exists := strategies[config.PeerSelection]; !exists {
+		return nil, errors.Errorf("unknown peer selection strategy %s", config.PeerSelection)
+	}
+	return &Server{
+		discovery: discovery,
+		support:   support,
+		verifier:  verifier,
+		config:    config,
+		latencies: &latencies{byEndpoint: make(map[string]time.Duration)},
+		notifier:  newCommitNotifier(),
+	}, nil
+}
+
+// Endorse collects the endorsements of a proposal required by the
+// endorsement plan of its chaincode and assembles them into a transaction
+func (s *Server) Endorse(ctx context.Context, request *gp.EndorseRequest) (*gp.EndorseResponse, error) {
+	signedProp := request.ProposedTransaction
+	if signedProp == nil {
+		return nil, errors.New("a signed proposal is required")
+	}
+	prop, err := utils.GetProposal(signedProp.ProposalBytes)
+	if err != nil {
+		return nil, err
+	}
+	hdr, err := utils.GetHeader(prop.Header)
+	if err != nil {
+		return nil, err
+	}
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	hdrExt, err := utils.GetChaincodeHeaderExtension(hdr)
+	if err != nil {
+		return nil, err
+	}
+	if hdrExt.ChaincodeId == nil || hdrExt.ChaincodeId.Name == "" {
+		return nil, errors.New("the proposal does not name a chaincode")
+	}
+	chaincode := hdrExt.ChaincodeId.Name
+
+	desc, err := s.discovery.PeersForEndorsement(gcommon.ChainID(chdr.ChannelId), &discprotos.ChaincodeInterest{
+		Chaincodes: []*discprotos.ChaincodeCall{{Name: chaincode}},
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to compute the endorsement plan")
+	}
+
+	responses, err := s.endorse(ctx, desc, signedProp)
+	if err != nil {
+		if ccErr, ok := errors.Cause(err).(*chaincodeError); ok {
+			err = commonerrors.WithDetail(err, &cb.ErrorDetail{
+				Reason:          cb.ErrorReason_CHAINCODE_ERROR,
+				ChannelId:       chdr.ChannelId,
+				Chaincode:       chaincode,
+				ChaincodeStatus: ccErr.status,
+			})
+		}
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to endorse transaction %s of chaincode %s", chdr.TxId, chaincode))
+	}
+
+	payload, err := utils.CreateTxPayload(prop, responses...)
+	if err != nil {
+		return nil, err
+	}
+	return &gp.EndorseResponse{
+		PreparedTransaction: &cb.Envelope{Payload: payload},
+		Result:              responses[0].Response,
+	}, nil
+}
+
+// endorse collects the endorsements of the first layout of the endorsement
+// descriptor whose groups all provide enough endorsements. The outcome of
+// the endorsement of each peer is remembered, so that when a layout fails
+// the alternative layouts reuse the endorsements already collected and do
+// not retry the peers which failed.
+func (s *Server) endorse(ctx context.Context, desc *discprotos.EndorsementDescriptor, signedProp *pb.SignedProposal) ([]*pb.ProposalResponse, error) {
+	groups := make(map[string][]*endorsingPeer, len(desc.EndorsersByGroups))
+	for group, peers := range desc.EndorsersByGroups {
+		groups[group] = s.orderPeers(peers.GetPeers())
+	}
+	attempts := &attempts{results: make(map[string]*attempt)}
+
+	var err error
+	for _, layout := range desc.Layouts {
+		var responses []*pb.ProposalResponse
+		responses, err = s.endorseLayout(ctx, groups, layout, attempts, signedProp)
+		if err == nil {
+			return responses, nil
+		}
+		logger.Debugf("Failed to endorse with layout %v: %s", layout.QuantitiesByGroup, err)
+	}
+	if err == nil {
+		return nil, errors.New("no endorsement layout satisfies the endorsement policy")
+	}
+	return nil, err
+}
+
+type groupEndorsements struct {
+	responses []*pb.ProposalResponse
+	err       error
+}
+
+// endorseLayout collects the endorsements of the groups of the layout in
+// parallel. The peers of each group are tried in order until the group
+// provides the number of endorsements required by the layout.
+func (s *Server) endorseLayout(ctx context.Context, groups map[string][]*endorsingPeer, layout *discprotos.Layout, attempts *attempts, signedProp *pb.SignedProposal) ([]*pb.ProposalResponse, error) {
+	results := make(map[string]*groupEndorsements, len(layout.QuantitiesByGroup))
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for group, quantity := range layout.QuantitiesByGroup {
+		wg.Add(1)
+		go func(group string, quantity uint32, peers []*endorsingPeer) {
+			defer wg.Done()
+			result := s.endorseGroup(ctx, quantity, peers, attempts, signedProp)
+			lock.Lock()
+			results[group] = result
+			lock.Unlock()
+		}(group, quantity, groups[group])
+	}
+	wg.Wait()
+
+	var responses []*pb.ProposalResponse
+	for group, result := range results {
+		if result.err != nil {
+			return nil, errors.WithMessage(result.err, fmt.Sprintf("group %s", group))
+		}
+		responses = append(responses, result.responses...)
+	}
+	return responses, nil
+}
+
+func (s *Server) endorseGroup(ctx context.Context, quantity uint32, peers []*endorsingPeer, attempts *attempts, signedProp *pb.SignedProposal) *groupEndorsements {
+	result := &groupEndorsements{}
+	for _, peer := range peers {
+		if uint32(len(result.responses)) == quantity {
+			break
+		}
+		response, err := attempts.endorse(peer.endpoint, func() (*pb.ProposalResponse, error) {
+			return s.endorseWithPeer(ctx, peer.endpoint, signedProp)
+		})
+		if err != nil {
+			result.err = err
+			continue
+		}
+		result.responses = append(result.responses, response)
+	}
+	if uint32(len(result.responses)) < quantity {
+		if result.err == nil {
+			result.err = errors.Errorf("%d endorsements are required but only %d peers are available", quantity, len(peers))
+		}
+		return result
+	}
+	result.err = nil
+	return result
+}
+
+func (s *Server) endorseWithPeer(ctx context.Context, endpoint string, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+	endorser, err := s.support.Endorser(endpoint)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to connect to peer %s", endpoint))
+	}
+	start := time.Now()
+	response, err := endorser.ProcessProposal(ctx, signedProp)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("peer %s failed to endorse the proposal", endpoint))
+	}
+	s.latencies.record(endpoint, time.Since(start))
+	if response.Response == nil || response.Response.Status < 200 || response.Response.Status >= 400 || response.Endorsement == nil {
+		var message string
+		if response.Response != nil {
+			message = response.Response.Message
+		}
+		if response.Response != nil && response.Response.Status >= 400 {
+			return nil, &chaincodeError{endpoint: endpoint, status: response.Response.Status, message: message}
+		}
+		return nil, errors.Errorf("peer %s did not endorse the proposal: %s", endpoint, message)
+	}
+	return response, nil
+}
+
+// chaincodeError reports the error status returned by the chaincode when a
+// peer simulated the proposal
+type chaincodeError struct {
+	endpoint string
+	status   int32
+	message  string
+}
+
+func (e *chaincodeError) Error() string {
+	return fmt.Sprintf("peer %s did not endorse the proposal: %s", e.endpoint, e.message)
+}
+
+// attempts remembers the outcome of the endorsements requested from each
+// peer while processing a proposal
+type attempts struct {
+	lock    sync.Mutex
+	results map[string]*attempt
+}
+
+type attempt struct {
+	response *pb.ProposalResponse
+	err      error
+}
+
+// endorse returns the outcome of the previous endorsement of the peer, or
+// requests its endorsement
+func (a *attempts) endorse(endpoint string, endorse func() (*pb.ProposalResponse, error)) (*pb.ProposalResponse, error) {
+	a.lock.Lock()
+	previous, exists := a.results[endpoint]
+	a.lock.Unlock()
+	if exists {
+		return previous.response, previous.err
+	}
+
+	response, err := endorse()
+	if err != nil {
+		logger.Warningf("%s", err)
+	}
+	a.lock.Lock()
+	a.results[endpoint] = &attempt{response: response, err: err}
+	a.lock.Unlock()
+	return response, err
+}
+
+// Submit sends a transaction signed by the client to the ordering service
+func (s *Server) Submit(ctx context.Context, request *gp.SubmitRequest) (*gp.SubmitResponse, error) {
+	env := request.PreparedTransaction
+	if env == nil {
+		return nil, errors.New("a prepared transaction is required")
+	}
+	if len(env.Signature) == 0 {
+		return nil, errors.New("the prepared transaction is not signed")
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("the prepared transaction has no header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.support.Broadcast(ctx, chdr.ChannelId, env)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to submit transaction %s", chdr.TxId))
+	}
+	if response.Status != cb.Status_SUCCESS {
+		err := errors.Errorf("the ordering service rejected transaction %s with status %s: %s", chdr.TxId, response.Status, response.Info)
+		return nil, commonerrors.WithReason(err, commonerrors.ReasonOfStatus(response.Status), chdr.ChannelId)
+	}
+	return &gp.SubmitResponse{}, nil
+}
+
+// CommitStatus waits for a transaction to be committed by the peer, until
+// the context of the call is done. The clients waiting for transactions of the
+// same channel share a single iterator over its blocks.
+func (s *Server) CommitStatus(ctx context.Context, signedRequest *gp.SignedCommitStatusRequest) (*gp.CommitStatusResponse, error) {
+	request := &gp.CommitStatusRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the commit status request")
+	}
+	err := s.verifier.VerifyByChannel(request.ChannelId, &cb.SignedData{
+		Data:      signedRequest.Request,
+		Identity:  request.Identity,
+		Signature: signedRequest.Signature,
+	})
+	if err != nil {
+		err = errors.WithMessage(err, fmt.Sprintf("access denied to the transactions of channel %s", request.ChannelId))
+		return nil, commonerrors.WithReason(err, cb.ErrorReason_POLICY_FAILURE, request.ChannelId)
+	}
+	l := s.support.Ledger(request.ChannelId)
+	if l == nil {
+		err := errors.Errorf("channel %s not found", request.ChannelId)
+		return nil, commonerrors.WithReason(err, cb.ErrorReason_BAD_CHANNEL, request.ChannelId)
+	}
+
+	status, err := s.notifier.waitForCommit(ctx, request.ChannelId, l, request.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+	return &gp.CommitStatusResponse{
+		Result:      status.code,
+		BlockNumber: status.blockNumber,
+	}, nil
+}
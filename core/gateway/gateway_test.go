@@ -0,0 +1,363 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	commonerrors "github.com/hyperledger/fabric/common/errors"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	mockmsp "github.com/hyperledger/fabric/common/mocks/msp"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	gcommon "github.com/hyperledger/fabric/gossip/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	gp "github.com/hyperledger/fabric/protos/gateway"
+	"github.com/hyperledger/fabric/protos/gossip"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ gp.GatewayServer = &Server{}
+
+type endorserFunc func(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error)
+
+func (f endorserFunc) ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+	return f(ctx, signedProp)
+}
+
+type testDiscovery struct {
+	desc *discprotos.EndorsementDescriptor
+	err  error
+}
+
+func (d *testDiscovery) PeersForEndorsement(chainID gcommon.ChainID, interest *discprotos.ChaincodeInterest) (*discprotos.EndorsementDescriptor, error) {
+	return d.desc, d.err
+}
+
+type testSupport struct {
+	endorsers map[string]Endorser
+	broadcast func(env *cb.Envelope) (*ab.BroadcastResponse, error)
+	ledgers   map[string]Ledger
+}
+
+func (s *testSupport) Endorser(endpoint string) (Endorser, error) {
+	e, exists := s.endorsers[endpoint]
+	if !exists {
+		return nil, errors.New("unreachable")
+	}
+	return e, nil
+}
+
+func (s *testSupport) Broadcast(ctx context.Context, channelID string, env *cb.Envelope) (*ab.BroadcastResponse, error) {
+	return s.broadcast(env)
+}
+
+func (s *testSupport) Ledger(channelID string) Ledger {
+	l, exists := s.ledgers[channelID]
+	if !exists {
+		return nil
+	}
+	return l
+}
+
+func peerAt(endpoint string) *discprotos.Peer {
+	msg := &gossip.GossipMessage{
+		Content: &gossip.GossipMessage_AliveMsg{
+			AliveMsg: &gossip.AliveMessage{Membership: &gossip.Member{Endpoint: endpoint}},
+		},
+	}
+	return &discprotos.Peer{MembershipInfo: &gossip.Envelope{Payload: utils.MarshalOrPanic(msg)}}
+}
+
+func newProposal(t *testing.T) (*pb.SignedProposal, *pb.Proposal) {
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: "mycc"},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte("invoke")}},
+		},
+	}
+	prop, _, err := utils.CreateChaincodeProposal(cb.HeaderType_ENDORSER_TRANSACTION, "mychannel", cis, []byte("creator"))
+	require.NoError(t, err)
+	return &pb.SignedProposal{ProposalBytes: utils.MarshalOrPanic(prop), Signature: []byte("signature")}, prop
+}
+
+func successfulPeer(t *testing.T, prop *pb.Proposal) Endorser {
+	signer, err := mockmsp.NewNoopMsp().GetDefaultSigningIdentity()
+	require.NoError(t, err)
+	return endorserFunc(func(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+		return utils.CreateProposalResponse(prop.Header, prop.Payload, &pb.Response{Status: 200, Payload: []byte("result")}, []byte("results"), nil, &pb.ChaincodeID{Name: "mycc"}, nil, signer)
+	})
+}
+
+func failingPeer(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+	return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: "chaincode failed"}}, nil
+}
+
+func TestEndorse(t *testing.T) {
+	signedProp, prop := newProposal(t)
+	discovery := &testDiscovery{
+		desc: &discprotos.EndorsementDescriptor{
+			Chaincode: "mycc",
+			EndorsersByGroups: map[string]*discprotos.Peers{
+				"G0": {Peers: []*discprotos.Peer{peerAt("peer0.org1:7051"), peerAt("peer1.org1:7051")}},
+				"G1": {Peers: []*discprotos.Peer{peerAt("peer0.org2:7051")}},
+			},
+			Layouts: []*discprotos.Layout{
+				{QuantitiesByGroup: map[string]uint32{"G0": 2, "G1": 1}},
+				{QuantitiesByGroup: map[string]uint32{"G0": 1, "G1": 1}},
+			},
+		},
+	}
+	support := &testSupport{
+		endorsers: map[string]Endorser{
+			"peer0.org1:7051": endorserFunc(failingPeer),
+			"peer1.org1:7051": successfulPeer(t, prop),
+			"peer0.org2:7051": successfulPeer(t, prop),
+		},
+	}
+	server, err := NewServer(discovery, support, nil, Config{})
+	require.NoError(t, err)
+
+	// the first layout cannot be satisfied as a peer of G0 fails
+	response, err := server.Endorse(context.Background(), &gp.EndorseRequest{ProposedTransaction: signedProp})
+	require.NoError(t, err)
+	assert.Equal(t, int32(200), response.Result.Status)
+	assert.Nil(t, response.PreparedTransaction.Signature)
+	payload, err := utils.UnmarshalPayload(response.PreparedTransaction.Payload)
+	require.NoError(t, err)
+	tx, err := utils.GetTransaction(payload.Data)
+	require.NoError(t, err)
+	cap, err := utils.GetChaincodeActionPayload(tx.Actions[0].Payload)
+	require.NoError(t, err)
+	assert.Len(t, cap.Action.Endorsements, 2)
+
+	support.endorsers["peer0.org2:7051"] = endorserFunc(failingPeer)
+	_, err = server.Endorse(context.Background(), &gp.EndorseRequest{ProposedTransaction: signedProp})
+	assert.Contains(t, err.Error(), "failed to endorse transaction")
+	assert.Contains(t, err.Error(), "group G1: peer peer0.org2:7051 did not endorse the proposal: chaincode failed")
+	assert.Equal(t, &cb.ErrorDetail{
+		Reason:          cb.ErrorReason_CHAINCODE_ERROR,
+		ChannelId:       "mychannel",
+		Chaincode:       "mycc",
+		ChaincodeStatus: 500,
+	}, commonerrors.DetailOf(err))
+
+	discovery.err = errors.New("no such chaincode")
+	_, err = server.Endorse(context.Background(), &gp.EndorseRequest{ProposedTransaction: signedProp})
+	assert.EqualError(t, err, "failed to compute the endorsement plan: no such chaincode")
+
+	_, err = server.Endorse(context.Background(), &gp.EndorseRequest{})
+	assert.EqualError(t, err, "a signed proposal is required")
+}
+
+func TestEndorseUnreachablePeers(t *testing.T) {
+	signedProp, _ := newProposal(t)
+	discovery := &testDiscovery{
+		desc: &discprotos.EndorsementDescriptor{
+			EndorsersByGroups: map[string]*discprotos.Peers{
+				"G0": {Peers: []*discprotos.Peer{peerAt("peer0.org1:7051"), {}}},
+			},
+			Layouts: []*discprotos.Layout{{QuantitiesByGroup: map[string]uint32{"G0": 1}}},
+		},
+	}
+	server, err := NewServer(discovery, &testSupport{}, nil, Config{})
+	require.NoError(t, err)
+	_, err = server.Endorse(context.Background(), &gp.EndorseRequest{ProposedTransaction: signedProp})
+	assert.Error(t, err)
+
+	discovery.desc.Layouts = nil
+	_, err = server.Endorse(context.Background(), &gp.EndorseRequest{ProposedTransaction: signedProp})
+	assert.Contains(t, err.Error(), "no endorsement layout satisfies the endorsement policy")
+}
+
+func TestPeerEndpoint(t *testing.T) {
+	endpoint, err := peerEndpoint(peerAt("peer0.org1:7051"))
+	assert.NoError(t, err)
+	assert.Equal(t, "peer0.org1:7051", endpoint)
+
+	_, err = peerEndpoint(&discprotos.Peer{})
+	assert.EqualError(t, err, "peer has no membership information")
+
+	_, err = peerEndpoint(peerAt(""))
+	assert.EqualError(t, err, "peer does not advertise its endpoint")
+}
+
+func TestSubmit(t *testing.T) {
+	_, prop := newProposal(t)
+	hdr, err := utils.GetHeader(prop.Header)
+	require.NoError(t, err)
+	env := &cb.Envelope{
+		Payload:   utils.MarshalOrPanic(&cb.Payload{Header: hdr}),
+		Signature: []byte("signature"),
+	}
+
+	var broadcast []*cb.Envelope
+	status := cb.Status_SUCCESS
+	support := &testSupport{
+		broadcast: func(env *cb.Envelope) (*ab.BroadcastResponse, error) {
+			broadcast = append(broadcast, env)
+			return &ab.BroadcastResponse{Status: status, Info: "info"}, nil
+		},
+	}
+	server, err := NewServer(nil, support, nil, Config{})
+	require.NoError(t, err)
+
+	_, err = server.Submit(context.Background(), &gp.SubmitRequest{PreparedTransaction: env})
+	assert.NoError(t, err)
+	assert.Equal(t, []*cb.Envelope{env}, broadcast)
+
+	status = cb.Status_BAD_REQUEST
+	_, err = server.Submit(context.Background(), &gp.SubmitRequest{PreparedTransaction: env})
+	assert.Contains(t, err.Error(), "rejected transaction")
+	assert.Contains(t, err.Error(), "with status BAD_REQUEST: info")
+	assert.Equal(t, cb.ErrorReason_MALFORMED_REQUEST, commonerrors.ReasonOf(err))
+
+	_, err = server.Submit(context.Background(), &gp.SubmitRequest{PreparedTransaction: &cb.Envelope{Payload: env.Payload}})
+	assert.EqualError(t, err, "the prepared transaction is not signed")
+	assert.Len(t, broadcast, 2)
+}
+
+type testLedger struct {
+	height uint64
+	txs    map[string]*pb.ProcessedTransaction
+	blocks chan *cb.Block
+}
+
+func (l *testLedger) GetBlockchainInfo() (*cb.BlockchainInfo, error) {
+	return &cb.BlockchainInfo{Height: l.height}, nil
+}
+
+func (l *testLedger) GetTransactionByID(txID string) (*pb.ProcessedTransaction, error) {
+	tx, exists := l.txs[txID]
+	if !exists {
+		return nil, errors.New("entry not found in index")
+	}
+	return tx, nil
+}
+
+func (l *testLedger) GetBlockByTxID(txID string) (*cb.Block, error) {
+	return &cb.Block{Header: &cb.BlockHeader{Number: 3}}, nil
+}
+
+func (l *testLedger) GetBlocksIterator(startBlockNumber uint64) (commonledger.ResultsIterator, error) {
+	return &testIterator{blocks: l.blocks, closed: make(chan struct{})}, nil
+}
+
+type testIterator struct {
+	blocks chan *cb.Block
+	closed chan struct{}
+}
+
+func (itr *testIterator) Next() (commonledger.QueryResult, error) {
+	select {
+	case block, ok := <-itr.blocks:
+		if !ok {
+			return nil, nil
+		}
+		return block, nil
+	case <-itr.closed:
+		return nil, nil
+	}
+}
+
+func (itr *testIterator) Close() {
+	close(itr.closed)
+}
+
+type verifierFunc func(channel string, sd *cb.SignedData) error
+
+func (f verifierFunc) VerifyByChannel(channel string, sd *cb.SignedData) error {
+	return f(channel, sd)
+}
+
+func blockWithTx(number uint64, txID string, code pb.TxValidationCode) *cb.Block {
+	env := &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{TxId: txID}),
+			},
+		}),
+	}
+	block := cb.NewBlock(number, nil)
+	block.Data.Data = [][]byte{[]byte("garbage"), utils.MarshalOrPanic(env)}
+	flags := util.NewTxValidationFlags(2)
+	flags.SetFlag(1, code)
+	block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = flags
+	return block
+}
+
+func TestCommitStatus(t *testing.T) {
+	l := &testLedger{
+		height: 5,
+		txs:    map[string]*pb.ProcessedTransaction{"tx1": {ValidationCode: int32(pb.TxValidationCode_MVCC_READ_CONFLICT)}},
+		blocks: make(chan *cb.Block, 2),
+	}
+	var verified *cb.SignedData
+	verifier := verifierFunc(func(channel string, sd *cb.SignedData) error {
+		if channel != "mychannel" {
+			return errors.New("not a reader")
+		}
+		verified = sd
+		return nil
+	})
+	server, err := NewServer(nil, &testSupport{ledgers: map[string]Ledger{"mychannel": l}}, verifier, Config{})
+	require.NoError(t, err)
+	commitStatus := func(ctx context.Context, channelID, txID string) (*gp.CommitStatusResponse, error) {
+		return server.CommitStatus(ctx, &gp.SignedCommitStatusRequest{
+			Request: utils.MarshalOrPanic(&gp.CommitStatusRequest{
+				ChannelId:     channelID,
+				TransactionId: txID,
+				Identity:      []byte("identity"),
+			}),
+			Signature: []byte("signature"),
+		})
+	}
+
+	// the transaction is already committed
+	response, err := commitStatus(context.Background(), "mychannel", "tx1")
+	require.NoError(t, err)
+	assert.Equal(t, pb.TxValidationCode_MVCC_READ_CONFLICT, response.Result)
+	assert.Equal(t, uint64(3), response.BlockNumber)
+	assert.Equal(t, []byte("identity"), verified.Identity)
+	assert.Equal(t, []byte("signature"), verified.Signature)
+
+	// the transaction is committed while waiting
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		response, err = commitStatus(context.Background(), "mychannel", "tx2")
+	}()
+	waitForSubscribers(t, server.notifier, "mychannel", "tx2", 1)
+	l.blocks <- blockWithTx(5, "tx0", pb.TxValidationCode_VALID)
+	l.blocks <- blockWithTx(6, "tx2", pb.TxValidationCode_VALID)
+	<-done
+	require.NoError(t, err)
+	assert.Equal(t, pb.TxValidationCode_VALID, response.Result)
+	assert.Equal(t, uint64(6), response.BlockNumber)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = commitStatus(ctx, "mychannel", "tx3")
+	assert.EqualError(t, err, "stopped waiting for transaction tx3: context deadline exceeded")
+
+	_, err = commitStatus(context.Background(), "otherchannel", "tx1")
+	assert.EqualError(t, err, "access denied to the transactions of channel otherchannel: not a reader")
+	assert.Equal(t, cb.ErrorReason_POLICY_FAILURE, commonerrors.ReasonOf(err))
+
+	server, err = NewServer(nil, &testSupport{}, verifier, Config{})
+	require.NoError(t, err)
+	_, err = commitStatus(context.Background(), "mychannel", "tx1")
+	assert.EqualError(t, err, "channel mychannel not found")
+	assert.Equal(t, cb.ErrorReason_BAD_CHANNEL, commonerrors.ReasonOf(err))
+}
@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// The strategies ordering the peers of a group
+const (
+	// SelectRandom tries the peers in a random order
+	SelectRandom = "random"
+	// SelectLocalOrg tries the peers of the organization of the gateway first
+	SelectLocalOrg = "localOrg"
+	// SelectLatency tries the peers which endorsed proposals the fastest
+	// first. The peers which have not endorsed any proposal yet come first,
+	// so that their latency gets measured.
+	SelectLatency = "latency"
+	// SelectHeight tries the peers with the highest ledger height first
+	SelectHeight = "height"
+)
+
+// strategies maps the peer selection strategies to the function telling
+// whether the left peer is preferred to the right one
+var strategies = map[string]func(s *Server, left, right *endorsingPeer) bool{
+	SelectRandom: func(s *Server, left, right *endorsingPeer) bool {
+		return false
+	},
+	SelectLocalOrg: func(s *Server, left, right *endorsingPeer) bool {
+		return left.mspID == s.config.LocalMSPID && right.mspID != s.config.LocalMSPID
+	},
+	SelectLatency: func(s *Server, left, right *endorsingPeer) bool {
+		return s.latencies.get(left.endpoint) < s.latencies.get(right.endpoint)
+	},
+	SelectHeight: func(s *Server, left, right *endorsingPeer) bool {
+		return left.height > right.height
+	},
+}
+
+// endorsingPeer is a peer which can endorse the proposals of a group
+type endorsingPeer struct {
+	endpoint string
+	mspID    string
+	height   uint64
+}
+
+// orderPeers returns the peers in the order they should be tried according
+// to the peer selection strategy, the peers equally preferred being
+// shuffled. The peers whose endpoint is unknown are dropped.
+func (s *Server) orderPeers(peers []*discprotos.Peer) []*endorsingPeer {
+	var endorsingPeers []*endorsingPeer
+	for _, i := range rand.Perm(len(peers)) {
+		peer, err := newEndorsingPeer(peers[i])
+		if err != nil {
+			logger.Warningf("Ignoring endorsing peer: %s", err)
+			continue
+		}
+		endorsingPeers = append(endorsingPeers, peer)
+	}
+	preferred := strategies[s.config.PeerSelection]
+	sort.SliceStable(endorsingPeers, func(i, j int) bool {
+		return preferred(s, endorsingPeers[i], endorsingPeers[j])
+	})
+	return endorsingPeers
+}
+
+func newEndorsingPeer(peer *discprotos.Peer) (*endorsingPeer, error) {
+	endpoint, err := peerEndpoint(peer)
+	if err != nil {
+		return nil, err
+	}
+	endorsingPeer := &endorsingPeer{endpoint: endpoint}
+	identity := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(peer.Identity, identity); err == nil {
+		endorsingPeer.mspID = identity.Mspid
+	}
+	if peer.StateInfo != nil {
+		if msg, err := peer.StateInfo.ToGossipMessage(); err == nil {
+			endorsingPeer.height = msg.GetStateInfo().GetProperties().GetLedgerHeight()
+		}
+	}
+	return endorsingPeer, nil
+}
+
+// peerEndpoint returns the endpoint advertised by the peer in its
+// membership information
+func peerEndpoint(peer *discprotos.Peer) (string, error) {
+	if peer.MembershipInfo == nil {
+		return "", errors.New("peer has no membership information")
+	}
+	msg, err := peer.MembershipInfo.ToGossipMessage()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read the membership information of a peer")
+	}
+	alive := msg.GetAliveMsg()
+	if alive == nil || alive.Membership == nil || alive.Membership.Endpoint == "" {
+		return "", errors.New("peer does not advertise its endpoint")
+	}
+	return alive.Membership.Endpoint, nil
+}
+
+// latencies tracks the moving average of the time taken by the peers to
+// endorse proposals
+type latencies struct {
+	lock       sync.RWMutex
+	byEndpoint map[string]time.Duration
+}
+
+func (l *latencies) record(endpoint string, latency time.Duration) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if average, exists := l.byEndpoint[endpoint]; exists {
+		latency = (3*average + latency) / 4
+	}
+	l.byEndpoint[endpoint] = latency
+}
+
+func (l *latencies) get(endpoint string) time.Duration {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return l.byEndpoint[endpoint]
+}
@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	discprotos "github.com/hyperledger/fabric/protos/discovery"
+	gp "github.com/hyperledger/fabric/protos/gateway"
+	"github.com/hyperledger/fabric/protos/gossip"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func peerOf(endpoint, mspID string, height uint64) *discprotos.Peer {
+	peer := peerAt(endpoint)
+	peer.Identity = utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: mspID})
+	msg := &gossip.GossipMessage{
+		Content: &gossip.GossipMessage_StateInfo{
+			StateInfo: &gossip.StateInfo{Properties: &gossip.Properties{LedgerHeight: height}},
+		},
+	}
+	peer.StateInfo = &gossip.Envelope{Payload: utils.MarshalOrPanic(msg)}
+	return peer
+}
+
+func endpoints(peers []*endorsingPeer) []string {
+	var endpoints []string
+	for _, peer := range peers {
+		endpoints = append(endpoints, peer.endpoint)
+	}
+	return endpoints
+}
+
+func TestOrderPeers(t *testing.T) {
+	peers := []*discprotos.Peer{
+		peerOf("peer0.org1:7051", "Org1MSP", 10),
+		peerOf("peer0.org2:7051", "Org2MSP", 12),
+		peerOf("peer1.org2:7051", "Org2MSP", 11),
+		{},
+	}
+
+	server, err := NewServer(nil, nil, nil, Config{PeerSelection: SelectHeight})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"peer0.org2:7051", "peer1.org2:7051", "peer0.org1:7051"}, endpoints(server.orderPeers(peers)))
+
+	server, err = NewServer(nil, nil, nil, Config{PeerSelection: SelectLocalOrg, LocalMSPID: "Org1MSP"})
+	require.NoError(t, err)
+	assert.Equal(t, "peer0.org1:7051", server.orderPeers(peers)[0].endpoint)
+
+	server, err = NewServer(nil, nil, nil, Config{PeerSelection: SelectLatency})
+	require.NoError(t, err)
+	server.latencies.record("peer0.org1:7051", 10*time.Millisecond)
+	server.latencies.record("peer0.org2:7051", 30*time.Millisecond)
+	server.latencies.record("peer0.org2:7051", 10*time.Millisecond)
+	server.latencies.record("peer1.org2:7051", 20*time.Millisecond)
+	// the average latency of peer0.org2 is 25ms
+	assert.Equal(t, []string{"peer0.org1:7051", "peer1.org2:7051", "peer0.org2:7051"}, endpoints(server.orderPeers(peers)))
+
+	server, err = NewServer(nil, nil, nil, Config{})
+	require.NoError(t, err)
+	assert.Len(t, server.orderPeers(peers), 3)
+
+	_, err = NewServer(nil, nil, nil, Config{PeerSelection: "fastest"})
+	assert.EqualError(t, err, "unknown peer selection strategy fastest")
+}
+
+func TestEndorseRetriesLayouts(t *testing.T) {
+	signedProp, prop := newProposal(t)
+	discovery := &testDiscovery{
+		desc: &discprotos.EndorsementDescriptor{
+			EndorsersByGroups: map[string]*discprotos.Peers{
+				"G0": {Peers: []*discprotos.Peer{peerAt("peer0.org1:7051")}},
+				"G1": {Peers: []*discprotos.Peer{peerAt("peer0.org2:7051")}},
+				"G2": {Peers: []*discprotos.Peer{peerAt("peer0.org3:7051")}},
+			},
+			Layouts: []*discprotos.Layout{
+				{QuantitiesByGroup: map[string]uint32{"G0": 1, "G1": 1}},
+				{QuantitiesByGroup: map[string]uint32{"G1": 1, "G2": 1}},
+				{QuantitiesByGroup: map[string]uint32{"G0": 1, "G2": 1}},
+			},
+		},
+	}
+	var calls int32
+	counted := func(e Endorser) Endorser {
+		return endorserFunc(func(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+			atomic.AddInt32(&calls, 1)
+			return e.ProcessProposal(ctx, signedProp)
+		})
+	}
+	support := &testSupport{
+		endorsers: map[string]Endorser{
+			"peer0.org1:7051": counted(successfulPeer(t, prop)),
+			"peer0.org2:7051": counted(endorserFunc(failingPeer)),
+			"peer0.org3:7051": counted(successfulPeer(t, prop)),
+		},
+	}
+	server, err := NewServer(discovery, support, nil, Config{})
+	require.NoError(t, err)
+
+	response, err := server.Endorse(context.Background(), &gp.EndorseRequest{ProposedTransaction: signedProp})
+	require.NoError(t, err)
+	assert.NotNil(t, response.PreparedTransaction)
+	// each peer is asked once, the failure of peer0.org2 and the endorsement
+	// of peer0.org1 are reused by the following layouts
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,122 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resolver
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/handlers/decoration"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("decoration.resolver")
+
+// DecorationPrefix prefixes the keys of the decorations holding the external
+// data passed to the chaincodes
+const DecorationPrefix = "external:"
+
+// Resolver fetches the data external to the ledger referenced by the
+// proposals, such as the answers of an oracle
+type Resolver interface {
+	// Resolve returns the data of the given key
+	Resolve(key string) ([]byte, error)
+}
+
+// NewDecorator creates a decorator passing to the chaincodes the external
+// data referenced by the proposals, fetched through the given resolver. The
+// data is passed as decorations, keyed by DecorationPrefix and the key of the
+// data, provided it matches the hash carried by the proposal. The last
+// cacheSize data are cached, so that the data is fetched once for all the
+// proposals referencing it.
+//
+// Decoration plugins construct it from their own resolver, for instance:
+//
+//	func NewDecorator() decoration.Decorator {
+//		return resolver.NewDecorator(&oracleClient{}, 1000)
+//	}
+func NewDecorator(r Resolver, cacheSize int) decoration.Decorator {
+	return &decorator{
+		resolver:  r,
+		cacheSize: cacheSize,
+		cache:     map[string][]byte{},
+	}
+}
+
+type decorator struct {
+	resolver  Resolver
+	cacheSize int
+
+	lock  sync.Mutex
+	cache map[string][]byte
+	// keys holds the keys of the cache, from the oldest to the newest
+	keys []string
+}
+
+// Decorate decorates a chaincode input with the external data referenced by
+// the proposal. The data which cannot be fetched or does not match its hash
+// is left out, for the chaincode to fail.
+func (d *decorator) Decorate(proposal *peer.Proposal, input *peer.ChaincodeInput) *peer.ChaincodeInput {
+	hdr, err := utils.GetHeader(proposal.Header)
+	if err != nil {
+		return input
+	}
+	ccHdrExt, err := utils.GetChaincodeHeaderExtension(hdr)
+	if err != nil {
+		return input
+	}
+
+	for _, data := range ccHdrExt.ExternalData {
+		value, err := d.fetch(data)
+		if err != nil {
+			logger.Warningf("Failed fetching external data %s: %s", data.Key, err)
+			continue
+		}
+		if input.Decorations == nil {
+			input.Decorations = make(map[string][]byte)
+		}
+		input.Decorations[DecorationPrefix+data.Key] = value
+	}
+	return input
+}
+
+// fetch returns the data of the given key, which must match the given hash
+func (d *decorator) fetch(data *peer.ExternalData) ([]byte, error) {
+	cacheKey := data.Key + "/" + hex.EncodeToString(data.Hash)
+
+	d.lock.Lock()
+	value, cached := d.cache[cacheKey]
+	d.lock.Unlock()
+	if cached {
+		return value, nil
+	}
+
+	value, err := d.resolver.Resolve(data.Key)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(util.ComputeSHA256(value), data.Hash) {
+		return nil, errors.New("the data does not match the hash of the proposal")
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if _, exists := d.cache[cacheKey]; !exists && d.cacheSize > 0 {
+		if len(d.keys) == d.cacheSize {
+			delete(d.cache, d.keys[0])
+			d.keys = d.keys[1:]
+		}
+		d.cache[cacheKey] = value
+		d.keys = append(d.keys, cacheKey)
+	}
+	return value, nil
+}
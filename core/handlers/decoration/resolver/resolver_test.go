@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resolver
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mapResolver struct {
+	data       map[string][]byte
+	resolved   []string
+	resolveErr error
+}
+
+func (r *mapResolver) Resolve(key string) ([]byte, error) {
+	r.resolved = append(r.resolved, key)
+	if r.resolveErr != nil {
+		return nil, r.resolveErr
+	}
+	return r.data[key], nil
+}
+
+func proposalWith(t *testing.T, data ...*peer.ExternalData) *peer.Proposal {
+	cis := &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			ChaincodeId: &peer.ChaincodeID{Name: "mycc"},
+			Input:       &peer.ChaincodeInput{Args: [][]byte{[]byte("invoke")}},
+		},
+	}
+	prop, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, "mychannel", cis, []byte("creator"))
+	assert.NoError(t, err)
+	assert.NoError(t, utils.SetProposalExternalData(prop, data...))
+	return prop
+}
+
+func TestDecorate(t *testing.T) {
+	r := &mapResolver{data: map[string][]byte{
+		"price": []byte("42"),
+		"rate":  []byte("1.5"),
+	}}
+	dec := NewDecorator(r, 1)
+
+	prop := proposalWith(t,
+		&peer.ExternalData{Key: "price", Hash: util.ComputeSHA256([]byte("42"))},
+		&peer.ExternalData{Key: "rate", Hash: util.ComputeSHA256([]byte("2.0"))},
+	)
+	input := dec.Decorate(prop, &peer.ChaincodeInput{Decorations: map[string][]byte{"other": []byte("decoration")}})
+	// the data not matching its hash is left out
+	assert.Equal(t, map[string][]byte{
+		"other":          []byte("decoration"),
+		"external:price": []byte("42"),
+	}, input.Decorations)
+	assert.Equal(t, []string{"price", "rate"}, r.resolved)
+
+	// the data is fetched once
+	input = dec.Decorate(prop, &peer.ChaincodeInput{})
+	assert.Equal(t, []byte("42"), input.Decorations["external:price"])
+	assert.Equal(t, []string{"price", "rate", "rate"}, r.resolved)
+
+	// the oldest data is evicted from the cache
+	r.data["price"] = []byte("43")
+	prop2 := proposalWith(t, &peer.ExternalData{Key: "price", Hash: util.ComputeSHA256([]byte("43"))})
+	input = dec.Decorate(prop2, &peer.ChaincodeInput{})
+	assert.Equal(t, []byte("43"), input.Decorations["external:price"])
+	r.resolved = nil
+	dec.Decorate(prop, &peer.ChaincodeInput{})
+	assert.Equal(t, []string{"price", "rate"}, r.resolved)
+
+	// the data which cannot be fetched is left out
+	r.resolveErr = errors.New("oracle unavailable")
+	input = dec.Decorate(proposalWith(t, &peer.ExternalData{Key: "rate", Hash: util.ComputeSHA256([]byte("1.5"))}), &peer.ChaincodeInput{})
+	assert.Empty(t, input.Decorations)
+
+	// the proposals without external data are not decorated
+	in := &peer.ChaincodeInput{Args: [][]byte{[]byte("invoke")}}
+	assert.Equal(t, in, dec.Decorate(proposalWith(t), in))
+	assert.Equal(t, in, dec.Decorate(&peer.Proposal{Header: []byte("garbage")}, in))
+}
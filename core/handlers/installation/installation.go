@@ -0,0 +1,31 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package installation
+
+import (
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+// Validator inspects the chaincode packages installed on the peer, and vetoes
+// the installation of those that violate the policies of the organization
+type Validator interface {
+	// Validate returns an error if the chaincode of the given deployment spec
+	// must not be installed
+	Validate(cds *peer.ChaincodeDeploymentSpec) error
+}
+
+// Validate applies the validators in the order provided, and returns the
+// error of the first validator that vetoes the installation
+func Validate(cds *peer.ChaincodeDeploymentSpec, validators ...Validator) error {
+	for _, validator := range validators {
+		if err := validator.Validate(cds); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
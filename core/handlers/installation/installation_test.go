@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package installation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	cds := &peer.ChaincodeDeploymentSpec{}
+
+	assert.NoError(t, Validate(cds))
+
+	first := &mockValidator{}
+	second := &mockValidator{}
+	assert.NoError(t, Validate(cds, first, second))
+	assert.Equal(t, cds, first.validated)
+	assert.Equal(t, cds, second.validated)
+
+	vetoing := &mockValidator{err: errors.New("math/rand is banned")}
+	last := &mockValidator{}
+	err := Validate(cds, &mockValidator{}, vetoing, last)
+	assert.EqualError(t, err, "math/rand is banned")
+	assert.Equal(t, cds, vetoing.validated)
+	assert.Nil(t, last.validated, "Expected the validators following the vetoing one not to be applied")
+}
+
+type mockValidator struct {
+	err       error
+	validated *peer.ChaincodeDeploymentSpec
+}
+
+func (v *mockValidator) Validate(cds *peer.ChaincodeDeploymentSpec) error {
+	v.validated = cds
+	return v.err
+}
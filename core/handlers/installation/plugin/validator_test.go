@@ -0,0 +1,22 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallValidator(t *testing.T) {
+	v := NewInstallValidator()
+	cds := &peer.ChaincodeDeploymentSpec{
+		CodePackage: []byte{1, 2, 3},
+	}
+	assert.NoError(t, v.Validate(cds))
+}
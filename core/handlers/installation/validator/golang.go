@@ -0,0 +1,162 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validator
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/handlers/installation"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// NewGoSourceCheck creates a Validator that vetoes the installation of the Go
+// chaincodes whose source files import one of the banned packages, or refer to
+// one of the banned functions. A banned function is qualified by the import
+// path of its package, such as time.Now
+func NewGoSourceCheck(bannedImports, bannedFunctions []string) (installation.Validator, error) {
+	c := &goSourceCheck{
+		bannedImports:   make(map[string]struct{}),
+		bannedFunctions: make(map[string]map[string]struct{}),
+	}
+	for _, importPath := range bannedImports {
+		c.bannedImports[importPath] = struct{}{}
+	}
+	for _, function := range bannedFunctions {
+		i := strings.LastIndex(function, ".")
+		if i <= 0 || i == len(function)-1 {
+			return nil, errors.Errorf("banned function %s is not qualified by the import path of its package", function)
+		}
+		importPath, name := function[:i], function[i+1:]
+		if c.bannedFunctions[importPath] == nil {
+			c.bannedFunctions[importPath] = make(map[string]struct{})
+		}
+		c.bannedFunctions[importPath][name] = struct{}{}
+	}
+	return c, nil
+}
+
+type goSourceCheck struct {
+	bannedImports map[string]struct{}
+	// bannedFunctions maps the import paths of packages to their banned functions
+	bannedFunctions map[string]map[string]struct{}
+}
+
+// Validate returns an error if a Go source file of the code package, other
+// than a test, imports a banned package or refers to a banned function
+func (c *goSourceCheck) Validate(cds *peer.ChaincodeDeploymentSpec) error {
+	if cds.ChaincodeSpec == nil || cds.ChaincodeSpec.Type != peer.ChaincodeSpec_GOLANG || len(cds.CodePackage) == 0 {
+		return nil
+	}
+
+	gr, err := gzip.NewReader(bytes.NewReader(cds.CodePackage))
+	if err != nil {
+		return errors.Wrap(err, "failure opening code package gzip stream")
+	}
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "failure reading code package")
+		}
+		if header.Typeflag != tar.TypeReg || !strings.HasSuffix(header.Name, ".go") || strings.HasSuffix(header.Name, "_test.go") {
+			continue
+		}
+		src, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return errors.Wrapf(err, "failure reading %s from code package", header.Name)
+		}
+		if err := c.checkFile(header.Name, src); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *goSourceCheck) checkFile(name string, src []byte) error {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, name, src, 0)
+	if err != nil {
+		return errors.Wrapf(err, "failed parsing %s", name)
+	}
+
+	// the local names of the imported packages that have banned functions
+	imported := make(map[string]string)
+	for _, imp := range f.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			return errors.Wrapf(err, "failed parsing import %s of %s", imp.Path.Value, name)
+		}
+		if _, banned := c.bannedImports[importPath]; banned {
+			return errors.Errorf("%s imports banned package %s", name, importPath)
+		}
+		if _, hasBanned := c.bannedFunctions[importPath]; !hasBanned {
+			continue
+		}
+		localName := defaultPackageName(importPath)
+		if imp.Name != nil {
+			localName = imp.Name.Name
+		}
+		switch localName {
+		case "_":
+			continue
+		case ".":
+			return errors.Errorf("%s dot-imports package %s, which has banned functions", name, importPath)
+		}
+		imported[localName] = importPath
+	}
+	if len(imported) == 0 {
+		return nil
+	}
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		if err != nil {
+			return false
+		}
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		// an identifier resolved by the parser is a local declaration
+		// shadowing the package name
+		id, ok := sel.X.(*ast.Ident)
+		if !ok || id.Obj != nil {
+			return true
+		}
+		importPath, ok := imported[id.Name]
+		if !ok {
+			return true
+		}
+		if _, banned := c.bannedFunctions[importPath][sel.Sel.Name]; banned {
+			err = errors.Errorf("%s: refers to banned function %s.%s", fset.Position(sel.Pos()), importPath, sel.Sel.Name)
+		}
+		return true
+	})
+	return err
+}
+
+// defaultPackageName returns the name a package is conventionally declared
+// with, the last element of its import path without any version suffix
+func defaultPackageName(importPath string) string {
+	name := path.Base(importPath)
+	if i := strings.Index(name, "."); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
@@ -0,0 +1,196 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validator
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+const chaincodeSource = `package main
+
+import (
+	"fmt"
+	%s
+)
+
+func main() {
+	%s
+	fmt.Println("done")
+}
+`
+
+func TestNewGoSourceCheck(t *testing.T) {
+	_, err := NewGoSourceCheck([]string{"math/rand"}, []string{"time.Now", "gopkg.in/yaml.v2.Marshal"})
+	assert.NoError(t, err)
+
+	for _, function := range []string{"Now", ".Now", "time."} {
+		_, err := NewGoSourceCheck(nil, []string{function})
+		assert.EqualError(t, err, "banned function "+function+" is not qualified by the import path of its package")
+	}
+}
+
+func TestGoSourceCheck(t *testing.T) {
+	check, err := NewGoSourceCheck([]string{"math/rand"}, []string{"time.Now", "gopkg.in/yaml.v2.Marshal"})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		imports     string
+		body        string
+		expectedErr string
+	}{
+		{
+			name: "no banned use",
+			imports: `"time"
+	"crypto/rand"`,
+			body: `_ = time.Unix(0, 0)
+	_, _ = rand.Read(nil)`,
+		},
+		{
+			name:        "banned import",
+			imports:     `"math/rand"`,
+			body:        `_ = rand.Int()`,
+			expectedErr: "src/cc/main.go imports banned package math/rand",
+		},
+		{
+			name:        "renamed banned import",
+			imports:     `mrand "math/rand"`,
+			body:        `_ = mrand.Int()`,
+			expectedErr: "src/cc/main.go imports banned package math/rand",
+		},
+		{
+			name:        "banned function",
+			imports:     `"time"`,
+			body:        `_ = time.Now()`,
+			expectedErr: "src/cc/main.go:9:6: refers to banned function time.Now",
+		},
+		{
+			name:        "banned function value",
+			imports:     `"time"`,
+			body:        `now := time.Now; _ = now()`,
+			expectedErr: "src/cc/main.go:9:9: refers to banned function time.Now",
+		},
+		{
+			name:        "banned function of renamed import",
+			imports:     `t "time"`,
+			body:        `_ = t.Now()`,
+			expectedErr: "src/cc/main.go:9:6: refers to banned function time.Now",
+		},
+		{
+			name:        "banned function of versioned package",
+			imports:     `"gopkg.in/yaml.v2"`,
+			body:        `_, _ = yaml.Marshal(nil)`,
+			expectedErr: "src/cc/main.go:9:9: refers to banned function gopkg.in/yaml.v2.Marshal",
+		},
+		{
+			name:        "dot import",
+			imports:     `. "time"`,
+			body:        `_ = Now()`,
+			expectedErr: "src/cc/main.go dot-imports package time, which has banned functions",
+		},
+		{
+			name:    "blank import",
+			imports: `_ "time"`,
+		},
+		{
+			name:    "shadowed package name",
+			imports: `"time"`,
+			body: `time := struct{ Now int }{}
+	_ = time.Now`,
+		},
+		{
+			name:        "unparsable source",
+			imports:     `"time`,
+			expectedErr: "failed parsing src/cc/main.go",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cds := &peer.ChaincodeDeploymentSpec{
+				ChaincodeSpec: &peer.ChaincodeSpec{Type: peer.ChaincodeSpec_GOLANG},
+				CodePackage: codePackage(t, map[string]string{
+					"src/cc/main.go": fmt.Sprintf(chaincodeSource, test.imports, test.body),
+				}),
+			}
+			err := check.Validate(cds)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestGoSourceCheckFiles(t *testing.T) {
+	check, err := NewGoSourceCheck([]string{"math/rand"}, nil)
+	assert.NoError(t, err)
+	banned := fmt.Sprintf(chaincodeSource, `"math/rand"`, `_ = rand.Int()`)
+
+	cds := &peer.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{Type: peer.ChaincodeSpec_GOLANG},
+		CodePackage: codePackage(t, map[string]string{
+			"src/cc/main.go":                    fmt.Sprintf(chaincodeSource, `"time"`, `_ = time.Now()`),
+			"src/cc/main_test.go":               banned,
+			"src/cc/README.md":                  banned,
+			"META-INF/statedb/couchdb/idx.json": "{}",
+		}),
+	}
+	assert.NoError(t, check.Validate(cds), "Expected tests and non Go files not to be checked")
+
+	cds.CodePackage = codePackage(t, map[string]string{
+		"src/cc/main.go":                 fmt.Sprintf(chaincodeSource, `"time"`, `_ = time.Now()`),
+		"src/cc/vendor/lib/lib.go":       banned,
+		"src/cc/vendor/lib/lib_other.go": banned,
+	})
+	assert.EqualError(t, check.Validate(cds), "src/cc/vendor/lib/lib.go imports banned package math/rand")
+
+	cds.ChaincodeSpec.Type = peer.ChaincodeSpec_NODE
+	assert.NoError(t, check.Validate(cds), "Expected chaincodes other than Go ones not to be checked")
+
+	cds.ChaincodeSpec.Type = peer.ChaincodeSpec_GOLANG
+	cds.CodePackage = []byte("not a code package")
+	err = check.Validate(cds)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failure opening code package gzip stream")
+}
+
+func codePackage(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	// the files are written in a stable order for the errors reported to be deterministic
+	for _, name := range sortedNames(files) {
+		content := files[name]
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		assert.NoError(t, err)
+		_, err = tw.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func sortedNames(files map[string]string) []string {
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validator
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/core/handlers/installation"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// maxScriptOutput is the maximum number of bytes of the output of a script
+// reported as the reason of a veto
+const maxScriptOutput = 1024
+
+// NewScriptCheck creates a Validator that runs the given executable for each
+// chaincode installed, and vetoes the installation if it exits with a non-zero
+// status. The executable is passed the name, version and type of the chaincode
+// and the path of a file holding its code package, and is killed if it runs
+// longer than the timeout
+func NewScriptCheck(script string, timeout time.Duration) installation.Validator {
+	return &scriptCheck{
+		script:  script,
+		timeout: timeout,
+	}
+}
+
+type scriptCheck struct {
+	script  string
+	timeout time.Duration
+}
+
+// Validate runs the script on the chaincode, and returns an error reporting
+// the output of the script if it does not exit successfully
+func (s *scriptCheck) Validate(cds *peer.ChaincodeDeploymentSpec) error {
+	f, err := ioutil.TempFile("", "ccpackage")
+	if err != nil {
+		return errors.Wrap(err, "failed creating code package file")
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(cds.CodePackage)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed writing code package file")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	spec := cds.ChaincodeSpec
+	cmd := exec.CommandContext(ctx, s.script, spec.ChaincodeId.Name, spec.ChaincodeId.Version, spec.Type.String(), f.Name())
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return errors.Errorf("install validation script %s timed out after %s", s.script, s.timeout)
+	}
+	if _, exited := err.(*exec.ExitError); exited {
+		reason := output
+		if len(reason) > maxScriptOutput {
+			reason = reason[:maxScriptOutput]
+		}
+		return errors.Errorf("install validation script %s vetoed the installation: %s", s.script, strings.TrimSpace(string(reason)))
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed running install validation script %s", s.script)
+	}
+	return nil
+}
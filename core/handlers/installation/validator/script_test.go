@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScriptCheck(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "scriptcheck")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	cds := &peer.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			Type:        peer.ChaincodeSpec_GOLANG,
+			ChaincodeId: &peer.ChaincodeID{Name: "mycc", Version: "1.0"},
+		},
+		CodePackage: []byte("code package"),
+	}
+
+	t.Run("accepted", func(t *testing.T) {
+		argsFile := filepath.Join(testDir, "args")
+		script := writeScript(t, testDir, "accept.sh", `echo "$1 $2 $3" > `+argsFile+`
+cat "$4" >> `+argsFile)
+		assert.NoError(t, NewScriptCheck(script, time.Minute).Validate(cds))
+
+		args, err := ioutil.ReadFile(argsFile)
+		assert.NoError(t, err)
+		assert.Equal(t, "mycc 1.0 GOLANG\ncode package", string(args))
+	})
+
+	t.Run("vetoed", func(t *testing.T) {
+		script := writeScript(t, testDir, "veto.sh", `echo "math/rand is banned"
+exit 1`)
+		err := NewScriptCheck(script, time.Minute).Validate(cds)
+		assert.EqualError(t, err, "install validation script "+script+" vetoed the installation: math/rand is banned")
+	})
+
+	t.Run("vetoed with long output", func(t *testing.T) {
+		script := writeScript(t, testDir, "verbose.sh", `head -c 2000 /dev/zero | tr '\0' x
+exit 1`)
+		err := NewScriptCheck(script, time.Minute).Validate(cds)
+		assert.EqualError(t, err, "install validation script "+script+" vetoed the installation: "+strings.Repeat("x", maxScriptOutput))
+	})
+
+	t.Run("timed out", func(t *testing.T) {
+		script := writeScript(t, testDir, "slow.sh", `exec sleep 10`)
+		err := NewScriptCheck(script, 100*time.Millisecond).Validate(cds)
+		assert.EqualError(t, err, "install validation script "+script+" timed out after 100ms")
+	})
+
+	t.Run("missing script", func(t *testing.T) {
+		script := filepath.Join(testDir, "missing.sh")
+		err := NewScriptCheck(script, time.Minute).Validate(cds)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed running install validation script "+script)
+	})
+}
+
+func writeScript(t *testing.T, dir, name, body string) string {
+	script := filepath.Join(dir, name)
+	err := ioutil.WriteFile(script, []byte("#!/bin/sh\n"+body+"\n"), 0755)
+	assert.NoError(t, err)
+	return script
+}
@@ -0,0 +1,130 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package library
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+// PluginOperation is an operation of an endorsement or validation plugin
+// which the registry reports
+type PluginOperation string
+
+const (
+	// PluginLoaded is reported once the factory of a plugin is loaded, and
+	// initialized if it implements FactoryLifecycle
+	PluginLoaded PluginOperation = "load"
+	// PluginInitialized is reported once a plugin instance, created for a
+	// channel, is initialized with its dependencies
+	PluginInitialized PluginOperation = "init"
+	// PluginInvoked is reported once a plugin instance endorses a proposal
+	// response or validates a transaction
+	PluginInvoked PluginOperation = "invoke"
+	// PluginClosed is reported once a plugin is closed on peer shutdown
+	PluginClosed PluginOperation = "close"
+)
+
+// Names of the metrics of the plugins, tagged by handler type, plugin and
+// operation
+const (
+	// pluginOperationsCounter counts the operations of the plugins
+	pluginOperationsCounter = "plugin_operations"
+	// pluginFailuresCounter counts the operations of the plugins which failed
+	pluginFailuresCounter = "plugin_failures"
+	// pluginDurationHistogram is the time in seconds the operations took
+	pluginDurationHistogram = "plugin_operation_duration"
+)
+
+// pluginDurationBuckets cover operations from a millisecond to a minute
+var pluginDurationBuckets = []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 60}
+
+// PluginEvent describes an operation of an endorsement or validation plugin
+type PluginEvent struct {
+	// HandlerType is Endorsement or Validation
+	HandlerType HandlerType
+	// Name is the name the plugin is registered under, as in escc or vscc
+	Name string
+	// Operation is the operation of the plugin
+	Operation PluginOperation
+	// Duration is the time the operation took
+	Duration time.Duration
+	// Err is the error the operation failed with, if it failed
+	Err error
+}
+
+// PluginListener is notified of the operations of the endorsement and
+// validation plugins. It is invoked synchronously, hence must not block.
+type PluginListener func(PluginEvent)
+
+var (
+	listenersLock sync.RWMutex
+	listeners     []PluginListener
+)
+
+// AddPluginListener adds a listener notified of the operations of the
+// plugins. Listeners added before the registry is initialized are notified
+// of the plugins loaded as well.
+func AddPluginListener(l PluginListener) {
+	listenersLock.Lock()
+	defer listenersLock.Unlock()
+	listeners = append(listeners, l)
+}
+
+// pluginReporter reports the operations of a plugin in the logs, the metrics
+// and to the listeners
+type pluginReporter struct {
+	handlerType HandlerType
+	name        string
+	scope       metrics.Scope
+}
+
+func newPluginReporter(handlerType HandlerType, name string) *pluginReporter {
+	return &pluginReporter{
+		handlerType: handlerType,
+		name:        name,
+		scope: metrics.SubScope("handlers").Tagged(map[string]string{
+			"type":   handlerType.String(),
+			"plugin": name,
+		}),
+	}
+}
+
+// report reports an operation which started at the given time and failed
+// with the given error, if not nil
+func (r *pluginReporter) report(op PluginOperation, start time.Time, err error) {
+	event := PluginEvent{
+		HandlerType: r.handlerType,
+		Name:        r.name,
+		Operation:   op,
+		Duration:    time.Since(start),
+		Err:         err,
+	}
+
+	scope := r.scope.Tagged(map[string]string{"operation": string(op)})
+	scope.Counter(pluginOperationsCounter).Inc(1)
+	scope.Histogram(pluginDurationHistogram, pluginDurationBuckets).RecordValue(event.Duration.Seconds())
+
+	log := logger.With("type", r.handlerType.String(), "plugin", r.name, "operation", string(op), "duration", event.Duration.String())
+	switch {
+	case err != nil:
+		scope.Counter(pluginFailuresCounter).Inc(1)
+		log.Warningf("Plugin operation failed: %s", err)
+	case op == PluginInvoked:
+		log.Debug("Plugin invoked")
+	default:
+		log.Info("Plugin operation completed")
+	}
+
+	listenersLock.RLock()
+	defer listenersLock.RUnlock()
+	for _, l := range listeners {
+		l(event)
+	}
+}
@@ -7,16 +7,27 @@ SPDX-License-Identifier: Apache-2.0
 package library
 
 import (
+	"time"
+
+	"github.com/hyperledger/fabric/common/viperutil"
+	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/handlers/auth"
 	"github.com/hyperledger/fabric/core/handlers/auth/filter"
 	"github.com/hyperledger/fabric/core/handlers/decoration"
 	"github.com/hyperledger/fabric/core/handlers/decoration/decorator"
 	"github.com/hyperledger/fabric/core/handlers/endorsement/api"
 	"github.com/hyperledger/fabric/core/handlers/endorsement/builtin"
+	"github.com/hyperledger/fabric/core/handlers/installation"
+	"github.com/hyperledger/fabric/core/handlers/installation/validator"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
 	. "github.com/hyperledger/fabric/core/handlers/validation/builtin"
+	"github.com/spf13/viper"
 )
 
+// defaultInstallScriptTimeout is the time an install validation script may
+// run for if chaincode.installValidation.script.timeout is not set
+const defaultInstallScriptTimeout = 30 * time.Second
+
 // HandlerLibrary is used to assert
 // how to create the various handlers
 type HandlerLibrary struct {
@@ -51,3 +62,32 @@ func (r *HandlerLibrary) DefaultEndorsement() endorsement.PluginFactory {
 func (r *HandlerLibrary) DefaultValidation() validation.PluginFactory {
 	return &DefaultValidationFactory{}
 }
+
+// GoSourceCheck is an install validator which vetoes the installation of
+// the Go chaincodes importing the packages, or referring to the functions,
+// banned by chaincode.installValidation.golang
+func (r *HandlerLibrary) GoSourceCheck() installation.Validator {
+	v, err := validator.NewGoSourceCheck(
+		viperutil.GetStringSlice("chaincode.installValidation.golang.bannedImports"),
+		viperutil.GetStringSlice("chaincode.installValidation.golang.bannedFunctions"),
+	)
+	if err != nil {
+		logger.Panicf("Invalid chaincode.installValidation.golang configuration: %s", err)
+	}
+	return v
+}
+
+// ScriptCheck is an install validator which runs the executable at
+// chaincode.installValidation.script.path on the chaincodes installed,
+// and vetoes their installation if it fails
+func (r *HandlerLibrary) ScriptCheck() installation.Validator {
+	script := config.GetPath("chaincode.installValidation.script.path")
+	if script == "" {
+		logger.Panicf("chaincode.installValidation.script.path must be set to use the ScriptCheck install validator")
+	}
+	timeout := viper.GetDuration("chaincode.installValidation.script.timeout")
+	if timeout <= 0 {
+		timeout = defaultInstallScriptTimeout
+	}
+	return validator.NewScriptCheck(script, timeout)
+}
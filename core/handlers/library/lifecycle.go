@@ -0,0 +1,176 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package library
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/core/handlers/endorsement/api"
+	"github.com/hyperledger/fabric/core/handlers/validation/api"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+// FactoryLifecycle is implemented by the factories of the endorsement and
+// validation plugins which hold resources shared by their plugin instances,
+// such as connections. The registry initializes such a factory once it is
+// loaded, and closes it when the peer shuts down so that it flushes its state
+// and releases the resources. The plugin instances holding resources of their
+// own implement io.Closer, and are closed before their factory.
+type FactoryLifecycle interface {
+	// Init is invoked once the factory is loaded, before it creates any
+	// plugin instance
+	Init() error
+	// Close is invoked when the peer shuts down, once the plugin instances
+	// of the factory are closed
+	Close() error
+}
+
+// instrumentedPlugins tracks the plugin instances of a factory, and reports
+// their operations
+type instrumentedPlugins struct {
+	reporter *pluginReporter
+	factory  interface{}
+
+	lock      sync.Mutex
+	instances []io.Closer
+}
+
+// init initializes the factory if it implements FactoryLifecycle
+func (p *instrumentedPlugins) init() error {
+	start := time.Now()
+	var err error
+	if lc, ok := p.factory.(FactoryLifecycle); ok {
+		err = lc.Init()
+	}
+	p.reporter.report(PluginLoaded, start, err)
+	return err
+}
+
+// track records a plugin instance which is closed along with the factory
+func (p *instrumentedPlugins) track(instance interface{}) {
+	closer, ok := instance.(io.Closer)
+	if !ok {
+		return
+	}
+	p.lock.Lock()
+	p.instances = append(p.instances, closer)
+	p.lock.Unlock()
+}
+
+// close closes the plugin instances and then the factory
+func (p *instrumentedPlugins) close() {
+	start := time.Now()
+	var closeErr error
+	p.lock.Lock()
+	for _, instance := range p.instances {
+		if err := instance.Close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+	p.instances = nil
+	p.lock.Unlock()
+	if lc, ok := p.factory.(FactoryLifecycle); ok {
+		if err := lc.Close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+	p.reporter.report(PluginClosed, start, closeErr)
+}
+
+// instrumentedEndorsementFactory creates endorsement plugins whose operations
+// are reported
+type instrumentedEndorsementFactory struct {
+	endorsement.PluginFactory
+	*instrumentedPlugins
+}
+
+func newInstrumentedEndorsementFactory(name string, factory endorsement.PluginFactory) *instrumentedEndorsementFactory {
+	return &instrumentedEndorsementFactory{
+		PluginFactory: factory,
+		instrumentedPlugins: &instrumentedPlugins{
+			reporter: newPluginReporter(Endorsement, name),
+			factory:  factory,
+		},
+	}
+}
+
+func (f *instrumentedEndorsementFactory) New() endorsement.Plugin {
+	return &instrumentedEndorsementPlugin{Plugin: f.PluginFactory.New(), plugins: f.instrumentedPlugins}
+}
+
+type instrumentedEndorsementPlugin struct {
+	endorsement.Plugin
+	plugins *instrumentedPlugins
+}
+
+func (p *instrumentedEndorsementPlugin) Init(dependencies ...endorsement.Dependency) error {
+	start := time.Now()
+	err := p.Plugin.Init(dependencies...)
+	if err == nil {
+		p.plugins.track(p.Plugin)
+	}
+	p.plugins.reporter.report(PluginInitialized, start, err)
+	return err
+}
+
+func (p *instrumentedEndorsementPlugin) Endorse(payload []byte, sp *peer.SignedProposal) (*peer.Endorsement, []byte, error) {
+	start := time.Now()
+	endorsement, output, err := p.Plugin.Endorse(payload, sp)
+	p.plugins.reporter.report(PluginInvoked, start, err)
+	return endorsement, output, err
+}
+
+// instrumentedValidationFactory creates validation plugins whose operations
+// are reported
+type instrumentedValidationFactory struct {
+	validation.PluginFactory
+	*instrumentedPlugins
+}
+
+func newInstrumentedValidationFactory(name string, factory validation.PluginFactory) *instrumentedValidationFactory {
+	return &instrumentedValidationFactory{
+		PluginFactory: factory,
+		instrumentedPlugins: &instrumentedPlugins{
+			reporter: newPluginReporter(Validation, name),
+			factory:  factory,
+		},
+	}
+}
+
+func (f *instrumentedValidationFactory) New() validation.Plugin {
+	return &instrumentedValidationPlugin{Plugin: f.PluginFactory.New(), plugins: f.instrumentedPlugins}
+}
+
+type instrumentedValidationPlugin struct {
+	validation.Plugin
+	plugins *instrumentedPlugins
+}
+
+func (p *instrumentedValidationPlugin) Init(dependencies ...validation.Dependency) error {
+	start := time.Now()
+	err := p.Plugin.Init(dependencies...)
+	if err == nil {
+		p.plugins.track(p.Plugin)
+	}
+	p.plugins.reporter.report(PluginInitialized, start, err)
+	return err
+}
+
+func (p *instrumentedValidationPlugin) Validate(block *common.Block, namespace string, txPosition int, actionPosition int, contextData ...validation.ContextDatum) error {
+	start := time.Now()
+	err := p.Plugin.Validate(block, namespace, txPosition, actionPosition, contextData...)
+	// Other errors invalidate the transaction, which the plugin validated
+	var failure error
+	if _, isExecutionFailure := err.(*validation.ExecutionFailureError); isExecutionFailure {
+		failure = err
+	}
+	p.plugins.reporter.report(PluginInvoked, start, failure)
+	return err
+}
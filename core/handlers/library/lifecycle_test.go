@@ -0,0 +1,163 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package library
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/handlers/endorsement/api"
+	"github.com/hyperledger/fabric/core/handlers/validation/api"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordedEvents records the events of the plugins registered under a name
+type recordedEvents struct {
+	lock   sync.Mutex
+	events []PluginEvent
+}
+
+func recordEvents(name string) *recordedEvents {
+	r := &recordedEvents{}
+	AddPluginListener(func(e PluginEvent) {
+		if e.Name != name {
+			return
+		}
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		r.events = append(r.events, e)
+	})
+	return r
+}
+
+// operations returns the operations recorded, suffixed with "!" if failed
+func (r *recordedEvents) operations() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var ops []string
+	for _, e := range r.events {
+		op := string(e.Operation)
+		if e.Err != nil {
+			op += "!"
+		}
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+type lifecycleEndorsementFactory struct {
+	calls   *[]string
+	initErr error
+}
+
+func (f *lifecycleEndorsementFactory) New() endorsement.Plugin {
+	return &closingEndorsementPlugin{calls: f.calls}
+}
+
+func (f *lifecycleEndorsementFactory) Init() error {
+	*f.calls = append(*f.calls, "init factory")
+	return f.initErr
+}
+
+func (f *lifecycleEndorsementFactory) Close() error {
+	*f.calls = append(*f.calls, "close factory")
+	return nil
+}
+
+type closingEndorsementPlugin struct {
+	calls *[]string
+}
+
+func (p *closingEndorsementPlugin) Endorse(payload []byte, sp *peer.SignedProposal) (*peer.Endorsement, []byte, error) {
+	if sp == nil {
+		return nil, nil, errors.New("no proposal")
+	}
+	return &peer.Endorsement{}, payload, nil
+}
+
+func (p *closingEndorsementPlugin) Init(dependencies ...endorsement.Dependency) error {
+	*p.calls = append(*p.calls, "init plugin")
+	return nil
+}
+
+func (p *closingEndorsementPlugin) Close() error {
+	*p.calls = append(*p.calls, "close plugin")
+	return errors.New("flush failed")
+}
+
+func TestEndorsementPluginLifecycle(t *testing.T) {
+	events := recordEvents("lifecycle-escc")
+	var calls []string
+	testReg := registry{endorsers: make(map[string]endorsement.PluginFactory)}
+	testReg.addEndorser("lifecycle-escc", &lifecycleEndorsementFactory{calls: &calls})
+
+	factory := testReg.Lookup(Endorsement).(map[string]endorsement.PluginFactory)["lifecycle-escc"]
+	plugin := factory.New()
+	assert.NoError(t, plugin.Init())
+	_, output, err := plugin.Endorse([]byte{1, 2, 3}, &peer.SignedProposal{})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, output)
+	_, _, err = plugin.Endorse([]byte{1, 2, 3}, nil)
+	assert.EqualError(t, err, "no proposal")
+
+	testReg.Close()
+	assert.Equal(t, []string{"init factory", "init plugin", "close plugin", "close factory"}, calls)
+	assert.Equal(t, []string{"load", "init", "invoke", "invoke!", "close!"}, events.operations())
+	for _, e := range events.events {
+		assert.Equal(t, Endorsement, e.HandlerType)
+	}
+	assert.EqualError(t, events.events[4].Err, "flush failed")
+}
+
+func TestEndorsementPluginInitFailure(t *testing.T) {
+	events := recordEvents("failing-escc")
+	var calls []string
+	testReg := registry{endorsers: make(map[string]endorsement.PluginFactory)}
+	assert.Panics(t, func() {
+		testReg.addEndorser("failing-escc", &lifecycleEndorsementFactory{calls: &calls, initErr: errors.New("no connection")})
+	})
+	assert.Equal(t, []string{"load!"}, events.operations())
+	assert.Empty(t, testReg.endorsers)
+}
+
+type failingValidationFactory struct{}
+
+func (failingValidationFactory) New() validation.Plugin {
+	return failingValidationPlugin{}
+}
+
+type failingValidationPlugin struct{}
+
+func (failingValidationPlugin) Init(dependencies ...validation.Dependency) error {
+	return nil
+}
+
+func (failingValidationPlugin) Validate(block *common.Block, namespace string, txPosition int, actionPosition int, contextData ...validation.ContextDatum) error {
+	if namespace == "" {
+		return &validation.ExecutionFailureError{Reason: "no namespace"}
+	}
+	return errors.New("invalid transaction")
+}
+
+func TestValidationPluginEvents(t *testing.T) {
+	events := recordEvents("events-vscc")
+	testReg := registry{validators: make(map[string]validation.PluginFactory)}
+	testReg.addValidator("events-vscc", failingValidationFactory{})
+
+	plugin := testReg.Lookup(Validation).(map[string]validation.PluginFactory)["events-vscc"].New()
+	assert.NoError(t, plugin.Init())
+	// an invalid transaction is validated, the plugin does not fail
+	assert.EqualError(t, plugin.Validate(nil, "ns", 0, 0), "invalid transaction")
+	assert.EqualError(t, plugin.Validate(nil, "", 0, 0), "no namespace")
+
+	testReg.Close()
+	assert.Equal(t, []string{"load", "init", "invoke", "invoke!", "close"}, events.operations())
+	assert.Equal(t, Validation, events.events[0].HandlerType)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric/core/handlers/auth"
 	"github.com/hyperledger/fabric/core/handlers/decoration"
 	endorsement2 "github.com/hyperledger/fabric/core/handlers/endorsement/api"
+	"github.com/hyperledger/fabric/core/handlers/installation"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
 )
 
@@ -28,6 +29,10 @@ type Registry interface {
 	// Lookup returns a handler with a given
 	// registered name, or nil if does not exist
 	Lookup(HandlerType) interface{}
+
+	// Close closes the endorsement and validation
+	// plugins when the peer shuts down
+	Close()
 }
 
 // HandlerType defines custom handlers that can filter and mutate
@@ -42,17 +47,45 @@ const (
 	Decoration
 	Endorsement
 	Validation
-
-	authPluginFactory      = "NewFilter"
-	decoratorPluginFactory = "NewDecorator"
-	pluginFactory          = "NewPluginFactory"
+	// InstallValidation handler - inspect chaincode packages and
+	// veto their installation on the peer
+	InstallValidation
+
+	authPluginFactory             = "NewFilter"
+	decoratorPluginFactory        = "NewDecorator"
+	pluginFactory                 = "NewPluginFactory"
+	installValidatorPluginFactory = "NewInstallValidator"
 )
 
+// String returns the name of the handler type
+func (t HandlerType) String() string {
+	switch t {
+	case Auth:
+		return "auth"
+	case Decoration:
+		return "decoration"
+	case Endorsement:
+		return "endorsement"
+	case Validation:
+		return "validation"
+	case InstallValidation:
+		return "installvalidation"
+	default:
+		return fmt.Sprintf("HandlerType(%d)", int(t))
+	}
+}
+
 type registry struct {
 	filters    []auth.Filter
 	decorators []decoration.Decorator
 	endorsers  map[string]endorsement2.PluginFactory
 	validators map[string]validation.PluginFactory
+
+	installValidators []installation.Validator
+
+	// plugins holds the endorsement and validation plugins
+	// closed when the peer shuts down
+	plugins []*instrumentedPlugins
 }
 
 var once sync.Once
@@ -65,6 +98,8 @@ type Config struct {
 	Decorators  []*HandlerConfig `mapstructure:"decorators" yaml:"decorators"`
 	Endorsers   PluginMapping    `mapstructure:"endorsers" yaml:"endorsers"`
 	Validators  PluginMapping    `mapstructure:"validators" yaml:"validators"`
+
+	InstallValidators []*HandlerConfig `mapstructure:"installValidators" yaml:"installValidators"`
 }
 
 type PluginMapping map[string]*HandlerConfig
@@ -104,6 +139,10 @@ func (r *registry) loadHandlers(c Config) {
 	for chaincodeID, config := range c.Validators {
 		r.evaluateModeAndLoad(config, Validation, chaincodeID)
 	}
+
+	for _, config := range c.InstallValidators {
+		r.evaluateModeAndLoad(config, InstallValidation)
+	}
 }
 
 // evaluateModeAndLoad if a library path is provided, load the shared object
@@ -134,12 +173,14 @@ func (r *registry) loadCompiled(handlerFactory string, handlerType HandlerType,
 		if len(extraArgs) != 1 {
 			logger.Panicf("expected 1 argument in extraArgs")
 		}
-		r.endorsers[extraArgs[0]] = inst.(endorsement2.PluginFactory)
+		r.addEndorser(extraArgs[0], inst.(endorsement2.PluginFactory))
 	} else if handlerType == Validation {
 		if len(extraArgs) != 1 {
 			logger.Panicf("expected 1 argument in extraArgs")
 		}
-		r.validators[extraArgs[0]] = inst.(validation.PluginFactory)
+		r.addValidator(extraArgs[0], inst.(validation.PluginFactory))
+	} else if handlerType == InstallValidation {
+		r.installValidators = append(r.installValidators, inst.(installation.Validator))
 	}
 }
 
@@ -161,6 +202,8 @@ func (r *registry) loadPlugin(pluginPath string, handlerType HandlerType, extraA
 		r.initEndorsementPlugin(p, extraArgs...)
 	} else if handlerType == Validation {
 		r.initValidationPlugin(p, extraArgs...)
+	} else if handlerType == InstallValidation {
+		r.initInstallValidationPlugin(p)
 	}
 }
 
@@ -214,7 +257,7 @@ func (r *registry) initEndorsementPlugin(p *plugin.Plugin, extraArgs ...string)
 	if factory == nil {
 		logger.Panicf("factory instance returned nil")
 	}
-	r.endorsers[extraArgs[0]] = factory
+	r.addEndorser(extraArgs[0], factory)
 }
 
 func (r *registry) initValidationPlugin(p *plugin.Plugin, extraArgs ...string) {
@@ -234,7 +277,46 @@ func (r *registry) initValidationPlugin(p *plugin.Plugin, extraArgs ...string) {
 	if factory == nil {
 		logger.Panicf("factory instance returned nil")
 	}
-	r.validators[extraArgs[0]] = factory
+	r.addValidator(extraArgs[0], factory)
+}
+
+// addEndorser registers an endorsement plugin factory under the given name,
+// once initialized, reporting the operations of its plugins
+func (r *registry) addEndorser(name string, factory endorsement2.PluginFactory) {
+	instrumented := newInstrumentedEndorsementFactory(name, factory)
+	if err := instrumented.init(); err != nil {
+		logger.Panicf("Failed initializing endorsement plugin %s: %s", name, err)
+	}
+	r.endorsers[name] = instrumented
+	r.plugins = append(r.plugins, instrumented.instrumentedPlugins)
+}
+
+// addValidator registers a validation plugin factory under the given name,
+// once initialized, reporting the operations of its plugins
+func (r *registry) addValidator(name string, factory validation.PluginFactory) {
+	instrumented := newInstrumentedValidationFactory(name, factory)
+	if err := instrumented.init(); err != nil {
+		logger.Panicf("Failed initializing validation plugin %s: %s", name, err)
+	}
+	r.validators[name] = instrumented
+	r.plugins = append(r.plugins, instrumented.instrumentedPlugins)
+}
+
+// initInstallValidationPlugin constructs an install validator from the given plugin
+func (r *registry) initInstallValidationPlugin(p *plugin.Plugin) {
+	constructorSymbol, err := p.Lookup(installValidatorPluginFactory)
+	if err != nil {
+		panicWithLookupError(installValidatorPluginFactory, err)
+	}
+	constructor, ok := constructorSymbol.(func() installation.Validator)
+	if !ok {
+		panicWithDefinitionError(installValidatorPluginFactory)
+	}
+
+	validator := constructor()
+	if validator != nil {
+		r.installValidators = append(r.installValidators, validator)
+	}
 }
 
 // panicWithLookupError panics when a handler constructor lookup fails
@@ -261,7 +343,19 @@ func (r *registry) Lookup(handlerType HandlerType) interface{} {
 		return r.endorsers
 	} else if handlerType == Validation {
 		return r.validators
+	} else if handlerType == InstallValidation {
+		return r.installValidators
 	}
 
 	return nil
 }
+
+// Close closes the plugin instances of the endorsement and validation
+// plugins, and then their factories. Failures are reported, not returned,
+// so that the peer goes on shutting down.
+func (r *registry) Close() {
+	for _, plugins := range r.plugins {
+		plugins.close()
+	}
+	r.plugins = nil
+}
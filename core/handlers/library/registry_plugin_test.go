@@ -19,6 +19,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/handlers/endorsement/api"
+	"github.com/hyperledger/fabric/core/handlers/installation"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/stretchr/testify/assert"
@@ -29,6 +30,8 @@ const (
 	decoratorPluginPackage = "github.com/hyperledger/fabric/core/handlers/decoration/plugin"
 	endorsementTestPlugin  = "github.com/hyperledger/fabric/core/handlers/endorsement/testdata/"
 	validationTestPlugin   = "github.com/hyperledger/fabric/core/handlers/validation/testdata/"
+
+	installValidatorPluginPackage = "github.com/hyperledger/fabric/core/handlers/installation/plugin"
 )
 
 // raceEnabled is set to true when the race build tag is enabled.
@@ -124,6 +127,21 @@ func TestValidationPlugin(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestLoadInstallValidatorPlugin(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "")
+	assert.NoError(t, err, "Could not create temp directory for plugins")
+	defer os.Remove(testDir)
+
+	pluginPath := filepath.Join(testDir, "installvalidatorplugin.so")
+	buildPlugin(t, pluginPath, installValidatorPluginPackage)
+
+	testReg := registry{}
+	testReg.loadPlugin(pluginPath, InstallValidation)
+	validators := testReg.Lookup(InstallValidation).([]installation.Validator)
+	assert.Len(t, validators, 1, "Expected install validator to be registered")
+	assert.NoError(t, validators[0].Validate(&peer.ChaincodeDeploymentSpec{}))
+}
+
 func TestLoadPluginInvalidPath(t *testing.T) {
 	defer func() {
 		if r := recover(); r == nil {
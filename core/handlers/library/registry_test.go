@@ -7,10 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package library
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hyperledger/fabric/core/handlers/auth"
 	"github.com/hyperledger/fabric/core/handlers/decoration"
+	"github.com/hyperledger/fabric/core/handlers/installation"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,3 +49,43 @@ func TestLoadCompiledInvalid(t *testing.T) {
 	testReg := registry{}
 	testReg.loadCompiled("InvalidFactory", Auth)
 }
+
+func TestLoadCompiledInstallValidators(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("chaincode.installValidation.golang.bannedImports", []string{"math/rand"})
+	viper.Set("chaincode.installValidation.golang.bannedFunctions", []string{"time.Now"})
+
+	testReg := registry{}
+	testReg.loadCompiled("GoSourceCheck", InstallValidation)
+	validators := testReg.Lookup(InstallValidation).([]installation.Validator)
+	assert.Len(t, validators, 1)
+	assert.NoError(t, validators[0].Validate(&peer.ChaincodeDeploymentSpec{}))
+
+	scriptDir, err := ioutil.TempDir("", "installvalidation")
+	assert.NoError(t, err)
+	defer os.RemoveAll(scriptDir)
+	script := filepath.Join(scriptDir, "veto.sh")
+	err = ioutil.WriteFile(script, []byte("#!/bin/sh\necho vetoed\nexit 1\n"), 0755)
+	assert.NoError(t, err)
+	viper.Set("chaincode.installValidation.script.path", script)
+
+	testReg.loadCompiled("ScriptCheck", InstallValidation)
+	validators = testReg.Lookup(InstallValidation).([]installation.Validator)
+	assert.Len(t, validators, 2)
+	err = validators[1].Validate(&peer.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{ChaincodeId: &peer.ChaincodeID{Name: "mycc", Version: "1.0"}},
+	})
+	assert.EqualError(t, err, "install validation script "+script+" vetoed the installation: vetoed")
+}
+
+func TestLoadCompiledInstallValidatorsMisconfigured(t *testing.T) {
+	defer viper.Reset()
+	testReg := registry{}
+
+	viper.Set("chaincode.installValidation.golang.bannedFunctions", []string{"Now"})
+	assert.Panics(t, func() { testReg.loadCompiled("GoSourceCheck", InstallValidation) })
+
+	assert.Panics(t, func() { testReg.loadCompiled("ScriptCheck", InstallValidation) },
+		"Expected panic when the script of ScriptCheck is not configured")
+	assert.Empty(t, testReg.installValidators)
+}
@@ -51,4 +51,36 @@ type Capabilities interface {
 	// KeyLevelEndorsement returns true if this channel supports endorsement
 	// policies expressible at a ledger key granularity, as described in FAB-8812
 	KeyLevelEndorsement() bool
+
+	// MaintenanceMode returns true if the channel state may be specified in the
+	// channel application config, so that the channel can be put in maintenance
+	MaintenanceMode() bool
+
+	// TxValidityWindow returns true if the validity windows of the transactions
+	// are enforced at commit, against the time of the blocks holding them
+	TxValidityWindow() bool
+
+	// ChaincodeRetirement returns true if the chaincodes may be retired through
+	// lscc, after which their invocations are rejected
+	ChaincodeRetirement() bool
+
+	// ChannelConfigPolicyReference returns true if the endorsement policy of a chaincode
+	// may reference a policy of the channel config instead of being a signature policy
+	ChannelConfigPolicyReference() bool
+
+	// CollectionEndorsementPolicy returns true if the collections may define the endorsement
+	// policy of their writes, enforced by the v1.3 validation in place of the chaincode one
+	CollectionEndorsementPolicy() bool
+
+	// RangeQueryValidation returns true if the range queries of the transactions are
+	// checked at commit to carry well formed results within the bound of the channel
+	RangeQueryValidation() bool
+
+	// DefaultAnchorPeers returns true if the peers use the peer endpoints of the orgs
+	// which define no anchor peers as their anchor peers for gossip
+	DefaultAnchorPeers() bool
+
+	// CouchDBBinaryValues returns true if the peers store the binary values in the
+	// documents of the CouchDB state database rather than in attachments
+	CouchDBBinaryValues() bool
 }
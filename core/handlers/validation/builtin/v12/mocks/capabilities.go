@@ -22,6 +22,48 @@ func (_m *Capabilities) ACLs() bool {
 	return r0
 }
 
+// ChaincodeRetirement provides a mock function with given fields:
+func (_m *Capabilities) ChaincodeRetirement() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ChannelConfigPolicyReference provides a mock function with given fields:
+func (_m *Capabilities) ChannelConfigPolicyReference() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// CollectionEndorsementPolicy provides a mock function with given fields:
+func (_m *Capabilities) CollectionEndorsementPolicy() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // CollectionUpgrade provides a mock function with given fields:
 func (_m *Capabilities) CollectionUpgrade() bool {
 	ret := _m.Called()
@@ -36,6 +78,34 @@ func (_m *Capabilities) CollectionUpgrade() bool {
 	return r0
 }
 
+// CouchDBBinaryValues provides a mock function with given fields:
+func (_m *Capabilities) CouchDBBinaryValues() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// DefaultAnchorPeers provides a mock function with given fields:
+func (_m *Capabilities) DefaultAnchorPeers() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // ForbidDuplicateTXIdInBlock provides a mock function with given fields:
 func (_m *Capabilities) ForbidDuplicateTXIdInBlock() bool {
 	ret := _m.Called()
@@ -64,6 +134,20 @@ func (_m *Capabilities) KeyLevelEndorsement() bool {
 	return r0
 }
 
+// MaintenanceMode provides a mock function with given fields:
+func (_m *Capabilities) MaintenanceMode() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // MetadataLifecycle provides a mock function with given fields:
 func (_m *Capabilities) MetadataLifecycle() bool {
 	ret := _m.Called()
@@ -92,6 +176,20 @@ func (_m *Capabilities) PrivateChannelData() bool {
 	return r0
 }
 
+// RangeQueryValidation provides a mock function with given fields:
+func (_m *Capabilities) RangeQueryValidation() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // Supported provides a mock function with given fields:
 func (_m *Capabilities) Supported() error {
 	ret := _m.Called()
@@ -106,6 +204,20 @@ func (_m *Capabilities) Supported() error {
 	return r0
 }
 
+// TxValidityWindow provides a mock function with given fields:
+func (_m *Capabilities) TxValidityWindow() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // V1_1Validation provides a mock function with given fields:
 func (_m *Capabilities) V1_1Validation() bool {
 	ret := _m.Called()
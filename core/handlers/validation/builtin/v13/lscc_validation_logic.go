@@ -99,6 +99,22 @@ func validateNewCollectionConfigs(newCollectionConfigs []*common.CollectionConfi
 	return nil
 }
 
+// validateCollectionEndorsementPolicies checks that the collections defining
+// an endorsement policy supply a signature policy
+func validateCollectionEndorsementPolicies(newCollectionConfigs []*common.CollectionConfig) error {
+	for _, newCollectionConfig := range newCollectionConfigs {
+		newCollection := newCollectionConfig.GetStaticCollectionConfig()
+		endorsementPolicy := newCollection.GetEndorsementPolicy()
+		if endorsementPolicy == nil {
+			continue
+		}
+		if endorsementPolicy.GetSignaturePolicy().GetRule() == nil {
+			return fmt.Errorf("collection-name: %s -- collection endorsement policy is empty", newCollection.GetName())
+		}
+	}
+	return nil
+}
+
 // validateSpOrConcat checks if the supplied signature policy is just an OR-concatenation of identities
 func validateSpOrConcat(sp *common.SignaturePolicy) error {
 	if sp.GetNOutOf() == nil {
@@ -305,6 +321,12 @@ func (vscc *Validator) validateRWSetAndCollection(
 			return policyErr(err)
 		}
 
+		if ac.CollectionEndorsementPolicy() {
+			if err := validateCollectionEndorsementPolicies(newCollectionConfigs); err != nil {
+				return policyErr(err)
+			}
+		}
+
 		if lsccFunc == lscc.UPGRADE {
 
 			collectionCriteria := common.CollectionCriteria{Channel: channelName, Namespace: cdRWSet.Name}
@@ -401,30 +423,9 @@ func (vscc *Validator) ValidateLSCCInvocation(
 		}
 
 		// get the rwset
-		pRespPayload, err := utils.GetProposalResponsePayload(cap.Action.ProposalResponsePayload)
-		if err != nil {
-			return policyErr(fmt.Errorf("GetProposalResponsePayload error %s", err))
-		}
-		if pRespPayload.Extension == nil {
-			return policyErr(fmt.Errorf("nil pRespPayload.Extension"))
-		}
-		respPayload, err := utils.GetChaincodeAction(pRespPayload.Extension)
-		if err != nil {
-			return policyErr(fmt.Errorf("GetChaincodeAction error %s", err))
-		}
-		txRWSet := &rwsetutil.TxRwSet{}
-		if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
-			return policyErr(fmt.Errorf("txRWSet.FromProtoBytes error %s", err))
-		}
-
-		// extract the rwset for lscc
-		var lsccrwset *kvrwset.KVRWSet
-		for _, ns := range txRWSet.NsRwSets {
-			logger.Debugf("Namespace %s", ns.NameSpace)
-			if ns.NameSpace == "lscc" {
-				lsccrwset = ns.KvRwSet
-				break
-			}
+		txRWSet, lsccrwset, vErr := lsccRWSet(cap)
+		if vErr != nil {
+			return vErr
 		}
 
 		// retrieve from the ledger the entry for the chaincode at hand
@@ -521,6 +522,9 @@ func (vscc *Validator) ValidateLSCCInvocation(
 			if !ccExistsOnLedger {
 				return policyErr(fmt.Errorf("Upgrading non-existent chaincode %s", cdsArgs.ChaincodeSpec.ChaincodeId.Name))
 			}
+			if cdLedger.Retired {
+				return policyErr(fmt.Errorf("Upgrading retired chaincode %s", cdsArgs.ChaincodeSpec.ChaincodeId.Name))
+			}
 
 			/**********************************************************/
 			/* security check 2 - existing cc's version was different */
@@ -584,11 +588,126 @@ func (vscc *Validator) ValidateLSCCInvocation(
 
 		// all is good!
 		return nil
+	case lscc.RETIRE:
+		logger.Debugf("VSCC info: validating invocation of lscc function %s on arguments %#v", lsccFunc, lsccArgs)
+
+		if !ac.ChaincodeRetirement() {
+			return policyErr(fmt.Errorf("VSCC error: chaincode retirement is not enabled, lscc(%s) is invalid", lsccFunc))
+		}
+
+		if len(lsccArgs) != 2 && len(lsccArgs) != 3 {
+			return policyErr(fmt.Errorf("Wrong number of arguments for invocation lscc(%s): expected 2 or 3, received %d", lsccFunc, len(lsccArgs)))
+		}
+
+		ccName := string(lsccArgs[1])
+		archive := len(lsccArgs) == 3
+		if archive && string(lsccArgs[2]) != lscc.ARCHIVE {
+			return policyErr(fmt.Errorf("VSCC error: invocation of lscc(%s) does not have appropriate arguments", lsccFunc))
+		}
+
+		if cap.Action == nil || cap.Action.ProposalResponsePayload == nil {
+			return policyErr(fmt.Errorf("VSCC error: invocation of lscc(%s) does not have appropriate arguments", lsccFunc))
+		}
+
+		txRWSet, lsccrwset, vErr := lsccRWSet(cap)
+		if vErr != nil {
+			return vErr
+		}
+
+		// retrieve from the ledger the entry for the chaincode at hand
+		cdLedger, ccExistsOnLedger, err := vscc.getInstantiatedCC(chid, ccName)
+		if err != nil {
+			return &commonerrors.VSCCExecutionFailureError{Err: err}
+		}
+
+		/******************************************/
+		/* security check 0 - validation of rwset */
+		/******************************************/
+		if lsccrwset == nil {
+			return policyErr(fmt.Errorf("No read write set for lscc was found"))
+		}
+		// there must be a single write, to the key of the retired chaincode
+		if len(lsccrwset.Writes) != 1 {
+			return policyErr(fmt.Errorf("LSCC can only issue a single putState upon retire"))
+		}
+		if lsccrwset.Writes[0].Key != ccName {
+			return policyErr(fmt.Errorf("expected key %s, found %s", ccName, lsccrwset.Writes[0].Key))
+		}
+		// it must only write to LSCC's namespace
+		for _, ns := range txRWSet.NsRwSets {
+			if ns.NameSpace != "lscc" && len(ns.KvRwSet.Writes) > 0 {
+				return policyErr(fmt.Errorf("LSCC invocation is attempting to write to namespace %s", ns.NameSpace))
+			}
+		}
+		cdRWSet := &ccprovider.ChaincodeData{}
+		err = proto.Unmarshal(lsccrwset.Writes[0].Value, cdRWSet)
+		if err != nil {
+			return policyErr(fmt.Errorf("unmarhsalling of ChaincodeData failed, error %s", err))
+		}
+
+		/*****************************************************************************/
+		/* security check 1 - cc in the LCCC table of instantiated cc, not retired */
+		/*****************************************************************************/
+		if !ccExistsOnLedger {
+			return policyErr(fmt.Errorf("Retiring non-existent chaincode %s", ccName))
+		}
+		if cdLedger.Retired {
+			return policyErr(fmt.Errorf("Chaincode %s is already retired", ccName))
+		}
+
+		/*************************************************************************/
+		/* security check 2 - the rwset only retires the cc found on the ledger */
+		/*************************************************************************/
+		expected := *cdLedger
+		expected.Retired = true
+		expected.ArchiveState = archive
+		if !proto.Equal(&expected, cdRWSet) {
+			return policyErr(fmt.Errorf("the ChaincodeData in the lscc writeset does not retire chaincode %s:%s", cdLedger.Name, cdLedger.Version))
+		}
+
+		/*****************************************************/
+		/* security check 3 - check the instantiation policy */
+		/*****************************************************/
+		pol := cdLedger.InstantiationPolicy
+		if pol == nil {
+			return policyErr(fmt.Errorf("No instantiation policy was specified"))
+		}
+		return vscc.checkInstantiationPolicy(chid, env, pol, payl)
 	default:
 		return policyErr(fmt.Errorf("VSCC error: committing an invocation of function %s of lscc is invalid", lsccFunc))
 	}
 }
 
+// lsccRWSet extracts the rwset of the transaction and the one of lscc, if any
+func lsccRWSet(cap *pb.ChaincodeActionPayload) (*rwsetutil.TxRwSet, *kvrwset.KVRWSet, commonerrors.TxValidationError) {
+	pRespPayload, err := utils.GetProposalResponsePayload(cap.Action.ProposalResponsePayload)
+	if err != nil {
+		return nil, nil, policyErr(fmt.Errorf("GetProposalResponsePayload error %s", err))
+	}
+	if pRespPayload.Extension == nil {
+		return nil, nil, policyErr(fmt.Errorf("nil pRespPayload.Extension"))
+	}
+	respPayload, err := utils.GetChaincodeAction(pRespPayload.Extension)
+	if err != nil {
+		return nil, nil, policyErr(fmt.Errorf("GetChaincodeAction error %s", err))
+	}
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+		return nil, nil, policyErr(fmt.Errorf("txRWSet.FromProtoBytes error %s", err))
+	}
+
+	// extract the rwset for lscc
+	var lsccrwset *kvrwset.KVRWSet
+	for _, ns := range txRWSet.NsRwSets {
+		logger.Debugf("Namespace %s", ns.NameSpace)
+		if ns.NameSpace == "lscc" {
+			lsccrwset = ns.KvRwSet
+			break
+		}
+	}
+	return txRWSet, lsccrwset, nil
+}
+
 func (vscc *Validator) getInstantiatedCC(chid, ccid string) (cd *ccprovider.ChaincodeData, exists bool, err error) {
 	qe, err := vscc.stateFetcher.FetchState()
 	if err != nil {
@@ -1716,6 +1716,159 @@ func TestValidateUpgradeWithPoliciesFail(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func createLSCCRetireTx(ccname, ccver string, args [][]byte, res []byte) (*common.Envelope, error) {
+	cis := &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			ChaincodeId: &peer.ChaincodeID{Name: "lscc"},
+			Input:       &peer.ChaincodeInput{Args: append([][]byte{[]byte(lscc.RETIRE)}, args...)},
+			Type:        peer.ChaincodeSpec_GOLANG,
+		},
+	}
+
+	prop, _, err := utils.CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, util.GetTestChainID(), cis, sid)
+	if err != nil {
+		return nil, err
+	}
+
+	ccid := &peer.ChaincodeID{Name: ccname, Version: ccver}
+
+	presp, err := utils.CreateProposalResponse(prop.Header, prop.Payload, &peer.Response{Status: 200}, res, nil, ccid, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.CreateSignedTx(prop, id, presp)
+}
+
+func TestValidateRetire(t *testing.T) {
+	ccname := "mycc"
+	ccver := "1"
+
+	ip, err := getSignedByMSPMemberPolicy(mspid)
+	assert.NoError(t, err)
+	policy, err := getSignedByMSPMemberPolicy(mspid)
+	assert.NoError(t, err)
+
+	cdLedger := &ccprovider.ChaincodeData{Name: ccname, Version: ccver, Vscc: "vscc", Policy: policy, InstantiationPolicy: ip}
+	retired := &ccprovider.ChaincodeData{Name: ccname, Version: ccver, Vscc: "vscc", Policy: policy, InstantiationPolicy: ip, Retired: true}
+	archived := &ccprovider.ChaincodeData{Name: ccname, Version: ccver, Vscc: "vscc", Policy: policy, InstantiationPolicy: ip, Retired: true, ArchiveState: true}
+	tampered := &ccprovider.ChaincodeData{Name: ccname, Version: ccver, Vscc: "vscc", Policy: []byte("barf"), InstantiationPolicy: ip, Retired: true}
+
+	rwset := func(key string, cd *ccprovider.ChaincodeData) []byte {
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		rwsetBuilder.AddToWriteSet("lscc", key, utils.MarshalOrPanic(cd))
+		sr, err := rwsetBuilder.GetTxSimulationResults()
+		assert.NoError(t, err)
+		res, err := sr.GetPubSimulationBytes()
+		assert.NoError(t, err)
+		return res
+	}
+
+	tests := []struct {
+		name        string
+		retirement  bool
+		ledger      *ccprovider.ChaincodeData
+		args        [][]byte
+		res         []byte
+		expectedErr string
+	}{
+		{
+			name:       "retire",
+			retirement: true,
+			ledger:     cdLedger,
+			args:       [][]byte{[]byte(chainId), []byte(ccname)},
+			res:        rwset(ccname, retired),
+		},
+		{
+			name:       "retire and archive",
+			retirement: true,
+			ledger:     cdLedger,
+			args:       [][]byte{[]byte(chainId), []byte(ccname), []byte(lscc.ARCHIVE)},
+			res:        rwset(ccname, archived),
+		},
+		{
+			name:        "capability disabled",
+			ledger:      cdLedger,
+			args:        [][]byte{[]byte(chainId), []byte(ccname)},
+			res:         rwset(ccname, retired),
+			expectedErr: "chaincode retirement is not enabled",
+		},
+		{
+			name:        "bad arguments",
+			retirement:  true,
+			ledger:      cdLedger,
+			args:        [][]byte{[]byte(chainId), []byte(ccname), []byte("barf")},
+			res:         rwset(ccname, retired),
+			expectedErr: "does not have appropriate arguments",
+		},
+		{
+			name:        "non-existent chaincode",
+			retirement:  true,
+			args:        [][]byte{[]byte(chainId), []byte(ccname)},
+			res:         rwset(ccname, retired),
+			expectedErr: "Retiring non-existent chaincode mycc",
+		},
+		{
+			name:        "already retired",
+			retirement:  true,
+			ledger:      retired,
+			args:        [][]byte{[]byte(chainId), []byte(ccname)},
+			res:         rwset(ccname, retired),
+			expectedErr: "Chaincode mycc is already retired",
+		},
+		{
+			name:        "wrong key",
+			retirement:  true,
+			ledger:      cdLedger,
+			args:        [][]byte{[]byte(chainId), []byte(ccname)},
+			res:         rwset("othercc", retired),
+			expectedErr: "expected key mycc, found othercc",
+		},
+		{
+			name:        "archive mismatch",
+			retirement:  true,
+			ledger:      cdLedger,
+			args:        [][]byte{[]byte(chainId), []byte(ccname)},
+			res:         rwset(ccname, archived),
+			expectedErr: "does not retire chaincode mycc:1",
+		},
+		{
+			name:        "tampered chaincode data",
+			retirement:  true,
+			ledger:      cdLedger,
+			args:        [][]byte{[]byte(chainId), []byte(ccname)},
+			res:         rwset(ccname, tampered),
+			expectedErr: "does not retire chaincode mycc:1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := map[string]map[string][]byte{"lscc": {}}
+			if tt.ledger != nil {
+				state["lscc"][ccname] = utils.MarshalOrPanic(tt.ledger)
+			}
+			qec := &mocks2.QueryExecutorCreator{}
+			qec.On("NewQueryExecutor").Return(lm.NewMockQueryExecutor(state), nil)
+			v := newCustomValidationInstance(qec, &mc.MockApplicationCapabilities{ChaincodeRetirementRv: tt.retirement})
+
+			tx, err := createLSCCRetireTx(ccname, ccver, tt.args, tt.res)
+			assert.NoError(t, err)
+			envBytes, err := utils.GetBytesEnvelope(tx)
+			assert.NoError(t, err)
+
+			bl := &common.Block{Data: &common.BlockData{Data: [][]byte{envBytes}}, Header: &common.BlockHeader{}}
+			err = v.Validate(bl, "lscc", 0, 0, policy)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			}
+		})
+	}
+}
+
 var id msp.SigningIdentity
 var sid []byte
 var mspid string
@@ -1896,7 +2049,29 @@ func TestValidateRWSetAndCollectionForDeploy(t *testing.T) {
 	err = testValidateCollection(t, v, []*common.CollectionConfig{coll3}, cdRWSet, lsccFunc, ac, chid)
 	assert.EqualError(t, err, "collection-name: mycollection3 -- error in member org policy: signature policy is not an OR concatenation, NOutOf 2")
 
-	// Test 13: deploy with existing collection config on the ledger -> error
+	// Test 13: collection endorsement policies are ignored without the capability,
+	// they must supply a signature policy, which need not be an OR concatenation
+	coll3 = createCollectionConfig(collName3, cauthdsl.Envelope(cauthdsl.Or(cauthdsl.SignedBy(0), cauthdsl.SignedBy(1)), signers), requiredPeerCount, maximumPeerCount, blockToLive)
+	coll3.GetStaticCollectionConfig().EndorsementPolicy = &common.CollectionPolicyConfig{}
+	err = testValidateCollection(t, v, []*common.CollectionConfig{coll1, coll3}, cdRWSet, lsccFunc, ac, chid)
+	assert.NoError(t, err)
+
+	acCollEP := capabilities.NewApplicationProvider(map[string]*common.Capability{
+		capabilities.ApplicationV1_3:                        {},
+		capabilities.ApplicationCollectionEndorsementPolicy: {},
+	})
+	err = testValidateCollection(t, v, []*common.CollectionConfig{coll1, coll3}, cdRWSet, lsccFunc, acCollEP, chid)
+	assert.EqualError(t, err, "collection-name: mycollection3 -- collection endorsement policy is empty")
+
+	coll3.GetStaticCollectionConfig().EndorsementPolicy = &common.CollectionPolicyConfig{
+		Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+			SignaturePolicy: policyEnvelope,
+		},
+	}
+	err = testValidateCollection(t, v, []*common.CollectionConfig{coll1, coll3}, cdRWSet, lsccFunc, acCollEP, chid)
+	assert.NoError(t, err)
+
+	// Test 14: deploy with existing collection config on the ledger -> error
 	ccp := &common.CollectionConfigPackage{Config: []*common.CollectionConfig{coll1}}
 	ccpBytes, err := proto.Marshal(ccp)
 	assert.NoError(t, err)
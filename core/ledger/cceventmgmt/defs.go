@@ -40,6 +40,13 @@ type ChaincodeLifecycleEventListener interface {
 	ChaincodeDeployDone(succeeded bool)
 }
 
+// ChaincodeRetireListener interface enables the components of the peer to listen to the retirement
+// of chaincodes, for instance to stop their containers
+type ChaincodeRetireListener interface {
+	// HandleChaincodeRetire is invoked after the state changes that retire the chaincode are committed
+	HandleChaincodeRetire(chainid string, chaincodeDefinition *ChaincodeDefinition)
+}
+
 // ChaincodeInfoProvider interface enables event mgr to retrieve chaincode info for a given chaincode
 type ChaincodeInfoProvider interface {
 	// IsChaincodeDeployed returns true if the given chaincode is deployed on the given channel.
@@ -31,6 +31,7 @@ func (listener *KVLedgerLSCCStateListener) HandleStateUpdates(trigger *ledger.St
 	kvWrites := stateUpdates[lsccNamespace].([]*kvrwset.KVWrite)
 	logger.Debugf("Channel [%s]: Handling state updates in LSCC namespace - stateUpdates=%#v", channelName, kvWrites)
 	chaincodeDefs := []*ChaincodeDefinition{}
+	retiredChaincodeDefs := []*ChaincodeDefinition{}
 	chaincodesCollConfigs := make(map[string][]byte)
 
 	for _, kvWrite := range kvWrites {
@@ -52,6 +53,16 @@ func (listener *KVLedgerLSCCStateListener) HandleStateUpdates(trigger *ledger.St
 		if err := proto.Unmarshal(kvWrite.Value, chaincodeData); err != nil {
 			return errors.Wrap(err, "error unmarshalling chaincode state data")
 		}
+		if chaincodeData.Retired {
+			retiredChaincodeDefs = append(retiredChaincodeDefs, &ChaincodeDefinition{Name: chaincodeData.CCName(), Version: chaincodeData.CCVersion(), Hash: chaincodeData.Hash()})
+			if chaincodeData.ArchiveState {
+				// the chaincode is retired regardless of the peer managing to archive its state
+				if err := archiveChaincodeState(trigger, chaincodeData); err != nil {
+					logger.Errorf("Channel [%s]: Error archiving the state of chaincode [%s]: %s", channelName, kvWrite.Key, err)
+				}
+			}
+			continue
+		}
 		chaincodeDefs = append(chaincodeDefs, &ChaincodeDefinition{Name: chaincodeData.CCName(), Version: chaincodeData.CCVersion(), Hash: chaincodeData.Hash()})
 	}
 
@@ -62,6 +73,7 @@ func (listener *KVLedgerLSCCStateListener) HandleStateUpdates(trigger *ledger.St
 		}
 	}
 
+	GetMgr().HandleChaincodeRetire(channelName, retiredChaincodeDefs)
 	return GetMgr().HandleChaincodeDeploy(channelName, chaincodeDefs)
 }
 
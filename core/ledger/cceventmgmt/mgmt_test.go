@@ -7,15 +7,20 @@ SPDX-License-Identifier: Apache-2.0
 package cceventmgmt
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -102,6 +107,8 @@ func TestLSCCListener(t *testing.T) {
 	defer clearEventMgrForTest()
 	handler1 := &mockHandler{}
 	GetMgr().Register(channelName, handler1)
+	retireListener := &mockRetireListener{}
+	GetMgr().RegisterRetireListener(retireListener)
 	lsccStateListener := &KVLedgerLSCCStateListener{}
 
 	// test1 regular deploy lscc event gets sent to handler
@@ -148,8 +155,78 @@ func TestLSCCListener(t *testing.T) {
 		)
 		assert.NotContains(t, handler1.eventsRecieved, &mockEvent{cc3Def, ccDBArtifactsTar})
 	})
+
+	// test4 retire lscc event NOT sent to handler but to the retire listener once committed,
+	// and the state of the chaincode is archived
+	t.Run("RetireEvent", func(t *testing.T) {
+		rootPath, err := ioutil.TempDir("", "cceventmgmt")
+		assert.NoError(t, err)
+		defer os.RemoveAll(rootPath)
+		viper.Set("peer.fileSystemPath", rootPath)
+		defer viper.Set("peer.fileSystemPath", "")
+
+		sampleChaincodeData4 := &ccprovider.ChaincodeData{Name: cc2Def.Name, Version: cc2Def.Version, Id: cc2Def.Hash, Retired: true, ArchiveState: true}
+		sampleChaincodeDataBytes4, err := proto.Marshal(sampleChaincodeData4)
+		assert.NoError(t, err, "")
+		err = lsccStateListener.HandleStateUpdates(&ledger.StateUpdateTrigger{
+			LedgerID: channelName,
+			StateUpdates: ledger.StateUpdates{
+				lsccNamespace: []*kvrwset.KVWrite{{Key: cc2Def.Name, Value: sampleChaincodeDataBytes4}},
+			},
+			CommittingBlockNum:      51,
+			PostCommitQueryExecutor: &mockQueryExecutor{kvs: []*queryresult.KV{{Key: "a", Value: []byte("100")}, {Key: "b", Value: []byte("200")}}},
+		})
+		assert.NoError(t, err)
+		assert.NotContains(t, handler1.eventsRecieved, &mockEvent{cc2Def, ccDBArtifactsTar})
+		assert.Empty(t, retireListener.retired)
+		lsccStateListener.StateCommitDone(channelName)
+		assert.Equal(t, []*ChaincodeDefinition{cc2Def}, retireListener.retired)
+
+		archiveBytes, err := ioutil.ReadFile(filepath.Join(rootPath, "ledgersData", "archive", "chaincodes", channelName, "testChaincode2_v1_51.json"))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{"key":"a","value":"MTAw"},{"key":"b","value":"MjAw"}]`, string(archiveBytes))
+
+		// the retirement is only notified once
+		lsccStateListener.StateCommitDone(channelName)
+		assert.Len(t, retireListener.retired, 1)
+	})
+}
+
+type mockRetireListener struct {
+	retired []*ChaincodeDefinition
+}
+
+func (l *mockRetireListener) HandleChaincodeRetire(chainid string, chaincodeDefinition *ChaincodeDefinition) {
+	l.retired = append(l.retired, chaincodeDefinition)
 }
 
+type mockQueryExecutor struct {
+	kvs []*queryresult.KV
+}
+
+func (qe *mockQueryExecutor) GetState(namespace string, key string) ([]byte, error) {
+	return nil, nil
+}
+
+func (qe *mockQueryExecutor) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	return &mockResultsIterator{kvs: qe.kvs}, nil
+}
+
+type mockResultsIterator struct {
+	kvs []*queryresult.KV
+}
+
+func (itr *mockResultsIterator) Next() (commonledger.QueryResult, error) {
+	if len(itr.kvs) == 0 {
+		return nil, nil
+	}
+	kv := itr.kvs[0]
+	itr.kvs = itr.kvs[1:]
+	return kv, nil
+}
+
+func (itr *mockResultsIterator) Close() {}
+
 type mockProvider struct {
 	chaincodesDeployed  map[[3]string]bool
 	chaincodesInstalled map[[2]string][]byte
@@ -41,6 +41,7 @@ type Mgr struct {
 	rwlock               sync.RWMutex
 	infoProvider         ChaincodeInfoProvider
 	ccLifecycleListeners map[string][]ChaincodeLifecycleEventListener
+	ccRetireListeners    []ChaincodeRetireListener
 	callbackStatus       *callbackStatus
 }
 
@@ -60,6 +61,21 @@ func (m *Mgr) Register(ledgerid string, l ChaincodeLifecycleEventListener) {
 	m.ccLifecycleListeners[ledgerid] = append(m.ccLifecycleListeners[ledgerid], l)
 }
 
+// RegisterRetireListener registers a ChaincodeRetireListener for all the ledgers
+func (m *Mgr) RegisterRetireListener(l ChaincodeRetireListener) {
+	m.rwlock.Lock()
+	defer m.rwlock.Unlock()
+	m.ccRetireListeners = append(m.ccRetireListeners, l)
+}
+
+// HandleChaincodeRetire is expected to be invoked when chaincodes are retired via a retire transaction,
+// before the transaction is committed. The retire listeners are invoked in `ChaincodeDeployDone`, once
+// the chaincodes are known to be retired in the state
+func (m *Mgr) HandleChaincodeRetire(chainid string, chaincodeDefinitions []*ChaincodeDefinition) {
+	logger.Debugf("Channel [%s]: Handling chaincode retire event for chaincode [%s]", chainid, chaincodeDefinitions)
+	m.callbackStatus.setRetirePending(chainid, chaincodeDefinitions)
+}
+
 // HandleChaincodeDeploy is expected to be invoked when a chaincode is deployed via a deploy transaction
 // The `chaincodeDefinitions` parameter contains all the chaincodes deployed in a block
 // We need to store the last received `chaincodeDefinitions` because this function is expected to be invoked
@@ -100,6 +116,11 @@ func (m *Mgr) ChaincodeDeployDone(chainid string) {
 		m.invokeDoneOnHandlers(chainid, true)
 		m.callbackStatus.unsetDeployPending(chainid)
 	}
+	for _, chaincodeDefinition := range m.callbackStatus.unsetRetirePending(chainid) {
+		for _, listener := range m.ccRetireListeners {
+			listener.HandleChaincodeRetire(chainid, chaincodeDefinition)
+		}
+	}
 }
 
 // HandleChaincodeInstall is expected to get invoked during installation of a chaincode package
@@ -161,12 +182,14 @@ type callbackStatus struct {
 	l              sync.Mutex
 	deployPending  map[string]bool
 	installPending map[string]bool
+	retirePending  map[string][]*ChaincodeDefinition
 }
 
 func newCallbackStatus() *callbackStatus {
 	return &callbackStatus{
 		deployPending:  make(map[string]bool),
-		installPending: make(map[string]bool)}
+		installPending: make(map[string]bool),
+		retirePending:  make(map[string][]*ChaincodeDefinition)}
 }
 
 func (s *callbackStatus) setDeployPending(channelID string) {
@@ -204,3 +227,21 @@ func (s *callbackStatus) isInstallPending(channelID string) bool {
 	defer s.l.Unlock()
 	return s.installPending[channelID]
 }
+
+func (s *callbackStatus) setRetirePending(channelID string, chaincodeDefinitions []*ChaincodeDefinition) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	if len(chaincodeDefinitions) == 0 {
+		delete(s.retirePending, channelID)
+		return
+	}
+	s.retirePending[channelID] = chaincodeDefinitions
+}
+
+func (s *callbackStatus) unsetRetirePending(channelID string) []*ChaincodeDefinition {
+	s.l.Lock()
+	defer s.l.Unlock()
+	chaincodeDefinitions := s.retirePending[channelID]
+	delete(s.retirePending, channelID)
+	return chaincodeDefinitions
+}
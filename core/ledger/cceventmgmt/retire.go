@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cceventmgmt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/pkg/errors"
+)
+
+// archivedKV is a key-value of the state of a retired chaincode, as written to its archive
+type archivedKV struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// chaincodeArchivePath returns the path of the archive of the state of the chaincode
+// retired in the given block of the channel
+func chaincodeArchivePath(channelName string, chaincodeData *ccprovider.ChaincodeData, blockNum uint64) string {
+	return filepath.Join(ledgerconfig.GetArchivePath(), "chaincodes", channelName,
+		fmt.Sprintf("%s_%s_%d.json", chaincodeData.Name, chaincodeData.Version, blockNum))
+}
+
+// archiveChaincodeState writes the public state of the retired chaincode, as it is once the
+// committing block is applied, to a json file under the archive directory of the ledgers.
+// The private data of the chaincode is not archived
+func archiveChaincodeState(trigger *ledger.StateUpdateTrigger, chaincodeData *ccprovider.ChaincodeData) error {
+	itr, err := trigger.PostCommitQueryExecutor.GetStateRangeScanIterator(chaincodeData.Name, "", "")
+	if err != nil {
+		return errors.WithMessage(err, "error querying the state of the chaincode")
+	}
+	defer itr.Close()
+
+	kvs := []*archivedKV{}
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return errors.WithMessage(err, "error iterating over the state of the chaincode")
+		}
+		if res == nil {
+			break
+		}
+		kv := res.(*queryresult.KV)
+		kvs = append(kvs, &archivedKV{Key: kv.Key, Value: kv.Value})
+	}
+	archiveBytes, err := json.Marshal(kvs)
+	if err != nil {
+		return errors.Wrap(err, "error marshaling the state of the chaincode")
+	}
+
+	path := chaincodeArchivePath(trigger.LedgerID, chaincodeData, trigger.CommittingBlockNum)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrapf(err, "error creating the archive directory [%s]", filepath.Dir(path))
+	}
+	// write to a temporary file first so that a partial archive is never left behind
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, archiveBytes, 0644); err != nil {
+		return errors.Wrapf(err, "error writing [%s]", tmpPath)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.Wrapf(err, "error renaming [%s]", tmpPath)
+	}
+	logger.Infof("Channel [%s]: Archived the state of chaincode [%s:%s] to [%s]", trigger.LedgerID, chaincodeData.Name, chaincodeData.Version, path)
+	return nil
+}
@@ -0,0 +1,353 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/ledger/backup"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// The files holding the entries of a ledger in the private data store and in
+// the collection config history, alongside the block files of its backup
+const (
+	backupPvtdataFile       = "pvtdata.kv"
+	backupConfigHistoryFile = "confighistory.kv"
+)
+
+// maxRestoreBatchSize bounds the number of entries written at once when a
+// backed up db is restored, or deleted at once when a db is cleared
+const maxRestoreBatchSize = 1000
+
+// BackupLedger writes a backup of the given ledger to the output directory,
+// which must not exist or be empty. The peer must be stopped. A backup holds
+// the block files of the ledger along with the entries of the ledger in the
+// private data store and in the collection config history. The state
+// database, the history database and the block index are not backed up, they
+// are rebuilt from the blocks when the restored ledger is opened.
+func BackupLedger(ledgerID string, outputDir string) (*backup.Manifest, error) {
+	idStore, err := openIDStoreOffline()
+	if err != nil {
+		return nil, err
+	}
+	defer idStore.close()
+	exists, err := idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNonExistingLedgerID
+	}
+	return backupLedger(ledgerID, outputDir)
+}
+
+// backupLedger writes a backup of the ledger, whose stores must not be in use,
+// to the output directory
+func backupLedger(ledgerID string, outputDir string) (*backup.Manifest, error) {
+	if err := backup.CreateEmptyDir(outputDir); err != nil {
+		return nil, err
+	}
+
+	manifest, err := backup.BackupBlockfiles(ledgerBlockDir(ledgerID), outputDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, db := range backupDBs() {
+		file, err := db.export(ledgerID, outputDir)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+	if err := backup.WriteManifest(outputDir, manifest); err != nil {
+		return nil, err
+	}
+	logger.Infof("Backed up ledger [%s] at height [%d] to [%s]", ledgerID, manifest.Height, outputDir)
+	return manifest, nil
+}
+
+// VerifyBackup checks the files of the backup found in the given directory
+// against its manifest, and the chain of the blocks it holds
+func VerifyBackup(dir string) (*backup.Manifest, error) {
+	manifest, _, err := backup.Verify(dir)
+	return manifest, err
+}
+
+// RestoreLedger restores the ledger backed up in the given directory, after
+// verifying the backup. The ledger must not exist on the peer, and the peer
+// must be stopped. The state and history databases, and the block index, of
+// the ledger are rebuilt when the peer opens the ledger.
+func RestoreLedger(dir string) (*backup.Manifest, error) {
+	manifest, genesisBlock, err := backup.Verify(dir)
+	if err != nil {
+		return nil, err
+	}
+	ledgerID := manifest.LedgerID
+
+	idStore, err := openIDStoreOffline()
+	if err != nil {
+		return nil, err
+	}
+	defer idStore.close()
+	exists, err := idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrLedgerIDExists
+	}
+	ledgerDir := ledgerBlockDir(ledgerID)
+	if err := backup.CreateEmptyDir(ledgerDir); err != nil {
+		return nil, err
+	}
+	for _, db := range backupDBs() {
+		if err := db.checkEmpty(ledgerID); err != nil {
+			return nil, err
+		}
+	}
+
+	restored := false
+	defer func() {
+		if restored {
+			return
+		}
+		// leave no partially restored ledger behind, so that the restore can be retried
+		if err := os.RemoveAll(ledgerDir); err != nil {
+			logger.Errorf("Error removing the block files of ledger [%s]: %s", ledgerID, err)
+		}
+		for _, db := range backupDBs() {
+			if err := db.clear(ledgerID); err != nil {
+				logger.Errorf("Error removing the entries of ledger [%s] from [%s]: %s", ledgerID, db.path, err)
+			}
+		}
+	}()
+
+	if err := backup.RestoreBlockfiles(dir, ledgerDir); err != nil {
+		return nil, err
+	}
+	for _, db := range backupDBs() {
+		if err := db.restore(ledgerID, dir); err != nil {
+			return nil, err
+		}
+	}
+
+	// the ledger becomes visible to the peer once it is added to the id store
+	if err := idStore.createLedgerID(ledgerID, genesisBlock); err != nil {
+		return nil, err
+	}
+	restored = true
+	logger.Infof("Restored ledger [%s] at height [%d] from [%s]", ledgerID, manifest.Height, dir)
+	return manifest, nil
+}
+
+// openIDStoreOffline opens the id store, failing rather than panicking if it
+// is held by a running peer
+func openIDStoreOffline() (*idStore, error) {
+	path := ledgerconfig.GetLedgerProviderPath()
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot open the ledgers at [%s], make sure the peer is stopped", path)
+	}
+	if err := db.Close(); err != nil {
+		return nil, err
+	}
+	return openIDStore(path), nil
+}
+
+func ledgerBlockDir(ledgerID string) string {
+	return filepath.Join(ledgerconfig.GetBlockStorePath(), fsblkstorage.ChainsDir, ledgerID)
+}
+
+// backupDB is a leveldb shared by the ledgers, holding a logical db named
+// after each ledger. The entries of the logical db of a ledger are backed up
+// to a file, as a sequence of length prefixed keys and values.
+type backupDB struct {
+	path string
+	// suffix is appended to the id of a ledger to name its logical db, for
+	// the leveldbs holding several logical dbs per ledger
+	suffix string
+	file   string
+	// exclude, if set, selects the entries left out of the backup by key
+	exclude func(key []byte) bool
+}
+
+func backupDBs() []*backupDB {
+	return []*backupDB{
+		{path: ledgerconfig.GetPvtdataStorePath(), file: backupPvtdataFile},
+		{path: ledgerconfig.GetConfigHistoryPath(), file: backupConfigHistoryFile},
+	}
+}
+
+func (b *backupDB) export(ledgerID string, outputDir string) (backup.File, error) {
+	p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: b.path})
+	defer p.Close()
+
+	f, err := os.OpenFile(filepath.Join(outputDir, b.file), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return backup.File{}, errors.Wrapf(err, "error creating file [%s]", b.file)
+	}
+	defer f.Close()
+	digest := sha256.New()
+	counter := &countingWriter{w: io.MultiWriter(f, digest)}
+	w := bufio.NewWriter(counter)
+
+	itr := p.GetDBHandle(b.dbName(ledgerID)).GetIterator(nil, nil)
+	defer itr.Release()
+	for itr.Next() {
+		if b.exclude != nil && b.exclude(itr.Key()) {
+			continue
+		}
+		if err := writeLengthPrefixed(w, itr.Key()); err != nil {
+			return backup.File{}, err
+		}
+		if err := writeLengthPrefixed(w, itr.Value()); err != nil {
+			return backup.File{}, err
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return backup.File{}, errors.Wrapf(err, "error iterating over [%s]", b.path)
+	}
+	if err := w.Flush(); err != nil {
+		return backup.File{}, errors.Wrapf(err, "error writing file [%s]", b.file)
+	}
+	if err := f.Sync(); err != nil {
+		return backup.File{}, errors.Wrapf(err, "error syncing file [%s]", b.file)
+	}
+	return backup.File{Path: b.file, Size: counter.n, SHA256: hex.EncodeToString(digest.Sum(nil))}, nil
+}
+
+func (b *backupDB) checkEmpty(ledgerID string) error {
+	return checkLogicalDBEmpty(b.path, b.dbName(ledgerID))
+}
+
+func (b *backupDB) restore(ledgerID string, dir string) error {
+	f, err := os.Open(filepath.Join(dir, b.file))
+	if err != nil {
+		return errors.Wrapf(err, "error opening file [%s]", b.file)
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+
+	p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: b.path})
+	defer p.Close()
+	handle := p.GetDBHandle(b.dbName(ledgerID))
+	batch := leveldbhelper.NewUpdateBatch()
+	for {
+		key, err := readLengthPrefixed(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrapf(err, "error reading file [%s]", b.file)
+		}
+		value, err := readLengthPrefixed(r)
+		if err != nil {
+			return errors.Wrapf(err, "error reading file [%s]", b.file)
+		}
+		batch.Put(key, value)
+		if len(batch.KVs) == maxRestoreBatchSize {
+			if err := handle.WriteBatch(batch, true); err != nil {
+				return err
+			}
+			batch = leveldbhelper.NewUpdateBatch()
+		}
+	}
+	return handle.WriteBatch(batch, true)
+}
+
+// clear deletes the entries of the ledger
+func (b *backupDB) clear(ledgerID string) error {
+	return clearLogicalDB(b.path, b.dbName(ledgerID))
+}
+
+func (b *backupDB) dbName(ledgerID string) string {
+	return ledgerID + b.suffix
+}
+
+// clearLogicalDB deletes the entries of the logical db of the leveldb found
+// at the given path
+func clearLogicalDB(path string, dbName string) error {
+	p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: path})
+	defer p.Close()
+	handle := p.GetDBHandle(dbName)
+	itr := handle.GetIterator(nil, nil)
+	defer itr.Release()
+	batch := leveldbhelper.NewUpdateBatch()
+	for itr.Next() {
+		batch.Delete(append([]byte(nil), itr.Key()...))
+		if len(batch.KVs) == maxRestoreBatchSize {
+			if err := handle.WriteBatch(batch, true); err != nil {
+				return err
+			}
+			batch = leveldbhelper.NewUpdateBatch()
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return err
+	}
+	return handle.WriteBatch(batch, true)
+}
+
+// checkLogicalDBEmpty fails if the logical db of the leveldb found at the
+// given path holds entries
+func checkLogicalDBEmpty(path string, dbName string) error {
+	p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: path})
+	defer p.Close()
+	itr := p.GetDBHandle(dbName).GetIterator(nil, nil)
+	defer itr.Release()
+	if itr.Next() {
+		return errors.Errorf("[%s] holds entries of [%s]", path, dbName)
+	}
+	return itr.Error()
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func writeLengthPrefixed(w io.Writer, b []byte) error {
+	lenBytes := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(lenBytes, uint64(len(b)))
+	if _, err := w.Write(lenBytes[:n]); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+func readLengthPrefixed(r *bufio.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, length)
+	if _, err := io.ReadFull(r, b); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/backup"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// createLedgerForBackup creates a ledger holding a block of collection configs
+// and a block with private data, and closes it
+func createLedgerForBackup(t *testing.T, ledgerID string) *common.BlockchainInfo {
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+	bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+
+	collConfigBlk := prepareNextBlockForTestCollectionConfigs(t, ledger, bg, "txid1", "ns", map[string]uint64{"coll": 0})
+	assert.NoError(t, ledger.CommitWithPvtData(collConfigBlk))
+	blk := prepareNextBlockForTest(t, ledger, bg, "txid2",
+		map[string]string{"key1": "value1"}, map[string]string{"key2": "pvtValue2"})
+	assert.NoError(t, ledger.CommitWithPvtData(blk))
+	bcInfo, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	return bcInfo
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	bcInfo := createLedgerForBackup(t, "testLedger")
+
+	backupDir := filepath.Join(env.path, "backup")
+	manifest, err := BackupLedger("testLedger", backupDir)
+	assert.NoError(t, err)
+	assert.Equal(t, "testLedger", manifest.LedgerID)
+	assert.Equal(t, bcInfo.Height, manifest.Height)
+	assert.Equal(t, bcInfo.CurrentBlockHash, manifest.CurrentBlockHash)
+	assert.Len(t, manifest.Files, 3)
+
+	verified, err := VerifyBackup(backupDir)
+	assert.NoError(t, err)
+	assert.Equal(t, manifest.Height, verified.Height)
+
+	// the output directory must be empty
+	_, err = BackupLedger("testLedger", backupDir)
+	assert.EqualError(t, err, "directory ["+backupDir+"] is not empty")
+	_, err = BackupLedger("nonExistingLedger", filepath.Join(env.path, "backup2"))
+	assert.Equal(t, ErrNonExistingLedgerID, err)
+	// the ledger exists on the peer
+	_, err = RestoreLedger(backupDir)
+	assert.Equal(t, ErrLedgerIDExists, err)
+
+	// restore to another peer
+	createTestEnv(t, filepath.Join(env.path, "restored"))
+	manifest, err = RestoreLedger(backupDir)
+	assert.NoError(t, err)
+	assert.Equal(t, "testLedger", manifest.LedgerID)
+
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+	ledger, err := provider.Open("testLedger")
+	assert.NoError(t, err)
+	defer ledger.Close()
+	restoredBCInfo, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, bcInfo, restoredBCInfo)
+
+	qe, err := ledger.NewQueryExecutor()
+	assert.NoError(t, err)
+	defer qe.Done()
+	value, err := qe.GetState("ns", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), value)
+	value, err = qe.GetPrivateData("ns", "coll", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("pvtValue2"), value)
+	pvtdataAndBlock, err := ledger.GetPvtDataAndBlockByNum(2, nil)
+	assert.NoError(t, err)
+	assert.True(t, pvtdataAndBlock.BlockPvtData[0].Has("ns", "coll"))
+	collConfigInfo, err := ledger.GetConfigHistoryRetriever()
+	assert.NoError(t, err)
+	configs, err := collConfigInfo.MostRecentCollectionConfigBelow(3, "ns")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), configs.CommittingBlockNum)
+}
+
+func TestVerifyBackupTampered(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	createLedgerForBackup(t, "testLedger")
+	backupDir := filepath.Join(env.path, "backup")
+	manifest, err := BackupLedger("testLedger", backupDir)
+	assert.NoError(t, err)
+
+	// a tampered file
+	blockfile := filepath.Join(backupDir, manifest.Files[0].Path)
+	content, err := ioutil.ReadFile(blockfile)
+	assert.NoError(t, err)
+	content[len(content)-1] ^= 0xff
+	assert.NoError(t, ioutil.WriteFile(blockfile, content, 0644))
+	_, err = VerifyBackup(backupDir)
+	assert.EqualError(t, err, "file ["+manifest.Files[0].Path+"] of the backup does not match the manifest")
+	_, err = RestoreLedger(backupDir)
+	assert.Error(t, err)
+
+	// a tampered manifest
+	content[len(content)-1] ^= 0xff
+	assert.NoError(t, ioutil.WriteFile(blockfile, content, 0644))
+	manifest.Height = 10
+	manifestBytes, err := json.Marshal(manifest)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(backupDir, backup.ManifestFile), manifestBytes, 0644))
+	_, err = VerifyBackup(backupDir)
+	assert.EqualError(t, err, "the blocks of the backup end at height [3], the manifest expects height [10]")
+
+	// an incomplete backup
+	assert.NoError(t, os.Remove(filepath.Join(backupDir, backup.ManifestFile)))
+	_, err = VerifyBackup(backupDir)
+	assert.Contains(t, err.Error(), "error reading the backup manifest")
+}
+
+func TestBackupPeerRunning(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	_, err := BackupLedger("testLedger", filepath.Join(env.path, "backup"))
+	assert.Contains(t, err.Error(), "make sure the peer is stopped")
+	_, err = RestoreLedger(filepath.Join(env.path, "backup"))
+	assert.Contains(t, err.Error(), "error reading the backup manifest")
+}
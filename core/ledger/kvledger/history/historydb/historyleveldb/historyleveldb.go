@@ -34,7 +34,10 @@ type HistoryDBProvider struct {
 func NewHistoryDBProvider() *HistoryDBProvider {
 	dbPath := ledgerconfig.GetHistoryLevelDBPath()
 	logger.Debugf("constructing HistoryDBProvider dbPath=%s", dbPath)
-	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{
+		DBPath:    dbPath,
+		DeferSync: ledgerconfig.IsCommitJournalEnabled(),
+	})
 	return &HistoryDBProvider{dbProvider}
 }
 
@@ -209,3 +212,8 @@ func (historyDB *historyDB) CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtD
 	}
 	return nil
 }
+
+// Sync implements method in HistoryDB interface
+func (historyDB *historyDB) Sync() error {
+	return historyDB.db.Sync()
+}
@@ -0,0 +1,146 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package journal
+
+import (
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+)
+
+// marshalEntry encodes the block, followed by its pvt data ordered by the
+// sequence of the transactions in the block, followed by its missing pvt data
+func marshalEntry(blockAndPvtdata *ledger.BlockAndPvtData) ([]byte, error) {
+	buffer := proto.NewBuffer(nil)
+	blockBytes, err := proto.Marshal(blockAndPvtdata.Block)
+	if err != nil {
+		return nil, err
+	}
+	if err := buffer.EncodeRawBytes(blockBytes); err != nil {
+		return nil, err
+	}
+
+	var seqs []uint64
+	for seq := range blockAndPvtdata.BlockPvtData {
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	if err := buffer.EncodeVarint(uint64(len(seqs))); err != nil {
+		return nil, err
+	}
+	for _, seq := range seqs {
+		writeSetBytes, err := proto.Marshal(blockAndPvtdata.BlockPvtData[seq].WriteSet)
+		if err != nil {
+			return nil, err
+		}
+		if err := buffer.EncodeVarint(seq); err != nil {
+			return nil, err
+		}
+		if err := buffer.EncodeRawBytes(writeSetBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	var missing []*ledger.MissingPrivateData
+	if blockAndPvtdata.Missing != nil {
+		missing = blockAndPvtdata.Missing.List
+	}
+	if err := buffer.EncodeVarint(uint64(len(missing))); err != nil {
+		return nil, err
+	}
+	for _, m := range missing {
+		var eligibleMarker uint64
+		if m.IsEligible {
+			eligibleMarker = 1
+		}
+		if err := buffer.EncodeStringBytes(m.TxId); err != nil {
+			return nil, err
+		}
+		if err := buffer.EncodeVarint(m.SeqInBlock); err != nil {
+			return nil, err
+		}
+		if err := buffer.EncodeStringBytes(m.Namespace); err != nil {
+			return nil, err
+		}
+		if err := buffer.EncodeStringBytes(m.Collection); err != nil {
+			return nil, err
+		}
+		if err := buffer.EncodeVarint(eligibleMarker); err != nil {
+			return nil, err
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+func unmarshalEntry(b []byte) (*ledger.BlockAndPvtData, error) {
+	buffer := proto.NewBuffer(b)
+	blockBytes, err := buffer.DecodeRawBytes(false)
+	if err != nil {
+		return nil, err
+	}
+	block := &common.Block{}
+	if err := proto.Unmarshal(blockBytes, block); err != nil {
+		return nil, err
+	}
+	blockAndPvtdata := &ledger.BlockAndPvtData{Block: block}
+
+	numPvtdata, err := buffer.DecodeVarint()
+	if err != nil {
+		return nil, err
+	}
+	if numPvtdata > 0 {
+		blockAndPvtdata.BlockPvtData = make(map[uint64]*ledger.TxPvtData)
+	}
+	for i := uint64(0); i < numPvtdata; i++ {
+		seq, err := buffer.DecodeVarint()
+		if err != nil {
+			return nil, err
+		}
+		writeSetBytes, err := buffer.DecodeRawBytes(false)
+		if err != nil {
+			return nil, err
+		}
+		writeSet := &rwset.TxPvtReadWriteSet{}
+		if err := proto.Unmarshal(writeSetBytes, writeSet); err != nil {
+			return nil, err
+		}
+		blockAndPvtdata.BlockPvtData[seq] = &ledger.TxPvtData{SeqInBlock: seq, WriteSet: writeSet}
+	}
+
+	numMissing, err := buffer.DecodeVarint()
+	if err != nil {
+		return nil, err
+	}
+	if numMissing > 0 {
+		blockAndPvtdata.Missing = &ledger.MissingPrivateDataList{}
+	}
+	for i := uint64(0); i < numMissing; i++ {
+		m := &ledger.MissingPrivateData{}
+		if m.TxId, err = buffer.DecodeStringBytes(); err != nil {
+			return nil, err
+		}
+		if m.SeqInBlock, err = buffer.DecodeVarint(); err != nil {
+			return nil, err
+		}
+		if m.Namespace, err = buffer.DecodeStringBytes(); err != nil {
+			return nil, err
+		}
+		if m.Collection, err = buffer.DecodeStringBytes(); err != nil {
+			return nil, err
+		}
+		eligibleMarker, err := buffer.DecodeVarint()
+		if err != nil {
+			return nil, err
+		}
+		m.IsEligible = eligibleMarker == 1
+		blockAndPvtdata.Missing.List = append(blockAndPvtdata.Missing.List, m)
+	}
+	return blockAndPvtdata, nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package journal
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("journal")
+
+const checksumSize = 4
+
+// Journal is the commit journal of a ledger. Each block is appended to the
+// journal, along with its pvt data, before being committed to the stores of
+// the ledger, which do not sync the commit to disk. A single sync of the
+// journal thus makes the commit of the block durable. The stores are synced
+// periodically, after which the journal is truncated. After a crash, the
+// blocks of the journal are recommitted to the stores which lost them.
+//
+// An entry of the journal consists of the varint encoded length of the entry,
+// the entry and the CRC-32 checksum of the entry. An entry left partially
+// written by a crash is truncated when the journal is opened.
+type Journal struct {
+	path       string
+	file       *os.File
+	numEntries int
+}
+
+// Open opens the journal at the given path, creating it if it does not exist
+func Open(path string) (*Journal, error) {
+	if _, err := util.CreateDirIfMissing(filepath.Dir(path)); err != nil {
+		return nil, errors.Wrapf(err, "error creating the directory of journal [%s]", path)
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0660)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening journal [%s]", path)
+	}
+	j := &Journal{path: path, file: file}
+	if err := j.recover(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return j, nil
+}
+
+// Exists returns true if a journal exists at the given path
+func Exists(path string) (bool, error) {
+	exists, _, err := util.FileExists(path)
+	return exists, err
+}
+
+// recover counts the entries of the journal and truncates the partially
+// written entry, if any, left at the end of the journal by a crash
+func (j *Journal) recover() error {
+	entries, validSize, size, err := j.readEntries()
+	if err != nil {
+		return err
+	}
+	if validSize < size {
+		logger.Warningf("Truncating [%d] bytes of a partially written entry from journal [%s]", size-validSize, j.path)
+		if err := j.file.Truncate(validSize); err != nil {
+			return errors.Wrapf(err, "error truncating journal [%s]", j.path)
+		}
+		if err := j.file.Sync(); err != nil {
+			return errors.Wrapf(err, "error syncing journal [%s]", j.path)
+		}
+	}
+	j.numEntries = len(entries)
+	return nil
+}
+
+// readEntries returns the complete entries of the journal, the size of the
+// journal up to the last complete entry and the size of the journal
+func (j *Journal) readEntries() ([][]byte, int64, int64, error) {
+	b, err := ioutil.ReadFile(j.path)
+	if err != nil {
+		return nil, 0, 0, errors.Wrapf(err, "error reading journal [%s]", j.path)
+	}
+	var entries [][]byte
+	offset := 0
+	for offset < len(b) {
+		length, n := proto.DecodeVarint(b[offset:])
+		remaining := len(b) - offset - n - checksumSize
+		if n == 0 || remaining < 0 || length > uint64(remaining) {
+			break
+		}
+		start := offset + n
+		end := start + int(length)
+		entry := b[start:end]
+		if binary.BigEndian.Uint32(b[end:end+checksumSize]) != crc32.ChecksumIEEE(entry) {
+			break
+		}
+		entries = append(entries, entry)
+		offset = end + checksumSize
+	}
+	return entries, int64(offset), int64(len(b)), nil
+}
+
+// Append appends the block and its pvt data to the journal and syncs the journal
+func (j *Journal) Append(blockAndPvtdata *ledger.BlockAndPvtData) error {
+	b, err := marshalEntry(blockAndPvtdata)
+	if err != nil {
+		return err
+	}
+	checksum := make([]byte, checksumSize)
+	binary.BigEndian.PutUint32(checksum, crc32.ChecksumIEEE(b))
+	record := append(append(proto.EncodeVarint(uint64(len(b))), b...), checksum...)
+	if _, err := j.file.Write(record); err != nil {
+		return errors.Wrapf(err, "error appending to journal [%s]", j.path)
+	}
+	if err := j.file.Sync(); err != nil {
+		return errors.Wrapf(err, "error syncing journal [%s]", j.path)
+	}
+	j.numEntries++
+	return nil
+}
+
+// Replay invokes the given function with the entries of the journal, in the order they were appended
+func (j *Journal) Replay(recommit func(*ledger.BlockAndPvtData) error) error {
+	entries, _, _, err := j.readEntries()
+	if err != nil {
+		return err
+	}
+	for _, b := range entries {
+		blockAndPvtdata, err := unmarshalEntry(b)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error decoding entry of journal [%s]", j.path))
+		}
+		if err := recommit(blockAndPvtdata); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Len returns the number of entries in the journal
+func (j *Journal) Len() int {
+	return j.numEntries
+}
+
+// Truncate removes all the entries from the journal. It is invoked once the
+// stores of the ledger are synced to disk.
+func (j *Journal) Truncate() error {
+	if err := j.file.Truncate(0); err != nil {
+		return errors.Wrapf(err, "error truncating journal [%s]", j.path)
+	}
+	if err := j.file.Sync(); err != nil {
+		return errors.Wrapf(err, "error syncing journal [%s]", j.path)
+	}
+	j.numEntries = 0
+	return nil
+}
+
+// Close closes the journal
+func (j *Journal) Close() error {
+	return j.file.Close()
+}
+
+// Remove closes and removes the journal
+func (j *Journal) Remove() error {
+	j.file.Close()
+	return errors.Wrapf(os.Remove(j.path), "error removing journal [%s]", j.path)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package journal
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournal(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "journal")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	path := filepath.Join(testDir, "journals", "testLedger")
+
+	exists, err := Exists(path)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	j, err := Open(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, j.Len())
+	sampleData := sampleBlocksAndPvtdata(t, 5)
+	for _, d := range sampleData[:3] {
+		assert.NoError(t, j.Append(d))
+	}
+	assert.Equal(t, 3, j.Len())
+	assert.NoError(t, j.Truncate())
+	assert.Equal(t, 0, j.Len())
+	for _, d := range sampleData[3:] {
+		assert.NoError(t, j.Append(d))
+	}
+	assert.NoError(t, j.Close())
+
+	j, err = Open(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, j.Len())
+	assertReplayed(t, j, sampleData[3:])
+
+	assert.NoError(t, j.Remove())
+	exists, err = Exists(path)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestJournalPartialEntry(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "journal")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	path := filepath.Join(testDir, "testLedger")
+
+	j, err := Open(path)
+	assert.NoError(t, err)
+	sampleData := sampleBlocksAndPvtdata(t, 3)
+	for _, d := range sampleData[:2] {
+		assert.NoError(t, j.Append(d))
+	}
+	_, size, err := util.FileExists(path)
+	assert.NoError(t, err)
+	assert.NoError(t, j.Append(sampleData[2]))
+	assert.NoError(t, j.Close())
+
+	// simulate a crash while appending the last entry
+	_, fullSize, err := util.FileExists(path)
+	assert.NoError(t, err)
+	assert.NoError(t, os.Truncate(path, size+(fullSize-size)/2))
+
+	j, err = Open(path)
+	assert.NoError(t, err)
+	defer j.Close()
+	assert.Equal(t, 2, j.Len())
+	_, truncatedSize, err := util.FileExists(path)
+	assert.NoError(t, err)
+	assert.Equal(t, size, truncatedSize)
+	assertReplayed(t, j, sampleData[:2])
+
+	assert.NoError(t, j.Append(sampleData[2]))
+	assertReplayed(t, j, sampleData)
+}
+
+func TestJournalCorruptedEntry(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "journal")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	path := filepath.Join(testDir, "testLedger")
+
+	j, err := Open(path)
+	assert.NoError(t, err)
+	sampleData := sampleBlocksAndPvtdata(t, 2)
+	assert.NoError(t, j.Append(sampleData[0]))
+	_, size, err := util.FileExists(path)
+	assert.NoError(t, err)
+	assert.NoError(t, j.Append(sampleData[1]))
+	assert.NoError(t, j.Close())
+
+	// flip a byte of the last entry, which fails its checksum
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	b[size+10] ^= 0xff
+	assert.NoError(t, ioutil.WriteFile(path, b, 0660))
+
+	j, err = Open(path)
+	assert.NoError(t, err)
+	defer j.Close()
+	assert.Equal(t, 1, j.Len())
+	assertReplayed(t, j, sampleData[:1])
+}
+
+func TestEntryEncoding(t *testing.T) {
+	for _, d := range sampleBlocksAndPvtdata(t, 3) {
+		b, err := marshalEntry(d)
+		assert.NoError(t, err)
+		decoded, err := unmarshalEntry(b)
+		assert.NoError(t, err)
+		assertEqual(t, d, decoded)
+	}
+	_, err := unmarshalEntry([]byte("garbage"))
+	assert.Error(t, err)
+}
+
+func assertReplayed(t *testing.T, j *Journal, expected []*ledger.BlockAndPvtData) {
+	var replayed []*ledger.BlockAndPvtData
+	assert.NoError(t, j.Replay(func(d *ledger.BlockAndPvtData) error {
+		replayed = append(replayed, d)
+		return nil
+	}))
+	assert.Len(t, replayed, len(expected))
+	for i := range replayed {
+		assertEqual(t, expected[i], replayed[i])
+	}
+}
+
+func assertEqual(t *testing.T, expected, actual *ledger.BlockAndPvtData) {
+	assert.True(t, proto.Equal(expected.Block, actual.Block))
+	assert.Len(t, actual.BlockPvtData, len(expected.BlockPvtData))
+	for seq, pvtdata := range expected.BlockPvtData {
+		assert.Equal(t, pvtdata.SeqInBlock, actual.BlockPvtData[seq].SeqInBlock)
+		assert.True(t, proto.Equal(pvtdata.WriteSet, actual.BlockPvtData[seq].WriteSet))
+	}
+	assert.Equal(t, expected.Missing, actual.Missing)
+}
+
+func sampleBlocksAndPvtdata(t *testing.T, numBlocks int) []*ledger.BlockAndPvtData {
+	var sampleData []*ledger.BlockAndPvtData
+	for i, block := range testutil.ConstructTestBlocks(t, numBlocks) {
+		d := &ledger.BlockAndPvtData{Block: block}
+		if i%2 == 1 {
+			d.BlockPvtData = map[uint64]*ledger.TxPvtData{
+				0: {SeqInBlock: 0, WriteSet: sampleWriteSet("ns-1", "coll-1")},
+				2: {SeqInBlock: 2, WriteSet: sampleWriteSet("ns-2", "coll-2")},
+			}
+			d.Missing = &ledger.MissingPrivateDataList{}
+			d.Missing.Add("txid-1", 1, "ns-1", "coll-1", true)
+			d.Missing.Add("txid-3", 3, "ns-2", "coll-3", false)
+		}
+		sampleData = append(sampleData, d)
+	}
+	return sampleData
+}
+
+func sampleWriteSet(ns, coll string) *rwset.TxPvtReadWriteSet {
+	return &rwset.TxPvtReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+			{
+				Namespace: ns,
+				CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+					{CollectionName: coll, Rwset: []byte("rws-" + coll)},
+				},
+			},
+		},
+	}
+}
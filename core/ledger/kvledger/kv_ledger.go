@@ -11,6 +11,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/flogging"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/util"
@@ -19,7 +23,9 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/confighistory"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/journal"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr/lockbasedtxmgr"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
@@ -27,6 +33,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
 
@@ -41,6 +48,8 @@ type kvLedger struct {
 	historyDB              historydb.HistoryDB
 	configHistoryRetriever ledger.ConfigHistoryRetriever
 	blockAPIsRWLock        *sync.RWMutex
+	commitJournal          *journal.Journal
+	stateFormat            statedb.BinaryValueFormatConfigurable
 }
 
 // NewKVLedger constructs new `KVLedger`
@@ -52,7 +61,8 @@ func newKVLedger(
 	configHistoryMgr confighistory.Mgr,
 	stateListeners []ledger.StateListener,
 	bookkeeperProvider bookkeeping.Provider,
-	ccInfoProvider ledger.DeployedChaincodeInfoProvider) (*kvLedger, error) {
+	ccInfoProvider ledger.DeployedChaincodeInfoProvider,
+	commitJournal *journal.Journal) (*kvLedger, error) {
 
 	logger.Debugf("Creating KVLedger ledgerID=%s: ", ledgerID)
 	stateListeners = append(stateListeners, configHistoryMgr)
@@ -73,14 +83,60 @@ func newKVLedger(
 		return nil, err
 	}
 	l.initBlockStore(btlPolicy)
+	if err := l.initStateFormat(versionedDB); err != nil {
+		return nil, err
+	}
+	//Recommit the blocks of the commit journal lost by the block storage after a crash
+	if commitJournal != nil {
+		if err := commitJournal.Replay(l.blockStore.RecommitWithPvtData); err != nil {
+			panic(errors.WithMessage(err, "error during commit journal replay"))
+		}
+	}
 	//Recover both state DB and history DB if they are out of sync with block storage
 	if err := l.recoverDBs(); err != nil {
 		panic(errors.WithMessage(err, "error during state DB recovery"))
 	}
+	if err := l.initCommitJournal(commitJournal); err != nil {
+		return nil, err
+	}
 	l.configHistoryRetriever = configHistoryMgr.GetRetriever(ledgerID, l)
 	return l, nil
 }
 
+// initCommitJournal starts the commit journal afresh once its blocks are
+// recommitted, or removes it if the commit journal was disabled since it was
+// last used
+func (l *kvLedger) initCommitJournal(commitJournal *journal.Journal) error {
+	if commitJournal == nil {
+		return nil
+	}
+	l.commitJournal = commitJournal
+	if err := l.syncStores(); err != nil {
+		return err
+	}
+	if !ledgerconfig.IsCommitJournalEnabled() {
+		l.commitJournal = nil
+		return commitJournal.Remove()
+	}
+	return nil
+}
+
+// syncStores syncs the stores of the ledger to disk and truncates the commit
+// journal, whose blocks they no longer need for recovery
+func (l *kvLedger) syncStores() error {
+	logger.Debugf("[%s] Syncing ledger stores after [%d] blocks", l.ledgerID, l.commitJournal.Len())
+	if err := l.blockStore.Sync(); err != nil {
+		return err
+	}
+	if err := l.txtmgmt.Sync(); err != nil {
+		return err
+	}
+	if err := l.historyDB.Sync(); err != nil {
+		return err
+	}
+	return l.commitJournal.Truncate()
+}
+
 func (l *kvLedger) initTxMgr(versionedDB privacyenabledstate.DB, stateListeners []ledger.StateListener,
 	btlPolicy pvtdatapolicy.BTLPolicy, bookkeeperProvider bookkeeping.Provider) error {
 	var err error
@@ -92,6 +148,85 @@ func (l *kvLedger) initBlockStore(btlPolicy pvtdatapolicy.BTLPolicy) {
 	l.blockStore.Init(btlPolicy)
 }
 
+// initStateFormat has the state database store the binary values in the
+// format allowed by the application capabilities of the last config block
+func (l *kvLedger) initStateFormat(versionedDB privacyenabledstate.DB) error {
+	stateFormat, ok := versionedDB.(statedb.BinaryValueFormatConfigurable)
+	if !ok {
+		return nil
+	}
+	l.stateFormat = stateFormat
+	info, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	if info.Height == 0 {
+		return nil
+	}
+	lastBlock, err := l.blockStore.RetrieveBlockByNumber(info.Height - 1)
+	if err != nil {
+		return err
+	}
+	lastConfigIndex, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return errors.WithMessage(err, "error retrieving the index of the last config block")
+	}
+	configBlock, err := l.blockStore.RetrieveBlockByNumber(lastConfigIndex)
+	if err != nil {
+		return err
+	}
+	return l.updateStateFormat(configBlock)
+}
+
+// updateStateFormat has the state database store the binary values in the
+// format allowed by the application capabilities of the block, if it is a
+// config block
+func (l *kvLedger) updateStateFormat(block *common.Block) error {
+	if l.stateFormat == nil || !utils.IsConfigBlock(block) {
+		return nil
+	}
+	binaryValuesInDocuments, err := couchDBBinaryValues(block)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error reading the application capabilities of config block [%d]", block.Header.Number))
+	}
+	l.stateFormat.SetBinaryValuesInDocuments(binaryValuesInDocuments)
+	return nil
+}
+
+// couchDBBinaryValues returns whether the application capabilities of the
+// config block have the peers store the binary values in the documents of the
+// CouchDB state database
+func couchDBBinaryValues(configBlock *common.Block) (bool, error) {
+	env, err := utils.ExtractEnvelope(configBlock, 0)
+	if err != nil {
+		return false, err
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return false, err
+	}
+	configEnv, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil {
+		return false, err
+	}
+	if configEnv.Config == nil || configEnv.Config.ChannelGroup == nil {
+		return false, errors.New("config envelope carries no channel group")
+	}
+	appGroup, ok := configEnv.Config.ChannelGroup.Groups[channelconfig.ApplicationGroupKey]
+	if !ok {
+		return false, nil
+	}
+	capsValue, ok := appGroup.Values[channelconfig.CapabilitiesKey]
+	if !ok {
+		return false, nil
+	}
+	caps := &common.Capabilities{}
+	if err := proto.Unmarshal(capsValue.Value, caps); err != nil {
+		return false, errors.Wrap(err, "error unmarshaling the application capabilities")
+	}
+	return capabilities.NewApplicationProvider(caps.Capabilities).CouchDBBinaryValues(), nil
+}
+
 //Recover the state database and history database (if exist)
 //by recommitting last valid blocks
 func (l *kvLedger) recoverDBs() error {
@@ -144,16 +279,33 @@ func (l *kvLedger) recommitLostBlocks(firstBlockNum uint64, lastBlockNum uint64,
 	logger.Infof("Recommitting lost blocks - firstBlockNum=%d, lastBlockNum=%d, recoverables=%#v", firstBlockNum, lastBlockNum, recoverables)
 	var err error
 	var blockAndPvtdata *ledger.BlockAndPvtData
+	for _, r := range recoverables {
+		if b, ok := r.(bulkLoadable); ok {
+			if err := b.BeginBulkLoad(); err != nil {
+				return err
+			}
+		}
+	}
 	for blockNumber := firstBlockNum; blockNumber <= lastBlockNum; blockNumber++ {
 		if blockAndPvtdata, err = l.GetPvtDataAndBlockByNum(blockNumber, nil); err != nil {
 			return err
 		}
+		if err := l.updateStateFormat(blockAndPvtdata.Block); err != nil {
+			return err
+		}
 		for _, r := range recoverables {
 			if err := r.CommitLostBlock(blockAndPvtdata); err != nil {
 				return err
 			}
 		}
 	}
+	for _, r := range recoverables {
+		if b, ok := r.(bulkLoadable); ok {
+			if err := b.EndBulkLoad(); err != nil {
+				return err
+			}
+		}
+	}
 	logger.Infof("Recommitted lost blocks - firstBlockNum=%d, lastBlockNum=%d, recoverables=%#v", firstBlockNum, lastBlockNum, recoverables)
 	return nil
 }
@@ -161,6 +313,14 @@ func (l *kvLedger) recommitLostBlocks(firstBlockNum uint64, lastBlockNum uint64,
 // GetTransactionByID retrieves a transaction by id
 func (l *kvLedger) GetTransactionByID(txID string) (*peer.ProcessedTransaction, error) {
 	tranEnv, err := l.blockStore.RetrieveTxByID(txID)
+	if _, notFound := err.(ledger.NotFoundInIndexErr); notFound {
+		// the transactions committed before the snapshot the ledger was
+		// bootstrapped from are only known by their validation codes
+		if txVResult, codeErr := l.blockStore.RetrieveTxValidationCodeByTxID(txID); codeErr == nil {
+			return &peer.ProcessedTransaction{ValidationCode: int32(txVResult)}, nil
+		}
+		return nil, err
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -182,6 +342,30 @@ func (l *kvLedger) GetBlockchainInfo() (*common.BlockchainInfo, error) {
 	return bcInfo, err
 }
 
+// GetStateAndBlockchainInfo returns the value of the given key in the
+// committed state, along with the info about the blockchain whose blocks the
+// state reflects. No block is being committed while the value is read, as the
+// commit of a block holds the lock of the block APIs until its transactions
+// are committed to the state.
+func (l *kvLedger) GetStateAndBlockchainInfo(namespace string, key string) ([]byte, *common.BlockchainInfo, error) {
+	l.blockAPIsRWLock.RLock()
+	defer l.blockAPIsRWLock.RUnlock()
+	bcInfo, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return nil, nil, err
+	}
+	qe, err := l.txtmgmt.NewQueryExecutor(util.GenerateUUID())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer qe.Done()
+	value, err := qe.GetState(namespace, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return value, bcInfo, nil
+}
+
 // GetBlockByNumber returns block at a given height
 // blockNumber of  math.MaxUint64 will return last block
 func (l *kvLedger) GetBlockByNumber(blockNumber uint64) (*common.Block, error) {
@@ -256,6 +440,10 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 	block := pvtdataAndBlock.Block
 	blockNo := pvtdataAndBlock.Block.Header.Number
 
+	if err = l.updateStateFormat(block); err != nil {
+		return err
+	}
+
 	startStateValidation := time.Now()
 	logger.Debugf("[%s] Validating state for block [%d]", l.ledgerID, blockNo)
 	err = l.txtmgmt.ValidateAndPrepare(pvtdataAndBlock, true)
@@ -264,6 +452,15 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 	}
 	elapsedStateValidation := time.Since(startStateValidation) / time.Millisecond // duration in ms
 
+	// With the commit journal, the commit of the block is made durable by the single sync of
+	// the journal and the stores do not sync the commit until the next checkpoint
+	if l.commitJournal != nil {
+		logger.Debugf("[%s] Appending block [%d] to commit journal", l.ledgerID, blockNo)
+		if err = l.commitJournal.Append(pvtdataAndBlock); err != nil {
+			return err
+		}
+	}
+
 	startCommitBlockStorage := time.Now()
 	logger.Debugf("[%s] Committing block [%d] to storage", l.ledgerID, blockNo)
 	l.blockAPIsRWLock.Lock()
@@ -289,6 +486,12 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 		}
 	}
 
+	if l.commitJournal != nil && l.commitJournal.Len() >= ledgerconfig.GetCommitJournalCheckpointInterval() {
+		if err := l.syncStores(); err != nil {
+			panic(errors.WithMessage(err, "error during sync of ledger stores"))
+		}
+	}
+
 	elapsedCommitWithPvtData := time.Since(startStateValidation) / time.Millisecond // total duration in ms
 
 	logger.Infof("[%s] Committed block [%d] with %d transaction(s) in %dms (state_validation=%dms block_commit=%dms state_commit=%dms)",
@@ -348,6 +551,12 @@ func (l *kvLedger) GetMissingPvtDataTracker() (ledger.MissingPvtDataTracker, err
 
 // Close closes `KVLedger`
 func (l *kvLedger) Close() {
+	if l.commitJournal != nil {
+		if err := l.syncStores(); err != nil {
+			logger.Errorf("[%s] Error syncing ledger stores, the commit journal will be replayed at restart: %s", l.ledgerID, err)
+		}
+		l.commitJournal.Close()
+	}
 	l.blockStore.Shutdown()
 	l.txtmgmt.Shutdown()
 }
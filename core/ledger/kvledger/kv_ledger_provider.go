@@ -9,6 +9,7 @@ package kvledger
 import (
 	"bytes"
 	"fmt"
+	"path/filepath"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
@@ -17,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb/historyleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/journal"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
@@ -33,9 +35,14 @@ var (
 	ErrNonExistingLedgerID = errors.New("LedgerID does not exist")
 	// ErrLedgerNotOpened is thrown by a CloseLedger call if a ledger with the given id has not been opened
 	ErrLedgerNotOpened = errors.New("ledger is not opened yet")
+	// ErrLedgerLeft is thrown by a CreateLedger or OpenLedger call if the peer left the channel of the ledger
+	ErrLedgerLeft = errors.New("the peer left the channel of the ledger, its data is disposed of when the peer restarts")
 
 	underConstructionLedgerKey = []byte("underConstructionLedgerKey")
 	ledgerKeyPrefix            = []byte("l")
+	ledgerKeyStop              = []byte("m")
+	leftLedgerKeyPrefix        = []byte("r")
+	leftLedgerKeyStop          = []byte("s")
 )
 
 // Provider implements interface ledger.PeerLedgerProvider
@@ -56,6 +63,9 @@ func NewProvider() (ledger.PeerLedgerProvider, error) {
 	logger.Info("Initializing ledger provider")
 	// Initialize the ID store (inventory of chainIds/ledgerIds)
 	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
+	// the data of the ledgers left is disposed of before the stores shared by
+	// the ledgers are opened
+	disposeLeftLedgers(idStore)
 	ledgerStoreProvider := ledgerstorage.NewProvider()
 	bookkeepingProvider := bookkeeping.NewProvider()
 	// Initialize the versioned database (state database)
@@ -94,7 +104,7 @@ func (provider *Provider) Create(genesisBlock *common.Block) (ledger.PeerLedger,
 		return nil, err
 	}
 	if exists {
-		return nil, ErrLedgerIDExists
+		return provider.resumeLeftLedger(ledgerID, genesisBlock)
 	}
 	if err = provider.idStore.setUnderConstructionFlag(ledgerID); err != nil {
 		return nil, err
@@ -127,6 +137,12 @@ func (provider *Provider) Open(ledgerID string) (ledger.PeerLedger, error) {
 	if !exists {
 		return nil, ErrNonExistingLedgerID
 	}
+	if _, left, err := provider.idStore.getLedgerDisposal(ledgerID); err != nil || left {
+		if err == nil {
+			err = ErrLedgerLeft
+		}
+		return nil, err
+	}
 	return provider.openInternal(ledgerID)
 }
 
@@ -149,16 +165,35 @@ func (provider *Provider) openInternal(ledgerID string) (ledger.PeerLedger, erro
 		return nil, err
 	}
 
+	commitJournal, err := openCommitJournal(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create a kvLedger for this chain/ledger, which encasulates the underlying data stores
 	// (id store, blockstore, state database, history database)
 	l, err := newKVLedger(ledgerID, blockStore, vDB, historyDB, provider.configHistoryMgr,
-		provider.stateListeners, provider.bookkeepingProvider, provider.initializer.DeployedChaincodeInfoProvider)
+		provider.stateListeners, provider.bookkeepingProvider, provider.initializer.DeployedChaincodeInfoProvider,
+		commitJournal)
 	if err != nil {
 		return nil, err
 	}
 	return l, nil
 }
 
+// openCommitJournal opens the commit journal of the ledger if it is enabled, or if it
+// was enabled when the ledger was last used, so that the blocks it holds are recommitted
+func openCommitJournal(ledgerID string) (*journal.Journal, error) {
+	path := filepath.Join(ledgerconfig.GetCommitJournalPath(), ledgerID)
+	if !ledgerconfig.IsCommitJournalEnabled() {
+		exists, err := journal.Exists(path)
+		if err != nil || !exists {
+			return nil, err
+		}
+	}
+	return journal.Open(path)
+}
+
 // Exists implements the corresponding method from interface ledger.PeerLedgerProvider
 func (provider *Provider) Exists(ledgerID string) (bool, error) {
 	return provider.idStore.ledgerIDExists(ledgerID)
@@ -166,7 +201,34 @@ func (provider *Provider) Exists(ledgerID string) (bool, error) {
 
 // List implements the corresponding method from interface ledger.PeerLedgerProvider
 func (provider *Provider) List() ([]string, error) {
-	return provider.idStore.getAllLedgerIds()
+	ids, err := provider.idStore.getAllLedgerIds()
+	if err != nil {
+		return nil, err
+	}
+	left, err := provider.idStore.getLeftLedgers()
+	if err != nil {
+		return nil, err
+	}
+	var listed []string
+	for _, id := range ids {
+		if _, ok := left[id]; !ok {
+			listed = append(listed, id)
+		}
+	}
+	return listed, nil
+}
+
+// Leave implements the corresponding method from interface ledger.PeerLedgerProvider
+func (provider *Provider) Leave(ledgerID string, disposal ledger.LedgerDisposal) error {
+	exists, err := provider.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNonExistingLedgerID
+	}
+	logger.Infof("Leaving ledger [%s]", ledgerID)
+	return provider.idStore.markLedgerLeft(ledgerID, disposal)
 }
 
 // Close implements the corresponding method from interface ledger.PeerLedgerProvider
@@ -295,7 +357,7 @@ func (s *idStore) ledgerIDExists(ledgerID string) (bool, error) {
 
 func (s *idStore) getAllLedgerIds() ([]string, error) {
 	var ids []string
-	itr := s.db.GetIterator(nil, nil)
+	itr := s.db.GetIterator(ledgerKeyPrefix, ledgerKeyStop)
 	defer itr.Release()
 	itr.First()
 	for itr.Valid() {
@@ -309,6 +371,62 @@ func (s *idStore) getAllLedgerIds() ([]string, error) {
 	return ids, nil
 }
 
+func (s *idStore) getGenesisBlock(ledgerID string) (*common.Block, error) {
+	val, err := s.db.Get(s.encodeLedgerKey(ledgerID))
+	if err != nil {
+		return nil, err
+	}
+	if val == nil {
+		return nil, ErrNonExistingLedgerID
+	}
+	gb := &common.Block{}
+	if err := proto.Unmarshal(val, gb); err != nil {
+		return nil, err
+	}
+	return gb, nil
+}
+
+// removeLedgerID removes the ledger from the created ledgers, along with the
+// mark of a ledger left
+func (s *idStore) removeLedgerID(ledgerID string) error {
+	batch := &leveldb.Batch{}
+	batch.Delete(s.encodeLedgerKey(ledgerID))
+	batch.Delete(s.encodeLeftLedgerKey(ledgerID))
+	return s.db.WriteBatch(batch, true)
+}
+
+func (s *idStore) markLedgerLeft(ledgerID string, disposal ledger.LedgerDisposal) error {
+	return s.db.Put(s.encodeLeftLedgerKey(ledgerID), []byte{byte(disposal)}, true)
+}
+
+func (s *idStore) unmarkLedgerLeft(ledgerID string) error {
+	return s.db.Delete(s.encodeLeftLedgerKey(ledgerID), true)
+}
+
+// getLedgerDisposal returns the disposal of the ledger, and whether the ledger was left
+func (s *idStore) getLedgerDisposal(ledgerID string) (ledger.LedgerDisposal, bool, error) {
+	val, err := s.db.Get(s.encodeLeftLedgerKey(ledgerID))
+	if err != nil || len(val) == 0 {
+		return ledger.KeepLedger, false, err
+	}
+	return ledger.LedgerDisposal(val[0]), true, nil
+}
+
+// getLeftLedgers returns the disposals of the ledgers left
+func (s *idStore) getLeftLedgers() (map[string]ledger.LedgerDisposal, error) {
+	left := make(map[string]ledger.LedgerDisposal)
+	itr := s.db.GetIterator(leftLedgerKeyPrefix, leftLedgerKeyStop)
+	defer itr.Release()
+	for itr.Next() {
+		if len(itr.Value()) == 0 {
+			continue
+		}
+		ledgerID := string(itr.Key()[len(leftLedgerKeyPrefix):])
+		left[ledgerID] = ledger.LedgerDisposal(itr.Value()[0])
+	}
+	return left, itr.Error()
+}
+
 func (s *idStore) close() {
 	s.db.Close()
 }
@@ -317,6 +435,10 @@ func (s *idStore) encodeLedgerKey(ledgerID string) []byte {
 	return append(ledgerKeyPrefix, []byte(ledgerID)...)
 }
 
+func (s *idStore) encodeLeftLedgerKey(ledgerID string) []byte {
+	return append(leftLedgerKeyPrefix, []byte(ledgerID)...)
+}
+
 func (s *idStore) decodeLedgerID(key []byte) string {
 	return string(key[len(ledgerKeyPrefix):])
 }
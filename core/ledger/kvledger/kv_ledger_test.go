@@ -8,14 +8,18 @@ package kvledger
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/journal"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
 	"github.com/hyperledger/fabric/protos/common"
@@ -376,6 +380,122 @@ func TestKVLedgerDBRecovery(t *testing.T) {
 	)
 }
 
+func TestKVLedgerCommitJournalRecovery(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	viper.Set("ledger.commitJournal.enabled", true)
+	defer viper.Set("ledger.commitJournal.enabled", false)
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+	testLedgerid := "testLedger"
+	bg, gb := testutil.NewBlockGenerator(t, testLedgerid, false)
+	ledger, _ := provider.Create(gb)
+	defer ledger.Close()
+
+	collectionConfigBlk := prepareNextBlockForTestCollectionConfigs(t, ledger, bg, "simulationForCollConfig", "ns", map[string]uint64{"coll": 0})
+	assert.NoError(t, ledger.CommitWithPvtData(collectionConfigBlk))
+	blockAndPvtdata1 := prepareNextBlockForTest(t, ledger, bg, "SimulateForBlk1",
+		map[string]string{"key1": "value1.1", "key2": "value2.1", "key3": "value3.1"},
+		map[string]string{"key1": "pvtValue1.1", "key2": "pvtValue2.1", "key3": "pvtValue3.1"})
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtdata1))
+	assert.Equal(t, 3, ledger.(*kvLedger).commitJournal.Len())
+
+	// peer appends the block to the commit journal and fails before committing it to any store
+	blockAndPvtdata2 := prepareNextBlockForTest(t, ledger, bg, "SimulateForBlk2",
+		map[string]string{"key1": "value1.2", "key2": "value2.2", "key3": "value3.2"},
+		map[string]string{"key1": "pvtValue1.2", "key2": "pvtValue2.2", "key3": "pvtValue3.2"})
+	assert.NoError(t, ledger.(*kvLedger).txtmgmt.ValidateAndPrepare(blockAndPvtdata2, true))
+	assert.NoError(t, ledger.(*kvLedger).commitJournal.Append(blockAndPvtdata2))
+	ledger.(*kvLedger).commitJournal.Close()
+	ledger.(*kvLedger).commitJournal = nil
+	ledger.Close()
+	provider.Close()
+
+	// the block is recommitted from the commit journal to all the stores when the ledger is opened
+	provider = testutilNewProvider(t)
+	ledger, _ = provider.Open(testLedgerid)
+	checkBCSummaryForTest(t, ledger,
+		&bcSummary{
+			bcInfo: &common.BlockchainInfo{Height: 4,
+				CurrentBlockHash:  blockAndPvtdata2.Block.Header.Hash(),
+				PreviousBlockHash: blockAndPvtdata1.Block.Header.Hash()},
+
+			stateDBSavePoint: uint64(3),
+			stateDBKVs:       map[string]string{"key1": "value1.2", "key2": "value2.2", "key3": "value3.2"},
+			stateDBPvtKVs:    map[string]string{"key1": "pvtValue1.2", "key2": "pvtValue2.2", "key3": "pvtValue3.2"},
+
+			historyDBSavePoint: uint64(3),
+			historyKey:         "key1",
+			historyVals:        []string{"value1.1", "value1.2"},
+		},
+	)
+	assert.Equal(t, 0, ledger.(*kvLedger).commitJournal.Len())
+
+	// the stores are synced and the commit journal truncated every checkpoint interval
+	viper.Set("ledger.commitJournal.checkpointInterval", 2)
+	defer viper.Set("ledger.commitJournal.checkpointInterval", 100)
+	blockAndPvtdata3 := prepareNextBlockForTest(t, ledger, bg, "SimulateForBlk3",
+		map[string]string{"key1": "value1.3"}, map[string]string{"key1": "pvtValue1.3"})
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtdata3))
+	assert.Equal(t, 1, ledger.(*kvLedger).commitJournal.Len())
+	blockAndPvtdata4 := prepareNextBlockForTest(t, ledger, bg, "SimulateForBlk4",
+		map[string]string{"key1": "value1.4"}, map[string]string{"key1": "pvtValue1.4"})
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtdata4))
+	assert.Equal(t, 0, ledger.(*kvLedger).commitJournal.Len())
+	ledger.Close()
+	provider.Close()
+
+	// the commit journal is removed once the commit journal is disabled
+	viper.Set("ledger.commitJournal.enabled", false)
+	provider = testutilNewProvider(t)
+	ledger, _ = provider.Open(testLedgerid)
+	assert.Nil(t, ledger.(*kvLedger).commitJournal)
+	exists, err := journal.Exists(filepath.Join(ledgerconfig.GetCommitJournalPath(), testLedgerid))
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	checkBCSummaryForTest(t, ledger,
+		&bcSummary{
+			stateDBSavePoint: uint64(5),
+			stateDBKVs:       map[string]string{"key1": "value1.4", "key2": "value2.2", "key3": "value3.2"},
+		},
+	)
+}
+
+func TestCouchDBBinaryValues(t *testing.T) {
+	configBlock := func(appCapabilities map[string]bool) *common.Block {
+		config := &common.Config{ChannelGroup: common.NewConfigGroup()}
+		if appCapabilities != nil {
+			appGroup := common.NewConfigGroup()
+			appGroup.Values[channelconfig.CapabilitiesKey] = &common.ConfigValue{
+				Value: putils.MarshalOrPanic(channelconfig.CapabilitiesValue(appCapabilities).Value()),
+			}
+			config.ChannelGroup.Groups[channelconfig.ApplicationGroupKey] = appGroup
+		}
+		env, err := putils.CreateSignedEnvelope(common.HeaderType_CONFIG, "testchain", nil, &common.ConfigEnvelope{Config: config}, 0, 0)
+		assert.NoError(t, err)
+		block := common.NewBlock(0, nil)
+		block.Data.Data = [][]byte{putils.MarshalOrPanic(env)}
+		return block
+	}
+
+	binaryValuesInDocuments, err := couchDBBinaryValues(configBlock(nil))
+	assert.NoError(t, err)
+	assert.False(t, binaryValuesInDocuments)
+
+	binaryValuesInDocuments, err = couchDBBinaryValues(configBlock(map[string]bool{capabilities.ApplicationV1_3: true}))
+	assert.NoError(t, err)
+	assert.False(t, binaryValuesInDocuments)
+
+	binaryValuesInDocuments, err = couchDBBinaryValues(configBlock(map[string]bool{capabilities.ApplicationCouchDBBinaryValues: true}))
+	assert.NoError(t, err)
+	assert.True(t, binaryValuesInDocuments)
+
+	block := configBlock(nil)
+	block.Data.Data = [][]byte{[]byte("garbage")}
+	_, err = couchDBBinaryValues(block)
+	assert.Error(t, err)
+}
+
 func TestLedgerWithCouchDbEnabledWithBinaryAndJSONData(t *testing.T) {
 
 	//call a helper method to load the core.yaml
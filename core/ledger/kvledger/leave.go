@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// resumeLeftLedger opens the ledger kept when the peer left its channel, if it
+// was created from the given genesis block
+func (provider *Provider) resumeLeftLedger(ledgerID string, genesisBlock *common.Block) (ledger.PeerLedger, error) {
+	disposal, left, err := provider.idStore.getLedgerDisposal(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if !left {
+		return nil, ErrLedgerIDExists
+	}
+	if disposal != ledger.KeepLedger {
+		return nil, ErrLedgerLeft
+	}
+	gb, err := provider.idStore.getGenesisBlock(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(gb.Header.Hash(), genesisBlock.Header.Hash()) {
+		return nil, errors.Errorf("ledger [%s] was kept with another genesis block", ledgerID)
+	}
+	if err := provider.idStore.unmarkLedgerLeft(ledgerID); err != nil {
+		return nil, err
+	}
+	logger.Infof("Resuming ledger [%s] kept when the peer left its channel", ledgerID)
+	return provider.openInternal(ledgerID)
+}
+
+// disposeLeftLedgers archives or deletes the ledgers left, as instructed when
+// they were left. A ledger that cannot be disposed of remains left, and its
+// disposal is retried on the next start of the peer.
+func disposeLeftLedgers(idStore *idStore) {
+	left, err := idStore.getLeftLedgers()
+	if err != nil {
+		logger.Errorf("Error retrieving the ledgers left: %s", err)
+		return
+	}
+	for ledgerID, disposal := range left {
+		if disposal == ledger.KeepLedger {
+			continue
+		}
+		if err := disposeLedger(idStore, ledgerID, disposal); err != nil {
+			logger.Errorf("Error disposing of ledger [%s]: %s", ledgerID, err)
+		}
+	}
+}
+
+func disposeLedger(idStore *idStore, ledgerID string, disposal ledger.LedgerDisposal) error {
+	if disposal == ledger.ArchiveLedger {
+		dir := filepath.Join(ledgerconfig.GetArchivePath(), fmt.Sprintf("%s_%s", ledgerID, time.Now().UTC().Format("20060102T150405Z")))
+		if _, err := backupLedger(ledgerID, dir); err != nil {
+			return errors.WithMessage(err, "error archiving the ledger")
+		}
+		// the ledger is not archived again if its removal is retried
+		if err := idStore.markLedgerLeft(ledgerID, ledger.DeleteLedger); err != nil {
+			return err
+		}
+		logger.Infof("Archived ledger [%s] to [%s]", ledgerID, dir)
+	}
+	if err := removeLedgerData(ledgerID); err != nil {
+		return err
+	}
+	if err := idStore.removeLedgerID(ledgerID); err != nil {
+		return err
+	}
+	logger.Infof("Removed ledger [%s]", ledgerID)
+	return nil
+}
+
+// removeLedgerData removes the entries of the ledger from the dbs shared by
+// the ledgers, and its commit journal and block files
+func removeLedgerData(ledgerID string) error {
+	for _, db := range ledgerDBs(ledgerID) {
+		if err := clearLogicalDB(db.path, db.name); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error removing the entries of the ledger from [%s]", db.path))
+		}
+	}
+	for _, dir := range []string{filepath.Join(ledgerconfig.GetCommitJournalPath(), ledgerID), ledgerBlockDir(ledgerID)} {
+		if err := os.RemoveAll(dir); err != nil {
+			return errors.Wrapf(err, "error removing [%s]", dir)
+		}
+	}
+	return nil
+}
+
+// logicalDB is the logical db holding the entries of a ledger in a leveldb
+// shared by the ledgers
+type logicalDB struct {
+	path string
+	name string
+}
+
+func ledgerDBs(ledgerID string) []logicalDB {
+	dbs := []logicalDB{
+		{path: ledgerconfig.GetPvtdataStorePath(), name: ledgerID},
+		{path: ledgerconfig.GetConfigHistoryPath(), name: ledgerID},
+		{path: ledgerconfig.GetHistoryLevelDBPath(), name: ledgerID},
+		{path: ledgerconfig.GetInternalBookkeeperPath(), name: fmt.Sprintf("%s/%d", ledgerID, bookkeeping.PvtdataExpiry)},
+		{path: ledgerconfig.GetInternalBookkeeperPath(), name: fmt.Sprintf("%s/%d", ledgerID, bookkeeping.MetadataPresenceIndicator)},
+	}
+	// badger and rocksdb hold the block indexes along with the state
+	if ledgerconfig.IsBadgerDBEnabled() || ledgerconfig.IsRocksDBEnabled() {
+		logger.Warningf("The state and block index entries of ledger [%s] are not removed from badger or rocksdb", ledgerID)
+		return dbs
+	}
+	dbs = append(dbs, logicalDB{path: filepath.Join(ledgerconfig.GetBlockStorePath(), fsblkstorage.IndexDir), name: ledgerID})
+	if ledgerconfig.IsCouchDBEnabled() || ledgerconfig.IsStatePluginEnabled() {
+		logger.Warningf("The state databases of ledger [%s] are not removed from %s", ledgerID, ledgerconfig.GetStateDatabase())
+		return dbs
+	}
+	return append(dbs, logicalDB{path: ledgerconfig.GetStateLevelDBPath(), name: ledgerID})
+}
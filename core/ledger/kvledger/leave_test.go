@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaveAndResumeLedger(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	bcInfo := createLedgerForBackup(t, "testLedger")
+
+	provider := testutilNewProvider(t)
+	assert.Equal(t, ErrNonExistingLedgerID, provider.Leave("nonExistingLedger", lgr.KeepLedger))
+	assert.NoError(t, provider.Leave("testLedger", lgr.KeepLedger))
+	ids, err := provider.List()
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+	_, err = provider.Open("testLedger")
+	assert.Equal(t, ErrLedgerLeft, err)
+	provider.Close()
+
+	// a kept ledger survives the restart of the peer
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	gb, err := provider.(*Provider).idStore.getGenesisBlock("testLedger")
+	assert.NoError(t, err)
+	otherGB := proto.Clone(gb).(*common.Block)
+	otherGB.Header.DataHash = []byte("otherDataHash")
+	_, err = provider.Create(otherGB)
+	assert.EqualError(t, err, "ledger [testLedger] was kept with another genesis block")
+
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+	resumedBCInfo, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, bcInfo, resumedBCInfo)
+	ids, err = provider.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"testLedger"}, ids)
+	_, err = provider.Create(gb)
+	assert.Equal(t, ErrLedgerIDExists, err)
+}
+
+func TestLeaveAndDisposeLedger(t *testing.T) {
+	for _, disposal := range []lgr.LedgerDisposal{lgr.ArchiveLedger, lgr.DeleteLedger} {
+		env := newTestEnv(t)
+		createLedgerForBackup(t, "testLedger")
+
+		provider := testutilNewProvider(t)
+		assert.NoError(t, provider.Leave("testLedger", disposal))
+		provider.Close()
+
+		provider = testutilNewProvider(t)
+		exists, err := provider.Exists("testLedger")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		archives, _ := ioutil.ReadDir(ledgerconfig.GetArchivePath())
+		if disposal == lgr.ArchiveLedger {
+			assert.Len(t, archives, 1)
+			_, err = VerifyBackup(filepath.Join(ledgerconfig.GetArchivePath(), archives[0].Name()))
+			assert.NoError(t, err)
+		} else {
+			assert.Empty(t, archives)
+		}
+
+		// the channel can be joined again from scratch
+		_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+		ledger, err := provider.Create(gb)
+		assert.NoError(t, err)
+		bcInfo, err := ledger.GetBlockchainInfo()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), bcInfo.Height)
+		ledger.Close()
+		provider.Close()
+		env.cleanup()
+	}
+}
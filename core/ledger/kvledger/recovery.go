@@ -27,6 +27,13 @@ type recoverable interface {
 	CommitLostBlock(block *ledger.BlockAndPvtData) error
 }
 
+// bulkLoadable is implemented by the recoverables capable of recommitting many
+// blocks in bulk, which are not durable until the bulk load ends
+type bulkLoadable interface {
+	BeginBulkLoad() error
+	EndBulkLoad() error
+}
+
 type recoverer struct {
 	firstBlockNum uint64
 	recoverable   recoverable
@@ -0,0 +1,584 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/ledger/backup"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb/historyleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// The files holding the entries of a ledger in the state database, in the
+// bookkeeping dbs and in the collection config history, and the validation
+// codes of its transactions, alongside the block files of its snapshot
+const (
+	snapshotStateFile            = "state.kv"
+	snapshotPvtdataExpiryFile    = "pvtdataexpiry.kv"
+	snapshotMetadataPresenceFile = "metadatapresence.kv"
+	snapshotTxIDsFile            = "txids.kv"
+)
+
+// snapshotSignatureFile holds the signature of the manifest of a snapshot by
+// the peer which took it. It is written before the manifest, which completes
+// the snapshot.
+const snapshotSignatureFile = "manifest.sig"
+
+// SnapshotSigner signs the manifests of the snapshots with the identity of the
+// peer which takes them
+type SnapshotSigner interface {
+	// Serialize returns the serialized identity of the signer
+	Serialize() ([]byte, error)
+
+	// Sign returns the signature of the message by the signer
+	Sign(msg []byte) ([]byte, error)
+}
+
+// snapshotSignature is the content of the signature file of a snapshot
+type snapshotSignature struct {
+	Creator   []byte `json:"creator"`
+	Signature []byte `json:"signature"`
+}
+
+// txValidationCodesStore is implemented by the block stores which export and
+// import the validation codes of their transactions
+type txValidationCodesStore interface {
+	ExportTxValidationCodes(handle func(txID string, code peer.TxValidationCode) error) error
+	ImportTxValidationCodes(next func() (string, peer.TxValidationCode, error)) error
+}
+
+// SnapshotLedger writes a snapshot of the given ledger, at its current
+// height, to the output directory, which must not exist or be empty. The
+// peer must be stopped. A snapshot lets another peer join the channel of the
+// ledger without committing the blocks of the channel again: it holds the
+// last config block and the last block of the ledger, the public state and
+// the hashes of the private data, the collection config history, and the
+// validation codes of the transactions, by which the duplicate transactions
+// are detected. It holds neither the other blocks, nor the history of the
+// keys, nor the private data. The manifest of the snapshot, which holds the
+// hashes of its files, is signed by the signer, so that the peers joining the
+// channel from the snapshot know which member of the channel vouches for it.
+// Snapshots are only supported with the state database and the block index in
+// goleveldb.
+func SnapshotLedger(ledgerID string, outputDir string, signer SnapshotSigner) (*backup.Manifest, error) {
+	if err := checkSnapshotsSupported(); err != nil {
+		return nil, err
+	}
+	idStore, err := openIDStoreOffline()
+	if err != nil {
+		return nil, err
+	}
+	defer idStore.close()
+	exists, err := idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNonExistingLedgerID
+	}
+	if err := backup.CreateEmptyDir(outputDir); err != nil {
+		return nil, err
+	}
+
+	manifest, err := snapshotBlockStore(ledgerID, outputDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStateSavepoint(ledgerID, manifest.Height); err != nil {
+		return nil, err
+	}
+	for _, db := range snapshotDBs() {
+		file, err := db.export(ledgerID, outputDir)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+	if err := signManifest(outputDir, manifest, signer); err != nil {
+		return nil, err
+	}
+	if err := backup.WriteManifest(outputDir, manifest); err != nil {
+		return nil, err
+	}
+	logger.Infof("Wrote snapshot of ledger [%s] at height [%d] to [%s]", ledgerID, manifest.Height, outputDir)
+	return manifest, nil
+}
+
+// snapshotBlockStore writes the last config block and the last block of the
+// ledger, and the validation codes of its transactions, to the snapshot
+func snapshotBlockStore(ledgerID string, outputDir string) (*backup.Manifest, error) {
+	provider := ledgerstorage.NewProvider()
+	defer provider.Close()
+	store, err := provider.Open(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Shutdown()
+
+	bcInfo, err := store.GetBlockchainInfo()
+	if err != nil {
+		return nil, err
+	}
+	if bcInfo.Height == 0 {
+		return nil, errors.Errorf("ledger [%s] is empty", ledgerID)
+	}
+	lastBlock, err := store.RetrieveBlockByNumber(bcInfo.Height - 1)
+	if err != nil {
+		return nil, err
+	}
+	lastConfigIndex, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error retrieving the index of the last config block")
+	}
+	blocks := []*common.Block{lastBlock}
+	if lastConfigIndex != lastBlock.Header.Number {
+		configBlock, err := store.RetrieveBlockByNumber(lastConfigIndex)
+		if err != nil {
+			return nil, err
+		}
+		blocks = []*common.Block{configBlock, lastBlock}
+	}
+
+	manifest := &backup.Manifest{
+		LedgerID:         ledgerID,
+		Height:           bcInfo.Height,
+		CurrentBlockHash: bcInfo.CurrentBlockHash,
+		CreatedAt:        time.Now().UTC(),
+	}
+	if err := os.Mkdir(filepath.Join(outputDir, backup.BlocksDir), 0755); err != nil {
+		return nil, errors.Wrap(err, "error creating the blocks directory")
+	}
+	names, err := fsblkstorage.WriteBlockfiles(filepath.Join(outputDir, backup.BlocksDir), blocks)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		file, err := backup.DescribeFile(outputDir, filepath.Join(backup.BlocksDir, name))
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	codesStore, ok := store.BlockStore.(txValidationCodesStore)
+	if !ok {
+		return nil, errors.New("the block store does not export the validation codes of its transactions")
+	}
+	file, err := exportTxValidationCodes(codesStore, outputDir)
+	if err != nil {
+		return nil, err
+	}
+	manifest.Files = append(manifest.Files, file)
+	return manifest, nil
+}
+
+// checkStateSavepoint checks that the state database holds the state of the
+// ledger at the given height, which it may not if the peer stopped before the
+// last block of the ledger was committed to the state
+func checkStateSavepoint(ledgerID string, height uint64) error {
+	provider := stateleveldb.NewVersionedDBProvider()
+	defer provider.Close()
+	db, err := provider.GetDBHandle(ledgerID)
+	if err != nil {
+		return err
+	}
+	savepoint, err := db.GetLatestSavePoint()
+	if err != nil {
+		return err
+	}
+	if savepoint == nil || savepoint.BlockNum != height-1 {
+		return errors.Errorf("the state database of ledger [%s] is behind its block [%d], start the peer to bring it up to date", ledgerID, height-1)
+	}
+	return nil
+}
+
+// signManifest writes the signature of the manifest of the snapshot by the
+// signer to the snapshot
+func signManifest(outputDir string, manifest *backup.Manifest, signer SnapshotSigner) error {
+	manifestBytes, err := backup.MarshalManifest(manifest)
+	if err != nil {
+		return err
+	}
+	creator, err := signer.Serialize()
+	if err != nil {
+		return errors.WithMessage(err, "error serializing the identity of the signer")
+	}
+	signature, err := signer.Sign(manifestBytes)
+	if err != nil {
+		return errors.WithMessage(err, "error signing the snapshot manifest")
+	}
+	signatureBytes, err := json.Marshal(&snapshotSignature{Creator: creator, Signature: signature})
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(ioutil.WriteFile(filepath.Join(outputDir, snapshotSignatureFile), signatureBytes, 0644), "error writing the signature of the snapshot manifest")
+}
+
+func exportTxValidationCodes(store txValidationCodesStore, outputDir string) (backup.File, error) {
+	f, err := os.OpenFile(filepath.Join(outputDir, snapshotTxIDsFile), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return backup.File{}, errors.Wrapf(err, "error creating file [%s]", snapshotTxIDsFile)
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	err = store.ExportTxValidationCodes(func(txID string, code peer.TxValidationCode) error {
+		if err := writeLengthPrefixed(w, []byte(txID)); err != nil {
+			return err
+		}
+		return writeLengthPrefixed(w, []byte{byte(code)})
+	})
+	if err != nil {
+		return backup.File{}, errors.WithMessage(err, "error exporting the validation codes of the transactions")
+	}
+	if err := w.Flush(); err != nil {
+		return backup.File{}, errors.Wrapf(err, "error writing file [%s]", snapshotTxIDsFile)
+	}
+	if err := f.Sync(); err != nil {
+		return backup.File{}, errors.Wrapf(err, "error syncing file [%s]", snapshotTxIDsFile)
+	}
+	return backup.DescribeFile(outputDir, snapshotTxIDsFile)
+}
+
+// VerifySnapshot checks the files of the snapshot found in the given
+// directory against its manifest, and the blocks it holds, whose last block
+// must be signed by the ordering service of the last config block. The
+// manifest must be signed by a member of an application organization of the
+// channel, as configured by the last config block.
+func VerifySnapshot(dir string) (*backup.Manifest, error) {
+	manifest, _, err := verifySnapshot(dir)
+	return manifest, err
+}
+
+// verifySnapshot verifies the snapshot, and returns its manifest along with
+// the last config block and the last block of the ledger
+func verifySnapshot(dir string) (*backup.Manifest, []*common.Block, error) {
+	manifest, err := backup.ReadManifest(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := backup.VerifyFiles(dir, manifest); err != nil {
+		return nil, nil, err
+	}
+
+	var blocks []*common.Block
+	collect := func(block *common.Block) error {
+		blocks = append(blocks, block)
+		return nil
+	}
+	if _, err := fsblkstorage.ScanBlockfiles(filepath.Join(dir, backup.BlocksDir), collect); err != nil {
+		return nil, nil, errors.WithMessage(err, "error scanning the block files of the snapshot")
+	}
+	if len(blocks) == 0 || len(blocks) > 2 {
+		return nil, nil, errors.Errorf("the snapshot holds [%d] blocks, rather than the last config block and the last block of the ledger", len(blocks))
+	}
+	configBlock, lastBlock := blocks[0], blocks[len(blocks)-1]
+	if !utils.IsConfigBlock(configBlock) {
+		return nil, nil, errors.Errorf("block [%d] of the snapshot is not a config block", configBlock.Header.Number)
+	}
+	lastConfigIndex, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "error retrieving the index of the last config block")
+	}
+	if lastConfigIndex != configBlock.Header.Number {
+		return nil, nil, errors.Errorf("the last config block of the snapshot is block [%d], its last block expects block [%d]", configBlock.Header.Number, lastConfigIndex)
+	}
+	hashingAlgorithm, err := utils.GetHashingAlgorithmFromBlock(configBlock)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "error determining the hashing algorithm of the chain")
+	}
+	if lastBlock.Header.Number+1 != manifest.Height || !bytes.Equal(lastBlock.Header.HashWith(hashingAlgorithm), manifest.CurrentBlockHash) {
+		return nil, nil, errors.Errorf("the blocks of the snapshot end at height [%d], the manifest expects height [%d]", lastBlock.Header.Number+1, manifest.Height)
+	}
+	ledgerID, err := utils.GetChainIDFromBlock(configBlock)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ledgerID != manifest.LedgerID {
+		return nil, nil, errors.Errorf("the blocks of the snapshot belong to ledger [%s], the manifest expects ledger [%s]", ledgerID, manifest.LedgerID)
+	}
+	if !bytes.Equal(lastBlock.Data.HashWith(hashingAlgorithm), lastBlock.Header.DataHash) {
+		return nil, nil, errors.Errorf("the data of block [%d] of the snapshot does not match its header", lastBlock.Header.Number)
+	}
+	env, err := utils.ExtractEnvelope(configBlock, 0)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "error extracting the config envelope")
+	}
+	bundle, err := channelconfig.NewBundleFromEnvelope(env)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "error loading the channel config of the snapshot")
+	}
+	if err := verifyBlockSignatures(bundle, lastBlock); err != nil {
+		return nil, nil, err
+	}
+	if err := verifyManifestSignature(dir, bundle); err != nil {
+		return nil, nil, err
+	}
+	return manifest, blocks, nil
+}
+
+// verifyBlockSignatures checks that the signatures of the block satisfy the
+// block validation policy of the ordering service, as configured by the
+// config block. As the signatures cover the header of the block, which chains
+// the hashes of the blocks preceding it, this binds the snapshot to a height
+// and a current block hash of the channel which the orderers of the config
+// block vouched for.
+func verifyBlockSignatures(bundle *channelconfig.Bundle, block *common.Block) error {
+	policy, ok := bundle.PolicyManager().GetPolicy(policies.BlockValidation)
+	if !ok {
+		return errors.Errorf("the channel config of the snapshot has no [%s] policy", policies.BlockValidation)
+	}
+	metadata, err := utils.GetMetadataFromBlock(block, common.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error retrieving the signatures of block [%d]", block.Header.Number))
+	}
+	var signedData []*common.SignedData
+	for _, metadataSignature := range metadata.Signatures {
+		shdr, err := utils.GetSignatureHeader(metadataSignature.SignatureHeader)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error unmarshaling a signature header of block [%d]", block.Header.Number))
+		}
+		signedData = append(signedData, &common.SignedData{
+			Identity:  shdr.Creator,
+			Data:      util.ConcatenateBytes(metadata.Value, metadataSignature.SignatureHeader, block.Header.Bytes()),
+			Signature: metadataSignature.Signature,
+		})
+	}
+	if err := policy.Evaluate(signedData); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("the signatures of block [%d] of the snapshot do not satisfy the [%s] policy", block.Header.Number, policies.BlockValidation))
+	}
+	return nil
+}
+
+// verifyManifestSignature checks that the manifest of the snapshot is signed
+// by a valid identity of an application organization of the channel, as
+// configured by the config block of the snapshot
+func verifyManifestSignature(dir string, bundle *channelconfig.Bundle) error {
+	manifestBytes, err := ioutil.ReadFile(filepath.Join(dir, backup.ManifestFile))
+	if err != nil {
+		return errors.Wrap(err, "error reading the backup manifest")
+	}
+	signatureBytes, err := ioutil.ReadFile(filepath.Join(dir, snapshotSignatureFile))
+	if err != nil {
+		return errors.Wrap(err, "error reading the signature of the snapshot manifest")
+	}
+	signature := &snapshotSignature{}
+	if err := json.Unmarshal(signatureBytes, signature); err != nil {
+		return errors.Wrap(err, "error unmarshaling the signature of the snapshot manifest")
+	}
+	identity, err := bundle.MSPManager().DeserializeIdentity(signature.Creator)
+	if err != nil {
+		return errors.WithMessage(err, "the signer of the snapshot is not a member of the channel")
+	}
+	if err := identity.Validate(); err != nil {
+		return errors.WithMessage(err, "the identity of the signer of the snapshot is not valid")
+	}
+	mspID := identity.GetMSPIdentifier()
+	if !isApplicationOrg(bundle, mspID) {
+		return errors.Errorf("the signer of the snapshot belongs to [%s], which is not an application organization of the channel", mspID)
+	}
+	if err := identity.Verify(manifestBytes, signature.Signature); err != nil {
+		return errors.WithMessage(err, "the signature of the snapshot manifest is not valid")
+	}
+	logger.Infof("The snapshot of ledger [%s] is signed by a member of [%s]", bundle.ConfigtxValidator().ChainID(), mspID)
+	return nil
+}
+
+func isApplicationOrg(bundle *channelconfig.Bundle, mspID string) bool {
+	ac, ok := bundle.ApplicationConfig()
+	if !ok {
+		return false
+	}
+	for _, org := range ac.Organizations() {
+		if org.MSPID() == mspID {
+			return true
+		}
+	}
+	return false
+}
+
+// JoinBySnapshot creates the ledger whose snapshot is found in the given
+// directory, after verifying the snapshot, so that the peer joins the channel
+// of the ledger at the height of the snapshot and pulls the following blocks
+// from the other peers or the ordering service. The ledger must not exist on
+// the peer, and the peer must be stopped. The last block of the snapshot is
+// verified against the orderers of its last config block, and its manifest
+// against the application organizations of the config block. The snapshot
+// must still come from a trusted peer, whose organization is logged: the
+// config block is only as trustworthy as the snapshot, and the state cannot
+// be verified against the blocks.
+// As the ledger does not hold the blocks preceding the snapshot, the peer
+// cannot serve them, and a ledger created from a snapshot cannot be resumed
+// with the genesis block of its channel once the peer left the channel.
+func JoinBySnapshot(dir string) (*backup.Manifest, error) {
+	if err := checkSnapshotsSupported(); err != nil {
+		return nil, err
+	}
+	manifest, blocks, err := verifySnapshot(dir)
+	if err != nil {
+		return nil, err
+	}
+	ledgerID := manifest.LedgerID
+	configBlock, lastBlock := blocks[0], blocks[len(blocks)-1]
+
+	idStore, err := openIDStoreOffline()
+	if err != nil {
+		return nil, err
+	}
+	defer idStore.close()
+	exists, err := idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrLedgerIDExists
+	}
+	ledgerDir := ledgerBlockDir(ledgerID)
+	if err := backup.CreateEmptyDir(ledgerDir); err != nil {
+		return nil, err
+	}
+	for _, db := range ledgerDBs(ledgerID) {
+		if err := checkLogicalDBEmpty(db.path, db.name); err != nil {
+			return nil, err
+		}
+	}
+
+	joined := false
+	defer func() {
+		if joined {
+			return
+		}
+		// leave no partially created ledger behind, so that the join can be retried
+		if err := removeLedgerData(ledgerID); err != nil {
+			logger.Errorf("Error removing the data of ledger [%s]: %s", ledgerID, err)
+		}
+	}()
+
+	if err := backup.RestoreBlockfiles(dir, ledgerDir); err != nil {
+		return nil, err
+	}
+	for _, db := range snapshotDBs() {
+		if err := db.restore(ledgerID, dir); err != nil {
+			return nil, err
+		}
+	}
+	if err := importTxValidationCodes(ledgerID, dir); err != nil {
+		return nil, err
+	}
+	// the history database starts at the last block, as the blocks preceding
+	// it are not available to recommit
+	if ledgerconfig.IsHistoryDBEnabled() {
+		if err := commitToHistoryDB(ledgerID, lastBlock); err != nil {
+			return nil, err
+		}
+	}
+
+	// the ledger becomes visible to the peer once it is added to the id store
+	if err := idStore.createLedgerID(ledgerID, configBlock); err != nil {
+		return nil, err
+	}
+	joined = true
+	logger.Infof("Created ledger [%s] at height [%d] from the snapshot in [%s]", ledgerID, manifest.Height, dir)
+	return manifest, nil
+}
+
+// importTxValidationCodes opens the block store of the ledger, which indexes
+// the blocks of the snapshot, and imports the validation codes of the
+// transactions of the snapshot
+func importTxValidationCodes(ledgerID string, dir string) error {
+	f, err := os.Open(filepath.Join(dir, snapshotTxIDsFile))
+	if err != nil {
+		return errors.Wrapf(err, "error opening file [%s]", snapshotTxIDsFile)
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+
+	provider := ledgerstorage.NewProvider()
+	defer provider.Close()
+	store, err := provider.Open(ledgerID)
+	if err != nil {
+		return err
+	}
+	defer store.Shutdown()
+	codesStore, ok := store.BlockStore.(txValidationCodesStore)
+	if !ok {
+		return errors.New("the block store does not import the validation codes of transactions")
+	}
+	return codesStore.ImportTxValidationCodes(func() (string, peer.TxValidationCode, error) {
+		txID, err := readLengthPrefixed(r)
+		if err == io.EOF {
+			return "", 0, err
+		}
+		if err != nil {
+			return "", 0, errors.Wrapf(err, "error reading file [%s]", snapshotTxIDsFile)
+		}
+		code, err := readLengthPrefixed(r)
+		if err == nil && len(code) != 1 {
+			err = errors.New("invalid validation code")
+		}
+		if err != nil {
+			return "", 0, errors.Wrapf(err, "error reading file [%s]", snapshotTxIDsFile)
+		}
+		return string(txID), peer.TxValidationCode(int32(code[0])), nil
+	})
+}
+
+func commitToHistoryDB(ledgerID string, block *common.Block) error {
+	provider := historyleveldb.NewHistoryDBProvider()
+	defer provider.Close()
+	db, err := provider.GetDBHandle(ledgerID)
+	if err != nil {
+		return err
+	}
+	return db.Commit(block)
+}
+
+// checkSnapshotsSupported fails unless the state database and the block
+// index are held by goleveldb, whose entries are exported to the snapshots
+func checkSnapshotsSupported() error {
+	if ledgerconfig.IsCouchDBEnabled() || ledgerconfig.IsBadgerDBEnabled() || ledgerconfig.IsRocksDBEnabled() ||
+		ledgerconfig.IsStatePluginEnabled() {
+		return errors.New("snapshots are only supported with the state database and the block index in goleveldb")
+	}
+	return nil
+}
+
+// snapshotDBs returns the dbs whose entries are exported to the snapshots.
+// The private data is left out of the state, as it is only disseminated to
+// the peers of the members of its collections.
+func snapshotDBs() []*backupDB {
+	return []*backupDB{
+		{path: ledgerconfig.GetStateLevelDBPath(), file: snapshotStateFile, exclude: isPvtDataStateKey},
+		{path: ledgerconfig.GetInternalBookkeeperPath(), suffix: fmt.Sprintf("/%d", bookkeeping.PvtdataExpiry), file: snapshotPvtdataExpiryFile},
+		{path: ledgerconfig.GetInternalBookkeeperPath(), suffix: fmt.Sprintf("/%d", bookkeeping.MetadataPresenceIndicator), file: snapshotMetadataPresenceFile},
+		{path: ledgerconfig.GetConfigHistoryPath(), file: backupConfigHistoryFile},
+	}
+}
+
+func isPvtDataStateKey(key []byte) bool {
+	return privacyenabledstate.IsPvtDataNs(stateleveldb.KeyNamespace(key))
+}
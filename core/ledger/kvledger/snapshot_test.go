@@ -0,0 +1,255 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/backup"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/msp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func txIDOfBlock(t *testing.T, block *common.Block) string {
+	env, err := utils.GetEnvelopeFromBlock(block.Data.Data[0])
+	assert.NoError(t, err)
+	chdr, err := utils.ChannelHeader(env)
+	assert.NoError(t, err)
+	return chdr.TxId
+}
+
+// signBlock signs the block as the orderer of the sample config, whose
+// organization shares the MSP of the dev config
+func signBlock(t *testing.T, block *common.Block) {
+	assert.NoError(t, msptesttools.LoadDevMsp())
+	signer := localmsp.NewSigner()
+	shdr, err := signer.NewSignatureHeader()
+	assert.NoError(t, err)
+	signature := &common.MetadataSignature{SignatureHeader: utils.MarshalOrPanic(shdr)}
+	signature.Signature, err = signer.Sign(util.ConcatenateBytes(signature.SignatureHeader, block.Header.Bytes()))
+	assert.NoError(t, err)
+	block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = utils.MarshalOrPanic(&common.Metadata{
+		Signatures: []*common.MetadataSignature{signature},
+	})
+}
+
+// snapshotSigner returns the signing identity of the dev MSP, which is the
+// MSP of the application organization of the sample config
+func snapshotSigner(t *testing.T) msp.SigningIdentity {
+	assert.NoError(t, msptesttools.LoadDevMsp())
+	return mspmgmt.GetLocalSigningIdentityOrPanic()
+}
+
+func TestSnapshotAndJoin(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	// a ledger holding a block of collection configs and a block with private data
+	provider := testutilNewProvider(t)
+	bg, gb := testutil.NewBlockGenerator(t, "testledger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	collConfigBlk := prepareNextBlockForTestCollectionConfigs(t, ledger, bg, "txid1", "ns", map[string]uint64{"coll": 0})
+	assert.NoError(t, ledger.CommitWithPvtData(collConfigBlk))
+	blk := prepareNextBlockForTest(t, ledger, bg, "txid2",
+		map[string]string{"key1": "value1"}, map[string]string{"key2": "pvtValue2"})
+	signBlock(t, blk.Block)
+	assert.NoError(t, ledger.CommitWithPvtData(blk))
+	bcInfo, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	ledger.Close()
+	provider.Close()
+
+	snapshotDir := filepath.Join(env.path, "snapshot")
+	manifest, err := SnapshotLedger("testledger", snapshotDir, snapshotSigner(t))
+	assert.NoError(t, err)
+	assert.Equal(t, "testledger", manifest.LedgerID)
+	assert.Equal(t, bcInfo.Height, manifest.Height)
+	assert.Equal(t, bcInfo.CurrentBlockHash, manifest.CurrentBlockHash)
+	// the genesis and last blocks, the validation codes, and four dbs
+	assert.Len(t, manifest.Files, 7)
+	assert.FileExists(t, filepath.Join(snapshotDir, snapshotSignatureFile))
+
+	verified, err := VerifySnapshot(snapshotDir)
+	assert.NoError(t, err)
+	assert.Equal(t, manifest.Height, verified.Height)
+
+	// the output directory must be empty
+	_, err = SnapshotLedger("testledger", snapshotDir, snapshotSigner(t))
+	assert.EqualError(t, err, "directory ["+snapshotDir+"] is not empty")
+	_, err = SnapshotLedger("nonExistingLedger", filepath.Join(env.path, "snapshot2"), snapshotSigner(t))
+	assert.Equal(t, ErrNonExistingLedgerID, err)
+	// the ledger exists on the peer
+	_, err = JoinBySnapshot(snapshotDir)
+	assert.Equal(t, ErrLedgerIDExists, err)
+
+	// join with another peer
+	createTestEnv(t, filepath.Join(env.path, "joined"))
+	manifest, err = JoinBySnapshot(snapshotDir)
+	assert.NoError(t, err)
+	assert.Equal(t, "testledger", manifest.LedgerID)
+
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	ledger, err = provider.Open("testledger")
+	assert.NoError(t, err)
+	defer ledger.Close()
+	joinedBCInfo, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, bcInfo, joinedBCInfo)
+
+	// the public state and the hashes of the private data are joined, and
+	// the private data left out
+	qe, err := ledger.NewQueryExecutor()
+	assert.NoError(t, err)
+	value, err := qe.GetState("ns", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), value)
+	_, err = qe.GetPrivateData("ns", "coll", "key2")
+	assert.Contains(t, err.Error(), "private data matching public hash version is not available")
+	qe.Done()
+	collConfigInfo, err := ledger.GetConfigHistoryRetriever()
+	assert.NoError(t, err)
+	configs, err := collConfigInfo.MostRecentCollectionConfigBelow(3, "ns")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), configs.CommittingBlockNum)
+
+	// the blocks between the genesis block and the last block are missing,
+	// but their transactions are known
+	_, err = ledger.GetBlockByNumber(1)
+	assert.Error(t, err)
+	tx, err := ledger.GetTransactionByID(txIDOfBlock(t, collConfigBlk.Block))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(peer.TxValidationCode_VALID), tx.ValidationCode)
+	assert.Nil(t, tx.TransactionEnvelope)
+	tx, err = ledger.GetTransactionByID(txIDOfBlock(t, blk.Block))
+	assert.NoError(t, err)
+	assert.NotNil(t, tx.TransactionEnvelope)
+
+	// the ledger goes on from the last block
+	blk = prepareNextBlockForTest(t, ledger, bg, "txid3",
+		map[string]string{"key1": "value3"}, map[string]string{"key2": "pvtValue3"})
+	assert.NoError(t, ledger.CommitWithPvtData(blk))
+	qe, err = ledger.NewQueryExecutor()
+	assert.NoError(t, err)
+	defer qe.Done()
+	value, err = qe.GetState("ns", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value3"), value)
+	value, err = qe.GetPrivateData("ns", "coll", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("pvtValue3"), value)
+}
+
+func TestVerifySnapshotTampered(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	createLedgerForBackup(t, "testLedger")
+	snapshotDir := filepath.Join(env.path, "snapshot")
+	manifest, err := SnapshotLedger("testLedger", snapshotDir, snapshotSigner(t))
+	assert.NoError(t, err)
+
+	manifest.Height = 10
+	manifestBytes, err := json.Marshal(manifest)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, backup.ManifestFile), manifestBytes, 0644))
+	_, err = VerifySnapshot(snapshotDir)
+	assert.EqualError(t, err, "the blocks of the snapshot end at height [3], the manifest expects height [10]")
+
+	manifest.Height = 3
+	manifest.LedgerID = "otherLedger"
+	manifestBytes, err = json.Marshal(manifest)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, backup.ManifestFile), manifestBytes, 0644))
+	createTestEnv(t, filepath.Join(env.path, "joined"))
+	_, err = JoinBySnapshot(snapshotDir)
+	assert.EqualError(t, err, "the blocks of the snapshot belong to ledger [testLedger], the manifest expects ledger [otherLedger]")
+}
+
+func TestVerifySnapshotUnsigned(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	// the last block of the ledger is not signed by the orderers
+	createLedgerForBackup(t, "testledger")
+	snapshotDir := filepath.Join(env.path, "snapshot")
+	_, err := SnapshotLedger("testledger", snapshotDir, snapshotSigner(t))
+	assert.NoError(t, err)
+
+	_, err = VerifySnapshot(snapshotDir)
+	assert.Contains(t, err.Error(), "the signatures of block [2] of the snapshot do not satisfy the [/Channel/Orderer/BlockValidation] policy")
+	createTestEnv(t, filepath.Join(env.path, "joined"))
+	_, err = JoinBySnapshot(snapshotDir)
+	assert.Contains(t, err.Error(), "the signatures of block [2] of the snapshot do not satisfy the [/Channel/Orderer/BlockValidation] policy")
+	_, err = testutilNewProvider(t).Open("testledger")
+	assert.Equal(t, ErrNonExistingLedgerID, err)
+}
+
+func TestVerifySnapshotManifestSignature(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	bg, gb := testutil.NewBlockGenerator(t, "testledger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	collConfigBlk := prepareNextBlockForTestCollectionConfigs(t, ledger, bg, "txid1", "ns", map[string]uint64{"coll": 0})
+	assert.NoError(t, ledger.CommitWithPvtData(collConfigBlk))
+	blk := prepareNextBlockForTest(t, ledger, bg, "txid2",
+		map[string]string{"key1": "value1"}, map[string]string{"key2": "pvtValue2"})
+	signBlock(t, blk.Block)
+	assert.NoError(t, ledger.CommitWithPvtData(blk))
+	ledger.Close()
+	provider.Close()
+
+	snapshotDir := filepath.Join(env.path, "snapshot")
+	manifest, err := SnapshotLedger("testledger", snapshotDir, snapshotSigner(t))
+	assert.NoError(t, err)
+	_, err = VerifySnapshot(snapshotDir)
+	assert.NoError(t, err)
+	manifestPath := filepath.Join(snapshotDir, backup.ManifestFile)
+	signaturePath := filepath.Join(snapshotDir, snapshotSignatureFile)
+	manifestBytes, err := ioutil.ReadFile(manifestPath)
+	assert.NoError(t, err)
+	signatureBytes, err := ioutil.ReadFile(signaturePath)
+	assert.NoError(t, err)
+
+	// the manifest was modified after it was signed
+	manifest.CreatedAt = manifest.CreatedAt.Add(time.Hour)
+	assert.NoError(t, backup.WriteManifest(snapshotDir, manifest))
+	_, err = VerifySnapshot(snapshotDir)
+	assert.Contains(t, err.Error(), "the signature of the snapshot manifest is not valid")
+	assert.NoError(t, ioutil.WriteFile(manifestPath, manifestBytes, 0644))
+
+	// the signer is not a member of the channel
+	garbageSignatureBytes, err := json.Marshal(&snapshotSignature{Creator: []byte("garbage"), Signature: []byte("signature")})
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(signaturePath, garbageSignatureBytes, 0644))
+	_, err = VerifySnapshot(snapshotDir)
+	assert.Contains(t, err.Error(), "the signer of the snapshot is not a member of the channel")
+
+	// the snapshot is not signed
+	assert.NoError(t, os.Remove(signaturePath))
+	createTestEnv(t, filepath.Join(env.path, "joined"))
+	_, err = JoinBySnapshot(snapshotDir)
+	assert.Contains(t, err.Error(), "error reading the signature of the snapshot manifest")
+
+	assert.NoError(t, ioutil.WriteFile(signaturePath, signatureBytes, 0644))
+	_, err = JoinBySnapshot(snapshotDir)
+	assert.NoError(t, err)
+}
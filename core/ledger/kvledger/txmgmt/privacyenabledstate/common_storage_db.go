@@ -17,8 +17,10 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statebadgerdb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/staterocksdb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/protos/common"
@@ -47,12 +49,40 @@ func NewCommonStorageDBProvider(bookkeeperProvider bookkeeping.Provider) (DBProv
 		if vdbProvider, err = statecouchdb.NewVersionedDBProvider(); err != nil {
 			return nil, err
 		}
+	} else if ledgerconfig.IsRocksDBEnabled() {
+		if vdbProvider, err = staterocksdb.NewVersionedDBProvider(); err != nil {
+			return nil, err
+		}
+	} else if ledgerconfig.IsBadgerDBEnabled() {
+		vdbProvider = statebadgerdb.NewVersionedDBProvider()
+	} else if ledgerconfig.IsStatePluginEnabled() {
+		if vdbProvider, err = newPluggedVersionedDBProvider(); err != nil {
+			return nil, err
+		}
 	} else {
 		vdbProvider = stateleveldb.NewVersionedDBProvider()
 	}
 	return &CommonStorageDBProvider{vdbProvider, bookkeeperProvider}, nil
 }
 
+// newPluggedVersionedDBProvider constructs the state database registered
+// under the name ledger.state.stateDatabase holds, after loading the Go plugin
+// supplying it, if one is configured
+func newPluggedVersionedDBProvider() (statedb.VersionedDBProvider, error) {
+	name := ledgerconfig.GetStateDatabase()
+	if library := ledgerconfig.GetStatePluginLibrary(); library != "" {
+		if err := statedb.LoadVersionedDBProviderPlugin(name, library); err != nil {
+			return nil, err
+		}
+	}
+	factory, ok := statedb.GetVersionedDBProviderFactory(name)
+	if !ok {
+		return nil, errors.Errorf("no state database is registered under name [%s]", name)
+	}
+	logger.Infof("Using state database [%s]", name)
+	return factory()
+}
+
 // GetDBHandle implements function from interface DBProvider
 func (p *CommonStorageDBProvider) GetDBHandle(id string) (DB, error) {
 	vdb, err := p.VersionedDBProvider.GetDBHandle(id)
@@ -128,6 +158,41 @@ func (s *CommonStorageDB) ClearCachedVersions() {
 	}
 }
 
+// Sync implements corresponding function in interface DB
+func (s *CommonStorageDB) Sync() error {
+	syncable, ok := s.VersionedDB.(statedb.Syncable)
+	if ok {
+		return syncable.Sync()
+	}
+	return nil
+}
+
+// BeginBulkLoad implements corresponding function in interface DB
+func (s *CommonStorageDB) BeginBulkLoad() error {
+	bulkLoadable, ok := s.VersionedDB.(statedb.BulkLoadable)
+	if ok {
+		return bulkLoadable.BeginBulkLoad()
+	}
+	return nil
+}
+
+// EndBulkLoad implements corresponding function in interface DB
+func (s *CommonStorageDB) EndBulkLoad() error {
+	bulkLoadable, ok := s.VersionedDB.(statedb.BulkLoadable)
+	if ok {
+		return bulkLoadable.EndBulkLoad()
+	}
+	return nil
+}
+
+// SetBinaryValuesInDocuments implements function in interface statedb.BinaryValueFormatConfigurable
+func (s *CommonStorageDB) SetBinaryValuesInDocuments(enabled bool) {
+	configurable, ok := s.VersionedDB.(statedb.BinaryValueFormatConfigurable)
+	if ok {
+		configurable.SetBinaryValuesInDocuments(enabled)
+	}
+}
+
 // GetChaincodeEventListener implements corresponding function in interface DB
 func (s *CommonStorageDB) GetChaincodeEventListener() cceventmgmt.ChaincodeLifecycleEventListener {
 	_, ok := s.VersionedDB.(statedb.IndexCapable)
@@ -343,6 +408,12 @@ func (s *CommonStorageDB) ChaincodeDeployDone(succeeded bool) {
 	// NOOP
 }
 
+// IsPvtDataNs returns true if the given namespace of the state db holds the
+// private data of a collection, rather than the hashes of the private data
+func IsPvtDataNs(ns string) bool {
+	return strings.Contains(ns, nsJoiner+pvtDataPrefix)
+}
+
 func derivePvtDataNs(namespace, collection string) string {
 	return namespace + nsJoiner + pvtDataPrefix + collection
 }
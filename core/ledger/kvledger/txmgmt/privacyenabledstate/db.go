@@ -39,6 +39,9 @@ type DB interface {
 	GetPrivateDataMetadataByHash(namespace, collection string, keyHash []byte) ([]byte, error)
 	ExecuteQueryOnPrivateData(namespace, collection, query string) (statedb.ResultsIterator, error)
 	ApplyPrivacyAwareUpdates(updates *UpdateBatch, height *version.Height) error
+	Sync() error
+	BeginBulkLoad() error
+	EndBulkLoad() error
 }
 
 // PvtdataCompositeKey encloses Namespace, CollectionName and Key components
@@ -18,7 +18,9 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
@@ -596,3 +598,43 @@ func deletePvtUpdates(t *testing.T, updates *UpdateBatch, ns, coll, key string,
 	updates.PvtUpdates.Delete(ns, coll, key, ver)
 	updates.HashUpdates.Delete(ns, coll, util.ComputeStringHash(key), ver)
 }
+
+func TestPluggedStateDB(t *testing.T) {
+	defer viper.Set("ledger.state.stateDatabase", "")
+	removeDBPath(t)
+	defer removeDBPath(t)
+	bookkeeperTestEnv := bookkeeping.NewTestEnv(t)
+	defer bookkeeperTestEnv.Cleanup()
+
+	// a state database compiled into the peer
+	assert.NoError(t, statedb.RegisterVersionedDBProvider("testplugin", func() (statedb.VersionedDBProvider, error) {
+		return stateleveldb.NewVersionedDBProvider(), nil
+	}))
+	viper.Set("ledger.state.stateDatabase", "testplugin")
+	provider, err := NewCommonStorageDBProvider(bookkeeperTestEnv.TestProvider)
+	assert.NoError(t, err)
+	defer provider.Close()
+	db, err := provider.GetDBHandle("testledger")
+	assert.NoError(t, err)
+	batch := NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	assert.NoError(t, db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 1)))
+	vv, err := db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), vv.Value)
+
+	// a state database registered twice
+	assert.EqualError(t, statedb.RegisterVersionedDBProvider("testplugin", nil),
+		"a state database is already registered under name [testplugin]")
+
+	// a state database registered by no one
+	viper.Set("ledger.state.stateDatabase", "unknown")
+	_, err = NewCommonStorageDBProvider(bookkeeperTestEnv.TestProvider)
+	assert.EqualError(t, err, "no state database is registered under name [unknown]")
+
+	// a state database supplied by a missing plugin
+	viper.Set("ledger.state.pluginConfig.library", "/non/existing/plugin.so")
+	defer viper.Set("ledger.state.pluginConfig.library", "")
+	_, err = NewCommonStorageDBProvider(bookkeeperTestEnv.TestProvider)
+	assert.Contains(t, err.Error(), "error opening state database plugin at path [/non/existing/plugin.so]")
+}
@@ -66,6 +66,7 @@ type rangeQueryKey struct {
 	startKey     string
 	endKey       string
 	itrExhausted bool
+	reverse      bool
 }
 
 // NewRWSetBuilder constructs a new instance of RWSetBuilder
@@ -95,7 +96,7 @@ func (b *RWSetBuilder) AddToMetadataWriteSet(ns, key string, metadata map[string
 // AddToRangeQuerySet adds a range query info for performing phantom read validation
 func (b *RWSetBuilder) AddToRangeQuerySet(ns string, rqi *kvrwset.RangeQueryInfo) {
 	nsPubRwBuilder := b.getOrCreateNsPubRwBuilder(ns)
-	key := rangeQueryKey{rqi.StartKey, rqi.EndKey, rqi.ItrExhausted}
+	key := rangeQueryKey{rqi.StartKey, rqi.EndKey, rqi.ItrExhausted, rqi.Reverse}
 	_, ok := nsPubRwBuilder.rangeQueriesMap[key]
 	if !ok {
 		nsPubRwBuilder.rangeQueriesMap[key] = rqi
@@ -204,6 +204,38 @@ func TestIterator(t *testing.T, dbProvider statedb.VersionedDBProvider) {
 
 }
 
+// TestReverseIterator tests the reverse iterator
+func TestReverseIterator(t *testing.T, dbProvider statedb.VersionedDBProvider) {
+	db, err := dbProvider.GetDBHandle("testreverseiterator")
+	assert.NoError(t, err)
+	db.Open()
+	defer db.Close()
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 2))
+	batch.Put("ns1", "key3", []byte("value3"), version.NewHeight(1, 3))
+	batch.Put("ns1", "key4", []byte("value4"), version.NewHeight(1, 4))
+	batch.Put("ns2", "key5", []byte("value5"), version.NewHeight(1, 5))
+	batch.Put("ns2", "key6", []byte("value6"), version.NewHeight(1, 6))
+	batch.Put("ns3", "key7", []byte("value7"), version.NewHeight(1, 7))
+	savePoint := version.NewHeight(2, 5)
+	db.ApplyUpdates(batch, savePoint)
+	itr1, _ := db.GetStateRangeScanIteratorReverse("ns1", "key1", "")
+	testItr(t, itr1, []string{"key4", "key3", "key2", "key1"})
+
+	itr2, _ := db.GetStateRangeScanIteratorReverse("ns1", "key2", "key4")
+	testItr(t, itr2, []string{"key3", "key2"})
+
+	itr3, _ := db.GetStateRangeScanIteratorReverse("ns1", "", "")
+	testItr(t, itr3, []string{"key4", "key3", "key2", "key1"})
+
+	itr4, _ := db.GetStateRangeScanIteratorReverse("ns2", "", "key55")
+	testItr(t, itr4, []string{"key5"})
+
+	itr5, _ := db.GetStateRangeScanIteratorReverse("ns1", "key5", "")
+	testItr(t, itr5, []string{})
+}
+
 func testItr(t *testing.T, itr statedb.ResultsIterator, expectedKeys []string) {
 	defer itr.Close()
 	for _, expectedKey := range expectedKeys {
@@ -947,3 +979,42 @@ func TestItrWithoutClose(t *testing.T, itr statedb.ResultsIterator, expectedKeys
 	assert.NoError(t, err, "An unexpected error was thrown during iterator Next()")
 	assert.Nil(t, queryResult)
 }
+
+// TestBulkLoad tests the updates applied in bulk load mode
+func TestBulkLoad(t *testing.T, dbProvider statedb.VersionedDBProvider) {
+	db, err := dbProvider.GetDBHandle("testbulkload")
+	assert.NoError(t, err)
+	bulkLoadable, ok := db.(statedb.BulkLoadable)
+	assert.True(t, ok)
+	assert.NoError(t, bulkLoadable.BeginBulkLoad())
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 2))
+	batch.Put("ns2", "key3", []byte("value3"), version.NewHeight(1, 3))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 3)))
+
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1.2"), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key2", version.NewHeight(2, 2))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 2)))
+	assert.NoError(t, bulkLoadable.EndBulkLoad())
+
+	savepoint, err := db.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(2, 2), savepoint)
+	vv, _ := db.GetState("ns1", "key1")
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value1.2"), Version: version.NewHeight(2, 1)}, vv)
+	vv, _ = db.GetState("ns1", "key2")
+	assert.Nil(t, vv)
+	vv, _ = db.GetState("ns2", "key3")
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value3"), Version: version.NewHeight(1, 3)}, vv)
+
+	// the updates applied after the bulk load are recorded as usual
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key2", []byte("value2.3"), version.NewHeight(3, 1))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(3, 1)))
+	savepoint, err = db.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(3, 1), savepoint)
+}
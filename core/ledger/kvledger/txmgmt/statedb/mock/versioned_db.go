@@ -66,6 +66,21 @@ type VersionedDB struct {
 		result1 statedb.ResultsIterator
 		result2 error
 	}
+	GetStateRangeScanIteratorReverseStub        func(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error)
+	getStateRangeScanIteratorReverseMutex       sync.RWMutex
+	getStateRangeScanIteratorReverseArgsForCall []struct {
+		namespace string
+		startKey  string
+		endKey    string
+	}
+	getStateRangeScanIteratorReverseReturns struct {
+		result1 statedb.ResultsIterator
+		result2 error
+	}
+	getStateRangeScanIteratorReverseReturnsOnCall map[int]struct {
+		result1 statedb.ResultsIterator
+		result2 error
+	}
 	GetStateRangeScanIteratorWithMetadataStub        func(namespace string, startKey string, endKey string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error)
 	getStateRangeScanIteratorWithMetadataMutex       sync.RWMutex
 	getStateRangeScanIteratorWithMetadataArgsForCall []struct {
@@ -385,6 +400,59 @@ func (fake *VersionedDB) GetStateRangeScanIteratorReturnsOnCall(i int, result1 s
 	}{result1, result2}
 }
 
+func (fake *VersionedDB) GetStateRangeScanIteratorReverse(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
+	fake.getStateRangeScanIteratorReverseMutex.Lock()
+	ret, specificReturn := fake.getStateRangeScanIteratorReverseReturnsOnCall[len(fake.getStateRangeScanIteratorReverseArgsForCall)]
+	fake.getStateRangeScanIteratorReverseArgsForCall = append(fake.getStateRangeScanIteratorReverseArgsForCall, struct {
+		namespace string
+		startKey  string
+		endKey    string
+	}{namespace, startKey, endKey})
+	fake.recordInvocation("GetStateRangeScanIteratorReverse", []interface{}{namespace, startKey, endKey})
+	fake.getStateRangeScanIteratorReverseMutex.Unlock()
+	if fake.GetStateRangeScanIteratorReverseStub != nil {
+		return fake.GetStateRangeScanIteratorReverseStub(namespace, startKey, endKey)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getStateRangeScanIteratorReverseReturns.result1, fake.getStateRangeScanIteratorReverseReturns.result2
+}
+
+func (fake *VersionedDB) GetStateRangeScanIteratorReverseCallCount() int {
+	fake.getStateRangeScanIteratorReverseMutex.RLock()
+	defer fake.getStateRangeScanIteratorReverseMutex.RUnlock()
+	return len(fake.getStateRangeScanIteratorReverseArgsForCall)
+}
+
+func (fake *VersionedDB) GetStateRangeScanIteratorReverseArgsForCall(i int) (string, string, string) {
+	fake.getStateRangeScanIteratorReverseMutex.RLock()
+	defer fake.getStateRangeScanIteratorReverseMutex.RUnlock()
+	return fake.getStateRangeScanIteratorReverseArgsForCall[i].namespace, fake.getStateRangeScanIteratorReverseArgsForCall[i].startKey, fake.getStateRangeScanIteratorReverseArgsForCall[i].endKey
+}
+
+func (fake *VersionedDB) GetStateRangeScanIteratorReverseReturns(result1 statedb.ResultsIterator, result2 error) {
+	fake.GetStateRangeScanIteratorReverseStub = nil
+	fake.getStateRangeScanIteratorReverseReturns = struct {
+		result1 statedb.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *VersionedDB) GetStateRangeScanIteratorReverseReturnsOnCall(i int, result1 statedb.ResultsIterator, result2 error) {
+	fake.GetStateRangeScanIteratorReverseStub = nil
+	if fake.getStateRangeScanIteratorReverseReturnsOnCall == nil {
+		fake.getStateRangeScanIteratorReverseReturnsOnCall = make(map[int]struct {
+			result1 statedb.ResultsIterator
+			result2 error
+		})
+	}
+	fake.getStateRangeScanIteratorReverseReturnsOnCall[i] = struct {
+		result1 statedb.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *VersionedDB) GetStateRangeScanIteratorWithMetadata(namespace string, startKey string, endKey string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
 	fake.getStateRangeScanIteratorWithMetadataMutex.Lock()
 	ret, specificReturn := fake.getStateRangeScanIteratorWithMetadataReturnsOnCall[len(fake.getStateRangeScanIteratorWithMetadataArgsForCall)]
@@ -797,6 +865,8 @@ func (fake *VersionedDB) Invocations() map[string][][]interface{} {
 	defer fake.getStateMultipleKeysMutex.RUnlock()
 	fake.getStateRangeScanIteratorMutex.RLock()
 	defer fake.getStateRangeScanIteratorMutex.RUnlock()
+	fake.getStateRangeScanIteratorReverseMutex.RLock()
+	defer fake.getStateRangeScanIteratorReverseMutex.RUnlock()
 	fake.getStateRangeScanIteratorWithMetadataMutex.RLock()
 	defer fake.getStateRangeScanIteratorWithMetadataMutex.RUnlock()
 	fake.executeQueryMutex.RLock()
@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"plugin"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// pluginFactory is the name of the constructor exported by the Go plugins
+// supplying a state database
+const pluginFactory = "NewVersionedDBProvider"
+
+// VersionedDBProviderFactory constructs the VersionedDBProvider of a state
+// database. A Go plugin supplying a state database exports one as
+// NewVersionedDBProvider.
+type VersionedDBProviderFactory func() (VersionedDBProvider, error)
+
+var (
+	factoriesLock sync.Mutex
+	factories     = map[string]VersionedDBProviderFactory{}
+)
+
+// RegisterVersionedDBProvider registers the factory of the state database
+// which ledger.state.stateDatabase selects by the given name. A state database
+// compiled into the peer, such as one behind a build tag, registers from the
+// init function of its package.
+func RegisterVersionedDBProvider(name string, factory VersionedDBProviderFactory) error {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+	if _, exists := factories[name]; exists {
+		return errors.Errorf("a state database is already registered under name [%s]", name)
+	}
+	factories[name] = factory
+	return nil
+}
+
+// GetVersionedDBProviderFactory returns the factory of the state database
+// registered under the given name, if any
+func GetVersionedDBProviderFactory(name string) (VersionedDBProviderFactory, bool) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+	factory, ok := factories[name]
+	return factory, ok
+}
+
+// LoadVersionedDBProviderPlugin registers under the given name the factory
+// exported by the Go plugin at the given path, unless a state database is
+// registered under that name already
+func LoadVersionedDBProviderPlugin(name, path string) error {
+	if _, ok := GetVersionedDBProviderFactory(name); ok {
+		return nil
+	}
+	p, err := plugin.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "error opening state database plugin at path [%s]", path)
+	}
+	symbol, err := p.Lookup(pluginFactory)
+	if err != nil {
+		return errors.Wrapf(err, "state database plugin at path [%s] must export %s", path, pluginFactory)
+	}
+	factory, ok := symbol.(func() (VersionedDBProvider, error))
+	if !ok {
+		return errors.Errorf("%s of state database plugin at path [%s] does not match the expected definition", pluginFactory, path)
+	}
+	return RegisterVersionedDBProvider(name, factory)
+}
@@ -0,0 +1,41 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterVersionedDBProvider(t *testing.T) {
+	_, ok := GetVersionedDBProviderFactory("registrytest")
+	assert.False(t, ok)
+
+	var constructed bool
+	assert.NoError(t, RegisterVersionedDBProvider("registrytest", func() (VersionedDBProvider, error) {
+		constructed = true
+		return nil, nil
+	}))
+	factory, ok := GetVersionedDBProviderFactory("registrytest")
+	assert.True(t, ok)
+	_, err := factory()
+	assert.NoError(t, err)
+	assert.True(t, constructed)
+
+	assert.EqualError(t, RegisterVersionedDBProvider("registrytest", nil),
+		"a state database is already registered under name [registrytest]")
+	// the plugin of a state database registered already is not loaded
+	assert.NoError(t, LoadVersionedDBProviderPlugin("registrytest", "/non/existing/plugin.so"))
+}
+
+func TestLoadVersionedDBProviderPluginMissing(t *testing.T) {
+	err := LoadVersionedDBProviderPlugin("missingplugin", "/non/existing/plugin.so")
+	assert.Contains(t, err.Error(), "error opening state database plugin at path [/non/existing/plugin.so]")
+	_, ok := GetVersionedDBProviderFactory("missingplugin")
+	assert.False(t, ok)
+}
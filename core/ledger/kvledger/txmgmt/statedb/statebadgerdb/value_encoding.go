@@ -0,0 +1,49 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebadgerdb
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb/msgs"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/pkg/errors"
+)
+
+// encodeValue encodes the versioned value in the same format as stateleveldb
+func encodeValue(v *statedb.VersionedValue) ([]byte, error) {
+	vvMsg := &msgs.VersionedValueProto{
+		VersionBytes: v.Version.ToBytes(),
+		Value:        v.Value,
+		Metadata:     v.Metadata,
+	}
+	encodedValue, err := proto.Marshal(vvMsg)
+	if err != nil {
+		return nil, err
+	}
+	encodedValue = append([]byte{0}, encodedValue...)
+	return encodedValue, nil
+}
+
+func decodeValue(encodedValue []byte) (*statedb.VersionedValue, error) {
+	if len(encodedValue) == 0 || encodedValue[0] != 0 {
+		return nil, errors.New("unexpected encoding of the value")
+	}
+	msg := &msgs.VersionedValueProto{}
+	err := proto.Unmarshal(encodedValue[1:], msg)
+	if err != nil {
+		return nil, err
+	}
+	ver, _ := version.NewHeightFromBytes(msg.VersionBytes)
+	val := msg.Value
+	metadata := msg.Metadata
+	// protobuf always makes an empty byte array as nil
+	if val == nil {
+		val = []byte{}
+	}
+	return &statedb.VersionedValue{Version: ver, Value: val, Metadata: metadata}, nil
+}
@@ -7,8 +7,10 @@ package statecouchdb
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/util/couchdb"
 	"github.com/pkg/errors"
@@ -17,10 +19,11 @@ import (
 // nsCommittersBuilder implements `batch` interface. Each batch operates on a specific namespace in the updates and
 // builds one or more batches of type subNsCommitter.
 type nsCommittersBuilder struct {
-	updates         map[string]*statedb.VersionedValue
-	db              *couchdb.CouchDatabase
-	revisions       map[string]string
-	subNsCommitters []batch
+	updates          map[string]*statedb.VersionedValue
+	db               *couchdb.CouchDatabase
+	revisions        map[string]string
+	binaryInDocument bool
+	subNsCommitters  []batch
 }
 
 // subNsCommitter implements `batch` interface. Each batch commits the portion of updates within a namespace assigned to it
@@ -45,7 +48,8 @@ func (vdb *VersionedDB) buildCommitters(updates *statedb.UpdateBatch) ([]batch,
 		}
 		// for each namespace, construct one builder with the corresponding couchdb handle and couch revisions
 		// that are already loaded into cache (during validation phase)
-		nsCommitterBuilder = append(nsCommitterBuilder, &nsCommittersBuilder{updates: nsUpdates, db: db, revisions: nsRevs})
+		nsCommitterBuilder = append(nsCommitterBuilder, &nsCommittersBuilder{updates: nsUpdates, db: db, revisions: nsRevs,
+			binaryInDocument: vdb.binaryValuesInDocuments()})
 	}
 	if err := executeBatches(nsCommitterBuilder); err != nil {
 		return nil, err
@@ -67,7 +71,7 @@ func (builder *nsCommittersBuilder) execute() error {
 	maxBacthSize := ledgerconfig.GetMaxBatchUpdateSize()
 	batchUpdateMap := make(map[string]*batchableDocument)
 	for key, vv := range builder.updates {
-		couchDoc, err := keyValToCouchDoc(&keyValue{key: key, VersionedValue: vv}, builder.revisions[key])
+		couchDoc, err := keyValToCouchDoc(&keyValue{key: key, VersionedValue: vv}, builder.revisions[key], builder.binaryInDocument)
 		if err != nil {
 			return err
 		}
@@ -191,3 +195,30 @@ type batchableDocument struct {
 	CouchDoc couchdb.CouchDoc
 	Deleted  bool
 }
+
+// bulkLoadState tracks the updates applied during a bulk load, whose flush and
+// savepoint are deferred until the bulk load ends
+type bulkLoadState struct {
+	height     *version.Height
+	namespaces map[string]struct{}
+}
+
+func newBulkLoadState() *bulkLoadState {
+	return &bulkLoadState{namespaces: make(map[string]struct{})}
+}
+
+func (s *bulkLoadState) add(height *version.Height, namespaces []string) {
+	s.height = height
+	for _, ns := range namespaces {
+		s.namespaces[ns] = struct{}{}
+	}
+}
+
+func (s *bulkLoadState) updatedNamespaces() []string {
+	var namespaces []string
+	for ns := range s.namespaces {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
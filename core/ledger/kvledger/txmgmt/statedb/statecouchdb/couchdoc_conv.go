@@ -7,6 +7,7 @@ package statecouchdb
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"strings"
 	"unicode/utf8"
@@ -18,11 +19,18 @@ import (
 )
 
 const (
+	// binaryWrapper is the name of the attachment under which the binary
+	// values are stored without the V1_4_COUCHDB_BINARY_VALUES capability
 	binaryWrapper = "valueBytes"
-	idField       = "_id"
-	revField      = "_rev"
-	versionField  = "~version"
-	deletedField  = "_deleted"
+	// binaryField is the field of the wrapper document under which a
+	// non-JSON value is stored, encoded in base64, once the channel has the
+	// V1_4_COUCHDB_BINARY_VALUES capability. The version field of the wrapper
+	// documents is marked by binaryValueVersionPrefix.
+	binaryField  = "~valueBytes"
+	idField      = "_id"
+	revField     = "_rev"
+	versionField = "~version"
+	deletedField = "_deleted"
 )
 
 type keyValue struct {
@@ -81,8 +89,8 @@ func couchDocToKeyValue(doc *couchdb.CouchDoc) (*keyValue, error) {
 	}
 	key := jsonResult[idField].(string)
 	// create the return version from the version field in the JSON
-
-	returnVersion, returnMetadata, err := decodeVersionAndMetadata(jsonResult[versionField].(string))
+	encodedVersion := jsonResult[versionField].(string)
+	returnVersion, returnMetadata, err := decodeVersionAndMetadata(encodedVersion)
 	if err != nil {
 		return nil, err
 	}
@@ -92,7 +100,15 @@ func couchDocToKeyValue(doc *couchdb.CouchDoc) (*keyValue, error) {
 	delete(jsonResult, versionField)
 
 	// handle binary or json data
-	if doc.Attachments != nil { // binary attachment
+	if isBinaryValue(encodedVersion) { // binary wrapper document
+		encodedValueStr, ok := jsonResult[binaryField].(string)
+		if !ok {
+			return nil, errors.Errorf("binary field %s of key [%s] is not a string", binaryField, key)
+		}
+		if returnValue, err = base64.StdEncoding.DecodeString(encodedValueStr); err != nil {
+			return nil, errors.Wrapf(err, "error decoding the binary value of key [%s]", key)
+		}
+	} else if doc.Attachments != nil { // binary attachment written by an earlier release
 		// get binary data from attachment
 		for _, attachment := range doc.Attachments {
 			if attachment.Name == binaryWrapper {
@@ -112,12 +128,16 @@ func couchDocToKeyValue(doc *couchdb.CouchDoc) (*keyValue, error) {
 	}, nil
 }
 
-func keyValToCouchDoc(kv *keyValue, revision string) (*couchdb.CouchDoc, error) {
+// keyValToCouchDoc returns the document of a key value. A binary value is
+// wrapped in the document if binaryInDocument is set, and stored in an
+// attachment otherwise, as the earlier releases expect.
+func keyValToCouchDoc(kv *keyValue, revision string, binaryInDocument bool) (*couchdb.CouchDoc, error) {
 	type kvType int32
 	const (
 		kvTypeDelete = iota
 		kvTypeJSON
 		kvTypeAttachment
+		kvTypeBinary
 	)
 	key, value, metadata, version := kv.key, kv.Value, kv.Metadata, kv.Version
 	jsonMap := make(jsonValue)
@@ -139,6 +159,9 @@ func keyValToCouchDoc(kv *keyValue, revision string) (*couchdb.CouchDoc, error)
 			jsonMap = make(jsonValue)
 		}
 		kvtype = kvTypeAttachment
+		if binaryInDocument {
+			kvtype = kvTypeBinary
+		}
 	}
 
 	verAndMetadata, err := encodeVersionAndMetadata(version, metadata)
@@ -151,8 +174,14 @@ func keyValToCouchDoc(kv *keyValue, revision string) (*couchdb.CouchDoc, error)
 	if revision != "" {
 		jsonMap[revField] = revision
 	}
-	if kvtype == kvTypeDelete {
+	switch kvtype {
+	case kvTypeDelete:
 		jsonMap[deletedField] = true
+	case kvTypeBinary:
+		// the binary value is wrapped in the document itself rather than in an
+		// attachment, so that the document is committed as plain JSON
+		jsonMap[versionField] = markBinaryValue(verAndMetadata)
+		jsonMap[binaryField] = base64.StdEncoding.EncodeToString(value)
 	}
 	jsonBytes, err := jsonMap.toBytes()
 	if err != nil {
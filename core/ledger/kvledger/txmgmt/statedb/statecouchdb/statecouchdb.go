@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
@@ -76,6 +77,8 @@ type VersionedDB struct {
 	committedDataCache *versionsCache                    // Used as a local cache during bulk processing of a block.
 	verCacheLock       sync.RWMutex
 	mux                sync.RWMutex
+	bulkLoad           *bulkLoadState // Set between the calls to BeginBulkLoad and EndBulkLoad.
+	binaryInDocuments  int32          // Set atomically, whether the binary values are wrapped in the documents.
 }
 
 // newVersionedDB constructs an instance of VersionedDB
@@ -290,6 +293,11 @@ func (vdb *VersionedDB) GetStateRangeScanIteratorWithMetadata(namespace string,
 	return newQueryScanner(namespace, db, "", internalQueryLimit, requestedLimit, "", startKey, endKey)
 }
 
+// GetStateRangeScanIteratorReverse implements method in VersionedDB interface
+func (vdb *VersionedDB) GetStateRangeScanIteratorReverse(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
+	return nil, errors.New("GetStateRangeScanIteratorReverse not supported for CouchDB")
+}
+
 func (scanner *queryScanner) getNextStateRangeScanResults() error {
 
 	queryLimit := scanner.queryDefinition.internalQueryLimit
@@ -433,6 +441,11 @@ func (vdb *VersionedDB) ApplyUpdates(updates *statedb.UpdateBatch, height *versi
 
 	// Stgae 3 - PostUpdateProcessing - flush and record savepoint.
 	namespaces := updates.GetUpdatedNamespaces()
+	// During a bulk load, both are deferred until the bulk load ends
+	if vdb.bulkLoad != nil {
+		vdb.bulkLoad.add(height, namespaces)
+		return nil
+	}
 	// Record a savepoint at a given height
 	if err = vdb.ensureFullCommitAndRecordSavepoint(height, namespaces); err != nil {
 		logger.Errorf("Error during recordSavepoint: %s", err.Error())
@@ -441,6 +454,38 @@ func (vdb *VersionedDB) ApplyUpdates(updates *statedb.UpdateBatch, height *versi
 	return nil
 }
 
+// BeginBulkLoad implements method in BulkLoadable interface
+func (vdb *VersionedDB) BeginBulkLoad() error {
+	logger.Debugf("Channel [%s]: Beginning bulk load", vdb.chainName)
+	vdb.bulkLoad = newBulkLoadState()
+	return nil
+}
+
+// EndBulkLoad implements method in BulkLoadable interface. It flushes all the
+// dbs updated during the bulk load and records the savepoint of the last update.
+func (vdb *VersionedDB) EndBulkLoad() error {
+	bulkLoad := vdb.bulkLoad
+	vdb.bulkLoad = nil
+	if bulkLoad == nil || bulkLoad.height == nil {
+		return nil
+	}
+	logger.Debugf("Channel [%s]: Ending bulk load at height [%s]", vdb.chainName, bulkLoad.height)
+	return vdb.ensureFullCommitAndRecordSavepoint(bulkLoad.height, bulkLoad.updatedNamespaces())
+}
+
+// SetBinaryValuesInDocuments implements method in BinaryValueFormatConfigurable interface
+func (vdb *VersionedDB) SetBinaryValuesInDocuments(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&vdb.binaryInDocuments, v)
+}
+
+func (vdb *VersionedDB) binaryValuesInDocuments() bool {
+	return atomic.LoadInt32(&vdb.binaryInDocuments) == 1
+}
+
 // ClearCachedVersions clears committedVersions and revisionNumbers
 func (vdb *VersionedDB) ClearCachedVersions() {
 	logger.Debugf("Clear Cache")
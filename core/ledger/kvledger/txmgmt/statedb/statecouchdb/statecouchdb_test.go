@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger/util/couchdb"
 	"github.com/hyperledger/fabric/integration/runner"
 )
 
@@ -140,6 +141,12 @@ func TestValueAndMetadataWrites(t *testing.T) {
 	commontests.TestValueAndMetadataWrites(t, env.DBProvider)
 }
 
+func TestBulkLoad(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestBulkLoad(t, env.DBProvider)
+}
+
 func TestPaginatedRangeQuery(t *testing.T) {
 	env := NewTestVDBEnv(t)
 	defer env.Cleanup()
@@ -236,6 +243,18 @@ func TestInvalidJSONFields(t *testing.T) {
 	savePoint = version.NewHeight(1, 2)
 	err = db.ApplyUpdates(batch, savePoint)
 	assert.Error(t, err, "Invalid field ~version should have thrown an error")
+
+	// the field of the binary wrapper documents is not reserved, as the earlier releases accepted it
+	batch = statedb.NewUpdateBatch()
+	jsonValue1 = `{"~valueBytes":"djE=","asset_name":"marble1","color":"blue","size":1,"owner":"tom"}`
+	batch.Put("ns1", "key1", []byte(jsonValue1), version.NewHeight(1, 1))
+
+	savePoint = version.NewHeight(1, 2)
+	err = db.ApplyUpdates(batch, savePoint)
+	assert.NoError(t, err)
+	vv, err := db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.JSONEq(t, jsonValue1, string(vv.Value))
 }
 
 func TestDebugFunctions(t *testing.T) {
@@ -360,6 +379,55 @@ func TestTryCastingToJSON(t *testing.T) {
 	assert.False(t, isJSON)
 }
 
+func TestBinaryValueConversion(t *testing.T) {
+	for _, value := range [][]byte{[]byte("This is not a json"), {}, {0x00, 0xff}, []byte(`"a json string"`)} {
+		// binary values are wrapped in the documents with the V1_4_COUCHDB_BINARY_VALUES capability
+		kv := &keyValue{"key1", &statedb.VersionedValue{Value: value, Version: version.NewHeight(1, 1)}}
+		couchDoc, err := keyValToCouchDoc(kv, "", true)
+		assert.NoError(t, err)
+		assert.Nil(t, couchDoc.Attachments)
+
+		convertedKV, err := couchDocToKeyValue(couchDoc)
+		assert.NoError(t, err)
+		assert.Equal(t, "key1", convertedKV.key)
+		assert.Equal(t, value, convertedKV.Value)
+		assert.Equal(t, version.NewHeight(1, 1), convertedKV.Version)
+
+		// and stored in attachments, as the earlier releases expect, without it
+		couchDoc, err = keyValToCouchDoc(kv, "", false)
+		assert.NoError(t, err)
+		assert.Len(t, couchDoc.Attachments, 1)
+		assert.Equal(t, binaryWrapper, couchDoc.Attachments[0].Name)
+
+		convertedKV, err = couchDocToKeyValue(couchDoc)
+		assert.NoError(t, err)
+		assert.Equal(t, value, convertedKV.Value)
+		assert.Equal(t, version.NewHeight(1, 1), convertedKV.Version)
+	}
+
+	// values stored as attachments by earlier releases are still readable
+	kv := &keyValue{"key1", &statedb.VersionedValue{Value: []byte(`{"a":"A"}`), Version: version.NewHeight(1, 1)}}
+	couchDoc, err := keyValToCouchDoc(kv, "", true)
+	assert.NoError(t, err)
+	couchDoc.Attachments = []*couchdb.AttachmentInfo{{Name: binaryWrapper, AttachmentBytes: []byte("binary")}}
+	convertedKV, err := couchDocToKeyValue(couchDoc)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("binary"), convertedKV.Value)
+
+	// JSON values holding the field of the binary wrapper documents are left as they are
+	for _, binaryInDocument := range []bool{true, false} {
+		value := []byte(`{"~valueBytes":"djE=","asset_name":"marble1"}`)
+		kv = &keyValue{"key1", &statedb.VersionedValue{Value: value, Version: version.NewHeight(1, 1)}}
+		couchDoc, err = keyValToCouchDoc(kv, "", binaryInDocument)
+		assert.NoError(t, err)
+		assert.Nil(t, couchDoc.Attachments)
+		convertedKV, err = couchDocToKeyValue(couchDoc)
+		assert.NoError(t, err)
+		assert.JSONEq(t, string(value), string(convertedKV.Value))
+		assert.Equal(t, version.NewHeight(1, 1), convertedKV.Version)
+	}
+}
+
 func TestHandleChaincodeDeployErroneousIndexFile(t *testing.T) {
 	channelName := "ch1"
 	env := NewTestVDBEnv(t)
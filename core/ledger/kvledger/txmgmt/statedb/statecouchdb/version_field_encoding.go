@@ -69,6 +69,23 @@ func decodeVersionOldFormat(encodedVersion string) *version.Height {
 	return version.NewHeight(blockNum, txNum)
 }
 
+// binaryValueVersionPrefix replaces the first byte of the version field of the
+// documents which wrap a binary value in their binaryField. JSON values may not
+// hold the version field, so that they cannot pass for a wrapper document, even
+// if they hold a binaryField of their own.
+const binaryValueVersionPrefix = byte(1)
+
+// markBinaryValue returns the version field of a document wrapping a binary value
+func markBinaryValue(encodedVersion string) string {
+	return string(binaryValueVersionPrefix) + encodedVersion[1:]
+}
+
+// isBinaryValue returns whether the version field is the one of a document
+// wrapping a binary value
+func isBinaryValue(encodedVersion string) bool {
+	return len(encodedVersion) > 0 && encodedVersion[0] == binaryValueVersionPrefix
+}
+
 func oldFormatEncoding(encodedstr string) bool {
-	return []byte(encodedstr)[0] != byte(0)
+	return []byte(encodedstr)[0] != byte(0) && !isBinaryValue(encodedstr)
 }
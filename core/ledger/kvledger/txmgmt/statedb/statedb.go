@@ -45,6 +45,12 @@ type VersionedDB interface {
 	// metadata is a map of additional query parameters
 	// The returned ResultsIterator contains results of type *VersionedKV
 	GetStateRangeScanIteratorWithMetadata(namespace string, startKey string, endKey string, metadata map[string]interface{}) (QueryResultsIterator, error)
+	// GetStateRangeScanIteratorReverse returns an iterator that contains all the key-values between given key ranges,
+	// in the descending order of the keys.
+	// startKey is inclusive
+	// endKey is exclusive
+	// The returned ResultsIterator contains results of type *VersionedKV
+	GetStateRangeScanIteratorReverse(namespace string, startKey string, endKey string) (ResultsIterator, error)
 	// ExecuteQuery executes the given query and returns an iterator that contains results of type *VersionedKV.
 	ExecuteQuery(namespace, query string) (ResultsIterator, error)
 	// ExecuteQueryWithMetadata executes the given query with associated query options and
@@ -81,6 +87,30 @@ type BulkOptimizable interface {
 	ClearCachedVersions()
 }
 
+//Syncable interface provides an additional function for
+//databases whose writes can be synced to disk on demand
+type Syncable interface {
+	Sync() error
+}
+
+//BulkLoadable interface provides additional functions for
+//databases capable of ingesting the updates of many blocks in bulk,
+//e.g. when the database is rebuilt from the blocks. Between the calls
+//to BeginBulkLoad and EndBulkLoad, the updates applied are not durable
+//until EndBulkLoad returns.
+type BulkLoadable interface {
+	BeginBulkLoad() error
+	EndBulkLoad() error
+}
+
+//BinaryValueFormatConfigurable interface provides an additional function
+//for databases which store the non-JSON values in a format that the
+//earlier releases cannot read, so that the format is only used once all
+//the peers of the channel can read it
+type BinaryValueFormatConfigurable interface {
+	SetBinaryValuesInDocuments(enabled bool)
+}
+
 //IndexCapable interface provides additional functions for
 //databases capable of index operations
 type IndexCapable interface {
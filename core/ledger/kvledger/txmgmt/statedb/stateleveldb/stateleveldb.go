@@ -32,7 +32,10 @@ type VersionedDBProvider struct {
 func NewVersionedDBProvider() *VersionedDBProvider {
 	dbPath := ledgerconfig.GetStateLevelDBPath()
 	logger.Debugf("constructing VersionedDBProvider dbPath=%s", dbPath)
-	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{
+		DBPath:    dbPath,
+		DeferSync: ledgerconfig.IsCommitJournalEnabled(),
+	})
 	return &VersionedDBProvider{dbProvider}
 }
 
@@ -48,13 +51,14 @@ func (provider *VersionedDBProvider) Close() {
 
 // VersionedDB implements VersionedDB interface
 type versionedDB struct {
-	db     *leveldbhelper.DBHandle
-	dbName string
+	db       *leveldbhelper.DBHandle
+	dbName   string
+	bulkLoad bool
 }
 
 // newVersionedDB constructs an instance of VersionedDB
 func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) *versionedDB {
-	return &versionedDB{db, dbName}
+	return &versionedDB{db: db, dbName: dbName}
 }
 
 // Open implements method in VersionedDB interface
@@ -154,6 +158,17 @@ func (vdb *versionedDB) GetStateRangeScanIteratorWithMetadata(namespace string,
 
 }
 
+// GetStateRangeScanIteratorReverse implements method in VersionedDB interface
+func (vdb *versionedDB) GetStateRangeScanIteratorReverse(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
+	compositeStartKey := constructCompositeKey(namespace, startKey)
+	compositeEndKey := constructCompositeKey(namespace, endKey)
+	if endKey == "" {
+		compositeEndKey[len(compositeEndKey)-1] = lastKeyIndicator
+	}
+	dbItr := &reverseIterator{Iterator: vdb.db.GetIterator(compositeStartKey, compositeEndKey)}
+	return newKVScanner(namespace, dbItr, 0), nil
+}
+
 // ExecuteQuery implements method in VersionedDB interface
 func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
 	return nil, errors.New("ExecuteQuery not supported for leveldb")
@@ -187,12 +202,27 @@ func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version
 	}
 	dbBatch.Put(savePointKey, height.ToBytes())
 	// Setting snyc to true as a precaution, false may be an ok optimization after further testing.
-	if err := vdb.db.WriteBatch(dbBatch, true); err != nil {
+	// During a bulk load, the batches are synced once, when the bulk load ends.
+	if err := vdb.db.WriteBatch(dbBatch, !vdb.bulkLoad); err != nil {
 		return err
 	}
 	return nil
 }
 
+// BeginBulkLoad implements method in BulkLoadable interface
+func (vdb *versionedDB) BeginBulkLoad() error {
+	logger.Debugf("Channel [%s]: Beginning bulk load", vdb.dbName)
+	vdb.bulkLoad = true
+	return nil
+}
+
+// EndBulkLoad implements method in BulkLoadable interface
+func (vdb *versionedDB) EndBulkLoad() error {
+	logger.Debugf("Channel [%s]: Ending bulk load", vdb.dbName)
+	vdb.bulkLoad = false
+	return vdb.db.Sync()
+}
+
 // GetLatestSavePoint implements method in VersionedDB interface
 func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
 	versionBytes, err := vdb.db.Get(savePointKey)
@@ -206,10 +236,21 @@ func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
 	return version, nil
 }
 
+// Sync implements method in Syncable interface
+func (vdb *versionedDB) Sync() error {
+	return vdb.db.Sync()
+}
+
 func constructCompositeKey(ns string, key string) []byte {
 	return append(append([]byte(ns), compositeKeySep...), []byte(key)...)
 }
 
+// KeyNamespace returns the namespace of the state held by the given key of
+// the db, which is empty for the savepoint of the db
+func KeyNamespace(dbKey []byte) string {
+	return string(bytes.SplitN(dbKey, compositeKeySep, 2)[0])
+}
+
 func splitCompositeKey(compositeKey []byte) (string, string) {
 	split := bytes.SplitN(compositeKey, compositeKeySep, 2)
 	return string(split[0]), string(split[1])
@@ -269,3 +310,18 @@ func (scanner *kvScanner) GetBookmarkAndClose() string {
 	scanner.Close()
 	return retval
 }
+
+// reverseIterator moves backward over the keys of the underlying iterator,
+// starting from its last key, on successive calls to `Next`
+type reverseIterator struct {
+	iterator.Iterator
+	started bool
+}
+
+func (itr *reverseIterator) Next() bool {
+	if !itr.started {
+		itr.started = true
+		return itr.Last()
+	}
+	return itr.Prev()
+}
@@ -56,6 +56,12 @@ func TestIterator(t *testing.T) {
 	commontests.TestIterator(t, env.DBProvider)
 }
 
+func TestReverseIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestReverseIterator(t, env.DBProvider)
+}
+
 func TestCompositeKey(t *testing.T) {
 	testCompositeKey(t, "ledger1", "ns", "key")
 	testCompositeKey(t, "ledger2", "ns", "")
@@ -125,6 +131,12 @@ func TestValueAndMetadataWrites(t *testing.T) {
 	commontests.TestValueAndMetadataWrites(t, env.DBProvider)
 }
 
+func TestBulkLoad(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestBulkLoad(t, env.DBProvider)
+}
+
 func TestPaginatedRangeQuery(t *testing.T) {
 	env := NewTestVDBEnv(t)
 	defer env.Cleanup()
@@ -0,0 +1,22 @@
+// +build !rocksdb
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package staterocksdb maintains the state database in rocksdb, with a
+// column family per channel. It requires cgo and the rocksdb library, and is
+// only built with the build tag `rocksdb`.
+package staterocksdb
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+)
+
+// NewVersionedDBProvider returns an error, as this binary was built without the rocksdb build tag
+func NewVersionedDBProvider() (statedb.VersionedDBProvider, error) {
+	return nil, errors.New("the state database cannot be maintained in rocksdb, as this binary was built without the rocksdb build tag")
+}
@@ -0,0 +1,21 @@
+// +build !rocksdb
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package staterocksdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVersionedDBProviderNotBuilt(t *testing.T) {
+	provider, err := NewVersionedDBProvider()
+	assert.Nil(t, provider)
+	assert.EqualError(t, err, "the state database cannot be maintained in rocksdb, as this binary was built without the rocksdb build tag")
+}
@@ -77,7 +77,20 @@ func (h *queryHelper) getStateRangeScanIterator(namespace string, startKey strin
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	itr, err := newResultsItr(namespace, startKey, endKey, nil, h.txmgr.db, h.rwsetBuilder,
+	itr, err := newResultsItr(namespace, startKey, endKey, nil, false, h.txmgr.db, h.rwsetBuilder,
+		ledgerconfig.IsQueryReadsHashingEnabled(), ledgerconfig.GetMaxDegreeQueryReadsHashing())
+	if err != nil {
+		return nil, err
+	}
+	h.itrs = append(h.itrs, itr)
+	return itr, nil
+}
+
+func (h *queryHelper) getStateRangeScanIteratorReverse(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	if err := h.checkDone(); err != nil {
+		return nil, err
+	}
+	itr, err := newResultsItr(namespace, startKey, endKey, nil, true, h.txmgr.db, h.rwsetBuilder,
 		ledgerconfig.IsQueryReadsHashingEnabled(), ledgerconfig.GetMaxDegreeQueryReadsHashing())
 	if err != nil {
 		return nil, err
@@ -90,7 +103,7 @@ func (h *queryHelper) getStateRangeScanIteratorWithMetadata(namespace string, st
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	itr, err := newResultsItr(namespace, startKey, endKey, metadata, h.txmgr.db, h.rwsetBuilder,
+	itr, err := newResultsItr(namespace, startKey, endKey, metadata, false, h.txmgr.db, h.rwsetBuilder,
 		ledgerconfig.IsQueryReadsHashingEnabled(), ledgerconfig.GetMaxDegreeQueryReadsHashing())
 	if err != nil {
 		return nil, err
@@ -330,18 +343,22 @@ func (h *queryHelper) validateCollName(ns, coll string) error {
 // for performing phantom read validation during commit
 type resultsItr struct {
 	ns                      string
+	startKey                string
 	endKey                  string
+	reverse                 bool
 	dbItr                   statedb.ResultsIterator
 	rwSetBuilder            *rwsetutil.RWSetBuilder
 	rangeQueryInfo          *kvrwset.RangeQueryInfo
 	rangeQueryResultsHelper *rwsetutil.RangeQueryResultsHelper
 }
 
-func newResultsItr(ns string, startKey string, endKey string, metadata map[string]interface{},
+func newResultsItr(ns string, startKey string, endKey string, metadata map[string]interface{}, reverse bool,
 	db statedb.VersionedDB, rwsetBuilder *rwsetutil.RWSetBuilder, enableHashing bool, maxDegree uint32) (*resultsItr, error) {
 	var err error
 	var dbItr statedb.ResultsIterator
-	if metadata == nil {
+	if reverse {
+		dbItr, err = db.GetStateRangeScanIteratorReverse(ns, startKey, endKey)
+	} else if metadata == nil {
 		dbItr, err = db.GetStateRangeScanIterator(ns, startKey, endKey)
 	} else {
 		dbItr, err = db.GetStateRangeScanIteratorWithMetadata(ns, startKey, endKey, metadata)
@@ -349,13 +366,19 @@ func newResultsItr(ns string, startKey string, endKey string, metadata map[strin
 	if err != nil {
 		return nil, err
 	}
-	itr := &resultsItr{ns: ns, dbItr: dbItr}
+	itr := &resultsItr{ns: ns, dbItr: dbItr, reverse: reverse}
 	// it's a simulation request so, enable capture of range query info
 	if rwsetBuilder != nil {
 		itr.rwSetBuilder = rwsetBuilder
+		itr.startKey = startKey
 		itr.endKey = endKey
-		// just set the StartKey... set the EndKey later below in the Next() method.
-		itr.rangeQueryInfo = &kvrwset.RangeQueryInfo{StartKey: startKey}
+		if reverse {
+			// a reverse scan begins at the EndKey... set the StartKey later below in the Next() method.
+			itr.rangeQueryInfo = &kvrwset.RangeQueryInfo{EndKey: endKey, Reverse: true}
+		} else {
+			// just set the StartKey... set the EndKey later below in the Next() method.
+			itr.rangeQueryInfo = &kvrwset.RangeQueryInfo{StartKey: startKey}
+		}
 		resultsHelper, err := rwsetutil.NewRangeQueryResultsHelper(enableHashing, maxDegree)
 		if err != nil {
 			return nil, err
@@ -399,6 +422,7 @@ func (itr *resultsItr) GetBookmarkAndClose() string {
 //                                  because, we do not know if the caller is again going to invoke Next() or not.
 //                            or b) the last key that was supplied in the original query (if the iterator is exhausted)
 // 2) The ItrExhausted - set to true if the iterator is going to return nil as a result of the Next() call
+// For a reverse range query, the StartKey is updated in the same manner instead of the EndKey
 func (itr *resultsItr) updateRangeQueryInfo(queryResult statedb.QueryResult) {
 	if itr.rwSetBuilder == nil {
 		return
@@ -408,14 +432,22 @@ func (itr *resultsItr) updateRangeQueryInfo(queryResult statedb.QueryResult) {
 		// caller scanned till the iterator got exhausted.
 		// So, set the endKey to the actual endKey supplied in the query
 		itr.rangeQueryInfo.ItrExhausted = true
-		itr.rangeQueryInfo.EndKey = itr.endKey
+		if itr.reverse {
+			itr.rangeQueryInfo.StartKey = itr.startKey
+		} else {
+			itr.rangeQueryInfo.EndKey = itr.endKey
+		}
 		return
 	}
 	versionedKV := queryResult.(*statedb.VersionedKV)
 	itr.rangeQueryResultsHelper.AddResult(rwsetutil.NewKVRead(versionedKV.Key, versionedKV.Version))
 	// Set the end key to the latest key retrieved by the caller.
 	// Because, the caller may actually not invoke the Next() function again
-	itr.rangeQueryInfo.EndKey = versionedKV.Key
+	if itr.reverse {
+		itr.rangeQueryInfo.StartKey = versionedKV.Key
+	} else {
+		itr.rangeQueryInfo.EndKey = versionedKV.Key
+	}
 }
 
 // Close implements method in interface ledger.ResultsIterator
@@ -55,6 +55,13 @@ func (q *lockBasedQueryExecutor) GetStateRangeScanIteratorWithMetadata(namespace
 	return q.helper.getStateRangeScanIteratorWithMetadata(namespace, startKey, endKey, metadata)
 }
 
+// GetStateRangeScanIteratorReverse implements method in interface `ledger.QueryExecutor`
+// The results are returned in the descending order of the keys, the startKey and the endKey
+// having the same meaning as in GetStateRangeScanIterator
+func (q *lockBasedQueryExecutor) GetStateRangeScanIteratorReverse(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	return q.helper.getStateRangeScanIteratorReverse(namespace, startKey, endKey)
+}
+
 // ExecuteQuery implements method in interface `ledger.QueryExecutor`
 func (q *lockBasedQueryExecutor) ExecuteQuery(namespace, query string) (commonledger.ResultsIterator, error) {
 	return q.helper.executeQuery(namespace, query)
@@ -193,6 +193,22 @@ func (txmgr *LockBasedTxMgr) Commit() error {
 	return nil
 }
 
+// Sync implements method in interface `txmgmt.TxMgr`
+func (txmgr *LockBasedTxMgr) Sync() error {
+	return txmgr.db.Sync()
+}
+
+// BeginBulkLoad prepares the state database for the recommit of many blocks,
+// which are not durable until EndBulkLoad returns
+func (txmgr *LockBasedTxMgr) BeginBulkLoad() error {
+	return txmgr.db.BeginBulkLoad()
+}
+
+// EndBulkLoad makes durable the blocks recommitted since BeginBulkLoad
+func (txmgr *LockBasedTxMgr) EndBulkLoad() error {
+	return txmgr.db.EndBulkLoad()
+}
+
 // Rollback implements method in interface `txmgmt.TxMgr`
 func (txmgr *LockBasedTxMgr) Rollback() {
 	txmgr.reset()
@@ -14,6 +14,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
@@ -337,6 +338,67 @@ func testTxPhantomValidation(t *testing.T, env testEnv) {
 	txMgrHelper.validateAndCommitRWSet(txRWSet4.PubSimulationResults)
 }
 
+func TestTxPhantomValidationWithReverseItr(t *testing.T) {
+	// reverse range queries are not supported by CouchDB
+	testEnv := testEnvsMap[levelDBtestEnvName]
+	testEnv.init(t, "testtxphantomvalidationwithreverseitr", nil)
+	defer testEnv.cleanup()
+	txMgr := testEnv.getTxMgr()
+	txMgrHelper := newTxMgrTestHelper(t, txMgr)
+	// simulate tx1
+	s1, _ := txMgr.NewTxSimulator("test_tx1")
+	for i := 1; i <= 6; i++ {
+		s1.SetState("ns", fmt.Sprintf("key%d", i), []byte(fmt.Sprintf("value%d", i)))
+	}
+	txRWSet1, _ := s1.GetTxSimulationResults()
+	s1.Done()
+	txMgrHelper.validateAndCommitRWSet(txRWSet1.PubSimulationResults)
+
+	readKeys := func(itr commonledger.ResultsIterator, max int) []string {
+		var keys []string
+		for len(keys) < max {
+			result, err := itr.Next()
+			assert.NoError(t, err)
+			if result == nil {
+				break
+			}
+			keys = append(keys, result.(*queryresult.KV).Key)
+		}
+		return keys
+	}
+
+	// simulate tx2, which deletes a key in the range it reads
+	s2, _ := txMgr.NewTxSimulator("test_tx2")
+	itr2, _ := s2.GetStateRangeScanIteratorReverse("ns", "key2", "key5")
+	assert.Equal(t, []string{"key4", "key3", "key2"}, readKeys(itr2, 10))
+	s2.DeleteState("ns", "key3")
+	txRWSet2, _ := s2.GetTxSimulationResults()
+	s2.Done()
+
+	// simulate tx3, which reads the same range
+	s3, _ := txMgr.NewTxSimulator("test_tx3")
+	itr3, _ := s3.GetStateRangeScanIteratorReverse("ns", "key2", "key5")
+	assert.Equal(t, []string{"key4", "key3", "key2"}, readKeys(itr3, 10))
+	s3.SetState("ns", "key1", []byte("value1_new"))
+	txRWSet3, _ := s3.GetTxSimulationResults()
+	s3.Done()
+
+	// simulate tx4, which stops reading the range before reaching the deleted key
+	s4, _ := txMgr.NewTxSimulator("test_tx4")
+	itr4, _ := s4.GetStateRangeScanIteratorReverse("ns", "", "")
+	assert.Equal(t, []string{"key6", "key5", "key4"}, readKeys(itr4, 3))
+	s4.SetState("ns", "key1", []byte("value1_new"))
+	txRWSet4, _ := s4.GetTxSimulationResults()
+	s4.Done()
+
+	// txRWSet2 should be valid
+	txMgrHelper.validateAndCommitRWSet(txRWSet2.PubSimulationResults)
+	// txRWSet2 makes txRWSet3 invalid as it deletes a key in the range
+	txMgrHelper.checkRWsetInvalid(txRWSet3.PubSimulationResults)
+	// txRWSet4 should be valid as the key was deleted from the part of the range it did not read
+	txMgrHelper.validateAndCommitRWSet(txRWSet4.PubSimulationResults)
+}
+
 func TestIterator(t *testing.T) {
 	for _, testEnv := range testEnvs {
 		t.Logf("Running test for TestEnv = %s", testEnv.getName())
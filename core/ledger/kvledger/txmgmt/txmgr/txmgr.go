@@ -30,6 +30,7 @@ type TxMgr interface {
 	ShouldRecover(lastAvailableBlock uint64) (bool, uint64, error)
 	CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtData) error
 	Commit() error
+	Sync() error
 	Rollback()
 	Shutdown()
 }
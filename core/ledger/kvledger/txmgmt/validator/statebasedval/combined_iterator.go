@@ -177,3 +177,48 @@ func compareKeys(item1 statedb.QueryResult, item2 statedb.QueryResult) int {
 func isDelete(item statedb.QueryResult) bool {
 	return item.(*statedb.VersionedKV).Value == nil
 }
+
+// reversedIterator implements the interface statedb.ResultsIterator.
+// It serves the results of the given iterator in the reverse order, which is the order in
+// which the results of a reverse range query were read during simulation. As the ledger
+// offers no reverse iteration over the update batch, all the results are read upfront.
+type reversedIterator struct {
+	results []statedb.QueryResult
+}
+
+func newReversedIterator(itr statedb.ResultsIterator) (*reversedIterator, error) {
+	defer itr.Close()
+	var results []statedb.QueryResult
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			break
+		}
+		results = append(results, result)
+	}
+	for i, j := 0, len(results)-1; i < j; i, j = i+1, j-1 {
+		results[i], results[j] = results[j], results[i]
+	}
+	return &reversedIterator{results}, nil
+}
+
+func (itr *reversedIterator) Next() (statedb.QueryResult, error) {
+	if len(itr.results) == 0 {
+		return nil, nil
+	}
+	result := itr.results[0]
+	itr.results = itr.results[1:]
+	return result, nil
+}
+
+func (itr *reversedIterator) Close() {
+	itr.results = nil
+}
+
+func (itr *reversedIterator) GetBookmarkAndClose() string {
+	itr.Close()
+	return ""
+}
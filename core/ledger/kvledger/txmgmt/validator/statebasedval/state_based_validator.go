@@ -89,8 +89,9 @@ func (v *Validator) preLoadCommittedVersionOfRSet(block *internal.Block) error {
 func (v *Validator) ValidateAndPrepareBatch(block *internal.Block, doMVCCValidation bool) (*internal.PubAndHashUpdates, error) {
 	// Check whether statedb implements BulkOptimizable interface. For now,
 	// only CouchDB implements BulkOptimizable to reduce the number of REST
-	// API calls from peer to CouchDB instance.
-	if v.db.IsBulkOptimizable() {
+	// API calls from peer to CouchDB instance. The committed versions are
+	// not needed when the blocks are recommitted without MVCC validation.
+	if v.db.IsBulkOptimizable() && doMVCCValidation {
 		err := v.preLoadCommittedVersionOfRSet(block)
 		if err != nil {
 			return nil, err
@@ -219,13 +220,20 @@ func (v *Validator) validateRangeQuery(ns string, rangeQueryInfo *kvrwset.RangeQ
 	// If during simulation, the caller had not exhausted the iterator so
 	// rangeQueryInfo.EndKey is not actual endKey given by the caller in the range query
 	// but rather it is the last key seen by the caller and hence the combinedItr should include the endKey in the results.
-	includeEndKey := !rangeQueryInfo.ItrExhausted
+	// A reverse range query records the last key seen by the caller as the StartKey, which is included anyway.
+	includeEndKey := !rangeQueryInfo.ItrExhausted && !rangeQueryInfo.Reverse
 
+	var combinedItr statedb.ResultsIterator
 	combinedItr, err := newCombinedIterator(v.db, updates.UpdateBatch,
 		ns, rangeQueryInfo.StartKey, rangeQueryInfo.EndKey, includeEndKey)
 	if err != nil {
 		return false, err
 	}
+	if rangeQueryInfo.Reverse {
+		if combinedItr, err = newReversedIterator(combinedItr); err != nil {
+			return false, err
+		}
+	}
 	defer combinedItr.Close()
 	var validator rangeQueryValidator
 	if rangeQueryInfo.GetReadsMerkleHashes() != nil {
@@ -235,7 +243,9 @@ func (v *Validator) validateRangeQuery(ns string, rangeQueryInfo *kvrwset.RangeQ
 		logger.Debug(`Hashing results are not present in the range query info hence, initiating raw KVReads based validation`)
 		validator = &rangeQueryResultsValidator{}
 	}
-	validator.init(rangeQueryInfo, combinedItr)
+	if err := validator.init(rangeQueryInfo, combinedItr); err != nil {
+		return false, err
+	}
 	return validator.validate()
 }
 
@@ -292,6 +292,49 @@ func TestPhantomValidation(t *testing.T) {
 	checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilder6, rwsetBuilder7), []int{1})
 }
 
+func TestPhantomValidationReverse(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	//populate db with initial data
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	batch.PubUpdates.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 1))
+	batch.PubUpdates.Put("ns1", "key3", []byte("value3"), version.NewHeight(1, 2))
+	batch.PubUpdates.Put("ns1", "key4", []byte("value4"), version.NewHeight(1, 3))
+	batch.PubUpdates.Put("ns1", "key5", []byte("value5"), version.NewHeight(1, 4))
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 4))
+
+	validator := NewValidator(db)
+
+	//rwset1 should be valid - the reads are in the descending order of the keys
+	rwsetBuilder1 := rwsetutil.NewRWSetBuilder()
+	rqi1 := &kvrwset.RangeQueryInfo{StartKey: "key2", EndKey: "key4", ItrExhausted: true, Reverse: true}
+	rqi1.SetRawReads([]*kvrwset.KVRead{
+		rwsetutil.NewKVRead("key3", version.NewHeight(1, 2)),
+		rwsetutil.NewKVRead("key2", version.NewHeight(1, 1))})
+	rwsetBuilder1.AddToRangeQuerySet("ns1", rqi1)
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilder1), []int{})
+
+	//rwset2 should be valid - iterator not exhausted, the start key is the last key read
+	rwsetBuilder2 := rwsetutil.NewRWSetBuilder()
+	rqi2 := &kvrwset.RangeQueryInfo{StartKey: "key3", EndKey: "key5", ItrExhausted: false, Reverse: true}
+	rqi2.SetRawReads([]*kvrwset.KVRead{
+		rwsetutil.NewKVRead("key4", version.NewHeight(1, 3)),
+		rwsetutil.NewKVRead("key3", version.NewHeight(1, 2))})
+	rwsetBuilder2.AddToRangeQuerySet("ns1", rqi2)
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilder2), []int{})
+
+	//Add a key in rwset3 and rwset4 should become invalid
+	rwsetBuilder3 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder3.AddToWriteSet("ns1", "key3_1", []byte("value3_1"))
+	rwsetBuilder4 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder4.AddToRangeQuerySet("ns1", rqi2)
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilder3, rwsetBuilder4), []int{1})
+}
+
 func TestPhantomHashBasedValidation(t *testing.T) {
 	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
 	testDBEnv.Init(t)
@@ -346,6 +389,31 @@ func TestPhantomHashBasedValidation(t *testing.T) {
 	checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilder2), []int{0})
 }
 
+func TestPhantomHashBasedValidationBadSummary(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 0))
+
+	// A merkle summary which cannot be rebuilt fails the validation
+	// instead of crashing the peer
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	rqi := &kvrwset.RangeQueryInfo{StartKey: "key1", EndKey: "key9", ItrExhausted: true}
+	rqi.SetMerkelSummary(&kvrwset.QueryReadsMerkleSummary{MaxDegree: 1, MaxLevel: 1, MaxLevelHashes: [][]byte{[]byte("hash")}})
+	rwsetBuilder.AddToRangeQuerySet("ns1", rqi)
+	block := &internal.Block{Num: 1, Txs: []*internal.Transaction{{
+		ID:             "txid-0",
+		ValidationCode: peer.TxValidationCode_VALID,
+		RWSet:          getTestPubSimulationRWSet(t, rwsetBuilder)[0],
+	}}}
+	_, err := NewValidator(db).ValidateAndPrepareBatch(block, true)
+	assert.EqualError(t, err, "maxDegree [1] should not be less than 2 in the merkle tree")
+}
+
 func checkValidation(t *testing.T, val *Validator, transRWSets []*rwsetutil.TxRwSet, expectedInvalidTxIndexes []int) {
 	var trans []*internal.Transaction
 	for i, tranRWSet := range transRWSets {
@@ -35,12 +35,31 @@ type PeerLedgerProvider interface {
 	Open(ledgerID string) (PeerLedger, error)
 	// Exists tells whether the ledger with given id exists
 	Exists(ledgerID string) (bool, error)
-	// List lists the ids of the existing ledgers
+	// List lists the ids of the existing ledgers, except for the ledgers left
 	List() ([]string, error)
+	// Leave marks the closed ledger with given id as left. The ledger is no
+	// longer listed, and its data is disposed of when the provider is next
+	// started, once the stores shared by the ledgers are no longer in use. A
+	// kept ledger is resumed by creating it again from its genesis block.
+	Leave(ledgerID string, disposal LedgerDisposal) error
 	// Close closes the PeerLedgerProvider
 	Close()
 }
 
+// LedgerDisposal tells what is done with the data of a ledger once the peer
+// leaves its channel
+type LedgerDisposal int32
+
+const (
+	// KeepLedger keeps the data of the ledger on the peer
+	KeepLedger LedgerDisposal = iota
+	// ArchiveLedger moves a backup of the ledger to the archive directory of
+	// the peer, and removes the data of the ledger
+	ArchiveLedger
+	// DeleteLedger removes the data of the ledger
+	DeleteLedger
+)
+
 // PeerLedger differs from the OrdererLedger in that PeerLedger locally maintain a bitmask
 // that tells apart valid transactions from invalid ones
 type PeerLedger interface {
@@ -92,6 +111,10 @@ type PeerLedger interface {
 	CommitPvtData(blockPvtData []*BlockPvtData) ([]*PvtdataHashMismatch, error)
 	// GetMissingPvtDataTracker return the MissingPvtDataTracker
 	GetMissingPvtDataTracker() (MissingPvtDataTracker, error)
+	// GetStateAndBlockchainInfo returns the value of the given key in the
+	// committed state, along with the info about the blockchain whose blocks
+	// the state reflects. A nil value is returned if the key does not exist.
+	GetStateAndBlockchainInfo(namespace string, key string) ([]byte, *common.BlockchainInfo, error)
 }
 
 // ValidatedLedger represents the 'final ledger' after filtering out invalid transactions from PeerLedger.
@@ -130,6 +153,11 @@ type QueryExecutor interface {
 	// metadata is a map of additional query parameters
 	// The returned ResultsIterator contains results of type *KV which is defined in protos/ledger/queryresult.
 	GetStateRangeScanIteratorWithMetadata(namespace string, startKey, endKey string, metadata map[string]interface{}) (QueryResultsIterator, error)
+	// GetStateRangeScanIteratorReverse returns an iterator that contains all the key-values between given key ranges,
+	// in the descending order of the keys. startKey is included in the results and endKey is excluded, an empty startKey
+	// and an empty endKey referring to the first and the last available keys, as for GetStateRangeScanIterator.
+	// The returned ResultsIterator contains results of type *KV which is defined in protos/ledger/queryresult.
+	GetStateRangeScanIteratorReverse(namespace string, startKey, endKey string) (commonledger.ResultsIterator, error)
 	// ExecuteQuery executes the given query and returns an iterator that contains results of type specific to the underlying data store.
 	// Only used for state databases that support query
 	// For a chaincode, the namespace corresponds to the chaincodeId
@@ -9,6 +9,8 @@ package ledgerconfig
 import (
 	"path/filepath"
 
+	"github.com/hyperledger/fabric/common/ledger/util/rocksdbhelper"
+	"github.com/hyperledger/fabric/common/viperutil"
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/spf13/viper"
 )
@@ -22,21 +24,73 @@ func IsCouchDBEnabled() bool {
 	return false
 }
 
+// IsBadgerDBEnabled returns true if the state database, along with the block
+// indexes, is maintained in badger instead of goleveldb
+func IsBadgerDBEnabled() bool {
+	return viper.GetString("ledger.state.stateDatabase") == "badger"
+}
+
+// IsRocksDBEnabled returns true if the state database, along with the block
+// indexes, is maintained in rocksdb instead of goleveldb
+func IsRocksDBEnabled() bool {
+	return viper.GetString("ledger.state.stateDatabase") == "rocksdb"
+}
+
+// GetStateDatabase returns the name of the state database
+func GetStateDatabase() string {
+	return viper.GetString("ledger.state.stateDatabase")
+}
+
+// IsStatePluginEnabled returns true if the state database is none of the
+// built-in ones, but one registered under its name by a provider compiled
+// into the peer or loaded from a Go plugin
+func IsStatePluginEnabled() bool {
+	switch GetStateDatabase() {
+	case "", "goleveldb", "CouchDB", "badger", "rocksdb":
+		return false
+	}
+	return true
+}
+
+// GetStatePluginLibrary returns the path of the Go plugin supplying the state
+// database, if any
+func GetStatePluginLibrary() string {
+	return config.GetPath("ledger.state.pluginConfig.library")
+}
+
 const confPeerFileSystemPath = "peer.fileSystemPath"
 const confLedgersData = "ledgersData"
 const confLedgerProvider = "ledgerProvider"
 const confStateleveldb = "stateLeveldb"
+const confStateBadgerdb = "stateBadgerdb"
+const confStateRocksdb = "stateRocksdb"
 const confHistoryLeveldb = "historyLeveldb"
 const confBookkeeper = "bookkeeper"
 const confConfigHistory = "configHistory"
 const confChains = "chains"
 const confPvtdataStore = "pvtdataStore"
+const confCommitJournal = "commitJournal"
+const confArchive = "archive"
 const confTotalQueryLimit = "ledger.state.totalQueryLimit"
 const confInternalQueryLimit = "ledger.state.couchDBConfig.internalQueryLimit"
+const confChaincodeQueryLimits = "ledger.state.chaincodeQueryLimits"
 const confEnableHistoryDatabase = "ledger.history.enableHistoryDatabase"
 const confMaxBatchSize = "ledger.state.couchDBConfig.maxBatchUpdateSize"
 const confAutoWarmIndexes = "ledger.state.couchDBConfig.autoWarmIndexes"
 const confWarmIndexesAfterNBlocks = "ledger.state.couchDBConfig.warmIndexesAfterNBlocks"
+const confRocksDBCompactionStyle = "ledger.state.rocksDBConfig.compactionStyle"
+const confRocksDBWriteBufferSize = "ledger.state.rocksDBConfig.writeBufferSize"
+const confRocksDBMaxBackgroundCompactions = "ledger.state.rocksDBConfig.maxBackgroundCompactions"
+const confRocksDBLevel0FileNumCompactionTrigger = "ledger.state.rocksDBConfig.level0FileNumCompactionTrigger"
+const confRocksDBTargetFileSizeBase = "ledger.state.rocksDBConfig.targetFileSizeBase"
+const confRocksDBMaxBytesForLevelBase = "ledger.state.rocksDBConfig.maxBytesForLevelBase"
+const confRocksDBDynamicLevelBytes = "ledger.state.rocksDBConfig.dynamicLevelBytes"
+const confEnableCommitJournal = "ledger.commitJournal.enabled"
+const confCommitJournalCheckpointInterval = "ledger.commitJournal.checkpointInterval"
+const confDisabledBlockIndexes = "ledger.blockchain.disabledIndexes"
+const confEnableTieredStorage = "ledger.blockchain.tieredStorage.enabled"
+const confTieredStoragePath = "ledger.blockchain.tieredStorage.path"
+const confTieredStorageRetainedBlocks = "ledger.blockchain.tieredStorage.retainedBlocks"
 
 // GetRootPath returns the filesystem path.
 // All ledger related contents are expected to be stored under this path
@@ -55,6 +109,16 @@ func GetStateLevelDBPath() string {
 	return filepath.Join(GetRootPath(), confStateleveldb)
 }
 
+// GetStateBadgerDBPath returns the filesystem path that is used to maintain the state badger db
+func GetStateBadgerDBPath() string {
+	return filepath.Join(GetRootPath(), confStateBadgerdb)
+}
+
+// GetStateRocksDBPath returns the filesystem path that is used to maintain the state rocksdb
+func GetStateRocksDBPath() string {
+	return filepath.Join(GetRootPath(), confStateRocksdb)
+}
+
 // GetHistoryLevelDBPath returns the filesystem path that is used to maintain the history level db
 func GetHistoryLevelDBPath() string {
 	return filepath.Join(GetRootPath(), confHistoryLeveldb)
@@ -70,6 +134,11 @@ func GetPvtdataStorePath() string {
 	return filepath.Join(GetRootPath(), confPvtdataStore)
 }
 
+// GetCommitJournalPath returns the filesystem path that is used for the commit journals of the ledgers
+func GetCommitJournalPath() string {
+	return filepath.Join(GetRootPath(), confCommitJournal)
+}
+
 // GetInternalBookkeeperPath returns the filesystem path that is used for bookkeeping the internal stuff by by KVledger (such as expiration time for pvt)
 func GetInternalBookkeeperPath() string {
 	return filepath.Join(GetRootPath(), confBookkeeper)
@@ -80,6 +149,41 @@ func GetConfigHistoryPath() string {
 	return filepath.Join(GetRootPath(), confConfigHistory)
 }
 
+// GetArchivePath returns the filesystem path that is used for the backups of the ledgers archived
+// when the peer leaves their channels
+func GetArchivePath() string {
+	return filepath.Join(GetRootPath(), confArchive)
+}
+
+// GetDisabledBlockIndexes returns the names of the indexes of the blocks and
+// the transactions which are not maintained by the block stores
+func GetDisabledBlockIndexes() []string {
+	return viper.GetStringSlice(confDisabledBlockIndexes)
+}
+
+// IsTieredStorageEnabled returns true if the old block files of the ledgers
+// are moved to the tiered storage path
+func IsTieredStorageEnabled() bool {
+	return viper.GetBool(confEnableTieredStorage)
+}
+
+// GetTieredStoragePath returns the filesystem path that the old block files of
+// the ledgers are moved to, such as the mount point of a cheaper disk
+func GetTieredStoragePath() string {
+	return config.GetPath(confTieredStoragePath)
+}
+
+// GetTieredStorageRetainedBlocks returns the number of the latest blocks of a
+// ledger whose block files are never moved to the tiered storage path
+func GetTieredStorageRetainedBlocks() uint64 {
+	retainedBlocks := viper.GetInt(confTieredStorageRetainedBlocks)
+	// if retainedBlocks was unset or invalid, default to 100000
+	if retainedBlocks <= 0 {
+		retainedBlocks = 100000
+	}
+	return uint64(retainedBlocks)
+}
+
 // GetMaxBlockfileSize returns maximum size of the block file
 func GetMaxBlockfileSize() int {
 	return 64 * 1024 * 1024
@@ -87,19 +191,40 @@ func GetMaxBlockfileSize() int {
 
 //GetTotalLimit exposes the totalLimit variable
 func GetTotalQueryLimit() int {
-	totalQueryLimit := viper.GetInt(confTotalQueryLimit)
+	totalQueryLimit := viperutil.Reloadable.GetInt(confTotalQueryLimit)
 	// if queryLimit was unset, default to 10000
-	if !viper.IsSet(confTotalQueryLimit) {
+	if !viperutil.Reloadable.IsSet(confTotalQueryLimit) {
 		totalQueryLimit = 10000
 	}
 	return totalQueryLimit
 }
 
+// chaincodeQueryLimit overrides the total query limit for a chaincode
+type chaincodeQueryLimit struct {
+	Chaincode       string `mapstructure:"chaincode"`
+	TotalQueryLimit int    `mapstructure:"totalQueryLimit"`
+}
+
+// GetTotalQueryLimitForChaincode returns the limit on the number of records
+// returned to the given chaincode per query, which defaults to the total
+// query limit of the peer
+func GetTotalQueryLimitForChaincode(chaincodeName string) int {
+	var limits []chaincodeQueryLimit
+	if err := viperutil.Reloadable.UnmarshalKey(confChaincodeQueryLimits, &limits); err == nil {
+		for _, limit := range limits {
+			if limit.Chaincode == chaincodeName && limit.TotalQueryLimit > 0 {
+				return limit.TotalQueryLimit
+			}
+		}
+	}
+	return GetTotalQueryLimit()
+}
+
 //GetQueryLimit exposes the queryLimit variable
 func GetInternalQueryLimit() int {
-	internalQueryLimit := viper.GetInt(confInternalQueryLimit)
+	internalQueryLimit := viperutil.Reloadable.GetInt(confInternalQueryLimit)
 	// if queryLimit was unset, default to 1000
-	if !viper.IsSet(confInternalQueryLimit) {
+	if !viperutil.Reloadable.IsSet(confInternalQueryLimit) {
 		internalQueryLimit = 1000
 	}
 	return internalQueryLimit
@@ -162,3 +287,33 @@ func GetWarmIndexesAfterNBlocks() int {
 	}
 	return warmAfterNBlocks
 }
+
+//IsCommitJournalEnabled exposes the commitJournal.enabled variable
+func IsCommitJournalEnabled() bool {
+	return viper.GetBool(confEnableCommitJournal)
+}
+
+// GetCommitJournalCheckpointInterval returns the number of blocks committed between
+// two syncs of the ledger stores when the commit journal is enabled
+func GetCommitJournalCheckpointInterval() int {
+	checkpointInterval := viper.GetInt(confCommitJournalCheckpointInterval)
+	// if checkpointInterval was unset or invalid, default to 100
+	if checkpointInterval <= 0 {
+		checkpointInterval = 100
+	}
+	return checkpointInterval
+}
+
+// GetRocksDBCompactionConf returns the tuning of the memtables and the compaction
+// of the rocksdbs which maintain the state database and the block indexes
+func GetRocksDBCompactionConf() *rocksdbhelper.CompactionConf {
+	return &rocksdbhelper.CompactionConf{
+		Style:                          viper.GetString(confRocksDBCompactionStyle),
+		WriteBufferSize:                int(viper.GetSizeInBytes(confRocksDBWriteBufferSize)),
+		MaxBackgroundCompactions:       viper.GetInt(confRocksDBMaxBackgroundCompactions),
+		Level0FileNumCompactionTrigger: viper.GetInt(confRocksDBLevel0FileNumCompactionTrigger),
+		TargetFileSizeBase:             uint64(viper.GetSizeInBytes(confRocksDBTargetFileSizeBase)),
+		MaxBytesForLevelBase:           uint64(viper.GetSizeInBytes(confRocksDBMaxBytesForLevelBase)),
+		DynamicLevelBytes:              viper.GetBool(confRocksDBDynamicLevelBytes),
+	}
+}
@@ -19,6 +19,7 @@ package ledgerconfig
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/ledger/util/rocksdbhelper"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -46,11 +47,65 @@ func TestIsCouchDBEnabled(t *testing.T) {
 	assert.True(t, updatedValue) //test config returns true
 }
 
+func TestIsBadgerDBEnabled(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.False(t, IsBadgerDBEnabled())
+	viper.Set("ledger.state.stateDatabase", "badger")
+	assert.True(t, IsBadgerDBEnabled())
+}
+
+func TestIsRocksDBEnabled(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.False(t, IsRocksDBEnabled())
+	viper.Set("ledger.state.stateDatabase", "rocksdb")
+	assert.True(t, IsRocksDBEnabled())
+}
+
+func TestIsStatePluginEnabled(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.False(t, IsStatePluginEnabled())
+	for _, builtin := range []string{"", "goleveldb", "CouchDB", "badger", "rocksdb"} {
+		viper.Set("ledger.state.stateDatabase", builtin)
+		assert.False(t, IsStatePluginEnabled())
+	}
+	viper.Set("ledger.state.stateDatabase", "postgres")
+	assert.True(t, IsStatePluginEnabled())
+	assert.Equal(t, "postgres", GetStateDatabase())
+	assert.Equal(t, "", GetStatePluginLibrary())
+	viper.Set("ledger.state.pluginConfig.library", "/opt/lib/postgres.so")
+	defer viper.Set("ledger.state.pluginConfig.library", "")
+	assert.Equal(t, "/opt/lib/postgres.so", GetStatePluginLibrary())
+}
+
+func TestGetRocksDBCompactionConf(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, &rocksdbhelper.CompactionConf{
+		Style:                          "level",
+		WriteBufferSize:                64 * 1024 * 1024,
+		MaxBackgroundCompactions:       4,
+		Level0FileNumCompactionTrigger: 4,
+		TargetFileSizeBase:             64 * 1024 * 1024,
+		MaxBytesForLevelBase:           256 * 1024 * 1024,
+		DynamicLevelBytes:              true,
+	}, GetRocksDBCompactionConf())
+	viper.Set("ledger.state.rocksDBConfig.compactionStyle", "universal")
+	viper.Set("ledger.state.rocksDBConfig.writeBufferSize", "1GB")
+	conf := GetRocksDBCompactionConf()
+	assert.Equal(t, "universal", conf.Style)
+	assert.Equal(t, 1024*1024*1024, conf.WriteBufferSize)
+}
+
 func TestLedgerConfigPathDefault(t *testing.T) {
 	setUpCoreYAMLConfig()
 	assert.Equal(t, "/var/hyperledger/production/ledgersData", GetRootPath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/ledgerProvider", GetLedgerProviderPath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/stateLeveldb", GetStateLevelDBPath())
+	assert.Equal(t, "/var/hyperledger/production/ledgersData/stateBadgerdb", GetStateBadgerDBPath())
+	assert.Equal(t, "/var/hyperledger/production/ledgersData/stateRocksdb", GetStateRocksDBPath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/historyLeveldb", GetHistoryLevelDBPath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/chains", GetBlockStorePath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/pvtdataStore", GetPvtdataStorePath())
@@ -64,6 +119,8 @@ func TestLedgerConfigPath(t *testing.T) {
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData", GetRootPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/ledgerProvider", GetLedgerProviderPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/stateLeveldb", GetStateLevelDBPath())
+	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/stateBadgerdb", GetStateBadgerDBPath())
+	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/stateRocksdb", GetStateRocksDBPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/historyLeveldb", GetHistoryLevelDBPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/chains", GetBlockStorePath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/pvtdataStore", GetPvtdataStorePath())
@@ -90,6 +147,23 @@ func TestGetTotalLimit(t *testing.T) {
 	assert.Equal(t, 5000, updatedValue) //test config returns 5000
 }
 
+func TestGetTotalQueryLimitForChaincode(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.state.totalQueryLimit", 5000)
+	assert.Equal(t, 5000, GetTotalQueryLimitForChaincode("mycc"))
+
+	viper.Set("ledger.state.chaincodeQueryLimits", []map[string]interface{}{
+		{"chaincode": "mycc", "totalQueryLimit": 100},
+		{"chaincode": "MyCC", "totalQueryLimit": 200},
+		{"chaincode": "othercc", "totalQueryLimit": 0},
+	})
+	assert.Equal(t, 100, GetTotalQueryLimitForChaincode("mycc"))
+	assert.Equal(t, 200, GetTotalQueryLimitForChaincode("MyCC"))
+	assert.Equal(t, 5000, GetTotalQueryLimitForChaincode("othercc"))
+	assert.Equal(t, 5000, GetTotalQueryLimitForChaincode("unknowncc"))
+}
+
 func TestGetQueryLimitDefault(t *testing.T) {
 	setUpCoreYAMLConfig()
 	defaultValue := GetInternalQueryLimit()
@@ -232,6 +306,44 @@ func TestGetMaxBlockfileSize(t *testing.T) {
 	assert.Equal(t, 67108864, GetMaxBlockfileSize())
 }
 
+func TestIsCommitJournalEnabled(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.False(t, IsCommitJournalEnabled()) //test default config is false
+	viper.Set("ledger.commitJournal.enabled", true)
+	assert.True(t, IsCommitJournalEnabled())
+}
+
+func TestGetCommitJournalCheckpointInterval(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, 100, GetCommitJournalCheckpointInterval())
+	viper.Set("ledger.commitJournal.checkpointInterval", 10)
+	assert.Equal(t, 10, GetCommitJournalCheckpointInterval())
+	viper.Set("ledger.commitJournal.checkpointInterval", 0)
+	assert.Equal(t, 100, GetCommitJournalCheckpointInterval())
+}
+
+func TestTieredStorage(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	defer func() {
+		viper.Set("ledger.blockchain.tieredStorage.enabled", false)
+		viper.Set("ledger.blockchain.tieredStorage.path", "")
+		viper.Set("ledger.blockchain.tieredStorage.retainedBlocks", 100000)
+	}()
+	assert.False(t, IsTieredStorageEnabled())
+	assert.Equal(t, uint64(100000), GetTieredStorageRetainedBlocks())
+	viper.Set("ledger.blockchain.tieredStorage.enabled", true)
+	viper.Set("ledger.blockchain.tieredStorage.path", "/mnt/blocks")
+	viper.Set("ledger.blockchain.tieredStorage.retainedBlocks", 1000)
+	assert.True(t, IsTieredStorageEnabled())
+	assert.Equal(t, "/mnt/blocks", GetTieredStoragePath())
+	assert.Equal(t, uint64(1000), GetTieredStorageRetainedBlocks())
+	viper.Set("ledger.blockchain.tieredStorage.retainedBlocks", -1)
+	assert.Equal(t, uint64(100000), GetTieredStorageRetainedBlocks())
+}
+
 func setUpCoreYAMLConfig() {
 	//call a helper method to load the core.yaml
 	ledgertestutil.SetupCoreYAMLConfig()
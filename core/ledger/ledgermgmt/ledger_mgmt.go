@@ -129,6 +129,20 @@ func GetLedgerIDs() ([]string, error) {
 	return ledgerProvider.List()
 }
 
+// LeaveLedger closes the ledger with the given id if it is opened, and marks it
+// as left. The data of the ledger is disposed of when the peer restarts.
+func LeaveLedger(id string, disposal ledger.LedgerDisposal) error {
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return ErrLedgerMgmtNotInitialized
+	}
+	if l, ok := openedLedgers[id]; ok {
+		l.(*closableLedger).closeWithoutLock()
+	}
+	return ledgerProvider.Leave(id, disposal)
+}
+
 // Close closes all the opened ledgers and any resources held for ledger management
 func Close() {
 	logger.Infof("Closing ledger mgmt")
@@ -27,6 +27,7 @@ var isMissingDataReconEnabled = false
 type Provider struct {
 	blkStoreProvider     blkstorage.BlockStoreProvider
 	pvtdataStoreProvider pvtdatastorage.Provider
+	journaled            bool
 }
 
 // Store encapsulates two stores 1) block store and pvt data store
@@ -34,26 +35,83 @@ type Store struct {
 	blkstorage.BlockStore
 	pvtdataStore pvtdatastorage.Store
 	rwlock       *sync.RWMutex
+	// journaled is set when the blocks are written to a commit journal before
+	// being committed to the stores, which do not sync the commits to disk
+	journaled bool
 }
 
 // NewProvider returns the handle to the provider
 func NewProvider() *Provider {
 	// Initialize the block storage
-	attrsToIndex := []blkstorage.IndexableAttr{
+	attrsToIndex, err := indexedAttrs(ledgerconfig.GetDisabledBlockIndexes(), ledgerconfig.IsHistoryDBEnabled())
+	if err != nil {
+		logger.Panicf("Invalid configuration of the block indexes: %s", err)
+	}
+	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	journaled := ledgerconfig.IsCommitJournalEnabled()
+	blockStoreConf := fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize())
+	if journaled {
+		blockStoreConf = fsblkstorage.NewConfWithDeferredSync(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize())
+	}
+	if ledgerconfig.IsBadgerDBEnabled() {
+		blockStoreConf.WithBadgerIndex()
+	}
+	if ledgerconfig.IsRocksDBEnabled() {
+		blockStoreConf.WithRocksDBIndex(ledgerconfig.GetRocksDBCompactionConf())
+	}
+	if ledgerconfig.IsTieredStorageEnabled() {
+		path := ledgerconfig.GetTieredStoragePath()
+		if path == "" {
+			logger.Panic("The tiered storage of the block files is enabled, but its path is not set")
+		}
+		blockStoreConf.WithArchive(fsblkstorage.NewDirBlockfileArchive(path), ledgerconfig.GetTieredStorageRetainedBlocks())
+	}
+	blockStoreProvider := fsblkstorage.NewProvider(blockStoreConf, indexConfig)
+
+	pvtStoreProvider := pvtdatastorage.NewProvider()
+	return &Provider{blockStoreProvider, pvtStoreProvider, journaled}
+}
+
+// indexedAttrs returns the attributes indexed by the block stores, all of
+// them but the disabled ones. Only the indexes which the peer does not rely
+// upon can be disabled.
+func indexedAttrs(disabled []string, historyDBEnabled bool) ([]blkstorage.IndexableAttr, error) {
+	optional := map[blkstorage.IndexableAttr]bool{
+		blkstorage.IndexableAttrBlockHash:       true,
+		blkstorage.IndexableAttrBlockTxID:       true,
+		blkstorage.IndexableAttrBlockNumTranNum: true,
+	}
+	disabledAttrs := map[blkstorage.IndexableAttr]bool{}
+	for _, name := range disabled {
+		attr := blkstorage.IndexableAttr(name)
+		if !optional[attr] {
+			return nil, errors.Errorf("index %s cannot be disabled, only the %s, %s and %s indexes can be", name,
+				blkstorage.IndexableAttrBlockHash, blkstorage.IndexableAttrBlockTxID, blkstorage.IndexableAttrBlockNumTranNum)
+		}
+		// the history database locates the transactions which wrote a key by their block and transaction numbers
+		if attr == blkstorage.IndexableAttrBlockNumTranNum && historyDBEnabled {
+			return nil, errors.Errorf("index %s cannot be disabled while the history database is enabled", name)
+		}
+		disabledAttrs[attr] = true
+	}
+
+	var attrs []blkstorage.IndexableAttr
+	for _, attr := range []blkstorage.IndexableAttr{
 		blkstorage.IndexableAttrBlockHash,
 		blkstorage.IndexableAttrBlockNum,
 		blkstorage.IndexableAttrTxID,
 		blkstorage.IndexableAttrBlockNumTranNum,
 		blkstorage.IndexableAttrBlockTxID,
 		blkstorage.IndexableAttrTxValidationCode,
+	} {
+		if !disabledAttrs[attr] {
+			attrs = append(attrs, attr)
+		}
 	}
-	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
-	blockStoreProvider := fsblkstorage.NewProvider(
-		fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize()),
-		indexConfig)
-
-	pvtStoreProvider := pvtdatastorage.NewProvider()
-	return &Provider{blockStoreProvider, pvtStoreProvider}
+	if len(disabledAttrs) > 0 {
+		logger.Infof("Block indexes disabled: %s", disabled)
+	}
+	return attrs, nil
 }
 
 // Open opens the store
@@ -68,7 +126,7 @@ func (p *Provider) Open(ledgerid string) (*Store, error) {
 	if pvtdataStore, err = p.pvtdataStoreProvider.OpenStore(ledgerid); err != nil {
 		return nil, err
 	}
-	store := &Store{blockStore, pvtdataStore, &sync.RWMutex{}}
+	store := &Store{blockStore, pvtdataStore, &sync.RWMutex{}, p.journaled}
 	if err := store.init(); err != nil {
 		return nil, err
 	}
@@ -132,6 +190,61 @@ func (s *Store) CommitWithPvtData(blockAndPvtdata *ledger.BlockAndPvtData) error
 	return nil
 }
 
+// RecommitWithPvtData commits the block and the corresponding pvt data to the
+// stores which do not have it yet. It is used to replay the commit journal after
+// a crash, when either store may have lost blocks whose commit was not synced.
+func (s *Store) RecommitWithPvtData(blockAndPvtdata *ledger.BlockAndPvtData) error {
+	blockNum := blockAndPvtdata.Block.Header.Number
+	missingDataList := blockAndPvtdata.Missing
+
+	if !isMissingDataReconEnabled {
+		// should not store any entries for missing data
+		missingDataList = nil
+	}
+
+	s.rwlock.Lock()
+	defer s.rwlock.Unlock()
+
+	pvtBlkStoreHt, err := s.pvtdataStore.LastCommittedBlockHeight()
+	if err != nil {
+		return err
+	}
+	bcInfo, err := s.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+
+	if pvtBlkStoreHt == blockNum {
+		logger.Infof("Recommitting pvt data of block [%d] to pvt block store", blockNum)
+		var pvtdata []*ledger.TxPvtData
+		for _, v := range blockAndPvtdata.BlockPvtData {
+			pvtdata = append(pvtdata, v)
+		}
+		if err := s.pvtdataStore.Prepare(blockNum, pvtdata, missingDataList); err != nil {
+			return err
+		}
+		if err := s.pvtdataStore.Commit(); err != nil {
+			return err
+		}
+	}
+
+	if bcInfo.Height == blockNum {
+		logger.Infof("Recommitting block [%d] to block store", blockNum)
+		return s.AddBlock(blockAndPvtdata.Block)
+	}
+	return nil
+}
+
+// Sync syncs to disk the blocks and the pvt data committed so far
+func (s *Store) Sync() error {
+	s.rwlock.RLock()
+	defer s.rwlock.RUnlock()
+	if err := s.BlockStore.Sync(); err != nil {
+		return err
+	}
+	return s.pvtdataStore.Sync()
+}
+
 // GetPvtDataAndBlockByNum returns the block and the corresponding pvt data.
 // The pvt data is filtered by the list of 'collections' supplied
 func (s *Store) GetPvtDataAndBlockByNum(blockNum uint64, filter ledger.PvtNsCollFilter) (*ledger.BlockAndPvtData, error) {
@@ -254,6 +367,14 @@ func (s *Store) syncPvtdataStoreWithBlockStore() error {
 		return s.pvtdataStore.Commit()
 	}
 
+	if s.journaled {
+		// either store may have lost the blocks committed since the last sync,
+		// which are recommitted from the commit journal
+		logger.Warningf("Rolling back the pending batch of the pvt data store as its height [%d] does not match the block store height [%d]",
+			pvtdataStoreHt, bcInfo.Height)
+		return s.pvtdataStore.Rollback()
+	}
+
 	return errors.Errorf("This is not expected. blockStoreHeight=%d, pvtdataStoreHeight=%d", bcInfo.Height, pvtdataStoreHt)
 }
 
@@ -21,6 +21,8 @@ import (
 	btltestutil "github.com/hyperledger/fabric/core/ledger/pvtdatapolicy/testutil"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -243,6 +245,57 @@ func TestCrashBeforePvtdataStoreCommit(t *testing.T) {
 	assert.True(t, proto.Equal(dataAtCrash.Block, blkAndPvtdata.Block))
 }
 
+func TestRecommitWithPvtData(t *testing.T) {
+	testEnv := newTestEnv(t)
+	defer testEnv.cleanup()
+	viper.Set("ledger.commitJournal.enabled", true)
+	defer viper.Set("ledger.commitJournal.enabled", false)
+	provider := NewProvider()
+	defer provider.Close()
+	store, err := provider.Open("testLedger")
+	assert.NoError(t, err)
+	store.Init(btlPolicyForSampleData())
+	defer store.Shutdown()
+
+	sampleData := sampleDataWithPvtdataForAllTxs(t)
+	for _, sampleDatum := range sampleData[0:3] {
+		assert.NoError(t, store.CommitWithPvtData(sampleDatum))
+	}
+	assert.NoError(t, store.Sync())
+
+	// Mimic a crash which lost block 3 from the block store but not from the pvt data store,
+	// with a pending batch of pvt data for block 4
+	var pvtdata []*ledger.TxPvtData
+	for _, p := range sampleData[3].BlockPvtData {
+		pvtdata = append(pvtdata, p)
+	}
+	assert.NoError(t, store.pvtdataStore.Prepare(3, pvtdata, nil))
+	assert.NoError(t, store.pvtdataStore.Commit())
+	assert.NoError(t, store.pvtdataStore.Prepare(4, nil, nil))
+	store.Shutdown()
+	provider.Close()
+	provider = NewProvider()
+	store, err = provider.Open("testLedger")
+	assert.NoError(t, err)
+	store.Init(btlPolicyForSampleData())
+
+	// the blocks from the commit journal are recommitted to the stores which lost them
+	for _, sampleDatum := range sampleData[3:5] {
+		assert.NoError(t, store.RecommitWithPvtData(sampleDatum))
+	}
+	assert.NoError(t, store.RecommitWithPvtData(sampleData[4]))
+	bcInfo, err := store.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), bcInfo.Height)
+	for _, sampleDatum := range sampleData[3:5] {
+		blkAndPvtdata, err := store.GetPvtDataAndBlockByNum(sampleDatum.Block.Header.Number, nil)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(sampleDatum.Block, blkAndPvtdata.Block))
+		assert.Equal(t, len(sampleDatum.BlockPvtData), len(blkAndPvtdata.BlockPvtData))
+	}
+	assert.NoError(t, store.CommitWithPvtData(sampleData[5]))
+}
+
 func TestAddAfterPvtdataStoreError(t *testing.T) {
 	testEnv := newTestEnv(t)
 	defer testEnv.cleanup()
@@ -309,6 +362,68 @@ func TestAddAfterBlkStoreError(t *testing.T) {
 	assert.False(t, pvtStorePndingBatch)
 }
 
+func TestDisabledBlockIndexes(t *testing.T) {
+	testEnv := newTestEnv(t)
+	defer testEnv.cleanup()
+	viper.Set("ledger.blockchain.disabledIndexes", []string{"BlockHash", "BlockTxID"})
+	defer viper.Set("ledger.blockchain.disabledIndexes", nil)
+	provider := NewProvider()
+	defer provider.Close()
+	store, err := provider.Open("testLedger")
+	assert.NoError(t, err)
+	store.Init(btlPolicyForSampleData())
+	defer store.Shutdown()
+
+	sampleData := sampleDataWithPvtdataForSelectiveTx(t)
+	for _, sampleDatum := range sampleData {
+		assert.NoError(t, store.CommitWithPvtData(sampleDatum))
+	}
+	block := sampleData[2].Block
+
+	_, err = store.RetrieveBlockByHash(block.Header.Hash())
+	assert.EqualError(t, err, "the BlockHash index is disabled on this peer: attribute not indexed")
+	assert.Equal(t, blkstorage.ErrAttrNotIndexed, errors.Cause(err))
+
+	env, err := utils.GetEnvelopeFromBlock(block.Data.Data[0])
+	assert.NoError(t, err)
+	chdr, err := utils.ChannelHeader(env)
+	assert.NoError(t, err)
+	txid := chdr.TxId
+	_, err = store.RetrieveBlockByTxID(txid)
+	assert.EqualError(t, err, "the BlockTxID index is disabled on this peer: attribute not indexed")
+
+	// the other indexes are maintained
+	retrievedBlock, err := store.RetrieveBlockByNumber(2)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(block, retrievedBlock))
+	_, err = store.RetrieveTxByID(txid)
+	assert.NoError(t, err)
+	_, err = store.RetrieveTxByBlockNumTranNum(2, 0)
+	assert.NoError(t, err)
+}
+
+func TestIndexedAttrs(t *testing.T) {
+	attrs, err := indexedAttrs(nil, true)
+	assert.NoError(t, err)
+	assert.Len(t, attrs, 6)
+
+	attrs, err = indexedAttrs([]string{"BlockHash", "BlockNumTranNum"}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []blkstorage.IndexableAttr{
+		blkstorage.IndexableAttrBlockNum,
+		blkstorage.IndexableAttrTxID,
+		blkstorage.IndexableAttrBlockTxID,
+		blkstorage.IndexableAttrTxValidationCode,
+	}, attrs)
+
+	_, err = indexedAttrs([]string{"TxID"}, false)
+	assert.EqualError(t, err, "index TxID cannot be disabled, only the BlockHash, BlockTxID and BlockNumTranNum indexes can be")
+	_, err = indexedAttrs([]string{"BlockHsh"}, false)
+	assert.EqualError(t, err, "index BlockHsh cannot be disabled, only the BlockHash, BlockTxID and BlockNumTranNum indexes can be")
+	_, err = indexedAttrs([]string{"BlockNumTranNum"}, true)
+	assert.EqualError(t, err, "index BlockNumTranNum cannot be disabled while the history database is enabled")
+}
+
 func TestConstructPvtdataMap(t *testing.T) {
 	assert.Nil(t, constructPvtdataMap(nil))
 }
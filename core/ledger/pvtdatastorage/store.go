@@ -66,6 +66,8 @@ type Store interface {
 	LastCommittedBlockHeight() (uint64, error)
 	// HasPendingBatch returns if the store has a pending batch
 	HasPendingBatch() (bool, error)
+	// Sync syncs to disk the pvt data committed so far, including the data whose sync was deferred
+	Sync() error
 	// Shutdown stops the store
 	Shutdown()
 }
@@ -81,7 +81,10 @@ type storeEntries struct {
 // NewProvider instantiates a StoreProvider
 func NewProvider() Provider {
 	dbPath := ledgerconfig.GetPvtdataStorePath()
-	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{
+		DBPath:    dbPath,
+		DeferSync: ledgerconfig.IsCommitJournalEnabled(),
+	})
 	return &provider{dbProvider: dbProvider}
 }
 
@@ -430,6 +433,11 @@ func (s *store) IsEmpty() (bool, error) {
 }
 
 // Shutdown implements the function in the interface `Store`
+// Sync implements the function in the interface `Store`
+func (s *store) Sync() error {
+	return s.db.Sync()
+}
+
 func (s *store) Shutdown() {
 	// do nothing
 }
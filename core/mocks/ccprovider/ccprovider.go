@@ -50,6 +50,10 @@ func (m *MockTxSim) GetStateRangeScanIterator(namespace string, startKey string,
 	return nil, nil
 }
 
+func (m *MockTxSim) GetStateRangeScanIteratorReverse(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	return nil, nil
+}
+
 func (m *MockTxSim) GetStateRangeScanIteratorWithMetadata(namespace string, startKey, endKey string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
 	return nil, nil
 }
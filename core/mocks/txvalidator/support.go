@@ -18,6 +18,7 @@ package support
 
 import (
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
 	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
@@ -28,10 +29,12 @@ import (
 )
 
 type Support struct {
-	LedgerVal     ledger.PeerLedger
-	MSPManagerVal msp.MSPManager
-	ApplyVal      error
-	ACVal         channelconfig.ApplicationCapabilities
+	LedgerVal               ledger.PeerLedger
+	MSPManagerVal           msp.MSPManager
+	ApplyVal                error
+	ACVal                   channelconfig.ApplicationCapabilities
+	MaxClockSkewVal         time.Duration
+	MaxRangeQueryResultsVal uint32
 
 	sync.Mutex
 	capabilitiesInvokeCount int
@@ -67,6 +70,16 @@ func (ms *Support) PolicyManager() policies.Manager {
 	return &mockpolicies.Manager{}
 }
 
+// MaxClockSkew returns MaxClockSkewVal
+func (ms *Support) MaxClockSkew() time.Duration {
+	return ms.MaxClockSkewVal
+}
+
+// MaxRangeQueryResults returns MaxRangeQueryResultsVal
+func (ms *Support) MaxRangeQueryResults() uint32 {
+	return ms.MaxRangeQueryResultsVal
+}
+
 func (ms *Support) GetMSPIDs(cid string) []string {
 	return []string{"SampleOrg"}
 }
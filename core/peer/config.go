@@ -26,6 +26,7 @@ import (
 	"net"
 	"path/filepath"
 
+	"github.com/hyperledger/fabric/common/viperutil"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/config"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -145,7 +146,7 @@ func GetServerConfig() (comm.ServerConfig, error) {
 		secureOptions.RequireClientCert = viper.GetBool("peer.tls.clientAuthRequired")
 		if secureOptions.RequireClientCert {
 			var clientRoots [][]byte
-			for _, file := range viper.GetStringSlice("peer.tls.clientRootCAs.files") {
+			for _, file := range viperutil.GetStringSlice("peer.tls.clientRootCAs.files") {
 				clientRoot, err := ioutil.ReadFile(
 					config.TranslatePath(filepath.Dir(viper.ConfigFileUsed()), file))
 				if err != nil {
@@ -171,6 +172,8 @@ func GetServerConfig() (comm.ServerConfig, error) {
 	if viper.IsSet("peer.keepalive.minInterval") {
 		serverConfig.KaOpts.ServerMinInterval = viper.GetDuration("peer.keepalive.minInterval")
 	}
+	serverConfig.Compression = viper.GetBool("peer.compression.enabled")
+	serverConfig.MaxConcurrentStreams = uint32(viper.GetInt("peer.limits.maxConcurrentStreams"))
 	return serverConfig, nil
 }
 
@@ -229,3 +232,46 @@ func GetClientCertificate() (tls.Certificate, error) {
 	}
 	return cert, nil
 }
+
+// ChaincodeServerTLS holds the TLS material the peer serves the chaincodes
+// with on its chaincode listen address
+type ChaincodeServerTLS struct {
+	Certificate []byte
+	Key         []byte
+	// RootCert is the certificate the chaincodes verify the peer's with
+	RootCert []byte
+}
+
+// GetChaincodeServerTLS returns the TLS material configured for the chaincode
+// server, which lets it present another certificate than the peer server,
+// such as one issued for an internal network interface. It returns nil if
+// none is configured, in which case the peer issues the certificate itself.
+func GetChaincodeServerTLS() (*ChaincodeServerTLS, error) {
+	certPath := config.GetPath("peer.chaincodeTLS.cert.file")
+	keyPath := config.GetPath("peer.chaincodeTLS.key.file")
+	rootCertPath := config.GetPath("peer.chaincodeTLS.rootcert.file")
+	if certPath == "" && keyPath == "" && rootCertPath == "" {
+		return nil, nil
+	}
+	if certPath == "" || keyPath == "" || rootCertPath == "" {
+		return nil, errors.New("peer.chaincodeTLS.cert.file, " +
+			"peer.chaincodeTLS.key.file and peer.chaincodeTLS.rootcert.file " +
+			"must all be set or must all be empty")
+	}
+	cert, err := ioutil.ReadFile(certPath)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error loading chaincode TLS certificate")
+	}
+	key, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error loading chaincode TLS key")
+	}
+	rootCert, err := ioutil.ReadFile(rootCertPath)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error loading chaincode TLS root certificate")
+	}
+	if _, err := tls.X509KeyPair(cert, key); err != nil {
+		return nil, errors.WithMessage(err, "error parsing chaincode TLS key pair")
+	}
+	return &ChaincodeServerTLS{Certificate: cert, Key: key, RootCert: rootCert}, nil
+}
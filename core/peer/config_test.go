@@ -8,6 +8,7 @@ package peer
 import (
 	"crypto/tls"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"path/filepath"
 	"testing"
@@ -249,3 +250,56 @@ func TestGetClientCertificate(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, cert)
 }
+
+func TestGetChaincodeServerTLS(t *testing.T) {
+	defer func() {
+		viper.Set("peer.chaincodeTLS.cert.file", "")
+		viper.Set("peer.chaincodeTLS.key.file", "")
+		viper.Set("peer.chaincodeTLS.rootcert.file", "")
+	}()
+	viper.Set("peer.chaincodeTLS.cert.file", "")
+	viper.Set("peer.chaincodeTLS.key.file", "")
+	viper.Set("peer.chaincodeTLS.rootcert.file", "")
+
+	// none set - the peer issues the certificate
+	ccTLS, err := GetChaincodeServerTLS()
+	assert.NoError(t, err)
+	assert.Nil(t, ccTLS)
+
+	// root certificate not set - expect error
+	viper.Set("peer.chaincodeTLS.cert.file",
+		filepath.Join("testdata", "Org1-server1-cert.pem"))
+	viper.Set("peer.chaincodeTLS.key.file",
+		filepath.Join("testdata", "Org1-server1-key.pem"))
+	_, err = GetChaincodeServerTLS()
+	assert.EqualError(t, err, "peer.chaincodeTLS.cert.file, peer.chaincodeTLS.key.file "+
+		"and peer.chaincodeTLS.rootcert.file must all be set or must all be empty")
+
+	// key not matching the certificate - expect error
+	viper.Set("peer.chaincodeTLS.rootcert.file",
+		filepath.Join("testdata", "Org1-cert.pem"))
+	viper.Set("peer.chaincodeTLS.key.file",
+		filepath.Join("testdata", "Org2-server1-key.pem"))
+	_, err = GetChaincodeServerTLS()
+	assert.Contains(t, err.Error(), "error parsing chaincode TLS key pair")
+
+	// missing file - expect error
+	viper.Set("peer.chaincodeTLS.key.file",
+		filepath.Join("testdata", "missing-key.pem"))
+	_, err = GetChaincodeServerTLS()
+	assert.Contains(t, err.Error(), "error loading chaincode TLS key")
+
+	viper.Set("peer.chaincodeTLS.key.file",
+		filepath.Join("testdata", "Org1-server1-key.pem"))
+	ccTLS, err = GetChaincodeServerTLS()
+	assert.NoError(t, err)
+	for expected, actual := range map[string][]byte{
+		"Org1-server1-cert.pem": ccTLS.Certificate,
+		"Org1-server1-key.pem":  ccTLS.Key,
+		"Org1-cert.pem":         ccTLS.RootCert,
+	} {
+		expectedBytes, err := ioutil.ReadFile(filepath.Join("testdata", expected))
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBytes, actual)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/binary"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/config"
+	"github.com/pkg/errors"
+)
+
+// DeliverCursorStore persists the cursors of the named consumers of the
+// deliver services, that is the number of the last block each consumer
+// acknowledged on each channel. Cursors belong to the identity which
+// acknowledged them, so consumers cannot move each other's cursors.
+type DeliverCursorStore struct {
+	provider *leveldbhelper.Provider
+}
+
+// GetDeliverCursorStorePath returns the path of the deliver cursor store
+func GetDeliverCursorStorePath() string {
+	sysPath := config.GetPath("peer.fileSystemPath")
+	return filepath.Join(sysPath, "deliverCursors")
+}
+
+// NewDeliverCursorStore opens the deliver cursor store at the given path
+func NewDeliverCursorStore(path string) *DeliverCursorStore {
+	return &DeliverCursorStore{
+		provider: leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: path}),
+	}
+}
+
+// Cursor returns the number of the last block acknowledged by the given
+// consumer of the creator on the channel, and false if it never acknowledged
+// any block.
+func (s *DeliverCursorStore) Cursor(channelID string, creator []byte, name string) (uint64, bool, error) {
+	value, err := s.provider.GetDBHandle(channelID).Get(cursorKey(creator, name))
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "could not retrieve cursor %s", name)
+	}
+	if value == nil {
+		return 0, false, nil
+	}
+	if len(value) != 8 {
+		return 0, false, errors.Errorf("cursor %s is corrupted", name)
+	}
+	return binary.BigEndian.Uint64(value), true, nil
+}
+
+// Acknowledge moves the cursor of the given consumer of the creator on the
+// channel to the given block
+func (s *DeliverCursorStore) Acknowledge(channelID string, creator []byte, name string, blockNumber uint64) error {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, blockNumber)
+	err := s.provider.GetDBHandle(channelID).Put(cursorKey(creator, name), value, true)
+	return errors.Wrapf(err, "could not store cursor %s", name)
+}
+
+// Close closes the deliver cursor store
+func (s *DeliverCursorStore) Close() {
+	s.provider.Close()
+}
+
+func cursorKey(creator []byte, name string) []byte {
+	return append(util.ComputeSHA256(creator), []byte(name)...)
+}
@@ -16,15 +16,19 @@ limitations under the License.
 package peer
 
 import (
+	"context"
+	"regexp"
 	"runtime/debug"
 	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/deliver"
 	"github.com/hyperledger/fabric/common/flogging"
+	util2 "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -43,11 +47,15 @@ type PolicyCheckerProvider func(resourceName string) deliver.PolicyCheckerFunc
 type server struct {
 	dh                    *deliver.Handler
 	policyCheckerProvider PolicyCheckerProvider
+	cursors               *DeliverCursorStore
 }
 
 // blockResponseSender structure used to send block responses
 type blockResponseSender struct {
 	peer.Deliver_DeliverServer
+	// data tells whether the client of the deliver request being served may
+	// read the transactions of the blocks
+	data *dataAccess
 }
 
 // SendStatusResponse generates status reply proto message
@@ -60,15 +68,37 @@ func (brs *blockResponseSender) SendStatusResponse(status common.Status) error {
 
 // SendBlockResponse generates deliver response with block message
 func (brs *blockResponseSender) SendBlockResponse(block *common.Block) error {
+	if brs.data.redacted() {
+		block = redactBlock(block)
+	}
 	response := &peer.DeliverResponse{
 		Type: &peer.DeliverResponse_Block{Block: block},
 	}
 	return brs.Send(response)
 }
 
+// redactBlock returns a copy of the block whose transactions are replaced by
+// their SHA256 hashes. The header and the metadata are kept, so that the
+// chain of the blocks and the signatures of the orderers can be verified, and
+// the inclusion of a transaction can be checked by whoever knows it.
+func redactBlock(block *common.Block) *common.Block {
+	redacted := &common.Block{
+		Header:   block.Header,
+		Data:     &common.BlockData{},
+		Metadata: block.Metadata,
+	}
+	for _, envelope := range block.GetData().GetData() {
+		redacted.Data.Data = append(redacted.Data.Data, util2.ComputeSHA256(envelope))
+	}
+	return redacted
+}
+
 // filteredBlockResponseSender structure used to send filtered block responses
 type filteredBlockResponseSender struct {
 	peer.Deliver_DeliverFilteredServer
+	// interest of the deliver request being served, nil if the client
+	// is interested in all transactions
+	interest *deliverInterest
 }
 
 func (fbrs *filteredBlockResponseSender) SendStatusResponse(status common.Status) error {
@@ -82,7 +112,7 @@ func (fbrs *filteredBlockResponseSender) SendStatusResponse(status common.Status
 func (fbrs *filteredBlockResponseSender) SendBlockResponse(block *common.Block) error {
 	// Generates filtered block response
 	b := blockEvent(*block)
-	filteredBlock, err := b.toFilteredBlock()
+	filteredBlock, err := b.toFilteredBlock(fbrs.interest)
 	if err != nil {
 		logger.Warningf("Failed to generate filtered block due to: %s", err)
 		return fbrs.SendStatusResponse(common.Status_BAD_REQUEST)
@@ -93,6 +123,262 @@ func (fbrs *filteredBlockResponseSender) SendBlockResponse(block *common.Block)
 	return fbrs.Send(response)
 }
 
+// chaincodeEventsResponseSender structure used to send the chaincode events
+// of the blocks
+type chaincodeEventsResponseSender struct {
+	peer.Deliver_DeliverWithEventFilterServer
+	// interest of the deliver request being served, nil if the client
+	// is interested in all chaincode events
+	interest *deliverInterest
+	// data tells whether the client of the deliver request being served may
+	// read the payloads of the events
+	data *dataAccess
+}
+
+func (cers *chaincodeEventsResponseSender) SendStatusResponse(status common.Status) error {
+	response := &peer.DeliverResponse{
+		Type: &peer.DeliverResponse_Status{Status: status},
+	}
+	return cers.Send(response)
+}
+
+// SendBlockResponse generates deliver response with the chaincode events of
+// the block
+func (cers *chaincodeEventsResponseSender) SendBlockResponse(block *common.Block) error {
+	b := blockEvent(*block)
+	chaincodeEvents, err := b.toBlockChaincodeEvents(cers.interest, !cers.data.redacted())
+	if err != nil {
+		logger.Warningf("Failed to extract the chaincode events of the block due to: %s", err)
+		return cers.SendStatusResponse(common.Status_BAD_REQUEST)
+	}
+	response := &peer.DeliverResponse{
+		Type: &peer.DeliverResponse_ChaincodeEvents{ChaincodeEvents: chaincodeEvents},
+	}
+	return cers.Send(response)
+}
+
+// deliverInterest is the parsed form of a peer.DeliverInterest
+type deliverInterest struct {
+	chaincodes map[string]bool
+	eventName  *regexp.Regexp
+}
+
+// newDeliverInterest parses the given peer.DeliverInterest
+func newDeliverInterest(di *peer.DeliverInterest) (*deliverInterest, error) {
+	interest := &deliverInterest{}
+	if len(di.ChaincodeNames) != 0 {
+		interest.chaincodes = make(map[string]bool)
+		for _, name := range di.ChaincodeNames {
+			interest.chaincodes[name] = true
+		}
+	}
+	if di.EventNameFilter != "" {
+		eventName, err := regexp.Compile(di.EventNameFilter)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid event name filter %s", di.EventNameFilter)
+		}
+		interest.eventName = eventName
+	}
+	return interest, nil
+}
+
+// interestedInChaincode returns true if the actions of the given chaincode are of interest
+func (di *deliverInterest) interestedInChaincode(name string) bool {
+	return di == nil || di.chaincodes == nil || di.chaincodes[name]
+}
+
+// interestedInEvent returns true if the chaincode events with the given name are of interest
+func (di *deliverInterest) interestedInEvent(name string) bool {
+	return di == nil || di.eventName == nil || di.eventName.MatchString(name)
+}
+
+// deliverConsumer identifies the cursor of a named consumer
+type deliverConsumer struct {
+	creator []byte
+	name    string
+}
+
+// extractSeekExtension returns the interest and the consumer carried by the
+// DeliverSeekExtension of the given envelope, or nil if there are none.
+// Envelopes that cannot be parsed yield neither, as they are rejected by
+// the deliver handler.
+func extractSeekExtension(envelope *common.Envelope) (*deliverInterest, *deliverConsumer, error) {
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil || payload.Header == nil {
+		return nil, nil, nil
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil || len(chdr.Extension) == 0 {
+		return nil, nil, nil
+	}
+	ext := &peer.DeliverSeekExtension{}
+	if err := proto.Unmarshal(chdr.Extension, ext); err != nil {
+		return nil, nil, errors.Wrap(err, "error unmarshaling deliver seek extension")
+	}
+
+	var interest *deliverInterest
+	if ext.Interest != nil {
+		interest, err = newDeliverInterest(ext.Interest)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var consumer *deliverConsumer
+	if ext.ConsumerName != "" {
+		shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+		if err != nil {
+			return nil, nil, nil
+		}
+		consumer = &deliverConsumer{creator: shdr.Creator, name: ext.ConsumerName}
+	}
+	return interest, consumer, nil
+}
+
+// seekReceiver receives the seek requests of a deliver stream, extracts the
+// options carried by their DeliverSeekExtension, and resolves the position
+// of the consumer cursors they resume from
+type seekReceiver struct {
+	deliver.Receiver
+	sender deliver.ResponseSender
+	// filteredSender is handed over the interest of the requests;
+	// nil on the other streams
+	filteredSender *filteredBlockResponseSender
+	// blockSender is told whether the blocks must be redacted, according to
+	// dataPolicyChecker; nil on the other streams
+	blockSender *blockResponseSender
+	// eventsSender is handed over the interest of the requests, and told
+	// whether the payloads of the events must be left out, according to
+	// dataPolicyChecker; nil on the other streams
+	eventsSender      *chaincodeEventsResponseSender
+	dataPolicyChecker deliver.PolicyChecker
+	// chains provides the config sequence of the channels, which triggers
+	// the evaluation of dataPolicyChecker again when it changes
+	chains  deliver.ChainManager
+	cursors *DeliverCursorStore
+	// consumer of the request being served, nil if none
+	consumer *deliverConsumer
+}
+
+// Recv receives the next seek request, replying with BAD_REQUEST to the
+// ones carrying invalid options
+func (sr *seekReceiver) Recv() (*common.Envelope, error) {
+	for {
+		envelope, err := sr.Receiver.Recv()
+		if err != nil {
+			return envelope, err
+		}
+		interest, consumer, err := extractSeekExtension(envelope)
+		if err == nil && consumer != nil && sr.cursors == nil {
+			err = errors.New("consumer cursors are not supported")
+		}
+		if err != nil {
+			logger.Warningf("Rejecting deliver request due to invalid seek extension: %s", err)
+			if err := sr.sender.SendStatusResponse(common.Status_BAD_REQUEST); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if sr.filteredSender != nil {
+			sr.filteredSender.interest = interest
+		}
+		if sr.blockSender != nil {
+			sr.blockSender.data = sr.newDataAccess(envelope)
+		}
+		if sr.eventsSender != nil {
+			sr.eventsSender.interest = interest
+			sr.eventsSender.data = sr.newDataAccess(envelope)
+		}
+		sr.consumer = consumer
+		return envelope, nil
+	}
+}
+
+// dataAccess tells whether the client of a deliver request may read the
+// transactions of the blocks. Like the deliver.SessionAccessControl of the
+// request, the policy is evaluated again whenever the config sequence of the
+// channel changes, so that the clients whose access is revoked in the middle
+// of a stream receive redacted blocks from then on.
+type dataAccess struct {
+	envelope      *common.Envelope
+	channelID     string
+	policyChecker deliver.PolicyChecker
+	// sequencer is nil if the channel is not found, in which case the
+	// deliver handler rejects the request
+	sequencer deliver.ConfigSequencer
+	sequence  uint64
+	mayRead   bool
+}
+
+// newDataAccess evaluates whether the creator of the request may read the
+// transactions of the blocks. Malformed requests are rejected by the deliver
+// handler, and are not redacted.
+func (sr *seekReceiver) newDataAccess(envelope *common.Envelope) *dataAccess {
+	da := &dataAccess{envelope: envelope, policyChecker: sr.dataPolicyChecker, mayRead: true}
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil || payload.Header == nil {
+		return da
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return da
+	}
+	da.channelID = chdr.ChannelId
+	if sr.chains != nil {
+		if chain, ok := sr.chains.GetChain(chdr.ChannelId); ok {
+			da.sequencer = chain
+			da.sequence = chain.Sequence()
+		}
+	}
+	da.evaluate()
+	return da
+}
+
+func (da *dataAccess) evaluate() {
+	da.mayRead = true
+	if err := da.policyChecker.CheckPolicy(da.envelope, da.channelID); err != nil {
+		logger.Debugf("[channel: %s] Redacting the blocks delivered, the client may not read their transactions: %s", da.channelID, err)
+		da.mayRead = false
+	}
+}
+
+// redacted returns whether the blocks must be redacted, evaluating the
+// policy again if the config sequence of the channel changed
+func (da *dataAccess) redacted() bool {
+	if da == nil {
+		return false
+	}
+	if da.sequencer != nil {
+		if sequence := da.sequencer.Sequence(); sequence != da.sequence {
+			da.sequence = sequence
+			da.evaluate()
+		}
+	}
+	return !da.mayRead
+}
+
+// ResolveStart resumes the request being served from the block following
+// the last one acknowledged by its consumer, if any
+func (sr *seekReceiver) ResolveStart(channelID string, start *orderer.SeekPosition) (*orderer.SeekPosition, error) {
+	if sr.consumer == nil {
+		return start, nil
+	}
+	blockNumber, exists, err := sr.cursors.Cursor(channelID, sr.consumer.creator, sr.consumer.name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		logger.Debugf("[channel: %s] Cursor %s not found, delivering from the requested start", channelID, sr.consumer.name)
+		return start, nil
+	}
+	logger.Debugf("[channel: %s] Resuming cursor %s from block %d", channelID, sr.consumer.name, blockNumber+1)
+	return &orderer.SeekPosition{
+		Type: &orderer.SeekPosition_Specified{
+			Specified: &orderer.SeekSpecified{Number: blockNumber + 1},
+		},
+	}, nil
+}
+
 // transactionActions aliasing for peer.TransactionAction pointers slice
 type transactionActions []*peer.TransactionAction
 
@@ -100,17 +386,30 @@ type transactionActions []*peer.TransactionAction
 // extend with auxiliary functionality
 type blockEvent common.Block
 
+// Drain ends the deliver streams with SERVICE_UNAVAILABLE
+func (s *server) Drain() {
+	s.dh.Drain()
+}
+
 // Deliver sends a stream of blocks to a client after commitment
 func (s *server) DeliverFiltered(srv peer.Deliver_DeliverFilteredServer) error {
 	logger.Debugf("Starting new DeliverFiltered handler")
 	defer dumpStacktraceOnPanic()
+	sender := &filteredBlockResponseSender{
+		Deliver_DeliverFilteredServer: srv,
+	}
+	receiver := &seekReceiver{
+		Receiver:       srv,
+		sender:         sender,
+		filteredSender: sender,
+		cursors:        s.cursors,
+	}
 	// getting policy checker based on resources.Event_FilteredBlock resource name
 	deliverServer := &deliver.Server{
-		Receiver:      srv,
-		PolicyChecker: s.policyCheckerProvider(resources.Event_FilteredBlock),
-		ResponseSender: &filteredBlockResponseSender{
-			Deliver_DeliverFilteredServer: srv,
-		},
+		Receiver:       receiver,
+		PolicyChecker:  s.policyCheckerProvider(resources.Event_FilteredBlock),
+		ResponseSender: sender,
+		StartResolver:  receiver,
 	}
 	return s.dh.Handle(srv.Context(), deliverServer)
 }
@@ -119,20 +418,130 @@ func (s *server) DeliverFiltered(srv peer.Deliver_DeliverFilteredServer) error {
 func (s *server) Deliver(srv peer.Deliver_DeliverServer) (err error) {
 	logger.Debugf("Starting new Deliver handler")
 	defer dumpStacktraceOnPanic()
+	sender := &blockResponseSender{
+		Deliver_DeliverServer: srv,
+	}
+	receiver := &seekReceiver{
+		Receiver:          srv,
+		sender:            sender,
+		blockSender:       sender,
+		dataPolicyChecker: s.policyCheckerProvider(resources.Event_BlockData),
+		chains:            s.dh.ChainManager,
+		cursors:           s.cursors,
+	}
 	// getting policy checker based on resources.Event_Block resource name
 	deliverServer := &deliver.Server{
-		PolicyChecker: s.policyCheckerProvider(resources.Event_Block),
-		Receiver:      srv,
-		ResponseSender: &blockResponseSender{
-			Deliver_DeliverServer: srv,
-		},
+		PolicyChecker:  s.policyCheckerProvider(resources.Event_Block),
+		Receiver:       receiver,
+		ResponseSender: sender,
+		StartResolver:  receiver,
 	}
 	return s.dh.Handle(srv.Context(), deliverServer)
 }
 
+// DeliverWithEventFilter sends a stream of the chaincode events of the
+// blocks to a client after commitment
+func (s *server) DeliverWithEventFilter(srv peer.Deliver_DeliverWithEventFilterServer) error {
+	logger.Debugf("Starting new DeliverWithEventFilter handler")
+	defer dumpStacktraceOnPanic()
+	sender := &chaincodeEventsResponseSender{
+		Deliver_DeliverWithEventFilterServer: srv,
+	}
+	receiver := &seekReceiver{
+		Receiver:          srv,
+		sender:            sender,
+		eventsSender:      sender,
+		dataPolicyChecker: s.policyCheckerProvider(resources.Event_BlockData),
+		chains:            s.dh.ChainManager,
+		cursors:           s.cursors,
+	}
+	// the events are part of the blocks, so getting policy checker based
+	// on resources.Event_Block resource name
+	deliverServer := &deliver.Server{
+		PolicyChecker:  s.policyCheckerProvider(resources.Event_Block),
+		Receiver:       receiver,
+		ResponseSender: sender,
+		StartResolver:  receiver,
+	}
+	return s.dh.Handle(srv.Context(), deliverServer)
+}
+
+// AcknowledgeDelivery moves the consumer cursor of the signer of the
+// envelope to the acknowledged block
+func (s *server) AcknowledgeDelivery(ctx context.Context, envelope *common.Envelope) (*peer.DeliverResponse, error) {
+	return &peer.DeliverResponse{
+		Type: &peer.DeliverResponse_Status{Status: s.acknowledge(ctx, envelope)},
+	}, nil
+}
+
+func (s *server) acknowledge(ctx context.Context, envelope *common.Envelope) common.Status {
+	addr := util2.ExtractRemoteAddress(ctx)
+	if s.cursors == nil {
+		logger.Warningf("Rejecting acknowledgement from %s because consumer cursors are not supported", addr)
+		return common.Status_NOT_IMPLEMENTED
+	}
+
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil || payload.Header == nil {
+		logger.Warningf("Received a malformed acknowledgement from %s", addr)
+		return common.Status_BAD_REQUEST
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		logger.Warningf("Failed to unmarshal channel header of acknowledgement from %s: %s", addr, err)
+		return common.Status_BAD_REQUEST
+	}
+	if err := s.dh.ValidateChannelHeader(ctx, chdr); err != nil {
+		logger.Warningf("Rejecting acknowledgement from %s due to envelope validation error: %s", addr, err)
+		return common.Status_BAD_REQUEST
+	}
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		logger.Warningf("Failed to unmarshal signature header of acknowledgement from %s: %s", addr, err)
+		return common.Status_BAD_REQUEST
+	}
+	cursor := &peer.DeliverCursor{}
+	if err := proto.Unmarshal(payload.Data, cursor); err != nil || cursor.ConsumerName == "" {
+		logger.Warningf("[channel: %s] Received an acknowledgement from %s with a malformed cursor", chdr.ChannelId, addr)
+		return common.Status_BAD_REQUEST
+	}
+
+	chain, ok := s.dh.ChainManager.GetChain(chdr.ChannelId)
+	if !ok {
+		logger.Debugf("Rejecting acknowledgement from %s because channel %s not found", addr, chdr.ChannelId)
+		return common.Status_NOT_FOUND
+	}
+	if err := s.policyCheckerProvider(resources.Event_FilteredBlock).CheckPolicy(envelope, chdr.ChannelId); err != nil {
+		logger.Warningf("[channel: %s] Client authorization failed for acknowledgement from %s: %s", chdr.ChannelId, addr, err)
+		return common.Status_FORBIDDEN
+	}
+	if height := chain.Reader().Height(); cursor.BlockNumber >= height {
+		logger.Warningf("[channel: %s] Received an acknowledgement from %s of block %d beyond the ledger height %d", chdr.ChannelId, addr, cursor.BlockNumber, height)
+		return common.Status_BAD_REQUEST
+	}
+
+	if err := s.cursors.Acknowledge(chdr.ChannelId, shdr.Creator, cursor.ConsumerName, cursor.BlockNumber); err != nil {
+		logger.Errorf("[channel: %s] Failed to acknowledge block %d for %s: %s", chdr.ChannelId, cursor.BlockNumber, addr, err)
+		return common.Status_INTERNAL_SERVER_ERROR
+	}
+	logger.Debugf("[channel: %s] Cursor %s of %s acknowledged block %d", chdr.ChannelId, cursor.ConsumerName, addr, cursor.BlockNumber)
+	return common.Status_SUCCESS
+}
+
+// DeliverEventsServer is a peer.DeliverServer which can be drained when the
+// peer shuts down
+type DeliverEventsServer interface {
+	peer.DeliverServer
+
+	// Drain ends the deliver streams with SERVICE_UNAVAILABLE so that clients
+	// fail over to another peer
+	Drain()
+}
+
 // NewDeliverEventsServer creates a peer.Deliver server to deliver block and
-// filtered block events
-func NewDeliverEventsServer(mutualTLS bool, policyCheckerProvider PolicyCheckerProvider, chainManager deliver.ChainManager) peer.DeliverServer {
+// filtered block events. The cursors of the consumers are persisted in the
+// given store; if nil, consumer cursors are not supported.
+func NewDeliverEventsServer(mutualTLS bool, policyCheckerProvider PolicyCheckerProvider, chainManager deliver.ChainManager, cursors *DeliverCursorStore) DeliverEventsServer {
 	timeWindow := viper.GetDuration("peer.authentication.timewindow")
 	if timeWindow == 0 {
 		defaultTimeWindow := 15 * time.Minute
@@ -142,6 +551,7 @@ func NewDeliverEventsServer(mutualTLS bool, policyCheckerProvider PolicyCheckerP
 	return &server{
 		dh: deliver.NewHandler(chainManager, timeWindow, mutualTLS),
 		policyCheckerProvider: policyCheckerProvider,
+		cursors:               cursors,
 	}
 }
 
@@ -156,7 +566,9 @@ func (s *server) sendProducer(srv peer.Deliver_DeliverFilteredServer) func(msg p
 	}
 }
 
-func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
+// toFilteredBlock converts the block to a filtered block, leaving out the
+// transactions which are not of interest
+func (block *blockEvent) toFilteredBlock(interest *deliverInterest) (*peer.FilteredBlock, error) {
 	filteredBlock := &peer.FilteredBlock{
 		Number: block.Header.Number,
 	}
@@ -208,11 +620,17 @@ func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 				return nil, errors.WithMessage(err, "error unmarshal transaction payload for block event")
 			}
 
-			filteredTransaction.Data, err = transactionActions(tx.Actions).toFilteredActions()
+			var interested bool
+			filteredTransaction.Data, interested, err = transactionActions(tx.Actions).toFilteredActions(interest)
 			if err != nil {
 				logger.Errorf(err.Error())
 				return nil, err
 			}
+			if !interested {
+				continue
+			}
+		} else if interest != nil {
+			continue
 		}
 
 		filteredBlock.FilteredTransactions = append(filteredBlock.FilteredTransactions, filteredTransaction)
@@ -221,34 +639,33 @@ func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 	return filteredBlock, nil
 }
 
-func (ta transactionActions) toFilteredActions() (*peer.FilteredTransaction_TransactionActions, error) {
+// toFilteredActions converts the transaction actions to filtered actions,
+// leaving out the ones which are not of interest. It returns false if the
+// transaction as a whole is not of interest.
+func (ta transactionActions) toFilteredActions(interest *deliverInterest) (*peer.FilteredTransaction_TransactionActions, bool, error) {
 	transactionActions := &peer.FilteredTransactionActions{}
+	interested := interest == nil || interest.chaincodes == nil
 	for _, action := range ta {
-		chaincodeActionPayload, err := utils.GetChaincodeActionPayload(action.Payload)
+		caPayload, err := chaincodeAction(action)
 		if err != nil {
-			return nil, errors.WithMessage(err, "error unmarshal transaction action payload for block event")
+			return nil, false, err
 		}
-
-		if chaincodeActionPayload.Action == nil {
+		if caPayload == nil {
 			logger.Debugf("chaincode action, the payload action is nil, skipping")
 			continue
 		}
-		propRespPayload, err := utils.GetProposalResponsePayload(chaincodeActionPayload.Action.ProposalResponsePayload)
-		if err != nil {
-			return nil, errors.WithMessage(err, "error unmarshal proposal response payload for block event")
-		}
 
-		caPayload, err := utils.GetChaincodeAction(propRespPayload.Extension)
-		if err != nil {
-			return nil, errors.WithMessage(err, "error unmarshal chaincode action for block event")
+		if !interest.interestedInChaincode(caPayload.GetChaincodeId().GetName()) {
+			continue
 		}
+		interested = true
 
 		ccEvent, err := utils.GetChaincodeEvents(caPayload.Events)
 		if err != nil {
-			return nil, errors.WithMessage(err, "error unmarshal chaincode event for block event")
+			return nil, false, errors.WithMessage(err, "error unmarshal chaincode event for block event")
 		}
 
-		if ccEvent.GetChaincodeId() != "" {
+		if ccEvent.GetChaincodeId() != "" && interest.interestedInEvent(ccEvent.EventName) {
 			filteredAction := &peer.FilteredChaincodeAction{
 				ChaincodeEvent: &peer.ChaincodeEvent{
 					TxId:        ccEvent.TxId,
@@ -259,9 +676,103 @@ func (ta transactionActions) toFilteredActions() (*peer.FilteredTransaction_Tran
 			transactionActions.ChaincodeActions = append(transactionActions.ChaincodeActions, filteredAction)
 		}
 	}
+	if interest != nil && interest.eventName != nil {
+		interested = len(transactionActions.ChaincodeActions) != 0
+	}
 	return &peer.FilteredTransaction_TransactionActions{
 		TransactionActions: transactionActions,
-	}, nil
+	}, interested, nil
+}
+
+// toBlockChaincodeEvents extracts the chaincode events of the valid
+// transactions of the block which are of interest, leaving their payloads
+// out unless withPayload is set
+func (block *blockEvent) toBlockChaincodeEvents(interest *deliverInterest, withPayload bool) (*peer.BlockChaincodeEvents, error) {
+	blockEvents := &peer.BlockChaincodeEvents{
+		Number: block.Header.Number,
+	}
+
+	txsFltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for txIndex, ebytes := range block.Data.Data {
+		if ebytes == nil {
+			logger.Debugf("got nil data bytes for tx index %d, "+
+				"block num %d", txIndex, block.Header.Number)
+			continue
+		}
+
+		env, err := utils.GetEnvelopeFromBlock(ebytes)
+		if err != nil {
+			logger.Errorf("error getting tx from block, %s", err)
+			continue
+		}
+		payload, err := utils.GetPayload(env)
+		if err != nil {
+			return nil, errors.WithMessage(err, "could not extract payload from envelope")
+		}
+		if payload.Header == nil {
+			logger.Debugf("transaction payload header is nil, %d, block num %d",
+				txIndex, block.Header.Number)
+			continue
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return nil, err
+		}
+
+		blockEvents.ChannelId = chdr.ChannelId
+
+		// the events of invalid transactions did not happen
+		if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION || !txsFltr.IsValid(txIndex) {
+			continue
+		}
+		tx, err := utils.GetTransaction(payload.Data)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error unmarshal transaction payload for block event")
+		}
+		for _, action := range tx.Actions {
+			caPayload, err := chaincodeAction(action)
+			if err != nil {
+				return nil, err
+			}
+			if caPayload == nil || !interest.interestedInChaincode(caPayload.GetChaincodeId().GetName()) {
+				continue
+			}
+			ccEvent, err := utils.GetChaincodeEvents(caPayload.Events)
+			if err != nil {
+				return nil, errors.WithMessage(err, "error unmarshal chaincode event for block event")
+			}
+			if ccEvent.GetChaincodeId() == "" || !interest.interestedInEvent(ccEvent.EventName) {
+				continue
+			}
+			if !withPayload {
+				ccEvent.Payload = nil
+			}
+			blockEvents.ChaincodeEvents = append(blockEvents.ChaincodeEvents, ccEvent)
+		}
+	}
+
+	return blockEvents, nil
+}
+
+// chaincodeAction returns the chaincode action of a transaction action, or
+// nil if its payload carries no action
+func chaincodeAction(action *peer.TransactionAction) (*peer.ChaincodeAction, error) {
+	chaincodeActionPayload, err := utils.GetChaincodeActionPayload(action.Payload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshal transaction action payload for block event")
+	}
+	if chaincodeActionPayload.Action == nil {
+		return nil, nil
+	}
+	propRespPayload, err := utils.GetProposalResponsePayload(chaincodeActionPayload.Action.ProposalResponsePayload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshal proposal response payload for block event")
+	}
+	caPayload, err := utils.GetChaincodeAction(propRespPayload.Extension)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshal chaincode action for block event")
+	}
+	return caPayload, nil
 }
 
 func dumpStacktraceOnPanic() {
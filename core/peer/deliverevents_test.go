@@ -9,6 +9,8 @@ package peer
 import (
 	"context"
 	"io"
+	"io/ioutil"
+	"os"
 	"sync"
 	"testing"
 
@@ -21,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -371,7 +374,7 @@ func TestEventsServer_DeliverFiltered(t *testing.T) {
 			wg := &sync.WaitGroup{}
 			chainManager, deliverServer := test.prepare(wg)
 
-			server := NewDeliverEventsServer(false, defaultPolicyCheckerProvider, chainManager)
+			server := NewDeliverEventsServer(false, defaultPolicyCheckerProvider, chainManager, nil)
 			err := server.DeliverFiltered(deliverServer)
 			wg.Wait()
 			// no error expected
@@ -448,11 +451,16 @@ func createEndorsement(channelID string, txID string, chaincodeActionPayload *pe
 }
 
 func createChaincodeAction(chaincodeName string, eventName string, txID string) (*peer.ChaincodeActionPayload, error) {
+	return createChaincodeActionWithPayload(chaincodeName, eventName, txID, nil)
+}
+
+func createChaincodeActionWithPayload(chaincodeName string, eventName string, txID string, eventPayload []byte) (*peer.ChaincodeActionPayload, error) {
 	// chaincode events
 	eventsBytes, err := proto.Marshal(&peer.ChaincodeEvent{
 		ChaincodeId: chaincodeName,
 		EventName:   eventName,
 		TxId:        txID,
+		Payload:     eventPayload,
 	})
 	if err != nil {
 		return nil, err
@@ -510,3 +518,432 @@ func createTestBlock(data []*common.Envelope) (*common.Block, error) {
 	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = make([]byte, len(data))
 	return block, nil
 }
+
+func TestDeliverInterest(t *testing.T) {
+	createTx := func(txID, chaincodeName, eventName string) *common.Envelope {
+		action, err := createChaincodeAction(chaincodeName, eventName, txID)
+		assert.NoError(t, err)
+		payload, err := createEndorsement("testChainID", txID, action)
+		assert.NoError(t, err)
+		return &common.Envelope{Payload: utils.MarshalOrPanic(payload)}
+	}
+	configTx := &common.Envelope{Payload: utils.MarshalOrPanic(&common.Payload{
+		Header: &common.Header{
+			ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+				ChannelId: "testChainID",
+				TxId:      "config",
+				Type:      int32(common.HeaderType_CONFIG),
+			}),
+		},
+	})}
+	block, err := createTestBlock([]*common.Envelope{
+		createTx("tx1", "mycc", "transfer"),
+		createTx("tx2", "mycc", "issue"),
+		createTx("tx3", "othercc", "transfer"),
+		createTx("tx4", "mycc", ""),
+		configTx,
+	})
+	assert.NoError(t, err)
+
+	txIDs := func(interest *peer.DeliverInterest) []string {
+		var di *deliverInterest
+		if interest != nil {
+			di, err = newDeliverInterest(interest)
+			assert.NoError(t, err)
+		}
+		b := blockEvent(*block)
+		filteredBlock, err := b.toFilteredBlock(di)
+		assert.NoError(t, err)
+		assert.Equal(t, "testChainID", filteredBlock.ChannelId)
+		var ids []string
+		for _, tx := range filteredBlock.FilteredTransactions {
+			ids = append(ids, tx.Txid)
+			for _, action := range tx.GetTransactionActions().GetChaincodeActions() {
+				assert.True(t, di.interestedInEvent(action.ChaincodeEvent.EventName))
+			}
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"tx1", "tx2", "tx3", "tx4", "config"}, txIDs(nil))
+	assert.Equal(t, []string{"tx1", "tx2", "tx3", "tx4"}, txIDs(&peer.DeliverInterest{}))
+	assert.Equal(t, []string{"tx1", "tx2", "tx4"}, txIDs(&peer.DeliverInterest{ChaincodeNames: []string{"mycc"}}))
+	assert.Equal(t, []string{"tx1", "tx3"}, txIDs(&peer.DeliverInterest{EventNameFilter: "^trans"}))
+	assert.Equal(t, []string{"tx1"}, txIDs(&peer.DeliverInterest{ChaincodeNames: []string{"mycc"}, EventNameFilter: "^trans"}))
+	assert.Empty(t, txIDs(&peer.DeliverInterest{ChaincodeNames: []string{"unknowncc"}}))
+
+	_, err = newDeliverInterest(&peer.DeliverInterest{EventNameFilter: "("})
+	assert.Contains(t, err.Error(), "invalid event name filter (")
+}
+
+func TestBlockChaincodeEvents(t *testing.T) {
+	createTx := func(txID, chaincodeName, eventName string) *common.Envelope {
+		action, err := createChaincodeActionWithPayload(chaincodeName, eventName, txID, []byte(txID+"-payload"))
+		assert.NoError(t, err)
+		payload, err := createEndorsement("testChainID", txID, action)
+		assert.NoError(t, err)
+		return &common.Envelope{Payload: utils.MarshalOrPanic(payload)}
+	}
+	block, err := createTestBlock([]*common.Envelope{
+		createTx("tx1", "mycc", "transfer"),
+		createTx("tx2", "mycc", "issue"),
+		createTx("tx3", "othercc", "transfer"),
+		createTx("tx4", "mycc", ""),
+		createTx("tx5", "mycc", "transfer"),
+	})
+	assert.NoError(t, err)
+	block.Header.Number = 7
+	// the events of invalid transactions are not delivered
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER][4] = byte(peer.TxValidationCode_MVCC_READ_CONFLICT)
+
+	txIDs := func(interest *peer.DeliverInterest, withPayload bool) []string {
+		var di *deliverInterest
+		if interest != nil {
+			di, err = newDeliverInterest(interest)
+			assert.NoError(t, err)
+		}
+		b := blockEvent(*block)
+		blockEvents, err := b.toBlockChaincodeEvents(di, withPayload)
+		assert.NoError(t, err)
+		assert.Equal(t, "testChainID", blockEvents.ChannelId)
+		assert.Equal(t, uint64(7), blockEvents.Number)
+		var ids []string
+		for _, event := range blockEvents.ChaincodeEvents {
+			ids = append(ids, event.TxId)
+			if withPayload {
+				assert.Equal(t, []byte(event.TxId+"-payload"), event.Payload)
+			} else {
+				assert.Nil(t, event.Payload)
+			}
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"tx1", "tx2", "tx3", "tx4"}, txIDs(nil, true))
+	assert.Equal(t, []string{"tx1", "tx2", "tx4"}, txIDs(&peer.DeliverInterest{ChaincodeNames: []string{"mycc"}}, true))
+	assert.Equal(t, []string{"tx1", "tx3"}, txIDs(&peer.DeliverInterest{EventNameFilter: "^trans"}, false))
+	assert.Equal(t, []string{"tx1"}, txIDs(&peer.DeliverInterest{ChaincodeNames: []string{"mycc"}, EventNameFilter: "^trans"}, true))
+	assert.Empty(t, txIDs(&peer.DeliverInterest{ChaincodeNames: []string{"unknowncc"}}, true))
+
+	// the payloads of the events in the block are left unchanged
+	assert.Equal(t, []string{"tx1", "tx2", "tx3", "tx4"}, txIDs(nil, true))
+}
+
+func TestDeliverWithEventFilter(t *testing.T) {
+	action, err := createChaincodeActionWithPayload("mycc", "transfer", "tx1", []byte("payload"))
+	assert.NoError(t, err)
+	payload, err := createEndorsement("testChainID", "tx1", action)
+	assert.NoError(t, err)
+	block, err := createTestBlock([]*common.Envelope{{Payload: utils.MarshalOrPanic(payload)}})
+	assert.NoError(t, err)
+
+	allowed := true
+	dataPolicyChecker := deliver.PolicyCheckerFunc(func(_ *common.Envelope, channelID string) error {
+		if !allowed {
+			return errors.New("not a member of the bilateral policy")
+		}
+		return nil
+	})
+
+	event := &peer.ChaincodeEvent{ChaincodeId: "mycc", EventName: "transfer", TxId: "tx1", Payload: []byte("payload")}
+	withPayload := &peer.DeliverResponse{Type: &peer.DeliverResponse_ChaincodeEvents{ChaincodeEvents: &peer.BlockChaincodeEvents{
+		ChannelId:       "testChainID",
+		ChaincodeEvents: []*peer.ChaincodeEvent{event},
+	}}}
+	withoutPayload := &peer.DeliverResponse{Type: &peer.DeliverResponse_ChaincodeEvents{ChaincodeEvents: &peer.BlockChaincodeEvents{
+		ChannelId:       "testChainID",
+		ChaincodeEvents: []*peer.ChaincodeEvent{{ChaincodeId: "mycc", EventName: "transfer", TxId: "tx1"}},
+	}}}
+	noEvents := &peer.DeliverResponse{Type: &peer.DeliverResponse_ChaincodeEvents{ChaincodeEvents: &peer.BlockChaincodeEvents{
+		ChannelId: "testChainID",
+	}}}
+
+	srv := &mockDeliverServer{}
+	srv.On("Recv").Return(seekEnvelope(nil), nil).Twice()
+	srv.On("Recv").Return(seekEnvelope(&peer.DeliverSeekExtension{Interest: &peer.DeliverInterest{EventNameFilter: "^issue$"}}), nil).Once()
+	srv.On("Send", withPayload).Return(nil).Once()
+	srv.On("Send", withoutPayload).Return(nil).Once()
+	srv.On("Send", noEvents).Return(nil).Once()
+	sender := &chaincodeEventsResponseSender{Deliver_DeliverWithEventFilterServer: srv}
+	receiver := &seekReceiver{Receiver: srv, sender: sender, eventsSender: sender, dataPolicyChecker: dataPolicyChecker}
+
+	// the clients satisfying the policy receive the payloads of the events
+	_, err = receiver.Recv()
+	assert.NoError(t, err)
+	assert.False(t, sender.data.redacted())
+	assert.NoError(t, sender.SendBlockResponse(block))
+
+	// the others do not
+	allowed = false
+	_, err = receiver.Recv()
+	assert.NoError(t, err)
+	assert.True(t, sender.data.redacted())
+	assert.NoError(t, sender.SendBlockResponse(block))
+
+	// the blocks are delivered regardless of the interest of the client
+	_, err = receiver.Recv()
+	assert.NoError(t, err)
+	assert.NotNil(t, sender.interest)
+	assert.NoError(t, sender.SendBlockResponse(block))
+	srv.AssertExpectations(t)
+}
+
+func seekEnvelope(extension *peer.DeliverSeekExtension) *common.Envelope {
+	chdr := &common.ChannelHeader{ChannelId: "testChainID"}
+	if extension != nil {
+		chdr.Extension = utils.MarshalOrPanic(extension)
+	}
+	return &common.Envelope{Payload: utils.MarshalOrPanic(&common.Payload{
+		Header: &common.Header{
+			ChannelHeader:   utils.MarshalOrPanic(chdr),
+			SignatureHeader: utils.MarshalOrPanic(&common.SignatureHeader{Creator: []byte("creator")}),
+		},
+	})}
+}
+
+func TestExtractSeekExtension(t *testing.T) {
+	interest, consumer, err := extractSeekExtension(seekEnvelope(nil))
+	assert.NoError(t, err)
+	assert.Nil(t, interest)
+	assert.Nil(t, consumer)
+
+	interest, consumer, err = extractSeekExtension(&common.Envelope{Payload: []byte("garbage")})
+	assert.NoError(t, err)
+	assert.Nil(t, interest)
+	assert.Nil(t, consumer)
+
+	interest, consumer, err = extractSeekExtension(seekEnvelope(&peer.DeliverSeekExtension{
+		Interest:     &peer.DeliverInterest{ChaincodeNames: []string{"mycc"}},
+		ConsumerName: "myconsumer",
+	}))
+	assert.NoError(t, err)
+	assert.True(t, interest.interestedInChaincode("mycc"))
+	assert.False(t, interest.interestedInChaincode("othercc"))
+	assert.Equal(t, &deliverConsumer{creator: []byte("creator"), name: "myconsumer"}, consumer)
+
+	_, _, err = extractSeekExtension(seekEnvelope(&peer.DeliverSeekExtension{
+		Interest: &peer.DeliverInterest{EventNameFilter: "("},
+	}))
+	assert.Contains(t, err.Error(), "invalid event name filter (")
+
+	env := seekEnvelope(nil)
+	payload := utils.UnmarshalPayloadOrPanic(env.Payload)
+	payload.Header.ChannelHeader = utils.MarshalOrPanic(&common.ChannelHeader{Extension: []byte("garbage")})
+	env.Payload = utils.MarshalOrPanic(payload)
+	_, _, err = extractSeekExtension(env)
+	assert.Contains(t, err.Error(), "error unmarshaling deliver seek extension")
+}
+
+func TestSeekReceiver(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "delivercursors")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	cursors := NewDeliverCursorStore(tempDir)
+	defer cursors.Close()
+
+	// requests with invalid options are rejected and the next one is received
+	srv := &mockDeliverServer{}
+	srv.On("Recv").Return(seekEnvelope(&peer.DeliverSeekExtension{
+		Interest: &peer.DeliverInterest{EventNameFilter: "("},
+	}), nil).Once()
+	srv.On("Recv").Return(seekEnvelope(&peer.DeliverSeekExtension{
+		Interest:     &peer.DeliverInterest{EventNameFilter: "issue"},
+		ConsumerName: "myconsumer",
+	}), nil).Once()
+	srv.On("Send", &peer.DeliverResponse{
+		Type: &peer.DeliverResponse_Status{Status: common.Status_BAD_REQUEST},
+	}).Return(nil).Once()
+	sender := &filteredBlockResponseSender{Deliver_DeliverFilteredServer: srv}
+	receiver := &seekReceiver{Receiver: srv, sender: sender, filteredSender: sender, cursors: cursors}
+	_, err = receiver.Recv()
+	assert.NoError(t, err)
+	srv.AssertExpectations(t)
+	assert.NotNil(t, sender.interest)
+	assert.True(t, sender.interest.interestedInEvent("issue"))
+	assert.False(t, sender.interest.interestedInEvent("transfer"))
+
+	// the requested start is used until the consumer acknowledges a block
+	start := &orderer.SeekPosition{Type: &orderer.SeekPosition_Oldest{Oldest: &orderer.SeekOldest{}}}
+	resolved, err := receiver.ResolveStart("testChainID", start)
+	assert.NoError(t, err)
+	assert.Equal(t, start, resolved)
+
+	assert.NoError(t, cursors.Acknowledge("testChainID", []byte("creator"), "myconsumer", 41))
+	resolved, err = receiver.ResolveStart("testChainID", start)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), resolved.GetSpecified().GetNumber())
+
+	// cursors belong to their channel and creator
+	resolved, err = receiver.ResolveStart("otherChainID", start)
+	assert.NoError(t, err)
+	assert.Equal(t, start, resolved)
+	receiver.consumer.creator = []byte("othercreator")
+	resolved, err = receiver.ResolveStart("testChainID", start)
+	assert.NoError(t, err)
+	assert.Equal(t, start, resolved)
+
+	// consumers are rejected when cursors are not supported
+	srv = &mockDeliverServer{}
+	srv.On("Recv").Return(seekEnvelope(&peer.DeliverSeekExtension{ConsumerName: "myconsumer"}), nil).Once()
+	srv.On("Recv").Return(nil, io.EOF).Once()
+	srv.On("Send", &peer.DeliverResponse{
+		Type: &peer.DeliverResponse_Status{Status: common.Status_BAD_REQUEST},
+	}).Return(nil).Once()
+	receiver = &seekReceiver{Receiver: srv, sender: &blockResponseSender{Deliver_DeliverServer: srv}}
+	_, err = receiver.Recv()
+	assert.Equal(t, io.EOF, err)
+	srv.AssertExpectations(t)
+}
+
+func TestRedactedDeliver(t *testing.T) {
+	block := &common.Block{
+		Header:   &common.BlockHeader{Number: 1, DataHash: []byte("datahash")},
+		Data:     &common.BlockData{Data: [][]byte{[]byte("tx1"), []byte("tx2")}},
+		Metadata: &common.BlockMetadata{Metadata: [][]byte{[]byte("signatures")}},
+	}
+	redacted := &common.Block{
+		Header:   block.Header,
+		Data:     &common.BlockData{Data: [][]byte{util.ComputeSHA256([]byte("tx1")), util.ComputeSHA256([]byte("tx2"))}},
+		Metadata: block.Metadata,
+	}
+
+	allowed := true
+	dataPolicyChecker := deliver.PolicyCheckerFunc(func(_ *common.Envelope, channelID string) error {
+		assert.Equal(t, "testChainID", channelID)
+		if !allowed {
+			return errors.New("not a member of the bilateral policy")
+		}
+		return nil
+	})
+
+	srv := &mockDeliverServer{}
+	srv.On("Recv").Return(seekEnvelope(nil), nil)
+	srv.On("Send", &peer.DeliverResponse{Type: &peer.DeliverResponse_Block{Block: block}}).Return(nil).Once()
+	srv.On("Send", &peer.DeliverResponse{Type: &peer.DeliverResponse_Block{Block: redacted}}).Return(nil).Once()
+	sender := &blockResponseSender{Deliver_DeliverServer: srv}
+	receiver := &seekReceiver{Receiver: srv, sender: sender, blockSender: sender, dataPolicyChecker: dataPolicyChecker}
+
+	// the clients satisfying the policy receive the transactions
+	_, err := receiver.Recv()
+	assert.NoError(t, err)
+	assert.False(t, sender.data.redacted())
+	assert.NoError(t, sender.SendBlockResponse(block))
+
+	// the others receive their hashes
+	allowed = false
+	_, err = receiver.Recv()
+	assert.NoError(t, err)
+	assert.True(t, sender.data.redacted())
+	assert.NoError(t, sender.SendBlockResponse(block))
+	srv.AssertExpectations(t)
+
+	// the block delivered to the other clients is left unchanged
+	assert.Equal(t, [][]byte{[]byte("tx1"), []byte("tx2")}, block.Data.Data)
+	assert.Equal(t, &common.BlockData{}, redactBlock(&common.Block{Header: block.Header}).Data)
+}
+
+func TestRedactedDeliverRevoked(t *testing.T) {
+	block := &common.Block{
+		Header: &common.BlockHeader{Number: 1, DataHash: []byte("datahash")},
+		Data:   &common.BlockData{Data: [][]byte{[]byte("tx1")}},
+	}
+	redacted := redactBlock(block)
+
+	allowed := true
+	dataPolicyChecker := deliver.PolicyCheckerFunc(func(_ *common.Envelope, channelID string) error {
+		if !allowed {
+			return errors.New("not a member of the bilateral policy")
+		}
+		return nil
+	})
+	chain := &mockChainSupport{}
+	chain.On("Sequence").Return(uint64(1)).Times(3)
+	chain.On("Sequence").Return(uint64(2))
+	chainManager := &mockChainManager{}
+	chainManager.On("GetChain", "testChainID").Return(chain, true)
+
+	srv := &mockDeliverServer{}
+	srv.On("Recv").Return(seekEnvelope(nil), nil).Once()
+	srv.On("Send", &peer.DeliverResponse{Type: &peer.DeliverResponse_Block{Block: block}}).Return(nil).Twice()
+	srv.On("Send", &peer.DeliverResponse{Type: &peer.DeliverResponse_Block{Block: redacted}}).Return(nil).Once()
+	sender := &blockResponseSender{Deliver_DeliverServer: srv}
+	receiver := &seekReceiver{Receiver: srv, sender: sender, blockSender: sender, dataPolicyChecker: dataPolicyChecker, chains: chainManager}
+
+	_, err := receiver.Recv()
+	assert.NoError(t, err)
+	assert.NoError(t, sender.SendBlockResponse(block))
+
+	// the policy is evaluated again once the config of the channel changes
+	allowed = false
+	assert.NoError(t, sender.SendBlockResponse(block))
+	assert.NoError(t, sender.SendBlockResponse(block))
+	srv.AssertExpectations(t)
+	chain.AssertExpectations(t)
+}
+
+func TestAcknowledgeDelivery(t *testing.T) {
+	viper.Set("peer.authentication.timewindow", "1s")
+	tempDir, err := ioutil.TempDir("", "delivercursors")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	cursors := NewDeliverCursorStore(tempDir)
+	defer cursors.Close()
+
+	reader := &mockReader{}
+	reader.On("Height").Return(uint64(10))
+	chain := &mockChainSupport{}
+	chain.On("Reader").Return(reader)
+	chainManager := &mockChainManager{}
+	chainManager.On("GetChain", "testChainID").Return(chain, true)
+	chainManager.On("GetChain", mock.Anything).Return(chain, false)
+
+	var policyErr error
+	policyCheckerProvider := func(_ string) deliver.PolicyCheckerFunc {
+		return func(_ *common.Envelope, _ string) error {
+			return policyErr
+		}
+	}
+
+	ack := func(channelID string, cursor *peer.DeliverCursor) common.Status {
+		env := &common.Envelope{Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{
+				ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+					ChannelId: channelID,
+					Timestamp: util.CreateUtcTimestamp(),
+				}),
+				SignatureHeader: utils.MarshalOrPanic(&common.SignatureHeader{Creator: []byte("creator")}),
+			},
+			Data: utils.MarshalOrPanic(cursor),
+		})}
+		server := NewDeliverEventsServer(false, policyCheckerProvider, chainManager, cursors)
+		resp, err := server.AcknowledgeDelivery(context.Background(), env)
+		assert.NoError(t, err)
+		return resp.GetStatus()
+	}
+
+	assert.Equal(t, common.Status_SUCCESS, ack("testChainID", &peer.DeliverCursor{ConsumerName: "myconsumer", BlockNumber: 5}))
+	blockNumber, exists, err := cursors.Cursor("testChainID", []byte("creator"), "myconsumer")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, uint64(5), blockNumber)
+
+	assert.Equal(t, common.Status_BAD_REQUEST, ack("testChainID", &peer.DeliverCursor{ConsumerName: "myconsumer", BlockNumber: 10}))
+	assert.Equal(t, common.Status_BAD_REQUEST, ack("testChainID", &peer.DeliverCursor{BlockNumber: 1}))
+	assert.Equal(t, common.Status_NOT_FOUND, ack("otherChainID", &peer.DeliverCursor{ConsumerName: "myconsumer", BlockNumber: 1}))
+	policyErr = errors.New("access denied")
+	assert.Equal(t, common.Status_FORBIDDEN, ack("testChainID", &peer.DeliverCursor{ConsumerName: "myconsumer", BlockNumber: 1}))
+
+	blockNumber, _, err = cursors.Cursor("testChainID", []byte("creator"), "myconsumer")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), blockNumber)
+
+	server := NewDeliverEventsServer(false, policyCheckerProvider, chainManager, cursors)
+	resp, err := server.AcknowledgeDelivery(context.Background(), &common.Envelope{Payload: []byte("garbage")})
+	assert.NoError(t, err)
+	assert.Equal(t, common.Status_BAD_REQUEST, resp.GetStatus())
+
+	server = NewDeliverEventsServer(false, policyCheckerProvider, chainManager, nil)
+	resp, err = server.AcknowledgeDelivery(context.Background(), &common.Envelope{})
+	assert.NoError(t, err)
+	assert.Equal(t, common.Status_NOT_IMPLEMENTED, resp.GetStatus())
+}
@@ -8,6 +8,7 @@ package peer
 
 import (
 	"fmt"
+	"math"
 	"net"
 	"runtime"
 	"sync"
@@ -62,6 +63,12 @@ type gossipSupport struct {
 	channelconfig.Channel
 }
 
+// Capabilities returns the application capabilities, which gossip is
+// concerned with, rather than the channel ones
+func (gs *gossipSupport) Capabilities() channelconfig.ApplicationCapabilities {
+	return gs.Application.Capabilities()
+}
+
 type chainSupport struct {
 	bundleSource *channelconfig.BundleSource
 	channelconfig.Resources
@@ -116,28 +123,18 @@ func (cs *chainSupport) Apply(configtx *common.ConfigEnvelope) error {
 			return err
 		}
 
-		capabilitiesSupportedOrPanic(bundle)
+		// the block holding the config cannot be committed, so the channel
+		// stalls rather than fork from the peers supporting the capabilities
+		if err := capabilitiesSupported(bundle); err != nil {
+			stallChannel(bundle.ConfigtxValidator().ChainID(), err)
+			return err
+		}
 
 		cs.bundleSource.Update(bundle)
 	}
 	return nil
 }
 
-func capabilitiesSupportedOrPanic(res channelconfig.Resources) {
-	ac, ok := res.ApplicationConfig()
-	if !ok {
-		peerLogger.Panicf("[channel %s] does not have application config so is incompatible", res.ConfigtxValidator().ChainID())
-	}
-
-	if err := ac.Capabilities().Supported(); err != nil {
-		peerLogger.Panicf("[channel %s] incompatible: %s", res.ConfigtxValidator().ChainID(), err)
-	}
-
-	if err := res.ChannelConfig().Capabilities().Supported(); err != nil {
-		peerLogger.Panicf("[channel %s] incompatible: %s", res.ConfigtxValidator().ChainID(), err)
-	}
-}
-
 func (cs *chainSupport) Ledger() ledger.PeerLedger {
 	return cs.ledger
 }
@@ -305,7 +302,10 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block, ccp ccp
 		}
 	}
 
-	capabilitiesSupportedOrPanic(bundle)
+	if err := capabilitiesSupported(bundle); err != nil {
+		stallChannel(cid, err)
+		return err
+	}
 
 	channelconfig.LogSanityChecks(bundle)
 
@@ -429,6 +429,39 @@ func CreateChainFromBlock(cb *common.Block, ccp ccprovider.ChaincodeProvider, sc
 	return createChain(cid, l, cb, ccp, sccp, pluginMapper)
 }
 
+// LeaveChannel stops the chain with chain ID, makes the peer leave the channel
+// in gossip and closes the ledger of the chain. The ledger is kept, archived or
+// deleted as requested by the disposal; a kept ledger is resumed if the peer
+// joins the channel again.
+func LeaveChannel(cid string, disposal ledger.LedgerDisposal) error {
+	chains.Lock()
+	_, ok := chains.list[cid]
+	delete(chains.list, cid)
+	chains.Unlock()
+	if !ok {
+		return errors.Errorf("channel %s not associated with this peer", cid)
+	}
+
+	peerLogger.Infof("Leaving channel [%s]", cid)
+	service.GetGossipService().LeaveChannel(cid)
+
+	TransientStoreFactory.Lock()
+	if store, ok := TransientStoreFactory.stores[cid]; ok && disposal != ledger.KeepLedger {
+		if err := store.PurgeByHeight(math.MaxUint64); err != nil {
+			peerLogger.Warningf("Failed purging the transient store of channel [%s]: %s", cid, err)
+		}
+	}
+	delete(TransientStoreFactory.stores, cid)
+	TransientStoreFactory.Unlock()
+
+	credSupport.Lock()
+	delete(credSupport.AppRootCAsByChain, cid)
+	delete(credSupport.OrdererRootCAsByChain, cid)
+	credSupport.Unlock()
+
+	return ledgermgmt.LeaveLedger(cid, disposal)
+}
+
 // GetLedger returns the ledger of the chain with chain ID. Note that this
 // call returns nil if chain cid has not been created.
 func GetLedger(cid string) ledger.PeerLedger {
@@ -462,6 +495,17 @@ func GetChannelConfig(cid string) channelconfig.Resources {
 	return nil
 }
 
+// GetHashingAlgorithm returns the hashing algorithm of the chain with channel ID. Note that this
+// call returns nil if chain cid has not been created.
+func GetHashingAlgorithm(cid string) func([]byte) []byte {
+	chains.RLock()
+	defer chains.RUnlock()
+	if c, ok := chains.list[cid]; ok {
+		return c.cs.ChannelConfig().HashingAlgorithm()
+	}
+	return nil
+}
+
 // GetPolicyManager returns the policy manager of the chain with chain ID. Note that this
 // call returns nil if chain cid has not been created.
 func GetPolicyManager(cid string) policies.Manager {
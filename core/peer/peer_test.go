@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
+	lgr "github.com/hyperledger/fabric/core/ledger"
 	ledgermocks "github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/hyperledger/fabric/core/mocks/ccprovider"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -109,7 +110,7 @@ func TestCreateChainFromBlock(t *testing.T) {
 	msptesttools.LoadMSPSetupForTesting()
 
 	identity, _ := mgmt.GetLocalSigningIdentityOrPanic().Serialize()
-	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager())
+	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager(), nil)
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager())
 	var defaultSecureDialOpts = func() []grpc.DialOption {
 		var dialOpts []grpc.DialOption
@@ -165,6 +166,10 @@ func TestCreateChainFromBlock(t *testing.T) {
 		t.Fatalf("got a bogus block")
 	}
 
+	// Hashing algorithm
+	assert.NotNil(t, GetHashingAlgorithm(testChainID), "failed to get hashing algorithm")
+	assert.Nil(t, GetHashingAlgorithm("BogusChain"), "got a bogus hashing algorithm")
+
 	// Correct PolicyManager
 	pmgr := GetPolicyManager(testChainID)
 	if pmgr == nil {
@@ -192,6 +197,19 @@ func TestCreateChainFromBlock(t *testing.T) {
 		t.Fatalf("incorrect number of channels")
 	}
 
+	// Leave the channel and join it again, resuming the kept ledger
+	genesisBlock, err := GetLedger(testChainID).GetBlockByNumber(0)
+	assert.NoError(t, err)
+	assert.NoError(t, LeaveChannel(testChainID, lgr.KeepLedger))
+	assert.Nil(t, GetLedger(testChainID))
+	assert.Empty(t, GetChannelsInfo())
+	assert.EqualError(t, LeaveChannel(testChainID, lgr.KeepLedger), "channel "+testChainID+" not associated with this peer")
+	otherGenesisBlock, err := configtxtest.MakeGenesisBlock(testChainID)
+	assert.NoError(t, err)
+	assert.Error(t, CreateChainFromBlock(otherGenesisBlock, nil, nil))
+	assert.NoError(t, CreateChainFromBlock(genesisBlock, nil, nil))
+	assert.NotNil(t, GetLedger(testChainID))
+
 	// cleanup the chain referenes to enable execution with -count n
 	chains.Lock()
 	chains.list = map[string]*chain{}
@@ -204,6 +222,9 @@ func TestGetLocalIP(t *testing.T) {
 }
 
 func TestDeliverSupportManager(t *testing.T) {
+	cleanup := setupPeerFS(t)
+	defer cleanup()
+
 	// reset chains for testing
 	MockInitialize()
 
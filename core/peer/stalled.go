@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	commonerrors "github.com/hyperledger/fabric/common/errors"
+	"github.com/hyperledger/fabric/common/metrics"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+const channelStalled = "channel_stalled"
+
+// stalledChannels keeps track of the channels the peer stopped processing,
+// along with the reason why
+var stalledChannels = struct {
+	sync.RWMutex
+	reasons map[string]string
+}{reasons: make(map[string]string)}
+
+// stallChannel records that the peer stopped processing the given channel.
+// The channel is reported by StalledChannels and by the channel_stalled
+// gauge of the peer until the peer is restarted.
+func stallChannel(cid string, reason error) {
+	peerLogger.Errorf("Channel [%s] stalled, the peer stopped processing its blocks: %s", cid, reason)
+	stalledChannels.Lock()
+	stalledChannels.reasons[cid] = reason.Error()
+	stalledChannels.Unlock()
+	metrics.SubScope("peer").Tagged(map[string]string{"channel": cid}).Gauge(channelStalled).Update(1)
+}
+
+// StalledChannels returns the channels the peer stopped processing, ordered
+// by channel ID
+func StalledChannels() []*pb.StalledChannel {
+	stalledChannels.RLock()
+	defer stalledChannels.RUnlock()
+	var channels []*pb.StalledChannel
+	for cid, reason := range stalledChannels.reasons {
+		channels = append(channels, &pb.StalledChannel{ChannelId: cid, Reason: reason})
+	}
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].ChannelId < channels[j].ChannelId
+	})
+	return channels
+}
+
+// capabilitiesSupported returns an UnsupportedCapabilitiesError if the config
+// of the channel requires capabilities the peer does not support
+func capabilitiesSupported(res channelconfig.Resources) error {
+	cid := res.ConfigtxValidator().ChainID()
+	ac, ok := res.ApplicationConfig()
+	if !ok {
+		return &commonerrors.UnsupportedCapabilitiesError{ChainID: cid, Err: errors.New("the channel does not have application config")}
+	}
+	if err := ac.Capabilities().Supported(); err != nil {
+		return &commonerrors.UnsupportedCapabilitiesError{ChainID: cid, Err: err}
+	}
+	if err := res.ChannelConfig().Capabilities().Supported(); err != nil {
+		return &commonerrors.UnsupportedCapabilitiesError{ChainID: cid, Err: err}
+	}
+	return nil
+}
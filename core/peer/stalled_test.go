@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"testing"
+
+	commonerrors "github.com/hyperledger/fabric/common/errors"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	mockconfigtx "github.com/hyperledger/fabric/common/mocks/configtx"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapabilitiesSupported(t *testing.T) {
+	appCapabilities := &mockconfig.MockApplicationCapabilities{}
+	channelCapabilities := &mockconfig.ChannelCapabilities{}
+	res := &mockconfig.Resources{
+		ConfigtxValidatorVal: &mockconfigtx.Validator{ChainIDVal: "mychannel"},
+		ChannelConfigVal:     &mockconfig.Channel{CapabilitiesVal: channelCapabilities},
+	}
+
+	err := capabilitiesSupported(res)
+	assert.EqualError(t, err, "channel [mychannel] requires unsupported capabilities: the channel does not have application config")
+
+	res.ApplicationConfigVal = &mockconfig.MockApplication{CapabilitiesRv: appCapabilities}
+	assert.NoError(t, capabilitiesSupported(res))
+
+	appCapabilities.SupportedRv = errors.New("application capability V9_9 is required but not supported")
+	err = capabilitiesSupported(res)
+	assert.IsType(t, &commonerrors.UnsupportedCapabilitiesError{}, err)
+	assert.EqualError(t, err, "channel [mychannel] requires unsupported capabilities: application capability V9_9 is required but not supported")
+
+	appCapabilities.SupportedRv = nil
+	channelCapabilities.SupportedErr = errors.New("channel capability V9_9 is required but not supported")
+	err = capabilitiesSupported(res)
+	assert.IsType(t, &commonerrors.UnsupportedCapabilitiesError{}, err)
+	assert.EqualError(t, err, "channel [mychannel] requires unsupported capabilities: channel capability V9_9 is required but not supported")
+}
+
+func TestStallChannel(t *testing.T) {
+	defer func() {
+		stalledChannels.Lock()
+		stalledChannels.reasons = make(map[string]string)
+		stalledChannels.Unlock()
+	}()
+	assert.Empty(t, StalledChannels())
+
+	stallChannel("channel2", errors.New("missing capability V9_9"))
+	stallChannel("channel1", errors.New("missing capability V8_8"))
+	assert.Equal(t, []*pb.StalledChannel{
+		{ChannelId: "channel1", Reason: "missing capability V8_8"},
+		{ChannelId: "channel2", Reason: "missing capability V9_9"},
+	}, StalledChannels())
+}
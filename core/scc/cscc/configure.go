@@ -8,7 +8,7 @@ SPDX-License-Identifier: Apache-2.0
 // configuration transactions as the network is being reconfigured. The
 // configuration transactions arrive from the ordering service to the committer
 // who calls this chaincode. The chaincode also provides peer configuration
-// services such as joining a chain or getting configuration data.
+// services such as joining or leaving a chain or getting configuration data.
 package cscc
 
 import (
@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/policy"
@@ -70,9 +71,17 @@ type PeerConfiger struct {
 
 var cnflogger = flogging.MustGetLogger("cscc")
 
+// ledgerDisposals maps the disposals of the ledger accepted by LeaveChain
+var ledgerDisposals = map[string]ledger.LedgerDisposal{
+	"keep":    ledger.KeepLedger,
+	"archive": ledger.ArchiveLedger,
+	"delete":  ledger.DeleteLedger,
+}
+
 // These are function names from Invoke first parameter
 const (
 	JoinChain                string = "JoinChain"
+	LeaveChain               string = "LeaveChain"
 	GetConfigBlock           string = "GetConfigBlock"
 	GetChannels              string = "GetChannels"
 	GetConfigTree            string = "GetConfigTree"
@@ -87,13 +96,16 @@ func (e *PeerConfiger) Init(stub shim.ChaincodeStubInterface) pb.Response {
 
 // Invoke is called for the following:
 // # to process joining a chain (called by app as a transaction proposal)
+// # to process leaving a chain (called by app as a transaction proposal)
 // # to get the current configuration block (called by app)
 // # to update the configuration block (called by committer)
 // Peer calls this function with 2 arguments:
-// # args[0] is the function name, which must be JoinChain, GetConfigBlock or
-// UpdateConfigBlock
+// # args[0] is the function name, which must be JoinChain, LeaveChain,
+// GetConfigBlock or UpdateConfigBlock
 // # args[1] is a configuration Block if args[0] is JoinChain or
 // UpdateConfigBlock; otherwise it is the chain id
+// # args[2], if args[0] is LeaveChain, is the optional disposal of the
+// ledger of the chain: keep (the default), archive or delete
 // TODO: Improve the scc interface to avoid marshal/unmarshal args
 func (e *PeerConfiger) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
@@ -162,6 +174,24 @@ func (e *PeerConfiger) InvokeNoShim(args [][]byte, sp *pb.SignedProposal) pb.Res
 		}
 
 		return joinChain(cid, block, e.ccp, e.sccp)
+	case LeaveChain:
+		cid := string(args[1])
+		disposal := ledger.KeepLedger
+		if len(args) > 2 {
+			var ok bool
+			if disposal, ok = ledgerDisposals[string(args[2])]; !ok {
+				return shim.Error(fmt.Sprintf("\"LeaveChain\" for chainID = %s failed because of "+
+					"invalid ledger disposal [%s]", cid, args[2]))
+			}
+		}
+
+		// check local MSP Admins policy
+		// TODO: move to ACLProvider once it will support chainless ACLs
+		if err = e.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s][%s]: [%s]", fname, cid, err))
+		}
+
+		return leaveChain(cid, disposal)
 	case GetConfigBlock:
 		// 2. check policy
 		if err = e.aclProvider.CheckACL(resources.Cscc_GetConfigBlock, string(args[1]), sp); err != nil {
@@ -242,6 +272,16 @@ func joinChain(chainID string, block *common.Block, ccp ccprovider.ChaincodeProv
 	return shim.Success(nil)
 }
 
+// leaveChain makes the peer leave the specified chain, disposing of its
+// ledger as requested
+func leaveChain(chainID string, disposal ledger.LedgerDisposal) pb.Response {
+	if err := peer.LeaveChannel(chainID, disposal); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(nil)
+}
+
 // Return the current configuration block for the specified chainID. If the
 // peer doesn't belong to the chain, return error
 func getConfigBlock(chainID []byte) pb.Response {
@@ -262,7 +262,7 @@ func TestConfigerInvokeJoinChainCorrectParams(t *testing.T) {
 	)
 
 	identity, _ := mgmt.GetLocalSigningIdentityOrPanic().Serialize()
-	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager())
+	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager(), nil)
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager())
 	err := service.InitGossipServiceCustomDeliveryFactory(identity, peerEndpoint, nil, nil, &mockDeliveryClientFactory{}, messageCryptoService, secAdv, nil)
 	assert.NoError(t, err)
@@ -351,6 +351,34 @@ func TestConfigerInvokeJoinChainCorrectParams(t *testing.T) {
 	if len(cqr.GetChannels()) != 1 {
 		t.FailNow()
 	}
+
+	// Try fail path with an invalid ledger disposal
+	args = [][]byte{[]byte(LeaveChain), []byte(chainID), []byte("shred")}
+	res = stub.MockInvokeWithSignedProposal("4", args, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "invalid ledger disposal [shred]")
+
+	// This call must fail
+	sProp.Signature = nil
+	args = [][]byte{[]byte(LeaveChain), []byte(chainID), []byte("delete")}
+	res = stub.MockInvokeWithSignedProposal("4", args, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "access denied for [LeaveChain][mytestchainid]")
+	sProp.Signature = sProp.ProposalBytes
+
+	// Leave the channel, which is no longer listed
+	res = stub.MockInvokeWithSignedProposal("4", args, sProp)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	res = stub.MockInvokeWithSignedProposal("5", [][]byte{[]byte(GetChannels)}, sProp)
+	assert.Equal(t, int32(shim.OK), res.Status)
+	cqr = &pb.ChannelQueryResponse{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, cqr))
+	assert.Empty(t, cqr.GetChannels())
+
+	// The peer cannot leave a channel it is not in
+	res = stub.MockInvokeWithSignedProposal("6", args, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "channel mytestchainid not associated with this peer")
 }
 
 func TestGetConfigTree(t *testing.T) {
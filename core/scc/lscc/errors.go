@@ -85,6 +85,13 @@ func (f InvalidStatedbArtifactsErr) Error() string {
 	return fmt.Sprintf("invalid state database artifact: %s", string(f))
 }
 
+//InstallVetoedErr chaincode installation vetoed by an install validator error
+type InstallVetoedErr string
+
+func (f InstallVetoedErr) Error() string {
+	return fmt.Sprintf("chaincode installation vetoed: %s", string(f))
+}
+
 //ChaincodeMismatchErr chaincode name from two places don't match
 type ChaincodeMismatchErr string
 
@@ -140,3 +147,24 @@ type PrivateChannelDataNotAvailable string
 func (f PrivateChannelDataNotAvailable) Error() string {
 	return "as V1_2 or later capability is not enabled, private channel collections and data are not available"
 }
+
+// ChaincodeRetirementNotAvailable when V1_3_CHAINCODE_RETIREMENT capability is not enabled
+type ChaincodeRetirementNotAvailable string
+
+func (f ChaincodeRetirementNotAvailable) Error() string {
+	return "as V1_3_CHAINCODE_RETIREMENT capability is not enabled, chaincodes cannot be retired"
+}
+
+// ChannelConfigPolicyReferenceNotAvailable when V1_3_CHANNEL_CONFIG_POLICY_REFERENCE capability is not enabled
+type ChannelConfigPolicyReferenceNotAvailable string
+
+func (f ChannelConfigPolicyReferenceNotAvailable) Error() string {
+	return "as V1_3_CHANNEL_CONFIG_POLICY_REFERENCE capability is not enabled, endorsement policies cannot reference channel config policies"
+}
+
+// CollectionEndorsementPolicyNotAvailable when V1_3_COLLECTION_ENDORSEMENT_POLICY capability is not enabled
+type CollectionEndorsementPolicyNotAvailable string
+
+func (f CollectionEndorsementPolicyNotAvailable) Error() string {
+	return "as V1_3_COLLECTION_ENDORSEMENT_POLICY capability is not enabled, collections cannot define endorsement policies"
+}
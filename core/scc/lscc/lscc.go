@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/core/handlers/installation"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/peer"
@@ -39,6 +40,7 @@ import (
 //on this peer. It manages chaincodes via Invoke proposals.
 //     "Args":["deploy",<ChaincodeDeploymentSpec>]
 //     "Args":["upgrade",<ChaincodeDeploymentSpec>]
+//     "Args":["retire",<chainname>,<chaincodename>,["archive"]]
 //     "Args":["stop",<ChaincodeInvocationSpec>]
 //     "Args":["start",<ChaincodeInvocationSpec>]
 
@@ -56,6 +58,12 @@ const (
 	// UPGRADE upgrade chaincode
 	UPGRADE = "upgrade"
 
+	// RETIRE retire chaincode
+	RETIRE = "retire"
+
+	// ARCHIVE is the optional argument of RETIRE archiving the state of the chaincode
+	ARCHIVE = "archive"
+
 	// CCEXISTS get chaincode
 	CCEXISTS = "getid"
 
@@ -139,6 +147,10 @@ type LifeCycleSysCC struct {
 	Support FilesystemSupport
 
 	PlatformRegistry *platforms.Registry
+
+	// InstallValidators inspect the chaincode packages installed,
+	// and can veto their installation
+	InstallValidators []installation.Validator
 }
 
 // New creates a new instance of the LSCC
@@ -180,11 +192,15 @@ func (lscc *LifeCycleSysCC) ChaincodeContainerInfo(channelID, ccName string) (*c
 	// Note, although it looks very tempting to replace the bulk of this function with
 	// the below 'ChaincodeDefinition' call, the 'getCCCode' call provides us security
 	// by side-effect, so we must leave it as is for now.
-	cds, _, err := lscc.getCCCode(ccName, chaincodeDataBytes)
+	cds, cd, err := lscc.getCCCodeAndData(ccName, chaincodeDataBytes)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not get chaincode code")
 	}
 
+	if cd.Retired {
+		return nil, ccprovider.ChaincodeRetiredErr(ccName)
+	}
+
 	return ccprovider.DeploymentSpecToChaincodeContainerInfo(cds), nil
 }
 
@@ -204,6 +220,10 @@ func (lscc *LifeCycleSysCC) ChaincodeDefinition(chaincodeName string, txsim ledg
 		return nil, errors.Wrapf(err, "chaincode %s has bad definition", chaincodeName)
 	}
 
+	if chaincodeData.Retired {
+		return nil, ccprovider.ChaincodeRetiredErr(chaincodeName)
+	}
+
 	return chaincodeData, nil
 }
 
@@ -246,6 +266,9 @@ func checkCollectionMemberPolicy(collectionConfig *common.CollectionConfig, mspm
 	if coll.MemberOrgsPolicy.GetSignaturePolicy() == nil {
 		return fmt.Errorf("collection member org policy is empty")
 	}
+	if coll.EndorsementPolicy != nil && coll.EndorsementPolicy.GetSignaturePolicy() == nil {
+		return fmt.Errorf("collection endorsement policy is empty")
+	}
 	// make sure that the orgs listed are actually part of the channel
 	// check all principals in the signature policy
 	for _, principal := range coll.MemberOrgsPolicy.GetSignaturePolicy().Identities {
@@ -304,6 +327,21 @@ func checkCollectionMemberPolicy(collectionConfig *common.CollectionConfig, mspm
 	return nil
 }
 
+// hasCollectionEndorsementPolicy returns true if a collection of the
+// supplied collection configuration defines an endorsement policy
+func hasCollectionEndorsementPolicy(collectionConfigBytes []byte) bool {
+	collections := &common.CollectionConfigPackage{}
+	if err := proto.Unmarshal(collectionConfigBytes, collections); err != nil {
+		return false
+	}
+	for _, collectionConfig := range collections.Config {
+		if collectionConfig.GetStaticCollectionConfig().GetEndorsementPolicy() != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // putChaincodeCollectionData adds collection data for the chaincode
 func (lscc *LifeCycleSysCC) putChaincodeCollectionData(stub shim.ChaincodeStubInterface, cd *ccprovider.ChaincodeData, collectionConfigBytes []byte) error {
 	if cd == nil {
@@ -391,23 +429,48 @@ func (lscc *LifeCycleSysCC) getCCCode(ccname string, cdbytes []byte) (*pb.Chainc
 		return nil, nil, err
 	}
 
-	ccpack, err := lscc.Support.GetChaincodeFromLocalStorage(ccname, cd.Version)
+	ccpack, err := lscc.getCCPackage(cd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	//these are guaranteed to be non-nil because we got a valid ccpack
+	depspec := ccpack.GetDepSpec()
+	depspecbytes := ccpack.GetDepSpecBytes()
+
+	return depspec, depspecbytes, nil
+}
+
+//same as getCCCode, but returns the chaincode data instead of the deployment spec bytes
+func (lscc *LifeCycleSysCC) getCCCodeAndData(ccname string, cdbytes []byte) (*pb.ChaincodeDeploymentSpec, *ccprovider.ChaincodeData, error) {
+	cd, err := lscc.getChaincodeData(ccname, cdbytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ccpack, err := lscc.getCCPackage(cd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ccpack.GetDepSpec(), cd, nil
+}
+
+//gets the chaincode package of the cd from the FS
+func (lscc *LifeCycleSysCC) getCCPackage(cd *ccprovider.ChaincodeData) (ccprovider.CCPackage, error) {
+	ccpack, err := lscc.Support.GetChaincodeFromLocalStorage(cd.Name, cd.Version)
 	if err != nil {
-		return nil, nil, InvalidDeploymentSpecErr(err.Error())
+		return nil, InvalidDeploymentSpecErr(err.Error())
 	}
 
 	//this is the big test and the reason every launch should go through
 	//getChaincode call. We validate the chaincode entry against the
 	//the chaincode in FS
 	if err = ccpack.ValidateCC(cd); err != nil {
-		return nil, nil, InvalidCCOnFSError(err.Error())
+		return nil, InvalidCCOnFSError(err.Error())
 	}
 
-	//these are guaranteed to be non-nil because we got a valid ccpack
-	depspec := ccpack.GetDepSpec()
-	depspecbytes := ccpack.GetDepSpecBytes()
-
-	return depspec, depspecbytes, nil
+	return ccpack, nil
 }
 
 // getChaincodes returns all chaincodes instantiated on this LSCC's channel
@@ -580,6 +643,10 @@ func (lscc *LifeCycleSysCC) executeInstall(stub shim.ChaincodeStubInterface, ccb
 		return errors.Errorf("cannot install: %s is the name of a system chaincode", cds.ChaincodeSpec.ChaincodeId.Name)
 	}
 
+	if err = installation.Validate(cds, lscc.InstallValidators...); err != nil {
+		return InstallVetoedErr(err.Error())
+	}
+
 	// Get any statedb artifacts from the chaincode package, e.g. couchdb index definitions
 	statedbArtifactsTar, err := ccprovider.ExtractStatedbArtifactsFromCCPackage(ccpack, lscc.PlatformRegistry)
 	if err != nil {
@@ -728,6 +795,11 @@ func (lscc *LifeCycleSysCC) executeUpgrade(stub shim.ChaincodeStubInterface, cha
 		return nil, err
 	}
 
+	//do not upgrade a retired chaincode
+	if cdLedger.Retired {
+		return nil, ccprovider.ChaincodeRetiredErr(chaincodeName)
+	}
+
 	//do not upgrade if same version
 	if cdLedger.Version == cds.ChaincodeSpec.ChaincodeId.Version {
 		return nil, IdenticalVersionErr(chaincodeName)
@@ -789,6 +861,50 @@ func (lscc *LifeCycleSysCC) executeUpgrade(stub shim.ChaincodeStubInterface, cha
 	return cdfs, nil
 }
 
+// executeRetire implements the "retire" Invoke transaction. The retired
+// chaincode keeps its entry in the LSCC, so that it cannot be deployed
+// again under the same name
+func (lscc *LifeCycleSysCC) executeRetire(stub shim.ChaincodeStubInterface, chainName string, chaincodeName string, archive bool) (*ccprovider.ChaincodeData, error) {
+	cdbytes, err := lscc.getCCInstance(stub, chaincodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	cdLedger, err := lscc.getChaincodeData(chaincodeName, cdbytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if cdLedger.Retired {
+		return nil, ccprovider.ChaincodeRetiredErr(chaincodeName)
+	}
+
+	//do not retire if instantiation policy is violated
+	if cdLedger.InstantiationPolicy == nil {
+		return nil, InstantiationPolicyMissing("")
+	}
+	signedProp, err := stub.GetSignedProposal()
+	if err != nil {
+		return nil, err
+	}
+	err = lscc.Support.CheckInstantiationPolicy(signedProp, chainName, cdLedger.InstantiationPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	cdLedger.Retired = true
+	cdLedger.ArchiveState = archive
+	err = lscc.putChaincodeData(stub, cdLedger)
+	if err != nil {
+		return nil, err
+	}
+
+	lifecycleEvent := &pb.LifecycleEvent{ChaincodeName: chaincodeName}
+	lifecycleEventBytes := utils.MarshalOrPanic(lifecycleEvent)
+	stub.SetEvent(RETIRE, lifecycleEventBytes)
+	return cdLedger, nil
+}
+
 //-------------- the chaincode stub interface implementation ----------
 
 //Init is mostly useless for SCC
@@ -796,7 +912,7 @@ func (lscc *LifeCycleSysCC) Init(stub shim.ChaincodeStubInterface) pb.Response {
 	return shim.Success(nil)
 }
 
-// Invoke implements lifecycle functions "deploy", "start", "stop", "upgrade".
+// Invoke implements lifecycle functions "deploy", "start", "stop", "upgrade", "retire".
 // Deploy's arguments -  {[]byte("deploy"), []byte(<chainname>), <unmarshalled pb.ChaincodeDeploymentSpec>}
 //
 // Invoke also implements some query-like functions
@@ -869,13 +985,17 @@ func (lscc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 		}
 
 		// optional arguments here (they can each be nil and may or may not be present)
-		// args[3] is a marshalled SignaturePolicyEnvelope representing the endorsement policy
+		// args[3] is a marshalled SignaturePolicyEnvelope representing the endorsement policy,
+		//         or a marshalled ApplicationPolicy referencing a channel config policy
 		// args[4] is the name of escc
 		// args[5] is the name of vscc
 		// args[6] is a marshalled CollectionConfigPackage struct
 		var EP []byte
 		if len(args) > 3 && len(args[3]) > 0 {
 			EP = args[3]
+			if _, isReference := ccprovider.ChannelConfigPolicyReference(EP); isReference && !ac.Capabilities().ChannelConfigPolicyReference() {
+				return shim.Error(ChannelConfigPolicyReferenceNotAvailable("").Error())
+			}
 		} else {
 			p := cauthdsl.SignedByAnyMember(peer.GetMSPIDs(channel))
 			EP, err = utils.Marshal(p)
@@ -903,6 +1023,9 @@ func (lscc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 		// we Support the PrivateChannelData capability
 		if ac.Capabilities().PrivateChannelData() && len(args) > 6 {
 			collectionsConfig = args[6]
+			if !ac.Capabilities().CollectionEndorsementPolicy() && hasCollectionEndorsementPolicy(collectionsConfig) {
+				return shim.Error(CollectionEndorsementPolicyNotAvailable("").Error())
+			}
 		}
 
 		cd, err := lscc.executeDeployOrUpgrade(stub, channel, cds, EP, escc, vscc, collectionsConfig, function)
@@ -914,6 +1037,46 @@ func (lscc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 			return shim.Error(err.Error())
 		}
 		return shim.Success(cdbytes)
+	case RETIRE:
+		// we expect the function name, the chain name, the chaincode
+		// name and optionally the archive argument
+		if len(args) != 3 && len(args) != 4 {
+			return shim.Error(InvalidArgsLenErr(len(args)).Error())
+		}
+
+		channel := string(args[1])
+		ccname := string(args[2])
+
+		if !lscc.isValidChannelName(channel) {
+			return shim.Error(InvalidChannelNameErr(channel).Error())
+		}
+
+		ac, exists := lscc.SCCProvider.GetApplicationConfig(channel)
+		if !exists {
+			logger.Panicf("programming error, non-existent appplication config for channel '%s'", channel)
+		}
+
+		if !ac.Capabilities().ChaincodeRetirement() {
+			return shim.Error(ChaincodeRetirementNotAvailable("").Error())
+		}
+
+		archive := false
+		if len(args) == 4 {
+			if string(args[3]) != ARCHIVE {
+				return shim.Error(fmt.Sprintf("invalid argument to %s: %s", function, string(args[3])))
+			}
+			archive = true
+		}
+
+		cd, err := lscc.executeRetire(stub, channel, ccname, archive)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		cdbytes, err := proto.Marshal(cd)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(cdbytes)
 	case CCEXISTS, CHAINCODEEXISTS, GETDEPSPEC, GETDEPLOYMENTSPEC, GETCCDATA, GETCHAINCODEDATA:
 		if len(args) != 3 {
 			return shim.Error(InvalidArgsLenErr(len(args)).Error())
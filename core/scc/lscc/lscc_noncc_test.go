@@ -129,6 +129,18 @@ var _ = Describe("LSCC", func() {
 				Expect(err).To(MatchError("chaincode chaincode-data-name not found on channel channel-foo"))
 			})
 		})
+
+		Context("when the chaincode is retired", func() {
+			BeforeEach(func() {
+				ccData.Retired = true
+				fakeQueryExecutor.GetStateReturns(proto.Marshal(ccData))
+			})
+
+			It("returns an error", func() {
+				_, err := l.ChaincodeContainerInfo("channel-foo", "chaincode-data-name")
+				Expect(err).To(Equal(ccprovider.ChaincodeRetiredErr("chaincode-data-name")))
+			})
+		})
 	})
 
 	Describe("ChaincodeDefinition", func() {
@@ -180,5 +192,17 @@ var _ = Describe("LSCC", func() {
 				Expect(err).To(MatchError(MatchRegexp("chaincode cc-name has bad definition: proto:.*")))
 			})
 		})
+
+		Context("when the chaincode is retired", func() {
+			BeforeEach(func() {
+				ccData.Retired = true
+				fakeQueryExecutor.GetStateReturns(proto.Marshal(ccData))
+			})
+
+			It("returns an error", func() {
+				_, err := l.ChaincodeDefinition("cc-name", fakeQueryExecutor)
+				Expect(err).To(Equal(ccprovider.ChaincodeRetiredErr("cc-name")))
+			})
+		})
 	})
 })
@@ -28,6 +28,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	cutil "github.com/hyperledger/fabric/core/container/util"
+	"github.com/hyperledger/fabric/core/handlers/installation"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/mocks/scc/lscc"
 	"github.com/hyperledger/fabric/core/policy"
@@ -142,12 +143,32 @@ func TestInstall(t *testing.T) {
 	testInstall(t, "example02-2", "1.0-alpha+001", path, false, "", "Alice", scc, stub)
 	testInstall(t, "example02-2", "1.0+sha.c0ffee", path, false, "", "Alice", scc, stub)
 
+	accepting := &mockInstallValidator{}
+	vetoing := &mockInstallValidator{err: errors.New("strconv is banned")}
+	scc.InstallValidators = []installation.Validator{accepting, vetoing}
+	testInstall(t, "example02", "0", path, false, InstallVetoedErr("strconv is banned").Error(), "Alice", scc, stub)
+	assert.Equal(t, "example02", accepting.validated.ChaincodeSpec.ChaincodeId.Name)
+	assert.Equal(t, "example02", vetoing.validated.ChaincodeSpec.ChaincodeId.Name)
+	scc.InstallValidators = []installation.Validator{accepting}
+	testInstall(t, "example02", "0", path, false, "", "Alice", scc, stub)
+	scc.InstallValidators = nil
+
 	scc.Support.(*lscc.MockSupport).PutChaincodeToLocalStorageErr = errors.New("barf")
 
 	testInstall(t, "example02", "0", path, false, "barf", "Alice", scc, stub)
 	testInstall(t, "lscc", "0", path, false, "cannot install: lscc is the name of a system chaincode", "Alice", scc, stub)
 }
 
+type mockInstallValidator struct {
+	err       error
+	validated *pb.ChaincodeDeploymentSpec
+}
+
+func (v *mockInstallValidator) Validate(cds *pb.ChaincodeDeploymentSpec) error {
+	v.validated = cds
+	return v.err
+}
+
 func testInstall(t *testing.T, ccname string, version string, path string, createInvalidIndex bool, expectedErrorMsg string, caller string, scc *LifeCycleSysCC, stub *shim.MockStub) {
 	identityDeserializer := &policymocks.MockIdentityDeserializer{
 		Identity: []byte("Alice"),
@@ -312,6 +333,48 @@ func TestDeploy(t *testing.T) {
 	assert.Equal(t, 1, len(stub.State))
 	_, ok = stub.State["example02"]
 	assert.Equal(t, true, ok)
+
+	coll1.GetStaticCollectionConfig().EndorsementPolicy = &common.CollectionPolicyConfig{
+		Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+			SignaturePolicy: policyEnvelope,
+		},
+	}
+	ccpBytes, err = proto.Marshal(ccp)
+	assert.NoError(t, err)
+
+	scc = New(mocksccProvider, mockAclProvider, platforms.NewRegistry(&golang.Platform{}))
+	scc.Support = &lscc.MockSupport{}
+	stub = shim.NewMockStub("lscc", scc)
+	res = stub.MockInit("1", nil)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	// As the CollectionEndorsementPolicy is disabled, collections defining an
+	// endorsement policy are rejected
+	testDeploy(t, "example02", "1.0", path, false, false, true, CollectionEndorsementPolicyNotAvailable("").Error(), scc, stub, ccpBytes)
+	assert.Equal(t, 0, len(stub.State))
+
+	// Enable CollectionEndorsementPolicy
+	mocksccProvider = (&mscc.MocksccProviderFactory{
+		ApplicationConfigBool: true,
+		ApplicationConfigRv: &config.MockApplication{
+			CapabilitiesRv: &config.MockApplicationCapabilities{
+				PrivateChannelDataRv:    true,
+				CollectionEndorsementRv: true,
+			},
+		},
+	}).NewSystemChaincodeProvider().(*mscc.MocksccProviderImpl)
+
+	scc = New(mocksccProvider, mockAclProvider, platforms.NewRegistry(&golang.Platform{}))
+	scc.Support = &lscc.MockSupport{}
+	stub = shim.NewMockStub("lscc", scc)
+	res = stub.MockInit("1", nil)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	testDeploy(t, "example02", "1.0", path, false, false, true, "", scc, stub, ccpBytes)
+	assert.Equal(t, 2, len(stub.State))
+	actualccpBytes, ok = stub.State["example02~collection"]
+	assert.Equal(t, true, ok)
+	assert.Equal(t, ccpBytes, actualccpBytes)
 }
 
 func createCollectionConfig(collectionName string, signaturePolicyEnvelope *common.SignaturePolicyEnvelope,
@@ -634,6 +697,119 @@ func testUpgrade(t *testing.T, ccname string, version string, newccname string,
 	}
 }
 
+// TestRetire tests the retire function with various inputs for basic use cases
+func TestRetire(t *testing.T) {
+	path := "github.com/hyperledger/fabric/examples/chaincode/go/example02/cmd"
+
+	newLSCC := func(retirement bool) (*LifeCycleSysCC, *shim.MockStub) {
+		mocksccProvider := (&mscc.MocksccProviderFactory{
+			ApplicationConfigBool: true,
+			ApplicationConfigRv: &config.MockApplication{
+				CapabilitiesRv: &config.MockApplicationCapabilities{
+					ChaincodeRetirementRv: retirement,
+				},
+			},
+		}).NewSystemChaincodeProvider().(*mscc.MocksccProviderImpl)
+		scc := New(mocksccProvider, mockAclProvider, platforms.NewRegistry(&golang.Platform{}))
+		scc.Support = &lscc.MockSupport{}
+		stub := shim.NewMockStub("lscc", scc)
+		res := stub.MockInit("1", nil)
+		assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+		scc.Support.(*lscc.MockSupport).GetInstantiationPolicyRv = []byte("instantiation policy")
+
+		cds, err := constructDeploymentSpec("example02", path, "0", [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}, false, true, scc)
+		assert.NoError(t, err)
+		sProp, _ := putils.MockSignedEndorserProposal2OrPanic(chainid, &pb.ChaincodeSpec{}, id)
+		res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("deploy"), []byte("test"), utils.MarshalOrPanic(cds)}, sProp)
+		assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+		return scc, stub
+	}
+	sProp, _ := putils.MockSignedEndorserProposal2OrPanic(chainid, &pb.ChaincodeSpec{}, id)
+
+	scc, stub := newLSCC(false)
+	res := stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("retire"), []byte("test"), []byte("example02")}, sProp)
+	assert.Equal(t, ChaincodeRetirementNotAvailable("").Error(), res.Message)
+
+	scc, stub = newLSCC(true)
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("retire"), []byte("test")}, sProp)
+	assert.Equal(t, InvalidArgsLenErr(2).Error(), res.Message)
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("retire"), []byte(""), []byte("example02")}, sProp)
+	assert.Equal(t, InvalidChannelNameErr("").Error(), res.Message)
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("retire"), []byte("test"), []byte("example02"), []byte("barf")}, sProp)
+	assert.Equal(t, "invalid argument to retire: barf", res.Message)
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("retire"), []byte("test"), []byte("example03")}, sProp)
+	assert.Equal(t, NotFoundErr("example03").Error(), res.Message)
+
+	scc.Support.(*lscc.MockSupport).CheckInstantiationPolicyErr = errors.New("barf")
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("retire"), []byte("test"), []byte("example02")}, sProp)
+	assert.Equal(t, "barf", res.Message)
+	scc.Support.(*lscc.MockSupport).CheckInstantiationPolicyErr = nil
+
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("retire"), []byte("test"), []byte("example02"), []byte("archive")}, sProp)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	cd := &ccprovider.ChaincodeData{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, cd))
+	assert.True(t, cd.Retired)
+	assert.True(t, cd.ArchiveState)
+	assert.Equal(t, "0", cd.Version)
+	chaincodeEvent := <-stub.ChaincodeEventsChannel
+	assert.Equal(t, "retire", chaincodeEvent.EventName)
+
+	// a retired chaincode can neither be retired again, upgraded nor deployed again
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("retire"), []byte("test"), []byte("example02")}, sProp)
+	assert.Equal(t, ccprovider.ChaincodeRetiredErr("example02").Error(), res.Message)
+	cds, err := constructDeploymentSpec("example02", path, "1", [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}, false, true, scc)
+	assert.NoError(t, err)
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("upgrade"), []byte("test"), utils.MarshalOrPanic(cds)}, sProp)
+	assert.Equal(t, ccprovider.ChaincodeRetiredErr("example02").Error(), res.Message)
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("deploy"), []byte("test"), utils.MarshalOrPanic(cds)}, sProp)
+	assert.Equal(t, ExistsErr("example02").Error(), res.Message)
+}
+
+// TestChannelConfigPolicyReference tests the deployment of chaincodes whose
+// endorsement policy references a channel config policy
+func TestChannelConfigPolicyReference(t *testing.T) {
+	path := "github.com/hyperledger/fabric/examples/chaincode/go/example02/cmd"
+	ep, err := ccprovider.MarshalChannelConfigPolicyReference("/Channel/Application/Endorsement")
+	assert.NoError(t, err)
+
+	newLSCC := func(policyReference bool) (*LifeCycleSysCC, *shim.MockStub) {
+		mocksccProvider := (&mscc.MocksccProviderFactory{
+			ApplicationConfigBool: true,
+			ApplicationConfigRv: &config.MockApplication{
+				CapabilitiesRv: &config.MockApplicationCapabilities{
+					ChannelConfigPolicyRefRv: policyReference,
+				},
+			},
+		}).NewSystemChaincodeProvider().(*mscc.MocksccProviderImpl)
+		scc := New(mocksccProvider, mockAclProvider, platforms.NewRegistry(&golang.Platform{}))
+		scc.Support = &lscc.MockSupport{}
+		stub := shim.NewMockStub("lscc", scc)
+		res := stub.MockInit("1", nil)
+		assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+		return scc, stub
+	}
+	sProp, _ := putils.MockSignedEndorserProposal2OrPanic(chainid, &pb.ChaincodeSpec{}, id)
+
+	scc, stub := newLSCC(false)
+	cds, err := constructDeploymentSpec("example02", path, "0", [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}, false, true, scc)
+	assert.NoError(t, err)
+	res := stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("deploy"), []byte("test"), utils.MarshalOrPanic(cds), ep}, sProp)
+	assert.Equal(t, ChannelConfigPolicyReferenceNotAvailable("").Error(), res.Message)
+
+	scc, stub = newLSCC(true)
+	cds, err = constructDeploymentSpec("example02", path, "0", [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}, false, true, scc)
+	assert.NoError(t, err)
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("deploy"), []byte("test"), utils.MarshalOrPanic(cds), ep}, sProp)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	cd := &ccprovider.ChaincodeData{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, cd))
+	assert.Equal(t, ep, cd.Policy)
+	policyName, isReference := ccprovider.ChannelConfigPolicyReference(cd.Policy)
+	assert.True(t, isReference)
+	assert.Equal(t, "/Channel/Application/Endorsement", policyName)
+}
+
 func TestFunctionsWithAliases(t *testing.T) {
 	scc := New(NewMockProvider(), mockAclProvider, platforms.NewRegistry(&golang.Platform{}))
 	scc.Support = &lscc.MockSupport{}
@@ -1003,6 +1179,25 @@ func TestCheckCollectionMemberPolicy(t *testing.T) {
 	err = checkCollectionMemberPolicy(cc, mgr)
 	assert.Error(t, err)
 
+	// error case: endorsement policy config empty
+	cc = &common.CollectionConfig{
+		Payload: &common.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: &common.StaticCollectionConfig{
+				Name: "mycollection",
+				MemberOrgsPolicy: &common.CollectionPolicyConfig{
+					Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+						SignaturePolicy: &common.SignaturePolicyEnvelope{},
+					},
+				},
+				EndorsementPolicy: &common.CollectionPolicyConfig{
+					Payload: &common.CollectionPolicyConfig_SignaturePolicy{},
+				},
+			},
+		},
+	}
+	err = checkCollectionMemberPolicy(cc, mgr)
+	assert.EqualError(t, err, "collection endorsement policy is empty")
+
 	// valid case: member org policy empty
 	cc = &common.CollectionConfig{
 		Payload: &common.CollectionConfig_StaticCollectionConfig{
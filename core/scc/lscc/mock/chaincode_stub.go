@@ -6,6 +6,7 @@ import (
 
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
@@ -156,6 +157,20 @@ type ChaincodeStub struct {
 		result1 shim.StateQueryIteratorInterface
 		result2 error
 	}
+	GetStateByRangeReverseStub        func(startKey, endKey string) (shim.StateQueryIteratorInterface, error)
+	getStateByRangeReverseMutex       sync.RWMutex
+	getStateByRangeReverseArgsForCall []struct {
+		startKey string
+		endKey   string
+	}
+	getStateByRangeReverseReturns struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}
+	getStateByRangeReverseReturnsOnCall map[int]struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}
 	GetStateByRangeWithPaginationStub        func(startKey, endKey string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error)
 	getStateByRangeWithPaginationMutex       sync.RWMutex
 	getStateByRangeWithPaginationArgsForCall []struct {
@@ -188,6 +203,20 @@ type ChaincodeStub struct {
 		result1 shim.StateQueryIteratorInterface
 		result2 error
 	}
+	GetStateByPartialCompositeKeyReverseStub        func(objectType string, keys []string) (shim.StateQueryIteratorInterface, error)
+	getStateByPartialCompositeKeyReverseMutex       sync.RWMutex
+	getStateByPartialCompositeKeyReverseArgsForCall []struct {
+		objectType string
+		keys       []string
+	}
+	getStateByPartialCompositeKeyReverseReturns struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}
+	getStateByPartialCompositeKeyReverseReturnsOnCall map[int]struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}
 	GetStateByPartialCompositeKeyWithPaginationStub        func(objectType string, keys []string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error)
 	getStateByPartialCompositeKeyWithPaginationMutex       sync.RWMutex
 	getStateByPartialCompositeKeyWithPaginationArgsForCall []struct {
@@ -452,6 +481,17 @@ type ChaincodeStub struct {
 		result1 *timestamp.Timestamp
 		result2 error
 	}
+	GetTxValidityWindowStub        func() (*common.ValidityWindow, error)
+	getTxValidityWindowMutex       sync.RWMutex
+	getTxValidityWindowArgsForCall []struct{}
+	getTxValidityWindowReturns     struct {
+		result1 *common.ValidityWindow
+		result2 error
+	}
+	getTxValidityWindowReturnsOnCall map[int]struct {
+		result1 *common.ValidityWindow
+		result2 error
+	}
 	SetEventStub        func(name string, payload []byte) error
 	setEventMutex       sync.RWMutex
 	setEventArgsForCall []struct {
@@ -1079,6 +1119,58 @@ func (fake *ChaincodeStub) GetStateByRangeReturnsOnCall(i int, result1 shim.Stat
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetStateByRangeReverse(startKey string, endKey string) (shim.StateQueryIteratorInterface, error) {
+	fake.getStateByRangeReverseMutex.Lock()
+	ret, specificReturn := fake.getStateByRangeReverseReturnsOnCall[len(fake.getStateByRangeReverseArgsForCall)]
+	fake.getStateByRangeReverseArgsForCall = append(fake.getStateByRangeReverseArgsForCall, struct {
+		startKey string
+		endKey   string
+	}{startKey, endKey})
+	fake.recordInvocation("GetStateByRangeReverse", []interface{}{startKey, endKey})
+	fake.getStateByRangeReverseMutex.Unlock()
+	if fake.GetStateByRangeReverseStub != nil {
+		return fake.GetStateByRangeReverseStub(startKey, endKey)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getStateByRangeReverseReturns.result1, fake.getStateByRangeReverseReturns.result2
+}
+
+func (fake *ChaincodeStub) GetStateByRangeReverseCallCount() int {
+	fake.getStateByRangeReverseMutex.RLock()
+	defer fake.getStateByRangeReverseMutex.RUnlock()
+	return len(fake.getStateByRangeReverseArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetStateByRangeReverseArgsForCall(i int) (string, string) {
+	fake.getStateByRangeReverseMutex.RLock()
+	defer fake.getStateByRangeReverseMutex.RUnlock()
+	return fake.getStateByRangeReverseArgsForCall[i].startKey, fake.getStateByRangeReverseArgsForCall[i].endKey
+}
+
+func (fake *ChaincodeStub) GetStateByRangeReverseReturns(result1 shim.StateQueryIteratorInterface, result2 error) {
+	fake.GetStateByRangeReverseStub = nil
+	fake.getStateByRangeReverseReturns = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetStateByRangeReverseReturnsOnCall(i int, result1 shim.StateQueryIteratorInterface, result2 error) {
+	fake.GetStateByRangeReverseStub = nil
+	if fake.getStateByRangeReverseReturnsOnCall == nil {
+		fake.getStateByRangeReverseReturnsOnCall = make(map[int]struct {
+			result1 shim.StateQueryIteratorInterface
+			result2 error
+		})
+	}
+	fake.getStateByRangeReverseReturnsOnCall[i] = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetStateByRangeWithPagination(startKey string, endKey string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
 	fake.getStateByRangeWithPaginationMutex.Lock()
 	ret, specificReturn := fake.getStateByRangeWithPaginationReturnsOnCall[len(fake.getStateByRangeWithPaginationArgsForCall)]
@@ -1193,6 +1285,63 @@ func (fake *ChaincodeStub) GetStateByPartialCompositeKeyReturnsOnCall(i int, res
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetStateByPartialCompositeKeyReverse(objectType string, keys []string) (shim.StateQueryIteratorInterface, error) {
+	var keysCopy []string
+	if keys != nil {
+		keysCopy = make([]string, len(keys))
+		copy(keysCopy, keys)
+	}
+	fake.getStateByPartialCompositeKeyReverseMutex.Lock()
+	ret, specificReturn := fake.getStateByPartialCompositeKeyReverseReturnsOnCall[len(fake.getStateByPartialCompositeKeyReverseArgsForCall)]
+	fake.getStateByPartialCompositeKeyReverseArgsForCall = append(fake.getStateByPartialCompositeKeyReverseArgsForCall, struct {
+		objectType string
+		keys       []string
+	}{objectType, keysCopy})
+	fake.recordInvocation("GetStateByPartialCompositeKeyReverse", []interface{}{objectType, keysCopy})
+	fake.getStateByPartialCompositeKeyReverseMutex.Unlock()
+	if fake.GetStateByPartialCompositeKeyReverseStub != nil {
+		return fake.GetStateByPartialCompositeKeyReverseStub(objectType, keys)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getStateByPartialCompositeKeyReverseReturns.result1, fake.getStateByPartialCompositeKeyReverseReturns.result2
+}
+
+func (fake *ChaincodeStub) GetStateByPartialCompositeKeyReverseCallCount() int {
+	fake.getStateByPartialCompositeKeyReverseMutex.RLock()
+	defer fake.getStateByPartialCompositeKeyReverseMutex.RUnlock()
+	return len(fake.getStateByPartialCompositeKeyReverseArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetStateByPartialCompositeKeyReverseArgsForCall(i int) (string, []string) {
+	fake.getStateByPartialCompositeKeyReverseMutex.RLock()
+	defer fake.getStateByPartialCompositeKeyReverseMutex.RUnlock()
+	return fake.getStateByPartialCompositeKeyReverseArgsForCall[i].objectType, fake.getStateByPartialCompositeKeyReverseArgsForCall[i].keys
+}
+
+func (fake *ChaincodeStub) GetStateByPartialCompositeKeyReverseReturns(result1 shim.StateQueryIteratorInterface, result2 error) {
+	fake.GetStateByPartialCompositeKeyReverseStub = nil
+	fake.getStateByPartialCompositeKeyReverseReturns = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetStateByPartialCompositeKeyReverseReturnsOnCall(i int, result1 shim.StateQueryIteratorInterface, result2 error) {
+	fake.GetStateByPartialCompositeKeyReverseStub = nil
+	if fake.getStateByPartialCompositeKeyReverseReturnsOnCall == nil {
+		fake.getStateByPartialCompositeKeyReverseReturnsOnCall = make(map[int]struct {
+			result1 shim.StateQueryIteratorInterface
+			result2 error
+		})
+	}
+	fake.getStateByPartialCompositeKeyReverseReturnsOnCall[i] = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetStateByPartialCompositeKeyWithPagination(objectType string, keys []string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
 	var keysCopy []string
 	if keys != nil {
@@ -2205,6 +2354,49 @@ func (fake *ChaincodeStub) GetTxTimestampReturnsOnCall(i int, result1 *timestamp
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetTxValidityWindow() (*common.ValidityWindow, error) {
+	fake.getTxValidityWindowMutex.Lock()
+	ret, specificReturn := fake.getTxValidityWindowReturnsOnCall[len(fake.getTxValidityWindowArgsForCall)]
+	fake.getTxValidityWindowArgsForCall = append(fake.getTxValidityWindowArgsForCall, struct{}{})
+	fake.recordInvocation("GetTxValidityWindow", []interface{}{})
+	fake.getTxValidityWindowMutex.Unlock()
+	if fake.GetTxValidityWindowStub != nil {
+		return fake.GetTxValidityWindowStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getTxValidityWindowReturns.result1, fake.getTxValidityWindowReturns.result2
+}
+
+func (fake *ChaincodeStub) GetTxValidityWindowCallCount() int {
+	fake.getTxValidityWindowMutex.RLock()
+	defer fake.getTxValidityWindowMutex.RUnlock()
+	return len(fake.getTxValidityWindowArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetTxValidityWindowReturns(result1 *common.ValidityWindow, result2 error) {
+	fake.GetTxValidityWindowStub = nil
+	fake.getTxValidityWindowReturns = struct {
+		result1 *common.ValidityWindow
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetTxValidityWindowReturnsOnCall(i int, result1 *common.ValidityWindow, result2 error) {
+	fake.GetTxValidityWindowStub = nil
+	if fake.getTxValidityWindowReturnsOnCall == nil {
+		fake.getTxValidityWindowReturnsOnCall = make(map[int]struct {
+			result1 *common.ValidityWindow
+			result2 error
+		})
+	}
+	fake.getTxValidityWindowReturnsOnCall[i] = struct {
+		result1 *common.ValidityWindow
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) SetEvent(name string, payload []byte) error {
 	var payloadCopy []byte
 	if payload != nil {
@@ -2288,10 +2480,14 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getStateValidationParameterMutex.RUnlock()
 	fake.getStateByRangeMutex.RLock()
 	defer fake.getStateByRangeMutex.RUnlock()
+	fake.getStateByRangeReverseMutex.RLock()
+	defer fake.getStateByRangeReverseMutex.RUnlock()
 	fake.getStateByRangeWithPaginationMutex.RLock()
 	defer fake.getStateByRangeWithPaginationMutex.RUnlock()
 	fake.getStateByPartialCompositeKeyMutex.RLock()
 	defer fake.getStateByPartialCompositeKeyMutex.RUnlock()
+	fake.getStateByPartialCompositeKeyReverseMutex.RLock()
+	defer fake.getStateByPartialCompositeKeyReverseMutex.RUnlock()
 	fake.getStateByPartialCompositeKeyWithPaginationMutex.RLock()
 	defer fake.getStateByPartialCompositeKeyWithPaginationMutex.RUnlock()
 	fake.createCompositeKeyMutex.RLock()
@@ -2332,6 +2528,8 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getSignedProposalMutex.RUnlock()
 	fake.getTxTimestampMutex.RLock()
 	defer fake.getTxTimestampMutex.RUnlock()
+	fake.getTxValidityWindowMutex.RLock()
+	defer fake.getTxValidityWindowMutex.RUnlock()
 	fake.setEventMutex.RLock()
 	defer fake.setEventMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -17,6 +17,15 @@ type StateQueryIterator struct {
 	hasNextReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	TruncatedStub        func() bool
+	truncatedMutex       sync.RWMutex
+	truncatedArgsForCall []struct{}
+	truncatedReturns     struct {
+		result1 bool
+	}
+	truncatedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	CloseStub        func() error
 	closeMutex       sync.RWMutex
 	closeArgsForCall []struct{}
@@ -81,6 +90,46 @@ func (fake *StateQueryIterator) HasNextReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *StateQueryIterator) Truncated() bool {
+	fake.truncatedMutex.Lock()
+	ret, specificReturn := fake.truncatedReturnsOnCall[len(fake.truncatedArgsForCall)]
+	fake.truncatedArgsForCall = append(fake.truncatedArgsForCall, struct{}{})
+	fake.recordInvocation("Truncated", []interface{}{})
+	fake.truncatedMutex.Unlock()
+	if fake.TruncatedStub != nil {
+		return fake.TruncatedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.truncatedReturns.result1
+}
+
+func (fake *StateQueryIterator) TruncatedCallCount() int {
+	fake.truncatedMutex.RLock()
+	defer fake.truncatedMutex.RUnlock()
+	return len(fake.truncatedArgsForCall)
+}
+
+func (fake *StateQueryIterator) TruncatedReturns(result1 bool) {
+	fake.TruncatedStub = nil
+	fake.truncatedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *StateQueryIterator) TruncatedReturnsOnCall(i int, result1 bool) {
+	fake.TruncatedStub = nil
+	if fake.truncatedReturnsOnCall == nil {
+		fake.truncatedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.truncatedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *StateQueryIterator) Close() error {
 	fake.closeMutex.Lock()
 	ret, specificReturn := fake.closeReturnsOnCall[len(fake.closeArgsForCall)]
@@ -169,6 +218,8 @@ func (fake *StateQueryIterator) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.hasNextMutex.RLock()
 	defer fake.hasNextMutex.RUnlock()
+	fake.truncatedMutex.RLock()
+	defer fake.truncatedMutex.RUnlock()
 	fake.closeMutex.RLock()
 	defer fake.closeMutex.RUnlock()
 	fake.nextMutex.RLock()
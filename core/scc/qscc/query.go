@@ -11,11 +11,13 @@ import (
 	"strconv"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
 
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 )
@@ -41,6 +43,7 @@ func (e *LedgerQuerier) Enabled() bool             { return true }
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetStateAttestation returns a StateAttestation
 type LedgerQuerier struct {
 	aclProvider aclmgmt.ACLProvider
 }
@@ -49,11 +52,12 @@ var qscclogger = flogging.MustGetLogger("qscc")
 
 // These are function names from Invoke first parameter
 const (
-	GetChainInfo       string = "GetChainInfo"
-	GetBlockByNumber   string = "GetBlockByNumber"
-	GetBlockByHash     string = "GetBlockByHash"
-	GetTransactionByID string = "GetTransactionByID"
-	GetBlockByTxID     string = "GetBlockByTxID"
+	GetChainInfo        string = "GetChainInfo"
+	GetBlockByNumber    string = "GetBlockByNumber"
+	GetBlockByHash      string = "GetBlockByHash"
+	GetTransactionByID  string = "GetTransactionByID"
+	GetBlockByTxID      string = "GetBlockByTxID"
+	GetStateAttestation string = "GetStateAttestation"
 )
 
 // Init is called once per chain when the chain is created.
@@ -72,6 +76,8 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetStateAttestation: Return the attestation of the value of the key in
+// args[3] of the chaincode in args[2]
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -84,6 +90,9 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	if fname != GetChainInfo && len(args) < 3 {
 		return shim.Error(fmt.Sprintf("missing 3rd argument for %s", fname))
 	}
+	if fname == GetStateAttestation && len(args) < 4 {
+		return shim.Error(fmt.Sprintf("missing 4th argument for %s", fname))
+	}
 
 	targetLedger := peer.GetLedger(cid)
 	if targetLedger == nil {
@@ -116,6 +125,8 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetStateAttestation:
+		return getStateAttestation(targetLedger, cid, string(args[2]), string(args[3]))
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -215,6 +226,31 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	return shim.Success(bytes)
 }
 
+func getStateAttestation(vledger ledger.PeerLedger, cid, namespace, key string) pb.Response {
+	value, binfo, err := vledger.GetStateAndBlockchainInfo(namespace, key)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get the state of key %s of chaincode %s, error %s", key, namespace, err))
+	}
+
+	attestation := &common.StateAttestation{
+		ChannelId:        cid,
+		Namespace:        namespace,
+		Key:              key,
+		Exists:           value != nil,
+		Height:           binfo.Height,
+		CurrentBlockHash: binfo.CurrentBlockHash,
+	}
+	if value != nil {
+		attestation.ValueHash = util.ComputeSHA256(value)
+	}
+	bytes, err := utils.Marshal(attestation)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
 func getACLResource(fname string) string {
 	return "qscc/" + fname
 }
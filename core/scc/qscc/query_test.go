@@ -21,6 +21,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
@@ -328,6 +329,53 @@ func TestQueryGeneratedBlock(t *testing.T) {
 	}
 }
 
+func TestQueryGetStateAttestation(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := tempDir(t, "test9")
+	defer os.RemoveAll(path)
+
+	stub, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block1 := addBlockForTesting(t, chainid)
+
+	args := [][]byte{[]byte(GetStateAttestation), []byte(chainid), []byte("ns1"), []byte("key1")}
+	prop := resetProvider(resources.Qscc_GetStateAttestation, chainid, &peer2.SignedProposal{}, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetStateAttestation failed with err: %s", res.Message)
+	attestation := &common.StateAttestation{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, attestation))
+	assert.Equal(t, &common.StateAttestation{
+		ChannelId:        chainid,
+		Namespace:        "ns1",
+		Key:              "key1",
+		Exists:           true,
+		ValueHash:        util.ComputeSHA256([]byte("value1")),
+		Height:           2,
+		CurrentBlockHash: block1.Header.Hash(),
+	}, attestation)
+
+	// the attestation of a missing key has no hash
+	args = [][]byte{[]byte(GetStateAttestation), []byte(chainid), []byte("ns1"), []byte("missing")}
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetStateAttestation failed with err: %s", res.Message)
+	attestation = &common.StateAttestation{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, attestation))
+	assert.False(t, attestation.Exists)
+	assert.Nil(t, attestation.ValueHash)
+
+	args = [][]byte{[]byte(GetStateAttestation), []byte(chainid), []byte("ns1")}
+	res = stub.MockInvoke("3", args)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateAttestation should have failed due to incorrect number of arguments")
+
+	resetProvider(resources.Qscc_GetStateAttestation, chainid, prop, errors.New("Failed access control"))
+	args = [][]byte{[]byte(GetStateAttestation), []byte(chainid), []byte("ns1"), []byte("key1")}
+	res = stub.MockInvokeWithSignedProposal("4", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateAttestation must fail: %s", res.Message)
+}
+
 func addBlockForTesting(t *testing.T, chainid string) *common.Block {
 	ledger := peer.GetLedger(chainid)
 	defer ledger.Close()
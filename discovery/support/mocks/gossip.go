@@ -89,6 +89,12 @@ type Gossip struct {
 		chaincode []*proto.Chaincode
 		chainID   common.ChainID
 	}
+	UpdateCheckpointStub        func(checkpoint *proto.Checkpoint, chainID common.ChainID)
+	updateCheckpointMutex       sync.RWMutex
+	updateCheckpointArgsForCall []struct {
+		checkpoint *proto.Checkpoint
+		chainID    common.ChainID
+	}
 	GossipStub        func(msg *proto.GossipMessage)
 	gossipMutex       sync.RWMutex
 	gossipArgsForCall []struct {
@@ -488,6 +494,31 @@ func (fake *Gossip) UpdateChaincodesArgsForCall(i int) ([]*proto.Chaincode, comm
 	return fake.updateChaincodesArgsForCall[i].chaincode, fake.updateChaincodesArgsForCall[i].chainID
 }
 
+func (fake *Gossip) UpdateCheckpoint(checkpoint *proto.Checkpoint, chainID common.ChainID) {
+	fake.updateCheckpointMutex.Lock()
+	fake.updateCheckpointArgsForCall = append(fake.updateCheckpointArgsForCall, struct {
+		checkpoint *proto.Checkpoint
+		chainID    common.ChainID
+	}{checkpoint, chainID})
+	fake.recordInvocation("UpdateCheckpoint", []interface{}{checkpoint, chainID})
+	fake.updateCheckpointMutex.Unlock()
+	if fake.UpdateCheckpointStub != nil {
+		fake.UpdateCheckpointStub(checkpoint, chainID)
+	}
+}
+
+func (fake *Gossip) UpdateCheckpointCallCount() int {
+	fake.updateCheckpointMutex.RLock()
+	defer fake.updateCheckpointMutex.RUnlock()
+	return len(fake.updateCheckpointArgsForCall)
+}
+
+func (fake *Gossip) UpdateCheckpointArgsForCall(i int) (*proto.Checkpoint, common.ChainID) {
+	fake.updateCheckpointMutex.RLock()
+	defer fake.updateCheckpointMutex.RUnlock()
+	return fake.updateCheckpointArgsForCall[i].checkpoint, fake.updateCheckpointArgsForCall[i].chainID
+}
+
 func (fake *Gossip) Gossip(msg *proto.GossipMessage) {
 	fake.gossipMutex.Lock()
 	fake.gossipArgsForCall = append(fake.gossipArgsForCall, struct {
@@ -766,6 +797,8 @@ func (fake *Gossip) Invocations() map[string][][]interface{} {
 	defer fake.updateLedgerHeightMutex.RUnlock()
 	fake.updateChaincodesMutex.RLock()
 	defer fake.updateChaincodesMutex.RUnlock()
+	fake.updateCheckpointMutex.RLock()
+	defer fake.updateCheckpointMutex.RUnlock()
 	fake.gossipMutex.RLock()
 	defer fake.gossipMutex.RUnlock()
 	fake.peerFilterMutex.RLock()
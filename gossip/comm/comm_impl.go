@@ -324,7 +324,6 @@ func (c *commImpl) Handshake(remotePeer *RemotePeer) (api.PeerIdentityType, erro
 }
 
 func (c *commImpl) Accept(acceptor common.MessageAcceptor) <-chan proto.ReceivedMessage {
-	genericChan := c.msgPublisher.AddChannel(acceptor)
 	specificChan := make(chan proto.ReceivedMessage, 10)
 
 	if c.isStopping() {
@@ -336,28 +335,14 @@ func (c *commImpl) Accept(acceptor common.MessageAcceptor) <-chan proto.Received
 	c.subscriptions = append(c.subscriptions, specificChan)
 	c.lock.Unlock()
 
-	c.stopWG.Add(1)
-	go func() {
-		defer c.logger.Debug("Exiting Accept() loop")
-
-		defer c.stopWG.Done()
-
-		for {
-			select {
-			case msg := <-genericChan:
-				if msg == nil {
-					return
-				}
-				select {
-				case specificChan <- msg.(*ReceivedMessageImpl):
-				case <-c.exitChan:
-					return
-				}
-			case <-c.exitChan:
-				return
-			}
+	// The messages are passed to the subscription by the goroutine
+	// publishing them, rather than by a goroutine per subscription
+	c.msgPublisher.AddSubscriber(acceptor, func(msg interface{}) {
+		select {
+		case specificChan <- msg.(*ReceivedMessageImpl):
+		case <-c.exitChan:
 		}
-	}()
+	})
 	return specificChan
 }
 
@@ -392,8 +377,10 @@ func (c *commImpl) Stop() {
 	}
 	c.connStore.shutdown()
 	c.logger.Debug("Shut down connection store, connection count:", c.connStore.connNum())
-	c.msgPublisher.Close()
+	// The exit channel is closed first, to release the publications blocked
+	// on subscriptions no longer read
 	close(c.exitChan)
+	c.msgPublisher.Close()
 	c.stopWG.Wait()
 	c.closeSubscriptions()
 }
@@ -30,8 +30,9 @@ func NewChannelDemultiplexer() *ChannelDeMultiplexer {
 }
 
 type channel struct {
-	pred common.MessageAcceptor
-	ch   chan interface{}
+	pred    common.MessageAcceptor
+	ch      chan interface{}
+	deliver func(interface{})
 }
 
 func (m *ChannelDeMultiplexer) isClosed() bool {
@@ -45,7 +46,9 @@ func (m *ChannelDeMultiplexer) Close() {
 	defer m.lock.Unlock()
 	m.closed = true
 	for _, ch := range m.channels {
-		close(ch.ch)
+		if ch.ch != nil {
+			close(ch.ch)
+		}
 	}
 	m.channels = nil
 }
@@ -55,10 +58,23 @@ func (m *ChannelDeMultiplexer) AddChannel(predicate common.MessageAcceptor) chan
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	ch := &channel{ch: make(chan interface{}, 10), pred: predicate}
+	ch.deliver = func(msg interface{}) {
+		ch.ch <- msg
+	}
 	m.channels = append(m.channels, ch)
 	return ch.ch
 }
 
+// AddSubscriber registers a function which is passed the publications that
+// hold the given predicate. The function is invoked by the publisher, so
+// that no goroutine is needed to relay the publications to the subscriber,
+// and blocks further publications until it returns.
+func (m *ChannelDeMultiplexer) AddSubscriber(predicate common.MessageAcceptor, deliver func(msg interface{})) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.channels = append(m.channels, &channel{pred: predicate, deliver: deliver})
+}
+
 // DeMultiplex broadcasts the message to all channels that were returned
 // by AddChannel calls and that hold the respected predicates.
 func (m *ChannelDeMultiplexer) DeMultiplex(msg interface{}) {
@@ -69,7 +85,7 @@ func (m *ChannelDeMultiplexer) DeMultiplex(msg interface{}) {
 	}
 	for _, ch := range m.channels {
 		if ch.pred(msg) {
-			ch.ch <- msg
+			ch.deliver(msg)
 		}
 	}
 }
@@ -6,10 +6,40 @@ SPDX-License-Identifier: Apache-2.0
 
 package comm
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
 
 func TestChannelDeMultiplexer_Close(t *testing.T) {
 	demux := NewChannelDemultiplexer()
 	demux.Close()
 	demux.DeMultiplex("msg")
 }
+
+func TestChannelDeMultiplexer_AddSubscriber(t *testing.T) {
+	demux := NewChannelDemultiplexer()
+	isEven := func(o interface{}) bool {
+		return o.(int)%2 == 0
+	}
+	var even []int
+	demux.AddSubscriber(isEven, func(msg interface{}) {
+		even = append(even, msg.(int))
+	})
+	ch := demux.AddChannel(func(o interface{}) bool {
+		return true
+	})
+
+	for i := 0; i < 4; i++ {
+		demux.DeMultiplex(i)
+	}
+	assert.Equal(t, []int{0, 2}, even)
+	assert.Len(t, ch, 4)
+
+	demux.Close()
+	demux.DeMultiplex(4)
+	assert.Equal(t, []int{0, 2}, even)
+	for range ch {
+	}
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package filter
 
 import (
+	"math"
 	"math/rand"
 
 	"github.com/hyperledger/fabric/gossip/comm"
@@ -62,6 +63,25 @@ func SelectPeers(k int, peerPool []discovery.NetworkMember, filter RoutingFilter
 	return res
 }
 
+// MinAdaptivePeerNum is the least number of peers selected by AdaptivePeerNum
+const MinAdaptivePeerNum = 3
+
+// AdaptivePeerNum returns the number of peers to push messages to, or to pull
+// messages from, out of a pool of the given size. A positive configured number
+// is returned as is. Otherwise the number grows with the logarithm of the size
+// of the pool, so that a message reaches all the peers of the pool within a
+// few rounds on large channels without flooding the peers of small ones.
+func AdaptivePeerNum(configured int, poolSize int) int {
+	if configured > 0 {
+		return configured
+	}
+	k := int(math.Ceil(math.Log2(float64(poolSize + 1))))
+	if k < MinAdaptivePeerNum {
+		k = MinAdaptivePeerNum
+	}
+	return k
+}
+
 // First returns the first peer that matches the given filter
 func First(peerPool []discovery.NetworkMember, filter RoutingFilter) *comm.RemotePeer {
 	for _, p := range peerPool {
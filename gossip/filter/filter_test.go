@@ -105,3 +105,12 @@ func TestSelectPeers(t *testing.T) {
 	assert.Len(t, SelectPeers(5, []discovery.NetworkMember{nm1, nm2, nm3}, CombineRoutingFilters(a, b)), 2)
 	assert.Len(t, SelectPeers(1, []discovery.NetworkMember{nm1, nm2, nm3}, CombineRoutingFilters(a, b)), 1)
 }
+
+func TestAdaptivePeerNum(t *testing.T) {
+	assert.Equal(t, 5, AdaptivePeerNum(5, 400))
+	assert.Equal(t, MinAdaptivePeerNum, AdaptivePeerNum(0, 0))
+	assert.Equal(t, MinAdaptivePeerNum, AdaptivePeerNum(0, 4))
+	assert.Equal(t, 4, AdaptivePeerNum(0, 10))
+	assert.Equal(t, 7, AdaptivePeerNum(0, 100))
+	assert.Equal(t, 9, AdaptivePeerNum(-1, 400))
+}
@@ -89,6 +89,7 @@ type PullAdapter interface {
 type PullEngine struct {
 	PullAdapter
 	stopFlag           int32
+	pullTask           *util.ScheduledTask
 	state              *util.Set
 	item2owners        map[string][]string
 	peers2nonces       map[string]uint64
@@ -125,15 +126,11 @@ func NewPullEngineWithFilter(participant PullAdapter, sleepTime time.Duration, d
 		responseWaitTime:   util.GetDurationOrDefault("peer.gossip.responseWaitTime", defResponseWaitTime),
 	}
 
-	go func() {
-		for !engine.toDie() {
-			time.Sleep(sleepTime)
-			if engine.toDie() {
-				return
-			}
+	engine.pullTask = util.DefaultScheduler.Schedule(sleepTime, func() {
+		if !engine.toDie() {
 			engine.initiatePull()
 		}
-	}()
+	})
 
 	return engine
 }
@@ -176,6 +173,7 @@ func (engine *PullEngine) ignoreDigests() {
 // Stop stops the engine
 func (engine *PullEngine) Stop() {
 	atomic.StoreInt32(&(engine.stopFlag), int32(1))
+	engine.pullTask.Cancel()
 }
 
 func (engine *PullEngine) initiatePull() {
@@ -0,0 +1,46 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+)
+
+const (
+	anchorPeerProbeFailures = "anchor_peer_probe_failures"
+	reachableAnchorPeers    = "reachable_anchor_peers"
+)
+
+// probeAnchorPeers performs a handshake with each of the given anchor peers of
+// an organization in a channel. Peers learn about the peers of other
+// organizations through their anchor peers, so anchor peers that cannot be
+// reached or fail the TLS handshake are reported, along with organizations
+// none of whose anchor peers are reachable.
+func (g *gossipServiceImpl) probeAnchorPeers(channel string, org api.OrgIdentityType, endpoints []string) {
+	defer g.stopSignal.Done()
+
+	scope := g.metricsScope.Tagged(map[string]string{"channel": channel, "org": string(org)})
+	reachable := 0
+	for _, endpoint := range endpoints {
+		if g.toDie() {
+			return
+		}
+		if _, err := g.comm.Handshake(&comm.RemotePeer{Endpoint: endpoint}); err != nil {
+			g.logger.Warnw("Anchor peer is unreachable", "channel", channel, "org", string(org), "endpoint", endpoint, "error", err.Error())
+			scope.Counter(anchorPeerProbeFailures).Inc(1)
+			continue
+		}
+		reachable++
+	}
+	scope.Gauge(reachableAnchorPeers).Update(float64(reachable))
+
+	if reachable == 0 {
+		g.logger.Warnw("None of the anchor peers of the organization are reachable, peers of the organization may not be discovered",
+			"channel", channel, "org", string(org), "anchorPeers", endpoints)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	mockmetrics "github.com/hyperledger/fabric/common/mocks/metrics"
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type handshakeComm struct {
+	comm.Comm
+	reachable map[string]bool
+	probed    []string
+}
+
+func (c *handshakeComm) Handshake(peer *comm.RemotePeer) (api.PeerIdentityType, error) {
+	c.probed = append(c.probed, peer.Endpoint)
+	if !c.reachable[peer.Endpoint] {
+		return nil, errors.New("authentication handshake failed: x509: certificate signed by unknown authority")
+	}
+	return api.PeerIdentityType(peer.Endpoint), nil
+}
+
+func TestProbeAnchorPeers(t *testing.T) {
+	newGossip := func(reachable ...string) (*gossipServiceImpl, *handshakeComm, *mockmetrics.MockScope) {
+		c := &handshakeComm{reachable: map[string]bool{}}
+		for _, endpoint := range reachable {
+			c.reachable[endpoint] = true
+		}
+		scope := mockmetrics.NewMockScope()
+		g := &gossipServiceImpl{
+			comm:         c,
+			logger:       util.GetLogger(util.LoggingGossipModule, ""),
+			stopSignal:   &sync.WaitGroup{},
+			metricsScope: scope,
+		}
+		return g, c, scope
+	}
+
+	endpoints := []string{"p1:7051", "p2:7051"}
+	tags := map[string]string{"channel": "mychannel", "org": "Org2MSP"}
+
+	g, c, scope := newGossip("p2:7051")
+	g.stopSignal.Add(1)
+	g.probeAnchorPeers("mychannel", api.OrgIdentityType("Org2MSP"), endpoints)
+	assert.Equal(t, endpoints, c.probed)
+	assert.Equal(t, int64(1), scope.CounterValue(anchorPeerProbeFailures, tags))
+	reachable, _ := scope.GaugeValue(reachableAnchorPeers, tags)
+	assert.Equal(t, float64(1), reachable)
+
+	g, c, scope = newGossip()
+	g.stopSignal.Add(1)
+	g.probeAnchorPeers("mychannel", api.OrgIdentityType("Org2MSP"), endpoints)
+	assert.Equal(t, endpoints, c.probed)
+	assert.Equal(t, int64(2), scope.CounterValue(anchorPeerProbeFailures, tags))
+	reachable, ok := scope.GaugeValue(reachableAnchorPeers, tags)
+	assert.True(t, ok)
+	assert.Equal(t, float64(0), reachable)
+
+	// a stopping gossip instance does not probe
+	g, c, scope = newGossip()
+	g.stopFlag = 1
+	g.stopSignal.Add(1)
+	g.probeAnchorPeers("mychannel", api.OrgIdentityType("Org2MSP"), endpoints)
+	assert.Empty(t, c.probed)
+	_, ok = scope.GaugeValue(reachableAnchorPeers, tags)
+	assert.False(t, ok)
+	g.stopSignal.Wait()
+}
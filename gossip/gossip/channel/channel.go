@@ -14,6 +14,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/hyperledger/fabric/common/metrics"
 	common_utils "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
@@ -65,6 +66,10 @@ type GossipChannel interface {
 	// to other peers in the channel
 	UpdateChaincodes(chaincode []*proto.Chaincode)
 
+	// UpdateCheckpoint updates the checkpoint the peer publishes
+	// to other peers in the channel, and compares it with theirs
+	UpdateCheckpoint(checkpoint *proto.Checkpoint)
+
 	// IsOrgInChannel returns whether the given organization is in the channel
 	IsOrgInChannel(membersOrg api.OrgIdentityType) bool
 
@@ -85,6 +90,9 @@ type GossipChannel interface {
 	// LeaveChannel makes the peer leave the channel
 	LeaveChannel()
 
+	// RejoinChannel makes the peer rejoin the channel it left
+	RejoinChannel()
+
 	// Stop stops the channel's activity
 	Stop()
 }
@@ -130,26 +138,26 @@ type Adapter interface {
 type gossipChannel struct {
 	Adapter
 	sync.RWMutex
-	shouldGossipStateInfo     int32
-	mcs                       api.MessageCryptoService
-	pkiID                     common.PKIidType
-	selfOrg                   api.OrgIdentityType
-	stopChan                  chan struct{}
-	stateInfoMsg              *proto.SignedGossipMessage
-	orgs                      []api.OrgIdentityType
-	joinMsg                   api.JoinChannelMessage
-	blockMsgStore             msgstore.MessageStore
-	stateInfoMsgStore         *stateInfoCache
-	leaderMsgStore            msgstore.MessageStore
-	chainID                   common.ChainID
-	blocksPuller              pull.Mediator
-	logger                    util.Logger
-	stateInfoPublishScheduler *time.Ticker
-	stateInfoRequestScheduler *time.Ticker
-	memFilter                 *membershipFilter
-	ledgerHeight              uint64
-	incTime                   uint64
-	leftChannel               int32
+	shouldGossipStateInfo int32
+	mcs                   api.MessageCryptoService
+	pkiID                 common.PKIidType
+	selfOrg               api.OrgIdentityType
+	stateInfoMsg          *proto.SignedGossipMessage
+	orgs                  []api.OrgIdentityType
+	joinMsg               api.JoinChannelMessage
+	blockMsgStore         msgstore.MessageStore
+	stateInfoMsgStore     *stateInfoCache
+	leaderMsgStore        msgstore.MessageStore
+	chainID               common.ChainID
+	blocksPuller          pull.Mediator
+	logger                util.Logger
+	periodicTasks         []*util.ScheduledTask
+	metricsScope          metrics.Scope
+	memFilter             *membershipFilter
+	checkpoints           *checkpointVerifier
+	ledgerHeight          uint64
+	incTime               uint64
+	leftChannel           int32
 }
 
 type membershipFilter struct {
@@ -175,18 +183,17 @@ func (mf *membershipFilter) GetMembership() []discovery.NetworkMember {
 func NewGossipChannel(pkiID common.PKIidType, org api.OrgIdentityType, mcs api.MessageCryptoService,
 	chainID common.ChainID, adapter Adapter, joinMsg api.JoinChannelMessage) GossipChannel {
 	gc := &gossipChannel{
-		incTime:                   uint64(time.Now().UnixNano()),
-		selfOrg:                   org,
-		pkiID:                     pkiID,
-		mcs:                       mcs,
-		Adapter:                   adapter,
-		logger:                    util.GetLogger(util.LoggingChannelModule, adapter.GetConf().ID),
-		stopChan:                  make(chan struct{}, 1),
-		shouldGossipStateInfo:     int32(0),
-		stateInfoPublishScheduler: time.NewTicker(adapter.GetConf().PublishStateInfoInterval),
-		stateInfoRequestScheduler: time.NewTicker(adapter.GetConf().RequestStateInfoInterval),
-		orgs:    []api.OrgIdentityType{},
-		chainID: chainID,
+		incTime:               uint64(time.Now().UnixNano()),
+		selfOrg:               org,
+		pkiID:                 pkiID,
+		mcs:                   mcs,
+		Adapter:               adapter,
+		logger:                util.GetLogger(util.LoggingChannelModule, adapter.GetConf().ID),
+		shouldGossipStateInfo: int32(0),
+		metricsScope:          metrics.SubScope("gossip").Tagged(map[string]string{"channel": string(chainID)}),
+		orgs:                  []api.OrgIdentityType{},
+		chainID:               chainID,
+		checkpoints:           newCheckpointVerifier(),
 	}
 
 	gc.memFilter = &membershipFilter{adapter: gc.Adapter, gossipChannel: gc}
@@ -259,34 +266,34 @@ func NewGossipChannel(pkiID common.PKIidType, org api.OrgIdentityType, mcs api.M
 
 	gc.ConfigureChannel(joinMsg)
 
-	// Periodically publish state info
-	go gc.periodicalInvocation(gc.publishStateInfo, gc.stateInfoPublishScheduler.C)
-	// Periodically request state info
-	go gc.periodicalInvocation(gc.requestStateInfo, gc.stateInfoRequestScheduler.C)
+	// The periodic tasks of the channels share the goroutines of the
+	// default scheduler, rather than each running its own
+	gc.periodicTasks = []*util.ScheduledTask{
+		util.DefaultScheduler.Schedule(adapter.GetConf().PublishStateInfoInterval, gc.publishStateInfo),
+		util.DefaultScheduler.Schedule(adapter.GetConf().RequestStateInfoInterval, gc.requestStateInfo),
+		util.DefaultScheduler.Schedule(adapter.GetConf().PublishStateInfoInterval, gc.reportMetrics),
+	}
 	return gc
 }
 
 // Stop stop the channel operations
 func (gc *gossipChannel) Stop() {
-	gc.stopChan <- struct{}{}
+	for _, task := range gc.periodicTasks {
+		task.Cancel()
+	}
 	gc.blocksPuller.Stop()
-	gc.stateInfoPublishScheduler.Stop()
-	gc.stateInfoRequestScheduler.Stop()
 	gc.leaderMsgStore.Stop()
 	gc.stateInfoMsgStore.Stop()
 	gc.blockMsgStore.Stop()
 }
 
-func (gc *gossipChannel) periodicalInvocation(fn func(), c <-chan time.Time) {
-	for {
-		select {
-		case <-c:
-			fn()
-		case <-gc.stopChan:
-			gc.stopChan <- struct{}{}
-			return
-		}
-	}
+// reportMetrics reports the number of peers of the channel and the sizes of
+// its message stores
+func (gc *gossipChannel) reportMetrics() {
+	gc.metricsScope.Gauge("channel_peers").Update(float64(len(gc.GetPeers())))
+	gc.metricsScope.Gauge("channel_block_store_size").Update(float64(gc.blockMsgStore.Size()))
+	gc.metricsScope.Gauge("channel_state_info_store_size").Update(float64(gc.stateInfoMsgStore.MessageStore.Size()))
+	gc.metricsScope.Gauge("channel_leader_store_size").Update(float64(gc.leaderMsgStore.Size()))
 }
 
 // Self returns a StateInfoMessage about the peer
@@ -305,11 +312,33 @@ func (gc *gossipChannel) LeaveChannel() {
 
 	var chaincodes []*proto.Chaincode
 	var height uint64
+	var checkpoint *proto.Checkpoint
+	if prevMsg := gc.stateInfoMsg; prevMsg != nil {
+		chaincodes = prevMsg.GetStateInfo().Properties.Chaincodes
+		height = prevMsg.GetStateInfo().Properties.LedgerHeight
+		checkpoint = prevMsg.GetStateInfo().Properties.Checkpoint
+	}
+	gc.updateProperties(height, chaincodes, true, checkpoint)
+}
+
+// RejoinChannel makes the peer rejoin the channel it left
+func (gc *gossipChannel) RejoinChannel() {
+	gc.Lock()
+	defer gc.Unlock()
+
+	if !atomic.CompareAndSwapInt32(&gc.leftChannel, 1, 0) {
+		return
+	}
+
+	var chaincodes []*proto.Chaincode
+	var height uint64
+	var checkpoint *proto.Checkpoint
 	if prevMsg := gc.stateInfoMsg; prevMsg != nil {
 		chaincodes = prevMsg.GetStateInfo().Properties.Chaincodes
 		height = prevMsg.GetStateInfo().Properties.LedgerHeight
+		checkpoint = prevMsg.GetStateInfo().Properties.Checkpoint
 	}
-	gc.updateProperties(height, chaincodes, true)
+	gc.updateProperties(height, chaincodes, false, checkpoint)
 }
 
 func (gc *gossipChannel) hasLeftChannel() bool {
@@ -348,7 +377,8 @@ func (gc *gossipChannel) requestStateInfo() {
 		gc.logger.Warningf("Failed creating SignedGossipMessage: %+v", errors.WithStack(err))
 		return
 	}
-	endpoints := filter.SelectPeers(gc.GetConf().PullPeerNum, gc.GetMembership(), gc.IsMemberInChan)
+	membership := gc.GetMembership()
+	endpoints := filter.SelectPeers(filter.AdaptivePeerNum(gc.GetConf().PullPeerNum, len(membership)), membership, gc.IsMemberInChan)
 	gc.Send(req, endpoints...)
 }
 
@@ -568,6 +598,9 @@ func (gc *gossipChannel) HandleMessage(msg proto.ReceivedMessage) {
 		} else { // StateInfoMsg verification should be handled in a layer above
 			//  since we don't have access to the id mapper here
 			added = gc.stateInfoMsgStore.Add(msg.GetGossipMessage())
+			if added {
+				gc.verifyCheckpoint(msg.GetGossipMessage())
+			}
 		}
 
 		if added {
@@ -688,7 +721,9 @@ func (gc *gossipChannel) handleStateInfSnapshot(m *proto.GossipMessage, sender c
 			continue
 		}
 
-		gc.stateInfoMsgStore.Add(stateInf)
+		if gc.stateInfoMsgStore.Add(stateInf) {
+			gc.verifyCheckpoint(stateInf)
+		}
 	}
 }
 
@@ -808,11 +843,13 @@ func (gc *gossipChannel) UpdateLedgerHeight(height uint64) {
 
 	var chaincodes []*proto.Chaincode
 	var leftChannel bool
+	var checkpoint *proto.Checkpoint
 	if prevMsg := gc.stateInfoMsg; prevMsg != nil {
 		leftChannel = prevMsg.GetStateInfo().Properties.LeftChannel
 		chaincodes = prevMsg.GetStateInfo().Properties.Chaincodes
+		checkpoint = prevMsg.GetStateInfo().Properties.Checkpoint
 	}
-	gc.updateProperties(height, chaincodes, leftChannel)
+	gc.updateProperties(height, chaincodes, leftChannel, checkpoint)
 }
 
 // UpdateChaincodes updates the chaincodes the peer publishes
@@ -823,11 +860,62 @@ func (gc *gossipChannel) UpdateChaincodes(chaincodes []*proto.Chaincode) {
 
 	var ledgerHeight uint64 = 1
 	var leftChannel bool
+	var checkpoint *proto.Checkpoint
+	if prevMsg := gc.stateInfoMsg; prevMsg != nil {
+		ledgerHeight = prevMsg.GetStateInfo().Properties.LedgerHeight
+		leftChannel = prevMsg.GetStateInfo().Properties.LeftChannel
+		checkpoint = prevMsg.GetStateInfo().Properties.Checkpoint
+	}
+	gc.updateProperties(ledgerHeight, chaincodes, leftChannel, checkpoint)
+}
+
+// UpdateCheckpoint updates the checkpoint the peer publishes
+// to other peers in the channel, and compares it with theirs
+func (gc *gossipChannel) UpdateCheckpoint(checkpoint *proto.Checkpoint) {
+	gc.Lock()
+	var ledgerHeight uint64 = 1
+	var chaincodes []*proto.Chaincode
+	var leftChannel bool
 	if prevMsg := gc.stateInfoMsg; prevMsg != nil {
 		ledgerHeight = prevMsg.GetStateInfo().Properties.LedgerHeight
+		chaincodes = prevMsg.GetStateInfo().Properties.Chaincodes
 		leftChannel = prevMsg.GetStateInfo().Properties.LeftChannel
 	}
-	gc.updateProperties(ledgerHeight, chaincodes, leftChannel)
+	gc.updateProperties(ledgerHeight, chaincodes, leftChannel, checkpoint)
+	gc.Unlock()
+
+	gc.checkpoints.add(checkpoint)
+	// The other peers may have published their checkpoint at this height
+	// before the peer reached it
+	for _, m := range gc.stateInfoMsgStore.Get() {
+		gc.verifyCheckpoint(m.(*proto.SignedGossipMessage))
+	}
+}
+
+// verifyCheckpoint compares the checkpoint published in the given StateInfo
+// message with the checkpoint of the peer at the same height, if any, and
+// raises an alert the first time they differ
+func (gc *gossipChannel) verifyCheckpoint(msg *proto.SignedGossipMessage) {
+	si := msg.GetStateInfo()
+	if bytes.Equal(si.PkiId, gc.pkiID) {
+		return
+	}
+	checkpoint := si.GetProperties().GetCheckpoint()
+	if checkpoint == nil {
+		return
+	}
+	checksum, mismatch := gc.checkpoints.verify(si.PkiId, checkpoint)
+	if !mismatch {
+		return
+	}
+	org := gc.GetOrgOfPeer(si.PkiId)
+	var endpoint string
+	if member := gc.Lookup(si.PkiId); member != nil {
+		endpoint = member.PreferredEndpoint()
+	}
+	gc.logger.Warningf("Peer %s (%s) of %s published checksum %x at height %d, which differs from the checksum %x of this peer: its ledger may have forked",
+		endpoint, si.PkiId, string(org), checkpoint.Checksum, checkpoint.Height, checksum)
+	gc.metricsScope.Tagged(map[string]string{"org": string(org)}).Counter("checkpoint_mismatches").Inc(1)
 }
 
 // UpdateStateInfo updates this channel's StateInfo message
@@ -839,7 +927,7 @@ func (gc *gossipChannel) updateStateInfo(msg *proto.SignedGossipMessage) {
 	atomic.StoreInt32(&gc.shouldGossipStateInfo, int32(1))
 }
 
-func (gc *gossipChannel) updateProperties(ledgerHeight uint64, chaincodes []*proto.Chaincode, leftChannel bool) {
+func (gc *gossipChannel) updateProperties(ledgerHeight uint64, chaincodes []*proto.Chaincode, leftChannel bool, checkpoint *proto.Checkpoint) {
 	stateInfMsg := &proto.StateInfo{
 		Channel_MAC: GenerateMAC(gc.pkiID, gc.chainID),
 		PkiId:       gc.pkiID,
@@ -851,6 +939,7 @@ func (gc *gossipChannel) updateProperties(ledgerHeight uint64, chaincodes []*pro
 			LeftChannel:  leftChannel,
 			LedgerHeight: ledgerHeight,
 			Chaincodes:   chaincodes,
+			Checkpoint:   checkpoint,
 		},
 	}
 	m := &proto.GossipMessage{
@@ -876,7 +965,6 @@ func newStateInfoCache(sweepInterval time.Duration, hasExpired func(interface{})
 	s := &stateInfoCache{
 		verify:          verifyFunc,
 		MembershipStore: membershipStore,
-		stopChan:        make(chan struct{}),
 	}
 	invalidationTrigger := func(m interface{}) {
 		pkiID := m.(*proto.SignedGossipMessage).GetStateInfo().PkiId
@@ -884,16 +972,9 @@ func newStateInfoCache(sweepInterval time.Duration, hasExpired func(interface{})
 	}
 	s.MessageStore = msgstore.NewMessageStore(pol, invalidationTrigger)
 
-	go func() {
-		for {
-			select {
-			case <-s.stopChan:
-				return
-			case <-time.After(sweepInterval):
-				s.Purge(hasExpired)
-			}
-		}
-	}()
+	s.sweepTask = util.DefaultScheduler.Schedule(sweepInterval, func() {
+		s.Purge(hasExpired)
+	})
 	return s
 }
 
@@ -909,7 +990,7 @@ type stateInfoCache struct {
 	verify membershipPredicate
 	*util.MembershipStore
 	msgstore.MessageStore
-	stopChan chan struct{}
+	sweepTask *util.ScheduledTask
 }
 
 func (cache *stateInfoCache) validate(orgs []api.OrgIdentityType) {
@@ -948,7 +1029,7 @@ func (cache *stateInfoCache) delete(msg *proto.SignedGossipMessage) {
 }
 
 func (cache *stateInfoCache) Stop() {
-	cache.stopChan <- struct{}{}
+	cache.sweepTask.Cancel()
 }
 
 // GenerateMAC returns a byte slice that is derived from the peer's PKI-ID
@@ -477,6 +477,81 @@ func TestLeaveChannel(t *testing.T) {
 
 }
 
+func TestRejoinChannel(t *testing.T) {
+	// Scenario: Have our peer leave the channel and rejoin it,
+	// and ensure it publishes it is in the channel again
+	// while keeping its ledger height.
+	t.Parallel()
+
+	cs := &cryptoService{}
+	jcm := &joinChanMsg{
+		members2AnchorPeers: map[string][]api.AnchorPeer{
+			string(orgInChannelA): {},
+		},
+	}
+	adapter := new(gossipAdapterMock)
+	configureAdapter(adapter)
+	adapter.On("Gossip", mock.Anything)
+	gc := NewGossipChannel(common.PKIidType("1"), orgInChannelA, cs, channelA, adapter, jcm)
+	gc.UpdateLedgerHeight(5)
+	// Rejoining a channel that was not left does nothing
+	gc.RejoinChannel()
+	assert.False(t, gc.Self().GetStateInfo().Properties.LeftChannel)
+
+	gc.LeaveChannel()
+	assert.True(t, gc.Self().GetStateInfo().Properties.LeftChannel)
+	gc.RejoinChannel()
+	assert.False(t, gc.(*gossipChannel).hasLeftChannel())
+	assert.False(t, gc.Self().GetStateInfo().Properties.LeftChannel)
+	assert.Equal(t, uint64(5), gc.Self().GetStateInfo().Properties.LedgerHeight)
+}
+
+func TestChannelCheckpoints(t *testing.T) {
+	// Scenario: Have our peer publish checkpoints, and ensure the
+	// checkpoints of the other peers are compared with them, whether
+	// they are received before or after our own checkpoints.
+	t.Parallel()
+
+	cs := &cryptoService{}
+	adapter := new(gossipAdapterMock)
+	configureAdapter(adapter)
+	adapter.On("Gossip", mock.Anything)
+	adapter.On("Forward", mock.Anything)
+	adapter.On("DeMultiplex", mock.Anything)
+	gc := NewGossipChannel(pkiIDInOrg1, orgInChannelA, cs, channelA, adapter, &joinChanMsg{}).(*gossipChannel)
+
+	reportedHeight := func() uint64 {
+		gc.checkpoints.Lock()
+		defer gc.checkpoints.Unlock()
+		return gc.checkpoints.reported[string(pkiIDInOrg1ButNotEligible)]
+	}
+	publish := func(height uint64, checksum string) {
+		msg := createStateInfoMsg(int(height), pkiIDInOrg1ButNotEligible, channelA)
+		msg.GetStateInfo().Properties.Checkpoint = &proto.Checkpoint{Height: height, Checksum: []byte(checksum)}
+		msg, _ = msg.NoopSign()
+		gc.HandleMessage(&receivedMsg{msg: msg, PKIID: pkiIDInOrg1ButNotEligible})
+	}
+
+	gc.UpdateLedgerHeight(10)
+	gc.UpdateCheckpoint(&proto.Checkpoint{Height: 10, Checksum: []byte("a")})
+	// The checkpoint is kept when the ledger height is updated
+	gc.UpdateLedgerHeight(11)
+	assert.Equal(t, []byte("a"), gc.Self().GetStateInfo().Properties.Checkpoint.Checksum)
+
+	// A peer with the same checksum is fine
+	publish(10, "a")
+	assert.Zero(t, reportedHeight())
+	// A peer with a different checksum is detected
+	publish(10, "b")
+	assert.Equal(t, uint64(10), reportedHeight())
+
+	// A peer which publishes its checkpoint first is detected once ours is computed
+	publish(20, "c")
+	assert.Equal(t, uint64(10), reportedHeight())
+	gc.UpdateCheckpoint(&proto.Checkpoint{Height: 20, Checksum: []byte("d")})
+	assert.Equal(t, uint64(20), reportedHeight())
+}
+
 func TestChannelPeriodicalPublishStateInfo(t *testing.T) {
 	t.Parallel()
 	ledgerHeight := 5
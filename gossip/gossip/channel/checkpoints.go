@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/hyperledger/fabric/gossip/common"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+)
+
+// maxCheckpoints is the number of recent checkpoints of the peer which are
+// kept to be compared with the checkpoints of the other peers
+const maxCheckpoints = 10
+
+// checkpointVerifier compares the checkpoints published by the other peers of
+// the channel with the recent checkpoints of the peer
+type checkpointVerifier struct {
+	sync.Mutex
+	checksums map[uint64][]byte
+	heights   []uint64
+	// reported holds, for each peer, the last height at which its
+	// checksum was found to differ, so that each mismatch is reported once
+	reported map[string]uint64
+}
+
+func newCheckpointVerifier() *checkpointVerifier {
+	return &checkpointVerifier{
+		checksums: make(map[uint64][]byte),
+		reported:  make(map[string]uint64),
+	}
+}
+
+// add records a checkpoint of the peer, evicting the oldest one if needed
+func (v *checkpointVerifier) add(checkpoint *proto.Checkpoint) {
+	if checkpoint == nil {
+		return
+	}
+	v.Lock()
+	defer v.Unlock()
+	if _, exists := v.checksums[checkpoint.Height]; !exists {
+		v.heights = append(v.heights, checkpoint.Height)
+	}
+	v.checksums[checkpoint.Height] = checkpoint.Checksum
+	if len(v.heights) > maxCheckpoints {
+		delete(v.checksums, v.heights[0])
+		v.heights = v.heights[1:]
+	}
+}
+
+// verify compares the checkpoint of the given peer with the checkpoint of the
+// peer at the same height. It returns the checksum of the peer and true if
+// they differ and the mismatch was not reported yet.
+func (v *checkpointVerifier) verify(pkiID common.PKIidType, checkpoint *proto.Checkpoint) ([]byte, bool) {
+	v.Lock()
+	defer v.Unlock()
+	checksum, exists := v.checksums[checkpoint.Height]
+	if !exists || bytes.Equal(checksum, checkpoint.Checksum) {
+		return nil, false
+	}
+	if height, reported := v.reported[string(pkiID)]; reported && height == checkpoint.Height {
+		return nil, false
+	}
+	v.reported[string(pkiID)] = checkpoint.Height
+	return checksum, true
+}
@@ -52,6 +52,10 @@ type Gossip interface {
 	// to other peers in the channel
 	UpdateChaincodes(chaincode []*proto.Chaincode, chainID common.ChainID)
 
+	// UpdateCheckpoint updates the checkpoint the peer publishes
+	// to other peers in the channel
+	UpdateCheckpoint(checkpoint *proto.Checkpoint, chainID common.ChainID)
+
 	// Gossip sends a message to other peers to the network
 	Gossip(msg *proto.GossipMessage)
 
@@ -113,7 +117,7 @@ type Config struct {
 	ID                  string   // ID of this instance
 	BootstrapPeers      []string // Peers we connect to at startup
 	PropagateIterations int      // Number of times a message is pushed to remote peers
-	PropagatePeerNum    int      // Number of peers selected to push messages to
+	PropagatePeerNum    int      // Number of peers selected to push messages to, adapted to the number of peers if 0
 
 	MaxBlockCountToStore int // Maximum count of blocks we store in memory
 
@@ -121,7 +125,7 @@ type Config struct {
 	MaxPropagationBurstLatency time.Duration // Max time between consecutive message pushes
 
 	PullInterval time.Duration // Determines frequency of pull phases
-	PullPeerNum  int           // Number of peers to pull from
+	PullPeerNum  int           // Number of peers to pull from, adapted to the number of peers if 0
 
 	SkipBlockVerification bool // Should we skip verifying block messages or not
 
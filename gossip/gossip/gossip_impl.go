@@ -14,6 +14,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
 	"github.com/hyperledger/fabric/gossip/common"
@@ -60,6 +61,7 @@ type gossipServiceImpl struct {
 	mcs               api.MessageCryptoService
 	stateInfoMsgStore msgstore.MessageStore
 	certPuller        pull.Mediator
+	metricsScope      metrics.Scope
 }
 
 // NewGossipService creates a gossip instance attached to a gRPC server
@@ -84,6 +86,7 @@ func NewGossipService(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 		stopFlag:              int32(0),
 		stopSignal:            &sync.WaitGroup{},
 		includeIdentityPeriod: time.Now().Add(conf.PublishCertPeriod),
+		metricsScope:          metrics.SubScope("gossip"),
 	}
 	g.stateInfoMsgStore = g.newStateInfoMsgStore()
 
@@ -220,6 +223,7 @@ func (g *gossipServiceImpl) learnAnchorPeers(channel string, orgOfAnchorPeers ap
 		return
 	}
 	g.logger.Info("Learning about the configured anchor peers of", string(orgOfAnchorPeers), "for channel", channel, ":", anchorPeers)
+	var endpoints []string
 	for _, ap := range anchorPeers {
 		if ap.Host == "" {
 			g.logger.Warning("Got empty hostname, skipping connecting to anchor peer", ap)
@@ -266,6 +270,12 @@ func (g *gossipServiceImpl) learnAnchorPeers(channel string, orgOfAnchorPeers ap
 
 		g.disc.Connect(discovery.NetworkMember{
 			InternalEndpoint: endpoint, Endpoint: endpoint}, identifier)
+		endpoints = append(endpoints, endpoint)
+	}
+
+	if len(endpoints) > 0 && !g.toDie() {
+		g.stopSignal.Add(1)
+		go g.probeAnchorPeers(channel, orgOfAnchorPeers, endpoints)
 	}
 }
 
@@ -288,7 +298,7 @@ func (g *gossipServiceImpl) syncDiscovery() {
 	g.logger.Debug("Entering discovery sync with interval", g.conf.PullInterval)
 	defer g.logger.Debug("Exiting discovery sync loop")
 	for !g.toDie() {
-		g.disc.InitiateSync(g.conf.PullPeerNum)
+		g.disc.InitiateSync(filter.AdaptivePeerNum(g.conf.PullPeerNum, len(g.disc.GetMembership())))
 		time.Sleep(g.conf.PullInterval)
 	}
 }
@@ -512,13 +522,15 @@ func (g *gossipServiceImpl) gossipBatch(msgs []*emittedGossipMessage) {
 			return stateInfMsg.filter(member.PKIid)
 		})
 
-		peers2Send := filter.SelectPeers(g.conf.PropagatePeerNum, g.disc.GetMembership(), peerSelector)
+		membership := g.disc.GetMembership()
+		peers2Send := filter.SelectPeers(g.propagatePeerNum(membership, peerSelector), membership, peerSelector)
 		g.comm.Send(stateInfMsg.SignedGossipMessage, peers2Send...)
 	}
 
 	// Gossip messages restricted to our org
 	orgMsgs, msgs = partitionMessages(isOrgRestricted, msgs)
-	peers2Send := filter.SelectPeers(g.conf.PropagatePeerNum, g.disc.GetMembership(), g.isInMyorg)
+	membership := g.disc.GetMembership()
+	peers2Send := filter.SelectPeers(g.propagatePeerNum(membership, g.isInMyorg), membership, g.isInMyorg)
 	for _, msg := range orgMsgs {
 		g.comm.Send(msg.SignedGossipMessage, g.removeSelfLoop(msg, peers2Send)...)
 	}
@@ -533,11 +545,23 @@ func (g *gossipServiceImpl) gossipBatch(msgs []*emittedGossipMessage) {
 		selector := filter.CombineRoutingFilters(selectByOriginOrg, func(member discovery.NetworkMember) bool {
 			return msg.filter(member.PKIid)
 		})
-		peers2Send := filter.SelectPeers(g.conf.PropagatePeerNum, g.disc.GetMembership(), selector)
+		membership := g.disc.GetMembership()
+		peers2Send := filter.SelectPeers(g.propagatePeerNum(membership, selector), membership, selector)
 		g.sendAndFilterSecrets(msg.SignedGossipMessage, peers2Send...)
 	}
 }
 
+// propagatePeerNum returns the number of peers to push a message to, out of
+// the peers of the membership which pass the routing filter, such as the peers
+// of a channel. It is the configured number, or grows with the number of the
+// peers when it isn't configured.
+func (g *gossipServiceImpl) propagatePeerNum(membership []discovery.NetworkMember, routingFilter filter.RoutingFilter) int {
+	if g.conf.PropagatePeerNum > 0 {
+		return g.conf.PropagatePeerNum
+	}
+	return filter.AdaptivePeerNum(0, len(filter.AnyMatch(membership, routingFilter)))
+}
+
 func (g *gossipServiceImpl) sendAndFilterSecrets(msg *proto.SignedGossipMessage, peers ...*comm.RemotePeer) {
 	for _, peer := range peers {
 		// Prevent forwarding alive messages of external organizations
@@ -587,7 +611,8 @@ func (g *gossipServiceImpl) gossipInChan(messages []*emittedGossipMessage, chanR
 		if messagesOfChannel[0].IsLeadershipMsg() {
 			peers2Send = filter.SelectPeers(len(membership), membership, chanRoutingFactory(gc))
 		} else {
-			peers2Send = filter.SelectPeers(g.conf.PropagatePeerNum, membership, chanRoutingFactory(gc))
+			routingFilter := chanRoutingFactory(gc)
+			peers2Send = filter.SelectPeers(g.propagatePeerNum(membership, routingFilter), membership, routingFilter)
 		}
 
 		// Send the messages to the remote peers
@@ -807,6 +832,17 @@ func (g *gossipServiceImpl) UpdateChaincodes(chaincodes []*proto.Chaincode, chai
 	gc.UpdateChaincodes(chaincodes)
 }
 
+// UpdateCheckpoint updates the checkpoint the peer publishes
+// to other peers in the channel
+func (g *gossipServiceImpl) UpdateCheckpoint(checkpoint *proto.Checkpoint, chainID common.ChainID) {
+	gc := g.chanState.getGossipChannelByChainID(chainID)
+	if gc == nil {
+		g.logger.Warning("No such channel", chainID)
+		return
+	}
+	gc.UpdateCheckpoint(checkpoint)
+}
+
 // Accept returns a dedicated read-only channel for messages sent by other nodes that match a certain predicate.
 // If passThrough is false, the messages are processed by the gossip layer beforehand.
 // If passThrough is true, the gossip layer doesn't intervene and the messages
@@ -826,22 +862,14 @@ func (g *gossipServiceImpl) Accept(acceptor common.MessageAcceptor, passThrough
 		g.logger.Warning("Message type:", reflect.TypeOf(o), "cannot be evaluated")
 		return false
 	}
-	inCh := g.AddChannel(acceptByType)
 	outCh := make(chan *proto.GossipMessage, acceptChanSize)
-	go func() {
-		for {
-			select {
-			case s := <-g.toDieChan:
-				g.toDieChan <- s
-				return
-			case m := <-inCh:
-				if m == nil {
-					return
-				}
-				outCh <- m.(*proto.SignedGossipMessage).GossipMessage
-			}
+	g.AddSubscriber(acceptByType, func(m interface{}) {
+		select {
+		case outCh <- m.(*proto.SignedGossipMessage).GossipMessage:
+		case s := <-g.toDieChan:
+			g.toDieChan <- s
 		}
-	}()
+	})
 	return outCh, nil
 }
 
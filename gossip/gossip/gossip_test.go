@@ -1628,3 +1628,37 @@ func checkPeersMembership(t *testing.T, peers []Gossip, n int) func() bool {
 		return true
 	}
 }
+
+type syncRecordingDiscovery struct {
+	discovery.Discovery
+	g          *gossipServiceImpl
+	membership []discovery.NetworkMember
+	peerNums   []int
+}
+
+func (d *syncRecordingDiscovery) GetMembership() []discovery.NetworkMember {
+	return d.membership
+}
+
+func (d *syncRecordingDiscovery) InitiateSync(peerNum int) {
+	d.peerNums = append(d.peerNums, peerNum)
+	atomic.StoreInt32(&d.g.stopFlag, int32(1))
+}
+
+func TestSyncDiscoveryPullPeerNum(t *testing.T) {
+	t.Parallel()
+	membership := make([]discovery.NetworkMember, 400)
+	for _, testCase := range []struct {
+		pullPeerNum int
+		expected    int
+	}{
+		{pullPeerNum: 5, expected: 5},
+		{pullPeerNum: 0, expected: 9},
+	} {
+		g := &gossipServiceImpl{conf: &Config{PullPeerNum: testCase.pullPeerNum}, logger: util.GetLogger(util.LoggingGossipModule, "")}
+		disc := &syncRecordingDiscovery{g: g, membership: membership}
+		g.disc = disc
+		g.syncDiscovery()
+		assert.Equal(t, []int{testCase.expected}, disc.peerNums)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/util"
 )
 
 var noopLock = func() {}
@@ -50,7 +51,7 @@ func NewMessageStoreExpirable(pol common.MessageReplacingPolicy, trigger invalid
 		store.expireMsgCallback = externalExpire
 	}
 
-	go store.expirationRoutine()
+	store.expirationTask = util.DefaultScheduler.Schedule(store.expirationCheckInterval(), store.expire)
 	return store
 }
 
@@ -64,8 +65,6 @@ func newMsgStore(pol common.MessageReplacingPolicy, trigger invalidationTrigger)
 		externalUnlock:    noopLock,
 		expireMsgCallback: func(m interface{}) {},
 		expiredCount:      0,
-
-		doneCh: make(chan struct{}),
 	}
 }
 
@@ -108,8 +107,7 @@ type messageStoreImpl struct {
 	externalLock      func()
 	externalUnlock    func()
 	expireMsgCallback func(msg interface{})
-	doneCh            chan struct{}
-	stopOnce          sync.Once
+	expirationTask    *util.ScheduledTask
 }
 
 type msg struct {
@@ -235,32 +233,25 @@ func (s *messageStoreImpl) isPurgeNeeded(shouldBePurged func(*msg) bool) bool {
 	return false
 }
 
-func (s *messageStoreImpl) expirationRoutine() {
-	for {
-		select {
-		case <-s.doneCh:
-			return
-		case <-time.After(s.expirationCheckInterval()):
-			hasMessageExpired := func(m *msg) bool {
-				if !m.expired && time.Since(m.created) > s.msgTTL {
-					return true
-				} else if time.Since(m.created) > (s.msgTTL * 2) {
-					return true
-				}
-				return false
-			}
-			if s.isPurgeNeeded(hasMessageExpired) {
-				s.expireMessages()
-			}
+// expire is run periodically by the shared scheduler
+func (s *messageStoreImpl) expire() {
+	hasMessageExpired := func(m *msg) bool {
+		if !m.expired && time.Since(m.created) > s.msgTTL {
+			return true
+		} else if time.Since(m.created) > (s.msgTTL * 2) {
+			return true
 		}
+		return false
+	}
+	if s.isPurgeNeeded(hasMessageExpired) {
+		s.expireMessages()
 	}
 }
 
 func (s *messageStoreImpl) Stop() {
-	stopFunc := func() {
-		close(s.doneCh)
+	if s.expirationTask != nil {
+		s.expirationTask.Cancel()
 	}
-	s.stopOnce.Do(stopFunc)
 }
 
 func (s *messageStoreImpl) expirationCheckInterval() time.Duration {
@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/comm"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/filter"
 	"github.com/hyperledger/fabric/gossip/gossip/algo"
 	"github.com/hyperledger/fabric/gossip/util"
 	proto "github.com/hyperledger/fabric/protos/gossip"
@@ -51,7 +52,7 @@ type Config struct {
 	ID                string
 	PullInterval      time.Duration // Duration between pull invocations
 	Channel           common.ChainID
-	PeerCountToSelect int // Number of peers to initiate pull with
+	PeerCountToSelect int // Number of peers to initiate pull with, adapted to the number of peers if not positive
 	Tag               proto.GossipMessage_Tag
 	MsgType           proto.PullMsgType
 }
@@ -241,7 +242,8 @@ func (p *pullMediatorImpl) Remove(digest string) {
 
 // SelectPeers returns a slice of peers which the engine will initiate the protocol with
 func (p *pullMediatorImpl) SelectPeers() []string {
-	remotePeers := SelectEndpoints(p.config.PeerCountToSelect, p.MemSvc.GetMembership())
+	membership := p.MemSvc.GetMembership()
+	remotePeers := SelectEndpoints(filter.AdaptivePeerNum(p.config.PeerCountToSelect, len(membership)), membership)
 	endpoints := make([]string, len(remotePeers))
 	for i, peer := range remotePeers {
 		endpoints[i] = peer.Endpoint
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/hyperledger/fabric/common/viperutil"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/gossip/api"
 	gossipCommon "github.com/hyperledger/fabric/gossip/common"
@@ -24,7 +25,6 @@ import (
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/transientstore"
 	"github.com/pkg/errors"
-	"github.com/spf13/viper"
 )
 
 // gossipAdapter an adapter for API's required from gossip module
@@ -189,7 +189,7 @@ func (d *distributorImpl) disseminationPlanForMsg(colAP privdata.CollectionAcces
 	}
 
 	sc := gossip2.SendCriteria{
-		Timeout:  viper.GetDuration("peer.gossip.pvtData.pushAckTimeout"),
+		Timeout:  viperutil.Reloadable.GetDuration("peer.gossip.pvtData.pushAckTimeout"),
 		Channel:  gossipCommon.ChainID(d.chainID),
 		MaxPeers: colAP.MaximumPeerCount(),
 		MinAck:   colAP.RequiredPeerCount(),
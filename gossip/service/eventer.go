@@ -27,6 +27,9 @@ type Config interface {
 
 	// OrdererAddresses returns the list of valid orderer addresses to connect to to invoke Broadcast/Deliver
 	OrdererAddresses() []string
+
+	// Capabilities returns the application capabilities of the channel
+	Capabilities() channelconfig.ApplicationCapabilities
 }
 
 // ConfigProcessor receives config updates
@@ -36,8 +39,9 @@ type ConfigProcessor interface {
 }
 
 type configStore struct {
-	anchorPeers []*peer.AnchorPeer
-	orgMap      map[string]channelconfig.ApplicationOrg
+	anchorPeers        []*peer.AnchorPeer
+	orgMap             map[string]channelconfig.ApplicationOrg
+	defaultAnchorPeers bool
 }
 
 type configEventReceiver interface {
@@ -63,7 +67,8 @@ func newConfigEventer(receiver configEventReceiver) *configEventer {
 func (ce *configEventer) ProcessConfigUpdate(config Config) {
 	logger.Debugf("Processing new config for channel %s", config.ChainID())
 	orgMap := cloneOrgConfig(config.Organizations())
-	if ce.lastConfig != nil && reflect.DeepEqual(ce.lastConfig.orgMap, orgMap) {
+	defaultAnchorPeers := config.Capabilities().DefaultAnchorPeers()
+	if ce.lastConfig != nil && reflect.DeepEqual(ce.lastConfig.orgMap, orgMap) && ce.lastConfig.defaultAnchorPeers == defaultAnchorPeers {
 		logger.Debugf("Ignoring new config for channel %s because it contained no anchor peer updates", config.ChainID())
 	} else {
 
@@ -73,8 +78,9 @@ func (ce *configEventer) ProcessConfigUpdate(config Config) {
 		}
 
 		newConfig := &configStore{
-			orgMap:      orgMap,
-			anchorPeers: newAnchorPeers,
+			orgMap:             orgMap,
+			anchorPeers:        newAnchorPeers,
+			defaultAnchorPeers: defaultAnchorPeers,
 		}
 		ce.lastConfig = newConfig
 
@@ -88,18 +94,20 @@ func cloneOrgConfig(src map[string]channelconfig.ApplicationOrg) map[string]chan
 	clone := make(map[string]channelconfig.ApplicationOrg)
 	for k, v := range src {
 		clone[k] = &appGrp{
-			name:        v.Name(),
-			mspID:       v.MSPID(),
-			anchorPeers: v.AnchorPeers(),
+			name:          v.Name(),
+			mspID:         v.MSPID(),
+			anchorPeers:   v.AnchorPeers(),
+			peerEndpoints: v.PeerEndpoints(),
 		}
 	}
 	return clone
 }
 
 type appGrp struct {
-	name        string
-	mspID       string
-	anchorPeers []*peer.AnchorPeer
+	name          string
+	mspID         string
+	anchorPeers   []*peer.AnchorPeer
+	peerEndpoints []string
 }
 
 func (ag *appGrp) Name() string {
@@ -113,3 +121,7 @@ func (ag *appGrp) MSPID() string {
 func (ag *appGrp) AnchorPeers() []*peer.AnchorPeer {
 	return ag.anchorPeers
 }
+
+func (ag *appGrp) PeerEndpoints() []string {
+	return ag.peerEndpoints
+}
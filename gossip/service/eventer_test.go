@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/hyperledger/fabric/protos/peer"
 )
@@ -22,14 +23,16 @@ func init() {
 }
 
 type mockReceiver struct {
-	orgs     map[string]channelconfig.ApplicationOrg
-	sequence uint64
+	orgs               map[string]channelconfig.ApplicationOrg
+	sequence           uint64
+	defaultAnchorPeers bool
 }
 
 func (mr *mockReceiver) updateAnchors(config Config) {
 	logger.Debugf("[TEST] Setting config to %d %v", config.Sequence(), config.Organizations())
 	mr.orgs = config.Organizations()
 	mr.sequence = config.Sequence()
+	mr.defaultAnchorPeers = config.Capabilities().DefaultAnchorPeers()
 }
 
 func (mr *mockReceiver) updateEndpoints(chainID string, endpoints []string) {
@@ -53,6 +56,10 @@ func (mc *mockConfig) ChainID() string {
 	return testChainID
 }
 
+func (mc *mockConfig) Capabilities() channelconfig.ApplicationCapabilities {
+	return &mockconfig.MockApplicationCapabilities{DefaultAnchorPeersRv: mc.defaultAnchorPeers}
+}
+
 const testOrgID = "testID"
 
 func TestInitialUpdate(t *testing.T) {
@@ -155,3 +162,26 @@ func TestUpdatedSeqOnly(t *testing.T) {
 		t.Errorf("Should not have cleared anchor peers when reprocessing newer config with higher sequence")
 	}
 }
+
+func TestUpdatedDefaultAnchorPeersOnly(t *testing.T) {
+	mc := &mockConfig{
+		sequence: 7,
+		orgs: map[string]channelconfig.ApplicationOrg{
+			testOrgID: &appGrp{
+				peerEndpoints: []string{"localhost:9"},
+			},
+		},
+	}
+
+	mr := &mockReceiver{}
+
+	ce := newConfigEventer(mr)
+	ce.ProcessConfigUpdate(mc)
+	mc.sequence = 9
+	mc.defaultAnchorPeers = true
+	ce.ProcessConfigUpdate(mc)
+
+	if !reflect.DeepEqual(mc, (*mockConfig)(mr)) {
+		t.Errorf("Should have updated config when the defaulting of the anchor peers was enabled")
+	}
+}
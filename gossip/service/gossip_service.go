@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package service
 
 import (
+	"net"
+	"strconv"
 	"sync"
 
 	"github.com/hyperledger/fabric/core/committer"
@@ -50,6 +52,9 @@ type GossipService interface {
 	InitializeChannel(chainID string, endpoints []string, support Support)
 	// AddPayload appends message payload to for given chain
 	AddPayload(chainID string, payload *gproto.Payload) error
+	// LeaveChannel stops the state provider and the blocks delivery of the given chain,
+	// and makes the peer leave the channel
+	LeaveChannel(chainID string)
 }
 
 // DeliveryServiceFactory factory to create and initialize delivery service instance
@@ -313,6 +318,7 @@ func (g *gossipServiceImpl) updateAnchors(config Config) {
 			"among the orgs of the channel:", orgListFromConfig(config), ", aborting.")
 		return
 	}
+	defaultAnchorPeers := config.Capabilities().DefaultAnchorPeers()
 	jcm := &joinChannelMessage{seqNum: config.Sequence(), members2AnchorPeers: map[string][]api.AnchorPeer{}}
 	for _, appOrg := range config.Organizations() {
 		logger.Debug(appOrg.MSPID(), "anchor peers:", appOrg.AnchorPeers())
@@ -324,6 +330,10 @@ func (g *gossipServiceImpl) updateAnchors(config Config) {
 			}
 			jcm.members2AnchorPeers[appOrg.MSPID()] = append(jcm.members2AnchorPeers[appOrg.MSPID()], anchorPeer)
 		}
+		if defaultAnchorPeers && len(appOrg.AnchorPeers()) == 0 {
+			logger.Debug(appOrg.MSPID(), "defines no anchor peers, using its peer endpoints:", appOrg.PeerEndpoints())
+			jcm.members2AnchorPeers[appOrg.MSPID()] = endpointsToAnchorPeers(appOrg.PeerEndpoints())
+		}
 	}
 
 	// Initialize new state provider for given committer
@@ -331,6 +341,26 @@ func (g *gossipServiceImpl) updateAnchors(config Config) {
 	g.JoinChan(jcm, gossipCommon.ChainID(config.ChainID()))
 }
 
+// endpointsToAnchorPeers converts the host:port peer endpoints of an org to anchor peers,
+// skipping the malformed ones
+func endpointsToAnchorPeers(endpoints []string) []api.AnchorPeer {
+	anchorPeers := []api.AnchorPeer{}
+	for _, endpoint := range endpoints {
+		host, portStr, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			logger.Warningf("Skipping malformed peer endpoint %s: %s", endpoint, err)
+			continue
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			logger.Warningf("Skipping peer endpoint %s with malformed port: %s", endpoint, err)
+			continue
+		}
+		anchorPeers = append(anchorPeers, api.AnchorPeer{Host: host, Port: port})
+	}
+	return anchorPeers
+}
+
 func (g *gossipServiceImpl) updateEndpoints(chainID string, endpoints []string) {
 	if ds, ok := g.deliveryService[chainID]; ok {
 		logger.Debugf("Updating endpoints for chainID", chainID)
@@ -349,6 +379,34 @@ func (g *gossipServiceImpl) AddPayload(chainID string, payload *gproto.Payload)
 	return g.chains[chainID].AddPayload(payload)
 }
 
+// LeaveChannel stops the state provider and the blocks delivery of the given chain,
+// and makes the peer leave the channel
+func (g *gossipServiceImpl) LeaveChannel(chainID string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	logger.Info("Leaving channel", chainID)
+	if le, exists := g.leaderElection[chainID]; exists {
+		le.Stop()
+		delete(g.leaderElection, chainID)
+	}
+	if ds, exists := g.deliveryService[chainID]; exists {
+		if ds != nil {
+			ds.Stop()
+		}
+		delete(g.deliveryService, chainID)
+	}
+	if sp, exists := g.chains[chainID]; exists {
+		sp.Stop()
+		delete(g.chains, chainID)
+	}
+	if ph, exists := g.privateHandlers[chainID]; exists {
+		ph.close()
+		delete(g.privateHandlers, chainID)
+	}
+	g.LeaveChan(gossipCommon.ChainID(chainID))
+}
+
 // Stop stops the gossip component
 func (g *gossipServiceImpl) Stop() {
 	g.lock.Lock()
@@ -81,7 +81,7 @@ func TestInitGossipService(t *testing.T) {
 	for i := 0; i < 10; i++ {
 		go func() {
 			defer wg.Done()
-			messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager())
+			messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager(), nil)
 			secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager())
 			err := InitGossipService(identity, "localhost:5611", grpcServer, nil, messageCryptoService,
 				secAdv, nil)
@@ -305,6 +305,40 @@ func TestWithStaticDeliverClientBothStaticAndLeaderElection(t *testing.T) {
 	stopPeers(gossips)
 }
 
+func TestLeaveChannel(t *testing.T) {
+	// Scenario: A peer joins two channels and leaves one of them.
+	// The state provider and the delivery service of the channel left are
+	// removed, while the other channel is untouched.
+	viper.Set("peer.gossip.useLeaderElection", false)
+	viper.Set("peer.gossip.orgLeader", true)
+
+	gossips := startPeers(t, 1, 20600)
+	defer stopPeers(gossips)
+	g := gossips[0].(*gossipServiceImpl)
+	g.deliveryFactory = &mockDeliverServiceFactory{
+		service: &mockDeliverService{
+			running: make(map[string]bool),
+		},
+	}
+	for _, channelName := range []string{"chanA", "chanB"} {
+		addPeersToChannel(t, 1, 20600, channelName, gossips, []int{0})
+		g.InitializeChannel(channelName, []string{"localhost:5005"}, Support{
+			Committer: &mockLedgerInfo{1},
+			Store:     &mockTransientStore{},
+		})
+	}
+
+	g.LeaveChannel("chanA")
+	assert.NotContains(t, g.chains, "chanA")
+	assert.NotContains(t, g.deliveryService, "chanA")
+	assert.NotContains(t, g.privateHandlers, "chanA")
+	assert.Contains(t, g.chains, "chanB")
+	assert.Contains(t, g.deliveryService, "chanB")
+	assert.Contains(t, g.privateHandlers, "chanB")
+	// Leaving a channel twice does nothing
+	g.LeaveChannel("chanA")
+}
+
 type mockDeliverServiceFactory struct {
 	service *mockDeliverService
 }
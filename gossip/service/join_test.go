@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
 	"github.com/hyperledger/fabric/gossip/common"
@@ -84,6 +85,12 @@ func (*gossipMock) UpdateChaincodes(chaincode []*proto.Chaincode, chainID common
 	panic("implement me")
 }
 
+// UpdateCheckpoint updates the checkpoint the peer publishes
+// to other peers in the channel
+func (*gossipMock) UpdateCheckpoint(checkpoint *proto.Checkpoint, chainID common.ChainID) {
+	panic("implement me")
+}
+
 func (*gossipMock) Gossip(msg *proto.GossipMessage) {
 	panic("implement me")
 }
@@ -113,7 +120,9 @@ func (*gossipMock) SendByCriteria(*proto.SignedGossipMessage, gossip.SendCriteri
 }
 
 type appOrgMock struct {
-	id string
+	id          string
+	anchorPeers []*peer.AnchorPeer
+	endpoints   []string
 }
 
 func (*appOrgMock) Name() string {
@@ -125,11 +134,19 @@ func (ao *appOrgMock) MSPID() string {
 }
 
 func (ao *appOrgMock) AnchorPeers() []*peer.AnchorPeer {
-	return []*peer.AnchorPeer{}
+	if ao.anchorPeers == nil {
+		return []*peer.AnchorPeer{}
+	}
+	return ao.anchorPeers
+}
+
+func (ao *appOrgMock) PeerEndpoints() []string {
+	return ao.endpoints
 }
 
 type configMock struct {
-	orgs2AppOrgs map[string]channelconfig.ApplicationOrg
+	orgs2AppOrgs       map[string]channelconfig.ApplicationOrg
+	defaultAnchorPeers bool
 }
 
 func (c *configMock) OrdererAddresses() []string {
@@ -148,6 +165,10 @@ func (*configMock) Sequence() uint64 {
 	return 0
 }
 
+func (c *configMock) Capabilities() channelconfig.ApplicationCapabilities {
+	return &mockconfig.MockApplicationCapabilities{DefaultAnchorPeersRv: c.defaultAnchorPeers}
+}
+
 func TestJoinChannelConfig(t *testing.T) {
 	// Scenarios: The channel we're joining has a single org - Org0
 	// but our org ID is actually Org0MSP in the negative path
@@ -224,3 +245,37 @@ func TestJoinChannelNoAnchorPeers(t *testing.T) {
 	})
 	joinChanCalled.Wait()
 }
+
+func TestJoinChannelDefaultAnchorPeers(t *testing.T) {
+	// Scenario: The channel we're joining has 2 orgs: Org0 with an anchor peer and
+	// Org1 with no anchor peers but with peer endpoints.
+	// The test ensures that the peer endpoints of Org1 are used as its anchor peers
+	// only if the channel has the capability to do so.
+
+	org0 := &appOrgMock{id: "Org0", anchorPeers: []*peer.AnchorPeer{{Host: "p0", Port: 5611}}, endpoints: []string{"p1:5611"}}
+	org1 := &appOrgMock{id: "Org1", endpoints: []string{"p2:5611", "p3", "p4:port", "p5:5612"}}
+
+	for _, defaultAnchorPeers := range []bool{false, true} {
+		jcmChan := make(chan api.JoinChannelMessage, 1)
+		gMock := &gossipMock{}
+		gMock.On("JoinChan", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			jcmChan <- args.Get(0).(api.JoinChannelMessage)
+		})
+		g := &gossipServiceImpl{secAdv: &secAdvMock{}, peerIdentity: api.PeerIdentityType("Org0"), gossipSvc: gMock}
+		g.updateAnchors(&configMock{
+			orgs2AppOrgs: map[string]channelconfig.ApplicationOrg{
+				"Org0": org0,
+				"Org1": org1,
+			},
+			defaultAnchorPeers: defaultAnchorPeers,
+		})
+
+		jcm := <-jcmChan
+		assert.Equal(t, []api.AnchorPeer{{Host: "p0", Port: 5611}}, jcm.AnchorPeersOf(api.OrgIdentityType("Org0")))
+		if defaultAnchorPeers {
+			assert.Equal(t, []api.AnchorPeer{{Host: "p2", Port: 5611}, {Host: "p5", Port: 5612}}, jcm.AnchorPeersOf(api.OrgIdentityType("Org1")))
+		} else {
+			assert.Empty(t, jcm.AnchorPeersOf(api.OrgIdentityType("Org1")))
+		}
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+
+	"github.com/hyperledger/fabric/protos/common"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+)
+
+// checkpointer computes the checkpoints the peer publishes to the other peers
+// of the channel, at the heights which are multiples of the checkpoint
+// interval. The checksum of a checkpoint covers the header hashes and the
+// transaction validation codes of the blocks committed since the previous
+// checkpoint. As the state of the ledger derives from the blocks and the
+// validation codes of their transactions, peers whose checksums differ at the
+// same height have diverged, for instance because they validated a
+// transaction differently.
+type checkpointer struct {
+	interval uint64
+	// checksum is nil until the first block of a checkpoint interval is
+	// committed, so that no checkpoint is computed out of an incomplete
+	// interval when the peer starts in the middle of one
+	checksum hash.Hash
+}
+
+// newCheckpointer returns a checkpointer for the given interval, or nil if
+// the interval is not positive, which disables the checkpoints
+func newCheckpointer(interval int) *checkpointer {
+	if interval <= 0 {
+		return nil
+	}
+	return &checkpointer{interval: uint64(interval)}
+}
+
+// committed adds the given committed block to the checksum of the current
+// interval, and returns the checkpoint if the block completes it
+func (c *checkpointer) committed(block *common.Block) *proto.Checkpoint {
+	if c == nil {
+		return nil
+	}
+	number := block.Header.Number
+	if number%c.interval == 0 {
+		c.checksum = sha256.New()
+	}
+	if c.checksum == nil {
+		return nil
+	}
+
+	numberBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(numberBytes, number)
+	c.checksum.Write(numberBytes)
+	c.checksum.Write(block.Header.Hash())
+	if len(block.GetMetadata().GetMetadata()) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		c.checksum.Write(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	if (number+1)%c.interval != 0 {
+		return nil
+	}
+	checkpoint := &proto.Checkpoint{
+		Height:   number + 1,
+		Checksum: c.checksum.Sum(nil),
+	}
+	c.checksum = nil
+	return checkpoint
+}
@@ -0,0 +1,63 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"testing"
+
+	pcomm "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCheckpointTestBlock(number uint64, validationCode peer.TxValidationCode) *pcomm.Block {
+	block := pcomm.NewBlock(number, []byte{1, 2, 3})
+	block.Data.Data = [][]byte{{4, 5, 6}}
+	block.Header.DataHash = block.Data.Hash()
+	block.Metadata.Metadata[pcomm.BlockMetadataIndex_TRANSACTIONS_FILTER] = []byte{uint8(validationCode)}
+	return block
+}
+
+func TestCheckpointer(t *testing.T) {
+	assert.Nil(t, newCheckpointer(0))
+	assert.Nil(t, newCheckpointer(-1))
+	// A disabled checkpointer never publishes checkpoints
+	var disabled *checkpointer
+	assert.Nil(t, disabled.committed(newCheckpointTestBlock(0, peer.TxValidationCode_VALID)))
+
+	commit := func(c *checkpointer, from, to uint64, invalid uint64) []uint64 {
+		var heights []uint64
+		for number := from; number < to; number++ {
+			validationCode := peer.TxValidationCode_VALID
+			if number == invalid {
+				validationCode = peer.TxValidationCode_MVCC_READ_CONFLICT
+			}
+			if checkpoint := c.committed(newCheckpointTestBlock(number, validationCode)); checkpoint != nil {
+				heights = append(heights, checkpoint.Height)
+			}
+		}
+		return heights
+	}
+
+	// A peer publishes a checkpoint at every multiple of the interval
+	c := newCheckpointer(5)
+	assert.Equal(t, []uint64{5, 10, 15}, commit(c, 0, 17, 100))
+
+	// A peer starting in the middle of an interval waits for the next one
+	c = newCheckpointer(5)
+	assert.Equal(t, []uint64{10, 15}, commit(c, 3, 17, 100))
+
+	// Peers which committed the same blocks publish the same checksums, unless
+	// they validated a transaction differently
+	checksum := func(invalid uint64) []byte {
+		c := newCheckpointer(5)
+		commit(c, 0, 4, invalid)
+		return c.committed(newCheckpointTestBlock(4, peer.TxValidationCode_VALID)).Checksum
+	}
+	assert.Equal(t, checksum(100), checksum(100))
+	assert.NotEqual(t, checksum(100), checksum(2))
+}
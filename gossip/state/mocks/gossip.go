@@ -49,6 +49,12 @@ func (g *GossipMock) UpdateChaincodes(chaincode []*proto.Chaincode, chainID comm
 
 }
 
+// UpdateCheckpoint updates the checkpoint the peer publishes
+// to other peers in the channel
+func (g *GossipMock) UpdateCheckpoint(checkpoint *proto.Checkpoint, chainID common.ChainID) {
+
+}
+
 func (g *GossipMock) LeaveChan(_ common.ChainID) {
 	panic("implement me")
 }
@@ -14,6 +14,8 @@ import (
 
 	pb "github.com/golang/protobuf/proto"
 	vsccErrors "github.com/hyperledger/fabric/common/errors"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/viperutil"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
 	common2 "github.com/hyperledger/fabric/gossip/common"
@@ -24,7 +26,6 @@ import (
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/transientstore"
 	"github.com/pkg/errors"
-	"github.com/spf13/viper"
 )
 
 // GossipStateProvider is the interface to acquire sequences of the ledger blocks
@@ -47,6 +48,8 @@ const (
 
 	defMaxBlockDistance = 100
 
+	defMetricsReportInterval = 10 * time.Second
+
 	blocking    = true
 	nonBlocking = false
 
@@ -68,6 +71,10 @@ type GossipAdapter interface {
 	// publishes to other peers in the channel
 	UpdateLedgerHeight(height uint64, chainID common2.ChainID)
 
+	// UpdateCheckpoint updates the checkpoint the peer
+	// publishes to other peers in the channel
+	UpdateCheckpoint(checkpoint *proto.Checkpoint, chainID common2.ChainID)
+
 	// PeersOfChannel returns the NetworkMembers considered alive
 	// and also subscribed to the channel given
 	PeersOfChannel(common2.ChainID) []discovery.NetworkMember
@@ -140,6 +147,14 @@ type GossipStateProviderImpl struct {
 
 	stateRequestCh chan proto.ReceivedMessage
 
+	channelBufferSize int
+
+	metricsScope metrics.Scope
+
+	metricsTask *util.ScheduledTask
+
+	checkpoints *checkpointer
+
 	stopCh chan struct{}
 
 	done sync.WaitGroup
@@ -197,6 +212,8 @@ func NewGossipStateProvider(chainID string, services *ServicesMediator, ledger l
 		return nil
 	}
 
+	channelBufferSize := util.GetIntOrDefault("peer.gossip.state.channelSize", defChannelBufferSize)
+
 	s := &GossipStateProviderImpl{
 		// MessageCryptoService
 		mediator: services,
@@ -215,9 +232,15 @@ func NewGossipStateProvider(chainID string, services *ServicesMediator, ledger l
 
 		ledger: ledger,
 
-		stateResponseCh: make(chan proto.ReceivedMessage, defChannelBufferSize),
+		stateResponseCh: make(chan proto.ReceivedMessage, channelBufferSize),
+
+		stateRequestCh: make(chan proto.ReceivedMessage, channelBufferSize),
 
-		stateRequestCh: make(chan proto.ReceivedMessage, defChannelBufferSize),
+		channelBufferSize: channelBufferSize,
+
+		metricsScope: metrics.SubScope("gossip").Tagged(map[string]string{"channel": chainID}),
+
+		checkpoints: newCheckpointer(util.GetIntOrDefault("peer.gossip.state.checkpointInterval", 0)),
 
 		stopCh: make(chan struct{}, 1),
 
@@ -241,10 +264,20 @@ func NewGossipStateProvider(chainID string, services *ServicesMediator, ledger l
 	go s.antiEntropy()
 	// Taking care of state request messages
 	go s.processStateRequests()
+	// Report the state of the channel on the goroutines shared by the channels
+	s.metricsTask = util.DefaultScheduler.Schedule(defMetricsReportInterval, s.reportMetrics)
 
 	return s
 }
 
+// reportMetrics reports the number of payloads buffered until they can be
+// committed and the number of state requests and responses queued
+func (s *GossipStateProviderImpl) reportMetrics() {
+	s.metricsScope.Gauge("state_payload_buffer_size").Update(float64(s.payloads.Size()))
+	s.metricsScope.Gauge("state_request_queue_size").Update(float64(len(s.stateRequestCh)))
+	s.metricsScope.Gauge("state_response_queue_size").Update(float64(len(s.stateResponseCh)))
+}
+
 func (s *GossipStateProviderImpl) listen() {
 	defer s.done.Done()
 
@@ -345,7 +378,7 @@ func (s *GossipStateProviderImpl) directMessage(msg proto.ReceivedMessage) {
 	incoming := msg.GetGossipMessage()
 
 	if incoming.GetStateRequest() != nil {
-		if len(s.stateRequestCh) < defChannelBufferSize {
+		if len(s.stateRequestCh) < s.channelBufferSize {
 			// Forward state request to the channel, if there are too
 			// many message of state request ignore to avoid flooding.
 			s.stateRequestCh <- msg
@@ -493,6 +526,7 @@ func (s *GossipStateProviderImpl) Stop() {
 	// Make sure stop won't be executed twice
 	// and stop channel won't be used again
 	s.once.Do(func() {
+		s.metricsTask.Cancel()
 		s.stopCh <- struct{}{}
 		// Make sure all go-routines has finished
 		s.done.Wait()
@@ -560,6 +594,10 @@ func (s *GossipStateProviderImpl) deliverPayloads() {
 						logger.Errorf("Failed executing VSCC due to %v. Aborting chain processing", executionErr)
 						return
 					}
+					if capabilitiesErr, isCapabilitiesErr := errors.Cause(err).(*vsccErrors.UnsupportedCapabilitiesError); isCapabilitiesErr {
+						logger.Errorf("Channel stalled: %v. Aborting chain processing", capabilitiesErr)
+						return
+					}
 					logger.Panicf("Cannot commit block to the ledger due to %+v", errors.WithStack(err))
 				}
 			}
@@ -733,7 +771,7 @@ func (s *GossipStateProviderImpl) hasRequiredHeight(height uint64) func(peer dis
 // AddPayload add new payload into state.
 func (s *GossipStateProviderImpl) AddPayload(payload *proto.Payload) error {
 	blockingMode := blocking
-	if viper.GetBool("peer.gossip.nonBlockingCommitMode") {
+	if viperutil.Reloadable.GetBool("peer.gossip.nonBlockingCommitMode") {
 		blockingMode = false
 	}
 	return s.addPayload(payload, blockingMode)
@@ -775,6 +813,12 @@ func (s *GossipStateProviderImpl) commitBlock(block *common.Block, pvtData util.
 
 	// Update ledger height
 	s.mediator.UpdateLedgerHeight(block.Header.Number+1, common2.ChainID(s.chainID))
+
+	// Publish the checkpoint if the block completes one
+	if checkpoint := s.checkpoints.committed(block); checkpoint != nil {
+		logger.Debugf("[%s] Publishing checkpoint at height %d with checksum %x", s.chainID, checkpoint.Height, checkpoint.Checksum)
+		s.mediator.UpdateCheckpoint(checkpoint, common2.ChainID(s.chainID))
+	}
 	logger.Debugf("[%s] Committed block [%d] with %d transaction(s)",
 		s.chainID, block.Header.Number, len(block.Data.Data))
 
@@ -8,7 +8,6 @@ package state
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"math/rand"
 	"sync"
@@ -39,6 +38,7 @@ import (
 	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	transientstore2 "github.com/hyperledger/fabric/protos/transientstore"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -729,6 +729,22 @@ func TestHaltChainProcessing(t *testing.T) {
 	assertLogged(t, recorder, "Got error while committing")
 	assertLogged(t, recorder, "Aborting chain processing")
 	assertLogged(t, recorder, "foobar")
+
+	// a config block requiring unsupported capabilities stalls the channel
+	gossipMsgs = make(chan *proto.GossipMessage)
+	g = &mocks.GossipMock{}
+	g.On("Accept", mock.Anything, false).Return(gossipChannel(gossipMsgs), nil)
+	g.On("Accept", mock.Anything, true).Return(nil, make(chan proto.ReceivedMessage))
+	g.On("PeersOfChannel", mock.Anything).Return([]discovery.NetworkMember{})
+	v = &validator.MockValidator{}
+	v.On("Validate").Return(errors.WithMessage(&errors2.UnsupportedCapabilitiesError{
+		ChainID: "testchainid",
+		Err:     errors.New("application capability V9_9 is required but not supported"),
+	}, "error validating config which passed initial validity checks")).Once()
+	portPrefix = portStartRange + 550
+	newPeerNodeWithGossipWithValidator(newGossipConfig(portPrefix, 0), mc, noopPeerIdentityAcceptor, g, v)
+	gossipMsgs <- newBlockMsg(1)
+	assertLogged(t, recorder, "Channel stalled: channel [testchainid] requires unsupported capabilities: application capability V9_9 is required but not supported")
 }
 
 func TestFailures(t *testing.T) {
@@ -47,6 +47,7 @@ type Logger interface {
 	Panicf(format string, args ...interface{})
 	Warning(args ...interface{})
 	Warningf(format string, args ...interface{})
+	Warnw(msg string, kvPairs ...interface{})
 	IsEnabledFor(l zapcore.Level) bool
 }
 
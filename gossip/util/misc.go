@@ -17,6 +17,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hyperledger/fabric/common/viperutil"
 	"github.com/spf13/viper"
 )
 
@@ -150,7 +151,7 @@ func GetIntOrDefault(key string, defVal int) int {
 	viperLock.RLock()
 	defer viperLock.RUnlock()
 
-	if val := viper.GetInt(key); val != 0 {
+	if val := viperutil.Reloadable.GetInt(key); val != 0 {
 		return val
 	}
 
@@ -174,7 +175,7 @@ func GetDurationOrDefault(key string, defVal time.Duration) time.Duration {
 	viperLock.RLock()
 	defer viperLock.RUnlock()
 
-	if val := viper.GetDuration(key); val != 0 {
+	if val := viperutil.Reloadable.GetDuration(key); val != 0 {
 		return val
 	}
 
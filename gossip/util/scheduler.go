@@ -0,0 +1,194 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"container/heap"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// DefaultScheduler is the Scheduler shared by the periodic tasks of the
+// channels of the peer. Its tasks may briefly block on the locks of their
+// channel, hence it runs them on more goroutines than there are CPUs.
+var DefaultScheduler = NewScheduler(defaultSchedulerWorkers())
+
+func defaultSchedulerWorkers() int {
+	workers := 4 * runtime.NumCPU()
+	if workers < 16 {
+		workers = 16
+	}
+	return workers
+}
+
+// Scheduler runs periodic tasks on a bounded number of goroutines, so that the
+// number of goroutines of the peer does not grow with the number of channels
+// it joins. The goroutines are started as tasks are scheduled, and exit once
+// no task is left. A task is not run again before its previous run returns,
+// and is expected not to block.
+type Scheduler struct {
+	lock       sync.Mutex
+	tasks      taskQueue
+	workers    int
+	maxWorkers int
+	// changed is closed and replaced whenever the first task changes, to
+	// wake up the workers waiting for it
+	changed chan struct{}
+}
+
+// ScheduledTask is a task run periodically by a Scheduler
+type ScheduledTask struct {
+	fn        func()
+	interval  time.Duration
+	next      time.Time
+	index     int
+	running   bool
+	cancelled bool
+	scheduler *Scheduler
+}
+
+// NewScheduler creates a Scheduler running the tasks on up to maxWorkers goroutines
+func NewScheduler(maxWorkers int) *Scheduler {
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
+	return &Scheduler{
+		maxWorkers: maxWorkers,
+		changed:    make(chan struct{}),
+	}
+}
+
+// Schedule runs fn every interval, starting one interval from now, until the
+// returned task is cancelled
+func (s *Scheduler) Schedule(interval time.Duration, fn func()) *ScheduledTask {
+	t := &ScheduledTask{
+		fn:        fn,
+		interval:  interval,
+		next:      time.Now().Add(interval),
+		scheduler: s,
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	heap.Push(&s.tasks, t)
+	if t.index == 0 {
+		s.notify()
+	}
+	if s.workers < s.maxWorkers {
+		s.workers++
+		go s.work()
+	}
+	return t
+}
+
+// Size returns the number of tasks scheduled
+func (s *Scheduler) Size() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.tasks)
+}
+
+// Workers returns the number of goroutines running the tasks
+func (s *Scheduler) Workers() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.workers
+}
+
+// Cancel stops running the task. It does not wait for a run in progress to
+// return.
+func (t *ScheduledTask) Cancel() {
+	s := t.scheduler
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if t.cancelled {
+		return
+	}
+	t.cancelled = true
+	if !t.running {
+		heap.Remove(&s.tasks, t.index)
+		s.notify()
+	}
+}
+
+// notify wakes up the workers, it is invoked with the lock held
+func (s *Scheduler) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *Scheduler) work() {
+	s.lock.Lock()
+	for {
+		if len(s.tasks) == 0 {
+			s.workers--
+			s.lock.Unlock()
+			return
+		}
+
+		t := s.tasks[0]
+		if wait := time.Until(t.next); wait > 0 {
+			changed := s.changed
+			s.lock.Unlock()
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-changed:
+				timer.Stop()
+			}
+			s.lock.Lock()
+			continue
+		}
+
+		heap.Pop(&s.tasks)
+		t.running = true
+		s.lock.Unlock()
+
+		t.fn()
+
+		s.lock.Lock()
+		t.running = false
+		if !t.cancelled {
+			t.next = time.Now().Add(t.interval)
+			heap.Push(&s.tasks, t)
+		}
+	}
+}
+
+// taskQueue is a heap of tasks ordered by their next run
+type taskQueue []*ScheduledTask
+
+func (q taskQueue) Len() int {
+	return len(q)
+}
+
+func (q taskQueue) Less(i, j int) bool {
+	return q[i].next.Before(q[j].next)
+}
+
+func (q taskQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *taskQueue) Push(x interface{}) {
+	t := x.(*ScheduledTask)
+	t.index = len(*q)
+	*q = append(*q, t)
+}
+
+func (q *taskQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	t.index = -1
+	*q = old[:n-1]
+	return t
+}
@@ -0,0 +1,95 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler(t *testing.T) {
+	s := NewScheduler(2)
+
+	var fast, slow int32
+	fastTask := s.Schedule(10*time.Millisecond, func() {
+		atomic.AddInt32(&fast, 1)
+	})
+	slowTask := s.Schedule(100*time.Millisecond, func() {
+		atomic.AddInt32(&slow, 1)
+	})
+	assert.Equal(t, 2, s.Size())
+
+	time.Sleep(550 * time.Millisecond)
+	assert.True(t, atomic.LoadInt32(&fast) > 10, "fast task ran %d times", atomic.LoadInt32(&fast))
+	assert.InDelta(t, 5, atomic.LoadInt32(&slow), 1)
+
+	fastTask.Cancel()
+	fastTask.Cancel()
+	time.Sleep(20 * time.Millisecond)
+	ran := atomic.LoadInt32(&fast)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, ran, atomic.LoadInt32(&fast), "Cancelled task should not run")
+
+	// the workers exit once no task is left
+	slowTask.Cancel()
+	assert.Equal(t, 0, s.Size())
+	waitForWorkers(t, s, 0)
+
+	s.Schedule(10*time.Millisecond, func() {
+		atomic.AddInt32(&fast, 1)
+	})
+	time.Sleep(50 * time.Millisecond)
+	assert.True(t, atomic.LoadInt32(&fast) > ran, "Task scheduled after the workers exited should run")
+}
+
+func TestSchedulerBoundedWorkers(t *testing.T) {
+	s := NewScheduler(3)
+
+	var running, maxRunning, overlaps int32
+	tasks := make([]*ScheduledTask, 100)
+	for i := range tasks {
+		var inTask int32
+		tasks[i] = s.Schedule(time.Millisecond, func() {
+			if !atomic.CompareAndSwapInt32(&inTask, 0, 1) {
+				atomic.AddInt32(&overlaps, 1)
+			}
+			n := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			atomic.StoreInt32(&inTask, 0)
+		})
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, 3, s.Workers())
+	assert.True(t, atomic.LoadInt32(&maxRunning) <= 3, "%d tasks ran concurrently", atomic.LoadInt32(&maxRunning))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&overlaps), "A task should not run concurrently with itself")
+
+	for _, task := range tasks {
+		task.Cancel()
+	}
+	waitForWorkers(t, s, 0)
+}
+
+func waitForWorkers(t *testing.T, s *Scheduler, n int) {
+	for i := 0; i < 100; i++ {
+		if s.Workers() == n {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Fail(t, "Workers did not exit", "%d workers left", s.Workers())
+}
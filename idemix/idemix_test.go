@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
 	"github.com/stretchr/testify/assert"
 )
@@ -162,3 +163,78 @@ func TestIdemix(t *testing.T) {
 		return
 	}
 }
+
+func TestIdemixRevocation(t *testing.T) {
+	rng, err := GetRand()
+	assert.NoError(t, err)
+
+	AttributeNames := []string{"Attr1", "Attr2", "Attr3", "Attr4", "Attr5"}
+	attrs := make([]*FP256BN.BIG, len(AttributeNames))
+	for i := range AttributeNames {
+		attrs[i] = FP256BN.NewBIGint(i)
+	}
+	rhindex := 4
+	rh := attrs[rhindex]
+
+	key, err := NewIssuerKey(AttributeNames, rng)
+	assert.NoError(t, err)
+	sk := RandModOrder(rng)
+	cred, err := NewCredential(key, NewCredRequest(sk, RandModOrder(rng), key.Ipk, rng), attrs, rng)
+	assert.NoError(t, err)
+	Nym, RandNym := MakeNym(sk, key.Ipk, rng)
+
+	revocationKey, err := GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+
+	disclosure := []byte{0, 1, 1, 1, 0}
+	msg := []byte{1, 2, 3, 4, 5}
+
+	// In epoch 1 the handle is not revoked
+	cri, err := CreateCRI(revocationKey, []*FP256BN.BIG{FP256BN.NewBIGint(42), rh}, 1, ALG_PLAIN_SIGNATURE, rng)
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyEpochPK(&revocationKey.PublicKey, cri.EpochPk, cri.EpochPkSig, int(cri.Epoch), RevocationAlgorithm(cri.RevocationAlg)))
+
+	sig, err := NewSignature(cred, sk, Nym, RandNym, key.Ipk, disclosure, msg, rhindex, cri, rng)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(ALG_PLAIN_SIGNATURE), sig.NonRevocationProof.RevocationAlg)
+	assert.NoError(t, sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &revocationKey.PublicKey, 1))
+
+	// The signature is not valid in a different epoch
+	err = sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &revocationKey.PublicKey, 2)
+	assert.EqualError(t, err, "signature invalid: created in epoch 1, but the current epoch is 2")
+
+	// The signature is not valid under a different revocation authority
+	otherKey, err := GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+	err = sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &otherKey.PublicKey, 1)
+	assert.EqualError(t, err, "signature invalid: epoch key is not valid: EpochPKSig invalid")
+
+	// A tampered non-revocation proof is detected
+	proof := &PlainSigNonRevokedProof{}
+	assert.NoError(t, proto.Unmarshal(sig.NonRevocationProof.NonRevocationProof, proof))
+	proof.ProofSR = BigToBytes(RandModOrder(rng))
+	sig.NonRevocationProof.NonRevocationProof, err = proto.Marshal(proof)
+	assert.NoError(t, err)
+	err = sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &revocationKey.PublicKey, 1)
+	assert.EqualError(t, err, "signature invalid: zero-knowledge proof is invalid")
+
+	// A signer cannot claim that revocation is not used in the epoch
+	sig, err = NewSignature(cred, sk, Nym, RandNym, key.Ipk, disclosure, msg, rhindex, cri, rng)
+	assert.NoError(t, err)
+	sig.NonRevocationProof = &NonRevocationProof{RevocationAlg: int32(ALG_NO_REVOCATION)}
+	err = sig.Ver(disclosure, key.Ipk, msg, attrs, rhindex, &revocationKey.PublicKey, 1)
+	assert.EqualError(t, err, "signature invalid: epoch key is not valid: EpochPKSig invalid")
+
+	// In epoch 2 the handle is revoked
+	cri, err = CreateCRI(revocationKey, []*FP256BN.BIG{FP256BN.NewBIGint(42)}, 2, ALG_PLAIN_SIGNATURE, rng)
+	assert.NoError(t, err)
+	_, err = NewSignature(cred, sk, Nym, RandNym, key.Ipk, disclosure, msg, rhindex, cri, rng)
+	assert.EqualError(t, err, "failed to compute non-revoked proof: the revocation handle is not valid in epoch 2")
+
+	// The revocation handle must remain hidden
+	_, err = NewSignature(cred, sk, Nym, RandNym, key.Ipk, []byte{0, 0, 0, 0, 1}, msg, rhindex, cri, rng)
+	assert.Error(t, err)
+
+	_, err = CreateCRI(revocationKey, nil, 2, RevocationAlgorithm(100), rng)
+	assert.EqualError(t, err, "the specified revocation algorithm is not supported.")
+}
@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package idemix
 
 import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-amcl/amcl"
 	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
 	"github.com/pkg/errors"
@@ -27,10 +30,78 @@ func (prover *nopNonRevokedProver) getNonRevokedProof(chal *FP256BN.BIG) (*NonRe
 	return ret, nil
 }
 
+// plainSigNonRevokedProver proves knowledge of a weak Boneh-Boyen signature
+// sigma of the revocation authority on the (hidden) revocation handle rh.
+// The signature is randomized as sigma' = sigma^r, and the prover shows
+// knowledge of r and rh such that e(sigma', epochPk) = e(g1, g2)^r * e(sigma', g2)^{-rh}.
+type plainSigNonRevokedProver struct {
+	sigmaPrime *FP256BN.ECP
+	r          *FP256BN.BIG
+	rR         *FP256BN.BIG
+}
+
+func (prover *plainSigNonRevokedProver) getFSContribution(rh *FP256BN.BIG, rRh *FP256BN.BIG, cri *CredentialRevocationInformation, rng *amcl.RAND) ([]byte, error) {
+	revocationData := &PlainSigRevocationData{}
+	err := proto.Unmarshal(cri.RevocationData, revocationData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal revocation data")
+	}
+
+	rhBytes := BigToBytes(rh)
+	var sigma *FP256BN.ECP
+	for _, sig := range revocationData.Signatures {
+		if bytes.Equal(sig.RevocationHandle, rhBytes) {
+			sigma = EcpFromProto(sig.RhSig)
+			break
+		}
+	}
+	if sigma == nil {
+		return nil, errors.Errorf("the revocation handle is not valid in epoch %d", cri.Epoch)
+	}
+
+	prover.r = RandModOrder(rng)
+	prover.rR = RandModOrder(rng)
+	prover.sigmaPrime = FP256BN.G1mul(sigma, prover.r)
+
+	// t = e(g1^{rR} * sigma'^{-rRh}, g2)
+	tG1 := FP256BN.G1mul(GenG1, prover.rR)
+	tG1.Sub(FP256BN.G1mul(prover.sigmaPrime, rRh))
+	t := FP256BN.Fexp(FP256BN.Ate(GenG2, tG1))
+
+	return plainSigFSContribution(prover.sigmaPrime, t), nil
+}
+
+func (prover *plainSigNonRevokedProver) getNonRevokedProof(chal *FP256BN.BIG) (*NonRevocationProof, error) {
+	if prover.sigmaPrime == nil {
+		return nil, errors.New("non-revocation proof was not initialized")
+	}
+	proofBytes, err := proto.Marshal(&PlainSigNonRevokedProof{
+		SigmaPrime: EcpToProto(prover.sigmaPrime),
+		ProofSR:    BigToBytes(Modadd(prover.rR, FP256BN.Modmul(chal, prover.r, GroupOrder), GroupOrder)),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal non-revocation proof")
+	}
+	return &NonRevocationProof{
+		RevocationAlg:      int32(ALG_PLAIN_SIGNATURE),
+		NonRevocationProof: proofBytes,
+	}, nil
+}
+
+// plainSigFSContribution returns the bytes hashed into the Fiat-Shamir challenge
+func plainSigFSContribution(sigmaPrime *FP256BN.ECP, t *FP256BN.FP12) []byte {
+	data := make([]byte, ProofBytes[ALG_PLAIN_SIGNATURE])
+	index := appendBytesG1(data, 0, sigmaPrime)
+	t.ToBytes(data[index:])
+	return data
+}
+
 func getNonRevocationProver(algorithm RevocationAlgorithm) (nonRevokedProver, error) {
 	switch algorithm {
 	case ALG_NO_REVOCATION:
 		return &nopNonRevokedProver{}, nil
+	case ALG_PLAIN_SIGNATURE:
+		return &plainSigNonRevokedProver{}, nil
 	default:
 		// unknown revocation algorithm
 		return nil, errors.Errorf("unknown revocation algorithm %d", algorithm)
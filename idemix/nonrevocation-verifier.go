@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package idemix
 
 import (
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-amcl/amcl/FP256BN"
 	"github.com/pkg/errors"
 )
@@ -20,10 +21,40 @@ func (verifier *nopNonRevocationVerifier) recomputeFSContribution(proof *NonRevo
 	return nil, nil
 }
 
+type plainSigNonRevocationVerifier struct{}
+
+func (verifier *plainSigNonRevocationVerifier) recomputeFSContribution(proof *NonRevocationProof, chal *FP256BN.BIG, epochPK *FP256BN.ECP2, proofSRh *FP256BN.BIG) ([]byte, error) {
+	if epochPK == nil {
+		return nil, errors.Errorf("non-revocation proof invalid: received nil epoch key")
+	}
+	plainSigProof := &PlainSigNonRevokedProof{}
+	err := proto.Unmarshal(proof.NonRevocationProof, plainSigProof)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal non-revocation proof")
+	}
+	if plainSigProof.SigmaPrime == nil {
+		return nil, errors.Errorf("non-revocation proof invalid: missing sigma'")
+	}
+	sigmaPrime := EcpFromProto(plainSigProof.SigmaPrime)
+	if sigmaPrime.Is_infinity() {
+		return nil, errors.Errorf("non-revocation proof invalid: sigma' = 1")
+	}
+	proofSR := FP256BN.FromBytes(plainSigProof.ProofSR)
+
+	// t = e(g1^{sR} * sigma'^{-sRh}, g2) * e(sigma'^{-c}, epochPk)
+	tG1 := FP256BN.G1mul(GenG1, proofSR)
+	tG1.Sub(FP256BN.G1mul(sigmaPrime, proofSRh))
+	t := FP256BN.Fexp(FP256BN.Ate2(GenG2, tG1, epochPK, FP256BN.G1mul(sigmaPrime, FP256BN.Modneg(chal, GroupOrder))))
+
+	return plainSigFSContribution(sigmaPrime, t), nil
+}
+
 func getNonRevocationVerifier(algorithm RevocationAlgorithm) (nonRevocationVerifier, error) {
 	switch algorithm {
 	case ALG_NO_REVOCATION:
 		return &nopNonRevocationVerifier{}, nil
+	case ALG_PLAIN_SIGNATURE:
+		return &plainSigNonRevocationVerifier{}, nil
 	default:
 		// unknown revocation algorithm
 		return nil, errors.Errorf("unknown revocation algorithm %d", algorithm)
@@ -25,10 +25,16 @@ type RevocationAlgorithm int32
 
 const (
 	ALG_NO_REVOCATION RevocationAlgorithm = iota
+	// ALG_PLAIN_SIGNATURE lets the revocation authority sign every unrevoked
+	// revocation handle with the epoch key. Signers prove in zero-knowledge
+	// that they hold such a signature on the handle in their credential.
+	ALG_PLAIN_SIGNATURE
 )
 
 var ProofBytes = map[RevocationAlgorithm]int{
 	ALG_NO_REVOCATION: 0,
+	// sigma' (an element of G1) and the first message of the proof (an element of GT)
+	ALG_PLAIN_SIGNATURE: 2*FieldBytes + 1 + 12*FieldBytes,
 }
 
 // GenerateLongTermRevocationKey generates a long term signing key that will be used for revocation
@@ -40,6 +46,9 @@ func GenerateLongTermRevocationKey() (*ecdsa.PrivateKey, error) {
 // Users can use the CRI to prove that they are not revoked.
 // Note that when not using revocation (i.e., alg = ALG_NO_REVOCATION), the entered unrevokedHandles are not used,
 // and the resulting CRI can be used by any signer.
+// With ALG_PLAIN_SIGNATURE, only signers whose revocation handle is among the unrevokedHandles
+// can prove that they are not revoked in the epoch, so a credential is revoked by leaving
+// its handle out of the CRI of the next epoch.
 func CreateCRI(key *ecdsa.PrivateKey, unrevokedHandles []*FP256BN.BIG, epoch int, alg RevocationAlgorithm, rng *amcl.RAND) (*CredentialRevocationInformation, error) {
 	if key == nil || rng == nil {
 		return nil, errors.Errorf("CreateCRI received nil input")
@@ -48,13 +57,18 @@ func CreateCRI(key *ecdsa.PrivateKey, unrevokedHandles []*FP256BN.BIG, epoch int
 	cri.RevocationAlg = int32(alg)
 	cri.Epoch = int64(epoch)
 
-	if alg == ALG_NO_REVOCATION {
+	var epochSk *FP256BN.BIG
+	switch alg {
+	case ALG_NO_REVOCATION:
 		// put a dummy PK in the proto
 		cri.EpochPk = Ecp2ToProto(GenG2)
-	} else {
+	case ALG_PLAIN_SIGNATURE:
 		// create epoch key
-		_, epochPk := WBBKeyGen(rng)
+		var epochPk *FP256BN.ECP2
+		epochSk, epochPk = WBBKeyGen(rng)
 		cri.EpochPk = Ecp2ToProto(epochPk)
+	default:
+		return nil, errors.Errorf("the specified revocation algorithm is not supported.")
 	}
 
 	// sign epoch + epoch key with long term key
@@ -72,9 +86,21 @@ func CreateCRI(key *ecdsa.PrivateKey, unrevokedHandles []*FP256BN.BIG, epoch int
 
 	if alg == ALG_NO_REVOCATION {
 		return cri, nil
-	} else {
-		return nil, errors.Errorf("the specified revocation algorithm is not supported.")
 	}
+
+	// sign the unrevoked handles with the epoch key
+	revocationData := &PlainSigRevocationData{}
+	for _, rh := range unrevokedHandles {
+		revocationData.Signatures = append(revocationData.Signatures, &MessageSignature{
+			RevocationHandle: BigToBytes(rh),
+			RhSig:            EcpToProto(WBBSign(epochSk, rh)),
+		})
+	}
+	cri.RevocationData, err = proto.Marshal(revocationData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal revocation data")
+	}
+	return cri, nil
 }
 
 // VerifyEpochPK verifies that the revocation PK for a certain epoch is valid,
@@ -205,10 +205,25 @@ func (sig *Signature) Ver(Disclosure []byte, ipk *IssuerPublicKey, msg []byte, a
 		return errors.Errorf("cannot verify idemix signature: received invalid input")
 	}
 
+	if sig.NonRevocationProof == nil {
+		return errors.Errorf("cannot verify idemix signature: missing non-revocation proof")
+	}
+
 	if sig.NonRevocationProof.RevocationAlg != int32(ALG_NO_REVOCATION) && Disclosure[rhIndex] == 1 {
 		return errors.Errorf("Attribute %d is disclosed but is also used as revocation handle, which should remain hidden.", rhIndex)
 	}
 
+	// a signature is only valid in the epoch it was created for, and the epoch key
+	// must be certified by the revocation authority; this also prevents a signer
+	// from claiming that no revocation is used in the epoch
+	if sig.Epoch != int64(epoch) {
+		return errors.Errorf("signature invalid: created in epoch %d, but the current epoch is %d", sig.Epoch, epoch)
+	}
+	err := VerifyEpochPK(revPk, sig.RevocationEpochPk, sig.RevocationPkSig, int(sig.Epoch), RevocationAlgorithm(sig.NonRevocationProof.RevocationAlg))
+	if err != nil {
+		return errors.WithMessage(err, "signature invalid: epoch key is not valid")
+	}
+
 	HiddenIndices := hiddenIndices(Disclosure)
 
 	APrime := EcpFromProto(sig.GetAPrime())
@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package crosscc
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// CrossChaincode invokes other chaincodes on behalf of its callers. Calls to
+// chaincodes on the same channel take part in the transaction, while calls to
+// chaincodes on other channels are queries whose writes are discarded.
+type CrossChaincode struct{}
+
+// Init initializes the chaincode; there is no state to set up.
+func (t *CrossChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+// Invoke dispatches to the requested function:
+//
+//	invoke <chaincode> <channel> <args...>
+//	    invokes chaincode with args and returns its response
+//	invokeAndPut <key> <chaincode> <channel> <args...>
+//	    invokes chaincode with args and stores the payload of its response
+//	    under key in the state of this chaincode
+//	get <key>
+//	    returns the value stored under key
+//
+// An empty channel name refers to the channel of the transaction.
+func (t *CrossChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	args := stub.GetArgs()
+	if len(args) == 0 {
+		return shim.Error("Incorrect number of arguments. Expecting a function name")
+	}
+	function, params := string(args[0]), args[1:]
+	fmt.Println("invoke is running " + function)
+
+	switch function {
+	case "invoke":
+		return invoke(stub, params)
+	case "invokeAndPut":
+		if len(params) < 1 {
+			return shim.Error("Incorrect number of arguments. Expecting a key, a chaincode, and a channel")
+		}
+		resp := invoke(stub, params[1:])
+		if resp.Status != shim.OK {
+			return resp
+		}
+		if err := stub.PutState(string(params[0]), resp.Payload); err != nil {
+			return shim.Error(err.Error())
+		}
+		return resp
+	case "get":
+		if len(params) != 1 {
+			return shim.Error("Incorrect number of arguments. Expecting a key")
+		}
+		val, err := stub.GetState(string(params[0]))
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(val)
+	default:
+		return shim.Error("Received unknown function invocation")
+	}
+}
+
+func invoke(stub shim.ChaincodeStubInterface, params [][]byte) pb.Response {
+	if len(params) < 2 {
+		return shim.Error("Incorrect number of arguments. Expecting a chaincode and a channel")
+	}
+	chaincode, channel := string(params[0]), string(params[1])
+	resp := stub.InvokeChaincode(chaincode, params[2:], channel)
+	if resp.Status != shim.OK {
+		return shim.Error(fmt.Sprintf("invoking %s on channel %q failed: %s", chaincode, channel, resp.Message))
+	}
+	return resp
+}
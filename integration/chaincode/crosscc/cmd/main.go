@@ -0,0 +1,23 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/integration/chaincode/crosscc"
+)
+
+func main() {
+	err := shim.Start(&crosscc.CrossChaincode{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Exiting CrossChaincode chaincode: %s", err)
+		os.Exit(2)
+	}
+}
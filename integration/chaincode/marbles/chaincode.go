@@ -0,0 +1,282 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package marbles
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// MarblesChaincode keeps marbles in the public state and exposes the CouchDB
+// rich queries over them. The index in META-INF supports queries by owner.
+type MarblesChaincode struct {
+}
+
+type marble struct {
+	ObjectType string `json:"docType"` //docType is used to distinguish the various types of objects in state database
+	Name       string `json:"name"`    //the fieldtags are needed to keep case from bouncing around
+	Color      string `json:"color"`
+	Size       int    `json:"size"`
+	Owner      string `json:"owner"`
+}
+
+// queryResult is a single record returned by the range and rich queries.
+type queryResult struct {
+	Key    string          `json:"Key"`
+	Record json.RawMessage `json:"Record"`
+}
+
+// Init initializes chaincode
+// ===========================
+func (t *MarblesChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+// Invoke - Our entry point for Invocations
+// ========================================
+func (t *MarblesChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	function, args := stub.GetFunctionAndParameters()
+	fmt.Println("invoke is running " + function)
+
+	// Handle different functions
+	switch function {
+	case "initMarble":
+		//create a new marble
+		return t.initMarble(stub, args)
+	case "readMarble":
+		//read a marble
+		return t.readMarble(stub, args)
+	case "transferMarble":
+		//change owner of a specific marble
+		return t.transferMarble(stub, args)
+	case "delete":
+		//delete a marble
+		return t.delete(stub, args)
+	case "getMarblesByRange":
+		//get marbles based on range query
+		return t.getMarblesByRange(stub, args)
+	case "queryMarblesByOwner":
+		//find marbles for owner X using rich query
+		return t.queryMarblesByOwner(stub, args)
+	case "queryMarbles":
+		//find marbles based on an ad hoc rich query
+		return t.queryMarbles(stub, args)
+	default:
+		//error
+		fmt.Println("invoke did not find func: " + function)
+		return shim.Error("Received unknown function invocation")
+	}
+}
+
+// ============================================================
+// initMarble - create a new marble, store into chaincode state
+// ============================================================
+func (t *MarblesChaincode) initMarble(stub shim.ChaincodeStubInterface, args []string) pb.Response {
+	//  0-name  1-color  2-size  3-owner
+	// "asdf",  "blue",  "35",   "bob"
+	if len(args) != 4 {
+		return shim.Error("Incorrect number of arguments. Expecting 4")
+	}
+	for i, arg := range args {
+		if len(arg) == 0 {
+			return shim.Error(fmt.Sprintf("argument %d must be a non-empty string", i+1))
+		}
+	}
+	marbleName := args[0]
+	color := strings.ToLower(args[1])
+	owner := strings.ToLower(args[3])
+	size, err := strconv.Atoi(args[2])
+	if err != nil {
+		return shim.Error("3rd argument must be a numeric string")
+	}
+
+	// ==== Check if marble already exists ====
+	marbleAsBytes, err := stub.GetState(marbleName)
+	if err != nil {
+		return shim.Error("Failed to get marble: " + err.Error())
+	} else if marbleAsBytes != nil {
+		return shim.Error("This marble already exists: " + marbleName)
+	}
+
+	// ==== Create marble object, marshal to JSON, and save to state ====
+	marbleJSONasBytes, err := json.Marshal(&marble{"marble", marbleName, color, size, owner})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	err = stub.PutState(marbleName, marbleJSONasBytes)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(nil)
+}
+
+// ===============================================
+// readMarble - read a marble from chaincode state
+// ===============================================
+func (t *MarblesChaincode) readMarble(stub shim.ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 1 {
+		return shim.Error("Incorrect number of arguments. Expecting name of the marble to query")
+	}
+
+	valAsbytes, err := stub.GetState(args[0])
+	if err != nil {
+		return shim.Error("Failed to get state for " + args[0])
+	} else if valAsbytes == nil {
+		return shim.Error("Marble does not exist: " + args[0])
+	}
+
+	return shim.Success(valAsbytes)
+}
+
+// ===========================================================
+// transferMarble - transfer a marble by setting a new owner name on the marble
+// ===========================================================
+func (t *MarblesChaincode) transferMarble(stub shim.ChaincodeStubInterface, args []string) pb.Response {
+	//   0       1
+	// "name", "bob"
+	if len(args) != 2 {
+		return shim.Error("Incorrect number of arguments. Expecting 2")
+	}
+
+	marbleName := args[0]
+	newOwner := strings.ToLower(args[1])
+
+	marbleAsBytes, err := stub.GetState(marbleName)
+	if err != nil {
+		return shim.Error("Failed to get marble: " + err.Error())
+	} else if marbleAsBytes == nil {
+		return shim.Error("Marble does not exist: " + marbleName)
+	}
+
+	marbleToTransfer := marble{}
+	err = json.Unmarshal(marbleAsBytes, &marbleToTransfer)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	marbleToTransfer.Owner = newOwner
+
+	marbleJSONasBytes, err := json.Marshal(marbleToTransfer)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	err = stub.PutState(marbleName, marbleJSONasBytes)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(nil)
+}
+
+// ==================================================
+// delete - remove a marble key/value pair from state
+// ==================================================
+func (t *MarblesChaincode) delete(stub shim.ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 1 {
+		return shim.Error("Incorrect number of arguments. Expecting 1")
+	}
+
+	err := stub.DelState(args[0])
+	if err != nil {
+		return shim.Error("Failed to delete state:" + err.Error())
+	}
+
+	return shim.Success(nil)
+}
+
+// ===========================================================================================
+// getMarblesByRange performs a range query based on the start and end keys provided.
+// ===========================================================================================
+func (t *MarblesChaincode) getMarblesByRange(stub shim.ChaincodeStubInterface, args []string) pb.Response {
+	//   0        1
+	// "start", "end"
+	if len(args) != 2 {
+		return shim.Error("Incorrect number of arguments. Expecting 2")
+	}
+
+	resultsIterator, err := stub.GetStateByRange(args[0], args[1])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	defer resultsIterator.Close()
+
+	results, err := marshalQueryResults(resultsIterator)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(results)
+}
+
+// =======Rich queries =========================================================================
+// Rich queries are only supported by state databases that support them, such as
+// CouchDB. Running them against LevelDB returns an error.
+// =============================================================================================
+
+// queryMarblesByOwner queries for marbles based on a passed in owner, using the
+// owner index that is deployed with the chaincode.
+func (t *MarblesChaincode) queryMarblesByOwner(stub shim.ChaincodeStubInterface, args []string) pb.Response {
+	//   0
+	// "bob"
+	if len(args) != 1 {
+		return shim.Error("Incorrect number of arguments. Expecting 1")
+	}
+
+	owner := strings.ToLower(args[0])
+	query, err := json.Marshal(map[string]interface{}{
+		"selector":  map[string]string{"docType": "marble", "owner": owner},
+		"use_index": []string{"_design/indexOwnerDoc", "indexOwner"},
+	})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return queryResponse(stub, string(query))
+}
+
+// queryMarbles runs the ad hoc rich query passed in as the only argument.
+func (t *MarblesChaincode) queryMarbles(stub shim.ChaincodeStubInterface, args []string) pb.Response {
+	//   0
+	// "queryString"
+	if len(args) != 1 {
+		return shim.Error("Incorrect number of arguments. Expecting 1")
+	}
+
+	return queryResponse(stub, args[0])
+}
+
+func queryResponse(stub shim.ChaincodeStubInterface, query string) pb.Response {
+	resultsIterator, err := stub.GetQueryResult(query)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	defer resultsIterator.Close()
+
+	results, err := marshalQueryResults(resultsIterator)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(results)
+}
+
+// marshalQueryResults drains the iterator into a JSON array of key and record
+// pairs.
+func marshalQueryResults(resultsIterator shim.StateQueryIteratorInterface) ([]byte, error) {
+	results := []queryResult{}
+	for resultsIterator.HasNext() {
+		kv, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, queryResult{Key: kv.Key, Record: kv.Value})
+	}
+	return json.Marshal(results)
+}
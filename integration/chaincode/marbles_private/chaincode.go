@@ -60,6 +60,9 @@ func (t *MarblesPrivateChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Re
 	case "delete":
 		//delete a marble
 		return t.delete(stub, args)
+	case "queryMarblesByOwner":
+		//find private marbles for owner X using rich query
+		return t.queryMarblesByOwner(stub, args)
 	default:
 		//error
 		fmt.Println("invoke did not find func: " + function)
@@ -252,3 +255,44 @@ func (t *MarblesPrivateChaincode) delete(stub shim.ChaincodeStubInterface, args
 
 	return shim.Success(nil)
 }
+
+// ===============================================================================
+// queryMarblesByOwner - rich query of the marbles collection for a given owner,
+// using the collection index deployed with the chaincode. Only supported when
+// the state database is CouchDB.
+// ===============================================================================
+func (t *MarblesPrivateChaincode) queryMarblesByOwner(stub shim.ChaincodeStubInterface, args []string) pb.Response {
+	//   0
+	// "bob"
+	if len(args) != 1 {
+		return shim.Error("Incorrect number of arguments. Expecting 1")
+	}
+
+	owner := strings.ToLower(args[0])
+	queryString := fmt.Sprintf("{\"selector\":{\"docType\":\"marble\",\"owner\":\"%s\"}, \"use_index\":[\"_design/indexOwnerDoc\", \"indexOwner\"]}", owner)
+
+	resultsIterator, err := stub.GetPrivateDataQueryResult("collectionMarbles", queryString)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	defer resultsIterator.Close()
+
+	marbles := []marble{}
+	for resultsIterator.HasNext() {
+		kv, err := resultsIterator.Next()
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		m := marble{}
+		if err := json.Unmarshal(kv.Value, &m); err != nil {
+			return shim.Error(err.Error())
+		}
+		marbles = append(marbles, m)
+	}
+
+	marblesJSONasBytes, err := json.Marshal(marbles)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(marblesJSONasBytes)
+}
@@ -7,12 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package e2e
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"syscall"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -24,6 +24,7 @@ import (
 	"github.com/fsouza/go-dockerclient"
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/integration/helpers"
 	"github.com/hyperledger/fabric/integration/nwo"
 	"github.com/hyperledger/fabric/integration/nwo/commands"
 	"github.com/hyperledger/fabric/msp"
@@ -40,6 +41,7 @@ var _ = Describe("DiscoveryService", func() {
 		client  *docker.Client
 		network *nwo.Network
 		process ifrit.Process
+		cancel  context.CancelFunc
 		orderer *nwo.Orderer
 	)
 
@@ -63,7 +65,9 @@ var _ = Describe("DiscoveryService", func() {
 		network.Bootstrap()
 
 		networkRunner := network.NetworkGroupRunner()
-		process = ifrit.Invoke(networkRunner)
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		process = helpers.InvokeContext(ctx, networkRunner)
 		Eventually(process.Ready()).Should(BeClosed())
 
 		orderer = network.Orderer("orderer")
@@ -73,7 +77,7 @@ var _ = Describe("DiscoveryService", func() {
 
 	AfterEach(func() {
 		if process != nil {
-			process.Signal(syscall.SIGTERM)
+			cancel()
 			Eventually(process.Wait(), time.Minute).Should(Receive())
 		}
 		if network != nil {
@@ -7,17 +7,18 @@ SPDX-License-Identifier: Apache-2.0
 package e2e
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"syscall"
 	"time"
 
 	docker "github.com/fsouza/go-dockerclient"
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/integration/helpers"
 	"github.com/hyperledger/fabric/integration/nwo"
 	"github.com/hyperledger/fabric/integration/nwo/commands"
 	"github.com/hyperledger/fabric/protos/common"
@@ -37,6 +38,7 @@ var _ = Describe("EndToEndACL", func() {
 		network   *nwo.Network
 		chaincode nwo.Chaincode
 		process   ifrit.Process
+		cancel    context.CancelFunc
 
 		orderer   *nwo.Orderer
 		org1Peer0 *nwo.Peer
@@ -63,7 +65,9 @@ var _ = Describe("EndToEndACL", func() {
 		network.Bootstrap()
 
 		networkRunner := network.NetworkGroupRunner()
-		process = ifrit.Invoke(networkRunner)
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		process = helpers.InvokeContext(ctx, networkRunner)
 		Eventually(process.Ready()).Should(BeClosed())
 
 		orderer = network.Orderer("orderer")
@@ -82,7 +86,7 @@ var _ = Describe("EndToEndACL", func() {
 	})
 
 	AfterEach(func() {
-		process.Signal(syscall.SIGTERM)
+		cancel()
 		Eventually(process.Wait()).Should(Receive())
 		network.Cleanup()
 		os.RemoveAll(testDir)
@@ -7,9 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package e2e
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
-	"syscall"
 	"time"
 
 	docker "github.com/fsouza/go-dockerclient"
@@ -19,6 +19,7 @@ import (
 	"github.com/onsi/gomega/gexec"
 	"github.com/tedsuo/ifrit"
 
+	"github.com/hyperledger/fabric/integration/helpers"
 	"github.com/hyperledger/fabric/integration/nwo"
 	"github.com/hyperledger/fabric/integration/nwo/commands"
 )
@@ -30,6 +31,7 @@ var _ = Describe("EndToEnd", func() {
 		network   *nwo.Network
 		chaincode nwo.Chaincode
 		process   ifrit.Process
+		cancel    context.CancelFunc
 	)
 
 	BeforeEach(func() {
@@ -51,7 +53,7 @@ var _ = Describe("EndToEnd", func() {
 
 	AfterEach(func() {
 		if process != nil {
-			process.Signal(syscall.SIGTERM)
+			cancel()
 			Eventually(process.Wait(), time.Minute).Should(Receive())
 		}
 		if network != nil {
@@ -67,7 +69,9 @@ var _ = Describe("EndToEnd", func() {
 			network.Bootstrap()
 
 			networkRunner := network.NetworkGroupRunner()
-			process = ifrit.Invoke(networkRunner)
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			process = helpers.InvokeContext(ctx, networkRunner)
 			Eventually(process.Ready()).Should(BeClosed())
 		})
 
@@ -96,7 +100,9 @@ var _ = Describe("EndToEnd", func() {
 			network.Bootstrap()
 
 			networkRunner := network.NetworkGroupRunner()
-			process = ifrit.Invoke(networkRunner)
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			process = helpers.InvokeContext(ctx, networkRunner)
 			Eventually(process.Ready()).Should(BeClosed())
 		})
 
@@ -0,0 +1,29 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package helpers
+
+import (
+	"context"
+
+	"github.com/tedsuo/ifrit"
+)
+
+// InvokeContext invokes the runner and returns its process once it is ready,
+// like ifrit.Invoke. The process is stopped with TerminateSignal when ctx is
+// done, so suites can shut networks down by cancelling a context instead of
+// sending signals that do not exist on every platform.
+func InvokeContext(ctx context.Context, runner ifrit.Runner) ifrit.Process {
+	process := ifrit.Invoke(runner)
+	go func() {
+		select {
+		case <-ctx.Done():
+			process.Signal(TerminateSignal)
+		case <-process.Wait():
+		}
+	}()
+	return process
+}
@@ -0,0 +1,38 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package helpers
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tedsuo/ifrit"
+)
+
+func TestInvokeContext(t *testing.T) {
+	signals := make(chan os.Signal, 1)
+	runner := ifrit.RunFunc(func(sigCh <-chan os.Signal, ready chan<- struct{}) error {
+		close(ready)
+		signals <- <-sigCh
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	process := InvokeContext(ctx, runner)
+	cancel()
+
+	select {
+	case sig := <-signals:
+		assert.Equal(t, TerminateSignal, sig)
+	case <-time.After(5 * time.Second):
+		t.Fatal("process was not signalled")
+	}
+	assert.NoError(t, <-process.Wait())
+}
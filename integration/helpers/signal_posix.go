@@ -0,0 +1,18 @@
+// +build !windows
+
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package helpers
+
+import (
+	"os"
+	"syscall"
+)
+
+// TerminateSignal is the signal used to gracefully stop the processes of a
+// network.
+var TerminateSignal os.Signal = syscall.SIGTERM
@@ -0,0 +1,13 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package helpers
+
+import "os"
+
+// TerminateSignal is the signal used to stop the processes of a network.
+// Windows can only kill processes, so they are not stopped gracefully.
+var TerminateSignal os.Signal = os.Kill
@@ -9,7 +9,6 @@ package e2e
 import (
 	"io/ioutil"
 	"os"
-	"syscall"
 	"time"
 
 	"github.com/fsouza/go-dockerclient"
@@ -51,7 +50,7 @@ var _ = Describe("Java Chaincode EndToEnd Suite", func() {
 
 	AfterEach(func() {
 		if process != nil {
-			process.Signal(syscall.SIGKILL)
+			process.Signal(os.Kill)
 			Eventually(process.Wait(), time.Minute).Should(Receive())
 		}
 		if network != nil {
@@ -0,0 +1,141 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package nwo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/onsi/gomega/gexec"
+	"github.com/pkg/errors"
+)
+
+// BuildCacheEnvVar names the environment variable that points to a directory
+// where built components are kept across suites. When it is set and the
+// source tree is a clean git checkout, binaries and plugins are built once per
+// revision and reused by every suite that asks for them.
+const BuildCacheEnvVar = "FABRIC_INTEGRATION_BUILD_CACHE"
+
+// A BuildCache stores compiled artifacts in a directory, keyed by the git
+// revision of the source tree they were built from.
+type BuildCache struct {
+	Dir      string
+	Revision string
+}
+
+// NewBuildCache returns the cache configured through BuildCacheEnvVar. It
+// returns nil when no cache is configured or when the working tree has local
+// modifications, as the revision would not identify the sources.
+func NewBuildCache() *BuildCache {
+	dir := os.Getenv(BuildCacheEnvVar)
+	if dir == "" {
+		return nil
+	}
+	revision, err := gitRevision()
+	if err != nil {
+		return nil
+	}
+	return &BuildCache{Dir: dir, Revision: revision}
+}
+
+func gitRevision() (string, error) {
+	status, err := exec.Command("git", "status", "--porcelain").Output()
+	if err != nil {
+		return "", err
+	}
+	if len(strings.TrimSpace(string(status))) != 0 {
+		return "", errors.New("working tree has local modifications")
+	}
+	revision, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(revision)), nil
+}
+
+// Path returns the location of the artifact built from pkg with args.
+func (b *BuildCache) Path(pkg string, args ...string) string {
+	h := sha256.New()
+	for _, s := range append([]string{b.Revision, runtime.Version(), runtime.GOOS, runtime.GOARCH, pkg}, args...) {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return filepath.Join(b.Dir, filepath.Base(pkg)+"-"+hex.EncodeToString(h.Sum(nil)))
+}
+
+// Contains returns true if path is a location managed by the cache.
+func (b *BuildCache) Contains(path string) bool {
+	rel, err := filepath.Rel(b.Dir, path)
+	return err == nil && !strings.HasPrefix(rel, "..")
+}
+
+// Build returns the cached artifact for pkg and args, compiling and storing
+// it when the cache does not have it yet.
+func (b *BuildCache) Build(pkg string, args ...string) (string, error) {
+	return b.build(gexec.Build, pkg, args...)
+}
+
+func (b *BuildCache) build(compile func(string, ...string) (string, error), pkg string, args ...string) (string, error) {
+	cached := b.Path(pkg, args...)
+	if _, err := os.Stat(cached); err == nil {
+		return cached, nil
+	}
+
+	compiled, err := compile(pkg, args...)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(b.Dir, 0755); err != nil {
+		return "", errors.Wrap(err, "failed creating build cache")
+	}
+	if err := store(compiled, cached); err != nil {
+		return "", errors.WithMessage(err, "failed storing "+pkg+" in build cache")
+	}
+	return cached, nil
+}
+
+// store copies the artifact into the cache through a temporary file so that
+// concurrent suites never observe a partially written artifact.
+func store(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := ioutil.TempFile(filepath.Dir(dst), filepath.Base(dst)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(out.Name())
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(out.Name(), 0755); err != nil {
+		return err
+	}
+	if err := os.Rename(out.Name(), dst); err != nil {
+		// another suite may have stored the same artifact first
+		if _, statErr := os.Stat(dst); statErr == nil {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package nwo_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/integration/nwo"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildCache", func() {
+	const pkg = "github.com/hyperledger/fabric/integration/chaincode/simple/cmd"
+
+	var (
+		cacheDir string
+		cache    *nwo.BuildCache
+	)
+
+	BeforeEach(func() {
+		var err error
+		cacheDir, err = ioutil.TempDir("", "build-cache")
+		Expect(err).NotTo(HaveOccurred())
+		cache = &nwo.BuildCache{Dir: cacheDir, Revision: "0123456789abcdef"}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(cacheDir)
+	})
+
+	It("keys artifacts by revision, package, and build arguments", func() {
+		path := cache.Path(pkg)
+		Expect(filepath.Dir(path)).To(Equal(cacheDir))
+		Expect(filepath.Base(path)).To(HavePrefix("cmd-"))
+		Expect(cache.Path(pkg)).To(Equal(path))
+		Expect(cache.Path(pkg, "-race")).NotTo(Equal(path))
+
+		other := &nwo.BuildCache{Dir: cacheDir, Revision: "fedcba9876543210"}
+		Expect(other.Path(pkg)).NotTo(Equal(path))
+	})
+
+	It("builds an artifact once and reuses it", func() {
+		path, err := cache.Build(pkg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal(cache.Path(pkg)))
+		Expect(cache.Contains(path)).To(BeTrue())
+
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		again, err := cache.Build(pkg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again).To(Equal(path))
+		againInfo, err := os.Stat(again)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(againInfo.ModTime()).To(Equal(info.ModTime()))
+	})
+
+	It("reports build failures", func() {
+		_, err := cache.Build("github.com/hyperledger/fabric/integration/nwo/does-not-exist")
+		Expect(err).To(HaveOccurred())
+		Expect(cache.Path("github.com/hyperledger/fabric/integration/nwo/does-not-exist")).NotTo(BeAnExistingFile())
+	})
+
+	It("does not contain paths outside of its directory", func() {
+		Expect(cache.Contains(filepath.Join(cacheDir, "peer-1234"))).To(BeTrue())
+		Expect(cache.Contains(os.TempDir())).To(BeFalse())
+	})
+})
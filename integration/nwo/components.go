@@ -32,36 +32,47 @@ var RequiredImages = []string{
 func (c *Components) Build(args ...string) {
 	helpers.AssertImagesExist(RequiredImages...)
 
+	c.build("cryptogen", "github.com/hyperledger/fabric/common/tools/cryptogen", args...)
+	c.build("idemixgen", "github.com/hyperledger/fabric/common/tools/idemixgen", args...)
+	c.build("configtxgen", "github.com/hyperledger/fabric/common/tools/configtxgen", args...)
+	c.build("orderer", "github.com/hyperledger/fabric/orderer", args...)
+	c.build("peer", "github.com/hyperledger/fabric/peer", args...)
+	c.build("discover", "github.com/hyperledger/fabric/cmd/discover", args...)
+}
+
+// BuildPlugin compiles the Go plugin in the package pkg and records its path
+// under name. Plugins must be built with the same flags as the binaries that
+// load them.
+func (c *Components) BuildPlugin(name, pkg string, args ...string) {
+	c.build(name, pkg, append([]string{"-buildmode=plugin"}, args...)...)
+}
+
+// build compiles pkg and records its path under name. Artifacts come from the
+// build cache when one is configured.
+func (c *Components) build(name, pkg string, args ...string) {
 	if c.Paths == nil {
 		c.Paths = map[string]string{}
 	}
-	cryptogen, err := gexec.Build("github.com/hyperledger/fabric/common/tools/cryptogen", args...)
-	Expect(err).NotTo(HaveOccurred())
-	c.Paths["cryptogen"] = cryptogen
-
-	idemixgen, err := gexec.Build("github.com/hyperledger/fabric/common/tools/idemixgen", args...)
-	Expect(err).NotTo(HaveOccurred())
-	c.Paths["idemixgen"] = idemixgen
 
-	configtxgen, err := gexec.Build("github.com/hyperledger/fabric/common/tools/configtxgen", args...)
-	Expect(err).NotTo(HaveOccurred())
-	c.Paths["configtxgen"] = configtxgen
-
-	orderer, err := gexec.Build("github.com/hyperledger/fabric/orderer", args...)
-	Expect(err).NotTo(HaveOccurred())
-	c.Paths["orderer"] = orderer
-
-	peer, err := gexec.Build("github.com/hyperledger/fabric/peer", args...)
-	Expect(err).NotTo(HaveOccurred())
-	c.Paths["peer"] = peer
-
-	discover, err := gexec.Build("github.com/hyperledger/fabric/cmd/discover", args...)
+	var path string
+	var err error
+	if cache := NewBuildCache(); cache != nil {
+		path, err = cache.Build(pkg, args...)
+	} else {
+		path, err = gexec.Build(pkg, args...)
+	}
 	Expect(err).NotTo(HaveOccurred())
-	c.Paths["discover"] = discover
+	c.Paths[name] = path
 }
 
+// Cleanup removes the artifacts built for the suite. Cached artifacts are
+// kept for later suites.
 func (c *Components) Cleanup() {
+	cache := &BuildCache{Dir: os.Getenv(BuildCacheEnvVar)}
 	for _, path := range c.Paths {
+		if cache.Dir != "" && cache.Contains(path) {
+			continue
+		}
 		err := os.Remove(path)
 		Expect(err).NotTo(HaveOccurred())
 	}
@@ -74,3 +85,6 @@ func (c *Components) ConfigTxGen() string { return c.Paths["configtxgen"] }
 func (c *Components) Orderer() string     { return c.Paths["orderer"] }
 func (c *Components) Peer() string        { return c.Paths["peer"] }
 func (c *Components) Discover() string    { return c.Paths["discover"] }
+
+// Plugin returns the path of the plugin built by BuildPlugin under name.
+func (c *Components) Plugin(name string) string { return c.Paths[name] }
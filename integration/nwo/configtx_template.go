@@ -87,6 +87,19 @@ Profiles:{{ range .Profiles }}
         - {{ . }}
         {{- end }}
       {{- end }}
+      {{- if eq $w.Consensus.Type "etcdraft" }}
+      EtcdRaft:
+        Consenters:{{ range $w.RaftConsenters . }}
+        - Host: 127.0.0.1
+          Port: {{ $w.OrdererPort . "Listen" }}
+          ClientTLSCert: {{ $w.OrdererLocalTLSDir . }}/server.crt
+          ServerTLSCert: {{ $w.OrdererLocalTLSDir . }}/server.crt
+        {{- end }}
+      {{- end }}
+      {{- if $w.ConsensusPlugin }}
+      Plugin:
+        Metadata: "{{ $w.Consensus.PluginMetadata }}"
+      {{- end }}
       Organizations:{{ range $w.OrgsForOrderers .Orderers }}
       - *{{ .MSPID }}
       {{- end }}
@@ -0,0 +1,36 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package nwo
+
+import "github.com/hyperledger/fabric/integration/nwo/fabricconfig"
+
+// ConsensusPlugin returns true if the consensus type of the network is not
+// builtin and must be provided by an orderer consensus plugin.
+func (n *Network) ConsensusPlugin() bool {
+	switch n.Consensus.Type {
+	case "", "solo", "kafka", "etcdraft":
+		return false
+	default:
+		return true
+	}
+}
+
+// ConfigureConsensusPlugin updates the orderer.yaml of every orderer in the
+// network to load the consensus type of the network from the plugin library,
+// as built by Components.BuildPlugin. It must be called after the
+// configuration tree has been generated.
+func (n *Network) ConfigureConsensusPlugin(library string) {
+	for _, o := range n.Orderers {
+		config := n.ReadOrdererConfig(o)
+		config.Consensus = &fabricconfig.Consensus{
+			Plugins: map[string]fabricconfig.ConsensusPlugin{
+				n.Consensus.Type: {Library: library},
+			},
+		}
+		n.WriteOrdererConfig(o, config)
+	}
+}
@@ -92,6 +92,8 @@ peer:
       - {{ .PeerLocalTLSDir Peer }}/ca.crt
   authentication:
     timewindow: 15m
+  shutdown:
+    drainTimeout: 30s
   fileSystemPath: filesystem
   BCCSP:
     Default: SW
@@ -192,4 +194,7 @@ ledger:
       warmIndexesAfterNBlocks: 1
   history:
     enableHistoryDatabase: true
+  commitJournal:
+    enabled: false
+    checkpointInterval: 100
 `
@@ -0,0 +1,56 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package nwo
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/integration/helpers"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+// A Deployment describes a chaincode that is deployed to a channel of the
+// network through one of its orderers.
+type Deployment struct {
+	Channel   string
+	Orderer   string // name of the orderer used to create the channel
+	Chaincode Chaincode
+	Peers     []*Peer // optional; defaults to the peers joined to the channel
+}
+
+// Setup generates the configuration for the network, bootstraps it, starts
+// all of the fabric processes, and sets up the provided deployments. The
+// processes are stopped when ctx is done.
+//
+// The returned process is ready when Setup returns.
+func (n *Network) Setup(ctx context.Context, deployments ...Deployment) ifrit.Process {
+	n.GenerateConfigTree()
+	n.Bootstrap()
+
+	process := helpers.InvokeContext(ctx, n.NetworkGroupRunner())
+	Eventually(process.Ready(), n.EventuallyTimeout).Should(BeClosed())
+
+	for _, d := range deployments {
+		n.SetupDeployment(d)
+	}
+	return process
+}
+
+// SetupDeployment creates the deployment channel, joins the referencing peers
+// to it, updates the channel anchor peers, and deploys the chaincode to the
+// deployment peers.
+//
+// The network must be running before this is called.
+func (n *Network) SetupDeployment(d Deployment) {
+	orderer := n.Orderer(d.Orderer)
+	Expect(orderer).NotTo(BeNil(), "orderer %s is not part of the network", d.Orderer)
+
+	n.CreateAndJoinChannel(orderer, d.Channel)
+	n.UpdateChannelAnchors(orderer, d.Channel)
+	DeployChaincode(n, d.Channel, orderer, d.Chaincode, d.Peers...)
+}
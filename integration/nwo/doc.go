@@ -0,0 +1,36 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+/*
+Package nwo provides the means to build, configure, and run Fabric networks
+from real Fabric binaries in end-to-end tests. It is supported for use by
+projects outside of this repository, such as plugins and SDKs, that need to
+test against a live network.
+
+A network is described by a Config, either loaded from a document like
+solo.yaml or taken from the standard networks, and is realized with New. The
+binaries are built once per suite through Components:
+
+	components := &nwo.Components{}
+	components.Build()
+	defer components.Cleanup()
+
+	network := nwo.New(nwo.BasicSolo(), testDir, dockerClient, 30000, components)
+	process := network.Setup(ctx, nwo.Deployment{
+		Channel:   "testchannel",
+		Orderer:   "orderer",
+		Chaincode: chaincode,
+	})
+	defer network.Cleanup()
+
+Peers and orderers are referenced through their Peer and Orderer handles,
+obtained with Network.Peer and Network.Orderer, which are used to locate their
+configuration, crypto material, and addresses. Peer CLI commands are run with
+PeerAdminSession and PeerUserSession using the command types in the commands
+package, and the configuration documents of individual processes can be
+customized with the Read and Write config methods before they are started.
+*/
+package nwo
@@ -13,6 +13,7 @@ type Orderer struct {
 	FileLedger *FileLedger `yaml:"FileLedger,omitempty"`
 	RAMLedger  *RAMLedger  `yaml:"RAMLedger,omitempty"`
 	Kafka      *Kafka      `yaml:"Kafka,omitempty"`
+	Consensus  *Consensus  `yaml:"Consensus,omitempty"`
 
 	ExtraProperties map[string]interface{} `yaml:",inline,omitempty"`
 }
@@ -109,3 +110,11 @@ type Backoff struct {
 	RetryBackoff time.Duration `yaml:"RetryBackoff,omitempty"`
 	RetryMax     int           `yaml:"RetryMax,omitempty"`
 }
+
+type Consensus struct {
+	Plugins map[string]ConsensusPlugin `yaml:"Plugins,omitempty"`
+}
+
+type ConsensusPlugin struct {
+	Library string `yaml:"Library,omitempty"`
+}
@@ -16,7 +16,6 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"syscall"
 	"text/template"
 	"time"
 
@@ -62,6 +61,9 @@ type Consensus struct {
 	Type       string `yaml:"type,omitempty"`
 	Brokers    int    `yaml:"brokers,omitempty"`
 	ZooKeepers int    `yaml:"zookeepers,omitempty"`
+	// PluginMetadata is the consensus metadata of types provided by a
+	// consensus plugin, see Network.ConfigureConsensusPlugin.
+	PluginMetadata string `yaml:"pluginMetadata,omitempty"`
 }
 
 // The SystemChannel declares the name of the network system channel and its
@@ -124,6 +126,9 @@ type Profile struct {
 	Orderers      []string `yaml:"orderers,omitempty"`
 	Consortium    string   `yaml:"consortium,omitempty"`
 	Organizations []string `yaml:"organizations,omitempty"`
+	// Consenters names the orderers of an etcdraft profile which are members
+	// of the raft cluster, all of them if empty.
+	Consenters []string `yaml:"consenters,omitempty"`
 }
 
 // Network holds information about a fabric network.
@@ -718,7 +723,7 @@ func (n *Network) CreateChannel(name string, o *Orderer, p *Peer) {
 		ChannelID:   name,
 		Orderer:     n.OrdererAddress(o, ListenPort),
 		File:        n.CreateChannelTxPath(name),
-		OutputBlock: "/dev/null",
+		OutputBlock: os.DevNull,
 	})
 	Expect(err).NotTo(HaveOccurred())
 	Eventually(sess, n.EventuallyTimeout).Should(gexec.Exit(0))
@@ -853,14 +858,17 @@ func (n *Network) BrokerGroupRunner() ifrit.Runner {
 		members = append(members, grouper.Member{Name: kafka.Name, Runner: kafka})
 	}
 
-	return grouper.NewOrdered(syscall.SIGTERM, members)
+	return grouper.NewOrdered(helpers.TerminateSignal, members)
 }
 
 // OrdererRunner returns an ifrit.Runner for the specified orderer. The runner
-// can be used to start and manage an orderer process.
-func (n *Network) OrdererRunner(o *Orderer) ifrit.Runner {
+// can be used to start and manage an orderer process. Additional environment
+// variables, e.g. to inject block store faults, can be passed to the process.
+func (n *Network) OrdererRunner(o *Orderer, env ...string) ifrit.Runner {
 	cmd := exec.Command(n.Components.Orderer())
+	cmd.Env = os.Environ()
 	cmd.Env = append(cmd.Env, fmt.Sprintf("FABRIC_CFG_PATH=%s", n.OrdererDir(o)))
+	cmd.Env = append(cmd.Env, env...)
 
 	config := ginkgomon.Config{
 		AnsiColorCode:     n.nextColor(),
@@ -885,15 +893,16 @@ func (n *Network) OrdererGroupRunner() ifrit.Runner {
 	for _, o := range n.Orderers {
 		members = append(members, grouper.Member{Name: o.ID(), Runner: n.OrdererRunner(o)})
 	}
-	return grouper.NewParallel(syscall.SIGTERM, members)
+	return grouper.NewParallel(helpers.TerminateSignal, members)
 }
 
 // PeerRunner returns an ifrit.Runner for the specified peer. The runner can be
-// used to start and manage a peer process.
-func (n *Network) PeerRunner(p *Peer) ifrit.Runner {
+// used to start and manage a peer process. Additional environment variables,
+// e.g. to inject block store faults, can be passed to the process.
+func (n *Network) PeerRunner(p *Peer, env ...string) ifrit.Runner {
 	cmd := n.peerCommand(
 		commands.NodeStart{PeerID: p.ID()},
-		fmt.Sprintf("FABRIC_CFG_PATH=%s", n.PeerDir(p)),
+		append([]string{fmt.Sprintf("FABRIC_CFG_PATH=%s", n.PeerDir(p))}, env...)...,
 	)
 
 	return ginkgomon.New(ginkgomon.Config{
@@ -912,7 +921,7 @@ func (n *Network) PeerGroupRunner() ifrit.Runner {
 	for _, p := range n.Peers {
 		members = append(members, grouper.Member{Name: p.ID(), Runner: n.PeerRunner(p)})
 	}
-	return grouper.NewParallel(syscall.SIGTERM, members)
+	return grouper.NewParallel(helpers.TerminateSignal, members)
 }
 
 // NetworkGroupRunner returns a runner that can be used to start and stop an
@@ -923,7 +932,7 @@ func (n *Network) NetworkGroupRunner() ifrit.Runner {
 		{Name: "orderers", Runner: n.OrdererGroupRunner()},
 		{Name: "peers", Runner: n.PeerGroupRunner()},
 	}
-	return grouper.NewOrdered(syscall.SIGTERM, members)
+	return grouper.NewOrdered(helpers.TerminateSignal, members)
 }
 
 func (n *Network) peerCommand(command Command, env ...string) *exec.Cmd {
@@ -1103,6 +1112,20 @@ func (n *Network) OrgsForOrderers(ordererNames []string) []*Organization {
 	return orgs
 }
 
+// RaftConsenters returns the orderers of the profile which are members of
+// its raft cluster.
+func (n *Network) RaftConsenters(p *Profile) []*Orderer {
+	names := p.Consenters
+	if len(names) == 0 {
+		names = p.Orderers
+	}
+	consenters := []*Orderer{}
+	for _, name := range names {
+		consenters = append(consenters, n.Orderer(name))
+	}
+	return consenters
+}
+
 // OrdererOrgs returns all Organization instances that own at least one
 // orderer.
 func (n *Network) OrdererOrgs() []*Organization {
@@ -7,10 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package nwo_test
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
-	"syscall"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -19,6 +19,7 @@ import (
 	"github.com/onsi/gomega/gexec"
 
 	docker "github.com/fsouza/go-dockerclient"
+	"github.com/hyperledger/fabric/integration/helpers"
 	"github.com/hyperledger/fabric/integration/nwo"
 	"github.com/hyperledger/fabric/integration/nwo/commands"
 	"github.com/tedsuo/ifrit"
@@ -47,6 +48,7 @@ var _ = Describe("Network", func() {
 	Describe("solo network", func() {
 		var network *nwo.Network
 		var process ifrit.Process
+		var cancel context.CancelFunc
 
 		BeforeEach(func() {
 			soloBytes, err := ioutil.ReadFile("solo.yaml")
@@ -57,19 +59,16 @@ var _ = Describe("Network", func() {
 			Expect(err).NotTo(HaveOccurred())
 			network = nwo.New(config, tempDir, client, 44444, components)
 
-			// Generate config and bootstrap the network
-			network.GenerateConfigTree()
-			network.Bootstrap()
-
-			// Start all of the fabric processes
-			networkRunner := network.NetworkGroupRunner()
-			process = ifrit.Invoke(networkRunner)
-			Eventually(process.Ready(), network.EventuallyTimeout).Should(BeClosed())
+			// Generate config, bootstrap the network, and start all of the
+			// fabric processes
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			process = network.Setup(ctx)
 		})
 
 		AfterEach(func() {
 			// Shutodwn processes and cleanup
-			process.Signal(syscall.SIGTERM)
+			cancel()
 			Eventually(process.Wait(), time.Minute).Should(Receive())
 			network.Cleanup()
 		})
@@ -86,8 +85,11 @@ var _ = Describe("Network", func() {
 				Policy:  `AND ('Org1ExampleCom.member','Org2ExampleCom.member')`,
 			}
 
-			network.CreateAndJoinChannels(orderer)
-			nwo.DeployChaincode(network, "testchannel", orderer, chaincode)
+			network.SetupDeployment(nwo.Deployment{
+				Channel:   "testchannel",
+				Orderer:   "orderer0",
+				Chaincode: chaincode,
+			})
 			RunQueryInvokeQuery(network, orderer, peer)
 		})
 	})
@@ -119,7 +121,7 @@ var _ = Describe("Network", func() {
 
 		AfterEach(func() {
 			for _, p := range processes {
-				p.Signal(syscall.SIGTERM)
+				p.Signal(helpers.TerminateSignal)
 				Eventually(p.Wait(), time.Minute).Should(Receive())
 			}
 			network.Cleanup()
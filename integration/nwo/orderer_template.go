@@ -44,6 +44,8 @@ General:
         KeyStore:
   Authentication:
     TimeWindow: 15m
+  Shutdown:
+    DrainTimeout: 30s
 FileLedger:
   Location: {{ .OrdererDir Orderer }}/system
   Prefix: hyperledger-fabric-ordererledger
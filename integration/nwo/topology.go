@@ -0,0 +1,168 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package nwo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Topology builds the Config of a network made of any number of orderer and
+// peer organizations, as an alternative to writing it by hand:
+//
+//	config := nwo.NewTopology().
+//	    AddOrdererOrg("OrdererOrg", 3).
+//	    AddPeerOrg("Org1", 2).
+//	    AddPeerOrg("Org2", 2).
+//	    WithConsensus("etcdraft", 3).
+//	    Config()
+//
+// The MSP IDs of the organizations are their names suffixed with MSP, and
+// their domains are their lowercased names under example.com. The orderers
+// are named orderer0, orderer1, ... across the orderer organizations, and the
+// peers of each organization peer0, peer1, ... The first peer of each
+// organization is its anchor peer. The peers of all the organizations join
+// testchannel, unless channels are added.
+type Topology struct {
+	ordererOrgs   []topologyOrg
+	peerOrgs      []topologyOrg
+	consensusType string
+	nodes         int
+	channels      []topologyChannel
+}
+
+type topologyOrg struct {
+	name  string
+	nodes int
+}
+
+type topologyChannel struct {
+	name string
+	orgs []string
+}
+
+// NewTopology creates a Topology of a solo network without organizations
+func NewTopology() *Topology {
+	return &Topology{consensusType: "solo"}
+}
+
+// AddOrdererOrg adds an organization owning the given number of orderers
+func (t *Topology) AddOrdererOrg(name string, orderers int) *Topology {
+	t.ordererOrgs = append(t.ordererOrgs, topologyOrg{name: name, nodes: orderers})
+	return t
+}
+
+// AddPeerOrg adds an organization owning the given number of peers
+func (t *Topology) AddPeerOrg(name string, peers int) *Topology {
+	t.peerOrgs = append(t.peerOrgs, topologyOrg{name: name, nodes: peers})
+	return t
+}
+
+// WithConsensus sets the consensus type of the ordering service. For kafka,
+// nodes is the number of brokers. For etcdraft, it is the number of orderers
+// which are members of the raft cluster in the genesis block, taken in the
+// order they are added; the others are left to be added by reconfiguration.
+// Zero means all of them. Nodes is ignored by the other consensus types.
+func (t *Topology) WithConsensus(consensusType string, nodes int) *Topology {
+	t.consensusType = consensusType
+	t.nodes = nodes
+	return t
+}
+
+// AddChannel adds a channel joined by the peers of the given organizations
+func (t *Topology) AddChannel(name string, orgs ...string) *Topology {
+	t.channels = append(t.channels, topologyChannel{name: name, orgs: orgs})
+	return t
+}
+
+// Config returns the Config of the network
+func (t *Topology) Config() *Config {
+	config := &Config{
+		Consensus: &Consensus{Type: t.consensusType},
+		SystemChannel: &SystemChannel{
+			Name:    "systemchannel",
+			Profile: "OrdererGenesis",
+		},
+	}
+	if t.consensusType == "kafka" {
+		config.Consensus.Brokers = t.nodes
+		if config.Consensus.Brokers == 0 {
+			config.Consensus.Brokers = 1
+		}
+		config.Consensus.ZooKeepers = 1
+	}
+
+	genesis := &Profile{Name: "OrdererGenesis"}
+	for _, org := range t.ordererOrgs {
+		config.Organizations = append(config.Organizations, &Organization{
+			Name:   org.name,
+			MSPID:  org.name + "MSP",
+			Domain: strings.ToLower(org.name) + ".example.com",
+			CA:     &CA{Hostname: "ca"},
+		})
+		for i := 0; i < org.nodes; i++ {
+			name := fmt.Sprintf("orderer%d", len(config.Orderers))
+			config.Orderers = append(config.Orderers, &Orderer{Name: name, Organization: org.name})
+			genesis.Orderers = append(genesis.Orderers, name)
+		}
+	}
+	if t.consensusType == "etcdraft" && t.nodes > 0 && t.nodes < len(genesis.Orderers) {
+		genesis.Consenters = genesis.Orderers[:t.nodes]
+	}
+	config.Profiles = append(config.Profiles, genesis)
+
+	consortium := &Consortium{Name: "SampleConsortium"}
+	for _, org := range t.peerOrgs {
+		config.Organizations = append(config.Organizations, &Organization{
+			Name:          org.name,
+			MSPID:         org.name + "MSP",
+			Domain:        strings.ToLower(org.name) + ".example.com",
+			EnableNodeOUs: true,
+			Users:         2,
+			CA:            &CA{Hostname: "ca"},
+		})
+		consortium.Organizations = append(consortium.Organizations, org.name)
+	}
+	config.Consortiums = []*Consortium{consortium}
+
+	channels := t.channels
+	if len(channels) == 0 {
+		channels = []topologyChannel{{name: "testchannel", orgs: consortium.Organizations}}
+	}
+	for _, ch := range channels {
+		profile := ch.name + "Profile"
+		config.Channels = append(config.Channels, &Channel{Name: ch.name, Profile: profile})
+		config.Profiles = append(config.Profiles, &Profile{
+			Name:          profile,
+			Consortium:    consortium.Name,
+			Organizations: ch.orgs,
+		})
+	}
+
+	for _, org := range t.peerOrgs {
+		for i := 0; i < org.nodes; i++ {
+			peer := &Peer{Name: fmt.Sprintf("peer%d", i), Organization: org.name}
+			for _, ch := range channels {
+				if contains(ch.orgs, org.name) {
+					peer.Channels = append(peer.Channels, &PeerChannel{Name: ch.name, Anchor: i == 0})
+				}
+			}
+			config.Peers = append(config.Peers, peer)
+		}
+	}
+
+	return config
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package nwo_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/integration/nwo"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Topology", func() {
+	var tempDir string
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "topology")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	It("lays out the organizations, orderers and peers", func() {
+		config := nwo.NewTopology().
+			AddOrdererOrg("OrdererOrg1", 2).
+			AddOrdererOrg("OrdererOrg2", 1).
+			AddPeerOrg("Org1", 2).
+			AddPeerOrg("Org2", 1).
+			AddPeerOrg("Org3", 1).
+			AddChannel("bilateral", "Org1", "Org2").
+			AddChannel("all", "Org1", "Org2", "Org3").
+			Config()
+
+		Expect(config.Consensus).To(Equal(&nwo.Consensus{Type: "solo"}))
+		Expect(config.Organizations).To(HaveLen(5))
+		Expect(config.Organizations[1]).To(Equal(&nwo.Organization{
+			Name:   "OrdererOrg2",
+			MSPID:  "OrdererOrg2MSP",
+			Domain: "ordererorg2.example.com",
+			CA:     &nwo.CA{Hostname: "ca"},
+		}))
+		Expect(config.Orderers).To(Equal([]*nwo.Orderer{
+			{Name: "orderer0", Organization: "OrdererOrg1"},
+			{Name: "orderer1", Organization: "OrdererOrg1"},
+			{Name: "orderer2", Organization: "OrdererOrg2"},
+		}))
+		Expect(config.Consortiums).To(Equal([]*nwo.Consortium{{
+			Name:          "SampleConsortium",
+			Organizations: []string{"Org1", "Org2", "Org3"},
+		}}))
+		Expect(config.Channels).To(Equal([]*nwo.Channel{
+			{Name: "bilateral", Profile: "bilateralProfile"},
+			{Name: "all", Profile: "allProfile"},
+		}))
+
+		Expect(config.Peers).To(HaveLen(4))
+		Expect(config.Peers[0].ID()).To(Equal("Org1.peer0"))
+		Expect(config.Peers[0].Channels).To(Equal([]*nwo.PeerChannel{
+			{Name: "bilateral", Anchor: true},
+			{Name: "all", Anchor: true},
+		}))
+		Expect(config.Peers[1].Channels).To(Equal([]*nwo.PeerChannel{
+			{Name: "bilateral", Anchor: false},
+			{Name: "all", Anchor: false},
+		}))
+		Expect(config.Peers[3].ID()).To(Equal("Org3.peer0"))
+		Expect(config.Peers[3].Channels).To(Equal([]*nwo.PeerChannel{
+			{Name: "all", Anchor: true},
+		}))
+	})
+
+	It("generates the config of raft clusters", func() {
+		config := nwo.NewTopology().
+			AddOrdererOrg("OrdererOrg", 5).
+			AddPeerOrg("Org1", 1).
+			WithConsensus("etcdraft", 3).
+			Config()
+		network := nwo.New(config, tempDir, nil, 33000, components)
+		network.GenerateConfigTree()
+
+		profile := localconfig.Load("OrdererGenesis", tempDir)
+		Expect(profile.Orderer.OrdererType).To(Equal("etcdraft"))
+		Expect(profile.Orderer.Addresses).To(HaveLen(5))
+		consenters := profile.Orderer.EtcdRaft.GetConsenters()
+		Expect(consenters).To(HaveLen(3))
+		for i, c := range consenters {
+			orderer := network.Orderer(config.Orderers[i].Name)
+			Expect(c.Host).To(Equal("127.0.0.1"))
+			Expect(c.Port).To(BeEquivalentTo(network.OrdererPort(orderer, nwo.ListenPort)))
+			Expect(string(c.ServerTlsCert)).To(Equal(filepath.Join(network.OrdererLocalTLSDir(orderer), "server.crt")))
+		}
+
+		for _, o := range network.Orderers {
+			Expect(network.OrdererConfigPath(o)).To(BeAnExistingFile())
+		}
+		for _, p := range network.Peers {
+			Expect(network.PeerConfigPath(p)).To(BeAnExistingFile())
+		}
+	})
+})
@@ -0,0 +1,85 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pluggable
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	docker "github.com/fsouza/go-dockerclient"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+
+	"github.com/hyperledger/fabric/integration/helpers"
+	"github.com/hyperledger/fabric/integration/nwo"
+)
+
+var _ = Describe("Consensus plugin", func() {
+	var (
+		testDir string
+		network *nwo.Network
+		process ifrit.Process
+		cancel  context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		var err error
+		testDir, err = ioutil.TempDir("", "pluggable-consensus")
+		Expect(err).NotTo(HaveOccurred())
+
+		dir := filepath.Join(testDir, "consensus")
+		err = os.Mkdir(dir, 0700)
+		Expect(err).NotTo(HaveOccurred())
+		SetConsensusPluginActivationFolder(dir)
+
+		soloConfig := nwo.BasicSolo()
+		soloConfig.RemovePeer("Org1", "peer1")
+		soloConfig.RemovePeer("Org2", "peer1")
+		soloConfig.Consensus.Type = "plugin-solo"
+		soloConfig.Consensus.PluginMetadata = "unused"
+
+		client, err := docker.NewClientFromEnv()
+		Expect(err).NotTo(HaveOccurred())
+
+		network = nwo.New(soloConfig, testDir, client, 34000, components)
+		Expect(network.ConsensusPlugin()).To(BeTrue())
+		network.GenerateConfigTree()
+		network.ConfigureConsensusPlugin(components.Plugin("consensus"))
+		network.Bootstrap()
+
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		process = helpers.InvokeContext(ctx, network.NetworkGroupRunner())
+		Eventually(process.Ready()).Should(BeClosed())
+	})
+
+	AfterEach(func() {
+		cancel()
+		Eventually(process.Wait()).Should(Receive())
+
+		network.Cleanup()
+		os.RemoveAll(testDir)
+	})
+
+	It("orders transactions with the consenter provided by the plugin", func() {
+		Expect(CountConsensusPluginActivations()).To(Equal(len(network.Orderers)))
+
+		orderer := network.Orderer("orderer")
+		network.CreateAndJoinChannel(orderer, "testchannel")
+		nwo.DeployChaincode(network, "testchannel", orderer, nwo.Chaincode{
+			Name:    "mycc",
+			Version: "0.0",
+			Path:    "github.com/hyperledger/fabric/integration/chaincode/simple/cmd",
+			Ctor:    `{"Args":["init","a","100","b","200"]}`,
+			Policy:  `OR ('Org1MSP.member','Org2MSP.member')`,
+		})
+		RunQueryInvokeQuery(network, orderer, network.Peer("Org1", "peer0"))
+	})
+})
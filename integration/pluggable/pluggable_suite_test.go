@@ -8,6 +8,7 @@ package pluggable
 
 import (
 	"encoding/json"
+	"runtime"
 	"testing"
 
 	. "github.com/onsi/ginkgo"
@@ -17,8 +18,11 @@ import (
 )
 
 func TestPluggable(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Go plugins are not supported on windows")
+	}
 	RegisterFailHandler(Fail)
-	RunSpecs(t, "Pluggable endorsement and validation EndToEnd Suite")
+	RunSpecs(t, "Pluggable endorsement, validation and consensus EndToEnd Suite")
 }
 
 var components *nwo.Components
@@ -26,6 +30,7 @@ var components *nwo.Components
 var _ = SynchronizedBeforeSuite(func() []byte {
 	components = &nwo.Components{}
 	components.Build()
+	components.BuildPlugin("consensus", "github.com/hyperledger/fabric/integration/pluggable/testdata/plugins/consensus")
 
 	payload, err := json.Marshal(components)
 	Expect(err).NotTo(HaveOccurred())
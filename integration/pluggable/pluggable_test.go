@@ -7,12 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package pluggable
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"syscall"
 	"time"
 
 	docker "github.com/fsouza/go-dockerclient"
@@ -22,6 +22,7 @@ import (
 	"github.com/onsi/gomega/gexec"
 	"github.com/tedsuo/ifrit"
 
+	"github.com/hyperledger/fabric/integration/helpers"
 	"github.com/hyperledger/fabric/integration/nwo"
 	"github.com/hyperledger/fabric/integration/nwo/commands"
 	"github.com/hyperledger/fabric/integration/nwo/fabricconfig"
@@ -34,6 +35,7 @@ var _ = Describe("EndToEnd", func() {
 		network   *nwo.Network
 		chaincode nwo.Chaincode
 		process   ifrit.Process
+		cancel    context.CancelFunc
 
 		endorsementPluginPath string
 		validationPluginPath  string
@@ -79,7 +81,9 @@ var _ = Describe("EndToEnd", func() {
 		network.Bootstrap()
 
 		networkRunner := network.NetworkGroupRunner()
-		process = ifrit.Invoke(networkRunner)
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		process = helpers.InvokeContext(ctx, networkRunner)
 		Eventually(process.Ready()).Should(BeClosed())
 
 		chaincode = nwo.Chaincode{
@@ -96,7 +100,7 @@ var _ = Describe("EndToEnd", func() {
 
 	AfterEach(func() {
 		// stop the network
-		process.Signal(syscall.SIGTERM)
+		cancel()
 		Eventually(process.Wait()).Should(Receive())
 
 		// cleanup the network artifacts
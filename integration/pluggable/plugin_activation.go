@@ -18,6 +18,7 @@ import (
 const (
 	EndorsementPluginEnvVar = "ENDORSEMENT_PLUGIN_ENV_VAR"
 	ValidationPluginEnvVar  = "VALIDATION_PLUGIN_ENV_VAR"
+	ConsensusPluginEnvVar   = "CONSENSUS_PLUGIN_ENV_VAR"
 )
 
 // EndorsementPluginActivationFolder returns the name of the folder that if
@@ -48,6 +49,18 @@ func SetValidationPluginActivationFolder(path string) {
 	os.Setenv(ValidationPluginEnvVar, path)
 }
 
+// ConsensusPluginActivationFolder returns the name of the folder in which
+// a file is created every time an orderer activates the consensus plugin
+func ConsensusPluginActivationFolder() string {
+	return os.Getenv(ConsensusPluginEnvVar)
+}
+
+// SetConsensusPluginActivationFolder sets the name of the folder in which
+// a file is created every time an orderer activates the consensus plugin
+func SetConsensusPluginActivationFolder(path string) {
+	os.Setenv(ConsensusPluginEnvVar, path)
+}
+
 func markPluginActivation(dir string) {
 	fileName := filepath.Join(dir, viper.GetString("peer.id"))
 	_, err := os.Create(fileName)
@@ -68,6 +81,18 @@ func PublishValidationPluginActivation() {
 	markPluginActivation(ValidationPluginActivationFolder())
 }
 
+// PublishConsensusPluginActivation makes it known that the consensus plugin
+// was activated for the orderer that is invoking this function
+func PublishConsensusPluginActivation() {
+	// the orderer does not use the global viper instance, so there is no
+	// identity to name the file after
+	f, err := ioutil.TempFile(ConsensusPluginActivationFolder(), "orderer")
+	if err != nil {
+		panic(fmt.Sprintf("failed to create file in %s: %v", ConsensusPluginActivationFolder(), err))
+	}
+	f.Close()
+}
+
 // CountEndorsementPluginActivations returns the number of peers that activated
 // the endorsement plugin
 func CountEndorsementPluginActivations() int {
@@ -80,6 +105,12 @@ func CountValidationPluginActivations() int {
 	return listDir(ValidationPluginActivationFolder())
 }
 
+// CountConsensusPluginActivations returns the number of orderers that activated
+// the consensus plugin
+func CountConsensusPluginActivations() int {
+	return listDir(ConsensusPluginActivationFolder())
+}
+
 func listDir(d string) int {
 	dir, err := ioutil.ReadDir(d)
 	if err != nil {
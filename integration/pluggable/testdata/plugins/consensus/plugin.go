@@ -0,0 +1,21 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"github.com/hyperledger/fabric/integration/pluggable"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/solo"
+)
+
+// go build -buildmode=plugin -o plugin.so
+
+// NewConsenter is the function ran by the orderer to create the consenter provided by the plugin.
+func NewConsenter() consensus.Consenter {
+	pluggable.PublishConsensusPluginActivation()
+	return solo.New()
+}
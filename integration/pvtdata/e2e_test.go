@@ -13,9 +13,9 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"syscall"
 	"time"
 
+	integrationhelpers "github.com/hyperledger/fabric/integration/helpers"
 	"github.com/hyperledger/fabric/integration/pvtdata/helpers"
 	"github.com/hyperledger/fabric/integration/pvtdata/runner"
 	"github.com/hyperledger/fabric/integration/pvtdata/world"
@@ -462,6 +462,53 @@ var _ = Describe("PrivateData-EndToEnd", func() {
 			verifyAccessFailed(d.Chaincode.Name, d.Channel, `{"Args":["readMarblePrivateDetails","marble1"]}`, adminPeer, "Failed to get private details for marble1")
 		})
 	})
+	PDescribe("transactions of Idemix clients", func() {
+		BeforeEach(func() {
+			var err error
+			testDir, err = ioutil.TempDir("", "e2e-pvtdata")
+			Expect(err).NotTo(HaveOccurred())
+			w = world.GenerateBasicConfig("solo", 1, 3, testDir, components)
+			w.AddIdemixOrg("IdemixOrg", "idemixorg.example.com", 1)
+
+			d = world.Deployment{
+				Channel: "testchannel",
+				Chaincode: world.Chaincode{
+					Name:                  "marblesp",
+					Version:               "1.0",
+					Path:                  "github.com/hyperledger/fabric/integration/chaincode/marbles_private/cmd",
+					ExecPath:              os.Getenv("PATH"),
+					CollectionsConfigPath: filepath.Join("testdata", "collection_configs", "collections_config1.json"),
+				},
+				InitArgs: `{"Args":["init"]}`,
+				Policy:   `OR ('Org1MSP.member','Org2MSP.member', 'Org3MSP.member')`,
+				Orderer:  "127.0.0.1:7050",
+			}
+
+			w.SetupWorld(d)
+		})
+
+		AfterEach(func() {
+			if w != nil {
+				w.Close(d)
+			}
+			os.RemoveAll(testDir)
+		})
+
+		It("endorses and commits the transactions signed by Idemix users", func() {
+			By("invoking initMarble function of the chaincode as an Idemix user")
+			idemixClient := w.IdemixClient("peer0.org1.example.com", w.IdemixOrgs[0], "User1")
+			clientRunner := idemixClient.InvokeChaincode(d.Chaincode.Name, d.Channel, `{"Args":["initMarble","marble1","blue","35","tom","99"]}`, d.Orderer)
+			err := helpers.Execute(clientRunner)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clientRunner.Err()).To(gbytes.Say("Chaincode invoke successful."))
+
+			By("querying the marble as an Idemix user")
+			verifyAccess(d.Chaincode.Name, d.Channel, `{"Args":["readMarble","marble1"]}`, []*runner.Peer{idemixClient}, `{"docType":"marble","name":"marble1","color":"blue","size":35,"owner":"tom"}`)
+
+			By("querying the marble as the admin of Org2")
+			verifyAccess(d.Chaincode.Name, d.Channel, `{"Args":["readMarble","marble1"]}`, []*runner.Peer{getPeer(0, 2, w.Rootpath)}, `{"docType":"marble","name":"marble1","color":"blue","size":35,"owner":"tom"}`)
+		})
+	})
 })
 
 func getPeer(peer int, org int, testDir string) *runner.Peer {
@@ -549,8 +596,8 @@ func spawnNewPeer(w *world.World, peerIndex int, orgIndex int) {
 
 func stopPeer(w *world.World, peer int, org int) {
 	peerName := fmt.Sprintf("peer%d.org%d.example.com", peer, org)
-	w.NameToProcessMapping[peerName].Signal(syscall.SIGTERM)
-	w.NameToProcessMapping[peerName].Signal(syscall.SIGKILL)
+	w.NameToProcessMapping[peerName].Signal(integrationhelpers.TerminateSignal)
+	w.NameToProcessMapping[peerName].Signal(os.Kill)
 	delete(w.NameToProcessMapping, peerName)
 }
 
@@ -11,10 +11,10 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"syscall"
 	"time"
 
 	"github.com/fsouza/go-dockerclient"
+	integrationhelpers "github.com/hyperledger/fabric/integration/helpers"
 	"github.com/hyperledger/fabric/integration/pvtdata/helpers"
 	"github.com/hyperledger/fabric/integration/pvtdata/runner"
 	. "github.com/onsi/ginkgo"
@@ -130,10 +130,10 @@ var _ bool = Describe("DiscoveryService", func() {
 
 	AfterEach(func() {
 		if ordererProcess != nil {
-			ordererProcess.Signal(syscall.SIGTERM)
+			ordererProcess.Signal(integrationhelpers.TerminateSignal)
 		}
 		if peerProcess != nil {
-			peerProcess.Signal(syscall.SIGTERM)
+			peerProcess.Signal(integrationhelpers.TerminateSignal)
 		}
 
 		// Stop the running chaincode containers
@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package runner
+
+import (
+	"os/exec"
+
+	"github.com/tedsuo/ifrit/ginkgomon"
+)
+
+// Idemixgen creates runners that call idemixgen functions.
+type Idemixgen struct {
+	// The location of the idemixgen executable
+	Path string
+	// The output directory
+	Output string
+}
+
+// CAKeyGen uses idemixgen to generate the issuer and revocation keys of an
+// Idemix CA in the output directory.
+func (i *Idemixgen) CAKeyGen(extraArgs ...string) *ginkgomon.Runner {
+	return ginkgomon.New(ginkgomon.Config{
+		Name:          "idemixgen ca-keygen",
+		AnsiColorCode: "33m",
+		Command: exec.Command(
+			i.Path,
+			append([]string{
+				"ca-keygen",
+				"--output", i.Output,
+			}, extraArgs...)...,
+		),
+	})
+}
+
+// SignerConfig uses idemixgen to generate the MSP config of a signer of the
+// CA whose keys are in caDir in the output directory.
+func (i *Idemixgen) SignerConfig(caDir string, extraArgs ...string) *ginkgomon.Runner {
+	return ginkgomon.New(ginkgomon.Config{
+		Name:          "idemixgen signerconfig",
+		AnsiColorCode: "33m",
+		Command: exec.Command(
+			i.Path,
+			append([]string{
+				"signerconfig",
+				"--ca-input", caDir,
+				"--output", i.Output,
+			}, extraArgs...)...,
+		),
+	})
+}
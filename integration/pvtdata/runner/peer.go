@@ -25,6 +25,8 @@ type Peer struct {
 	ExecPath      string
 	ConfigDir     string
 	MSPConfigPath string
+	MSPID         string
+	MSPType       string
 	LogLevel      string
 }
 
@@ -38,6 +40,12 @@ func (p *Peer) setupEnvironment(cmd *exec.Cmd) {
 	if p.MSPConfigPath != "" {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("CORE_PEER_MSPCONFIGPATH=%s", p.MSPConfigPath))
 	}
+	if p.MSPID != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("CORE_PEER_LOCALMSPID=%s", p.MSPID))
+	}
+	if p.MSPType != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("CORE_PEER_LOCALMSPTYPE=%s", p.MSPType))
+	}
 	if p.GoPath != "" {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("GOPATH=%s", p.GoPath))
 	}
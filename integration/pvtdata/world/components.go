@@ -47,6 +47,10 @@ func (c *Components) Build(args ...string) {
 	Expect(err).NotTo(HaveOccurred())
 	c.Paths["configtxgen"] = configtxgen
 
+	idemixgen, err := gexec.Build("github.com/hyperledger/fabric/common/tools/idemixgen", args...)
+	Expect(err).NotTo(HaveOccurred())
+	c.Paths["idemixgen"] = idemixgen
+
 	orderer, err := gexec.Build("github.com/hyperledger/fabric/orderer", args...)
 	Expect(err).NotTo(HaveOccurred())
 	c.Paths["orderer"] = orderer
@@ -79,6 +83,12 @@ func (c *Components) Configtxgen() *pvtdatarunner.Configtxgen {
 	}
 }
 
+func (c *Components) Idemixgen() *pvtdatarunner.Idemixgen {
+	return &pvtdatarunner.Idemixgen{
+		Path: c.Paths["idemixgen"],
+	}
+}
+
 func (c *Components) Orderer() *pvtdatarunner.Orderer {
 	return &pvtdatarunner.Orderer{
 		Path: c.Paths["orderer"],
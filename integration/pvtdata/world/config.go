@@ -14,12 +14,12 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/alecthomas/template"
 	docker "github.com/fsouza/go-dockerclient"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	integrationhelpers "github.com/hyperledger/fabric/integration/helpers"
 	"github.com/hyperledger/fabric/integration/pvtdata/helpers"
 	pvtdatarunner "github.com/hyperledger/fabric/integration/pvtdata/runner"
 	"github.com/hyperledger/fabric/integration/runner"
@@ -52,6 +52,15 @@ type PeerOrgConfig struct {
 	PeerCount        int
 }
 
+// IdemixOrgConfig is an organization whose members hold Idemix credentials
+// issued by its own CA. It has no peers, and its users are clients of the
+// peers of the other organizations.
+type IdemixOrgConfig struct {
+	OrganizationName string
+	Domain           string
+	UserCount        int
+}
+
 type Stopper interface {
 	Stop() error
 }
@@ -64,6 +73,7 @@ type World struct {
 	ChannelProfileName string
 	OrdererOrgs        []OrdererConfig
 	PeerOrgs           []PeerOrgConfig
+	IdemixOrgs         []IdemixOrgConfig
 	Profiles           map[string]localconfig.Profile
 	Cryptogen          pvtdatarunner.Cryptogen
 	SystemChannel      string
@@ -224,6 +234,44 @@ func GenerateBasicConfig(ordererType string, numPeers, numPeerOrgs int, testDir
 	return w
 }
 
+// AddIdemixOrg adds an Idemix organization with an admin and userCount users
+// to the channel and to the consortium of the world.
+func (w *World) AddIdemixOrg(orgName, domain string, userCount int) {
+	org := &localconfig.Organization{
+		Name:    orgName,
+		ID:      fmt.Sprintf("%sMSP", orgName),
+		MSPDir:  filepath.Join("crypto", "idemixOrganizations", domain),
+		MSPType: "idemix",
+	}
+
+	channelProfile := w.Profiles[w.ChannelProfileName]
+	channelProfile.Application.Organizations = append(channelProfile.Application.Organizations, org)
+	consortium := w.Profiles[w.OrdererProfileName].Consortiums[channelProfile.Consortium]
+	consortium.Organizations = append(consortium.Organizations, org)
+
+	w.IdemixOrgs = append(w.IdemixOrgs, IdemixOrgConfig{
+		OrganizationName: orgName,
+		Domain:           domain,
+		UserCount:        userCount,
+	})
+}
+
+// IdemixUserMSPDir returns the directory of the MSP config of an Idemix user.
+func (w *World) IdemixUserMSPDir(org IdemixOrgConfig, user string) string {
+	return filepath.Join(w.Rootpath, "crypto", "idemixOrganizations", org.Domain, "users", fmt.Sprintf("%s@%s", user, org.Domain))
+}
+
+// IdemixClient returns a peer runner of the peer peerID whose commands are
+// signed by an Idemix user.
+func (w *World) IdemixClient(peerID string, org IdemixOrgConfig, user string) *pvtdatarunner.Peer {
+	p := w.Components.Peer()
+	p.ConfigDir = filepath.Join(w.Rootpath, peerID)
+	p.MSPConfigPath = w.IdemixUserMSPDir(org, user)
+	p.MSPID = fmt.Sprintf("%sMSP", org.OrganizationName)
+	p.MSPType = "idemix"
+	return p
+}
+
 func (w *World) Construct() {
 	var ordererCrypto = `
 OrdererOrgs:{{range .OrdererOrgs}}
@@ -283,6 +331,10 @@ func (w *World) BootstrapNetwork(channel string) {
 	r := w.Cryptogen.Generate()
 	execute(r)
 
+	for _, org := range w.IdemixOrgs {
+		w.generateIdemixOrg(org)
+	}
+
 	configtxgen := pvtdatarunner.Configtxgen{
 		Path:      w.Components.Paths["configtxgen"],
 		ChannelID: w.SystemChannel,
@@ -317,6 +369,24 @@ func (w *World) BootstrapNetwork(channel string) {
 	}
 }
 
+// generateIdemixOrg generates the keys of the CA of an Idemix organization,
+// and the credentials of its admin and users.
+func (w *World) generateIdemixOrg(org IdemixOrgConfig) {
+	orgDir := filepath.Join(w.Rootpath, "crypto", "idemixOrganizations", org.Domain)
+
+	idemixgen := w.Components.Idemixgen()
+	idemixgen.Output = orgDir
+	execute(idemixgen.CAKeyGen())
+
+	idemixgen.Output = w.IdemixUserMSPDir(org, "Admin")
+	execute(idemixgen.SignerConfig(orgDir, "--admin", "--enrollmentId", "Admin", "--org-unit", org.OrganizationName, "--revocationHandle", "1"))
+	for user := 1; user <= org.UserCount; user++ {
+		name := fmt.Sprintf("User%d", user)
+		idemixgen.Output = w.IdemixUserMSPDir(org, name)
+		execute(idemixgen.SignerConfig(orgDir, "--enrollmentId", name, "--org-unit", org.OrganizationName, "--revocationHandle", strconv.Itoa(user+1)))
+	}
+}
+
 func (w *World) BuildNetwork() {
 	w.ordererNetwork()
 	w.peerNetwork()
@@ -475,10 +545,10 @@ func (w *World) Close(deployments ...Deployment) {
 
 	// Stop the orderers and peers
 	for _, localProc := range w.LocalProcess {
-		localProc.Signal(syscall.SIGTERM)
+		localProc.Signal(integrationhelpers.TerminateSignal)
 	}
 	for _, localProc := range w.LocalProcess {
-		localProc.Signal(syscall.SIGKILL)
+		localProc.Signal(os.Kill)
 	}
 
 	// Stop the docker constainers for zookeeper and kafka
@@ -7,10 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package e2e
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"os"
-	"syscall"
 	"time"
 
 	docker "github.com/fsouza/go-dockerclient"
@@ -20,6 +20,7 @@ import (
 	"github.com/onsi/gomega/gexec"
 	"github.com/tedsuo/ifrit"
 
+	"github.com/hyperledger/fabric/integration/helpers"
 	"github.com/hyperledger/fabric/integration/nwo"
 	"github.com/hyperledger/fabric/integration/nwo/commands"
 )
@@ -31,6 +32,7 @@ var _ = Describe("SBE_E2E", func() {
 		network   *nwo.Network
 		chaincode nwo.Chaincode
 		process   ifrit.Process
+		cancel    context.CancelFunc
 	)
 
 	BeforeEach(func() {
@@ -52,7 +54,7 @@ var _ = Describe("SBE_E2E", func() {
 
 	AfterEach(func() {
 		if process != nil {
-			process.Signal(syscall.SIGTERM)
+			cancel()
 			Eventually(process.Wait(), time.Minute).Should(Receive())
 		}
 		if network != nil {
@@ -68,7 +70,9 @@ var _ = Describe("SBE_E2E", func() {
 			network.Bootstrap()
 
 			networkRunner := network.NetworkGroupRunner()
-			process = ifrit.Invoke(networkRunner)
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			process = helpers.InvokeContext(ctx, networkRunner)
 			Eventually(process.Ready()).Should(BeClosed())
 		})
 
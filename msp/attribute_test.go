@@ -0,0 +1,81 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/attrmgr"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func attributePrincipal(t *testing.T, mspID, name, value string) *msp.MSPPrincipal {
+	principalBytes, err := proto.Marshal(&msp.MSPAttribute{MspIdentifier: mspID, Name: name, Value: value})
+	require.NoError(t, err)
+	return &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ATTRIBUTE,
+		Principal:               principalBytes,
+	}
+}
+
+func TestCertHasAttribute(t *testing.T) {
+	cert := &x509.Certificate{}
+	err := certHasAttribute(cert, "role", "auditor")
+	assert.EqualError(t, err, "the identity does not have attribute role")
+
+	attrs := &attrmgr.Attributes{Attrs: map[string]string{"role": "auditor"}}
+	require.NoError(t, attrmgr.New().AddAttributesToCert(attrs, cert))
+	assert.NoError(t, certHasAttribute(cert, "role", "auditor"))
+
+	err = certHasAttribute(cert, "role", "admin")
+	assert.EqualError(t, err, "the identity attribute role does not match (expected admin, got auditor)")
+
+	cert.Extensions[0].Value = []byte("garbage")
+	err = certHasAttribute(cert, "role", "auditor")
+	assert.Contains(t, err.Error(), "failed reading attributes from certificate")
+}
+
+func TestSatisfiesAttributePrincipal(t *testing.T) {
+	mspDir, err := configtest.GetDevMspDir()
+	require.NoError(t, err)
+	thisMSP := getLocalMSPWithVersion(t, mspDir, MSPv1_4)
+
+	id, err := thisMSP.GetDefaultSigningIdentity()
+	require.NoError(t, err)
+
+	err = id.SatisfiesPrincipal(attributePrincipal(t, "OtherOrg", "role", "auditor"))
+	assert.EqualError(t, err, "the identity is a member of a different MSP (expected OtherOrg, got SampleOrg)")
+
+	err = id.SatisfiesPrincipal(attributePrincipal(t, "SampleOrg", "role", "auditor"))
+	assert.EqualError(t, err, "the identity does not have attribute role")
+
+	err = id.SatisfiesPrincipal(&msp.MSPPrincipal{PrincipalClassification: msp.MSPPrincipal_ATTRIBUTE, Principal: []byte("garbage")})
+	assert.Contains(t, err.Error(), "could not unmarshal MSPAttribute from principal")
+
+	// principals understood by earlier versions are still supported
+	memberBytes, err := proto.Marshal(&msp.MSPRole{Role: msp.MSPRole_MEMBER, MspIdentifier: "SampleOrg"})
+	require.NoError(t, err)
+	assert.NoError(t, id.SatisfiesPrincipal(&msp.MSPPrincipal{PrincipalClassification: msp.MSPPrincipal_ROLE, Principal: memberBytes}))
+}
+
+func TestAttributePrincipalRequiresV14(t *testing.T) {
+	mspDir, err := configtest.GetDevMspDir()
+	require.NoError(t, err)
+	thisMSP := getLocalMSPWithVersion(t, mspDir, MSPv1_3)
+
+	id, err := thisMSP.GetDefaultSigningIdentity()
+	require.NoError(t, err)
+
+	err = id.SatisfiesPrincipal(attributePrincipal(t, "SampleOrg", "role", "auditor"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid principal type 5")
+}
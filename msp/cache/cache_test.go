@@ -10,6 +10,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/hyperledger/fabric/core/config/configtest"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mocks"
 	msp2 "github.com/hyperledger/fabric/protos/msp"
@@ -321,3 +322,67 @@ func TestSatisfiesPrincipal(t *testing.T) {
 	assert.NotNil(t, v)
 	assert.Contains(t, "Invalid", v.(error).Error())
 }
+
+// BenchmarkDeserializeAndValidate measures what evaluating the policies of
+// the requests of a client costs with and without the cache which wraps the
+// MSPs of the channels, when the client signs every request with the same
+// certificate. With the cache, only the signature of the request is verified
+// every time.
+func BenchmarkDeserializeAndValidate(b *testing.B) {
+	mspDir, err := configtest.GetDevMspDir()
+	if err != nil {
+		b.Fatal(err)
+	}
+	conf, err := msp.GetLocalMspConfig(mspDir, nil, "SampleOrg")
+	if err != nil {
+		b.Fatal(err)
+	}
+	newMSP := func() msp.MSP {
+		m, err := msp.New(&msp.BCCSPNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: msp.MSPv1_0}})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := m.Setup(conf); err != nil {
+			b.Fatal(err)
+		}
+		return m
+	}
+	signer, err := newMSP().GetDefaultSigningIdentity()
+	if err != nil {
+		b.Fatal(err)
+	}
+	creator, err := signer.Serialize()
+	if err != nil {
+		b.Fatal(err)
+	}
+	msg := []byte("deliver request")
+	sig, err := signer.Sign(msg)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	deserializeAndValidate := func(b *testing.B, m msp.MSP, verify bool) {
+		for i := 0; i < b.N; i++ {
+			id, err := m.DeserializeIdentity(creator)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := id.Validate(); err != nil {
+				b.Fatal(err)
+			}
+			if verify {
+				if err := id.Verify(msg, sig); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+	cached, err := New(newMSP())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("Uncached", func(b *testing.B) { deserializeAndValidate(b, newMSP(), false) })
+	b.Run("Cached", func(b *testing.B) { deserializeAndValidate(b, cached, false) })
+	b.Run("CachedAndVerify", func(b *testing.B) { deserializeAndValidate(b, cached, true) })
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/idemix"
 	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
@@ -391,6 +392,15 @@ func GetIdemixMspConfig(dir string, ID string) (*msp.MSPConfig, error) {
 			return nil, err
 		}
 		idemixConfig.Signer = signerConfig
+
+		// the local MSP verifies in the epoch of the signer's
+		// credential revocation information
+		cri := &idemix.CredentialRevocationInformation{}
+		err = proto.Unmarshal(signerConfig.CredentialRevocationInformation, cri)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal credential revocation information")
+		}
+		idemixConfig.Epoch = cri.Epoch
 	}
 
 	confBytes, err := proto.Marshal(idemixConfig)
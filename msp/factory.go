@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package msp
 
 import (
+	"github.com/hyperledger/fabric/bccsp"
 	"github.com/pkg/errors"
 )
 
@@ -16,6 +17,7 @@ const (
 	MSPv1_0 = iota
 	MSPv1_1
 	MSPv1_3
+	MSPv1_4
 )
 
 // NewOpts represent
@@ -36,6 +38,16 @@ func (o *NewBaseOpts) GetVersion() MSPVersion {
 // BCCSPNewOpts contains the options to instantiate a new BCCSP-based (X509) MSP
 type BCCSPNewOpts struct {
 	NewBaseOpts
+
+	// BCCSP is the crypto provider backing the MSP. If nil, the
+	// default provider of the BCCSP factory is used.
+	BCCSP bccsp.BCCSP
+
+	// ExpiredCertTolerance makes the MSP validate its TLS CA certificates
+	// at a time they were valid, as it does for its other certificates,
+	// rather than at the current time. The MSP can then be set up from a
+	// config whose TLS CA certificates expired since.
+	ExpiredCertTolerance bool
 }
 
 // IdemixNewOpts contains the options to instantiate a new Idemix-based MSP
@@ -45,20 +57,33 @@ type IdemixNewOpts struct {
 
 // New create a new MSP instance depending on the passed Opts
 func New(opts NewOpts) (MSP, error) {
-	switch opts.(type) {
+	switch o := opts.(type) {
 	case *BCCSPNewOpts:
+		var inst MSP
+		var err error
 		switch opts.GetVersion() {
 		case MSPv1_0:
-			return newBccspMsp(MSPv1_0)
+			inst, err = newBccspMsp(MSPv1_0)
 		case MSPv1_1:
-			return newBccspMsp(MSPv1_1)
+			inst, err = newBccspMsp(MSPv1_1)
+		case MSPv1_4:
+			inst, err = newBccspMsp(MSPv1_4)
 		case MSPv1_3:
-			return newBccspMsp(MSPv1_3)
+			inst, err = newBccspMsp(MSPv1_3)
 		default:
 			return nil, errors.Errorf("Invalid *BCCSPNewOpts. Version not recognized [%v]", opts.GetVersion())
 		}
+		if err == nil && o.BCCSP != nil {
+			inst.(*bccspmsp).bccsp = o.BCCSP
+		}
+		if err == nil {
+			inst.(*bccspmsp).expiredCertTolerance = o.ExpiredCertTolerance
+		}
+		return inst, err
 	case *IdemixNewOpts:
 		switch opts.GetVersion() {
+		case MSPv1_4:
+			return newIdemixMsp(MSPv1_4)
 		case MSPv1_3:
 			return newIdemixMsp(MSPv1_3)
 		case MSPv1_1:
@@ -12,6 +12,8 @@ import (
 	"reflect"
 	"runtime"
 
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,7 +23,7 @@ func TestNewInvalidOpts(t *testing.T) {
 	assert.Contains(t, err.Error(), "Invalid msp.NewOpts instance. It must be either *BCCSPNewOpts or *IdemixNewOpts. It was [<nil>]")
 	assert.Nil(t, i)
 
-	i, err = New(&BCCSPNewOpts{NewBaseOpts{Version: -1}})
+	i, err = New(&BCCSPNewOpts{NewBaseOpts: NewBaseOpts{Version: -1}})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "Invalid *BCCSPNewOpts. Version not recognized [-1]")
 	assert.Nil(t, i)
@@ -33,7 +35,7 @@ func TestNewInvalidOpts(t *testing.T) {
 }
 
 func TestNew(t *testing.T) {
-	i, err := New(&BCCSPNewOpts{NewBaseOpts{Version: MSPv1_0}})
+	i, err := New(&BCCSPNewOpts{NewBaseOpts: NewBaseOpts{Version: MSPv1_0}})
 	assert.NoError(t, err)
 	assert.NotNil(t, i)
 	assert.Equal(t, MSPVersion(MSPv1_0), i.(*bccspmsp).version)
@@ -46,7 +48,7 @@ func TestNew(t *testing.T) {
 		runtime.FuncForPC(reflect.ValueOf(i.(*bccspmsp).validateIdentityOUsV1).Pointer()).Name(),
 	)
 
-	i, err = New(&BCCSPNewOpts{NewBaseOpts{Version: MSPv1_1}})
+	i, err = New(&BCCSPNewOpts{NewBaseOpts: NewBaseOpts{Version: MSPv1_1}})
 	assert.NoError(t, err)
 	assert.NotNil(t, i)
 	assert.Equal(t, MSPVersion(MSPv1_1), i.(*bccspmsp).version)
@@ -68,3 +70,16 @@ func TestNew(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, i)
 }
+
+func TestNewWithBCCSP(t *testing.T) {
+	csp, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+
+	i, err := New(&BCCSPNewOpts{NewBaseOpts: NewBaseOpts{Version: MSPv1_1}, BCCSP: csp})
+	assert.NoError(t, err)
+	assert.Equal(t, csp, i.(*bccspmsp).bccsp)
+
+	i, err = New(&BCCSPNewOpts{NewBaseOpts: NewBaseOpts{Version: MSPv1_1}})
+	assert.NoError(t, err)
+	assert.Equal(t, factory.GetDefault(), i.(*bccspmsp).bccsp)
+}
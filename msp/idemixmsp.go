@@ -148,6 +148,7 @@ func (msp *idemixmsp) Setup(conf1 *m.MSPConfig) error {
 		return errors.Errorf("key is of type %v, not of type ECDSA", reflect.TypeOf(revocationPk))
 	}
 	msp.revocationPK = ecdsaPublicKey
+	msp.epoch = int(conf.Epoch)
 
 	if conf.Signer == nil {
 		// No credential in config, so we don't setup a default signer
@@ -513,8 +514,8 @@ func newIdemixIdentity(msp *idemixmsp, nym *FP256BN.ECP, role *m.MSPRole, ou *m.
 }
 
 func (id *idemixidentity) ExpiresAt() time.Time {
-	// Idemix MSP currently does not use expiration dates or revocation,
-	// so we return the zero time to indicate this.
+	// Idemix MSP currently does not use expiration dates, credentials
+	// are revoked by means of epochs instead, so we return the zero time.
 	return time.Time{}
 }
 
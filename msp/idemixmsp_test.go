@@ -186,6 +186,36 @@ func TestIdentitySerialization(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestIdentityWrongEpoch(t *testing.T) {
+	signer, err := setup("testdata/idemix/MSP1OU1", "MSP1OU1")
+	assert.NoError(t, err)
+
+	id, err := getDefaultSigner(signer)
+	assert.NoError(t, err)
+	serializedID, err := id.Serialize()
+	assert.NoError(t, err)
+
+	// a verifier that moved on to the next epoch no longer accepts the identity
+	conf, err := GetIdemixMspConfig("testdata/idemix/MSP1OU1", "MSP1OU1")
+	assert.NoError(t, err)
+	idemixConf := &msp.IdemixMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(conf.Config, idemixConf))
+	idemixConf.Signer = nil
+	idemixConf.Epoch++
+	conf.Config, err = proto.Marshal(idemixConf)
+	assert.NoError(t, err)
+
+	verifier, err := newIdemixMsp(MSPv1_3)
+	assert.NoError(t, err)
+	assert.NoError(t, verifier.Setup(conf))
+
+	verID, err := verifier.DeserializeIdentity(serializedID)
+	assert.NoError(t, err)
+	err = verifier.Validate(verID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "but the current epoch is 1")
+}
+
 func TestIdentitySerializationBad(t *testing.T) {
 	msp, err := setup("testdata/idemix/MSP1OU1", "MSP1OU1")
 	assert.NoError(t, err)
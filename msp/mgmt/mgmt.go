@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package mgmt
 
 import (
+	"path/filepath"
 	"reflect"
 	"sync"
 
@@ -46,6 +47,105 @@ func LoadLocalMsp(dir string, bccspConfig *factory.FactoryOpts, mspID string) er
 	return GetLocalMSP().Setup(conf)
 }
 
+// LoadAdditionalLocalMsp loads a further local MSP from the specified directory,
+// for a peer that operates on behalf of more than one organization. When the
+// software BCCSP is used, the keys of the MSP are read from the keystore in
+// the specified directory.
+func LoadAdditionalLocalMsp(dir string, bccspConfig *factory.FactoryOpts, mspID string) error {
+	if mspID == "" {
+		return errors.New("the local MSP must have an ID")
+	}
+	for _, local := range GetLocalMSPs() {
+		if id, _ := local.GetIdentifier(); id == mspID {
+			return errors.Errorf("local MSP %s is already loaded", mspID)
+		}
+	}
+
+	conf, err := msp.GetLocalMspConfig(dir, nil, mspID)
+	if err != nil {
+		return err
+	}
+
+	if bccspConfig == nil || bccspConfig.ProviderName == "" || bccspConfig.ProviderName == factory.SoftwareBasedFactoryName {
+		swOpts := factory.GetDefaultOpts().SwOpts
+		if bccspConfig != nil && bccspConfig.SwOpts != nil {
+			swOpts.SecLevel = bccspConfig.SwOpts.SecLevel
+			swOpts.HashFamily = bccspConfig.SwOpts.HashFamily
+		}
+		swOpts.Ephemeral = false
+		swOpts.FileKeystore = &factory.FileKeystoreOpts{KeyStorePath: filepath.Join(dir, "keystore")}
+		bccspConfig = &factory.FactoryOpts{ProviderName: factory.SoftwareBasedFactoryName, SwOpts: swOpts}
+	}
+	csp, err := factory.GetBCCSPFromOpts(bccspConfig)
+	if err != nil {
+		return err
+	}
+
+	mspInst, err := msp.New(&msp.BCCSPNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: msp.MSPv1_0}, BCCSP: csp})
+	if err != nil {
+		return err
+	}
+	mspInst, err = cache.New(mspInst)
+	if err != nil {
+		return err
+	}
+	if err := mspInst.Setup(conf); err != nil {
+		return err
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	additionalLocalMsps = append(additionalLocalMsps, mspInst)
+	return nil
+}
+
+// GetLocalMSPs returns the local MSP followed by the additional local MSPs
+func GetLocalMSPs() []msp.MSP {
+	local := GetLocalMSP()
+
+	m.Lock()
+	defer m.Unlock()
+	return append([]msp.MSP{local}, additionalLocalMsps...)
+}
+
+// GetLocalMSPForChannel returns the local MSP whose identity is used on the
+// supplied channel: the first of the local MSPs that is a member of the
+// channel, or the local MSP if there is none
+func GetLocalMSPForChannel(chainID string) msp.MSP {
+	locals := GetLocalMSPs()
+	if len(locals) == 1 || chainID == "" {
+		return locals[0]
+	}
+
+	m.Lock()
+	mspMgr, ok := mspMap[chainID]
+	m.Unlock()
+	if !ok {
+		return locals[0]
+	}
+	channelMSPs, err := mspMgr.GetMSPs()
+	if err != nil {
+		return locals[0]
+	}
+
+	for _, local := range locals {
+		id, err := local.GetIdentifier()
+		if err != nil {
+			continue
+		}
+		if _, member := channelMSPs[id]; member {
+			return local
+		}
+	}
+	return locals[0]
+}
+
+// GetLocalSigningIdentityForChannel returns the signing identity of the local
+// MSP used on the supplied channel
+func GetLocalSigningIdentityForChannel(chainID string) (msp.SigningIdentity, error) {
+	return GetLocalMSPForChannel(chainID).GetDefaultSigningIdentity()
+}
+
 // FIXME: AS SOON AS THE CHAIN MANAGEMENT CODE IS COMPLETE,
 // THESE MAPS AND HELPSER FUNCTIONS SHOULD DISAPPEAR BECAUSE
 // OWNERSHIP OF PER-CHAIN MSP MANAGERS WILL BE HANDLED BY IT;
@@ -53,6 +153,7 @@ func LoadLocalMsp(dir string, bccspConfig *factory.FactoryOpts, mspID string) er
 
 var m sync.Mutex
 var localMsp msp.MSP
+var additionalLocalMsps []msp.MSP
 var mspMap map[string]msp.MSPManager = make(map[string]msp.MSPManager)
 var mspLogger = flogging.MustGetLogger("msp")
 
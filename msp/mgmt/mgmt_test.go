@@ -134,3 +134,37 @@ func LoadMSPSetupForTesting() error {
 
 	return nil
 }
+
+func TestAdditionalLocalMsps(t *testing.T) {
+	defer func() { additionalLocalMsps = nil }()
+
+	err := LoadAdditionalLocalMsp("../testdata/nodeous1", nil, "")
+	assert.EqualError(t, err, "the local MSP must have an ID")
+	err = LoadAdditionalLocalMsp("../testdata/doesnotexist", nil, "Org2MSP")
+	assert.Error(t, err)
+
+	err = LoadAdditionalLocalMsp("../testdata/nodeous1", nil, "Org2MSP")
+	assert.NoError(t, err)
+	err = LoadAdditionalLocalMsp("../testdata/nodeous1", nil, "Org2MSP")
+	assert.EqualError(t, err, "local MSP Org2MSP is already loaded")
+
+	locals := GetLocalMSPs()
+	assert.Len(t, locals, 2)
+	assert.Equal(t, GetLocalMSP(), locals[0])
+
+	mgr := msp.NewMSPManager()
+	assert.NoError(t, mgr.Setup([]msp.MSP{locals[1]}))
+	XXXSetMSPManager("org2channel", mgr)
+
+	assert.Equal(t, locals[1], GetLocalMSPForChannel("org2channel"))
+	sid, err := GetLocalSigningIdentityForChannel("org2channel")
+	assert.NoError(t, err)
+	assert.Equal(t, "Org2MSP", sid.GetMSPIdentifier())
+	_, err = sid.Sign([]byte("msg"))
+	assert.NoError(t, err, "the key of the additional MSP should be in its keystore")
+
+	// channels that none of the additional MSPs are a member of use the local MSP
+	assert.Equal(t, GetLocalMSP(), GetLocalMSPForChannel("foo"))
+	assert.Equal(t, GetLocalMSP(), GetLocalMSPForChannel("nonexistent"))
+	assert.Equal(t, GetLocalMSP(), GetLocalMSPForChannel(""))
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/bccsp/signer"
+	"github.com/hyperledger/fabric/common/attrmgr"
 	m "github.com/hyperledger/fabric/protos/msp"
 	"github.com/pkg/errors"
 )
@@ -80,6 +81,10 @@ type bccspmsp struct {
 	// verification options for MSP members
 	opts *x509.VerifyOptions
 
+	// expiredCertTolerance tells whether the TLS CA certs are validated at
+	// a time they were valid rather than at the current time
+	expiredCertTolerance bool
+
 	// list of certificate revocation lists
 	CRL []*pkix.CertificateList
 
@@ -120,6 +125,10 @@ func newBccspMsp(version MSPVersion) (MSP, error) {
 		theMsp.internalSetupFunc = theMsp.setupV11
 		theMsp.internalValidateIdentityOusFunc = theMsp.validateIdentityOUsV11
 		theMsp.internalSatisfiesPrincipalInternalFunc = theMsp.satisfiesPrincipalInternalV13
+	case MSPv1_4:
+		theMsp.internalSetupFunc = theMsp.setupV11
+		theMsp.internalValidateIdentityOusFunc = theMsp.validateIdentityOUsV11
+		theMsp.internalSatisfiesPrincipalInternalFunc = theMsp.satisfiesPrincipalInternalV14
 	default:
 		return nil, errors.Errorf("Invalid MSP version [%v]", version)
 	}
@@ -561,6 +570,61 @@ func (msp *bccspmsp) satisfiesPrincipalInternalV13(id Identity, principal *m.MSP
 	}
 }
 
+// satisfiesPrincipalInternalV14 takes as arguments the identity and the principal.
+// The function returns an error if one occurred.
+// The function implements the additional behavior expected of an MSP starting from v1.4.
+// For v1.3 functionality, the function calls the satisfiesPrincipalInternalV13.
+func (msp *bccspmsp) satisfiesPrincipalInternalV14(id Identity, principal *m.MSPPrincipal) error {
+	switch principal.PrincipalClassification {
+	case m.MSPPrincipal_ATTRIBUTE:
+		// Principal contains the MSPAttribute
+		mspAttribute := &m.MSPAttribute{}
+		err := proto.Unmarshal(principal.Principal, mspAttribute)
+		if err != nil {
+			return errors.Wrap(err, "could not unmarshal MSPAttribute from principal")
+		}
+
+		// at first, we check whether the MSP
+		// identifier is the same as that of the identity
+		if mspAttribute.MspIdentifier != msp.name {
+			return errors.Errorf("the identity is a member of a different MSP (expected %s, got %s)", mspAttribute.MspIdentifier, id.GetMSPIdentifier())
+		}
+
+		// we then check if the identity is valid with this MSP
+		// and fail if it is not
+		if err := msp.Validate(id); err != nil {
+			return err
+		}
+
+		bccspID, ok := id.(*identity)
+		if !ok {
+			return errors.New("identity type not recognized")
+		}
+		return certHasAttribute(bccspID.cert, mspAttribute.Name, mspAttribute.Value)
+	default:
+		// Use the v1.3 function to check other principal types
+		return msp.satisfiesPrincipalInternalV13(id, principal)
+	}
+}
+
+// certHasAttribute returns nil if the certificate carries the named attribute
+// with the given value in its attribute extension (OID 1.2.3.4.5.6.7.8.1, the
+// format used by Fabric CA), or an error otherwise.
+func certHasAttribute(cert *x509.Certificate, name, value string) error {
+	attrs, err := attrmgr.New().GetAttributesFromCert(cert)
+	if err != nil {
+		return errors.WithMessage(err, "failed reading attributes from certificate")
+	}
+	actual, ok, _ := attrs.Value(name)
+	if !ok {
+		return errors.Errorf("the identity does not have attribute %s", name)
+	}
+	if actual != value {
+		return errors.Errorf("the identity attribute %s does not match (expected %s, got %s)", name, value, actual)
+	}
+	return nil
+}
+
 // getCertificationChain returns the certification chain of the passed identity within this msp
 func (msp *bccspmsp) getCertificationChain(id Identity) ([]*x509.Certificate, error) {
 	mspLogger.Debugf("MSP %s getting certification chain", msp.name)
@@ -355,7 +355,11 @@ func (msp *bccspmsp) setupTLSCAs(conf *m.FabricMSPConfig) error {
 			return errors.Errorf("CA Certificate did not have the Subject Key Identifier extension, (SN: %s)", cert.SerialNumber)
 		}
 
-		if err := msp.validateTLSCAIdentity(cert, opts); err != nil {
+		tlsOpts := opts
+		if msp.expiredCertTolerance {
+			tlsOpts = msp.getTLSValidityOptsForCert(cert, opts)
+		}
+		if err := msp.validateTLSCAIdentity(cert, tlsOpts); err != nil {
 			return errors.WithMessage(err, fmt.Sprintf("CA Certificate is not valid, (SN: %s)", cert.SerialNumber))
 		}
 	}
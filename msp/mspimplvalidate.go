@@ -221,6 +221,14 @@ func (msp *bccspmsp) getValidityOptsForCert(cert *x509.Certificate) x509.VerifyO
 	return tempOpts
 }
 
+// getTLSValidityOptsForCert returns the options to validate the given TLS CA
+// cert with, independently from the current time like getValidityOptsForCert
+func (msp *bccspmsp) getTLSValidityOptsForCert(cert *x509.Certificate, opts *x509.VerifyOptions) *x509.VerifyOptions {
+	tempOpts := *opts
+	tempOpts.CurrentTime = cert.NotBefore.Add(time.Second)
+	return &tempOpts
+}
+
 /*
    This is the definition of the ASN.1 marshalling of AuthorityKeyIdentifier
    from https://www.ietf.org/rfc/rfc5280.txt
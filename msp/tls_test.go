@@ -17,8 +17,13 @@ limitations under the License.
 package msp
 
 import (
+	"encoding/pem"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	m "github.com/hyperledger/fabric/protos/msp"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -46,3 +51,29 @@ func TestTLSCAs(t *testing.T) {
 	assert.Len(t, tlsIntermediateCerts2, 1)
 	assert.Equal(t, tlsIntermediateCerts2[0], tlsIntermediateCerts[0])
 }
+
+func TestExpiredTLSCAs(t *testing.T) {
+	mspDir, err := configtest.GetDevMspDir()
+	assert.NoError(t, err)
+	conf, err := GetVerifyingMspConfig(mspDir, "SampleOrg", ProviderTypeToString(FABRIC))
+	assert.NoError(t, err)
+
+	// add a TLS root CA which expired a day ago
+	_, cert := generateSelfSignedCert(t, time.Now().Add(-25*time.Hour))
+	fabricConf := &m.FabricMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(conf.Config, fabricConf))
+	fabricConf.TlsRootCerts = append(fabricConf.TlsRootCerts, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+	conf.Config, err = proto.Marshal(fabricConf)
+	assert.NoError(t, err)
+
+	thisMSP, err := New(&BCCSPNewOpts{NewBaseOpts: NewBaseOpts{Version: MSPv1_3}})
+	assert.NoError(t, err)
+	err = thisMSP.Setup(conf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "certificate has expired")
+
+	thisMSP, err = New(&BCCSPNewOpts{NewBaseOpts: NewBaseOpts{Version: MSPv1_3}, ExpiredCertTolerance: true})
+	assert.NoError(t, err)
+	assert.NoError(t, thisMSP.Setup(conf))
+	assert.Len(t, thisMSP.GetTLSRootCerts(), len(fabricConf.TlsRootCerts))
+}
@@ -9,6 +9,7 @@ package blockcutter
 import (
 	"github.com/hyperledger/fabric/common/channelconfig"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
 
 	"github.com/hyperledger/fabric/common/flogging"
 )
@@ -36,6 +37,16 @@ type receiver struct {
 	sharedConfigFetcher   OrdererConfigFetcher
 	pendingBatch          []*cb.Envelope
 	pendingBatchSizeBytes uint32
+	// pendingOrder holds the priority of each pending message, in the same order
+	pendingOrder []pendingMessage
+}
+
+// pendingMessage holds the priority of a pending message, along with the
+// number of messages of higher priority received after it which were ordered
+// ahead of it
+type pendingMessage struct {
+	priority  uint32
+	overtakes uint32
 }
 
 // NewReceiverImpl creates a Receiver implementation based on the given configtxorderer manager
@@ -61,6 +72,11 @@ func NewReceiverImpl(sharedConfigFetcher OrdererConfigFetcher) Receiver {
 //   - impossible
 //
 // Note that messageBatches can not be greater than 2.
+//
+// If the channel has the TxPriority capability and a priority reordering
+// limit, the message is enqueued ahead of the pending messages of lower
+// priority, except for those which were already overtaken by as many
+// messages as the limit, so that their delay is bounded.
 func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, pending bool) {
 	ordererConfig, ok := r.sharedConfigFetcher.OrdererConfig()
 	if !ok {
@@ -93,8 +109,14 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 		messageBatches = append(messageBatches, messageBatch)
 	}
 
+	var priority uint32
+	reorderingLimit := batchSize.PriorityReorderingLimit
+	if reorderingLimit > 0 && ordererConfig.Capabilities().TxPriority() {
+		priority = messagePriority(msg)
+	}
+
 	logger.Debugf("Enqueuing message into batch")
-	r.pendingBatch = append(r.pendingBatch, msg)
+	r.enqueue(msg, priority, reorderingLimit)
 	r.pendingBatchSizeBytes += messageSizeBytes
 	pending = true
 
@@ -112,10 +134,44 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 func (r *receiver) Cut() []*cb.Envelope {
 	batch := r.pendingBatch
 	r.pendingBatch = nil
+	r.pendingOrder = nil
 	r.pendingBatchSizeBytes = 0
 	return batch
 }
 
+// enqueue inserts the message in the pending batch after the last message
+// which either has a priority at least as high, or was already overtaken by
+// reorderingLimit messages
+func (r *receiver) enqueue(msg *cb.Envelope, priority uint32, reorderingLimit uint32) {
+	i := len(r.pendingBatch)
+	for i > 0 && r.pendingOrder[i-1].priority < priority && r.pendingOrder[i-1].overtakes < reorderingLimit {
+		i--
+	}
+	if i < len(r.pendingBatch) {
+		logger.Debugf("Ordering message of priority %d ahead of %d pending messages", priority, len(r.pendingBatch)-i)
+	}
+	for j := i; j < len(r.pendingOrder); j++ {
+		r.pendingOrder[j].overtakes++
+	}
+
+	r.pendingBatch = append(r.pendingBatch, nil)
+	copy(r.pendingBatch[i+1:], r.pendingBatch[i:])
+	r.pendingBatch[i] = msg
+	r.pendingOrder = append(r.pendingOrder, pendingMessage{})
+	copy(r.pendingOrder[i+1:], r.pendingOrder[i:])
+	r.pendingOrder[i] = pendingMessage{priority: priority}
+}
+
+// messagePriority returns the priority set in the channel header of the
+// message, the messages which cannot be parsed have the lowest priority
+func messagePriority(msg *cb.Envelope) uint32 {
+	chdr, err := utils.ChannelHeader(msg)
+	if err != nil {
+		return 0
+	}
+	return chdr.Priority
+}
+
 func messageSizeBytes(message *cb.Envelope) uint32 {
 	return uint32(len(message.Payload) + len(message.Signature))
 }
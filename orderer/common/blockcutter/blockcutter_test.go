@@ -10,9 +10,11 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter/mock"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/stretchr/testify/assert"
@@ -142,3 +144,67 @@ func TestPanicOnMissingConfig(t *testing.T) {
 	r := NewReceiverImpl(mockConfigFetcher)
 	assert.Panics(t, func() { r.Ordered(tx) })
 }
+
+func TestPriorityReordering(t *testing.T) {
+	priorityTx := func(txID string, priority uint32) *cb.Envelope {
+		return &cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{TxId: txID, Priority: priority})},
+		})}
+	}
+	txIDs := func(batch []*cb.Envelope) []string {
+		var ids []string
+		for _, env := range batch {
+			chdr, err := utils.ChannelHeader(env)
+			assert.NoError(t, err)
+			ids = append(ids, chdr.TxId)
+		}
+		return ids
+	}
+	order := func(txPriority bool, reorderingLimit uint32) []string {
+		mockConfig := &mock.OrdererConfig{}
+		mockConfig.BatchSizeReturns(&ab.BatchSize{
+			MaxMessageCount:         10,
+			AbsoluteMaxBytes:        10000,
+			PreferredMaxBytes:       10000,
+			PriorityReorderingLimit: reorderingLimit,
+		})
+		mockConfig.CapabilitiesReturns(&mockconfig.OrdererCapabilities{TxPriorityVal: txPriority})
+		mockConfigFetcher := &mock.OrdererConfigFetcher{}
+		mockConfigFetcher.OrdererConfigReturns(mockConfig, true)
+
+		r := NewReceiverImpl(mockConfigFetcher)
+		for _, env := range []*cb.Envelope{
+			priorityTx("a", 0),
+			priorityTx("b", 0),
+			priorityTx("c", 5),
+			priorityTx("d", 5),
+			priorityTx("e", 9),
+			{Payload: []byte("GOOD")},
+		} {
+			batches, pending := r.Ordered(env)
+			assert.Nil(t, batches)
+			assert.True(t, pending)
+		}
+		batch := r.Cut()
+		assert.Equal(t, []byte("GOOD"), batch[len(batch)-1].Payload)
+		assert.Empty(t, r.Cut())
+		return txIDs(batch[:len(batch)-1])
+	}
+
+	t.Run("NoCapability", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "b", "c", "d", "e"}, order(false, 2))
+	})
+
+	t.Run("NoReorderingLimit", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "b", "c", "d", "e"}, order(true, 0))
+	})
+
+	t.Run("BoundedReordering", func(t *testing.T) {
+		// a and b are each overtaken by c and d, hence not by e
+		assert.Equal(t, []string{"c", "d", "a", "b", "e"}, order(true, 2))
+	})
+
+	t.Run("HigherLimit", func(t *testing.T) {
+		assert.Equal(t, []string{"e", "c", "d", "a", "b"}, order(true, 3))
+	})
+}
@@ -28,6 +28,15 @@ type OrdererConfig struct {
 	consensusMetadataReturnsOnCall map[int]struct {
 		result1 []byte
 	}
+	ConsensusStateStub        func() ab.ConsensusType_State
+	consensusStateMutex       sync.RWMutex
+	consensusStateArgsForCall []struct{}
+	consensusStateReturns     struct {
+		result1 ab.ConsensusType_State
+	}
+	consensusStateReturnsOnCall map[int]struct {
+		result1 ab.ConsensusType_State
+	}
 	BatchSizeStub        func() *ab.BatchSize
 	batchSizeMutex       sync.RWMutex
 	batchSizeArgsForCall []struct{}
@@ -166,6 +175,46 @@ func (fake *OrdererConfig) ConsensusMetadataReturnsOnCall(i int, result1 []byte)
 	}{result1}
 }
 
+func (fake *OrdererConfig) ConsensusState() ab.ConsensusType_State {
+	fake.consensusStateMutex.Lock()
+	ret, specificReturn := fake.consensusStateReturnsOnCall[len(fake.consensusStateArgsForCall)]
+	fake.consensusStateArgsForCall = append(fake.consensusStateArgsForCall, struct{}{})
+	fake.recordInvocation("ConsensusState", []interface{}{})
+	fake.consensusStateMutex.Unlock()
+	if fake.ConsensusStateStub != nil {
+		return fake.ConsensusStateStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.consensusStateReturns.result1
+}
+
+func (fake *OrdererConfig) ConsensusStateCallCount() int {
+	fake.consensusStateMutex.RLock()
+	defer fake.consensusStateMutex.RUnlock()
+	return len(fake.consensusStateArgsForCall)
+}
+
+func (fake *OrdererConfig) ConsensusStateReturns(result1 ab.ConsensusType_State) {
+	fake.ConsensusStateStub = nil
+	fake.consensusStateReturns = struct {
+		result1 ab.ConsensusType_State
+	}{result1}
+}
+
+func (fake *OrdererConfig) ConsensusStateReturnsOnCall(i int, result1 ab.ConsensusType_State) {
+	fake.ConsensusStateStub = nil
+	if fake.consensusStateReturnsOnCall == nil {
+		fake.consensusStateReturnsOnCall = make(map[int]struct {
+			result1 ab.ConsensusType_State
+		})
+	}
+	fake.consensusStateReturnsOnCall[i] = struct {
+		result1 ab.ConsensusType_State
+	}{result1}
+}
+
 func (fake *OrdererConfig) BatchSize() *ab.BatchSize {
 	fake.batchSizeMutex.Lock()
 	ret, specificReturn := fake.batchSizeReturnsOnCall[len(fake.batchSizeArgsForCall)]
@@ -413,6 +462,8 @@ func (fake *OrdererConfig) Invocations() map[string][][]interface{} {
 	defer fake.consensusTypeMutex.RUnlock()
 	fake.consensusMetadataMutex.RLock()
 	defer fake.consensusMetadataMutex.RUnlock()
+	fake.consensusStateMutex.RLock()
+	defer fake.consensusStateMutex.RUnlock()
 	fake.batchSizeMutex.RLock()
 	defer fake.batchSizeMutex.RUnlock()
 	fake.batchTimeoutMutex.RLock()
@@ -8,8 +8,11 @@ package broadcast
 
 import (
 	"io"
+	"sync/atomic"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -25,6 +28,10 @@ var logger = flogging.MustGetLogger(pkgLogID)
 type Handler interface {
 	// Handle starts a service thread for a given gRPC connection and services the broadcast connection
 	Handle(srv ab.AtomicBroadcast_BroadcastServer) error
+
+	// Drain rejects the messages received from now on with SERVICE_UNAVAILABLE and closes
+	// their broadcast connection, so that clients resubmit them to another orderer
+	Drain()
 }
 
 // ChannelSupportRegistrar provides a way for the Handler to look up the Support for a channel
@@ -60,16 +67,33 @@ type Consenter interface {
 }
 
 type handlerImpl struct {
-	sm ChannelSupportRegistrar
+	sm       ChannelSupportRegistrar
+	quotas   *quotaLimiter
+	draining int32
 }
 
 // NewHandlerImpl constructs a new implementation of the Handler interface
 func NewHandlerImpl(sm ChannelSupportRegistrar) Handler {
+	return NewHandlerWithQuotas(sm, Quotas{}, metrics.SubScope("orderer").SubScope("broadcast"))
+}
+
+// NewHandlerWithQuotas constructs a Handler rejecting the transactions of the
+// channels exceeding their quotas with SERVICE_UNAVAILABLE, and reporting the
+// transactions it accepts and throttles per channel to the given scope. The
+// config updates are not subject to the quotas.
+func NewHandlerWithQuotas(sm ChannelSupportRegistrar, quotas Quotas, scope metrics.Scope) Handler {
 	return &handlerImpl{
-		sm: sm,
+		sm:     sm,
+		quotas: newQuotaLimiter(quotas, scope),
 	}
 }
 
+// Drain rejects the messages received from now on with SERVICE_UNAVAILABLE and closes
+// their broadcast connection, so that clients resubmit them to another orderer
+func (bh *handlerImpl) Drain() {
+	atomic.StoreInt32(&bh.draining, 1)
+}
+
 // Handle starts a service thread for a given gRPC connection and services the broadcast connection
 func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 	addr := util.ExtractRemoteAddress(srv.Context())
@@ -85,59 +109,92 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 			return err
 		}
 
-		chdr, isConfig, processor, err := bh.sm.BroadcastChannelSupport(msg)
+		if atomic.LoadInt32(&bh.draining) == 1 {
+			logger.Infof("Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: orderer is shutting down", addr)
+			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: "orderer is shutting down"})
+		}
+
+		resp := bh.processMessage(msg, addr)
+		err = srv.Send(resp)
+		if resp.Status != cb.Status_SUCCESS {
+			return err
+		}
+
 		if err != nil {
-			channelID := "<malformed_header>"
-			if chdr != nil {
-				channelID = chdr.ChannelId
-			}
-			logger.Warningf("[channel: %s] Could not get message processor for serving %s: %s", channelID, addr, err)
-			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: err.Error()})
+			logger.Warningf("Error sending to %s: %s", addr, err)
+			return err
 		}
+	}
+}
 
-		if err = processor.WaitReady(); err != nil {
-			logger.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
-			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()})
+// processMessage validates and enqueues a single message, and returns the
+// response to send back to the client
+func (bh *handlerImpl) processMessage(msg *cb.Envelope, addr string) (resp *ab.BroadcastResponse) {
+	chdr, isConfig, processor, err := bh.sm.BroadcastChannelSupport(msg)
+	if err != nil {
+		channelID := "<malformed_header>"
+		if chdr != nil {
+			channelID = chdr.ChannelId
 		}
+		logger.Warningf("[channel: %s] Could not get message processor for serving %s: %s", channelID, addr, err)
+		return &ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: err.Error()}
+	}
 
-		if !isConfig {
-			logger.Debugf("[channel: %s] Broadcast is processing normal message from %s with txid '%s' of type %s", chdr.ChannelId, addr, chdr.TxId, cb.HeaderType_name[chdr.Type])
-
-			configSeq, err := processor.ProcessNormalMsg(msg)
-			if err != nil {
-				logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s because of error: %s", chdr.ChannelId, addr, err)
-				return srv.Send(&ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()})
-			}
-
-			err = processor.Order(msg, configSeq)
-			if err != nil {
-				logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s with SERVICE_UNAVAILABLE: rejected by Order: %s", chdr.ChannelId, addr, err)
-				return srv.Send(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()})
-			}
-		} else { // isConfig
-			logger.Debugf("[channel: %s] Broadcast is processing config update message from %s", chdr.ChannelId, addr)
-
-			config, configSeq, err := processor.ProcessConfigUpdateMsg(msg)
-			if err != nil {
-				logger.Warningf("[channel: %s] Rejecting broadcast of config message from %s because of error: %s", chdr.ChannelId, addr, err)
-				return srv.Send(&ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()})
-			}
-
-			err = processor.Configure(config, configSeq)
-			if err != nil {
-				logger.Warningf("[channel: %s] Rejecting broadcast of config message from %s with SERVICE_UNAVAILABLE: rejected by Configure: %s", chdr.ChannelId, addr, err)
-				return srv.Send(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()})
-			}
+	span := tracing.StartSpan("orderer.Broadcast", tracing.TxSpanContext(chdr))
+	span.SetAttribute("channel", chdr.ChannelId)
+	span.SetAttribute("txid", chdr.TxId)
+	defer func() {
+		if resp.Status != cb.Status_SUCCESS {
+			span.SetError(resp.Info)
 		}
+		span.End()
+	}()
 
-		logger.Debugf("[channel: %s] Broadcast has successfully enqueued message of type %s from %s", chdr.ChannelId, cb.HeaderType_name[chdr.Type], addr)
+	if err = processor.WaitReady(); err != nil {
+		logger.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
+		return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
+	}
 
-		err = srv.Send(&ab.BroadcastResponse{Status: cb.Status_SUCCESS})
+	if !isConfig {
+		logger.Debugf("[channel: %s] Broadcast is processing normal message from %s with txid '%s' of type %s", chdr.ChannelId, addr, chdr.TxId, cb.HeaderType_name[chdr.Type])
+
+		configSeq, err := processor.ProcessNormalMsg(msg)
 		if err != nil {
-			logger.Warningf("[channel: %s] Error sending to %s: %s", chdr.ChannelId, addr, err)
-			return err
+			logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s because of error: %s", chdr.ChannelId, addr, err)
+			return &ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()}
+		}
+
+		// the quota is only charged once the message is known to be signed
+		// by a writer of an existing channel, so that other clients cannot
+		// exhaust the quota of the channel
+		if err := bh.quotas.admit(chdr.ChannelId, len(msg.GetPayload())+len(msg.GetSignature())); err != nil {
+			logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s with SERVICE_UNAVAILABLE: %s", chdr.ChannelId, addr, err)
+			return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
+		}
+
+		err = processor.Order(msg, configSeq)
+		if err != nil {
+			logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s with SERVICE_UNAVAILABLE: rejected by Order: %s", chdr.ChannelId, addr, err)
+			return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
+		}
+	} else { // isConfig
+		logger.Debugf("[channel: %s] Broadcast is processing config update message from %s", chdr.ChannelId, addr)
+
+		config, configSeq, err := processor.ProcessConfigUpdateMsg(msg)
+		if err != nil {
+			logger.Warningf("[channel: %s] Rejecting broadcast of config message from %s because of error: %s", chdr.ChannelId, addr, err)
+			return &ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()}
+		}
+
+		err = processor.Configure(config, configSeq)
+		if err != nil {
+			logger.Warningf("[channel: %s] Rejecting broadcast of config message from %s with SERVICE_UNAVAILABLE: rejected by Configure: %s", chdr.ChannelId, addr, err)
+			return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
 		}
 	}
+
+	logger.Debugf("[channel: %s] Broadcast has successfully enqueued message of type %s from %s", chdr.ChannelId, cb.HeaderType_name[chdr.Type], addr)
+	return &ab.BroadcastResponse{Status: cb.Status_SUCCESS}
 }
 
 // ClassifyError converts an error type into a status code.
@@ -147,6 +204,8 @@ func ClassifyError(err error) cb.Status {
 		return cb.Status_NOT_FOUND
 	case msgprocessor.ErrPermissionDenied:
 		return cb.Status_FORBIDDEN
+	case msgprocessor.ErrMaintenanceMode:
+		return cb.Status_SERVICE_UNAVAILABLE
 	default:
 		return cb.Status_BAD_REQUEST
 	}
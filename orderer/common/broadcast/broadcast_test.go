@@ -185,6 +185,34 @@ func TestEnqueueFailure(t *testing.T) {
 	}
 }
 
+func TestDrain(t *testing.T) {
+	mm := getMockSupportManager()
+	bh := NewHandlerImpl(mm)
+	m := newMockB()
+	defer close(m.recvChan)
+	done := make(chan struct{})
+	go func() {
+		bh.Handle(m)
+		close(done)
+	}()
+
+	m.recvChan <- nil
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status, "Should have successfully queued the message")
+
+	bh.Drain()
+	m.recvChan <- nil
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, reply.Status, "Should have rejected the message")
+	assert.Equal(t, "orderer is shutting down", reply.Info)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Should have terminated the stream")
+	}
+}
+
 func TestClassifyError(t *testing.T) {
 	t.Run("NotFound", func(t *testing.T) {
 		assert.Equal(t, cb.Status_NOT_FOUND, ClassifyError(msgprocessor.ErrChannelDoesNotExist))
@@ -192,6 +220,9 @@ func TestClassifyError(t *testing.T) {
 	t.Run("Forbidden", func(t *testing.T) {
 		assert.Equal(t, cb.Status_FORBIDDEN, ClassifyError(msgprocessor.ErrPermissionDenied))
 	})
+	t.Run("ServiceUnavailable", func(t *testing.T) {
+		assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, ClassifyError(errors.WithMessage(msgprocessor.ErrMaintenanceMode, "only config transactions are accepted")))
+	})
 	t.Run("WrappedErr", func(t *testing.T) {
 		assert.Equal(t, cb.Status_NOT_FOUND, ClassifyError(errors.Wrap(msgprocessor.ErrChannelDoesNotExist, "A wrapped error")))
 	})
@@ -0,0 +1,167 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/pkg/errors"
+)
+
+const (
+	acceptedTransactions  = "accepted_transactions"
+	acceptedBytes         = "accepted_bytes"
+	throttledTransactions = "throttled_transactions"
+
+	// sweepInterval is the period of the removal of the buckets which
+	// are full again
+	sweepInterval = time.Minute
+)
+
+// Quota limits the rate at which the orderer accepts the transactions of a
+// channel. A limit of 0 is unlimited.
+type Quota struct {
+	TxPerSecond    uint32
+	BytesPerSecond uint64
+}
+
+// Quotas holds the quotas of the channels served by the orderer
+type Quotas struct {
+	// Default is the quota of the channels without a quota of their own
+	Default Quota
+	// Channels maps the IDs of channels to their quotas
+	Channels map[string]Quota
+}
+
+func (q Quotas) quota(channelID string) Quota {
+	if quota, ok := q.Channels[channelID]; ok {
+		return quota
+	}
+	return q.Default
+}
+
+// quotaLimiter enforces the quotas of the channels with a pair of token
+// buckets per channel, which fill up at the rates of the quota and hold up to
+// a second worth of transactions and bytes. A transaction larger than the
+// byte rate is accepted once the bucket is full, and pushes the bucket into
+// debt. The buckets which are full again are dropped, as they are no
+// different from new ones.
+type quotaLimiter struct {
+	quotas Quotas
+	scope  metrics.Scope
+	now    func() time.Time
+
+	mutex     sync.Mutex
+	buckets   map[string]*channelBuckets
+	lastSweep time.Time
+}
+
+type channelBuckets struct {
+	txs   *tokenBucket
+	bytes *tokenBucket
+}
+
+func newQuotaLimiter(quotas Quotas, scope metrics.Scope) *quotaLimiter {
+	return &quotaLimiter{
+		quotas:  quotas,
+		scope:   scope,
+		now:     time.Now,
+		buckets: make(map[string]*channelBuckets),
+	}
+}
+
+// admit consumes the quota of the channel for a transaction of the given
+// size, or returns an error if the quota is exhausted
+func (ql *quotaLimiter) admit(channelID string, size int) error {
+	scope := ql.scope.Tagged(map[string]string{"channel": channelID})
+	quota := ql.quotas.quota(channelID)
+
+	ql.mutex.Lock()
+	now := ql.now()
+	ql.sweep(now)
+	b, ok := ql.buckets[channelID]
+	if !ok {
+		b = &channelBuckets{
+			txs:   newTokenBucket(float64(quota.TxPerSecond), now),
+			bytes: newTokenBucket(float64(quota.BytesPerSecond), now),
+		}
+		ql.buckets[channelID] = b
+	}
+	b.txs.refill(now)
+	b.bytes.refill(now)
+	var err error
+	switch {
+	case !b.txs.allows(1):
+		err = errors.Errorf("channel %s exceeded its quota of %d transactions per second", channelID, quota.TxPerSecond)
+	case !b.bytes.allows(float64(size)):
+		err = errors.Errorf("channel %s exceeded its quota of %d bytes per second", channelID, quota.BytesPerSecond)
+	default:
+		b.txs.take(1)
+		b.bytes.take(float64(size))
+	}
+	ql.mutex.Unlock()
+
+	if err != nil {
+		scope.Counter(throttledTransactions).Inc(1)
+		return err
+	}
+	scope.Counter(acceptedTransactions).Inc(1)
+	scope.Counter(acceptedBytes).Inc(int64(size))
+	return nil
+}
+
+// sweep drops the buckets which are full again, at most once per
+// sweepInterval, so that the buckets of the channels which are no longer used
+// are not kept forever
+func (ql *quotaLimiter) sweep(now time.Time) {
+	if now.Sub(ql.lastSweep) < sweepInterval {
+		return
+	}
+	ql.lastSweep = now
+	for channelID, b := range ql.buckets {
+		if b.txs.full(now) && b.bytes.full(now) {
+			delete(ql.buckets, channelID)
+		}
+	}
+}
+
+// tokenBucket is unlimited if its rate is 0
+type tokenBucket struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, now time.Time) *tokenBucket {
+	return &tokenBucket{rate: rate, tokens: rate, last: now}
+}
+
+func (tb *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(tb.last); elapsed > 0 {
+		tb.tokens = math.Min(tb.rate, tb.tokens+elapsed.Seconds()*tb.rate)
+		tb.last = now
+	}
+}
+
+func (tb *tokenBucket) allows(n float64) bool {
+	return tb.rate == 0 || tb.tokens >= math.Min(n, tb.rate)
+}
+
+func (tb *tokenBucket) take(n float64) {
+	if tb.rate > 0 {
+		tb.tokens -= n
+	}
+}
+
+// full returns whether the bucket holds as many tokens as a new one
+func (tb *tokenBucket) full(now time.Time) bool {
+	tb.refill(now)
+	return tb.rate == 0 || tb.tokens >= tb.rate
+}
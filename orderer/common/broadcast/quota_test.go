@@ -0,0 +1,196 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeScope records the values of the counters by channel tag
+type fakeScope struct {
+	mutex    sync.Mutex
+	channel  string
+	counters map[string]int64
+}
+
+func newFakeScope() *fakeScope {
+	return &fakeScope{counters: make(map[string]int64)}
+}
+
+func (s *fakeScope) Counter(name string) metrics.Counter {
+	return &fakeCounter{scope: s, key: s.channel + "/" + name}
+}
+
+func (s *fakeScope) Gauge(name string) metrics.Gauge {
+	panic("unexpected gauge")
+}
+
+func (s *fakeScope) Histogram(name string, buckets []float64) metrics.Histogram {
+	panic("unexpected histogram")
+}
+
+func (s *fakeScope) Tagged(tags map[string]string) metrics.Scope {
+	return &fakeScope{channel: tags["channel"], counters: s.counters}
+}
+
+func (s *fakeScope) SubScope(name string) metrics.Scope {
+	return s
+}
+
+func (s *fakeScope) Start() error { return nil }
+func (s *fakeScope) Close() error { return nil }
+
+func (s *fakeScope) counter(channel, name string) int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.counters[channel+"/"+name]
+}
+
+type fakeCounter struct {
+	scope *fakeScope
+	key   string
+}
+
+func (c *fakeCounter) Inc(delta int64) {
+	c.scope.mutex.Lock()
+	defer c.scope.mutex.Unlock()
+	c.scope.counters[c.key] += delta
+}
+
+func TestQuotaLimiter(t *testing.T) {
+	scope := newFakeScope()
+	ql := newQuotaLimiter(Quotas{
+		Default: Quota{TxPerSecond: 2},
+		Channels: map[string]Quota{
+			"bulk":      {BytesPerSecond: 100},
+			"unlimited": {},
+		},
+	}, scope)
+	now := time.Unix(1000, 0)
+	ql.now = func() time.Time { return now }
+
+	// the transaction rate is enforced per channel
+	assert.NoError(t, ql.admit("foo", 10))
+	assert.NoError(t, ql.admit("foo", 10))
+	assert.EqualError(t, ql.admit("foo", 10), "channel foo exceeded its quota of 2 transactions per second")
+	assert.NoError(t, ql.admit("bar", 10))
+	now = now.Add(500 * time.Millisecond)
+	assert.NoError(t, ql.admit("foo", 10))
+	assert.Error(t, ql.admit("foo", 10))
+
+	// a transaction larger than the byte rate is accepted once the bucket is
+	// full, and the next ones wait for the debt to be paid off
+	assert.NoError(t, ql.admit("bulk", 250))
+	assert.EqualError(t, ql.admit("bulk", 10), "channel bulk exceeded its quota of 100 bytes per second")
+	now = now.Add(time.Second)
+	assert.Error(t, ql.admit("bulk", 10))
+	now = now.Add(time.Second)
+	assert.NoError(t, ql.admit("bulk", 10))
+
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, ql.admit("unlimited", 1000))
+	}
+
+	assert.Equal(t, int64(3), scope.counter("foo", acceptedTransactions))
+	assert.Equal(t, int64(30), scope.counter("foo", acceptedBytes))
+	assert.Equal(t, int64(2), scope.counter("foo", throttledTransactions))
+	assert.Equal(t, int64(2), scope.counter("bulk", acceptedTransactions))
+	assert.Equal(t, int64(2), scope.counter("bulk", throttledTransactions))
+	assert.Equal(t, int64(100), scope.counter("unlimited", acceptedTransactions))
+}
+
+func TestQuotaLimiterSweep(t *testing.T) {
+	ql := newQuotaLimiter(Quotas{Default: Quota{TxPerSecond: 2, BytesPerSecond: 100}}, newFakeScope())
+	now := time.Unix(1000, 0)
+	ql.now = func() time.Time { return now }
+
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, ql.admit(fmt.Sprintf("channel%d", i), 10))
+	}
+	assert.NoError(t, ql.admit("debtor", 8000))
+	assert.Len(t, ql.buckets, 11)
+
+	// the buckets which are full again are no different from new ones, but
+	// the buckets still in debt are kept
+	now = now.Add(sweepInterval)
+	assert.NoError(t, ql.admit("foo", 10))
+	assert.Len(t, ql.buckets, 2)
+	assert.Contains(t, ql.buckets, "foo")
+	assert.EqualError(t, ql.admit("debtor", 10), "channel debtor exceeded its quota of 100 bytes per second")
+}
+
+func TestQuotaNotChargedForRejectedMessages(t *testing.T) {
+	mm := getMockSupportManager()
+	mm.ChdrVal = &cb.ChannelHeader{ChannelId: "foo"}
+	support := &mockSupport{ProcessErr: msgprocessor.ErrPermissionDenied}
+	mm.MsgProcessorVal = support
+	bh := NewHandlerWithQuotas(mm, Quotas{Default: Quota{TxPerSecond: 1}}, newFakeScope()).(*handlerImpl)
+
+	for i := 0; i < 3; i++ {
+		resp := bh.processMessage(&cb.Envelope{}, "client")
+		assert.Equal(t, cb.Status_FORBIDDEN, resp.Status)
+	}
+	assert.Empty(t, bh.quotas.buckets, "rejected messages are not charged to the quota")
+
+	support.ProcessErr = nil
+	assert.Equal(t, cb.Status_SUCCESS, bh.processMessage(&cb.Envelope{}, "client").Status)
+	assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, bh.processMessage(&cb.Envelope{}, "client").Status)
+}
+
+func TestQuotaExceeded(t *testing.T) {
+	mm := getMockSupportManager()
+	mm.ChdrVal = &cb.ChannelHeader{ChannelId: "foo"}
+	scope := newFakeScope()
+	bh := NewHandlerWithQuotas(mm, Quotas{Default: Quota{TxPerSecond: 1}}, scope)
+	m := newMockB()
+	defer close(m.recvChan)
+	done := make(chan struct{})
+	go func() {
+		bh.Handle(m)
+		close(done)
+	}()
+
+	m.recvChan <- nil
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status)
+
+	m.recvChan <- nil
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, reply.Status)
+	assert.Equal(t, "channel foo exceeded its quota of 1 transactions per second", reply.Info)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Should have terminated the stream")
+	}
+	assert.Equal(t, int64(1), scope.counter("foo", throttledTransactions))
+}
+
+func TestQuotaConfigUpdateExempt(t *testing.T) {
+	mm := getMockSupportManager()
+	mm.MsgProcessorIsConfig = true
+	mm.ChdrVal = &cb.ChannelHeader{ChannelId: "foo"}
+	bh := NewHandlerWithQuotas(mm, Quotas{Default: Quota{TxPerSecond: 1}}, newFakeScope())
+	m := newMockB()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	for i := 0; i < 3; i++ {
+		m.recvChan <- nil
+		reply := <-m.sendChan
+		assert.Equal(t, cb.Status_SUCCESS, reply.Status)
+	}
+}
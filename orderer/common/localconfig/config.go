@@ -33,27 +33,35 @@ type TopLevel struct {
 	FileLedger FileLedger
 	RAMLedger  RAMLedger
 	Kafka      Kafka
+	Consensus  Consensus
 	Debug      Debug
+	Tracing    Tracing
+	Metrics    Metrics
 }
 
 // General contains config which should be common among all orderer types.
 type General struct {
-	LedgerType     string
-	ListenAddress  string
-	ListenPort     uint16
-	TLS            TLS
-	Keepalive      Keepalive
-	GenesisMethod  string
-	GenesisProfile string
-	SystemChannel  string
-	GenesisFile    string
-	Profile        Profile
-	LogLevel       string
-	LogFormat      string
-	LocalMSPDir    string
-	LocalMSPID     string
-	BCCSP          *bccsp.FactoryOpts
-	Authentication Authentication
+	LedgerType           string
+	ListenAddress        string
+	ListenPort           uint16
+	TLS                  TLS
+	Keepalive            Keepalive
+	GenesisMethod        string
+	MaxConcurrentStreams uint32
+	GenesisProfile       string
+	SystemChannel        string
+	GenesisFile          string
+	Profile              Profile
+	LogLevel             string
+	LogFormat            string
+	LocalMSPDir          string
+	LocalMSPID           string
+	BCCSP                *bccsp.FactoryOpts
+	Authentication       Authentication
+	Shutdown             Shutdown
+	Compression          Compression
+	Quotas               Quotas
+	LazyLoading          LazyLoading
 }
 
 // Keepalive contains configuration for gRPC servers.
@@ -80,6 +88,40 @@ type SASLPlain struct {
 	Password string
 }
 
+// Shutdown contains configuration parameters related to the graceful shutdown
+// of the orderer.
+type Shutdown struct {
+	DrainTimeout time.Duration
+}
+
+// Compression contains configuration for the gzip compression of the calls
+// to the orderer.
+type Compression struct {
+	Enabled bool
+}
+
+// Quotas contains configuration for the rates at which the orderer accepts
+// the transactions of the channels it serves. A limit of 0 is unlimited.
+type Quotas struct {
+	TxPerSecond    uint32
+	BytesPerSecond uint64
+	Channels       map[string]Quota
+}
+
+// Quota contains the rates at which the orderer accepts the transactions of
+// a channel.
+type Quota struct {
+	TxPerSecond    uint32
+	BytesPerSecond uint64
+}
+
+// LazyLoading contains configuration for deferring the loading of the
+// channels until they are used, and evicting them once idle.
+type LazyLoading struct {
+	Enabled     bool
+	IdleTimeout time.Duration
+}
+
 // Authentication contains configuration parameters related to authenticating
 // client messages.
 type Authentication struct {
@@ -103,6 +145,17 @@ type RAMLedger struct {
 	HistorySize uint
 }
 
+// Consensus contains configuration for the consensus implementations
+// available to the orderer in addition to the builtin ones.
+type Consensus struct {
+	Plugins map[string]ConsensusPlugin
+}
+
+// ConsensusPlugin points to the Go plugin implementing a consensus type.
+type ConsensusPlugin struct {
+	Library string
+}
+
 // Kafka contains configuration for the Kafka-based orderer.
 type Kafka struct {
 	Retry     Retry
@@ -167,6 +220,40 @@ type Debug struct {
 	DeliverTraceDir   string
 }
 
+// Tracing contains configuration for the export of the spans recorded for
+// the transactions broadcast to the orderer.
+type Tracing struct {
+	Enabled       bool
+	ServiceName   string
+	Endpoint      string
+	FlushInterval time.Duration
+	MaxBatchSize  int
+	Timeout       time.Duration
+}
+
+// Metrics contains configuration for the report of the metrics of the
+// orderer.
+type Metrics struct {
+	Enabled        bool
+	Reporter       string
+	Interval       time.Duration
+	StatsdReporter StatsdReporter
+	PromReporter   PromReporter
+}
+
+// StatsdReporter contains configuration for pushing the metrics to a statsd
+// server.
+type StatsdReporter struct {
+	Address       string
+	FlushInterval time.Duration
+	FlushBytes    int
+}
+
+// PromReporter contains configuration for serving the metrics to Prometheus.
+type PromReporter struct {
+	ListenAddress string
+}
+
 // Defaults carries the default orderer configuration values.
 var Defaults = TopLevel{
 	General: General{
@@ -189,6 +276,13 @@ var Defaults = TopLevel{
 		Authentication: Authentication{
 			TimeWindow: time.Duration(15 * time.Minute),
 		},
+		Shutdown: Shutdown{
+			DrainTimeout: 30 * time.Second,
+		},
+		LazyLoading: LazyLoading{
+			Enabled:     false,
+			IdleTimeout: 30 * time.Minute,
+		},
 	},
 	RAMLedger: RAMLedger{
 		HistorySize: 10000,
@@ -233,6 +327,27 @@ var Defaults = TopLevel{
 		BroadcastTraceDir: "",
 		DeliverTraceDir:   "",
 	},
+	Tracing: Tracing{
+		Enabled:       false,
+		ServiceName:   "orderer",
+		Endpoint:      "localhost:4318",
+		FlushInterval: 5 * time.Second,
+		MaxBatchSize:  512,
+		Timeout:       10 * time.Second,
+	},
+	Metrics: Metrics{
+		Enabled:  false,
+		Reporter: "statsd",
+		Interval: time.Second,
+		StatsdReporter: StatsdReporter{
+			Address:       "0.0.0.0:8125",
+			FlushInterval: 2 * time.Second,
+			FlushBytes:    1432,
+		},
+		PromReporter: PromReporter{
+			ListenAddress: "0.0.0.0:8080",
+		},
+	},
 }
 
 // Load parses the orderer YAML file and environment, producing
@@ -324,6 +439,12 @@ func (c *TopLevel) completeInitialization(configDir string) {
 		case c.General.Authentication.TimeWindow == 0:
 			logger.Infof("General.Authentication.TimeWindow unset, setting to %s", Defaults.General.Authentication.TimeWindow)
 			c.General.Authentication.TimeWindow = Defaults.General.Authentication.TimeWindow
+		case c.General.Shutdown.DrainTimeout == 0:
+			logger.Infof("General.Shutdown.DrainTimeout unset, setting to %s", Defaults.General.Shutdown.DrainTimeout)
+			c.General.Shutdown.DrainTimeout = Defaults.General.Shutdown.DrainTimeout
+		case c.General.LazyLoading.Enabled && c.General.LazyLoading.IdleTimeout == 0:
+			logger.Infof("General.LazyLoading.IdleTimeout unset, setting to %s", Defaults.General.LazyLoading.IdleTimeout)
+			c.General.LazyLoading.IdleTimeout = Defaults.General.LazyLoading.IdleTimeout
 
 		case c.FileLedger.Prefix == "":
 			logger.Infof("FileLedger.Prefix unset, setting to %s", Defaults.FileLedger.Prefix)
@@ -374,6 +495,35 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			logger.Infof("Kafka.Version unset, setting to %v", Defaults.Kafka.Version)
 			c.Kafka.Version = Defaults.Kafka.Version
 
+		case c.Tracing.Enabled && c.Tracing.ServiceName == "":
+			logger.Infof("Tracing.ServiceName unset, setting to %s", Defaults.Tracing.ServiceName)
+			c.Tracing.ServiceName = Defaults.Tracing.ServiceName
+		case c.Tracing.Enabled && c.Tracing.Endpoint == "":
+			logger.Infof("Tracing.Endpoint unset, setting to %s", Defaults.Tracing.Endpoint)
+			c.Tracing.Endpoint = Defaults.Tracing.Endpoint
+		case c.Tracing.Enabled && c.Tracing.FlushInterval == 0:
+			logger.Infof("Tracing.FlushInterval unset, setting to %v", Defaults.Tracing.FlushInterval)
+			c.Tracing.FlushInterval = Defaults.Tracing.FlushInterval
+		case c.Tracing.Enabled && c.Tracing.MaxBatchSize == 0:
+			logger.Infof("Tracing.MaxBatchSize unset, setting to %d", Defaults.Tracing.MaxBatchSize)
+			c.Tracing.MaxBatchSize = Defaults.Tracing.MaxBatchSize
+		case c.Tracing.Enabled && c.Tracing.Timeout == 0:
+			logger.Infof("Tracing.Timeout unset, setting to %v", Defaults.Tracing.Timeout)
+			c.Tracing.Timeout = Defaults.Tracing.Timeout
+
+		case c.Metrics.Enabled && c.Metrics.Reporter == "":
+			logger.Infof("Metrics.Reporter unset, setting to %s", Defaults.Metrics.Reporter)
+			c.Metrics.Reporter = Defaults.Metrics.Reporter
+		case c.Metrics.Enabled && c.Metrics.Interval == 0:
+			logger.Infof("Metrics.Interval unset, setting to %v", Defaults.Metrics.Interval)
+			c.Metrics.Interval = Defaults.Metrics.Interval
+		case c.Metrics.Enabled && c.Metrics.StatsdReporter.FlushInterval == 0:
+			logger.Infof("Metrics.StatsdReporter.FlushInterval unset, setting to %v", Defaults.Metrics.StatsdReporter.FlushInterval)
+			c.Metrics.StatsdReporter.FlushInterval = Defaults.Metrics.StatsdReporter.FlushInterval
+		case c.Metrics.Enabled && c.Metrics.StatsdReporter.FlushBytes == 0:
+			logger.Infof("Metrics.StatsdReporter.FlushBytes unset, setting to %d", Defaults.Metrics.StatsdReporter.FlushBytes)
+			c.Metrics.StatsdReporter.FlushBytes = Defaults.Metrics.StatsdReporter.FlushBytes
+
 		default:
 			return
 		}
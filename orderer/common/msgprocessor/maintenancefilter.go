@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"github.com/hyperledger/fabric/common/channelconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// ErrMaintenanceMode is returned by the maintenance filter for the transactions
+// of a channel in maintenance which are not config transactions
+var ErrMaintenanceMode = errors.New("channel is in maintenance mode")
+
+// ApplicationConfigSupport defines the subset of the channel resources required
+// to create the maintenance filter
+type ApplicationConfigSupport interface {
+	// ApplicationConfig returns the channelconfig.Application for the channel
+	// and whether the Application config exists
+	ApplicationConfig() (channelconfig.Application, bool)
+}
+
+// NewMaintenanceFilter returns a rule that rejects all messages but for the
+// config messages while the channel is in maintenance
+func NewMaintenanceFilter(support ApplicationConfigSupport) Rule {
+	return &maintenanceFilter{support: support}
+}
+
+type maintenanceFilter struct {
+	support ApplicationConfigSupport
+}
+
+// Apply rejects the message if the channel is in maintenance and the message
+// is not a config message
+func (mf *maintenanceFilter) Apply(message *cb.Envelope) error {
+	ac, ok := mf.support.ApplicationConfig()
+	if !ok || ac.ChannelState() != pb.ChannelState_MAINTENANCE {
+		return nil
+	}
+
+	chdr, err := utils.ChannelHeader(message)
+	if err != nil {
+		return errors.WithMessage(err, "could not extract the channel header")
+	}
+	switch cb.HeaderType(chdr.Type) {
+	case cb.HeaderType_CONFIG, cb.HeaderType_CONFIG_UPDATE, cb.HeaderType_ORDERER_TRANSACTION:
+		return nil
+	default:
+		return errors.WithMessage(ErrMaintenanceMode, "only config transactions are accepted")
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/mocks/config"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTypedEnvelope(headerType cb.HeaderType) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: utils.MakePayloadHeader(utils.MakeChannelHeader(headerType, 0, "mychannel", 0), utils.MakeSignatureHeader(nil, nil)),
+		}),
+	}
+}
+
+func TestMaintenanceFilter(t *testing.T) {
+	mf := NewMaintenanceFilter(&config.Resources{})
+	assert.NoError(t, mf.Apply(makeTypedEnvelope(cb.HeaderType_ENDORSER_TRANSACTION)), "channels without application config are never in maintenance")
+
+	app := &config.MockApplication{}
+	mf = NewMaintenanceFilter(&config.Resources{ApplicationConfigVal: app})
+	assert.NoError(t, mf.Apply(makeTypedEnvelope(cb.HeaderType_ENDORSER_TRANSACTION)))
+	assert.NoError(t, mf.Apply(&cb.Envelope{Payload: []byte("garbage")}), "messages are not inspected out of maintenance")
+
+	app.ChannelStateRv = pb.ChannelState_MAINTENANCE
+	for _, headerType := range []cb.HeaderType{cb.HeaderType_CONFIG, cb.HeaderType_CONFIG_UPDATE, cb.HeaderType_ORDERER_TRANSACTION} {
+		assert.NoError(t, mf.Apply(makeTypedEnvelope(headerType)), "%s messages are accepted in maintenance", headerType)
+	}
+	for _, headerType := range []cb.HeaderType{cb.HeaderType_ENDORSER_TRANSACTION, cb.HeaderType_MESSAGE} {
+		err := mf.Apply(makeTypedEnvelope(headerType))
+		assert.EqualError(t, err, "only config transactions are accepted: channel is in maintenance mode")
+		assert.Equal(t, ErrMaintenanceMode, errors.Cause(err))
+	}
+
+	err := mf.Apply(&cb.Envelope{Payload: []byte("garbage")})
+	assert.Contains(t, err.Error(), "could not extract the channel header")
+}
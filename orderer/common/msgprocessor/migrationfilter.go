@@ -0,0 +1,114 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// OrdererConfigSupport defines the subset of the channel resources required
+// to create the migration filter
+type OrdererConfigSupport interface {
+	// OrdererConfig returns the channelconfig.Orderer for the channel
+	// and whether the Orderer config exists
+	OrdererConfig() (channelconfig.Orderer, bool)
+}
+
+// ConsensusMetadataValidator validates the consensus type a channel migrates to
+type ConsensusMetadataValidator interface {
+	// ValidateConsensusMetadata returns an error if the consensus type is not
+	// supported by this orderer, or if the metadata is not valid for it
+	ValidateConsensusMetadata(consensusType string, metadata []byte) error
+}
+
+// NewMigrationFilter returns a rule that rejects all messages but for the config
+// messages while the consensus type of the channel is in maintenance, and the
+// config messages changing the consensus type to one which the validator rejects
+func NewMigrationFilter(support OrdererConfigSupport, validator ConsensusMetadataValidator) Rule {
+	return &migrationFilter{support: support, validator: validator}
+}
+
+type migrationFilter struct {
+	support   OrdererConfigSupport
+	validator ConsensusMetadataValidator
+}
+
+// Apply rejects the message if the consensus type of the channel is in maintenance
+// and the message is not a config message, or if the message is a config message
+// changing the consensus type to one which cannot be migrated to
+func (mf *migrationFilter) Apply(message *cb.Envelope) error {
+	oc, ok := mf.support.OrdererConfig()
+	if !ok {
+		return nil
+	}
+
+	chdr, err := utils.ChannelHeader(message)
+	if err != nil {
+		return errors.WithMessage(err, "could not extract the channel header")
+	}
+	switch cb.HeaderType(chdr.Type) {
+	case cb.HeaderType_CONFIG_UPDATE:
+		return nil
+	case cb.HeaderType_CONFIG:
+		return mf.inspectConfig(message, oc)
+	default:
+		// The channel creations are rejected as well, as the new channels
+		// would be created with the consensus type in maintenance
+		if oc.ConsensusState() == ab.ConsensusType_STATE_MAINTENANCE {
+			return errors.WithMessage(ErrMaintenanceMode, "the consensus type is in maintenance, only config transactions are accepted")
+		}
+		return nil
+	}
+}
+
+func (mf *migrationFilter) inspectConfig(message *cb.Envelope, oc channelconfig.Orderer) error {
+	configEnvelope := &cb.ConfigEnvelope{}
+	if _, err := utils.UnmarshalEnvelopeOfType(message, cb.HeaderType_CONFIG, configEnvelope); err != nil {
+		return errors.WithMessage(err, "could not extract the config envelope")
+	}
+
+	consensusType, ok := nextConsensusType(configEnvelope)
+	if !ok || consensusType.Type == oc.ConsensusType() {
+		return nil
+	}
+
+	if mf.validator == nil {
+		return errors.Errorf("cannot migrate from consensus type %s to %s: migration is not supported", oc.ConsensusType(), consensusType.Type)
+	}
+	if err := mf.validator.ValidateConsensusMetadata(consensusType.Type, consensusType.Metadata); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("cannot migrate from consensus type %s to %s", oc.ConsensusType(), consensusType.Type))
+	}
+	return nil
+}
+
+// nextConsensusType returns the consensus type set by the config, and whether it is set
+func nextConsensusType(configEnvelope *cb.ConfigEnvelope) (*ab.ConsensusType, bool) {
+	if configEnvelope.Config == nil || configEnvelope.Config.ChannelGroup == nil {
+		return nil, false
+	}
+	ordererGroup, ok := configEnvelope.Config.ChannelGroup.Groups[channelconfig.OrdererGroupKey]
+	if !ok {
+		return nil, false
+	}
+	value, ok := ordererGroup.Values[channelconfig.ConsensusTypeKey]
+	if !ok {
+		return nil, false
+	}
+	consensusType := &ab.ConsensusType{}
+	if err := proto.Unmarshal(value.Value, consensusType); err != nil {
+		// The config itself is rejected when its bundle is created
+		return nil, false
+	}
+	return consensusType, true
+}
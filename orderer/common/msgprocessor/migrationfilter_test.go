@@ -0,0 +1,113 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/mocks/config"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockMetadataValidator struct {
+	consensusType string
+	metadata      []byte
+	err           error
+}
+
+func (mmv *mockMetadataValidator) ValidateConsensusMetadata(consensusType string, metadata []byte) error {
+	mmv.consensusType = consensusType
+	mmv.metadata = metadata
+	return mmv.err
+}
+
+func makeConsensusTypeConfig(consensusType *ab.ConsensusType) *cb.Envelope {
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, "mychannel", nil, &cb.ConfigEnvelope{
+		Config: &cb.Config{
+			ChannelGroup: &cb.ConfigGroup{
+				Groups: map[string]*cb.ConfigGroup{
+					channelconfig.OrdererGroupKey: {
+						Values: map[string]*cb.ConfigValue{
+							channelconfig.ConsensusTypeKey: {Value: utils.MarshalOrPanic(consensusType)},
+						},
+					},
+				},
+			},
+		},
+	}, 0, 0)
+	if err != nil {
+		panic(err)
+	}
+	return env
+}
+
+func TestMigrationFilterMaintenance(t *testing.T) {
+	mf := NewMigrationFilter(&config.Resources{}, nil)
+	assert.NoError(t, mf.Apply(makeTypedEnvelope(cb.HeaderType_ENDORSER_TRANSACTION)), "channels without orderer config are never in maintenance")
+
+	orderer := &config.Orderer{ConsensusTypeVal: "kafka"}
+	mf = NewMigrationFilter(&config.Resources{OrdererConfigVal: orderer}, nil)
+	for _, headerType := range []cb.HeaderType{cb.HeaderType_ENDORSER_TRANSACTION, cb.HeaderType_ORDERER_TRANSACTION} {
+		assert.NoError(t, mf.Apply(makeTypedEnvelope(headerType)))
+	}
+
+	orderer.ConsensusStateVal = ab.ConsensusType_STATE_MAINTENANCE
+	assert.NoError(t, mf.Apply(makeTypedEnvelope(cb.HeaderType_CONFIG_UPDATE)))
+	assert.NoError(t, mf.Apply(makeConsensusTypeConfig(&ab.ConsensusType{Type: "kafka"})))
+	for _, headerType := range []cb.HeaderType{cb.HeaderType_ENDORSER_TRANSACTION, cb.HeaderType_ORDERER_TRANSACTION, cb.HeaderType_MESSAGE} {
+		err := mf.Apply(makeTypedEnvelope(headerType))
+		assert.EqualError(t, err, "the consensus type is in maintenance, only config transactions are accepted: channel is in maintenance mode")
+		assert.Equal(t, ErrMaintenanceMode, errors.Cause(err))
+	}
+
+	err := mf.Apply(&cb.Envelope{Payload: []byte("garbage")})
+	assert.Contains(t, err.Error(), "could not extract the channel header")
+}
+
+func TestMigrationFilterConsensusTypeChange(t *testing.T) {
+	orderer := &config.Orderer{ConsensusTypeVal: "kafka", ConsensusStateVal: ab.ConsensusType_STATE_MAINTENANCE}
+	resources := &config.Resources{OrdererConfigVal: orderer}
+	migration := makeConsensusTypeConfig(&ab.ConsensusType{Type: "etcdraft", Metadata: []byte("metadata"), State: ab.ConsensusType_STATE_MAINTENANCE})
+
+	t.Run("NotSupported", func(t *testing.T) {
+		err := NewMigrationFilter(resources, nil).Apply(migration)
+		assert.EqualError(t, err, "cannot migrate from consensus type kafka to etcdraft: migration is not supported")
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		validator := &mockMetadataValidator{err: errors.New("bad metadata")}
+		err := NewMigrationFilter(resources, validator).Apply(migration)
+		assert.EqualError(t, err, "cannot migrate from consensus type kafka to etcdraft: bad metadata")
+	})
+
+	t.Run("Accepted", func(t *testing.T) {
+		validator := &mockMetadataValidator{}
+		assert.NoError(t, NewMigrationFilter(resources, validator).Apply(migration))
+		assert.Equal(t, "etcdraft", validator.consensusType)
+		assert.Equal(t, []byte("metadata"), validator.metadata)
+	})
+
+	t.Run("SameType", func(t *testing.T) {
+		validator := &mockMetadataValidator{err: errors.New("bad metadata")}
+		assert.NoError(t, NewMigrationFilter(resources, validator).Apply(makeConsensusTypeConfig(&ab.ConsensusType{Type: "kafka"})))
+		assert.Empty(t, validator.consensusType, "the consensus type is only validated when it changes")
+	})
+
+	t.Run("BadConfigEnvelope", func(t *testing.T) {
+		env := makeTypedEnvelope(cb.HeaderType_CONFIG)
+		payload := utils.UnmarshalPayloadOrPanic(env.Payload)
+		payload.Data = []byte("garbage")
+		env.Payload = utils.MarshalOrPanic(payload)
+		err := NewMigrationFilter(resources, nil).Apply(env)
+		assert.Contains(t, err.Error(), "could not extract the config envelope")
+	})
+}
@@ -44,8 +44,9 @@ func NewStandardChannel(support StandardChannelSupport, filters *RuleSet) *Stand
 	}
 }
 
-// CreateStandardChannelFilters creates the set of filters for a normal (non-system) chain
-func CreateStandardChannelFilters(filterSupport channelconfig.Resources) *RuleSet {
+// CreateStandardChannelFilters creates the set of filters for a normal (non-system) chain,
+// the validator checks the consensus types the chain may migrate to
+func CreateStandardChannelFilters(filterSupport channelconfig.Resources, validator ConsensusMetadataValidator) *RuleSet {
 	ordererConfig, ok := filterSupport.OrdererConfig()
 	if !ok {
 		logger.Panicf("Missing orderer config")
@@ -55,6 +56,8 @@ func CreateStandardChannelFilters(filterSupport channelconfig.Resources) *RuleSe
 		NewExpirationRejectRule(filterSupport),
 		NewSizeFilter(ordererConfig),
 		NewSigFilter(policies.ChannelWriters, filterSupport),
+		NewMaintenanceFilter(filterSupport),
+		NewMigrationFilter(filterSupport, validator),
 	})
 }
 
@@ -40,8 +40,9 @@ func NewSystemChannel(support StandardChannelSupport, templator ChannelConfigTem
 	}
 }
 
-// CreateSystemChannelFilters creates the set of filters for the ordering system chain.
-func CreateSystemChannelFilters(chainCreator ChainCreator, ledgerResources channelconfig.Resources) *RuleSet {
+// CreateSystemChannelFilters creates the set of filters for the ordering system chain,
+// the validator checks the consensus types the chain may migrate to.
+func CreateSystemChannelFilters(chainCreator ChainCreator, ledgerResources channelconfig.Resources, validator ConsensusMetadataValidator) *RuleSet {
 	ordererConfig, ok := ledgerResources.OrdererConfig()
 	if !ok {
 		logger.Panicf("Cannot create system channel filters without orderer config")
@@ -52,6 +53,7 @@ func CreateSystemChannelFilters(chainCreator ChainCreator, ledgerResources chann
 		NewSizeFilter(ordererConfig),
 		NewSigFilter(policies.ChannelWriters, ledgerResources),
 		NewSystemChannelFilter(ledgerResources, chainCreator),
+		NewMigrationFilter(ledgerResources, validator),
 	})
 }
 
@@ -8,6 +8,7 @@ package multichannel
 
 import (
 	"sync"
+	"time"
 
 	newchannelconfig "github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/configtx"
@@ -26,6 +27,8 @@ type blockWriterSupport interface {
 	configtx.Validator
 	Update(*newchannelconfig.Bundle)
 	CreateBundle(channelID string, config *cb.Config) (*newchannelconfig.Bundle, error)
+	ChannelConfig() newchannelconfig.Channel
+	OrdererConfig() (newchannelconfig.Orderer, bool)
 }
 
 // BlockWriter efficiently writes the blockchain to disk.
@@ -39,14 +42,23 @@ type BlockWriter struct {
 	lastConfigSeq      uint64
 	lastBlock          *cb.Block
 	committingBlock    sync.Mutex
+	// hashingAlgorithm is the hashing algorithm of the channel, which config
+	// updates cannot change
+	hashingAlgorithm func([]byte) []byte
+	// migrated is set once the block changing the consensus type of the
+	// channel is written, after which the blocks of the halting chain are
+	// dropped so that the new consenter starts from the same block on all
+	// the orderers
+	migrated bool
 }
 
 func newBlockWriter(lastBlock *cb.Block, r *Registrar, support blockWriterSupport) *BlockWriter {
 	bw := &BlockWriter{
-		support:       support,
-		lastConfigSeq: support.Sequence(),
-		lastBlock:     lastBlock,
-		registrar:     r,
+		support:          support,
+		lastConfigSeq:    support.Sequence(),
+		lastBlock:        lastBlock,
+		registrar:        r,
+		hashingAlgorithm: support.ChannelConfig().HashingAlgorithm(),
 	}
 
 	// If this is the genesis block, the lastconfig field may be empty, and, the last config block is necessarily block 0
@@ -65,7 +77,11 @@ func newBlockWriter(lastBlock *cb.Block, r *Registrar, support blockWriterSuppor
 
 // CreateNextBlock creates a new block with the next block number, and the given contents.
 func (bw *BlockWriter) CreateNextBlock(messages []*cb.Envelope) *cb.Block {
-	previousBlockHash := bw.lastBlock.Header.Hash()
+	hash := bw.hashingAlgorithm
+	if hash == nil {
+		hash = util.ComputeSHA256
+	}
+	previousBlockHash := bw.lastBlock.Header.HashWith(hash)
 
 	data := &cb.BlockData{
 		Data: make([][]byte, len(messages)),
@@ -80,8 +96,11 @@ func (bw *BlockWriter) CreateNextBlock(messages []*cb.Envelope) *cb.Block {
 	}
 
 	block := cb.NewBlock(bw.lastBlock.Header.Number+1, previousBlockHash)
-	block.Header.DataHash = data.Hash()
+	block.Header.DataHash = data.HashWith(hash)
 	block.Data = data
+	if err := utils.SetBlockTime(block, time.Now()); err != nil {
+		logger.Panicf("Could not set block time: %s", err)
+	}
 
 	return block
 }
@@ -90,6 +109,10 @@ func (bw *BlockWriter) CreateNextBlock(messages []*cb.Envelope) *cb.Block {
 // This call will block until the new config has taken effect, then will return
 // while the block is written asynchronously to disk.
 func (bw *BlockWriter) WriteConfigBlock(block *cb.Block, encodedMetadataValue []byte) {
+	if bw.dropMigratedBlock(block) {
+		return
+	}
+
 	ctx, err := utils.ExtractEnvelope(block, 0)
 	if err != nil {
 		logger.Panicf("Told to write a config block, but could not get configtx: %s", err)
@@ -132,6 +155,18 @@ func (bw *BlockWriter) WriteConfigBlock(block *cb.Block, encodedMetadataValue []
 			logger.Panicf("Told to write a config block with a new config, but could not convert it to a bundle: %s", err)
 		}
 
+		if consensusTypeChanged(bw.support, bundle) {
+			noc, _ := bundle.OrdererConfig()
+			logger.Infof("[channel: %s] Block %d changes the consensus type to %s, restarting the chain once it is written", chdr.ChannelId, block.Header.Number, noc.ConsensusType())
+			bw.support.Update(bundle)
+			// The metadata of the current consenter is meaningless to the new one,
+			// which starts the chain as a new one
+			bw.WriteBlock(block, nil)
+			bw.migrated = true
+			go bw.registrar.switchConsensusType(chdr.ChannelId)
+			return
+		}
+
 		bw.support.Update(bundle)
 	default:
 		logger.Panicf("Told to write a config block with unknown header type: %v", chdr.Type)
@@ -140,6 +175,26 @@ func (bw *BlockWriter) WriteConfigBlock(block *cb.Block, encodedMetadataValue []
 	bw.WriteBlock(block, encodedMetadataValue)
 }
 
+// consensusTypeChanged returns whether the new config changes the consensus type of the channel
+func consensusTypeChanged(support blockWriterSupport, bundle *newchannelconfig.Bundle) bool {
+	oc, ok := support.OrdererConfig()
+	if !ok {
+		return false
+	}
+	noc, ok := bundle.OrdererConfig()
+	return ok && oc.ConsensusType() != noc.ConsensusType()
+}
+
+// dropMigratedBlock returns whether the block is dropped, because it follows the
+// block changing the consensus type of the channel
+func (bw *BlockWriter) dropMigratedBlock(block *cb.Block) bool {
+	if !bw.migrated {
+		return false
+	}
+	logger.Warningf("[channel: %s] Dropping block %d, the chain is restarting with a new consensus type", bw.support.ChainID(), block.Header.Number)
+	return true
+}
+
 // WriteBlock should be invoked for blocks which contain normal transactions.
 // It sets the target block as the pending next block, and returns before it is committed.
 // Before returning, it acquires the committing lock, and spawns a go routine which will
@@ -147,6 +202,10 @@ func (bw *BlockWriter) WriteConfigBlock(block *cb.Block, encodedMetadataValue []
 // then release the lock.  This allows the calling thread to begin assembling the next block
 // before the commit phase is complete.
 func (bw *BlockWriter) WriteBlock(block *cb.Block, encodedMetadataValue []byte) {
+	if bw.dropMigratedBlock(block) {
+		return
+	}
+
 	bw.committingBlock.Lock()
 	bw.lastBlock = block
 
@@ -156,6 +215,12 @@ func (bw *BlockWriter) WriteBlock(block *cb.Block, encodedMetadataValue []byte)
 	}()
 }
 
+// waitCommitted blocks until the block being committed, if any, is written to the ledger
+func (bw *BlockWriter) waitCommitted() {
+	bw.committingBlock.Lock()
+	bw.committingBlock.Unlock()
+}
+
 // commitBlock should only ever be invoked with the bw.committingBlock held
 // this ensures that the encoded config sequence numbers stay in sync
 func (bw *BlockWriter) commitBlock(encodedMetadataValue []byte) {
@@ -178,9 +243,9 @@ func (bw *BlockWriter) addBlockSignature(block *cb.Block) {
 		SignatureHeader: utils.MarshalOrPanic(utils.NewSignatureHeaderOrPanic(bw.support)),
 	}
 
-	// Note, this value is intentionally nil, as this metadata is only about the signature, there is no additional metadata
-	// information required beyond the fact that the metadata item is signed.
-	blockSignatureValue := []byte(nil)
+	// The value is the time at which the block was created, if any, which is kept as is
+	// so that all the orderers writing the block sign the same time.
+	blockSignatureValue := utils.GetMetadataFromBlockOrPanic(block, cb.BlockMetadataIndex_SIGNATURES).Value
 
 	blockSignature.Signature = utils.SignOrPanic(bw.support, util.ConcatenateBytes(blockSignatureValue, blockSignature.SignatureHeader, block.Header.Bytes()))
 
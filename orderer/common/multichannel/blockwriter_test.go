@@ -18,12 +18,15 @@ package multichannel
 
 import (
 	"testing"
+	"time"
 
 	newchannelconfig "github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	mockconfigtx "github.com/hyperledger/fabric/common/mocks/configtx"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
@@ -37,15 +40,24 @@ type mockBlockWriterSupport struct {
 
 func (mbws mockBlockWriterSupport) Update(bundle *newchannelconfig.Bundle) {}
 
+func (mbws mockBlockWriterSupport) ChannelConfig() newchannelconfig.Channel {
+	return &mockconfig.Channel{HashingAlgorithmVal: util.ComputeSHA256}
+}
+
 func (mbws mockBlockWriterSupport) CreateBundle(channelID string, config *cb.Config) (*newchannelconfig.Bundle, error) {
 	return nil, nil
 }
 
+func (mbws mockBlockWriterSupport) OrdererConfig() (newchannelconfig.Orderer, bool) {
+	return nil, false
+}
+
 func TestCreateBlock(t *testing.T) {
 	seedBlock := cb.NewBlock(7, []byte("lasthash"))
 	seedBlock.Data.Data = [][]byte{[]byte("somebytes")}
 
 	bw := &BlockWriter{lastBlock: seedBlock}
+	before := time.Now()
 	block := bw.CreateNextBlock([]*cb.Envelope{
 		{Payload: []byte("some other bytes")},
 	})
@@ -53,6 +65,26 @@ func TestCreateBlock(t *testing.T) {
 	assert.Equal(t, seedBlock.Header.Number+1, block.Header.Number)
 	assert.Equal(t, block.Data.Hash(), block.Header.DataHash)
 	assert.Equal(t, seedBlock.Header.Hash(), block.Header.PreviousHash)
+
+	blockTime, ok, err := utils.GetBlockTime(block)
+	assert.NoError(t, err)
+	assert.True(t, ok, "Block should record the time it was created at")
+	assert.False(t, blockTime.Before(before.Truncate(time.Microsecond)))
+	assert.False(t, blockTime.After(time.Now()))
+}
+
+func TestCreateBlockWithHashingAlgorithm(t *testing.T) {
+	seedBlock := cb.NewBlock(7, []byte("lasthash"))
+	seedBlock.Data.Data = [][]byte{[]byte("somebytes")}
+
+	bw := &BlockWriter{lastBlock: seedBlock, hashingAlgorithm: util.ComputeSHA3256}
+	block := bw.CreateNextBlock([]*cb.Envelope{
+		{Payload: []byte("some other bytes")},
+	})
+
+	assert.Equal(t, block.Data.HashWith(util.ComputeSHA3256), block.Header.DataHash)
+	assert.Equal(t, seedBlock.Header.HashWith(util.ComputeSHA3256), block.Header.PreviousHash)
+	assert.NotEqual(t, seedBlock.Header.Hash(), block.Header.PreviousHash)
 }
 
 func TestBlockSignature(t *testing.T) {
@@ -70,6 +102,30 @@ func TestBlockSignature(t *testing.T) {
 	assert.NotNil(t, md.Signatures, "Should have signature")
 }
 
+func TestBlockSignatureWithBlockTime(t *testing.T) {
+	bw := &BlockWriter{
+		support: &mockBlockWriterSupport{
+			LocalSigner: mockCrypto(),
+		},
+	}
+
+	blockTime := time.Date(2018, time.October, 1, 12, 0, 0, 0, time.UTC)
+	block := cb.NewBlock(7, []byte("foo"))
+	assert.NoError(t, utils.SetBlockTime(block, blockTime))
+	bw.addBlockSignature(block)
+
+	md := utils.GetMetadataFromBlockOrPanic(block, cb.BlockMetadataIndex_SIGNATURES)
+	assert.NotEmpty(t, md.Value, "Value should hold the block time")
+	assert.Len(t, md.Signatures, 1, "Should have signature")
+	assert.Equal(t, util.ConcatenateBytes(md.Value, md.Signatures[0].SignatureHeader, block.Header.Bytes()), md.Signatures[0].Signature,
+		"Signature should cover the block time")
+
+	recorded, ok, err := utils.GetBlockTime(block)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, blockTime.Equal(recorded))
+}
+
 func TestBlockLastConfig(t *testing.T) {
 	lastConfigSeq := uint64(6)
 	newConfigSeq := lastConfigSeq + 1
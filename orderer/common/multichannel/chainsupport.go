@@ -7,12 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package multichannel
 
 import (
+	"sync"
+	"sync/atomic"
+	"time"
+
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
 
 	"github.com/pkg/errors"
@@ -20,6 +25,15 @@ import (
 
 // ChainSupport holds the resources for a particular channel.
 type ChainSupport struct {
+	// lastUsed is the time in nanoseconds the chain was last looked up, or
+	// one of the iterators of its reader closed
+	lastUsed int64
+	// iterators is the number of iterators open on the reader of the chain
+	iterators int32
+	// idleHeight is the height of the ledger when the chain was last checked
+	// for idleness
+	idleHeight uint64
+
 	*ledgerResources
 	msgprocessor.Processor
 	*BlockWriter
@@ -52,7 +66,7 @@ func newChainSupport(
 	}
 
 	// Set up the msgprocessor
-	cs.Processor = msgprocessor.NewStandardChannel(cs, msgprocessor.CreateStandardChannelFilters(cs))
+	cs.Processor = msgprocessor.NewStandardChannel(cs, msgprocessor.CreateStandardChannelFilters(cs, registrar))
 
 	// Set up the block writer
 	cs.BlockWriter = newBlockWriter(lastBlock, registrar, cs)
@@ -74,8 +88,10 @@ func newChainSupport(
 	return cs
 }
 
+// Reader returns a reader of the ledger of the chain, which keeps the chain
+// from being evicted while one of its iterators is open.
 func (cs *ChainSupport) Reader() blockledger.Reader {
-	return cs
+	return &usageTrackingReader{Reader: cs, cs: cs}
 }
 
 // Signer returns the crypto.Localsigner for this channel.
@@ -87,6 +103,49 @@ func (cs *ChainSupport) start() {
 	cs.Chain.Start()
 }
 
+func (cs *ChainSupport) touch(now time.Time) {
+	atomic.StoreInt64(&cs.lastUsed, now.UnixNano())
+}
+
+// idle returns whether the chain was not used and did not commit any block
+// for longer than the timeout. It is invoked with the registrar lock held.
+func (cs *ChainSupport) idle(now time.Time, timeout time.Duration) bool {
+	if height := cs.Height(); height != cs.idleHeight {
+		cs.idleHeight = height
+		cs.touch(now)
+		return false
+	}
+	if atomic.LoadInt32(&cs.iterators) > 0 {
+		return false
+	}
+	return now.Sub(time.Unix(0, atomic.LoadInt64(&cs.lastUsed))) > timeout
+}
+
+type usageTrackingReader struct {
+	blockledger.Reader
+	cs *ChainSupport
+}
+
+func (r *usageTrackingReader) Iterator(startType *ab.SeekPosition) (blockledger.Iterator, uint64) {
+	it, num := r.Reader.Iterator(startType)
+	atomic.AddInt32(&r.cs.iterators, 1)
+	return &usageTrackingIterator{Iterator: it, cs: r.cs}, num
+}
+
+type usageTrackingIterator struct {
+	blockledger.Iterator
+	cs   *ChainSupport
+	once sync.Once
+}
+
+func (it *usageTrackingIterator) Close() {
+	it.once.Do(func() {
+		it.cs.touch(time.Now())
+		atomic.AddInt32(&it.cs.iterators, -1)
+	})
+	it.Iterator.Close()
+}
+
 // BlockCutter returns the blockcutter.Receiver instance for this channel.
 func (cs *ChainSupport) BlockCutter() blockcutter.Receiver {
 	return cs.cutter
@@ -12,12 +12,15 @@ package multichannel
 import (
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -92,7 +95,9 @@ type ledgerResources struct {
 
 // Registrar serves as a point of access and control for the individual channel resources.
 type Registrar struct {
-	lock   sync.RWMutex
+	lock sync.RWMutex
+	// chains maps the IDs of all the channels to their chain support, which
+	// is nil for the channels not loaded yet or evicted
 	chains map[string]*ChainSupport
 
 	consenters      map[string]consensus.Consenter
@@ -102,6 +107,8 @@ type Registrar struct {
 	systemChannel   *ChainSupport
 	templator       msgprocessor.ChannelConfigTemplator
 	callbacks       []func(bundle *channelconfig.Bundle)
+	idleTimeout     time.Duration
+	stopEviction    chan struct{}
 }
 
 func getConfigTx(reader blockledger.Reader) *cb.Envelope {
@@ -121,12 +128,25 @@ func getConfigTx(reader blockledger.Reader) *cb.Envelope {
 // NewRegistrar produces an instance of a *Registrar.
 func NewRegistrar(ledgerFactory blockledger.Factory, consenters map[string]consensus.Consenter,
 	signer crypto.LocalSigner, callbacks ...func(bundle *channelconfig.Bundle)) *Registrar {
+	return NewLazyRegistrar(ledgerFactory, consenters, signer, 0, callbacks...)
+}
+
+// NewLazyRegistrar produces an instance of a *Registrar which defers the
+// loading of the channels ordered by an evictable consenter until they are
+// used, and evicts their chains from memory once they are idle for longer than
+// idleTimeout. Their ledgers remain on disk. The resources of the deferred
+// channels are not built at startup, unless callbacks are registered. The
+// system channel and the channels of the other consenters are always loaded.
+// An idleTimeout of 0 loads all the channels at startup and never evicts them.
+func NewLazyRegistrar(ledgerFactory blockledger.Factory, consenters map[string]consensus.Consenter,
+	signer crypto.LocalSigner, idleTimeout time.Duration, callbacks ...func(bundle *channelconfig.Bundle)) *Registrar {
 	r := &Registrar{
 		chains:        make(map[string]*ChainSupport),
 		ledgerFactory: ledgerFactory,
 		consenters:    consenters,
 		signer:        signer,
 		callbacks:     callbacks,
+		idleTimeout:   idleTimeout,
 	}
 
 	existingChains := ledgerFactory.ChainIDs()
@@ -139,6 +159,19 @@ func NewRegistrar(ledgerFactory blockledger.Factory, consenters map[string]conse
 		if configTx == nil {
 			logger.Panic("Programming error, configTx should never be nil here")
 		}
+		if r.deferrable(configTx) {
+			// The config is only built if callbacks are registered, so that
+			// they are invoked with the config of the channel
+			if len(r.callbacks) > 0 {
+				bundle := r.newBundle(configTx)
+				for _, callback := range r.callbacks {
+					callback(bundle)
+				}
+			}
+			logger.Debugf("Deferring the loading of chain %s until it is used", chainID)
+			r.chains[chainID] = nil
+			continue
+		}
 		ledgerResources := r.newLedgerResources(configTx)
 		chainID := ledgerResources.ConfigtxValidator().ChainID()
 
@@ -146,13 +179,7 @@ func NewRegistrar(ledgerFactory blockledger.Factory, consenters map[string]conse
 			if r.systemChannelID != "" {
 				logger.Panicf("There appear to be two system chains %s and %s", r.systemChannelID, chainID)
 			}
-			chain := newChainSupport(
-				r,
-				ledgerResources,
-				consenters,
-				signer)
-			r.templator = msgprocessor.NewDefaultTemplator(chain)
-			chain.Processor = msgprocessor.NewSystemChannel(chain, r.templator, msgprocessor.CreateSystemChannelFilters(r, chain))
+			chain := r.newSystemChainSupport(ledgerResources)
 
 			// Retrieve genesis block to log its hash. See FAB-5450 for the purpose
 			iter, pos := rl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{Oldest: &ab.SeekOldest{}}})
@@ -188,9 +215,64 @@ func NewRegistrar(ledgerFactory blockledger.Factory, consenters map[string]conse
 		logger.Panicf("No system chain found.  If bootstrapping, does your system channel contain a consortiums group definition?")
 	}
 
+	if r.idleTimeout > 0 {
+		r.stopEviction = make(chan struct{})
+		go r.evictIdleChainsPeriodically()
+	}
+
 	return r
 }
 
+// newSystemChainSupport creates the chain support of the system channel, whose
+// message processor creates the new channels
+func (r *Registrar) newSystemChainSupport(ledgerResources *ledgerResources) *ChainSupport {
+	chain := newChainSupport(r, ledgerResources, r.consenters, r.signer)
+	r.templator = msgprocessor.NewDefaultTemplator(chain)
+	chain.Processor = msgprocessor.NewSystemChannel(chain, r.templator, msgprocessor.CreateSystemChannelFilters(r, chain, r))
+	return chain
+}
+
+// ValidateConsensusMetadata returns an error if the consensus type is not
+// supported by this orderer, or if its consenter rejects the metadata
+func (r *Registrar) ValidateConsensusMetadata(consensusType string, metadata []byte) error {
+	consenter, ok := r.consenters[consensusType]
+	if !ok {
+		return errors.Errorf("consensus type %s is not supported by this orderer", consensusType)
+	}
+	if validator, ok := consenter.(consensus.MetadataValidator); ok {
+		return validator.ValidateConsensusMetadata(metadata)
+	}
+	return nil
+}
+
+// switchConsensusType halts the chain of a channel whose consensus type changed,
+// and restarts it with the consenter of the new consensus type once the block
+// changing it is written to the ledger
+func (r *Registrar) switchConsensusType(chainID string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	cs := r.chains[chainID]
+	if cs == nil {
+		// The chain was evicted, it is loaded with the new consenter when used
+		return
+	}
+	cs.Halt()
+	cs.waitCommitted()
+
+	ledgerResources := r.newLedgerResources(getConfigTx(cs.ledgerResources))
+	logger.Infof("Restarting chain %s with consensus type %s", chainID, ledgerResources.SharedConfig().ConsensusType())
+	if chainID == r.systemChannelID {
+		cs = r.newSystemChainSupport(ledgerResources)
+		r.systemChannel = cs
+	} else {
+		cs = newChainSupport(r, ledgerResources, r.consenters, r.signer)
+	}
+	cs.touch(time.Now())
+	r.chains[chainID] = cs
+	cs.start()
+}
+
 // SystemChannelID returns the ChannelID for the system channel.
 func (r *Registrar) SystemChannelID() string {
 	return r.systemChannelID
@@ -207,7 +289,9 @@ func (r *Registrar) BroadcastChannelSupport(msg *cb.Envelope) (*cb.ChannelHeader
 
 	cs, ok := r.GetChain(chdr.ChannelId)
 	if !ok {
+		r.lock.RLock()
 		cs = r.systemChannel
+		r.lock.RUnlock()
 	}
 
 	isConfig := false
@@ -222,16 +306,139 @@ func (r *Registrar) BroadcastChannelSupport(msg *cb.Envelope) (*cb.ChannelHeader
 	return chdr, isConfig, cs, nil
 }
 
-// GetChain retrieves the chain support for a chain (and whether it exists)
+// Close halts the chains, waits for the blocks they are committing to be
+// written to their ledgers, and closes the ledgers.
+func (r *Registrar) Close() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.stopEviction != nil {
+		close(r.stopEviction)
+	}
+	for chainID, cs := range r.chains {
+		if cs == nil {
+			continue
+		}
+		logger.Infof("Halting channel %s", chainID)
+		cs.Halt()
+		cs.waitCommitted()
+	}
+	r.ledgerFactory.Close()
+}
+
+// GetChain retrieves the chain support for a chain (and whether it exists),
+// loading the chain if it is not loaded
 func (r *Registrar) GetChain(chainID string) (*ChainSupport, bool) {
 	r.lock.RLock()
-	defer r.lock.RUnlock()
-
 	cs, ok := r.chains[chainID]
-	return cs, ok
+	r.lock.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+	if cs == nil {
+		cs = r.loadChain(chainID)
+	}
+	cs.touch(time.Now())
+	return cs, true
 }
 
-func (r *Registrar) newLedgerResources(configTx *cb.Envelope) *ledgerResources {
+// loadChain creates and starts the chain of a channel which was not loaded at
+// startup or was evicted since
+func (r *Registrar) loadChain(chainID string) *ChainSupport {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if cs := r.chains[chainID]; cs != nil {
+		return cs
+	}
+
+	rl, err := r.ledgerFactory.GetOrCreate(chainID)
+	if err != nil {
+		logger.Panicf("Ledger factory reported chainID %s but could not retrieve it: %s", chainID, err)
+	}
+	cs := newChainSupport(r, r.newLedgerResources(getConfigTx(rl)), r.consenters, r.signer)
+	cs.touch(time.Now())
+
+	logger.Infof("Loading chain %s", chainID)
+
+	r.chains[chainID] = cs
+	cs.start()
+	return cs
+}
+
+// evictable returns whether the consenter of a consensus type supports evicting its chains
+func (r *Registrar) evictable(consensusType string) bool {
+	consenter, ok := r.consenters[consensusType].(consensus.EvictableConsenter)
+	return ok && consenter.Evictable()
+}
+
+// deferrable returns whether the loading of a channel may be deferred until it
+// is used. It only reads the consensus type from the config of the channel, so
+// that the resources of the deferred channels are not built at startup.
+func (r *Registrar) deferrable(configTx *cb.Envelope) bool {
+	if r.idleTimeout == 0 {
+		return false
+	}
+	_, config := unmarshalConfigTx(configTx)
+	if config == nil || config.ChannelGroup == nil {
+		return false
+	}
+	if _, ok := config.ChannelGroup.Groups[channelconfig.ConsortiumsGroupKey]; ok {
+		return false
+	}
+	ordererGroup, ok := config.ChannelGroup.Groups[channelconfig.OrdererGroupKey]
+	if !ok {
+		return false
+	}
+	value, ok := ordererGroup.Values[channelconfig.ConsensusTypeKey]
+	if !ok {
+		return false
+	}
+	consensusType := &ab.ConsensusType{}
+	if err := proto.Unmarshal(value.Value, consensusType); err != nil {
+		return false
+	}
+	return r.evictable(consensusType.Type)
+}
+
+func (r *Registrar) evictIdleChainsPeriodically() {
+	ticker := time.NewTicker(r.idleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			r.evictIdleChains(now)
+		case <-r.stopEviction:
+			return
+		}
+	}
+}
+
+// evictIdleChains halts the chains of the evictable channels which were idle
+// for longer than the idle timeout, and releases them once the blocks they
+// are committing are written to their ledgers
+func (r *Registrar) evictIdleChains(now time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for chainID, cs := range r.chains {
+		if cs == nil || chainID == r.systemChannelID || !r.evictable(cs.SharedConfig().ConsensusType()) {
+			continue
+		}
+		if !cs.idle(now, r.idleTimeout) {
+			continue
+		}
+		logger.Infof("Evicting chain %s, idle for more than %s", chainID, r.idleTimeout)
+		cs.Halt()
+		cs.waitCommitted()
+		r.chains[chainID] = nil
+	}
+}
+
+// unmarshalConfigTx returns the ID of the channel and the config carried by a
+// config transaction
+func unmarshalConfigTx(configTx *cb.Envelope) (string, *cb.Config) {
 	payload, err := utils.UnmarshalPayload(configTx.Payload)
 	if err != nil {
 		logger.Panicf("Error umarshaling envelope to payload: %s", err)
@@ -251,16 +458,27 @@ func (r *Registrar) newLedgerResources(configTx *cb.Envelope) *ledgerResources {
 		logger.Panicf("Error umarshaling config envelope from payload data: %s", err)
 	}
 
-	bundle, err := channelconfig.NewBundle(chdr.ChannelId, configEnvelope.Config)
+	return chdr.ChannelId, configEnvelope.Config
+}
+
+func (r *Registrar) newBundle(configTx *cb.Envelope) *channelconfig.Bundle {
+	chainID, config := unmarshalConfigTx(configTx)
+	bundle, err := channelconfig.NewBundle(chainID, config)
 	if err != nil {
 		logger.Panicf("Error creating channelconfig bundle: %s", err)
 	}
 
 	checkResourcesOrPanic(bundle)
+	return bundle
+}
 
-	ledger, err := r.ledgerFactory.GetOrCreate(chdr.ChannelId)
+func (r *Registrar) newLedgerResources(configTx *cb.Envelope) *ledgerResources {
+	bundle := r.newBundle(configTx)
+	chainID := bundle.ConfigtxValidator().ChainID()
+
+	ledger, err := r.ledgerFactory.GetOrCreate(chainID)
 	if err != nil {
-		logger.Panicf("Error getting ledger for %s", chdr.ChannelId)
+		logger.Panicf("Error getting ledger for %s", chainID)
 	}
 
 	return &ledgerResources{
@@ -276,7 +494,9 @@ func (r *Registrar) newChain(configtx *cb.Envelope) {
 	defer r.lock.Unlock()
 
 	ledgerResources := r.newLedgerResources(configtx)
-	ledgerResources.Append(blockledger.CreateNextBlock(ledgerResources, []*cb.Envelope{configtx}))
+	genesisBlock := blockledger.CreateNextBlock(ledgerResources, []*cb.Envelope{configtx})
+	genesisBlock.Header.DataHash = genesisBlock.Data.HashWith(ledgerResources.ChannelConfig().HashingAlgorithm())
+	ledgerResources.Append(genesisBlock)
 
 	// Copy the map to allow concurrent reads from broadcast/deliver while the new chainSupport is
 	newChains := make(map[string]*ChainSupport)
@@ -285,6 +505,7 @@ func (r *Registrar) newChain(configtx *cb.Envelope) {
 	}
 
 	cs := newChainSupport(r, ledgerResources, r.consenters, r.signer)
+	cs.touch(time.Now())
 	chainID := ledgerResources.ConfigtxValidator().ChainID()
 
 	logger.Infof("Created and starting new chain %s", chainID)
@@ -303,9 +524,32 @@ func (r *Registrar) ChannelsCount() int {
 	return len(r.chains)
 }
 
+// MSPManagers returns the MSP managers of the loaded channels, the system
+// channel first since its consortiums hold the MSPs of all organizations.
+func (r *Registrar) MSPManagers() []msp.MSPManager {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var managers []msp.MSPManager
+	if r.systemChannel != nil {
+		managers = append(managers, r.systemChannel.MSPManager())
+	}
+	for chainID, cs := range r.chains {
+		if cs == nil || chainID == r.systemChannelID {
+			continue
+		}
+		managers = append(managers, cs.MSPManager())
+	}
+	return managers
+}
+
 // NewChannelConfig produces a new template channel configuration based on the system channel's current config.
 func (r *Registrar) NewChannelConfig(envConfigUpdate *cb.Envelope) (channelconfig.Resources, error) {
-	return r.templator.NewChannelConfig(envConfigUpdate)
+	// The templator is replaced when the system channel switches consensus type
+	r.lock.RLock()
+	templator := r.templator
+	r.lock.RUnlock()
+	return templator.NewChannelConfig(envConfigUpdate)
 }
 
 // CreateBundle calls channelconfig.NewBundle
@@ -8,9 +8,13 @@ package multichannel
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	ramledger "github.com/hyperledger/fabric/common/ledger/blockledger/ram"
@@ -20,10 +24,15 @@ import (
 	"github.com/hyperledger/fabric/common/tools/configtxgen/configtxgentest"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	"github.com/hyperledger/fabric/orderer/consensus/solo"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	etcdraftprotos "github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	"github.com/hyperledger/fabric/protos/utils"
 
 	mmsp "github.com/hyperledger/fabric/common/mocks/msp"
@@ -337,3 +346,291 @@ func TestBroadcastChannelSupportRejection(t *testing.T) {
 	_, _, _, err := registrar.BroadcastChannelSupport(configTx)
 	assert.Error(t, err, "Messages of type HeaderType_CONFIG should return an error.")
 }
+
+type closeRecordingFactory struct {
+	blockledger.Factory
+	closed bool
+}
+
+func (f *closeRecordingFactory) Close() {
+	f.closed = true
+	f.Factory.Close()
+}
+
+func TestRegistrarClose(t *testing.T) {
+	rlf, rl := NewRAMLedgerAndFactory(10)
+	lf := &closeRecordingFactory{Factory: rlf}
+
+	consenters := make(map[string]consensus.Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
+
+	manager := NewRegistrar(lf, consenters, mockCrypto())
+	chainSupport, ok := manager.GetChain(genesisconfig.TestChainID)
+	assert.True(t, ok, "Should have gotten chain which was initialized by ramledger")
+
+	for i := 0; i < int(conf.Orderer.BatchSize.MaxMessageCount); i++ {
+		chainSupport.Order(makeNormalTx(genesisconfig.TestChainID, i), 0)
+	}
+	mch := chainSupport.Chain.(*mockChain)
+	manager.Close()
+
+	<-mch.done
+	chainSupport.waitCommitted()
+	assert.True(t, lf.closed, "Should have closed the ledger factory")
+	assert.Equal(t, uint64(2), rl.Height(), "Should have committed the block being written")
+}
+
+func TestLazyRegistrar(t *testing.T) {
+	lf, _ := NewRAMLedgerAndFactory(10)
+	channelConf := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	channelConf.Consortiums = nil
+	rl, err := lf.GetOrCreate("foo")
+	assert.NoError(t, err)
+	assert.NoError(t, rl.Append(encoder.New(channelConf).GenesisBlockForChannel("foo")))
+
+	consenters := make(map[string]consensus.Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockEvictableConsenter{}
+
+	manager := NewLazyRegistrar(lf, consenters, mockCrypto(), time.Minute)
+	defer manager.Close()
+	assert.NotNil(t, manager.chains[genesisconfig.TestChainID], "Should have loaded the system channel")
+	assert.Nil(t, manager.chains["foo"], "Should have deferred the loading of the channel")
+	assert.Equal(t, 2, manager.ChannelsCount())
+	assert.Equal(t, []msp.MSPManager{manager.systemChannel.MSPManager()}, manager.MSPManagers(), "Should only return the MSPs of the loaded channels")
+
+	chainSupport, ok := manager.GetChain("foo")
+	assert.True(t, ok, "Should have loaded the channel")
+	assert.Equal(t, chainSupport, manager.chains["foo"])
+	assert.Equal(t, []msp.MSPManager{manager.systemChannel.MSPManager(), chainSupport.MSPManager()}, manager.MSPManagers())
+	_, ok = manager.GetChain("bar")
+	assert.False(t, ok)
+
+	// the chain is not evicted while an iterator is open on it
+	it, _ := chainSupport.Reader().Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{Oldest: &ab.SeekOldest{}}})
+	now := time.Now()
+	manager.evictIdleChains(now.Add(time.Hour))
+	manager.evictIdleChains(now.Add(2 * time.Hour))
+	assert.Equal(t, chainSupport, manager.chains["foo"], "Should not have evicted the channel")
+
+	it.Close()
+	manager.evictIdleChains(now.Add(2*time.Hour + time.Second))
+	assert.Nil(t, manager.chains["foo"], "Should have evicted the channel")
+	assert.NotNil(t, manager.chains[genesisconfig.TestChainID], "Should not have evicted the system channel")
+	<-chainSupport.Chain.(*mockChain).done
+
+	chainSupport, ok = manager.GetChain("foo")
+	assert.True(t, ok, "Should have reloaded the channel")
+	for i := 0; i < int(conf.Orderer.BatchSize.MaxMessageCount); i++ {
+		chainSupport.Order(makeNormalTx("foo", i), 0)
+	}
+	for rl.Height() != 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// the block committed since the last check keeps the chain loaded
+	manager.evictIdleChains(now.Add(3 * time.Hour))
+	assert.Equal(t, chainSupport, manager.chains["foo"], "Should not have evicted the channel")
+	manager.evictIdleChains(now.Add(3*time.Hour + 2*time.Minute))
+	assert.Nil(t, manager.chains["foo"], "Should have evicted the channel")
+}
+
+func TestValidateConsensusMetadata(t *testing.T) {
+	lf, _ := NewRAMLedgerAndFactory(10)
+	consenters := map[string]consensus.Consenter{conf.Orderer.OrdererType: &mockConsenter{}}
+	manager := NewRegistrar(lf, consenters, mockCrypto())
+
+	assert.NoError(t, manager.ValidateConsensusMetadata(conf.Orderer.OrdererType, []byte("metadata")))
+	assert.EqualError(t, manager.ValidateConsensusMetadata("bogus", nil), "consensus type bogus is not supported by this orderer")
+
+	manager.consenters["validating"] = &mockValidatingConsenter{err: errors.New("bad metadata")}
+	assert.EqualError(t, manager.ValidateConsensusMetadata("validating", []byte("metadata")), "bad metadata")
+}
+
+// consensusTypeUpdate processes a config update setting the consensus type of the channel
+func consensusTypeUpdate(t *testing.T, cs *ChainSupport, consensusType *ab.ConsensusType) (*cb.Envelope, uint64, error) {
+	original := cs.ConfigtxValidator().ConfigProto()
+	updated := proto.Clone(original).(*cb.Config)
+	updated.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.ConsensusTypeKey].Value = utils.MarshalOrPanic(consensusType)
+	configUpdate, err := update.Compute(original, updated)
+	assert.NoError(t, err)
+	configUpdate.ChannelId = cs.ChainID()
+	configUpdateTx, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, cs.ChainID(), mockCrypto(), &cb.ConfigUpdateEnvelope{
+		ConfigUpdate: utils.MarshalOrPanic(configUpdate),
+	}, msgVersion, epoch)
+	assert.NoError(t, err)
+	return cs.ProcessConfigUpdateMsg(configUpdateTx)
+}
+
+func TestConsensusTypeMigration(t *testing.T) {
+	lf, _ := NewRAMLedgerAndFactory(10)
+	channelConf := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	channelConf.Consortiums = nil
+	channelConf.Orderer.Capabilities = map[string]bool{capabilities.OrdererConsensusTypeMigration: true}
+	fooLedger, err := lf.GetOrCreate("foo")
+	assert.NoError(t, err)
+	assert.NoError(t, fooLedger.Append(encoder.New(channelConf).GenesisBlockForChannel("foo")))
+
+	consenters := map[string]consensus.Consenter{
+		conf.Orderer.OrdererType: &mockConsenter{},
+		"other":                  &mockConsenter{},
+	}
+	manager := NewRegistrar(lf, consenters, mockCrypto())
+	defer manager.Close()
+
+	chainSupport, ok := manager.GetChain("foo")
+	assert.True(t, ok)
+
+	updateConsensusType := func(consensusType *ab.ConsensusType) (*cb.Envelope, uint64, error) {
+		return consensusTypeUpdate(t, chainSupport, consensusType)
+	}
+
+	_, _, err = updateConsensusType(&ab.ConsensusType{Type: "other"})
+	assert.EqualError(t, err, "Attempted to change consensus type from solo to other outside of maintenance")
+
+	config, seq, err := updateConsensusType(&ab.ConsensusType{Type: conf.Orderer.OrdererType, State: ab.ConsensusType_STATE_MAINTENANCE})
+	assert.NoError(t, err)
+	assert.NoError(t, chainSupport.Configure(config, seq))
+	for fooLedger.Height() != 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	_, err = chainSupport.ProcessNormalMsg(makeNormalTx("foo", 0))
+	assert.Equal(t, msgprocessor.ErrMaintenanceMode, errors.Cause(err))
+
+	_, _, err = updateConsensusType(&ab.ConsensusType{Type: "bogus", State: ab.ConsensusType_STATE_MAINTENANCE})
+	assert.EqualError(t, err, "cannot migrate from consensus type solo to bogus: consensus type bogus is not supported by this orderer")
+
+	config, seq, err = updateConsensusType(&ab.ConsensusType{Type: "other", State: ab.ConsensusType_STATE_MAINTENANCE})
+	assert.NoError(t, err)
+	assert.NoError(t, chainSupport.Configure(config, seq))
+	<-chainSupport.Chain.(*mockChain).done
+
+	// the chain is restarted once the block changing the consensus type is written
+	var migrated *ChainSupport
+	for migrated == nil || migrated == chainSupport {
+		time.Sleep(10 * time.Millisecond)
+		migrated, _ = manager.GetChain("foo")
+	}
+	assert.Equal(t, uint64(3), fooLedger.Height())
+	assert.Equal(t, "other", migrated.SharedConfig().ConsensusType())
+	assert.Equal(t, ab.ConsensusType_STATE_MAINTENANCE, migrated.SharedConfig().ConsensusState())
+	assert.Empty(t, migrated.Chain.(*mockChain).metadata.Value, "the new consenter should start without the metadata of the previous one")
+	assert.Equal(t, 2, manager.ChannelsCount())
+}
+
+// This test migrates a live solo channel to the etcdraft consenter, and orders
+// transactions with raft once the channel is out of maintenance
+func TestConsensusTypeMigrationToRaft(t *testing.T) {
+	lf, _ := NewRAMLedgerAndFactory(10)
+	channelConf := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	channelConf.Consortiums = nil
+	channelConf.Orderer.Capabilities = map[string]bool{capabilities.OrdererConsensusTypeMigration: true}
+	fooLedger, err := lf.GetOrCreate("foo")
+	assert.NoError(t, err)
+	assert.NoError(t, fooLedger.Append(encoder.New(channelConf).GenesisBlockForChannel("foo")))
+
+	ca, err := tlsgen.NewCA()
+	assert.NoError(t, err)
+	serverKP, err := ca.NewServerCertKeyPair("raft0.example.com")
+	assert.NoError(t, err)
+	clientKP, err := ca.NewClientCertKeyPair()
+	assert.NoError(t, err)
+	raftMetadata := utils.MarshalOrPanic(&etcdraftprotos.Metadata{
+		Consenters: []*etcdraftprotos.Consenter{
+			{Host: "raft0.example.com", Port: 7050, ClientTlsCert: clientKP.Cert, ServerTlsCert: serverKP.Cert},
+		},
+	})
+
+	consenters := map[string]consensus.Consenter{
+		"solo":     solo.New(),
+		"etcdraft": etcdraft.New(serverKP.Cert),
+	}
+	manager := NewRegistrar(lf, consenters, mockCrypto())
+	defer manager.Close()
+	chainSupport, ok := manager.GetChain("foo")
+	assert.True(t, ok)
+
+	configure := func(cs *ChainSupport, consensusType *ab.ConsensusType) {
+		config, seq, err := consensusTypeUpdate(t, cs, consensusType)
+		assert.NoError(t, err)
+		height := fooLedger.Height()
+		// the raft chain accepts transactions once its node is elected leader
+		for err = cs.Configure(config, seq); err != nil; err = cs.Configure(config, seq) {
+			assert.EqualError(t, err, "no raft leader")
+			time.Sleep(10 * time.Millisecond)
+		}
+		for fooLedger.Height() != height+1 {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	configure(chainSupport, &ab.ConsensusType{Type: "solo", State: ab.ConsensusType_STATE_MAINTENANCE})
+
+	_, _, err = consensusTypeUpdate(t, chainSupport, &ab.ConsensusType{Type: "etcdraft", Metadata: []byte("garbage"), State: ab.ConsensusType_STATE_MAINTENANCE})
+	assert.Contains(t, err.Error(), "failed to unmarshal consensus metadata")
+
+	configure(chainSupport, &ab.ConsensusType{Type: "etcdraft", Metadata: raftMetadata, State: ab.ConsensusType_STATE_MAINTENANCE})
+	var migrated *ChainSupport
+	for migrated == nil || migrated == chainSupport {
+		time.Sleep(10 * time.Millisecond)
+		migrated, _ = manager.GetChain("foo")
+	}
+	assert.IsType(t, &etcdraft.Chain{}, migrated.Chain)
+
+	configure(migrated, &ab.ConsensusType{Type: "etcdraft", Metadata: raftMetadata, State: ab.ConsensusType_STATE_NORMAL})
+	assert.Equal(t, ab.ConsensusType_STATE_NORMAL, migrated.SharedConfig().ConsensusState())
+
+	height := fooLedger.Height()
+	for i := 0; i < int(conf.Orderer.BatchSize.MaxMessageCount); i++ {
+		tx := makeNormalTx("foo", i)
+		seq, err := migrated.ProcessNormalMsg(tx)
+		assert.NoError(t, err)
+		assert.NoError(t, migrated.Order(tx, seq))
+	}
+	for fooLedger.Height() != height+1 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	block := blockledger.GetBlock(fooLedger, height)
+	assert.Len(t, block.Data.Data, int(conf.Orderer.BatchSize.MaxMessageCount))
+}
+
+func TestLazyRegistrarCallbacks(t *testing.T) {
+	lf, _ := NewRAMLedgerAndFactory(10)
+	channelConf := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	channelConf.Consortiums = nil
+	rl, err := lf.GetOrCreate("foo")
+	assert.NoError(t, err)
+	assert.NoError(t, rl.Append(encoder.New(channelConf).GenesisBlockForChannel("foo")))
+
+	consenters := make(map[string]consensus.Consenter)
+	consenters[conf.Orderer.OrdererType] = &mockEvictableConsenter{}
+
+	var chainIDs []string
+	callback := func(bundle *channelconfig.Bundle) {
+		chainIDs = append(chainIDs, bundle.ConfigtxValidator().ChainID())
+	}
+	manager := NewLazyRegistrar(lf, consenters, mockCrypto(), time.Minute, callback)
+	defer manager.Close()
+	assert.Nil(t, manager.chains["foo"], "Should have deferred the loading of the channel")
+	assert.ElementsMatch(t, []string{genesisconfig.TestChainID, "foo"}, chainIDs, "Should have invoked the callbacks with the config of the deferred channel")
+}
+
+func TestDeferrable(t *testing.T) {
+	channelConf := configtxgentest.Load(genesisconfig.SampleInsecureSoloProfile)
+	systemConfigTx := utils.ExtractEnvelopeOrPanic(encoder.New(channelConf).GenesisBlockForChannel("system"), 0)
+	channelConf.Consortiums = nil
+	configTx := utils.ExtractEnvelopeOrPanic(encoder.New(channelConf).GenesisBlockForChannel("foo"), 0)
+
+	r := &Registrar{
+		consenters:  map[string]consensus.Consenter{conf.Orderer.OrdererType: &mockEvictableConsenter{}},
+		idleTimeout: time.Minute,
+	}
+	assert.True(t, r.deferrable(configTx))
+	assert.False(t, r.deferrable(systemConfigTx), "The system channel should always be loaded")
+
+	r.idleTimeout = 0
+	assert.False(t, r.deferrable(configTx), "The channels should all be loaded without an idle timeout")
+
+	r.idleTimeout = time.Minute
+	r.consenters = map[string]consensus.Consenter{conf.Orderer.OrdererType: &mockConsenter{}}
+	assert.False(t, r.deferrable(configTx), "The channels of a consenter which is not evictable should always be loaded")
+}
@@ -32,6 +32,23 @@ func (mc *mockConsenter) HandleChain(support consensus.ConsenterSupport, metadat
 	}, nil
 }
 
+type mockEvictableConsenter struct {
+	mockConsenter
+}
+
+func (mc *mockEvictableConsenter) Evictable() bool {
+	return true
+}
+
+type mockValidatingConsenter struct {
+	mockConsenter
+	err error
+}
+
+func (mc *mockValidatingConsenter) ValidateConsensusMetadata(metadata []byte) error {
+	return mc.err
+}
+
 type mockChain struct {
 	queue    chan *cb.Envelope
 	cutter   blockcutter.Receiver
@@ -0,0 +1,72 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/fabric/common/ledger/backup"
+	fileledger "github.com/hyperledger/fabric/common/ledger/blockledger/file"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/pkg/errors"
+)
+
+// backupLedger writes a backup of the ledger of the given channel to the
+// output directory. The orderer must be stopped.
+func backupLedger(conf *localconfig.TopLevel, channelID, outputDir string, out io.Writer) error {
+	ledgerDir, err := fileLedgerLocation(conf)
+	if err != nil {
+		return err
+	}
+	manifest, err := fileledger.BackupLedger(ledgerDir, channelID, outputDir)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to back up the ledger of channel [%s]", channelID))
+	}
+	printManifest(out, manifest)
+	return nil
+}
+
+// restoreLedger restores the ledger backed up in the given directory, or only
+// verifies the backup. The orderer must be stopped.
+func restoreLedger(conf *localconfig.TopLevel, inputDir string, verifyOnly bool, out io.Writer) error {
+	if verifyOnly {
+		manifest, _, err := backup.Verify(inputDir)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed to verify the backup in [%s]", inputDir))
+		}
+		printManifest(out, manifest)
+		return nil
+	}
+	ledgerDir, err := fileLedgerLocation(conf)
+	if err != nil {
+		return err
+	}
+	manifest, err := fileledger.RestoreLedger(ledgerDir, inputDir)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to restore the backup in [%s]", inputDir))
+	}
+	printManifest(out, manifest)
+	return nil
+}
+
+func fileLedgerLocation(conf *localconfig.TopLevel) (string, error) {
+	if conf.General.LedgerType != "file" {
+		return "", errors.Errorf("only the file ledger can be backed up and restored, the ledger type is %s", conf.General.LedgerType)
+	}
+	if conf.FileLedger.Location == "" {
+		return "", errors.New("the location of the file ledger is not set")
+	}
+	return conf.FileLedger.Location, nil
+}
+
+func printManifest(out io.Writer, manifest *backup.Manifest) {
+	fmt.Fprintf(out, "Channel: %s\n", manifest.LedgerID)
+	fmt.Fprintf(out, "Height: %d\n", manifest.Height)
+	fmt.Fprintf(out, "Current block hash: %x\n", manifest.CurrentBlockHash)
+	fmt.Fprintf(out, "Created at: %s\n", manifest.CreatedAt)
+	for _, file := range manifest.Files {
+		fmt.Fprintf(out, "File: %s (%d bytes, sha256 %s)\n", file.Path, file.Size, file.SHA256)
+	}
+}
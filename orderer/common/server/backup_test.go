@@ -0,0 +1,62 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	fileledger "github.com/hyperledger/fabric/common/ledger/blockledger/file"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupAndRestoreLedger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "orderer-backup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := &localconfig.TopLevel{}
+	conf.General.LedgerType = "file"
+	conf.FileLedger.Location = filepath.Join(dir, "ledger")
+	bg, gb := testutil.NewBlockGenerator(t, "testchain", false)
+	flf := fileledger.New(conf.FileLedger.Location)
+	fl, err := flf.GetOrCreate("testchain")
+	assert.NoError(t, err)
+	assert.NoError(t, fl.Append(gb))
+	assert.NoError(t, fl.Append(bg.NextTestBlocks(1)[0]))
+	flf.Close()
+
+	backupDir := filepath.Join(dir, "backup")
+	buf := &bytes.Buffer{}
+	assert.NoError(t, backupLedger(conf, "testchain", backupDir, buf))
+	assert.Contains(t, buf.String(), "Channel: testchain\nHeight: 2\n")
+
+	buf.Reset()
+	assert.NoError(t, restoreLedger(conf, backupDir, true, buf))
+	assert.Contains(t, buf.String(), "Channel: testchain\nHeight: 2\n")
+
+	err = restoreLedger(conf, backupDir, false, buf)
+	assert.EqualError(t, err, "failed to restore the backup in ["+backupDir+"]: the block index holds entries of chain [testchain]")
+
+	conf.FileLedger.Location = filepath.Join(dir, "restored")
+	buf.Reset()
+	assert.NoError(t, restoreLedger(conf, backupDir, false, buf))
+	assert.Contains(t, buf.String(), "Channel: testchain\nHeight: 2\n")
+
+	err = restoreLedger(conf, filepath.Join(dir, "missing"), true, buf)
+	assert.Contains(t, err.Error(), "failed to verify the backup in ["+filepath.Join(dir, "missing")+"]: error reading the backup manifest")
+
+	conf.General.LedgerType = "ram"
+	err = backupLedger(conf, "testchain", filepath.Join(dir, "backup2"), buf)
+	assert.EqualError(t, err, "only the file ledger can be backed up and restored, the ledger type is ram")
+	conf.General.LedgerType = "file"
+	conf.FileLedger.Location = ""
+	err = restoreLedger(conf, backupDir, false, buf)
+	assert.EqualError(t, err, "the location of the file ledger is not set")
+}
@@ -12,14 +12,19 @@ import (
 	_ "net/http/pprof" // This is essentially the main package for the orderer
 
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/diagnostics"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
@@ -27,6 +32,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/metadata"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
 	"github.com/hyperledger/fabric/orderer/consensus/kafka"
 	"github.com/hyperledger/fabric/orderer/consensus/solo"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -51,6 +57,15 @@ var (
 	start     = app.Command("start", "Start the orderer node").Default()
 	version   = app.Command("version", "Show version information")
 	benchmark = app.Command("benchmark", "Run orderer in benchmark mode")
+	preflight = app.Command("preflight", "Validate the orderer configuration and bootstrap material without starting the orderer")
+
+	backupCmd       = app.Command("backup", "Back up the ledger of a channel while the orderer is stopped")
+	backupChannelID = backupCmd.Flag("channelID", "Channel whose ledger is backed up").Short('c').Required().String()
+	backupOutputDir = backupCmd.Flag("output", "Directory the backup is written to, which must not exist or be empty").Required().String()
+
+	restoreCmd        = app.Command("restore", "Restore the ledger of a channel from a backup while the orderer is stopped")
+	restoreInputDir   = restoreCmd.Flag("input", "Directory holding the backup").Required().String()
+	restoreVerifyOnly = restoreCmd.Flag("verify-only", "Verify the backup without restoring it").Bool()
 )
 
 // Main is the entry point of orderer process
@@ -69,6 +84,33 @@ func Main() {
 		os.Exit(1)
 	}
 	initializeLoggingLevel(conf)
+
+	// "backup" and "restore" commands
+	switch fullCmd {
+	case backupCmd.FullCommand():
+		if err := backupLedger(conf, *backupChannelID, *backupOutputDir, os.Stdout); err != nil {
+			logger.Error(err)
+			os.Exit(1)
+		}
+		return
+	case restoreCmd.FullCommand():
+		if err := restoreLedger(conf, *restoreInputDir, *restoreVerifyOnly, os.Stdout); err != nil {
+			logger.Error(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// "preflight" command, which loads the local MSP itself to report its errors
+	if fullCmd == preflight.FullCommand() {
+		p := &Preflight{Config: conf}
+		if failed := printPreflightResults(os.Stdout, p.Run()); failed > 0 {
+			fmt.Printf("%d preflight check(s) failed\n", failed)
+			os.Exit(1)
+		}
+		return
+	}
+
 	initializeLocalMsp(conf)
 
 	prettyPrintStruct(conf)
@@ -78,6 +120,12 @@ func Main() {
 // Start provides a layer of abstraction for benchmark test
 func Start(cmd string, conf *localconfig.TopLevel) {
 	signer := localmsp.NewSigner()
+
+	// The metrics root scope must be initialized before the gRPC server and
+	// the broadcast handler, which report their metrics to it, are created
+	initializeMetrics(conf)
+	defer metrics.Shutdown()
+
 	serverConfig := initializeServerConfig(conf)
 	grpcServer := initializeGrpcServer(conf, serverConfig)
 	caSupport := &comm.CASupport{
@@ -85,25 +133,35 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 		OrdererRootCAsByChain: make(map[string][][]byte),
 		ClientRootCAs:         serverConfig.SecOpts.ClientRootCAs,
 	}
-	tlsCallback := func(bundle *channelconfig.Bundle) {
-		// only need to do this if mutual TLS is required
-		if grpcServer.MutualTLSRequired() {
+	// only need to do this if mutual TLS is required. The callback is not
+	// registered otherwise, so that the registrar does not build the config of
+	// the channels it defers the loading of
+	var callbacks []func(bundle *channelconfig.Bundle)
+	if grpcServer.MutualTLSRequired() {
+		callbacks = append(callbacks, func(bundle *channelconfig.Bundle) {
 			logger.Debug("Executing callback to update root CAs")
 			updateTrustedRoots(grpcServer, caSupport, bundle)
-		}
+		})
 	}
 
-	manager := initializeMultichannelRegistrar(conf, signer, tlsCallback)
+	manager := initializeMultichannelRegistrar(conf, signer, callbacks...)
 	mutualTLS := serverConfig.SecOpts.UseTLS && serverConfig.SecOpts.RequireClientCert
-	server := NewServer(manager, signer, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS)
+	server := NewServer(manager, signer, &conf.Debug, &conf.General.Quotas, conf.General.Authentication.TimeWindow, mutualTLS)
 
 	switch cmd {
 	case start.FullCommand(): // "start" command
 		logger.Infof("Starting %s", metadata.GetVersionInfo())
 		initializeProfilingService(conf)
+		initializeTracing(conf)
+		defer tracing.Shutdown()
 		ab.RegisterAtomicBroadcastServer(grpcServer.Server(), server)
+		cb.RegisterDiagnosticsServer(grpcServer.Server(), diagnostics.NewServer(signer, diagnosticsDeserializers(manager), conf.General.Authentication.TimeWindow))
+		shutdown := handleSignals(grpcServer, server, manager, conf.General.Shutdown.DrainTimeout)
 		logger.Info("Beginning to serve requests")
-		grpcServer.Start()
+		if err := grpcServer.Start(); err != nil {
+			logger.Panicf("gRPC server exited with error: %s", err)
+		}
+		<-shutdown
 	case benchmark.FullCommand(): // "benchmark" command
 		logger.Info("Starting orderer in benchmark mode")
 		benchmarkServer := performance.GetBenchmarkServer()
@@ -112,6 +170,41 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 	}
 }
 
+// diagnosticsDeserializers returns the deserializers of the local MSP and of
+// the MSPs of the channels loaded by the registrar
+func diagnosticsDeserializers(manager *multichannel.Registrar) diagnostics.Deserializers {
+	return func() []msp.IdentityDeserializer {
+		deserializers := []msp.IdentityDeserializer{mspmgmt.GetLocalMSP()}
+		for _, m := range manager.MSPManagers() {
+			deserializers = append(deserializers, m)
+		}
+		return deserializers
+	}
+}
+
+// handleSignals shuts the orderer down gracefully upon SIGINT or SIGTERM: the
+// broadcast and deliver streams are drained, the gRPC server waits up to the
+// drain timeout for the pending requests to complete, and the channels are
+// halted once the blocks being committed are written to their ledgers. The
+// returned channel is closed once the orderer is shut down.
+func handleSignals(grpcServer *comm.GRPCServer, server BroadcastServer, registrar *multichannel.Registrar, drainTimeout time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigs
+		logger.Infof("Received %s, draining requests for up to %s", sig, drainTimeout)
+		server.Drain()
+		if !grpcServer.GracefulStop(drainTimeout) {
+			logger.Warningf("Pending requests did not complete within %s, closed their connections", drainTimeout)
+		}
+		registrar.Close()
+		logger.Info("Orderer stopped")
+		close(done)
+	}()
+	return done
+}
+
 // Set the logging level
 func initializeLoggingLevel(conf *localconfig.TopLevel) {
 	flogging.Init(flogging.Config{
@@ -132,6 +225,48 @@ func initializeProfilingService(conf *localconfig.TopLevel) {
 	}
 }
 
+// Start the export of the spans recorded for the transactions if enabled.
+func initializeTracing(conf *localconfig.TopLevel) {
+	err := tracing.Init(tracing.Opts{
+		Enabled:       conf.Tracing.Enabled,
+		ServiceName:   conf.Tracing.ServiceName,
+		Endpoint:      conf.Tracing.Endpoint,
+		FlushInterval: conf.Tracing.FlushInterval,
+		MaxBatchSize:  conf.Tracing.MaxBatchSize,
+		Timeout:       conf.Tracing.Timeout,
+	})
+	if err != nil {
+		logger.Panicf("Failed to initialize tracing: %s", err)
+	}
+	tracing.Start()
+}
+
+// Initialize the metrics root scope, and start reporting the metrics if
+// enabled.
+func initializeMetrics(conf *localconfig.TopLevel) {
+	err := metrics.Init(metrics.Opts{
+		Enabled:  conf.Metrics.Enabled,
+		Reporter: conf.Metrics.Reporter,
+		Interval: conf.Metrics.Interval,
+		StatsdReporterOpts: metrics.StatsdReporterOpts{
+			Address:       conf.Metrics.StatsdReporter.Address,
+			FlushInterval: conf.Metrics.StatsdReporter.FlushInterval,
+			FlushBytes:    conf.Metrics.StatsdReporter.FlushBytes,
+		},
+		PromReporterOpts: metrics.PromReporterOpts{
+			ListenAddress: conf.Metrics.PromReporter.ListenAddress,
+		},
+	})
+	if err != nil {
+		logger.Panicf("Failed to initialize metrics: %s", err)
+	}
+	go func() {
+		if err := metrics.Start(); err != nil {
+			logger.Errorf("Error starting metrics server: %s", err)
+		}
+	}()
+}
+
 func initializeServerConfig(conf *localconfig.TopLevel) comm.ServerConfig {
 	// secure server config
 	secureOpts := &comm.SecureOptions{
@@ -187,7 +322,13 @@ func initializeServerConfig(conf *localconfig.TopLevel) comm.ServerConfig {
 	kaOpts.ServerInterval = conf.General.Keepalive.ServerInterval
 	kaOpts.ServerTimeout = conf.General.Keepalive.ServerTimeout
 
-	return comm.ServerConfig{SecOpts: secureOpts, KaOpts: kaOpts}
+	serverConfig := comm.ServerConfig{
+		SecOpts:              secureOpts,
+		KaOpts:               kaOpts,
+		Compression:          conf.General.Compression.Enabled,
+		MaxConcurrentStreams: conf.General.MaxConcurrentStreams,
+	}
+	return serverConfig.ForServer("OrdererServer")
 }
 
 func initializeBootstrapChannel(conf *localconfig.TopLevel, lf blockledger.Factory) {
@@ -254,8 +395,25 @@ func initializeMultichannelRegistrar(conf *localconfig.TopLevel, signer crypto.L
 	consenters := make(map[string]consensus.Consenter)
 	consenters["solo"] = solo.New()
 	consenters["kafka"] = kafka.New(conf.Kafka)
+	// The etcdraft consenter identifies the orderer among the consenters of
+	// a channel by its TLS server certificate
+	var serverCert []byte
+	if conf.General.TLS.Enabled {
+		var err error
+		if serverCert, err = ioutil.ReadFile(conf.General.TLS.Certificate); err != nil {
+			logger.Panicf("Failed to load server certificate %s: %s", conf.General.TLS.Certificate, err)
+		}
+	}
+	consenters["etcdraft"] = etcdraft.New(serverCert)
+	if err := loadConsenterPlugins(conf.Consensus.Plugins, consenters); err != nil {
+		logger.Panicf("Failed loading consensus plugins: %s", err)
+	}
 
-	return multichannel.NewRegistrar(lf, consenters, signer, callbacks...)
+	var idleTimeout time.Duration
+	if conf.General.LazyLoading.Enabled {
+		idleTimeout = conf.General.LazyLoading.IdleTimeout
+	}
+	return multichannel.NewLazyRegistrar(lf, consenters, signer, idleTimeout, callbacks...)
 }
 
 func updateTrustedRoots(srv *comm.GRPCServer, rootCASupport *comm.CASupport,
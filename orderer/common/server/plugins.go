@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"os"
+	"plugin"
+
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/pkg/errors"
+)
+
+// consenterPluginFactory is the symbol consensus plugins must export.
+// It must be a function with the signature func() consensus.Consenter.
+const consenterPluginFactory = "NewConsenter"
+
+// loadConsenterPlugins opens the configured consensus plugins and adds the
+// consenters they provide to consenters, keyed by consensus type. Plugins
+// may not replace the builtin consensus types.
+func loadConsenterPlugins(plugins map[string]localconfig.ConsensusPlugin, consenters map[string]consensus.Consenter) error {
+	for consensusType, p := range plugins {
+		if _, exists := consenters[consensusType]; exists {
+			return errors.Errorf("consensus plugin %s conflicts with a builtin consensus type", consensusType)
+		}
+		consenter, err := loadConsenterPlugin(p.Library)
+		if err != nil {
+			return errors.WithMessage(err, "failed loading consensus plugin "+consensusType)
+		}
+		logger.Infof("Loaded consensus plugin %s from %s", consensusType, p.Library)
+		consenters[consensusType] = consenter
+	}
+	return nil
+}
+
+func loadConsenterPlugin(library string) (consensus.Consenter, error) {
+	if _, err := os.Stat(library); err != nil {
+		return nil, errors.Wrapf(err, "could not find plugin at path %s", library)
+	}
+
+	p, err := plugin.Open(library)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not open plugin at path %s", library)
+	}
+
+	symbol, err := p.Lookup(consenterPluginFactory)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin must contain constructor with name %s", consenterPluginFactory)
+	}
+	constructor, ok := symbol.(func() consensus.Consenter)
+	if !ok {
+		return nil, errors.Errorf("constructor method %s does not match expected definition", consenterPluginFactory)
+	}
+
+	consenter := constructor()
+	if consenter == nil {
+		return nil, errors.Errorf("constructor method %s returned nil", consenterPluginFactory)
+	}
+	return consenter, nil
+}
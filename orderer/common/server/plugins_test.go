@@ -0,0 +1,42 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/solo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConsenterPlugins(t *testing.T) {
+	consenters := map[string]consensus.Consenter{"solo": solo.New()}
+	assert.NoError(t, loadConsenterPlugins(nil, consenters))
+	assert.Len(t, consenters, 1)
+
+	err := loadConsenterPlugins(map[string]localconfig.ConsensusPlugin{"solo": {Library: "plugin.so"}}, consenters)
+	assert.EqualError(t, err, "consensus plugin solo conflicts with a builtin consensus type")
+
+	tmpdir, err := ioutil.TempDir("", "plugins")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+	library := filepath.Join(tmpdir, "plugin.so")
+
+	err = loadConsenterPlugins(map[string]localconfig.ConsensusPlugin{"custom": {Library: library}}, consenters)
+	assert.Contains(t, err.Error(), "failed loading consensus plugin custom: could not find plugin at path "+library)
+
+	require.NoError(t, ioutil.WriteFile(library, []byte("not a plugin"), 0644))
+	err = loadConsenterPlugins(map[string]localconfig.ConsensusPlugin{"custom": {Library: library}}, consenters)
+	assert.Contains(t, err.Error(), "could not open plugin at path "+library)
+	assert.NotContains(t, consenters, "custom")
+}
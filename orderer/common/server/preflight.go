@@ -0,0 +1,231 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/msp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	"github.com/hyperledger/fabric/orderer/consensus/kafka"
+	cb "github.com/hyperledger/fabric/protos/common"
+	raftprotos "github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// PreflightResult is the outcome of a single preflight check.
+type PreflightResult struct {
+	Check string
+	Err   error
+}
+
+// Preflight validates the orderer configuration and bootstrap material
+// without starting any of the orderer services. It runs before the local MSP
+// is initialized, so that an invalid MSP is reported as a failed check.
+type Preflight struct {
+	Config *localconfig.TopLevel
+	// LoadLocalMSP loads the local MSP; defaults to loading it from the
+	// General section of the config.
+	LoadLocalMSP func() error
+	// CheckBroker probes a Kafka broker; defaults to kafka.CheckBroker with
+	// the Kafka section of the config.
+	CheckBroker func(address string) error
+	// LocalSigningIdentity returns the identity the orderer signs with;
+	// defaults to the identity of the local MSP.
+	LocalSigningIdentity func() (msp.SigningIdentity, error)
+}
+
+// Run executes every preflight check and returns their results in order.
+// Checks that depend on the bootstrap block are skipped if it cannot be
+// loaded.
+func (p *Preflight) Run() []PreflightResult {
+	var results []PreflightResult
+	record := func(check string, err error) {
+		results = append(results, PreflightResult{Check: check, Err: err})
+	}
+
+	block, err := p.genesisBlock()
+	record("load bootstrap block", err)
+	if err != nil {
+		return results
+	}
+
+	bundle, err := bundleFromBlock(block)
+	record("parse bootstrap block", err)
+	if err != nil {
+		return results
+	}
+
+	oc, ok := bundle.OrdererConfig()
+	if !ok {
+		record("orderer config", errors.New("bootstrap block does not contain orderer config"))
+		return results
+	}
+	record("orderer capabilities", oc.Capabilities().Supported())
+	record("channel capabilities", bundle.ChannelConfig().Capabilities().Supported())
+
+	err = p.loadLocalMSP()
+	record("load local MSP", err)
+	if err == nil {
+		record("local MSP", p.checkLocalMSP(bundle, oc))
+	}
+
+	switch oc.ConsensusType() {
+	case raftprotos.TypeKey:
+		record("raft consenter", p.checkRaftConsenter(oc))
+	case "kafka":
+		for _, broker := range oc.KafkaBrokers() {
+			record(fmt.Sprintf("kafka broker %s", broker), p.checkBroker(broker))
+		}
+	case "solo":
+	default:
+		record(fmt.Sprintf("consensus plugin %s", oc.ConsensusType()), p.checkConsensusPlugin(oc.ConsensusType()))
+	}
+
+	return results
+}
+
+func (p *Preflight) genesisBlock() (block *cb.Block, err error) {
+	conf := p.Config.General
+	switch conf.GenesisMethod {
+	case "provisional":
+		// The encoder panics on invalid profiles, surface that as an error instead.
+		defer func() {
+			if r := recover(); r != nil {
+				err = errors.Errorf("failed generating genesis block from profile %s: %v", conf.GenesisProfile, r)
+			}
+		}()
+		return encoder.New(genesisconfig.Load(conf.GenesisProfile)).GenesisBlockForChannel(conf.SystemChannel), nil
+	case "file":
+		raw, err := ioutil.ReadFile(conf.GenesisFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed reading genesis block file %s", conf.GenesisFile)
+		}
+		block := &cb.Block{}
+		if err := proto.Unmarshal(raw, block); err != nil {
+			return nil, errors.Wrapf(err, "failed unmarshaling genesis block file %s", conf.GenesisFile)
+		}
+		return block, nil
+	default:
+		return nil, errors.Errorf("unknown genesis method: %s", conf.GenesisMethod)
+	}
+}
+
+func bundleFromBlock(block *cb.Block) (*channelconfig.Bundle, error) {
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed extracting config envelope")
+	}
+	return channelconfig.NewBundleFromEnvelope(env)
+}
+
+func (p *Preflight) loadLocalMSP() error {
+	if p.LoadLocalMSP != nil {
+		return p.LoadLocalMSP()
+	}
+	conf := p.Config.General
+	return mspmgmt.LoadLocalMsp(conf.LocalMSPDir, conf.BCCSP, conf.LocalMSPID)
+}
+
+func (p *Preflight) checkLocalMSP(bundle *channelconfig.Bundle, oc channelconfig.Orderer) error {
+	found := false
+	for _, org := range oc.Organizations() {
+		if org.MSPID() == p.Config.General.LocalMSPID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return errors.Errorf("local MSP ID %s is not an orderer organization of channel %s", p.Config.General.LocalMSPID, bundle.ConfigtxValidator().ChainID())
+	}
+
+	localSigningIdentity := p.LocalSigningIdentity
+	if localSigningIdentity == nil {
+		localSigningIdentity = func() (msp.SigningIdentity, error) {
+			return mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+		}
+	}
+	signer, err := localSigningIdentity()
+	if err != nil {
+		return errors.Wrap(err, "failed obtaining local signing identity")
+	}
+	serialized, err := signer.Serialize()
+	if err != nil {
+		return errors.Wrap(err, "failed serializing local signing identity")
+	}
+	id, err := bundle.MSPManager().DeserializeIdentity(serialized)
+	if err != nil {
+		return errors.Wrap(err, "local signing identity cannot be deserialized by the channel MSPs")
+	}
+	if err := id.Validate(); err != nil {
+		return errors.Wrap(err, "local signing identity is not valid for the channel MSPs")
+	}
+	return nil
+}
+
+// checkRaftConsenter validates the raft metadata as the etcdraft consenter
+// does, and checks that the orderer is its consenter, otherwise it would
+// serve an inactive chain.
+func (p *Preflight) checkRaftConsenter(oc channelconfig.Orderer) error {
+	if err := etcdraft.New(nil).ValidateConsensusMetadata(oc.ConsensusMetadata()); err != nil {
+		return err
+	}
+	md := &raftprotos.Metadata{}
+	if err := proto.Unmarshal(oc.ConsensusMetadata(), md); err != nil {
+		return errors.Wrap(err, "failed to unmarshal consensus metadata")
+	}
+	var serverCert []byte
+	if p.Config.General.TLS.Enabled {
+		var err error
+		serverCert, err = ioutil.ReadFile(p.Config.General.TLS.Certificate)
+		if err != nil {
+			return errors.Wrapf(err, "failed reading TLS certificate %s", p.Config.General.TLS.Certificate)
+		}
+	}
+	_, err := etcdraft.RaftID(md, serverCert)
+	return err
+}
+
+func (p *Preflight) checkConsensusPlugin(consensusType string) error {
+	plugin, ok := p.Config.Consensus.Plugins[consensusType]
+	if !ok {
+		return errors.Errorf("consensus type %s is neither builtin nor provided by a plugin in the Consensus.Plugins section", consensusType)
+	}
+	if _, err := os.Stat(plugin.Library); err != nil {
+		return errors.Wrapf(err, "could not find plugin at path %s", plugin.Library)
+	}
+	return nil
+}
+
+func (p *Preflight) checkBroker(broker string) error {
+	if p.CheckBroker != nil {
+		return p.CheckBroker(broker)
+	}
+	return kafka.CheckBroker(p.Config.Kafka, broker)
+}
+
+// printPreflightResults writes a report of the results to w and returns
+// the number of failed checks.
+func printPreflightResults(w io.Writer, results []PreflightResult) int {
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+			fmt.Fprintf(w, "[FAIL] %s: %s\n", r.Check, r.Err)
+			continue
+		}
+		fmt.Fprintf(w, "[ OK ] %s\n", r.Check)
+	}
+	return failed
+}
@@ -0,0 +1,184 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func failedChecks(results []PreflightResult) map[string]error {
+	failed := map[string]error{}
+	for _, r := range results {
+		if r.Err != nil {
+			failed[r.Check] = r.Err
+		}
+	}
+	return failed
+}
+
+func TestPreflightSolo(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	conf := genesisConfig(t)
+
+	results := (&Preflight{Config: conf}).Run()
+	assert.Empty(t, failedChecks(results))
+	assert.Len(t, results, 6)
+}
+
+func TestPreflightLoadLocalMSP(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	conf := genesisConfig(t)
+	conf.General.LocalMSPDir = "does-not-exist"
+
+	results := (&Preflight{Config: conf}).Run()
+	failed := failedChecks(results)
+	require.Contains(t, failed, "load local MSP")
+	assert.NotContains(t, failed, "local MSP")
+	assert.Len(t, results, 5)
+
+	p := &Preflight{
+		Config:       genesisConfig(t),
+		LoadLocalMSP: func() error { return errors.New("bad MSP") },
+	}
+	assert.EqualError(t, failedChecks(p.Run())["load local MSP"], "bad MSP")
+}
+
+func TestPreflightUnknownLocalMSP(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	conf := genesisConfig(t)
+	conf.General.LocalMSPID = "OtherOrg"
+
+	failed := failedChecks((&Preflight{Config: conf}).Run())
+	require.Contains(t, failed, "local MSP")
+	assert.Contains(t, failed["local MSP"].Error(), "local MSP ID OtherOrg is not an orderer organization")
+}
+
+func TestPreflightSigningIdentityError(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	conf := genesisConfig(t)
+
+	p := &Preflight{
+		Config: conf,
+		LocalSigningIdentity: func() (msp.SigningIdentity, error) {
+			return nil, errors.New("no identity")
+		},
+	}
+	failed := failedChecks(p.Run())
+	assert.EqualError(t, failed["local MSP"], "failed obtaining local signing identity: no identity")
+}
+
+func TestPreflightGenesisFile(t *testing.T) {
+	conf := genesisConfig(t)
+	conf.General.GenesisMethod = "file"
+	conf.General.GenesisFile = "does-not-exist"
+	results := (&Preflight{Config: conf}).Run()
+	require.Len(t, results, 1)
+	assert.Contains(t, results[0].Err.Error(), "failed reading genesis block file does-not-exist")
+
+	tmpdir, err := ioutil.TempDir("", "preflight")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+	conf.General.GenesisFile = filepath.Join(tmpdir, "garbage")
+	require.NoError(t, ioutil.WriteFile(conf.General.GenesisFile, []byte("garbage"), 0644))
+	results = (&Preflight{Config: conf}).Run()
+	require.Len(t, results, 1)
+	assert.Contains(t, results[0].Err.Error(), "failed unmarshaling genesis block file")
+}
+
+func TestPreflightUnknownGenesisMethod(t *testing.T) {
+	conf := genesisConfig(t)
+	conf.General.GenesisMethod = "foo"
+	results := (&Preflight{Config: conf}).Run()
+	require.Len(t, results, 1)
+	assert.EqualError(t, results[0].Err, "unknown genesis method: foo")
+}
+
+func TestPreflightKafkaBrokers(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	conf := genesisConfig(t)
+	conf.General.GenesisProfile = "SampleDevModeKafka"
+
+	var checked []string
+	p := &Preflight{
+		Config: conf,
+		CheckBroker: func(address string) error {
+			checked = append(checked, address)
+			return errors.New("failed connecting to " + address)
+		},
+	}
+	failed := failedChecks(p.Run())
+	assert.Equal(t, []string{"kafka0:9092", "kafka1:9092", "kafka2:9092"}, checked)
+	assert.EqualError(t, failed["kafka broker kafka0:9092"], "failed connecting to kafka0:9092")
+
+	p.CheckBroker = func(address string) error { return nil }
+	assert.Empty(t, failedChecks(p.Run()))
+}
+
+func TestPreflightRaftConsenters(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	devConfigDir, err := configtest.GetDevConfigDir()
+	require.NoError(t, err)
+	conf := genesisConfig(t)
+	conf.General.GenesisProfile = "SampleDevModeEtcdRaft"
+
+	failed := failedChecks((&Preflight{Config: conf}).Run())
+	assert.EqualError(t, failed["raft consenter"], "TLS must be enabled to identify the orderer among the consenters")
+
+	conf.General.TLS.Enabled = true
+	conf.General.TLS.Certificate = filepath.Join(devConfigDir, "etcdraft", "tls-client-1.pem")
+	assert.Empty(t, failedChecks((&Preflight{Config: conf}).Run()))
+
+	conf.General.TLS.Certificate = filepath.Join(devConfigDir, "etcdraft", "tls-server-1.pem")
+	failed = failedChecks((&Preflight{Config: conf}).Run())
+	assert.EqualError(t, failed["raft consenter"], "the TLS server certificate of the orderer does not match any of the 1 consenters")
+
+	conf.General.TLS.Certificate = "does-not-exist"
+	failed = failedChecks((&Preflight{Config: conf}).Run())
+	assert.Contains(t, failed["raft consenter"].Error(), "failed reading TLS certificate does-not-exist")
+}
+
+func TestPrintPreflightResults(t *testing.T) {
+	buf := &bytes.Buffer{}
+	failed := printPreflightResults(buf, []PreflightResult{
+		{Check: "good"},
+		{Check: "bad", Err: errors.New("boom")},
+	})
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, "[ OK ] good\n[FAIL] bad: boom\n", buf.String())
+}
+
+func TestPreflightConsensusPlugin(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "preflight")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+	library := filepath.Join(tmpdir, "plugin.so")
+
+	p := &Preflight{Config: genesisConfig(t)}
+	err = p.checkConsensusPlugin("custom")
+	assert.EqualError(t, err, "consensus type custom is neither builtin nor provided by a plugin in the Consensus.Plugins section")
+
+	p.Config.Consensus.Plugins = map[string]localconfig.ConsensusPlugin{"custom": {Library: library}}
+	err = p.checkConsensusPlugin("custom")
+	assert.Contains(t, err.Error(), "could not find plugin at path "+library)
+
+	require.NoError(t, ioutil.WriteFile(library, []byte("plugin"), 0644))
+	assert.NoError(t, p.checkConsensusPlugin("custom"))
+}
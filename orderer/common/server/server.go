@@ -16,6 +16,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/deliver"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/orderer/common/broadcast"
 	localconfig "github.com/hyperledger/fabric/orderer/common/localconfig"
@@ -67,17 +68,46 @@ func (rs *responseSender) SendBlockResponse(block *cb.Block) error {
 	return rs.Send(response)
 }
 
-// NewServer creates an ab.AtomicBroadcastServer based on the broadcast target and ledger Reader
-func NewServer(r *multichannel.Registrar, _ crypto.LocalSigner, debug *localconfig.Debug, timeWindow time.Duration, mutualTLS bool) ab.AtomicBroadcastServer {
+// BroadcastServer is an ab.AtomicBroadcastServer which can be drained when the
+// orderer shuts down
+type BroadcastServer interface {
+	ab.AtomicBroadcastServer
+
+	// Drain rejects the broadcasts received from now on and ends the deliver
+	// streams with SERVICE_UNAVAILABLE, so that clients fail over to another orderer
+	Drain()
+}
+
+// NewServer creates a BroadcastServer based on the broadcast target and ledger Reader
+func NewServer(r *multichannel.Registrar, _ crypto.LocalSigner, debug *localconfig.Debug, quotas *localconfig.Quotas, timeWindow time.Duration, mutualTLS bool) BroadcastServer {
 	s := &server{
 		dh:        deliver.NewHandler(deliverSupport{Registrar: r}, timeWindow, mutualTLS),
-		bh:        broadcast.NewHandlerImpl(broadcastSupport{Registrar: r}),
+		bh:        broadcast.NewHandlerWithQuotas(broadcastSupport{Registrar: r}, broadcastQuotas(quotas), metrics.SubScope("orderer").SubScope("broadcast")),
 		debug:     debug,
 		Registrar: r,
 	}
 	return s
 }
 
+// broadcastQuotas converts the quotas of the local configuration to those
+// enforced by the broadcast handler
+func broadcastQuotas(conf *localconfig.Quotas) broadcast.Quotas {
+	quotas := broadcast.Quotas{
+		Default: broadcast.Quota{
+			TxPerSecond:    conf.TxPerSecond,
+			BytesPerSecond: conf.BytesPerSecond,
+		},
+		Channels: make(map[string]broadcast.Quota, len(conf.Channels)),
+	}
+	for channelID, quota := range conf.Channels {
+		quotas.Channels[channelID] = broadcast.Quota{
+			TxPerSecond:    quota.TxPerSecond,
+			BytesPerSecond: quota.BytesPerSecond,
+		}
+	}
+	return quotas
+}
+
 type msgTracer struct {
 	function string
 	debug    *localconfig.Debug
@@ -130,6 +160,13 @@ func (dmt *deliverMsgTracer) Recv() (*cb.Envelope, error) {
 	return msg, err
 }
 
+// Drain rejects the broadcasts received from now on and ends the deliver
+// streams with SERVICE_UNAVAILABLE
+func (s *server) Drain() {
+	s.bh.Drain()
+	s.dh.Drain()
+}
+
 // Broadcast receives a stream of messages from a client for ordering
 func (s *server) Broadcast(srv ab.AtomicBroadcast_BroadcastServer) error {
 	logger.Debugf("Starting new Broadcast handler")
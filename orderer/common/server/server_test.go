@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/orderer/common/broadcast"
 	localconfig "github.com/hyperledger/fabric/orderer/common/localconfig"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -35,6 +36,21 @@ func TestDeliverNoPanic(t *testing.T) {
 	_ = (&server{}).Deliver(nil)
 }
 
+func TestBroadcastQuotas(t *testing.T) {
+	quotas := broadcastQuotas(&localconfig.Quotas{
+		TxPerSecond: 100,
+		Channels: map[string]localconfig.Quota{
+			"mychannel": {TxPerSecond: 10, BytesPerSecond: 1024},
+		},
+	})
+	assert.Equal(t, broadcast.Quotas{
+		Default: broadcast.Quota{TxPerSecond: 100},
+		Channels: map[string]broadcast.Quota{
+			"mychannel": {TxPerSecond: 10, BytesPerSecond: 1024},
+		},
+	}, quotas)
+}
+
 type recvr interface {
 	Recv() (*cb.Envelope, error)
 }
@@ -17,7 +17,8 @@ import (
 // Consenter defines the backing ordering mechanism.
 type Consenter interface {
 	// HandleChain should create and return a reference to a Chain for the given set of resources.
-	// It will only be invoked for a given chain once per process.  In general, errors will be treated
+	// It will only be invoked for a given chain once per process, unless the Consenter is an
+	// EvictableConsenter.  In general, errors will be treated
 	// as irrecoverable and cause system shutdown.  See the description of Chain for more details
 	// The second argument to HandleChain is a pointer to the metadata stored on the `ORDERER` slot of
 	// the last block committed to the ledger of this Chain.  For a new chain, this metadata will be
@@ -25,6 +26,26 @@ type Consenter interface {
 	HandleChain(support ConsenterSupport, metadata *cb.Metadata) (Chain, error)
 }
 
+// EvictableConsenter is implemented by the Consenters whose chains keep no state
+// besides the ledger, so that the chain of an idle channel may be halted to free
+// its resources and handed to HandleChain again once the channel is used.
+type EvictableConsenter interface {
+	Consenter
+
+	// Evictable returns whether the chains of this consenter may be evicted
+	Evictable() bool
+}
+
+// MetadataValidator is implemented by the Consenters which validate the consensus
+// metadata of the channels migrating to their consensus type, before the config
+// update changing the consensus type is ordered.
+type MetadataValidator interface {
+	Consenter
+
+	// ValidateConsensusMetadata returns an error if the metadata is not valid for this consenter
+	ValidateConsensusMetadata(metadata []byte) error
+}
+
 // Chain defines a way to inject messages for ordering.
 // Note, that in order to allow flexibility in the implementation, it is the responsibility of the implementer
 // to take the ordered messages, send them through the blockcutter.Receiver supplied via HandleChain to cut blocks,
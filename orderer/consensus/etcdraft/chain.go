@@ -117,8 +117,7 @@ func (c *Chain) Order(env *common.Envelope, configSeq uint64) error {
 
 // Configure submits config type transactions for ordering.
 func (c *Chain) Configure(env *common.Envelope, configSeq uint64) error {
-	c.logger.Panicf("Configure not implemented yet")
-	return nil
+	return c.Submit(&orderer.SubmitRequest{LastValidationSeq: configSeq, Content: env}, 0)
 }
 
 // WaitReady is currently a no-op.
@@ -197,7 +196,11 @@ func (c *Chain) serveRequest() {
 		select {
 		case msg := <-c.submitC:
 			if c.isConfig(msg.Content) {
-				c.logger.Panicf("Processing config envelope is not implemented yet")
+				stop()
+				if err := c.commitConfig(msg, seq); err != nil {
+					c.logger.Errorf("Failed to commit config block: %s", err)
+				}
+				continue
 			}
 
 			if msg.LastValidationSeq < seq {
@@ -242,27 +245,58 @@ func (c *Chain) serveRequest() {
 
 func (c *Chain) commitBatches(batches ...[]*common.Envelope) error {
 	for _, batch := range batches {
-		b := c.support.CreateNextBlock(batch)
-		data := utils.MarshalOrPanic(b)
-		if err := c.node.Propose(context.TODO(), data); err != nil {
-			return errors.Errorf("failed to propose data to raft: %s", err)
+		block, err := c.propose(c.support.CreateNextBlock(batch))
+		if err != nil || block == nil {
+			return err
 		}
+		c.support.WriteBlock(block, nil)
+	}
 
-		select {
-		case block := <-c.commitC:
-			if utils.IsConfigBlock(block) {
-				c.logger.Panicf("Config block is not supported yet")
-			}
-			c.support.WriteBlock(block, nil)
+	return nil
+}
 
-		case <-c.doneC:
+// commitConfig revalidates the config message if the config changed since it
+// was validated, then commits the pending envelopes, which were validated
+// against the current config, and the config block on their own.
+func (c *Chain) commitConfig(msg *orderer.SubmitRequest, seq uint64) error {
+	config := msg.Content
+	if msg.LastValidationSeq < seq {
+		var err error
+		if config, _, err = c.support.ProcessConfigMsg(msg.Content); err != nil {
+			c.logger.Warningf("Discarding bad config message: %s", err)
 			return nil
 		}
 	}
 
+	if batch := c.support.BlockCutter().Cut(); len(batch) != 0 {
+		if err := c.commitBatches(batch); err != nil {
+			return err
+		}
+	}
+
+	block, err := c.propose(c.support.CreateNextBlock([]*common.Envelope{config}))
+	if err != nil || block == nil {
+		return err
+	}
+	c.support.WriteConfigBlock(block, nil)
 	return nil
 }
 
+// propose proposes the block to raft, and returns it once it is committed,
+// or nil if the chain is halted before.
+func (c *Chain) propose(b *common.Block) (*common.Block, error) {
+	if err := c.node.Propose(context.TODO(), utils.MarshalOrPanic(b)); err != nil {
+		return nil, errors.Errorf("failed to propose data to raft: %s", err)
+	}
+
+	select {
+	case block := <-c.commitC:
+		return block, nil
+	case <-c.doneC:
+		return nil, nil
+	}
+}
+
 func (c *Chain) serveRaft() {
 	ticker := c.clock.NewTicker(c.opts.TickInterval)
 
@@ -303,17 +303,68 @@ var _ = Describe("Chain", func() {
 				Expect(chain.Errored()).Should(BeClosed())
 			})
 
-			It("config message is not yet supported", func() {
-				c := &common.Envelope{
-					Payload: utils.MarshalOrPanic(&common.Payload{
-						Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{Type: int32(common.HeaderType_CONFIG), ChannelId: channelID})},
-						Data:   []byte("TEST_MESSAGE"),
-					}),
-				}
-
-				Expect(func() {
-					chain.Configure(c, uint64(0))
-				}).To(Panic())
+			Context("config messages", func() {
+				var c *common.Envelope
+
+				BeforeEach(func() {
+					close(cutter.Block)
+					support.CreateNextBlockReturns(normalBlock)
+					support.SharedConfigReturns(&mockconfig.Orderer{BatchTimeoutVal: time.Hour})
+					c = &common.Envelope{
+						Payload: utils.MarshalOrPanic(&common.Payload{
+							Header: &common.Header{ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{Type: int32(common.HeaderType_CONFIG), ChannelId: channelID})},
+							Data:   []byte("TEST_CONFIG"),
+						}),
+					}
+				})
+
+				It("writes a config block", func() {
+					err := chain.Configure(c, uint64(0))
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(support.WriteConfigBlockCallCount).Should(Equal(1))
+					Expect(support.CreateNextBlockArgsForCall(0)).To(Equal([]*common.Envelope{c}))
+					Expect(support.WriteBlockCallCount()).To(Equal(0))
+				})
+
+				It("writes the pending envelopes before the config block", func() {
+					err := chain.Order(m, uint64(0))
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(func() int {
+						return len(cutter.CurBatch)
+					}).Should(Equal(1))
+
+					err = chain.Configure(c, uint64(0))
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(support.WriteConfigBlockCallCount).Should(Equal(1))
+					Expect(support.WriteBlockCallCount()).To(Equal(1))
+					Expect(support.CreateNextBlockArgsForCall(0)).To(Equal([]*common.Envelope{m}))
+					Expect(support.CreateNextBlockArgsForCall(1)).To(Equal([]*common.Envelope{c}))
+				})
+
+				Context("revalidation", func() {
+					BeforeEach(func() {
+						support.SequenceReturns(1)
+					})
+
+					It("revalidates a config message if the config changed", func() {
+						revalidated := &common.Envelope{Payload: c.Payload, Signature: []byte("revalidated")}
+						support.ProcessConfigMsgReturns(revalidated, 1, nil)
+
+						err := chain.Configure(c, uint64(0))
+						Expect(err).NotTo(HaveOccurred())
+						Eventually(support.WriteConfigBlockCallCount).Should(Equal(1))
+						Expect(support.CreateNextBlockArgsForCall(0)).To(Equal([]*common.Envelope{revalidated}))
+					})
+
+					It("discards a config message which is no longer valid", func() {
+						support.ProcessConfigMsgReturns(nil, 1, errors.Errorf("Config is invalid"))
+
+						err := chain.Configure(c, uint64(0))
+						Expect(err).NotTo(HaveOccurred())
+						Consistently(support.CreateNextBlockCallCount).Should(Equal(0))
+						Expect(support.WriteConfigBlockCallCount()).To(Equal(0))
+					})
+				})
 			})
 		})
 	})
@@ -0,0 +1,179 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/coreos/etcd/raft"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	"github.com/pkg/errors"
+)
+
+const pkgLogID = "orderer/consensus/etcdraft"
+
+// The raft settings of the chains, which are not part of the channel config yet
+const (
+	tickInterval    = 100 * time.Millisecond
+	electionTick    = 10
+	heartbeatTick   = 1
+	maxSizePerMsg   = 1024 * 1024
+	maxInflightMsgs = 256
+)
+
+// Consenter implements the etcdraft consensus type. Only single node raft
+// clusters are supported: the chain of a channel is served by the orderer
+// whose TLS server certificate is that of the sole consenter of the channel,
+// and the other orderers serve an inactive chain, which rejects transactions.
+type Consenter struct {
+	cert   []byte
+	logger *flogging.FabricLogger
+}
+
+// New creates an etcdraft consenter for the orderer with the given TLS server
+// certificate, which is nil if TLS is disabled.
+func New(cert []byte) *Consenter {
+	return &Consenter{
+		cert:   cert,
+		logger: flogging.MustGetLogger(pkgLogID),
+	}
+}
+
+// HandleChain creates the chain of the channel, which starts from the ledger
+// of the channel, as the raft log is not persisted.
+func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *common.Metadata) (consensus.Chain, error) {
+	m := &etcdraft.Metadata{}
+	if err := proto.Unmarshal(support.SharedConfig().ConsensusMetadata(), m); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal consensus metadata")
+	}
+	if err := validateMetadata(m); err != nil {
+		return nil, err
+	}
+	id, err := RaftID(m, c.cert)
+	if err != nil {
+		c.logger.Warningf("Channel %s is served by another orderer: %s", support.ChainID(), err)
+		return &inactiveChain{err: err, doneC: make(chan struct{})}, nil
+	}
+
+	opts := Options{
+		RaftID:          id,
+		Clock:           clock.NewClock(),
+		Storage:         raft.NewMemoryStorage(),
+		Logger:          c.logger,
+		TickInterval:    tickInterval,
+		ElectionTick:    electionTick,
+		HeartbeatTick:   heartbeatTick,
+		MaxSizePerMsg:   maxSizePerMsg,
+		MaxInflightMsgs: maxInflightMsgs,
+		Peers:           []raft.Peer{{ID: id}},
+	}
+	return NewChain(support, opts, nil)
+}
+
+// ValidateConsensusMetadata checks the metadata of a channel migrating to the
+// etcdraft consensus type.
+func (c *Consenter) ValidateConsensusMetadata(metadata []byte) error {
+	m := &etcdraft.Metadata{}
+	if err := proto.Unmarshal(metadata, m); err != nil {
+		return errors.Wrap(err, "failed to unmarshal consensus metadata")
+	}
+	return validateMetadata(m)
+}
+
+func validateMetadata(m *etcdraft.Metadata) error {
+	if len(m.Consenters) != 1 {
+		return errors.Errorf("etcdraft supports a single consenter, got %d", len(m.Consenters))
+	}
+	consenter := m.Consenters[0]
+	if consenter.Host == "" || consenter.Port == 0 {
+		return errors.Errorf("consenter %s:%d has no valid endpoint", consenter.Host, consenter.Port)
+	}
+	if err := validateCert(consenter.ClientTlsCert); err != nil {
+		return errors.WithMessage(err, "invalid client TLS certificate of consenter")
+	}
+	if err := validateCert(consenter.ServerTlsCert); err != nil {
+		return errors.WithMessage(err, "invalid server TLS certificate of consenter")
+	}
+	return nil
+}
+
+func validateCert(cert []byte) error {
+	bl, _ := pem.Decode(cert)
+	if bl == nil {
+		return errors.New("not PEM encoded")
+	}
+	_, err := x509.ParseCertificate(bl.Bytes)
+	return errors.Wrap(err, "not an x509 certificate")
+}
+
+// RaftID returns the raft ID of the orderer with the given TLS server
+// certificate among the consenters of the metadata, which is its index
+// starting from 1.
+func RaftID(m *etcdraft.Metadata, cert []byte) (uint64, error) {
+	if len(cert) == 0 {
+		return 0, errors.New("TLS must be enabled to identify the orderer among the consenters")
+	}
+	for i, consenter := range m.Consenters {
+		if certsEqual(consenter.ServerTlsCert, cert) {
+			return uint64(i + 1), nil
+		}
+	}
+	return 0, errors.Errorf("the TLS server certificate of the orderer does not match any of the %d consenters", len(m.Consenters))
+}
+
+// certsEqual compares two certificates by their DER content so that
+// differences in PEM framing or whitespace are ignored.
+func certsEqual(a, b []byte) bool {
+	derA, derB := pemToDER(a), pemToDER(b)
+	return len(derA) > 0 && bytes.Equal(derA, derB)
+}
+
+func pemToDER(raw []byte) []byte {
+	bl, _ := pem.Decode(raw)
+	if bl == nil {
+		return raw
+	}
+	return bl.Bytes
+}
+
+// inactiveChain is the chain of a channel whose consenter is another orderer
+type inactiveChain struct {
+	err   error
+	doneC chan struct{}
+}
+
+func (c *inactiveChain) Order(env *common.Envelope, configSeq uint64) error {
+	return errors.WithMessage(c.err, "orderer is not a consenter of the channel")
+}
+
+func (c *inactiveChain) Configure(config *common.Envelope, configSeq uint64) error {
+	return errors.WithMessage(c.err, "orderer is not a consenter of the channel")
+}
+
+func (c *inactiveChain) WaitReady() error {
+	return nil
+}
+
+// Errored returns a closed channel, so that the deliver clients of the orderer
+// do not wait for blocks it does not write.
+func (c *inactiveChain) Errored() <-chan struct{} {
+	return c.doneC
+}
+
+func (c *inactiveChain) Start() {
+	close(c.doneC)
+}
+
+func (c *inactiveChain) Halt() {}
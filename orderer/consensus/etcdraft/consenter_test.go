@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+package etcdraft_test
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	consensusmocks "github.com/hyperledger/fabric/orderer/consensus/mocks"
+	"github.com/hyperledger/fabric/protos/common"
+	raftprotos "github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Consenter", func() {
+	var (
+		serverCert []byte
+		clientCert []byte
+		metadata   *raftprotos.Metadata
+		support    *consensusmocks.FakeConsenterSupport
+	)
+
+	BeforeEach(func() {
+		ca, err := tlsgen.NewCA()
+		Expect(err).NotTo(HaveOccurred())
+		serverKP, err := ca.NewServerCertKeyPair("raft0.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		clientKP, err := ca.NewClientCertKeyPair()
+		Expect(err).NotTo(HaveOccurred())
+		serverCert, clientCert = serverKP.Cert, clientKP.Cert
+
+		metadata = &raftprotos.Metadata{
+			Consenters: []*raftprotos.Consenter{
+				{Host: "raft0.example.com", Port: 7050, ClientTlsCert: clientCert, ServerTlsCert: serverCert},
+			},
+		}
+		support = &consensusmocks.FakeConsenterSupport{}
+		support.ChainIDReturns("test-chain")
+		support.SharedConfigReturns(&mockconfig.Orderer{
+			BatchTimeoutVal:      time.Hour,
+			ConsensusMetadataVal: utils.MarshalOrPanic(metadata),
+		})
+	})
+
+	Describe("ValidateConsensusMetadata", func() {
+		It("accepts a single consenter", func() {
+			err := etcdraft.New(serverCert).ValidateConsensusMetadata(utils.MarshalOrPanic(metadata))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects bad metadata", func() {
+			consenter := etcdraft.New(serverCert)
+			err := consenter.ValidateConsensusMetadata([]byte("garbage"))
+			Expect(err).To(MatchError(ContainSubstring("failed to unmarshal consensus metadata")))
+
+			m := proto.Clone(metadata).(*raftprotos.Metadata)
+			m.Consenters = append(m.Consenters, m.Consenters[0])
+			err = consenter.ValidateConsensusMetadata(utils.MarshalOrPanic(m))
+			Expect(err).To(MatchError("etcdraft supports a single consenter, got 2"))
+
+			m = proto.Clone(metadata).(*raftprotos.Metadata)
+			m.Consenters[0].Port = 0
+			err = consenter.ValidateConsensusMetadata(utils.MarshalOrPanic(m))
+			Expect(err).To(MatchError("consenter raft0.example.com:0 has no valid endpoint"))
+
+			m = proto.Clone(metadata).(*raftprotos.Metadata)
+			m.Consenters[0].ServerTlsCert = []byte("not a certificate")
+			err = consenter.ValidateConsensusMetadata(utils.MarshalOrPanic(m))
+			Expect(err).To(MatchError("invalid server TLS certificate of consenter: not PEM encoded"))
+		})
+	})
+
+	Describe("HandleChain", func() {
+		It("serves the chain of the channel of which it is the consenter", func() {
+			chain, err := etcdraft.New(serverCert).HandleChain(support, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(chain).To(BeAssignableToTypeOf(&etcdraft.Chain{}))
+		})
+
+		It("serves an inactive chain for the channels of other orderers", func() {
+			chain, err := etcdraft.New(clientCert).HandleChain(support, nil)
+			Expect(err).NotTo(HaveOccurred())
+			chain.Start()
+			defer chain.Halt()
+			Expect(chain.Errored()).To(BeClosed())
+			err = chain.Order(&common.Envelope{}, 0)
+			Expect(err).To(MatchError("orderer is not a consenter of the channel: the TLS server certificate of the orderer does not match any of the 1 consenters"))
+
+			chain, err = etcdraft.New(nil).HandleChain(support, nil)
+			Expect(err).NotTo(HaveOccurred())
+			err = chain.Configure(&common.Envelope{}, 0)
+			Expect(err).To(MatchError("orderer is not a consenter of the channel: TLS must be enabled to identify the orderer among the consenters"))
+		})
+
+		It("fails if the metadata is not valid", func() {
+			metadata.Consenters = nil
+			support.SharedConfigReturns(&mockconfig.Orderer{ConsensusMetadataVal: utils.MarshalOrPanic(metadata)})
+			_, err := etcdraft.New(serverCert).HandleChain(support, nil)
+			Expect(err).To(MatchError("etcdraft supports a single consenter, got 0"))
+		})
+	})
+})
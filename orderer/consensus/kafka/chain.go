@@ -64,10 +64,15 @@ func newChain(
 		close(doneReprocessingMsgInFlight)
 	}
 
+	channel := newChannel(support.ChainID(), defaultPartition)
+	chainMetrics := newChannelMetrics(consenter.metricsScope(), channel, lastOffsetPersisted)
+	chainMetrics.connected(false)
+
 	return &chainImpl{
 		consenter:                   consenter,
 		ConsenterSupport:            support,
-		channel:                     newChannel(support.ChainID(), defaultPartition),
+		channel:                     channel,
+		metrics:                     chainMetrics,
 		lastOffsetPersisted:         lastOffsetPersisted,
 		lastOriginalOffsetProcessed: lastOriginalOffsetProcessed,
 		lastResubmittedConfigOffset: lastResubmittedConfigOffset,
@@ -85,6 +90,7 @@ type chainImpl struct {
 	consensus.ConsenterSupport
 
 	channel                     channel
+	metrics                     *channelMetrics
 	lastOffsetPersisted         int64
 	lastOriginalOffsetProcessed int64
 	lastResubmittedConfigOffset int64
@@ -256,34 +262,34 @@ func startThread(chain *chainImpl) {
 	var err error
 
 	// Create topic if it does not exist (requires Kafka v0.10.1.0)
-	err = setupTopicForChannel(chain.consenter.retryOptions(), chain.haltChan, chain.SharedConfig().KafkaBrokers(), chain.consenter.brokerConfig(), chain.consenter.topicDetail(), chain.channel)
+	err = setupTopicForChannel(chain.consenter.retryOptions(), chain.haltChan, chain.SharedConfig().KafkaBrokers(), chain.consenter.brokerConfig(), chain.consenter.topicDetail(), chain.channel, chain.metrics)
 	if err != nil {
 		// log for now and fallback to auto create topics setting for broker
 		logger.Infof("[channel: %s]: failed to create Kafka topic = %s", chain.channel.topic(), err)
 	}
 
 	// Set up the producer
-	chain.producer, err = setupProducerForChannel(chain.consenter.retryOptions(), chain.haltChan, chain.SharedConfig().KafkaBrokers(), chain.consenter.brokerConfig(), chain.channel)
+	chain.producer, err = setupProducerForChannel(chain.consenter.retryOptions(), chain.haltChan, chain.SharedConfig().KafkaBrokers(), chain.consenter.brokerConfig(), chain.channel, chain.metrics)
 	if err != nil {
 		logger.Panicf("[channel: %s] Cannot set up producer = %s", chain.channel.topic(), err)
 	}
 	logger.Infof("[channel: %s] Producer set up successfully", chain.ChainID())
 
 	// Have the producer post the CONNECT message
-	if err = sendConnectMessage(chain.consenter.retryOptions(), chain.haltChan, chain.producer, chain.channel); err != nil {
+	if err = sendConnectMessage(chain.consenter.retryOptions(), chain.haltChan, chain.producer, chain.channel, chain.metrics); err != nil {
 		logger.Panicf("[channel: %s] Cannot post CONNECT message = %s", chain.channel.topic(), err)
 	}
 	logger.Infof("[channel: %s] CONNECT message posted successfully", chain.channel.topic())
 
 	// Set up the parent consumer
-	chain.parentConsumer, err = setupParentConsumerForChannel(chain.consenter.retryOptions(), chain.haltChan, chain.SharedConfig().KafkaBrokers(), chain.consenter.brokerConfig(), chain.channel)
+	chain.parentConsumer, err = setupParentConsumerForChannel(chain.consenter.retryOptions(), chain.haltChan, chain.SharedConfig().KafkaBrokers(), chain.consenter.brokerConfig(), chain.channel, chain.metrics)
 	if err != nil {
 		logger.Panicf("[channel: %s] Cannot set up parent consumer = %s", chain.channel.topic(), err)
 	}
 	logger.Infof("[channel: %s] Parent consumer set up successfully", chain.channel.topic())
 
 	// Set up the channel consumer
-	chain.channelConsumer, err = setupChannelConsumerForChannel(chain.consenter.retryOptions(), chain.haltChan, chain.parentConsumer, chain.channel, chain.lastOffsetPersisted+1)
+	chain.channelConsumer, err = setupChannelConsumerForChannel(chain.consenter.retryOptions(), chain.haltChan, chain.parentConsumer, chain.channel, chain.lastOffsetPersisted+1, chain.metrics)
 	if err != nil {
 		logger.Panicf("[channel: %s] Cannot set up channel consumer = %s", chain.channel.topic(), err)
 	}
@@ -293,6 +299,7 @@ func startThread(chain *chainImpl) {
 
 	close(chain.startChan)                // Broadcast requests will now go through
 	chain.errorChan = make(chan struct{}) // Deliver requests will also go through
+	chain.metrics.connected(true)
 
 	logger.Infof("[channel: %s] Start phase completed successfully", chain.channel.topic())
 
@@ -317,6 +324,7 @@ func (chain *chainImpl) processMessagesToBlocks() ([]uint64, error) {
 		default:
 			close(chain.errorChan)
 		}
+		chain.metrics.connected(false)
 	}()
 
 	subscription := fmt.Sprintf("added subscription to %s/%d", chain.channel.topic(), chain.channel.partition())
@@ -333,6 +341,7 @@ func (chain *chainImpl) processMessagesToBlocks() ([]uint64, error) {
 		case kafkaErr := <-chain.channelConsumer.Errors():
 			logger.Errorf("[channel: %s] Error during consumption: %s", chain.ChainID(), kafkaErr)
 			counts[indexRecvError]++
+			chain.metrics.consumerErrored()
 			select {
 			case <-chain.errorChan: // If already closed, don't do anything
 			default:
@@ -342,6 +351,7 @@ func (chain *chainImpl) processMessagesToBlocks() ([]uint64, error) {
 					// the kafka consumer will auto retry for all errors except for ErrOffsetOutOfRange
 					logger.Errorf("[channel: %s] Unrecoverable error during consumption: %s", chain.ChainID(), kafkaErr)
 					close(chain.errorChan)
+					chain.metrics.connected(false)
 				default:
 					if topicPartitionSubscriptionResumed == nil {
 						// register listener
@@ -362,7 +372,7 @@ func (chain *chainImpl) processMessagesToBlocks() ([]uint64, error) {
 				// there is no trigger that can recreate the errorChan again and
 				// mark the chain as available, so we have to force that trigger via
 				// the emission of a CONNECT message. TODO Consider rate limiting
-				go sendConnectMessage(chain.consenter.retryOptions(), chain.haltChan, chain.producer, chain.channel)
+				go sendConnectMessage(chain.consenter.retryOptions(), chain.haltChan, chain.producer, chain.channel, chain.metrics)
 			default: // we are ignoring the error
 				logger.Warningf("[channel: %s] Deliver sessions will be dropped if consumption errors continue.", chain.ChainID())
 			}
@@ -385,10 +395,11 @@ func (chain *chainImpl) processMessagesToBlocks() ([]uint64, error) {
 			topicPartitionSubscriptionResumed = nil
 
 			close(chain.errorChan)
+			chain.metrics.connected(false)
 			logger.Warningf("[channel: %s] Closed the errorChan", chain.ChainID())
 
 			// make chain available again via CONNECT message trigger
-			go sendConnectMessage(chain.consenter.retryOptions(), chain.haltChan, chain.producer, chain.channel)
+			go sendConnectMessage(chain.consenter.retryOptions(), chain.haltChan, chain.producer, chain.channel, chain.metrics)
 
 		case in, ok := <-chain.channelConsumer.Messages():
 			if !ok {
@@ -412,9 +423,11 @@ func (chain *chainImpl) processMessagesToBlocks() ([]uint64, error) {
 			select {
 			case <-chain.errorChan: // If this channel was closed...
 				chain.errorChan = make(chan struct{}) // ...make a new one.
+				chain.metrics.connected(true)
 				logger.Infof("[channel: %s] Marked consenter as available again", chain.ChainID())
 			default:
 			}
+			chain.metrics.consumed(chain.channelConsumer.HighWaterMarkOffset())
 			if err := proto.Unmarshal(in.Value, msg); err != nil {
 				// This shouldn't happen, it should be filtered at ingress
 				logger.Criticalf("[channel: %s] Unable to unmarshal consumed message = %s", chain.ChainID(), err)
@@ -429,7 +442,7 @@ func (chain *chainImpl) processMessagesToBlocks() ([]uint64, error) {
 				_ = chain.processConnect(chain.ChainID())
 				counts[indexProcessConnectPass]++
 			case *ab.KafkaMessage_TimeToCut:
-				if err := chain.processTimeToCut(msg.GetTimeToCut(), in.Offset); err != nil {
+				if err := chain.processTimeToCut(msg.GetTimeToCut(), in.Offset, in.Timestamp); err != nil {
 					logger.Warningf("[channel: %s] %s", chain.ChainID(), err)
 					logger.Criticalf("[channel: %s] Consenter for channel exiting", chain.ChainID())
 					counts[indexProcessTimeToCutError]++
@@ -437,7 +450,7 @@ func (chain *chainImpl) processMessagesToBlocks() ([]uint64, error) {
 				}
 				counts[indexProcessTimeToCutPass]++
 			case *ab.KafkaMessage_Regular:
-				if err := chain.processRegular(msg.GetRegular(), in.Offset); err != nil {
+				if err := chain.processRegular(msg.GetRegular(), in.Offset, in.Timestamp); err != nil {
 					logger.Warningf("[channel: %s] Error when processing incoming message of type REGULAR = %s", chain.ChainID(), err)
 					counts[indexProcessRegularError]++
 				} else {
@@ -566,7 +579,7 @@ func (chain *chainImpl) processConnect(channelName string) error {
 	return nil
 }
 
-func (chain *chainImpl) processRegular(regularMessage *ab.KafkaMessageRegular, receivedOffset int64) error {
+func (chain *chainImpl) processRegular(regularMessage *ab.KafkaMessageRegular, receivedOffset int64, receivedTime time.Time) error {
 	// When committing a normal message, we also update `lastOriginalOffsetProcessed` with `newOffset`.
 	// It is caller's responsibility to deduce correct value of `newOffset` based on following rules:
 	// - if Resubmission is switched off, it should always be zero
@@ -614,7 +627,7 @@ func (chain *chainImpl) processRegular(regularMessage *ab.KafkaMessageRegular, r
 		}
 
 		// Commit the first block
-		block := chain.CreateNextBlock(batches[0])
+		block := chain.createNextBlock(batches[0], receivedTime)
 		metadata := utils.MarshalOrPanic(&ab.KafkaMetadata{
 			LastOffsetPersisted:         offset,
 			LastOriginalOffsetProcessed: chain.lastOriginalOffsetProcessed,
@@ -622,6 +635,7 @@ func (chain *chainImpl) processRegular(regularMessage *ab.KafkaMessageRegular, r
 		})
 		chain.WriteBlock(block, metadata)
 		chain.lastCutBlockNumber++
+		chain.metrics.persisted(offset)
 		logger.Debugf("[channel: %s] Batch filled, just cut block %d - last persisted offset is now %d", chain.ChainID(), chain.lastCutBlockNumber, offset)
 
 		// Commit the second block if exists
@@ -629,7 +643,7 @@ func (chain *chainImpl) processRegular(regularMessage *ab.KafkaMessageRegular, r
 			chain.lastOriginalOffsetProcessed = newOffset
 			offset++
 
-			block := chain.CreateNextBlock(batches[1])
+			block := chain.createNextBlock(batches[1], receivedTime)
 			metadata := utils.MarshalOrPanic(&ab.KafkaMetadata{
 				LastOffsetPersisted:         offset,
 				LastOriginalOffsetProcessed: newOffset,
@@ -637,6 +651,7 @@ func (chain *chainImpl) processRegular(regularMessage *ab.KafkaMessageRegular, r
 			})
 			chain.WriteBlock(block, metadata)
 			chain.lastCutBlockNumber++
+			chain.metrics.persisted(offset)
 			logger.Debugf("[channel: %s] Batch filled, just cut block %d - last persisted offset is now %d", chain.ChainID(), chain.lastCutBlockNumber, offset)
 		}
 	}
@@ -654,7 +669,7 @@ func (chain *chainImpl) processRegular(regularMessage *ab.KafkaMessageRegular, r
 
 		if batch != nil {
 			logger.Debugf("[channel: %s] Cut pending messages into block", chain.ChainID())
-			block := chain.CreateNextBlock(batch)
+			block := chain.createNextBlock(batch, receivedTime)
 			metadata := utils.MarshalOrPanic(&ab.KafkaMetadata{
 				LastOffsetPersisted:         receivedOffset - 1,
 				LastOriginalOffsetProcessed: chain.lastOriginalOffsetProcessed,
@@ -662,11 +677,12 @@ func (chain *chainImpl) processRegular(regularMessage *ab.KafkaMessageRegular, r
 			})
 			chain.WriteBlock(block, metadata)
 			chain.lastCutBlockNumber++
+			chain.metrics.persisted(receivedOffset - 1)
 		}
 
 		logger.Debugf("[channel: %s] Creating isolated block for config message", chain.ChainID())
 		chain.lastOriginalOffsetProcessed = newOffset
-		block := chain.CreateNextBlock([]*cb.Envelope{message})
+		block := chain.createNextBlock([]*cb.Envelope{message}, receivedTime)
 		metadata := utils.MarshalOrPanic(&ab.KafkaMetadata{
 			LastOffsetPersisted:         receivedOffset,
 			LastOriginalOffsetProcessed: chain.lastOriginalOffsetProcessed,
@@ -674,6 +690,7 @@ func (chain *chainImpl) processRegular(regularMessage *ab.KafkaMessageRegular, r
 		})
 		chain.WriteConfigBlock(block, metadata)
 		chain.lastCutBlockNumber++
+		chain.metrics.persisted(receivedOffset)
 		chain.timer = nil
 	}
 
@@ -858,7 +875,19 @@ func (chain *chainImpl) processRegular(regularMessage *ab.KafkaMessageRegular, r
 	return nil
 }
 
-func (chain *chainImpl) processTimeToCut(ttcMessage *ab.KafkaMessageTimeToCut, receivedOffset int64) error {
+// createNextBlock creates the next block, stamped with the time at which the
+// Kafka message leading to it was produced, so that all the orderers give the
+// block the same time. The block gets no time if the Kafka message has none,
+// as with Kafka versions prior to 0.10.
+func (chain *chainImpl) createNextBlock(batch []*cb.Envelope, receivedTime time.Time) *cb.Block {
+	block := chain.CreateNextBlock(batch)
+	if err := utils.SetBlockTime(block, receivedTime); err != nil {
+		logger.Panicf("[channel: %s] Could not set block time: %s", chain.ChainID(), err)
+	}
+	return block
+}
+
+func (chain *chainImpl) processTimeToCut(ttcMessage *ab.KafkaMessageTimeToCut, receivedOffset int64, receivedTime time.Time) error {
 	ttcNumber := ttcMessage.GetBlockNumber()
 	logger.Debugf("[channel: %s] It's a time-to-cut message for block %d", chain.ChainID(), ttcNumber)
 	if ttcNumber == chain.lastCutBlockNumber+1 {
@@ -869,13 +898,14 @@ func (chain *chainImpl) processTimeToCut(ttcMessage *ab.KafkaMessageTimeToCut, r
 			return fmt.Errorf("got right time-to-cut message (for block %d),"+
 				" no pending requests though; this might indicate a bug", chain.lastCutBlockNumber+1)
 		}
-		block := chain.CreateNextBlock(batch)
+		block := chain.createNextBlock(batch, receivedTime)
 		metadata := utils.MarshalOrPanic(&ab.KafkaMetadata{
 			LastOffsetPersisted:         receivedOffset,
 			LastOriginalOffsetProcessed: chain.lastOriginalOffsetProcessed,
 		})
 		chain.WriteBlock(block, metadata)
 		chain.lastCutBlockNumber++
+		chain.metrics.persisted(receivedOffset)
 		logger.Debugf("[channel: %s] Proper time-to-cut received, just cut block %d", chain.ChainID(), chain.lastCutBlockNumber)
 		return nil
 	} else if ttcNumber > chain.lastCutBlockNumber+1 {
@@ -889,14 +919,14 @@ func (chain *chainImpl) processTimeToCut(ttcMessage *ab.KafkaMessageTimeToCut, r
 // Post a CONNECT message to the channel using the given retry options. This
 // prevents the panicking that would occur if we were to set up a consumer and
 // seek on a partition that hadn't been written to yet.
-func sendConnectMessage(retryOptions localconfig.Retry, exitChan chan struct{}, producer sarama.SyncProducer, channel channel) error {
+func sendConnectMessage(retryOptions localconfig.Retry, exitChan chan struct{}, producer sarama.SyncProducer, channel channel, metrics *channelMetrics) error {
 	logger.Infof("[channel: %s] About to post the CONNECT message...", channel.topic())
 
 	payload := utils.MarshalOrPanic(newConnectMessage())
 	message := newProducerMessage(channel, payload)
 
 	retryMsg := "Attempting to post the CONNECT message..."
-	postConnect := newRetryProcess(retryOptions, exitChan, channel, retryMsg, func() error {
+	postConnect := newRetryProcess(retryOptions, exitChan, channel, metrics, postConnectOperation, retryMsg, func() error {
 		select {
 		case <-exitChan:
 			logger.Debugf("[channel: %s] Consenter for channel exiting, aborting retry", channel)
@@ -920,14 +950,14 @@ func sendTimeToCut(producer sarama.SyncProducer, channel channel, timeToCutBlock
 }
 
 // Sets up the partition consumer for a channel using the given retry options.
-func setupChannelConsumerForChannel(retryOptions localconfig.Retry, haltChan chan struct{}, parentConsumer sarama.Consumer, channel channel, startFrom int64) (sarama.PartitionConsumer, error) {
+func setupChannelConsumerForChannel(retryOptions localconfig.Retry, haltChan chan struct{}, parentConsumer sarama.Consumer, channel channel, startFrom int64, metrics *channelMetrics) (sarama.PartitionConsumer, error) {
 	var err error
 	var channelConsumer sarama.PartitionConsumer
 
 	logger.Infof("[channel: %s] Setting up the channel consumer for this channel (start offset: %d)...", channel.topic(), startFrom)
 
 	retryMsg := "Connecting to the Kafka cluster"
-	setupChannelConsumer := newRetryProcess(retryOptions, haltChan, channel, retryMsg, func() error {
+	setupChannelConsumer := newRetryProcess(retryOptions, haltChan, channel, metrics, setupChannelConsumerOperation, retryMsg, func() error {
 		channelConsumer, err = parentConsumer.ConsumePartition(channel.topic(), channel.partition(), startFrom)
 		return err
 	})
@@ -936,14 +966,14 @@ func setupChannelConsumerForChannel(retryOptions localconfig.Retry, haltChan cha
 }
 
 // Sets up the parent consumer for a channel using the given retry options.
-func setupParentConsumerForChannel(retryOptions localconfig.Retry, haltChan chan struct{}, brokers []string, brokerConfig *sarama.Config, channel channel) (sarama.Consumer, error) {
+func setupParentConsumerForChannel(retryOptions localconfig.Retry, haltChan chan struct{}, brokers []string, brokerConfig *sarama.Config, channel channel, metrics *channelMetrics) (sarama.Consumer, error) {
 	var err error
 	var parentConsumer sarama.Consumer
 
 	logger.Infof("[channel: %s] Setting up the parent consumer for this channel...", channel.topic())
 
 	retryMsg := "Connecting to the Kafka cluster"
-	setupParentConsumer := newRetryProcess(retryOptions, haltChan, channel, retryMsg, func() error {
+	setupParentConsumer := newRetryProcess(retryOptions, haltChan, channel, metrics, setupParentConsumerOperation, retryMsg, func() error {
 		parentConsumer, err = sarama.NewConsumer(brokers, brokerConfig)
 		return err
 	})
@@ -952,14 +982,14 @@ func setupParentConsumerForChannel(retryOptions localconfig.Retry, haltChan chan
 }
 
 // Sets up the writer/producer for a channel using the given retry options.
-func setupProducerForChannel(retryOptions localconfig.Retry, haltChan chan struct{}, brokers []string, brokerConfig *sarama.Config, channel channel) (sarama.SyncProducer, error) {
+func setupProducerForChannel(retryOptions localconfig.Retry, haltChan chan struct{}, brokers []string, brokerConfig *sarama.Config, channel channel, metrics *channelMetrics) (sarama.SyncProducer, error) {
 	var err error
 	var producer sarama.SyncProducer
 
 	logger.Infof("[channel: %s] Setting up the producer for this channel...", channel.topic())
 
 	retryMsg := "Connecting to the Kafka cluster"
-	setupProducer := newRetryProcess(retryOptions, haltChan, channel, retryMsg, func() error {
+	setupProducer := newRetryProcess(retryOptions, haltChan, channel, metrics, setupProducerOperation, retryMsg, func() error {
 		producer, err = sarama.NewSyncProducer(brokers, brokerConfig)
 		return err
 	})
@@ -968,7 +998,7 @@ func setupProducerForChannel(retryOptions localconfig.Retry, haltChan chan struc
 }
 
 // Creates the Kafka topic for the channel if it does not already exist
-func setupTopicForChannel(retryOptions localconfig.Retry, haltChan chan struct{}, brokers []string, brokerConfig *sarama.Config, topicDetail *sarama.TopicDetail, channel channel) error {
+func setupTopicForChannel(retryOptions localconfig.Retry, haltChan chan struct{}, brokers []string, brokerConfig *sarama.Config, topicDetail *sarama.TopicDetail, channel channel, metrics *channelMetrics) error {
 
 	// requires Kafka v0.10.1.0 or higher
 	if !brokerConfig.Version.IsAtLeast(sarama.V0_10_1_0) {
@@ -985,6 +1015,8 @@ func setupTopicForChannel(retryOptions localconfig.Retry, haltChan chan struct{}
 		retryOptions,
 		haltChan,
 		channel,
+		metrics,
+		createTopicOperation,
 		retryMsg,
 		func() error {
 
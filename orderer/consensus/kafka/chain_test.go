@@ -16,6 +16,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	mockmetrics "github.com/hyperledger/fabric/common/mocks/metrics"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	mockblockcutter "github.com/hyperledger/fabric/orderer/mocks/common/blockcutter"
@@ -591,7 +592,8 @@ func TestSetupTopicForChannel(t *testing.T) {
 				&sarama.TopicDetail{
 					NumPartitions:     1,
 					ReplicationFactor: 2},
-				mockChannel)
+				mockChannel,
+				nil)
 			if test.expectErr {
 				assert.Contains(t, err.Error(), test.errorMsg)
 			} else {
@@ -620,13 +622,13 @@ func TestSetupProducerForChannel(t *testing.T) {
 		metadataResponse.AddTopicPartition(mockChannel.topic(), mockChannel.partition(), mockBroker.BrokerID(), nil, nil, sarama.ErrNoError)
 		mockBroker.Returns(metadataResponse)
 
-		producer, err := setupProducerForChannel(mockConsenter.retryOptions(), haltChan, []string{mockBroker.Addr()}, mockBrokerConfig, mockChannel)
+		producer, err := setupProducerForChannel(mockConsenter.retryOptions(), haltChan, []string{mockBroker.Addr()}, mockBrokerConfig, mockChannel, nil)
 		assert.NoError(t, err, "Expected the setupProducerForChannel call to return without errors")
 		assert.NoError(t, producer.Close(), "Expected to close the producer without errors")
 	})
 
 	t.Run("WithError", func(t *testing.T) {
-		_, err := setupProducerForChannel(mockConsenter.retryOptions(), haltChan, []string{}, mockBrokerConfig, mockChannel)
+		_, err := setupProducerForChannel(mockConsenter.retryOptions(), haltChan, []string{}, mockBrokerConfig, mockChannel, nil)
 		assert.Error(t, err, "Expected the setupProducerForChannel call to return an error")
 	})
 }
@@ -657,29 +659,29 @@ func TestSetupConsumerForChannel(t *testing.T) {
 	haltChan := make(chan struct{})
 
 	t.Run("ProperParent", func(t *testing.T) {
-		parentConsumer, err := setupParentConsumerForChannel(mockConsenter.retryOptions(), haltChan, []string{mockBroker.Addr()}, mockBrokerConfig, mockChannel)
+		parentConsumer, err := setupParentConsumerForChannel(mockConsenter.retryOptions(), haltChan, []string{mockBroker.Addr()}, mockBrokerConfig, mockChannel, nil)
 		assert.NoError(t, err, "Expected the setupParentConsumerForChannel call to return without errors")
 		assert.NoError(t, parentConsumer.Close(), "Expected to close the parentConsumer without errors")
 	})
 
 	t.Run("ProperChannel", func(t *testing.T) {
-		parentConsumer, _ := setupParentConsumerForChannel(mockConsenter.retryOptions(), haltChan, []string{mockBroker.Addr()}, mockBrokerConfig, mockChannel)
+		parentConsumer, _ := setupParentConsumerForChannel(mockConsenter.retryOptions(), haltChan, []string{mockBroker.Addr()}, mockBrokerConfig, mockChannel, nil)
 		defer func() { parentConsumer.Close() }()
-		channelConsumer, err := setupChannelConsumerForChannel(mockConsenter.retryOptions(), haltChan, parentConsumer, mockChannel, newestOffset)
+		channelConsumer, err := setupChannelConsumerForChannel(mockConsenter.retryOptions(), haltChan, parentConsumer, mockChannel, newestOffset, nil)
 		assert.NoError(t, err, "Expected the setupChannelConsumerForChannel call to return without errors")
 		assert.NoError(t, channelConsumer.Close(), "Expected to close the channelConsumer without errors")
 	})
 
 	t.Run("WithParentConsumerError", func(t *testing.T) {
 		// Provide an empty brokers list
-		_, err := setupParentConsumerForChannel(mockConsenter.retryOptions(), haltChan, []string{}, mockBrokerConfig, mockChannel)
+		_, err := setupParentConsumerForChannel(mockConsenter.retryOptions(), haltChan, []string{}, mockBrokerConfig, mockChannel, nil)
 		assert.Error(t, err, "Expected the setupParentConsumerForChannel call to return an error")
 	})
 
 	t.Run("WithChannelConsumerError", func(t *testing.T) {
 		// Provide an out-of-range offset
-		parentConsumer, _ := setupParentConsumerForChannel(mockConsenter.retryOptions(), haltChan, []string{mockBroker.Addr()}, mockBrokerConfig, mockChannel)
-		_, err := setupChannelConsumerForChannel(mockConsenter.retryOptions(), haltChan, parentConsumer, mockChannel, newestOffset+1)
+		parentConsumer, _ := setupParentConsumerForChannel(mockConsenter.retryOptions(), haltChan, []string{mockBroker.Addr()}, mockBrokerConfig, mockChannel, nil)
+		_, err := setupChannelConsumerForChannel(mockConsenter.retryOptions(), haltChan, parentConsumer, mockChannel, newestOffset+1, nil)
 		defer func() { parentConsumer.Close() }()
 		assert.Error(t, err, "Expected the setupChannelConsumerForChannel call to return an error")
 	})
@@ -715,9 +717,9 @@ func TestCloseKafkaObjects(t *testing.T) {
 	haltChan := make(chan struct{})
 
 	t.Run("Proper", func(t *testing.T) {
-		producer, _ := setupProducerForChannel(mockConsenter.retryOptions(), haltChan, []string{mockBroker.Addr()}, mockBrokerConfig, mockChannel)
-		parentConsumer, _ := setupParentConsumerForChannel(mockConsenter.retryOptions(), haltChan, []string{mockBroker.Addr()}, mockBrokerConfig, mockChannel)
-		channelConsumer, _ := setupChannelConsumerForChannel(mockConsenter.retryOptions(), haltChan, parentConsumer, mockChannel, startFrom)
+		producer, _ := setupProducerForChannel(mockConsenter.retryOptions(), haltChan, []string{mockBroker.Addr()}, mockBrokerConfig, mockChannel, nil)
+		parentConsumer, _ := setupParentConsumerForChannel(mockConsenter.retryOptions(), haltChan, []string{mockBroker.Addr()}, mockBrokerConfig, mockChannel, nil)
+		channelConsumer, _ := setupChannelConsumerForChannel(mockConsenter.retryOptions(), haltChan, parentConsumer, mockChannel, startFrom, nil)
 
 		// Set up a chain with just the minimum necessary fields instantiated so
 		// as to test the function
@@ -855,7 +857,7 @@ func TestSendConnectMessage(t *testing.T) {
 		successResponse.AddTopicPartition(mockChannel.topic(), mockChannel.partition(), sarama.ErrNoError)
 		mockBroker.Returns(successResponse)
 
-		assert.NoError(t, sendConnectMessage(mockConsenter.retryOptions(), haltChan, producer, mockChannel), "Expected the sendConnectMessage call to return without errors")
+		assert.NoError(t, sendConnectMessage(mockConsenter.retryOptions(), haltChan, producer, mockChannel, nil), "Expected the sendConnectMessage call to return without errors")
 	})
 
 	t.Run("WithError", func(t *testing.T) {
@@ -869,7 +871,7 @@ func TestSendConnectMessage(t *testing.T) {
 		failureResponse.AddTopicPartition(mockChannel.topic(), mockChannel.partition(), sarama.ErrNotEnoughReplicas)
 		mockBroker.Returns(failureResponse)
 
-		assert.Error(t, sendConnectMessage(mockConsenter.retryOptions(), haltChan, producer, mockChannel), "Expected the sendConnectMessage call to return an error")
+		assert.Error(t, sendConnectMessage(mockConsenter.retryOptions(), haltChan, producer, mockChannel, nil), "Expected the sendConnectMessage call to return an error")
 	})
 }
 
@@ -918,6 +920,31 @@ func TestSendTimeToCut(t *testing.T) {
 	})
 }
 
+func TestCreateNextBlock(t *testing.T) {
+	chain := &chainImpl{
+		ConsenterSupport: &mockmultichannel.ConsenterSupport{},
+	}
+	batch := []*cb.Envelope{{Payload: []byte("foo")}}
+
+	t.Run("WithMessageTime", func(t *testing.T) {
+		receivedTime := time.Date(2018, time.October, 1, 12, 0, 0, 0, time.UTC)
+		block := chain.createNextBlock(batch, receivedTime)
+
+		blockTime, ok, err := utils.GetBlockTime(block)
+		assert.NoError(t, err)
+		assert.True(t, ok, "Expected the block to record the time of the Kafka message")
+		assert.True(t, receivedTime.Equal(blockTime), "Expected the block time to be the time of the Kafka message")
+	})
+
+	t.Run("WithoutMessageTime", func(t *testing.T) {
+		block := chain.createNextBlock(batch, time.Time{})
+
+		_, ok, err := utils.GetBlockTime(block)
+		assert.NoError(t, err)
+		assert.False(t, ok, "Expected the block to record no time when the Kafka message has none")
+	})
+}
+
 func TestProcessMessagesToBlocks(t *testing.T) {
 	mockBroker := sarama.NewMockBroker(t, 0)
 	defer func() { mockBroker.Close() }()
@@ -1388,11 +1415,13 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 				}
 				defer close(mockSupport.BlockCutterVal.Block)
 
+				scope := mockmetrics.NewMockScope()
 				bareMinimumChain := &chainImpl{
 					parentConsumer:  mockParentConsumer,
 					channelConsumer: mockChannelConsumer,
 
 					channel:            mockChannel,
+					metrics:            newChannelMetrics(scope, mockChannel, 0),
 					ConsenterSupport:   mockSupport,
 					lastCutBlockNumber: lastCutBlockNumber,
 
@@ -1426,6 +1455,12 @@ func TestProcessMessagesToBlocks(t *testing.T) {
 				assert.Equal(t, uint64(1), counts[indexRecvPass], "Expected 1 message received and unmarshaled")
 				assert.Equal(t, uint64(1), counts[indexProcessRegularPass], "Expected 1 REGULAR message processed")
 				assert.Equal(t, lastCutBlockNumber+1, bareMinimumChain.lastCutBlockNumber, "Expected lastCutBlockNumber to be bumped up by one")
+				persisted, _ := scope.GaugeValue(lastOffsetPersistedGauge, metricTags(mockChannel))
+				assert.Equal(t, float64(mpc.HighWaterMarkOffset()-1), persisted, "Expected the offset of the message to be persisted")
+				lag, _ := scope.GaugeValue(offsetLagGauge, metricTags(mockChannel))
+				assert.Equal(t, float64(0), lag, "Expected no lag once the message is persisted")
+				connected, _ := scope.GaugeValue(connectedGauge, metricTags(mockChannel))
+				assert.Equal(t, float64(0), connected, "Expected the chain to be disconnected once halted")
 			})
 
 			// This test ensures the corner case in FAB-5709 is taken care of
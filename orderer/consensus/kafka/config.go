@@ -12,6 +12,7 @@ import (
 
 	"github.com/Shopify/sarama"
 	localconfig "github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/pkg/errors"
 )
 
 func newBrokerConfig(
@@ -87,3 +88,36 @@ func newBrokerConfig(
 
 	return brokerConfig
 }
+
+// CheckBroker connects to the Kafka broker at the given address with the TLS
+// and SASL settings of the orderer, and fetches the metadata of its cluster.
+// Starting with Kafka 0.10, it also checks that the cluster has a controller,
+// which the brokers elect through ZooKeeper.
+func CheckBroker(config localconfig.Kafka, address string) (err error) {
+	// The broker config panics on invalid TLS material, surface that as an error instead.
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("invalid Kafka settings: %v", r)
+		}
+	}()
+	brokerConfig := newBrokerConfig(config.TLS, config.SASLPlain, config.Retry, config.Version, defaultPartition)
+
+	broker := sarama.NewBroker(address)
+	if err := broker.Open(brokerConfig); err != nil {
+		return errors.Wrapf(err, "failed connecting to %s", address)
+	}
+	defer broker.Close()
+
+	request := &sarama.MetadataRequest{Topics: []string{}}
+	if config.Version.IsAtLeast(sarama.V0_10_0_0) {
+		request.Version = 1
+	}
+	response, err := broker.GetMetadata(request)
+	if err != nil {
+		return errors.Wrapf(err, "failed fetching metadata from %s", address)
+	}
+	if request.Version >= 1 && response.ControllerID < 0 {
+		return errors.Errorf("the Kafka cluster of %s has no controller, its brokers may not reach ZooKeeper", address)
+	}
+	return nil
+}
@@ -167,3 +167,52 @@ func TestBrokerConfigTLSConfigBadCert(t *testing.T) {
 		})
 	})
 }
+
+func TestCheckBroker(t *testing.T) {
+	config := mockLocalConfig.Kafka
+	config.Version = sarama.V0_10_2_0
+
+	t.Run("Reachable", func(t *testing.T) {
+		mockBroker := sarama.NewMockBroker(t, 1)
+		defer mockBroker.Close()
+		mockBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+			"MetadataRequest": sarama.NewMockMetadataResponse(t).
+				SetBroker(mockBroker.Addr(), mockBroker.BrokerID()).
+				SetController(mockBroker.BrokerID()),
+		})
+
+		assert.NoError(t, CheckBroker(config, mockBroker.Addr()))
+	})
+
+	t.Run("NoController", func(t *testing.T) {
+		mockBroker := sarama.NewMockBroker(t, 1)
+		defer mockBroker.Close()
+		mockBroker.SetHandlerByMap(map[string]sarama.MockResponse{
+			"MetadataRequest": sarama.NewMockMetadataResponse(t).
+				SetBroker(mockBroker.Addr(), mockBroker.BrokerID()).
+				SetController(-1),
+		})
+
+		err := CheckBroker(config, mockBroker.Addr())
+		assert.EqualError(t, err, "the Kafka cluster of "+mockBroker.Addr()+" has no controller, its brokers may not reach ZooKeeper")
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		mockBroker := sarama.NewMockBroker(t, 1)
+		addr := mockBroker.Addr()
+		mockBroker.Close()
+
+		err := CheckBroker(config, addr)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed fetching metadata from "+addr)
+	})
+
+	t.Run("BadTLS", func(t *testing.T) {
+		badConfig := config
+		badConfig.TLS = localconfig.TLS{Enabled: true, PrivateKey: "TRASH", Certificate: "TRASH"}
+
+		err := CheckBroker(badConfig, "127.0.0.1:9092")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid Kafka settings")
+	})
+}
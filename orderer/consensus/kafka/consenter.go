@@ -8,6 +8,7 @@ package kafka
 
 import (
 	"github.com/Shopify/sarama"
+	"github.com/hyperledger/fabric/common/metrics"
 	localconfig "github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -34,6 +35,7 @@ func New(config localconfig.Kafka) consensus.Consenter {
 			NumPartitions:     1,
 			ReplicationFactor: config.Topic.ReplicationFactor,
 		},
+		metricsScopeVal: metrics.SubScope("orderer").SubScope("kafka"),
 	}
 }
 
@@ -46,6 +48,7 @@ type consenterImpl struct {
 	retryOptionsVal localconfig.Retry
 	kafkaVersionVal sarama.KafkaVersion
 	topicDetailVal  *sarama.TopicDetail
+	metricsScopeVal metrics.Scope
 }
 
 // HandleChain creates/returns a reference to a consensus.Chain object for the
@@ -58,6 +61,13 @@ func (consenter *consenterImpl) HandleChain(support consensus.ConsenterSupport,
 	return newChain(consenter, support, lastOffsetPersisted, lastOriginalOffsetProcessed, lastResubmittedConfigOffset)
 }
 
+// Evictable returns true, as a Kafka chain resumes consuming its partition
+// from the offsets recorded in the metadata of the last block of its ledger.
+// Implements the consensus.EvictableConsenter interface.
+func (consenter *consenterImpl) Evictable() bool {
+	return true
+}
+
 // commonConsenter allows us to retrieve the configuration options set on the
 // consenter object. These will be common across all chain objects derived by
 // this consenter. They are set using using local configuration settings. This
@@ -66,6 +76,7 @@ type commonConsenter interface {
 	brokerConfig() *sarama.Config
 	retryOptions() localconfig.Retry
 	topicDetail() *sarama.TopicDetail
+	metricsScope() metrics.Scope
 }
 
 func (consenter *consenterImpl) brokerConfig() *sarama.Config {
@@ -80,6 +91,10 @@ func (consenter *consenterImpl) topicDetail() *sarama.TopicDetail {
 	return consenter.topicDetailVal
 }
 
+func (consenter *consenterImpl) metricsScope() metrics.Scope {
+	return consenter.metricsScopeVal
+}
+
 // closeable allows the shut down of the calling resource.
 type closeable interface {
 	close() error
@@ -16,6 +16,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	mockmetrics "github.com/hyperledger/fabric/common/mocks/metrics"
 	localconfig "github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	mockmultichannel "github.com/hyperledger/fabric/orderer/mocks/common/multichannel"
@@ -72,6 +73,12 @@ func TestNew(t *testing.T) {
 	_ = consensus.Consenter(New(mockLocalConfig.Kafka))
 }
 
+func TestEvictable(t *testing.T) {
+	consenter, ok := New(mockLocalConfig.Kafka).(consensus.EvictableConsenter)
+	assert.True(t, ok, "Should be an evictable consenter")
+	assert.True(t, consenter.Evictable())
+}
+
 func TestHandleChain(t *testing.T) {
 	consenter := consensus.Consenter(New(mockLocalConfig.Kafka))
 
@@ -145,6 +152,7 @@ func newMockConsenter(brokerConfig *sarama.Config, tlsConfig localconfig.TLS, re
 		tlsConfigVal:    tlsConfig,
 		retryOptionsVal: retryOptions,
 		kafkaVersionVal: kafkaVersion,
+		metricsScopeVal: mockmetrics.NewMockScope(),
 	}
 }
 
@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kafka
+
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+// Names of the metrics of the Kafka-based consenter, tagged by channel
+const (
+	// retriesCounter counts the retries of the operations against the Kafka
+	// cluster, additionally tagged by operation
+	retriesCounter = "retries"
+	// consumerErrorsCounter counts the errors returned by the partition
+	// consumer, which retries on its own
+	consumerErrorsCounter = "consumer_errors"
+	// lastOffsetPersistedGauge is the offset of the last message written
+	// into a block
+	lastOffsetPersistedGauge = "last_offset_persisted"
+	// highWaterMarkGauge is the offset of the next message to be produced
+	// to the partition of the channel
+	highWaterMarkGauge = "high_water_mark"
+	// offsetLagGauge is the number of messages of the partition which are not
+	// written into a block yet
+	offsetLagGauge = "offset_lag"
+	// connectedGauge is 1 when the chain consumes from its partition, and 0
+	// otherwise
+	connectedGauge = "connected"
+)
+
+// Operations whose retries are counted
+const (
+	createTopicOperation          = "create_topic"
+	setupProducerOperation        = "setup_producer"
+	postConnectOperation          = "post_connect"
+	setupParentConsumerOperation  = "setup_parent_consumer"
+	setupChannelConsumerOperation = "setup_channel_consumer"
+)
+
+// channelMetrics reports the state of the Kafka partition of a channel. Its
+// methods do nothing on a nil channelMetrics.
+type channelMetrics struct {
+	scope metrics.Scope
+
+	lastOffsetPersisted int64
+	highWaterMark       int64
+}
+
+func newChannelMetrics(scope metrics.Scope, channel channel, lastOffsetPersisted int64) *channelMetrics {
+	return &channelMetrics{
+		scope:               scope.Tagged(map[string]string{"channel": channel.topic()}),
+		lastOffsetPersisted: lastOffsetPersisted,
+	}
+}
+
+// retried counts a retry of the given operation
+func (m *channelMetrics) retried(operation string) {
+	if m == nil {
+		return
+	}
+	m.scope.Tagged(map[string]string{"operation": operation}).Counter(retriesCounter).Inc(1)
+}
+
+// consumerErrored counts an error of the partition consumer
+func (m *channelMetrics) consumerErrored() {
+	if m == nil {
+		return
+	}
+	m.scope.Counter(consumerErrorsCounter).Inc(1)
+}
+
+// persisted reports the offset of the last message written into a block
+func (m *channelMetrics) persisted(offset int64) {
+	if m == nil {
+		return
+	}
+	m.lastOffsetPersisted = offset
+	m.scope.Gauge(lastOffsetPersistedGauge).Update(float64(offset))
+	m.reportLag()
+}
+
+// consumed reports the high water mark of the partition once a message is
+// consumed from it
+func (m *channelMetrics) consumed(highWaterMark int64) {
+	if m == nil {
+		return
+	}
+	m.highWaterMark = highWaterMark
+	m.scope.Gauge(highWaterMarkGauge).Update(float64(highWaterMark))
+	m.reportLag()
+}
+
+func (m *channelMetrics) reportLag() {
+	if m.highWaterMark == 0 {
+		return
+	}
+	lag := m.highWaterMark - m.lastOffsetPersisted - 1
+	if lag < 0 {
+		lag = 0
+	}
+	m.scope.Gauge(offsetLagGauge).Update(float64(lag))
+}
+
+// connected reports whether the chain consumes from its partition
+func (m *channelMetrics) connected(connected bool) {
+	if m == nil {
+		return
+	}
+	value := 0.0
+	if connected {
+		value = 1
+	}
+	m.scope.Gauge(connectedGauge).Update(value)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kafka
+
+import (
+	"testing"
+
+	mockmetrics "github.com/hyperledger/fabric/common/mocks/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+// metricTags returns the tags of the metrics of the channel, and of the
+// operation if any
+func metricTags(channel channel, operation ...string) map[string]string {
+	tags := map[string]string{"channel": channel.topic()}
+	for _, op := range operation {
+		tags["operation"] = op
+	}
+	return tags
+}
+
+func TestChannelMetrics(t *testing.T) {
+	mockChannel := newChannel(channelNameForTest(t), defaultPartition)
+	tags := metricTags(mockChannel)
+
+	t.Run("Offsets", func(t *testing.T) {
+		scope := mockmetrics.NewMockScope()
+		m := newChannelMetrics(scope, mockChannel, 4)
+
+		// The lag is unknown until a message is consumed
+		m.persisted(5)
+		_, ok := scope.GaugeValue(offsetLagGauge, tags)
+		assert.False(t, ok)
+
+		m.consumed(10)
+		m.persisted(7)
+		persisted, _ := scope.GaugeValue(lastOffsetPersistedGauge, tags)
+		assert.Equal(t, float64(7), persisted)
+		highWaterMark, _ := scope.GaugeValue(highWaterMarkGauge, tags)
+		assert.Equal(t, float64(10), highWaterMark)
+		lag, _ := scope.GaugeValue(offsetLagGauge, tags)
+		assert.Equal(t, float64(2), lag)
+
+		m.persisted(9)
+		lag, _ = scope.GaugeValue(offsetLagGauge, tags)
+		assert.Equal(t, float64(0), lag)
+	})
+
+	t.Run("Counters", func(t *testing.T) {
+		scope := mockmetrics.NewMockScope()
+		m := newChannelMetrics(scope, mockChannel, 0)
+
+		m.retried(setupProducerOperation)
+		m.retried(setupProducerOperation)
+		m.retried(postConnectOperation)
+		m.consumerErrored()
+		assert.Equal(t, int64(2), scope.CounterValue(retriesCounter, metricTags(mockChannel, setupProducerOperation)))
+		assert.Equal(t, int64(1), scope.CounterValue(retriesCounter, metricTags(mockChannel, postConnectOperation)))
+		assert.Equal(t, int64(1), scope.CounterValue(consumerErrorsCounter, tags))
+	})
+
+	t.Run("Connected", func(t *testing.T) {
+		scope := mockmetrics.NewMockScope()
+		m := newChannelMetrics(scope, mockChannel, 0)
+
+		m.connected(true)
+		connected, _ := scope.GaugeValue(connectedGauge, tags)
+		assert.Equal(t, float64(1), connected)
+		m.connected(false)
+		connected, _ = scope.GaugeValue(connectedGauge, tags)
+		assert.Equal(t, float64(0), connected)
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		var m *channelMetrics
+		assert.NotPanics(t, func() {
+			m.retried(createTopicOperation)
+			m.consumerErrored()
+			m.persisted(1)
+			m.consumed(2)
+			m.connected(true)
+		})
+	})
+}
@@ -18,11 +18,13 @@ type retryProcess struct {
 	longPollingInterval, longTimeout   time.Duration
 	exit                               chan struct{}
 	channel                            channel
+	metrics                            *channelMetrics
+	operation                          string
 	msg                                string
 	fn                                 func() error
 }
 
-func newRetryProcess(retryOptions localconfig.Retry, exit chan struct{}, channel channel, msg string, fn func() error) *retryProcess {
+func newRetryProcess(retryOptions localconfig.Retry, exit chan struct{}, channel channel, metrics *channelMetrics, operation string, msg string, fn func() error) *retryProcess {
 	return &retryProcess{
 		shortPollingInterval: retryOptions.ShortInterval,
 		shortTimeout:         retryOptions.ShortTotal,
@@ -30,6 +32,8 @@ func newRetryProcess(retryOptions localconfig.Retry, exit chan struct{}, channel
 		longTimeout:          retryOptions.LongTotal,
 		exit:                 exit,
 		channel:              channel,
+		metrics:              metrics,
+		operation:            operation,
 		msg:                  msg,
 		fn:                   fn,
 	}
@@ -77,6 +81,7 @@ func (rp *retryProcess) try(interval, total time.Duration) (err error) {
 		case <-tickTotal.C:
 			return
 		case <-tickInterval.C:
+			rp.metrics.retried(rp.operation)
 			logger.Debugf("[channel: %s] "+rp.msg, rp.channel.topic())
 			if err = rp.fn(); err == nil {
 				logger.Debugf("[channel: %s] Error is nil, breaking the retry loop", rp.channel.topic())
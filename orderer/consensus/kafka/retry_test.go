@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"testing"
 
+	mockmetrics "github.com/hyperledger/fabric/common/mocks/metrics"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,14 +29,16 @@ func TestRetry(t *testing.T) {
 
 	t.Run("Proper", func(t *testing.T) {
 		exitChan := make(chan struct{})
-		rp = newRetryProcess(mockRetryOptions, exitChan, mockChannel, "foo", noErrorFn)
+		rp = newRetryProcess(mockRetryOptions, exitChan, mockChannel, nil, "", "foo", noErrorFn)
 		assert.NoError(t, rp.retry(), "Expected retry to return no errors")
 		assert.Equal(t, true, flag, "Expected flag to be set to true")
 	})
 
 	t.Run("WithError", func(t *testing.T) {
 		exitChan := make(chan struct{})
-		rp = newRetryProcess(mockRetryOptions, exitChan, mockChannel, "foo", errorFn)
+		scope := mockmetrics.NewMockScope()
+		rp = newRetryProcess(mockRetryOptions, exitChan, mockChannel, newChannelMetrics(scope, mockChannel, 0), "bar", "foo", errorFn)
 		assert.Error(t, rp.retry(), "Expected retry to return an error")
+		assert.NotZero(t, scope.CounterValue(retriesCounter, metricTags(mockChannel, "bar")), "Expected the retries to be counted")
 	})
 }
@@ -51,6 +51,11 @@ func New() consensus.Consenter {
 	return &consenter{}
 }
 
+// Evictable returns true, as a solo chain is rebuilt from its ledger
+func (solo *consenter) Evictable() bool {
+	return true
+}
+
 func (solo *consenter) HandleChain(support consensus.ConsenterSupport, metadata *cb.Metadata) (consensus.Chain, error) {
 	return newChain(support), nil
 }
@@ -23,7 +23,7 @@ import (
 
 const (
 	chainFuncName = "chaincode"
-	chainCmdDes   = "Operate a chaincode: install|instantiate|invoke|package|query|signpackage|upgrade|list."
+	chainCmdDes   = "Operate a chaincode: install|instantiate|invoke|package|query|signpackage|upgrade|retire|list."
 )
 
 var logger = flogging.MustGetLogger("chaincodeCmd")
@@ -56,6 +56,7 @@ func Cmd(cf *ChaincodeCmdFactory) *cobra.Command {
 	chaincodeCmd.AddCommand(queryCmd(cf))
 	chaincodeCmd.AddCommand(signpackageCmd(cf))
 	chaincodeCmd.AddCommand(upgradeCmd(cf))
+	chaincodeCmd.AddCommand(retireCmd(cf))
 	chaincodeCmd.AddCommand(listCmd(cf))
 
 	return chaincodeCmd
@@ -72,7 +73,10 @@ var (
 	chaincodeQueryHex     bool
 	channelID             string
 	chaincodeVersion      string
+	chaincodeEndpoint     string
+	endpointRootCertFile  string
 	policy                string
+	channelConfigPolicy   string
 	escc                  string
 	vscc                  string
 	policyMarshalled      []byte
@@ -84,6 +88,9 @@ var (
 	connectionProfile     string
 	waitForEvent          bool
 	waitForEventTimeout   time.Duration
+	validityWindow        time.Duration
+	priority              uint32
+	archiveState          bool
 )
 
 var chaincodeCmd = &cobra.Command{
@@ -122,6 +129,8 @@ func resetFlags() {
 		fmt.Sprint("The channel on which this command should be executed"))
 	flags.StringVarP(&policy, "policy", "P", common.UndefinedParamValue,
 		fmt.Sprint("The endorsement policy associated to this chaincode"))
+	flags.StringVar(&channelConfigPolicy, "channel-config-policy", common.UndefinedParamValue,
+		fmt.Sprint("The channel config policy, such as /Channel/Application/Endorsement, the endorsement policy of this chaincode references instead of the --policy signature policy"))
 	flags.StringVarP(&escc, "escc", "E", common.UndefinedParamValue,
 		fmt.Sprint("The name of the endorsement system chaincode to be used for this chaincode"))
 	flags.StringVarP(&vscc, "vscc", "V", common.UndefinedParamValue,
@@ -130,6 +139,8 @@ func resetFlags() {
 		"Get the installed chaincodes on a peer")
 	flags.BoolVarP(&getInstantiatedChaincodes, "instantiated", "", false,
 		"Get the instantiated chaincodes on a channel")
+	flags.StringVarP(&listOutput, "output", "", "",
+		fmt.Sprintf("The output format of the list command, '%s' for JSON, human readable if not set", jsonOutput))
 	flags.StringVar(&collectionsConfigFile, "collections-config", common.UndefinedParamValue,
 		fmt.Sprint("The fully qualified path to the collection JSON file including the file name"))
 	flags.StringArrayVarP(&peerAddresses, "peerAddresses", "", []string{common.UndefinedParamValue},
@@ -142,6 +153,16 @@ func resetFlags() {
 		fmt.Sprint("Whether to wait for the event from each peer's deliver filtered service signifying that the 'invoke' transaction has been committed successfully"))
 	flags.DurationVar(&waitForEventTimeout, "waitForEventTimeout", 30*time.Second,
 		fmt.Sprint("Time to wait for the event from each peer's deliver filtered service signifying that the 'invoke' transaction has been committed successfully"))
+	flags.DurationVar(&validityWindow, "validityWindow", 0,
+		fmt.Sprint("Time after the creation of the 'invoke' transaction past which it may not be committed, on the channels enforcing the validity windows of the transactions. The transaction has no validity window if not set"))
+	flags.Uint32Var(&priority, "priority", 0,
+		fmt.Sprint("Priority of the 'invoke' transaction, on the channels whose orderers order the transactions of a block by priority. The higher the value the higher the priority"))
+	flags.StringVar(&chaincodeEndpoint, "endpoint", "",
+		fmt.Sprint("Address of the server of a chaincode run as an external service, which the peer connects to instead of launching the chaincode"))
+	flags.StringVar(&endpointRootCertFile, "endpointRootCert", "",
+		fmt.Sprint("File holding the PEM encoded certificates of the CAs issuing the TLS certificate of the server of a chaincode run as an external service, which the peer then connects to over TLS"))
+	flags.BoolVar(&archiveState, "archive", false,
+		fmt.Sprint("Whether the peers archive the state of the chaincode retired by the 'retire' transaction"))
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
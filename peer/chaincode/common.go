@@ -15,13 +15,16 @@ import (
 	"math"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/localmsp"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/msp"
 	ccapi "github.com/hyperledger/fabric/peer/chaincode/api"
@@ -49,7 +52,10 @@ func checkSpec(spec *pb.ChaincodeSpec) error {
 // getChaincodeDeploymentSpec get chaincode deployment spec given the chaincode spec
 func getChaincodeDeploymentSpec(spec *pb.ChaincodeSpec, crtPkg bool) (*pb.ChaincodeDeploymentSpec, error) {
 	var codePackageBytes []byte
-	if chaincode.IsDevMode() == false && crtPkg {
+	// the peer does not build the chaincodes run as external services, which
+	// may be packaged without their code
+	withoutCode := chaincodeEndpoint != "" && spec.Path() == ""
+	if chaincode.IsDevMode() == false && crtPkg && !withoutCode {
 		var err error
 		if err = checkSpec(spec); err != nil {
 			return nil, err
@@ -60,8 +66,27 @@ func getChaincodeDeploymentSpec(spec *pb.ChaincodeSpec, crtPkg bool) (*pb.Chainc
 			err = errors.WithMessage(err, "error getting chaincode package bytes")
 			return nil, err
 		}
+
+		// the peer builds the chaincode from the path in the spec, which
+		// must not refer to the local directory it was packaged from
+		spec.ChaincodeId.Path, err = platformRegistry.NormalizePath(spec.CCType(), spec.Path())
+		if err != nil {
+			return nil, errors.WithMessage(err, "error normalizing chaincode path")
+		}
 	}
 	chaincodeDeploymentSpec := &pb.ChaincodeDeploymentSpec{ChaincodeSpec: spec, CodePackage: codePackageBytes}
+	if crtPkg {
+		// the endpoint of a chaincode run as an external service is packaged
+		// along with its code
+		chaincodeDeploymentSpec.Endpoint = chaincodeEndpoint
+		if endpointRootCertFile != "" {
+			rootCert, err := ioutil.ReadFile(endpointRootCertFile)
+			if err != nil {
+				return nil, errors.Wrap(err, "error reading the endpoint root certificate")
+			}
+			chaincodeDeploymentSpec.EndpointRootCert = rootCert
+		}
+	}
 	return chaincodeDeploymentSpec, nil
 }
 
@@ -128,6 +153,9 @@ func chaincodeInvokeOrQuery(cmd *cobra.Command, invoke bool, cf *ChaincodeCmdFac
 			return errors.Errorf("endorsement failure during invoke. response: %v", proposalResp.Response)
 		}
 		logger.Infof("Chaincode invoke successful. result: %v", ca.Response)
+		if event := proposalResp.GetSimulationHints().GetEvent(); event != nil {
+			logger.Infof("Chaincode event emitted: %s", event.EventName)
+		}
 	} else {
 		if proposalResp == nil {
 			return errors.New("error during query: received nil proposal response")
@@ -153,11 +181,12 @@ func chaincodeInvokeOrQuery(cmd *cobra.Command, invoke bool, cf *ChaincodeCmdFac
 }
 
 type collectionConfigJson struct {
-	Name          string `json:"name"`
-	Policy        string `json:"policy"`
-	RequiredCount int32  `json:"requiredPeerCount"`
-	MaxPeerCount  int32  `json:"maxPeerCount"`
-	BlockToLive   uint64 `json:"blockToLive"`
+	Name              string `json:"name"`
+	Policy            string `json:"policy"`
+	RequiredCount     int32  `json:"requiredPeerCount"`
+	MaxPeerCount      int32  `json:"maxPeerCount"`
+	BlockToLive       uint64 `json:"blockToLive"`
+	EndorsementPolicy string `json:"endorsementPolicy,omitempty"`
 }
 
 // getCollectionConfig retrieves the collection configuration
@@ -195,6 +224,19 @@ func getCollectionConfigFromBytes(cconfBytes []byte) ([]byte, error) {
 			},
 		}
 
+		var epc *pcommon.CollectionPolicyConfig
+		if cconfitem.EndorsementPolicy != "" {
+			ep, err := cauthdsl.FromString(cconfitem.EndorsementPolicy)
+			if err != nil {
+				return nil, errors.WithMessage(err, fmt.Sprintf("invalid endorsement policy %s", cconfitem.EndorsementPolicy))
+			}
+			epc = &pcommon.CollectionPolicyConfig{
+				Payload: &pcommon.CollectionPolicyConfig_SignaturePolicy{
+					SignaturePolicy: ep,
+				},
+			}
+		}
+
 		cc := &pcommon.CollectionConfig{
 			Payload: &pcommon.CollectionConfig_StaticCollectionConfig{
 				StaticCollectionConfig: &pcommon.StaticCollectionConfig{
@@ -203,6 +245,7 @@ func getCollectionConfigFromBytes(cconfBytes []byte) ([]byte, error) {
 					RequiredPeerCount: cconfitem.RequiredCount,
 					MaximumPeerCount:  cconfitem.MaxPeerCount,
 					BlockToLive:       cconfitem.BlockToLive,
+					EndorsementPolicy: epc,
 				},
 			},
 		}
@@ -240,6 +283,10 @@ func checkChaincodeCmdParams(cmd *cobra.Command) error {
 			vscc = "vscc"
 		}
 
+		if policy != common.UndefinedParamValue && channelConfigPolicy != common.UndefinedParamValue {
+			return errors.New("the --policy and --channel-config-policy flags are mutually exclusive")
+		}
+
 		if policy != common.UndefinedParamValue {
 			p, err := cauthdsl.FromString(policy)
 			if err != nil {
@@ -248,6 +295,14 @@ func checkChaincodeCmdParams(cmd *cobra.Command) error {
 			policyMarshalled = putils.MarshalOrPanic(p)
 		}
 
+		if channelConfigPolicy != common.UndefinedParamValue {
+			var err error
+			policyMarshalled, err = ccprovider.MarshalChannelConfigPolicyReference(channelConfigPolicy)
+			if err != nil {
+				return err
+			}
+		}
+
 		if collectionsConfigFile != common.UndefinedParamValue {
 			var err error
 			collectionConfigBytes, err = getCollectionConfigFromFile(collectionsConfigFile)
@@ -457,6 +512,16 @@ func ChaincodeInvokeOrQuery(
 	if err != nil {
 		return nil, errors.WithMessage(err, fmt.Sprintf("error creating proposal for %s", funcName))
 	}
+	if invoke && validityWindow > 0 {
+		if err := setValidityWindow(prop, validityWindow); err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("error setting the validity window of the proposal for %s", funcName))
+		}
+	}
+	if invoke && priority > 0 {
+		if err := putils.SetProposalPriority(prop, priority); err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("error setting the priority of the proposal for %s", funcName))
+		}
+	}
 
 	signedProp, err := putils.GetSignedProposal(prop, signer)
 	if err != nil {
@@ -522,6 +587,28 @@ func ChaincodeInvokeOrQuery(
 	return proposalResp, nil
 }
 
+// setValidityWindow makes the transaction of the proposal invalid if it is
+// committed more than the given duration after its creation
+func setValidityWindow(prop *pb.Proposal, d time.Duration) error {
+	hdr, err := putils.GetHeader(prop.Header)
+	if err != nil {
+		return err
+	}
+	chdr, err := putils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return err
+	}
+	created, err := ptypes.Timestamp(chdr.Timestamp)
+	if err != nil {
+		return errors.Wrap(err, "invalid timestamp")
+	}
+	notAfter, err := ptypes.TimestampProto(created.Add(d))
+	if err != nil {
+		return errors.Wrap(err, "invalid validity window")
+	}
+	return putils.SetProposalValidityWindow(prop, &pcommon.ValidityWindow{NotAfter: notAfter})
+}
+
 // deliverGroup holds all of the information needed to connect
 // to a set of peers to wait for the interested txid to be
 // committed to the ledgers of all peers. This functionality
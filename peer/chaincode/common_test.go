@@ -195,6 +195,26 @@ const sampleCollectionConfigBad = `[
 	}
 ]`
 
+const sampleCollectionConfigEndorsement = `[
+	{
+		"name": "foo",
+		"policy": "OR('A.member', 'B.member')",
+		"requiredPeerCount": 3,
+		"maxPeerCount": 483279847,
+		"endorsementPolicy": "AND('A.member', 'B.member')"
+	}
+]`
+
+const sampleCollectionConfigBadEndorsement = `[
+	{
+		"name": "foo",
+		"policy": "OR('A.member', 'B.member')",
+		"requiredPeerCount": 3,
+		"maxPeerCount": 483279847,
+		"endorsementPolicy": "barf"
+	}
+]`
+
 func TestCollectionParsing(t *testing.T) {
 	cc, err := getCollectionConfigFromBytes([]byte(sampleCollectionConfigGood))
 	assert.NoError(t, err)
@@ -208,8 +228,22 @@ func TestCollectionParsing(t *testing.T) {
 	assert.Equal(t, "foo", conf.Name)
 	assert.Equal(t, pol, conf.MemberOrgsPolicy.GetSignaturePolicy())
 	assert.Equal(t, 10, int(conf.BlockToLive))
+	assert.Nil(t, conf.EndorsementPolicy)
 	t.Logf("conf=%s", conf)
 
+	cc, err = getCollectionConfigFromBytes([]byte(sampleCollectionConfigEndorsement))
+	assert.NoError(t, err)
+	ccp = &common2.CollectionConfigPackage{}
+	proto.Unmarshal(cc, ccp)
+	conf = ccp.Config[0].GetStaticCollectionConfig()
+	ep, _ := cauthdsl.FromString("AND('A.member', 'B.member')")
+	assert.Equal(t, pol, conf.MemberOrgsPolicy.GetSignaturePolicy())
+	assert.Equal(t, ep, conf.EndorsementPolicy.GetSignaturePolicy())
+
+	cc, err = getCollectionConfigFromBytes([]byte(sampleCollectionConfigBadEndorsement))
+	assert.EqualError(t, err, "invalid endorsement policy barf: unrecognized token 'barf' in policy string")
+	assert.Nil(t, cc)
+
 	cc, err = getCollectionConfigFromBytes([]byte(sampleCollectionConfigBad))
 	assert.Error(t, err)
 	assert.Nil(t, cc)
@@ -337,6 +371,24 @@ func TestInitCmdFactoryFailures(t *testing.T) {
 	assert.Nil(cf)
 }
 
+func TestSetValidityWindow(t *testing.T) {
+	prop, _, err := utils.CreateChaincodeProposal(common2.HeaderType_ENDORSER_TRANSACTION, "testchannel", &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "mycc"}}}, []byte("creator"))
+	assert.NoError(t, err)
+
+	err = setValidityWindow(prop, time.Minute)
+	assert.NoError(t, err)
+	hdr, err := utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	assert.NoError(t, err)
+	assert.Nil(t, chdr.ValidityWindow.NotBefore)
+	assert.Equal(t, chdr.Timestamp.Seconds+60, chdr.ValidityWindow.NotAfter.Seconds)
+	assert.Equal(t, chdr.Timestamp.Nanos, chdr.ValidityWindow.NotAfter.Nanos)
+
+	err = setValidityWindow(&pb.Proposal{Header: []byte("garbage")}, time.Minute)
+	assert.Error(t, err)
+}
+
 func TestDeliverGroupConnect(t *testing.T) {
 	defer resetFlags()
 	g := NewGomegaWithT(t)
@@ -50,6 +50,8 @@ func installCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 		"path",
 		"name",
 		"version",
+		"endpoint",
+		"endpointRootCert",
 		"peerAddresses",
 		"tlsRootCertFiles",
 		"connectionProfile",
@@ -165,7 +167,8 @@ func chaincodeInstall(cmd *cobra.Command, ccpackfile string, cf *ChaincodeCmdFac
 
 	var ccpackmsg proto.Message
 	if ccpackfile == "" {
-		if chaincodePath == common.UndefinedParamValue || chaincodeVersion == common.UndefinedParamValue || chaincodeName == common.UndefinedParamValue {
+		// a chaincode run as an external service may be installed without its path
+		if (chaincodePath == common.UndefinedParamValue && chaincodeEndpoint == "") || chaincodeVersion == common.UndefinedParamValue || chaincodeName == common.UndefinedParamValue {
 			return fmt.Errorf("Must supply value for %s name, path and version parameters.", chainFuncName)
 		}
 		//generate a raw ChaincodeDeploymentSpec
@@ -12,8 +12,11 @@ import (
 	"os"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/peer/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -173,3 +176,75 @@ func TestInstall(t *testing.T) {
 		t.Fatalf("Install failed with error: %v", err)
 	}
 }
+
+func TestInstallExternal(t *testing.T) {
+	defer viper.Reset()
+	defer func() { chaincodeEndpoint = "" }()
+	viper.Set("chaincode.mode", "dev")
+
+	fsPath := "/tmp/installtest"
+	cmd, mockCF := initInstallTest(fsPath, t)
+	defer cleanupInstallTest(fsPath)
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	client := &capturingEndorserClient{EndorserClient: common.GetMockEndorserClient(mockResponse, nil)}
+	mockCF.EndorserClients = []pb.EndorserClient{client}
+
+	cmd.SetArgs([]string{"-n", "example02", "-p", "github.com/hyperledger/fabric/examples/chaincode/go/example02/cmd", "-v", "1.0",
+		"--endpoint", "example02.example.com:9999"})
+	assert.NoError(t, cmd.Execute())
+
+	// the endpoint is installed along with the deployment spec
+	prop, err := utils.GetProposal(client.signedProp.ProposalBytes)
+	assert.NoError(t, err)
+	cis, err := utils.GetChaincodeInvocationSpec(prop)
+	assert.NoError(t, err)
+	cds := &pb.ChaincodeDeploymentSpec{}
+	assert.NoError(t, proto.Unmarshal(cis.ChaincodeSpec.Input.Args[1], cds))
+	assert.Equal(t, "example02.example.com:9999", cds.Endpoint)
+	assert.Equal(t, "example02", cds.ChaincodeSpec.ChaincodeId.Name)
+}
+
+func TestInstallExternalWithoutCode(t *testing.T) {
+	defer func() {
+		chaincodeEndpoint = ""
+		endpointRootCertFile = ""
+	}()
+
+	fsPath := "/tmp/installtest"
+	cmd, mockCF := initInstallTest(fsPath, t)
+	defer cleanupInstallTest(fsPath)
+	chaincodePath = common.UndefinedParamValue
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	client := &capturingEndorserClient{EndorserClient: common.GetMockEndorserClient(mockResponse, nil)}
+	mockCF.EndorserClients = []pb.EndorserClient{client}
+
+	rootCertFile := fsPath + "/endpoint-ca.pem"
+	assert.NoError(t, ioutil.WriteFile(rootCertFile, []byte("endpoint-ca"), 0600))
+
+	// the path may be omitted along with the code of the chaincode
+	cmd.SetArgs([]string{"-n", "example02", "-v", "1.0",
+		"--endpoint", "example02.example.com:9999", "--endpointRootCert", rootCertFile})
+	assert.NoError(t, cmd.Execute())
+
+	prop, err := utils.GetProposal(client.signedProp.ProposalBytes)
+	assert.NoError(t, err)
+	cis, err := utils.GetChaincodeInvocationSpec(prop)
+	assert.NoError(t, err)
+	cds := &pb.ChaincodeDeploymentSpec{}
+	assert.NoError(t, proto.Unmarshal(cis.ChaincodeSpec.Input.Args[1], cds))
+	assert.Equal(t, "example02.example.com:9999", cds.Endpoint)
+	assert.Equal(t, []byte("endpoint-ca"), cds.EndpointRootCert)
+	assert.Empty(t, cds.CodePackage)
+
+	// the root certificate must be readable
+	cmd, _ = initInstallTest(fsPath, t)
+	cmd.SetArgs([]string{"-n", "example02", "-v", "1.0",
+		"--endpoint", "example02.example.com:9999", "--endpointRootCert", fsPath + "/missing.pem"})
+	assert.Contains(t, cmd.Execute().Error(), "error reading the endpoint root certificate")
+}
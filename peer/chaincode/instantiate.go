@@ -41,6 +41,7 @@ func instantiateCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 		"channelID",
 		"version",
 		"policy",
+		"channel-config-policy",
 		"escc",
 		"vscc",
 		"collections-config",
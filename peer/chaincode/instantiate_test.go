@@ -65,6 +65,24 @@ func TestInstantiateCmd(t *testing.T) {
 			errorExpected: false,
 			errMsg:        "Run chaincode instantiate cmd error",
 		},
+		{
+			name:          "successful with channel config policy",
+			args:          []string{"--channel-config-policy", "/Channel/Application/Endorsement", "-n", "example02", "-v", "anotherversion", "-C", "mychannel", "-c", "{\"Args\": [\"init\",\"a\",\"100\",\"b\",\"200\"]}"},
+			errorExpected: false,
+			errMsg:        "Run chaincode instantiate cmd error",
+		},
+		{
+			name:          "invalid channel config policy",
+			args:          []string{"--channel-config-policy", "Endorsement", "-n", "example02", "-v", "anotherversion", "-C", "mychannel", "-c", "{\"Args\": [\"init\",\"a\",\"100\",\"b\",\"200\"]}"},
+			errorExpected: true,
+			errMsg:        "Expected error executing instantiate command with a relative channel config policy",
+		},
+		{
+			name:          "policy and channel config policy",
+			args:          []string{"-P", "OR('MSP.member')", "--channel-config-policy", "/Channel/Application/Endorsement", "-n", "example02", "-v", "anotherversion", "-C", "mychannel", "-c", "{\"Args\": [\"init\",\"a\",\"100\",\"b\",\"200\"]}"},
+			errorExpected: true,
+			errMsg:        "Expected error executing instantiate command with both -P and --channel-config-policy",
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
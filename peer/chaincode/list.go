@@ -10,11 +10,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/peer/common"
 	cb "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -24,16 +28,23 @@ import (
 
 var getInstalledChaincodes bool
 var getInstantiatedChaincodes bool
+var listOutput string
 var chaincodeListCmd *cobra.Command
 
 const list_cmdname = "list"
 
+// jsonOutput is the value of the --output flag selecting JSON output
+const jsonOutput = "json"
+
 // installCmd returns the cobra command for Chaincode Deploy
 func listCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 	chaincodeListCmd = &cobra.Command{
 		Use:   "list",
 		Short: "Get the instantiated chaincodes on a channel or installed chaincodes on a peer.",
-		Long:  "Get the instantiated chaincodes in the channel if specify channel, or get installed chaincodes on the peer",
+		Long: "Get the instantiated chaincodes in the channel if specify channel, or get installed chaincodes on the peer. " +
+			"Both can be requested together to get a combined view of the chaincodes in the channel and on the peer, " +
+			"and the chaincodes can be filtered by name and version. " +
+			"The JSON output also includes the package id, endorsement policy and collection names of the instantiated chaincodes",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return getChaincodes(cmd, cf)
 		},
@@ -43,6 +54,9 @@ func listCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 		"channelID",
 		"installed",
 		"instantiated",
+		"name",
+		"version",
+		"output",
 		"peerAddresses",
 		"tlsRootCertFiles",
 		"connectionProfile",
@@ -52,10 +66,45 @@ func listCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 	return chaincodeListCmd
 }
 
+// listedChaincode is a chaincode in the output of the list command
+type listedChaincode struct {
+	info         *pb.ChaincodeInfo
+	installed    bool
+	instantiated bool
+}
+
+// chaincodeListEntry is the JSON representation of a listed chaincode. The
+// installed and instantiated fields are only set for the views requested.
+type chaincodeListEntry struct {
+	Name              string   `json:"name"`
+	Version           string   `json:"version"`
+	Path              string   `json:"path,omitempty"`
+	Input             string   `json:"input,omitempty"`
+	Escc              string   `json:"escc,omitempty"`
+	Vscc              string   `json:"vscc,omitempty"`
+	PackageID         string   `json:"package_id,omitempty"`
+	Installed         *bool    `json:"installed,omitempty"`
+	Instantiated      *bool    `json:"instantiated,omitempty"`
+	EndorsementPolicy string   `json:"endorsement_policy,omitempty"`
+	Collections       []string `json:"collections,omitempty"`
+}
+
+// chaincodeList is the JSON output of the list command
+type chaincodeList struct {
+	Channel    string                `json:"channel,omitempty"`
+	Chaincodes []*chaincodeListEntry `json:"chaincodes"`
+}
+
 func getChaincodes(cmd *cobra.Command, cf *ChaincodeCmdFactory) error {
 	if getInstantiatedChaincodes && channelID == "" {
 		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
 	}
+	if !getInstalledChaincodes && !getInstantiatedChaincodes {
+		return fmt.Errorf("Must explicitly specify \"--installed\" or \"--instantiated\"")
+	}
+	if listOutput != "" && listOutput != jsonOutput {
+		return errors.Errorf("Unknown output format '%s', the supported format is '%s'", listOutput, jsonOutput)
+	}
 	// Parsing of the command line is done so silence cmd usage
 	cmd.SilenceUsage = true
 
@@ -72,54 +121,230 @@ func getChaincodes(cmd *cobra.Command, cf *ChaincodeCmdFactory) error {
 		return fmt.Errorf("Error serializing identity for %s: %s", cf.Signer.GetIdentifier(), err)
 	}
 
-	var prop *pb.Proposal
-	if getInstalledChaincodes && (!getInstantiatedChaincodes) {
-		prop, _, err = utils.CreateGetInstalledChaincodesProposal(creator)
-	} else if getInstantiatedChaincodes && (!getInstalledChaincodes) {
-		prop, _, err = utils.CreateGetChaincodesProposal(channelID, creator)
-	} else {
-		return fmt.Errorf("Must explicitly specify \"--installed\" or \"--instantiated\"")
+	var installed, instantiated []*pb.ChaincodeInfo
+	if getInstalledChaincodes {
+		installed, err = queryChaincodes(cf, "", creator, "getinstalledchaincodes")
+		if err != nil {
+			return err
+		}
+	}
+	if getInstantiatedChaincodes {
+		instantiated, err = queryChaincodes(cf, channelID, creator, "getchaincodes")
+		if err != nil {
+			return err
+		}
 	}
+	chaincodes := mergeChaincodes(filterChaincodes(installed), filterChaincodes(instantiated))
 
-	if err != nil {
-		return fmt.Errorf("Error creating proposal %s: %s", chainFuncName, err)
+	if listOutput == jsonOutput {
+		list, err := newChaincodeList(cf, creator, chaincodes)
+		if err != nil {
+			return err
+		}
+		jsonBytes, err := json.MarshalIndent(list, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(jsonBytes))
+		return nil
 	}
 
-	var signedProp *pb.SignedProposal
-	signedProp, err = utils.GetSignedProposal(prop, cf.Signer)
+	switch {
+	case getInstalledChaincodes && getInstantiatedChaincodes:
+		fmt.Printf("Get installed and instantiated chaincodes on channel %s:\n", channelID)
+	case getInstalledChaincodes:
+		fmt.Println("Get installed chaincodes on peer:")
+	default:
+		fmt.Printf("Get instantiated chaincodes on channel %s:\n", channelID)
+	}
+	for _, chaincode := range chaincodes {
+		if getInstalledChaincodes && getInstantiatedChaincodes {
+			fmt.Printf("%v, Installed: %t, Instantiated: %t\n", ccInfo{chaincode.info}.String(), chaincode.installed, chaincode.instantiated)
+			continue
+		}
+		fmt.Printf("%v\n", ccInfo{chaincode.info}.String())
+	}
+	return nil
+}
+
+// filterChaincodes returns the chaincodes matching the name and version
+// passed on the command line, if any
+func filterChaincodes(chaincodes []*pb.ChaincodeInfo) []*pb.ChaincodeInfo {
+	var filtered []*pb.ChaincodeInfo
+	for _, chaincode := range chaincodes {
+		if chaincodeName != common.UndefinedParamValue && chaincode.Name != chaincodeName {
+			continue
+		}
+		if chaincodeVersion != common.UndefinedParamValue && chaincode.Version != chaincodeVersion {
+			continue
+		}
+		filtered = append(filtered, chaincode)
+	}
+	return filtered
+}
+
+// mergeChaincodes combines the installed and instantiated chaincodes by
+// name and version, listing the instantiated ones first
+func mergeChaincodes(installed, instantiated []*pb.ChaincodeInfo) []*listedChaincode {
+	var chaincodes []*listedChaincode
+	byID := map[string]*listedChaincode{}
+	for _, info := range instantiated {
+		chaincode := &listedChaincode{info: info, instantiated: true}
+		byID[info.Name+":"+info.Version] = chaincode
+		chaincodes = append(chaincodes, chaincode)
+	}
+	for _, info := range installed {
+		if chaincode, ok := byID[info.Name+":"+info.Version]; ok {
+			chaincode.installed = true
+			chaincode.info.Id = info.Id
+			continue
+		}
+		chaincodes = append(chaincodes, &listedChaincode{info: info, installed: true})
+	}
+	return chaincodes
+}
+
+// newChaincodeList returns the JSON representation of the listed chaincodes
+func newChaincodeList(cf *ChaincodeCmdFactory, creator []byte, chaincodes []*listedChaincode) (*chaincodeList, error) {
+	list := &chaincodeList{Chaincodes: []*chaincodeListEntry{}}
+	if getInstantiatedChaincodes {
+		list.Channel = channelID
+	}
+	for _, chaincode := range chaincodes {
+		entry := &chaincodeListEntry{
+			Name:      chaincode.info.Name,
+			Version:   chaincode.info.Version,
+			Path:      chaincode.info.Path,
+			Input:     chaincode.info.Input,
+			Escc:      chaincode.info.Escc,
+			Vscc:      chaincode.info.Vscc,
+			PackageID: hex.EncodeToString(chaincode.info.Id),
+		}
+		if getInstalledChaincodes {
+			entry.Installed = &chaincode.installed
+		}
+		if getInstantiatedChaincodes {
+			entry.Instantiated = &chaincode.instantiated
+		}
+		if chaincode.instantiated {
+			if err := describeInstantiated(cf, creator, entry); err != nil {
+				return nil, err
+			}
+		}
+		list.Chaincodes = append(list.Chaincodes, entry)
+	}
+	return list, nil
+}
+
+// describeInstantiated adds the package id, the endorsement policy and the
+// collection names of an instantiated chaincode to its entry
+func describeInstantiated(cf *ChaincodeCmdFactory, creator []byte, entry *chaincodeListEntry) error {
+	response, err := queryLscc(cf, channelID, creator, "getccdata", channelID, entry.Name)
 	if err != nil {
-		return fmt.Errorf("Error creating signed proposal  %s: %s", chainFuncName, err)
+		return err
+	}
+	if response.Status != int32(cb.Status_SUCCESS) {
+		return errors.Errorf("Bad response getting the data of chaincode %s: %d - %s", entry.Name, response.Status, response.Message)
+	}
+	cd := &ccprovider.ChaincodeData{}
+	if err := proto.Unmarshal(response.Payload, cd); err != nil {
+		return errors.Wrapf(err, "error unmarshaling the data of chaincode %s", entry.Name)
+	}
+	if len(cd.Id) != 0 {
+		entry.PackageID = hex.EncodeToString(cd.Id)
+	}
+	if policyName, isReference := ccprovider.ChannelConfigPolicyReference(cd.Policy); isReference {
+		entry.EndorsementPolicy = policyName
+	} else if len(cd.Policy) != 0 {
+		policy := &cb.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(cd.Policy, policy); err != nil {
+			return errors.Wrapf(err, "error unmarshaling the endorsement policy of chaincode %s", entry.Name)
+		}
+		if entry.EndorsementPolicy, err = cauthdsl.ToString(policy); err != nil {
+			logger.Warningf("Cannot represent the endorsement policy of chaincode %s: %s", entry.Name, err)
+		}
 	}
 
-	// list is currently only supported for one peer
-	proposalResponse, err := cf.EndorserClients[0].ProcessProposal(context.Background(), signedProp)
+	response, err = queryLscc(cf, channelID, creator, "GetCollectionsConfig", entry.Name)
 	if err != nil {
-		return errors.Errorf("Error endorsing %s: %s", chainFuncName, err)
+		return err
+	}
+	if response.Status != int32(cb.Status_SUCCESS) {
+		// lscc fails the query of the chaincodes without collections
+		if strings.HasPrefix(response.Message, "collections config not defined") {
+			return nil
+		}
+		return errors.Errorf("Bad response getting the collections of chaincode %s: %d - %s", entry.Name, response.Status, response.Message)
+	}
+	ccp := &cb.CollectionConfigPackage{}
+	if err := proto.Unmarshal(response.Payload, ccp); err != nil {
+		return errors.Wrapf(err, "error unmarshaling the collections of chaincode %s", entry.Name)
+	}
+	for _, config := range ccp.Config {
+		if collection := config.GetStaticCollectionConfig(); collection != nil {
+			entry.Collections = append(entry.Collections, collection.Name)
+		}
 	}
+	return nil
+}
 
-	if proposalResponse.Response == nil {
-		return errors.Errorf("Proposal response had nil 'response'")
+// queryChaincodes returns the chaincodes listed by the given lscc function
+func queryChaincodes(cf *ChaincodeCmdFactory, channel string, creator []byte, function string) ([]*pb.ChaincodeInfo, error) {
+	args := []string{function}
+	if channel != "" {
+		args = append(args, channel)
+	}
+	response, err := queryLscc(cf, channel, creator, args...)
+	if err != nil {
+		return nil, err
 	}
 
-	if proposalResponse.Response.Status != int32(cb.Status_SUCCESS) {
-		return errors.Errorf("Bad response: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
+	if response.Status != int32(cb.Status_SUCCESS) {
+		return nil, errors.Errorf("Bad response: %d - %s", response.Status, response.Message)
 	}
 
 	cqr := &pb.ChaincodeQueryResponse{}
-	err = proto.Unmarshal(proposalResponse.Response.Payload, cqr)
+	err = proto.Unmarshal(response.Payload, cqr)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return cqr.Chaincodes, nil
+}
 
-	if getInstalledChaincodes {
-		fmt.Println("Get installed chaincodes on peer:")
-	} else {
-		fmt.Printf("Get instantiated chaincodes on channel %s:\n", channelID)
+// queryLscc sends a proposal invoking lscc with the given arguments
+func queryLscc(cf *ChaincodeCmdFactory, channel string, creator []byte, args ...string) (*pb.Response, error) {
+	input := &pb.ChaincodeInput{}
+	for _, arg := range args {
+		input.Args = append(input.Args, []byte(arg))
 	}
-	for _, chaincode := range cqr.Chaincodes {
-		fmt.Printf("%v\n", ccInfo{chaincode}.String())
+	lsccSpec := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_GOLANG,
+			ChaincodeId: &pb.ChaincodeID{Name: "lscc"},
+			Input:       input,
+		},
 	}
-	return nil
+	prop, _, err := utils.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, channel, lsccSpec, creator)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating proposal %s: %s", chainFuncName, err)
+	}
+
+	var signedProp *pb.SignedProposal
+	signedProp, err = utils.GetSignedProposal(prop, cf.Signer)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating signed proposal  %s: %s", chainFuncName, err)
+	}
+
+	// list is currently only supported for one peer
+	proposalResponse, err := cf.EndorserClients[0].ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return nil, errors.Errorf("Error endorsing %s: %s", chainFuncName, err)
+	}
+
+	if proposalResponse.Response == nil {
+		return nil, errors.Errorf("Proposal response had nil 'response'")
+	}
+	return proposalResponse.Response, nil
 }
 
 type ccInfo struct {
@@ -7,15 +7,21 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"encoding/hex"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
 )
 
 func TestChaincodeListCmd(t *testing.T) {
@@ -83,12 +89,11 @@ func TestChaincodeListCmd(t *testing.T) {
 	err = Cmd.Execute()
 	assert.Error(t, err, "Run chaincode list cmd to get instantiated/installed chaincodes should fail if invoked without -C flag")
 
+	// Get the combined view
 	args = []string{"--installed", "--instantiated", "-C", "mychannel"}
 	Cmd.SetArgs(args)
-	expectErr := fmt.Errorf("Must explicitly specify \"--installed\" or \"--instantiated\"")
-	if err := Cmd.Execute(); err == nil || err.Error() != expectErr.Error() {
-		t.Errorf("Expect error: %s", expectErr)
-	}
+	err = Cmd.Execute()
+	assert.NoError(t, err)
 
 	resetFlags()
 
@@ -98,7 +103,7 @@ func TestChaincodeListCmd(t *testing.T) {
 	args = []string{"-C", "mychannel"}
 	nilCmd.SetArgs(args)
 
-	expectErr = fmt.Errorf("Must explicitly specify \"--installed\" or \"--instantiated\"")
+	expectErr := fmt.Errorf("Must explicitly specify \"--installed\" or \"--instantiated\"")
 	if err := nilCmd.Execute(); err == nil || err.Error() != expectErr.Error() {
 		t.Errorf("Expect error: %s", expectErr)
 	}
@@ -152,3 +157,180 @@ func TestString(t *testing.T) {
 	}
 	assert.Equal(t, "Name: ccName, Version: 1.0, Input: input, Escc: escc, Vscc: vscc, Id: 0102030405", ccInf.String())
 }
+
+// lsccEndorserClient answers the lscc queries of the list command, keyed by
+// function and, for the queries of a single chaincode, chaincode name
+type lsccEndorserClient struct {
+	responses map[string]*pb.Response
+}
+
+func (c *lsccEndorserClient) ProcessProposal(ctx context.Context, sp *pb.SignedProposal, opts ...grpc.CallOption) (*pb.ProposalResponse, error) {
+	prop, err := utils.GetProposal(sp.ProposalBytes)
+	if err != nil {
+		return nil, err
+	}
+	cis, err := utils.GetChaincodeInvocationSpec(prop)
+	if err != nil {
+		return nil, err
+	}
+	args := cis.ChaincodeSpec.Input.Args
+	key := string(args[0])
+	switch key {
+	case "getccdata", "GetCollectionsConfig":
+		key += ":" + string(args[len(args)-1])
+	}
+	return &pb.ProposalResponse{Response: c.responses[key]}, nil
+}
+
+func newLsccEndorserClient(t *testing.T) *lsccEndorserClient {
+	policy, err := cauthdsl.FromString("OR('Org1MSP.member', 'Org2MSP.member')")
+	assert.NoError(t, err)
+	policyReference, err := ccprovider.MarshalChannelConfigPolicyReference("/Channel/Application/Endorsement")
+	assert.NoError(t, err)
+	collections := &cb.CollectionConfigPackage{}
+	for _, name := range []string{"coll1", "coll2"} {
+		collections.Config = append(collections.Config, &cb.CollectionConfig{
+			Payload: &cb.CollectionConfig_StaticCollectionConfig{
+				StaticCollectionConfig: &cb.StaticCollectionConfig{Name: name},
+			},
+		})
+	}
+
+	success := func(msg proto.Message) *pb.Response {
+		return &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(msg)}
+	}
+	return &lsccEndorserClient{
+		responses: map[string]*pb.Response{
+			"getinstalledchaincodes": success(&pb.ChaincodeQueryResponse{
+				Chaincodes: []*pb.ChaincodeInfo{
+					{Name: "mycc1", Version: "1.0", Path: "codePath1", Id: []byte{1, 2, 3}},
+					{Name: "mycc2", Version: "1.0", Path: "codePath2", Id: []byte{4, 5, 6}},
+				},
+			}),
+			"getchaincodes": success(&pb.ChaincodeQueryResponse{
+				Chaincodes: []*pb.ChaincodeInfo{
+					{Name: "mycc1", Version: "1.0", Path: "codePath1", Input: "input", Escc: "escc", Vscc: "vscc"},
+					{Name: "mycc3", Version: "2.0", Escc: "escc", Vscc: "vscc"},
+				},
+			}),
+			"getccdata:mycc1": success(&ccprovider.ChaincodeData{
+				Name:    "mycc1",
+				Version: "1.0",
+				Id:      []byte{1, 2, 3},
+				Policy:  utils.MarshalOrPanic(policy),
+			}),
+			"GetCollectionsConfig:mycc1": success(collections),
+			"getccdata:mycc3":            success(&ccprovider.ChaincodeData{Name: "mycc3", Version: "2.0", Id: []byte{7, 8, 9}, Policy: policyReference}),
+			"GetCollectionsConfig:mycc3": {Status: 500, Message: "collections config not defined for chaincode mycc3"},
+		},
+	}
+}
+
+func TestChaincodeListJSON(t *testing.T) {
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		t.Fatalf("Get default signer error: %s", err)
+	}
+	creator, err := signer.Serialize()
+	assert.NoError(t, err)
+
+	endorserClient := newLsccEndorserClient(t)
+	mockCF := &ChaincodeCmdFactory{
+		EndorserClients: []pb.EndorserClient{endorserClient},
+		Signer:          signer,
+		BroadcastClient: common.GetMockBroadcastClient(nil),
+	}
+
+	channelID = ""
+	resetFlags()
+	defer resetFlags()
+
+	cmd := listCmd(mockCF)
+	cmd.SetArgs([]string{"--installed", "--instantiated", "-C", "mychannel", "--output", "json"})
+	assert.NoError(t, cmd.Execute())
+
+	installed, err := queryChaincodes(mockCF, "", creator, "getinstalledchaincodes")
+	assert.NoError(t, err)
+	instantiated, err := queryChaincodes(mockCF, channelID, creator, "getchaincodes")
+	assert.NoError(t, err)
+	list, err := newChaincodeList(mockCF, creator, mergeChaincodes(installed, instantiated))
+	assert.NoError(t, err)
+
+	yes, no := true, false
+	assert.Equal(t, &chaincodeList{
+		Channel: "mychannel",
+		Chaincodes: []*chaincodeListEntry{
+			{
+				Name:              "mycc1",
+				Version:           "1.0",
+				Path:              "codePath1",
+				Input:             "input",
+				Escc:              "escc",
+				Vscc:              "vscc",
+				PackageID:         "010203",
+				Installed:         &yes,
+				Instantiated:      &yes,
+				EndorsementPolicy: "OR('Org1MSP.member', 'Org2MSP.member')",
+				Collections:       []string{"coll1", "coll2"},
+			},
+			{
+				Name:              "mycc3",
+				Version:           "2.0",
+				Escc:              "escc",
+				Vscc:              "vscc",
+				PackageID:         "070809",
+				Installed:         &no,
+				Instantiated:      &yes,
+				EndorsementPolicy: "/Channel/Application/Endorsement",
+			},
+			{
+				Name:         "mycc2",
+				Version:      "1.0",
+				Path:         "codePath2",
+				PackageID:    "040506",
+				Installed:    &yes,
+				Instantiated: &no,
+			},
+		},
+	}, list)
+
+	// the chaincode data can't be queried
+	delete(endorserClient.responses, "getccdata:mycc3")
+	cmd.SetArgs([]string{"--instantiated", "-C", "mychannel", "--output", "json"})
+	assert.EqualError(t, cmd.Execute(), "Proposal response had nil 'response'")
+}
+
+func TestChaincodeListFilters(t *testing.T) {
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		t.Fatalf("Get default signer error: %s", err)
+	}
+
+	mockCF := &ChaincodeCmdFactory{
+		EndorserClients: []pb.EndorserClient{newLsccEndorserClient(t)},
+		Signer:          signer,
+		BroadcastClient: common.GetMockBroadcastClient(nil),
+	}
+
+	channelID = ""
+	resetFlags()
+	defer resetFlags()
+
+	cmd := listCmd(mockCF)
+	cmd.SetArgs([]string{"--installed", "--instantiated", "-C", "mychannel", "-n", "mycc1", "-v", "1.0"})
+	assert.NoError(t, cmd.Execute())
+
+	chaincodes := []*pb.ChaincodeInfo{
+		{Name: "mycc1", Version: "1.0"},
+		{Name: "mycc1", Version: "2.0"},
+		{Name: "mycc2", Version: "1.0"},
+	}
+	assert.Equal(t, chaincodes[:1], filterChaincodes(chaincodes))
+	chaincodeVersion = ""
+	assert.Equal(t, chaincodes[:2], filterChaincodes(chaincodes))
+	chaincodeName = ""
+	assert.Equal(t, chaincodes, filterChaincodes(chaincodes))
+
+	cmd.SetArgs([]string{"--installed", "--output", "yaml"})
+	assert.EqualError(t, cmd.Execute(), "Unknown output format 'yaml', the supported format is 'json'")
+}
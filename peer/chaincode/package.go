@@ -71,6 +71,8 @@ func packageCmd(cf *ChaincodeCmdFactory, cdsFact ccDepSpecFactory) *cobra.Comman
 		"path",
 		"name",
 		"version",
+		"endpoint",
+		"endpointRootCert",
 	}
 	attachFlags(chaincodePackageCmd, flagList)
 
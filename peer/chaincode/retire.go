@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/fabric/peer/common"
+	protcommon "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/spf13/cobra"
+)
+
+var chaincodeRetireCmd *cobra.Command
+
+const retireCmdName = "retire"
+
+// retireCmd returns the cobra command for Chaincode Retire
+func retireCmd(cf *ChaincodeCmdFactory) *cobra.Command {
+	chaincodeRetireCmd = &cobra.Command{
+		Use:       retireCmdName,
+		Short:     "Retire chaincode.",
+		Long:      "Retire an instantiated chaincode. Once the transaction is committed, the invocations of the chaincode are rejected and its containers are stopped.",
+		ValidArgs: []string{"1"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return chaincodeRetire(cmd, args, cf)
+		},
+	}
+	flagList := []string{
+		"name",
+		"channelID",
+		"archive",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+	}
+	attachFlags(chaincodeRetireCmd, flagList)
+
+	return chaincodeRetireCmd
+}
+
+// retire the command via Endorser
+func retire(cmd *cobra.Command, cf *ChaincodeCmdFactory) (*protcommon.Envelope, error) {
+	creator, err := cf.Signer.Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("error serializing identity for %s: %s", cf.Signer.GetIdentifier(), err)
+	}
+
+	prop, _, err := utils.CreateRetireProposal(channelID, chaincodeName, creator, archiveState)
+	if err != nil {
+		return nil, fmt.Errorf("error creating proposal %s: %s", chainFuncName, err)
+	}
+	logger.Debugf("Get retire proposal for chaincode <%s>", chaincodeName)
+
+	var signedProp *pb.SignedProposal
+	signedProp, err = utils.GetSignedProposal(prop, cf.Signer)
+	if err != nil {
+		return nil, fmt.Errorf("error creating signed proposal  %s: %s", chainFuncName, err)
+	}
+
+	// retire is currently only supported for one peer
+	proposalResponse, err := cf.EndorserClients[0].ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return nil, fmt.Errorf("error endorsing %s: %s", chainFuncName, err)
+	}
+	logger.Debugf("endorse retire proposal, get response <%v>", proposalResponse.Response)
+
+	if proposalResponse != nil {
+		// assemble a signed transaction (it's an Envelope message)
+		env, err := utils.CreateSignedTx(prop, cf.Signer, proposalResponse)
+		if err != nil {
+			return nil, fmt.Errorf("could not assemble transaction, err %s", err)
+		}
+		logger.Debug("Get Signed envelope")
+		return env, nil
+	}
+
+	return nil, nil
+}
+
+// chaincodeRetire retires the chaincode
+func chaincodeRetire(cmd *cobra.Command, args []string, cf *ChaincodeCmdFactory) error {
+	if channelID == "" {
+		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+	if chaincodeName == common.UndefinedParamValue {
+		return fmt.Errorf("must supply value for %s name parameter", chainFuncName)
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(cmd.Name(), true, true)
+		if err != nil {
+			return err
+		}
+	}
+	defer cf.BroadcastClient.Close()
+
+	env, err := retire(cmd, cf)
+	if err != nil {
+		return err
+	}
+
+	if env != nil {
+		logger.Debug("Send signed envelope to orderer")
+		err = cf.BroadcastClient.Send(env)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type capturingEndorserClient struct {
+	pb.EndorserClient
+	signedProp *pb.SignedProposal
+}
+
+func (c *capturingEndorserClient) ProcessProposal(ctx context.Context, in *pb.SignedProposal, opts ...grpc.CallOption) (*pb.ProposalResponse, error) {
+	c.signedProp = in
+	return c.EndorserClient.ProcessProposal(ctx, in, opts...)
+}
+
+func TestRetireCmd(t *testing.T) {
+	defer resetFlags()
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		t.Fatalf("Get default signer error: %v", err)
+	}
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	endorserClient := &capturingEndorserClient{EndorserClient: common.GetMockEndorserClient(mockResponse, nil)}
+	mockCF := &ChaincodeCmdFactory{
+		EndorserClients: []pb.EndorserClient{endorserClient},
+		Signer:          signer,
+		BroadcastClient: common.GetMockBroadcastClient(nil),
+	}
+
+	// reset channelID, it might have been set by previous test
+	channelID = ""
+
+	cmd := retireCmd(mockCF)
+	addFlags(cmd)
+
+	cmd.SetArgs([]string{"-n", "example02"})
+	err = cmd.Execute()
+	assert.EqualError(t, err, "The required parameter 'channelID' is empty. Rerun the command with -C flag")
+
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "example02", "--archive"})
+	err = cmd.Execute()
+	assert.NoError(t, err, "'peer chaincode retire' command failed")
+
+	prop := &pb.Proposal{}
+	assert.NoError(t, proto.Unmarshal(endorserClient.signedProp.ProposalBytes, prop))
+	cis, err := utils.GetChaincodeInvocationSpec(prop)
+	assert.NoError(t, err)
+	assert.Equal(t, "lscc", cis.ChaincodeSpec.ChaincodeId.Name)
+	assert.Equal(t, [][]byte{[]byte("retire"), []byte("mychannel"), []byte("example02"), []byte("archive")}, cis.ChaincodeSpec.Input.Args)
+}
+
+func TestRetireCmdEndorseFail(t *testing.T) {
+	defer resetFlags()
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		t.Fatalf("Get default signer error: %v", err)
+	}
+
+	mockResponse := &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: "chaincode example02 has been retired"}}
+	mockCF := &ChaincodeCmdFactory{
+		EndorserClients: []pb.EndorserClient{common.GetMockEndorserClient(mockResponse, nil)},
+		Signer:          signer,
+		BroadcastClient: common.GetMockBroadcastClient(nil),
+	}
+
+	cmd := retireCmd(mockCF)
+	addFlags(cmd)
+
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "example02"})
+	err = cmd.Execute()
+	assert.EqualError(t, err, "could not assemble transaction, err proposal response was not successful, error code 500, msg chaincode example02 has been retired")
+}
+
+func TestRetireCmdSendTXFail(t *testing.T) {
+	defer resetFlags()
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		t.Fatalf("Get default signer error: %v", err)
+	}
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	mockCF := &ChaincodeCmdFactory{
+		EndorserClients: []pb.EndorserClient{common.GetMockEndorserClient(mockResponse, nil)},
+		Signer:          signer,
+		BroadcastClient: common.GetMockBroadcastClient(errors.New("send tx failed")),
+	}
+
+	cmd := retireCmd(mockCF)
+	addFlags(cmd)
+
+	cmd.SetArgs([]string{"-C", "mychannel", "-n", "example02"})
+	err = cmd.Execute()
+	assert.EqualError(t, err, "send tx failed")
+}
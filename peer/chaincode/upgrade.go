@@ -40,6 +40,7 @@ func upgradeCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 		"channelID",
 		"version",
 		"policy",
+		"channel-config-policy",
 		"escc",
 		"vscc",
 		"peerAddresses",
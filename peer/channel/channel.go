@@ -40,6 +40,12 @@ var (
 	channelTxFile string
 	outputBlock   string
 	timeout       time.Duration
+
+	// getinfo related variables
+	verify bool
+
+	// leave related variables
+	ledgerDisposal string
 )
 
 // Cmd returns the cobra command for Node
@@ -49,10 +55,12 @@ func Cmd(cf *ChannelCmdFactory) *cobra.Command {
 	channelCmd.AddCommand(createCmd(cf))
 	channelCmd.AddCommand(fetchCmd(cf))
 	channelCmd.AddCommand(joinCmd(cf))
+	channelCmd.AddCommand(leaveCmd(cf))
 	channelCmd.AddCommand(listCmd(cf))
 	channelCmd.AddCommand(updateCmd(cf))
 	channelCmd.AddCommand(signconfigtxCmd(cf))
 	channelCmd.AddCommand(getinfoCmd(cf))
+	channelCmd.AddCommand(getmembersCmd(cf))
 
 	return channelCmd
 }
@@ -77,6 +85,8 @@ func resetFlags() {
 	flags.StringVarP(&channelTxFile, "file", "f", "", "Configuration transaction file generated by a tool such as configtxgen for submitting to orderer")
 	flags.StringVarP(&outputBlock, "outputBlock", "", common.UndefinedParamValue, `The path to write the genesis block for the channel. (default ./<channelID>.block)`)
 	flags.DurationVarP(&timeout, "timeout", "t", 5*time.Second, "Channel creation timeout")
+	flags.StringVarP(&ledgerDisposal, "ledger", "", "keep", "What to do with the ledger of the channel left when the peer restarts: keep, archive or delete")
+	flags.BoolVarP(&verify, "verify", "", false, "Whether to also get the height of the channel on the orderer and verify the latest block of the peer against the orderer's")
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
@@ -92,8 +102,8 @@ func attachFlags(cmd *cobra.Command, names []string) {
 
 var channelCmd = &cobra.Command{
 	Use:   "channel",
-	Short: "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo.",
-	Long:  "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo.",
+	Short: "Operate a channel: create|fetch|join|leave|list|update|signconfigtx|getinfo|getmembers.",
+	Long:  "Operate a channel: create|fetch|join|leave|list|update|signconfigtx|getinfo|getmembers.",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		common.InitCmd(cmd, args)
 		common.SetOrdererEnv(cmd, args)
@@ -116,6 +126,7 @@ type ChannelCmdFactory struct {
 	BroadcastClient  common.BroadcastClient
 	DeliverClient    deliverClientIntf
 	BroadcastFactory BroadcastClientFactory
+	AdminClient      pb.AdminClient
 }
 
 // InitCmdFactory init the ChannelCmdFactory with clients to endorser and orderer according to params
@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/scc/qscc"
@@ -25,26 +26,46 @@ func getinfoCmd(cf *ChannelCmdFactory) *cobra.Command {
 	getinfoCmd := &cobra.Command{
 		Use:   "getinfo",
 		Short: "get blockchain information of a specified channel.",
-		Long:  "get blockchain information of a specified channel. Requires '-c'.",
+		Long: "get blockchain information of a specified channel. Requires '-c'. " +
+			"With '--verify', the height of the channel on the orderer is also reported along with the lag of the peer, " +
+			"and the latest block of the peer is verified against the orderer's. Requires '-o'.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return getinfo(cmd, cf)
 		},
 	}
 	flagList := []string{
 		"channelID",
+		"verify",
 	}
 	attachFlags(getinfoCmd, flagList)
 
 	return getinfoCmd
 }
-func (cc *endorserClient) getBlockChainInfo() (*cb.BlockchainInfo, error) {
+
+// ordererInfo reports the height of the channel on the orderer and how the
+// ledger of the peer compares to it
+type ordererInfo struct {
+	Height uint64 `json:"height"`
+	// Lag is the number of blocks the peer is missing, it is negative if
+	// the peer is ahead of the orderer
+	Lag int64 `json:"lag"`
+	// LatestBlockVerified is true if the latest block of the peer has the
+	// same header on the orderer
+	LatestBlockVerified bool `json:"latestBlockVerified"`
+}
+
+func (cc *endorserClient) queryQSCC(args ...string) ([]byte, error) {
 	var err error
 
+	input := &pb.ChaincodeInput{}
+	for _, arg := range args {
+		input.Args = append(input.Args, []byte(arg))
+	}
 	invocation := &pb.ChaincodeInvocationSpec{
 		ChaincodeSpec: &pb.ChaincodeSpec{
 			Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
 			ChaincodeId: &pb.ChaincodeID{Name: "qscc"},
-			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(qscc.GetChainInfo), []byte(channelID)}},
+			Input:       input,
 		},
 	}
 
@@ -70,8 +91,17 @@ func (cc *endorserClient) getBlockChainInfo() (*cb.BlockchainInfo, error) {
 		return nil, errors.Errorf("received bad response, status %d: %s", proposalResp.Response.Status, proposalResp.Response.Message)
 	}
 
+	return proposalResp.Response.Payload, nil
+}
+
+func (cc *endorserClient) getBlockChainInfo() (*cb.BlockchainInfo, error) {
+	payload, err := cc.queryQSCC(qscc.GetChainInfo, channelID)
+	if err != nil {
+		return nil, err
+	}
+
 	blockChainInfo := &cb.BlockchainInfo{}
-	err = proto.Unmarshal(proposalResp.Response.Payload, blockChainInfo)
+	err = proto.Unmarshal(payload, blockChainInfo)
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot read qscc response")
 	}
@@ -80,6 +110,21 @@ func (cc *endorserClient) getBlockChainInfo() (*cb.BlockchainInfo, error) {
 
 }
 
+func (cc *endorserClient) getBlockByNumber(number uint64) (*cb.Block, error) {
+	payload, err := cc.queryQSCC(qscc.GetBlockByNumber, channelID, strconv.FormatUint(number, 10))
+	if err != nil {
+		return nil, err
+	}
+
+	block := &cb.Block{}
+	err = proto.Unmarshal(payload, block)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read qscc response")
+	}
+
+	return block, nil
+}
+
 func getinfo(cmd *cobra.Command, cf *ChannelCmdFactory) error {
 	//the global chainID filled by the "-c" command
 	if channelID == common.UndefinedParamValue {
@@ -90,7 +135,11 @@ func getinfo(cmd *cobra.Command, cf *ChannelCmdFactory) error {
 
 	var err error
 	if cf == nil {
-		cf, err = InitCmdFactory(EndorserRequired, PeerDeliverNotRequired, OrdererNotRequired)
+		ordererRequired := OrdererNotRequired
+		if verify {
+			ordererRequired = OrdererRequired
+		}
+		cf, err = InitCmdFactory(EndorserRequired, PeerDeliverNotRequired, ordererRequired)
 		if err != nil {
 			return err
 		}
@@ -109,5 +158,62 @@ func getinfo(cmd *cobra.Command, cf *ChannelCmdFactory) error {
 
 	fmt.Printf("Blockchain info: %s\n", string(jsonBytes))
 
+	if !verify {
+		return nil
+	}
+	defer cf.DeliverClient.Close()
+
+	info, err := verifyAgainstOrderer(client, cf.DeliverClient, blockChainInfo)
+	if err != nil {
+		return err
+	}
+	jsonBytes, err = json.Marshal(info)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Orderer info: %s\n", string(jsonBytes))
+
+	if !info.LatestBlockVerified && info.Lag >= 0 && blockChainInfo.Height > 0 {
+		return errors.Errorf("block [%d] of the peer does not match the block of the orderer", blockChainInfo.Height-1)
+	}
 	return nil
 }
+
+// verifyAgainstOrderer compares the ledger of the peer with the orderer's.
+// The headers of the latest block of the peer are compared rather than their
+// hashes, as these depend on the hashing algorithm of the channel.
+func verifyAgainstOrderer(client *endorserClient, deliverClient deliverClientIntf, blockChainInfo *cb.BlockchainInfo) (*ordererInfo, error) {
+	newest, err := deliverClient.GetNewestBlock()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed getting the newest block from the orderer")
+	}
+	info := &ordererInfo{
+		Height: newest.Header.Number + 1,
+	}
+	info.Lag = int64(info.Height) - int64(blockChainInfo.Height)
+
+	if blockChainInfo.Height == 0 {
+		return info, nil
+	}
+	latest := blockChainInfo.Height - 1
+	if latest > newest.Header.Number {
+		logger.Warningf("The peer is ahead of the orderer, block [%d] cannot be verified", latest)
+		return info, nil
+	}
+
+	ordererBlock := newest
+	if latest != newest.Header.Number {
+		ordererBlock, err = deliverClient.GetSpecifiedBlock(latest)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed getting block [%d] from the orderer", latest))
+		}
+	}
+	peerBlock, err := client.getBlockByNumber(latest)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed getting block [%d] from the peer", latest))
+	}
+	info.LatestBlockVerified = proto.Equal(peerBlock.Header, ordererBlock.Header)
+
+	return info, nil
+}
@@ -7,13 +7,19 @@ SPDX-License-Identifier: Apache-2.0
 package channel
 
 import (
+	"context"
+	"strconv"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/scc/qscc"
 	"github.com/hyperledger/fabric/peer/common"
 	cb "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
 )
 
 func TestGetChannelInfo(t *testing.T) {
@@ -75,3 +81,162 @@ func TestGetChannelInfoMissingChannelID(t *testing.T) {
 
 	assert.Error(t, cmd.Execute())
 }
+
+// qsccEndorserClient serves the chain info and the blocks of a ledger
+type qsccEndorserClient struct {
+	blocks []*cb.Block
+}
+
+func (c *qsccEndorserClient) ProcessProposal(ctx context.Context, sp *pb.SignedProposal, opts ...grpc.CallOption) (*pb.ProposalResponse, error) {
+	prop, err := utils.GetProposal(sp.ProposalBytes)
+	if err != nil {
+		return nil, err
+	}
+	cis, err := utils.GetChaincodeInvocationSpec(prop)
+	if err != nil {
+		return nil, err
+	}
+	args := cis.ChaincodeSpec.Input.Args
+	var payload []byte
+	switch string(args[0]) {
+	case qscc.GetChainInfo:
+		latest := c.blocks[len(c.blocks)-1]
+		payload = utils.MarshalOrPanic(&cb.BlockchainInfo{
+			Height:            uint64(len(c.blocks)),
+			CurrentBlockHash:  latest.Header.Hash(),
+			PreviousBlockHash: latest.Header.PreviousHash,
+		})
+	case qscc.GetBlockByNumber:
+		number, err := strconv.Atoi(string(args[2]))
+		if err != nil {
+			return nil, err
+		}
+		payload = utils.MarshalOrPanic(c.blocks[number])
+	}
+	return &pb.ProposalResponse{Response: &pb.Response{Status: 200, Payload: payload}}, nil
+}
+
+// blocksDeliverClient delivers the blocks of a ledger
+type blocksDeliverClient struct {
+	blocks []*cb.Block
+	err    error
+}
+
+func (d *blocksDeliverClient) GetSpecifiedBlock(num uint64) (*cb.Block, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	return d.blocks[num], nil
+}
+
+func (d *blocksDeliverClient) GetOldestBlock() (*cb.Block, error) {
+	return d.GetSpecifiedBlock(0)
+}
+
+func (d *blocksDeliverClient) GetNewestBlock() (*cb.Block, error) {
+	return d.GetSpecifiedBlock(uint64(len(d.blocks) - 1))
+}
+
+func (d *blocksDeliverClient) Close() error {
+	return nil
+}
+
+func newTestChain(height int) []*cb.Block {
+	var blocks []*cb.Block
+	var previousHash []byte
+	for i := 0; i < height; i++ {
+		block := cb.NewBlock(uint64(i), previousHash)
+		block.Data.Data = [][]byte{[]byte(strconv.Itoa(i))}
+		block.Header.DataHash = block.Data.Hash()
+		previousHash = block.Header.Hash()
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func TestGetChannelInfoVerify(t *testing.T) {
+	InitMSP()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	chain := newTestChain(5)
+	forkedChain := newTestChain(5)
+	forkedChain[3].Data.Data = [][]byte{[]byte("forked")}
+	forkedChain[3].Header.DataHash = forkedChain[3].Data.Hash()
+
+	tests := []struct {
+		name          string
+		peerBlocks    []*cb.Block
+		ordererBlocks []*cb.Block
+		ordererErr    error
+		expectedInfo  *ordererInfo
+		expectedErr   string
+	}{
+		{
+			name:          "in sync",
+			peerBlocks:    chain,
+			ordererBlocks: chain,
+			expectedInfo:  &ordererInfo{Height: 5, Lag: 0, LatestBlockVerified: true},
+		},
+		{
+			name:          "lagging",
+			peerBlocks:    chain[:3],
+			ordererBlocks: chain,
+			expectedInfo:  &ordererInfo{Height: 5, Lag: 2, LatestBlockVerified: true},
+		},
+		{
+			name:          "ahead",
+			peerBlocks:    chain,
+			ordererBlocks: chain[:4],
+			expectedInfo:  &ordererInfo{Height: 4, Lag: -1},
+		},
+		{
+			name:          "forked",
+			peerBlocks:    forkedChain[:4],
+			ordererBlocks: chain,
+			expectedInfo:  &ordererInfo{Height: 5, Lag: 1},
+			expectedErr:   "block [3] of the peer does not match the block of the orderer",
+		},
+		{
+			name:          "orderer unavailable",
+			peerBlocks:    chain,
+			ordererBlocks: chain,
+			ordererErr:    errors.New("connection refused"),
+			expectedErr:   "failed getting the newest block from the orderer: connection refused",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resetFlags()
+
+			peer := &qsccEndorserClient{blocks: test.peerBlocks}
+			deliverClient := &blocksDeliverClient{blocks: test.ordererBlocks, err: test.ordererErr}
+			mockCF := &ChannelCmdFactory{
+				EndorserClient: peer,
+				DeliverClient:  deliverClient,
+				Signer:         signer,
+			}
+
+			client := &endorserClient{mockCF}
+			blockChainInfo, err := client.getBlockChainInfo()
+			assert.NoError(t, err)
+			info, err := verifyAgainstOrderer(client, deliverClient, blockChainInfo)
+			if test.ordererErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedInfo, info)
+			}
+
+			cmd := getinfoCmd(mockCF)
+			AddFlags(cmd)
+			cmd.SetArgs([]string{"-c", mockChannel, "--verify"})
+			err = cmd.Execute()
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
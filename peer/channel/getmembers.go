@@ -0,0 +1,80 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func getmembersCmd(cf *ChannelCmdFactory) *cobra.Command {
+	getmembersCmd := &cobra.Command{
+		Use:   "getmembers",
+		Short: "get the gossip membership view of the peer.",
+		Long:  "get the alive peers known to the gossip service of the peer, as JSON. With '-c' only the peers of the channel, along with their ledger heights, are returned.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getmembers(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+	}
+	attachFlags(getmembersCmd, flagList)
+
+	return getmembersCmd
+}
+
+func getmembers(cmd *cobra.Command, cf *ChannelCmdFactory) error {
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserNotRequired, PeerDeliverNotRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+	if cf.AdminClient == nil {
+		cf.AdminClient, err = common.GetAdminClientFnc()
+		if err != nil {
+			return errors.WithMessage(err, "error getting admin client")
+		}
+	}
+
+	op := &pb.AdminOperation{
+		Content: &pb.AdminOperation_MembershipReq{
+			MembershipReq: &pb.GossipMembershipRequest{Channel: channelID},
+		},
+	}
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_PEER_ADMIN_OPERATION, "", crypto.NewSignatureHeaderCreator(cf.Signer), op, 0, 0)
+	if err != nil {
+		return errors.WithMessage(err, "cannot create signed envelope")
+	}
+
+	membership, err := cf.AdminClient.GetGossipMembership(context.Background(), env)
+	if err != nil {
+		return errors.WithMessage(err, "failed getting gossip membership")
+	}
+	jsonBytes, err := json.MarshalIndent(membership, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(jsonBytes))
+
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type membershipAdminClient struct {
+	pb.AdminClient
+	requests []*pb.GossipMembershipRequest
+	err      error
+}
+
+func (m *membershipAdminClient) GetGossipMembership(ctx context.Context, env *cb.Envelope, opts ...grpc.CallOption) (*pb.GossipMembership, error) {
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	op := &pb.AdminOperation{}
+	if err := proto.Unmarshal(payload.Data, op); err != nil {
+		return nil, err
+	}
+	m.requests = append(m.requests, op.GetMembershipReq())
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &pb.GossipMembership{Channel: op.GetMembershipReq().GetChannel()}, nil
+}
+
+func TestGetMembers(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	require.NoError(t, err)
+
+	adminClient := &membershipAdminClient{}
+	mockCF := &ChannelCmdFactory{
+		Signer:      signer,
+		AdminClient: adminClient,
+	}
+
+	cmd := getmembersCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel})
+	assert.NoError(t, cmd.Execute())
+
+	resetFlags()
+	cmd = getmembersCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{})
+	assert.NoError(t, cmd.Execute())
+
+	require.Len(t, adminClient.requests, 2)
+	assert.Equal(t, mockChannel, adminClient.requests[0].Channel)
+	assert.Equal(t, "", adminClient.requests[1].Channel)
+}
+
+func TestGetMembersFailure(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	require.NoError(t, err)
+
+	mockCF := &ChannelCmdFactory{
+		Signer:      signer,
+		AdminClient: &membershipAdminClient{err: errors.New("peer is not part of channel mychannel")},
+	}
+
+	cmd := getmembersCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel})
+	assert.EqualError(t, cmd.Execute(), "failed getting gossip membership: peer is not part of channel mychannel")
+
+	resetFlags()
+	mockCF.AdminClient = nil
+	defer func(f func() (pb.AdminClient, error)) { common.GetAdminClientFnc = f }(common.GetAdminClientFnc)
+	common.GetAdminClientFnc = func() (pb.AdminClient, error) { return nil, errors.New("no peer") }
+	cmd = getmembersCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{})
+	assert.EqualError(t, cmd.Execute(), "error getting admin client: no peer")
+}
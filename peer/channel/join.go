@@ -17,11 +17,14 @@ limitations under the License.
 package channel
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/core/scc/cscc"
 	"github.com/hyperledger/fabric/peer/common"
 	pcommon "github.com/hyperledger/fabric/protos/common"
@@ -44,6 +47,7 @@ func joinCmd(cf *ChannelCmdFactory) *cobra.Command {
 	}
 	flagList := []string{
 		"blockpath",
+		"channelID",
 	}
 	attachFlags(joinCmd, flagList)
 
@@ -64,14 +68,19 @@ func (e ProposalFailedErr) Error() string {
 	return fmt.Sprintf("proposal failed (err: %s)", string(e))
 }
 
-func getJoinCCSpec() (*pb.ChaincodeSpec, error) {
-	if genesisBlockPath == common.UndefinedParamValue {
-		return nil, errors.New("Must supply genesis block file")
-	}
-
-	gb, err := ioutil.ReadFile(genesisBlockPath)
-	if err != nil {
-		return nil, GBFileNotFoundErr(err.Error())
+func getJoinCCSpec(cf *ChannelCmdFactory) (*pb.ChaincodeSpec, error) {
+	var gb []byte
+	var err error
+	if genesisBlockPath != common.UndefinedParamValue {
+		gb, err = ioutil.ReadFile(genesisBlockPath)
+		if err != nil {
+			return nil, GBFileNotFoundErr(err.Error())
+		}
+	} else {
+		gb, err = fetchGenesisBlock(cf)
+		if err != nil {
+			return nil, err
+		}
 	}
 	// Build the spec
 	input := &pb.ChaincodeInput{Args: [][]byte{[]byte(cscc.JoinChain), gb}}
@@ -85,8 +94,79 @@ func getJoinCCSpec() (*pb.ChaincodeSpec, error) {
 	return spec, nil
 }
 
+// fetchGenesisBlock fetches the genesis block of the channel from the
+// orderer, and checks that it was signed by an orderer of the channel
+func fetchGenesisBlock(cf *ChannelCmdFactory) ([]byte, error) {
+	block, err := cf.DeliverClient.GetSpecifiedBlock(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching the genesis block of channel %s from the orderer: %s", channelID, err)
+	}
+	if err := verifyGenesisBlock(block, channelID); err != nil {
+		return nil, fmt.Errorf("invalid genesis block received for channel %s: %s", channelID, err)
+	}
+	logger.Infof("Fetched the genesis block of channel %s from the orderer", channelID)
+	return proto.Marshal(block)
+}
+
+// verifyGenesisBlock checks that the block is the genesis block of the
+// channel, and that its configuration transaction was signed by a member of
+// one of the orderer organizations it defines
+func verifyGenesisBlock(block *pcommon.Block, channelID string) error {
+	if block.Header == nil || block.Data == nil || block.Header.Number != 0 {
+		return errors.New("not a genesis block")
+	}
+	if !bytes.Equal(block.Header.DataHash, block.Data.Hash()) {
+		return errors.New("the data hash does not match the header")
+	}
+	env, err := putils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return err
+	}
+	payload, err := putils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return err
+	}
+	chdr, err := putils.ChannelHeader(env)
+	if err != nil {
+		return err
+	}
+	if chdr.Type != int32(pcommon.HeaderType_CONFIG) {
+		return fmt.Errorf("unexpected transaction of type %d", chdr.Type)
+	}
+	if chdr.ChannelId != channelID {
+		return fmt.Errorf("the block is the genesis block of channel %s", chdr.ChannelId)
+	}
+	bundle, err := channelconfig.NewBundleFromEnvelope(env)
+	if err != nil {
+		return err
+	}
+	ordererConfig, ok := bundle.OrdererConfig()
+	if !ok {
+		return errors.New("the channel has no orderer configuration")
+	}
+
+	shdr, err := putils.GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return err
+	}
+	identity, err := bundle.MSPManager().DeserializeIdentity(shdr.Creator)
+	if err != nil {
+		return fmt.Errorf("failed deserializing the creator of the configuration: %s", err)
+	}
+	if _, exists := ordererConfig.Organizations()[identity.GetMSPIdentifier()]; !exists {
+		return fmt.Errorf("the configuration was created by %s, which is not an orderer organization", identity.GetMSPIdentifier())
+	}
+	if err := identity.Validate(); err != nil {
+		return fmt.Errorf("the creator of the configuration is not valid: %s", err)
+	}
+	if err := identity.Verify(env.Payload, env.Signature); err != nil {
+		return fmt.Errorf("the signature of the configuration is not valid: %s", err)
+	}
+	return nil
+}
+
 func executeJoin(cf *ChannelCmdFactory) (err error) {
-	spec, err := getJoinCCSpec()
+	spec, err := getJoinCCSpec(cf)
 	if err != nil {
 		return err
 	}
@@ -129,15 +209,21 @@ func executeJoin(cf *ChannelCmdFactory) (err error) {
 }
 
 func join(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
-	if genesisBlockPath == common.UndefinedParamValue {
-		return errors.New("Must supply genesis block path")
+	// without a genesis block file, the genesis block is fetched from the orderer
+	fetch := genesisBlockPath == common.UndefinedParamValue
+	if fetch && (channelID == common.UndefinedParamValue || common.OrderingEndpoint == "") {
+		return errors.New("Must supply genesis block path, or the channel ID and the orderer to fetch it from")
 	}
 	// Parsing of the command line is done so silence cmd usage
 	cmd.SilenceUsage = true
 
 	var err error
 	if cf == nil {
-		cf, err = InitCmdFactory(EndorserRequired, PeerDeliverNotRequired, OrdererNotRequired)
+		ordererRequired := OrdererNotRequired
+		if fetch {
+			ordererRequired = OrdererRequired
+		}
+		cf, err = InitCmdFactory(EndorserRequired, PeerDeliverNotRequired, ordererRequired)
 		if err != nil {
 			return err
 		}
@@ -23,8 +23,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/configtxgentest"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
 	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -79,6 +86,104 @@ func TestJoin(t *testing.T) {
 	assert.NoError(t, cmd.Execute(), "expected join command to succeed")
 }
 
+func TestJoinFromOrderer(t *testing.T) {
+	defer resetFlags()
+
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err, "Get default signer error: %v", err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	mockEndorserClient := common.GetMockEndorserClient(mockResponse, nil)
+
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   mockEndorserClient,
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+		DeliverClient:    getMockDeliverClientWithBlock("mychannel", signedGenesisBlock(t, "mychannel")),
+	}
+
+	cmd := joinCmd(mockCF)
+	AddFlags(cmd)
+	defer func() { common.OrderingEndpoint = "" }()
+
+	args := []string{"-c", "mychannel", "-o", "orderer.example.com:7050"}
+	cmd.SetArgs(args)
+
+	assert.NoError(t, cmd.Execute(), "expected join command to succeed")
+}
+
+func TestJoinMissingOrderer(t *testing.T) {
+	defer resetFlags()
+
+	resetFlags()
+
+	cmd := joinCmd(nil)
+	AddFlags(cmd)
+	args := []string{"-c", "mychannel"}
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	assert.EqualError(t, err, "Must supply genesis block path, or the channel ID and the orderer to fetch it from")
+}
+
+func TestVerifyGenesisBlock(t *testing.T) {
+	InitMSP()
+
+	block := signedGenesisBlock(t, "mychannel")
+	assert.NoError(t, verifyGenesisBlock(block, "mychannel"))
+
+	err := verifyGenesisBlock(block, "otherchannel")
+	assert.EqualError(t, err, "the block is the genesis block of channel mychannel")
+
+	tampered := proto.Clone(block).(*cb.Block)
+	tampered.Header.Number = 1
+	assert.EqualError(t, verifyGenesisBlock(tampered, "mychannel"), "not a genesis block")
+
+	tampered = proto.Clone(block).(*cb.Block)
+	tampered.Header.DataHash = []byte("garbage")
+	assert.EqualError(t, verifyGenesisBlock(tampered, "mychannel"), "the data hash does not match the header")
+
+	// the signature must cover the configuration
+	tampered = proto.Clone(block).(*cb.Block)
+	env := putils.ExtractEnvelopeOrPanic(tampered, 0)
+	env.Signature = []byte("garbage")
+	tampered.Data.Data[0] = putils.MarshalOrPanic(env)
+	tampered.Header.DataHash = tampered.Data.Hash()
+	err = verifyGenesisBlock(tampered, "mychannel")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the signature of the configuration is not valid")
+
+	// the configuration must be signed by an orderer
+	unsigned := encoder.New(configtxgentest.Load(genesisconfig.SampleSingleMSPSoloProfile)).GenesisBlockForChannel("mychannel")
+	err = verifyGenesisBlock(unsigned, "mychannel")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed deserializing the creator of the configuration")
+}
+
+// signedGenesisBlock returns a genesis block for the channel whose
+// configuration is signed by the local identity, a member of the orderer
+// organization of the sample configuration
+func signedGenesisBlock(t *testing.T, channelID string) *cb.Block {
+	unsigned := encoder.New(configtxgentest.Load(genesisconfig.SampleSingleMSPSoloProfile)).GenesisBlockForChannel(channelID)
+	payload, err := putils.UnmarshalPayload(putils.ExtractEnvelopeOrPanic(unsigned, 0).Payload)
+	assert.NoError(t, err)
+	configEnv := &cb.ConfigEnvelope{}
+	assert.NoError(t, proto.Unmarshal(payload.Data, configEnv))
+
+	env, err := putils.CreateSignedEnvelope(cb.HeaderType_CONFIG, channelID, localmsp.NewSigner(), configEnv, 0, 0)
+	assert.NoError(t, err)
+	block := cb.NewBlock(0, nil)
+	block.Data.Data = [][]byte{putils.MarshalOrPanic(env)}
+	block.Header.DataHash = block.Data.Hash()
+	return block
+}
+
 func TestJoinNonExistentBlock(t *testing.T) {
 	defer resetFlags()
 
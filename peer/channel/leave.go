@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/scc/cscc"
+	"github.com/hyperledger/fabric/peer/common"
+	pcommon "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func leaveCmd(cf *ChannelCmdFactory) *cobra.Command {
+	leaveCmd := &cobra.Command{
+		Use:   "leave",
+		Short: "Makes the peer leave a channel.",
+		Long:  "Makes the peer leave a channel: the deliver client and the gossip membership of the channel are stopped, and its ledger is kept, archived or deleted when the peer restarts, as set by '--ledger'. A kept ledger is resumed if the peer joins the channel again.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return leave(cmd, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"ledger",
+	}
+	attachFlags(leaveCmd, flagList)
+
+	return leaveCmd
+}
+
+func executeLeave(cf *ChannelCmdFactory) error {
+	spec := &pb.ChaincodeSpec{
+		Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
+		ChaincodeId: &pb.ChaincodeID{Name: "cscc"},
+		Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(cscc.LeaveChain), []byte(channelID), []byte(ledgerDisposal)}},
+	}
+	invocation := &pb.ChaincodeInvocationSpec{ChaincodeSpec: spec}
+
+	creator, err := cf.Signer.Serialize()
+	if err != nil {
+		return fmt.Errorf("Error serializing identity for %s: %s", cf.Signer.GetIdentifier(), err)
+	}
+
+	prop, _, err := putils.CreateProposalFromCIS(pcommon.HeaderType_CONFIG, "", invocation, creator)
+	if err != nil {
+		return fmt.Errorf("Error creating proposal for leave %s", err)
+	}
+
+	signedProp, err := putils.GetSignedProposal(prop, cf.Signer)
+	if err != nil {
+		return fmt.Errorf("Error creating signed proposal %s", err)
+	}
+
+	proposalResp, err := cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return ProposalFailedErr(err.Error())
+	}
+
+	if proposalResp == nil {
+		return ProposalFailedErr("nil proposal response")
+	}
+
+	if proposalResp.Response.Status != 0 && proposalResp.Response.Status != 200 {
+		return ProposalFailedErr(fmt.Sprintf("bad proposal response %d: %s", proposalResp.Response.Status, proposalResp.Response.Message))
+	}
+	logger.Infof("Successfully submitted proposal to leave channel %s", channelID)
+	return nil
+}
+
+func leave(cmd *cobra.Command, cf *ChannelCmdFactory) error {
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+	switch ledgerDisposal {
+	case "keep", "archive", "delete":
+	default:
+		return errors.Errorf("invalid ledger disposal [%s], expected keep, archive or delete", ledgerDisposal)
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserRequired, PeerDeliverNotRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+	return executeLeave(cf)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeave(t *testing.T) {
+	defer resetFlags()
+
+	InitMSP()
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	for _, tc := range []struct {
+		name        string
+		args        []string
+		status      int32
+		expectedErr string
+	}{
+		{name: "keep by default", args: []string{"-c", "mychannel"}, status: 200},
+		{name: "delete", args: []string{"-c", "mychannel", "--ledger", "delete"}, status: 200},
+		{name: "missing channel", args: []string{}, expectedErr: "Must supply channel ID"},
+		{name: "invalid disposal", args: []string{"-c", "mychannel", "--ledger", "shred"},
+			expectedErr: "invalid ledger disposal [shred], expected keep, archive or delete"},
+		{name: "bad response", args: []string{"-c", "mychannel"}, status: 500,
+			expectedErr: "proposal failed (err: bad proposal response 500: channel mychannel not associated with this peer)"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resetFlags()
+			mockResponse := &pb.ProposalResponse{
+				Response:    &pb.Response{Status: tc.status, Message: "channel mychannel not associated with this peer"},
+				Endorsement: &pb.Endorsement{},
+			}
+			mockCF := &ChannelCmdFactory{
+				EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+				BroadcastFactory: mockBroadcastClientFactory,
+				Signer:           signer,
+			}
+
+			cmd := leaveCmd(mockCF)
+			AddFlags(cmd)
+			cmd.SetArgs(tc.args)
+			err := cmd.Execute()
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
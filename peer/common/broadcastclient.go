@@ -17,6 +17,8 @@ limitations under the License.
 package common
 
 import (
+	"time"
+
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/pkg/errors"
@@ -28,7 +30,17 @@ type BroadcastClient interface {
 	Close() error
 }
 
+// broadcastAttempts is the number of times an envelope is sent to the
+// orderer when the broadcast stream fails, rather than the orderer
+// rejecting the envelope
+const broadcastAttempts = 3
+
+// broadcastRetryInterval is the time waited before sending the envelope
+// again, doubled at each attempt
+var broadcastRetryInterval = 100 * time.Millisecond
+
 type broadcastClient struct {
+	oc     *OrdererClient
 	client ab.AtomicBroadcast_BroadcastClient
 }
 
@@ -40,34 +52,58 @@ func GetBroadcastClient() (BroadcastClient, error) {
 	}
 	bc, err := oc.Broadcast()
 	if err != nil {
+		oc.Close()
 		return nil, err
 	}
 
-	return &broadcastClient{client: bc}, nil
+	return &broadcastClient{oc: oc, client: bc}, nil
 }
 
-func (s *broadcastClient) getAck() error {
+// getAck returns the error of the stream, or rejected set when the orderer
+// replied with a status other than SUCCESS
+func (s *broadcastClient) getAck() (rejected bool, err error) {
 	msg, err := s.client.Recv()
 	if err != nil {
-		return err
+		return false, err
 	}
 	if msg.Status != cb.Status_SUCCESS {
-		return errors.Errorf("got unexpected status: %v -- %s", msg.Status, msg.Info)
+		return true, errors.Errorf("got unexpected status: %v -- %s", msg.Status, msg.Info)
 	}
-	return nil
+	return false, nil
 }
 
 //Send data to orderer
 func (s *broadcastClient) Send(env *cb.Envelope) error {
-	if err := s.client.Send(env); err != nil {
-		return errors.WithMessage(err, "could not send")
-	}
+	interval := broadcastRetryInterval
+	for attempt := 1; ; attempt++ {
+		rejected, err := s.send(env)
+		if err == nil || rejected || attempt == broadcastAttempts {
+			return err
+		}
+		logger.Warningf("Failed broadcasting to the orderer, retrying in %s: %s", interval, err)
+		time.Sleep(interval)
+		interval *= 2
 
-	err := s.getAck()
+		// the stream is opened again over the connection of the client,
+		// which is re-established if it was lost
+		s.client.CloseSend()
+		bc, err := s.oc.Broadcast()
+		if err != nil {
+			return err
+		}
+		s.client = bc
+	}
+}
 
-	return err
+func (s *broadcastClient) send(env *cb.Envelope) (rejected bool, err error) {
+	if err := s.client.Send(env); err != nil {
+		return false, errors.WithMessage(err, "could not send")
+	}
+	return s.getAck()
 }
 
+// Close closes the broadcast stream and the connection to the orderer
 func (s *broadcastClient) Close() error {
+	defer s.oc.Close()
 	return s.client.CloseSend()
 }
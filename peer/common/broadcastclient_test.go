@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// flakyOrderer fails the first broadcast streams, then replies to the
+// envelopes with the given status
+type flakyOrderer struct {
+	lock     sync.Mutex
+	failures int
+	streams  int
+	status   cb.Status
+}
+
+func (o *flakyOrderer) Broadcast(stream ab.AtomicBroadcast_BroadcastServer) error {
+	o.lock.Lock()
+	o.streams++
+	fail := o.streams <= o.failures
+	o.lock.Unlock()
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return err
+		}
+		if fail {
+			return errors.New("stream broken")
+		}
+		o.lock.Lock()
+		status := o.status
+		o.lock.Unlock()
+		if err := stream.Send(&ab.BroadcastResponse{Status: status}); err != nil {
+			return err
+		}
+	}
+}
+
+func (o *flakyOrderer) set(failures int, status cb.Status) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.failures = failures
+	o.status = status
+}
+
+func (o *flakyOrderer) streamCount() int {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	return o.streams
+}
+
+func (o *flakyOrderer) Deliver(ab.AtomicBroadcast_DeliverServer) error {
+	return errors.New("not implemented")
+}
+
+func TestBroadcastClientRetries(t *testing.T) {
+	os.Setenv("FABRIC_CFG_PATH", "./testdata")
+	defer os.Unsetenv("FABRIC_CFG_PATH")
+	viper.Reset()
+	defer viper.Reset()
+	assert.NoError(t, InitConfig("test"))
+	defer func(interval time.Duration) { broadcastRetryInterval = interval }(broadcastRetryInterval)
+	broadcastRetryInterval = time.Millisecond
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	orderer := &flakyOrderer{failures: 2, status: cb.Status_SUCCESS}
+	server := grpc.NewServer()
+	ab.RegisterAtomicBroadcastServer(server, orderer)
+	go server.Serve(lis)
+	defer server.Stop()
+	viper.Set("orderer.address", lis.Addr().String())
+
+	bc, err := GetBroadcastClient()
+	assert.NoError(t, err)
+	client := bc.(*broadcastClient)
+	conn, err := client.oc.conns.Get(client.oc.address)
+	assert.NoError(t, err)
+
+	// the envelope is sent again over new streams when the stream fails
+	assert.NoError(t, bc.Send(&cb.Envelope{}))
+	assert.Equal(t, 3, orderer.streamCount())
+
+	// the streams share the connection of the client
+	reused, err := client.oc.conns.Get(client.oc.address)
+	assert.NoError(t, err)
+	assert.True(t, conn == reused)
+
+	// the envelopes rejected by the orderer are not sent again
+	orderer.set(0, cb.Status_BAD_REQUEST)
+	assert.EqualError(t, bc.Send(&cb.Envelope{}), "got unexpected status: BAD_REQUEST -- ")
+	assert.Equal(t, 3, orderer.streamCount())
+
+	// the client gives up after the last attempt
+	orderer.set(orderer.streamCount()+broadcastAttempts+1, cb.Status_SUCCESS)
+	bc.Close()
+	bc, err = GetBroadcastClient()
+	assert.NoError(t, err)
+	assert.Error(t, bc.Send(&cb.Envelope{}))
+	assert.Equal(t, 3+broadcastAttempts, orderer.streamCount())
+
+	// closing the client closes its connection
+	client = bc.(*broadcastClient)
+	conn, err = client.oc.conns.Get(client.oc.address)
+	assert.NoError(t, err)
+	bc.Close()
+	assert.Equal(t, connectivity.Shutdown, conn.GetState())
+}
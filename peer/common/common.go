@@ -9,6 +9,8 @@ package common
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -19,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/common/viperutil"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/config"
@@ -76,6 +79,10 @@ var (
 
 	// GetCertificateFnc is a function that returns the client TLS certificate
 	GetCertificateFnc func() (tls.Certificate, error)
+
+	// GetAdminClientFnc is a function that returns a new admin client connection
+	// to the local peer, by default it is set to GetAdminClient function
+	GetAdminClientFnc func() (pb.AdminClient, error)
 )
 
 type commonClient struct {
@@ -92,6 +99,7 @@ func init() {
 	GetDeliverClientFnc = GetDeliverClient
 	GetPeerDeliverClientFnc = GetPeerDeliverClient
 	GetCertificateFnc = GetCertificate
+	GetAdminClientFnc = GetAdminClient
 }
 
 // InitConfig initializes viper config
@@ -259,6 +267,7 @@ func configFromEnv(prefix string) (address, override string, clientConfig comm.C
 		connTimeout = defaultConnTimeout
 	}
 	clientConfig.Timeout = connTimeout
+	clientConfig.Compression = viper.GetBool(prefix + ".client.compression")
 	secOpts := &comm.SecureOptions{
 		UseTLS:            viper.GetBool(prefix + ".tls.enabled"),
 		RequireClientCert: viper.GetBool(prefix + ".tls.clientAuthRequired")}
@@ -270,6 +279,9 @@ func configFromEnv(prefix string) (address, override string, clientConfig comm.C
 			return
 		}
 		secOpts.ServerRootCAs = [][]byte{caPEM}
+		if certHash := viper.GetString(prefix + ".tls.certHash"); certHash != "" {
+			secOpts.VerifyCertificate = pinnedCertificate(certHash)
+		}
 	}
 	if secOpts.RequireClientCert {
 		keyPEM, res := ioutil.ReadFile(config.GetPath(prefix + ".tls.clientKey.file"))
@@ -291,6 +303,20 @@ func configFromEnv(prefix string) (address, override string, clientConfig comm.C
 	return
 }
 
+// pinnedCertificate returns a function checking that the TLS certificate
+// presented by the server has the given hex encoded SHA-256 hash
+func pinnedCertificate(certHash string) func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("no TLS certificate presented by the server")
+		}
+		if hash := hex.EncodeToString(util.ComputeSHA256(rawCerts[0])); !strings.EqualFold(hash, certHash) {
+			return errors.Errorf("the TLS certificate of the server has hash %s, expected %s", hash, certHash)
+		}
+		return nil
+	}
+}
+
 func InitCmd(cmd *cobra.Command, args []string) {
 	err := InitConfig(CmdRoot)
 	if err != nil { // Handle errors reading the config file
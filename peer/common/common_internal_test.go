@@ -0,0 +1,49 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigFromEnvPinnedCertificate(t *testing.T) {
+	os.Setenv("FABRIC_CFG_PATH", "./testdata")
+	defer os.Unsetenv("FABRIC_CFG_PATH")
+	viper.Reset()
+	defer viper.Reset()
+	assert.NoError(t, InitConfig("test"))
+
+	certPEM, err := ioutil.ReadFile("testdata/certs/client.crt")
+	assert.NoError(t, err)
+	block, _ := pem.Decode(certPEM)
+	certHash := hex.EncodeToString(util.ComputeSHA256(block.Bytes))
+
+	viper.Set("orderer.tls.enabled", true)
+	_, _, clientConfig, err := configFromEnv("orderer")
+	assert.NoError(t, err)
+	assert.Nil(t, clientConfig.SecOpts.VerifyCertificate)
+
+	viper.Set("orderer.tls.certHash", certHash)
+	_, _, clientConfig, err = configFromEnv("orderer")
+	assert.NoError(t, err)
+	verify := clientConfig.SecOpts.VerifyCertificate
+	assert.NotNil(t, verify)
+
+	assert.NoError(t, verify([][]byte{block.Bytes}, nil))
+	err = verify([][]byte{[]byte("another certificate")}, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "expected "+certHash)
+	assert.EqualError(t, verify(nil, nil), "no TLS certificate presented by the server")
+}
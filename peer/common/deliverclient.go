@@ -143,6 +143,13 @@ func seekHelper(channelID string, position *ab.SeekPosition, tlsCertHash []byte)
 
 type ordererDeliverService struct {
 	ab.AtomicBroadcast_DeliverClient
+	oc *OrdererClient
+}
+
+// CloseSend closes the deliver stream and the connection to the orderer
+func (o *ordererDeliverService) CloseSend() error {
+	defer o.oc.Close()
+	return o.AtomicBroadcast_DeliverClient.CloseSend()
 }
 
 // NewDeliverClientForOrderer creates a new DeliverClient from an OrdererClient
@@ -155,13 +162,14 @@ func NewDeliverClientForOrderer(channelID string) (*DeliverClient, error) {
 
 	dc, err := oc.Deliver()
 	if err != nil {
+		oc.Close()
 		return nil, errors.WithMessage(err, "failed to create deliver client")
 	}
 	// check for client certificate and create hash if present
 	if len(oc.Certificate().Certificate) > 0 {
 		tlsCertHash = util.ComputeSHA256(oc.Certificate().Certificate[0])
 	}
-	ds := &ordererDeliverService{dc}
+	ds := &ordererDeliverService{AtomicBroadcast_DeliverClient: dc, oc: oc}
 	o := &DeliverClient{
 		Service:     ds,
 		ChannelID:   channelID,
@@ -103,3 +103,11 @@ func (m *mockAdminClient) SetModuleLogLevel(ctx context.Context, env *cb.Envelop
 func (m *mockAdminClient) RevertLogLevels(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*empty.Empty, error) {
 	return &empty.Empty{}, m.err
 }
+
+func (m *mockAdminClient) GetConfigReloadStatus(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.ConfigReloadStatus, error) {
+	return &pb.ConfigReloadStatus{}, m.err
+}
+
+func (m *mockAdminClient) GetGossipMembership(ctx context.Context, in *cb.Envelope, opts ...grpc.CallOption) (*pb.GossipMembership, error) {
+	return &pb.GossipMembership{}, m.err
+}
@@ -13,12 +13,16 @@ import (
 	"github.com/hyperledger/fabric/core/comm"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
 )
 
 // OrdererClient represents a client for communicating with an ordering
 // service
 type OrdererClient struct {
 	commonClient
+	// conns shares the connection to the orderer between the broadcast
+	// and deliver streams of the client
+	conns *comm.ConnectionPool
 }
 
 // NewOrdererClientFromEnv creates an instance of an OrdererClient from the
@@ -36,13 +40,16 @@ func NewOrdererClientFromEnv() (*OrdererClient, error) {
 		commonClient: commonClient{
 			GRPCClient: gClient,
 			address:    address,
-			sn:         override}}
+			sn:         override},
+		conns: comm.NewConnectionPool(func(endpoint string) (*grpc.ClientConn, error) {
+			return gClient.NewConnection(endpoint, override)
+		})}
 	return oClient, nil
 }
 
 // Broadcast returns a broadcast client for the AtomicBroadcast service
 func (oc *OrdererClient) Broadcast() (ab.AtomicBroadcast_BroadcastClient, error) {
-	conn, err := oc.commonClient.NewConnection(oc.address, oc.sn)
+	conn, err := oc.conns.Get(oc.address)
 	if err != nil {
 		return nil, errors.WithMessage(err, fmt.Sprintf("orderer client failed to connect to %s", oc.address))
 	}
@@ -52,7 +59,7 @@ func (oc *OrdererClient) Broadcast() (ab.AtomicBroadcast_BroadcastClient, error)
 
 // Deliver returns a deliver client for the AtomicBroadcast service
 func (oc *OrdererClient) Deliver() (ab.AtomicBroadcast_DeliverClient, error) {
-	conn, err := oc.commonClient.NewConnection(oc.address, oc.sn)
+	conn, err := oc.conns.Get(oc.address)
 	if err != nil {
 		return nil, errors.WithMessage(err, fmt.Sprintf("orderer client failed to connect to %s", oc.address))
 	}
@@ -61,6 +68,11 @@ func (oc *OrdererClient) Deliver() (ab.AtomicBroadcast_DeliverClient, error) {
 
 }
 
+// Close closes the connection to the orderer
+func (oc *OrdererClient) Close() {
+	oc.conns.Close()
+}
+
 // Certificate returns the TLS client certificate (if available)
 func (oc *OrdererClient) Certificate() tls.Certificate {
 	return oc.commonClient.Certificate()
@@ -21,6 +21,7 @@ var (
 	keyFile                    string
 	certFile                   string
 	ordererTLSHostnameOverride string
+	ordererTLSCertHash         string
 	connTimeout                time.Duration
 )
 
@@ -36,6 +37,7 @@ func SetOrdererEnv(cmd *cobra.Command, args []string) {
 	viper.Set("orderer.tls.clientCert.file", certFile)
 	viper.Set("orderer.address", OrderingEndpoint)
 	viper.Set("orderer.tls.serverhostoverride", ordererTLSHostnameOverride)
+	viper.Set("orderer.tls.certHash", ordererTLSCertHash)
 	viper.Set("orderer.tls.enabled", tlsEnabled)
 	viper.Set("orderer.tls.clientAuthRequired", clientAuth)
 	viper.Set("orderer.client.connTimeout", connTimeout)
@@ -59,6 +61,8 @@ func AddOrdererFlags(cmd *cobra.Command) {
 			"mutual TLS communication with the orderer endpoint")
 	flags.StringVarP(&ordererTLSHostnameOverride, "ordererTLSHostnameOverride",
 		"", "", "The hostname override to use when validating the TLS connection to the orderer.")
+	flags.StringVarP(&ordererTLSCertHash, "ordererTLSCertHash",
+		"", "", "The hex encoded SHA-256 hash of the TLS certificate the orderer must present, in addition to being trusted")
 	flags.DurationVarP(&connTimeout, "connTimeout",
 		"", 3*time.Second, "Timeout for client to connect")
 }
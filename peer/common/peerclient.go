@@ -14,6 +14,7 @@ import (
 
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/peer/common/api"
+	cb "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
 )
@@ -106,6 +107,15 @@ func (pc *PeerClient) Admin() (pb.AdminClient, error) {
 	return pb.NewAdminClient(conn), nil
 }
 
+// Diagnostics returns a client for the Diagnostics service
+func (pc *PeerClient) Diagnostics() (cb.DiagnosticsClient, error) {
+	conn, err := pc.commonClient.NewConnection(pc.address, pc.sn)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("diagnostics client failed to connect to %s", pc.address))
+	}
+	return cb.NewDiagnosticsClient(conn), nil
+}
+
 // Certificate returns the TLS client certificate (if available)
 func (pc *PeerClient) Certificate() tls.Certificate {
 	return pc.commonClient.Certificate()
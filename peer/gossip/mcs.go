@@ -51,6 +51,7 @@ type mspMessageCryptoService struct {
 	channelPolicyManagerGetter policies.ChannelPolicyManagerGetter
 	localSigner                crypto.LocalSigner
 	deserializer               mgmt.DeserializersManager
+	hashingAlgorithmGetter     func(channelID string) func([]byte) []byte
 }
 
 // NewMCS creates a new instance of mspMessageCryptoService
@@ -59,8 +60,20 @@ type mspMessageCryptoService struct {
 // 1. a policies.ChannelPolicyManagerGetter that gives access to the policy manager of a given channel via the Manager method.
 // 2. an instance of crypto.LocalSigner
 // 3. an identity deserializer manager
-func NewMCS(channelPolicyManagerGetter policies.ChannelPolicyManagerGetter, localSigner crypto.LocalSigner, deserializer mgmt.DeserializersManager) *mspMessageCryptoService {
-	return &mspMessageCryptoService{channelPolicyManagerGetter: channelPolicyManagerGetter, localSigner: localSigner, deserializer: deserializer}
+// 4. a function returning the hashing algorithm of a given channel, or nil if it is not known,
+// in which case blocks are verified with SHA256. The function itself may be nil.
+func NewMCS(channelPolicyManagerGetter policies.ChannelPolicyManagerGetter, localSigner crypto.LocalSigner, deserializer mgmt.DeserializersManager, hashingAlgorithmGetter func(channelID string) func([]byte) []byte) *mspMessageCryptoService {
+	return &mspMessageCryptoService{channelPolicyManagerGetter: channelPolicyManagerGetter, localSigner: localSigner, deserializer: deserializer, hashingAlgorithmGetter: hashingAlgorithmGetter}
+}
+
+// dataHash hashes the block data with the hashing algorithm of the channel
+func (s *mspMessageCryptoService) dataHash(channelID string, data *pcommon.BlockData) []byte {
+	if s.hashingAlgorithmGetter != nil {
+		if hash := s.hashingAlgorithmGetter(channelID); hash != nil {
+			return data.HashWith(hash)
+		}
+	}
+	return data.Hash()
 }
 
 // ValidateIdentity validates the identity of a remote peer.
@@ -155,7 +168,7 @@ func (s *mspMessageCryptoService) VerifyBlock(chainID common.ChainID, seqNum uin
 
 	// - Verify that Header.DataHash is equal to the hash of block.Data
 	// This is to ensure that the header is consistent with the data carried by this block
-	if !bytes.Equal(block.Data.Hash(), block.Header.DataHash) {
+	if !bytes.Equal(s.dataHash(channelID, block.Data), block.Header.DataHash) {
 		return fmt.Errorf("Header.DataHash is different from Hash(block.Data) for block with id [%d] on channel [%s]", block.Header.Number, chainID)
 	}
 
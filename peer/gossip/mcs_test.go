@@ -51,6 +51,7 @@ func TestPKIidOfCert(t *testing.T) {
 	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetterWithManager{},
 		&mockscrypto.LocalSigner{Identity: []byte("Alice")},
 		deserializersManager,
+		nil,
 	)
 
 	peerIdentity := []byte("Alice")
@@ -80,7 +81,7 @@ func TestPKIidOfCert(t *testing.T) {
 }
 
 func TestPKIidOfNil(t *testing.T) {
-	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager())
+	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager(), nil)
 
 	pkid := msgCryptoService.GetPKIidOfCert(nil)
 	// Check pkid is not nil
@@ -98,6 +99,7 @@ func TestValidateIdentity(t *testing.T) {
 		&mocks.ChannelPolicyManagerGetterWithManager{},
 		&mockscrypto.LocalSigner{Identity: []byte("Charlie")},
 		deserializersManager,
+		nil,
 	)
 
 	err := msgCryptoService.ValidateIdentity([]byte("Alice"))
@@ -129,6 +131,7 @@ func TestSign(t *testing.T) {
 		&mocks.ChannelPolicyManagerGetter{},
 		&mockscrypto.LocalSigner{Identity: []byte("Alice")},
 		mgmt.NewDeserializersManager(),
+		nil,
 	)
 
 	msg := []byte("Hello World!!!")
@@ -159,6 +162,7 @@ func TestVerify(t *testing.T) {
 				"C": &mocks.IdentityDeserializer{Identity: []byte("Dave"), Msg: []byte("msg4"), Mock: mock.Mock{}},
 			},
 		},
+		nil,
 	)
 
 	msg := []byte("msg1")
@@ -213,6 +217,7 @@ func TestVerifyBlock(t *testing.T) {
 				"B": &mocks.IdentityDeserializer{Identity: []byte("Charlie"), Msg: []byte("msg3"), Mock: mock.Mock{}},
 			},
 		},
+		nil,
 	)
 
 	// - Prepare testing valid block, Alice signs it.
@@ -244,6 +249,31 @@ func TestVerifyBlock(t *testing.T) {
 	// Check invalid args
 	assert.Error(t, msgCryptoService.VerifyBlock([]byte("C"), 42, []byte{0, 1, 2, 3, 4}))
 	assert.Error(t, msgCryptoService.VerifyBlock([]byte("C"), 42, nil))
+
+	// - Blocks are verified with the hashing algorithm of the channel
+	blockRaw, msg = mockBlock(t, "C", 42, aliceSigner, nil)
+	policyManagerGetter.Managers["C"].(*mocks.ChannelPolicyManager).Policy.(*mocks.Policy).Deserializer.(*mocks.IdentityDeserializer).Msg = msg
+	msgCryptoService.hashingAlgorithmGetter = func(channelID string) func([]byte) []byte {
+		return util.ComputeSHA3256
+	}
+	err = msgCryptoService.VerifyBlock([]byte("C"), 42, blockRaw)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Header.DataHash is different from Hash(block.Data)")
+}
+
+func TestDataHash(t *testing.T) {
+	data := &common.BlockData{Data: [][]byte{[]byte("tx")}}
+	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetter{}, localmsp.NewSigner(), mgmt.NewDeserializersManager(), nil)
+	assert.Equal(t, data.Hash(), msgCryptoService.dataHash("A", data))
+
+	msgCryptoService.hashingAlgorithmGetter = func(channelID string) func([]byte) []byte {
+		if channelID == "A" {
+			return util.ComputeSHA3256
+		}
+		return nil
+	}
+	assert.Equal(t, data.HashWith(util.ComputeSHA3256), msgCryptoService.dataHash("A", data))
+	assert.Equal(t, data.Hash(), msgCryptoService.dataHash("B", data))
 }
 
 func mockBlock(t *testing.T, channel string, seqNum uint64, localSigner crypto.LocalSigner, dataHash []byte) ([]byte, []byte) {
@@ -330,6 +360,7 @@ func TestExpiration(t *testing.T) {
 		&mocks.ChannelPolicyManagerGetterWithManager{},
 		&mockscrypto.LocalSigner{Identity: []byte("Yacov")},
 		deserializersManager,
+		nil,
 	)
 
 	// Green path I check the expiration date is as expected
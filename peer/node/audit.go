@@ -0,0 +1,32 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/core/audit"
+	"github.com/hyperledger/fabric/core/config"
+	"github.com/spf13/viper"
+)
+
+// newAuditor returns the auditor of the ledgers of the channels when the
+// peer is in audit mode, nil otherwise
+func newAuditor() *audit.Auditor {
+	if !viper.GetBool("peer.audit.enabled") {
+		return nil
+	}
+	reportDir := config.GetPath("peer.audit.reportDir")
+	if reportDir == "" {
+		reportDir = filepath.Join(config.GetPath("peer.fileSystemPath"), "audit")
+	}
+	logger.Warningf("The peer is in audit mode, it does not endorse the proposals of application chaincodes and writes its reports to %s", reportDir)
+	return &audit.Auditor{
+		ReportDir:              reportDir,
+		MaxInvalidTransactions: viper.GetInt("peer.audit.maxInvalidTransactions"),
+	}
+}
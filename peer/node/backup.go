@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/common/ledger/backup"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupChannelID string
+	backupOutputDir string
+)
+
+func backupCmd() *cobra.Command {
+	flags := nodeBackupCmd.Flags()
+	flags.StringVarP(&backupChannelID, "channelID", "c", "", "Channel whose ledger is backed up")
+	flags.StringVarP(&backupOutputDir, "output", "", "", "Directory the backup is written to, which must not exist or be empty")
+	return nodeBackupCmd
+}
+
+var nodeBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Backs up the ledger of a channel.",
+	Long: `Backs up the block files, the private data and the collection config history of the ledger of a channel to a directory, ` +
+		`along with a manifest describing the backup. The peer must be stopped.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected: %s", args)
+		}
+		if backupChannelID == "" {
+			return errors.New("must supply channel ID")
+		}
+		if backupOutputDir == "" {
+			return errors.New("must supply the output directory")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return backupLedger(backupChannelID, backupOutputDir, os.Stdout)
+	},
+}
+
+func backupLedger(channelID, outputDir string, out io.Writer) error {
+	manifest, err := kvledger.BackupLedger(channelID, outputDir)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to back up the ledger of channel [%s]", channelID))
+	}
+	printManifest(out, manifest)
+	return nil
+}
+
+func printManifest(out io.Writer, manifest *backup.Manifest) {
+	fmt.Fprintf(out, "Channel: %s\n", manifest.LedgerID)
+	fmt.Fprintf(out, "Height: %d\n", manifest.Height)
+	fmt.Fprintf(out, "Current block hash: %x\n", manifest.CurrentBlockHash)
+	fmt.Fprintf(out, "Created at: %s\n", manifest.CreatedAt)
+	for _, file := range manifest.Files {
+		fmt.Fprintf(out, "File: %s (%d bytes, sha256 %s)\n", file.Path, file.Size, file.SHA256)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/backup"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupCmd(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "backup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	viper.Set("peer.fileSystemPath", tempDir)
+	defer viper.Reset()
+
+	cmd := backupCmd()
+	cmd.SetArgs([]string{"--output", filepath.Join(tempDir, "backup")})
+	assert.EqualError(t, cmd.Execute(), "must supply channel ID")
+	cmd.SetArgs([]string{"-c", "mychannel", "--output", ""})
+	assert.EqualError(t, cmd.Execute(), "must supply the output directory")
+	cmd.SetArgs([]string{"-c", "mychannel", "--output", filepath.Join(tempDir, "backup")})
+	assert.EqualError(t, cmd.Execute(), "failed to back up the ledger of channel [mychannel]: LedgerID does not exist")
+}
+
+func TestRestoreCmd(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "restore")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	viper.Set("peer.fileSystemPath", tempDir)
+	defer viper.Reset()
+
+	cmd := restoreCmd()
+	cmd.SetArgs([]string{"trailing"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected: [trailing]")
+	cmd.SetArgs([]string{})
+	assert.EqualError(t, cmd.Execute(), "must supply the input directory")
+	cmd.SetArgs([]string{"--input", tempDir, "--verify-only"})
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to verify the backup in ["+tempDir+"]: error reading the backup manifest")
+}
+
+func TestPrintManifest(t *testing.T) {
+	buf := &bytes.Buffer{}
+	printManifest(buf, &backup.Manifest{
+		LedgerID:         "mychannel",
+		Height:           3,
+		CurrentBlockHash: []byte{0xca, 0xfe},
+		CreatedAt:        time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC),
+		Files:            []backup.File{{Path: "blocks/blockfile_000000", Size: 42, SHA256: "abcd"}},
+	})
+	assert.Equal(t, `Channel: mychannel
+Height: 3
+Current block hash: cafe
+Created at: 2018-09-01 12:00:00 +0000 UTC
+File: blocks/blockfile_000000 (42 bytes, sha256 abcd)
+`, buf.String())
+}
@@ -0,0 +1,58 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/core/certexpiry"
+	"github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/spf13/viper"
+)
+
+// certExpirySources returns the sources of the certificates of the peer whose
+// expiration is monitored: the certificates of the local MSPs, the TLS
+// certificates, and the CA certificates of the MSPs of the joined channels
+func certExpirySources() ([]certexpiry.Source, error) {
+	var sources []certexpiry.Source
+	if viper.GetString("peer.localMspType") == msp.ProviderTypeToString(msp.FABRIC) {
+		sources = append(sources, certexpiry.MSPDirSource(viper.GetString("peer.localMspId"), config.GetPath("peer.mspConfigPath")))
+	}
+	msps, err := additionalLocalMsps()
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range msps {
+		sources = append(sources, certexpiry.MSPDirSource(m.MspID, m.MspConfigPath))
+	}
+	if viper.GetBool("peer.tls.enabled") {
+		sources = append(sources,
+			certexpiry.FileSource(certexpiry.TLSServerCert, config.GetPath("peer.tls.cert.file")),
+			certexpiry.FileSource(certexpiry.TLSClientCert, config.GetPath("peer.tls.clientCert.file")),
+			certexpiry.FileSource(certexpiry.TLSCACert, config.GetPath("peer.tls.rootcert.file")),
+		)
+	}
+	return append(sources, channelCerts), nil
+}
+
+// channelCerts supplies the certificates held by the MSPs in the config of the
+// channels the peer joined
+func channelCerts() ([]*certexpiry.Cert, error) {
+	var certs []*certexpiry.Cert
+	for _, ci := range peer.GetChannelsInfo() {
+		res := peer.GetStableChannelConfig(ci.ChannelId)
+		if res == nil {
+			continue
+		}
+		c, err := certexpiry.ChannelConfigCerts(ci.ChannelId, res.ConfigtxValidator().ConfigProto())
+		if err != nil {
+			return certs, err
+		}
+		certs = append(certs, c...)
+	}
+	return certs, nil
+}
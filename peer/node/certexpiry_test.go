@@ -0,0 +1,49 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertExpirySources(t *testing.T) {
+	defer viper.Reset()
+	viper.SetConfigType("yaml")
+
+	require.NoError(t, viper.ReadConfig(bytes.NewBufferString(`peer:
+    localMspType: bccsp
+    mspConfigPath: msp
+    tls:
+        enabled: true
+    additionalLocalMsps:
+      - mspID: Org2MSP
+        mspConfigPath: org2msp
+`)))
+	sources, err := certExpirySources()
+	assert.NoError(t, err)
+	// the local MSP, the additional local MSP, the 3 TLS certificates and the channels
+	assert.Len(t, sources, 6)
+
+	require.NoError(t, viper.ReadConfig(bytes.NewBufferString("peer:\n    localMspType: idemix\n")))
+	sources, err = certExpirySources()
+	assert.NoError(t, err)
+	assert.Len(t, sources, 1)
+
+	certs, err := sources[0]()
+	assert.NoError(t, err)
+	assert.Empty(t, certs)
+
+	require.NoError(t, viper.ReadConfig(bytes.NewBufferString("peer:\n    additionalLocalMsps:\n      - Org2MSP\n")))
+	_, err = certExpirySources()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "could not parse peer.additionalLocalMsps")
+}
@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/core/explorer"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/spf13/viper"
+)
+
+// startExplorerServer serves the read-only JSON endpoints over the blocks
+// and transactions of the channels joined by the peer
+func startExplorerServer() {
+	listenAddress := viper.GetString("peer.explorer.listenAddress")
+	tlsConfig := explorer.TLSConfig{
+		Enabled:            viper.GetBool("peer.explorer.tls.enabled"),
+		CertFile:           config.GetPath("peer.explorer.tls.cert.file"),
+		KeyFile:            config.GetPath("peer.explorer.tls.key.file"),
+		ClientAuthRequired: viper.GetBool("peer.explorer.tls.clientAuthRequired"),
+	}
+	for _, file := range viper.GetStringSlice("peer.explorer.tls.clientRootCAs.files") {
+		tlsConfig.ClientRootCAs = append(tlsConfig.ClientRootCAs, config.TranslatePath(filepath.Dir(viper.ConfigFileUsed()), file))
+	}
+	server, err := explorer.NewServer(listenAddress, tlsConfig, explorerSupport{})
+	if err != nil {
+		logger.Fatalf("Failed to create explorer server: %s", err)
+	}
+	logger.Infof("Starting explorer server with listenAddress = %s", server.Addr())
+	go func() {
+		if err := server.Start(); err != nil {
+			logger.Errorf("Explorer server exited with error: %s", err)
+		}
+	}()
+}
+
+// explorerSupport exposes the ledgers of the channels joined by the peer to
+// the explorer
+type explorerSupport struct{}
+
+func (explorerSupport) Channels() []string {
+	var channels []string
+	for _, channelInfo := range peer.GetChannelsInfo() {
+		channels = append(channels, channelInfo.ChannelId)
+	}
+	return channels
+}
+
+func (explorerSupport) Ledger(channelID string) explorer.Ledger {
+	l := peer.GetLedger(channelID)
+	if l == nil {
+		return nil
+	}
+	return l
+}
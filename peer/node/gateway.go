@@ -0,0 +1,177 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/policies"
+	cc "github.com/hyperledger/fabric/core/cclifecycle"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/gateway"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/discovery/endorsement"
+	discacl "github.com/hyperledger/fabric/discovery/support/acl"
+	ccsupport "github.com/hyperledger/fabric/discovery/support/chaincode"
+	gossipsupport "github.com/hyperledger/fabric/discovery/support/gossip"
+	"github.com/hyperledger/fabric/gossip/service"
+	cb "github.com/hyperledger/fabric/protos/common"
+	gp "github.com/hyperledger/fabric/protos/gateway"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+)
+
+// registerGatewayService registers the gateway service, which endorses
+// proposals with the peers chosen by the endorsement analyzer of the
+// discovery service and submits the transactions to the ordering service
+func registerGatewayService(peerServer *comm.GRPCServer, polMgr policies.ChannelPolicyManagerGetter, lc *cc.Lifecycle, localEndorser pb.EndorserServer, localEndpoint string) {
+	acl := discacl.NewDiscoverySupport(
+		discacl.NewChannelVerifier(policies.ChannelApplicationWriters, polMgr),
+		nil,
+		discacl.ChannelConfigGetterFunc(peer.GetStableChannelConfig),
+	)
+	ea := endorsement.NewEndorsementAnalyzer(gossipsupport.NewDiscoverySupport(service.GetGossipService()), ccsupport.NewDiscoverySupport(lc), acl, lc)
+	support := &gatewaySupport{
+		tlsEnabled:    peerServer.TLSEnabled(),
+		localEndorser: localEndorser,
+		localEndpoint: localEndpoint,
+		conns:         make(map[string]*grpc.ClientConn),
+		ordererConns:  make(map[string]*comm.ConnectionPool),
+	}
+	verifier := discacl.NewChannelVerifier(policies.ChannelApplicationReaders, polMgr)
+	server, err := gateway.NewServer(ea, support, verifier, gateway.Config{
+		PeerSelection: viper.GetString("peer.gateway.peerSelection"),
+		LocalMSPID:    viper.GetString("peer.localMspId"),
+	})
+	if err != nil {
+		logger.Panicf("Failed creating the gateway service: %s", err)
+	}
+	gp.RegisterGatewayServer(peerServer.Server(), server)
+	logger.Info("Gateway service activated")
+}
+
+// gatewaySupport connects the gateway to the endorsers of the channels and to
+// the ordering service
+type gatewaySupport struct {
+	tlsEnabled    bool
+	localEndorser pb.EndorserServer
+	localEndpoint string
+
+	lock  sync.Mutex
+	conns map[string]*grpc.ClientConn
+	// ordererConns holds the connections to the orderers of each channel,
+	// which are shared by the transactions submitted to the channel
+	ordererConns map[string]*comm.ConnectionPool
+}
+
+func (s *gatewaySupport) Endorser(endpoint string) (gateway.Endorser, error) {
+	if endpoint == s.localEndpoint {
+		return s.localEndorser, nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	conn, exists := s.conns[endpoint]
+	if !exists {
+		var err error
+		conn, err = comm.NewClientConnectionWithAddress(endpoint, false, s.tlsEnabled, comm.GetCredentialSupport().GetPeerCredentials(), nil)
+		if err != nil {
+			return nil, err
+		}
+		s.conns[endpoint] = conn
+	}
+	return &endorserClient{client: pb.NewEndorserClient(conn)}, nil
+}
+
+func (s *gatewaySupport) Broadcast(ctx context.Context, channelID string, env *cb.Envelope) (*ab.BroadcastResponse, error) {
+	resources := peer.GetStableChannelConfig(channelID)
+	if resources == nil {
+		return nil, errors.Errorf("channel %s not found", channelID)
+	}
+	pool := s.ordererConnectionPool(channelID)
+
+	var err error
+	addresses := resources.ChannelConfig().OrdererAddresses()
+	for _, i := range rand.Perm(len(addresses)) {
+		var conn *grpc.ClientConn
+		conn, err = pool.Get(addresses[i])
+		if err != nil {
+			logger.Warningf("Failed to connect to orderer %s: %s", addresses[i], err)
+			continue
+		}
+		var response *ab.BroadcastResponse
+		response, err = broadcast(ctx, conn, env)
+		if err == nil {
+			return response, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		logger.Warningf("Failed to broadcast to orderer %s: %s", addresses[i], err)
+		// the connection is re-established by the next transaction, with
+		// the credentials of the current config of the channel
+		pool.Evict(addresses[i])
+	}
+	if err == nil {
+		return nil, errors.Errorf("no orderer is defined for channel %s", channelID)
+	}
+	return nil, err
+}
+
+// ordererConnectionPool returns the pool of the connections to the orderers
+// of the channel
+func (s *gatewaySupport) ordererConnectionPool(channelID string) *comm.ConnectionPool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	pool, exists := s.ordererConns[channelID]
+	if !exists {
+		pool = comm.NewConnectionPool(func(endpoint string) (*grpc.ClientConn, error) {
+			creds, err := comm.GetCredentialSupport().GetDeliverServiceCredentials(channelID)
+			if err != nil {
+				return nil, err
+			}
+			return comm.NewClientConnectionWithAddress(endpoint, true, s.tlsEnabled, creds, nil)
+		})
+		s.ordererConns[channelID] = pool
+	}
+	return pool
+}
+
+func broadcast(ctx context.Context, conn *grpc.ClientConn, env *cb.Envelope) (*ab.BroadcastResponse, error) {
+	stream, err := ab.NewAtomicBroadcastClient(conn).Broadcast(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.CloseSend()
+	if err := stream.Send(env); err != nil {
+		return nil, err
+	}
+	return stream.Recv()
+}
+
+func (s *gatewaySupport) Ledger(channelID string) gateway.Ledger {
+	l := peer.GetLedger(channelID)
+	if l == nil {
+		return nil
+	}
+	return l
+}
+
+// endorserClient adapts the client of the endorser service of a peer to the
+// gateway
+type endorserClient struct {
+	client pb.EndorserClient
+}
+
+func (e *endorserClient) ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+	return e.client.ProcessProposal(ctx, signedProp)
+}
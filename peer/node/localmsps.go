@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/viperutil"
+	"github.com/hyperledger/fabric/core/config"
+	endorsement "github.com/hyperledger/fabric/core/handlers/endorsement/api/identities"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// additionalLocalMsp is an entry of peer.additionalLocalMsps
+type additionalLocalMsp struct {
+	MspID         string `mapstructure:"mspID"`
+	MspConfigPath string `mapstructure:"mspConfigPath"`
+}
+
+// additionalLocalMsps returns the entries of peer.additionalLocalMsps, with
+// their paths relative to the config file translated
+func additionalLocalMsps() ([]additionalLocalMsp, error) {
+	var msps []additionalLocalMsp
+	if err := viperutil.EnhancedExactUnmarshalKey("peer.additionalLocalMsps", &msps); err != nil {
+		return nil, errors.WithMessage(err, "could not parse peer.additionalLocalMsps")
+	}
+	configDir := filepath.Dir(viper.ConfigFileUsed())
+	for i := range msps {
+		msps[i].MspConfigPath = config.TranslatePath(configDir, msps[i].MspConfigPath)
+	}
+	return msps, nil
+}
+
+// loadAdditionalLocalMsps loads the local MSPs in peer.additionalLocalMsps
+func loadAdditionalLocalMsps() error {
+	msps, err := additionalLocalMsps()
+	if err != nil {
+		return err
+	}
+	if len(msps) == 0 {
+		return nil
+	}
+
+	var bccspConfig *factory.FactoryOpts
+	if err := viperutil.EnhancedExactUnmarshalKey("peer.BCCSP", &bccspConfig); err != nil {
+		return errors.WithMessage(err, "could not parse peer.BCCSP")
+	}
+
+	for _, m := range msps {
+		dir := m.MspConfigPath
+		if err := mgmt.LoadAdditionalLocalMsp(dir, bccspConfig, m.MspID); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed loading local MSP %s from %s", m.MspID, dir))
+		}
+		logger.Infof("Loaded additional local MSP %s from %s", m.MspID, dir)
+	}
+	return nil
+}
+
+// channelSigningIdentity returns the identity the peer endorses with on a channel
+func channelSigningIdentity(channel string) (endorsement.SigningIdentity, error) {
+	return mgmt.GetLocalSigningIdentityForChannel(channel)
+}
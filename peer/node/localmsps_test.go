@@ -0,0 +1,29 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAdditionalLocalMsps(t *testing.T) {
+	defer viper.Reset()
+	viper.SetConfigType("yaml")
+
+	require.NoError(t, viper.ReadConfig(bytes.NewBufferString("peer:\n    additionalLocalMsps:\n")))
+	assert.NoError(t, loadAdditionalLocalMsps())
+
+	require.NoError(t, viper.ReadConfig(bytes.NewBufferString("peer:\n    additionalLocalMsps:\n      - Org2MSP\n")))
+	err := loadAdditionalLocalMsps()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "could not parse peer.additionalLocalMsps")
+}
@@ -0,0 +1,98 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"sort"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/service"
+	gproto "github.com/hyperledger/fabric/protos/gossip"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// membershipSource is the part of the gossip service the membership view is
+// assembled from.
+type membershipSource interface {
+	SelfMembershipInfo() discovery.NetworkMember
+	SelfChannelInfo(gossipcommon.ChainID) *gproto.SignedGossipMessage
+	Peers() []discovery.NetworkMember
+	PeersOfChannel(gossipcommon.ChainID) []discovery.NetworkMember
+	IdentityInfo() api.PeerIdentitySet
+}
+
+// gossipMembership reports the membership view of the gossip service to the
+// admin service. The gossip service is looked up on every request as it is
+// initialized after the admin service is started.
+type gossipMembership struct {
+	source func() membershipSource
+}
+
+func newGossipMembership() *gossipMembership {
+	return &gossipMembership{
+		source: func() membershipSource {
+			if gs := service.GetGossipService(); gs != nil {
+				return gs
+			}
+			return nil
+		},
+	}
+}
+
+// Membership returns the alive peers of the channel, along with their ledger
+// heights, or the alive peers of the whole network if channel is empty.
+func (g *gossipMembership) Membership(channel string) (*pb.GossipMembership, error) {
+	source := g.source()
+	if source == nil {
+		return nil, errors.New("gossip service is not initialized")
+	}
+
+	orgs := map[string]string{}
+	for _, id := range source.IdentityInfo() {
+		orgs[string(id.PKIId)] = string(id.Organization)
+	}
+	member := func(nm discovery.NetworkMember) *pb.GossipMember {
+		m := &pb.GossipMember{
+			Endpoint:         nm.Endpoint,
+			InternalEndpoint: nm.InternalEndpoint,
+			Mspid:            orgs[string(nm.PKIid)],
+			PkiId:            nm.PKIid,
+		}
+		if nm.Properties != nil {
+			m.LedgerHeight = nm.Properties.LedgerHeight
+		}
+		return m
+	}
+
+	self := source.SelfMembershipInfo()
+	var alive []discovery.NetworkMember
+	if channel == "" {
+		alive = source.Peers()
+	} else {
+		stateInfo := source.SelfChannelInfo(gossipcommon.ChainID(channel))
+		if stateInfo == nil {
+			return nil, errors.Errorf("peer is not part of channel %s", channel)
+		}
+		self.Properties = stateInfo.GetStateInfo().GetProperties()
+		alive = source.PeersOfChannel(gossipcommon.ChainID(channel))
+	}
+
+	membership := &pb.GossipMembership{
+		Channel: channel,
+		Self:    member(self),
+	}
+	for _, nm := range alive {
+		membership.Alive = append(membership.Alive, member(nm))
+	}
+	sort.Slice(membership.Alive, func(i, j int) bool {
+		return membership.Alive[i].Endpoint < membership.Alive[j].Endpoint
+	})
+	return membership, nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	gproto "github.com/hyperledger/fabric/protos/gossip"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMembershipSource struct {
+	channels map[string]uint64
+}
+
+func (f *fakeMembershipSource) SelfMembershipInfo() discovery.NetworkMember {
+	return discovery.NetworkMember{Endpoint: "p0:7051", InternalEndpoint: "p0:7051", PKIid: gossipcommon.PKIidType("p0")}
+}
+
+func (f *fakeMembershipSource) SelfChannelInfo(channel gossipcommon.ChainID) *gproto.SignedGossipMessage {
+	height, exists := f.channels[string(channel)]
+	if !exists {
+		return nil
+	}
+	return &gproto.SignedGossipMessage{
+		GossipMessage: &gproto.GossipMessage{
+			Content: &gproto.GossipMessage_StateInfo{
+				StateInfo: &gproto.StateInfo{Properties: &gproto.Properties{LedgerHeight: height}},
+			},
+		},
+	}
+}
+
+func (f *fakeMembershipSource) Peers() []discovery.NetworkMember {
+	return []discovery.NetworkMember{
+		{Endpoint: "p2:7051", PKIid: gossipcommon.PKIidType("p2")},
+		{Endpoint: "p1:7051", PKIid: gossipcommon.PKIidType("p1")},
+	}
+}
+
+func (f *fakeMembershipSource) PeersOfChannel(gossipcommon.ChainID) []discovery.NetworkMember {
+	return []discovery.NetworkMember{
+		{Endpoint: "p1:7051", PKIid: gossipcommon.PKIidType("p1"), Properties: &gproto.Properties{LedgerHeight: 7}},
+	}
+}
+
+func (f *fakeMembershipSource) IdentityInfo() api.PeerIdentitySet {
+	return api.PeerIdentitySet{
+		{PKIId: gossipcommon.PKIidType("p0"), Organization: api.OrgIdentityType("Org1MSP")},
+		{PKIId: gossipcommon.PKIidType("p1"), Organization: api.OrgIdentityType("Org1MSP")},
+		{PKIId: gossipcommon.PKIidType("p2"), Organization: api.OrgIdentityType("Org2MSP")},
+	}
+}
+
+func TestGossipMembership(t *testing.T) {
+	g := &gossipMembership{source: func() membershipSource { return nil }}
+	_, err := g.Membership("")
+	assert.EqualError(t, err, "gossip service is not initialized")
+
+	g.source = func() membershipSource {
+		return &fakeMembershipSource{channels: map[string]uint64{"mychannel": 9}}
+	}
+
+	membership, err := g.Membership("")
+	require.NoError(t, err)
+	assert.Equal(t, &pb.GossipMembership{
+		Self: &pb.GossipMember{Endpoint: "p0:7051", InternalEndpoint: "p0:7051", Mspid: "Org1MSP", PkiId: []byte("p0")},
+		Alive: []*pb.GossipMember{
+			{Endpoint: "p1:7051", Mspid: "Org1MSP", PkiId: []byte("p1")},
+			{Endpoint: "p2:7051", Mspid: "Org2MSP", PkiId: []byte("p2")},
+		},
+	}, membership)
+
+	membership, err = g.Membership("mychannel")
+	require.NoError(t, err)
+	assert.Equal(t, &pb.GossipMembership{
+		Channel: "mychannel",
+		Self:    &pb.GossipMember{Endpoint: "p0:7051", InternalEndpoint: "p0:7051", Mspid: "Org1MSP", PkiId: []byte("p0"), LedgerHeight: 9},
+		Alive: []*pb.GossipMember{
+			{Endpoint: "p1:7051", Mspid: "Org1MSP", PkiId: []byte("p1"), LedgerHeight: 7},
+		},
+	}, membership)
+
+	_, err = g.Membership("otherchannel")
+	assert.EqualError(t, err, "peer is not part of channel otherchannel")
+}
@@ -26,7 +26,7 @@ import (
 
 const (
 	nodeFuncName = "node"
-	nodeCmdDes   = "Operate a peer node: start|status."
+	nodeCmdDes   = "Operate a peer node: start|status|ping|backup|restore."
 )
 
 var logger = flogging.MustGetLogger("nodeCmd")
@@ -35,6 +35,11 @@ var logger = flogging.MustGetLogger("nodeCmd")
 func Cmd() *cobra.Command {
 	nodeCmd.AddCommand(startCmd())
 	nodeCmd.AddCommand(statusCmd())
+	nodeCmd.AddCommand(pingCmd())
+	nodeCmd.AddCommand(backupCmd())
+	nodeCmd.AddCommand(restoreCmd())
+	nodeCmd.AddCommand(snapshotCmd())
+	nodeCmd.AddCommand(joinBySnapshotCmd())
 
 	return nodeCmd
 }
@@ -0,0 +1,179 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/diagnostics"
+	"github.com/hyperledger/fabric/msp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	grpcpeer "google.golang.org/grpc/peer"
+)
+
+var (
+	pingTLSRootCertFile string
+	pingConfigBlockFile string
+)
+
+func pingCmd() *cobra.Command {
+	nodePingCmd.Flags().StringVarP(&pingTLSRootCertFile, "tlsRootCertFile", "", "",
+		"If TLS is enabled, the path to the TLS root cert file of the node to ping")
+	nodePingCmd.Flags().StringVarP(&pingConfigBlockFile, "configBlock", "", "",
+		"The path to a config block of a channel of the node to ping, whose MSPs verify the node if it is not a member of the local MSP")
+	return nodePingCmd
+}
+
+var nodePingCmd = &cobra.Command{
+	Use:   "ping <endpoint>",
+	Short: "Pings a peer or an orderer.",
+	Long:  `Pings a peer or an orderer and reports the round trip time, the TLS connection details and the MSP of the node.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("expected exactly one endpoint, got %d arguments", len(args))
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return ping(args[0], pingTLSRootCertFile, pingConfigBlockFile, os.Stdout)
+	},
+}
+
+var tlsVersions = map[uint16]string{
+	tls.VersionTLS10: "TLS 1.0",
+	tls.VersionTLS11: "TLS 1.1",
+	tls.VersionTLS12: "TLS 1.2",
+}
+
+var tlsCipherSuites = map[uint16]string{
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256:   "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384:   "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256: "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384: "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	tls.TLS_RSA_WITH_AES_128_GCM_SHA256:         "TLS_RSA_WITH_AES_128_GCM_SHA256",
+	tls.TLS_RSA_WITH_AES_256_GCM_SHA384:         "TLS_RSA_WITH_AES_256_GCM_SHA384",
+}
+
+func ping(endpoint, tlsRootCertFile, configBlockFile string, out io.Writer) error {
+	deserializers, err := pingDeserializers(configBlockFile)
+	if err != nil {
+		return err
+	}
+	client, err := common.NewPeerClientForAddress(endpoint, tlsRootCertFile)
+	if err != nil {
+		return err
+	}
+	diagClient, err := client.Diagnostics()
+	if err != nil {
+		return err
+	}
+	signer, err := common.GetDefaultSignerFnc()
+	if err != nil {
+		return errors.Errorf("failed obtaining default signer: %v", err)
+	}
+	nonce, err := crypto.GetRandomNonce()
+	if err != nil {
+		return errors.WithMessage(err, "failed creating nonce")
+	}
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_MESSAGE, "", crypto.NewSignatureHeaderCreator(signer), &cb.PingRequest{Nonce: nonce}, 0, 0)
+	if err != nil {
+		return errors.WithMessage(err, "cannot create signed envelope")
+	}
+
+	remote := &grpcpeer.Peer{}
+	start := time.Now()
+	resp, err := diagClient.Ping(context.Background(), env, grpc.Peer(remote))
+	rtt := time.Since(start)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed pinging %s", endpoint))
+	}
+
+	payload, mspID, err := diagnostics.VerifyResponse(deserializers, resp)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed verifying the response of %s", endpoint))
+	}
+	if !bytes.Equal(payload.Nonce, nonce) {
+		return errors.Errorf("%s responded with a wrong nonce", endpoint)
+	}
+
+	fmt.Fprintf(out, "Endpoint: %s\n", endpoint)
+	fmt.Fprintf(out, "Round trip time: %s\n", rtt)
+	fmt.Fprintf(out, "MSP ID: %s\n", mspID)
+	fmt.Fprintf(out, "Version: %s\n", payload.Version)
+	if ts, err := ptypes.Timestamp(payload.Timestamp); err == nil {
+		fmt.Fprintf(out, "Remote time: %s\n", ts)
+	}
+	printTLSInfo(out, remote)
+	return nil
+}
+
+// pingDeserializers returns the deserializers of the local MSP and, if a
+// config block is given, of the MSPs of its channel.
+func pingDeserializers(configBlockFile string) ([]msp.IdentityDeserializer, error) {
+	deserializers := []msp.IdentityDeserializer{mspmgmt.GetLocalMSP()}
+	if configBlockFile == "" {
+		return deserializers, nil
+	}
+	blockBytes, err := ioutil.ReadFile(configBlockFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading config block")
+	}
+	block, err := utils.GetBlockFromBlockBytes(blockBytes)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed unmarshaling config block")
+	}
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed extracting config envelope")
+	}
+	bundle, err := channelconfig.NewBundleFromEnvelope(env)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed loading channel config")
+	}
+	return append(deserializers, bundle.MSPManager()), nil
+}
+
+func printTLSInfo(out io.Writer, remote *grpcpeer.Peer) {
+	tlsInfo, ok := remote.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		fmt.Fprintln(out, "TLS: disabled")
+		return
+	}
+	state := tlsInfo.State
+	version, ok := tlsVersions[state.Version]
+	if !ok {
+		version = fmt.Sprintf("%#04x", state.Version)
+	}
+	cipherSuite, ok := tlsCipherSuites[state.CipherSuite]
+	if !ok {
+		cipherSuite = fmt.Sprintf("%#04x", state.CipherSuite)
+	}
+	fmt.Fprintf(out, "TLS version: %s\n", version)
+	fmt.Fprintf(out, "TLS cipher suite: %s\n", cipherSuite)
+	if len(state.PeerCertificates) > 0 {
+		cert := state.PeerCertificates[0]
+		fmt.Fprintf(out, "TLS server certificate subject: %s\n", cert.Subject.CommonName)
+		fmt.Fprintf(out, "TLS server certificate issuer: %s\n", cert.Issuer.CommonName)
+		fmt.Fprintf(out, "TLS server certificate expiration: %s\n", cert.NotAfter)
+	}
+}
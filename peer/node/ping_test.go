@@ -0,0 +1,50 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/diagnostics"
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/msp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPing(t *testing.T) {
+	assert.NoError(t, msptesttools.LoadMSPSetupForTesting())
+	defer func(f func() (msp.SigningIdentity, error)) { common.GetDefaultSignerFnc = f }(common.GetDefaultSignerFnc)
+	common.GetDefaultSignerFnc = func() (msp.SigningIdentity, error) {
+		return mspmgmt.GetLocalSigningIdentityOrPanic(), nil
+	}
+
+	server, err := comm.NewGRPCServer("localhost:0", comm.ServerConfig{})
+	assert.NoError(t, err)
+	cb.RegisterDiagnosticsServer(server.Server(), diagnostics.NewServer(localmsp.NewSigner(), diagnosticsDeserializers, time.Minute))
+	go server.Start()
+	defer server.Stop()
+
+	out := &bytes.Buffer{}
+	err = ping(server.Address(), "", "", out)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "Endpoint: "+server.Address())
+	assert.Contains(t, out.String(), "MSP ID: SampleOrg")
+	assert.Contains(t, out.String(), "TLS: disabled")
+
+	err = ping("", "", "", out)
+	assert.EqualError(t, err, "peer address must be set")
+
+	err = ping(server.Address(), "", "nonexistent.block", out)
+	assert.Contains(t, err.Error(), "failed reading config block")
+}
@@ -0,0 +1,145 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/viperutil"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// logModulesFromViper are the modules whose levels may be set individually
+// in the logging section of core.yaml.
+var logModulesFromViper = []string{"msp", "gossip", "ledger", "cauthdsl", "policies", "grpc", "peer.gossip"}
+
+// reloadableSections lists the parts of core.yaml that take effect without a
+// restart. Values under the keepalive client and query limit keys are read
+// through viperutil.Reloadable whenever a connection is established or a
+// query is executed, so they only need to be committed to it.
+//
+// The same goes for the gossip tuning keys below, which are read whenever a
+// gossip connection is established, a block is committed or private data is
+// disseminated. The rest of the gossip section, such as the fanout and the
+// push and pull intervals, is copied into the gossip configuration when the
+// gossip service starts, so changing it requires a restart.
+var reloadableSections = []viperutil.ReloadSection{
+	{Key: "logging", Apply: applyLogging},
+	{Key: "peer.keepalive.client"},
+	{Key: "peer.keepalive.deliveryClient"},
+	{Key: "peer.gossip.connTimeout"},
+	{Key: "peer.gossip.sendBuffSize"},
+	{Key: "peer.gossip.recvBuffSize"},
+	{Key: "peer.gossip.nonBlockingCommitMode"},
+	{Key: "peer.gossip.pvtData.pullRetryThreshold"},
+	{Key: "peer.gossip.pvtData.pushAckTimeout"},
+	{Key: "ledger.state.totalQueryLimit"},
+	{Key: "ledger.state.chaincodeQueryLimits"},
+	{Key: "ledger.state.couchDBConfig.internalQueryLimit"},
+}
+
+func applyLogging(v *viper.Viper) error {
+	// the --logging-level flag overrides the configuration file
+	spec := viper.GetString("logging_level")
+	if spec == "" {
+		spec = v.GetString("logging.level")
+	}
+	if err := flogging.Global.ActivateSpec(spec); err != nil {
+		return err
+	}
+	for _, module := range logModulesFromViper {
+		level := v.GetString("logging." + module)
+		if level == "" {
+			continue
+		}
+		if !flogging.IsValidLevel(level) {
+			return errors.Errorf("invalid log level provided for module %s: %s", module, level)
+		}
+		if err := flogging.SetModuleLevels("^"+module, level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadPeerConfig reads a fresh copy of the peer configuration from the file
+// the peer was started with, honoring the same environment overrides.
+func loadPeerConfig() (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigFile(viper.ConfigFileUsed())
+	v.SetEnvPrefix(common.CmdRoot)
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// configReloader reloads the peer configuration on SIGHUP and reports the
+// outcome of the latest reload to the admin service.
+type configReloader struct {
+	*viperutil.Reloader
+}
+
+func newConfigReloader() *configReloader {
+	return &configReloader{
+		Reloader: &viperutil.Reloader{
+			Live:     viperutil.Reloadable,
+			Load:     loadPeerConfig,
+			Sections: reloadableSections,
+		},
+	}
+}
+
+// handleSignals reloads the configuration every time SIGHUP is received.
+func (c *configReloader) handleSignals() {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	go func() {
+		for range sighup {
+			c.reload()
+		}
+	}()
+}
+
+func (c *configReloader) reload() {
+	status := c.Reload()
+	if status.Err != nil {
+		logger.Errorf("Configuration reload failed: %s", status.Err)
+	}
+	if len(status.Applied) > 0 {
+		logger.Infof("Configuration reload applied: %s", strings.Join(status.Applied, ", "))
+	}
+	if len(status.RequiresRestart) > 0 {
+		logger.Warningf("Configuration changes require a restart to take effect: %s", strings.Join(status.RequiresRestart, ", "))
+	}
+}
+
+// ReloadStatus returns the outcome of the latest reload.
+func (c *configReloader) ReloadStatus() *pb.ConfigReloadStatus {
+	status := c.Status()
+	result := &pb.ConfigReloadStatus{
+		Applied:         status.Applied,
+		RequiresRestart: status.RequiresRestart,
+	}
+	if !status.Time.IsZero() {
+		result.ReloadTime, _ = ptypes.TimestampProto(status.Time)
+	}
+	if status.Err != nil {
+		result.Error = status.Err.Error()
+	}
+	return result
+}
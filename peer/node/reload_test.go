@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/viperutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyLogging(t *testing.T) {
+	defer flogging.Reset()
+
+	v := viper.New()
+	flogging.MustGetLogger("gossip/discovery")
+	v.Set("logging.level", "warning")
+	v.Set("logging.gossip", "debug")
+	require.NoError(t, applyLogging(v))
+	assert.Equal(t, "WARN", flogging.GetModuleLevel("core"))
+	assert.Equal(t, "DEBUG", flogging.GetModuleLevel("gossip/discovery"))
+
+	v.Set("logging.gossip", "bogus")
+	assert.Error(t, applyLogging(v))
+}
+
+func TestConfigReloader(t *testing.T) {
+	defer viper.Reset()
+	defer viperutil.Reloadable.Reset()
+	defer flogging.Reset()
+
+	tempDir, err := ioutil.TempDir("", "reload")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	configFile := filepath.Join(tempDir, "core.yaml")
+	write := func(data string) {
+		require.NoError(t, ioutil.WriteFile(configFile, []byte(data), 0644))
+	}
+
+	write("logging:\n    level: info\npeer:\n    keepalive:\n        client:\n            interval: 60s\n    gossip:\n        maxBlockCountToStore: 100\n        pvtData:\n            pushAckTimeout: 3s\n")
+	viper.SetConfigFile(configFile)
+	require.NoError(t, viper.ReadInConfig())
+
+	reloader := newConfigReloader()
+	status := reloader.ReloadStatus()
+	assert.Nil(t, status.ReloadTime)
+
+	write("logging:\n    level: info\npeer:\n    keepalive:\n        client:\n            interval: 30s\n    gossip:\n        maxBlockCountToStore: 10\n        pvtData:\n            pushAckTimeout: 5s\n")
+	reloader.reload()
+	status = reloader.ReloadStatus()
+	assert.NotNil(t, status.ReloadTime)
+	assert.Empty(t, status.Error)
+	assert.Equal(t, []string{"peer.gossip.pvtData.pushAckTimeout", "peer.keepalive.client.interval"}, status.Applied)
+	assert.Equal(t, []string{"peer.gossip.maxBlockCountToStore"}, status.RequiresRestart)
+	assert.Equal(t, 30*time.Second, viperutil.Reloadable.GetDuration("peer.keepalive.client.interval"))
+	assert.Equal(t, 5*time.Second, viperutil.Reloadable.GetDuration("peer.gossip.pvtData.pushAckTimeout"))
+	assert.Equal(t, 100, viperutil.Reloadable.GetInt("peer.gossip.maxBlockCountToStore"))
+	assert.Equal(t, "60s", viper.GetString("peer.keepalive.client.interval"), "the global viper must not be written")
+
+	os.Remove(configFile)
+	reloader.reload()
+	assert.Contains(t, reloader.ReloadStatus().Error, "failed reloading configuration")
+}
+
+func TestReloadStatusError(t *testing.T) {
+	reloader := &configReloader{Reloader: &viperutil.Reloader{
+		Live: viper.New(),
+		Load: func() (*viper.Viper, error) { return nil, errors.New("boom") },
+	}}
+	reloader.reload()
+	assert.Equal(t, "failed reloading configuration: boom", reloader.ReloadStatus().Error)
+}
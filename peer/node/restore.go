@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	restoreInputDir   string
+	restoreVerifyOnly bool
+)
+
+func restoreCmd() *cobra.Command {
+	flags := nodeRestoreCmd.Flags()
+	flags.StringVarP(&restoreInputDir, "input", "", "", "Directory holding the backup")
+	flags.BoolVarP(&restoreVerifyOnly, "verify-only", "", false, "Verify the backup without restoring it")
+	return nodeRestoreCmd
+}
+
+var nodeRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restores the ledger of a channel from a backup.",
+	Long: `Verifies a backup made by the backup command and restores the ledger of the channel it holds. ` +
+		`The channel must not exist on the peer, and the peer must be stopped. ` +
+		`The state and history databases of the ledger are rebuilt from the blocks when the peer is started.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected: %s", args)
+		}
+		if restoreInputDir == "" {
+			return errors.New("must supply the input directory")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return restoreLedger(restoreInputDir, restoreVerifyOnly, os.Stdout)
+	},
+}
+
+func restoreLedger(inputDir string, verifyOnly bool, out io.Writer) error {
+	if verifyOnly {
+		manifest, err := kvledger.VerifyBackup(inputDir)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed to verify the backup in [%s]", inputDir))
+		}
+		printManifest(out, manifest)
+		return nil
+	}
+	manifest, err := kvledger.RestoreLedger(inputDir)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to restore the backup in [%s]", inputDir))
+	}
+	printManifest(out, manifest)
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// drainingEndorser rejects the proposals received once it is drained, so that
+// clients send them to another peer, and keeps track of the proposals being
+// endorsed so that they can complete before the peer is stopped.
+type drainingEndorser struct {
+	pb.EndorserServer
+
+	lock     sync.RWMutex
+	draining bool
+	inFlight sync.WaitGroup
+}
+
+func newDrainingEndorser(endorser pb.EndorserServer) *drainingEndorser {
+	return &drainingEndorser{EndorserServer: endorser}
+}
+
+// ProcessProposal endorses the proposal unless the endorser is drained
+func (d *drainingEndorser) ProcessProposal(ctx context.Context, sp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+	d.lock.RLock()
+	if d.draining {
+		d.lock.RUnlock()
+		return &pb.ProposalResponse{Response: &pb.Response{Status: http.StatusServiceUnavailable, Message: "peer is shutting down"}}, nil
+	}
+	d.inFlight.Add(1)
+	d.lock.RUnlock()
+	defer d.inFlight.Done()
+
+	return d.EndorserServer.ProcessProposal(ctx, sp)
+}
+
+// drain rejects the proposals received from now on, and waits up to the given
+// timeout for the proposals being endorsed to complete. It returns false if
+// they did not complete in time.
+func (d *drainingEndorser) drain(timeout time.Duration) bool {
+	d.lock.Lock()
+	d.draining = true
+	d.lock.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.inFlight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// drainer is a service whose requests can be drained before the peer stops
+type drainer interface {
+	Drain()
+}
+
+// gracefulStopper is a server which stops once its pending requests complete
+type gracefulStopper interface {
+	GracefulStop(timeout time.Duration) bool
+}
+
+// peerShutdown shuts the peer down gracefully
+type peerShutdown struct {
+	drainTimeout time.Duration
+	endorser     *drainingEndorser
+	deliver      drainer
+	stopGossip   func()
+	server       gracefulStopper
+	closePlugins func()
+	closeLedgers func()
+}
+
+// shutdown rejects new proposals and ends the deliver streams, waits for the
+// proposals being endorsed to complete, stops gossip once the blocks being
+// committed are written to the ledgers, stops the gRPC server once its pending
+// requests complete, closes the endorsement and validation plugins and closes
+// the ledgers. It waits up to the drain timeout overall for the pending
+// requests to complete.
+func (s *peerShutdown) shutdown() {
+	deadline := time.Now().Add(s.drainTimeout)
+
+	s.deliver.Drain()
+	if !s.endorser.drain(s.drainTimeout) {
+		logger.Warningf("Pending proposals did not complete within %s", s.drainTimeout)
+	}
+
+	s.stopGossip()
+
+	remaining := deadline.Sub(time.Now())
+	if remaining < 0 {
+		remaining = 0
+	}
+	if !s.server.GracefulStop(remaining) {
+		logger.Warningf("Pending requests did not complete within %s, closed their connections", s.drainTimeout)
+	}
+
+	s.closePlugins()
+	s.closeLedgers()
+	logger.Info("Peer stopped")
+}
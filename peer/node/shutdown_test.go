@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+type blockingEndorser struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (e *blockingEndorser) ProcessProposal(context.Context, *pb.SignedProposal) (*pb.ProposalResponse, error) {
+	e.started <- struct{}{}
+	<-e.release
+	return &pb.ProposalResponse{Response: &pb.Response{Status: http.StatusOK}}, nil
+}
+
+func TestDrainingEndorser(t *testing.T) {
+	e := &blockingEndorser{started: make(chan struct{}, 1), release: make(chan struct{})}
+	d := newDrainingEndorser(e)
+
+	responses := make(chan *pb.ProposalResponse, 1)
+	go func() {
+		resp, _ := d.ProcessProposal(context.Background(), &pb.SignedProposal{})
+		responses <- resp
+	}()
+	<-e.started
+
+	// the pending proposal does not complete within the timeout
+	assert.False(t, d.drain(10*time.Millisecond))
+
+	resp, err := d.ProcessProposal(context.Background(), &pb.SignedProposal{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(http.StatusServiceUnavailable), resp.Response.Status)
+	assert.Equal(t, "peer is shutting down", resp.Response.Message)
+
+	close(e.release)
+	assert.True(t, d.drain(time.Second))
+	assert.Equal(t, int32(http.StatusOK), (<-responses).Response.Status)
+}
+
+type recordingDrainer struct {
+	calls *[]string
+}
+
+func (r *recordingDrainer) Drain() {
+	*r.calls = append(*r.calls, "drain deliver")
+}
+
+type recordingStopper struct {
+	calls   *[]string
+	timeout time.Duration
+}
+
+func (r *recordingStopper) GracefulStop(timeout time.Duration) bool {
+	*r.calls = append(*r.calls, "stop server")
+	r.timeout = timeout
+	return false
+}
+
+func TestPeerShutdown(t *testing.T) {
+	var calls []string
+	server := &recordingStopper{calls: &calls}
+	ps := &peerShutdown{
+		drainTimeout: time.Minute,
+		endorser:     newDrainingEndorser(nil),
+		deliver:      &recordingDrainer{calls: &calls},
+		stopGossip:   func() { calls = append(calls, "stop gossip") },
+		server:       server,
+		closePlugins: func() { calls = append(calls, "close plugins") },
+		closeLedgers: func() { calls = append(calls, "close ledgers") },
+	}
+	ps.shutdown()
+
+	assert.Equal(t, []string{"drain deliver", "stop gossip", "stop server", "close plugins", "close ledgers"}, calls)
+	assert.True(t, ps.endorser.draining)
+	assert.True(t, server.timeout > 0 && server.timeout <= time.Minute)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotChannelID string
+	snapshotOutputDir string
+	joinInputDir      string
+	joinVerifyOnly    bool
+)
+
+func snapshotCmd() *cobra.Command {
+	flags := nodeSnapshotCmd.Flags()
+	flags.StringVarP(&snapshotChannelID, "channelID", "c", "", "Channel whose ledger is snapshotted")
+	flags.StringVarP(&snapshotOutputDir, "output", "", "", "Directory the snapshot is written to, which must not exist or be empty")
+	return nodeSnapshotCmd
+}
+
+var nodeSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Takes a snapshot of the ledger of a channel.",
+	Long: `Writes a snapshot of the ledger of a channel at its current height to a directory, along with a manifest describing the snapshot. ` +
+		`The snapshot holds the last config block and the last block of the ledger, its public state, the hashes of its private data, ` +
+		`its collection config history and the validation codes of its transactions, from which another peer joins the channel ` +
+		`without committing its blocks. The manifest is signed with the identity of the peer. ` +
+		`The peer must be stopped, and the state database and the block index held by goleveldb.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected: %s", args)
+		}
+		if snapshotChannelID == "" {
+			return errors.New("must supply channel ID")
+		}
+		if snapshotOutputDir == "" {
+			return errors.New("must supply the output directory")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return snapshotLedger(snapshotChannelID, snapshotOutputDir, os.Stdout)
+	},
+}
+
+func snapshotLedger(channelID, outputDir string, out io.Writer) error {
+	signer, err := common.GetDefaultSignerFnc()
+	if err != nil {
+		return errors.Errorf("failed obtaining default signer: %v", err)
+	}
+	manifest, err := kvledger.SnapshotLedger(channelID, outputDir, signer)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to take a snapshot of the ledger of channel [%s]", channelID))
+	}
+	printManifest(out, manifest)
+	return nil
+}
+
+func joinBySnapshotCmd() *cobra.Command {
+	flags := nodeJoinBySnapshotCmd.Flags()
+	flags.StringVarP(&joinInputDir, "input", "", "", "Directory holding the snapshot")
+	flags.BoolVarP(&joinVerifyOnly, "verify-only", "", false, "Verify the snapshot without joining the channel")
+	return nodeJoinBySnapshotCmd
+}
+
+var nodeJoinBySnapshotCmd = &cobra.Command{
+	Use:   "joinbysnapshot",
+	Short: "Joins the channel of a ledger snapshot.",
+	Long: `Verifies a snapshot taken by the snapshot command, whose manifest must be signed by a member of an application organization of the channel, ` +
+		`and creates the ledger of the channel it holds, ` +
+		`which the peer brings up to date from the other peers or the ordering service when it is started. ` +
+		`The channel must not exist on the peer, and the peer must be stopped. ` +
+		`The blocks preceding the snapshot, the history of the keys and the private data written before the snapshot are not available on the peer.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected: %s", args)
+		}
+		if joinInputDir == "" {
+			return errors.New("must supply the input directory")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		return joinBySnapshot(joinInputDir, joinVerifyOnly, os.Stdout)
+	},
+}
+
+func joinBySnapshot(inputDir string, verifyOnly bool, out io.Writer) error {
+	if verifyOnly {
+		manifest, err := kvledger.VerifySnapshot(inputDir)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed to verify the snapshot in [%s]", inputDir))
+		}
+		printManifest(out, manifest)
+		return nil
+	}
+	manifest, err := kvledger.JoinBySnapshot(inputDir)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to join the channel of the snapshot in [%s]", inputDir))
+	}
+	printManifest(out, manifest)
+	return nil
+}
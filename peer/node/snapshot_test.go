@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/msp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotCmd(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	viper.Set("peer.fileSystemPath", tempDir)
+	defer viper.Reset()
+
+	cmd := snapshotCmd()
+	cmd.SetArgs([]string{"--output", filepath.Join(tempDir, "snapshot")})
+	assert.EqualError(t, cmd.Execute(), "must supply channel ID")
+	cmd.SetArgs([]string{"-c", "mychannel", "--output", ""})
+	assert.EqualError(t, cmd.Execute(), "must supply the output directory")
+	defer func(f func() (msp.SigningIdentity, error)) { common.GetDefaultSignerFnc = f }(common.GetDefaultSignerFnc)
+	common.GetDefaultSignerFnc = func() (msp.SigningIdentity, error) {
+		return nil, errors.New("no signing identity")
+	}
+	cmd.SetArgs([]string{"-c", "mychannel", "--output", filepath.Join(tempDir, "snapshot")})
+	assert.EqualError(t, cmd.Execute(), "failed obtaining default signer: no signing identity")
+
+	assert.NoError(t, msptesttools.LoadMSPSetupForTesting())
+	common.GetDefaultSignerFnc = func() (msp.SigningIdentity, error) {
+		return mspmgmt.GetLocalSigningIdentityOrPanic(), nil
+	}
+	assert.EqualError(t, cmd.Execute(), "failed to take a snapshot of the ledger of channel [mychannel]: LedgerID does not exist")
+}
+
+func TestJoinBySnapshotCmd(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "joinbysnapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	viper.Set("peer.fileSystemPath", tempDir)
+	defer viper.Reset()
+
+	cmd := joinBySnapshotCmd()
+	cmd.SetArgs([]string{"trailing"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected: [trailing]")
+	cmd.SetArgs([]string{})
+	assert.EqualError(t, cmd.Execute(), "must supply the input directory")
+	cmd.SetArgs([]string{"--input", tempDir, "--verify-only"})
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to verify the snapshot in ["+tempDir+"]: error reading the backup manifest")
+	cmd.SetArgs([]string{"--input", tempDir, "--verify-only=false"})
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to join the channel of the snapshot in ["+tempDir+"]: error reading the backup manifest")
+}
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -20,13 +21,17 @@ import (
 	ccdef "github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/deliver"
-	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/diagnostics"
+	commonerrors "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/common/viperutil"
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/admin"
 	"github.com/hyperledger/fabric/core/cclifecycle"
+	"github.com/hyperledger/fabric/core/certexpiry"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/accesscontrol"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
@@ -37,6 +42,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/platforms/java"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/node"
 	"github.com/hyperledger/fabric/core/comm"
+	msgvalidation "github.com/hyperledger/fabric/core/common/validation"
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/container"
@@ -46,6 +52,7 @@ import (
 	authHandler "github.com/hyperledger/fabric/core/handlers/auth"
 	endorsement2 "github.com/hyperledger/fabric/core/handlers/endorsement/api"
 	endorsement3 "github.com/hyperledger/fabric/core/handlers/endorsement/api/identities"
+	"github.com/hyperledger/fabric/core/handlers/installation"
 	"github.com/hyperledger/fabric/core/handlers/library"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
@@ -125,6 +132,10 @@ func serve(args []string) error {
 		panic("Unsupported msp type " + msp.ProviderTypeToString(mspType))
 	}
 
+	if err := loadAdditionalLocalMsps(); err != nil {
+		return err
+	}
+
 	// set the logging level for specific modules defined via environment
 	// variables or core.yaml
 	overrideLogModules := []string{"msp", "gossip", "ledger", "cauthdsl", "policies", "grpc", "peer.gossip"}
@@ -140,6 +151,26 @@ func serve(args []string) error {
 	// and was racy with respect to initialization of gRPC clients and servers.
 	grpc.EnableTracing = true
 
+	// The metrics root scope must be initialized before the components that
+	// report metrics are created
+	if err := metrics.Init(metrics.NewOpts()); err != nil {
+		return errors.WithMessage(err, "failed to initialize metrics")
+	}
+	go func() {
+		if err := metrics.Start(); err != nil {
+			logger.Errorf("Error starting metrics server: %s", err)
+		}
+	}()
+	defer metrics.Shutdown()
+
+	// Tracing is initialized along with the metrics, before the endorser
+	// and the committers start recording spans
+	if err := tracing.Init(tracing.NewOpts()); err != nil {
+		return errors.WithMessage(err, "failed to initialize tracing")
+	}
+	tracing.Start()
+	defer tracing.Shutdown()
+
 	logger.Infof("Starting %s", version.GetInfo())
 
 	//startup aclmgmt with default ACL providers (resource based and default 1.0 policies based).
@@ -196,7 +227,10 @@ func serve(args []string) error {
 	if err != nil {
 		logger.Fatalf("Error loading secure config for peer (%s)", err)
 	}
-	serverConfig.Logger = flogging.MustGetLogger("core/comm").With("server", "PeerServer")
+	serverConfig = serverConfig.ForServer("PeerServer")
+	// the errors of the services carry their reason in the status of the calls
+	serverConfig.UnaryInterceptors = append(serverConfig.UnaryInterceptors, commonerrors.UnaryServerInterceptor)
+	serverConfig.StreamInterceptors = append(serverConfig.StreamInterceptors, commonerrors.StreamServerInterceptor)
 	peerServer, err := peer.NewPeerServer(listenAddr, serverConfig)
 	if err != nil {
 		logger.Fatalf("Failed to create peer server (%s)", err)
@@ -223,16 +257,37 @@ func serve(args []string) error {
 		}
 	}
 
-	abServer := peer.NewDeliverEventsServer(mutualTLS, policyCheckerProvider, &peer.DeliverChainManager{})
+	deliverCursors := peer.NewDeliverCursorStore(peer.GetDeliverCursorStorePath())
+	defer deliverCursors.Close()
+
+	abServer := peer.NewDeliverEventsServer(mutualTLS, policyCheckerProvider, &peer.DeliverChainManager{}, deliverCursors)
 	pb.RegisterDeliverServer(peerServer.Server(), abServer)
 
+	// Register the Diagnostics service used to troubleshoot connectivity to the peer
+	timeWindow := viper.GetDuration("peer.authentication.timewindow")
+	if timeWindow == 0 {
+		timeWindow = 15 * time.Minute
+	}
+	cb.RegisterDiagnosticsServer(peerServer.Server(), diagnostics.NewServer(localmsp.NewSigner(), diagnosticsDeserializers, timeWindow))
+
+	libConf := library.Config{}
+	if err = viperutil.EnhancedExactUnmarshalKey("peer.handlers", &libConf); err != nil {
+		return errors.WithMessage(err, "could not load YAML config")
+	}
+	reg := library.InitRegistry(libConf)
+
 	// Initialize chaincode service
-	chaincodeSupport, ccp, sccp, packageProvider := startChaincodeServer(peerHost, aclProvider, pr)
+	installValidators := reg.Lookup(library.InstallValidation).([]installation.Validator)
+	chaincodeSupport, ccp, sccp, packageProvider := startChaincodeServer(peerHost, aclProvider, pr, installValidators)
 
 	logger.Debugf("Running peer")
 
+	// Reload the safe subset of core.yaml on SIGHUP
+	reloader := newConfigReloader()
+	reloader.handleSignals()
+
 	// Start the Admin server
-	startAdminServer(listenAddr, peerServer.Server())
+	startAdminServer(listenAddr, peerServer.Server(), reloader, newGossipMembership(), admin.ChannelStatusProviderFunc(peer.StalledChannels))
 
 	privDataDist := func(channel string, txID string, privateData *transientstore.TxPvtReadWriteSetWithConfigInfo, blkHt uint64) error {
 		return service.GetGossipService().DistributePrivateData(channel, txID, privateData, blkHt)
@@ -244,12 +299,6 @@ func serve(args []string) error {
 		logger.Panicf("Failed serializing self identity: %v", err)
 	}
 
-	libConf := library.Config{}
-	if err = viperutil.EnhancedExactUnmarshalKey("peer.handlers", &libConf); err != nil {
-		return errors.WithMessage(err, "could not load YAML config")
-	}
-	reg := library.InitRegistry(libConf)
-
 	authFilters := reg.Lookup(library.Auth).([]authHandler.Filter)
 	endorserSupport := &endorser.SupportImpl{
 		SignerSupport:    signingIdentity,
@@ -259,6 +308,9 @@ func serve(args []string) error {
 		SysCCProvider:    sccp,
 		ACLProvider:      aclProvider,
 	}
+	if len(mgmt.GetLocalMSPs()) > 1 {
+		endorserSupport.ChannelSigningIdentity = channelSigningIdentity
+	}
 	endorsementPluginsByName := reg.Lookup(library.Endorsement).(map[string]endorsement2.PluginFactory)
 	validationPluginsByName := reg.Lookup(library.Validation).(map[string]validation.PluginFactory)
 	signingIdentityFetcher := (endorsement3.SigningIdentityFetcher)(endorserSupport)
@@ -272,9 +324,13 @@ func serve(args []string) error {
 	})
 	endorserSupport.PluginEndorser = pluginEndorser
 	serverEndorser := endorser.NewEndorserServer(privDataDist, endorserSupport, pr)
+	serverEndorser.DeterminismCheck = determinismCheck()
+	auditor := newAuditor()
+	serverEndorser.AuditMode = auditor != nil
 	auth := authHandler.ChainFilters(serverEndorser, authFilters...)
-	// Register the Endorser server
-	pb.RegisterEndorserServer(peerServer.Server(), auth)
+	// Register the Endorser server, which rejects new proposals once the peer shuts down
+	endorserServer := newDrainingEndorser(auth)
+	pb.RegisterEndorserServer(peerServer.Server(), endorserServer)
 
 	policyMgr := peer.NewChannelPolicyManagerGetter()
 
@@ -283,7 +339,14 @@ func serve(args []string) error {
 	if err != nil {
 		return err
 	}
-	defer service.GetGossipService().Stop()
+	// Gossip is stopped either when the peer shuts down or when serve returns
+	var gossipStopped sync.Once
+	stopGossip := func() {
+		gossipStopped.Do(func() {
+			service.GetGossipService().Stop()
+		})
+	}
+	defer stopGossip()
 
 	// initialize system chaincodes
 
@@ -303,6 +366,13 @@ func serve(args []string) error {
 	})
 	lifecycle.AddListener(onUpdate)
 
+	// transaction ids are checked with the hashing algorithm of their channel
+	msgvalidation.SetHashingAlgorithmGetter(peer.GetHashingAlgorithm)
+
+	// in audit mode, the ledger of each channel is audited once brought up
+	stopAudit := make(chan struct{})
+	defer close(stopAudit)
+
 	// this brings up all the channels
 	peer.Initialize(func(cid string) {
 		logger.Debugf("Deploying system CC, for channel <%s>", cid)
@@ -314,12 +384,42 @@ func serve(args []string) error {
 			logger.Panicf("Failed subscribing to chaincode lifecycle updates")
 		}
 		cceventmgmt.GetMgr().Register(cid, sub)
+		if auditor != nil {
+			go func() {
+				if err := auditor.Audit(cid, peer.GetLedger(cid), stopAudit); err != nil {
+					logger.Errorf("Failed auditing channel %s: %s", cid, err)
+				}
+			}()
+		}
 	}, ccp, sccp, txvalidator.MapBasedPluginMapper(validationPluginsByName), pr, deployedCCInfoProvider)
 
+	// the containers of the chaincodes are stopped when they are retired
+	cceventmgmt.GetMgr().RegisterRetireListener(&chaincodeRetirer{chaincodeSupport: chaincodeSupport})
+
 	if viper.GetBool("peer.discovery.enabled") {
 		registerDiscoveryService(peerServer, policyMgr, lifecycle)
 	}
 
+	if viper.GetBool("peer.gateway.enabled") && auditor == nil {
+		registerGatewayService(peerServer, policyMgr, lifecycle, endorserServer, viper.GetString("peer.gossip.externalEndpoint"))
+	}
+
+	// the expiration of the certificates is monitored once the channels are
+	// brought up, as the CA certificates of their MSPs are monitored too
+	certSources, err := certExpirySources()
+	if err != nil {
+		return err
+	}
+	stopCertExpiry := make(chan struct{})
+	defer close(stopCertExpiry)
+	go certexpiry.NewMonitor(certexpiry.NewOpts(), metrics.SubScope("peer"), certSources...).Run(stopCertExpiry)
+
+	if chaincodeSupport.ContainerCollector != nil {
+		stopContainerCollector := make(chan struct{})
+		defer close(stopContainerCollector)
+		go chaincodeSupport.ContainerCollector.Run(stopContainerCollector)
+	}
+
 	logger.Infof("Starting peer with ID=[%s], network ID=[%s], address=[%s]",
 		peerEndpoint.Id, viper.GetString("peer.networkId"), peerEndpoint.Address)
 
@@ -329,20 +429,34 @@ func serve(args []string) error {
 
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	drainTimeout := viper.GetDuration("peer.shutdown.drainTimeout")
+	if drainTimeout == 0 {
+		drainTimeout = 30 * time.Second
+	}
+	ps := &peerShutdown{
+		drainTimeout: drainTimeout,
+		endorser:     endorserServer,
+		deliver:      abServer,
+		stopGossip:   stopGossip,
+		server:       peerServer,
+		closePlugins: reg.Close,
+		closeLedgers: ledgermgmt.Close,
+	}
 	go func() {
 		sig := <-sigs
-		logger.Debugf("sig: %s", sig)
+		logger.Infof("Received %s, draining requests for up to %s", sig, drainTimeout)
+		ps.shutdown()
 		serve <- nil
 	}()
 
 	go func() {
-		var grpcErr error
-		if grpcErr = peerServer.Start(); grpcErr != nil {
-			grpcErr = fmt.Errorf("grpc server exited with error: %s", grpcErr)
-		} else {
-			logger.Info("peer server exited")
+		if grpcErr := peerServer.Start(); grpcErr != nil {
+			serve <- fmt.Errorf("grpc server exited with error: %s", grpcErr)
+			return
 		}
-		serve <- grpcErr
+		// The server only exits without error when the peer shuts down, which
+		// reports on the serve channel once the ledgers are closed
+		logger.Info("peer server exited")
 	}()
 
 	// Start profiling http endpoint if enabled
@@ -356,6 +470,10 @@ func serve(args []string) error {
 		}()
 	}
 
+	if viper.GetBool("peer.explorer.enabled") {
+		startExplorerServer()
+	}
+
 	logger.Infof("Started peer with ID=[%s], network ID=[%s], address=[%s]",
 		peerEndpoint.Id, viper.GetString("peer.networkId"), peerEndpoint.Address)
 
@@ -363,6 +481,16 @@ func serve(args []string) error {
 	return <-serve
 }
 
+// diagnosticsDeserializers returns the deserializers of the local MSP and of
+// the MSPs of the channels the peer joined
+func diagnosticsDeserializers() []msp.IdentityDeserializer {
+	deserializers := []msp.IdentityDeserializer{mgmt.GetLocalMSP()}
+	for _, d := range mgmt.GetDeserializers() {
+		deserializers = append(deserializers, d)
+	}
+	return deserializers
+}
+
 func localPolicy(policyObject proto.Message) policies.Policy {
 	localMSP := mgmt.GetLocalMSP()
 	pp := cauthdsl.NewPolicyProvider(localMSP)
@@ -397,7 +525,7 @@ func registerDiscoveryService(peerServer *comm.GRPCServer, polMgr policies.Chann
 }
 
 //create a CC listener using peer.chaincodeListenAddress (and if that's not set use peer.peerAddress)
-func createChaincodeServer(ca tlsgen.CA, peerHostname string) (srv *comm.GRPCServer, ccEndpoint string, err error) {
+func createChaincodeServer(ca tlsgen.CA, ccTLS *peer.ChaincodeServerTLS, peerHostname string) (srv *comm.GRPCServer, ccEndpoint string, err error) {
 	// before potentially setting chaincodeListenAddress, compute chaincode endpoint at first
 	ccEndpoint, err = computeChaincodeEndpoint(peerHostname)
 	if err != nil {
@@ -430,15 +558,27 @@ func createChaincodeServer(ca tlsgen.CA, peerHostname string) (srv *comm.GRPCSer
 		return nil, "", err
 	}
 
-	// set the logger for the server
-	config.Logger = flogging.MustGetLogger("core/comm").With("server", "ChaincodeServer")
+	// set the logger and the metrics scope for the server
+	config = config.ForServer("ChaincodeServer")
+
+	// the chaincode shim requests compression when it is enabled for chaincodes
+	if viper.GetBool("chaincode.compression.enabled") {
+		config.Compression = true
+	}
 
 	// Override TLS configuration if TLS is applicable
 	if config.SecOpts.UseTLS {
-		// Create a self-signed TLS certificate with a SAN that matches the computed chaincode endpoint
-		certKeyPair, err := ca.NewServerCertKeyPair(host)
-		if err != nil {
-			logger.Panicf("Failed generating TLS certificate for chaincode service: +%v", err)
+		// Create a self-signed TLS certificate with a SAN that matches the computed chaincode endpoint,
+		// unless one is configured for the chaincode service
+		var cert, key []byte
+		if ccTLS != nil {
+			cert, key = ccTLS.Certificate, ccTLS.Key
+		} else {
+			certKeyPair, err := ca.NewServerCertKeyPair(host)
+			if err != nil {
+				logger.Panicf("Failed generating TLS certificate for chaincode service: +%v", err)
+			}
+			cert, key = certKeyPair.Cert, certKeyPair.Key
 		}
 		config.SecOpts = &comm.SecureOptions{
 			UseTLS: true,
@@ -446,9 +586,9 @@ func createChaincodeServer(ca tlsgen.CA, peerHostname string) (srv *comm.GRPCSer
 			RequireClientCert: true,
 			// Trust only client certificates signed by ourselves
 			ClientRootCAs: [][]byte{ca.CertBytes()},
-			// Use our own self-signed TLS certificate and key
-			Certificate: certKeyPair.Cert,
-			Key:         certKeyPair.Key,
+			// Use the TLS certificate and key of the chaincode service
+			Certificate: cert,
+			Key:         key,
 			// No point in specifying server root CAs since this TLS config is only used for
 			// a gRPC server and not a client
 			ServerRootCAs: nil,
@@ -546,23 +686,31 @@ func computeChaincodeEndpoint(peerHostname string) (ccEndpoint string, err error
 //NOTE - when we implement JOIN we will no longer pass the chainID as param
 //The chaincode support will come up without registering system chaincodes
 //which will be registered only during join phase.
-func registerChaincodeSupport(grpcServer *comm.GRPCServer, ccEndpoint string, ca tlsgen.CA, packageProvider *persistence.PackageProvider, aclProvider aclmgmt.ACLProvider, pr *platforms.Registry) (*chaincode.ChaincodeSupport, ccprovider.ChaincodeProvider, *scc.Provider) {
+func registerChaincodeSupport(grpcServer *comm.GRPCServer, ccEndpoint string, ca tlsgen.CA, ccTLS *peer.ChaincodeServerTLS, packageProvider *persistence.PackageProvider, aclProvider aclmgmt.ACLProvider, pr *platforms.Registry, installValidators []installation.Validator) (*chaincode.ChaincodeSupport, ccprovider.ChaincodeProvider, *scc.Provider) {
 	//get user mode
 	userRunsCC := chaincode.IsDevMode()
 	tlsEnabled := viper.GetBool("peer.tls.enabled")
 
+	// the chaincodes verify the certificate of the chaincode service with the
+	// root certificate configured along with it, if any
+	serverRootCert := ca.CertBytes()
+	if ccTLS != nil {
+		serverRootCert = ccTLS.RootCert
+	}
+
 	authenticator := accesscontrol.NewAuthenticator(ca)
 	ipRegistry := inproccontroller.NewRegistry()
 
 	sccp := scc.NewProvider(peer.Default, peer.DefaultSupport, ipRegistry)
 	lsccInst := lscc.New(sccp, aclProvider, pr)
+	lsccInst.InstallValidators = installValidators
 	lifecycleSCC := &lifecycle.SCC{}
 
 	chaincodeSupport := chaincode.NewChaincodeSupport(
 		chaincode.GlobalConfig(),
 		ccEndpoint,
 		userRunsCC,
-		ca.CertBytes(),
+		serverRootCert,
 		authenticator,
 		packageProvider,
 		lsccInst,
@@ -603,7 +751,7 @@ func registerChaincodeSupport(grpcServer *comm.GRPCServer, ccEndpoint string, ca
 // 1) setup local chaincode install path
 // 2) create chaincode specific tls CA
 // 3) start the chaincode specific gRPC listening service
-func startChaincodeServer(peerHost string, aclProvider aclmgmt.ACLProvider, pr *platforms.Registry) (*chaincode.ChaincodeSupport, ccprovider.ChaincodeProvider, *scc.Provider, *persistence.PackageProvider) {
+func startChaincodeServer(peerHost string, aclProvider aclmgmt.ACLProvider, pr *platforms.Registry, installValidators []installation.Validator) (*chaincode.ChaincodeSupport, ccprovider.ChaincodeProvider, *scc.Provider, *persistence.PackageProvider) {
 	// Setup chaincode path
 	chaincodeInstallPath := ccprovider.GetChaincodeInstallPathFromViper()
 	ccprovider.SetChaincodesPath(chaincodeInstallPath)
@@ -621,7 +769,11 @@ func startChaincodeServer(peerHost string, aclProvider aclmgmt.ACLProvider, pr *
 	if err != nil {
 		logger.Panic("Failed creating authentication layer:", err)
 	}
-	ccSrv, ccEndpoint, err := createChaincodeServer(ca, peerHost)
+	ccTLS, err := peer.GetChaincodeServerTLS()
+	if err != nil {
+		logger.Panicf("Failed to load the TLS material of the chaincode server: %s", err)
+	}
+	ccSrv, ccEndpoint, err := createChaincodeServer(ca, ccTLS, peerHost)
 	if err != nil {
 		logger.Panicf("Failed to create chaincode server: %s", err)
 	}
@@ -629,14 +781,50 @@ func startChaincodeServer(peerHost string, aclProvider aclmgmt.ACLProvider, pr *
 		ccSrv,
 		ccEndpoint,
 		ca,
+		ccTLS,
 		packageProvider,
 		aclProvider,
 		pr,
+		installValidators,
 	)
 	go ccSrv.Start()
 	return chaincodeSupport, ccp, sccp, packageProvider
 }
 
+// chaincodeRetirer stops the containers of the chaincodes retired on a channel.
+// A container shared with a channel on which the chaincode is not retired is
+// launched again on the next invocation of the chaincode on that channel
+type chaincodeRetirer struct {
+	chaincodeSupport *chaincode.ChaincodeSupport
+}
+
+func (r *chaincodeRetirer) HandleChaincodeRetire(chainID string, chaincodeDefinition *cceventmgmt.ChaincodeDefinition) {
+	logger.Infof("Stopping chaincode %s:%s retired on channel %s", chaincodeDefinition.Name, chaincodeDefinition.Version, chainID)
+	ccci := &ccprovider.ChaincodeContainerInfo{
+		Name:          chaincodeDefinition.Name,
+		Version:       chaincodeDefinition.Version,
+		ContainerType: pb.ChaincodeDeploymentSpec_DOCKER.String(),
+	}
+	if err := r.chaincodeSupport.Stop(ccci); err != nil {
+		logger.Warningf("Failed stopping chaincode %s:%s: %s", chaincodeDefinition.Name, chaincodeDefinition.Version, err)
+	}
+}
+
+// determinismCheck returns the determinism check of the chaincodes the
+// endorser performs, nil if it is disabled
+func determinismCheck() *endorser.DeterminismCheck {
+	if !viper.GetBool("peer.determinismCheck.enabled") {
+		return nil
+	}
+	chaincodes := viperutil.GetStringSlice("peer.determinismCheck.chaincodes")
+	if len(chaincodes) == 0 {
+		logger.Warning("The determinism check is enabled, the proposals of all the application chaincodes are executed twice")
+	} else {
+		logger.Warningf("The determinism check is enabled, the proposals of chaincodes %v are executed twice", chaincodes)
+	}
+	return &endorser.DeterminismCheck{Chaincodes: chaincodes}
+}
+
 func adminHasSeparateListener(peerListenAddr string, adminListenAddress string) bool {
 	// By default, admin listens on the same port as the peer data service
 	if adminListenAddress == "" {
@@ -656,7 +844,7 @@ func adminHasSeparateListener(peerListenAddr string, adminListenAddress string)
 	return adminPort != peerPort
 }
 
-func startAdminServer(peerListenAddr string, peerServer *grpc.Server) {
+func startAdminServer(peerListenAddr string, peerServer *grpc.Server, rsp admin.ReloadStatusProvider, mp admin.MembershipProvider, csp admin.ChannelStatusProvider) {
 	adminListenAddress := viper.GetString("peer.adminService.listenAddress")
 	separateLsnrForAdmin := adminHasSeparateListener(peerListenAddr, adminListenAddress)
 	mspID := viper.GetString("peer.localMspId")
@@ -665,10 +853,12 @@ func startAdminServer(peerListenAddr string, peerServer *grpc.Server) {
 	if separateLsnrForAdmin {
 		logger.Info("Creating gRPC server for admin service on", adminListenAddress)
 		serverConfig, err := peer.GetServerConfig()
-		serverConfig.Logger = flogging.MustGetLogger("core/comm").With("server", "AdminServer")
 		if err != nil {
 			logger.Fatalf("Error loading secure config for admin service (%s)", err)
 		}
+		serverConfig = serverConfig.ForServer("AdminServer")
+		serverConfig.UnaryInterceptors = append(serverConfig.UnaryInterceptors, commonerrors.UnaryServerInterceptor)
+		serverConfig.StreamInterceptors = append(serverConfig.StreamInterceptors, commonerrors.StreamServerInterceptor)
 		adminServer, err := peer.NewPeerServer(adminListenAddress, serverConfig)
 		if err != nil {
 			logger.Fatalf("Failed to create admin server (%s)", err)
@@ -679,7 +869,7 @@ func startAdminServer(peerListenAddr string, peerServer *grpc.Server) {
 		}()
 	}
 
-	pb.RegisterAdminServer(gRPCService, admin.NewAdminServer(adminPolicy))
+	pb.RegisterAdminServer(gRPCService, admin.NewAdminServer(adminPolicy, rsp, mp, csp))
 }
 
 // secureDialOpts is the callback function for secure dial options for gossip service
@@ -693,11 +883,11 @@ func secureDialOpts() []grpc.DialOption {
 			grpc.MaxCallSendMsgSize(comm.MaxSendMsgSize)))
 	// set the keepalive options
 	kaOpts := comm.DefaultKeepaliveOptions
-	if viper.IsSet("peer.keepalive.client.interval") {
-		kaOpts.ClientInterval = viper.GetDuration("peer.keepalive.client.interval")
+	if viperutil.Reloadable.IsSet("peer.keepalive.client.interval") {
+		kaOpts.ClientInterval = viperutil.Reloadable.GetDuration("peer.keepalive.client.interval")
 	}
-	if viper.IsSet("peer.keepalive.client.timeout") {
-		kaOpts.ClientTimeout = viper.GetDuration("peer.keepalive.client.timeout")
+	if viperutil.Reloadable.IsSet("peer.keepalive.client.timeout") {
+		kaOpts.ClientTimeout = viperutil.Reloadable.GetDuration("peer.keepalive.client.timeout")
 	}
 	dialOpts = append(dialOpts, comm.ClientKeepaliveOptions(kaOpts)...)
 
@@ -730,9 +920,10 @@ func initGossipService(policyMgr policies.ChannelPolicyManagerGetter, peerServer
 	messageCryptoService := peergossip.NewMCS(
 		policyMgr,
 		localmsp.NewSigner(),
-		mgmt.NewDeserializersManager())
+		mgmt.NewDeserializersManager(),
+		peer.GetHashingAlgorithm)
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager())
-	bootstrap := viper.GetStringSlice("peer.gossip.bootstrap")
+	bootstrap := viperutil.GetStringSlice("peer.gossip.bootstrap")
 
 	return service.InitGossipService(serializedIdentity, peerAddr, peerServer.Server(), certs,
 		messageCryptoService, secAdv, secureDialOpts, bootstrap...)
@@ -13,9 +13,15 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/viperutil"
+	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/mock"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/endorser"
 	"github.com/hyperledger/fabric/core/handlers/library"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
@@ -66,6 +72,33 @@ func TestAdminHasSeparateListener(t *testing.T) {
 	assert.True(t, adminHasSeparateListener("0.0.0.0:7051", "0.0.0.0:7055"))
 }
 
+func TestDeterminismCheck(t *testing.T) {
+	defer viper.Reset()
+
+	viper.Set("peer.determinismCheck.enabled", false)
+	assert.Nil(t, determinismCheck())
+
+	viper.Set("peer.determinismCheck.enabled", true)
+	assert.Equal(t, &endorser.DeterminismCheck{}, determinismCheck())
+
+	viper.Set("peer.determinismCheck.chaincodes", "[mycc, yourcc]")
+	assert.Equal(t, &endorser.DeterminismCheck{Chaincodes: []string{"mycc", "yourcc"}}, determinismCheck())
+}
+
+func TestChaincodeRetirer(t *testing.T) {
+	fakeRuntime := &mock.Runtime{}
+	r := &chaincodeRetirer{chaincodeSupport: &chaincode.ChaincodeSupport{Runtime: fakeRuntime}}
+
+	r.HandleChaincodeRetire("mychannel", &cceventmgmt.ChaincodeDefinition{Name: "mycc", Version: "1.0"})
+	assert.Equal(t, 1, fakeRuntime.StopCallCount())
+	assert.Equal(t, &ccprovider.ChaincodeContainerInfo{Name: "mycc", Version: "1.0", ContainerType: "DOCKER"}, fakeRuntime.StopArgsForCall(0))
+
+	// a failure to stop the container is only logged
+	fakeRuntime.StopReturns(errors.New("no such container"))
+	r.HandleChaincodeRetire("mychannel", &cceventmgmt.ChaincodeDefinition{Name: "yourcc", Version: "1.0"})
+	assert.Equal(t, 2, fakeRuntime.StopCallCount())
+}
+
 func TestHandlerMap(t *testing.T) {
 	config1 := `
   peer:
@@ -76,6 +109,11 @@ func TestHandlerMap(t *testing.T) {
           library: /opt/lib/filter1.so
         -
           name: filter2
+      installValidators:
+        -
+          name: GoSourceCheck
+        -
+          library: /opt/lib/installvalidator.so
   `
 	viper.SetConfigType("yaml")
 	err := viper.ReadConfig(bytes.NewBuffer([]byte(config1)))
@@ -87,6 +125,9 @@ func TestHandlerMap(t *testing.T) {
 	assert.Len(t, libConf.AuthFilters, 2, "expected two filters")
 	assert.Equal(t, "/opt/lib/filter1.so", libConf.AuthFilters[0].Library)
 	assert.Equal(t, "filter2", libConf.AuthFilters[1].Name)
+	assert.Len(t, libConf.InstallValidators, 2, "expected two install validators")
+	assert.Equal(t, "GoSourceCheck", libConf.InstallValidators[0].Name)
+	assert.Equal(t, "/opt/lib/installvalidator.so", libConf.InstallValidators[1].Library)
 }
 
 func TestComputeChaincodeEndpoint(t *testing.T) {
@@ -68,12 +68,17 @@ func (b *BlockHeader) Bytes() []byte {
 	return result
 }
 
-// Hash returns the hash of the block header.
-// XXX This method will be removed shortly to allow for confgurable hashing algorithms
+// Hash returns the SHA256 hash of the block header.
 func (b *BlockHeader) Hash() []byte {
 	return util.ComputeSHA256(b.Bytes())
 }
 
+// HashWith returns the hash of the block header computed with the hashing
+// algorithm of the channel.
+func (b *BlockHeader) HashWith(hash func([]byte) []byte) []byte {
+	return hash(b.Bytes())
+}
+
 // Bytes returns a deterministically serialized version of the BlockData
 // eventually, this should be replaced with a true Merkle tree construction,
 // but for the moment, we assume a Merkle tree of infinite width (uint32_max)
@@ -82,7 +87,13 @@ func (b *BlockData) Bytes() []byte {
 	return util.ConcatenateBytes(b.Data...)
 }
 
-// Hash returns the hash of the marshaled representation of the block data.
+// Hash returns the SHA256 hash of the marshaled representation of the block data.
 func (b *BlockData) Hash() []byte {
 	return util.ComputeSHA256(b.Bytes())
 }
+
+// HashWith returns the hash of the marshaled representation of the block data
+// computed with the hashing algorithm of the channel.
+func (b *BlockData) HashWith(hash func([]byte) []byte) []byte {
+	return hash(b.Bytes())
+}
@@ -53,6 +53,25 @@ func (p *Payload) VariablyOpaqueFieldProto(name string) (proto.Message, error) {
 	return nil, fmt.Errorf("decoding type %v is unimplemented", ch.Type)
 }
 
+func (ch *ChannelHeader) VariablyOpaqueFields() []string {
+	return []string{"extension"}
+}
+
+// ChannelHeaderExtensionMap holds the type of the extension of the channel
+// header for each header type which defines one
+var ChannelHeaderExtensionMap = map[int32]proto.Message{}
+
+func (ch *ChannelHeader) VariablyOpaqueFieldProto(name string) (proto.Message, error) {
+	if name != ch.VariablyOpaqueFields()[0] {
+		return nil, fmt.Errorf("not a marshaled field: %s", name)
+	}
+
+	if msg, ok := ChannelHeaderExtensionMap[ch.Type]; ok {
+		return proto.Clone(msg), nil
+	}
+	return nil, fmt.Errorf("decoding extension of type %v is unimplemented", ch.Type)
+}
+
 func (h *Header) StaticallyOpaqueFields() []string {
 	return []string{"channel_header", "signature_header"}
 }
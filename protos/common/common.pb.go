@@ -61,7 +61,7 @@ func (x Status) String() string {
 	return proto.EnumName(Status_name, int32(x))
 }
 func (Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{0}
+	return fileDescriptor_common_f49453c97e16714e, []int{0}
 }
 
 type HeaderType int32
@@ -105,14 +105,15 @@ func (x HeaderType) String() string {
 	return proto.EnumName(HeaderType_name, int32(x))
 }
 func (HeaderType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{1}
+	return fileDescriptor_common_f49453c97e16714e, []int{1}
 }
 
 // This enum enlists indexes of the block metadata array
 type BlockMetadataIndex int32
 
 const (
-	BlockMetadataIndex_SIGNATURES          BlockMetadataIndex = 0
+	BlockMetadataIndex_SIGNATURES BlockMetadataIndex = 0
+	// is the time at which the orderer created the block
 	BlockMetadataIndex_LAST_CONFIG         BlockMetadataIndex = 1
 	BlockMetadataIndex_TRANSACTIONS_FILTER BlockMetadataIndex = 2
 	BlockMetadataIndex_ORDERER             BlockMetadataIndex = 3
@@ -135,7 +136,7 @@ func (x BlockMetadataIndex) String() string {
 	return proto.EnumName(BlockMetadataIndex_name, int32(x))
 }
 func (BlockMetadataIndex) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{2}
+	return fileDescriptor_common_f49453c97e16714e, []int{2}
 }
 
 // LastConfig is the encoded value for the Metadata message which is encoded in the LAST_CONFIGURATION block metadata index
@@ -150,7 +151,7 @@ func (m *LastConfig) Reset()         { *m = LastConfig{} }
 func (m *LastConfig) String() string { return proto.CompactTextString(m) }
 func (*LastConfig) ProtoMessage()    {}
 func (*LastConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{0}
+	return fileDescriptor_common_f49453c97e16714e, []int{0}
 }
 func (m *LastConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LastConfig.Unmarshal(m, b)
@@ -190,7 +191,7 @@ func (m *Metadata) Reset()         { *m = Metadata{} }
 func (m *Metadata) String() string { return proto.CompactTextString(m) }
 func (*Metadata) ProtoMessage()    {}
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{1}
+	return fileDescriptor_common_f49453c97e16714e, []int{1}
 }
 func (m *Metadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Metadata.Unmarshal(m, b)
@@ -236,7 +237,7 @@ func (m *MetadataSignature) Reset()         { *m = MetadataSignature{} }
 func (m *MetadataSignature) String() string { return proto.CompactTextString(m) }
 func (*MetadataSignature) ProtoMessage()    {}
 func (*MetadataSignature) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{2}
+	return fileDescriptor_common_f49453c97e16714e, []int{2}
 }
 func (m *MetadataSignature) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MetadataSignature.Unmarshal(m, b)
@@ -282,7 +283,7 @@ func (m *Header) Reset()         { *m = Header{} }
 func (m *Header) String() string { return proto.CompactTextString(m) }
 func (*Header) ProtoMessage()    {}
 func (*Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{3}
+	return fileDescriptor_common_f49453c97e16714e, []int{3}
 }
 func (m *Header) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Header.Unmarshal(m, b)
@@ -345,7 +346,18 @@ type ChannelHeader struct {
 	Extension []byte `protobuf:"bytes,7,opt,name=extension,proto3" json:"extension,omitempty"`
 	// If mutual TLS is employed, this represents
 	// the hash of the client's TLS certificate
-	TlsCertHash          []byte   `protobuf:"bytes,8,opt,name=tls_cert_hash,json=tlsCertHash,proto3" json:"tls_cert_hash,omitempty"`
+	TlsCertHash []byte `protobuf:"bytes,8,opt,name=tls_cert_hash,json=tlsCertHash,proto3" json:"tls_cert_hash,omitempty"`
+	// Trace context of the transaction, as a W3C traceparent string, so
+	// that the spans recorded for it at endorsement, ordering, validation
+	// and commit are correlated in a single trace
+	TraceContext string `protobuf:"bytes,9,opt,name=trace_context,json=traceContext" json:"trace_context,omitempty"`
+	// Validity window of the transaction, outside of which the peers mark it
+	// invalid at commit once the channel enables it
+	ValidityWindow *ValidityWindow `protobuf:"bytes,10,opt,name=validity_window,json=validityWindow" json:"validity_window,omitempty"`
+	// Priority of the transaction, which the orderers use to order it ahead
+	// of the transactions of lower priorities of the same block once the
+	// channel enables it. The higher the value the higher the priority.
+	Priority             uint32   `protobuf:"varint,11,opt,name=priority" json:"priority,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -355,7 +367,7 @@ func (m *ChannelHeader) Reset()         { *m = ChannelHeader{} }
 func (m *ChannelHeader) String() string { return proto.CompactTextString(m) }
 func (*ChannelHeader) ProtoMessage()    {}
 func (*ChannelHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{4}
+	return fileDescriptor_common_f49453c97e16714e, []int{4}
 }
 func (m *ChannelHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChannelHeader.Unmarshal(m, b)
@@ -431,6 +443,76 @@ func (m *ChannelHeader) GetTlsCertHash() []byte {
 	return nil
 }
 
+func (m *ChannelHeader) GetTraceContext() string {
+	if m != nil {
+		return m.TraceContext
+	}
+	return ""
+}
+
+func (m *ChannelHeader) GetValidityWindow() *ValidityWindow {
+	if m != nil {
+		return m.ValidityWindow
+	}
+	return nil
+}
+
+func (m *ChannelHeader) GetPriority() uint32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+// ValidityWindow bounds the time during which a transaction may be committed.
+// The bounds are compared with the time at which the orderer created the
+// block holding the transaction, and either of them may be left unset.
+type ValidityWindow struct {
+	NotBefore            *timestamp.Timestamp `protobuf:"bytes,1,opt,name=not_before,json=notBefore" json:"not_before,omitempty"`
+	NotAfter             *timestamp.Timestamp `protobuf:"bytes,2,opt,name=not_after,json=notAfter" json:"not_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ValidityWindow) Reset()         { *m = ValidityWindow{} }
+func (m *ValidityWindow) String() string { return proto.CompactTextString(m) }
+func (*ValidityWindow) ProtoMessage()    {}
+func (*ValidityWindow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_common_f49453c97e16714e, []int{5}
+}
+func (m *ValidityWindow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidityWindow.Unmarshal(m, b)
+}
+func (m *ValidityWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidityWindow.Marshal(b, m, deterministic)
+}
+func (dst *ValidityWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidityWindow.Merge(dst, src)
+}
+func (m *ValidityWindow) XXX_Size() int {
+	return xxx_messageInfo_ValidityWindow.Size(m)
+}
+func (m *ValidityWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidityWindow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidityWindow proto.InternalMessageInfo
+
+func (m *ValidityWindow) GetNotBefore() *timestamp.Timestamp {
+	if m != nil {
+		return m.NotBefore
+	}
+	return nil
+}
+
+func (m *ValidityWindow) GetNotAfter() *timestamp.Timestamp {
+	if m != nil {
+		return m.NotAfter
+	}
+	return nil
+}
+
 type SignatureHeader struct {
 	// Creator of the message, a marshaled msp.SerializedIdentity
 	Creator []byte `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
@@ -445,7 +527,7 @@ func (m *SignatureHeader) Reset()         { *m = SignatureHeader{} }
 func (m *SignatureHeader) String() string { return proto.CompactTextString(m) }
 func (*SignatureHeader) ProtoMessage()    {}
 func (*SignatureHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{5}
+	return fileDescriptor_common_f49453c97e16714e, []int{6}
 }
 func (m *SignatureHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignatureHeader.Unmarshal(m, b)
@@ -494,7 +576,7 @@ func (m *Payload) Reset()         { *m = Payload{} }
 func (m *Payload) String() string { return proto.CompactTextString(m) }
 func (*Payload) ProtoMessage()    {}
 func (*Payload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{6}
+	return fileDescriptor_common_f49453c97e16714e, []int{7}
 }
 func (m *Payload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Payload.Unmarshal(m, b)
@@ -543,7 +625,7 @@ func (m *Envelope) Reset()         { *m = Envelope{} }
 func (m *Envelope) String() string { return proto.CompactTextString(m) }
 func (*Envelope) ProtoMessage()    {}
 func (*Envelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{7}
+	return fileDescriptor_common_f49453c97e16714e, []int{8}
 }
 func (m *Envelope) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Envelope.Unmarshal(m, b)
@@ -594,7 +676,7 @@ func (m *Block) Reset()         { *m = Block{} }
 func (m *Block) String() string { return proto.CompactTextString(m) }
 func (*Block) ProtoMessage()    {}
 func (*Block) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{8}
+	return fileDescriptor_common_f49453c97e16714e, []int{9}
 }
 func (m *Block) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Block.Unmarshal(m, b)
@@ -651,7 +733,7 @@ func (m *BlockHeader) Reset()         { *m = BlockHeader{} }
 func (m *BlockHeader) String() string { return proto.CompactTextString(m) }
 func (*BlockHeader) ProtoMessage()    {}
 func (*BlockHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{9}
+	return fileDescriptor_common_f49453c97e16714e, []int{10}
 }
 func (m *BlockHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockHeader.Unmarshal(m, b)
@@ -703,7 +785,7 @@ func (m *BlockData) Reset()         { *m = BlockData{} }
 func (m *BlockData) String() string { return proto.CompactTextString(m) }
 func (*BlockData) ProtoMessage()    {}
 func (*BlockData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{10}
+	return fileDescriptor_common_f49453c97e16714e, []int{11}
 }
 func (m *BlockData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockData.Unmarshal(m, b)
@@ -741,7 +823,7 @@ func (m *BlockMetadata) Reset()         { *m = BlockMetadata{} }
 func (m *BlockMetadata) String() string { return proto.CompactTextString(m) }
 func (*BlockMetadata) ProtoMessage()    {}
 func (*BlockMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_b374fafc5e1c956e, []int{11}
+	return fileDescriptor_common_f49453c97e16714e, []int{12}
 }
 func (m *BlockMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockMetadata.Unmarshal(m, b)
@@ -774,6 +856,7 @@ func init() {
 	proto.RegisterType((*MetadataSignature)(nil), "common.MetadataSignature")
 	proto.RegisterType((*Header)(nil), "common.Header")
 	proto.RegisterType((*ChannelHeader)(nil), "common.ChannelHeader")
+	proto.RegisterType((*ValidityWindow)(nil), "common.ValidityWindow")
 	proto.RegisterType((*SignatureHeader)(nil), "common.SignatureHeader")
 	proto.RegisterType((*Payload)(nil), "common.Payload")
 	proto.RegisterType((*Envelope)(nil), "common.Envelope")
@@ -786,68 +869,76 @@ func init() {
 	proto.RegisterEnum("common.BlockMetadataIndex", BlockMetadataIndex_name, BlockMetadataIndex_value)
 }
 
-func init() { proto.RegisterFile("common/common.proto", fileDescriptor_common_b374fafc5e1c956e) }
-
-var fileDescriptor_common_b374fafc5e1c956e = []byte{
-	// 959 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0xcf, 0x6f, 0xe3, 0x44,
-	0x18, 0xdd, 0xc4, 0xf9, 0xf9, 0xa5, 0x69, 0xdd, 0x49, 0xcb, 0x9a, 0xc2, 0x6a, 0x2b, 0xc3, 0xa2,
-	0xd2, 0x4a, 0xa9, 0x28, 0x17, 0x38, 0x3a, 0xf6, 0xb4, 0xb5, 0x9a, 0x8e, 0xc3, 0xd8, 0x59, 0xc4,
-	0x2e, 0x92, 0xe5, 0x24, 0xd3, 0x24, 0x22, 0xb1, 0x23, 0x7b, 0x52, 0xb5, 0x67, 0xee, 0x08, 0x09,
-	0xae, 0xfc, 0x2f, 0x1c, 0x11, 0x7f, 0x0f, 0x88, 0x2b, 0x1a, 0x8f, 0xed, 0x4d, 0xca, 0x4a, 0x9c,
-	0xe2, 0xf7, 0xe6, 0xcd, 0xf7, 0xbd, 0xf9, 0xde, 0xc4, 0x86, 0xce, 0x38, 0x5a, 0x2e, 0xa3, 0xf0,
-	0x5c, 0xfe, 0x74, 0x57, 0x71, 0xc4, 0x23, 0x54, 0x93, 0xe8, 0xe8, 0xe5, 0x34, 0x8a, 0xa6, 0x0b,
-	0x76, 0x9e, 0xb2, 0xa3, 0xf5, 0xdd, 0x39, 0x9f, 0x2f, 0x59, 0xc2, 0x83, 0xe5, 0x4a, 0x0a, 0x75,
-	0x1d, 0xa0, 0x1f, 0x24, 0xdc, 0x8c, 0xc2, 0xbb, 0xf9, 0x14, 0x1d, 0x40, 0x75, 0x1e, 0x4e, 0xd8,
-	0x83, 0x56, 0x3a, 0x2e, 0x9d, 0x54, 0xa8, 0x04, 0xfa, 0x5b, 0x68, 0xdc, 0x32, 0x1e, 0x4c, 0x02,
-	0x1e, 0x08, 0xc5, 0x7d, 0xb0, 0x58, 0xb3, 0x54, 0xb1, 0x43, 0x25, 0x40, 0x5f, 0x03, 0x24, 0xf3,
-	0x69, 0x18, 0xf0, 0x75, 0xcc, 0x12, 0xad, 0x7c, 0xac, 0x9c, 0xb4, 0x2e, 0x3e, 0xec, 0x66, 0x8e,
-	0xf2, 0xbd, 0x6e, 0xae, 0xa0, 0x1b, 0x62, 0xfd, 0x7b, 0xd8, 0xff, 0x8f, 0x00, 0x7d, 0x0e, 0x6a,
-	0x21, 0xf1, 0x67, 0x2c, 0x98, 0xb0, 0x38, 0x6b, 0xb8, 0x57, 0xf0, 0xd7, 0x29, 0x8d, 0x3e, 0x86,
-	0x66, 0x41, 0x69, 0xe5, 0x54, 0xf3, 0x8e, 0xd0, 0xdf, 0x40, 0x2d, 0xd3, 0xbd, 0x82, 0xdd, 0xf1,
-	0x2c, 0x08, 0x43, 0xb6, 0xd8, 0x2e, 0xd8, 0xce, 0xd8, 0x4c, 0xf6, 0xbe, 0xce, 0xe5, 0xf7, 0x76,
-	0xd6, 0x7f, 0x2c, 0x43, 0xdb, 0xdc, 0xda, 0x8c, 0xa0, 0xc2, 0x1f, 0x57, 0x72, 0x36, 0x55, 0x9a,
-	0x3e, 0x23, 0x0d, 0xea, 0xf7, 0x2c, 0x4e, 0xe6, 0x51, 0x98, 0xd6, 0xa9, 0xd2, 0x1c, 0xa2, 0xaf,
-	0xa0, 0x59, 0xa4, 0xa1, 0x29, 0xc7, 0xa5, 0x93, 0xd6, 0xc5, 0x51, 0x57, 0xe6, 0xd5, 0xcd, 0xf3,
-	0xea, 0x7a, 0xb9, 0x82, 0xbe, 0x13, 0xa3, 0x17, 0x00, 0xf9, 0x59, 0xe6, 0x13, 0xad, 0x72, 0x5c,
-	0x3a, 0x69, 0xd2, 0x66, 0xc6, 0xd8, 0x13, 0xd4, 0x81, 0x2a, 0x7f, 0x10, 0x2b, 0xd5, 0x74, 0xa5,
-	0xc2, 0x1f, 0xec, 0x89, 0x08, 0x8e, 0xad, 0xa2, 0xf1, 0x4c, 0xab, 0xc9, 0x68, 0x53, 0x20, 0xa6,
-	0xc7, 0x1e, 0x38, 0x0b, 0x53, 0x7f, 0x75, 0x39, 0xbd, 0x82, 0x40, 0x3a, 0xb4, 0xf9, 0x22, 0xf1,
-	0xc7, 0x2c, 0xe6, 0xfe, 0x2c, 0x48, 0x66, 0x5a, 0x23, 0x55, 0xb4, 0xf8, 0x22, 0x31, 0x59, 0xcc,
-	0xaf, 0x83, 0x64, 0xa6, 0x1b, 0xb0, 0xe7, 0x3e, 0x89, 0x44, 0x83, 0xfa, 0x38, 0x66, 0x01, 0x8f,
-	0xf2, 0x19, 0xe7, 0x50, 0x98, 0x08, 0xa3, 0x70, 0x9c, 0x07, 0x25, 0x81, 0x8e, 0xa1, 0x3e, 0x08,
-	0x1e, 0x17, 0x51, 0x30, 0x41, 0x9f, 0x41, 0x6d, 0x23, 0x9d, 0xd6, 0xc5, 0x6e, 0x7e, 0x89, 0x64,
-	0x69, 0x9a, 0xad, 0x8a, 0x49, 0x8b, 0x1b, 0x93, 0xd5, 0x49, 0x9f, 0xf5, 0x1e, 0x34, 0x70, 0x78,
-	0xcf, 0x16, 0x91, 0x9c, 0xfa, 0x4a, 0x96, 0xcc, 0x2d, 0x64, 0xf0, 0x7f, 0xee, 0xcb, 0x4f, 0x25,
-	0xa8, 0xf6, 0x16, 0xd1, 0xf8, 0x07, 0x74, 0xf6, 0xc4, 0x49, 0x27, 0x77, 0x92, 0x2e, 0x3f, 0xb1,
-	0xf3, 0x6a, 0xc3, 0x4e, 0xeb, 0x62, 0x7f, 0x4b, 0x6a, 0x05, 0x3c, 0x90, 0x0e, 0xd1, 0x17, 0xd0,
-	0x58, 0x66, 0x77, 0x3d, 0x0b, 0xfc, 0x70, 0x4b, 0x9a, 0xff, 0x11, 0x68, 0x21, 0xd3, 0xa7, 0xd0,
-	0xda, 0x68, 0x88, 0x3e, 0x80, 0x5a, 0xb8, 0x5e, 0x8e, 0x32, 0x57, 0x15, 0x9a, 0x21, 0xf4, 0x09,
-	0xb4, 0x57, 0x31, 0xbb, 0x9f, 0x47, 0xeb, 0x44, 0x26, 0x25, 0x4f, 0xb6, 0x93, 0x93, 0x22, 0x2a,
-	0xf4, 0x11, 0x34, 0x45, 0x4d, 0x29, 0x50, 0x52, 0x41, 0x43, 0x10, 0x69, 0x8e, 0x2f, 0xa1, 0x59,
-	0xd8, 0x2d, 0xc6, 0x5b, 0x3a, 0x56, 0x8a, 0xf1, 0x9e, 0x41, 0x7b, 0xcb, 0x24, 0x3a, 0xda, 0x38,
-	0x8d, 0x14, 0x16, 0xf8, 0xf4, 0xf7, 0x12, 0xd4, 0x5c, 0x1e, 0xf0, 0x75, 0x82, 0x5a, 0x50, 0x1f,
-	0x92, 0x1b, 0xe2, 0x7c, 0x4b, 0xd4, 0x67, 0x68, 0x07, 0xea, 0xee, 0xd0, 0x34, 0xb1, 0xeb, 0xaa,
-	0x7f, 0x94, 0x90, 0x0a, 0xad, 0x9e, 0x61, 0xf9, 0x14, 0x7f, 0x33, 0xc4, 0xae, 0xa7, 0xfe, 0xac,
-	0xa0, 0x5d, 0x68, 0x5e, 0x3a, 0xb4, 0x67, 0x5b, 0x16, 0x26, 0xea, 0x2f, 0x29, 0x26, 0x8e, 0xe7,
-	0x5f, 0x3a, 0x43, 0x62, 0xa9, 0xbf, 0x2a, 0xe8, 0x05, 0x68, 0x99, 0xda, 0xc7, 0xc4, 0xb3, 0xbd,
-	0xef, 0x7c, 0xcf, 0x71, 0xfc, 0xbe, 0x41, 0xaf, 0xb0, 0xfa, 0x9b, 0x82, 0x8e, 0xe0, 0xd0, 0x26,
-	0x1e, 0xa6, 0xc4, 0xe8, 0xfb, 0x2e, 0xa6, 0xaf, 0x31, 0xf5, 0x31, 0xa5, 0x0e, 0x55, 0xff, 0x52,
-	0xd0, 0x01, 0xec, 0x89, 0x52, 0xf6, 0xed, 0xa0, 0x8f, 0x6f, 0x31, 0xf1, 0xb0, 0xa5, 0xfe, 0xad,
-	0x20, 0x0d, 0x3a, 0x42, 0x68, 0x9b, 0xd8, 0x1f, 0x12, 0xe3, 0xb5, 0x61, 0xf7, 0x8d, 0x5e, 0x1f,
-	0xab, 0xff, 0x28, 0xa7, 0x7f, 0x96, 0x00, 0xe4, 0xd4, 0x3d, 0xf1, 0x3f, 0x6e, 0x41, 0xfd, 0x16,
-	0xbb, 0xae, 0x71, 0x85, 0xd5, 0x67, 0x08, 0xa0, 0x66, 0x3a, 0xe4, 0xd2, 0xbe, 0x52, 0x4b, 0x68,
-	0x1f, 0xda, 0xf2, 0xd9, 0x1f, 0x0e, 0x2c, 0xc3, 0xc3, 0x6a, 0x19, 0x69, 0x70, 0x80, 0x89, 0xe5,
-	0x50, 0x17, 0x53, 0xdf, 0xa3, 0x06, 0x71, 0x0d, 0xd3, 0xb3, 0x1d, 0xa2, 0x2a, 0xe8, 0x39, 0x74,
-	0x1c, 0x6a, 0x61, 0xfa, 0x64, 0xa1, 0x82, 0x0e, 0x61, 0xdf, 0xc2, 0x7d, 0x5b, 0x38, 0x76, 0x31,
-	0xbe, 0xf1, 0x6d, 0x72, 0xe9, 0xa8, 0x55, 0x41, 0x9b, 0xd7, 0x86, 0x4d, 0x4c, 0xc7, 0xc2, 0xfe,
-	0xc0, 0x30, 0x6f, 0x44, 0xff, 0x9a, 0x68, 0x30, 0xc0, 0x98, 0xfa, 0x86, 0x75, 0x6b, 0x13, 0xdf,
-	0x19, 0x60, 0x6a, 0xa4, 0x75, 0x1a, 0x62, 0x83, 0xe7, 0xdc, 0x60, 0xb2, 0x55, 0xbe, 0x79, 0xfa,
-	0x16, 0xd0, 0x56, 0x78, 0xb6, 0x78, 0xb1, 0xa3, 0x5d, 0x00, 0xd7, 0xbe, 0x22, 0x86, 0x37, 0xa4,
-	0xd8, 0x55, 0x9f, 0xa1, 0x3d, 0x68, 0xf5, 0x0d, 0xd7, 0xf3, 0x8b, 0xb3, 0x3d, 0x87, 0xce, 0x46,
-	0x1d, 0xd7, 0xbf, 0xb4, 0xfb, 0x1e, 0xa6, 0x6a, 0x59, 0x4c, 0x23, 0x3b, 0x87, 0xaa, 0xf4, 0x5c,
-	0xf8, 0x34, 0x8a, 0xa7, 0xdd, 0xd9, 0xe3, 0x8a, 0xc5, 0x0b, 0x36, 0x99, 0xb2, 0xb8, 0x7b, 0x17,
-	0x8c, 0xe2, 0xf9, 0x58, 0xbe, 0xc6, 0x92, 0xec, 0x8e, 0xbf, 0x39, 0x9b, 0xce, 0xf9, 0x6c, 0x3d,
-	0x12, 0xf0, 0x7c, 0x43, 0x7c, 0x2e, 0xc5, 0xf2, 0x1b, 0x95, 0x64, 0xdf, 0xb1, 0x51, 0x2d, 0x85,
-	0x5f, 0xfe, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x63, 0x9b, 0x9f, 0x8b, 0xdf, 0x06, 0x00, 0x00,
+func init() { proto.RegisterFile("common/common.proto", fileDescriptor_common_f49453c97e16714e) }
+
+var fileDescriptor_common_f49453c97e16714e = []byte{
+	// 1075 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0x5f, 0x6f, 0xe3, 0xc4,
+	0x17, 0x5d, 0xd7, 0xcd, 0xbf, 0x9b, 0xa6, 0x75, 0x27, 0xfb, 0xc7, 0xbf, 0xfe, 0x58, 0x6d, 0x64,
+	0x58, 0x54, 0x76, 0xa5, 0x54, 0x94, 0x07, 0xe0, 0x09, 0x39, 0xf6, 0xb4, 0x6b, 0x35, 0xb5, 0xc3,
+	0xd8, 0xe9, 0x8a, 0x5d, 0x24, 0xcb, 0x49, 0xa6, 0x89, 0x45, 0x62, 0x47, 0xf6, 0x24, 0xdb, 0xbe,
+	0xc3, 0x2b, 0x42, 0x82, 0x57, 0xbe, 0x0b, 0x8f, 0x88, 0xcf, 0x03, 0xe2, 0x15, 0x8d, 0xc7, 0xf6,
+	0x26, 0x65, 0xc5, 0x3e, 0x25, 0xe7, 0xdc, 0x33, 0xf7, 0xde, 0xb9, 0xf7, 0xd8, 0x86, 0xf6, 0x38,
+	0x5e, 0x2c, 0xe2, 0xe8, 0x44, 0xfc, 0x74, 0x97, 0x49, 0xcc, 0x62, 0x54, 0x15, 0xe8, 0xe8, 0xc9,
+	0x34, 0x8e, 0xa7, 0x73, 0x7a, 0x92, 0xb1, 0xa3, 0xd5, 0xf5, 0x09, 0x0b, 0x17, 0x34, 0x65, 0xc1,
+	0x62, 0x29, 0x84, 0x9a, 0x06, 0xd0, 0x0f, 0x52, 0x66, 0xc4, 0xd1, 0x75, 0x38, 0x45, 0xf7, 0xa1,
+	0x12, 0x46, 0x13, 0x7a, 0xa3, 0x4a, 0x1d, 0xe9, 0x78, 0x97, 0x08, 0xa0, 0xbd, 0x86, 0xfa, 0x25,
+	0x65, 0xc1, 0x24, 0x60, 0x01, 0x57, 0xac, 0x83, 0xf9, 0x8a, 0x66, 0x8a, 0x3d, 0x22, 0x00, 0xfa,
+	0x12, 0x20, 0x0d, 0xa7, 0x51, 0xc0, 0x56, 0x09, 0x4d, 0xd5, 0x9d, 0x8e, 0x7c, 0xdc, 0x3c, 0xfd,
+	0x5f, 0x37, 0xef, 0xa8, 0x38, 0xeb, 0x16, 0x0a, 0xb2, 0x21, 0xd6, 0xbe, 0x85, 0xc3, 0x7f, 0x09,
+	0xd0, 0x27, 0xa0, 0x94, 0x12, 0x7f, 0x46, 0x83, 0x09, 0x4d, 0xf2, 0x82, 0x07, 0x25, 0xff, 0x22,
+	0xa3, 0xd1, 0x07, 0xd0, 0x28, 0x29, 0x75, 0x27, 0xd3, 0xbc, 0x25, 0xb4, 0x57, 0x50, 0xcd, 0x75,
+	0x4f, 0x61, 0x7f, 0x3c, 0x0b, 0xa2, 0x88, 0xce, 0xb7, 0x13, 0xb6, 0x72, 0x36, 0x97, 0xbd, 0xab,
+	0xf2, 0xce, 0x3b, 0x2b, 0x6b, 0x3f, 0xc8, 0xd0, 0x32, 0xb6, 0x0e, 0x23, 0xd8, 0x65, 0xb7, 0x4b,
+	0x31, 0x9b, 0x0a, 0xc9, 0xfe, 0x23, 0x15, 0x6a, 0x6b, 0x9a, 0xa4, 0x61, 0x1c, 0x65, 0x79, 0x2a,
+	0xa4, 0x80, 0xe8, 0x0b, 0x68, 0x94, 0xdb, 0x50, 0xe5, 0x8e, 0x74, 0xdc, 0x3c, 0x3d, 0xea, 0x8a,
+	0x7d, 0x75, 0x8b, 0x7d, 0x75, 0xbd, 0x42, 0x41, 0xde, 0x8a, 0xd1, 0x63, 0x80, 0xe2, 0x2e, 0xe1,
+	0x44, 0xdd, 0xed, 0x48, 0xc7, 0x0d, 0xd2, 0xc8, 0x19, 0x6b, 0x82, 0xda, 0x50, 0x61, 0x37, 0x3c,
+	0x52, 0xc9, 0x22, 0xbb, 0xec, 0xc6, 0x9a, 0xf0, 0xc5, 0xd1, 0x65, 0x3c, 0x9e, 0xa9, 0x55, 0xb1,
+	0xda, 0x0c, 0xf0, 0xe9, 0xd1, 0x1b, 0x46, 0xa3, 0xac, 0xbf, 0x9a, 0x98, 0x5e, 0x49, 0x20, 0x0d,
+	0x5a, 0x6c, 0x9e, 0xfa, 0x63, 0x9a, 0x30, 0x7f, 0x16, 0xa4, 0x33, 0xb5, 0x9e, 0x29, 0x9a, 0x6c,
+	0x9e, 0x1a, 0x34, 0x61, 0x2f, 0x82, 0x74, 0x86, 0x3e, 0x84, 0x16, 0x4b, 0x82, 0x31, 0xf5, 0xc7,
+	0x71, 0xc4, 0xe8, 0x0d, 0x53, 0x1b, 0x59, 0xd1, 0xbd, 0x8c, 0x34, 0x04, 0x87, 0xbe, 0x82, 0x83,
+	0x75, 0x30, 0x0f, 0x27, 0x21, 0xbb, 0xf5, 0xdf, 0x84, 0xd1, 0x24, 0x7e, 0xa3, 0x42, 0x76, 0xe1,
+	0x87, 0x85, 0x49, 0xae, 0xf2, 0xf0, 0xcb, 0x2c, 0x4a, 0xf6, 0xd7, 0x5b, 0x18, 0x1d, 0x41, 0x7d,
+	0x99, 0x84, 0x71, 0x12, 0xb2, 0x5b, 0xb5, 0xd9, 0x91, 0x8e, 0x5b, 0xa4, 0xc4, 0xda, 0xf7, 0x12,
+	0xec, 0x6f, 0x1f, 0xe7, 0x7e, 0x8c, 0x62, 0xe6, 0x8f, 0xe8, 0x75, 0x9c, 0x88, 0x75, 0xbc, 0x67,
+	0xb6, 0x51, 0xcc, 0x7a, 0x99, 0x18, 0x7d, 0x0e, 0x1c, 0xf8, 0xc1, 0x35, 0xcb, 0x37, 0xff, 0xdf,
+	0x27, 0xeb, 0x51, 0xcc, 0x74, 0xae, 0xd5, 0x74, 0x38, 0x70, 0xef, 0x78, 0x53, 0x85, 0xda, 0x38,
+	0xa1, 0x01, 0x8b, 0x0b, 0xb3, 0x15, 0x90, 0x6f, 0x23, 0x8a, 0xa3, 0x71, 0xe1, 0x58, 0x01, 0x34,
+	0x0c, 0xb5, 0x41, 0x70, 0x3b, 0x8f, 0x83, 0x09, 0xfa, 0x18, 0xaa, 0x1b, 0x36, 0x6d, 0x9e, 0xee,
+	0x17, 0x83, 0x12, 0xa9, 0x49, 0x75, 0x56, 0x5a, 0x8e, 0x3f, 0x3a, 0x79, 0x9e, 0xec, 0xbf, 0xd6,
+	0x83, 0x3a, 0x8e, 0xd6, 0x74, 0x1e, 0x0b, 0xfb, 0x2d, 0x45, 0xca, 0xa2, 0x85, 0x1c, 0xbe, 0xe7,
+	0xc1, 0xf9, 0x51, 0x82, 0x4a, 0x6f, 0x1e, 0x8f, 0xbf, 0x43, 0xcf, 0xef, 0x74, 0xd2, 0x2e, 0x3a,
+	0xc9, 0xc2, 0x77, 0xda, 0x79, 0xba, 0xd1, 0x4e, 0xf3, 0xf4, 0x70, 0x4b, 0x6a, 0x06, 0x2c, 0x10,
+	0x1d, 0xa2, 0x4f, 0xa1, 0xbe, 0xc8, 0x1f, 0xfa, 0xdc, 0xf9, 0x0f, 0xb6, 0xa4, 0xc5, 0x1b, 0x81,
+	0x94, 0x32, 0x6d, 0x0a, 0xcd, 0x8d, 0x82, 0xe8, 0x21, 0x54, 0xa3, 0xd5, 0x62, 0x94, 0x77, 0xb5,
+	0x4b, 0x72, 0xc4, 0xed, 0xb8, 0x4c, 0xe8, 0x3a, 0x8c, 0x57, 0xa9, 0xb0, 0xac, 0xb8, 0xd9, 0x5e,
+	0x41, 0x66, 0x9e, 0xfd, 0x3f, 0x34, 0x78, 0x4e, 0x21, 0x90, 0x33, 0x41, 0x9d, 0x13, 0x3c, 0xa8,
+	0x3d, 0x81, 0x46, 0xd9, 0x6e, 0x39, 0x5e, 0xa9, 0x23, 0x97, 0xe3, 0x7d, 0x0e, 0xad, 0xad, 0x26,
+	0xb9, 0x39, 0xcb, 0xdb, 0x08, 0x61, 0x89, 0x9f, 0xfd, 0x26, 0x41, 0xd5, 0x65, 0x01, 0x5b, 0xa5,
+	0xa8, 0x09, 0xb5, 0xa1, 0x7d, 0x61, 0x3b, 0x2f, 0x6d, 0xe5, 0x1e, 0xda, 0x83, 0x9a, 0x3b, 0x34,
+	0x0c, 0xec, 0xba, 0xca, 0xef, 0x12, 0x52, 0xa0, 0xd9, 0xd3, 0x4d, 0x9f, 0xe0, 0xaf, 0x87, 0xd8,
+	0xf5, 0x94, 0x9f, 0x64, 0xb4, 0x0f, 0x8d, 0x33, 0x87, 0xf4, 0x2c, 0xd3, 0xc4, 0xb6, 0xf2, 0x73,
+	0x86, 0x6d, 0xc7, 0xf3, 0xcf, 0x9c, 0xa1, 0x6d, 0x2a, 0xbf, 0xc8, 0xe8, 0x31, 0xa8, 0xb9, 0xda,
+	0xc7, 0xb6, 0x67, 0x79, 0xdf, 0xf8, 0x9e, 0xe3, 0xf8, 0x7d, 0x9d, 0x9c, 0x63, 0xe5, 0x57, 0x19,
+	0x1d, 0xc1, 0x03, 0xcb, 0xf6, 0x30, 0xb1, 0xf5, 0xbe, 0xef, 0x62, 0x72, 0x85, 0x89, 0x8f, 0x09,
+	0x71, 0x88, 0xf2, 0xa7, 0x8c, 0xee, 0xc3, 0x01, 0x4f, 0x65, 0x5d, 0x0e, 0xfa, 0xf8, 0x12, 0xdb,
+	0x1e, 0x36, 0x95, 0xbf, 0x64, 0xa4, 0x42, 0x9b, 0x0b, 0x2d, 0x03, 0xfb, 0x43, 0x5b, 0xbf, 0xd2,
+	0xad, 0xbe, 0xde, 0xeb, 0x63, 0xe5, 0x6f, 0xf9, 0xd9, 0x1f, 0x12, 0x80, 0x98, 0xba, 0xc7, 0x5f,
+	0x68, 0x4d, 0xa8, 0x5d, 0x62, 0xd7, 0xd5, 0xcf, 0xb1, 0x72, 0x0f, 0x01, 0x54, 0x0d, 0xc7, 0x3e,
+	0xb3, 0xce, 0x15, 0x09, 0x1d, 0x42, 0x4b, 0xfc, 0xf7, 0x87, 0x03, 0x53, 0xf7, 0xb0, 0xb2, 0x83,
+	0x54, 0xb8, 0x8f, 0x6d, 0xd3, 0x21, 0x2e, 0x26, 0xbe, 0x47, 0x74, 0xdb, 0xd5, 0x0d, 0xcf, 0x72,
+	0x6c, 0x45, 0x46, 0x8f, 0xa0, 0xed, 0x10, 0x13, 0x93, 0x3b, 0x81, 0x5d, 0xf4, 0x00, 0x0e, 0x4d,
+	0xdc, 0xb7, 0x78, 0xc7, 0x2e, 0xc6, 0x17, 0xbe, 0x65, 0x9f, 0x39, 0x4a, 0x85, 0xd3, 0xc6, 0x0b,
+	0xdd, 0xb2, 0x0d, 0xc7, 0xc4, 0xfe, 0x40, 0x37, 0x2e, 0x78, 0xfd, 0x2a, 0x2f, 0x30, 0xc0, 0x98,
+	0xf8, 0xba, 0x79, 0x69, 0xd9, 0xbe, 0x33, 0xc0, 0x44, 0xcf, 0xf2, 0xd4, 0xf9, 0x01, 0xcf, 0xb9,
+	0xc0, 0xf6, 0x56, 0xfa, 0xc6, 0xb3, 0xd7, 0x80, 0xb6, 0x96, 0x67, 0xf1, 0x2f, 0x1c, 0xda, 0x07,
+	0x70, 0xad, 0x73, 0x5b, 0xf7, 0x86, 0x04, 0xbb, 0xca, 0x3d, 0x74, 0x00, 0xcd, 0xbe, 0xee, 0x7a,
+	0x7e, 0x79, 0xb7, 0x47, 0xd0, 0xde, 0xc8, 0xe3, 0xfa, 0x67, 0x56, 0xdf, 0xc3, 0x44, 0xd9, 0xe1,
+	0xd3, 0xc8, 0xef, 0xa1, 0xc8, 0x3d, 0x17, 0x3e, 0x8a, 0x93, 0x69, 0x77, 0x76, 0xbb, 0xa4, 0xc9,
+	0x9c, 0x4e, 0xa6, 0x34, 0xe9, 0x5e, 0x07, 0xa3, 0x24, 0x1c, 0x8b, 0x37, 0x47, 0x9a, 0x7b, 0xfc,
+	0xd5, 0xf3, 0x69, 0xc8, 0x66, 0xab, 0x11, 0x87, 0x27, 0x1b, 0xe2, 0x13, 0x21, 0x16, 0x1f, 0xeb,
+	0x34, 0xff, 0xa0, 0x8f, 0xaa, 0x19, 0xfc, 0xec, 0x9f, 0x01, 0x00, 0xc9, 0x7c, 0x7b, 0x2c, 0xe8,
+	0x07, 0x00, 0x00,
 }
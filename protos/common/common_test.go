@@ -345,6 +345,26 @@ func TestCommonProtolator(t *testing.T) {
 	assert.Equal(t, &SignatureHeader{}, msg)
 	assert.NoError(t, err)
 
+	// ChannelHeader
+	ch = &ChannelHeader{
+		Type: int32(HeaderType_CONFIG),
+	}
+	assert.Equal(t, []string{"extension"}, ch.VariablyOpaqueFields())
+
+	msg, err = ch.VariablyOpaqueFieldProto("badproto")
+	assert.Nil(t, msg)
+	assert.Error(t, err)
+
+	msg, err = ch.VariablyOpaqueFieldProto("extension")
+	assert.Nil(t, msg)
+	assert.Error(t, err)
+
+	ChannelHeaderExtensionMap[int32(HeaderType_CONFIG)] = &SignatureHeader{}
+	defer delete(ChannelHeaderExtensionMap, int32(HeaderType_CONFIG))
+	msg, err = ch.VariablyOpaqueFieldProto("extension")
+	assert.Equal(t, &SignatureHeader{}, msg)
+	assert.NoError(t, err)
+
 	// BlockData
 	var bd *BlockData
 	assert.Equal(t, []string{"data"}, bd.StaticallyOpaqueSliceFields())
@@ -215,6 +215,8 @@ func (dcocv *DynamicConsortiumOrgConfigValue) VariablyOpaqueFieldProto(name stri
 	switch dcocv.name {
 	case "MSP":
 		return &msp.MSPConfig{}, nil
+	case "PeerEndpoints":
+		return &PeerEndpoints{}, nil
 	default:
 		return nil, fmt.Errorf("unknown Consortium Org ConfigValue name: %s", dcocv.name)
 	}
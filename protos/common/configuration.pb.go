@@ -32,7 +32,7 @@ func (m *HashingAlgorithm) Reset()         { *m = HashingAlgorithm{} }
 func (m *HashingAlgorithm) String() string { return proto.CompactTextString(m) }
 func (*HashingAlgorithm) ProtoMessage()    {}
 func (*HashingAlgorithm) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_c60fbe5ebb3de531, []int{0}
+	return fileDescriptor_configuration_9f9a9857b17c2e6e, []int{0}
 }
 func (m *HashingAlgorithm) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HashingAlgorithm.Unmarshal(m, b)
@@ -74,7 +74,7 @@ func (m *BlockDataHashingStructure) Reset()         { *m = BlockDataHashingStruc
 func (m *BlockDataHashingStructure) String() string { return proto.CompactTextString(m) }
 func (*BlockDataHashingStructure) ProtoMessage()    {}
 func (*BlockDataHashingStructure) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_c60fbe5ebb3de531, []int{1}
+	return fileDescriptor_configuration_9f9a9857b17c2e6e, []int{1}
 }
 func (m *BlockDataHashingStructure) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockDataHashingStructure.Unmarshal(m, b)
@@ -114,7 +114,7 @@ func (m *OrdererAddresses) Reset()         { *m = OrdererAddresses{} }
 func (m *OrdererAddresses) String() string { return proto.CompactTextString(m) }
 func (*OrdererAddresses) ProtoMessage()    {}
 func (*OrdererAddresses) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_c60fbe5ebb3de531, []int{2}
+	return fileDescriptor_configuration_9f9a9857b17c2e6e, []int{2}
 }
 func (m *OrdererAddresses) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_OrdererAddresses.Unmarshal(m, b)
@@ -141,6 +141,47 @@ func (m *OrdererAddresses) GetAddresses() []string {
 	return nil
 }
 
+// PeerEndpoints is encoded into the configuration transaction as a configuration item of an organization
+// with a Key of "PeerEndpoints" and a Value of PeerEndpoints as marshaled protobuf bytes.  The addresses are
+// the host:port external endpoints of the peers of the organization
+type PeerEndpoints struct {
+	Addresses            []string `protobuf:"bytes,1,rep,name=addresses" json:"addresses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerEndpoints) Reset()         { *m = PeerEndpoints{} }
+func (m *PeerEndpoints) String() string { return proto.CompactTextString(m) }
+func (*PeerEndpoints) ProtoMessage()    {}
+func (*PeerEndpoints) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_9f9a9857b17c2e6e, []int{3}
+}
+func (m *PeerEndpoints) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerEndpoints.Unmarshal(m, b)
+}
+func (m *PeerEndpoints) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerEndpoints.Marshal(b, m, deterministic)
+}
+func (dst *PeerEndpoints) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerEndpoints.Merge(dst, src)
+}
+func (m *PeerEndpoints) XXX_Size() int {
+	return xxx_messageInfo_PeerEndpoints.Size(m)
+}
+func (m *PeerEndpoints) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerEndpoints.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerEndpoints proto.InternalMessageInfo
+
+func (m *PeerEndpoints) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
 // Consortium represents the consortium context in which the channel was created
 type Consortium struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
@@ -153,7 +194,7 @@ func (m *Consortium) Reset()         { *m = Consortium{} }
 func (m *Consortium) String() string { return proto.CompactTextString(m) }
 func (*Consortium) ProtoMessage()    {}
 func (*Consortium) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_c60fbe5ebb3de531, []int{3}
+	return fileDescriptor_configuration_9f9a9857b17c2e6e, []int{4}
 }
 func (m *Consortium) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Consortium.Unmarshal(m, b)
@@ -221,7 +262,7 @@ func (m *Capabilities) Reset()         { *m = Capabilities{} }
 func (m *Capabilities) String() string { return proto.CompactTextString(m) }
 func (*Capabilities) ProtoMessage()    {}
 func (*Capabilities) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_c60fbe5ebb3de531, []int{4}
+	return fileDescriptor_configuration_9f9a9857b17c2e6e, []int{5}
 }
 func (m *Capabilities) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Capabilities.Unmarshal(m, b)
@@ -262,7 +303,7 @@ func (m *Capability) Reset()         { *m = Capability{} }
 func (m *Capability) String() string { return proto.CompactTextString(m) }
 func (*Capability) ProtoMessage()    {}
 func (*Capability) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_c60fbe5ebb3de531, []int{5}
+	return fileDescriptor_configuration_9f9a9857b17c2e6e, []int{6}
 }
 func (m *Capability) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Capability.Unmarshal(m, b)
@@ -286,6 +327,7 @@ func init() {
 	proto.RegisterType((*HashingAlgorithm)(nil), "common.HashingAlgorithm")
 	proto.RegisterType((*BlockDataHashingStructure)(nil), "common.BlockDataHashingStructure")
 	proto.RegisterType((*OrdererAddresses)(nil), "common.OrdererAddresses")
+	proto.RegisterType((*PeerEndpoints)(nil), "common.PeerEndpoints")
 	proto.RegisterType((*Consortium)(nil), "common.Consortium")
 	proto.RegisterType((*Capabilities)(nil), "common.Capabilities")
 	proto.RegisterMapType((map[string]*Capability)(nil), "common.Capabilities.CapabilitiesEntry")
@@ -293,29 +335,30 @@ func init() {
 }
 
 func init() {
-	proto.RegisterFile("common/configuration.proto", fileDescriptor_configuration_c60fbe5ebb3de531)
-}
-
-var fileDescriptor_configuration_c60fbe5ebb3de531 = []byte{
-	// 314 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x41, 0x6b, 0xf2, 0x40,
-	0x10, 0x86, 0x89, 0x7e, 0x0a, 0x8e, 0x7e, 0x60, 0x97, 0x1e, 0xac, 0xf4, 0x10, 0x42, 0x91, 0x40,
-	0x21, 0x69, 0xed, 0xa5, 0xf4, 0xa6, 0xb6, 0x50, 0x7a, 0x29, 0xc4, 0x5b, 0x6f, 0x9b, 0x64, 0x4c,
-	0x16, 0x93, 0x5d, 0x99, 0xdd, 0xb4, 0xe4, 0x57, 0xf5, 0x2f, 0x16, 0xb3, 0x16, 0x23, 0xf6, 0x36,
-	0xcf, 0xce, 0xf3, 0xce, 0xce, 0xb2, 0x30, 0x4d, 0x54, 0x59, 0x2a, 0x19, 0x26, 0x4a, 0x6e, 0x44,
-	0x56, 0x11, 0x37, 0x42, 0xc9, 0x60, 0x47, 0xca, 0x28, 0xd6, 0xb7, 0x3d, 0x6f, 0x06, 0xe3, 0x57,
-	0xae, 0x73, 0x21, 0xb3, 0x45, 0x91, 0x29, 0x12, 0x26, 0x2f, 0x19, 0x83, 0x7f, 0x92, 0x97, 0x38,
-	0x71, 0x5c, 0xc7, 0x1f, 0x44, 0x4d, 0xed, 0xdd, 0xc3, 0xd5, 0xb2, 0x50, 0xc9, 0xf6, 0x99, 0x1b,
-	0x7e, 0x08, 0xac, 0x0d, 0x55, 0x89, 0xa9, 0x08, 0xd9, 0x25, 0xf4, 0xbe, 0x44, 0x6a, 0xf2, 0x26,
-	0xf1, 0x3f, 0xb2, 0xe0, 0xdd, 0xc1, 0xf8, 0x9d, 0x52, 0x24, 0xa4, 0x45, 0x9a, 0x12, 0x6a, 0x8d,
-	0x9a, 0x5d, 0xc3, 0x80, 0xff, 0xc2, 0xc4, 0x71, 0xbb, 0xfe, 0x20, 0x3a, 0x1e, 0x78, 0x2e, 0xc0,
-	0x4a, 0x49, 0xad, 0xc8, 0x88, 0xea, 0xef, 0x35, 0xbe, 0x1d, 0x18, 0xad, 0xf8, 0x8e, 0xc7, 0xa2,
-	0x10, 0x46, 0xa0, 0x66, 0x6f, 0x30, 0x4a, 0x5a, 0xdc, 0xcc, 0x1c, 0xce, 0x67, 0x81, 0x7d, 0x5e,
-	0xd0, 0x76, 0x4f, 0xe0, 0x45, 0x1a, 0xaa, 0xa3, 0x93, 0xec, 0x74, 0x0d, 0x17, 0x67, 0x0a, 0x1b,
-	0x43, 0x77, 0x8b, 0xf5, 0x61, 0x89, 0x7d, 0xc9, 0x7c, 0xe8, 0x7d, 0xf2, 0xa2, 0xc2, 0x49, 0xc7,
-	0x75, 0xfc, 0xe1, 0x9c, 0x9d, 0xdd, 0x55, 0x47, 0x56, 0x78, 0xea, 0x3c, 0x3a, 0xde, 0x08, 0xe0,
-	0xd8, 0x58, 0xae, 0xe1, 0x46, 0x51, 0x16, 0xe4, 0xf5, 0x0e, 0xa9, 0xc0, 0x34, 0x43, 0x0a, 0x36,
-	0x3c, 0x26, 0x91, 0xd8, 0x6f, 0xd1, 0x87, 0x59, 0x1f, 0xb7, 0x99, 0x30, 0x79, 0x15, 0xef, 0x31,
-	0x6c, 0xc9, 0xa1, 0x95, 0x43, 0x2b, 0x87, 0x56, 0x8e, 0xfb, 0x0d, 0x3e, 0xfc, 0x04, 0x00, 0x00,
-	0xff, 0xff, 0xd6, 0x7e, 0xb4, 0x89, 0xf0, 0x01, 0x00, 0x00,
+	proto.RegisterFile("common/configuration.proto", fileDescriptor_configuration_9f9a9857b17c2e6e)
+}
+
+var fileDescriptor_configuration_9f9a9857b17c2e6e = []byte{
+	// 326 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x91, 0x41, 0x6b, 0xea, 0x40,
+	0x10, 0xc7, 0x89, 0x3e, 0x05, 0x47, 0x05, 0xdf, 0xf2, 0x0e, 0x3e, 0xe9, 0x21, 0x84, 0x22, 0x81,
+	0xd2, 0xa4, 0xb5, 0x97, 0xd2, 0x9b, 0x5a, 0xa1, 0xf4, 0xd2, 0x12, 0x6f, 0xbd, 0x6d, 0x92, 0x31,
+	0x59, 0x4c, 0x76, 0xc3, 0x64, 0xd3, 0x92, 0x4f, 0xd5, 0xaf, 0x58, 0xcc, 0x5a, 0x54, 0x2c, 0xf4,
+	0x36, 0xbf, 0x9d, 0xdf, 0x7f, 0x76, 0xd8, 0x85, 0x49, 0xa4, 0xf2, 0x5c, 0x49, 0x3f, 0x52, 0x72,
+	0x23, 0x92, 0x8a, 0xb8, 0x16, 0x4a, 0x7a, 0x05, 0x29, 0xad, 0x58, 0xd7, 0xf4, 0x9c, 0x29, 0x8c,
+	0x9e, 0x78, 0x99, 0x0a, 0x99, 0xcc, 0xb3, 0x44, 0x91, 0xd0, 0x69, 0xce, 0x18, 0xfc, 0x91, 0x3c,
+	0xc7, 0xb1, 0x65, 0x5b, 0x6e, 0x2f, 0x68, 0x6a, 0xe7, 0x16, 0xfe, 0x2f, 0x32, 0x15, 0x6d, 0x1f,
+	0xb9, 0xe6, 0xfb, 0xc0, 0x5a, 0x53, 0x15, 0xe9, 0x8a, 0x90, 0xfd, 0x83, 0xce, 0x87, 0x88, 0x75,
+	0xda, 0x24, 0x86, 0x81, 0x01, 0xe7, 0x06, 0x46, 0x2f, 0x14, 0x23, 0x21, 0xcd, 0xe3, 0x98, 0xb0,
+	0x2c, 0xb1, 0x64, 0x17, 0xd0, 0xe3, 0xdf, 0x30, 0xb6, 0xec, 0xb6, 0xdb, 0x0b, 0x0e, 0x07, 0xce,
+	0x35, 0x0c, 0x5f, 0x11, 0x69, 0x25, 0xe3, 0x42, 0x09, 0xa9, 0x7f, 0xd3, 0x6d, 0x80, 0xa5, 0x92,
+	0xa5, 0x22, 0x2d, 0xaa, 0x9f, 0xb7, 0xfe, 0xb4, 0x60, 0xb0, 0xe4, 0x05, 0x0f, 0x45, 0x26, 0xb4,
+	0xc0, 0x92, 0x3d, 0xc3, 0x20, 0x3a, 0xe2, 0x66, 0x66, 0x7f, 0x36, 0xf5, 0xcc, 0x6b, 0x78, 0xc7,
+	0xee, 0x09, 0xac, 0xa4, 0xa6, 0x3a, 0x38, 0xc9, 0x4e, 0xd6, 0xf0, 0xf7, 0x4c, 0x61, 0x23, 0x68,
+	0x6f, 0xb1, 0xde, 0x2f, 0xb1, 0x2b, 0x99, 0x0b, 0x9d, 0x77, 0x9e, 0x55, 0x38, 0x6e, 0xd9, 0x96,
+	0xdb, 0x9f, 0xb1, 0xb3, 0xbb, 0xea, 0xc0, 0x08, 0x0f, 0xad, 0x7b, 0xcb, 0x19, 0x00, 0x1c, 0x1a,
+	0x8b, 0x35, 0x5c, 0x2a, 0x4a, 0xbc, 0xb4, 0x2e, 0x90, 0x32, 0x8c, 0x13, 0x24, 0x6f, 0xc3, 0x43,
+	0x12, 0x91, 0xf9, 0xc5, 0x72, 0x3f, 0xeb, 0xed, 0x2a, 0x11, 0x3a, 0xad, 0xc2, 0x1d, 0xfa, 0x47,
+	0xb2, 0x6f, 0x64, 0xdf, 0xc8, 0xbe, 0x91, 0xc3, 0x6e, 0x83, 0x77, 0x5f, 0x03, 0x00, 0xbe, 0x83,
+	0x83, 0x58, 0x1f, 0x02, 0x00, 0x00,
 }
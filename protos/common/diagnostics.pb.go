@@ -0,0 +1,285 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: common/diagnostics.proto
+
+package common // import "github.com/hyperledger/fabric/protos/common"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// PingRequest is sent to a node to check that it is reachable
+type PingRequest struct {
+	Nonce                []byte   `protobuf:"bytes,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PingRequest) Reset()         { *m = PingRequest{} }
+func (m *PingRequest) String() string { return proto.CompactTextString(m) }
+func (*PingRequest) ProtoMessage()    {}
+func (*PingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_diagnostics_112a9e132323079b, []int{0}
+}
+func (m *PingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PingRequest.Unmarshal(m, b)
+}
+func (m *PingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PingRequest.Marshal(b, m, deterministic)
+}
+func (dst *PingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PingRequest.Merge(dst, src)
+}
+func (m *PingRequest) XXX_Size() int {
+	return xxx_messageInfo_PingRequest.Size(m)
+}
+func (m *PingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PingRequest proto.InternalMessageInfo
+
+func (m *PingRequest) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
+// PingResponse carries a PingResponsePayload along with a signature over it
+// by the node that handled the request
+type PingResponse struct {
+	Payload              []byte   `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PingResponse) Reset()         { *m = PingResponse{} }
+func (m *PingResponse) String() string { return proto.CompactTextString(m) }
+func (*PingResponse) ProtoMessage()    {}
+func (*PingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_diagnostics_112a9e132323079b, []int{1}
+}
+func (m *PingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PingResponse.Unmarshal(m, b)
+}
+func (m *PingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PingResponse.Marshal(b, m, deterministic)
+}
+func (dst *PingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PingResponse.Merge(dst, src)
+}
+func (m *PingResponse) XXX_Size() int {
+	return xxx_messageInfo_PingResponse.Size(m)
+}
+func (m *PingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PingResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PingResponse proto.InternalMessageInfo
+
+func (m *PingResponse) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *PingResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// PingResponsePayload describes the node that handled a PingRequest
+type PingResponsePayload struct {
+	Nonce                []byte               `protobuf:"bytes,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Identity             []byte               `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	Version              string               `protobuf:"bytes,4,opt,name=version" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PingResponsePayload) Reset()         { *m = PingResponsePayload{} }
+func (m *PingResponsePayload) String() string { return proto.CompactTextString(m) }
+func (*PingResponsePayload) ProtoMessage()    {}
+func (*PingResponsePayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_diagnostics_112a9e132323079b, []int{2}
+}
+func (m *PingResponsePayload) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PingResponsePayload.Unmarshal(m, b)
+}
+func (m *PingResponsePayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PingResponsePayload.Marshal(b, m, deterministic)
+}
+func (dst *PingResponsePayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PingResponsePayload.Merge(dst, src)
+}
+func (m *PingResponsePayload) XXX_Size() int {
+	return xxx_messageInfo_PingResponsePayload.Size(m)
+}
+func (m *PingResponsePayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_PingResponsePayload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PingResponsePayload proto.InternalMessageInfo
+
+func (m *PingResponsePayload) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
+func (m *PingResponsePayload) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *PingResponsePayload) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *PingResponsePayload) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*PingRequest)(nil), "common.PingRequest")
+	proto.RegisterType((*PingResponse)(nil), "common.PingResponse")
+	proto.RegisterType((*PingResponsePayload)(nil), "common.PingResponsePayload")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Diagnostics service
+
+type DiagnosticsClient interface {
+	// Ping expects an Envelope of type MESSAGE signed by the caller whose data
+	// is a PingRequest, and returns a PingResponse signed by the node
+	Ping(ctx context.Context, in *Envelope, opts ...grpc.CallOption) (*PingResponse, error)
+}
+
+type diagnosticsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDiagnosticsClient(cc *grpc.ClientConn) DiagnosticsClient {
+	return &diagnosticsClient{cc}
+}
+
+func (c *diagnosticsClient) Ping(ctx context.Context, in *Envelope, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	err := grpc.Invoke(ctx, "/common.Diagnostics/Ping", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Diagnostics service
+
+type DiagnosticsServer interface {
+	// Ping expects an Envelope of type MESSAGE signed by the caller whose data
+	// is a PingRequest, and returns a PingResponse signed by the node
+	Ping(context.Context, *Envelope) (*PingResponse, error)
+}
+
+func RegisterDiagnosticsServer(s *grpc.Server, srv DiagnosticsServer) {
+	s.RegisterService(&_Diagnostics_serviceDesc, srv)
+}
+
+func _Diagnostics_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagnosticsServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/common.Diagnostics/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagnosticsServer).Ping(ctx, req.(*Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Diagnostics_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "common.Diagnostics",
+	HandlerType: (*DiagnosticsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Ping",
+			Handler:    _Diagnostics_Ping_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "common/diagnostics.proto",
+}
+
+func init() {
+	proto.RegisterFile("common/diagnostics.proto", fileDescriptor_diagnostics_112a9e132323079b)
+}
+
+var fileDescriptor_diagnostics_112a9e132323079b = []byte{
+	// 311 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x4f, 0x4b, 0xf3, 0x40,
+	0x10, 0xc6, 0xdf, 0xbc, 0xd6, 0x6a, 0xa7, 0x3d, 0xc8, 0xb6, 0x87, 0x10, 0x04, 0x4b, 0xf4, 0x50,
+	0x10, 0x36, 0x52, 0x2f, 0xde, 0x04, 0x51, 0xcf, 0x25, 0x7a, 0xf2, 0x96, 0x3f, 0xd3, 0xed, 0x42,
+	0xb2, 0x13, 0x77, 0x37, 0x85, 0x7e, 0x16, 0xbf, 0xac, 0xb4, 0xbb, 0x69, 0x73, 0xf0, 0x14, 0x9e,
+	0x67, 0x7f, 0x33, 0x99, 0x79, 0x06, 0xc2, 0x82, 0xea, 0x9a, 0x54, 0x52, 0xca, 0x4c, 0x28, 0x32,
+	0x56, 0x16, 0x86, 0x37, 0x9a, 0x2c, 0xb1, 0xa1, 0x7b, 0x89, 0x6e, 0x04, 0x91, 0xa8, 0x30, 0x39,
+	0xb8, 0x79, 0xbb, 0x4e, 0xac, 0xac, 0xd1, 0xd8, 0xac, 0x6e, 0x1c, 0x18, 0x4d, 0x7d, 0x0b, 0xf7,
+	0x71, 0x66, 0x7c, 0x0b, 0xe3, 0x95, 0x54, 0x22, 0xc5, 0xef, 0x16, 0x8d, 0x65, 0x33, 0x38, 0x57,
+	0xa4, 0x0a, 0x0c, 0x83, 0x79, 0xb0, 0x98, 0xa4, 0x4e, 0xc4, 0xef, 0x30, 0x71, 0x90, 0x69, 0x48,
+	0x19, 0x64, 0x21, 0x5c, 0x34, 0xd9, 0xae, 0xa2, 0xac, 0xf4, 0x5c, 0x27, 0xd9, 0x35, 0x8c, 0x8c,
+	0x14, 0x2a, 0xb3, 0xad, 0xc6, 0xf0, 0xff, 0xe1, 0xed, 0x64, 0xc4, 0x3f, 0x01, 0x4c, 0xfb, 0x8d,
+	0x56, 0xbe, 0xea, 0xcf, 0xbf, 0xb2, 0x08, 0x2e, 0x65, 0x89, 0xca, 0x4a, 0xbb, 0xf3, 0xad, 0x8e,
+	0x9a, 0x3d, 0xc1, 0xe8, 0xb8, 0x5e, 0x78, 0x36, 0x0f, 0x16, 0xe3, 0x65, 0xc4, 0x5d, 0x00, 0xbc,
+	0x0b, 0x80, 0x7f, 0x76, 0x44, 0x7a, 0x82, 0xf7, 0xb3, 0x6f, 0x51, 0x1b, 0x49, 0x2a, 0x1c, 0xcc,
+	0x83, 0xc5, 0x28, 0xed, 0xe4, 0xf2, 0x19, 0xc6, 0xaf, 0xa7, 0x74, 0xd9, 0x03, 0x0c, 0xf6, 0xb3,
+	0xb2, 0x2b, 0xee, 0x03, 0x7b, 0x53, 0x5b, 0xac, 0xa8, 0xc1, 0x68, 0xd6, 0x39, 0xfd, 0x5d, 0xe2,
+	0x7f, 0x2f, 0x1f, 0x70, 0x47, 0x5a, 0xf0, 0xcd, 0xae, 0x41, 0x5d, 0x61, 0x29, 0x50, 0xf3, 0x75,
+	0x96, 0x6b, 0x59, 0xb8, 0x91, 0x8c, 0x2f, 0xfb, 0xba, 0x17, 0xd2, 0x6e, 0xda, 0x7c, 0x2f, 0x93,
+	0x1e, 0x9c, 0x38, 0xd8, 0x1d, 0xd0, 0xf8, 0x33, 0xe5, 0xc3, 0x83, 0x7c, 0xfc, 0x1d, 0x00, 0x78,
+	0x5f, 0x3e, 0xba, 0x01, 0x02, 0x00, 0x00,
+}
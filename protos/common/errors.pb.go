@@ -0,0 +1,152 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: common/errors.proto
+
+package common // import "github.com/hyperledger/fabric/protos/common"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// ErrorReason classifies the errors returned by the gRPC services of the
+// peers and the orderers, so that clients can handle them without parsing
+// their messages
+type ErrorReason int32
+
+const (
+	ErrorReason_UNKNOWN_REASON     ErrorReason = 0
+	ErrorReason_MALFORMED_REQUEST  ErrorReason = 1
+	ErrorReason_POLICY_FAILURE     ErrorReason = 2
+	ErrorReason_BAD_CHANNEL        ErrorReason = 3
+	ErrorReason_CHAINCODE_ERROR    ErrorReason = 4
+	ErrorReason_LEDGER_UNAVAILABLE ErrorReason = 5
+)
+
+var ErrorReason_name = map[int32]string{
+	0: "UNKNOWN_REASON",
+	1: "MALFORMED_REQUEST",
+	2: "POLICY_FAILURE",
+	3: "BAD_CHANNEL",
+	4: "CHAINCODE_ERROR",
+	5: "LEDGER_UNAVAILABLE",
+}
+var ErrorReason_value = map[string]int32{
+	"UNKNOWN_REASON":     0,
+	"MALFORMED_REQUEST":  1,
+	"POLICY_FAILURE":     2,
+	"BAD_CHANNEL":        3,
+	"CHAINCODE_ERROR":    4,
+	"LEDGER_UNAVAILABLE": 5,
+}
+
+func (x ErrorReason) String() string {
+	return proto.EnumName(ErrorReason_name, int32(x))
+}
+func (ErrorReason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_errors_9cfc336cd905de7c, []int{0}
+}
+
+// ErrorDetail is attached to the status of a failed gRPC call
+type ErrorDetail struct {
+	Reason               ErrorReason `protobuf:"varint,1,opt,name=reason,enum=common.ErrorReason" json:"reason,omitempty"`
+	ChannelId            string      `protobuf:"bytes,2,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	Chaincode            string      `protobuf:"bytes,3,opt,name=chaincode" json:"chaincode,omitempty"`
+	ChaincodeStatus      int32       `protobuf:"varint,4,opt,name=chaincode_status,json=chaincodeStatus" json:"chaincode_status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ErrorDetail) Reset()         { *m = ErrorDetail{} }
+func (m *ErrorDetail) String() string { return proto.CompactTextString(m) }
+func (*ErrorDetail) ProtoMessage()    {}
+func (*ErrorDetail) Descriptor() ([]byte, []int) {
+	return fileDescriptor_errors_9cfc336cd905de7c, []int{0}
+}
+func (m *ErrorDetail) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ErrorDetail.Unmarshal(m, b)
+}
+func (m *ErrorDetail) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ErrorDetail.Marshal(b, m, deterministic)
+}
+func (dst *ErrorDetail) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErrorDetail.Merge(dst, src)
+}
+func (m *ErrorDetail) XXX_Size() int {
+	return xxx_messageInfo_ErrorDetail.Size(m)
+}
+func (m *ErrorDetail) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErrorDetail.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ErrorDetail proto.InternalMessageInfo
+
+func (m *ErrorDetail) GetReason() ErrorReason {
+	if m != nil {
+		return m.Reason
+	}
+	return ErrorReason_UNKNOWN_REASON
+}
+
+func (m *ErrorDetail) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *ErrorDetail) GetChaincode() string {
+	if m != nil {
+		return m.Chaincode
+	}
+	return ""
+}
+
+func (m *ErrorDetail) GetChaincodeStatus() int32 {
+	if m != nil {
+		return m.ChaincodeStatus
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*ErrorDetail)(nil), "common.ErrorDetail")
+	proto.RegisterEnum("common.ErrorReason", ErrorReason_name, ErrorReason_value)
+}
+
+func init() { proto.RegisterFile("common/errors.proto", fileDescriptor_errors_9cfc336cd905de7c) }
+
+var fileDescriptor_errors_9cfc336cd905de7c = []byte{
+	// 324 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x90, 0xdd, 0x4a, 0xc3, 0x30,
+	0x1c, 0x47, 0xed, 0xbe, 0x60, 0x19, 0x6c, 0x35, 0x43, 0xe9, 0x85, 0xc2, 0x10, 0x2f, 0xa6, 0x83,
+	0x16, 0xf4, 0x09, 0xb2, 0x36, 0x73, 0xc5, 0x2c, 0xd5, 0xd4, 0x2a, 0x7a, 0x13, 0xba, 0x36, 0x6e,
+	0x85, 0xad, 0x19, 0x69, 0x77, 0xe1, 0x2b, 0xf8, 0x18, 0x3e, 0xa9, 0xb4, 0x99, 0xc3, 0xcb, 0x73,
+	0x7e, 0x07, 0x12, 0xfe, 0x60, 0x98, 0xc8, 0xed, 0x56, 0xe6, 0x8e, 0x50, 0x4a, 0xaa, 0xc2, 0xde,
+	0x29, 0x59, 0x4a, 0xd8, 0xd1, 0xf2, 0xea, 0xc7, 0x00, 0x3d, 0x5c, 0x0d, 0x9e, 0x28, 0xe3, 0x6c,
+	0x03, 0x27, 0xa0, 0xa3, 0x44, 0x5c, 0xc8, 0xdc, 0x32, 0x46, 0xc6, 0xb8, 0x7f, 0x37, 0xb4, 0x75,
+	0x68, 0xd7, 0x11, 0xab, 0x27, 0x76, 0x48, 0xe0, 0x25, 0x00, 0xc9, 0x3a, 0xce, 0x73, 0xb1, 0xe1,
+	0x59, 0x6a, 0x35, 0x46, 0xc6, 0xb8, 0xcb, 0xba, 0x07, 0xe3, 0xa7, 0xf0, 0x02, 0x54, 0x90, 0xe5,
+	0x89, 0x4c, 0x85, 0xd5, 0x3c, 0xae, 0x5a, 0xc0, 0x1b, 0x60, 0x1e, 0x81, 0x17, 0x65, 0x5c, 0xee,
+	0x0b, 0xab, 0x35, 0x32, 0xc6, 0x6d, 0x36, 0x38, 0xfa, 0xb0, 0xd6, 0xb7, 0xdf, 0x7f, 0x9f, 0xd4,
+	0xef, 0x43, 0x08, 0xfa, 0x11, 0x7d, 0xa4, 0xc1, 0x1b, 0xe5, 0x0c, 0xa3, 0x30, 0xa0, 0xe6, 0x09,
+	0x3c, 0x03, 0xa7, 0x0b, 0x44, 0x66, 0x01, 0x5b, 0x60, 0x8f, 0x33, 0xfc, 0x1c, 0xe1, 0xf0, 0xc5,
+	0x34, 0xaa, 0xf4, 0x29, 0x20, 0xbe, 0xfb, 0xce, 0x67, 0xc8, 0x27, 0x11, 0xc3, 0x66, 0x03, 0x0e,
+	0x40, 0x6f, 0x8a, 0x3c, 0xee, 0xce, 0x11, 0xa5, 0x98, 0x98, 0x4d, 0x38, 0x04, 0x03, 0x77, 0x8e,
+	0x7c, 0xea, 0x06, 0x1e, 0xe6, 0x98, 0xb1, 0x80, 0x99, 0x2d, 0x78, 0x0e, 0x20, 0xc1, 0xde, 0x03,
+	0x66, 0x3c, 0xa2, 0xe8, 0x15, 0xf9, 0x04, 0x4d, 0x09, 0x36, 0xdb, 0xd3, 0x10, 0x5c, 0x4b, 0xb5,
+	0xb2, 0xd7, 0x5f, 0x3b, 0xa1, 0x36, 0x22, 0x5d, 0x09, 0x65, 0x7f, 0xc6, 0x4b, 0x95, 0x25, 0xfa,
+	0xb2, 0xc5, 0xe1, 0x60, 0x1f, 0x93, 0x55, 0x56, 0xae, 0xf7, 0xcb, 0x0a, 0x9d, 0x7f, 0xb1, 0xa3,
+	0x63, 0x47, 0xc7, 0x8e, 0x8e, 0x97, 0x9d, 0x1a, 0xef, 0x7f, 0x07, 0x00, 0xde, 0x33, 0x7a, 0x96,
+	0xac, 0x01, 0x00, 0x00,
+}
@@ -33,7 +33,7 @@ func (m *BlockchainInfo) Reset()         { *m = BlockchainInfo{} }
 func (m *BlockchainInfo) String() string { return proto.CompactTextString(m) }
 func (*BlockchainInfo) ProtoMessage()    {}
 func (*BlockchainInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ledger_109361caaf4d9d5c, []int{0}
+	return fileDescriptor_ledger_8e96e585e24b23ff, []int{0}
 }
 func (m *BlockchainInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockchainInfo.Unmarshal(m, b)
@@ -74,24 +74,121 @@ func (m *BlockchainInfo) GetPreviousBlockHash() []byte {
 	return nil
 }
 
+// StateAttestation attests the value of a key in the state of a channel, as
+// of the given height of its blockchain. It is returned by the
+// GetStateAttestation function of qscc, so that it is signed by the peer in
+// the endorsement of the proposal response.
+type StateAttestation struct {
+	ChannelId            string   `protobuf:"bytes,1,opt,name=channelId" json:"channelId,omitempty"`
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+	Key                  string   `protobuf:"bytes,3,opt,name=key" json:"key,omitempty"`
+	Exists               bool     `protobuf:"varint,4,opt,name=exists" json:"exists,omitempty"`
+	ValueHash            []byte   `protobuf:"bytes,5,opt,name=valueHash,proto3" json:"valueHash,omitempty"`
+	Height               uint64   `protobuf:"varint,6,opt,name=height" json:"height,omitempty"`
+	CurrentBlockHash     []byte   `protobuf:"bytes,7,opt,name=currentBlockHash,proto3" json:"currentBlockHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateAttestation) Reset()         { *m = StateAttestation{} }
+func (m *StateAttestation) String() string { return proto.CompactTextString(m) }
+func (*StateAttestation) ProtoMessage()    {}
+func (*StateAttestation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ledger_8e96e585e24b23ff, []int{1}
+}
+func (m *StateAttestation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateAttestation.Unmarshal(m, b)
+}
+func (m *StateAttestation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateAttestation.Marshal(b, m, deterministic)
+}
+func (dst *StateAttestation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateAttestation.Merge(dst, src)
+}
+func (m *StateAttestation) XXX_Size() int {
+	return xxx_messageInfo_StateAttestation.Size(m)
+}
+func (m *StateAttestation) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateAttestation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateAttestation proto.InternalMessageInfo
+
+func (m *StateAttestation) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *StateAttestation) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *StateAttestation) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *StateAttestation) GetExists() bool {
+	if m != nil {
+		return m.Exists
+	}
+	return false
+}
+
+func (m *StateAttestation) GetValueHash() []byte {
+	if m != nil {
+		return m.ValueHash
+	}
+	return nil
+}
+
+func (m *StateAttestation) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *StateAttestation) GetCurrentBlockHash() []byte {
+	if m != nil {
+		return m.CurrentBlockHash
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*BlockchainInfo)(nil), "common.BlockchainInfo")
+	proto.RegisterType((*StateAttestation)(nil), "common.StateAttestation")
 }
 
-func init() { proto.RegisterFile("common/ledger.proto", fileDescriptor_ledger_109361caaf4d9d5c) }
-
-var fileDescriptor_ledger_109361caaf4d9d5c = []byte{
-	// 186 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x4e, 0xce, 0xcf, 0xcd,
-	0xcd, 0xcf, 0xd3, 0xcf, 0x49, 0x4d, 0x49, 0x4f, 0x2d, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17,
-	0x62, 0x83, 0x08, 0x2a, 0x35, 0x31, 0x72, 0xf1, 0x39, 0xe5, 0xe4, 0x27, 0x67, 0x27, 0x67, 0x24,
-	0x66, 0xe6, 0x79, 0xe6, 0xa5, 0xe5, 0x0b, 0x89, 0x71, 0xb1, 0x65, 0xa4, 0x66, 0xa6, 0x67, 0x94,
-	0x48, 0x30, 0x2a, 0x30, 0x6a, 0xb0, 0x04, 0x41, 0x79, 0x42, 0x5a, 0x5c, 0x02, 0xc9, 0xa5, 0x45,
-	0x45, 0xa9, 0x79, 0x25, 0x60, 0x0d, 0x1e, 0x89, 0xc5, 0x19, 0x12, 0x4c, 0x0a, 0x8c, 0x1a, 0x3c,
-	0x41, 0x18, 0xe2, 0x42, 0x3a, 0x5c, 0x82, 0x05, 0x45, 0xa9, 0x65, 0x99, 0xf9, 0xa5, 0xc5, 0x08,
-	0xc5, 0xcc, 0x60, 0xc5, 0x98, 0x12, 0x4e, 0xc1, 0x5c, 0x2a, 0xf9, 0x45, 0xe9, 0x7a, 0x19, 0x95,
-	0x05, 0xa9, 0x45, 0x50, 0x57, 0xa6, 0x25, 0x26, 0x15, 0x65, 0x26, 0x43, 0x1c, 0x5b, 0xac, 0x07,
-	0x71, 0x6c, 0x94, 0x76, 0x7a, 0x66, 0x49, 0x46, 0x69, 0x12, 0x88, 0xab, 0x8f, 0xa4, 0x58, 0x1f,
-	0xa2, 0x58, 0x1f, 0xa2, 0x58, 0x1f, 0xa2, 0x38, 0x89, 0x0d, 0xcc, 0x35, 0x06, 0x04, 0x00, 0x00,
-	0xff, 0xff, 0x9f, 0xcc, 0x05, 0xd1, 0xff, 0x00, 0x00, 0x00,
+func init() { proto.RegisterFile("common/ledger.proto", fileDescriptor_ledger_8e96e585e24b23ff) }
+
+var fileDescriptor_ledger_8e96e585e24b23ff = []byte{
+	// 281 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x91, 0x31, 0x4f, 0xc3, 0x30,
+	0x10, 0x85, 0x65, 0x5a, 0x02, 0xb1, 0x10, 0x2a, 0x46, 0x42, 0x1d, 0x18, 0xaa, 0x8a, 0x21, 0x02,
+	0x94, 0x0c, 0xfc, 0x02, 0x3a, 0xd1, 0xd5, 0xdd, 0xd8, 0x1c, 0xf7, 0x1a, 0x5b, 0x4d, 0xec, 0xc8,
+	0xbe, 0x54, 0x74, 0xe5, 0x67, 0xf2, 0x6b, 0x50, 0xec, 0x48, 0xa9, 0x54, 0x24, 0xb6, 0xbc, 0x77,
+	0x5f, 0xec, 0xe7, 0x7b, 0xf4, 0x5e, 0xda, 0xa6, 0xb1, 0xa6, 0xa8, 0x61, 0x5b, 0x81, 0xcb, 0x5b,
+	0x67, 0xd1, 0xb2, 0x24, 0x9a, 0xcb, 0x6f, 0x42, 0x6f, 0x57, 0xb5, 0x95, 0x7b, 0xa9, 0x84, 0x36,
+	0x6b, 0xb3, 0xb3, 0xec, 0x81, 0x26, 0x0a, 0x74, 0xa5, 0x70, 0x4e, 0x16, 0x24, 0x9b, 0xf2, 0x41,
+	0xb1, 0x67, 0x3a, 0x93, 0x9d, 0x73, 0x60, 0x30, 0xfc, 0xf0, 0x21, 0xbc, 0x9a, 0x5f, 0x2c, 0x48,
+	0x76, 0xc3, 0xcf, 0x7c, 0xf6, 0x4a, 0xef, 0x5a, 0x07, 0x07, 0x6d, 0x3b, 0x3f, 0xc2, 0x93, 0x00,
+	0x9f, 0x0f, 0x96, 0x3f, 0x84, 0xce, 0x36, 0x28, 0x10, 0xde, 0x11, 0xc1, 0xa3, 0x40, 0x6d, 0x0d,
+	0x7b, 0xa4, 0xa9, 0x54, 0xc2, 0x18, 0xa8, 0xd7, 0xdb, 0x90, 0x24, 0xe5, 0xa3, 0xd1, 0x4f, 0x8d,
+	0x68, 0xc0, 0xb7, 0x42, 0x42, 0x48, 0x91, 0xf2, 0xd1, 0x60, 0x33, 0x3a, 0xd9, 0xc3, 0x31, 0x5c,
+	0x98, 0xf2, 0xfe, 0xb3, 0x7f, 0x14, 0x7c, 0x69, 0x8f, 0x7e, 0x3e, 0x5d, 0x90, 0xec, 0x9a, 0x0f,
+	0xaa, 0x3f, 0xe7, 0x20, 0xea, 0x0e, 0x42, 0xc0, 0xcb, 0x10, 0x70, 0x34, 0x4e, 0x56, 0x91, 0xfc,
+	0xbb, 0x8a, 0xab, 0xbf, 0x57, 0xb1, 0xda, 0xd0, 0x27, 0xeb, 0xaa, 0x5c, 0x1d, 0x5b, 0x70, 0x43,
+	0x05, 0x3b, 0x51, 0x3a, 0x2d, 0x63, 0x13, 0x3e, 0x8f, 0x4d, 0x7c, 0xbe, 0x54, 0x1a, 0x55, 0x57,
+	0xf6, 0xb2, 0x38, 0x81, 0x8b, 0x08, 0x17, 0x11, 0x2e, 0x22, 0x5c, 0x26, 0x41, 0xbe, 0xfd, 0x0e,
+	0x00, 0x04, 0x59, 0x67, 0x8f, 0xdc, 0x01, 0x00, 0x00,
 }
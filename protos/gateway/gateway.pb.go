@@ -0,0 +1,540 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: gateway/gateway.proto
+
+package gateway // import "github.com/hyperledger/fabric/protos/gateway"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import common "github.com/hyperledger/fabric/protos/common"
+import peer "github.com/hyperledger/fabric/protos/peer"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// EndorseRequest carries the proposal to endorse, signed by the client.
+type EndorseRequest struct {
+	ProposedTransaction  *peer.SignedProposal `protobuf:"bytes,1,opt,name=proposed_transaction,json=proposedTransaction" json:"proposed_transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *EndorseRequest) Reset()         { *m = EndorseRequest{} }
+func (m *EndorseRequest) String() string { return proto.CompactTextString(m) }
+func (*EndorseRequest) ProtoMessage()    {}
+func (*EndorseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gateway_8c263052859cfbf0, []int{0}
+}
+func (m *EndorseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndorseRequest.Unmarshal(m, b)
+}
+func (m *EndorseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndorseRequest.Marshal(b, m, deterministic)
+}
+func (dst *EndorseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndorseRequest.Merge(dst, src)
+}
+func (m *EndorseRequest) XXX_Size() int {
+	return xxx_messageInfo_EndorseRequest.Size(m)
+}
+func (m *EndorseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndorseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndorseRequest proto.InternalMessageInfo
+
+func (m *EndorseRequest) GetProposedTransaction() *peer.SignedProposal {
+	if m != nil {
+		return m.ProposedTransaction
+	}
+	return nil
+}
+
+// EndorseResponse carries the transaction assembled from the endorsements,
+// whose signature is to be set by the client, and the response of the
+// chaincode.
+type EndorseResponse struct {
+	PreparedTransaction  *common.Envelope `protobuf:"bytes,1,opt,name=prepared_transaction,json=preparedTransaction" json:"prepared_transaction,omitempty"`
+	Result               *peer.Response   `protobuf:"bytes,2,opt,name=result" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *EndorseResponse) Reset()         { *m = EndorseResponse{} }
+func (m *EndorseResponse) String() string { return proto.CompactTextString(m) }
+func (*EndorseResponse) ProtoMessage()    {}
+func (*EndorseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gateway_8c263052859cfbf0, []int{1}
+}
+func (m *EndorseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndorseResponse.Unmarshal(m, b)
+}
+func (m *EndorseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndorseResponse.Marshal(b, m, deterministic)
+}
+func (dst *EndorseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndorseResponse.Merge(dst, src)
+}
+func (m *EndorseResponse) XXX_Size() int {
+	return xxx_messageInfo_EndorseResponse.Size(m)
+}
+func (m *EndorseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndorseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndorseResponse proto.InternalMessageInfo
+
+func (m *EndorseResponse) GetPreparedTransaction() *common.Envelope {
+	if m != nil {
+		return m.PreparedTransaction
+	}
+	return nil
+}
+
+func (m *EndorseResponse) GetResult() *peer.Response {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+// SubmitRequest carries the transaction to submit, signed by the client.
+type SubmitRequest struct {
+	PreparedTransaction  *common.Envelope `protobuf:"bytes,1,opt,name=prepared_transaction,json=preparedTransaction" json:"prepared_transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *SubmitRequest) Reset()         { *m = SubmitRequest{} }
+func (m *SubmitRequest) String() string { return proto.CompactTextString(m) }
+func (*SubmitRequest) ProtoMessage()    {}
+func (*SubmitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gateway_8c263052859cfbf0, []int{2}
+}
+func (m *SubmitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitRequest.Unmarshal(m, b)
+}
+func (m *SubmitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitRequest.Marshal(b, m, deterministic)
+}
+func (dst *SubmitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitRequest.Merge(dst, src)
+}
+func (m *SubmitRequest) XXX_Size() int {
+	return xxx_messageInfo_SubmitRequest.Size(m)
+}
+func (m *SubmitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitRequest proto.InternalMessageInfo
+
+func (m *SubmitRequest) GetPreparedTransaction() *common.Envelope {
+	if m != nil {
+		return m.PreparedTransaction
+	}
+	return nil
+}
+
+// SubmitResponse is returned once the ordering service accepted the
+// transaction.
+type SubmitResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubmitResponse) Reset()         { *m = SubmitResponse{} }
+func (m *SubmitResponse) String() string { return proto.CompactTextString(m) }
+func (*SubmitResponse) ProtoMessage()    {}
+func (*SubmitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gateway_8c263052859cfbf0, []int{3}
+}
+func (m *SubmitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitResponse.Unmarshal(m, b)
+}
+func (m *SubmitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitResponse.Marshal(b, m, deterministic)
+}
+func (dst *SubmitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitResponse.Merge(dst, src)
+}
+func (m *SubmitResponse) XXX_Size() int {
+	return xxx_messageInfo_SubmitResponse.Size(m)
+}
+func (m *SubmitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitResponse proto.InternalMessageInfo
+
+// SignedCommitStatusRequest contains a serialized CommitStatusRequest and the
+// signature of the identity of the request over it.
+type SignedCommitStatusRequest struct {
+	Request              []byte   `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedCommitStatusRequest) Reset()         { *m = SignedCommitStatusRequest{} }
+func (m *SignedCommitStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedCommitStatusRequest) ProtoMessage()    {}
+func (*SignedCommitStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gateway_8c263052859cfbf0, []int{4}
+}
+func (m *SignedCommitStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedCommitStatusRequest.Unmarshal(m, b)
+}
+func (m *SignedCommitStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedCommitStatusRequest.Marshal(b, m, deterministic)
+}
+func (dst *SignedCommitStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedCommitStatusRequest.Merge(dst, src)
+}
+func (m *SignedCommitStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedCommitStatusRequest.Size(m)
+}
+func (m *SignedCommitStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedCommitStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedCommitStatusRequest proto.InternalMessageInfo
+
+func (m *SignedCommitStatusRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedCommitStatusRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// CommitStatusRequest identifies the transaction to wait for. The identity
+// must satisfy the Readers policy of the channel.
+type CommitStatusRequest struct {
+	ChannelId            string   `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	TransactionId        string   `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId" json:"transaction_id,omitempty"`
+	Identity             []byte   `protobuf:"bytes,3,opt,name=identity,proto3" json:"identity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitStatusRequest) Reset()         { *m = CommitStatusRequest{} }
+func (m *CommitStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*CommitStatusRequest) ProtoMessage()    {}
+func (*CommitStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gateway_8c263052859cfbf0, []int{5}
+}
+func (m *CommitStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitStatusRequest.Unmarshal(m, b)
+}
+func (m *CommitStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitStatusRequest.Marshal(b, m, deterministic)
+}
+func (dst *CommitStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitStatusRequest.Merge(dst, src)
+}
+func (m *CommitStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_CommitStatusRequest.Size(m)
+}
+func (m *CommitStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitStatusRequest proto.InternalMessageInfo
+
+func (m *CommitStatusRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *CommitStatusRequest) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *CommitStatusRequest) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+// CommitStatusResponse carries the validation code of the committed
+// transaction and the number of the block containing it.
+type CommitStatusResponse struct {
+	Result               peer.TxValidationCode `protobuf:"varint,1,opt,name=result,enum=protos.TxValidationCode" json:"result,omitempty"`
+	BlockNumber          uint64                `protobuf:"varint,2,opt,name=block_number,json=blockNumber" json:"block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *CommitStatusResponse) Reset()         { *m = CommitStatusResponse{} }
+func (m *CommitStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*CommitStatusResponse) ProtoMessage()    {}
+func (*CommitStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_gateway_8c263052859cfbf0, []int{6}
+}
+func (m *CommitStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitStatusResponse.Unmarshal(m, b)
+}
+func (m *CommitStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitStatusResponse.Marshal(b, m, deterministic)
+}
+func (dst *CommitStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitStatusResponse.Merge(dst, src)
+}
+func (m *CommitStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_CommitStatusResponse.Size(m)
+}
+func (m *CommitStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitStatusResponse proto.InternalMessageInfo
+
+func (m *CommitStatusResponse) GetResult() peer.TxValidationCode {
+	if m != nil {
+		return m.Result
+	}
+	return peer.TxValidationCode_VALID
+}
+
+func (m *CommitStatusResponse) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*EndorseRequest)(nil), "gateway.EndorseRequest")
+	proto.RegisterType((*EndorseResponse)(nil), "gateway.EndorseResponse")
+	proto.RegisterType((*SubmitRequest)(nil), "gateway.SubmitRequest")
+	proto.RegisterType((*SubmitResponse)(nil), "gateway.SubmitResponse")
+	proto.RegisterType((*SignedCommitStatusRequest)(nil), "gateway.SignedCommitStatusRequest")
+	proto.RegisterType((*CommitStatusRequest)(nil), "gateway.CommitStatusRequest")
+	proto.RegisterType((*CommitStatusResponse)(nil), "gateway.CommitStatusResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Gateway service
+
+type GatewayClient interface {
+	// Endorse collects the endorsements of a proposal from the peers chosen
+	// according to the endorsement plan of the chaincode, and returns the
+	// transaction assembled from them. The payload of the transaction must
+	// be signed by the client before it is submitted.
+	Endorse(ctx context.Context, in *EndorseRequest, opts ...grpc.CallOption) (*EndorseResponse, error)
+	// Submit sends a signed transaction to the ordering service of its channel.
+	Submit(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*SubmitResponse, error)
+	// CommitStatus waits for a transaction to be committed by the peer and
+	// returns its validation code. The transaction is looked up in the ledger
+	// and, if it is not committed yet, the call waits for the block containing
+	// it until the deadline of the call expires.
+	CommitStatus(ctx context.Context, in *SignedCommitStatusRequest, opts ...grpc.CallOption) (*CommitStatusResponse, error)
+}
+
+type gatewayClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewGatewayClient(cc *grpc.ClientConn) GatewayClient {
+	return &gatewayClient{cc}
+}
+
+func (c *gatewayClient) Endorse(ctx context.Context, in *EndorseRequest, opts ...grpc.CallOption) (*EndorseResponse, error) {
+	out := new(EndorseResponse)
+	err := grpc.Invoke(ctx, "/gateway.Gateway/Endorse", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayClient) Submit(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*SubmitResponse, error) {
+	out := new(SubmitResponse)
+	err := grpc.Invoke(ctx, "/gateway.Gateway/Submit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayClient) CommitStatus(ctx context.Context, in *SignedCommitStatusRequest, opts ...grpc.CallOption) (*CommitStatusResponse, error) {
+	out := new(CommitStatusResponse)
+	err := grpc.Invoke(ctx, "/gateway.Gateway/CommitStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Gateway service
+
+type GatewayServer interface {
+	// Endorse collects the endorsements of a proposal from the peers chosen
+	// according to the endorsement plan of the chaincode, and returns the
+	// transaction assembled from them. The payload of the transaction must
+	// be signed by the client before it is submitted.
+	Endorse(context.Context, *EndorseRequest) (*EndorseResponse, error)
+	// Submit sends a signed transaction to the ordering service of its channel.
+	Submit(context.Context, *SubmitRequest) (*SubmitResponse, error)
+	// CommitStatus waits for a transaction to be committed by the peer and
+	// returns its validation code. The transaction is looked up in the ledger
+	// and, if it is not committed yet, the call waits for the block containing
+	// it until the deadline of the call expires.
+	CommitStatus(context.Context, *SignedCommitStatusRequest) (*CommitStatusResponse, error)
+}
+
+func RegisterGatewayServer(s *grpc.Server, srv GatewayServer) {
+	s.RegisterService(&_Gateway_serviceDesc, srv)
+}
+
+func _Gateway_Endorse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndorseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServer).Endorse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gateway.Gateway/Endorse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServer).Endorse(ctx, req.(*EndorseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gateway_Submit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServer).Submit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gateway.Gateway/Submit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServer).Submit(ctx, req.(*SubmitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gateway_CommitStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedCommitStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServer).CommitStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gateway.Gateway/CommitStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServer).CommitStatus(ctx, req.(*SignedCommitStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Gateway_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "gateway.Gateway",
+	HandlerType: (*GatewayServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Endorse",
+			Handler:    _Gateway_Endorse_Handler,
+		},
+		{
+			MethodName: "Submit",
+			Handler:    _Gateway_Submit_Handler,
+		},
+		{
+			MethodName: "CommitStatus",
+			Handler:    _Gateway_CommitStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gateway/gateway.proto",
+}
+
+func init() { proto.RegisterFile("gateway/gateway.proto", fileDescriptor_gateway_8c263052859cfbf0) }
+
+var fileDescriptor_gateway_8c263052859cfbf0 = []byte{
+	// 487 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0xcf, 0x6f, 0xd3, 0x30,
+	0x14, 0x5e, 0x07, 0x5a, 0xe9, 0x5b, 0x57, 0x26, 0x77, 0x74, 0x21, 0xda, 0x24, 0x88, 0x84, 0xb4,
+	0x03, 0x6a, 0x50, 0x39, 0x22, 0x71, 0xa0, 0x9a, 0x50, 0x2f, 0x08, 0xa5, 0x15, 0x07, 0x38, 0x54,
+	0x4e, 0xfd, 0x68, 0xad, 0x25, 0x76, 0xb0, 0x1d, 0x46, 0x6f, 0xfc, 0x9f, 0xfc, 0x33, 0xa8, 0xfe,
+	0x91, 0xb6, 0x5a, 0xb9, 0x71, 0x4a, 0xfc, 0xbd, 0xef, 0x7d, 0x9f, 0xdf, 0x0f, 0xc3, 0xb3, 0x25,
+	0x35, 0x78, 0x4f, 0xd7, 0xa9, 0xff, 0x0e, 0x2b, 0x25, 0x8d, 0x24, 0x6d, 0x7f, 0x8c, 0xfb, 0x0b,
+	0x59, 0x96, 0x52, 0xa4, 0xee, 0xe3, 0xa2, 0x71, 0xbf, 0x42, 0x54, 0x69, 0xa5, 0x64, 0x25, 0x35,
+	0x2d, 0x3c, 0x78, 0xb5, 0x07, 0xce, 0x15, 0xea, 0x4a, 0x0a, 0x8d, 0x3e, 0x3a, 0xb0, 0x51, 0xa3,
+	0xa8, 0xd0, 0x74, 0x61, 0x78, 0x90, 0x4a, 0xbe, 0x41, 0xef, 0x56, 0x30, 0xa9, 0x34, 0x66, 0xf8,
+	0xa3, 0x46, 0x6d, 0xc8, 0x04, 0x2e, 0x9c, 0x08, 0xb2, 0xf9, 0x0e, 0x3f, 0x6a, 0xbd, 0x68, 0xdd,
+	0x9c, 0x8e, 0x06, 0x2e, 0x4f, 0x0f, 0xa7, 0x7c, 0x29, 0x90, 0x7d, 0xf6, 0x76, 0x59, 0x3f, 0xe4,
+	0xcc, 0xb6, 0x29, 0xc9, 0xef, 0x16, 0x3c, 0x6d, 0xd4, 0xdd, 0x75, 0xc8, 0x78, 0x23, 0x8f, 0x15,
+	0x55, 0x07, 0xe5, 0xcf, 0x87, 0xbe, 0xd0, 0x5b, 0xf1, 0x13, 0x0b, 0x59, 0x61, 0xd6, 0x0f, 0xec,
+	0x1d, 0x61, 0x72, 0x03, 0x27, 0x0a, 0x75, 0x5d, 0x98, 0xe8, 0xd8, 0xa7, 0xf9, 0x5b, 0x05, 0x9b,
+	0xcc, 0xc7, 0x93, 0x19, 0x9c, 0x4d, 0xeb, 0xbc, 0xe4, 0x26, 0x94, 0xf7, 0x3f, 0xfc, 0x93, 0x73,
+	0xe8, 0x05, 0x55, 0xe7, 0x97, 0x4c, 0xe1, 0xb9, 0xeb, 0xc8, 0x58, 0x96, 0x25, 0x37, 0x53, 0x43,
+	0x4d, 0xad, 0x83, 0x67, 0x04, 0x6d, 0xe5, 0x7e, 0xad, 0x4d, 0x37, 0x0b, 0x47, 0x72, 0x05, 0x1d,
+	0xcd, 0x97, 0x82, 0x9a, 0x5a, 0xa1, 0xad, 0xa5, 0x9b, 0x6d, 0x81, 0xe4, 0x1e, 0xfa, 0x87, 0xe4,
+	0xae, 0x01, 0x16, 0x2b, 0x2a, 0x04, 0x16, 0x73, 0xce, 0xac, 0x62, 0x27, 0xeb, 0x78, 0x64, 0xc2,
+	0xc8, 0x2b, 0xe8, 0xed, 0x14, 0xb6, 0xa1, 0x1c, 0x5b, 0xca, 0xd9, 0x0e, 0x3a, 0x61, 0x24, 0x86,
+	0x27, 0x9c, 0xa1, 0x30, 0xdc, 0xac, 0xa3, 0x47, 0xd6, 0xb9, 0x39, 0x27, 0x77, 0x70, 0xb1, 0x6f,
+	0xec, 0x87, 0xf7, 0xa6, 0xe9, 0xfb, 0xc6, 0xb5, 0x37, 0x8a, 0x42, 0xdf, 0x67, 0xbf, 0xbe, 0xd0,
+	0x82, 0x33, 0xba, 0xd1, 0x1e, 0x4b, 0xd6, 0xf4, 0x9f, 0xbc, 0x84, 0x6e, 0x5e, 0xc8, 0xc5, 0xdd,
+	0x5c, 0xd4, 0x65, 0x8e, 0xca, 0x5e, 0xe5, 0x71, 0x76, 0x6a, 0xb1, 0x4f, 0x16, 0x1a, 0xfd, 0x69,
+	0x41, 0xfb, 0xa3, 0x5b, 0x77, 0xf2, 0x1e, 0xda, 0x7e, 0x61, 0xc8, 0xe5, 0x30, 0x3c, 0x89, 0xfd,
+	0x05, 0x8d, 0xa3, 0x87, 0x01, 0x3f, 0x84, 0x23, 0xf2, 0x0e, 0x4e, 0xdc, 0x60, 0xc8, 0xa0, 0x61,
+	0xed, 0xcd, 0x3f, 0xbe, 0x7c, 0x80, 0x37, 0xc9, 0x53, 0xe8, 0xee, 0x56, 0x4d, 0x92, 0x2d, 0xf5,
+	0x5f, 0xa3, 0x8d, 0xaf, 0x1b, 0xce, 0xa1, 0x86, 0x25, 0x47, 0x1f, 0x86, 0x5f, 0x5f, 0x2f, 0xb9,
+	0x59, 0xd5, 0xf9, 0x66, 0xb3, 0xd2, 0xd5, 0xba, 0x42, 0x55, 0x20, 0x5b, 0xa2, 0x4a, 0xbf, 0xd3,
+	0x5c, 0xf1, 0x45, 0xea, 0x3a, 0x18, 0xde, 0x7f, 0x7e, 0x62, 0xcf, 0x6f, 0xff, 0x0e, 0x00, 0x15,
+	0x4a, 0x1f, 0xc8, 0x19, 0x04, 0x00, 0x00,
+}
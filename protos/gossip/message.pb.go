@@ -47,7 +47,7 @@ func (x PullMsgType) String() string {
 	return proto.EnumName(PullMsgType_name, int32(x))
 }
 func (PullMsgType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{0}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{0}
 }
 
 type GossipMessage_Tag int32
@@ -82,7 +82,7 @@ func (x GossipMessage_Tag) String() string {
 	return proto.EnumName(GossipMessage_Tag_name, int32(x))
 }
 func (GossipMessage_Tag) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{3, 0}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{3, 0}
 }
 
 // Envelope contains a marshalled
@@ -102,7 +102,7 @@ func (m *Envelope) Reset()         { *m = Envelope{} }
 func (m *Envelope) String() string { return proto.CompactTextString(m) }
 func (*Envelope) ProtoMessage()    {}
 func (*Envelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{0}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{0}
 }
 func (m *Envelope) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Envelope.Unmarshal(m, b)
@@ -160,7 +160,7 @@ func (m *SecretEnvelope) Reset()         { *m = SecretEnvelope{} }
 func (m *SecretEnvelope) String() string { return proto.CompactTextString(m) }
 func (*SecretEnvelope) ProtoMessage()    {}
 func (*SecretEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{1}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{1}
 }
 func (m *SecretEnvelope) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SecretEnvelope.Unmarshal(m, b)
@@ -210,7 +210,7 @@ func (m *Secret) Reset()         { *m = Secret{} }
 func (m *Secret) String() string { return proto.CompactTextString(m) }
 func (*Secret) ProtoMessage()    {}
 func (*Secret) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{2}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{2}
 }
 func (m *Secret) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Secret.Unmarshal(m, b)
@@ -349,7 +349,7 @@ func (m *GossipMessage) Reset()         { *m = GossipMessage{} }
 func (m *GossipMessage) String() string { return proto.CompactTextString(m) }
 func (*GossipMessage) ProtoMessage()    {}
 func (*GossipMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{3}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{3}
 }
 func (m *GossipMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GossipMessage.Unmarshal(m, b)
@@ -1088,7 +1088,7 @@ func (m *StateInfo) Reset()         { *m = StateInfo{} }
 func (m *StateInfo) String() string { return proto.CompactTextString(m) }
 func (*StateInfo) ProtoMessage()    {}
 func (*StateInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{4}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{4}
 }
 func (m *StateInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateInfo.Unmarshal(m, b)
@@ -1140,6 +1140,7 @@ type Properties struct {
 	LedgerHeight         uint64       `protobuf:"varint,1,opt,name=ledger_height,json=ledgerHeight" json:"ledger_height,omitempty"`
 	LeftChannel          bool         `protobuf:"varint,2,opt,name=left_channel,json=leftChannel" json:"left_channel,omitempty"`
 	Chaincodes           []*Chaincode `protobuf:"bytes,3,rep,name=chaincodes" json:"chaincodes,omitempty"`
+	Checkpoint           *Checkpoint  `protobuf:"bytes,4,opt,name=checkpoint" json:"checkpoint,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -1149,7 +1150,7 @@ func (m *Properties) Reset()         { *m = Properties{} }
 func (m *Properties) String() string { return proto.CompactTextString(m) }
 func (*Properties) ProtoMessage()    {}
 func (*Properties) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{5}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{5}
 }
 func (m *Properties) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Properties.Unmarshal(m, b)
@@ -1190,6 +1191,61 @@ func (m *Properties) GetChaincodes() []*Chaincode {
 	return nil
 }
 
+func (m *Properties) GetCheckpoint() *Checkpoint {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return nil
+}
+
+// Checkpoint is the checksum of the blocks a peer committed
+// up to a checkpoint height, along with their validation codes
+type Checkpoint struct {
+	Height               uint64   `protobuf:"varint,1,opt,name=height" json:"height,omitempty"`
+	Checksum             []byte   `protobuf:"bytes,2,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Checkpoint) Reset()         { *m = Checkpoint{} }
+func (m *Checkpoint) String() string { return proto.CompactTextString(m) }
+func (*Checkpoint) ProtoMessage()    {}
+func (*Checkpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_message_55956b3b1ad34a96, []int{6}
+}
+func (m *Checkpoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Checkpoint.Unmarshal(m, b)
+}
+func (m *Checkpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Checkpoint.Marshal(b, m, deterministic)
+}
+func (dst *Checkpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Checkpoint.Merge(dst, src)
+}
+func (m *Checkpoint) XXX_Size() int {
+	return xxx_messageInfo_Checkpoint.Size(m)
+}
+func (m *Checkpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_Checkpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Checkpoint proto.InternalMessageInfo
+
+func (m *Checkpoint) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *Checkpoint) GetChecksum() []byte {
+	if m != nil {
+		return m.Checksum
+	}
+	return nil
+}
+
 // StateInfoSnapshot is an aggregation of StateInfo messages
 type StateInfoSnapshot struct {
 	Elements             []*Envelope `protobuf:"bytes,1,rep,name=elements" json:"elements,omitempty"`
@@ -1202,7 +1258,7 @@ func (m *StateInfoSnapshot) Reset()         { *m = StateInfoSnapshot{} }
 func (m *StateInfoSnapshot) String() string { return proto.CompactTextString(m) }
 func (*StateInfoSnapshot) ProtoMessage()    {}
 func (*StateInfoSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{6}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{7}
 }
 func (m *StateInfoSnapshot) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateInfoSnapshot.Unmarshal(m, b)
@@ -1245,7 +1301,7 @@ func (m *StateInfoPullRequest) Reset()         { *m = StateInfoPullRequest{} }
 func (m *StateInfoPullRequest) String() string { return proto.CompactTextString(m) }
 func (*StateInfoPullRequest) ProtoMessage()    {}
 func (*StateInfoPullRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{7}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{8}
 }
 func (m *StateInfoPullRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateInfoPullRequest.Unmarshal(m, b)
@@ -1288,7 +1344,7 @@ func (m *ConnEstablish) Reset()         { *m = ConnEstablish{} }
 func (m *ConnEstablish) String() string { return proto.CompactTextString(m) }
 func (*ConnEstablish) ProtoMessage()    {}
 func (*ConnEstablish) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{8}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{9}
 }
 func (m *ConnEstablish) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConnEstablish.Unmarshal(m, b)
@@ -1345,7 +1401,7 @@ func (m *PeerIdentity) Reset()         { *m = PeerIdentity{} }
 func (m *PeerIdentity) String() string { return proto.CompactTextString(m) }
 func (*PeerIdentity) ProtoMessage()    {}
 func (*PeerIdentity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{9}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{10}
 }
 func (m *PeerIdentity) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerIdentity.Unmarshal(m, b)
@@ -1401,7 +1457,7 @@ func (m *DataRequest) Reset()         { *m = DataRequest{} }
 func (m *DataRequest) String() string { return proto.CompactTextString(m) }
 func (*DataRequest) ProtoMessage()    {}
 func (*DataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{10}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{11}
 }
 func (m *DataRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataRequest.Unmarshal(m, b)
@@ -1457,7 +1513,7 @@ func (m *GossipHello) Reset()         { *m = GossipHello{} }
 func (m *GossipHello) String() string { return proto.CompactTextString(m) }
 func (*GossipHello) ProtoMessage()    {}
 func (*GossipHello) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{11}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{12}
 }
 func (m *GossipHello) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GossipHello.Unmarshal(m, b)
@@ -1513,7 +1569,7 @@ func (m *DataUpdate) Reset()         { *m = DataUpdate{} }
 func (m *DataUpdate) String() string { return proto.CompactTextString(m) }
 func (*DataUpdate) ProtoMessage()    {}
 func (*DataUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{12}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{13}
 }
 func (m *DataUpdate) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataUpdate.Unmarshal(m, b)
@@ -1569,7 +1625,7 @@ func (m *DataDigest) Reset()         { *m = DataDigest{} }
 func (m *DataDigest) String() string { return proto.CompactTextString(m) }
 func (*DataDigest) ProtoMessage()    {}
 func (*DataDigest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{13}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{14}
 }
 func (m *DataDigest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataDigest.Unmarshal(m, b)
@@ -1622,7 +1678,7 @@ func (m *DataMessage) Reset()         { *m = DataMessage{} }
 func (m *DataMessage) String() string { return proto.CompactTextString(m) }
 func (*DataMessage) ProtoMessage()    {}
 func (*DataMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{14}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{15}
 }
 func (m *DataMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DataMessage.Unmarshal(m, b)
@@ -1663,7 +1719,7 @@ func (m *PrivateDataMessage) Reset()         { *m = PrivateDataMessage{} }
 func (m *PrivateDataMessage) String() string { return proto.CompactTextString(m) }
 func (*PrivateDataMessage) ProtoMessage()    {}
 func (*PrivateDataMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{15}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{16}
 }
 func (m *PrivateDataMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PrivateDataMessage.Unmarshal(m, b)
@@ -1704,7 +1760,7 @@ func (m *Payload) Reset()         { *m = Payload{} }
 func (m *Payload) String() string { return proto.CompactTextString(m) }
 func (*Payload) ProtoMessage()    {}
 func (*Payload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{16}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{17}
 }
 func (m *Payload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Payload.Unmarshal(m, b)
@@ -1764,7 +1820,7 @@ func (m *PrivatePayload) Reset()         { *m = PrivatePayload{} }
 func (m *PrivatePayload) String() string { return proto.CompactTextString(m) }
 func (*PrivatePayload) ProtoMessage()    {}
 func (*PrivatePayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{17}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{18}
 }
 func (m *PrivatePayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PrivatePayload.Unmarshal(m, b)
@@ -1841,7 +1897,7 @@ func (m *AliveMessage) Reset()         { *m = AliveMessage{} }
 func (m *AliveMessage) String() string { return proto.CompactTextString(m) }
 func (*AliveMessage) ProtoMessage()    {}
 func (*AliveMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{18}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{19}
 }
 func (m *AliveMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AliveMessage.Unmarshal(m, b)
@@ -1897,7 +1953,7 @@ func (m *LeadershipMessage) Reset()         { *m = LeadershipMessage{} }
 func (m *LeadershipMessage) String() string { return proto.CompactTextString(m) }
 func (*LeadershipMessage) ProtoMessage()    {}
 func (*LeadershipMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{19}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{20}
 }
 func (m *LeadershipMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LeadershipMessage.Unmarshal(m, b)
@@ -1951,7 +2007,7 @@ func (m *PeerTime) Reset()         { *m = PeerTime{} }
 func (m *PeerTime) String() string { return proto.CompactTextString(m) }
 func (*PeerTime) ProtoMessage()    {}
 func (*PeerTime) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{20}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{21}
 }
 func (m *PeerTime) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerTime.Unmarshal(m, b)
@@ -1999,7 +2055,7 @@ func (m *MembershipRequest) Reset()         { *m = MembershipRequest{} }
 func (m *MembershipRequest) String() string { return proto.CompactTextString(m) }
 func (*MembershipRequest) ProtoMessage()    {}
 func (*MembershipRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{21}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{22}
 }
 func (m *MembershipRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MembershipRequest.Unmarshal(m, b)
@@ -2046,7 +2102,7 @@ func (m *MembershipResponse) Reset()         { *m = MembershipResponse{} }
 func (m *MembershipResponse) String() string { return proto.CompactTextString(m) }
 func (*MembershipResponse) ProtoMessage()    {}
 func (*MembershipResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{22}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{23}
 }
 func (m *MembershipResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MembershipResponse.Unmarshal(m, b)
@@ -2095,7 +2151,7 @@ func (m *Member) Reset()         { *m = Member{} }
 func (m *Member) String() string { return proto.CompactTextString(m) }
 func (*Member) ProtoMessage()    {}
 func (*Member) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{23}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{24}
 }
 func (m *Member) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Member.Unmarshal(m, b)
@@ -2147,7 +2203,7 @@ func (m *Empty) Reset()         { *m = Empty{} }
 func (m *Empty) String() string { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()    {}
 func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{24}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{25}
 }
 func (m *Empty) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Empty.Unmarshal(m, b)
@@ -2181,7 +2237,7 @@ func (m *RemoteStateRequest) Reset()         { *m = RemoteStateRequest{} }
 func (m *RemoteStateRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoteStateRequest) ProtoMessage()    {}
 func (*RemoteStateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{25}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{26}
 }
 func (m *RemoteStateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoteStateRequest.Unmarshal(m, b)
@@ -2228,7 +2284,7 @@ func (m *RemoteStateResponse) Reset()         { *m = RemoteStateResponse{} }
 func (m *RemoteStateResponse) String() string { return proto.CompactTextString(m) }
 func (*RemoteStateResponse) ProtoMessage()    {}
 func (*RemoteStateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{26}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{27}
 }
 func (m *RemoteStateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemoteStateResponse.Unmarshal(m, b)
@@ -2268,7 +2324,7 @@ func (m *RemotePvtDataRequest) Reset()         { *m = RemotePvtDataRequest{} }
 func (m *RemotePvtDataRequest) String() string { return proto.CompactTextString(m) }
 func (*RemotePvtDataRequest) ProtoMessage()    {}
 func (*RemotePvtDataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{27}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{28}
 }
 func (m *RemotePvtDataRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemotePvtDataRequest.Unmarshal(m, b)
@@ -2311,7 +2367,7 @@ func (m *PvtDataDigest) Reset()         { *m = PvtDataDigest{} }
 func (m *PvtDataDigest) String() string { return proto.CompactTextString(m) }
 func (*PvtDataDigest) ProtoMessage()    {}
 func (*PvtDataDigest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{28}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{29}
 }
 func (m *PvtDataDigest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PvtDataDigest.Unmarshal(m, b)
@@ -2379,7 +2435,7 @@ func (m *RemotePvtDataResponse) Reset()         { *m = RemotePvtDataResponse{} }
 func (m *RemotePvtDataResponse) String() string { return proto.CompactTextString(m) }
 func (*RemotePvtDataResponse) ProtoMessage()    {}
 func (*RemotePvtDataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{29}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{30}
 }
 func (m *RemotePvtDataResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RemotePvtDataResponse.Unmarshal(m, b)
@@ -2419,7 +2475,7 @@ func (m *PvtDataElement) Reset()         { *m = PvtDataElement{} }
 func (m *PvtDataElement) String() string { return proto.CompactTextString(m) }
 func (*PvtDataElement) ProtoMessage()    {}
 func (*PvtDataElement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{30}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{31}
 }
 func (m *PvtDataElement) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PvtDataElement.Unmarshal(m, b)
@@ -2469,7 +2525,7 @@ func (m *PvtDataPayload) Reset()         { *m = PvtDataPayload{} }
 func (m *PvtDataPayload) String() string { return proto.CompactTextString(m) }
 func (*PvtDataPayload) ProtoMessage()    {}
 func (*PvtDataPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{31}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{32}
 }
 func (m *PvtDataPayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PvtDataPayload.Unmarshal(m, b)
@@ -2514,7 +2570,7 @@ func (m *Acknowledgement) Reset()         { *m = Acknowledgement{} }
 func (m *Acknowledgement) String() string { return proto.CompactTextString(m) }
 func (*Acknowledgement) ProtoMessage()    {}
 func (*Acknowledgement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{32}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{33}
 }
 func (m *Acknowledgement) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Acknowledgement.Unmarshal(m, b)
@@ -2556,7 +2612,7 @@ func (m *Chaincode) Reset()         { *m = Chaincode{} }
 func (m *Chaincode) String() string { return proto.CompactTextString(m) }
 func (*Chaincode) ProtoMessage()    {}
 func (*Chaincode) Descriptor() ([]byte, []int) {
-	return fileDescriptor_message_7c42328ef5ef9997, []int{33}
+	return fileDescriptor_message_55956b3b1ad34a96, []int{34}
 }
 func (m *Chaincode) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Chaincode.Unmarshal(m, b)
@@ -2604,6 +2660,7 @@ func init() {
 	proto.RegisterType((*GossipMessage)(nil), "gossip.GossipMessage")
 	proto.RegisterType((*StateInfo)(nil), "gossip.StateInfo")
 	proto.RegisterType((*Properties)(nil), "gossip.Properties")
+	proto.RegisterType((*Checkpoint)(nil), "gossip.Checkpoint")
 	proto.RegisterType((*StateInfoSnapshot)(nil), "gossip.StateInfoSnapshot")
 	proto.RegisterType((*StateInfoPullRequest)(nil), "gossip.StateInfoPullRequest")
 	proto.RegisterType((*ConnEstablish)(nil), "gossip.ConnEstablish")
@@ -2778,126 +2835,128 @@ var _Gossip_serviceDesc = grpc.ServiceDesc{
 	Metadata: "gossip/message.proto",
 }
 
-func init() { proto.RegisterFile("gossip/message.proto", fileDescriptor_message_7c42328ef5ef9997) }
-
-var fileDescriptor_message_7c42328ef5ef9997 = []byte{
-	// 1874 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5b, 0x6f, 0xe3, 0xc6,
-	0x15, 0x16, 0x6d, 0x5d, 0x8f, 0x2e, 0x96, 0xc7, 0xde, 0x5d, 0xc6, 0x49, 0x13, 0x87, 0xed, 0x26,
-	0xdb, 0x7a, 0x23, 0x6f, 0x9d, 0x16, 0x0d, 0x90, 0xb6, 0x0b, 0x5b, 0x52, 0x2c, 0x21, 0x2b, 0xad,
-	0x4b, 0x7b, 0xd1, 0xba, 0x2f, 0xc4, 0x98, 0x1c, 0x53, 0xac, 0xc9, 0x21, 0xcd, 0x19, 0x3b, 0xf6,
-	0x63, 0xd1, 0x87, 0x00, 0x7d, 0xe9, 0x6f, 0xe8, 0x53, 0xff, 0x66, 0x31, 0x33, 0xbc, 0x4a, 0xf6,
-	0x02, 0x1b, 0x20, 0x6f, 0x3c, 0xf7, 0x99, 0x33, 0x67, 0xbe, 0x73, 0x86, 0xb0, 0xed, 0x86, 0x8c,
-	0x79, 0xd1, 0x7e, 0x40, 0x18, 0xc3, 0x2e, 0x19, 0x44, 0x71, 0xc8, 0x43, 0x54, 0x57, 0xdc, 0x9d,
-	0x67, 0x76, 0x18, 0x04, 0x21, 0xdd, 0xb7, 0x43, 0xdf, 0x27, 0x36, 0xf7, 0x42, 0xaa, 0x14, 0x8c,
-	0x7f, 0x69, 0xd0, 0x1c, 0xd3, 0x5b, 0xe2, 0x87, 0x11, 0x41, 0x3a, 0x34, 0x22, 0x7c, 0xef, 0x87,
-	0xd8, 0xd1, 0xb5, 0x5d, 0xed, 0x45, 0xc7, 0x4c, 0x49, 0xf4, 0x09, 0xb4, 0x98, 0xe7, 0x52, 0xcc,
-	0x6f, 0x62, 0xa2, 0xaf, 0x49, 0x59, 0xce, 0x40, 0xaf, 0x61, 0x83, 0x11, 0x3b, 0x26, 0xdc, 0x22,
-	0x89, 0x2b, 0x7d, 0x7d, 0x57, 0x7b, 0xd1, 0x3e, 0x78, 0x3a, 0x50, 0xf1, 0x07, 0xa7, 0x52, 0x9c,
-	0x06, 0x32, 0x7b, 0xac, 0x44, 0x1b, 0x13, 0xe8, 0x95, 0x35, 0x7e, 0xea, 0x52, 0x8c, 0x43, 0xa8,
-	0x2b, 0x4f, 0xe8, 0x25, 0xf4, 0x3d, 0xca, 0x49, 0x4c, 0xb1, 0x3f, 0xa6, 0x4e, 0x14, 0x7a, 0x94,
-	0x4b, 0x57, 0xad, 0x49, 0xc5, 0x5c, 0x91, 0x1c, 0xb5, 0xa0, 0x61, 0x87, 0x94, 0x13, 0xca, 0x8d,
-	0x1f, 0xdb, 0xd0, 0x3d, 0x96, 0xcb, 0x9e, 0xa9, 0x5c, 0xa2, 0x6d, 0xa8, 0xd1, 0x90, 0xda, 0x44,
-	0xda, 0x57, 0x4d, 0x45, 0x88, 0x25, 0xda, 0x0b, 0x4c, 0x29, 0xf1, 0x93, 0x65, 0xa4, 0x24, 0xda,
-	0x83, 0x75, 0x8e, 0x5d, 0x99, 0x83, 0xde, 0xc1, 0x47, 0x69, 0x0e, 0x4a, 0x3e, 0x07, 0x67, 0xd8,
-	0x35, 0x85, 0x16, 0xfa, 0x1a, 0x5a, 0xd8, 0xf7, 0x6e, 0x89, 0x15, 0x30, 0x57, 0xaf, 0xc9, 0xb4,
-	0x6d, 0xa7, 0x26, 0x87, 0x42, 0x90, 0x58, 0x4c, 0x2a, 0x66, 0x53, 0x2a, 0xce, 0x98, 0x8b, 0x7e,
-	0x07, 0x8d, 0x80, 0x04, 0x56, 0x4c, 0xae, 0xf5, 0xba, 0x34, 0xc9, 0xa2, 0xcc, 0x48, 0x70, 0x41,
-	0x62, 0xb6, 0xf0, 0x22, 0x93, 0x5c, 0xdf, 0x10, 0xc6, 0x27, 0x15, 0xb3, 0x1e, 0x90, 0xc0, 0x24,
-	0xd7, 0xe8, 0xf7, 0xa9, 0x15, 0xd3, 0x1b, 0xd2, 0x6a, 0xe7, 0x21, 0x2b, 0x16, 0x85, 0x94, 0x91,
-	0xcc, 0x8c, 0xa1, 0x57, 0xd0, 0x74, 0x30, 0xc7, 0x72, 0x81, 0x4d, 0x69, 0xb7, 0x95, 0xda, 0x8d,
-	0x30, 0xc7, 0xf9, 0xfa, 0x1a, 0x42, 0x4d, 0x2c, 0x6f, 0x0f, 0x6a, 0x0b, 0xe2, 0xfb, 0xa1, 0xde,
-	0x2a, 0xab, 0xab, 0x14, 0x4c, 0x84, 0x68, 0x52, 0x31, 0x95, 0x0e, 0xda, 0x4f, 0xdc, 0x3b, 0x9e,
-	0xab, 0x83, 0xd4, 0x47, 0x45, 0xf7, 0x23, 0xcf, 0x55, 0xbb, 0x90, 0xde, 0x47, 0x9e, 0x9b, 0xad,
-	0x47, 0xec, 0xbe, 0xbd, 0xba, 0x9e, 0x7c, 0xdf, 0xd2, 0x42, 0x6d, 0xbc, 0x2d, 0x2d, 0x6e, 0x22,
-	0x07, 0x73, 0xa2, 0x77, 0x56, 0xa3, 0xbc, 0x93, 0x92, 0x49, 0xc5, 0x04, 0x27, 0xa3, 0xd0, 0x73,
-	0xa8, 0x91, 0x20, 0xe2, 0xf7, 0x7a, 0x57, 0x1a, 0x74, 0x53, 0x83, 0xb1, 0x60, 0x8a, 0x0d, 0x48,
-	0x29, 0xda, 0x83, 0xaa, 0x1d, 0x52, 0xaa, 0xf7, 0xa4, 0xd6, 0x93, 0x54, 0x6b, 0x18, 0x52, 0x3a,
-	0x66, 0x1c, 0x5f, 0xf8, 0x1e, 0x5b, 0x4c, 0x2a, 0xa6, 0x54, 0x42, 0x07, 0x00, 0x8c, 0x63, 0x4e,
-	0x2c, 0x8f, 0x5e, 0x86, 0xfa, 0x86, 0x34, 0xd9, 0xcc, 0xae, 0x89, 0x90, 0x4c, 0xe9, 0xa5, 0xc8,
-	0x4e, 0x8b, 0xa5, 0x04, 0x3a, 0x82, 0x9e, 0xb2, 0x61, 0x14, 0x47, 0x6c, 0x11, 0x72, 0xbd, 0x5f,
-	0x3e, 0xf4, 0xcc, 0xee, 0x34, 0x51, 0x98, 0x54, 0xcc, 0xae, 0x34, 0x49, 0x19, 0x68, 0x06, 0x5b,
-	0x79, 0x5c, 0x2b, 0xba, 0xf1, 0x7d, 0x99, 0xbf, 0x4d, 0xe9, 0xe8, 0x93, 0x15, 0x47, 0x27, 0x37,
-	0xbe, 0x9f, 0x27, 0xb2, 0xcf, 0x96, 0xf8, 0xe8, 0x10, 0x94, 0x7f, 0xe1, 0x44, 0x28, 0xe9, 0xa8,
-	0x5c, 0x50, 0x26, 0x09, 0x42, 0x4e, 0xa4, 0xbb, 0xdc, 0x4d, 0x87, 0x15, 0x68, 0x34, 0x4a, 0x77,
-	0x15, 0x27, 0x25, 0xa7, 0x6f, 0x49, 0x1f, 0x1f, 0x3f, 0xe8, 0x23, 0xab, 0xca, 0x2e, 0x2b, 0x32,
-	0x44, 0x6e, 0x7c, 0x82, 0x1d, 0x55, 0xbc, 0xb2, 0x44, 0xb7, 0xcb, 0xb9, 0x79, 0x93, 0x49, 0xf3,
-	0x42, 0xed, 0xe6, 0x26, 0xa2, 0x5c, 0xbf, 0x85, 0x6e, 0x44, 0x48, 0x6c, 0x79, 0x0e, 0xa1, 0xdc,
-	0xe3, 0xf7, 0xfa, 0x93, 0xf2, 0x35, 0x3c, 0x21, 0x24, 0x9e, 0x26, 0x32, 0xb1, 0x8d, 0xa8, 0x40,
-	0x8b, 0xcb, 0x8e, 0xed, 0x2b, 0xfd, 0xa9, 0x34, 0x79, 0x96, 0xdd, 0x5c, 0xfb, 0x8a, 0x86, 0x3f,
-	0xf8, 0xc4, 0x71, 0x49, 0x40, 0xa8, 0xd8, 0xbc, 0xd0, 0x42, 0x7f, 0x06, 0x88, 0x62, 0xef, 0x56,
-	0x65, 0x41, 0x7f, 0x56, 0x4e, 0xbe, 0xda, 0xef, 0xc9, 0x2d, 0x2f, 0x57, 0x71, 0xc1, 0x02, 0xbd,
-	0x2e, 0xd8, 0x33, 0x5d, 0x97, 0xf6, 0xbf, 0x78, 0xc4, 0x3e, 0xcb, 0x58, 0xc1, 0x04, 0xbd, 0x86,
-	0x4e, 0x42, 0x59, 0xa2, 0xd0, 0xf5, 0x8f, 0xca, 0xc7, 0x76, 0xa2, 0x64, 0xe5, 0x6b, 0xdd, 0x8e,
-	0x72, 0xae, 0x61, 0xc1, 0xfa, 0x19, 0x76, 0x51, 0x17, 0x5a, 0xef, 0xe6, 0xa3, 0xf1, 0x77, 0xd3,
-	0xf9, 0x78, 0xd4, 0xaf, 0xa0, 0x16, 0xd4, 0xc6, 0xb3, 0x93, 0xb3, 0xf3, 0xbe, 0x86, 0x3a, 0xd0,
-	0x7c, 0x6b, 0x1e, 0x5b, 0x6f, 0xe7, 0x6f, 0xce, 0xfb, 0x6b, 0x42, 0x6f, 0x38, 0x39, 0x9c, 0x2b,
-	0x72, 0x1d, 0xf5, 0xa1, 0x23, 0xc9, 0xc3, 0xf9, 0xc8, 0x7a, 0x6b, 0x1e, 0xf7, 0xab, 0x68, 0x03,
-	0xda, 0x4a, 0xc1, 0x94, 0x8c, 0x5a, 0x11, 0x89, 0xff, 0xa7, 0x41, 0x2b, 0xab, 0x48, 0x34, 0x80,
-	0x16, 0xf7, 0x02, 0xc2, 0x38, 0x0e, 0x22, 0x89, 0xb8, 0xed, 0x83, 0x7e, 0xf1, 0x84, 0xce, 0xbc,
-	0x80, 0x98, 0xb9, 0x0a, 0x7a, 0x02, 0xf5, 0xe8, 0xca, 0xb3, 0x3c, 0x47, 0x02, 0x71, 0xc7, 0xac,
-	0x45, 0x57, 0xde, 0xd4, 0x41, 0x9f, 0x41, 0x3b, 0xc1, 0x69, 0x6b, 0x76, 0x38, 0xd4, 0xab, 0x52,
-	0x06, 0x09, 0x6b, 0x76, 0x38, 0x14, 0x37, 0x34, 0x8a, 0xc3, 0x88, 0xc4, 0xdc, 0x23, 0x2c, 0x41,
-	0x64, 0x94, 0x27, 0x28, 0x95, 0x98, 0x05, 0x2d, 0xe3, 0x47, 0x0d, 0x20, 0x17, 0xa1, 0x5f, 0x42,
-	0x57, 0x1e, 0x7d, 0x6c, 0x2d, 0x88, 0xe7, 0x2e, 0x78, 0xd2, 0x38, 0x3a, 0x8a, 0x39, 0x91, 0x3c,
-	0xf4, 0x39, 0x74, 0x7c, 0x72, 0xc9, 0xad, 0x62, 0x13, 0x69, 0x9a, 0x6d, 0xc1, 0x1b, 0x26, 0x8d,
-	0xe4, 0xb7, 0x20, 0x16, 0xe6, 0x51, 0x3b, 0x74, 0x08, 0xd3, 0xd7, 0x77, 0xd7, 0x8b, 0x60, 0x31,
-	0x4c, 0x25, 0x66, 0x41, 0xc9, 0x38, 0x84, 0xcd, 0x15, 0x34, 0x40, 0x2f, 0xa1, 0x49, 0x7c, 0x59,
-	0x88, 0x4c, 0xd7, 0xa4, 0x97, 0x2c, 0x73, 0x59, 0x4f, 0xce, 0x34, 0x8c, 0x3f, 0xc0, 0xf6, 0x43,
-	0x38, 0xb0, 0x9c, 0x39, 0x6d, 0x39, 0x73, 0xc6, 0x25, 0x74, 0x4b, 0xa0, 0x57, 0x38, 0x02, 0xad,
-	0x78, 0x04, 0x3b, 0xd0, 0xcc, 0xae, 0x9a, 0x6a, 0x9d, 0x19, 0x8d, 0x0c, 0xe8, 0x72, 0x9f, 0x59,
-	0x36, 0x89, 0xb9, 0xb5, 0xc0, 0x6c, 0x91, 0x1c, 0x5e, 0x9b, 0xfb, 0x6c, 0x48, 0x62, 0x3e, 0xc1,
-	0x6c, 0x61, 0xbc, 0x83, 0x4e, 0xf1, 0x4a, 0x3e, 0x16, 0x06, 0x41, 0x55, 0xb8, 0x49, 0x42, 0xc8,
-	0x6f, 0x11, 0x3a, 0x20, 0x1c, 0xcb, 0xda, 0x57, 0x9e, 0x33, 0xda, 0x08, 0xa0, 0x5d, 0xb8, 0x79,
-	0x8f, 0x77, 0x7d, 0x47, 0x76, 0x24, 0xa6, 0xaf, 0xed, 0xae, 0x8b, 0xae, 0x9f, 0x90, 0x68, 0x00,
-	0xcd, 0x80, 0xb9, 0x16, 0xbf, 0x4f, 0xc6, 0x9f, 0x5e, 0xde, 0x96, 0x44, 0x16, 0x67, 0xcc, 0x3d,
-	0xbb, 0x8f, 0x88, 0xd9, 0x08, 0xd4, 0x87, 0x11, 0x42, 0xbb, 0xd0, 0x0f, 0x1f, 0x09, 0x57, 0x5c,
-	0xef, 0x5a, 0x79, 0xbd, 0x1f, 0x1c, 0xf0, 0x0e, 0x20, 0x6f, 0x75, 0x8f, 0xc4, 0xfb, 0x15, 0x54,
-	0x93, 0x58, 0x0f, 0x57, 0x49, 0xf5, 0x27, 0x45, 0xf6, 0x55, 0x64, 0xd5, 0xca, 0x7f, 0xf6, 0xc4,
-	0x7e, 0xa3, 0xce, 0x31, 0x9d, 0xde, 0x7e, 0x5d, 0x1e, 0x25, 0xdb, 0x07, 0x1b, 0x99, 0xb5, 0x62,
-	0x67, 0xb3, 0xa5, 0xf1, 0x1d, 0xa0, 0x55, 0x04, 0x44, 0xaf, 0x96, 0x1d, 0x3c, 0x5d, 0x82, 0xcb,
-	0x15, 0x3f, 0xe7, 0xd0, 0x48, 0x78, 0xe8, 0x19, 0x34, 0x18, 0xb9, 0xb6, 0xe8, 0x4d, 0x90, 0x6c,
-	0xb7, 0xce, 0xc8, 0xf5, 0xfc, 0x26, 0x10, 0xd5, 0x59, 0x38, 0x55, 0x95, 0xd7, 0xcf, 0x97, 0xd0,
-	0x79, 0x5d, 0x26, 0xa2, 0x84, 0xbf, 0xff, 0x59, 0x83, 0x5e, 0x39, 0x2c, 0xfa, 0x12, 0x36, 0xf2,
-	0xb9, 0xde, 0xa2, 0x38, 0x50, 0x99, 0x6d, 0x99, 0xbd, 0x9c, 0x3d, 0xc7, 0x01, 0x11, 0xa3, 0xb3,
-	0x90, 0xb2, 0x08, 0xdb, 0x6a, 0x74, 0x6e, 0x99, 0x39, 0x03, 0x6d, 0x41, 0x8d, 0xdf, 0xa5, 0x70,
-	0xd9, 0x32, 0xab, 0xfc, 0x6e, 0xea, 0x08, 0x24, 0x4b, 0x57, 0x14, 0xff, 0xc0, 0x08, 0x4f, 0xf0,
-	0x32, 0x5d, 0xa6, 0x29, 0x78, 0xe8, 0x25, 0xa0, 0x54, 0x89, 0x79, 0x41, 0x8a, 0x79, 0x35, 0xb9,
-	0xdd, 0x7e, 0x22, 0x39, 0xf5, 0x82, 0x04, 0xf7, 0xe6, 0x80, 0x0a, 0xcb, 0xb5, 0x43, 0x7a, 0xe9,
-	0xb9, 0x2c, 0x19, 0x63, 0x3f, 0x1b, 0xa8, 0x87, 0xca, 0x60, 0x98, 0x69, 0x0c, 0xa5, 0xc2, 0x09,
-	0xb6, 0xaf, 0xb0, 0x4b, 0xcc, 0x4d, 0x7b, 0x49, 0xc0, 0x8c, 0x7f, 0x6b, 0xd0, 0x29, 0x0e, 0xca,
-	0x68, 0x00, 0x10, 0x64, 0xf3, 0x6c, 0x72, 0x64, 0xbd, 0xf2, 0xa4, 0x6b, 0x16, 0x34, 0x3e, 0xb8,
-	0xb1, 0x14, 0xe1, 0xab, 0x5a, 0x86, 0x2f, 0xe3, 0x9f, 0x1a, 0x6c, 0xae, 0x4c, 0x1c, 0x8f, 0x01,
-	0xd4, 0x87, 0x06, 0x7e, 0x0e, 0x3d, 0x8f, 0x59, 0x0e, 0xb1, 0x7d, 0x1c, 0x63, 0x91, 0x02, 0x79,
-	0x54, 0x4d, 0xb3, 0xeb, 0xb1, 0x51, 0xce, 0x34, 0xfe, 0x08, 0xcd, 0xd4, 0x5a, 0x94, 0x9f, 0x47,
-	0xed, 0x62, 0xf9, 0x79, 0xd4, 0x16, 0xe5, 0x57, 0xa8, 0xcb, 0xb5, 0x62, 0x5d, 0x1a, 0x97, 0xb0,
-	0xb9, 0xf2, 0x86, 0x40, 0xdf, 0x42, 0x9f, 0x11, 0xff, 0x52, 0x0e, 0x8f, 0x71, 0xa0, 0x62, 0x6b,
-	0xe5, 0x05, 0x67, 0x10, 0xb1, 0x21, 0x34, 0xa7, 0xb9, 0xa2, 0xb8, 0xef, 0x62, 0x18, 0xa2, 0xc9,
-	0xbd, 0x56, 0x84, 0x71, 0x01, 0x68, 0xf5, 0xd5, 0x81, 0xbe, 0x80, 0x9a, 0x7c, 0xe4, 0x3c, 0xda,
-	0xa6, 0x94, 0x58, 0xe2, 0x14, 0xc1, 0xce, 0x7b, 0x70, 0x8a, 0x60, 0xc7, 0xf8, 0x2b, 0xd4, 0x55,
-	0x0c, 0x71, 0x66, 0xa4, 0xf4, 0x0a, 0x34, 0x33, 0xfa, 0xbd, 0x18, 0xfb, 0xf0, 0x10, 0x61, 0x34,
-	0xa0, 0x26, 0x1f, 0x01, 0xc6, 0xdf, 0x00, 0xad, 0x8e, 0xba, 0xa2, 0x89, 0x31, 0x8e, 0x63, 0x6e,
-	0x95, 0xaf, 0x7e, 0x5b, 0x32, 0x4f, 0xd5, 0xfd, 0xff, 0x14, 0xda, 0x84, 0x3a, 0x56, 0xf9, 0x10,
-	0x5a, 0x84, 0x3a, 0x4a, 0x6e, 0x1c, 0xc1, 0xd6, 0x03, 0x03, 0x30, 0xda, 0x83, 0x66, 0x82, 0x32,
-	0x69, 0x2b, 0x5f, 0x81, 0xb3, 0x4c, 0xc1, 0x38, 0x86, 0xed, 0x87, 0x86, 0x4a, 0xb4, 0x9f, 0x63,
-	0xad, 0xf2, 0x91, 0x3d, 0x5a, 0x12, 0x45, 0x85, 0xd4, 0x19, 0x04, 0x1b, 0xff, 0xd5, 0xa0, 0x5b,
-	0x12, 0xe5, 0x68, 0xa1, 0x15, 0xd0, 0xe2, 0xfd, 0x00, 0xf3, 0x29, 0x40, 0x7e, 0x7b, 0x13, 0x94,
-	0x29, 0x70, 0xd0, 0xc7, 0xd0, 0xba, 0xf0, 0x43, 0xfb, 0x4a, 0xe4, 0x44, 0x5e, 0xac, 0xaa, 0xd9,
-	0x94, 0x8c, 0x53, 0x72, 0x8d, 0x76, 0xa1, 0x23, 0x52, 0xe5, 0x51, 0x4b, 0xb2, 0x12, 0x74, 0x01,
-	0x46, 0xae, 0xa7, 0xf4, 0x48, 0x70, 0x8c, 0xef, 0xe1, 0xc9, 0x83, 0x13, 0x30, 0x3a, 0x58, 0x99,
-	0x7e, 0x9e, 0x2e, 0x6d, 0x77, 0xac, 0xc4, 0x85, 0x19, 0xe8, 0x1c, 0x7a, 0x65, 0x19, 0xfa, 0x0a,
-	0xea, 0x2a, 0x1b, 0x49, 0xe1, 0x3f, 0x92, 0xb2, 0x44, 0xa9, 0xf8, 0x03, 0x23, 0x69, 0x67, 0x69,
-	0x73, 0xf8, 0x4b, 0xe6, 0x3a, 0x05, 0xf0, 0xe7, 0xb0, 0xc1, 0xef, 0xac, 0xd2, 0xf6, 0x92, 0x81,
-	0x91, 0xdf, 0x9d, 0x66, 0x1b, 0x2c, 0xbb, 0x2c, 0xfe, 0x13, 0x31, 0xbe, 0x84, 0x8d, 0xa5, 0x07,
-	0x87, 0xb8, 0x74, 0x24, 0x8e, 0xc3, 0x38, 0x39, 0x1f, 0x45, 0x18, 0xef, 0xa0, 0x95, 0x8d, 0x8d,
-	0xa2, 0x03, 0x15, 0x9a, 0x85, 0xfc, 0x16, 0x31, 0x6e, 0x49, 0xcc, 0xc4, 0x01, 0xa9, 0xf3, 0x4b,
-	0xc9, 0xf7, 0x4d, 0x4e, 0xbf, 0xf9, 0x13, 0xb4, 0x0b, 0x9d, 0x78, 0xf9, 0x71, 0xd0, 0x85, 0xd6,
-	0xd1, 0x9b, 0xb7, 0xc3, 0xef, 0xad, 0xd9, 0xe9, 0x71, 0x5f, 0x13, 0x6f, 0x80, 0xe9, 0x68, 0x3c,
-	0x3f, 0x9b, 0x9e, 0x9d, 0x4b, 0xce, 0xda, 0xc1, 0x3f, 0xa0, 0xae, 0x26, 0x21, 0xf4, 0x0d, 0x74,
-	0xd4, 0xd7, 0x29, 0x8f, 0x09, 0x0e, 0xd0, 0xca, 0xc5, 0xde, 0x59, 0xe1, 0x18, 0x95, 0x17, 0xda,
-	0x2b, 0x0d, 0x7d, 0x01, 0xd5, 0x13, 0x8f, 0xba, 0xa8, 0xfc, 0x48, 0xdf, 0x29, 0x93, 0x46, 0xe5,
-	0xe8, 0xab, 0xbf, 0xef, 0xb9, 0x1e, 0x5f, 0xdc, 0x5c, 0x88, 0x4e, 0xb3, 0xbf, 0xb8, 0x8f, 0x48,
-	0xac, 0xa6, 0xf2, 0xfd, 0x4b, 0x7c, 0x11, 0x7b, 0xf6, 0xbe, 0xfc, 0x2f, 0xc6, 0xf6, 0x95, 0xd9,
-	0x45, 0x5d, 0x92, 0x5f, 0xff, 0x3f, 0x00, 0x00, 0xff, 0xff, 0xdd, 0x1d, 0xb3, 0x7e, 0x5f, 0x13,
-	0x00, 0x00,
+func init() { proto.RegisterFile("gossip/message.proto", fileDescriptor_message_55956b3b1ad34a96) }
+
+var fileDescriptor_message_55956b3b1ad34a96 = []byte{
+	// 1913 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5f, 0x53, 0xe4, 0xc6,
+	0x11, 0x5f, 0xc1, 0xee, 0xb2, 0xdb, 0xfb, 0x87, 0x65, 0x8e, 0xe3, 0x64, 0xec, 0xd8, 0x44, 0xc9,
+	0xd9, 0x97, 0x70, 0x86, 0x0b, 0x4e, 0x2a, 0xae, 0x72, 0x92, 0x0b, 0x2c, 0x98, 0xa5, 0x7c, 0x70,
+	0x44, 0x70, 0x95, 0x90, 0x17, 0xd5, 0x20, 0x0d, 0x5a, 0x05, 0x69, 0x24, 0x34, 0x03, 0x86, 0xc7,
+	0x54, 0x1e, 0x52, 0x95, 0x97, 0x7c, 0x86, 0x3c, 0xe5, 0x43, 0xe4, 0xcb, 0xa5, 0xe6, 0x8f, 0xa4,
+	0x11, 0x0b, 0x57, 0x75, 0xae, 0xf2, 0x9b, 0xfa, 0xef, 0xf4, 0xf4, 0xf4, 0xfc, 0xba, 0x47, 0xb0,
+	0x1c, 0xa6, 0x8c, 0x45, 0xd9, 0x66, 0x42, 0x18, 0xc3, 0x21, 0xd9, 0xc8, 0xf2, 0x94, 0xa7, 0xa8,
+	0xad, 0xb8, 0xab, 0xcf, 0xfc, 0x34, 0x49, 0x52, 0xba, 0xe9, 0xa7, 0x71, 0x4c, 0x7c, 0x1e, 0xa5,
+	0x54, 0x29, 0x38, 0xff, 0xb0, 0xa0, 0xb3, 0x47, 0x6f, 0x48, 0x9c, 0x66, 0x04, 0xd9, 0xb0, 0x90,
+	0xe1, 0xbb, 0x38, 0xc5, 0x81, 0x6d, 0xad, 0x59, 0x2f, 0xfa, 0x6e, 0x41, 0xa2, 0x4f, 0xa0, 0xcb,
+	0xa2, 0x90, 0x62, 0x7e, 0x9d, 0x13, 0x7b, 0x4e, 0xca, 0x2a, 0x06, 0x7a, 0x0d, 0x8b, 0x8c, 0xf8,
+	0x39, 0xe1, 0x1e, 0xd1, 0xae, 0xec, 0xf9, 0x35, 0xeb, 0x45, 0x6f, 0x6b, 0x65, 0x43, 0xad, 0xbf,
+	0x71, 0x22, 0xc5, 0xc5, 0x42, 0xee, 0x90, 0xd5, 0x68, 0x67, 0x02, 0xc3, 0xba, 0xc6, 0x0f, 0x0d,
+	0xc5, 0xd9, 0x86, 0xb6, 0xf2, 0x84, 0x5e, 0xc2, 0x28, 0xa2, 0x9c, 0xe4, 0x14, 0xc7, 0x7b, 0x34,
+	0xc8, 0xd2, 0x88, 0x72, 0xe9, 0xaa, 0x3b, 0x69, 0xb8, 0x33, 0x92, 0x9d, 0x2e, 0x2c, 0xf8, 0x29,
+	0xe5, 0x84, 0x72, 0xe7, 0x9f, 0x3d, 0x18, 0xec, 0xcb, 0xb0, 0x0f, 0x55, 0x2e, 0xd1, 0x32, 0xb4,
+	0x68, 0x4a, 0x7d, 0x22, 0xed, 0x9b, 0xae, 0x22, 0x44, 0x88, 0xfe, 0x14, 0x53, 0x4a, 0x62, 0x1d,
+	0x46, 0x41, 0xa2, 0x75, 0x98, 0xe7, 0x38, 0x94, 0x39, 0x18, 0x6e, 0x7d, 0x54, 0xe4, 0xa0, 0xe6,
+	0x73, 0xe3, 0x14, 0x87, 0xae, 0xd0, 0x42, 0x5f, 0x41, 0x17, 0xc7, 0xd1, 0x0d, 0xf1, 0x12, 0x16,
+	0xda, 0x2d, 0x99, 0xb6, 0xe5, 0xc2, 0x64, 0x5b, 0x08, 0xb4, 0xc5, 0xa4, 0xe1, 0x76, 0xa4, 0xe2,
+	0x21, 0x0b, 0xd1, 0xaf, 0x61, 0x21, 0x21, 0x89, 0x97, 0x93, 0x2b, 0xbb, 0x2d, 0x4d, 0xca, 0x55,
+	0x0e, 0x49, 0x72, 0x4e, 0x72, 0x36, 0x8d, 0x32, 0x97, 0x5c, 0x5d, 0x13, 0xc6, 0x27, 0x0d, 0xb7,
+	0x9d, 0x90, 0xc4, 0x25, 0x57, 0xe8, 0x37, 0x85, 0x15, 0xb3, 0x17, 0xa4, 0xd5, 0xea, 0x43, 0x56,
+	0x2c, 0x4b, 0x29, 0x23, 0xa5, 0x19, 0x43, 0xaf, 0xa0, 0x13, 0x60, 0x8e, 0x65, 0x80, 0x1d, 0x69,
+	0xf7, 0xa4, 0xb0, 0xdb, 0xc5, 0x1c, 0x57, 0xf1, 0x2d, 0x08, 0x35, 0x11, 0xde, 0x3a, 0xb4, 0xa6,
+	0x24, 0x8e, 0x53, 0xbb, 0x5b, 0x57, 0x57, 0x29, 0x98, 0x08, 0xd1, 0xa4, 0xe1, 0x2a, 0x1d, 0xb4,
+	0xa9, 0xdd, 0x07, 0x51, 0x68, 0x83, 0xd4, 0x47, 0xa6, 0xfb, 0xdd, 0x28, 0x54, 0xbb, 0x90, 0xde,
+	0x77, 0xa3, 0xb0, 0x8c, 0x47, 0xec, 0xbe, 0x37, 0x1b, 0x4f, 0xb5, 0x6f, 0x69, 0xa1, 0x36, 0xde,
+	0x93, 0x16, 0xd7, 0x59, 0x80, 0x39, 0xb1, 0xfb, 0xb3, 0xab, 0xbc, 0x93, 0x92, 0x49, 0xc3, 0x85,
+	0xa0, 0xa4, 0xd0, 0x73, 0x68, 0x91, 0x24, 0xe3, 0x77, 0xf6, 0x40, 0x1a, 0x0c, 0x0a, 0x83, 0x3d,
+	0xc1, 0x14, 0x1b, 0x90, 0x52, 0xb4, 0x0e, 0x4d, 0x3f, 0xa5, 0xd4, 0x1e, 0x4a, 0xad, 0xa7, 0x85,
+	0xd6, 0x38, 0xa5, 0x74, 0x8f, 0x71, 0x7c, 0x1e, 0x47, 0x6c, 0x3a, 0x69, 0xb8, 0x52, 0x09, 0x6d,
+	0x01, 0x30, 0x8e, 0x39, 0xf1, 0x22, 0x7a, 0x91, 0xda, 0x8b, 0xd2, 0x64, 0xa9, 0xbc, 0x26, 0x42,
+	0x72, 0x40, 0x2f, 0x44, 0x76, 0xba, 0xac, 0x20, 0xd0, 0x0e, 0x0c, 0x95, 0x0d, 0xa3, 0x38, 0x63,
+	0xd3, 0x94, 0xdb, 0xa3, 0xfa, 0xa1, 0x97, 0x76, 0x27, 0x5a, 0x61, 0xd2, 0x70, 0x07, 0xd2, 0xa4,
+	0x60, 0xa0, 0x43, 0x78, 0x52, 0xad, 0xeb, 0x65, 0xd7, 0x71, 0x2c, 0xf3, 0xb7, 0x24, 0x1d, 0x7d,
+	0x32, 0xe3, 0xe8, 0xf8, 0x3a, 0x8e, 0xab, 0x44, 0x8e, 0xd8, 0x3d, 0x3e, 0xda, 0x06, 0xe5, 0xdf,
+	0xcb, 0x95, 0x92, 0x8d, 0xea, 0x05, 0xe5, 0x92, 0x24, 0xe5, 0x44, 0xba, 0xab, 0xdc, 0xf4, 0x99,
+	0x41, 0xa3, 0xdd, 0x62, 0x57, 0xb9, 0x2e, 0x39, 0xfb, 0x89, 0xf4, 0xf1, 0xf1, 0x83, 0x3e, 0xca,
+	0xaa, 0x1c, 0x30, 0x93, 0x21, 0x72, 0x13, 0x13, 0x1c, 0xa8, 0xe2, 0x95, 0x25, 0xba, 0x5c, 0xcf,
+	0xcd, 0x9b, 0x52, 0x5a, 0x15, 0xea, 0xa0, 0x32, 0x11, 0xe5, 0xfa, 0x0d, 0x0c, 0x32, 0x42, 0x72,
+	0x2f, 0x0a, 0x08, 0xe5, 0x11, 0xbf, 0xb3, 0x9f, 0xd6, 0xaf, 0xe1, 0x31, 0x21, 0xf9, 0x81, 0x96,
+	0x89, 0x6d, 0x64, 0x06, 0x2d, 0x2e, 0x3b, 0xf6, 0x2f, 0xed, 0x15, 0x69, 0xf2, 0xac, 0xbc, 0xb9,
+	0xfe, 0x25, 0x4d, 0xbf, 0x8f, 0x49, 0x10, 0x92, 0x84, 0x50, 0xb1, 0x79, 0xa1, 0x85, 0xfe, 0x00,
+	0x90, 0xe5, 0xd1, 0x8d, 0xca, 0x82, 0xfd, 0xac, 0x9e, 0x7c, 0xb5, 0xdf, 0xe3, 0x1b, 0x5e, 0xaf,
+	0x62, 0xc3, 0x02, 0xbd, 0x36, 0xec, 0x99, 0x6d, 0x4b, 0xfb, 0x9f, 0x3c, 0x62, 0x5f, 0x66, 0xcc,
+	0x30, 0x41, 0xaf, 0xa1, 0xaf, 0x29, 0x4f, 0x14, 0xba, 0xfd, 0x51, 0xfd, 0xd8, 0x8e, 0x95, 0xac,
+	0x7e, 0xad, 0x7b, 0x59, 0xc5, 0x75, 0x3c, 0x98, 0x3f, 0xc5, 0x21, 0x1a, 0x40, 0xf7, 0xdd, 0xd1,
+	0xee, 0xde, 0xb7, 0x07, 0x47, 0x7b, 0xbb, 0xa3, 0x06, 0xea, 0x42, 0x6b, 0xef, 0xf0, 0xf8, 0xf4,
+	0x6c, 0x64, 0xa1, 0x3e, 0x74, 0xde, 0xba, 0xfb, 0xde, 0xdb, 0xa3, 0x37, 0x67, 0xa3, 0x39, 0xa1,
+	0x37, 0x9e, 0x6c, 0x1f, 0x29, 0x72, 0x1e, 0x8d, 0xa0, 0x2f, 0xc9, 0xed, 0xa3, 0x5d, 0xef, 0xad,
+	0xbb, 0x3f, 0x6a, 0xa2, 0x45, 0xe8, 0x29, 0x05, 0x57, 0x32, 0x5a, 0x26, 0x12, 0xff, 0xd7, 0x82,
+	0x6e, 0x59, 0x91, 0x68, 0x03, 0xba, 0x3c, 0x4a, 0x08, 0xe3, 0x38, 0xc9, 0x24, 0xe2, 0xf6, 0xb6,
+	0x46, 0xe6, 0x09, 0x9d, 0x46, 0x09, 0x71, 0x2b, 0x15, 0xf4, 0x14, 0xda, 0xd9, 0x65, 0xe4, 0x45,
+	0x81, 0x04, 0xe2, 0xbe, 0xdb, 0xca, 0x2e, 0xa3, 0x83, 0x00, 0x7d, 0x06, 0x3d, 0x8d, 0xd3, 0xde,
+	0xe1, 0xf6, 0xd8, 0x6e, 0x4a, 0x19, 0x68, 0xd6, 0xe1, 0xf6, 0x58, 0xdc, 0xd0, 0x2c, 0x4f, 0x33,
+	0x92, 0xf3, 0x88, 0x30, 0xbb, 0x55, 0xc7, 0x8a, 0xe3, 0x52, 0xe2, 0x1a, 0x5a, 0xce, 0xff, 0x2c,
+	0x80, 0x4a, 0x84, 0x7e, 0x06, 0x03, 0x79, 0xf4, 0xb9, 0x37, 0x25, 0x51, 0x38, 0xe5, 0xba, 0x71,
+	0xf4, 0x15, 0x73, 0x22, 0x79, 0xe8, 0xa7, 0xd0, 0x8f, 0xc9, 0x05, 0xf7, 0xcc, 0x26, 0xd2, 0x71,
+	0x7b, 0x82, 0x37, 0x56, 0x2c, 0xf4, 0x2b, 0x10, 0x81, 0x45, 0xd4, 0x4f, 0x03, 0xc2, 0xec, 0xf9,
+	0xb5, 0x79, 0x13, 0x2c, 0xc6, 0x85, 0xc4, 0x35, 0x94, 0x44, 0xf4, 0xfe, 0x94, 0xf8, 0x97, 0xaa,
+	0xe1, 0x35, 0xeb, 0xd1, 0x8f, 0x4b, 0x89, 0x6b, 0x68, 0x39, 0x7f, 0x04, 0xa8, 0x24, 0x68, 0x05,
+	0xda, 0xb5, 0xa8, 0x35, 0x85, 0x56, 0xa1, 0x23, 0x6d, 0xd8, 0x75, 0xa2, 0x1b, 0x5e, 0x49, 0x3b,
+	0xdb, 0xb0, 0x34, 0x83, 0x41, 0xe8, 0x25, 0x74, 0x48, 0x2c, 0xcb, 0x9f, 0xd9, 0xd6, 0xda, 0xbc,
+	0x79, 0x5e, 0xe5, 0x24, 0x50, 0x6a, 0x38, 0xbf, 0x85, 0xe5, 0x87, 0xd0, 0xe7, 0xfe, 0x79, 0x59,
+	0xf7, 0xcf, 0xcb, 0xb9, 0x80, 0x41, 0x0d, 0x6a, 0x8d, 0x83, 0xb7, 0xcc, 0x83, 0x5f, 0x85, 0x4e,
+	0x79, 0xc1, 0x75, 0xfc, 0x05, 0x8d, 0x1c, 0x18, 0xf0, 0x98, 0x79, 0x3e, 0xc9, 0xb9, 0x37, 0xc5,
+	0x6c, 0xaa, 0x4b, 0xa6, 0xc7, 0x63, 0x36, 0x26, 0x39, 0x9f, 0x60, 0x36, 0x75, 0xde, 0x41, 0xdf,
+	0x04, 0x82, 0xc7, 0x96, 0x41, 0xd0, 0x14, 0x6e, 0xf4, 0x12, 0xf2, 0x5b, 0x2c, 0x9d, 0x10, 0x8e,
+	0xe5, 0x8d, 0x53, 0x9e, 0x4b, 0xda, 0x49, 0xa0, 0x67, 0xdc, 0xf7, 0xc7, 0x67, 0x8d, 0x40, 0xf6,
+	0x41, 0x66, 0xcf, 0xad, 0xcd, 0x8b, 0x59, 0x43, 0x93, 0x68, 0x03, 0x3a, 0x09, 0x0b, 0x3d, 0x7e,
+	0xa7, 0x87, 0xae, 0x61, 0xd5, 0x0c, 0x45, 0x16, 0x0f, 0x59, 0x78, 0x7a, 0x97, 0x11, 0x77, 0x21,
+	0x51, 0x1f, 0x4e, 0x0a, 0x3d, 0xa3, 0x0b, 0x3f, 0xb2, 0x9c, 0x19, 0xef, 0x5c, 0x3d, 0xde, 0x0f,
+	0x5e, 0xf0, 0x16, 0xa0, 0x6a, 0xb0, 0x8f, 0xac, 0xf7, 0x73, 0x68, 0xea, 0xb5, 0x1e, 0xae, 0x92,
+	0xe6, 0x0f, 0x5a, 0x39, 0x06, 0xa8, 0x06, 0x88, 0x1f, 0x3d, 0xb1, 0x5f, 0x43, 0xcf, 0x80, 0x4d,
+	0xf4, 0x8b, 0xfa, 0x00, 0xdb, 0xdb, 0x5a, 0x2c, 0xad, 0x15, 0xbb, 0x9c, 0x68, 0x9d, 0x6f, 0x01,
+	0xcd, 0xe2, 0x2e, 0x7a, 0x75, 0xdf, 0xc1, 0xca, 0x3d, 0x90, 0x9e, 0xf1, 0x73, 0x06, 0x0b, 0x9a,
+	0x87, 0x9e, 0xc1, 0x02, 0x23, 0x57, 0x1e, 0xbd, 0x4e, 0x8a, 0x4b, 0xcc, 0xc8, 0xd5, 0xd1, 0x75,
+	0x22, 0xaa, 0xd3, 0x38, 0x55, 0xf9, 0x2d, 0x80, 0xa8, 0xd6, 0x13, 0xe6, 0x65, 0x22, 0x6a, 0xa8,
+	0xff, 0xef, 0x39, 0x18, 0xd6, 0x97, 0x45, 0x5f, 0xc0, 0x62, 0xf5, 0x9a, 0xf0, 0x28, 0x4e, 0x54,
+	0x66, 0xbb, 0xee, 0xb0, 0x62, 0x1f, 0xe1, 0x84, 0x88, 0x81, 0x5d, 0x48, 0x59, 0x86, 0x7d, 0x35,
+	0xb0, 0x77, 0xdd, 0x8a, 0x81, 0x9e, 0x40, 0x8b, 0xdf, 0x16, 0x20, 0xdd, 0x75, 0x9b, 0xfc, 0xf6,
+	0x20, 0x10, 0xf8, 0x59, 0x44, 0x94, 0x7f, 0xcf, 0x08, 0xd7, 0x28, 0x5d, 0x84, 0xe9, 0x0a, 0x1e,
+	0x7a, 0x09, 0xa8, 0x50, 0x62, 0x51, 0x52, 0x20, 0x6d, 0x4b, 0x6e, 0x77, 0xa4, 0x25, 0x27, 0x51,
+	0xa2, 0xd1, 0xf6, 0x08, 0x90, 0x11, 0xae, 0x9f, 0xd2, 0x8b, 0x28, 0x64, 0x7a, 0x78, 0xfe, 0x6c,
+	0x43, 0x3d, 0x8f, 0x36, 0xc6, 0xa5, 0xc6, 0x58, 0x2a, 0x1c, 0x63, 0xff, 0x12, 0x87, 0xc4, 0x5d,
+	0xf2, 0xef, 0x09, 0x98, 0xf3, 0x2f, 0x0b, 0xfa, 0xe6, 0x78, 0x8e, 0x36, 0x00, 0x92, 0x72, 0x8a,
+	0xd6, 0x47, 0x36, 0xac, 0xcf, 0xd7, 0xae, 0xa1, 0xf1, 0xc1, 0xed, 0xcc, 0x84, 0xaf, 0x66, 0x1d,
+	0xbe, 0x9c, 0xbf, 0x5b, 0xb0, 0x34, 0x33, 0xe7, 0x3c, 0x06, 0x50, 0x1f, 0xba, 0xf0, 0x73, 0x18,
+	0x46, 0xcc, 0x0b, 0x88, 0x1f, 0xe3, 0x1c, 0x8b, 0x14, 0xc8, 0xa3, 0xea, 0xb8, 0x83, 0x88, 0xed,
+	0x56, 0x4c, 0xe7, 0x77, 0xd0, 0x29, 0xac, 0x45, 0xf9, 0x45, 0xd4, 0x37, 0xcb, 0x2f, 0xa2, 0xbe,
+	0x28, 0x3f, 0xa3, 0x2e, 0xe7, 0xcc, 0xba, 0x74, 0x2e, 0x60, 0x69, 0xe6, 0xe5, 0x82, 0xbe, 0x81,
+	0x11, 0x23, 0xf1, 0x85, 0x1c, 0x59, 0xf3, 0x44, 0xad, 0x6d, 0xad, 0x59, 0x0f, 0x42, 0xc4, 0xa2,
+	0xd0, 0x3c, 0xa8, 0x14, 0xc5, 0x7d, 0x17, 0x23, 0x18, 0xd5, 0xf7, 0x5a, 0x11, 0xce, 0x39, 0xa0,
+	0xd9, 0xb7, 0x0e, 0xfa, 0x1c, 0x5a, 0xf2, 0x69, 0xf5, 0x68, 0x9b, 0x52, 0x62, 0x89, 0x53, 0x04,
+	0x07, 0xef, 0xc1, 0x29, 0x82, 0x03, 0xe7, 0xcf, 0xd0, 0x56, 0x6b, 0x88, 0x33, 0x23, 0xb5, 0xb7,
+	0xa7, 0x5b, 0xd2, 0xef, 0xc5, 0xd8, 0x87, 0x47, 0x17, 0x67, 0x01, 0x5a, 0xf2, 0xe9, 0xe1, 0xfc,
+	0x05, 0xd0, 0xec, 0x80, 0x2d, 0x9a, 0x18, 0xe3, 0x38, 0xe7, 0x5e, 0xfd, 0xea, 0xf7, 0x24, 0xf3,
+	0x44, 0xdd, 0xff, 0x4f, 0xa1, 0x47, 0x68, 0xe0, 0xd5, 0x0f, 0xa1, 0x4b, 0x68, 0xa0, 0xe4, 0xce,
+	0x0e, 0x3c, 0x79, 0x60, 0xec, 0x46, 0xeb, 0xd0, 0xd1, 0x28, 0x53, 0xb4, 0xf2, 0x19, 0x38, 0x2b,
+	0x15, 0x9c, 0x7d, 0x58, 0x7e, 0x68, 0x94, 0x45, 0x9b, 0x15, 0xd6, 0x2a, 0x1f, 0xe5, 0x53, 0x49,
+	0x2b, 0x2a, 0xa4, 0x2e, 0x21, 0xd8, 0xf9, 0x8f, 0x05, 0x83, 0x9a, 0xa8, 0x42, 0x0b, 0xcb, 0x40,
+	0x8b, 0xf7, 0x03, 0xcc, 0xa7, 0x00, 0xd5, 0xed, 0xd5, 0x28, 0x63, 0x70, 0xd0, 0xc7, 0xd0, 0x3d,
+	0x8f, 0x53, 0xff, 0x52, 0xe4, 0x44, 0x5e, 0xac, 0xa6, 0xdb, 0x91, 0x8c, 0x13, 0x72, 0x85, 0xd6,
+	0xa0, 0x2f, 0x52, 0x15, 0x51, 0x4f, 0xb2, 0x34, 0xba, 0x00, 0x23, 0x57, 0x07, 0x74, 0x47, 0x70,
+	0x9c, 0xef, 0xe0, 0xe9, 0x83, 0x73, 0x37, 0xda, 0x9a, 0x99, 0x7e, 0x56, 0xee, 0x6d, 0x77, 0x4f,
+	0x89, 0x8d, 0x19, 0xe8, 0x0c, 0x86, 0x75, 0x19, 0xfa, 0x12, 0xda, 0x2a, 0x1b, 0xba, 0xf0, 0x1f,
+	0x49, 0x99, 0x56, 0x32, 0x7f, 0x9b, 0xe8, 0x76, 0xa6, 0x49, 0xe7, 0x4f, 0xa5, 0xeb, 0x02, 0xc0,
+	0x9f, 0xc3, 0x22, 0xbf, 0xf5, 0x6a, 0xdb, 0xd3, 0x63, 0x2a, 0xbf, 0x3d, 0x29, 0x37, 0x58, 0x77,
+	0x69, 0xfe, 0x89, 0x71, 0xbe, 0x80, 0xc5, 0x7b, 0xcf, 0x1c, 0x71, 0xe9, 0x48, 0x9e, 0xa7, 0xb9,
+	0x3e, 0x1f, 0x45, 0x38, 0xef, 0xa0, 0x5b, 0x0e, 0xab, 0xa2, 0x03, 0x19, 0xcd, 0x42, 0x7e, 0x8b,
+	0x35, 0x6e, 0x48, 0xce, 0xc4, 0x01, 0xa9, 0xf3, 0x2b, 0xc8, 0xf7, 0x4d, 0x4e, 0xbf, 0xfc, 0x3d,
+	0xf4, 0x8c, 0x4e, 0x7c, 0xff, 0x49, 0x32, 0x80, 0xee, 0xce, 0x9b, 0xb7, 0xe3, 0xef, 0xbc, 0xc3,
+	0x93, 0xfd, 0x91, 0x25, 0x5e, 0x1e, 0x07, 0xbb, 0x7b, 0x47, 0xa7, 0x07, 0xa7, 0x67, 0x92, 0x33,
+	0xb7, 0xf5, 0x37, 0x68, 0xab, 0x49, 0x08, 0x7d, 0x0d, 0x7d, 0xf5, 0x75, 0xc2, 0x73, 0x82, 0x13,
+	0x34, 0x73, 0xb1, 0x57, 0x67, 0x38, 0x4e, 0xe3, 0x85, 0xf5, 0xca, 0x42, 0x9f, 0x43, 0xf3, 0x38,
+	0xa2, 0x21, 0xaa, 0xff, 0x1a, 0x58, 0xad, 0x93, 0x4e, 0x63, 0xe7, 0xcb, 0xbf, 0xae, 0x87, 0x11,
+	0x9f, 0x5e, 0x9f, 0x8b, 0x4e, 0xb3, 0x39, 0xbd, 0xcb, 0x48, 0xae, 0xde, 0x02, 0x9b, 0x17, 0xf8,
+	0x3c, 0x8f, 0xfc, 0x4d, 0xf9, 0x37, 0x8e, 0x6d, 0x2a, 0xb3, 0xf3, 0xb6, 0x24, 0xbf, 0xfa, 0xff,
+	0x00, 0x06, 0x09, 0xca, 0x84, 0xd5, 0x13, 0x00, 0x00,
 }
@@ -34,7 +34,7 @@ func (m *KVRWSet) Reset()         { *m = KVRWSet{} }
 func (m *KVRWSet) String() string { return proto.CompactTextString(m) }
 func (*KVRWSet) ProtoMessage()    {}
 func (*KVRWSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{0}
+	return fileDescriptor_kv_rwset_41b04b259ab96793, []int{0}
 }
 func (m *KVRWSet) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVRWSet.Unmarshal(m, b)
@@ -96,7 +96,7 @@ func (m *HashedRWSet) Reset()         { *m = HashedRWSet{} }
 func (m *HashedRWSet) String() string { return proto.CompactTextString(m) }
 func (*HashedRWSet) ProtoMessage()    {}
 func (*HashedRWSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{1}
+	return fileDescriptor_kv_rwset_41b04b259ab96793, []int{1}
 }
 func (m *HashedRWSet) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HashedRWSet.Unmarshal(m, b)
@@ -151,7 +151,7 @@ func (m *KVRead) Reset()         { *m = KVRead{} }
 func (m *KVRead) String() string { return proto.CompactTextString(m) }
 func (*KVRead) ProtoMessage()    {}
 func (*KVRead) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{2}
+	return fileDescriptor_kv_rwset_41b04b259ab96793, []int{2}
 }
 func (m *KVRead) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVRead.Unmarshal(m, b)
@@ -199,7 +199,7 @@ func (m *KVWrite) Reset()         { *m = KVWrite{} }
 func (m *KVWrite) String() string { return proto.CompactTextString(m) }
 func (*KVWrite) ProtoMessage()    {}
 func (*KVWrite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{3}
+	return fileDescriptor_kv_rwset_41b04b259ab96793, []int{3}
 }
 func (m *KVWrite) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVWrite.Unmarshal(m, b)
@@ -253,7 +253,7 @@ func (m *KVMetadataWrite) Reset()         { *m = KVMetadataWrite{} }
 func (m *KVMetadataWrite) String() string { return proto.CompactTextString(m) }
 func (*KVMetadataWrite) ProtoMessage()    {}
 func (*KVMetadataWrite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{4}
+	return fileDescriptor_kv_rwset_41b04b259ab96793, []int{4}
 }
 func (m *KVMetadataWrite) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVMetadataWrite.Unmarshal(m, b)
@@ -302,7 +302,7 @@ func (m *KVReadHash) Reset()         { *m = KVReadHash{} }
 func (m *KVReadHash) String() string { return proto.CompactTextString(m) }
 func (*KVReadHash) ProtoMessage()    {}
 func (*KVReadHash) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{5}
+	return fileDescriptor_kv_rwset_41b04b259ab96793, []int{5}
 }
 func (m *KVReadHash) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVReadHash.Unmarshal(m, b)
@@ -350,7 +350,7 @@ func (m *KVWriteHash) Reset()         { *m = KVWriteHash{} }
 func (m *KVWriteHash) String() string { return proto.CompactTextString(m) }
 func (*KVWriteHash) ProtoMessage()    {}
 func (*KVWriteHash) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{6}
+	return fileDescriptor_kv_rwset_41b04b259ab96793, []int{6}
 }
 func (m *KVWriteHash) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVWriteHash.Unmarshal(m, b)
@@ -404,7 +404,7 @@ func (m *KVMetadataWriteHash) Reset()         { *m = KVMetadataWriteHash{} }
 func (m *KVMetadataWriteHash) String() string { return proto.CompactTextString(m) }
 func (*KVMetadataWriteHash) ProtoMessage()    {}
 func (*KVMetadataWriteHash) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{7}
+	return fileDescriptor_kv_rwset_41b04b259ab96793, []int{7}
 }
 func (m *KVMetadataWriteHash) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVMetadataWriteHash.Unmarshal(m, b)
@@ -451,7 +451,7 @@ func (m *KVMetadataEntry) Reset()         { *m = KVMetadataEntry{} }
 func (m *KVMetadataEntry) String() string { return proto.CompactTextString(m) }
 func (*KVMetadataEntry) ProtoMessage()    {}
 func (*KVMetadataEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{8}
+	return fileDescriptor_kv_rwset_41b04b259ab96793, []int{8}
 }
 func (m *KVMetadataEntry) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVMetadataEntry.Unmarshal(m, b)
@@ -501,7 +501,7 @@ func (m *Version) Reset()         { *m = Version{} }
 func (m *Version) String() string { return proto.CompactTextString(m) }
 func (*Version) ProtoMessage()    {}
 func (*Version) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{9}
+	return fileDescriptor_kv_rwset_41b04b259ab96793, []int{9}
 }
 func (m *Version) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Version.Unmarshal(m, b)
@@ -541,6 +541,9 @@ func (m *Version) GetTxNum() uint64 {
 // (in addition to regular checks for updates/deletes of the existing items).
 // readInfo field contains either the KVReads (for the items read by the range query) or a merkle-tree hash
 // if the KVReads exceeds a pre-configured numbers
+// reverse is set for a range query which iterated over the keys in the descending order, in which case
+// the reads are captured in that order and the start_key, instead of the end_key, is set to the last
+// key read if the iterator was not exhausted
 type RangeQueryInfo struct {
 	StartKey     string `protobuf:"bytes,1,opt,name=start_key,json=startKey" json:"start_key,omitempty"`
 	EndKey       string `protobuf:"bytes,2,opt,name=end_key,json=endKey" json:"end_key,omitempty"`
@@ -549,6 +552,7 @@ type RangeQueryInfo struct {
 	//	*RangeQueryInfo_RawReads
 	//	*RangeQueryInfo_ReadsMerkleHashes
 	ReadsInfo            isRangeQueryInfo_ReadsInfo `protobuf_oneof:"reads_info"`
+	Reverse              bool                       `protobuf:"varint,6,opt,name=reverse" json:"reverse,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
 	XXX_unrecognized     []byte                     `json:"-"`
 	XXX_sizecache        int32                      `json:"-"`
@@ -558,7 +562,7 @@ func (m *RangeQueryInfo) Reset()         { *m = RangeQueryInfo{} }
 func (m *RangeQueryInfo) String() string { return proto.CompactTextString(m) }
 func (*RangeQueryInfo) ProtoMessage()    {}
 func (*RangeQueryInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{10}
+	return fileDescriptor_kv_rwset_41b04b259ab96793, []int{10}
 }
 func (m *RangeQueryInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RangeQueryInfo.Unmarshal(m, b)
@@ -634,6 +638,13 @@ func (m *RangeQueryInfo) GetReadsMerkleHashes() *QueryReadsMerkleSummary {
 	return nil
 }
 
+func (m *RangeQueryInfo) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*RangeQueryInfo) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _RangeQueryInfo_OneofMarshaler, _RangeQueryInfo_OneofUnmarshaler, _RangeQueryInfo_OneofSizer, []interface{}{
@@ -720,7 +731,7 @@ func (m *QueryReads) Reset()         { *m = QueryReads{} }
 func (m *QueryReads) String() string { return proto.CompactTextString(m) }
 func (*QueryReads) ProtoMessage()    {}
 func (*QueryReads) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{11}
+	return fileDescriptor_kv_rwset_41b04b259ab96793, []int{11}
 }
 func (m *QueryReads) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryReads.Unmarshal(m, b)
@@ -765,7 +776,7 @@ func (m *QueryReadsMerkleSummary) Reset()         { *m = QueryReadsMerkleSummary
 func (m *QueryReadsMerkleSummary) String() string { return proto.CompactTextString(m) }
 func (*QueryReadsMerkleSummary) ProtoMessage()    {}
 func (*QueryReadsMerkleSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{12}
+	return fileDescriptor_kv_rwset_41b04b259ab96793, []int{12}
 }
 func (m *QueryReadsMerkleSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryReadsMerkleSummary.Unmarshal(m, b)
@@ -823,56 +834,56 @@ func init() {
 }
 
 func init() {
-	proto.RegisterFile("ledger/rwset/kvrwset/kv_rwset.proto", fileDescriptor_kv_rwset_b744a14a894993b5)
-}
-
-var fileDescriptor_kv_rwset_b744a14a894993b5 = []byte{
-	// 740 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xdf, 0x6b, 0xdb, 0x48,
-	0x10, 0x8e, 0x7f, 0xcb, 0x63, 0x3b, 0xf1, 0x6d, 0x72, 0x44, 0xc7, 0xdd, 0x81, 0x51, 0x38, 0x30,
-	0x79, 0xb0, 0xc1, 0x07, 0xc7, 0x85, 0xe3, 0x1e, 0x5a, 0xe2, 0x92, 0x92, 0x26, 0xd0, 0x0d, 0x24,
-	0xd0, 0x17, 0xb1, 0x8e, 0x26, 0xb6, 0xb0, 0x25, 0xa5, 0xab, 0x95, 0x6d, 0x3d, 0x95, 0xfe, 0x75,
-	0xfd, 0x47, 0xfa, 0x87, 0x94, 0x9d, 0x95, 0x63, 0xc5, 0x75, 0x0c, 0xed, 0x93, 0xb5, 0xf3, 0xcd,
-	0x37, 0x3b, 0xdf, 0x37, 0xde, 0x5d, 0x38, 0x99, 0xa1, 0x37, 0x46, 0xd9, 0x97, 0x8b, 0x18, 0x55,
-	0x7f, 0x3a, 0x5f, 0xfd, 0xba, 0xf4, 0xd1, 0x7b, 0x94, 0x91, 0x8a, 0x58, 0x2d, 0x8b, 0x3b, 0x5f,
-	0x0b, 0x50, 0xbb, 0xbc, 0xe5, 0x77, 0x37, 0xa8, 0xd8, 0x5f, 0x50, 0x91, 0x28, 0xbc, 0xd8, 0x2e,
-	0x74, 0x4a, 0xdd, 0xc6, 0xe0, 0xa0, 0x97, 0x25, 0xf5, 0x2e, 0x6f, 0x39, 0x0a, 0x8f, 0x1b, 0x94,
-	0x0d, 0x81, 0x49, 0x11, 0x8e, 0xd1, 0xfd, 0x98, 0xa0, 0xf4, 0x31, 0x76, 0xfd, 0xf0, 0x21, 0xb2,
-	0x8b, 0xc4, 0x39, 0x7e, 0xe2, 0x70, 0x9d, 0xf2, 0x3e, 0x41, 0x99, 0xbe, 0x0d, 0x1f, 0x22, 0xde,
-	0x96, 0xab, 0xb5, 0x8f, 0xb1, 0x8e, 0xb0, 0x2e, 0x54, 0x17, 0xd2, 0x57, 0x18, 0xdb, 0x25, 0xa2,
-	0xb6, 0x73, 0xdb, 0xdd, 0x69, 0x80, 0x67, 0x38, 0x7b, 0x05, 0x07, 0x01, 0x2a, 0xe1, 0x09, 0x25,
-	0xdc, 0x8c, 0x52, 0x26, 0x8a, 0x9d, 0xa3, 0x5c, 0x65, 0x19, 0x86, 0xba, 0x1f, 0xe4, 0x97, 0xb1,
-	0xf3, 0xa5, 0x00, 0x8d, 0x0b, 0x11, 0x4f, 0xd0, 0x33, 0x52, 0xff, 0x81, 0xe6, 0x84, 0x96, 0x6e,
-	0x5e, 0xf1, 0xe1, 0x86, 0x62, 0xcd, 0xe0, 0x0d, 0x93, 0xc8, 0x49, 0xfb, 0x19, 0xb4, 0x32, 0x5e,
-	0xd6, 0x88, 0x91, 0x7d, 0xb4, 0xd9, 0x3b, 0x31, 0xb3, 0x2d, 0x4c, 0x0b, 0x6c, 0xf8, 0xbd, 0x0a,
-	0x23, 0xfc, 0x8f, 0x97, 0x54, 0x50, 0x91, 0x4d, 0x25, 0x6f, 0xa0, 0x6a, 0x9a, 0x63, 0x6d, 0x28,
-	0x4d, 0x31, 0xb5, 0x0b, 0x9d, 0x42, 0xb7, 0xce, 0xf5, 0x27, 0x3b, 0x85, 0xda, 0x1c, 0x65, 0xec,
-	0x47, 0xa1, 0x5d, 0xec, 0x14, 0x9e, 0x79, 0x7a, 0x6b, 0xe2, 0x7c, 0x95, 0xe0, 0x5c, 0xeb, 0xb9,
-	0x53, 0xcd, 0x2d, 0x85, 0x7e, 0x87, 0xba, 0x1f, 0xbb, 0x1e, 0xce, 0x50, 0x21, 0x95, 0xb2, 0xb8,
-	0xe5, 0xc7, 0xe7, 0xb4, 0x66, 0x47, 0x50, 0x99, 0x8b, 0x59, 0x82, 0x76, 0xa9, 0x53, 0xe8, 0x36,
-	0xb9, 0x59, 0x38, 0x77, 0x70, 0xb0, 0xd1, 0xfe, 0x96, 0xba, 0x03, 0xa8, 0x61, 0xa8, 0xf4, 0x5f,
-	0x20, 0x33, 0x6e, 0xdb, 0x04, 0x87, 0xa1, 0x92, 0x29, 0x5f, 0x25, 0x3a, 0x37, 0x00, 0xeb, 0x69,
-	0xb0, 0xdf, 0xc0, 0x9a, 0x62, 0xea, 0x6a, 0x67, 0xa9, 0x70, 0x93, 0xd7, 0xa6, 0x98, 0x12, 0xf4,
-	0x23, 0xea, 0x3d, 0x68, 0xe4, 0x26, 0xb5, 0xab, 0xea, 0x4e, 0x2b, 0xfe, 0x04, 0x20, 0xf5, 0x86,
-	0x69, 0xfc, 0xa8, 0x53, 0x44, 0x73, 0x1d, 0x0f, 0x0e, 0xb7, 0x8c, 0x74, 0xd7, 0x6e, 0x3f, 0x63,
-	0xd0, 0x7f, 0x79, 0xe7, 0x09, 0x63, 0x0c, 0xca, 0xa1, 0x08, 0x30, 0xb3, 0x9e, 0xbe, 0xd7, 0x63,
-	0x2b, 0xe6, 0xc7, 0xf6, 0x3f, 0xd4, 0x32, 0x73, 0xb4, 0xd2, 0xd1, 0x2c, 0xba, 0x9f, 0xba, 0x61,
-	0x12, 0x10, 0xb3, 0xcc, 0x2d, 0x0a, 0x5c, 0x27, 0x01, 0xfb, 0x15, 0xaa, 0x6a, 0x49, 0x48, 0x91,
-	0x90, 0x8a, 0x5a, 0x5e, 0x27, 0x81, 0xf3, 0xb9, 0x08, 0xfb, 0xcf, 0x4f, 0xba, 0x2e, 0x13, 0x2b,
-	0x21, 0x95, 0xbb, 0x9e, 0xbd, 0x45, 0x81, 0x4b, 0x4c, 0xd9, 0xb1, 0xd6, 0xe7, 0x11, 0x54, 0x24,
-	0xa8, 0x8a, 0xa1, 0xa7, 0x81, 0x13, 0x68, 0xf9, 0x4a, 0xba, 0xb8, 0x9c, 0x88, 0x24, 0x56, 0xe8,
-	0x91, 0x99, 0x16, 0x6f, 0xfa, 0x4a, 0x0e, 0x57, 0x31, 0x36, 0x80, 0xba, 0x14, 0x8b, 0xec, 0xc8,
-	0x96, 0x69, 0xc6, 0xeb, 0x23, 0x4b, 0x1d, 0xd0, 0x29, 0xbd, 0xd8, 0xe3, 0x96, 0x14, 0x0b, 0x73,
-	0x62, 0x39, 0x1c, 0x52, 0xbe, 0x1b, 0xa0, 0x9c, 0xce, 0xcc, 0xa4, 0x30, 0xb6, 0x2b, 0xc4, 0xee,
-	0x6c, 0x61, 0x5f, 0x51, 0xde, 0x4d, 0x12, 0x04, 0x42, 0xa6, 0x17, 0x7b, 0xfc, 0x17, 0xb9, 0x8e,
-	0xd2, 0x15, 0x12, 0xbf, 0x6e, 0x02, 0x98, 0x9a, 0xfa, 0xe6, 0x73, 0xfe, 0x05, 0x58, 0xb3, 0xd9,
-	0x29, 0x58, 0xfa, 0xae, 0xdd, 0x75, 0x8f, 0xd6, 0xa6, 0x73, 0xca, 0x75, 0x3e, 0xc1, 0xf1, 0x0b,
-	0xfb, 0xea, 0x7f, 0x56, 0x20, 0x96, 0xae, 0x87, 0x63, 0x89, 0x66, 0x8e, 0x2d, 0x5e, 0x0f, 0xc4,
-	0xf2, 0x9c, 0x02, 0xda, 0x64, 0x0d, 0xcf, 0x70, 0x8e, 0x33, 0x72, 0xb2, 0xc5, 0xad, 0x40, 0x2c,
-	0xdf, 0xe9, 0x35, 0xeb, 0x42, 0xfb, 0x09, 0x5c, 0xe9, 0xd5, 0x57, 0x4d, 0x93, 0xef, 0xaf, 0x72,
-	0x32, 0x21, 0x11, 0x0c, 0x22, 0x39, 0xee, 0x4d, 0xd2, 0x47, 0x94, 0xe6, 0xd9, 0xe8, 0x3d, 0x88,
-	0x91, 0xf4, 0xef, 0xcd, 0x33, 0x11, 0xf7, 0xb2, 0xa0, 0x69, 0x3f, 0x93, 0xf1, 0xe1, 0x6c, 0xec,
-	0xab, 0x49, 0x32, 0xea, 0xdd, 0x47, 0x41, 0x3f, 0x47, 0xed, 0x1b, 0x6a, 0xdf, 0x50, 0xfb, 0xdb,
-	0x9e, 0xa1, 0x51, 0x95, 0xc0, 0xbf, 0xbf, 0x05, 0x00, 0x00, 0xff, 0xff, 0xe3, 0xe1, 0xb5, 0x07,
-	0xa5, 0x06, 0x00, 0x00,
+	proto.RegisterFile("ledger/rwset/kvrwset/kv_rwset.proto", fileDescriptor_kv_rwset_41b04b259ab96793)
+}
+
+var fileDescriptor_kv_rwset_41b04b259ab96793 = []byte{
+	// 750 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xdd, 0x6a, 0xeb, 0x46,
+	0x10, 0x3e, 0xf2, 0x9f, 0xe4, 0xb1, 0x1d, 0xbb, 0x9b, 0x53, 0xa2, 0xd2, 0x16, 0x8c, 0x0e, 0x05,
+	0x73, 0x2e, 0x6c, 0x70, 0xa1, 0x34, 0x94, 0x5e, 0x34, 0xc4, 0x25, 0x25, 0x4d, 0xa0, 0x1b, 0x48,
+	0xa0, 0x37, 0x62, 0x1d, 0x4d, 0x6c, 0x61, 0x4b, 0x4a, 0x57, 0x2b, 0xdb, 0xba, 0xea, 0x83, 0xf4,
+	0x81, 0xfa, 0x22, 0x7d, 0x90, 0xb2, 0xb3, 0x52, 0xec, 0xb8, 0x8e, 0xa1, 0xe7, 0x4a, 0x3b, 0xf3,
+	0xcd, 0x37, 0x3b, 0xdf, 0x8c, 0x76, 0x17, 0x3e, 0x2c, 0x31, 0x98, 0xa1, 0x1c, 0xc9, 0x75, 0x8a,
+	0x6a, 0xb4, 0x58, 0x95, 0x5f, 0x9f, 0x16, 0xc3, 0x67, 0x99, 0xa8, 0x84, 0xd9, 0x85, 0xdf, 0xfb,
+	0xc7, 0x02, 0xfb, 0xfa, 0x9e, 0x3f, 0xdc, 0xa1, 0x62, 0xdf, 0x40, 0x5d, 0xa2, 0x08, 0x52, 0xd7,
+	0xea, 0x57, 0x07, 0xad, 0x71, 0x77, 0x58, 0x04, 0x0d, 0xaf, 0xef, 0x39, 0x8a, 0x80, 0x1b, 0x94,
+	0x4d, 0x80, 0x49, 0x11, 0xcf, 0xd0, 0xff, 0x23, 0x43, 0x19, 0x62, 0xea, 0x87, 0xf1, 0x53, 0xe2,
+	0x56, 0x88, 0x73, 0xf6, 0xc2, 0xe1, 0x3a, 0xe4, 0xb7, 0x0c, 0x65, 0xfe, 0x4b, 0xfc, 0x94, 0xf0,
+	0x9e, 0x2c, 0xed, 0x10, 0x53, 0xed, 0x61, 0x03, 0x68, 0xac, 0x65, 0xa8, 0x30, 0x75, 0xab, 0x44,
+	0xed, 0xed, 0x6c, 0xf7, 0xa0, 0x01, 0x5e, 0xe0, 0xec, 0x27, 0xe8, 0x46, 0xa8, 0x44, 0x20, 0x94,
+	0xf0, 0x0b, 0x4a, 0x8d, 0x28, 0xee, 0x0e, 0xe5, 0xa6, 0x88, 0x30, 0xd4, 0x93, 0x68, 0xd7, 0x4c,
+	0xbd, 0xbf, 0x2d, 0x68, 0x5d, 0x89, 0x74, 0x8e, 0x81, 0x91, 0xfa, 0x1d, 0xb4, 0xe7, 0x64, 0xfa,
+	0xbb, 0x8a, 0x4f, 0xf7, 0x14, 0x6b, 0x06, 0x6f, 0x99, 0x40, 0x4e, 0xda, 0xcf, 0xa1, 0x53, 0xf0,
+	0x8a, 0x42, 0x8c, 0xec, 0xf7, 0xfb, 0xb5, 0x13, 0xb3, 0xd8, 0xc2, 0x94, 0xc0, 0x26, 0xff, 0x55,
+	0x61, 0x84, 0x7f, 0xf5, 0x96, 0x0a, 0x4a, 0xb2, 0xaf, 0xe4, 0x67, 0x68, 0x98, 0xe2, 0x58, 0x0f,
+	0xaa, 0x0b, 0xcc, 0x5d, 0xab, 0x6f, 0x0d, 0x9a, 0x5c, 0x2f, 0xd9, 0x47, 0xb0, 0x57, 0x28, 0xd3,
+	0x30, 0x89, 0xdd, 0x4a, 0xdf, 0x7a, 0xd5, 0xd3, 0x7b, 0xe3, 0xe7, 0x65, 0x80, 0x77, 0xab, 0xe7,
+	0x4e, 0x39, 0x0f, 0x24, 0xfa, 0x12, 0x9a, 0x61, 0xea, 0x07, 0xb8, 0x44, 0x85, 0x94, 0xca, 0xe1,
+	0x4e, 0x98, 0x5e, 0x92, 0xcd, 0xde, 0x43, 0x7d, 0x25, 0x96, 0x19, 0xba, 0xd5, 0xbe, 0x35, 0x68,
+	0x73, 0x63, 0x78, 0x0f, 0xd0, 0xdd, 0x2b, 0xff, 0x40, 0xde, 0x31, 0xd8, 0x18, 0x2b, 0x19, 0xbe,
+	0x34, 0xee, 0xd0, 0x04, 0x27, 0xb1, 0x92, 0x39, 0x2f, 0x03, 0xbd, 0x3b, 0x80, 0xed, 0x34, 0xd8,
+	0x17, 0xe0, 0x2c, 0x30, 0xf7, 0x75, 0x67, 0x29, 0x71, 0x9b, 0xdb, 0x0b, 0xcc, 0x09, 0xfa, 0x3f,
+	0xea, 0x03, 0x68, 0xed, 0x4c, 0xea, 0x58, 0xd6, 0xa3, 0xad, 0xf8, 0x1a, 0x80, 0xd4, 0x1b, 0xa6,
+	0xe9, 0x47, 0x93, 0x3c, 0x9a, 0xeb, 0x05, 0x70, 0x7a, 0x60, 0xa4, 0xc7, 0x76, 0xfb, 0x94, 0x06,
+	0xfd, 0x00, 0xdd, 0x3d, 0x8c, 0x31, 0xa8, 0xc5, 0x22, 0xc2, 0xa2, 0xf5, 0xb4, 0xde, 0x8e, 0xad,
+	0xb2, 0x3b, 0xb6, 0x1f, 0xc1, 0x2e, 0x9a, 0xa3, 0x95, 0x4e, 0x97, 0xc9, 0xe3, 0xc2, 0x8f, 0xb3,
+	0x88, 0x98, 0x35, 0xee, 0x90, 0xe3, 0x36, 0x8b, 0xd8, 0xe7, 0xd0, 0x50, 0x1b, 0x42, 0x2a, 0x84,
+	0xd4, 0xd5, 0xe6, 0x36, 0x8b, 0xbc, 0xbf, 0x2a, 0x70, 0xf2, 0xfa, 0xa4, 0xeb, 0x34, 0xa9, 0x12,
+	0x52, 0xf9, 0xdb, 0xd9, 0x3b, 0xe4, 0xb8, 0xc6, 0x9c, 0x9d, 0x69, 0x7d, 0x01, 0x41, 0x15, 0x82,
+	0x1a, 0x18, 0x07, 0x1a, 0xf8, 0x00, 0x9d, 0x50, 0x49, 0x1f, 0x37, 0x73, 0x91, 0xa5, 0x0a, 0x03,
+	0x6a, 0xa6, 0xc3, 0xdb, 0xa1, 0x92, 0x93, 0xd2, 0xc7, 0xc6, 0xd0, 0x94, 0x62, 0x5d, 0x1c, 0xd9,
+	0x5a, 0xdf, 0x7a, 0x75, 0x64, 0xa9, 0x02, 0x3a, 0xa5, 0x57, 0xef, 0xb8, 0x23, 0xc5, 0x9a, 0xd6,
+	0x8c, 0xc3, 0x29, 0xc5, 0xfb, 0x11, 0xca, 0xc5, 0xd2, 0x4c, 0x0a, 0x53, 0xb7, 0x4e, 0xec, 0xfe,
+	0x01, 0xf6, 0x0d, 0xc5, 0xdd, 0x65, 0x51, 0x24, 0x64, 0x7e, 0xf5, 0x8e, 0x7f, 0x26, 0xb7, 0x5e,
+	0xba, 0x42, 0x52, 0xe6, 0x82, 0x2d, 0x51, 0xff, 0x4a, 0xe8, 0x36, 0xa8, 0xcc, 0xd2, 0xbc, 0x68,
+	0x03, 0x98, 0xdd, 0xf4, 0x9d, 0xe8, 0x7d, 0x0f, 0xb0, 0xcd, 0xcb, 0x3e, 0x82, 0xa3, 0x6f, 0xe1,
+	0x63, 0x37, 0xac, 0xbd, 0x58, 0x51, 0xac, 0xf7, 0x27, 0x9c, 0xbd, 0x51, 0x91, 0xfe, 0xe7, 0x22,
+	0xb1, 0xf1, 0x03, 0x9c, 0x49, 0x34, 0x13, 0xee, 0xf0, 0x66, 0x24, 0x36, 0x97, 0xe4, 0xd0, 0xed,
+	0xd7, 0xf0, 0x12, 0x57, 0xb8, 0xa4, 0x1e, 0x77, 0xb8, 0x13, 0x89, 0xcd, 0xaf, 0xda, 0x66, 0x03,
+	0xe8, 0xbd, 0x80, 0x65, 0x27, 0xf4, 0x25, 0xd4, 0xe6, 0x27, 0x65, 0x8c, 0x91, 0x78, 0x91, 0xc0,
+	0x38, 0x91, 0xb3, 0xe1, 0x3c, 0x7f, 0x46, 0x69, 0x1e, 0x94, 0xe1, 0x93, 0x98, 0xca, 0xf0, 0xd1,
+	0x3c, 0x20, 0xe9, 0xb0, 0x70, 0x9a, 0xf2, 0x0b, 0x19, 0xbf, 0x9f, 0xcf, 0x42, 0x35, 0xcf, 0xa6,
+	0xc3, 0xc7, 0x24, 0x1a, 0xed, 0x50, 0x47, 0x86, 0x3a, 0x32, 0xd4, 0xd1, 0xa1, 0x07, 0x6a, 0xda,
+	0x20, 0xf0, 0xdb, 0x7f, 0x07, 0x00, 0x1e, 0x5e, 0xe9, 0x1a, 0xbf, 0x06, 0x00, 0x00,
 }
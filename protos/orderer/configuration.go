@@ -142,6 +142,8 @@ func (doocv *DynamicOrdererOrgConfigValue) VariablyOpaqueFieldProto(name string)
 	switch doocv.name {
 	case "MSP":
 		return &msp.MSPConfig{}, nil
+	case "PeerEndpoints":
+		return &common.PeerEndpoints{}, nil
 	default:
 		return nil, fmt.Errorf("unknown Orderer Org ConfigValue name: %s", doocv.name)
 	}
@@ -18,20 +18,46 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// State defines the orderer mode of operation, typically for consensus type migration.
+type ConsensusType_State int32
+
+const (
+	ConsensusType_STATE_NORMAL      ConsensusType_State = 0
+	ConsensusType_STATE_MAINTENANCE ConsensusType_State = 1
+)
+
+var ConsensusType_State_name = map[int32]string{
+	0: "STATE_NORMAL",
+	1: "STATE_MAINTENANCE",
+}
+var ConsensusType_State_value = map[string]int32{
+	"STATE_NORMAL":      0,
+	"STATE_MAINTENANCE": 1,
+}
+
+func (x ConsensusType_State) String() string {
+	return proto.EnumName(ConsensusType_State_name, int32(x))
+}
+func (ConsensusType_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_8a5140130a5876e9, []int{0, 0}
+}
+
 type ConsensusType struct {
 	Type string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
 	// Opaque metadata, dependent on the consensus type.
-	Metadata             []byte   `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Metadata []byte `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// The state of the channel, which must be in maintenance for its consensus type to change.
+	State                ConsensusType_State `protobuf:"varint,3,opt,name=state,enum=orderer.ConsensusType_State" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *ConsensusType) Reset()         { *m = ConsensusType{} }
 func (m *ConsensusType) String() string { return proto.CompactTextString(m) }
 func (*ConsensusType) ProtoMessage()    {}
 func (*ConsensusType) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_3289caae61166f4d, []int{0}
+	return fileDescriptor_configuration_8a5140130a5876e9, []int{0}
 }
 func (m *ConsensusType) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConsensusType.Unmarshal(m, b)
@@ -65,6 +91,13 @@ func (m *ConsensusType) GetMetadata() []byte {
 	return nil
 }
 
+func (m *ConsensusType) GetState() ConsensusType_State {
+	if m != nil {
+		return m.State
+	}
+	return ConsensusType_STATE_NORMAL
+}
+
 type BatchSize struct {
 	// Simply specified as number of messages for now, in the future
 	// we may want to allow this to be specified by size in bytes
@@ -74,17 +107,21 @@ type BatchSize struct {
 	AbsoluteMaxBytes uint32 `protobuf:"varint,2,opt,name=absolute_max_bytes,json=absoluteMaxBytes" json:"absolute_max_bytes,omitempty"`
 	// The byte count of the serialized messages in a batch should not
 	// exceed this value.
-	PreferredMaxBytes    uint32   `protobuf:"varint,3,opt,name=preferred_max_bytes,json=preferredMaxBytes" json:"preferred_max_bytes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PreferredMaxBytes uint32 `protobuf:"varint,3,opt,name=preferred_max_bytes,json=preferredMaxBytes" json:"preferred_max_bytes,omitempty"`
+	// The maximum number of transactions of higher priority received after
+	// a transaction which may be ordered ahead of it within its batch. The
+	// priorities of the transactions are ignored if 0, which is the default.
+	PriorityReorderingLimit uint32   `protobuf:"varint,4,opt,name=priority_reordering_limit,json=priorityReorderingLimit" json:"priority_reordering_limit,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
 }
 
 func (m *BatchSize) Reset()         { *m = BatchSize{} }
 func (m *BatchSize) String() string { return proto.CompactTextString(m) }
 func (*BatchSize) ProtoMessage()    {}
 func (*BatchSize) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_3289caae61166f4d, []int{1}
+	return fileDescriptor_configuration_8a5140130a5876e9, []int{1}
 }
 func (m *BatchSize) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BatchSize.Unmarshal(m, b)
@@ -125,6 +162,13 @@ func (m *BatchSize) GetPreferredMaxBytes() uint32 {
 	return 0
 }
 
+func (m *BatchSize) GetPriorityReorderingLimit() uint32 {
+	if m != nil {
+		return m.PriorityReorderingLimit
+	}
+	return 0
+}
+
 type BatchTimeout struct {
 	// Any duration string parseable by ParseDuration():
 	// https://golang.org/pkg/time/#ParseDuration
@@ -138,7 +182,7 @@ func (m *BatchTimeout) Reset()         { *m = BatchTimeout{} }
 func (m *BatchTimeout) String() string { return proto.CompactTextString(m) }
 func (*BatchTimeout) ProtoMessage()    {}
 func (*BatchTimeout) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_3289caae61166f4d, []int{2}
+	return fileDescriptor_configuration_8a5140130a5876e9, []int{2}
 }
 func (m *BatchTimeout) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BatchTimeout.Unmarshal(m, b)
@@ -180,7 +224,7 @@ func (m *KafkaBrokers) Reset()         { *m = KafkaBrokers{} }
 func (m *KafkaBrokers) String() string { return proto.CompactTextString(m) }
 func (*KafkaBrokers) ProtoMessage()    {}
 func (*KafkaBrokers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_3289caae61166f4d, []int{3}
+	return fileDescriptor_configuration_8a5140130a5876e9, []int{3}
 }
 func (m *KafkaBrokers) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KafkaBrokers.Unmarshal(m, b)
@@ -219,7 +263,7 @@ func (m *ChannelRestrictions) Reset()         { *m = ChannelRestrictions{} }
 func (m *ChannelRestrictions) String() string { return proto.CompactTextString(m) }
 func (*ChannelRestrictions) ProtoMessage()    {}
 func (*ChannelRestrictions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_3289caae61166f4d, []int{4}
+	return fileDescriptor_configuration_8a5140130a5876e9, []int{4}
 }
 func (m *ChannelRestrictions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChannelRestrictions.Unmarshal(m, b)
@@ -252,33 +296,40 @@ func init() {
 	proto.RegisterType((*BatchTimeout)(nil), "orderer.BatchTimeout")
 	proto.RegisterType((*KafkaBrokers)(nil), "orderer.KafkaBrokers")
 	proto.RegisterType((*ChannelRestrictions)(nil), "orderer.ChannelRestrictions")
+	proto.RegisterEnum("orderer.ConsensusType_State", ConsensusType_State_name, ConsensusType_State_value)
 }
 
 func init() {
-	proto.RegisterFile("orderer/configuration.proto", fileDescriptor_configuration_3289caae61166f4d)
-}
-
-var fileDescriptor_configuration_3289caae61166f4d = []byte{
-	// 333 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x91, 0x4f, 0x6b, 0xf2, 0x40,
-	0x10, 0xc6, 0xc9, 0xab, 0xbc, 0xea, 0xa2, 0xbc, 0xaf, 0xeb, 0x25, 0xd4, 0x8b, 0x04, 0x0a, 0x52,
-	0x24, 0x81, 0xf6, 0x03, 0x14, 0xe2, 0xb1, 0x78, 0x49, 0xed, 0xa5, 0x17, 0x99, 0x24, 0x93, 0x3f,
-	0x68, 0x76, 0xc3, 0xec, 0x06, 0x92, 0x7e, 0x8f, 0x7e, 0xdf, 0xb2, 0x9b, 0x68, 0xbd, 0xcd, 0x33,
-	0xcf, 0x6f, 0x87, 0x79, 0x76, 0xd8, 0x5a, 0x52, 0x8a, 0x84, 0x14, 0x24, 0x52, 0x64, 0x65, 0xde,
-	0x10, 0xe8, 0x52, 0x0a, 0xbf, 0x26, 0xa9, 0x25, 0x9f, 0x0c, 0xa6, 0xf7, 0xca, 0x16, 0x7b, 0x29,
-	0x14, 0x0a, 0xd5, 0xa8, 0x63, 0x57, 0x23, 0xe7, 0x6c, 0xac, 0xbb, 0x1a, 0x5d, 0x67, 0xe3, 0x6c,
-	0x67, 0x91, 0xad, 0xf9, 0x03, 0x9b, 0x56, 0xa8, 0x21, 0x05, 0x0d, 0xee, 0x9f, 0x8d, 0xb3, 0x9d,
-	0x47, 0x37, 0xed, 0x7d, 0x3b, 0x6c, 0x16, 0x82, 0x4e, 0x8a, 0xf7, 0xf2, 0x0b, 0xf9, 0x13, 0x5b,
-	0x56, 0xd0, 0x9e, 0x2a, 0x54, 0x0a, 0x72, 0x3c, 0x25, 0xb2, 0x11, 0xda, 0x8e, 0x5a, 0x44, 0xff,
-	0x2a, 0x68, 0x0f, 0x7d, 0x7f, 0x6f, 0xda, 0x7c, 0xc7, 0x38, 0xc4, 0x4a, 0x5e, 0x1a, 0x8d, 0x27,
-	0xf3, 0x28, 0xee, 0x34, 0x2a, 0x3b, 0x7f, 0x11, 0xfd, 0xbf, 0x3a, 0x07, 0x68, 0x43, 0xd3, 0xe7,
-	0x3e, 0x5b, 0xd5, 0x84, 0x19, 0x12, 0x61, 0x7a, 0x87, 0x8f, 0x2c, 0xbe, 0xbc, 0x59, 0x57, 0xde,
-	0xdb, 0xb2, 0xb9, 0x5d, 0xeb, 0x58, 0x56, 0x28, 0x1b, 0xcd, 0x5d, 0x36, 0xd1, 0x7d, 0x39, 0x44,
-	0xbb, 0x4a, 0x43, 0xbe, 0x41, 0x76, 0x86, 0x90, 0xe4, 0x19, 0x49, 0x19, 0x32, 0xee, 0x4b, 0xd7,
-	0xd9, 0x8c, 0x0c, 0x39, 0x48, 0xef, 0x99, 0xad, 0xf6, 0x05, 0x08, 0x81, 0x97, 0x08, 0x95, 0xa6,
-	0x32, 0x31, 0x3f, 0xaa, 0xf8, 0x9a, 0xcd, 0xcc, 0x42, 0xbf, 0x61, 0xc7, 0xd1, 0xb4, 0x82, 0xd6,
-	0xa6, 0x0c, 0x3f, 0xd8, 0xa3, 0xa4, 0xdc, 0x2f, 0xba, 0x1a, 0xe9, 0x82, 0x69, 0x8e, 0xe4, 0x67,
-	0x10, 0x53, 0x99, 0xf4, 0x97, 0x50, 0xfe, 0x70, 0x89, 0xcf, 0x5d, 0x5e, 0xea, 0xa2, 0x89, 0xfd,
-	0x44, 0x56, 0xc1, 0x1d, 0x1d, 0xf4, 0x74, 0xd0, 0xd3, 0xc1, 0x40, 0xc7, 0x7f, 0xad, 0x7e, 0xf9,
-	0x09, 0x00, 0x00, 0xff, 0xff, 0xb5, 0x9c, 0xb6, 0xa5, 0xe6, 0x01, 0x00, 0x00,
+	proto.RegisterFile("orderer/configuration.proto", fileDescriptor_configuration_8a5140130a5876e9)
+}
+
+var fileDescriptor_configuration_8a5140130a5876e9 = []byte{
+	// 430 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x92, 0x51, 0x8f, 0xd2, 0x40,
+	0x14, 0x85, 0xad, 0xb0, 0xee, 0x72, 0x03, 0x0a, 0xb3, 0x31, 0x56, 0xd7, 0x07, 0x42, 0x62, 0x42,
+	0xcc, 0xa6, 0x35, 0xf8, 0xe6, 0x1b, 0x10, 0x1e, 0x8c, 0x0b, 0x26, 0x43, 0x7d, 0xf1, 0xa5, 0x99,
+	0x96, 0x4b, 0x99, 0x2c, 0xed, 0x34, 0x77, 0xa6, 0x09, 0xf5, 0xff, 0xf8, 0x83, 0xfc, 0x47, 0x66,
+	0xa6, 0x05, 0xd7, 0xb7, 0x7b, 0xce, 0xf9, 0x66, 0xd2, 0x73, 0xa7, 0x70, 0xa7, 0x68, 0x87, 0x84,
+	0x14, 0xa6, 0xaa, 0xd8, 0xcb, 0xac, 0x22, 0x61, 0xa4, 0x2a, 0x82, 0x92, 0x94, 0x51, 0xec, 0xba,
+	0x0d, 0x27, 0xbf, 0x3d, 0x18, 0x2c, 0x55, 0xa1, 0xb1, 0xd0, 0x95, 0x8e, 0xea, 0x12, 0x19, 0x83,
+	0xae, 0xa9, 0x4b, 0xf4, 0xbd, 0xb1, 0x37, 0xed, 0x71, 0x37, 0xb3, 0x77, 0x70, 0x93, 0xa3, 0x11,
+	0x3b, 0x61, 0x84, 0xff, 0x7c, 0xec, 0x4d, 0xfb, 0xfc, 0xa2, 0xd9, 0x0c, 0xae, 0xb4, 0x11, 0x06,
+	0xfd, 0xce, 0xd8, 0x9b, 0xbe, 0x9c, 0xbd, 0x0f, 0xda, 0xab, 0x83, 0xff, 0xae, 0x0d, 0xb6, 0x96,
+	0xe1, 0x0d, 0x3a, 0xf9, 0x04, 0x57, 0x4e, 0xb3, 0x21, 0xf4, 0xb7, 0xd1, 0x3c, 0x5a, 0xc5, 0x9b,
+	0xef, 0x7c, 0x3d, 0x7f, 0x18, 0x3e, 0x63, 0xaf, 0x61, 0xd4, 0x38, 0xeb, 0xf9, 0xd7, 0x4d, 0xb4,
+	0xda, 0xcc, 0x37, 0xcb, 0xd5, 0xd0, 0x9b, 0xfc, 0xf1, 0xa0, 0xb7, 0x10, 0x26, 0x3d, 0x6c, 0xe5,
+	0x2f, 0x64, 0x1f, 0x61, 0x94, 0x8b, 0x53, 0x9c, 0xa3, 0xd6, 0x22, 0xc3, 0x38, 0x55, 0x55, 0x61,
+	0xdc, 0x07, 0x0f, 0xf8, 0xab, 0x5c, 0x9c, 0xd6, 0x8d, 0xbf, 0xb4, 0x36, 0xbb, 0x07, 0x26, 0x12,
+	0xad, 0x8e, 0x95, 0xc1, 0xd8, 0x1e, 0x4a, 0x6a, 0x83, 0xda, 0xb5, 0x18, 0xf0, 0xe1, 0x39, 0x59,
+	0x8b, 0xd3, 0xc2, 0xfa, 0x2c, 0x80, 0xdb, 0x92, 0x70, 0x8f, 0x44, 0xb8, 0x7b, 0x82, 0x77, 0x1c,
+	0x3e, 0xba, 0x44, 0x17, 0xfe, 0x0b, 0xbc, 0x2d, 0x49, 0x2a, 0x92, 0xa6, 0x8e, 0x09, 0x5d, 0x75,
+	0x59, 0x64, 0xf1, 0x51, 0xe6, 0xd2, 0xf8, 0x5d, 0x77, 0xea, 0xcd, 0x19, 0xe0, 0x97, 0xfc, 0xc1,
+	0xc6, 0x93, 0x29, 0xf4, 0x5d, 0xa5, 0x48, 0xe6, 0xa8, 0x2a, 0xc3, 0x7c, 0xb8, 0x36, 0xcd, 0xd8,
+	0x2e, 0xff, 0x2c, 0x2d, 0xf9, 0x4d, 0xec, 0x1f, 0xc5, 0x82, 0xd4, 0x23, 0x92, 0xb6, 0x64, 0xd2,
+	0x8c, 0xbe, 0x37, 0xee, 0x58, 0xb2, 0x95, 0x93, 0x19, 0xdc, 0x2e, 0x0f, 0xa2, 0x28, 0xf0, 0xc8,
+	0x51, 0x1b, 0x92, 0xa9, 0x7d, 0x74, 0xcd, 0xee, 0xa0, 0x67, 0xcb, 0xfc, 0x5b, 0x54, 0x97, 0xdf,
+	0xe4, 0xe2, 0xe4, 0x36, 0xb4, 0xf8, 0x01, 0x1f, 0x14, 0x65, 0xc1, 0xa1, 0x2e, 0x91, 0x8e, 0xb8,
+	0xcb, 0x90, 0x82, 0xbd, 0x48, 0x48, 0xa6, 0xcd, 0xcf, 0xa2, 0xcf, 0x2f, 0xfa, 0xf3, 0x3e, 0x93,
+	0xe6, 0x50, 0x25, 0x41, 0xaa, 0xf2, 0xf0, 0x09, 0x1d, 0x36, 0x74, 0xd8, 0xd0, 0x61, 0x4b, 0x27,
+	0x2f, 0x9c, 0xfe, 0xfc, 0x77, 0x00, 0xc5, 0xf2, 0xf0, 0xac, 0x89, 0x02, 0x00, 0x00,
 }
@@ -7,6 +7,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import empty "github.com/golang/protobuf/ptypes/empty"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
 import common "github.com/hyperledger/fabric/protos/common"
 
 import (
@@ -57,21 +58,23 @@ func (x ServerStatus_StatusCode) String() string {
 	return proto.EnumName(ServerStatus_StatusCode_name, int32(x))
 }
 func (ServerStatus_StatusCode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_admin_6c8e47987088ed1f, []int{0, 0}
+	return fileDescriptor_admin_f9a2a11f1fc4c582, []int{0, 0}
 }
 
 type ServerStatus struct {
-	Status               ServerStatus_StatusCode `protobuf:"varint,1,opt,name=status,enum=protos.ServerStatus_StatusCode" json:"status,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	Status ServerStatus_StatusCode `protobuf:"varint,1,opt,name=status,enum=protos.ServerStatus_StatusCode" json:"status,omitempty"`
+	// stalled_channels lists the channels the peer stopped processing
+	StalledChannels      []*StalledChannel `protobuf:"bytes,2,rep,name=stalled_channels,json=stalledChannels" json:"stalled_channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ServerStatus) Reset()         { *m = ServerStatus{} }
 func (m *ServerStatus) String() string { return proto.CompactTextString(m) }
 func (*ServerStatus) ProtoMessage()    {}
 func (*ServerStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_6c8e47987088ed1f, []int{0}
+	return fileDescriptor_admin_f9a2a11f1fc4c582, []int{0}
 }
 func (m *ServerStatus) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ServerStatus.Unmarshal(m, b)
@@ -98,6 +101,62 @@ func (m *ServerStatus) GetStatus() ServerStatus_StatusCode {
 	return ServerStatus_UNDEFINED
 }
 
+func (m *ServerStatus) GetStalledChannels() []*StalledChannel {
+	if m != nil {
+		return m.StalledChannels
+	}
+	return nil
+}
+
+// StalledChannel is a channel the peer stopped processing, as it cannot
+// process its blocks any further, e.g. because the channel config requires
+// capabilities the peer does not support
+type StalledChannel struct {
+	ChannelId            string   `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StalledChannel) Reset()         { *m = StalledChannel{} }
+func (m *StalledChannel) String() string { return proto.CompactTextString(m) }
+func (*StalledChannel) ProtoMessage()    {}
+func (*StalledChannel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_f9a2a11f1fc4c582, []int{1}
+}
+func (m *StalledChannel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StalledChannel.Unmarshal(m, b)
+}
+func (m *StalledChannel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StalledChannel.Marshal(b, m, deterministic)
+}
+func (dst *StalledChannel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StalledChannel.Merge(dst, src)
+}
+func (m *StalledChannel) XXX_Size() int {
+	return xxx_messageInfo_StalledChannel.Size(m)
+}
+func (m *StalledChannel) XXX_DiscardUnknown() {
+	xxx_messageInfo_StalledChannel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StalledChannel proto.InternalMessageInfo
+
+func (m *StalledChannel) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *StalledChannel) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 type LogLevelRequest struct {
 	LogModule            string   `protobuf:"bytes,1,opt,name=log_module,json=logModule" json:"log_module,omitempty"`
 	LogLevel             string   `protobuf:"bytes,2,opt,name=log_level,json=logLevel" json:"log_level,omitempty"`
@@ -110,7 +169,7 @@ func (m *LogLevelRequest) Reset()         { *m = LogLevelRequest{} }
 func (m *LogLevelRequest) String() string { return proto.CompactTextString(m) }
 func (*LogLevelRequest) ProtoMessage()    {}
 func (*LogLevelRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_6c8e47987088ed1f, []int{1}
+	return fileDescriptor_admin_f9a2a11f1fc4c582, []int{2}
 }
 func (m *LogLevelRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogLevelRequest.Unmarshal(m, b)
@@ -156,7 +215,7 @@ func (m *LogLevelResponse) Reset()         { *m = LogLevelResponse{} }
 func (m *LogLevelResponse) String() string { return proto.CompactTextString(m) }
 func (*LogLevelResponse) ProtoMessage()    {}
 func (*LogLevelResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_6c8e47987088ed1f, []int{2}
+	return fileDescriptor_admin_f9a2a11f1fc4c582, []int{3}
 }
 func (m *LogLevelResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogLevelResponse.Unmarshal(m, b)
@@ -193,6 +252,7 @@ func (m *LogLevelResponse) GetLogLevel() string {
 type AdminOperation struct {
 	// Types that are valid to be assigned to Content:
 	//	*AdminOperation_LogReq
+	//	*AdminOperation_MembershipReq
 	Content              isAdminOperation_Content `protobuf_oneof:"content"`
 	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
 	XXX_unrecognized     []byte                   `json:"-"`
@@ -203,7 +263,7 @@ func (m *AdminOperation) Reset()         { *m = AdminOperation{} }
 func (m *AdminOperation) String() string { return proto.CompactTextString(m) }
 func (*AdminOperation) ProtoMessage()    {}
 func (*AdminOperation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_6c8e47987088ed1f, []int{3}
+	return fileDescriptor_admin_f9a2a11f1fc4c582, []int{4}
 }
 func (m *AdminOperation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AdminOperation.Unmarshal(m, b)
@@ -230,8 +290,12 @@ type isAdminOperation_Content interface {
 type AdminOperation_LogReq struct {
 	LogReq *LogLevelRequest `protobuf:"bytes,1,opt,name=logReq,oneof"`
 }
+type AdminOperation_MembershipReq struct {
+	MembershipReq *GossipMembershipRequest `protobuf:"bytes,2,opt,name=membershipReq,oneof"`
+}
 
-func (*AdminOperation_LogReq) isAdminOperation_Content() {}
+func (*AdminOperation_LogReq) isAdminOperation_Content()        {}
+func (*AdminOperation_MembershipReq) isAdminOperation_Content() {}
 
 func (m *AdminOperation) GetContent() isAdminOperation_Content {
 	if m != nil {
@@ -247,10 +311,18 @@ func (m *AdminOperation) GetLogReq() *LogLevelRequest {
 	return nil
 }
 
+func (m *AdminOperation) GetMembershipReq() *GossipMembershipRequest {
+	if x, ok := m.GetContent().(*AdminOperation_MembershipReq); ok {
+		return x.MembershipReq
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*AdminOperation) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _AdminOperation_OneofMarshaler, _AdminOperation_OneofUnmarshaler, _AdminOperation_OneofSizer, []interface{}{
 		(*AdminOperation_LogReq)(nil),
+		(*AdminOperation_MembershipReq)(nil),
 	}
 }
 
@@ -263,6 +335,11 @@ func _AdminOperation_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.LogReq); err != nil {
 			return err
 		}
+	case *AdminOperation_MembershipReq:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.MembershipReq); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("AdminOperation.Content has unexpected type %T", x)
@@ -281,6 +358,14 @@ func _AdminOperation_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto
 		err := b.DecodeMessage(msg)
 		m.Content = &AdminOperation_LogReq{msg}
 		return true, err
+	case 2: // content.membershipReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(GossipMembershipRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_MembershipReq{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -295,6 +380,11 @@ func _AdminOperation_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *AdminOperation_MembershipReq:
+		s := proto.Size(x.MembershipReq)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -302,11 +392,246 @@ func _AdminOperation_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// ConfigReloadStatus reports the outcome of the latest configuration reload
+type ConfigReloadStatus struct {
+	ReloadTime           *timestamp.Timestamp `protobuf:"bytes,1,opt,name=reload_time,json=reloadTime" json:"reload_time,omitempty"`
+	Applied              []string             `protobuf:"bytes,2,rep,name=applied" json:"applied,omitempty"`
+	RequiresRestart      []string             `protobuf:"bytes,3,rep,name=requires_restart,json=requiresRestart" json:"requires_restart,omitempty"`
+	Error                string               `protobuf:"bytes,4,opt,name=error" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ConfigReloadStatus) Reset()         { *m = ConfigReloadStatus{} }
+func (m *ConfigReloadStatus) String() string { return proto.CompactTextString(m) }
+func (*ConfigReloadStatus) ProtoMessage()    {}
+func (*ConfigReloadStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_f9a2a11f1fc4c582, []int{5}
+}
+func (m *ConfigReloadStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigReloadStatus.Unmarshal(m, b)
+}
+func (m *ConfigReloadStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigReloadStatus.Marshal(b, m, deterministic)
+}
+func (dst *ConfigReloadStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigReloadStatus.Merge(dst, src)
+}
+func (m *ConfigReloadStatus) XXX_Size() int {
+	return xxx_messageInfo_ConfigReloadStatus.Size(m)
+}
+func (m *ConfigReloadStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigReloadStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigReloadStatus proto.InternalMessageInfo
+
+func (m *ConfigReloadStatus) GetReloadTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ReloadTime
+	}
+	return nil
+}
+
+func (m *ConfigReloadStatus) GetApplied() []string {
+	if m != nil {
+		return m.Applied
+	}
+	return nil
+}
+
+func (m *ConfigReloadStatus) GetRequiresRestart() []string {
+	if m != nil {
+		return m.RequiresRestart
+	}
+	return nil
+}
+
+func (m *ConfigReloadStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// GossipMembershipRequest selects the channel whose membership view is
+// returned. The view of the whole network is returned if channel is empty.
+type GossipMembershipRequest struct {
+	Channel              string   `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GossipMembershipRequest) Reset()         { *m = GossipMembershipRequest{} }
+func (m *GossipMembershipRequest) String() string { return proto.CompactTextString(m) }
+func (*GossipMembershipRequest) ProtoMessage()    {}
+func (*GossipMembershipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_f9a2a11f1fc4c582, []int{6}
+}
+func (m *GossipMembershipRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GossipMembershipRequest.Unmarshal(m, b)
+}
+func (m *GossipMembershipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GossipMembershipRequest.Marshal(b, m, deterministic)
+}
+func (dst *GossipMembershipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GossipMembershipRequest.Merge(dst, src)
+}
+func (m *GossipMembershipRequest) XXX_Size() int {
+	return xxx_messageInfo_GossipMembershipRequest.Size(m)
+}
+func (m *GossipMembershipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GossipMembershipRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GossipMembershipRequest proto.InternalMessageInfo
+
+func (m *GossipMembershipRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+// GossipMember describes a peer as seen by the gossip membership view
+type GossipMember struct {
+	Endpoint             string   `protobuf:"bytes,1,opt,name=endpoint" json:"endpoint,omitempty"`
+	InternalEndpoint     string   `protobuf:"bytes,2,opt,name=internal_endpoint,json=internalEndpoint" json:"internal_endpoint,omitempty"`
+	Mspid                string   `protobuf:"bytes,3,opt,name=mspid" json:"mspid,omitempty"`
+	PkiId                []byte   `protobuf:"bytes,4,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
+	LedgerHeight         uint64   `protobuf:"varint,5,opt,name=ledger_height,json=ledgerHeight" json:"ledger_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GossipMember) Reset()         { *m = GossipMember{} }
+func (m *GossipMember) String() string { return proto.CompactTextString(m) }
+func (*GossipMember) ProtoMessage()    {}
+func (*GossipMember) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_f9a2a11f1fc4c582, []int{7}
+}
+func (m *GossipMember) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GossipMember.Unmarshal(m, b)
+}
+func (m *GossipMember) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GossipMember.Marshal(b, m, deterministic)
+}
+func (dst *GossipMember) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GossipMember.Merge(dst, src)
+}
+func (m *GossipMember) XXX_Size() int {
+	return xxx_messageInfo_GossipMember.Size(m)
+}
+func (m *GossipMember) XXX_DiscardUnknown() {
+	xxx_messageInfo_GossipMember.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GossipMember proto.InternalMessageInfo
+
+func (m *GossipMember) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *GossipMember) GetInternalEndpoint() string {
+	if m != nil {
+		return m.InternalEndpoint
+	}
+	return ""
+}
+
+func (m *GossipMember) GetMspid() string {
+	if m != nil {
+		return m.Mspid
+	}
+	return ""
+}
+
+func (m *GossipMember) GetPkiId() []byte {
+	if m != nil {
+		return m.PkiId
+	}
+	return nil
+}
+
+func (m *GossipMember) GetLedgerHeight() uint64 {
+	if m != nil {
+		return m.LedgerHeight
+	}
+	return 0
+}
+
+// GossipMembership is the gossip membership view of a peer, either of the
+// whole network or of a single channel
+type GossipMembership struct {
+	Channel              string          `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
+	Self                 *GossipMember   `protobuf:"bytes,2,opt,name=self" json:"self,omitempty"`
+	Alive                []*GossipMember `protobuf:"bytes,3,rep,name=alive" json:"alive,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GossipMembership) Reset()         { *m = GossipMembership{} }
+func (m *GossipMembership) String() string { return proto.CompactTextString(m) }
+func (*GossipMembership) ProtoMessage()    {}
+func (*GossipMembership) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_f9a2a11f1fc4c582, []int{8}
+}
+func (m *GossipMembership) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GossipMembership.Unmarshal(m, b)
+}
+func (m *GossipMembership) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GossipMembership.Marshal(b, m, deterministic)
+}
+func (dst *GossipMembership) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GossipMembership.Merge(dst, src)
+}
+func (m *GossipMembership) XXX_Size() int {
+	return xxx_messageInfo_GossipMembership.Size(m)
+}
+func (m *GossipMembership) XXX_DiscardUnknown() {
+	xxx_messageInfo_GossipMembership.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GossipMembership proto.InternalMessageInfo
+
+func (m *GossipMembership) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *GossipMembership) GetSelf() *GossipMember {
+	if m != nil {
+		return m.Self
+	}
+	return nil
+}
+
+func (m *GossipMembership) GetAlive() []*GossipMember {
+	if m != nil {
+		return m.Alive
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ServerStatus)(nil), "protos.ServerStatus")
+	proto.RegisterType((*StalledChannel)(nil), "protos.StalledChannel")
 	proto.RegisterType((*LogLevelRequest)(nil), "protos.LogLevelRequest")
 	proto.RegisterType((*LogLevelResponse)(nil), "protos.LogLevelResponse")
 	proto.RegisterType((*AdminOperation)(nil), "protos.AdminOperation")
+	proto.RegisterType((*ConfigReloadStatus)(nil), "protos.ConfigReloadStatus")
+	proto.RegisterType((*GossipMembershipRequest)(nil), "protos.GossipMembershipRequest")
+	proto.RegisterType((*GossipMember)(nil), "protos.GossipMember")
+	proto.RegisterType((*GossipMembership)(nil), "protos.GossipMembership")
 	proto.RegisterEnum("protos.ServerStatus_StatusCode", ServerStatus_StatusCode_name, ServerStatus_StatusCode_value)
 }
 
@@ -326,6 +651,8 @@ type AdminClient interface {
 	GetModuleLogLevel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogLevelResponse, error)
 	SetModuleLogLevel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogLevelResponse, error)
 	RevertLogLevels(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetConfigReloadStatus(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ConfigReloadStatus, error)
+	GetGossipMembership(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*GossipMembership, error)
 }
 
 type adminClient struct {
@@ -381,6 +708,24 @@ func (c *adminClient) RevertLogLevels(ctx context.Context, in *common.Envelope,
 	return out, nil
 }
 
+func (c *adminClient) GetConfigReloadStatus(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ConfigReloadStatus, error) {
+	out := new(ConfigReloadStatus)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetConfigReloadStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetGossipMembership(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*GossipMembership, error) {
+	out := new(GossipMembership)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetGossipMembership", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Admin service
 
 type AdminServer interface {
@@ -389,6 +734,8 @@ type AdminServer interface {
 	GetModuleLogLevel(context.Context, *common.Envelope) (*LogLevelResponse, error)
 	SetModuleLogLevel(context.Context, *common.Envelope) (*LogLevelResponse, error)
 	RevertLogLevels(context.Context, *common.Envelope) (*empty.Empty, error)
+	GetConfigReloadStatus(context.Context, *common.Envelope) (*ConfigReloadStatus, error)
+	GetGossipMembership(context.Context, *common.Envelope) (*GossipMembership, error)
 }
 
 func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
@@ -485,6 +832,42 @@ func _Admin_RevertLogLevels_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_GetConfigReloadStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetConfigReloadStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetConfigReloadStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetConfigReloadStatus(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetGossipMembership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetGossipMembership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetGossipMembership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetGossipMembership(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Admin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Admin",
 	HandlerType: (*AdminServer)(nil),
@@ -509,43 +892,73 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RevertLogLevels",
 			Handler:    _Admin_RevertLogLevels_Handler,
 		},
+		{
+			MethodName: "GetConfigReloadStatus",
+			Handler:    _Admin_GetConfigReloadStatus_Handler,
+		},
+		{
+			MethodName: "GetGossipMembership",
+			Handler:    _Admin_GetGossipMembership_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "peer/admin.proto",
 }
 
-func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor_admin_6c8e47987088ed1f) }
-
-var fileDescriptor_admin_6c8e47987088ed1f = []byte{
-	// 466 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0xdd, 0x6e, 0xd3, 0x30,
-	0x14, 0x6e, 0x0b, 0xed, 0xc8, 0xe9, 0xd8, 0x82, 0x41, 0x50, 0x75, 0x42, 0xa0, 0x5c, 0xc1, 0x8d,
-	0x23, 0x8a, 0xd0, 0xae, 0xb8, 0x68, 0x49, 0x18, 0x88, 0x2d, 0xad, 0x9c, 0x55, 0x08, 0x24, 0x34,
-	0xa5, 0xed, 0x99, 0x57, 0xe1, 0xc4, 0x99, 0xe3, 0x54, 0xda, 0xeb, 0xf0, 0x1c, 0x3c, 0x1c, 0x8a,
-	0x9d, 0x68, 0x13, 0xf4, 0x06, 0xf5, 0xea, 0xf8, 0x1c, 0x7f, 0xdf, 0x67, 0x9f, 0x3f, 0x70, 0x73,
-	0x44, 0xe5, 0x27, 0xab, 0x74, 0x9d, 0xd1, 0x5c, 0x49, 0x2d, 0x49, 0xcf, 0x98, 0x62, 0x78, 0xc4,
-	0xa5, 0xe4, 0x02, 0x7d, 0xe3, 0x2e, 0xca, 0x4b, 0x1f, 0xd3, 0x5c, 0xdf, 0x58, 0xd0, 0xf0, 0xf1,
-	0x52, 0xa6, 0xa9, 0xcc, 0x7c, 0x6b, 0x6c, 0xd0, 0xfb, 0xd5, 0x86, 0xfd, 0x18, 0xd5, 0x06, 0x55,
-	0xac, 0x13, 0x5d, 0x16, 0xe4, 0x18, 0x7a, 0x85, 0x39, 0x0d, 0xda, 0x2f, 0xdb, 0xaf, 0x0e, 0x46,
-	0x2f, 0x2c, 0xb0, 0xa0, 0x77, 0x51, 0xd4, 0x9a, 0x0f, 0x72, 0x85, 0xac, 0x86, 0x7b, 0xdf, 0x00,
-	0x6e, 0xa3, 0xe4, 0x21, 0x38, 0xf3, 0x28, 0x08, 0x3f, 0x7e, 0x8e, 0xc2, 0xc0, 0x6d, 0x91, 0x3e,
-	0xec, 0xc5, 0xe7, 0x63, 0x76, 0x1e, 0x06, 0x6e, 0xdb, 0x3a, 0xd3, 0xd9, 0x2c, 0x0c, 0xdc, 0x0e,
-	0x01, 0xe8, 0xcd, 0xc6, 0xf3, 0x38, 0x0c, 0xdc, 0x7b, 0xc4, 0x81, 0x6e, 0xc8, 0xd8, 0x94, 0xb9,
-	0xf7, 0x2b, 0xcc, 0x3c, 0xfa, 0x12, 0x4d, 0xbf, 0x46, 0x6e, 0xd7, 0x3b, 0x83, 0xc3, 0x53, 0xc9,
-	0x4f, 0x71, 0x83, 0x82, 0xe1, 0x75, 0x89, 0x85, 0x26, 0xcf, 0x01, 0x84, 0xe4, 0x17, 0xa9, 0x5c,
-	0x95, 0x02, 0xcd, 0x57, 0x1d, 0xe6, 0x08, 0xc9, 0xcf, 0x4c, 0x80, 0x1c, 0x41, 0xe5, 0x5c, 0x88,
-	0x8a, 0x32, 0xe8, 0x98, 0xdb, 0x07, 0xa2, 0x96, 0xf0, 0x22, 0x70, 0x6f, 0xe5, 0x8a, 0x5c, 0x66,
-	0x05, 0xee, 0xa8, 0x77, 0x30, 0xae, 0x9a, 0x31, 0xcd, 0x51, 0x25, 0x7a, 0x2d, 0x33, 0xf2, 0x06,
-	0x7a, 0x42, 0x72, 0x86, 0xd7, 0x46, 0xa9, 0x3f, 0x7a, 0xd6, 0x14, 0xf1, 0xaf, 0x34, 0x3e, 0xb5,
-	0x58, 0x0d, 0x9c, 0x38, 0xb0, 0xb7, 0x94, 0x99, 0xc6, 0x4c, 0x8f, 0x7e, 0x77, 0xa0, 0x6b, 0x04,
-	0xc9, 0x3b, 0x70, 0x4e, 0x50, 0xd7, 0x9d, 0x71, 0x69, 0xdd, 0xb9, 0x30, 0xdb, 0xa0, 0x90, 0x39,
-	0x0e, 0x9f, 0x6c, 0xeb, 0x8d, 0xd7, 0x22, 0xc7, 0xd0, 0x8f, 0x75, 0xa2, 0xb4, 0x0d, 0xff, 0x07,
-	0x71, 0x0c, 0x8f, 0x4e, 0x50, 0xdb, 0x9c, 0x9b, 0xaf, 0x6e, 0xa1, 0x0f, 0xfe, 0x4d, 0xc7, 0x96,
-	0xd1, 0x4a, 0xc4, 0x3b, 0x4a, 0xbc, 0x87, 0x43, 0x86, 0x1b, 0x54, 0xba, 0xb9, 0xdb, 0x96, 0xfb,
-	0x53, 0x6a, 0x67, 0x9d, 0x36, 0xb3, 0x4e, 0xc3, 0x6a, 0xd6, 0xbd, 0xd6, 0xe4, 0x07, 0x78, 0x52,
-	0x71, 0x7a, 0x75, 0x93, 0xa3, 0x12, 0xb8, 0xe2, 0xa8, 0xe8, 0x65, 0xb2, 0x50, 0xeb, 0x65, 0xf3,
-	0x64, 0xb5, 0x3e, 0x93, 0x7d, 0x53, 0xe1, 0x59, 0xb2, 0xfc, 0x99, 0x70, 0xfc, 0xfe, 0x9a, 0xaf,
-	0xf5, 0x55, 0xb9, 0xa8, 0x5e, 0xf1, 0xef, 0x10, 0x7d, 0x4b, 0xb4, 0xfb, 0x54, 0xf8, 0x15, 0x71,
-	0x61, 0x77, 0xed, 0xed, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xaf, 0x20, 0x26, 0x0b, 0x86, 0x03,
-	0x00, 0x00,
+func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor_admin_f9a2a11f1fc4c582) }
+
+var fileDescriptor_admin_f9a2a11f1fc4c582 = []byte{
+	// 831 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xd1, 0x6e, 0xdb, 0x36,
+	0x17, 0xb6, 0xe2, 0xd8, 0xa9, 0x8f, 0x9d, 0x44, 0x65, 0xd3, 0x54, 0x70, 0xf1, 0xa3, 0x81, 0xfe,
+	0x9b, 0x74, 0x03, 0x64, 0x2c, 0xc5, 0xd0, 0x8b, 0x61, 0x17, 0x4e, 0xac, 0xb9, 0xc1, 0x1a, 0x27,
+	0xa0, 0x13, 0x0c, 0x1b, 0x30, 0x18, 0xb2, 0x75, 0x22, 0x13, 0xa1, 0x44, 0x85, 0xa4, 0x0d, 0xf4,
+	0x76, 0x2f, 0xb1, 0x67, 0x18, 0xb0, 0x27, 0xd9, 0x7b, 0xec, 0x3d, 0x06, 0x91, 0x52, 0x9a, 0xc4,
+	0xce, 0x80, 0xa1, 0x57, 0xf4, 0x39, 0xe7, 0xfb, 0x3e, 0xf2, 0x98, 0xdf, 0xa1, 0xc0, 0xcd, 0x11,
+	0x65, 0x2f, 0x8a, 0x53, 0x96, 0x05, 0xb9, 0x14, 0x5a, 0x90, 0xa6, 0x59, 0x54, 0xf7, 0x75, 0x22,
+	0x44, 0xc2, 0xb1, 0x67, 0xc2, 0xe9, 0xe2, 0xba, 0x87, 0x69, 0xae, 0x3f, 0x59, 0x50, 0xf7, 0xcd,
+	0xe3, 0xa2, 0x66, 0x29, 0x2a, 0x1d, 0xa5, 0x79, 0x09, 0x78, 0x31, 0x13, 0x69, 0x2a, 0xb2, 0x9e,
+	0x5d, 0x6c, 0xd2, 0xff, 0xdb, 0x81, 0xce, 0x18, 0xe5, 0x12, 0xe5, 0x58, 0x47, 0x7a, 0xa1, 0xc8,
+	0x7b, 0x68, 0x2a, 0xf3, 0xcb, 0x73, 0x0e, 0x9c, 0xc3, 0x9d, 0xa3, 0x37, 0x16, 0xa8, 0x82, 0xfb,
+	0xa8, 0xc0, 0x2e, 0x27, 0x22, 0x46, 0x5a, 0xc2, 0x49, 0x1f, 0x5c, 0xa5, 0x23, 0xce, 0x31, 0x9e,
+	0xcc, 0xe6, 0x51, 0x96, 0x21, 0x57, 0xde, 0xc6, 0x41, 0xfd, 0xb0, 0x7d, 0xb4, 0x7f, 0x27, 0x61,
+	0xeb, 0x27, 0xb6, 0x4c, 0x77, 0xd5, 0x83, 0x58, 0xf9, 0x3f, 0x03, 0x7c, 0x16, 0x26, 0xdb, 0xd0,
+	0xba, 0x1a, 0x0d, 0xc2, 0x1f, 0x4e, 0x47, 0xe1, 0xc0, 0xad, 0x91, 0x36, 0x6c, 0x8d, 0x2f, 0xfb,
+	0xf4, 0x32, 0x1c, 0xb8, 0x8e, 0x0d, 0xce, 0x2f, 0x2e, 0xc2, 0x81, 0xbb, 0x41, 0x00, 0x9a, 0x17,
+	0xfd, 0xab, 0x71, 0x38, 0x70, 0xeb, 0xa4, 0x05, 0x8d, 0x90, 0xd2, 0x73, 0xea, 0x6e, 0x16, 0x98,
+	0xab, 0xd1, 0x8f, 0xa3, 0xf3, 0x9f, 0x46, 0x6e, 0xc3, 0x1f, 0xc2, 0xce, 0xc3, 0xdd, 0xc9, 0xff,
+	0x00, 0xca, 0x73, 0x4e, 0x58, 0x6c, 0x9a, 0x6d, 0xd1, 0x56, 0x99, 0x39, 0x8d, 0xc9, 0x3e, 0x34,
+	0x25, 0x46, 0x4a, 0x64, 0xde, 0x86, 0x29, 0x95, 0x91, 0x7f, 0x06, 0xbb, 0x1f, 0x45, 0xf2, 0x11,
+	0x97, 0xc8, 0x29, 0xde, 0x2e, 0x50, 0xe9, 0x42, 0x89, 0x8b, 0x64, 0x92, 0x8a, 0x78, 0xc1, 0xb1,
+	0x52, 0xe2, 0x22, 0x39, 0x33, 0x09, 0xf2, 0x1a, 0x8a, 0x60, 0xc2, 0x0b, 0x4a, 0x29, 0xf6, 0x8c,
+	0x97, 0x12, 0xfe, 0x08, 0xdc, 0xcf, 0x72, 0x2a, 0x17, 0x99, 0xc2, 0x2f, 0xd2, 0xfb, 0xdd, 0x81,
+	0x9d, 0x7e, 0x61, 0x9d, 0xf3, 0x1c, 0x65, 0xa4, 0x99, 0xc8, 0xc8, 0x37, 0xd0, 0xe4, 0x22, 0xa1,
+	0x78, 0x6b, 0xa4, 0xda, 0x47, 0xaf, 0xaa, 0xeb, 0x78, 0xd4, 0xc7, 0x87, 0x1a, 0x2d, 0x81, 0x64,
+	0x08, 0xdb, 0x29, 0xa6, 0x53, 0x94, 0x6a, 0xce, 0xf2, 0x82, 0xb9, 0x61, 0x98, 0x77, 0x5e, 0x18,
+	0x0a, 0xa5, 0x58, 0x7e, 0x76, 0x1f, 0x52, 0x2a, 0x3c, 0xe4, 0x1d, 0xb7, 0x60, 0x6b, 0x26, 0x32,
+	0x8d, 0x99, 0xf6, 0xff, 0x74, 0x80, 0x9c, 0x88, 0xec, 0x9a, 0x25, 0x14, 0xb9, 0x88, 0xe2, 0xd2,
+	0x6f, 0xdf, 0x41, 0x5b, 0x9a, 0x78, 0x52, 0xf8, 0xb5, 0x3c, 0x62, 0x37, 0xb0, 0x66, 0x0e, 0x2a,
+	0x33, 0x07, 0x97, 0x95, 0x99, 0x29, 0x58, 0x78, 0x91, 0x20, 0x1e, 0x6c, 0x45, 0x79, 0xce, 0x19,
+	0xc6, 0xc6, 0x6a, 0x2d, 0x5a, 0x85, 0xe4, 0x2d, 0xb8, 0x12, 0x6f, 0x17, 0x4c, 0xa2, 0x9a, 0xc8,
+	0x82, 0x29, 0xb5, 0x57, 0x37, 0x90, 0xdd, 0x2a, 0x4f, 0x6d, 0x9a, 0xec, 0x41, 0x03, 0xa5, 0x14,
+	0xd2, 0xdb, 0x34, 0xff, 0xa5, 0x0d, 0xfc, 0x77, 0xf0, 0xea, 0x89, 0x2e, 0x8b, 0x5d, 0x4b, 0x9f,
+	0x94, 0x97, 0x53, 0x85, 0xfe, 0x1f, 0x0e, 0x74, 0xee, 0xb3, 0x48, 0x17, 0x9e, 0x61, 0x16, 0xe7,
+	0x82, 0x65, 0xba, 0xc4, 0xde, 0xc5, 0xe4, 0x6b, 0x78, 0xce, 0x32, 0x8d, 0x32, 0x8b, 0xf8, 0xe4,
+	0x0e, 0x64, 0xef, 0xd3, 0xad, 0x0a, 0x61, 0x05, 0xde, 0x83, 0x46, 0xaa, 0x72, 0x16, 0x7b, 0x75,
+	0x7b, 0x48, 0x13, 0x90, 0x97, 0xd0, 0xcc, 0x6f, 0x58, 0xe1, 0xdf, 0xe2, 0xec, 0x1d, 0xda, 0xc8,
+	0x6f, 0xd8, 0x69, 0x4c, 0xfe, 0x0f, 0xdb, 0x1c, 0xe3, 0x04, 0xe5, 0x64, 0x8e, 0x2c, 0x99, 0x6b,
+	0xaf, 0x71, 0xe0, 0x1c, 0x6e, 0xd2, 0x8e, 0x4d, 0x7e, 0x30, 0x39, 0xff, 0x37, 0x07, 0xdc, 0xc7,
+	0x1d, 0x3e, 0xdd, 0x1a, 0x39, 0x84, 0x4d, 0x85, 0xfc, 0xba, 0x74, 0xc2, 0xde, 0x3a, 0x27, 0x50,
+	0x83, 0x20, 0x5f, 0x41, 0x23, 0xe2, 0x6c, 0x89, 0xe6, 0xff, 0x7e, 0x0a, 0x6a, 0x21, 0x47, 0x7f,
+	0xd5, 0xa1, 0x61, 0xec, 0x4a, 0xbe, 0x85, 0xd6, 0x10, 0x75, 0x69, 0x0a, 0x37, 0x28, 0x1f, 0xa9,
+	0x30, 0x5b, 0x22, 0x17, 0x39, 0x76, 0xf7, 0xd6, 0x3d, 0x43, 0x7e, 0x8d, 0xbc, 0x87, 0xf6, 0xb8,
+	0xb8, 0x45, 0x9b, 0xfe, 0x0f, 0xc4, 0x3e, 0x3c, 0x1f, 0xa2, 0xb6, 0x23, 0x55, 0x0d, 0xc2, 0x1a,
+	0xba, 0xb7, 0x3a, 0x2c, 0x76, 0x4a, 0xad, 0xc4, 0xf8, 0x0b, 0x25, 0xbe, 0x87, 0x5d, 0x8a, 0x4b,
+	0x94, 0xba, 0xaa, 0xad, 0xeb, 0x7d, 0x7f, 0x65, 0x1a, 0xc2, 0xe2, 0xdd, 0xf7, 0x6b, 0x64, 0x08,
+	0x2f, 0x87, 0xa8, 0xd7, 0x4c, 0xd5, 0xaa, 0x48, 0xb7, 0x3a, 0xc5, 0x2a, 0xda, 0xaf, 0x91, 0x13,
+	0x78, 0x31, 0x44, 0xbd, 0x62, 0x87, 0x7f, 0x69, 0xe6, 0x31, 0xd6, 0xaf, 0x1d, 0xff, 0x0a, 0xbe,
+	0x90, 0x49, 0x30, 0xff, 0x94, 0xa3, 0xb4, 0x56, 0x0b, 0xae, 0xa3, 0xa9, 0x64, 0xb3, 0x8a, 0x93,
+	0x23, 0xca, 0xe3, 0x8e, 0xb9, 0xef, 0x8b, 0x68, 0x76, 0x13, 0x25, 0xf8, 0xcb, 0xdb, 0x84, 0xe9,
+	0xf9, 0x62, 0x5a, 0xec, 0xd3, 0xbb, 0x47, 0xec, 0x59, 0xa2, 0xfd, 0x98, 0xa9, 0x5e, 0x41, 0x9c,
+	0xda, 0xaf, 0xe0, 0xbb, 0x7f, 0x06, 0x00, 0xd8, 0x05, 0xe0, 0x5c, 0x20, 0x07, 0x00, 0x00,
 }
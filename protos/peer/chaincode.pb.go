@@ -39,7 +39,7 @@ func (x ConfidentialityLevel) String() string {
 	return proto.EnumName(ConfidentialityLevel_name, int32(x))
 }
 func (ConfidentialityLevel) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{0}
+	return fileDescriptor_chaincode_d90e4c1b13694c76, []int{0}
 }
 
 type ChaincodeSpec_Type int32
@@ -71,7 +71,7 @@ func (x ChaincodeSpec_Type) String() string {
 	return proto.EnumName(ChaincodeSpec_Type_name, int32(x))
 }
 func (ChaincodeSpec_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{2, 0}
+	return fileDescriptor_chaincode_d90e4c1b13694c76, []int{2, 0}
 }
 
 type ChaincodeDeploymentSpec_ExecutionEnvironment int32
@@ -94,7 +94,7 @@ func (x ChaincodeDeploymentSpec_ExecutionEnvironment) String() string {
 	return proto.EnumName(ChaincodeDeploymentSpec_ExecutionEnvironment_name, int32(x))
 }
 func (ChaincodeDeploymentSpec_ExecutionEnvironment) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{3, 0}
+	return fileDescriptor_chaincode_d90e4c1b13694c76, []int{3, 0}
 }
 
 // ChaincodeID contains the path as specified by the deploy transaction
@@ -121,7 +121,7 @@ func (m *ChaincodeID) Reset()         { *m = ChaincodeID{} }
 func (m *ChaincodeID) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeID) ProtoMessage()    {}
 func (*ChaincodeID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{0}
+	return fileDescriptor_chaincode_d90e4c1b13694c76, []int{0}
 }
 func (m *ChaincodeID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeID.Unmarshal(m, b)
@@ -177,7 +177,7 @@ func (m *ChaincodeInput) Reset()         { *m = ChaincodeInput{} }
 func (m *ChaincodeInput) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeInput) ProtoMessage()    {}
 func (*ChaincodeInput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{1}
+	return fileDescriptor_chaincode_d90e4c1b13694c76, []int{1}
 }
 func (m *ChaincodeInput) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeInput.Unmarshal(m, b)
@@ -227,7 +227,7 @@ func (m *ChaincodeSpec) Reset()         { *m = ChaincodeSpec{} }
 func (m *ChaincodeSpec) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeSpec) ProtoMessage()    {}
 func (*ChaincodeSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{2}
+	return fileDescriptor_chaincode_d90e4c1b13694c76, []int{2}
 }
 func (m *ChaincodeSpec) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeSpec.Unmarshal(m, b)
@@ -278,19 +278,26 @@ func (m *ChaincodeSpec) GetTimeout() int32 {
 // Specify the deployment of a chaincode.
 // TODO: Define `codePackage`.
 type ChaincodeDeploymentSpec struct {
-	ChaincodeSpec        *ChaincodeSpec                               `protobuf:"bytes,1,opt,name=chaincode_spec,json=chaincodeSpec" json:"chaincode_spec,omitempty"`
-	CodePackage          []byte                                       `protobuf:"bytes,3,opt,name=code_package,json=codePackage,proto3" json:"code_package,omitempty"`
-	ExecEnv              ChaincodeDeploymentSpec_ExecutionEnvironment `protobuf:"varint,4,opt,name=exec_env,json=execEnv,enum=protos.ChaincodeDeploymentSpec_ExecutionEnvironment" json:"exec_env,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                                     `json:"-"`
-	XXX_unrecognized     []byte                                       `json:"-"`
-	XXX_sizecache        int32                                        `json:"-"`
+	ChaincodeSpec *ChaincodeSpec                               `protobuf:"bytes,1,opt,name=chaincode_spec,json=chaincodeSpec" json:"chaincode_spec,omitempty"`
+	CodePackage   []byte                                       `protobuf:"bytes,3,opt,name=code_package,json=codePackage,proto3" json:"code_package,omitempty"`
+	ExecEnv       ChaincodeDeploymentSpec_ExecutionEnvironment `protobuf:"varint,4,opt,name=exec_env,json=execEnv,enum=protos.ChaincodeDeploymentSpec_ExecutionEnvironment" json:"exec_env,omitempty"`
+	// The address of the server of a chaincode run as an external service,
+	// which the peer connects to instead of launching the chaincode
+	Endpoint string `protobuf:"bytes,5,opt,name=endpoint" json:"endpoint,omitempty"`
+	// The PEM encoded certificates of the CAs issuing the TLS certificate of
+	// the server of a chaincode run as an external service. When set, the
+	// peer connects to the server over TLS and trusts only these CAs
+	EndpointRootCert     []byte   `protobuf:"bytes,6,opt,name=endpoint_root_cert,json=endpointRootCert,proto3" json:"endpoint_root_cert,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ChaincodeDeploymentSpec) Reset()         { *m = ChaincodeDeploymentSpec{} }
 func (m *ChaincodeDeploymentSpec) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeDeploymentSpec) ProtoMessage()    {}
 func (*ChaincodeDeploymentSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{3}
+	return fileDescriptor_chaincode_d90e4c1b13694c76, []int{3}
 }
 func (m *ChaincodeDeploymentSpec) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeDeploymentSpec.Unmarshal(m, b)
@@ -331,6 +338,20 @@ func (m *ChaincodeDeploymentSpec) GetExecEnv() ChaincodeDeploymentSpec_Execution
 	return ChaincodeDeploymentSpec_DOCKER
 }
 
+func (m *ChaincodeDeploymentSpec) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *ChaincodeDeploymentSpec) GetEndpointRootCert() []byte {
+	if m != nil {
+		return m.EndpointRootCert
+	}
+	return nil
+}
+
 // Carries the chaincode function and its arguments.
 type ChaincodeInvocationSpec struct {
 	ChaincodeSpec        *ChaincodeSpec `protobuf:"bytes,1,opt,name=chaincode_spec,json=chaincodeSpec" json:"chaincode_spec,omitempty"`
@@ -343,7 +364,7 @@ func (m *ChaincodeInvocationSpec) Reset()         { *m = ChaincodeInvocationSpec
 func (m *ChaincodeInvocationSpec) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeInvocationSpec) ProtoMessage()    {}
 func (*ChaincodeInvocationSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{4}
+	return fileDescriptor_chaincode_d90e4c1b13694c76, []int{4}
 }
 func (m *ChaincodeInvocationSpec) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeInvocationSpec.Unmarshal(m, b)
@@ -382,7 +403,7 @@ func (m *LifecycleEvent) Reset()         { *m = LifecycleEvent{} }
 func (m *LifecycleEvent) String() string { return proto.CompactTextString(m) }
 func (*LifecycleEvent) ProtoMessage()    {}
 func (*LifecycleEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{5}
+	return fileDescriptor_chaincode_d90e4c1b13694c76, []int{5}
 }
 func (m *LifecycleEvent) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LifecycleEvent.Unmarshal(m, b)
@@ -422,48 +443,50 @@ func init() {
 	proto.RegisterEnum("protos.ChaincodeDeploymentSpec_ExecutionEnvironment", ChaincodeDeploymentSpec_ExecutionEnvironment_name, ChaincodeDeploymentSpec_ExecutionEnvironment_value)
 }
 
-func init() { proto.RegisterFile("peer/chaincode.proto", fileDescriptor_chaincode_d56d9efe15650cad) }
-
-var fileDescriptor_chaincode_d56d9efe15650cad = []byte{
-	// 632 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x6d, 0x6f, 0xda, 0x3c,
-	0x14, 0x6d, 0x80, 0xbe, 0xdd, 0x50, 0x94, 0xc7, 0x0f, 0xcf, 0x33, 0xd4, 0x4f, 0x2c, 0xd2, 0x34,
-	0x36, 0x4d, 0x41, 0x62, 0xd5, 0x36, 0x4d, 0x53, 0x25, 0x4a, 0xd2, 0x2a, 0x1d, 0x83, 0xca, 0x6d,
-	0x27, 0x6d, 0x5f, 0x50, 0xea, 0x5c, 0xc0, 0x6a, 0x70, 0xa2, 0x60, 0xa2, 0xe6, 0x67, 0xec, 0x97,
-	0xec, 0x27, 0x6e, 0xb2, 0x53, 0x5e, 0xba, 0xf6, 0xdb, 0x3e, 0x71, 0x7d, 0x7c, 0x7c, 0xee, 0x3d,
-	0x07, 0xc7, 0x50, 0x4f, 0x10, 0xd3, 0x36, 0x9b, 0x06, 0x5c, 0xb0, 0x38, 0x44, 0x27, 0x49, 0x63,
-	0x19, 0x93, 0x1d, 0xfd, 0x33, 0xb7, 0x87, 0x60, 0xf6, 0x96, 0x5b, 0xbe, 0x4b, 0x08, 0x54, 0x92,
-	0x40, 0x4e, 0x1b, 0x46, 0xd3, 0x68, 0xed, 0x53, 0x5d, 0x2b, 0x4c, 0x04, 0x33, 0x6c, 0x94, 0x0a,
-	0x4c, 0xd5, 0xa4, 0x01, 0xbb, 0x19, 0xa6, 0x73, 0x1e, 0x8b, 0x46, 0x59, 0xc3, 0xcb, 0xa5, 0xfd,
-	0xd3, 0x80, 0xda, 0x5a, 0x51, 0x24, 0x0b, 0xa9, 0x04, 0x82, 0x74, 0x32, 0x6f, 0x18, 0xcd, 0x72,
-	0xab, 0x4a, 0x75, 0x4d, 0x7c, 0x30, 0x43, 0x64, 0x71, 0x1a, 0x48, 0x1e, 0x8b, 0x79, 0xa3, 0xd4,
-	0x2c, 0xb7, 0xcc, 0xce, 0xcb, 0x62, 0xb8, 0xb9, 0xf3, 0x50, 0xc0, 0x71, 0xd7, 0x4c, 0x4f, 0xc8,
-	0x34, 0xa7, 0x9b, 0x67, 0x0f, 0x8f, 0xc1, 0xfa, 0x93, 0x40, 0x2c, 0x28, 0xdf, 0x62, 0x7e, 0x6f,
-	0x43, 0x95, 0xa4, 0x0e, 0xdb, 0x59, 0x10, 0x2d, 0x0a, 0x1b, 0x55, 0x5a, 0x2c, 0x3e, 0x96, 0x3e,
-	0x18, 0xf6, 0x2f, 0x03, 0x0e, 0x56, 0x0d, 0x2f, 0x13, 0x64, 0xc4, 0x81, 0x8a, 0xcc, 0x13, 0xd4,
-	0xc7, 0x6b, 0x9d, 0xc3, 0x47, 0x53, 0x29, 0x92, 0x73, 0x95, 0x27, 0x48, 0x35, 0x8f, 0xbc, 0x83,
-	0xea, 0x2a, 0xdf, 0x11, 0x0f, 0x75, 0x0b, 0xb3, 0xf3, 0xef, 0x63, 0x37, 0x2e, 0x35, 0x57, 0x44,
-	0x3f, 0x24, 0x6f, 0x60, 0x9b, 0x2b, 0x83, 0x3a, 0x43, 0xb3, 0xf3, 0xff, 0xd3, 0xf6, 0x69, 0x41,
-	0x52, 0x99, 0x4b, 0x3e, 0xc3, 0x78, 0x21, 0x1b, 0x95, 0xa6, 0xd1, 0xda, 0xa6, 0xcb, 0xa5, 0x7d,
-	0x0c, 0x15, 0x35, 0x0d, 0x39, 0x80, 0xfd, 0xeb, 0x81, 0xeb, 0x9d, 0xfa, 0x03, 0xcf, 0xb5, 0xb6,
-	0x08, 0xc0, 0xce, 0xd9, 0xb0, 0xdf, 0x1d, 0x9c, 0x59, 0x06, 0xd9, 0x83, 0xca, 0x60, 0xe8, 0x7a,
-	0x56, 0x89, 0xec, 0x42, 0xb9, 0xd7, 0xa5, 0x56, 0x59, 0x41, 0xe7, 0xdd, 0xaf, 0x5d, 0xab, 0x62,
-	0xff, 0x28, 0xc1, 0xb3, 0x55, 0x4f, 0x17, 0x93, 0x28, 0xce, 0x67, 0x28, 0xa4, 0xce, 0xe2, 0x13,
-	0xd4, 0xd6, 0xde, 0xe6, 0x09, 0x32, 0x9d, 0x8a, 0xd9, 0xf9, 0xef, 0xc9, 0x54, 0xe8, 0x01, 0x7b,
-	0x90, 0xe4, 0x73, 0xa8, 0xea, 0x83, 0x49, 0xc0, 0x6e, 0x83, 0x09, 0x6a, 0xa3, 0x55, 0x6a, 0x2a,
-	0xec, 0xa2, 0x80, 0xc8, 0x10, 0xf6, 0xf0, 0x0e, 0xd9, 0x08, 0x45, 0xa6, 0x7d, 0xd5, 0x3a, 0x47,
-	0x8f, 0xa4, 0x1f, 0xce, 0xe4, 0x78, 0x77, 0xc8, 0x16, 0xea, 0xdf, 0xf6, 0x44, 0xc6, 0xd3, 0x58,
-	0xa8, 0x0d, 0xba, 0xab, 0x54, 0x3c, 0x91, 0xd9, 0x0e, 0xd4, 0x9f, 0x22, 0xa8, 0x38, 0xdc, 0x61,
-	0xef, 0xb3, 0x47, 0x8b, 0x68, 0x2e, 0xbf, 0x5d, 0x5e, 0x79, 0x5f, 0x2c, 0xe3, 0xbc, 0xb2, 0x57,
-	0xb2, 0xca, 0xb4, 0x86, 0xe3, 0x31, 0x32, 0xc9, 0x33, 0x1c, 0x85, 0x81, 0x44, 0x3b, 0xd9, 0x88,
-	0xc4, 0x17, 0x59, 0xcc, 0xf4, 0xf5, 0xfa, 0xfb, 0x48, 0xee, 0xdb, 0xfd, 0xc3, 0xc3, 0xd1, 0x04,
-	0x05, 0x16, 0xb7, 0x76, 0x14, 0x44, 0x13, 0xfb, 0x3d, 0xd4, 0xfa, 0x7c, 0x8c, 0x2c, 0x67, 0x11,
-	0x7a, 0x99, 0x9a, 0xf8, 0xc5, 0x66, 0x23, 0xfd, 0x0d, 0x16, 0x17, 0x7a, 0xad, 0x38, 0x08, 0x66,
-	0xf8, 0xfa, 0x08, 0xea, 0xbd, 0x58, 0x8c, 0x79, 0x88, 0x42, 0xf2, 0x20, 0xe2, 0x32, 0xef, 0x63,
-	0x86, 0x91, 0x32, 0x79, 0x71, 0x7d, 0xd2, 0xf7, 0x7b, 0xd6, 0x16, 0xb1, 0xa0, 0xda, 0x1b, 0x0e,
-	0x4e, 0x7d, 0xd7, 0x1b, 0x5c, 0xf9, 0xdd, 0xbe, 0x65, 0x9c, 0x0c, 0xc1, 0x8e, 0xd3, 0x89, 0x33,
-	0xcd, 0x13, 0x4c, 0x23, 0x0c, 0x27, 0x98, 0x3a, 0xe3, 0xe0, 0x26, 0xe5, 0x6c, 0xe9, 0x42, 0xbd,
-	0x1b, 0xdf, 0x5f, 0x4d, 0xb8, 0x9c, 0x2e, 0x6e, 0x1c, 0x16, 0xcf, 0xda, 0x1b, 0xd4, 0x76, 0x41,
-	0x6d, 0x17, 0xd4, 0xb6, 0xa2, 0xde, 0x14, 0x4f, 0xca, 0xdb, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff,
-	0xea, 0x1f, 0x3b, 0xe4, 0x71, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/chaincode.proto", fileDescriptor_chaincode_d90e4c1b13694c76) }
+
+var fileDescriptor_chaincode_d90e4c1b13694c76 = []byte{
+	// 668 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x5d, 0x6f, 0xdb, 0x36,
+	0x14, 0x8d, 0xfc, 0x91, 0x38, 0x57, 0x8e, 0xa1, 0x71, 0xde, 0x26, 0xe4, 0xc9, 0x13, 0x30, 0xcc,
+	0x1b, 0x02, 0x19, 0xf0, 0x82, 0x6d, 0x28, 0x8a, 0x00, 0x8e, 0xa5, 0x04, 0x4a, 0x5d, 0x3b, 0x50,
+	0x92, 0x02, 0xed, 0x8b, 0xa1, 0x50, 0xd7, 0x36, 0x11, 0x9b, 0x14, 0x64, 0x5a, 0x88, 0x7e, 0x55,
+	0x7f, 0x50, 0x7f, 0x4c, 0x0b, 0x52, 0xf1, 0x47, 0x9a, 0xbc, 0xf5, 0x49, 0xf7, 0x5e, 0x1e, 0xde,
+	0x7b, 0xce, 0x11, 0x49, 0x68, 0x26, 0x88, 0x69, 0x87, 0xce, 0x22, 0xc6, 0xa9, 0x88, 0xd1, 0x4d,
+	0x52, 0x21, 0x05, 0xd9, 0xd7, 0x9f, 0xa5, 0x33, 0x02, 0xb3, 0xbf, 0x5e, 0x0a, 0x3c, 0x42, 0xa0,
+	0x92, 0x44, 0x72, 0x66, 0x1b, 0x2d, 0xa3, 0x7d, 0x18, 0xea, 0x58, 0xd5, 0x78, 0xb4, 0x40, 0xbb,
+	0x54, 0xd4, 0x54, 0x4c, 0x6c, 0x38, 0xc8, 0x30, 0x5d, 0x32, 0xc1, 0xed, 0xb2, 0x2e, 0xaf, 0x53,
+	0xe7, 0xb3, 0x01, 0x8d, 0x6d, 0x47, 0x9e, 0xac, 0xa4, 0x6a, 0x10, 0xa5, 0xd3, 0xa5, 0x6d, 0xb4,
+	0xca, 0xed, 0x7a, 0xa8, 0x63, 0x12, 0x80, 0x19, 0x23, 0x15, 0x69, 0x24, 0x99, 0xe0, 0x4b, 0xbb,
+	0xd4, 0x2a, 0xb7, 0xcd, 0xee, 0x9f, 0x05, 0xb9, 0xa5, 0xfb, 0xbc, 0x81, 0xeb, 0x6d, 0x91, 0x3e,
+	0x97, 0x69, 0x1e, 0xee, 0xee, 0x3d, 0x3e, 0x03, 0xeb, 0x7b, 0x00, 0xb1, 0xa0, 0xfc, 0x80, 0xf9,
+	0x93, 0x0c, 0x15, 0x92, 0x26, 0x54, 0xb3, 0x68, 0xbe, 0x2a, 0x64, 0xd4, 0xc3, 0x22, 0x79, 0x53,
+	0xfa, 0xdf, 0x70, 0xbe, 0x1a, 0x70, 0xb4, 0x19, 0x78, 0x93, 0x20, 0x25, 0x2e, 0x54, 0x64, 0x9e,
+	0xa0, 0xde, 0xde, 0xe8, 0x1e, 0xbf, 0x60, 0xa5, 0x40, 0xee, 0x6d, 0x9e, 0x60, 0xa8, 0x71, 0xe4,
+	0x5f, 0xa8, 0x6f, 0xfc, 0x1d, 0xb3, 0x58, 0x8f, 0x30, 0xbb, 0x3f, 0xbf, 0x54, 0xe3, 0x85, 0xe6,
+	0x06, 0x18, 0xc4, 0xe4, 0x04, 0xaa, 0x4c, 0x09, 0xd4, 0x1e, 0x9a, 0xdd, 0x5f, 0x5f, 0x97, 0x1f,
+	0x16, 0x20, 0xe5, 0xb9, 0x64, 0x0b, 0x14, 0x2b, 0x69, 0x57, 0x5a, 0x46, 0xbb, 0x1a, 0xae, 0x53,
+	0xe7, 0x0c, 0x2a, 0x8a, 0x0d, 0x39, 0x82, 0xc3, 0xbb, 0xa1, 0xe7, 0x5f, 0x04, 0x43, 0xdf, 0xb3,
+	0xf6, 0x08, 0xc0, 0xfe, 0xe5, 0x68, 0xd0, 0x1b, 0x5e, 0x5a, 0x06, 0xa9, 0x41, 0x65, 0x38, 0xf2,
+	0x7c, 0xab, 0x44, 0x0e, 0xa0, 0xdc, 0xef, 0x85, 0x56, 0x59, 0x95, 0xae, 0x7a, 0x1f, 0x7a, 0x56,
+	0xc5, 0xf9, 0x52, 0x82, 0xdf, 0x36, 0x33, 0x3d, 0x4c, 0xe6, 0x22, 0x5f, 0x20, 0x97, 0xda, 0x8b,
+	0xb7, 0xd0, 0xd8, 0x6a, 0x5b, 0x26, 0x48, 0xb5, 0x2b, 0x66, 0xf7, 0x97, 0x57, 0x5d, 0x09, 0x8f,
+	0xe8, 0x6e, 0x4a, 0x7e, 0x87, 0xba, 0xde, 0x98, 0x44, 0xf4, 0x21, 0x9a, 0xa2, 0x16, 0x5a, 0x0f,
+	0x4d, 0x55, 0xbb, 0x2e, 0x4a, 0x64, 0x04, 0x35, 0x7c, 0x44, 0x3a, 0x46, 0x9e, 0x69, 0x5d, 0x8d,
+	0xee, 0xe9, 0x8b, 0xd6, 0xcf, 0x39, 0xb9, 0xfe, 0x23, 0xd2, 0x95, 0xfa, 0xdb, 0x3e, 0xcf, 0x58,
+	0x2a, 0xb8, 0x5a, 0x08, 0x0f, 0x54, 0x17, 0x9f, 0x67, 0xe4, 0x18, 0x6a, 0xc8, 0xe3, 0x44, 0x30,
+	0x2e, 0xed, 0xaa, 0x3e, 0x00, 0x9b, 0x9c, 0x9c, 0x00, 0x59, 0xc7, 0xe3, 0x54, 0x08, 0x39, 0xa6,
+	0x98, 0x4a, 0x7b, 0x5f, 0xb3, 0xb2, 0xd6, 0x2b, 0xa1, 0x10, 0xb2, 0x8f, 0xa9, 0x74, 0x5c, 0x68,
+	0xbe, 0x36, 0x4a, 0x19, 0xeb, 0x8d, 0xfa, 0xef, 0xfc, 0xb0, 0x30, 0xf9, 0xe6, 0xe3, 0xcd, 0xad,
+	0xff, 0xde, 0x32, 0xae, 0x2a, 0xb5, 0x92, 0x55, 0x0e, 0x1b, 0x38, 0x99, 0x20, 0x95, 0x2c, 0xc3,
+	0x71, 0x1c, 0x49, 0x74, 0x92, 0x1d, 0x73, 0x03, 0x9e, 0x09, 0xaa, 0x0f, 0xea, 0x8f, 0x9b, 0xfb,
+	0x34, 0xee, 0x27, 0x16, 0x8f, 0xa7, 0xc8, 0xb1, 0x38, 0xff, 0xe3, 0x68, 0x3e, 0x75, 0xfe, 0x83,
+	0xc6, 0x80, 0x4d, 0x90, 0xe6, 0x74, 0x8e, 0x7e, 0xa6, 0x18, 0xff, 0xb1, 0x3b, 0x48, 0xdf, 0xe6,
+	0xe2, 0x6a, 0x6c, 0x3b, 0x0e, 0xa3, 0x05, 0xfe, 0x7d, 0x0a, 0xcd, 0xbe, 0xe0, 0x13, 0x16, 0x23,
+	0x97, 0x2c, 0x9a, 0x33, 0x99, 0x0f, 0x30, 0xc3, 0xb9, 0x12, 0x79, 0x7d, 0x77, 0x3e, 0x08, 0xfa,
+	0xd6, 0x1e, 0xb1, 0xa0, 0xde, 0x1f, 0x0d, 0x2f, 0x02, 0xcf, 0x1f, 0xde, 0x06, 0xbd, 0x81, 0x65,
+	0x9c, 0x8f, 0xc0, 0x11, 0xe9, 0xd4, 0x9d, 0xe5, 0x09, 0xa6, 0x73, 0x8c, 0xa7, 0x98, 0xba, 0x93,
+	0xe8, 0x3e, 0x65, 0x74, 0xad, 0x42, 0xbd, 0x40, 0x9f, 0xfe, 0x9a, 0x32, 0x39, 0x5b, 0xdd, 0xbb,
+	0x54, 0x2c, 0x3a, 0x3b, 0xd0, 0x4e, 0x01, 0xed, 0x14, 0xd0, 0x8e, 0x82, 0xde, 0x17, 0x8f, 0xd3,
+	0x3f, 0xdf, 0x06, 0x00, 0x6d, 0x13, 0xa5, 0xcf, 0xbb, 0x04, 0x00, 0x00,
 }
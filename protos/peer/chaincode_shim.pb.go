@@ -101,7 +101,7 @@ func (x ChaincodeMessage_Type) String() string {
 	return proto.EnumName(ChaincodeMessage_Type_name, int32(x))
 }
 func (ChaincodeMessage_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{0, 0}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{0, 0}
 }
 
 type ChaincodeMessage struct {
@@ -125,7 +125,7 @@ func (m *ChaincodeMessage) Reset()         { *m = ChaincodeMessage{} }
 func (m *ChaincodeMessage) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeMessage) ProtoMessage()    {}
 func (*ChaincodeMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{0}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{0}
 }
 func (m *ChaincodeMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeMessage.Unmarshal(m, b)
@@ -209,7 +209,7 @@ func (m *GetState) Reset()         { *m = GetState{} }
 func (m *GetState) String() string { return proto.CompactTextString(m) }
 func (*GetState) ProtoMessage()    {}
 func (*GetState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{1}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{1}
 }
 func (m *GetState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetState.Unmarshal(m, b)
@@ -255,7 +255,7 @@ func (m *GetStateMetadata) Reset()         { *m = GetStateMetadata{} }
 func (m *GetStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*GetStateMetadata) ProtoMessage()    {}
 func (*GetStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{2}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{2}
 }
 func (m *GetStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateMetadata.Unmarshal(m, b)
@@ -306,7 +306,7 @@ func (m *PutState) Reset()         { *m = PutState{} }
 func (m *PutState) String() string { return proto.CompactTextString(m) }
 func (*PutState) ProtoMessage()    {}
 func (*PutState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{3}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{3}
 }
 func (m *PutState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutState.Unmarshal(m, b)
@@ -360,7 +360,7 @@ func (m *PutStateMetadata) Reset()         { *m = PutStateMetadata{} }
 func (m *PutStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*PutStateMetadata) ProtoMessage()    {}
 func (*PutStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{4}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{4}
 }
 func (m *PutStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutStateMetadata.Unmarshal(m, b)
@@ -417,7 +417,7 @@ func (m *DelState) Reset()         { *m = DelState{} }
 func (m *DelState) String() string { return proto.CompactTextString(m) }
 func (*DelState) ProtoMessage()    {}
 func (*DelState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{5}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{5}
 }
 func (m *DelState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DelState.Unmarshal(m, b)
@@ -454,12 +454,14 @@ func (m *DelState) GetCollection() string {
 // GetStateByRange is the payload of a ChaincodeMessage. It contains a start key and
 // a end key required to execute range query. If the collection is specified,
 // the range query needs to be executed on the private data. The metadata hold
-// the byte representation of QueryMetadata.
+// the byte representation of QueryMetadata. If reverse is set, the keys are
+// returned in the descending order.
 type GetStateByRange struct {
 	StartKey             string   `protobuf:"bytes,1,opt,name=startKey" json:"startKey,omitempty"`
 	EndKey               string   `protobuf:"bytes,2,opt,name=endKey" json:"endKey,omitempty"`
 	Collection           string   `protobuf:"bytes,3,opt,name=collection" json:"collection,omitempty"`
 	Metadata             []byte   `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Reverse              bool     `protobuf:"varint,5,opt,name=reverse" json:"reverse,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -469,7 +471,7 @@ func (m *GetStateByRange) Reset()         { *m = GetStateByRange{} }
 func (m *GetStateByRange) String() string { return proto.CompactTextString(m) }
 func (*GetStateByRange) ProtoMessage()    {}
 func (*GetStateByRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{6}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{6}
 }
 func (m *GetStateByRange) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateByRange.Unmarshal(m, b)
@@ -517,6 +519,13 @@ func (m *GetStateByRange) GetMetadata() []byte {
 	return nil
 }
 
+func (m *GetStateByRange) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
+}
+
 // GetQueryResult is the payload of a ChaincodeMessage. It contains a query
 // string in the form that is supported by the underlying state database.
 // If the collection is specified, the query needs to be executed on the
@@ -534,7 +543,7 @@ func (m *GetQueryResult) Reset()         { *m = GetQueryResult{} }
 func (m *GetQueryResult) String() string { return proto.CompactTextString(m) }
 func (*GetQueryResult) ProtoMessage()    {}
 func (*GetQueryResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{7}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{7}
 }
 func (m *GetQueryResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetQueryResult.Unmarshal(m, b)
@@ -590,7 +599,7 @@ func (m *QueryMetadata) Reset()         { *m = QueryMetadata{} }
 func (m *QueryMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryMetadata) ProtoMessage()    {}
 func (*QueryMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{8}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{8}
 }
 func (m *QueryMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryMetadata.Unmarshal(m, b)
@@ -637,7 +646,7 @@ func (m *GetHistoryForKey) Reset()         { *m = GetHistoryForKey{} }
 func (m *GetHistoryForKey) String() string { return proto.CompactTextString(m) }
 func (*GetHistoryForKey) ProtoMessage()    {}
 func (*GetHistoryForKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{9}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{9}
 }
 func (m *GetHistoryForKey) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHistoryForKey.Unmarshal(m, b)
@@ -675,7 +684,7 @@ func (m *QueryStateNext) Reset()         { *m = QueryStateNext{} }
 func (m *QueryStateNext) String() string { return proto.CompactTextString(m) }
 func (*QueryStateNext) ProtoMessage()    {}
 func (*QueryStateNext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{10}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{10}
 }
 func (m *QueryStateNext) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateNext.Unmarshal(m, b)
@@ -713,7 +722,7 @@ func (m *QueryStateClose) Reset()         { *m = QueryStateClose{} }
 func (m *QueryStateClose) String() string { return proto.CompactTextString(m) }
 func (*QueryStateClose) ProtoMessage()    {}
 func (*QueryStateClose) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{11}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{11}
 }
 func (m *QueryStateClose) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateClose.Unmarshal(m, b)
@@ -752,7 +761,7 @@ func (m *QueryResultBytes) Reset()         { *m = QueryResultBytes{} }
 func (m *QueryResultBytes) String() string { return proto.CompactTextString(m) }
 func (*QueryResultBytes) ProtoMessage()    {}
 func (*QueryResultBytes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{12}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{12}
 }
 func (m *QueryResultBytes) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResultBytes.Unmarshal(m, b)
@@ -782,23 +791,27 @@ func (m *QueryResultBytes) GetResultBytes() []byte {
 // QueryResponse is returned by the peer as a result of a GetStateByRange,
 // GetQueryResult, and GetHistoryForKey. It holds a bunch of records in
 // results field, a flag to denote whether more results need to be fetched from
-// the peer in has_more field, transaction id in id field, and a QueryResponseMetadata
-// in metadata field.
+// the peer in has_more field, transaction id in id field, a QueryResponseMetadata
+// in metadata field, and a flag to denote whether the results were truncated by
+// the query limit of the peer in truncated field.
 type QueryResponse struct {
-	Results              []*QueryResultBytes `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
-	HasMore              bool                `protobuf:"varint,2,opt,name=has_more,json=hasMore" json:"has_more,omitempty"`
-	Id                   string              `protobuf:"bytes,3,opt,name=id" json:"id,omitempty"`
-	Metadata             []byte              `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	Results  []*QueryResultBytes `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+	HasMore  bool                `protobuf:"varint,2,opt,name=has_more,json=hasMore" json:"has_more,omitempty"`
+	Id       string              `protobuf:"bytes,3,opt,name=id" json:"id,omitempty"`
+	Metadata []byte              `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// truncated is set on the last response of a query whose results were
+	// capped by the query limit of the peer
+	Truncated            bool     `protobuf:"varint,5,opt,name=truncated" json:"truncated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
 func (m *QueryResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()    {}
 func (*QueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{13}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{13}
 }
 func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponse.Unmarshal(m, b)
@@ -846,6 +859,13 @@ func (m *QueryResponse) GetMetadata() []byte {
 	return nil
 }
 
+func (m *QueryResponse) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
+}
+
 // QueryResponseMetadata is the metadata of a QueryResponse. It contains a count
 // which denotes the number of records fetched from the ledger and a bookmark.
 type QueryResponseMetadata struct {
@@ -860,7 +880,7 @@ func (m *QueryResponseMetadata) Reset()         { *m = QueryResponseMetadata{} }
 func (m *QueryResponseMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryResponseMetadata) ProtoMessage()    {}
 func (*QueryResponseMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{14}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{14}
 }
 func (m *QueryResponseMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponseMetadata.Unmarshal(m, b)
@@ -906,7 +926,7 @@ func (m *StateMetadata) Reset()         { *m = StateMetadata{} }
 func (m *StateMetadata) String() string { return proto.CompactTextString(m) }
 func (*StateMetadata) ProtoMessage()    {}
 func (*StateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{15}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{15}
 }
 func (m *StateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadata.Unmarshal(m, b)
@@ -951,7 +971,7 @@ func (m *StateMetadataResult) Reset()         { *m = StateMetadataResult{} }
 func (m *StateMetadataResult) String() string { return proto.CompactTextString(m) }
 func (*StateMetadataResult) ProtoMessage()    {}
 func (*StateMetadataResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{16}
+	return fileDescriptor_chaincode_shim_76fea2a98aa3c6fc, []int{16}
 }
 func (m *StateMetadataResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadataResult.Unmarshal(m, b)
@@ -1103,74 +1123,172 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 	Metadata: "peer/chaincode_shim.proto",
 }
 
+// Client API for Chaincode service
+
+type ChaincodeClient interface {
+	Connect(ctx context.Context, opts ...grpc.CallOption) (Chaincode_ConnectClient, error)
+}
+
+type chaincodeClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChaincodeClient(cc *grpc.ClientConn) ChaincodeClient {
+	return &chaincodeClient{cc}
+}
+
+func (c *chaincodeClient) Connect(ctx context.Context, opts ...grpc.CallOption) (Chaincode_ConnectClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Chaincode_serviceDesc.Streams[0], c.cc, "/protos.Chaincode/Connect", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chaincodeConnectClient{stream}
+	return x, nil
+}
+
+type Chaincode_ConnectClient interface {
+	Send(*ChaincodeMessage) error
+	Recv() (*ChaincodeMessage, error)
+	grpc.ClientStream
+}
+
+type chaincodeConnectClient struct {
+	grpc.ClientStream
+}
+
+func (x *chaincodeConnectClient) Send(m *ChaincodeMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *chaincodeConnectClient) Recv() (*ChaincodeMessage, error) {
+	m := new(ChaincodeMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for Chaincode service
+
+type ChaincodeServer interface {
+	Connect(Chaincode_ConnectServer) error
+}
+
+func RegisterChaincodeServer(s *grpc.Server, srv ChaincodeServer) {
+	s.RegisterService(&_Chaincode_serviceDesc, srv)
+}
+
+func _Chaincode_Connect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ChaincodeServer).Connect(&chaincodeConnectServer{stream})
+}
+
+type Chaincode_ConnectServer interface {
+	Send(*ChaincodeMessage) error
+	Recv() (*ChaincodeMessage, error)
+	grpc.ServerStream
+}
+
+type chaincodeConnectServer struct {
+	grpc.ServerStream
+}
+
+func (x *chaincodeConnectServer) Send(m *ChaincodeMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *chaincodeConnectServer) Recv() (*ChaincodeMessage, error) {
+	m := new(ChaincodeMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Chaincode_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protos.Chaincode",
+	HandlerType: (*ChaincodeServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Connect",
+			Handler:       _Chaincode_Connect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "peer/chaincode_shim.proto",
+}
+
 func init() {
-	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_3c6cdf1ba834f673)
-}
-
-var fileDescriptor_chaincode_shim_3c6cdf1ba834f673 = []byte{
-	// 1010 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcf, 0x73, 0xda, 0x46,
-	0x14, 0x0e, 0x06, 0x8c, 0x78, 0xd8, 0x78, 0xb3, 0x8e, 0x5d, 0xc2, 0x4c, 0x5a, 0xca, 0xf4, 0x40,
-	0x2f, 0xd0, 0xd0, 0x1e, 0x7a, 0xe8, 0x4c, 0x06, 0xc3, 0x1a, 0x33, 0xb6, 0x05, 0x59, 0xc9, 0x99,
-	0xb8, 0x17, 0x8d, 0x90, 0xd6, 0x42, 0x63, 0xa1, 0x55, 0xa5, 0x25, 0x0d, 0xbd, 0xf5, 0xda, 0x7f,
-	0xa9, 0x7f, 0x58, 0xaf, 0x9d, 0xd5, 0x2f, 0x03, 0xae, 0x93, 0x4e, 0x4e, 0xe8, 0x7b, 0xef, 0xdb,
-	0xef, 0xfd, 0xda, 0x87, 0x04, 0x2f, 0x03, 0xc6, 0xc2, 0x9e, 0xb5, 0x30, 0x5d, 0xdf, 0xe2, 0x36,
-	0x33, 0xa2, 0x85, 0xbb, 0xec, 0x06, 0x21, 0x17, 0x1c, 0xef, 0xc7, 0x3f, 0x51, 0xb3, 0xb9, 0x43,
-	0x61, 0x1f, 0x98, 0x2f, 0x12, 0x4e, 0xf3, 0x38, 0xf6, 0x05, 0x21, 0x0f, 0x78, 0x64, 0x7a, 0xa9,
-	0xf1, 0x1b, 0x87, 0x73, 0xc7, 0x63, 0xbd, 0x18, 0xcd, 0x57, 0x77, 0x3d, 0xe1, 0x2e, 0x59, 0x24,
-	0xcc, 0x65, 0x90, 0x10, 0xda, 0x7f, 0x97, 0x01, 0x0d, 0x33, 0xbd, 0x6b, 0x16, 0x45, 0xa6, 0xc3,
-	0xf0, 0x6b, 0x28, 0x89, 0x75, 0xc0, 0x1a, 0x85, 0x56, 0xa1, 0x53, 0xef, 0xbf, 0x4a, 0xa8, 0x51,
-	0x77, 0x97, 0xd7, 0xd5, 0xd7, 0x01, 0xa3, 0x31, 0x15, 0xff, 0x0c, 0xd5, 0x5c, 0xba, 0xb1, 0xd7,
-	0x2a, 0x74, 0x6a, 0xfd, 0x66, 0x37, 0x09, 0xde, 0xcd, 0x82, 0x77, 0xf5, 0x8c, 0x41, 0x1f, 0xc8,
-	0xb8, 0x01, 0x95, 0xc0, 0x5c, 0x7b, 0xdc, 0xb4, 0x1b, 0xc5, 0x56, 0xa1, 0x73, 0x40, 0x33, 0x88,
-	0x31, 0x94, 0xc4, 0x47, 0xd7, 0x6e, 0x94, 0x5a, 0x85, 0x4e, 0x95, 0xc6, 0xcf, 0xb8, 0x0f, 0x4a,
-	0x56, 0x62, 0xa3, 0x1c, 0x87, 0x39, 0xcd, 0xd2, 0xd3, 0x5c, 0xc7, 0x67, 0xf6, 0x2c, 0xf5, 0xd2,
-	0x9c, 0x87, 0xdf, 0xc0, 0xd1, 0x4e, 0xcb, 0x1a, 0xfb, 0xdb, 0x47, 0xf3, 0xca, 0x88, 0xf4, 0xd2,
-	0xba, 0xb5, 0x85, 0xf1, 0x2b, 0x00, 0x6b, 0x61, 0xfa, 0x3e, 0xf3, 0x0c, 0xd7, 0x6e, 0x54, 0xe2,
-	0x74, 0xaa, 0xa9, 0x65, 0x62, 0xb7, 0xff, 0xd9, 0x83, 0x92, 0x6c, 0x05, 0x3e, 0x84, 0xea, 0x8d,
-	0x3a, 0x22, 0xe7, 0x13, 0x95, 0x8c, 0xd0, 0x33, 0x7c, 0x00, 0x0a, 0x25, 0xe3, 0x89, 0xa6, 0x13,
-	0x8a, 0x0a, 0xb8, 0x0e, 0x90, 0x21, 0x32, 0x42, 0x7b, 0x58, 0x81, 0xd2, 0x44, 0x9d, 0xe8, 0xa8,
-	0x88, 0xab, 0x50, 0xa6, 0x64, 0x30, 0xba, 0x45, 0x25, 0x7c, 0x04, 0x35, 0x9d, 0x0e, 0x54, 0x6d,
-	0x30, 0xd4, 0x27, 0x53, 0x15, 0x95, 0xa5, 0xe4, 0x70, 0x7a, 0x3d, 0xbb, 0x22, 0x3a, 0x19, 0xa1,
-	0x7d, 0x49, 0x25, 0x94, 0x4e, 0x29, 0xaa, 0x48, 0xcf, 0x98, 0xe8, 0x86, 0xa6, 0x0f, 0x74, 0x82,
-	0x14, 0x09, 0x67, 0x37, 0x19, 0xac, 0x4a, 0x38, 0x22, 0x57, 0x29, 0x04, 0xfc, 0x02, 0xd0, 0x44,
-	0x7d, 0x37, 0xbd, 0x24, 0xc6, 0xf0, 0x62, 0x30, 0x51, 0x87, 0xd3, 0x11, 0x41, 0xb5, 0x24, 0x41,
-	0x6d, 0x36, 0x55, 0x35, 0x82, 0x0e, 0xf1, 0x29, 0xe0, 0x5c, 0xd0, 0x38, 0xbb, 0x35, 0xe8, 0x40,
-	0x1d, 0x13, 0x54, 0x97, 0x67, 0xa5, 0xfd, 0xed, 0x0d, 0xa1, 0xb7, 0x06, 0x25, 0xda, 0xcd, 0x95,
-	0x8e, 0x8e, 0xa4, 0x35, 0xb1, 0x24, 0x7c, 0x95, 0xbc, 0xd7, 0x11, 0xc2, 0x27, 0xf0, 0x7c, 0xd3,
-	0x3a, 0xbc, 0x9a, 0x6a, 0x04, 0x3d, 0x97, 0xd9, 0x5c, 0x12, 0x32, 0x1b, 0x5c, 0x4d, 0xde, 0x11,
-	0x84, 0xf1, 0x57, 0x70, 0x2c, 0x15, 0x2f, 0x26, 0x9a, 0x3e, 0xa5, 0xb7, 0xc6, 0xf9, 0x94, 0x1a,
-	0x97, 0xe4, 0x16, 0x1d, 0x6f, 0xa7, 0x70, 0x4d, 0xf4, 0xc1, 0x68, 0xa0, 0x0f, 0xd0, 0x0b, 0x69,
-	0xcf, 0x8b, 0x7b, 0xb0, 0x9f, 0xb4, 0x7f, 0x01, 0x65, 0xcc, 0x84, 0x26, 0x4c, 0xc1, 0x30, 0x82,
-	0xe2, 0x3d, 0x5b, 0xc7, 0x77, 0xb6, 0x4a, 0xe5, 0x23, 0xfe, 0x1a, 0xc0, 0xe2, 0x9e, 0xc7, 0x2c,
-	0xe1, 0x72, 0x3f, 0xbe, 0x94, 0x55, 0xba, 0x61, 0x69, 0x8f, 0x00, 0x65, 0xa7, 0xaf, 0x99, 0x30,
-	0x6d, 0x53, 0x98, 0x5f, 0xa0, 0x42, 0x41, 0x99, 0xad, 0x9e, 0xcc, 0xe1, 0x05, 0x94, 0x3f, 0x98,
-	0xde, 0x8a, 0xc5, 0x07, 0x0f, 0x68, 0x02, 0x76, 0x34, 0x8b, 0x8f, 0x34, 0x7f, 0x07, 0x94, 0x69,
-	0xfe, 0xef, 0xcc, 0x1e, 0xa9, 0xe0, 0xd7, 0xa0, 0x2c, 0xd3, 0xd3, 0xf1, 0x0e, 0xd5, 0xfa, 0x27,
-	0xf9, 0xae, 0x6c, 0x4a, 0xd3, 0x9c, 0x26, 0x1b, 0x3a, 0x62, 0xde, 0x97, 0x36, 0xf4, 0xcf, 0x02,
-	0x1c, 0x65, 0x1d, 0x3d, 0x5b, 0x53, 0xd3, 0x77, 0x18, 0x6e, 0x82, 0x12, 0x09, 0x33, 0x14, 0x97,
-	0xb9, 0x54, 0x8e, 0xf1, 0x29, 0xec, 0x33, 0xdf, 0x96, 0x9e, 0x44, 0x2b, 0x45, 0x9f, 0x2d, 0xac,
-	0xb9, 0x53, 0xd8, 0xc1, 0x46, 0x05, 0x73, 0xa8, 0x8f, 0x99, 0x78, 0xbb, 0x62, 0xe1, 0x9a, 0xb2,
-	0x68, 0xe5, 0x09, 0x39, 0x82, 0xdf, 0x24, 0x4c, 0xc3, 0x27, 0xe0, 0x73, 0xb5, 0x6c, 0xc5, 0x28,
-	0xee, 0xc4, 0x18, 0xc3, 0x61, 0x1c, 0x20, 0x9f, 0x4d, 0x13, 0x94, 0xc0, 0x74, 0x98, 0xe6, 0xfe,
-	0x91, 0xfc, 0x69, 0x96, 0x69, 0x8e, 0xa5, 0x6f, 0xce, 0xf9, 0xfd, 0xd2, 0x0c, 0xef, 0xd3, 0x30,
-	0x39, 0x6e, 0x7f, 0x17, 0xdf, 0xc0, 0x0b, 0x37, 0x12, 0x3c, 0x5c, 0x9f, 0xf3, 0x50, 0x16, 0xff,
-	0xa8, 0xed, 0xed, 0x16, 0xd4, 0xe3, 0x70, 0x71, 0x5f, 0x55, 0xf6, 0x51, 0xe0, 0x3a, 0xec, 0xb9,
-	0x76, 0x4a, 0xd9, 0x73, 0xed, 0xf6, 0xb7, 0x70, 0xf4, 0xc0, 0x18, 0x7a, 0x3c, 0x62, 0x8f, 0x28,
-	0x3f, 0x01, 0xda, 0x68, 0xca, 0xd9, 0x5a, 0xb0, 0x08, 0xb7, 0xa0, 0x16, 0x3e, 0xc0, 0x98, 0x7c,
-	0x40, 0x37, 0x4d, 0xed, 0xbf, 0x0a, 0x69, 0xa9, 0x94, 0x45, 0x01, 0xf7, 0x23, 0x86, 0xfb, 0x50,
-	0x49, 0x08, 0x92, 0x5f, 0xec, 0xd4, 0xfa, 0x8d, 0xec, 0x4e, 0xed, 0xca, 0xd3, 0x8c, 0x88, 0x5f,
-	0x82, 0xb2, 0x30, 0x23, 0x63, 0xc9, 0xc3, 0x64, 0x0f, 0x14, 0x5a, 0x59, 0x98, 0xd1, 0x35, 0x0f,
-	0xb3, 0x34, 0x8b, 0x59, 0x9a, 0x9f, 0x1c, 0xad, 0x03, 0x27, 0x5b, 0xb9, 0xe4, 0xed, 0xef, 0xc3,
-	0xc9, 0x1d, 0x13, 0xd6, 0x82, 0xd9, 0x46, 0xc8, 0x2c, 0x1e, 0xda, 0x91, 0x61, 0xf1, 0x95, 0x2f,
-	0xd2, 0x59, 0x1c, 0xa7, 0x4e, 0x9a, 0xf8, 0x86, 0xd2, 0xf5, 0xc9, 0xb1, 0xbc, 0x81, 0xc3, 0xed,
-	0xdd, 0x6b, 0x40, 0x45, 0x66, 0xf1, 0x30, 0x97, 0x0c, 0xfe, 0xf7, 0x7e, 0xb7, 0xcf, 0xe1, 0x78,
-	0x7b, 0xc3, 0x92, 0x9b, 0xd8, 0x83, 0x0a, 0xf3, 0x45, 0xe8, 0xb2, 0xac, 0x77, 0x4f, 0xec, 0x63,
-	0xc6, 0xea, 0xbf, 0xdf, 0x78, 0x39, 0x6b, 0xab, 0x20, 0xe0, 0xa1, 0xc0, 0x23, 0x50, 0x28, 0x73,
-	0xdc, 0x48, 0xb0, 0x10, 0x37, 0x9e, 0x7a, 0x35, 0x37, 0x9f, 0xf4, 0xb4, 0x9f, 0x75, 0x0a, 0x3f,
-	0x14, 0xce, 0xa6, 0xd0, 0xe6, 0xa1, 0xd3, 0x5d, 0xac, 0x03, 0x16, 0x7a, 0xcc, 0x76, 0x58, 0xd8,
-	0xbd, 0x33, 0xe7, 0xa1, 0x6b, 0x65, 0xe7, 0xe4, 0xd7, 0xc4, 0xaf, 0xdf, 0x3b, 0xae, 0x58, 0xac,
-	0xe6, 0x5d, 0x8b, 0x2f, 0x7b, 0x1b, 0xd4, 0x5e, 0x42, 0x4d, 0xbe, 0x2a, 0xa2, 0x9e, 0xa4, 0xce,
-	0x93, 0x4f, 0x94, 0x1f, 0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x41, 0xbd, 0x1a, 0x01, 0xc6, 0x08,
-	0x00, 0x00,
+	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_76fea2a98aa3c6fc)
+}
+
+var fileDescriptor_chaincode_shim_76fea2a98aa3c6fc = []byte{
+	// 1054 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xdd, 0x72, 0xda, 0x46,
+	0x1b, 0x0e, 0x06, 0x8c, 0x78, 0xb1, 0xf1, 0x66, 0x1d, 0xe7, 0x23, 0xcc, 0x97, 0x96, 0x6a, 0x7a,
+	0x40, 0x4f, 0xa0, 0xa1, 0x3d, 0xe8, 0x41, 0x67, 0x32, 0x18, 0xd6, 0x84, 0xb1, 0x0d, 0x64, 0x25,
+	0x67, 0xe2, 0x9e, 0x68, 0x84, 0xb4, 0x01, 0x4d, 0x40, 0xab, 0xae, 0x16, 0x37, 0xf4, 0x56, 0x7a,
+	0x03, 0xbd, 0x87, 0x5e, 0x58, 0x4f, 0x3b, 0xab, 0x3f, 0x7e, 0x5c, 0x27, 0x53, 0x1f, 0xc1, 0xf3,
+	0xbe, 0xcf, 0x3e, 0xef, 0xdf, 0xee, 0x6a, 0xe1, 0x45, 0xc0, 0x98, 0x68, 0x3b, 0x73, 0xdb, 0xf3,
+	0x1d, 0xee, 0x32, 0x2b, 0x9c, 0x7b, 0xcb, 0x56, 0x20, 0xb8, 0xe4, 0xf8, 0x30, 0xfa, 0x09, 0xeb,
+	0xf5, 0x3d, 0x0a, 0xbb, 0x63, 0xbe, 0x8c, 0x39, 0xf5, 0xd3, 0xc8, 0x17, 0x08, 0x1e, 0xf0, 0xd0,
+	0x5e, 0x24, 0xc6, 0xaf, 0x67, 0x9c, 0xcf, 0x16, 0xac, 0x1d, 0xa1, 0xe9, 0xea, 0x43, 0x5b, 0x7a,
+	0x4b, 0x16, 0x4a, 0x7b, 0x19, 0xc4, 0x04, 0xfd, 0xaf, 0x22, 0xa0, 0x5e, 0xaa, 0x77, 0xcd, 0xc2,
+	0xd0, 0x9e, 0x31, 0xfc, 0x0a, 0x0a, 0x72, 0x1d, 0xb0, 0x5a, 0xae, 0x91, 0x6b, 0x56, 0x3b, 0x2f,
+	0x63, 0x6a, 0xd8, 0xda, 0xe7, 0xb5, 0xcc, 0x75, 0xc0, 0x68, 0x44, 0xc5, 0x3f, 0x41, 0x39, 0x93,
+	0xae, 0x1d, 0x34, 0x72, 0xcd, 0x4a, 0xa7, 0xde, 0x8a, 0x83, 0xb7, 0xd2, 0xe0, 0x2d, 0x33, 0x65,
+	0xd0, 0x0d, 0x19, 0xd7, 0xa0, 0x14, 0xd8, 0xeb, 0x05, 0xb7, 0xdd, 0x5a, 0xbe, 0x91, 0x6b, 0x1e,
+	0xd1, 0x14, 0x62, 0x0c, 0x05, 0xf9, 0xc9, 0x73, 0x6b, 0x85, 0x46, 0xae, 0x59, 0xa6, 0xd1, 0x7f,
+	0xdc, 0x01, 0x2d, 0x2d, 0xb1, 0x56, 0x8c, 0xc2, 0x3c, 0x4f, 0xd3, 0x33, 0xbc, 0x99, 0xcf, 0xdc,
+	0x49, 0xe2, 0xa5, 0x19, 0x0f, 0xbf, 0x86, 0x93, 0xbd, 0x96, 0xd5, 0x0e, 0x77, 0x97, 0x66, 0x95,
+	0x11, 0xe5, 0xa5, 0x55, 0x67, 0x07, 0xe3, 0x97, 0x00, 0xce, 0xdc, 0xf6, 0x7d, 0xb6, 0xb0, 0x3c,
+	0xb7, 0x56, 0x8a, 0xd2, 0x29, 0x27, 0x96, 0xa1, 0xab, 0xff, 0x7d, 0x00, 0x05, 0xd5, 0x0a, 0x7c,
+	0x0c, 0xe5, 0x9b, 0x51, 0x9f, 0x5c, 0x0c, 0x47, 0xa4, 0x8f, 0x9e, 0xe0, 0x23, 0xd0, 0x28, 0x19,
+	0x0c, 0x0d, 0x93, 0x50, 0x94, 0xc3, 0x55, 0x80, 0x14, 0x91, 0x3e, 0x3a, 0xc0, 0x1a, 0x14, 0x86,
+	0xa3, 0xa1, 0x89, 0xf2, 0xb8, 0x0c, 0x45, 0x4a, 0xba, 0xfd, 0x5b, 0x54, 0xc0, 0x27, 0x50, 0x31,
+	0x69, 0x77, 0x64, 0x74, 0x7b, 0xe6, 0x70, 0x3c, 0x42, 0x45, 0x25, 0xd9, 0x1b, 0x5f, 0x4f, 0xae,
+	0x88, 0x49, 0xfa, 0xe8, 0x50, 0x51, 0x09, 0xa5, 0x63, 0x8a, 0x4a, 0xca, 0x33, 0x20, 0xa6, 0x65,
+	0x98, 0x5d, 0x93, 0x20, 0x4d, 0xc1, 0xc9, 0x4d, 0x0a, 0xcb, 0x0a, 0xf6, 0xc9, 0x55, 0x02, 0x01,
+	0x3f, 0x03, 0x34, 0x1c, 0xbd, 0x1b, 0x5f, 0x12, 0xab, 0xf7, 0xa6, 0x3b, 0x1c, 0xf5, 0xc6, 0x7d,
+	0x82, 0x2a, 0x71, 0x82, 0xc6, 0x64, 0x3c, 0x32, 0x08, 0x3a, 0xc6, 0xcf, 0x01, 0x67, 0x82, 0xd6,
+	0xf9, 0xad, 0x45, 0xbb, 0xa3, 0x01, 0x41, 0x55, 0xb5, 0x56, 0xd9, 0xdf, 0xde, 0x10, 0x7a, 0x6b,
+	0x51, 0x62, 0xdc, 0x5c, 0x99, 0xe8, 0x44, 0x59, 0x63, 0x4b, 0xcc, 0x1f, 0x91, 0xf7, 0x26, 0x42,
+	0xf8, 0x0c, 0x9e, 0x6e, 0x5b, 0x7b, 0x57, 0x63, 0x83, 0xa0, 0xa7, 0x2a, 0x9b, 0x4b, 0x42, 0x26,
+	0xdd, 0xab, 0xe1, 0x3b, 0x82, 0x30, 0xfe, 0x1f, 0x9c, 0x2a, 0xc5, 0x37, 0x43, 0xc3, 0x1c, 0xd3,
+	0x5b, 0xeb, 0x62, 0x4c, 0xad, 0x4b, 0x72, 0x8b, 0x4e, 0x77, 0x53, 0xb8, 0x26, 0x66, 0xb7, 0xdf,
+	0x35, 0xbb, 0xe8, 0x99, 0xb2, 0x4f, 0x6e, 0xee, 0xd9, 0xcf, 0xf4, 0x9f, 0x41, 0x1b, 0x30, 0x69,
+	0x48, 0x5b, 0x32, 0x8c, 0x20, 0xff, 0x91, 0xad, 0xa3, 0x3d, 0x5b, 0xa6, 0xea, 0x2f, 0xfe, 0x0a,
+	0xc0, 0xe1, 0x8b, 0x05, 0x73, 0xa4, 0xc7, 0xfd, 0x68, 0x53, 0x96, 0xe9, 0x96, 0x45, 0xef, 0x03,
+	0x4a, 0x57, 0x5f, 0x33, 0x69, 0xbb, 0xb6, 0xb4, 0x1f, 0xa1, 0x42, 0x41, 0x9b, 0xac, 0x1e, 0xcc,
+	0xe1, 0x19, 0x14, 0xef, 0xec, 0xc5, 0x8a, 0x45, 0x0b, 0x8f, 0x68, 0x0c, 0xf6, 0x34, 0xf3, 0xf7,
+	0x34, 0x7f, 0x03, 0x34, 0x59, 0xfd, 0xc7, 0xcc, 0xee, 0xa9, 0xe0, 0x57, 0xa0, 0x2d, 0x93, 0xd5,
+	0xd1, 0x19, 0xaa, 0x74, 0xce, 0xb2, 0xb3, 0xb2, 0x2d, 0x4d, 0x33, 0x9a, 0x6a, 0x68, 0x9f, 0x2d,
+	0x1e, 0xdb, 0xd0, 0x3f, 0x72, 0x70, 0x92, 0x76, 0xf4, 0x7c, 0x4d, 0x6d, 0x7f, 0xc6, 0x70, 0x1d,
+	0xb4, 0x50, 0xda, 0x42, 0x5e, 0x66, 0x52, 0x19, 0xc6, 0xcf, 0xe1, 0x90, 0xf9, 0xae, 0xf2, 0xc4,
+	0x5a, 0x09, 0xfa, 0x62, 0x61, 0xf5, 0xbd, 0xc2, 0x8e, 0x36, 0x15, 0xa8, 0xeb, 0x44, 0xb0, 0x3b,
+	0x26, 0x42, 0x16, 0xdd, 0x0f, 0x1a, 0x4d, 0xa1, 0x3e, 0x85, 0xea, 0x80, 0xc9, 0xb7, 0x2b, 0x26,
+	0xd6, 0x94, 0x85, 0xab, 0x85, 0x54, 0xc3, 0xf9, 0x55, 0xc1, 0x24, 0xb1, 0x18, 0x7c, 0xa9, 0xca,
+	0x9d, 0xe8, 0xf9, 0xdd, 0xe8, 0xfa, 0x00, 0x8e, 0xa3, 0x00, 0xd9, 0xd4, 0xea, 0xa0, 0x05, 0xf6,
+	0x8c, 0x19, 0xde, 0xef, 0xf1, 0x75, 0x5a, 0xa4, 0x19, 0x56, 0xbe, 0x29, 0xe7, 0x1f, 0x97, 0xb6,
+	0xf8, 0x98, 0x84, 0xc9, 0xb0, 0xfe, 0x6d, 0xb4, 0x37, 0xdf, 0x78, 0xa1, 0xe4, 0x62, 0x7d, 0xc1,
+	0x85, 0x6a, 0xcb, 0xbd, 0x81, 0xe8, 0x0d, 0xa8, 0x46, 0xe1, 0xa2, 0x8e, 0x8f, 0xd8, 0x27, 0x89,
+	0xab, 0x70, 0xe0, 0xb9, 0x09, 0xe5, 0xc0, 0x73, 0xf5, 0x6f, 0xe0, 0x64, 0xc3, 0xe8, 0x2d, 0x78,
+	0xc8, 0xee, 0x51, 0x7e, 0x04, 0xb4, 0xd5, 0x94, 0xf3, 0xb5, 0x64, 0x21, 0x6e, 0x40, 0x45, 0x6c,
+	0x60, 0x44, 0x3e, 0xa2, 0xdb, 0x26, 0xfd, 0xcf, 0x5c, 0x52, 0x2a, 0x65, 0x61, 0xc0, 0xfd, 0x90,
+	0xe1, 0x0e, 0x94, 0x62, 0x82, 0xe2, 0xe7, 0x9b, 0x95, 0x4e, 0x2d, 0xdd, 0x6d, 0xfb, 0xf2, 0x34,
+	0x25, 0xe2, 0x17, 0xa0, 0xcd, 0xed, 0xd0, 0x5a, 0x72, 0x11, 0x9f, 0x10, 0x8d, 0x96, 0xe6, 0x76,
+	0x78, 0xcd, 0x45, 0x9a, 0x66, 0x3e, 0x4d, 0xf3, 0xb3, 0x43, 0xff, 0x3f, 0x94, 0xa5, 0x58, 0xf9,
+	0x8e, 0x2d, 0x99, 0x9b, 0x8c, 0x7d, 0x63, 0xd0, 0x67, 0x70, 0xb6, 0x93, 0x69, 0x36, 0x9c, 0x0e,
+	0x9c, 0x7d, 0x60, 0xd2, 0x99, 0x33, 0xd7, 0x12, 0xcc, 0xe1, 0xc2, 0x0d, 0x2d, 0x87, 0xaf, 0x7c,
+	0x99, 0x4c, 0xea, 0x34, 0x71, 0xd2, 0xd8, 0xd7, 0x53, 0xae, 0xcf, 0x0e, 0xed, 0x35, 0x1c, 0xef,
+	0x9e, 0xd9, 0x1a, 0x94, 0x54, 0x8e, 0x9b, 0xa9, 0xa5, 0xf0, 0xdf, 0xef, 0x05, 0xfd, 0x02, 0x4e,
+	0x77, 0x4f, 0x66, 0xbc, 0x4f, 0xdb, 0x50, 0x62, 0xbe, 0x14, 0x1e, 0x4b, 0x3b, 0xfb, 0xc0, 0x39,
+	0x4e, 0x59, 0x9d, 0xf7, 0x5b, 0x1f, 0x75, 0x63, 0x15, 0x04, 0x5c, 0x48, 0xdc, 0x07, 0x8d, 0xb2,
+	0x99, 0x17, 0x4a, 0x26, 0x70, 0xed, 0xa1, 0x4f, 0x7a, 0xfd, 0x41, 0x8f, 0xfe, 0xa4, 0x99, 0xfb,
+	0x3e, 0xd7, 0x99, 0x40, 0x39, 0xf3, 0xe0, 0x1e, 0x94, 0x7a, 0xdc, 0xf7, 0x99, 0x23, 0x1f, 0xaf,
+	0x78, 0x3e, 0x06, 0x9d, 0x8b, 0x59, 0x6b, 0xbe, 0x0e, 0x98, 0x58, 0x30, 0x77, 0xc6, 0x44, 0xeb,
+	0x83, 0x3d, 0x15, 0x9e, 0x93, 0xae, 0x53, 0xef, 0x9a, 0x5f, 0xbe, 0x9b, 0x79, 0x72, 0xbe, 0x9a,
+	0xb6, 0x1c, 0xbe, 0x6c, 0x6f, 0x51, 0xdb, 0x31, 0x35, 0x7e, 0xdf, 0x84, 0x6d, 0x45, 0x9d, 0xc6,
+	0x8f, 0xa5, 0x1f, 0xfe, 0x19, 0x00, 0x2b, 0x1a, 0x2f, 0x2e, 0x50, 0x09, 0x00, 0x00,
 }
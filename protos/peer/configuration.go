@@ -103,6 +103,12 @@ func (ccv *DynamicApplicationConfigValue) VariablyOpaqueFieldProto(name string)
 		return &common.Capabilities{}, nil
 	case "ACLs":
 		return &ACLs{}, nil
+	case "ChannelState":
+		return &ChannelState{}, nil
+	case "TxValidityWindow":
+		return &TxValidityWindow{}, nil
+	case "RangeQueryValidation":
+		return &RangeQueryValidation{}, nil
 	default:
 		return nil, fmt.Errorf("Unknown Application ConfigValue name: %s", ccv.name)
 	}
@@ -122,6 +128,8 @@ func (daocv *DynamicApplicationOrgConfigValue) VariablyOpaqueFieldProto(name str
 		return &msp.MSPConfig{}, nil
 	case "AnchorPeers":
 		return &AnchorPeers{}, nil
+	case "PeerEndpoints":
+		return &common.PeerEndpoints{}, nil
 	default:
 		return nil, fmt.Errorf("Unknown Application Org ConfigValue name: %s", daocv.name)
 	}
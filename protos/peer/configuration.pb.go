@@ -18,6 +18,29 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type ChannelState_State int32
+
+const (
+	ChannelState_NORMAL      ChannelState_State = 0
+	ChannelState_MAINTENANCE ChannelState_State = 1
+)
+
+var ChannelState_State_name = map[int32]string{
+	0: "NORMAL",
+	1: "MAINTENANCE",
+}
+var ChannelState_State_value = map[string]int32{
+	"NORMAL":      0,
+	"MAINTENANCE": 1,
+}
+
+func (x ChannelState_State) String() string {
+	return proto.EnumName(ChannelState_State_name, int32(x))
+}
+func (ChannelState_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_2ae46d2114b74524, []int{4, 0}
+}
+
 // AnchorPeers simply represents list of anchor peers which is used in ConfigurationItem
 type AnchorPeers struct {
 	AnchorPeers          []*AnchorPeer `protobuf:"bytes,1,rep,name=anchor_peers,json=anchorPeers" json:"anchor_peers,omitempty"`
@@ -30,7 +53,7 @@ func (m *AnchorPeers) Reset()         { *m = AnchorPeers{} }
 func (m *AnchorPeers) String() string { return proto.CompactTextString(m) }
 func (*AnchorPeers) ProtoMessage()    {}
 func (*AnchorPeers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_e51d0015b9cb595d, []int{0}
+	return fileDescriptor_configuration_2ae46d2114b74524, []int{0}
 }
 func (m *AnchorPeers) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnchorPeers.Unmarshal(m, b)
@@ -73,7 +96,7 @@ func (m *AnchorPeer) Reset()         { *m = AnchorPeer{} }
 func (m *AnchorPeer) String() string { return proto.CompactTextString(m) }
 func (*AnchorPeer) ProtoMessage()    {}
 func (*AnchorPeer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_e51d0015b9cb595d, []int{1}
+	return fileDescriptor_configuration_2ae46d2114b74524, []int{1}
 }
 func (m *AnchorPeer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnchorPeer.Unmarshal(m, b)
@@ -120,7 +143,7 @@ func (m *APIResource) Reset()         { *m = APIResource{} }
 func (m *APIResource) String() string { return proto.CompactTextString(m) }
 func (*APIResource) ProtoMessage()    {}
 func (*APIResource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_e51d0015b9cb595d, []int{2}
+	return fileDescriptor_configuration_2ae46d2114b74524, []int{2}
 }
 func (m *APIResource) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_APIResource.Unmarshal(m, b)
@@ -160,7 +183,7 @@ func (m *ACLs) Reset()         { *m = ACLs{} }
 func (m *ACLs) String() string { return proto.CompactTextString(m) }
 func (*ACLs) ProtoMessage()    {}
 func (*ACLs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_e51d0015b9cb595d, []int{3}
+	return fileDescriptor_configuration_2ae46d2114b74524, []int{3}
 }
 func (m *ACLs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ACLs.Unmarshal(m, b)
@@ -187,37 +210,176 @@ func (m *ACLs) GetAcls() map[string]*APIResource {
 	return nil
 }
 
+// ChannelState is the state of an application channel. While a channel is in
+// maintenance, the orderers and the peers reject its transactions except for
+// the config transactions.
+type ChannelState struct {
+	State                ChannelState_State `protobuf:"varint,1,opt,name=state,enum=protos.ChannelState_State" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ChannelState) Reset()         { *m = ChannelState{} }
+func (m *ChannelState) String() string { return proto.CompactTextString(m) }
+func (*ChannelState) ProtoMessage()    {}
+func (*ChannelState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_2ae46d2114b74524, []int{4}
+}
+func (m *ChannelState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelState.Unmarshal(m, b)
+}
+func (m *ChannelState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelState.Marshal(b, m, deterministic)
+}
+func (dst *ChannelState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelState.Merge(dst, src)
+}
+func (m *ChannelState) XXX_Size() int {
+	return xxx_messageInfo_ChannelState.Size(m)
+}
+func (m *ChannelState) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelState proto.InternalMessageInfo
+
+func (m *ChannelState) GetState() ChannelState_State {
+	if m != nil {
+		return m.State
+	}
+	return ChannelState_NORMAL
+}
+
+// TxValidityWindow configures the enforcement of the validity windows of the
+// transactions of an application channel
+type TxValidityWindow struct {
+	// The tolerance, as a duration such as "30s", extending both bounds of
+	// the windows to account for the clock skew between the clients and the
+	// orderers
+	MaxClockSkew         string   `protobuf:"bytes,1,opt,name=max_clock_skew,json=maxClockSkew" json:"max_clock_skew,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxValidityWindow) Reset()         { *m = TxValidityWindow{} }
+func (m *TxValidityWindow) String() string { return proto.CompactTextString(m) }
+func (*TxValidityWindow) ProtoMessage()    {}
+func (*TxValidityWindow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_2ae46d2114b74524, []int{5}
+}
+func (m *TxValidityWindow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxValidityWindow.Unmarshal(m, b)
+}
+func (m *TxValidityWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxValidityWindow.Marshal(b, m, deterministic)
+}
+func (dst *TxValidityWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxValidityWindow.Merge(dst, src)
+}
+func (m *TxValidityWindow) XXX_Size() int {
+	return xxx_messageInfo_TxValidityWindow.Size(m)
+}
+func (m *TxValidityWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxValidityWindow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxValidityWindow proto.InternalMessageInfo
+
+func (m *TxValidityWindow) GetMaxClockSkew() string {
+	if m != nil {
+		return m.MaxClockSkew
+	}
+	return ""
+}
+
+// RangeQueryValidation configures the validation of the range queries of the
+// transactions of an application channel
+type RangeQueryValidation struct {
+	// The max number of results a peer goes through to validate a range
+	// query, as bounded by the results or the summary of the results recorded
+	// in the read set. Zero means no limit.
+	MaxResults           uint32   `protobuf:"varint,1,opt,name=max_results,json=maxResults" json:"max_results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RangeQueryValidation) Reset()         { *m = RangeQueryValidation{} }
+func (m *RangeQueryValidation) String() string { return proto.CompactTextString(m) }
+func (*RangeQueryValidation) ProtoMessage()    {}
+func (*RangeQueryValidation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_2ae46d2114b74524, []int{6}
+}
+func (m *RangeQueryValidation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RangeQueryValidation.Unmarshal(m, b)
+}
+func (m *RangeQueryValidation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RangeQueryValidation.Marshal(b, m, deterministic)
+}
+func (dst *RangeQueryValidation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RangeQueryValidation.Merge(dst, src)
+}
+func (m *RangeQueryValidation) XXX_Size() int {
+	return xxx_messageInfo_RangeQueryValidation.Size(m)
+}
+func (m *RangeQueryValidation) XXX_DiscardUnknown() {
+	xxx_messageInfo_RangeQueryValidation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RangeQueryValidation proto.InternalMessageInfo
+
+func (m *RangeQueryValidation) GetMaxResults() uint32 {
+	if m != nil {
+		return m.MaxResults
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*AnchorPeers)(nil), "protos.AnchorPeers")
 	proto.RegisterType((*AnchorPeer)(nil), "protos.AnchorPeer")
 	proto.RegisterType((*APIResource)(nil), "protos.APIResource")
 	proto.RegisterType((*ACLs)(nil), "protos.ACLs")
 	proto.RegisterMapType((map[string]*APIResource)(nil), "protos.ACLs.AclsEntry")
+	proto.RegisterType((*ChannelState)(nil), "protos.ChannelState")
+	proto.RegisterType((*TxValidityWindow)(nil), "protos.TxValidityWindow")
+	proto.RegisterType((*RangeQueryValidation)(nil), "protos.RangeQueryValidation")
+	proto.RegisterEnum("protos.ChannelState_State", ChannelState_State_name, ChannelState_State_value)
 }
 
 func init() {
-	proto.RegisterFile("peer/configuration.proto", fileDescriptor_configuration_e51d0015b9cb595d)
-}
-
-var fileDescriptor_configuration_e51d0015b9cb595d = []byte{
-	// 295 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x91, 0xdf, 0x4b, 0xfb, 0x30,
-	0x14, 0xc5, 0xe9, 0x7e, 0x7c, 0x61, 0xb7, 0xdf, 0x07, 0x89, 0x20, 0x45, 0x10, 0x46, 0x9f, 0x36,
-	0x91, 0x14, 0xa6, 0x82, 0xf8, 0x56, 0xa7, 0x0f, 0xc2, 0xc0, 0x91, 0x47, 0x5f, 0x46, 0x16, 0x6f,
-	0x7f, 0x60, 0x6d, 0xca, 0x4d, 0x2a, 0xf4, 0xcd, 0x3f, 0x5d, 0x9a, 0x6c, 0xab, 0x4f, 0x39, 0x39,
-	0xf9, 0x9c, 0xcb, 0x21, 0x17, 0xa2, 0x06, 0x91, 0x12, 0xa5, 0xeb, 0xac, 0xcc, 0x5b, 0x92, 0xb6,
-	0xd4, 0x35, 0x6f, 0x48, 0x5b, 0xcd, 0xfe, 0xb9, 0xc3, 0xc4, 0xcf, 0x10, 0xa6, 0xb5, 0x2a, 0x34,
-	0x6d, 0x11, 0xc9, 0xb0, 0x7b, 0xf8, 0x2f, 0xdd, 0x75, 0xd7, 0x27, 0x4d, 0x14, 0xcc, 0xc7, 0x8b,
-	0x70, 0xc5, 0x7c, 0xc8, 0xf0, 0x01, 0x15, 0xa1, 0x1c, 0x62, 0xf1, 0x1d, 0xc0, 0xf0, 0xc4, 0x18,
-	0x4c, 0x0a, 0x6d, 0x6c, 0x14, 0xcc, 0x83, 0xc5, 0x4c, 0x38, 0xdd, 0x7b, 0x8d, 0x26, 0x1b, 0x8d,
-	0xe6, 0xc1, 0x62, 0x2a, 0x9c, 0x8e, 0x6f, 0x20, 0x4c, 0xb7, 0xaf, 0x02, 0x8d, 0x6e, 0x49, 0x21,
-	0xbb, 0x02, 0x68, 0x74, 0x55, 0xaa, 0x6e, 0x47, 0x98, 0x1d, 0xc2, 0x33, 0xef, 0x08, 0xcc, 0xe2,
-	0x9f, 0x00, 0x26, 0xe9, 0x7a, 0x63, 0xd8, 0x35, 0x4c, 0xa4, 0xaa, 0x8e, 0xdd, 0x2e, 0x4e, 0xdd,
-	0xd6, 0x1b, 0xc3, 0x53, 0x55, 0x99, 0x97, 0xda, 0x52, 0x27, 0x1c, 0x73, 0xb9, 0x81, 0xd9, 0xc9,
-	0x62, 0x67, 0x30, 0xfe, 0xc4, 0xee, 0x30, 0xb9, 0x97, 0x6c, 0x09, 0xd3, 0x6f, 0x59, 0xb5, 0xe8,
-	0x6a, 0x85, 0xab, 0xf3, 0xd3, 0xac, 0xa1, 0x96, 0xf0, 0xc4, 0xe3, 0xe8, 0x21, 0x78, 0x7a, 0x83,
-	0x58, 0x53, 0xce, 0x8b, 0xae, 0x41, 0xaa, 0xf0, 0x23, 0x47, 0xe2, 0x99, 0xdc, 0x53, 0xa9, 0x8e,
-	0xb9, 0xfe, 0xd3, 0xde, 0x97, 0x79, 0x69, 0x8b, 0x76, 0xcf, 0x95, 0xfe, 0x4a, 0xfe, 0xa0, 0x89,
-	0x47, 0x13, 0x8f, 0x26, 0x3d, 0xba, 0xf7, 0x5b, 0xb8, 0xfd, 0x0d, 0x00, 0x00, 0xff, 0xff, 0xae,
-	0x0a, 0x1d, 0x41, 0xa8, 0x01, 0x00, 0x00,
+	proto.RegisterFile("peer/configuration.proto", fileDescriptor_configuration_2ae46d2114b74524)
+}
+
+var fileDescriptor_configuration_2ae46d2114b74524 = []byte{
+	// 432 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x92, 0xcf, 0x6b, 0xdb, 0x30,
+	0x14, 0xc7, 0xe7, 0x36, 0x29, 0xe4, 0x39, 0xeb, 0x82, 0x36, 0x46, 0x28, 0x8c, 0x05, 0xd3, 0x43,
+	0x3a, 0x86, 0x33, 0xb2, 0x8d, 0x95, 0xdd, 0x3c, 0x2f, 0x87, 0x42, 0x9a, 0x76, 0x6a, 0xd9, 0x60,
+	0x97, 0xa0, 0x28, 0xcf, 0x3f, 0x88, 0x62, 0x19, 0x49, 0x5e, 0xe2, 0xdb, 0xfe, 0xf4, 0x62, 0x29,
+	0x3f, 0x7a, 0xb1, 0x9f, 0xbf, 0xfe, 0x7c, 0x1e, 0x4f, 0xe8, 0x41, 0xbf, 0x44, 0x54, 0x23, 0x2e,
+	0x8b, 0x24, 0x4f, 0x2b, 0xc5, 0x4c, 0x2e, 0x8b, 0xb0, 0x54, 0xd2, 0x48, 0x72, 0x66, 0x5f, 0x3a,
+	0xf8, 0x09, 0x7e, 0x54, 0xf0, 0x4c, 0xaa, 0x7b, 0x44, 0xa5, 0xc9, 0x57, 0xe8, 0x32, 0xfb, 0x39,
+	0x6f, 0x4c, 0xdd, 0xf7, 0x06, 0xa7, 0x43, 0x7f, 0x4c, 0x9c, 0xa4, 0xc3, 0x23, 0x4a, 0x7d, 0x76,
+	0xd4, 0x82, 0x2f, 0x00, 0xc7, 0x5f, 0x84, 0x40, 0x2b, 0x93, 0xda, 0xf4, 0xbd, 0x81, 0x37, 0xec,
+	0x50, 0x5b, 0x37, 0x59, 0x29, 0x95, 0xe9, 0x9f, 0x0c, 0xbc, 0x61, 0x9b, 0xda, 0x3a, 0xf8, 0x08,
+	0x7e, 0x74, 0x7f, 0x43, 0x51, 0xcb, 0x4a, 0x71, 0x24, 0xef, 0x00, 0x4a, 0x29, 0x72, 0x5e, 0xcf,
+	0x15, 0x26, 0x3b, 0xb9, 0xe3, 0x12, 0x8a, 0x49, 0xf0, 0xdf, 0x83, 0x56, 0x14, 0x4f, 0x35, 0xf9,
+	0x00, 0x2d, 0xc6, 0xc5, 0x7e, 0xb6, 0xb7, 0x87, 0xd9, 0xe2, 0xa9, 0x0e, 0x23, 0x2e, 0xf4, 0xa4,
+	0x30, 0xaa, 0xa6, 0x96, 0xb9, 0x98, 0x42, 0xe7, 0x10, 0x91, 0x1e, 0x9c, 0xae, 0xb0, 0xde, 0x75,
+	0x6e, 0x4a, 0x72, 0x05, 0xed, 0x7f, 0x4c, 0x54, 0x68, 0xc7, 0xf2, 0xc7, 0xaf, 0x0f, 0xbd, 0x8e,
+	0x63, 0x51, 0x47, 0x7c, 0x3f, 0xb9, 0xf6, 0x82, 0x04, 0xba, 0x71, 0xc6, 0x8a, 0x02, 0xc5, 0x83,
+	0x61, 0x06, 0xc9, 0x27, 0x68, 0xeb, 0xa6, 0xb0, 0x2d, 0xcf, 0xc7, 0x17, 0x7b, 0xfd, 0x39, 0x14,
+	0xda, 0x27, 0x75, 0x60, 0x70, 0x09, 0x6d, 0xa7, 0x02, 0x9c, 0xcd, 0xee, 0xe8, 0x6d, 0x34, 0xed,
+	0xbd, 0x20, 0xaf, 0xc0, 0xbf, 0x8d, 0x6e, 0x66, 0x8f, 0x93, 0x59, 0x34, 0x8b, 0x27, 0x3d, 0x2f,
+	0xb8, 0x86, 0xde, 0xe3, 0xf6, 0x37, 0x13, 0xf9, 0x32, 0x37, 0xf5, 0x9f, 0xbc, 0x58, 0xca, 0x0d,
+	0xb9, 0x84, 0xf3, 0x35, 0xdb, 0xce, 0xb9, 0x90, 0x7c, 0x35, 0xd7, 0x2b, 0xdc, 0xec, 0xce, 0xd1,
+	0x5d, 0xb3, 0x6d, 0xdc, 0x84, 0x0f, 0x2b, 0xdc, 0x04, 0xdf, 0xe0, 0x0d, 0x65, 0x45, 0x8a, 0xbf,
+	0x2a, 0x54, 0xb5, 0xed, 0x60, 0x2f, 0x9d, 0xbc, 0x07, 0xbf, 0xb1, 0x15, 0xea, 0x4a, 0x18, 0x6d,
+	0xd5, 0x97, 0x14, 0xd6, 0x6c, 0x4b, 0x5d, 0xf2, 0xe3, 0x0e, 0x02, 0xa9, 0xd2, 0x30, 0xab, 0x4b,
+	0x54, 0x02, 0x97, 0x29, 0xaa, 0x30, 0x61, 0x0b, 0x95, 0xf3, 0xfd, 0x99, 0x9a, 0x7d, 0xf8, 0x7b,
+	0x95, 0xe6, 0x26, 0xab, 0x16, 0x21, 0x97, 0xeb, 0xd1, 0x33, 0x74, 0xe4, 0xd0, 0x91, 0x43, 0x47,
+	0x0d, 0xba, 0x70, 0x0b, 0xf6, 0xf9, 0x69, 0x00, 0x4d, 0xd1, 0x81, 0x71, 0x83, 0x02, 0x00, 0x00,
 }
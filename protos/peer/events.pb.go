@@ -39,7 +39,7 @@ func (m *FilteredBlock) Reset()         { *m = FilteredBlock{} }
 func (m *FilteredBlock) String() string { return proto.CompactTextString(m) }
 func (*FilteredBlock) ProtoMessage()    {}
 func (*FilteredBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{0}
+	return fileDescriptor_events_df41d6e0d816147c, []int{0}
 }
 func (m *FilteredBlock) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredBlock.Unmarshal(m, b)
@@ -98,7 +98,7 @@ func (m *FilteredTransaction) Reset()         { *m = FilteredTransaction{} }
 func (m *FilteredTransaction) String() string { return proto.CompactTextString(m) }
 func (*FilteredTransaction) ProtoMessage()    {}
 func (*FilteredTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{1}
+	return fileDescriptor_events_df41d6e0d816147c, []int{1}
 }
 func (m *FilteredTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredTransaction.Unmarshal(m, b)
@@ -231,7 +231,7 @@ func (m *FilteredTransactionActions) Reset()         { *m = FilteredTransactionA
 func (m *FilteredTransactionActions) String() string { return proto.CompactTextString(m) }
 func (*FilteredTransactionActions) ProtoMessage()    {}
 func (*FilteredTransactionActions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{2}
+	return fileDescriptor_events_df41d6e0d816147c, []int{2}
 }
 func (m *FilteredTransactionActions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredTransactionActions.Unmarshal(m, b)
@@ -271,7 +271,7 @@ func (m *FilteredChaincodeAction) Reset()         { *m = FilteredChaincodeAction
 func (m *FilteredChaincodeAction) String() string { return proto.CompactTextString(m) }
 func (*FilteredChaincodeAction) ProtoMessage()    {}
 func (*FilteredChaincodeAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{3}
+	return fileDescriptor_events_df41d6e0d816147c, []int{3}
 }
 func (m *FilteredChaincodeAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredChaincodeAction.Unmarshal(m, b)
@@ -298,12 +298,227 @@ func (m *FilteredChaincodeAction) GetChaincodeEvent() *ChaincodeEvent {
 	return nil
 }
 
+// BlockChaincodeEvents holds the chaincode events of the valid transactions
+// of a block which match the interest of a DeliverWithEventFilter request
+type BlockChaincodeEvents struct {
+	ChannelId            string            `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	Number               uint64            `protobuf:"varint,2,opt,name=number" json:"number,omitempty"`
+	ChaincodeEvents      []*ChaincodeEvent `protobuf:"bytes,3,rep,name=chaincode_events,json=chaincodeEvents" json:"chaincode_events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *BlockChaincodeEvents) Reset()         { *m = BlockChaincodeEvents{} }
+func (m *BlockChaincodeEvents) String() string { return proto.CompactTextString(m) }
+func (*BlockChaincodeEvents) ProtoMessage()    {}
+func (*BlockChaincodeEvents) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_df41d6e0d816147c, []int{4}
+}
+func (m *BlockChaincodeEvents) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockChaincodeEvents.Unmarshal(m, b)
+}
+func (m *BlockChaincodeEvents) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockChaincodeEvents.Marshal(b, m, deterministic)
+}
+func (dst *BlockChaincodeEvents) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockChaincodeEvents.Merge(dst, src)
+}
+func (m *BlockChaincodeEvents) XXX_Size() int {
+	return xxx_messageInfo_BlockChaincodeEvents.Size(m)
+}
+func (m *BlockChaincodeEvents) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockChaincodeEvents.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockChaincodeEvents proto.InternalMessageInfo
+
+func (m *BlockChaincodeEvents) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *BlockChaincodeEvents) GetNumber() uint64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *BlockChaincodeEvents) GetChaincodeEvents() []*ChaincodeEvent {
+	if m != nil {
+		return m.ChaincodeEvents
+	}
+	return nil
+}
+
+// DeliverSeekExtension holds the options of a request to the deliver
+// services of the peer. It is carried, marshaled, in the extension of the
+// channel header of the DELIVER_SEEK_INFO envelope.
+type DeliverSeekExtension struct {
+	// The interest of the client, honored by the DeliverFiltered and the
+	// DeliverWithEventFilter services
+	Interest *DeliverInterest `protobuf:"bytes,1,opt,name=interest" json:"interest,omitempty"`
+	// The name of the consumer cursor to resume from. If the peer holds a
+	// cursor with this name for the signer of the request, blocks are
+	// delivered from the one following the last acknowledged block, instead
+	// of the start position of the seek info.
+	ConsumerName         string   `protobuf:"bytes,2,opt,name=consumer_name,json=consumerName" json:"consumer_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeliverSeekExtension) Reset()         { *m = DeliverSeekExtension{} }
+func (m *DeliverSeekExtension) String() string { return proto.CompactTextString(m) }
+func (*DeliverSeekExtension) ProtoMessage()    {}
+func (*DeliverSeekExtension) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_df41d6e0d816147c, []int{5}
+}
+func (m *DeliverSeekExtension) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeliverSeekExtension.Unmarshal(m, b)
+}
+func (m *DeliverSeekExtension) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeliverSeekExtension.Marshal(b, m, deterministic)
+}
+func (dst *DeliverSeekExtension) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeliverSeekExtension.Merge(dst, src)
+}
+func (m *DeliverSeekExtension) XXX_Size() int {
+	return xxx_messageInfo_DeliverSeekExtension.Size(m)
+}
+func (m *DeliverSeekExtension) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeliverSeekExtension.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeliverSeekExtension proto.InternalMessageInfo
+
+func (m *DeliverSeekExtension) GetInterest() *DeliverInterest {
+	if m != nil {
+		return m.Interest
+	}
+	return nil
+}
+
+func (m *DeliverSeekExtension) GetConsumerName() string {
+	if m != nil {
+		return m.ConsumerName
+	}
+	return ""
+}
+
+// DeliverInterest restricts the transactions and chaincode events sent by
+// the DeliverFiltered and the DeliverWithEventFilter services to the ones a
+// client is interested in. Blocks are delivered regardless, so that clients
+// keep track of the height of the ledger.
+type DeliverInterest struct {
+	// The names of the chaincodes whose transactions are delivered. If empty,
+	// the transactions of all chaincodes are delivered.
+	ChaincodeNames []string `protobuf:"bytes,1,rep,name=chaincode_names,json=chaincodeNames" json:"chaincode_names,omitempty"`
+	// A regular expression the names of the delivered chaincode events must
+	// match. If set, transactions without matching events are not delivered.
+	EventNameFilter      string   `protobuf:"bytes,2,opt,name=event_name_filter,json=eventNameFilter" json:"event_name_filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeliverInterest) Reset()         { *m = DeliverInterest{} }
+func (m *DeliverInterest) String() string { return proto.CompactTextString(m) }
+func (*DeliverInterest) ProtoMessage()    {}
+func (*DeliverInterest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_df41d6e0d816147c, []int{6}
+}
+func (m *DeliverInterest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeliverInterest.Unmarshal(m, b)
+}
+func (m *DeliverInterest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeliverInterest.Marshal(b, m, deterministic)
+}
+func (dst *DeliverInterest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeliverInterest.Merge(dst, src)
+}
+func (m *DeliverInterest) XXX_Size() int {
+	return xxx_messageInfo_DeliverInterest.Size(m)
+}
+func (m *DeliverInterest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeliverInterest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeliverInterest proto.InternalMessageInfo
+
+func (m *DeliverInterest) GetChaincodeNames() []string {
+	if m != nil {
+		return m.ChaincodeNames
+	}
+	return nil
+}
+
+func (m *DeliverInterest) GetEventNameFilter() string {
+	if m != nil {
+		return m.EventNameFilter
+	}
+	return ""
+}
+
+// DeliverCursor is the position of a named consumer of the deliver
+// services, persisted by the peer on behalf of the signer of the
+// acknowledgements
+type DeliverCursor struct {
+	ConsumerName         string   `protobuf:"bytes,1,opt,name=consumer_name,json=consumerName" json:"consumer_name,omitempty"`
+	BlockNumber          uint64   `protobuf:"varint,2,opt,name=block_number,json=blockNumber" json:"block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeliverCursor) Reset()         { *m = DeliverCursor{} }
+func (m *DeliverCursor) String() string { return proto.CompactTextString(m) }
+func (*DeliverCursor) ProtoMessage()    {}
+func (*DeliverCursor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_events_df41d6e0d816147c, []int{7}
+}
+func (m *DeliverCursor) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeliverCursor.Unmarshal(m, b)
+}
+func (m *DeliverCursor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeliverCursor.Marshal(b, m, deterministic)
+}
+func (dst *DeliverCursor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeliverCursor.Merge(dst, src)
+}
+func (m *DeliverCursor) XXX_Size() int {
+	return xxx_messageInfo_DeliverCursor.Size(m)
+}
+func (m *DeliverCursor) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeliverCursor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeliverCursor proto.InternalMessageInfo
+
+func (m *DeliverCursor) GetConsumerName() string {
+	if m != nil {
+		return m.ConsumerName
+	}
+	return ""
+}
+
+func (m *DeliverCursor) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
 // DeliverResponse
 type DeliverResponse struct {
 	// Types that are valid to be assigned to Type:
 	//	*DeliverResponse_Status
 	//	*DeliverResponse_Block
 	//	*DeliverResponse_FilteredBlock
+	//	*DeliverResponse_ChaincodeEvents
 	Type                 isDeliverResponse_Type `protobuf_oneof:"Type"`
 	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
 	XXX_unrecognized     []byte                 `json:"-"`
@@ -314,7 +529,7 @@ func (m *DeliverResponse) Reset()         { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()    {}
 func (*DeliverResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{4}
+	return fileDescriptor_events_df41d6e0d816147c, []int{8}
 }
 func (m *DeliverResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeliverResponse.Unmarshal(m, b)
@@ -347,10 +562,14 @@ type DeliverResponse_Block struct {
 type DeliverResponse_FilteredBlock struct {
 	FilteredBlock *FilteredBlock `protobuf:"bytes,3,opt,name=filtered_block,json=filteredBlock,oneof"`
 }
+type DeliverResponse_ChaincodeEvents struct {
+	ChaincodeEvents *BlockChaincodeEvents `protobuf:"bytes,4,opt,name=chaincode_events,json=chaincodeEvents,oneof"`
+}
 
-func (*DeliverResponse_Status) isDeliverResponse_Type()        {}
-func (*DeliverResponse_Block) isDeliverResponse_Type()         {}
-func (*DeliverResponse_FilteredBlock) isDeliverResponse_Type() {}
+func (*DeliverResponse_Status) isDeliverResponse_Type()          {}
+func (*DeliverResponse_Block) isDeliverResponse_Type()           {}
+func (*DeliverResponse_FilteredBlock) isDeliverResponse_Type()   {}
+func (*DeliverResponse_ChaincodeEvents) isDeliverResponse_Type() {}
 
 func (m *DeliverResponse) GetType() isDeliverResponse_Type {
 	if m != nil {
@@ -380,12 +599,20 @@ func (m *DeliverResponse) GetFilteredBlock() *FilteredBlock {
 	return nil
 }
 
+func (m *DeliverResponse) GetChaincodeEvents() *BlockChaincodeEvents {
+	if x, ok := m.GetType().(*DeliverResponse_ChaincodeEvents); ok {
+		return x.ChaincodeEvents
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*DeliverResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _DeliverResponse_OneofMarshaler, _DeliverResponse_OneofUnmarshaler, _DeliverResponse_OneofSizer, []interface{}{
 		(*DeliverResponse_Status)(nil),
 		(*DeliverResponse_Block)(nil),
 		(*DeliverResponse_FilteredBlock)(nil),
+		(*DeliverResponse_ChaincodeEvents)(nil),
 	}
 }
 
@@ -406,6 +633,11 @@ func _DeliverResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.FilteredBlock); err != nil {
 			return err
 		}
+	case *DeliverResponse_ChaincodeEvents:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChaincodeEvents); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("DeliverResponse.Type has unexpected type %T", x)
@@ -439,6 +671,14 @@ func _DeliverResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *prot
 		err := b.DecodeMessage(msg)
 		m.Type = &DeliverResponse_FilteredBlock{msg}
 		return true, err
+	case 4: // Type.chaincode_events
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(BlockChaincodeEvents)
+		err := b.DecodeMessage(msg)
+		m.Type = &DeliverResponse_ChaincodeEvents{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -461,6 +701,11 @@ func _DeliverResponse_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *DeliverResponse_ChaincodeEvents:
+		s := proto.Size(x.ChaincodeEvents)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -473,6 +718,10 @@ func init() {
 	proto.RegisterType((*FilteredTransaction)(nil), "protos.FilteredTransaction")
 	proto.RegisterType((*FilteredTransactionActions)(nil), "protos.FilteredTransactionActions")
 	proto.RegisterType((*FilteredChaincodeAction)(nil), "protos.FilteredChaincodeAction")
+	proto.RegisterType((*BlockChaincodeEvents)(nil), "protos.BlockChaincodeEvents")
+	proto.RegisterType((*DeliverSeekExtension)(nil), "protos.DeliverSeekExtension")
+	proto.RegisterType((*DeliverInterest)(nil), "protos.DeliverInterest")
+	proto.RegisterType((*DeliverCursor)(nil), "protos.DeliverCursor")
 	proto.RegisterType((*DeliverResponse)(nil), "protos.DeliverResponse")
 }
 
@@ -495,6 +744,15 @@ type DeliverClient interface {
 	// Payload data as a marshaled orderer.SeekInfo message,
 	// then a stream of **filtered** block replies is received
 	DeliverFiltered(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverFilteredClient, error)
+	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with
+	// Payload data as a marshaled orderer.SeekInfo message,
+	// then a stream of the chaincode events of the blocks, matching the
+	// interest of the DeliverSeekExtension, is received
+	DeliverWithEventFilter(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverWithEventFilterClient, error)
+	// AcknowledgeDelivery requires an Envelope with Payload data as a
+	// marshaled DeliverCursor message, and moves the consumer cursor of the
+	// signer to the acknowledged block; a status reply is received
+	AcknowledgeDelivery(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*DeliverResponse, error)
 }
 
 type deliverClient struct {
@@ -567,6 +825,46 @@ func (x *deliverDeliverFilteredClient) Recv() (*DeliverResponse, error) {
 	return m, nil
 }
 
+func (c *deliverClient) DeliverWithEventFilter(ctx context.Context, opts ...grpc.CallOption) (Deliver_DeliverWithEventFilterClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Deliver_serviceDesc.Streams[2], c.cc, "/protos.Deliver/DeliverWithEventFilter", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &deliverDeliverWithEventFilterClient{stream}
+	return x, nil
+}
+
+type Deliver_DeliverWithEventFilterClient interface {
+	Send(*common.Envelope) error
+	Recv() (*DeliverResponse, error)
+	grpc.ClientStream
+}
+
+type deliverDeliverWithEventFilterClient struct {
+	grpc.ClientStream
+}
+
+func (x *deliverDeliverWithEventFilterClient) Send(m *common.Envelope) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *deliverDeliverWithEventFilterClient) Recv() (*DeliverResponse, error) {
+	m := new(DeliverResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *deliverClient) AcknowledgeDelivery(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*DeliverResponse, error) {
+	out := new(DeliverResponse)
+	err := grpc.Invoke(ctx, "/protos.Deliver/AcknowledgeDelivery", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Deliver service
 
 type DeliverServer interface {
@@ -578,6 +876,15 @@ type DeliverServer interface {
 	// Payload data as a marshaled orderer.SeekInfo message,
 	// then a stream of **filtered** block replies is received
 	DeliverFiltered(Deliver_DeliverFilteredServer) error
+	// deliver first requires an Envelope of type ab.DELIVER_SEEK_INFO with
+	// Payload data as a marshaled orderer.SeekInfo message,
+	// then a stream of the chaincode events of the blocks, matching the
+	// interest of the DeliverSeekExtension, is received
+	DeliverWithEventFilter(Deliver_DeliverWithEventFilterServer) error
+	// AcknowledgeDelivery requires an Envelope with Payload data as a
+	// marshaled DeliverCursor message, and moves the consumer cursor of the
+	// signer to the acknowledged block; a status reply is received
+	AcknowledgeDelivery(context.Context, *common.Envelope) (*DeliverResponse, error)
 }
 
 func RegisterDeliverServer(s *grpc.Server, srv DeliverServer) {
@@ -636,10 +943,59 @@ func (x *deliverDeliverFilteredServer) Recv() (*common.Envelope, error) {
 	return m, nil
 }
 
+func _Deliver_DeliverWithEventFilter_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DeliverServer).DeliverWithEventFilter(&deliverDeliverWithEventFilterServer{stream})
+}
+
+type Deliver_DeliverWithEventFilterServer interface {
+	Send(*DeliverResponse) error
+	Recv() (*common.Envelope, error)
+	grpc.ServerStream
+}
+
+type deliverDeliverWithEventFilterServer struct {
+	grpc.ServerStream
+}
+
+func (x *deliverDeliverWithEventFilterServer) Send(m *DeliverResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *deliverDeliverWithEventFilterServer) Recv() (*common.Envelope, error) {
+	m := new(common.Envelope)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Deliver_AcknowledgeDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeliverServer).AcknowledgeDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Deliver/AcknowledgeDelivery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeliverServer).AcknowledgeDelivery(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Deliver_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Deliver",
 	HandlerType: (*DeliverServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AcknowledgeDelivery",
+			Handler:    _Deliver_AcknowledgeDelivery_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "Deliver",
@@ -653,47 +1009,65 @@ var _Deliver_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "DeliverWithEventFilter",
+			Handler:       _Deliver_DeliverWithEventFilter_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "peer/events.proto",
 }
 
-func init() { proto.RegisterFile("peer/events.proto", fileDescriptor_events_8af932975aef5a3c) }
-
-var fileDescriptor_events_8af932975aef5a3c = []byte{
-	// 560 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0x8e, 0x69, 0x08, 0xea, 0x44, 0x49, 0xdb, 0x2d, 0x4d, 0xa3, 0x20, 0xd4, 0xc8, 0x12, 0xc8,
-	0x5c, 0x62, 0x64, 0x6e, 0x1c, 0x40, 0xa4, 0x3f, 0x0a, 0x12, 0x87, 0x6a, 0x09, 0x1c, 0x7a, 0xc0,
-	0x5a, 0xdb, 0x13, 0xc7, 0xd4, 0xf1, 0x5a, 0xbb, 0x9b, 0x28, 0x79, 0x04, 0xde, 0x80, 0x67, 0xe0,
-	0x09, 0x39, 0x22, 0xaf, 0xbd, 0x49, 0x9a, 0x52, 0x24, 0x4e, 0xf6, 0xce, 0x7c, 0x3f, 0x33, 0xb3,
-	0x63, 0xc3, 0x51, 0x8e, 0x28, 0x5c, 0x5c, 0x60, 0xa6, 0xe4, 0x20, 0x17, 0x5c, 0x71, 0xd2, 0xd0,
-	0x0f, 0xd9, 0x3b, 0x0e, 0xf9, 0x6c, 0xc6, 0x33, 0xb7, 0x7c, 0x94, 0xc9, 0xde, 0x59, 0xcc, 0x79,
-	0x9c, 0xa2, 0xab, 0x4f, 0xc1, 0x7c, 0xe2, 0xaa, 0x64, 0x86, 0x52, 0xb1, 0x59, 0x5e, 0x01, 0x7a,
-	0x5a, 0x30, 0x9c, 0xb2, 0x24, 0x0b, 0x79, 0x84, 0xbe, 0x96, 0xae, 0x72, 0x1d, 0x9d, 0x53, 0x82,
-	0x65, 0x92, 0x85, 0x2a, 0x31, 0xa2, 0xf6, 0x4f, 0x0b, 0x5a, 0x57, 0x49, 0xaa, 0x50, 0x60, 0x34,
-	0x4c, 0x79, 0x78, 0x4b, 0x9e, 0x03, 0x84, 0x53, 0x96, 0x65, 0x98, 0xfa, 0x49, 0xd4, 0xb5, 0xfa,
-	0x96, 0xb3, 0x4f, 0xf7, 0xab, 0xc8, 0xc7, 0x88, 0x74, 0xa0, 0x91, 0xcd, 0x67, 0x01, 0x8a, 0xee,
-	0xa3, 0xbe, 0xe5, 0xd4, 0x69, 0x75, 0x22, 0xd7, 0x70, 0x32, 0xa9, 0x74, 0xfc, 0x2d, 0x1b, 0xd9,
-	0xad, 0xf7, 0xf7, 0x9c, 0xa6, 0xf7, 0xac, 0xf4, 0x93, 0x03, 0x63, 0x36, 0xde, 0x60, 0xe8, 0xd3,
-	0xc9, 0xfd, 0xa0, 0xb4, 0x7f, 0x5b, 0x70, 0xfc, 0x17, 0x34, 0x21, 0x50, 0x57, 0xcb, 0x75, 0x69,
-	0xfa, 0x9d, 0xbc, 0x84, 0xba, 0x5a, 0xe5, 0xa8, 0x6b, 0x6a, 0x7b, 0x64, 0x50, 0x0d, 0x6e, 0x84,
-	0x2c, 0x42, 0x31, 0x5e, 0xe5, 0x48, 0x75, 0x9e, 0x5c, 0x01, 0x51, 0x4b, 0x7f, 0xc1, 0xd2, 0x24,
-	0x62, 0x85, 0x98, 0x5f, 0x0c, 0xaa, 0xbb, 0xa7, 0x59, 0x5d, 0x53, 0xe2, 0x78, 0xf9, 0x75, 0x0d,
-	0x38, 0xe7, 0x11, 0xd2, 0x43, 0xb5, 0x13, 0x21, 0x5f, 0xe0, 0x78, 0xab, 0x49, 0x7f, 0xd3, 0xab,
-	0xe5, 0x34, 0x3d, 0xfb, 0x1f, 0xbd, 0x7e, 0x28, 0x91, 0xa3, 0x1a, 0x25, 0xea, 0x5e, 0x74, 0xd8,
-	0x80, 0xfa, 0x05, 0x53, 0xcc, 0xfe, 0x0e, 0xbd, 0x87, 0xb9, 0xe4, 0x13, 0x1c, 0x6d, 0x2e, 0xd9,
-	0x58, 0x5b, 0x7a, 0xcc, 0x67, 0xbb, 0xd6, 0xe7, 0x06, 0x58, 0x92, 0xe9, 0x61, 0x78, 0x37, 0x20,
-	0xed, 0x1b, 0x38, 0x7d, 0x00, 0x4c, 0xde, 0xc3, 0xc1, 0xce, 0x36, 0xe9, 0xa1, 0x37, 0xbd, 0x8e,
-	0xb1, 0x59, 0x33, 0x2e, 0x8b, 0x2c, 0x6d, 0x87, 0x77, 0xce, 0xf6, 0x2f, 0x0b, 0x0e, 0x2e, 0x30,
-	0x4d, 0x16, 0x28, 0x28, 0xca, 0x9c, 0x67, 0x12, 0x89, 0x03, 0x0d, 0xa9, 0x98, 0x9a, 0x4b, 0xad,
-	0xd5, 0xf6, 0xda, 0xe6, 0xb2, 0x3e, 0xeb, 0xe8, 0xa8, 0x46, 0xab, 0x3c, 0x79, 0x01, 0x8f, 0x83,
-	0x62, 0x25, 0xf5, 0xad, 0x36, 0xbd, 0x96, 0x01, 0xea, 0x3d, 0x1d, 0xd5, 0x68, 0x99, 0x25, 0xef,
-	0xa0, 0xbd, 0xde, 0xbc, 0x12, 0xbf, 0xa7, 0xf1, 0x27, 0xbb, 0xb3, 0x30, 0xbc, 0xd6, 0x64, 0x3b,
-	0x50, 0x0c, 0xbd, 0xd8, 0x10, 0xef, 0x87, 0x05, 0x4f, 0xaa, 0x62, 0xc9, 0xdb, 0xcd, 0xeb, 0xa1,
-	0xb1, 0xbd, 0xcc, 0x16, 0x98, 0xf2, 0x1c, 0x7b, 0xa7, 0x46, 0x78, 0xa7, 0x35, 0xbb, 0xe6, 0x58,
-	0xaf, 0x2d, 0x32, 0x5c, 0xf7, 0x6c, 0x8c, 0xff, 0x5b, 0x63, 0xf8, 0x0d, 0x6c, 0x2e, 0xe2, 0xc1,
-	0x74, 0x95, 0xa3, 0x48, 0x31, 0x8a, 0x51, 0x0c, 0x26, 0x2c, 0x10, 0x49, 0x68, 0x68, 0xc5, 0xe7,
-	0x3c, 0x6c, 0xe9, 0x29, 0xcb, 0x6b, 0x16, 0xde, 0xb2, 0x18, 0x6f, 0x5e, 0xc5, 0x89, 0x9a, 0xce,
-	0x83, 0xc2, 0xcb, 0xdd, 0x62, 0xba, 0x25, 0xb3, 0xfc, 0x6f, 0x48, 0xb7, 0x60, 0x06, 0xe5, 0x8f,
-	0xe6, 0xcd, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd3, 0x19, 0xd1, 0xa9, 0x84, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/events.proto", fileDescriptor_events_df41d6e0d816147c) }
+
+var fileDescriptor_events_df41d6e0d816147c = []byte{
+	// 747 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x4b, 0x6f, 0xda, 0x4c,
+	0x14, 0xc5, 0x81, 0x8f, 0xaf, 0x0c, 0xe1, 0x91, 0x21, 0x21, 0x88, 0xb6, 0x0a, 0x75, 0xd5, 0x96,
+	0x76, 0x01, 0x15, 0xd9, 0x75, 0xd1, 0x2a, 0xe4, 0x21, 0x90, 0xaa, 0x28, 0x72, 0xd2, 0x46, 0xca,
+	0xa2, 0xd6, 0x60, 0x5f, 0xc0, 0xc5, 0x2f, 0xcd, 0x0c, 0x14, 0xfe, 0x45, 0x97, 0xf9, 0xa3, 0x95,
+	0xba, 0xac, 0x3c, 0xe3, 0xe1, 0x99, 0x44, 0x4a, 0x56, 0xf6, 0xdc, 0x7b, 0xce, 0xb9, 0x8f, 0xb9,
+	0xd7, 0x46, 0x3b, 0x21, 0x00, 0x6d, 0xc2, 0x04, 0x7c, 0xce, 0x1a, 0x21, 0x0d, 0x78, 0x80, 0xd3,
+	0xe2, 0xc1, 0xaa, 0x25, 0x2b, 0xf0, 0xbc, 0xc0, 0x6f, 0xca, 0x87, 0x74, 0x56, 0x0f, 0x06, 0x41,
+	0x30, 0x70, 0xa1, 0x29, 0x4e, 0xbd, 0x71, 0xbf, 0xc9, 0x1d, 0x0f, 0x18, 0x27, 0x5e, 0x18, 0x03,
+	0xaa, 0x42, 0xd0, 0x1a, 0x12, 0xc7, 0xb7, 0x02, 0x1b, 0x4c, 0x21, 0x1d, 0xfb, 0xca, 0xc2, 0xc7,
+	0x29, 0xf1, 0x19, 0xb1, 0xb8, 0xa3, 0x44, 0xf5, 0x5b, 0x0d, 0xe5, 0xce, 0x1c, 0x97, 0x03, 0x05,
+	0xbb, 0xed, 0x06, 0xd6, 0x08, 0xbf, 0x44, 0xc8, 0x1a, 0x12, 0xdf, 0x07, 0xd7, 0x74, 0xec, 0x8a,
+	0x56, 0xd3, 0xea, 0x19, 0x23, 0x13, 0x5b, 0xba, 0x36, 0x2e, 0xa3, 0xb4, 0x3f, 0xf6, 0x7a, 0x40,
+	0x2b, 0x5b, 0x35, 0xad, 0x9e, 0x32, 0xe2, 0x13, 0xbe, 0x40, 0x7b, 0xfd, 0x58, 0xc7, 0x5c, 0x0a,
+	0xc3, 0x2a, 0xa9, 0x5a, 0xb2, 0x9e, 0x6d, 0x3d, 0x97, 0xf1, 0x58, 0x43, 0x05, 0xbb, 0x5a, 0x60,
+	0x8c, 0xdd, 0xfe, 0xa6, 0x91, 0xe9, 0x7f, 0x35, 0x54, 0xba, 0x03, 0x8d, 0x31, 0x4a, 0xf1, 0xe9,
+	0x3c, 0x35, 0xf1, 0x8e, 0xdf, 0xa2, 0x14, 0x9f, 0x85, 0x20, 0x72, 0xca, 0xb7, 0x70, 0x23, 0x6e,
+	0x5c, 0x07, 0x88, 0x0d, 0xf4, 0x6a, 0x16, 0x82, 0x21, 0xfc, 0xf8, 0x0c, 0x61, 0x3e, 0x35, 0x27,
+	0xc4, 0x75, 0x6c, 0x12, 0x89, 0x99, 0x51, 0xa3, 0x2a, 0x49, 0xc1, 0xaa, 0xa8, 0x14, 0xaf, 0xa6,
+	0xdf, 0xe7, 0x80, 0xe3, 0xc0, 0x06, 0xa3, 0xc8, 0xd7, 0x2c, 0xf8, 0x1b, 0x2a, 0x2d, 0x15, 0x69,
+	0x2e, 0x6a, 0xd5, 0xea, 0xd9, 0x96, 0xfe, 0x40, 0xad, 0x47, 0x12, 0xd9, 0x49, 0x18, 0x98, 0x6f,
+	0x58, 0xdb, 0x69, 0x94, 0x3a, 0x21, 0x9c, 0xe8, 0x3f, 0x51, 0xf5, 0x7e, 0x2e, 0xfe, 0x8a, 0x76,
+	0x16, 0x97, 0xac, 0x42, 0x6b, 0xa2, 0xcd, 0x07, 0xeb, 0xa1, 0x8f, 0x15, 0x50, 0x92, 0x8d, 0xa2,
+	0xb5, 0x6a, 0x60, 0xfa, 0x0d, 0xda, 0xbf, 0x07, 0x8c, 0xbf, 0xa0, 0xc2, 0xda, 0x34, 0x89, 0xa6,
+	0x67, 0x5b, 0x65, 0x15, 0x66, 0xce, 0x38, 0x8d, 0xbc, 0x46, 0xde, 0x5a, 0x39, 0xeb, 0xbf, 0x35,
+	0xb4, 0x2b, 0xa6, 0x6a, 0x15, 0xc7, 0x9e, 0x3a, 0x64, 0x47, 0xa8, 0xb8, 0x96, 0x10, 0xab, 0x24,
+	0x6b, 0xc9, 0x07, 0x32, 0x2a, 0xac, 0x66, 0xc4, 0xf4, 0x10, 0xed, 0x9e, 0x80, 0xeb, 0x4c, 0x80,
+	0x5e, 0x02, 0x8c, 0x4e, 0xa7, 0x1c, 0x7c, 0x16, 0xd5, 0x7a, 0x88, 0x9e, 0x39, 0x3e, 0x07, 0x0a,
+	0x4c, 0x15, 0xb9, 0xaf, 0x24, 0x63, 0x7c, 0x37, 0x76, 0x1b, 0x73, 0x20, 0x7e, 0x8d, 0x72, 0x56,
+	0xe0, 0xb3, 0xb1, 0x07, 0xd4, 0xf4, 0x89, 0x27, 0xe7, 0x2f, 0x63, 0x6c, 0x2b, 0xe3, 0x39, 0xf1,
+	0x40, 0xef, 0xa3, 0xc2, 0x9a, 0x02, 0x7e, 0xb7, 0xdc, 0xd8, 0x88, 0x28, 0xef, 0x2f, 0xb3, 0xd4,
+	0xc0, 0x88, 0xca, 0xf0, 0x07, 0xb4, 0x23, 0xca, 0x14, 0x20, 0x53, 0xae, 0x49, 0x1c, 0xa4, 0x20,
+	0x1c, 0x11, 0x4c, 0x5e, 0x9f, 0x7e, 0x8d, 0x72, 0x71, 0x9c, 0xe3, 0x31, 0x65, 0x01, 0xdd, 0xcc,
+	0x4e, 0xdb, 0xcc, 0x0e, 0xbf, 0x42, 0xdb, 0xbd, 0xe8, 0x86, 0xcc, 0x95, 0x86, 0x67, 0x85, 0xed,
+	0x5c, 0x98, 0xf4, 0x3f, 0xda, 0xbc, 0x02, 0x03, 0x58, 0x18, 0xf8, 0x0c, 0x70, 0x1d, 0xa5, 0x19,
+	0x27, 0x7c, 0xcc, 0x84, 0x68, 0xbe, 0x95, 0x57, 0x2b, 0x77, 0x29, 0xac, 0x9d, 0x84, 0x11, 0xfb,
+	0xf1, 0x1b, 0xf4, 0x9f, 0x10, 0x13, 0xca, 0xd9, 0x56, 0x4e, 0x01, 0xc5, 0x5c, 0x74, 0x12, 0x86,
+	0xf4, 0xe2, 0xcf, 0x28, 0x3f, 0xff, 0x7e, 0x48, 0x7c, 0x52, 0xe0, 0xf7, 0xd6, 0x27, 0x5a, 0xf1,
+	0x72, 0xfd, 0x65, 0x03, 0xee, 0xde, 0x31, 0x1a, 0x72, 0x1d, 0x5f, 0x28, 0x85, 0xbb, 0x26, 0xb1,
+	0x93, 0xd8, 0x18, 0x91, 0x68, 0x0b, 0xa3, 0x4f, 0x46, 0xeb, 0x76, 0x0b, 0xfd, 0x1f, 0xd7, 0x8d,
+	0x3f, 0x2d, 0x5e, 0x8b, 0xaa, 0x82, 0x53, 0x7f, 0x02, 0x6e, 0x10, 0x42, 0x75, 0x7d, 0x52, 0x54,
+	0x97, 0xf4, 0x44, 0x5d, 0xfb, 0xa8, 0xe1, 0xf6, 0xbc, 0x7d, 0xaa, 0x86, 0xc7, 0x6b, 0x74, 0x51,
+	0x39, 0x76, 0x5c, 0x3b, 0x7c, 0x28, 0x12, 0x95, 0x62, 0x4f, 0x49, 0xa7, 0x74, 0x64, 0x8d, 0xfc,
+	0xe0, 0x97, 0x0b, 0xf6, 0x00, 0x62, 0xcc, 0xec, 0x51, 0x3a, 0xed, 0x1f, 0x48, 0x0f, 0xe8, 0xa0,
+	0x31, 0x9c, 0x85, 0x40, 0x85, 0x0c, 0x6d, 0xf4, 0x49, 0x8f, 0x3a, 0x96, 0xa2, 0x84, 0x00, 0xb4,
+	0x9d, 0x93, 0x0d, 0xbd, 0x20, 0xd6, 0x88, 0x0c, 0xe0, 0xe6, 0xfd, 0xc0, 0xe1, 0xc3, 0x71, 0x2f,
+	0x8a, 0xd3, 0x5c, 0x62, 0x36, 0x25, 0x53, 0xfe, 0xd7, 0x58, 0x33, 0x62, 0xf6, 0xe4, 0x8f, 0xf0,
+	0xf0, 0xdf, 0x00, 0x29, 0xf0, 0x64, 0xd3, 0x24, 0x07, 0x00, 0x00,
 }
@@ -10,9 +10,74 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 )
 
+func (sp *SignedProposal) StaticallyOpaqueFields() []string {
+	return []string{"proposal_bytes"}
+}
+
+func (sp *SignedProposal) StaticallyOpaqueFieldProto(name string) (proto.Message, error) {
+	if name != sp.StaticallyOpaqueFields()[0] {
+		return nil, fmt.Errorf("not a marshaled field: %s", name)
+	}
+	return &Proposal{}, nil
+}
+
+func (p *Proposal) StaticallyOpaqueFields() []string {
+	return []string{"header"}
+}
+
+func (p *Proposal) StaticallyOpaqueFieldProto(name string) (proto.Message, error) {
+	if name != p.StaticallyOpaqueFields()[0] {
+		return nil, fmt.Errorf("not a marshaled field: %s", name)
+	}
+	return &common.Header{}, nil
+}
+
+func (p *Proposal) VariablyOpaqueFields() []string {
+	return []string{"payload", "extension"}
+}
+
+// ProposalPayloadMap holds the type of the payload of a proposal for each
+// header type which can be proposed
+var ProposalPayloadMap = map[int32]proto.Message{
+	int32(common.HeaderType_ENDORSER_TRANSACTION): &ChaincodeProposalPayload{},
+}
+
+// ProposalExtensionMap holds the type of the extension of a proposal for each
+// header type which defines one
+var ProposalExtensionMap = map[int32]proto.Message{
+	int32(common.HeaderType_ENDORSER_TRANSACTION): &ChaincodeAction{},
+}
+
+func (p *Proposal) VariablyOpaqueFieldProto(name string) (proto.Message, error) {
+	var typeMap map[int32]proto.Message
+	switch name {
+	case p.VariablyOpaqueFields()[0]: // payload
+		typeMap = ProposalPayloadMap
+	case p.VariablyOpaqueFields()[1]: // extension
+		typeMap = ProposalExtensionMap
+	default:
+		return nil, fmt.Errorf("not a marshaled field: %s", name)
+	}
+
+	header := &common.Header{}
+	if err := proto.Unmarshal(p.Header, header); err != nil {
+		return nil, fmt.Errorf("corrupt header: %s", err)
+	}
+	ch := &common.ChannelHeader{}
+	if err := proto.Unmarshal(header.ChannelHeader, ch); err != nil {
+		return nil, fmt.Errorf("corrupt channel header: %s", err)
+	}
+
+	if msg, ok := typeMap[ch.Type]; ok {
+		return proto.Clone(msg), nil
+	}
+	return nil, fmt.Errorf("decoding type %v is unimplemented", ch.Type)
+}
+
 func (cpp *ChaincodeProposalPayload) StaticallyOpaqueFields() []string {
 	return []string{"input"}
 }
@@ -26,22 +26,22 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 // When an endorser receives a SignedProposal message, it should verify the
 // signature over the proposal bytes. This verification requires the following
 // steps:
-// 1. Verification of the validity of the certificate that was used to produce
-//    the signature.  The certificate will be available once proposalBytes has
-//    been unmarshalled to a Proposal message, and Proposal.header has been
-//    unmarshalled to a Header message. While this unmarshalling-before-verifying
-//    might not be ideal, it is unavoidable because i) the signature needs to also
-//    protect the signing certificate; ii) it is desirable that Header is created
-//    once by the client and never changed (for the sake of accountability and
-//    non-repudiation). Note also that it is actually impossible to conclusively
-//    verify the validity of the certificate included in a Proposal, because the
-//    proposal needs to first be endorsed and ordered with respect to certificate
-//    expiration transactions. Still, it is useful to pre-filter expired
-//    certificates at this stage.
-// 2. Verification that the certificate is trusted (signed by a trusted CA) and
-//    that it is allowed to transact with us (with respect to some ACLs);
-// 3. Verification that the signature on proposalBytes is valid;
-// 4. Detect replay attacks;
+//  1. Verification of the validity of the certificate that was used to produce
+//     the signature.  The certificate will be available once proposalBytes has
+//     been unmarshalled to a Proposal message, and Proposal.header has been
+//     unmarshalled to a Header message. While this unmarshalling-before-verifying
+//     might not be ideal, it is unavoidable because i) the signature needs to also
+//     protect the signing certificate; ii) it is desirable that Header is created
+//     once by the client and never changed (for the sake of accountability and
+//     non-repudiation). Note also that it is actually impossible to conclusively
+//     verify the validity of the certificate included in a Proposal, because the
+//     proposal needs to first be endorsed and ordered with respect to certificate
+//     expiration transactions. Still, it is useful to pre-filter expired
+//     certificates at this stage.
+//  2. Verification that the certificate is trusted (signed by a trusted CA) and
+//     that it is allowed to transact with us (with respect to some ACLs);
+//  3. Verification that the signature on proposalBytes is valid;
+//  4. Detect replay attacks;
 type SignedProposal struct {
 	// The bytes of Proposal
 	ProposalBytes []byte `protobuf:"bytes,1,opt,name=proposal_bytes,json=proposalBytes,proto3" json:"proposal_bytes,omitempty"`
@@ -58,7 +58,7 @@ func (m *SignedProposal) Reset()         { *m = SignedProposal{} }
 func (m *SignedProposal) String() string { return proto.CompactTextString(m) }
 func (*SignedProposal) ProtoMessage()    {}
 func (*SignedProposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_8e93dae9c9555ac0, []int{0}
+	return fileDescriptor_proposal_63f7cf1ec1bd97eb, []int{0}
 }
 func (m *SignedProposal) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedProposal.Unmarshal(m, b)
@@ -93,24 +93,24 @@ func (m *SignedProposal) GetSignature() []byte {
 }
 
 // A Proposal is sent to an endorser for endorsement.  The proposal contains:
-// 1. A header which should be unmarshaled to a Header message.  Note that
-//    Header is both the header of a Proposal and of a Transaction, in that i)
-//    both headers should be unmarshaled to this message; and ii) it is used to
-//    compute cryptographic hashes and signatures.  The header has fields common
-//    to all proposals/transactions.  In addition it has a type field for
-//    additional customization. An example of this is the ChaincodeHeaderExtension
-//    message used to extend the Header for type CHAINCODE.
-// 2. A payload whose type depends on the header's type field.
-// 3. An extension whose type depends on the header's type field.
+//  1. A header which should be unmarshaled to a Header message.  Note that
+//     Header is both the header of a Proposal and of a Transaction, in that i)
+//     both headers should be unmarshaled to this message; and ii) it is used to
+//     compute cryptographic hashes and signatures.  The header has fields common
+//     to all proposals/transactions.  In addition it has a type field for
+//     additional customization. An example of this is the ChaincodeHeaderExtension
+//     message used to extend the Header for type CHAINCODE.
+//  2. A payload whose type depends on the header's type field.
+//  3. An extension whose type depends on the header's type field.
 //
 // Let us see an example. For type CHAINCODE (see the Header message),
 // we have the following:
-// 1. The header is a Header message whose extensions field is a
-//    ChaincodeHeaderExtension message.
-// 2. The payload is a ChaincodeProposalPayload message.
-// 3. The extension is a ChaincodeAction that might be used to ask the
-//    endorsers to endorse a specific ChaincodeAction, thus emulating the
-//    submitting peer model.
+//  1. The header is a Header message whose extensions field is a
+//     ChaincodeHeaderExtension message.
+//  2. The payload is a ChaincodeProposalPayload message.
+//  3. The extension is a ChaincodeAction that might be used to ask the
+//     endorsers to endorse a specific ChaincodeAction, thus emulating the
+//     submitting peer model.
 type Proposal struct {
 	// The header of the proposal. It is the bytes of the Header
 	Header []byte `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
@@ -130,7 +130,7 @@ func (m *Proposal) Reset()         { *m = Proposal{} }
 func (m *Proposal) String() string { return proto.CompactTextString(m) }
 func (*Proposal) ProtoMessage()    {}
 func (*Proposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_8e93dae9c9555ac0, []int{1}
+	return fileDescriptor_proposal_63f7cf1ec1bd97eb, []int{1}
 }
 func (m *Proposal) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Proposal.Unmarshal(m, b)
@@ -188,17 +188,20 @@ type ChaincodeHeaderExtension struct {
 	// this field impacts the content of ProposalResponsePayload.proposalHash.
 	PayloadVisibility []byte `protobuf:"bytes,1,opt,name=payload_visibility,json=payloadVisibility,proto3" json:"payload_visibility,omitempty"`
 	// The ID of the chaincode to target.
-	ChaincodeId          *ChaincodeID `protobuf:"bytes,2,opt,name=chaincode_id,json=chaincodeId" json:"chaincode_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	ChaincodeId *ChaincodeID `protobuf:"bytes,2,opt,name=chaincode_id,json=chaincodeId" json:"chaincode_id,omitempty"`
+	// The data external to the ledger which the endorsers fetch and pass to
+	// the chaincode. Only the hashes of the data are recorded on-chain.
+	ExternalData         []*ExternalData `protobuf:"bytes,3,rep,name=external_data,json=externalData" json:"external_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *ChaincodeHeaderExtension) Reset()         { *m = ChaincodeHeaderExtension{} }
 func (m *ChaincodeHeaderExtension) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeHeaderExtension) ProtoMessage()    {}
 func (*ChaincodeHeaderExtension) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_8e93dae9c9555ac0, []int{2}
+	return fileDescriptor_proposal_63f7cf1ec1bd97eb, []int{2}
 }
 func (m *ChaincodeHeaderExtension) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeHeaderExtension.Unmarshal(m, b)
@@ -232,6 +235,63 @@ func (m *ChaincodeHeaderExtension) GetChaincodeId() *ChaincodeID {
 	return nil
 }
 
+func (m *ChaincodeHeaderExtension) GetExternalData() []*ExternalData {
+	if m != nil {
+		return m.ExternalData
+	}
+	return nil
+}
+
+// ExternalData references data external to the ledger, such as the answer of
+// an oracle. All the endorsers of a proposal fetch the data by its key, and
+// pass it to the chaincode only if it matches the hash, so that they all
+// endorse the proposal with the same data.
+type ExternalData struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Hash                 []byte   `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExternalData) Reset()         { *m = ExternalData{} }
+func (m *ExternalData) String() string { return proto.CompactTextString(m) }
+func (*ExternalData) ProtoMessage()    {}
+func (*ExternalData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proposal_63f7cf1ec1bd97eb, []int{3}
+}
+func (m *ExternalData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExternalData.Unmarshal(m, b)
+}
+func (m *ExternalData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExternalData.Marshal(b, m, deterministic)
+}
+func (dst *ExternalData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExternalData.Merge(dst, src)
+}
+func (m *ExternalData) XXX_Size() int {
+	return xxx_messageInfo_ExternalData.Size(m)
+}
+func (m *ExternalData) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExternalData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExternalData proto.InternalMessageInfo
+
+func (m *ExternalData) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ExternalData) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
 // ChaincodeProposalPayload is the Proposal's payload message to be used when
 // the Header's type is CHAINCODE.  It contains the arguments for this
 // invocation.
@@ -254,7 +314,7 @@ func (m *ChaincodeProposalPayload) Reset()         { *m = ChaincodeProposalPaylo
 func (m *ChaincodeProposalPayload) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeProposalPayload) ProtoMessage()    {}
 func (*ChaincodeProposalPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_8e93dae9c9555ac0, []int{3}
+	return fileDescriptor_proposal_63f7cf1ec1bd97eb, []int{4}
 }
 func (m *ChaincodeProposalPayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeProposalPayload.Unmarshal(m, b)
@@ -314,7 +374,7 @@ func (m *ChaincodeAction) Reset()         { *m = ChaincodeAction{} }
 func (m *ChaincodeAction) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeAction) ProtoMessage()    {}
 func (*ChaincodeAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_8e93dae9c9555ac0, []int{4}
+	return fileDescriptor_proposal_63f7cf1ec1bd97eb, []int{5}
 }
 func (m *ChaincodeAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeAction.Unmarshal(m, b)
@@ -366,42 +426,45 @@ func init() {
 	proto.RegisterType((*SignedProposal)(nil), "protos.SignedProposal")
 	proto.RegisterType((*Proposal)(nil), "protos.Proposal")
 	proto.RegisterType((*ChaincodeHeaderExtension)(nil), "protos.ChaincodeHeaderExtension")
+	proto.RegisterType((*ExternalData)(nil), "protos.ExternalData")
 	proto.RegisterType((*ChaincodeProposalPayload)(nil), "protos.ChaincodeProposalPayload")
 	proto.RegisterMapType((map[string][]byte)(nil), "protos.ChaincodeProposalPayload.TransientMapEntry")
 	proto.RegisterType((*ChaincodeAction)(nil), "protos.ChaincodeAction")
 }
 
-func init() { proto.RegisterFile("peer/proposal.proto", fileDescriptor_proposal_8e93dae9c9555ac0) }
-
-var fileDescriptor_proposal_8e93dae9c9555ac0 = []byte{
-	// 452 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x4d, 0x6f, 0xd3, 0x4c,
-	0x10, 0x96, 0x93, 0xf7, 0xed, 0xc7, 0x24, 0xf4, 0x63, 0x5b, 0x21, 0x2b, 0xea, 0xa1, 0xb2, 0x84,
-	0x54, 0x24, 0xb0, 0xa5, 0x20, 0x21, 0xc4, 0x05, 0x11, 0xa8, 0x44, 0x0f, 0x48, 0x95, 0x81, 0x1e,
-	0x7a, 0x09, 0x6b, 0x7b, 0x70, 0x56, 0x35, 0xbb, 0xab, 0xdd, 0x75, 0x84, 0x8f, 0xfc, 0x1c, 0x7e,
-	0x0a, 0xff, 0x0a, 0xd9, 0xfb, 0xd1, 0x94, 0x5c, 0x38, 0x25, 0x33, 0xf3, 0x3c, 0xcf, 0xcc, 0x3c,
-	0xb3, 0x86, 0x13, 0x89, 0xa8, 0x32, 0xa9, 0x84, 0x14, 0x9a, 0x36, 0xa9, 0x54, 0xc2, 0x08, 0xb2,
-	0x33, 0xfc, 0xe8, 0xd9, 0xe9, 0x50, 0x2c, 0x57, 0x94, 0xf1, 0x52, 0x54, 0x68, 0xab, 0xb3, 0xb3,
-	0x07, 0x94, 0xa5, 0x42, 0x2d, 0x05, 0xd7, 0xae, 0x9a, 0x7c, 0x81, 0x83, 0x4f, 0xac, 0xe6, 0x58,
-	0x5d, 0x3b, 0x00, 0x79, 0x02, 0x07, 0x01, 0x5c, 0x74, 0x06, 0x75, 0x1c, 0x9d, 0x47, 0x17, 0xd3,
-	0xfc, 0x91, 0xcf, 0x2e, 0xfa, 0x24, 0x39, 0x83, 0x7d, 0xcd, 0x6a, 0x4e, 0x4d, 0xab, 0x30, 0x1e,
-	0x0d, 0x88, 0xfb, 0x44, 0x72, 0x0b, 0x7b, 0x41, 0xf0, 0x31, 0xec, 0xac, 0x90, 0x56, 0xa8, 0x9c,
-	0x90, 0x8b, 0x48, 0x0c, 0xbb, 0x92, 0x76, 0x8d, 0xa0, 0x95, 0xe3, 0xfb, 0xb0, 0xd7, 0xc6, 0x1f,
-	0x06, 0xb9, 0x66, 0x82, 0xc7, 0x63, 0xab, 0x1d, 0x12, 0xc9, 0xcf, 0x08, 0xe2, 0x77, 0x7e, 0xc9,
-	0x0f, 0x83, 0xd6, 0xa5, 0x2f, 0x92, 0xe7, 0x40, 0x9c, 0xca, 0x72, 0xcd, 0x34, 0x2b, 0x58, 0xc3,
-	0x4c, 0xe7, 0x1a, 0x1f, 0xbb, 0xca, 0x4d, 0x28, 0x90, 0x97, 0x30, 0x0d, 0x7e, 0x2d, 0x99, 0x1d,
-	0x64, 0x32, 0x3f, 0xb1, 0xe6, 0xe8, 0x34, 0xb4, 0xb9, 0x7a, 0x9f, 0x4f, 0x02, 0xf0, 0xaa, 0x4a,
-	0x7e, 0x6f, 0xce, 0xe0, 0x37, 0xbd, 0x76, 0xe3, 0x9f, 0xc2, 0xff, 0x8c, 0xcb, 0xd6, 0xb8, 0xb6,
-	0x36, 0x20, 0x37, 0x30, 0xfd, 0xac, 0x28, 0xd7, 0x0c, 0xb9, 0xf9, 0x48, 0x65, 0x3c, 0x3a, 0x1f,
-	0x5f, 0x4c, 0xe6, 0xf3, 0xad, 0x56, 0x7f, 0xa9, 0xa5, 0x9b, 0xa4, 0x4b, 0x6e, 0x54, 0x97, 0x3f,
-	0xd0, 0x99, 0xbd, 0x81, 0xe3, 0x2d, 0x08, 0x39, 0x82, 0xf1, 0x1d, 0xda, 0xbd, 0xf7, 0xf3, 0xfe,
-	0x6f, 0x3f, 0xd4, 0x9a, 0x36, 0xad, 0xbf, 0x95, 0x0d, 0x5e, 0x8f, 0x5e, 0x45, 0xc9, 0xaf, 0x08,
-	0x0e, 0x43, 0xf7, 0xb7, 0xa5, 0xe9, 0x6d, 0x8c, 0x61, 0x57, 0xa1, 0x6e, 0x1b, 0xe3, 0xaf, 0xef,
-	0xc3, 0xfe, 0x9a, 0xb8, 0x46, 0x6e, 0xb4, 0x13, 0x72, 0x11, 0x79, 0x06, 0x7b, 0xfe, 0x69, 0x0d,
-	0x27, 0x9b, 0xcc, 0x8f, 0xfc, 0x6a, 0xb9, 0xcb, 0xe7, 0x01, 0xb1, 0xe5, 0xfb, 0x7f, 0xff, 0xe6,
-	0xfb, 0xe2, 0x2b, 0x24, 0x42, 0xd5, 0xe9, 0xaa, 0x93, 0xa8, 0x1a, 0xac, 0x6a, 0x54, 0xe9, 0x37,
-	0x5a, 0x28, 0x56, 0x7a, 0x66, 0xff, 0xd8, 0x17, 0x87, 0xf7, 0x1e, 0x96, 0x77, 0xb4, 0xc6, 0xdb,
-	0xa7, 0x35, 0x33, 0xab, 0xb6, 0x48, 0x4b, 0xf1, 0x3d, 0xdb, 0xe0, 0x66, 0x96, 0x9b, 0x59, 0x6e,
-	0xd6, 0x73, 0x0b, 0xfb, 0x31, 0xbd, 0xf8, 0x13, 0x00, 0x00, 0xff, 0xff, 0x12, 0x75, 0xb6, 0xaf,
-	0x6a, 0x03, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/proposal.proto", fileDescriptor_proposal_63f7cf1ec1bd97eb) }
+
+var fileDescriptor_proposal_63f7cf1ec1bd97eb = []byte{
+	// 493 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0xcd, 0x6e, 0xd4, 0x30,
+	0x10, 0xd6, 0xee, 0xf6, 0x77, 0x76, 0xfb, 0xe7, 0xae, 0x50, 0xb4, 0xea, 0xa1, 0x8a, 0x84, 0x54,
+	0x24, 0x48, 0xa4, 0x05, 0x21, 0xe0, 0x82, 0x58, 0xba, 0x12, 0x3d, 0x20, 0x55, 0x01, 0x7a, 0xe8,
+	0x65, 0x71, 0x92, 0x21, 0xb1, 0x1a, 0xec, 0xc8, 0x76, 0x56, 0xe4, 0x91, 0x78, 0x07, 0x5e, 0x80,
+	0xb7, 0x42, 0x89, 0xed, 0x34, 0x65, 0x39, 0x70, 0x4a, 0xbe, 0xf9, 0xe6, 0xfb, 0x3c, 0x33, 0x1e,
+	0xc3, 0x69, 0x89, 0x28, 0xc3, 0x52, 0x8a, 0x52, 0x28, 0x5a, 0x04, 0xa5, 0x14, 0x5a, 0x90, 0x9d,
+	0xf6, 0xa3, 0x66, 0xd3, 0x96, 0x4c, 0x72, 0xca, 0x78, 0x22, 0x52, 0x34, 0xec, 0xec, 0xec, 0x81,
+	0x64, 0x25, 0x51, 0x95, 0x82, 0x2b, 0xcb, 0xfa, 0x5f, 0xe0, 0xf0, 0x13, 0xcb, 0x38, 0xa6, 0xd7,
+	0x36, 0x81, 0x3c, 0x86, 0xc3, 0x2e, 0x39, 0xae, 0x35, 0x2a, 0x6f, 0x70, 0x3e, 0xb8, 0x98, 0x44,
+	0x07, 0x2e, 0xba, 0x68, 0x82, 0xe4, 0x0c, 0xf6, 0x15, 0xcb, 0x38, 0xd5, 0x95, 0x44, 0x6f, 0xd8,
+	0x66, 0xdc, 0x07, 0xfc, 0x5b, 0xd8, 0xeb, 0x0c, 0x1f, 0xc1, 0x4e, 0x8e, 0x34, 0x45, 0x69, 0x8d,
+	0x2c, 0x22, 0x1e, 0xec, 0x96, 0xb4, 0x2e, 0x04, 0x4d, 0xad, 0xde, 0xc1, 0xc6, 0x1b, 0x7f, 0x68,
+	0xe4, 0x8a, 0x09, 0xee, 0x8d, 0x8c, 0x77, 0x17, 0xf0, 0x7f, 0x0d, 0xc0, 0x7b, 0xef, 0x9a, 0xfc,
+	0xd0, 0x7a, 0x2d, 0x1d, 0x49, 0x9e, 0x01, 0xb1, 0x2e, 0xab, 0x35, 0x53, 0x2c, 0x66, 0x05, 0xd3,
+	0xb5, 0x3d, 0xf8, 0xc4, 0x32, 0x37, 0x1d, 0x41, 0x5e, 0xc2, 0xa4, 0x9b, 0xd7, 0x8a, 0x99, 0x42,
+	0xc6, 0xf3, 0x53, 0x33, 0x1c, 0x15, 0x74, 0xc7, 0x5c, 0x5d, 0x46, 0xe3, 0x2e, 0xf1, 0x2a, 0x25,
+	0xaf, 0xe1, 0xa0, 0x29, 0x48, 0x72, 0x5a, 0xac, 0x52, 0xaa, 0xa9, 0x37, 0x3a, 0x1f, 0x5d, 0x8c,
+	0xe7, 0x53, 0x27, 0x5c, 0x5a, 0xf2, 0x92, 0x6a, 0x1a, 0x4d, 0xb0, 0x87, 0xfc, 0x17, 0x30, 0xe9,
+	0xb3, 0xe4, 0x18, 0x46, 0x77, 0x68, 0x4a, 0xdc, 0x8f, 0x9a, 0x5f, 0x42, 0x60, 0x2b, 0xa7, 0x2a,
+	0xb7, 0x53, 0x69, 0xff, 0xfd, 0xdf, 0xfd, 0xa6, 0xdd, 0x68, 0xaf, 0xed, 0xbc, 0xa6, 0xb0, 0xcd,
+	0x78, 0x59, 0x69, 0xdb, 0xa7, 0x01, 0xe4, 0x06, 0x26, 0x9f, 0x25, 0xe5, 0x8a, 0x21, 0xd7, 0x1f,
+	0x69, 0xe9, 0x0d, 0xdb, 0x12, 0xe7, 0x1b, 0xbd, 0xfd, 0xe5, 0x16, 0xf4, 0x45, 0x4b, 0xae, 0x65,
+	0x1d, 0x3d, 0xf0, 0x99, 0xbd, 0x85, 0x93, 0x8d, 0x94, 0x7f, 0x74, 0x31, 0x85, 0xed, 0x35, 0x2d,
+	0x2a, 0xb7, 0x1c, 0x06, 0xbc, 0x19, 0xbe, 0x1a, 0xf8, 0x3f, 0x07, 0x70, 0xd4, 0x9d, 0xfe, 0x2e,
+	0xd1, 0xcd, 0xbd, 0x79, 0xb0, 0x2b, 0x51, 0x55, 0x85, 0x76, 0xeb, 0xe6, 0x60, 0xb3, 0x3e, 0xb8,
+	0x46, 0xae, 0x95, 0x35, 0xb2, 0x88, 0x3c, 0x85, 0x3d, 0xb7, 0xcb, 0xed, 0x8e, 0x8c, 0xe7, 0xc7,
+	0xae, 0xb5, 0xc8, 0xc6, 0xa3, 0x2e, 0x63, 0xe3, 0xa2, 0xb7, 0xfe, 0xef, 0xa2, 0x17, 0x5f, 0xc1,
+	0x17, 0x32, 0x0b, 0xf2, 0xba, 0x44, 0x59, 0x60, 0x9a, 0xa1, 0x0c, 0xbe, 0xd1, 0x58, 0xb2, 0xc4,
+	0x29, 0x9b, 0xd7, 0xb5, 0x38, 0xba, 0x9f, 0x61, 0x72, 0x47, 0x33, 0xbc, 0x7d, 0x92, 0x31, 0x9d,
+	0x57, 0x71, 0x90, 0x88, 0xef, 0x61, 0x4f, 0x1b, 0x1a, 0x6d, 0x68, 0xb4, 0x61, 0xa3, 0x8d, 0xcd,
+	0xeb, 0x7d, 0xfe, 0x67, 0x00, 0x34, 0xa5, 0xd9, 0x99, 0xdb, 0x03, 0x00, 0x00,
 }
@@ -10,8 +10,31 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/msp"
 )
 
+func (pr *ProposalResponse) StaticallyOpaqueFields() []string {
+	return []string{"payload"}
+}
+
+func (pr *ProposalResponse) StaticallyOpaqueFieldProto(name string) (proto.Message, error) {
+	if name != pr.StaticallyOpaqueFields()[0] {
+		return nil, fmt.Errorf("not a marshaled field: %s", name)
+	}
+	return &ProposalResponsePayload{}, nil
+}
+
+func (e *Endorsement) StaticallyOpaqueFields() []string {
+	return []string{"endorser"}
+}
+
+func (e *Endorsement) StaticallyOpaqueFieldProto(name string) (proto.Message, error) {
+	if name != e.StaticallyOpaqueFields()[0] {
+		return nil, fmt.Errorf("not a marshaled field: %s", name)
+	}
+	return &msp.SerializedIdentity{}, nil
+}
+
 func (ppr *ProposalResponsePayload) StaticallyOpaqueFields() []string {
 	return []string{"extension"}
 }
@@ -40,17 +40,23 @@ type ProposalResponse struct {
 	Payload []byte `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
 	// The endorsement of the proposal, basically
 	// the endorser's signature over the payload
-	Endorsement          *Endorsement `protobuf:"bytes,6,opt,name=endorsement" json:"endorsement,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Endorsement *Endorsement `protobuf:"bytes,6,opt,name=endorsement" json:"endorsement,omitempty"`
+	// Information about the simulation of the proposal, which lets the
+	// client inspect its results before submitting the transaction
+	SimulationHints *SimulationHints `protobuf:"bytes,7,opt,name=simulation_hints,json=simulationHints" json:"simulation_hints,omitempty"`
+	// The diagnostics of the chaincode when its execution failed, returned
+	// by the peers configured to do so
+	ChaincodeDiagnostics *ChaincodeDiagnostics `protobuf:"bytes,8,opt,name=chaincode_diagnostics,json=chaincodeDiagnostics" json:"chaincode_diagnostics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *ProposalResponse) Reset()         { *m = ProposalResponse{} }
 func (m *ProposalResponse) String() string { return proto.CompactTextString(m) }
 func (*ProposalResponse) ProtoMessage()    {}
 func (*ProposalResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_response_22a755721b685f40, []int{0}
+	return fileDescriptor_proposal_response_554d291bf660f7f1, []int{0}
 }
 func (m *ProposalResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ProposalResponse.Unmarshal(m, b)
@@ -105,6 +111,142 @@ func (m *ProposalResponse) GetEndorsement() *Endorsement {
 	return nil
 }
 
+func (m *ProposalResponse) GetSimulationHints() *SimulationHints {
+	if m != nil {
+		return m.SimulationHints
+	}
+	return nil
+}
+
+func (m *ProposalResponse) GetChaincodeDiagnostics() *ChaincodeDiagnostics {
+	if m != nil {
+		return m.ChaincodeDiagnostics
+	}
+	return nil
+}
+
+// SimulationHints describe the results of the simulation of a proposal by an
+// endorser. They are not covered by the endorsement, and are hence only
+// informative: the event and the read-write set actually endorsed are found
+// in the ChaincodeAction held by the payload of the proposal response.
+type SimulationHints struct {
+	// The event set by the chaincode, if any
+	Event *ChaincodeEvent `protobuf:"bytes,1,opt,name=event" json:"event,omitempty"`
+	// The size in bytes of the public read-write set resulting from the
+	// simulation, which is included in the transaction along with the
+	// endorsements
+	ReadWriteSetSize     uint64   `protobuf:"varint,2,opt,name=read_write_set_size,json=readWriteSetSize" json:"read_write_set_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SimulationHints) Reset()         { *m = SimulationHints{} }
+func (m *SimulationHints) String() string { return proto.CompactTextString(m) }
+func (*SimulationHints) ProtoMessage()    {}
+func (*SimulationHints) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proposal_response_554d291bf660f7f1, []int{1}
+}
+func (m *SimulationHints) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SimulationHints.Unmarshal(m, b)
+}
+func (m *SimulationHints) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SimulationHints.Marshal(b, m, deterministic)
+}
+func (dst *SimulationHints) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimulationHints.Merge(dst, src)
+}
+func (m *SimulationHints) XXX_Size() int {
+	return xxx_messageInfo_SimulationHints.Size(m)
+}
+func (m *SimulationHints) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimulationHints.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SimulationHints proto.InternalMessageInfo
+
+func (m *SimulationHints) GetEvent() *ChaincodeEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *SimulationHints) GetReadWriteSetSize() uint64 {
+	if m != nil {
+		return m.ReadWriteSetSize
+	}
+	return 0
+}
+
+// ChaincodeDiagnostics describe the container of a chaincode whose execution
+// failed, so that developers can debug the chaincode without access to the
+// peer
+type ChaincodeDiagnostics struct {
+	// The last lines written by the chaincode to its standard output and error
+	Output []string `protobuf:"bytes,1,rep,name=output" json:"output,omitempty"`
+	// The panic of the chaincode along with its stack, if it crashed
+	Panic string `protobuf:"bytes,2,opt,name=panic" json:"panic,omitempty"`
+	// Whether the container of the chaincode exited, and its exit code
+	Exited               bool     `protobuf:"varint,3,opt,name=exited" json:"exited,omitempty"`
+	ExitCode             int32    `protobuf:"varint,4,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChaincodeDiagnostics) Reset()         { *m = ChaincodeDiagnostics{} }
+func (m *ChaincodeDiagnostics) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeDiagnostics) ProtoMessage()    {}
+func (*ChaincodeDiagnostics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proposal_response_554d291bf660f7f1, []int{2}
+}
+func (m *ChaincodeDiagnostics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeDiagnostics.Unmarshal(m, b)
+}
+func (m *ChaincodeDiagnostics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeDiagnostics.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeDiagnostics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeDiagnostics.Merge(dst, src)
+}
+func (m *ChaincodeDiagnostics) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeDiagnostics.Size(m)
+}
+func (m *ChaincodeDiagnostics) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeDiagnostics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeDiagnostics proto.InternalMessageInfo
+
+func (m *ChaincodeDiagnostics) GetOutput() []string {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *ChaincodeDiagnostics) GetPanic() string {
+	if m != nil {
+		return m.Panic
+	}
+	return ""
+}
+
+func (m *ChaincodeDiagnostics) GetExited() bool {
+	if m != nil {
+		return m.Exited
+	}
+	return false
+}
+
+func (m *ChaincodeDiagnostics) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
 // A response with a representation similar to an HTTP response that can
 // be used within another message.
 type Response struct {
@@ -123,7 +265,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_response_22a755721b685f40, []int{1}
+	return fileDescriptor_proposal_response_554d291bf660f7f1, []int{3}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Response.Unmarshal(m, b)
@@ -201,7 +343,7 @@ func (m *ProposalResponsePayload) Reset()         { *m = ProposalResponsePayload
 func (m *ProposalResponsePayload) String() string { return proto.CompactTextString(m) }
 func (*ProposalResponsePayload) ProtoMessage()    {}
 func (*ProposalResponsePayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_response_22a755721b685f40, []int{2}
+	return fileDescriptor_proposal_response_554d291bf660f7f1, []int{4}
 }
 func (m *ProposalResponsePayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ProposalResponsePayload.Unmarshal(m, b)
@@ -259,7 +401,7 @@ func (m *Endorsement) Reset()         { *m = Endorsement{} }
 func (m *Endorsement) String() string { return proto.CompactTextString(m) }
 func (*Endorsement) ProtoMessage()    {}
 func (*Endorsement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_response_22a755721b685f40, []int{3}
+	return fileDescriptor_proposal_response_554d291bf660f7f1, []int{5}
 }
 func (m *Endorsement) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Endorsement.Unmarshal(m, b)
@@ -295,38 +437,52 @@ func (m *Endorsement) GetSignature() []byte {
 
 func init() {
 	proto.RegisterType((*ProposalResponse)(nil), "protos.ProposalResponse")
+	proto.RegisterType((*SimulationHints)(nil), "protos.SimulationHints")
+	proto.RegisterType((*ChaincodeDiagnostics)(nil), "protos.ChaincodeDiagnostics")
 	proto.RegisterType((*Response)(nil), "protos.Response")
 	proto.RegisterType((*ProposalResponsePayload)(nil), "protos.ProposalResponsePayload")
 	proto.RegisterType((*Endorsement)(nil), "protos.Endorsement")
 }
 
 func init() {
-	proto.RegisterFile("peer/proposal_response.proto", fileDescriptor_proposal_response_22a755721b685f40)
-}
-
-var fileDescriptor_proposal_response_22a755721b685f40 = []byte{
-	// 367 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x51, 0x4b, 0xfb, 0x30,
-	0x14, 0xc5, 0xe9, 0xfe, 0xff, 0xcd, 0x2d, 0x9b, 0x30, 0x2a, 0x68, 0x19, 0x03, 0x47, 0x7d, 0x99,
-	0x20, 0x29, 0x28, 0x82, 0xcf, 0x03, 0xd1, 0xc7, 0x11, 0xc4, 0x07, 0x11, 0x24, 0xdd, 0xee, 0xd2,
-	0x62, 0xdb, 0x84, 0xdc, 0x54, 0xdc, 0x07, 0xf6, 0x7b, 0x48, 0xd3, 0xa6, 0xab, 0xe2, 0xd3, 0x38,
-	0x77, 0x27, 0xbf, 0x7b, 0xcf, 0xed, 0x25, 0x73, 0x05, 0xa0, 0x23, 0xa5, 0xa5, 0x92, 0xc8, 0xb3,
-	0x37, 0x0d, 0xa8, 0x64, 0x81, 0x40, 0x95, 0x96, 0x46, 0xfa, 0x03, 0xfb, 0x83, 0xb3, 0x73, 0x21,
-	0xa5, 0xc8, 0x20, 0xb2, 0x32, 0x2e, 0x77, 0x91, 0x49, 0x73, 0x40, 0xc3, 0x73, 0x55, 0x1b, 0xc3,
-	0x2f, 0x8f, 0x4c, 0xd7, 0x0d, 0x84, 0x35, 0x0c, 0x3f, 0x20, 0x47, 0x1f, 0xa0, 0x31, 0x95, 0x45,
-	0xe0, 0x2d, 0xbc, 0x65, 0x9f, 0x39, 0xe9, 0xdf, 0x91, 0x51, 0x4b, 0x08, 0x7a, 0x0b, 0x6f, 0x39,
-	0xbe, 0x9e, 0xd1, 0xba, 0x07, 0x75, 0x3d, 0xe8, 0x93, 0x73, 0xb0, 0x83, 0xd9, 0xbf, 0x22, 0x43,
-	0x37, 0x63, 0xf0, 0xdf, 0x3e, 0x9c, 0xd6, 0x2f, 0x90, 0xba, 0xbe, 0xac, 0x75, 0x54, 0x13, 0x28,
-	0xbe, 0xcf, 0x24, 0xdf, 0x06, 0xfd, 0x85, 0xb7, 0x9c, 0x30, 0x27, 0xfd, 0x5b, 0x32, 0x86, 0x62,
-	0x2b, 0x35, 0x42, 0x0e, 0x85, 0x09, 0x06, 0x16, 0x75, 0xe2, 0x50, 0xf7, 0x87, 0xbf, 0x58, 0xd7,
-	0x17, 0x3e, 0x93, 0x61, 0x1b, 0xef, 0x94, 0x0c, 0xd0, 0x70, 0x53, 0x62, 0x93, 0xae, 0x51, 0x55,
-	0xd3, 0x1c, 0x10, 0xb9, 0x00, 0x1b, 0x6d, 0xc4, 0x9c, 0xec, 0x8e, 0xf3, 0xef, 0xc7, 0x38, 0xe1,
-	0x2b, 0x39, 0xfb, 0xbd, 0xbe, 0x75, 0x33, 0xe9, 0x05, 0x39, 0x6e, 0x3f, 0x4f, 0xc2, 0x31, 0xb1,
-	0xdd, 0x26, 0x6c, 0xe2, 0x8a, 0x8f, 0x1c, 0x13, 0x7f, 0x4e, 0x46, 0xf0, 0x69, 0xa0, 0xb0, 0xcb,
-	0xee, 0x59, 0xc3, 0xa1, 0x10, 0x3e, 0x90, 0x71, 0x27, 0x91, 0x3f, 0x23, 0xc3, 0x26, 0x93, 0x6e,
-	0x60, 0xad, 0xae, 0x40, 0x98, 0x8a, 0x82, 0x9b, 0x52, 0x83, 0x03, 0xb5, 0x85, 0x55, 0x42, 0x42,
-	0xa9, 0x05, 0x4d, 0xf6, 0x0a, 0x74, 0x06, 0x5b, 0x01, 0x9a, 0xee, 0x78, 0xac, 0xd3, 0x8d, 0x5b,
-	0x5c, 0x75, 0x4d, 0xab, 0x3f, 0xa2, 0x6c, 0xde, 0xb9, 0x80, 0x97, 0x4b, 0x91, 0x9a, 0xa4, 0x8c,
-	0xe9, 0x46, 0xe6, 0x51, 0x87, 0x11, 0xd5, 0x8c, 0xfa, 0xba, 0x30, 0xaa, 0x18, 0x71, 0x7d, 0x79,
-	0x37, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x0e, 0x52, 0x0b, 0x35, 0xa0, 0x02, 0x00, 0x00,
+	proto.RegisterFile("peer/proposal_response.proto", fileDescriptor_proposal_response_554d291bf660f7f1)
+}
+
+var fileDescriptor_proposal_response_554d291bf660f7f1 = []byte{
+	// 555 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x54, 0xd1, 0x6e, 0xda, 0x30,
+	0x14, 0x15, 0x6d, 0xa1, 0xc1, 0x30, 0x15, 0xb9, 0x8c, 0x46, 0x0c, 0x69, 0x28, 0x7b, 0x61, 0x52,
+	0x97, 0x48, 0x9d, 0x26, 0xed, 0x99, 0xae, 0x5a, 0x1f, 0x3b, 0x33, 0x6d, 0xd2, 0x34, 0x29, 0x32,
+	0xc9, 0x6d, 0x62, 0x8d, 0xd8, 0x91, 0xed, 0x74, 0xa3, 0xdf, 0xbe, 0x87, 0xc9, 0x4e, 0x1c, 0x68,
+	0xc7, 0x13, 0x9c, 0x7b, 0x8f, 0xcf, 0xb9, 0xf8, 0x1e, 0x83, 0x66, 0x25, 0x80, 0x8c, 0x4a, 0x29,
+	0x4a, 0xa1, 0xe8, 0x26, 0x96, 0xa0, 0x4a, 0xc1, 0x15, 0x84, 0xa5, 0x14, 0x5a, 0xe0, 0x9e, 0xfd,
+	0x50, 0xd3, 0xd7, 0x99, 0x10, 0xd9, 0x06, 0x22, 0x0b, 0xd7, 0xd5, 0x7d, 0xa4, 0x59, 0x01, 0x4a,
+	0xd3, 0xa2, 0xac, 0x89, 0xd3, 0xa9, 0x95, 0x49, 0x72, 0xca, 0x78, 0x22, 0x52, 0x88, 0xe1, 0x01,
+	0xb8, 0xae, 0x7b, 0xc1, 0xdf, 0x23, 0x34, 0xba, 0x6b, 0x0c, 0x48, 0xa3, 0x8f, 0x7d, 0x74, 0xfa,
+	0x00, 0x52, 0x31, 0xc1, 0xfd, 0xce, 0xbc, 0xb3, 0xe8, 0x12, 0x07, 0xf1, 0x47, 0xd4, 0x6f, 0xd5,
+	0xfd, 0xa3, 0x79, 0x67, 0x31, 0xb8, 0x9a, 0x86, 0xb5, 0x7f, 0xe8, 0xfc, 0xc3, 0xaf, 0x8e, 0x41,
+	0x76, 0x64, 0x7c, 0x89, 0x3c, 0x37, 0xbf, 0x7f, 0x62, 0x0f, 0x8e, 0xea, 0x13, 0x2a, 0x74, 0xbe,
+	0xc4, 0x93, 0x7b, 0x13, 0x94, 0x74, 0xbb, 0x11, 0x34, 0xf5, 0xbb, 0xf3, 0xce, 0x62, 0x48, 0x1c,
+	0xc4, 0x1f, 0xd0, 0x00, 0x78, 0x2a, 0xa4, 0x82, 0x02, 0xb8, 0xf6, 0x7b, 0x56, 0xea, 0xdc, 0x49,
+	0xdd, 0xec, 0x5a, 0x64, 0x9f, 0x87, 0x97, 0x68, 0xa4, 0x58, 0x51, 0x6d, 0xa8, 0x66, 0x82, 0xc7,
+	0x39, 0xe3, 0x5a, 0xf9, 0xa7, 0xf6, 0xec, 0x85, 0x3b, 0xbb, 0x6a, 0xfb, 0xb7, 0xa6, 0x4d, 0xce,
+	0xd4, 0xd3, 0x02, 0xfe, 0x82, 0x5e, 0xee, 0x2e, 0x31, 0x65, 0x34, 0xe3, 0x42, 0x69, 0x96, 0x28,
+	0xdf, 0xb3, 0x42, 0x33, 0x27, 0x74, 0xed, 0x48, 0x9f, 0x76, 0x1c, 0x32, 0x4e, 0x0e, 0x54, 0x03,
+	0x8e, 0xce, 0x9e, 0xd9, 0xe2, 0x4b, 0xd4, 0xb5, 0x0b, 0xb2, 0x57, 0x3f, 0xb8, 0x9a, 0xfc, 0xa7,
+	0x7a, 0x63, 0xba, 0xa4, 0x26, 0xe1, 0x77, 0xe8, 0x5c, 0x02, 0x4d, 0xe3, 0xdf, 0x92, 0x69, 0x88,
+	0x15, 0xe8, 0x58, 0xb1, 0x47, 0xb0, 0xab, 0x39, 0x21, 0x23, 0xd3, 0xfa, 0x6e, 0x3a, 0x2b, 0xd0,
+	0x2b, 0xf6, 0x08, 0xc1, 0x16, 0x8d, 0x0f, 0x4d, 0x87, 0x27, 0xa8, 0x27, 0x2a, 0x5d, 0x56, 0xc6,
+	0xf5, 0x78, 0xd1, 0x27, 0x0d, 0xc2, 0x63, 0xd4, 0x2d, 0x29, 0x67, 0x89, 0x15, 0xec, 0x93, 0x1a,
+	0x18, 0x36, 0xfc, 0x61, 0x1a, 0x52, 0xff, 0x78, 0xde, 0x59, 0x78, 0xa4, 0x41, 0xf8, 0x15, 0xea,
+	0x9b, 0x6f, 0xb1, 0x51, 0xb7, 0x4b, 0xee, 0x12, 0xcf, 0x14, 0xae, 0x45, 0x0a, 0xc1, 0x37, 0xe4,
+	0xb5, 0x01, 0x9b, 0xa0, 0x9e, 0xd2, 0x54, 0x57, 0xaa, 0xc9, 0x57, 0x83, 0xcc, 0xda, 0x0b, 0x50,
+	0x8a, 0x66, 0xd0, 0x18, 0x3a, 0xb8, 0x1f, 0x88, 0xe3, 0x27, 0x81, 0x08, 0x7e, 0xa2, 0x8b, 0xe7,
+	0x01, 0xbe, 0x6b, 0xb2, 0xf2, 0x06, 0xbd, 0x68, 0x1f, 0x4f, 0x4e, 0x55, 0x6e, 0xdd, 0x86, 0x64,
+	0xe8, 0x8a, 0xb7, 0x54, 0xe5, 0x78, 0x66, 0x86, 0xd6, 0xc0, 0x6d, 0xdc, 0x8f, 0x2c, 0x61, 0x57,
+	0x08, 0x3e, 0xa3, 0xc1, 0x5e, 0xa6, 0xf0, 0x14, 0x79, 0x4d, 0xaa, 0x64, 0x23, 0xd6, 0x62, 0x23,
+	0xa4, 0x58, 0xc6, 0xa9, 0xae, 0x24, 0x38, 0xa1, 0xb6, 0xb0, 0xcc, 0x51, 0x20, 0x64, 0x16, 0xe6,
+	0xdb, 0x12, 0xe4, 0x06, 0xd2, 0x0c, 0x64, 0x78, 0x4f, 0xd7, 0x92, 0x25, 0x6e, 0xbf, 0xe6, 0x91,
+	0x2e, 0x0f, 0xfc, 0x94, 0xe4, 0x17, 0xcd, 0xe0, 0xc7, 0xdb, 0x8c, 0xe9, 0xbc, 0x5a, 0x87, 0x89,
+	0x28, 0xa2, 0x3d, 0x8d, 0xa8, 0xd6, 0xa8, 0xdf, 0xbe, 0x8a, 0x8c, 0xc6, 0xba, 0xfe, 0x5f, 0x78,
+	0xff, 0x6f, 0x00, 0x95, 0xb3, 0x8b, 0x30, 0x3e, 0x04, 0x00, 0x00,
 }
@@ -0,0 +1,29 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+)
+
+func (scds *SignedChaincodeDeploymentSpec) StaticallyOpaqueFields() []string {
+	return []string{"chaincode_deployment_spec", "instantiation_policy"}
+}
+
+func (scds *SignedChaincodeDeploymentSpec) StaticallyOpaqueFieldProto(name string) (proto.Message, error) {
+	switch name {
+	case scds.StaticallyOpaqueFields()[0]: // chaincode_deployment_spec
+		return &ChaincodeDeploymentSpec{}, nil
+	case scds.StaticallyOpaqueFields()[1]: // instantiation_policy
+		return &common.SignaturePolicyEnvelope{}, nil
+	default:
+		return nil, fmt.Errorf("not a marshaled field: %s", name)
+	}
+}
@@ -16,6 +16,18 @@ import (
 
 func init() {
 	common.PayloadDataMap[int32(common.HeaderType_ENDORSER_TRANSACTION)] = &Transaction{}
+	common.ChannelHeaderExtensionMap[int32(common.HeaderType_ENDORSER_TRANSACTION)] = &ChaincodeHeaderExtension{}
+}
+
+func (st *SignedTransaction) StaticallyOpaqueFields() []string {
+	return []string{"transaction_bytes"}
+}
+
+func (st *SignedTransaction) StaticallyOpaqueFieldProto(name string) (proto.Message, error) {
+	if name != st.StaticallyOpaqueFields()[0] {
+		return nil, fmt.Errorf("not a marshaled field: %s", name)
+	}
+	return &Transaction{}, nil
 }
 
 func (ta *TransactionAction) StaticallyOpaqueFields() []string {
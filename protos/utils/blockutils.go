@@ -7,7 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package utils
 
 import (
+	"time"
+
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/util"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/pkg/errors"
 )
@@ -49,6 +55,66 @@ func GetChainIDFromBlock(block *cb.Block) (string, error) {
 	return chdr.ChannelId, nil
 }
 
+// GetHashingAlgorithmFromBlock returns the hashing algorithm of the channel
+// configuration carried by a genesis or config block, which the blocks of the
+// chain are hashed with. As for the channel config, an algorithm other than
+// SHA256 only takes effect with the V1_4 channel capability. Blocks which carry
+// no transaction and configs which do not specify a hashing algorithm, such as
+// those of some test chains, yield SHA256.
+func GetHashingAlgorithmFromBlock(block *cb.Block) (func([]byte) []byte, error) {
+	if block != nil && (block.Data == nil || len(block.Data.Data) == 0) {
+		return util.ComputeSHA256, nil
+	}
+	channelGroup, err := channelGroupFromBlock(block)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to determine the hashing algorithm of the chain")
+	}
+	value, ok := channelGroup.Values["HashingAlgorithm"]
+	if !ok {
+		return util.ComputeSHA256, nil
+	}
+	hashingAlgorithm := &cb.HashingAlgorithm{}
+	if err := proto.Unmarshal(value.Value, hashingAlgorithm); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling hashing algorithm")
+	}
+	hash, err := util.HashFunction(hashingAlgorithm.Name)
+	if err != nil {
+		return nil, err
+	}
+	channelCapabilities := &cb.Capabilities{}
+	if value, ok := channelGroup.Values["Capabilities"]; ok {
+		if err := proto.Unmarshal(value.Value, channelCapabilities); err != nil {
+			return nil, errors.Wrap(err, "error unmarshaling channel capabilities")
+		}
+	}
+	if !capabilities.NewChannelProvider(channelCapabilities.Capabilities).ConfigurableHashing() {
+		return util.ComputeSHA256, nil
+	}
+	return hash, nil
+}
+
+func channelGroupFromBlock(block *cb.Block) (*cb.ConfigGroup, error) {
+	if block == nil || block.Data == nil || len(block.Data.Data) == 0 {
+		return nil, errors.New("block is empty")
+	}
+	envelope, err := GetEnvelopeFromBlock(block.Data.Data[0])
+	if err != nil {
+		return nil, err
+	}
+	payload, err := GetPayload(envelope)
+	if err != nil {
+		return nil, err
+	}
+	configEnvelope := &cb.ConfigEnvelope{}
+	if err := proto.Unmarshal(payload.Data, configEnvelope); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling config envelope")
+	}
+	if configEnvelope.Config == nil || configEnvelope.Config.ChannelGroup == nil {
+		return nil, errors.New("block does not carry a channel config")
+	}
+	return configEnvelope.Config.ChannelGroup, nil
+}
+
 // GetMetadataFromBlock retrieves metadata at the specified index.
 func GetMetadataFromBlock(block *cb.Block, index cb.BlockMetadataIndex) (*cb.Metadata, error) {
 	md := &cb.Metadata{}
@@ -94,6 +160,44 @@ func GetLastConfigIndexFromBlockOrPanic(block *cb.Block) uint64 {
 	return index
 }
 
+// SetBlockTime records the time at which the block is created as the value of
+// its signatures metadata, which the orderers sign along with the block
+// header. A zero time clears the value.
+func SetBlockTime(block *cb.Block, t time.Time) error {
+	var value []byte
+	if !t.IsZero() {
+		ts, err := ptypes.TimestampProto(t)
+		if err != nil {
+			return errors.Wrap(err, "invalid block time")
+		}
+		value = MarshalOrPanic(ts)
+	}
+	InitBlockMetadata(block)
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = MarshalOrPanic(&cb.Metadata{Value: value})
+	return nil
+}
+
+// GetBlockTime returns the time at which the block was created, and whether
+// the block records it, as the blocks created by prior orderers do not
+func GetBlockTime(block *cb.Block) (time.Time, bool, error) {
+	md, err := GetMetadataFromBlock(block, cb.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if len(md.Value) == 0 {
+		return time.Time{}, false, nil
+	}
+	ts := &timestamp.Timestamp{}
+	if err := proto.Unmarshal(md.Value, ts); err != nil {
+		return time.Time{}, false, errors.Wrap(err, "error unmarshaling block time")
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return time.Time{}, false, errors.Wrap(err, "invalid block time")
+	}
+	return t, true, nil
+}
+
 // GetBlockFromBlockBytes marshals the bytes into Block
 func GetBlockFromBlockBytes(blockBytes []byte) (*cb.Block, error) {
 	block := &cb.Block{}
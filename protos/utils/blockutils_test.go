@@ -8,9 +8,11 @@ package utils_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/protos/common"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -106,6 +108,56 @@ func TestGetChainIDFromBlock(t *testing.T) {
 	assert.Error(t, err, "Expected error when payload header is nil")
 }
 
+func blockWithHashingAlgorithm(name string, capabilities ...string) *cb.Block {
+	channelCapabilities := &cb.Capabilities{Capabilities: map[string]*cb.Capability{}}
+	for _, capability := range capabilities {
+		channelCapabilities.Capabilities[capability] = &cb.Capability{}
+	}
+	config := &cb.ConfigEnvelope{
+		Config: &cb.Config{
+			ChannelGroup: &cb.ConfigGroup{
+				Values: map[string]*cb.ConfigValue{
+					"HashingAlgorithm": {Value: utils.MarshalOrPanic(&cb.HashingAlgorithm{Name: name})},
+					"Capabilities":     {Value: utils.MarshalOrPanic(channelCapabilities)},
+				},
+			},
+		},
+	}
+	env := &cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{Data: utils.MarshalOrPanic(config)})}
+	block := cb.NewBlock(0, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(env)}
+	return block
+}
+
+func TestGetHashingAlgorithmFromBlock(t *testing.T) {
+	data := []byte("data")
+
+	gb, err := configtxtest.MakeGenesisBlock(testChainID)
+	assert.NoError(t, err)
+	hash, err := utils.GetHashingAlgorithmFromBlock(gb)
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeSHA256(data), hash(data))
+
+	hash, err = utils.GetHashingAlgorithmFromBlock(blockWithHashingAlgorithm("SHA3_256", "V1_4"))
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeSHA3256(data), hash(data))
+
+	// without the V1_4 capability, the chain is hashed with SHA256
+	hash, err = utils.GetHashingAlgorithmFromBlock(blockWithHashingAlgorithm("SHA3_256"))
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeSHA256(data), hash(data))
+
+	hash, err = utils.GetHashingAlgorithmFromBlock(cb.NewBlock(0, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeSHA256(data), hash(data))
+
+	_, err = utils.GetHashingAlgorithmFromBlock(&cb.Block{Data: &cb.BlockData{Data: [][]byte{[]byte("garbage")}}})
+	assert.Contains(t, err.Error(), "failed to determine the hashing algorithm of the chain")
+
+	_, err = utils.GetHashingAlgorithmFromBlock(blockWithHashingAlgorithm("MD5", "V1_4"))
+	assert.EqualError(t, err, "Unknown hashing algorithm type: MD5")
+}
+
 func TestGetBlockFromBlockBytes(t *testing.T) {
 	testChainID := "myuniquetestchainid"
 	gb, err := configtxtest.MakeGenesisBlock(testChainID)
@@ -204,3 +256,30 @@ func TestGetLastConfigIndexFromBlock(t *testing.T) {
 		_ = utils.GetLastConfigIndexFromBlockOrPanic(block)
 	}, "Expected panic with malformed last config metadata")
 }
+
+func TestBlockTime(t *testing.T) {
+	block := common.NewBlock(0, nil)
+	_, ok, err := utils.GetBlockTime(block)
+	assert.NoError(t, err)
+	assert.False(t, ok, "Expected a new block to have no time")
+
+	now := time.Date(2018, 9, 1, 12, 0, 0, 42, time.UTC)
+	assert.NoError(t, utils.SetBlockTime(block, now))
+	blockTime, ok, err := utils.GetBlockTime(block)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, now.Equal(blockTime), "Unexpected block time %s", blockTime)
+
+	assert.NoError(t, utils.SetBlockTime(block, time.Time{}))
+	_, ok, err = utils.GetBlockTime(block)
+	assert.NoError(t, err)
+	assert.False(t, ok, "Expected the block time to be cleared")
+
+	// malformed block time
+	metadata, _ := proto.Marshal(&cb.Metadata{
+		Value: []byte("bad block time"),
+	})
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = metadata
+	_, _, err = utils.GetBlockTime(block)
+	assert.Error(t, err, "Expected error with malformed block time")
+}
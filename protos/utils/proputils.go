@@ -335,6 +335,68 @@ func CreateChaincodeProposalWithTxIDNonceAndTransient(txid string, typ common.He
 	return prop, txid, nil
 }
 
+// SetProposalValidityWindow sets the validity window of the transaction of
+// the given proposal, which must be set before the proposal is signed. The
+// proposal carries the window, along with the timestamp of the transaction,
+// to all the endorsers
+func SetProposalValidityWindow(prop *peer.Proposal, window *common.ValidityWindow) error {
+	return updateProposalChannelHeader(prop, func(chdr *common.ChannelHeader) {
+		chdr.ValidityWindow = window
+	})
+}
+
+// SetProposalPriority sets the priority of the transaction of the given
+// proposal, which must be set before the proposal is signed. The orderers of
+// the channels honoring the priorities order the transactions of a block by
+// decreasing priority, within the reordering limit of the channel
+func SetProposalPriority(prop *peer.Proposal, priority uint32) error {
+	return updateProposalChannelHeader(prop, func(chdr *common.ChannelHeader) {
+		chdr.Priority = priority
+	})
+}
+
+// SetProposalExternalData sets the data external to the ledger which the
+// endorsers of the given proposal pass to the chaincode, which must be set
+// before the proposal is signed. Only the hashes of the data are recorded in
+// the transaction.
+func SetProposalExternalData(prop *peer.Proposal, data ...*peer.ExternalData) error {
+	var err error
+	updateErr := updateProposalChannelHeader(prop, func(chdr *common.ChannelHeader) {
+		ccHdrExt := &peer.ChaincodeHeaderExtension{}
+		if err = proto.Unmarshal(chdr.Extension, ccHdrExt); err != nil {
+			err = errors.Wrap(err, "error unmarshaling ChaincodeHeaderExtension")
+			return
+		}
+		ccHdrExt.ExternalData = data
+		if chdr.Extension, err = proto.Marshal(ccHdrExt); err != nil {
+			err = errors.Wrap(err, "error marshaling ChaincodeHeaderExtension")
+		}
+	})
+	if updateErr != nil {
+		return updateErr
+	}
+	return err
+}
+
+func updateProposalChannelHeader(prop *peer.Proposal, update func(chdr *common.ChannelHeader)) error {
+	hdr, err := GetHeader(prop.Header)
+	if err != nil {
+		return err
+	}
+	chdr, err := UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return err
+	}
+	update(chdr)
+	if hdr.ChannelHeader, err = proto.Marshal(chdr); err != nil {
+		return errors.Wrap(err, "error marshaling ChannelHeader")
+	}
+	if prop.Header, err = proto.Marshal(hdr); err != nil {
+		return errors.Wrap(err, "error marshaling Header")
+	}
+	return nil
+}
+
 // GetBytesProposalResponsePayload gets proposal response payload
 func GetBytesProposalResponsePayload(hash []byte, response *peer.Response, result []byte, event []byte, ccid *peer.ChaincodeID) ([]byte, error) {
 	cAct := &peer.ChaincodeAction{
@@ -530,6 +592,26 @@ func CreateUpgradeProposalFromCDS(
 	return createProposalFromCDS(chainID, cds, creator, "upgrade", policy, escc, vscc, collectionConfig)
 }
 
+// CreateRetireProposal returns a retire proposal of the chaincode with the
+// given name, given a serialized identity. The state of the chaincode is
+// archived by the peers if archive is set
+func CreateRetireProposal(chainID string, ccname string, creator []byte, archive bool) (*peer.Proposal, string, error) {
+	args := [][]byte{[]byte("retire"), []byte(chainID), []byte(ccname)}
+	if archive {
+		args = append(args, []byte("archive"))
+	}
+
+	lsccSpec := &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			Type:        peer.ChaincodeSpec_GOLANG,
+			ChaincodeId: &peer.ChaincodeID{Name: "lscc"},
+			Input:       &peer.ChaincodeInput{Args: args},
+		},
+	}
+
+	return CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, chainID, lsccSpec, creator)
+}
+
 // createProposalFromCDS returns a deploy or upgrade proposal given a
 // serialized identity and a ChaincodeDeploymentSpec
 func createProposalFromCDS(chainID string, msg proto.Message, creator []byte, propType string, args ...[]byte) (*peer.Proposal, string, error) {
@@ -586,6 +668,12 @@ func ComputeProposalTxID(nonce, creator []byte) (string, error) {
 	return hex.EncodeToString(digest), nil
 }
 
+// ComputeTxIDWith computes TxID as the hash computed with the given
+// hashing algorithm over the concatenation of nonce and creator.
+func ComputeTxIDWith(nonce, creator []byte, hash func([]byte) []byte) string {
+	return hex.EncodeToString(hash(append(append([]byte{}, nonce...), creator...)))
+}
+
 // CheckProposalTxID checks that txid is equal to the Hash computed
 // over the concatenation of nonce and creator.
 func CheckProposalTxID(txid string, nonce, creator []byte) error {
@@ -601,6 +689,22 @@ func CheckProposalTxID(txid string, nonce, creator []byte) error {
 	return nil
 }
 
+// CheckTxIDWith checks that txid is equal to the hash computed with the
+// given hashing algorithm over the concatenation of nonce and creator.
+// A nil hash selects SHA256, as in CheckProposalTxID.
+func CheckTxIDWith(txid string, nonce, creator []byte, hash func([]byte) []byte) error {
+	if hash == nil {
+		return CheckProposalTxID(txid, nonce, creator)
+	}
+
+	computedTxID := ComputeTxIDWith(nonce, creator, hash)
+	if txid != computedTxID {
+		return errors.Errorf("invalid txid. got [%s], expected [%s]", txid, computedTxID)
+	}
+
+	return nil
+}
+
 // ComputeProposalBinding computes the binding of a proposal
 func ComputeProposalBinding(proposal *peer.Proposal) ([]byte, error) {
 	if proposal == nil {
@@ -15,6 +15,7 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
@@ -222,6 +223,15 @@ func TestCDSProposals(t *testing.T) {
 	assert.NoError(t, err, "Unexpected error creating upgrade proposal")
 	assert.NotEqual(t, "", txid, "txid should not be empty")
 
+	// retire
+	prop, txid, err = utils.CreateRetireProposal(chainID, "mycc", creator, true)
+	assert.NotNil(t, prop, "Retire proposal should not be nil")
+	assert.NoError(t, err, "Unexpected error creating retire proposal")
+	assert.NotEqual(t, "", txid, "txid should not be empty")
+	cis, err := utils.GetChaincodeInvocationSpec(prop)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("retire"), []byte(chainID), []byte("mycc"), []byte("archive")}, cis.ChaincodeSpec.Input.Args)
+
 }
 
 func TestComputeProposalBinding(t *testing.T) {
@@ -386,6 +396,77 @@ func TestProposalWithTxID(t *testing.T) {
 	assert.NotEmpty(t, txid)
 }
 
+func TestSetProposalValidityWindow(t *testing.T) {
+	prop, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, util.GetTestChainID(), createCIS(), []byte("creator"))
+	assert.NoError(t, err)
+	hdr, err := utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	assert.NoError(t, err)
+
+	window := &common.ValidityWindow{NotAfter: &timestamp.Timestamp{Seconds: chdr.Timestamp.Seconds + 60}}
+	err = utils.SetProposalValidityWindow(prop, window)
+	assert.NoError(t, err)
+
+	newHdr, err := utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	assert.Equal(t, hdr.SignatureHeader, newHdr.SignatureHeader)
+	newChdr, err := utils.UnmarshalChannelHeader(newHdr.ChannelHeader)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(window, newChdr.ValidityWindow))
+	// the rest of the channel header is preserved
+	newChdr.ValidityWindow = nil
+	assert.True(t, proto.Equal(chdr, newChdr))
+
+	err = utils.SetProposalValidityWindow(&pb.Proposal{Header: []byte("garbage")}, window)
+	assert.Error(t, err)
+}
+
+func TestSetProposalExternalData(t *testing.T) {
+	cis := createCIS()
+	prop, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, util.GetTestChainID(), cis, []byte("creator"))
+	assert.NoError(t, err)
+
+	data := &pb.ExternalData{Key: "price", Hash: util.ComputeSHA256([]byte("42"))}
+	err = utils.SetProposalExternalData(prop, data)
+	assert.NoError(t, err)
+
+	hdr, err := utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	ccHdrExt, err := utils.GetChaincodeHeaderExtension(hdr)
+	assert.NoError(t, err)
+	assert.Len(t, ccHdrExt.ExternalData, 1)
+	assert.True(t, proto.Equal(data, ccHdrExt.ExternalData[0]))
+	// the rest of the extension is preserved
+	assert.True(t, proto.Equal(cis.ChaincodeSpec.ChaincodeId, ccHdrExt.ChaincodeId))
+
+	err = utils.SetProposalExternalData(&pb.Proposal{Header: []byte("garbage")}, data)
+	assert.Error(t, err)
+}
+
+func TestSetProposalPriority(t *testing.T) {
+	prop, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, util.GetTestChainID(), createCIS(), []byte("creator"))
+	assert.NoError(t, err)
+	hdr, err := utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	assert.NoError(t, err)
+
+	err = utils.SetProposalPriority(prop, 7)
+	assert.NoError(t, err)
+
+	newHdr, err := utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	newChdr, err := utils.UnmarshalChannelHeader(newHdr.ChannelHeader)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(7), newChdr.Priority)
+	newChdr.Priority = 0
+	assert.True(t, proto.Equal(chdr, newChdr))
+
+	err = utils.SetProposalPriority(&pb.Proposal{Header: []byte("garbage")}, 7)
+	assert.Error(t, err)
+}
+
 func TestProposalResponse(t *testing.T) {
 	events := &pb.ChaincodeEvent{
 		ChaincodeId: "ccid",
@@ -617,6 +698,21 @@ func TestProposalTxID(t *testing.T) {
 	assert.NoError(t, err, "Failed computing txID")
 }
 
+func TestTxIDWith(t *testing.T) {
+	nonce := []byte{1}
+	creator := []byte{2}
+
+	txid := utils.ComputeTxIDWith(nonce, creator, util.ComputeSHA3256)
+	assert.Equal(t, hex.EncodeToString(util.ComputeSHA3256([]byte{1, 2})), txid)
+	assert.NoError(t, utils.CheckTxIDWith(txid, nonce, creator, util.ComputeSHA3256))
+	assert.Error(t, utils.CheckTxIDWith(txid, nonce, creator, nil))
+
+	sha256TxID, err := utils.ComputeProposalTxID(nonce, creator)
+	assert.NoError(t, err)
+	assert.NoError(t, utils.CheckTxIDWith(sha256TxID, nonce, creator, nil))
+	assert.Error(t, utils.CheckTxIDWith(sha256TxID, nonce, creator, util.ComputeSHA3256))
+}
+
 func TestComputeProposalTxID(t *testing.T) {
 	txid, err := utils.ComputeProposalTxID([]byte{1}, []byte{1})
 	assert.NoError(t, err, "Failed computing TxID")
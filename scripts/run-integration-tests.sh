@@ -27,6 +27,13 @@ main() {
         dirs=($(integration_dirs "./..."))
     fi
 
+    # share built binaries between the suites of this run
+    if [ -z "${FABRIC_INTEGRATION_BUILD_CACHE:-}" ]; then
+        FABRIC_INTEGRATION_BUILD_CACHE="$(mktemp -d -t fabric-integration.XXXXXX)"
+        trap 'rm -rf "$FABRIC_INTEGRATION_BUILD_CACHE"' EXIT
+    fi
+    export FABRIC_INTEGRATION_BUILD_CACHE
+
     echo "Running integration tests..."
     ginkgo -keepGoing --slowSpecThreshold 60 "${dirs[@]}"
 }